@@ -0,0 +1,20 @@
+package apply
+
+import (
+	"github.com/confluentinc/kcp/cmd/apply/target"
+	"github.com/spf13/cobra"
+)
+
+func NewApplyCmd() *cobra.Command {
+	applyCmd := &cobra.Command{
+		Use:           "apply",
+		Short:         "Create migration infrastructure directly, without Terraform",
+		Long:          "Commands that create migration infrastructure directly through the Confluent Cloud API, as an alternative to the Terraform generated by `kcp create-asset`.",
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+	applyCmd.AddCommand(
+		target.NewApplyTargetCmd(),
+	)
+	return applyCmd
+}
@@ -0,0 +1,260 @@
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/targetapply"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile       string
+	sourceClusterId string
+	awsRegion       string
+
+	environmentName string
+	environmentId   string
+
+	clusterName         string
+	clusterType         string
+	clusterAvailability string
+	clusterCku          int
+
+	needsPrivateLink bool
+
+	ccApiKey    string
+	ccApiSecret string
+
+	plan bool
+)
+
+func NewApplyTargetCmd() *cobra.Command {
+	applyTargetCmd := &cobra.Command{
+		Use:   "target",
+		Short: "Create the target Confluent Cloud environment, network and cluster",
+		Long: `Create the target Confluent Cloud environment, cluster and, with --needs-private-link, the PrivateLink network (dedicated clusters) or ingress gateway (enterprise clusters) directly through the Confluent Cloud API. This is an alternative to ` + "`kcp create-asset target-infra`" + ` for users who don't run Terraform.
+
+Every object is looked up by name first and only created when missing, so the command can be re-run safely, for example after a failure. Use --plan to print what would be created without changing anything.
+
+Only the Confluent Cloud side is created. The AWS VPC endpoints and DNS records that connect to a PrivateLink network or gateway still come from ` + "`kcp create-asset target-infra`" + ` with --env-id and --cluster-id.`,
+		Example: `  # Preview, then create, a dedicated cluster with PrivateLink in a new environment
+  kcp apply target \
+      --state-file kcp-state.json \
+      --source-cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --env-name example-env \
+      --cluster-name example-cluster --cluster-type dedicated --cluster-availability MULTI_ZONE --cluster-cku 2 \
+      --needs-private-link \
+      --cc-api-key ABCDEFGHIJKLMNOP --cc-api-secret <secret> \
+      --plan
+
+  # Create an enterprise cluster in an existing environment
+  kcp apply target \
+      --aws-region us-east-1 --env-id env-abc123 \
+      --cluster-name example-cluster --cluster-type enterprise \
+      --cc-api-key ABCDEFGHIJKLMNOP --cc-api-secret <secret>`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunApplyTarget,
+		RunE:          runApplyTarget,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	stateFileFlags := pflag.NewFlagSet("statefile", pflag.ExitOnError)
+	stateFileFlags.SortFlags = false
+	stateFileFlags.StringVar(&stateFile, "state-file", "", "Path to kcp state file (if provided, aws-region is extracted from state)")
+	stateFileFlags.StringVar(&sourceClusterId, "source-cluster-id", "", "The ARN of the MSK cluster (required when --state-file is provided).")
+	applyTargetCmd.Flags().AddFlagSet(stateFileFlags)
+	groups[stateFileFlags] = "State File (Optional)"
+
+	manualConfigFlags := pflag.NewFlagSet("manualconfig", pflag.ExitOnError)
+	manualConfigFlags.SortFlags = false
+	manualConfigFlags.StringVar(&awsRegion, "aws-region", "", "AWS region for the cluster (required when --state-file is not provided)")
+	applyTargetCmd.Flags().AddFlagSet(manualConfigFlags)
+	groups[manualConfigFlags] = "Manual Configuration (when not using state file)"
+
+	envFlags := pflag.NewFlagSet("environment", pflag.ExitOnError)
+	envFlags.SortFlags = false
+	envFlags.StringVar(&environmentName, "env-name", "", "Name of the environment, created when no environment has this name")
+	envFlags.StringVar(&environmentId, "env-id", "", "ID of an existing environment")
+	applyTargetCmd.Flags().AddFlagSet(envFlags)
+	groups[envFlags] = "Target Environment"
+
+	clusterFlags := pflag.NewFlagSet("cluster", pflag.ExitOnError)
+	clusterFlags.SortFlags = false
+	clusterFlags.StringVar(&clusterName, "cluster-name", "", "Name of the cluster, created when the environment has no cluster of this name")
+	clusterFlags.StringVar(&clusterType, "cluster-type", "", "Cluster type: 'dedicated' or 'enterprise'")
+	clusterFlags.StringVar(&clusterAvailability, "cluster-availability", "SINGLE_ZONE", "Cluster availability for dedicated clusters: 'SINGLE_ZONE' or 'MULTI_ZONE'")
+	clusterFlags.IntVar(&clusterCku, "cluster-cku", 1, "Number of CKUs for dedicated clusters (MULTI_ZONE requires >= 2)")
+	clusterFlags.BoolVar(&needsPrivateLink, "needs-private-link", false, "Create a PrivateLink network (dedicated) or ingress PrivateLink gateway (enterprise) for the cluster")
+	applyTargetCmd.Flags().AddFlagSet(clusterFlags)
+	groups[clusterFlags] = "Target Cluster"
+
+	credentialFlags := pflag.NewFlagSet("credentials", pflag.ExitOnError)
+	credentialFlags.SortFlags = false
+	credentialFlags.StringVar(&ccApiKey, "cc-api-key", "", "A Confluent Cloud API key (a Cloud API key, not a cluster API key).")
+	credentialFlags.StringVar(&ccApiSecret, "cc-api-secret", "", "The secret of the Confluent Cloud API key.")
+	utils.AddSecretStdinFlag(credentialFlags, "cc-api-secret")
+	applyTargetCmd.Flags().AddFlagSet(credentialFlags)
+	groups[credentialFlags] = "Confluent Cloud Credentials"
+
+	outputFlags := pflag.NewFlagSet("output", pflag.ExitOnError)
+	outputFlags.SortFlags = false
+	outputFlags.BoolVar(&plan, "plan", false, "Print what would be created without creating anything")
+	applyTargetCmd.Flags().AddFlagSet(outputFlags)
+	groups[outputFlags] = "Output"
+
+	applyTargetCmd.MarkFlagsMutuallyExclusive("env-name", "env-id")
+	_ = applyTargetCmd.MarkFlagRequired("cluster-name")
+	_ = applyTargetCmd.MarkFlagRequired("cluster-type")
+	_ = applyTargetCmd.MarkFlagRequired("cc-api-key")
+	_ = applyTargetCmd.MarkFlagRequired("cc-api-secret")
+
+	applyTargetCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{stateFileFlags, manualConfigFlags, envFlags, clusterFlags, credentialFlags, outputFlags}
+		groupNames := []string{"State File (Optional)", "Manual Configuration (when not using state file)", "Target Environment", "Target Cluster", "Confluent Cloud Credentials", "Output"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	return applyTargetCmd
+}
+
+func preRunApplyTarget(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if stateFile != "" {
+		if sourceClusterId == "" {
+			return fmt.Errorf("required flag `--source-cluster-id` not set when `--state-file` is provided")
+		}
+	} else if awsRegion == "" {
+		return fmt.Errorf("--aws-region is required when --state-file is not provided")
+	}
+
+	if environmentName == "" && environmentId == "" {
+		return fmt.Errorf("one of --env-name or --env-id is required")
+	}
+
+	return validateCluster(clusterType, clusterAvailability, clusterCku)
+}
+
+func validateCluster(clusterType, availability string, cku int) error {
+	switch clusterType {
+	case "dedicated":
+		if availability != "SINGLE_ZONE" && availability != "MULTI_ZONE" {
+			return fmt.Errorf("invalid --cluster-availability: must be 'SINGLE_ZONE' or 'MULTI_ZONE', got '%s'", availability)
+		}
+		if cku < 1 {
+			return fmt.Errorf("invalid --cluster-cku: must be >= 1, got %d", cku)
+		}
+		if availability == "MULTI_ZONE" && cku < 2 {
+			return fmt.Errorf("invalid --cluster-cku: MULTI_ZONE requires >= 2 CKUs, got %d", cku)
+		}
+	case "enterprise":
+	default:
+		return fmt.Errorf("invalid --cluster-type: must be 'dedicated' or 'enterprise', got '%s'", clusterType)
+	}
+	return nil
+}
+
+func runApplyTarget(cmd *cobra.Command, args []string) error {
+	if stateFile != "" {
+		region, err := regionFromState(stateFile, sourceClusterId)
+		if err != nil {
+			return err
+		}
+		awsRegion = region
+	}
+
+	request := targetapply.Request{
+		EnvironmentID:       environmentId,
+		EnvironmentName:     environmentName,
+		ClusterName:         clusterName,
+		ClusterType:         clusterType,
+		ClusterAvailability: clusterAvailability,
+		ClusterCku:          clusterCku,
+		Region:              awsRegion,
+		NeedsPrivateLink:    needsPrivateLink,
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	applier := targetapply.NewApplier(
+		&http.Client{Timeout: 30 * time.Second},
+		targetapply.Config{BaseURL: targetapply.DefaultBaseURL, APIKey: ccApiKey, APISecret: ccApiSecret},
+	)
+
+	if plan {
+		fmt.Printf("🔍 Planning target infrastructure in %s\n", awsRegion)
+		actions, err := applier.Plan(ctx, request)
+		if err != nil {
+			return err
+		}
+		printActions(actions)
+		fmt.Println("\nRun without --plan to create the missing objects.")
+		return nil
+	}
+
+	fmt.Printf("🚀 Applying target infrastructure in %s\n", awsRegion)
+	actions, err := applier.Apply(ctx, request)
+	if err != nil {
+		return err
+	}
+	printActions(actions)
+	fmt.Println("\n✅ Target infrastructure applied. New clusters and networks keep provisioning in Confluent Cloud; dedicated clusters can take an hour or more to become ready.")
+	return nil
+}
+
+func printActions(actions []targetapply.Action) {
+	for _, action := range actions {
+		switch {
+		case action.Exists:
+			fmt.Printf("  = %s %s (%s) already exists\n", action.Resource, action.Name, action.ID)
+		case action.ID == "":
+			fmt.Printf("  + %s %s will be created\n", action.Resource, action.Name)
+		default:
+			fmt.Printf("  + %s %s (%s) created\n", action.Resource, action.Name, action.ID)
+		}
+	}
+}
+
+func regionFromState(path, clusterArn string) (string, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read statefile %s: %w", path, err)
+	}
+
+	var state types.State
+	if err := json.Unmarshal(file, &state); err != nil {
+		return "", fmt.Errorf("failed to parse statefile JSON: %w", err)
+	}
+
+	cluster, err := state.GetClusterByArn(clusterArn)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster: %w", err)
+	}
+	return cluster.Region, nil
+}
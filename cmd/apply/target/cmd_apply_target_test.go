@@ -0,0 +1,37 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCluster(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterType  string
+		availability string
+		cku          int
+		wantErr      string
+	}{
+		{name: "dedicated single zone", clusterType: "dedicated", availability: "SINGLE_ZONE", cku: 1},
+		{name: "dedicated multi zone", clusterType: "dedicated", availability: "MULTI_ZONE", cku: 2},
+		{name: "enterprise ignores cku", clusterType: "enterprise", availability: "SINGLE_ZONE", cku: 0},
+		{name: "unknown type", clusterType: "basic", availability: "SINGLE_ZONE", cku: 1, wantErr: "invalid --cluster-type"},
+		{name: "bad availability", clusterType: "dedicated", availability: "HIGH", cku: 1, wantErr: "invalid --cluster-availability"},
+		{name: "multi zone needs two ckus", clusterType: "dedicated", availability: "MULTI_ZONE", cku: 1, wantErr: "MULTI_ZONE requires >= 2 CKUs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCluster(tt.clusterType, tt.availability, tt.cku)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
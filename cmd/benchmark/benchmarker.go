@@ -0,0 +1,173 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/benchmark"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+type BenchmarkerOpts struct {
+	SourceBootstrap       string
+	Topic                 string
+	MessageSize           int
+	Rate                  int
+	Duration              time.Duration
+	RequiredAcks          sarama.RequiredAcks
+	Compression           sarama.CompressionCodec
+	OutputFile            string
+	Force                 bool
+	AWSRegion             string
+	AuthType              types.AuthType
+	SaslScramUsername     string
+	SaslScramPassword     string
+	SaslScramMechanism    string
+	SaslPlainUsername     string
+	SaslPlainPassword     string
+	TlsCaCert             string
+	TlsClientCert         string
+	TlsClientKey          string
+	InsecureSkipTLSVerify bool
+}
+
+type Benchmarker struct {
+	opts BenchmarkerOpts
+}
+
+func NewBenchmarker(opts BenchmarkerOpts) *Benchmarker {
+	return &Benchmarker{
+		opts: opts,
+	}
+}
+
+func (b *Benchmarker) Run(ctx context.Context) error {
+	cfg := benchmark.Config{
+		Topic:       b.opts.Topic,
+		MessageSize: b.opts.MessageSize,
+		Rate:        b.opts.Rate,
+		Duration:    b.opts.Duration,
+		Force:       b.opts.Force,
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	brokerAddresses := strings.Split(b.opts.SourceBootstrap, ",")
+	adminOpts := []client.AdminOption{
+		client.AdminOptionForAuth(b.opts.AuthType, b.clusterAuth()),
+		client.WithSaramaConfig(b.configureProducer),
+	}
+	if b.opts.InsecureSkipTLSVerify {
+		adminOpts = append(adminOpts, client.WithInsecureSkipVerify())
+	}
+
+	slog.Debug("connecting to source cluster", "brokers", len(brokerAddresses), "auth_type", b.opts.AuthType, "region", b.opts.AWSRegion)
+	sourceClient, err := client.NewKafkaClient(brokerAddresses, b.opts.AWSRegion, adminOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to source cluster: %w", err)
+	}
+	defer func() { _ = sourceClient.Close() }()
+
+	target := "as fast as possible"
+	if b.opts.Rate > 0 {
+		target = fmt.Sprintf("at %d msg/s", b.opts.Rate)
+	}
+	fmt.Printf("🚀 Benchmarking topic %s on %s: %d-byte records %s for %s\n", b.opts.Topic, b.opts.SourceBootstrap, b.opts.MessageSize, target, b.opts.Duration)
+
+	result, err := benchmark.Run(ctx, sourceClient, cfg)
+	if errors.Is(err, benchmark.ErrTopicHasRecords) {
+		return fmt.Errorf("refusing to benchmark topic %s: %w; use a dedicated empty test topic, or pass --force if the records are from an earlier benchmark", b.opts.Topic, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to benchmark topic %s: %w", b.opts.Topic, err)
+	}
+
+	printResult(result)
+
+	if b.opts.OutputFile != "" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal benchmark result: %w", err)
+		}
+		if err := summary.WriteFile(b.opts.OutputFile, data, 0644); err != nil {
+			return fmt.Errorf("failed to write benchmark result: %w", err)
+		}
+		fmt.Printf("✅ Benchmark result written to %s\n", b.opts.OutputFile)
+	}
+
+	return nil
+}
+
+// configureProducer applies the producer settings under test on top of the
+// auth settings; the benchmark needs acknowledgements to measure latency.
+func (b *Benchmarker) configureProducer(config *sarama.Config) {
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	config.Producer.RequiredAcks = b.opts.RequiredAcks
+	config.Producer.Compression = b.opts.Compression
+	config.Producer.MaxMessageBytes = benchmark.MaxMessageSize + 1024
+}
+
+func printResult(r *benchmark.Result) {
+	fmt.Printf("\n📊 Benchmark results for %s (%d partitions)\n", r.Topic, r.Partitions)
+	fmt.Printf("   Produced: %d records in %s (%.0f msg/s, %.2f MB/s)\n", r.Produced, r.ProduceElapsed.Round(time.Millisecond), r.ProducedPerSecond(), r.ProducedMBPerSecond())
+	fmt.Printf("   Consumed: %d records (%.2f MB/s)\n", r.Consumed, r.ConsumedMBPerSecond())
+	fmt.Printf("   Produce latency:    p50 %s, p95 %s, p99 %s, max %s\n", formatLatency(r.ProduceLatency.P50), formatLatency(r.ProduceLatency.P95), formatLatency(r.ProduceLatency.P99), formatLatency(r.ProduceLatency.Max))
+	fmt.Printf("   End-to-end latency: p50 %s, p95 %s, p99 %s, max %s\n", formatLatency(r.EndToEndLatency.P50), formatLatency(r.EndToEndLatency.P95), formatLatency(r.EndToEndLatency.P99), formatLatency(r.EndToEndLatency.Max))
+
+	if !r.TargetMet() {
+		fmt.Printf("⚠️ Target rate of %d msg/s was not reached; the network path or cluster is the bottleneck\n", r.TargetRate)
+	}
+	if r.ProduceErrors > 0 {
+		fmt.Printf("⚠️ %d records failed to produce, first error: %s\n", r.ProduceErrors, r.FirstError)
+	}
+	if r.Consumed < r.Produced {
+		fmt.Printf("⚠️ Only %d of %d produced records were consumed back before the drain timeout\n", r.Consumed, r.Produced)
+	}
+}
+
+func formatLatency(d time.Duration) string {
+	return d.Round(100 * time.Microsecond).String()
+}
+
+func (b *Benchmarker) clusterAuth() types.ClusterAuth {
+	clusterAuth := types.ClusterAuth{}
+	switch b.opts.AuthType {
+	case types.AuthTypeSASLSCRAM:
+		clusterAuth.AuthMethod.SASLScram = &types.SASLScramConfig{
+			Use:       true,
+			Username:  b.opts.SaslScramUsername,
+			Password:  b.opts.SaslScramPassword,
+			Mechanism: b.opts.SaslScramMechanism,
+		}
+	case types.AuthTypeTLS:
+		clusterAuth.AuthMethod.TLS = &types.TLSConfig{
+			Use:        true,
+			CACert:     b.opts.TlsCaCert,
+			ClientCert: b.opts.TlsClientCert,
+			ClientKey:  b.opts.TlsClientKey,
+		}
+	case types.AuthTypeSASLPlain:
+		clusterAuth.AuthMethod.SASLPlain = &types.SASLPlainConfig{
+			Use:      true,
+			Username: b.opts.SaslPlainUsername,
+			Password: b.opts.SaslPlainPassword,
+		}
+	case types.AuthTypeIAM:
+		clusterAuth.AuthMethod.IAM = &types.IAMConfig{Use: true}
+	case types.AuthTypeUnauthenticatedTLS:
+		clusterAuth.AuthMethod.UnauthenticatedTLS = &types.UnauthenticatedTLSConfig{Use: true}
+	case types.AuthTypeUnauthenticatedPlaintext:
+		clusterAuth.AuthMethod.UnauthenticatedPlaintext = &types.UnauthenticatedPlaintextConfig{Use: true}
+	}
+	return clusterAuth
+}
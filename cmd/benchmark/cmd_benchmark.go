@@ -0,0 +1,278 @@
+package benchmark
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	sourceBootstrap             string
+	topic                       string
+	messageSize                 int
+	rate                        int
+	duration                    time.Duration
+	acks                        string
+	compression                 string
+	outputFile                  string
+	force                       bool
+	awsRegion                   string
+	useSaslIam                  bool
+	useSaslScram                bool
+	useSaslPlain                bool
+	useTls                      bool
+	useUnauthenticatedTLS       bool
+	useUnauthenticatedPlaintext bool
+
+	saslScramUsername  string
+	saslScramPassword  string
+	saslScramMechanism string
+
+	saslPlainUsername string
+	saslPlainPassword string
+
+	tlsCaCert             string
+	tlsClientCert         string
+	tlsClientKey          string
+	insecureSkipTLSVerify bool
+)
+
+func NewBenchmarkCmd() *cobra.Command {
+	benchmarkCmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure produce and consume throughput against a test topic on the source cluster",
+		Long: `Produce to and consume from a dedicated test topic on the source cluster for a
+fixed duration, then report the throughput and latency achieved.
+
+Run it from a host on the network path the migration will use (for example the
+jump cluster setup host, or a host reaching the cluster over PrivateLink) to
+check that path can carry the expected migration throughput before any real
+data moves. The topic must already exist and should be used for nothing else;
+records are random, keyed per run, and left to expire with the topic's retention.
+
+A topic any consumer group has committed offsets on is refused. So is one that
+already holds records, unless --force is set (for example to rerun against a
+topic an earlier benchmark wrote to).`,
+		Example: `  # 1000 msg/s of 1 KiB records for five minutes, MSK source with IAM auth
+  kcp benchmark \
+      --source-bootstrap b-1.my-cluster.kafka.us-east-1.amazonaws.com:9098 \
+      --topic kcp-benchmark --rate 1000 --duration 5m \
+      --use-sasl-iam --aws-region us-east-1
+
+  # As fast as the cluster acknowledges, saving the result
+  kcp benchmark \
+      --source-bootstrap broker1:9096 --topic kcp-benchmark --message-size 10240 \
+      --output-file benchmark.json \
+      --use-sasl-scram --sasl-scram-username kafkauser --sasl-scram-password kafkapass`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunBenchmark,
+		RunE:          runBenchmark,
+	}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&sourceBootstrap, "source-bootstrap", "", "Bootstrap server(s) of the source Kafka cluster (e.g. broker1:9092,broker2:9092).")
+	requiredFlags.StringVar(&topic, "topic", "", "Existing test topic to produce to and consume from. Do not use a topic with production traffic.")
+	benchmarkCmd.Flags().AddFlagSet(requiredFlags)
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.IntVar(&messageSize, "message-size", 1024, "Size of each record value in bytes.")
+	optionalFlags.IntVar(&rate, "rate", 0, "Target produce rate in messages per second (0 = as fast as the cluster acknowledges).")
+	optionalFlags.DurationVar(&duration, "duration", time.Minute, "How long to produce for (e.g. 30s, 5m).")
+	optionalFlags.StringVar(&acks, "acks", "all", "Producer acks: all, 1 or 0. Use the setting the migration's producers will use.")
+	optionalFlags.StringVar(&compression, "compression", "none", "Producer compression: none, gzip, snappy, lz4 or zstd. Record values are random, so compression does not shrink them.")
+	optionalFlags.StringVar(&outputFile, "output-file", "", "Path of a JSON file to write the benchmark result to.")
+	optionalFlags.BoolVar(&force, "force", false, "Benchmark a topic that already holds records, such as those of an earlier run. Topics with committed consumer-group offsets are refused regardless.")
+	optionalFlags.BoolVar(&insecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for Kafka connections.")
+	benchmarkCmd.Flags().AddFlagSet(optionalFlags)
+
+	authFlags := pflag.NewFlagSet("auth", pflag.ExitOnError)
+	authFlags.SortFlags = false
+	authFlags.BoolVar(&useSaslIam, "use-sasl-iam", false, "Use IAM authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useSaslScram, "use-sasl-scram", false, "Use SASL/SCRAM authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useSaslPlain, "use-sasl-plain", false, "Use SASL/PLAIN authentication for the source cluster.")
+	authFlags.BoolVar(&useTls, "use-tls", false, "Use TLS authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useUnauthenticatedTLS, "use-unauthenticated-tls", false, "Use unauthenticated (TLS encryption) for the source MSK cluster.")
+	authFlags.BoolVar(&useUnauthenticatedPlaintext, "use-unauthenticated-plaintext", false, "Use unauthenticated (plaintext) for the source MSK cluster.")
+	benchmarkCmd.Flags().AddFlagSet(authFlags)
+
+	saslScramFlags := pflag.NewFlagSet("sasl-scram", pflag.ExitOnError)
+	saslScramFlags.SortFlags = false
+	saslScramFlags.StringVar(&saslScramUsername, "sasl-scram-username", "", "SASL/SCRAM username for the source MSK cluster.")
+	saslScramFlags.StringVar(&saslScramPassword, "sasl-scram-password", "", "SASL/SCRAM password for the source MSK cluster.")
+	utils.AddSecretStdinFlag(saslScramFlags, "sasl-scram-password")
+	saslScramFlags.StringVar(&saslScramMechanism, "sasl-scram-mechanism", "SHA512", "SASL/SCRAM mechanism (SHA256 or SHA512). Defaults to SHA512 for MSK compatibility.")
+	benchmarkCmd.Flags().AddFlagSet(saslScramFlags)
+
+	saslPlainFlags := pflag.NewFlagSet("sasl-plain", pflag.ExitOnError)
+	saslPlainFlags.SortFlags = false
+	saslPlainFlags.StringVar(&saslPlainUsername, "sasl-plain-username", "", "SASL/PLAIN username for the source cluster.")
+	saslPlainFlags.StringVar(&saslPlainPassword, "sasl-plain-password", "", "SASL/PLAIN password for the source cluster.")
+	utils.AddSecretStdinFlag(saslPlainFlags, "sasl-plain-password")
+	benchmarkCmd.Flags().AddFlagSet(saslPlainFlags)
+
+	iamFlags := pflag.NewFlagSet("iam", pflag.ExitOnError)
+	iamFlags.SortFlags = false
+	iamFlags.StringVar(&awsRegion, "aws-region", "", "AWS region of the source MSK cluster (e.g. us-east-1).")
+	benchmarkCmd.Flags().AddFlagSet(iamFlags)
+
+	tlsFlags := pflag.NewFlagSet("tls", pflag.ExitOnError)
+	tlsFlags.SortFlags = false
+	tlsFlags.StringVar(&tlsCaCert, "tls-ca-cert", "", "Path to the TLS CA certificate for the source MSK cluster.")
+	tlsFlags.StringVar(&tlsClientCert, "tls-client-cert", "", "Path to the TLS client certificate for the source MSK cluster.")
+	tlsFlags.StringVar(&tlsClientKey, "tls-client-key", "", "Path to the TLS client key for the source MSK cluster.")
+	benchmarkCmd.Flags().AddFlagSet(tlsFlags)
+
+	benchmarkCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, authFlags, iamFlags, saslScramFlags, saslPlainFlags, tlsFlags}
+		groupNames := []string{"Required Flags", "Optional Flags", "Source Cluster Authentication Flags", "IAM Flags", "SASL/SCRAM Flags", "SASL/PLAIN Flags", "TLS Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = benchmarkCmd.MarkFlagRequired("source-bootstrap")
+	_ = benchmarkCmd.MarkFlagRequired("topic")
+	benchmarkCmd.MarkFlagsMutuallyExclusive("use-sasl-iam", "use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+	benchmarkCmd.MarkFlagsOneRequired("use-sasl-iam", "use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+
+	benchmarkCmd.MarkFlagsRequiredTogether("sasl-scram-username", "sasl-scram-password")
+	benchmarkCmd.MarkFlagsRequiredTogether("sasl-plain-username", "sasl-plain-password")
+	benchmarkCmd.MarkFlagsRequiredTogether("tls-ca-cert", "tls-client-cert", "tls-client-key")
+
+	return benchmarkCmd
+}
+
+func preRunBenchmark(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if _, err := parseAcks(acks); err != nil {
+		return err
+	}
+	if _, err := parseCompression(compression); err != nil {
+		return err
+	}
+
+	if useSaslIam {
+		_ = cmd.MarkFlagRequired("aws-region")
+	}
+
+	if useSaslScram {
+		_ = cmd.MarkFlagRequired("sasl-scram-username")
+		_ = cmd.MarkFlagRequired("sasl-scram-password")
+		switch saslScramMechanism {
+		case "SHA256", "SHA512":
+			// valid
+		default:
+			return fmt.Errorf("invalid --sasl-scram-mechanism %q: must be SHA256 or SHA512", saslScramMechanism)
+		}
+	}
+
+	if useSaslPlain {
+		_ = cmd.MarkFlagRequired("sasl-plain-username")
+		_ = cmd.MarkFlagRequired("sasl-plain-password")
+	}
+
+	if useTls {
+		_ = cmd.MarkFlagRequired("tls-ca-cert")
+		_ = cmd.MarkFlagRequired("tls-client-cert")
+		_ = cmd.MarkFlagRequired("tls-client-key")
+	}
+
+	return nil
+}
+
+func runBenchmark(cmd *cobra.Command, args []string) error {
+	opts := parseBenchmarkerOpts()
+
+	return NewBenchmarker(opts).Run(cmd.Context())
+}
+
+func resolveAuthType() types.AuthType {
+	switch {
+	case useSaslIam:
+		return types.AuthTypeIAM
+	case useSaslScram:
+		return types.AuthTypeSASLSCRAM
+	case useSaslPlain:
+		return types.AuthTypeSASLPlain
+	case useTls:
+		return types.AuthTypeTLS
+	case useUnauthenticatedTLS:
+		return types.AuthTypeUnauthenticatedTLS
+	case useUnauthenticatedPlaintext:
+		return types.AuthTypeUnauthenticatedPlaintext
+	default:
+		panic("unreachable: MarkFlagsOneRequired guarantees an auth flag is set")
+	}
+}
+
+func parseBenchmarkerOpts() BenchmarkerOpts {
+	// Both were validated in preRunBenchmark.
+	requiredAcks, _ := parseAcks(acks)
+	compressionCodec, _ := parseCompression(compression)
+
+	return BenchmarkerOpts{
+		SourceBootstrap:       sourceBootstrap,
+		Topic:                 topic,
+		MessageSize:           messageSize,
+		Rate:                  rate,
+		Duration:              duration,
+		RequiredAcks:          requiredAcks,
+		Compression:           compressionCodec,
+		OutputFile:            outputFile,
+		Force:                 force,
+		AWSRegion:             awsRegion,
+		AuthType:              resolveAuthType(),
+		SaslScramUsername:     saslScramUsername,
+		SaslScramPassword:     saslScramPassword,
+		SaslScramMechanism:    saslScramMechanism,
+		SaslPlainUsername:     saslPlainUsername,
+		SaslPlainPassword:     saslPlainPassword,
+		TlsCaCert:             tlsCaCert,
+		TlsClientCert:         tlsClientCert,
+		TlsClientKey:          tlsClientKey,
+		InsecureSkipTLSVerify: insecureSkipTLSVerify,
+	}
+}
+
+func parseAcks(value string) (sarama.RequiredAcks, error) {
+	switch value {
+	case "all", "-1":
+		return sarama.WaitForAll, nil
+	case "1":
+		return sarama.WaitForLocal, nil
+	case "0":
+		return sarama.NoResponse, nil
+	default:
+		return 0, fmt.Errorf("invalid --acks %q: must be all, 1 or 0", value)
+	}
+}
+
+func parseCompression(value string) (sarama.CompressionCodec, error) {
+	var codec sarama.CompressionCodec
+	if err := codec.UnmarshalText([]byte(value)); err != nil {
+		return 0, fmt.Errorf("invalid --compression %q: must be none, gzip, snappy, lz4 or zstd", value)
+	}
+	return codec, nil
+}
@@ -7,29 +7,82 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"os/signal"
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 
+	"github.com/confluentinc/kcp/cmd/apply"
+	"github.com/confluentinc/kcp/cmd/benchmark"
+	"github.com/confluentinc/kcp/cmd/commands"
+	"github.com/confluentinc/kcp/cmd/completion"
 	"github.com/confluentinc/kcp/cmd/create_asset"
+	"github.com/confluentinc/kcp/cmd/dev"
 	"github.com/confluentinc/kcp/cmd/discover"
 	"github.com/confluentinc/kcp/cmd/docs"
+	"github.com/confluentinc/kcp/cmd/doctor"
+	"github.com/confluentinc/kcp/cmd/export"
+	"github.com/confluentinc/kcp/cmd/generate"
 	"github.com/confluentinc/kcp/cmd/healthcheck"
 	"github.com/confluentinc/kcp/cmd/migration"
 	"github.com/confluentinc/kcp/cmd/report"
 	"github.com/confluentinc/kcp/cmd/scan"
+	"github.com/confluentinc/kcp/cmd/serve"
 	"github.com/confluentinc/kcp/cmd/state"
 	"github.com/confluentinc/kcp/cmd/ui"
 	"github.com/confluentinc/kcp/cmd/update"
 	"github.com/confluentinc/kcp/cmd/version"
+	"github.com/confluentinc/kcp/internal/audit"
 	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/client"
 	"github.com/confluentinc/kcp/internal/logging"
+	"github.com/confluentinc/kcp/internal/redact"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/tracing"
+	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var verbose bool
+var (
+	verbose             bool
+	apiRateLimit        float64
+	apiServiceRateLimit map[string]string
+	endpointURL         string
+	serviceEndpointURL  map[string]string
+	profile             string
+	otlpEndpoint        string
+	redactPatterns      []string
+	noRedact            bool
+	auditLog            string
+	noAuditLog          bool
+	timeout             time.Duration
+	jsonSummary         bool
+
+	// finishTracing ends the command span and flushes it; set once tracing is configured.
+	finishTracing func(error)
+
+	// cancelCommand releases the signal handler and --timeout timer; set once
+	// cancellation is configured. commandFinished silences the interruption
+	// notice when the command's own completion cancels the context.
+	cancelCommand   context.CancelFunc
+	commandFinished atomic.Bool
+)
+
+// machineReadableCommands write a script or JSON to stdout for another
+// program to consume, so PersistentPreRun leaves them alone: no build banner
+// in their output and no kcp.log, tracing or audit setup. Cobra's hidden
+// __complete commands are among them since shells run them on every <TAB>.
+var machineReadableCommands = map[string]bool{
+	"completion":                    true,
+	"commands":                      true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
 
 var RootCmd = &cobra.Command{
 	Use:           "kcp",
@@ -37,6 +90,16 @@ var RootCmd = &cobra.Command{
 	Long:          "A comprehensive CLI tool for planning and executing kafka cluster migrations to confluent cloud. Docs: " + build_info.DocsURL(),
 	SilenceErrors: true,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if machineReadableCommands[cmd.Name()] {
+			return
+		}
+
+		// Before logging setup, which captures os.Stdout for the console.
+		if err := configureSummary(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
+
 		// --- Logging setup (must be here so --verbose flag is parsed) ---
 		lumberjackLogger := &lumberjack.Logger{
 			Filename: "kcp.log",
@@ -64,8 +127,12 @@ var RootCmd = &cobra.Command{
 			Console: true,
 		})
 
-		// Fan out to both handlers
-		logger := slog.New(NewFanOutHandler(fileHandler, consoleHandler))
+		// Fan out to both handlers, and to the --json-summary warnings and errors.
+		handlers := []slog.Handler{fileHandler, consoleHandler}
+		if summary.Enabled() {
+			handlers = append(handlers, summary.Handler())
+		}
+		logger := slog.New(NewFanOutHandler(handlers...))
 		slog.SetDefault(logger)
 
 		// File-only mirror sink for components that own rich terminal output
@@ -125,6 +192,36 @@ var RootCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
 			os.Exit(1)
 		}
+
+		if err := configureAPIRateLimits(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
+
+		if err := configureAWSEndpoint(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
+
+		if err := configureCancellation(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
+
+		if err := configureTracing(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
+
+		if err := configureRedaction(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
+
+		if err := configureAudit(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", color.RedString("Error: %v", err))
+			os.Exit(1)
+		}
 	},
 }
 
@@ -132,20 +229,46 @@ func init() {
 	cobra.EnableTraverseRunHooks = true
 
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose logging to console")
+	RootCmd.PersistentFlags().Float64Var(&apiRateLimit, "api-rate-limit", 0, "Maximum AWS API requests per second, shared by all AWS clients (0 = unlimited). Use to keep large scans under account API limits.")
+	RootCmd.PersistentFlags().StringToStringVar(&apiServiceRateLimit, "api-service-rate-limit", nil, "Per-service AWS API requests per second overriding --api-rate-limit, e.g. kafka=5,cloudwatch=10,ce=1 (services: kafka, cloudwatch, ce).")
+	RootCmd.PersistentFlags().StringVar(&endpointURL, "endpoint-url", "", "Send all AWS API calls to this endpoint instead of the regional service endpoints, e.g. http://localhost:4566 for LocalStack. Overrides endpoint_url from ~/.aws/config and AWS_ENDPOINT_URL; max_attempts and retry_mode from the AWS profile are always honored.")
+	RootCmd.PersistentFlags().StringToStringVar(&serviceEndpointURL, "service-endpoint-url", nil, "Per-service AWS endpoints overriding --endpoint-url, e.g. for VPC interface endpoints: kafka=https://vpce-0abc.kafka.us-east-1.vpce.amazonaws.com (services: athena, ce, cloudtrail, cloudwatch, ec2, glue, iam, kafka, kafkaconnect, s3, secretsmanager, service-quotas, sns, ssm, sts).")
+	RootCmd.PersistentFlags().StringVar(&profile, utils.ProfileFlag, "", "Named profile from ~/.kcp/config.yaml (or $KCP_CONFIG) supplying defaults for any flag not set on the command line or via environment variables. Defaults to the file's default_profile.")
+	RootCmd.PersistentFlags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP collector to send OpenTelemetry traces of AWS and Kafka API calls to, e.g. http://localhost:4318. The standard OTEL_EXPORTER_OTLP_ENDPOINT variable also enables tracing.")
+	RootCmd.PersistentFlags().StringSliceVar(&redactPatterns, "redact-pattern", nil, "Additional keys whose values are redacted from the state file and uploaded JSON, as case-insensitive globs matched against the key or its dotted path, e.g. '*.bootstrap_brokers' (repeatable). Known secret keys such as *.password and sasl.jaas.config are always redacted.")
+	RootCmd.PersistentFlags().BoolVar(&noRedact, "no-redact", false, "Write and upload JSON documents without redacting sensitive values. Connector configurations are still redacted when discovered.")
+	RootCmd.PersistentFlags().StringVar(&auditLog, "audit-log", audit.DefaultPath, "Append-only JSONL file recording every AWS, Kafka and Confluent Cloud API call kcp makes, with timestamps, redacted parameters and results.")
+	RootCmd.PersistentFlags().BoolVar(&noAuditLog, "no-audit-log", false, "Do not record API calls in the audit log.")
+	RootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Stop the command after this long, e.g. 30m or 2h (0 = no limit). Like Ctrl-C, a timed-out discover or scan clusters saves the clusters it finished and leaves a checkpoint to continue from with --resume, and scan client-inventory, cloudtrail-clients and self-managed-connectors save the log files, regions or connectors they finished. Other commands, including the single Athena query of scan flow-logs, stop without saving partial results.")
+	RootCmd.PersistentFlags().BoolVar(&jsonSummary, "json-summary", false, "Print a JSON object summarizing the run (command, status, duration, files written, counts, warnings and errors) to stdout when the command finishes. Everything else the command prints goes to stderr, so stdout carries only the summary.")
+
+	// Replaced by completion.NewCompletionCmd, which leaves out PowerShell.
+	RootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	RootCmd.AddCommand(
 		create_asset.NewCreateAssetCmd(),
+		apply.NewApplyCmd(),
 		scan.NewScanCmd(),
 		report.NewReportCmd(),
 		ui.NewUICmd(),
+		serve.NewServeCmd(),
 		discover.NewDiscoverCmd(),
+		doctor.NewDoctorCmd(),
+		export.NewExportCmd(),
+		generate.NewGenerateCmd(),
 		healthcheck.NewHealthcheckCmd(),
 		migration.NewMigrationCmd(),
+		benchmark.NewBenchmarkCmd(),
+		dev.NewDevCmd(),
 		state.NewStateCmd(),
 		version.NewVersionCmd(),
 		update.NewUpdateCmd(),
 		docs.NewDocsCmd(),
+		completion.NewCompletionCmd(),
+		commands.NewCommandsCmd(),
 	)
+
+	utils.RegisterSecretFlagSources(RootCmd)
 }
 
 type PrettyHandlerOptions struct {
@@ -219,6 +342,249 @@ func NewPrettyHandler(
 	return h
 }
 
+// configureAPIRateLimits applies --api-rate-limit and --api-service-rate-limit
+// to every AWS client. It runs before the subcommand binds environment
+// variables and profile values, so it reads API_RATE_LIMIT /
+// API_SERVICE_RATE_LIMIT and the profile itself.
+func configureAPIRateLimits(cmd *cobra.Command) error {
+	rateLimitFlags := []string{"api-rate-limit", "api-service-rate-limit"}
+	for _, name := range rateLimitFlags {
+		envVarName := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if value, ok := os.LookupEnv(envVarName); ok && !cmd.Flags().Changed(name) {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				return fmt.Errorf("invalid %s: %w", envVarName, err)
+			}
+		}
+	}
+	if err := utils.ApplyProfileToFlags(cmd, rateLimitFlags...); err != nil {
+		return err
+	}
+
+	perService, err := client.ParseAPIServiceRateLimits(apiServiceRateLimit)
+	if err != nil {
+		return err
+	}
+	if err := client.SetAPIRateLimits(apiRateLimit, perService); err != nil {
+		return err
+	}
+	if apiRateLimit > 0 || len(perService) > 0 {
+		slog.Debug("aws api rate limits configured", "global", apiRateLimit, "perService", perService)
+	}
+	return nil
+}
+
+// configureAWSEndpoint applies --endpoint-url and --service-endpoint-url to
+// every AWS client. Like configureAPIRateLimits it reads ENDPOINT_URL /
+// SERVICE_ENDPOINT_URL and the profile itself.
+func configureAWSEndpoint(cmd *cobra.Command) error {
+	endpointFlags := []string{"endpoint-url", "service-endpoint-url"}
+	for _, name := range endpointFlags {
+		envVarName := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if value, ok := os.LookupEnv(envVarName); ok && !cmd.Flags().Changed(name) {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				return fmt.Errorf("invalid %s: %w", envVarName, err)
+			}
+		}
+	}
+	if err := utils.ApplyProfileToFlags(cmd, endpointFlags...); err != nil {
+		return err
+	}
+	if err := client.SetAWSEndpointURL(endpointURL); err != nil {
+		return err
+	}
+	if err := client.SetAWSServiceEndpointURLs(serviceEndpointURL); err != nil {
+		return err
+	}
+	if endpointURL != "" || len(serviceEndpointURL) > 0 {
+		slog.Debug("aws endpoint override configured", "endpointURL", endpointURL, "perService", serviceEndpointURL)
+	}
+	return nil
+}
+
+// configureCancellation cancels the command's context on SIGINT or SIGTERM,
+// or once --timeout elapses. Long-running commands stop between clusters,
+// save what they finished and leave a checkpoint; a second Ctrl-C exits at
+// once. Like configureAPIRateLimits it reads TIMEOUT and the profile itself.
+func configureCancellation(cmd *cobra.Command) error {
+	if value, ok := os.LookupEnv("TIMEOUT"); ok && !cmd.Flags().Changed("timeout") {
+		if err := cmd.Flags().Set("timeout", value); err != nil {
+			return fmt.Errorf("invalid TIMEOUT: %w", err)
+		}
+	}
+	if err := utils.ApplyProfileToFlags(cmd, "timeout"); err != nil {
+		return err
+	}
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative (got %s)", timeout)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	cancel := stop
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("--timeout %s elapsed", timeout))
+		cancel = func() {
+			cancelTimeout()
+			stop()
+		}
+	}
+	cmd.SetContext(ctx)
+	cancelCommand = cancel
+
+	go func() {
+		<-ctx.Done()
+		// Restore the default handler so a second Ctrl-C kills kcp.
+		stop()
+		if commandFinished.Load() {
+			return
+		}
+		logging.File().Warn("command interrupted", "cause", context.Cause(ctx))
+		fmt.Fprintf(os.Stderr, "\n%s\n", color.YellowString("⚠️  %v — stopping after the current step and saving finished work (Ctrl-C again to quit now)", context.Cause(ctx)))
+	}()
+	return nil
+}
+
+// configureTracing starts the OpenTelemetry exporter when --otlp-endpoint (or
+// an OTEL_EXPORTER_OTLP_* variable) is set and opens a span for the whole
+// command. Subcommands that pass cmd.Context() down parent their spans on it.
+func configureTracing(cmd *cobra.Command) error {
+	if value, ok := os.LookupEnv("OTLP_ENDPOINT"); ok && !cmd.Flags().Changed("otlp-endpoint") {
+		_ = cmd.Flags().Set("otlp-endpoint", value)
+	}
+	if err := utils.ApplyProfileToFlags(cmd, "otlp-endpoint"); err != nil {
+		return err
+	}
+
+	shutdown, err := tracing.Setup(cmd.Context(), otlpEndpoint)
+	if err != nil {
+		return err
+	}
+	ctx, span := tracing.Start(cmd.Context(), cmd.CommandPath())
+	cmd.SetContext(ctx)
+
+	finishTracing = func(err error) {
+		tracing.End(span, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			slog.Warn("failed to flush traces", "error", err)
+		}
+	}
+	return nil
+}
+
+// configureRedaction applies --redact-pattern and --no-redact to every JSON
+// document kcp writes or uploads. Like configureAPIRateLimits it runs before
+// the subcommand binds environment variables, so it reads REDACT_PATTERN /
+// NO_REDACT and the profile itself.
+func configureRedaction(cmd *cobra.Command) error {
+	redactFlags := []string{"redact-pattern", "no-redact"}
+	for _, name := range redactFlags {
+		envVarName := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if value, ok := os.LookupEnv(envVarName); ok && !cmd.Flags().Changed(name) {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				return fmt.Errorf("invalid %s: %w", envVarName, err)
+			}
+		}
+	}
+	if err := utils.ApplyProfileToFlags(cmd, redactFlags...); err != nil {
+		return err
+	}
+
+	if err := redact.Configure(!noRedact, redactPatterns); err != nil {
+		return err
+	}
+	if noRedact {
+		slog.Warn("redaction disabled: sensitive values will be written and uploaded as-is")
+	} else if len(redactPatterns) > 0 {
+		slog.Debug("redaction patterns configured", "patterns", redactPatterns)
+	}
+	return nil
+}
+
+// configureAudit points the audit log at --audit-log, or disables it with
+// --no-audit-log. Like configureRedaction it reads AUDIT_LOG / NO_AUDIT_LOG
+// and the profile itself.
+func configureAudit(cmd *cobra.Command) error {
+	auditFlags := []string{"audit-log", "no-audit-log"}
+	for _, name := range auditFlags {
+		envVarName := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if value, ok := os.LookupEnv(envVarName); ok && !cmd.Flags().Changed(name) {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				return fmt.Errorf("invalid %s: %w", envVarName, err)
+			}
+		}
+	}
+	if err := utils.ApplyProfileToFlags(cmd, auditFlags...); err != nil {
+		return err
+	}
+
+	if noAuditLog || auditLog == "" {
+		audit.Setup("", "")
+		slog.Debug("audit log disabled")
+		return nil
+	}
+	audit.Setup(auditLog, cmd.CommandPath())
+	slog.Debug("audit log configured", "path", auditLog)
+	return nil
+}
+
+// configureSummary starts collecting the --json-summary of the command and
+// moves everything else the command prints to stderr, so stdout carries only
+// the summary. Like configureAudit it reads JSON_SUMMARY and the profile
+// itself.
+func configureSummary(cmd *cobra.Command) error {
+	if value, ok := os.LookupEnv("JSON_SUMMARY"); ok && !cmd.Flags().Changed("json-summary") {
+		if err := cmd.Flags().Set("json-summary", value); err != nil {
+			return fmt.Errorf("invalid JSON_SUMMARY: %w", err)
+		}
+	}
+	if err := utils.ApplyProfileToFlags(cmd, "json-summary"); err != nil {
+		return err
+	}
+	if !jsonSummary {
+		return nil
+	}
+
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	color.Output = os.Stderr
+	summary.Setup(cmd.CommandPath(), stdout)
+	return nil
+}
+
+// FinishSummary prints the --json-summary of a command that returned err. It
+// is a no-op when --json-summary is not set.
+func FinishSummary(err error) {
+	if summaryErr := summary.Finish(err); summaryErr != nil {
+		slog.Warn("failed to print command summary", "error", summaryErr)
+	}
+}
+
+// FinishAudit closes the audit log. It is a no-op when nothing was recorded.
+func FinishAudit() {
+	if err := audit.Close(); err != nil {
+		slog.Warn("failed to close audit log", "error", err)
+	}
+}
+
+// FinishCancellation releases the signal handler and --timeout timer once
+// the command has returned. It is a no-op when no command ran.
+func FinishCancellation() {
+	if cancelCommand != nil {
+		commandFinished.Store(true)
+		cancelCommand()
+	}
+}
+
+// FinishTracing ends the command span with the command's error and flushes
+// pending spans to the collector. It is a no-op when no command ran.
+func FinishTracing(err error) {
+	if finishTracing != nil {
+		finishTracing(err)
+	}
+}
+
 func checkWritePermissions() error {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Catalog is the machine-readable description of the kcp command tree that
+// `kcp commands --json` prints, for wrappers that generate forms or
+// validate invocations without parsing --help output.
+type Catalog struct {
+	Version string `json:"version"`
+	// GlobalFlags are accepted by every command.
+	GlobalFlags []Flag    `json:"global_flags"`
+	Commands    []Command `json:"commands"`
+}
+
+// Command is one command in the tree, parents included.
+type Command struct {
+	// Path is the full invocation, e.g. "kcp scan clusters".
+	Path    string `json:"path"`
+	Use     string `json:"use"`
+	Short   string `json:"short"`
+	Long    string `json:"long,omitempty"`
+	Example string `json:"example,omitempty"`
+	// Runnable is false for parents that only group subcommands.
+	Runnable    bool     `json:"runnable"`
+	Subcommands []string `json:"subcommands,omitempty"`
+	// ValidArgs lists the accepted positional arguments, when the command
+	// restricts them.
+	ValidArgs []string `json:"valid_args,omitempty"`
+	Flags     []Flag   `json:"flags"`
+}
+
+// Flag is one command-line flag.
+type Flag struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	// Type is the pflag value type: string, bool, int, float64, duration,
+	// stringSlice, stringToString, ...
+	Type    string `json:"type"`
+	Default string `json:"default,omitempty"`
+	Usage   string `json:"usage"`
+	// Required flags must be set on the command line, through their
+	// environment variable or through a profile.
+	Required bool `json:"required"`
+	// Repeatable flags accept several values, either repeated or comma
+	// separated.
+	Repeatable bool `json:"repeatable"`
+}
+
+func NewCommandsCmd() *cobra.Command {
+	var asJSON bool
+
+	commandsCmd := &cobra.Command{
+		Use:   "commands",
+		Short: "List all kcp commands and their flags",
+		Long: `List every kcp command with a one-line description.
+
+With --json, print a catalog of every command and flag instead: the command path, description, examples, accepted positional arguments and, per flag, its type, default, description and whether it is required or repeatable. Internal tooling can use it to build forms or validate kcp invocations without parsing --help output.`,
+		Example: `  kcp commands
+  kcp commands --json | jq '.commands[] | select(.path == "kcp scan clusters").flags'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalog := BuildCatalog(cmd.Root())
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(catalog)
+			}
+			return writeList(cmd.OutOrStdout(), catalog)
+		},
+	}
+
+	commandsCmd.Flags().BoolVar(&asJSON, "json", false, "Print the full command and flag catalog as JSON")
+
+	return commandsCmd
+}
+
+// BuildCatalog walks the command tree under root, skipping hidden,
+// deprecated and help commands and hidden or deprecated flags.
+func BuildCatalog(root *cobra.Command) Catalog {
+	catalog := Catalog{
+		Version:     build_info.Version,
+		GlobalFlags: collectFlags(root.PersistentFlags()),
+	}
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		entry := Command{
+			Path:      c.CommandPath(),
+			Use:       c.Use,
+			Short:     c.Short,
+			Long:      c.Long,
+			Example:   c.Example,
+			Runnable:  c.Runnable(),
+			ValidArgs: c.ValidArgs,
+			Flags:     collectFlags(c.LocalNonPersistentFlags()),
+		}
+		var children []*cobra.Command
+		for _, sub := range c.Commands() {
+			if !sub.IsAvailableCommand() || sub.IsAdditionalHelpTopicCommand() {
+				continue
+			}
+			entry.Subcommands = append(entry.Subcommands, sub.Name())
+			children = append(children, sub)
+		}
+		catalog.Commands = append(catalog.Commands, entry)
+		for _, sub := range children {
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	return catalog
+}
+
+func collectFlags(flags *pflag.FlagSet) []Flag {
+	out := []Flag{}
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Deprecated != "" || f.Name == "help" {
+			return
+		}
+		flagType := f.Value.Type()
+		out = append(out, Flag{
+			Name:       f.Name,
+			Shorthand:  f.Shorthand,
+			Type:       flagType,
+			Default:    flagDefault(f),
+			Usage:      f.Usage,
+			Required:   len(f.Annotations[cobra.BashCompOneRequiredFlag]) > 0,
+			Repeatable: strings.HasSuffix(flagType, "Slice") || strings.HasSuffix(flagType, "Array") || strings.HasPrefix(flagType, "stringTo"),
+		})
+	})
+	return out
+}
+
+// flagDefault drops the empty defaults pflag renders for collections.
+func flagDefault(f *pflag.Flag) string {
+	switch f.DefValue {
+	case "[]", "map[]":
+		return ""
+	}
+	return f.DefValue
+}
+
+func writeList(w io.Writer, catalog Catalog) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, c := range catalog.Commands {
+		if !c.Runnable {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", c.Path, c.Short)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "\nRun 'kcp <command> --help' for a command's flags, or 'kcp commands --json' for all of them.")
+	return err
+}
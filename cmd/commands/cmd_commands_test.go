@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTree() *cobra.Command {
+	root := &cobra.Command{Use: "kcp"}
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.PersistentFlags().Bool("verbose", false, "Enable verbose logging")
+
+	scan := &cobra.Command{Use: "scan", Short: "Scan"}
+	clusters := &cobra.Command{Use: "clusters", Short: "Scan clusters", Run: func(*cobra.Command, []string) {}}
+	clusters.Flags().String("source-type", "", "Source type")
+	clusters.Flags().StringSlice("notify", nil, "Notify targets")
+	clusters.Flags().String("old-flag", "", "Old")
+	_ = clusters.Flags().MarkDeprecated("old-flag", "use --source-type")
+	_ = clusters.MarkFlagRequired("source-type")
+	scan.AddCommand(clusters)
+
+	hidden := &cobra.Command{Use: "internal", Hidden: true, Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(scan, hidden, NewCommandsCmd())
+	return root
+}
+
+func TestBuildCatalog(t *testing.T) {
+	catalog := BuildCatalog(testTree())
+
+	require.Len(t, catalog.GlobalFlags, 1)
+	assert.Equal(t, "verbose", catalog.GlobalFlags[0].Name)
+
+	var paths []string
+	for _, c := range catalog.Commands {
+		paths = append(paths, c.Path)
+	}
+	assert.Equal(t, []string{"kcp", "kcp commands", "kcp scan", "kcp scan clusters"}, paths, "hidden and help commands are skipped")
+
+	scan := catalog.Commands[2]
+	assert.False(t, scan.Runnable)
+	assert.Equal(t, []string{"clusters"}, scan.Subcommands)
+
+	clusters := catalog.Commands[3]
+	assert.True(t, clusters.Runnable)
+	assert.Equal(t, []Flag{
+		{Name: "notify", Type: "stringSlice", Usage: "Notify targets", Repeatable: true},
+		{Name: "source-type", Type: "string", Usage: "Source type", Required: true},
+	}, clusters.Flags, "deprecated flags are skipped")
+}
+
+func TestCommandsCmdJSON(t *testing.T) {
+	root := testTree()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetArgs([]string{"commands", "--json"})
+
+	require.NoError(t, root.Execute())
+
+	var catalog Catalog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &catalog))
+	assert.Len(t, catalog.Commands, 4)
+}
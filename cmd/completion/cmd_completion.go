@@ -0,0 +1,50 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion <bash|zsh|fish>",
+		Short: "Generate a shell completion script",
+		Long: `Print a completion script for kcp commands, flags and flag values to stdout.
+
+**Bash** (requires the bash-completion package):
+
+    source <(kcp completion bash)                                # current shell
+    kcp completion bash > /etc/bash_completion.d/kcp             # Linux
+    kcp completion bash > $(brew --prefix)/etc/bash_completion.d/kcp   # macOS
+
+**Zsh** (run ` + "`autoload -U compinit; compinit`" + ` once in ~/.zshrc if completion isn't already enabled):
+
+    kcp completion zsh > "${fpath[1]}/_kcp"
+
+**Fish:**
+
+    kcp completion fish > ~/.config/fish/completions/kcp.fish
+
+Start a new shell for the completions to take effect.`,
+		Example: `  kcp completion bash > ~/.local/share/bash-completion/completions/kcp
+  kcp completion zsh > "${fpath[1]}/_kcp"
+  kcp completion fish > ~/.config/fish/completions/kcp.fish`,
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, out := cmd.Root(), cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			default:
+				return fmt.Errorf("unsupported shell %q: must be bash, zsh or fish", args[0])
+			}
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/utils"
 )
 
@@ -63,7 +64,7 @@ func (bh *BastionHostAssetGenerator) Run() error {
 	}
 
 	userDataPath := filepath.Join(outputDir, "bastion-host-user-data.tpl")
-	if err := os.WriteFile(userDataPath, []byte(hclService.GenerateBastionHostUserDataTemplate()), 0644); err != nil {
+	if err := summary.WriteFile(userDataPath, []byte(hclService.GenerateBastionHostUserDataTemplate()), 0644); err != nil {
 		return fmt.Errorf("failed to write user-data template: %w", err)
 	}
 	slog.Debug("wrote bastion-host-user-data.tpl")
@@ -74,35 +75,35 @@ func (bh *BastionHostAssetGenerator) Run() error {
 
 func (bh *BastionHostAssetGenerator) writeTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
 	if files.MainTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(files.MainTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(files.MainTf), 0644); err != nil {
 			return fmt.Errorf("failed to write main.tf: %w", err)
 		}
 		slog.Debug("wrote main.tf")
 	}
 
 	if files.ProvidersTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(files.ProvidersTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(files.ProvidersTf), 0644); err != nil {
 			return fmt.Errorf("failed to write providers.tf: %w", err)
 		}
 		slog.Debug("wrote providers.tf")
 	}
 
 	if files.VariablesTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(files.VariablesTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(files.VariablesTf), 0644); err != nil {
 			return fmt.Errorf("failed to write variables.tf: %w", err)
 		}
 		slog.Debug("wrote variables.tf")
 	}
 
 	if files.OutputsTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "outputs.tf"), []byte(files.OutputsTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "outputs.tf"), []byte(files.OutputsTf), 0644); err != nil {
 			return fmt.Errorf("failed to write outputs.tf: %w", err)
 		}
 		slog.Debug("wrote outputs.tf")
 	}
 
 	if files.InputsAutoTfvars != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(files.InputsAutoTfvars), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(files.InputsAutoTfvars), 0644); err != nil {
 			return fmt.Errorf("failed to write inputs.auto.tfvars: %w", err)
 		}
 		slog.Debug("wrote inputs.auto.tfvars")
@@ -0,0 +1,570 @@
+package client_playbooks
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+// targetBootstrapPlaceholder is written when --target-bootstrap-servers is
+// not set, so the playbooks can be generated before the target cluster exists.
+const targetBootstrapPlaceholder = "<confluent-cloud-bootstrap-servers>"
+
+// The OAuth placeholders are written for the identity pool and cluster IDs
+// not given, like targetBootstrapPlaceholder.
+const (
+	identityPoolPlaceholder   = "<identity-pool-id>"
+	logicalClusterPlaceholder = "<logical-cluster-id>"
+)
+
+type ClientPlaybooksOpts struct {
+	State                  *types.State
+	ClusterArn             string
+	TargetBootstrapServers string
+	TopicNaming            topicnaming.Mapping
+	OutputDir              string
+	// OAuth, when set, has the playbooks authenticate applications with
+	// OAuth tokens instead of API keys.
+	OAuth *OAuthSettings
+}
+
+// OAuthSettings describe how applications authenticate to Confluent Cloud
+// with OAuth: they fetch tokens from TokenEndpointURL of their identity
+// provider and map to the identity pool IdentityPoolID (the identity_pool_id
+// output of `kcp create-asset target-infra --oauth-issuer`) on the target
+// cluster ClusterID.
+type OAuthSettings struct {
+	TokenEndpointURL string
+	IdentityPoolID   string
+	ClusterID        string
+}
+
+type ClientPlaybooksGenerator struct {
+	opts ClientPlaybooksOpts
+}
+
+func NewClientPlaybooksGenerator(opts ClientPlaybooksOpts) *ClientPlaybooksGenerator {
+	return &ClientPlaybooksGenerator{opts: opts}
+}
+
+// application is everything known about one client application on the
+// source cluster, merged from Kafka ACLs, IAM policies and the client
+// inventory built from broker logs.
+type application struct {
+	name       string
+	principals map[string]bool
+	clientIDs  map[string]bool
+	auth       types.AuthType
+	produces   map[string]bool
+	consumes   map[string]bool
+	groups     map[string]bool
+}
+
+func newApplication(name string) *application {
+	return &application{
+		name:       name,
+		principals: map[string]bool{},
+		clientIDs:  map[string]bool{},
+		produces:   map[string]bool{},
+		consumes:   map[string]bool{},
+		groups:     map[string]bool{},
+	}
+}
+
+func (g *ClientPlaybooksGenerator) Run() error {
+	fmt.Printf("🚀 Generating client migration playbooks\n")
+
+	cluster, err := g.opts.State.GetClusterByArn(g.opts.ClusterArn)
+	if err != nil {
+		return err
+	}
+
+	applications := collectApplications(cluster)
+	if len(applications) == 0 {
+		return fmt.Errorf("no client applications found for cluster %s: run `kcp scan clusters` to capture ACLs, `kcp discover` to capture IAM access, or `kcp scan client-inventory` to capture clients from broker logs", cluster.Name)
+	}
+
+	if err := utils.ValidateOutputDir(g.opts.OutputDir); err != nil {
+		return err
+	}
+	slog.Debug("creating client playbooks directory", "directory", g.opts.OutputDir)
+	if err := os.MkdirAll(g.opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create client playbooks directory: %w", err)
+	}
+
+	index := markdown.New()
+	index.AddHeading(fmt.Sprintf("Client migration playbooks: %s", cluster.Name), 1)
+	index.AddParagraph(fmt.Sprintf("One playbook per application using MSK cluster `%s`. Hand each file to the team that owns the application.", cluster.Arn))
+	var rows [][]string
+	for _, app := range applications {
+		fileName := playbookFileName(app.name)
+		playbook := g.renderPlaybook(cluster, app)
+		if err := summary.WriteFile(filepath.Join(g.opts.OutputDir, fileName), []byte(playbook.String()), 0644); err != nil {
+			return fmt.Errorf("failed to write playbook for %s: %w", app.name, err)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("[%s](%s)", app.name, fileName),
+			authLabel(app.auth),
+			fmt.Sprintf("%d", len(app.produces)),
+			fmt.Sprintf("%d", len(app.consumes)),
+			fmt.Sprintf("%d", len(app.groups)),
+		})
+	}
+	index.AddTable([]string{"Application", "Source auth", "Produces to", "Consumes from", "Consumer groups"}, rows)
+	if err := summary.WriteFile(filepath.Join(g.opts.OutputDir, "README.md"), []byte(index.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write playbook index: %w", err)
+	}
+
+	fmt.Printf("✅ Client migration playbooks generated: %s (%d applications)\n", g.opts.OutputDir, len(applications))
+	return nil
+}
+
+// collectApplications groups the cluster's ACLs, IAM access and discovered
+// clients by application, keyed on the principal name (or the client ID for
+// unauthenticated clients), and returns them sorted by name.
+func collectApplications(cluster *types.DiscoveredCluster) []*application {
+	byName := map[string]*application{}
+	get := func(principal, fallback string) *application {
+		name := applicationName(principal)
+		if name == "" {
+			name = fallback
+		}
+		if name == "" {
+			return nil
+		}
+		app, ok := byName[name]
+		if !ok {
+			app = newApplication(name)
+			byName[name] = app
+		}
+		if principal != "" {
+			app.principals[principal] = true
+		}
+		return app
+	}
+
+	for _, acl := range cluster.KafkaAdminClientInformation.Acls {
+		if !strings.EqualFold(acl.PermissionType, "ALLOW") {
+			continue
+		}
+		app := get(acl.Principal, "")
+		if app == nil {
+			continue
+		}
+		if app.auth == "" {
+			app.auth = aclPrincipalAuth(acl.Principal)
+		}
+		resource := acl.ResourceName
+		if strings.EqualFold(acl.ResourcePatternType, "PREFIXED") {
+			resource += "*"
+		}
+		switch strings.ToUpper(acl.ResourceType) {
+		case "TOPIC":
+			operation := strings.ToUpper(acl.Operation)
+			if operation == "WRITE" || operation == "ALL" {
+				app.produces[resource] = true
+			}
+			if operation == "READ" || operation == "ALL" {
+				app.consumes[resource] = true
+			}
+		case "GROUP":
+			app.groups[resource] = true
+		}
+	}
+
+	for _, access := range cluster.AWSClientInformation.IAMAccess {
+		app := get(access.PrincipalArn, "")
+		if app == nil {
+			continue
+		}
+		app.auth = types.AuthTypeIAM
+		for _, permission := range access.Permissions {
+			produce, consume := iamDataActions(permission.Actions)
+			for _, resource := range permission.Resources {
+				resourceType, name := iamResourceName(resource)
+				switch resourceType {
+				case "topic":
+					if produce {
+						app.produces[name] = true
+					}
+					if consume {
+						app.consumes[name] = true
+					}
+				case "group":
+					app.groups[name] = true
+				}
+			}
+		}
+	}
+
+	for _, client := range cluster.DiscoveredClients {
+		app := get(client.Principal, client.ClientId)
+		if app == nil {
+			continue
+		}
+		if client.ClientId != "" {
+			app.clientIDs[client.ClientId] = true
+		}
+		if auth := clientInventoryAuth(client.Auth); auth != "" {
+			app.auth = auth
+		}
+		if client.Topic == "" {
+			continue
+		}
+		switch client.Role {
+		case "Producer":
+			app.produces[client.Topic] = true
+		case "Consumer":
+			app.consumes[client.Topic] = true
+		}
+	}
+
+	applications := make([]*application, 0, len(byName))
+	for _, app := range byName {
+		applications = append(applications, app)
+	}
+	sort.Slice(applications, func(i, j int) bool { return applications[i].name < applications[j].name })
+	return applications
+}
+
+// applicationName derives a readable application name from a Kafka or IAM
+// principal: the role or user name of an IAM ARN (including assumed-role
+// session ARNs), the CN of a certificate principal, or the SCRAM user name.
+func applicationName(principal string) string {
+	name := strings.TrimPrefix(principal, "User:")
+	if strings.HasPrefix(name, "arn:") {
+		parts := strings.SplitN(name, ":", 6)
+		if len(parts) != 6 {
+			return name
+		}
+		segments := strings.Split(parts[5], "/")
+		if segments[0] == "assumed-role" && len(segments) > 1 {
+			return segments[1]
+		}
+		return segments[len(segments)-1]
+	}
+	if strings.HasPrefix(name, "CN=") {
+		cn, _, _ := strings.Cut(strings.TrimPrefix(name, "CN="), ",")
+		return strings.TrimSpace(cn)
+	}
+	return name
+}
+
+func aclPrincipalAuth(principal string) types.AuthType {
+	if strings.HasPrefix(strings.TrimPrefix(principal, "User:"), "CN=") {
+		return types.AuthTypeTLS
+	}
+	return types.AuthTypeSASLSCRAM
+}
+
+// clientInventoryAuth maps the auth recorded by `kcp scan client-inventory`
+// onto the cluster auth types.
+func clientInventoryAuth(auth string) types.AuthType {
+	switch auth {
+	case "IAM":
+		return types.AuthTypeIAM
+	case "SASL_SCRAM":
+		return types.AuthTypeSASLSCRAM
+	case "TLS":
+		return types.AuthTypeTLS
+	case "UNAUTHENTICATED":
+		return types.AuthTypeUnauthenticatedTLS
+	}
+	return ""
+}
+
+// iamDataActions reports whether a kafka-cluster action list lets the
+// principal produce (WriteData) and consume (ReadData).
+func iamDataActions(actions []string) (produce, consume bool) {
+	for _, action := range actions {
+		action = strings.ToLower(action)
+		if action == "kafka-cluster:*" || action == "kafka-cluster:writedata" || action == "kafka-cluster:writedataidempotently" {
+			produce = true
+		}
+		if action == "kafka-cluster:*" || action == "kafka-cluster:readdata" {
+			consume = true
+		}
+	}
+	return produce, consume
+}
+
+// iamResourceName returns the resource type and name of an MSK topic or
+// group ARN, e.g. ("topic", "orders-*") for
+// arn:aws:kafka:us-east-1:123456789012:topic/my-cluster/<uuid>/orders-*.
+// A bare "*" covers every topic and group.
+func iamResourceName(resource string) (string, string) {
+	if resource == "*" {
+		return "topic", "*"
+	}
+	parts := strings.SplitN(resource, ":", 6)
+	if len(parts) != 6 {
+		return "", ""
+	}
+	segments := strings.SplitN(parts[5], "/", 4)
+	switch {
+	case len(segments) == 4:
+		return segments[0], segments[3]
+	case len(segments) > 1 && strings.HasSuffix(segments[len(segments)-1], "*"):
+		return segments[0], "*"
+	}
+	return "", ""
+}
+
+func playbookFileName(name string) string {
+	return utils.CleanPrincipalName(name) + ".md"
+}
+
+func authLabel(auth types.AuthType) string {
+	if auth == "" {
+		return "unknown"
+	}
+	return string(auth)
+}
+
+func (g *ClientPlaybooksGenerator) renderPlaybook(cluster *types.DiscoveredCluster, app *application) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading(fmt.Sprintf("Client migration playbook: %s", app.name), 1)
+	md.AddParagraph(fmt.Sprintf("This application is moving from MSK cluster `%s` (%s) to Confluent Cloud. It lists the configuration changes and the cutover steps for the team that owns the application.", cluster.Name, cluster.Region))
+
+	summary := []string{fmt.Sprintf("**Source auth:** %s", authLabel(app.auth))}
+	if principals := sortedSet(app.principals); len(principals) > 0 {
+		summary = append(summary, fmt.Sprintf("**Source principals:** %s", codeJoin(principals)))
+	}
+	if clientIDs := sortedSet(app.clientIDs); len(clientIDs) > 0 {
+		summary = append(summary, fmt.Sprintf("**Client IDs seen in broker logs:** %s", codeJoin(clientIDs)))
+	}
+	if g.opts.OAuth != nil {
+		summary = append(summary, fmt.Sprintf("**Target auth:** OAuth token of your identity provider, mapped to identity pool `%s`", placeholder(g.opts.OAuth.IdentityPoolID, identityPoolPlaceholder)))
+	} else {
+		summary = append(summary, "**Target auth:** Confluent Cloud API key for a service account")
+	}
+	md.AddHeading("Summary", 2)
+	md.AddList(summary)
+
+	g.addConnectionChanges(md, cluster, app)
+	g.addTopicMapping(md, app)
+
+	if groups := sortedSet(app.groups); len(groups) > 0 {
+		md.AddHeading("Consumer groups", 2)
+		md.AddParagraph("Keep the same `group.id` values. Cluster link consumer offset sync carries their committed offsets to Confluent Cloud, so consumers resume where they stopped on MSK.")
+		md.AddList(codeEach(groups))
+	}
+
+	addCutoverSteps(md, app, g.opts.OAuth != nil)
+	return md
+}
+
+func (g *ClientPlaybooksGenerator) addConnectionChanges(md *markdown.Markdown, cluster *types.DiscoveredCluster, app *application) {
+	target := g.opts.TargetBootstrapServers
+	if target == "" {
+		target = targetBootstrapPlaceholder
+	}
+	source := "_see `kcp discover` output_"
+	if app.auth != "" {
+		if brokers, err := cluster.AWSClientInformation.GetAllBootstrapBrokersForAuthType(app.auth); err == nil && len(brokers) > 0 {
+			source = "`" + strings.Join(brokers, ",") + "`"
+		}
+	}
+
+	md.AddHeading("Connection changes", 2)
+	rows := [][]string{{"`bootstrap.servers`", source, "`" + target + "`"}}
+	rows = append(rows, sourceAuthSettings(app.auth, g.opts.OAuth != nil)...)
+	md.AddTable([]string{"Setting", "MSK (current)", "Confluent Cloud (new)"}, rows)
+
+	if g.opts.OAuth != nil {
+		addOAuthConnectionChanges(md, app, target, g.opts.OAuth)
+		return
+	}
+
+	switch app.auth {
+	case types.AuthTypeIAM:
+		md.AddParagraph("**IAM → API key.** Confluent Cloud does not accept AWS IAM credentials. Remove the `aws-msk-iam-auth` library and its callback handler from the client, and read the API key and secret from your secret store instead of the instance or task role. The IAM policies granting `kafka-cluster:*` actions are replaced by ACLs on the service account.")
+	case types.AuthTypeSASLSCRAM:
+		md.AddParagraph("**SCRAM → API key.** Swap the SCRAM user name and password for the API key and secret, and change the mechanism from `SCRAM-SHA-512` to `PLAIN`. The ACLs of the SCRAM user are recreated for the service account.")
+	case types.AuthTypeTLS:
+		md.AddParagraph("**mTLS → API key.** Replace the client certificate and key store settings with the API key and secret. If the application must keep certificate authentication, ask the platform team whether the target cluster is configured for mTLS instead.")
+	}
+
+	md.AddParagraph("New client properties:")
+	md.AddCodeBlock(strings.Join([]string{
+		"bootstrap.servers=" + target,
+		"security.protocol=SASL_SSL",
+		"sasl.mechanism=PLAIN",
+		`sasl.jaas.config=org.apache.kafka.common.security.plain.PlainLoginModule required username="<API_KEY>" password="<API_SECRET>";`,
+	}, "\n"), "properties")
+}
+
+// addOAuthConnectionChanges explains the switch from the source auth to
+// OAuth and lists the OAUTHBEARER client properties.
+func addOAuthConnectionChanges(md *markdown.Markdown, app *application, target string, oauth *OAuthSettings) {
+	switch app.auth {
+	case types.AuthTypeIAM:
+		md.AddParagraph("**IAM → OAuth.** Confluent Cloud does not accept AWS IAM credentials. Remove the `aws-msk-iam-auth` library and its callback handler from the client, and register the application with your identity provider: it fetches tokens with its own client ID and secret, read from your secret store. The IAM policies granting `kafka-cluster:*` actions are replaced by the role bindings of the identity pool.")
+	case types.AuthTypeSASLSCRAM:
+		md.AddParagraph("**SCRAM → OAuth.** Swap the SCRAM user name and password for the application's client ID and secret in your identity provider, and change the mechanism from `SCRAM-SHA-512` to `OAUTHBEARER`. The ACLs of the SCRAM user are replaced by the role bindings of the identity pool.")
+	case types.AuthTypeTLS:
+		md.AddParagraph("**mTLS → OAuth.** Replace the client certificate and key store settings with the application's client ID and secret in your identity provider.")
+	}
+	md.AddParagraph("The token must pass the identity pool's filter; ask the platform team which audience or scope to request. Kafka clients older than 3.4 load the callback handler from `org.apache.kafka.common.security.oauthbearer.secured` instead.")
+
+	md.AddParagraph("New client properties:")
+	md.AddCodeBlock(strings.Join([]string{
+		"bootstrap.servers=" + target,
+		"security.protocol=SASL_SSL",
+		"sasl.mechanism=OAUTHBEARER",
+		"sasl.oauthbearer.token.endpoint.url=" + oauth.TokenEndpointURL,
+		"sasl.login.callback.handler.class=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginCallbackHandler",
+		fmt.Sprintf(`sasl.jaas.config=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required clientId="<CLIENT_ID>" clientSecret="<CLIENT_SECRET>" scope="<SCOPE>" extension_logicalCluster="%s" extension_identityPoolId="%s";`,
+			placeholder(oauth.ClusterID, logicalClusterPlaceholder), placeholder(oauth.IdentityPoolID, identityPoolPlaceholder)),
+	}, "\n"), "properties")
+}
+
+func placeholder(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// sourceAuthSettings lists the auth settings the application uses today and
+// what they become on Confluent Cloud, with an API key or, when oauth is set,
+// an OAuth token.
+func sourceAuthSettings(auth types.AuthType, oauth bool) [][]string {
+	mechanism, jaas := "`PLAIN`", "`PlainLoginModule` with the API key and secret"
+	if oauth {
+		mechanism, jaas = "`OAUTHBEARER`", "`OAuthBearerLoginModule` with the client ID and secret"
+	}
+
+	var rows [][]string
+	switch auth {
+	case types.AuthTypeIAM:
+		rows = [][]string{
+			{"`security.protocol`", "`SASL_SSL`", "`SASL_SSL`"},
+			{"`sasl.mechanism`", "`AWS_MSK_IAM`", mechanism},
+			{"`sasl.jaas.config`", "`software.amazon.msk.auth.iam.IAMLoginModule required;`", jaas},
+			{"`sasl.client.callback.handler.class`", "`software.amazon.msk.auth.iam.IAMClientCallbackHandler`", "_remove_"},
+		}
+	case types.AuthTypeSASLSCRAM:
+		rows = [][]string{
+			{"`security.protocol`", "`SASL_SSL`", "`SASL_SSL`"},
+			{"`sasl.mechanism`", "`SCRAM-SHA-512`", mechanism},
+			{"`sasl.jaas.config`", "`ScramLoginModule` with the SCRAM user", jaas},
+		}
+	case types.AuthTypeTLS:
+		rows = [][]string{
+			{"`security.protocol`", "`SSL`", "`SASL_SSL`"},
+			{"`ssl.keystore.*`", "client certificate", "_remove_"},
+		}
+	case types.AuthTypeUnauthenticatedTLS, types.AuthTypeUnauthenticatedPlaintext:
+		rows = [][]string{
+			{"`security.protocol`", "`SSL` or `PLAINTEXT`", "`SASL_SSL`"},
+		}
+	}
+	if oauth {
+		rows = append(rows,
+			[]string{"`sasl.oauthbearer.token.endpoint.url`", "_not set_", "token endpoint of your identity provider"},
+			[]string{"`sasl.login.callback.handler.class`", "_not set_", "`OAuthBearerLoginCallbackHandler`"},
+		)
+	}
+	return rows
+}
+
+func (g *ClientPlaybooksGenerator) addTopicMapping(md *markdown.Markdown, app *application) {
+	topics := map[string]bool{}
+	for topic := range app.produces {
+		topics[topic] = true
+	}
+	for topic := range app.consumes {
+		topics[topic] = true
+	}
+	if len(topics) == 0 {
+		return
+	}
+
+	var rows [][]string
+	renamed := false
+	for _, topic := range sortedSet(topics) {
+		destination := g.opts.TopicNaming.Destination(topic)
+		if destination != topic {
+			renamed = true
+		}
+		var access []string
+		if app.produces[topic] {
+			access = append(access, "produce")
+		}
+		if app.consumes[topic] {
+			access = append(access, "consume")
+		}
+		rows = append(rows, []string{"`" + topic + "`", "`" + destination + "`", strings.Join(access, ", ")})
+	}
+
+	md.AddHeading("Topic mapping", 2)
+	if renamed {
+		md.AddParagraph("Some topics have a new name on Confluent Cloud. Update every topic reference in the application configuration and code.")
+	} else {
+		md.AddParagraph("Topic names are unchanged on Confluent Cloud.")
+	}
+	md.AddTable([]string{"MSK topic", "Confluent Cloud topic", "Access"}, rows)
+}
+
+func addCutoverSteps(md *markdown.Markdown, app *application, oauth bool) {
+	credentials := "Request a Confluent Cloud API key for the application's service account from the platform team, and store it in your secret store."
+	if oauth {
+		credentials = "Register the application as a client of your identity provider, so that its tokens pass the identity pool's filter, and store its client ID and secret in your secret store."
+	}
+	steps := []string{
+		credentials,
+		"Prepare the configuration change above behind a deploy flag or in a release branch, and check connectivity from the application's network with `kafka-broker-api-versions --bootstrap-server <bootstrap> --command-config <client.properties>`.",
+	}
+	if len(app.consumes) > 0 {
+		steps = append(steps,
+			"**Consumers first.** Confirm with the platform team (`kcp migration lag-check`) that the mirror topics and consumer offsets are in sync, stop the consumers on MSK, deploy the new configuration and start them against Confluent Cloud.",
+		)
+	}
+	if len(app.produces) > 0 {
+		steps = append(steps,
+			"**Producers after promotion.** Wait for the platform team to promote the mirror topics (`kcp migration execute`); mirror topics are read-only until then. Stop the producers on MSK, deploy the new configuration and start them against Confluent Cloud.",
+		)
+	}
+	steps = append(steps,
+		"Validate: watch consumer lag, produce error rates and end-to-end latency for at least one business cycle.",
+		"Rollback: until the mirror topics are promoted, point the application back at the MSK bootstrap servers and restart. After promotion, coordinate any rollback with the platform team.",
+	)
+
+	md.AddHeading("Cutover steps", 2)
+	for i, step := range steps {
+		steps[i] = fmt.Sprintf("%d. %s", i+1, step)
+	}
+	md.AddParagraph(strings.Join(steps, "\n"))
+}
+
+func sortedSet(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	return values
+}
+
+func codeEach(values []string) []string {
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = "`" + value + "`"
+	}
+	return out
+}
+
+func codeJoin(values []string) string {
+	return strings.Join(codeEach(values), ", ")
+}
@@ -0,0 +1,184 @@
+package client_playbooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClusterArn = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/0a1b2c3d-4e5f-6789-abcd-ef0123456789-2"
+
+func newTestCluster() types.DiscoveredCluster {
+	cluster := types.DiscoveredCluster{
+		Name:   "orders",
+		Arn:    testClusterArn,
+		Region: "us-east-1",
+		AWSClientInformation: types.AWSClientInformation{
+			IAMAccess: []types.IAMPrincipalAccess{{
+				PrincipalArn:  "arn:aws:iam::123456789012:role/orders-producer",
+				PrincipalName: "orders-producer",
+				PrincipalType: "role",
+				Permissions: []types.IAMKafkaPermission{{
+					Policy:  "orders",
+					Actions: []string{"kafka-cluster:Connect", "kafka-cluster:WriteData"},
+					Resources: []string{
+						testClusterArn,
+						"arn:aws:kafka:us-east-1:123456789012:topic/orders/0a1b2c3d-4e5f-6789-abcd-ef0123456789-2/legacy-orders",
+					},
+				}},
+			}},
+		},
+		DiscoveredClients: []types.DiscoveredClient{
+			{ClientId: "orders-producer-1", Role: "Producer", Topic: "legacy-orders", Auth: "IAM", Principal: "arn:aws:sts::123456789012:assumed-role/orders-producer/i-0abc"},
+			{ClientId: "reporting", Role: "Consumer", Topic: "payments", Auth: "UNAUTHENTICATED"},
+		},
+	}
+	cluster.KafkaAdminClientInformation.Acls = []types.Acls{
+		{ResourceType: "TOPIC", ResourceName: "payments", ResourcePatternType: "LITERAL", Principal: "User:billing", Operation: "READ", PermissionType: "ALLOW"},
+		{ResourceType: "TOPIC", ResourceName: "audit-", ResourcePatternType: "PREFIXED", Principal: "User:billing", Operation: "ALL", PermissionType: "ALLOW"},
+		{ResourceType: "GROUP", ResourceName: "billing-group", ResourcePatternType: "LITERAL", Principal: "User:billing", Operation: "READ", PermissionType: "ALLOW"},
+		{ResourceType: "TOPIC", ResourceName: "secrets", ResourcePatternType: "LITERAL", Principal: "User:billing", Operation: "READ", PermissionType: "DENY"},
+	}
+	return cluster
+}
+
+func TestCollectApplications(t *testing.T) {
+	cluster := newTestCluster()
+	applications := collectApplications(&cluster)
+
+	require.Len(t, applications, 3)
+
+	billing := applications[0]
+	assert.Equal(t, "billing", billing.name)
+	assert.Equal(t, types.AuthTypeSASLSCRAM, billing.auth)
+	assert.Equal(t, []string{"audit-*", "payments"}, sortedSet(billing.consumes))
+	assert.Equal(t, []string{"audit-*"}, sortedSet(billing.produces))
+	assert.Equal(t, []string{"billing-group"}, sortedSet(billing.groups))
+	assert.NotContains(t, billing.consumes, "secrets", "DENY ACLs are not access")
+
+	producer := applications[1]
+	assert.Equal(t, "orders-producer", producer.name, "IAM role and assumed-role session merge into one application")
+	assert.Equal(t, types.AuthTypeIAM, producer.auth)
+	assert.Equal(t, []string{"legacy-orders"}, sortedSet(producer.produces))
+	assert.Equal(t, []string{"orders-producer-1"}, sortedSet(producer.clientIDs))
+	assert.Len(t, producer.principals, 2)
+
+	reporting := applications[2]
+	assert.Equal(t, "reporting", reporting.name, "unauthenticated clients fall back to the client ID")
+	assert.Equal(t, types.AuthTypeUnauthenticatedTLS, reporting.auth)
+	assert.Empty(t, reporting.principals)
+}
+
+func TestApplicationName(t *testing.T) {
+	tests := map[string]string{
+		"User:billing":                                                        "billing",
+		"User:CN=payments.example.com,OU=Kafka":                               "payments.example.com",
+		"arn:aws:iam::123456789012:role/service/orders-producer":              "orders-producer",
+		"arn:aws:iam::123456789012:user/admin":                                "admin",
+		"arn:aws:sts::123456789012:assumed-role/orders-producer/i-0abc":       "orders-producer",
+		"User:arn:aws:sts::123456789012:assumed-role/orders-consumer/session": "orders-consumer",
+		"": "",
+	}
+	for principal, want := range tests {
+		assert.Equal(t, want, applicationName(principal), principal)
+	}
+}
+
+func TestIAMResourceName(t *testing.T) {
+	tests := []struct {
+		resource     string
+		wantType     string
+		wantResource string
+	}{
+		{"*", "topic", "*"},
+		{"arn:aws:kafka:us-east-1:123456789012:topic/orders/abc-2/orders-*", "topic", "orders-*"},
+		{"arn:aws:kafka:us-east-1:123456789012:group/orders/abc-2/billing", "group", "billing"},
+		{"arn:aws:kafka:us-east-1:123456789012:topic/orders/*", "topic", "*"},
+		{testClusterArn, "", ""},
+	}
+	for _, tt := range tests {
+		resourceType, name := iamResourceName(tt.resource)
+		assert.Equal(t, tt.wantType, resourceType, tt.resource)
+		assert.Equal(t, tt.wantResource, name, tt.resource)
+	}
+}
+
+func TestClientPlaybooksGenerator_Run(t *testing.T) {
+	state := &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{newTestCluster()}},
+	}}}
+	outputDir := filepath.Join(t.TempDir(), "playbooks")
+
+	generator := NewClientPlaybooksGenerator(ClientPlaybooksOpts{
+		State:                  state,
+		ClusterArn:             testClusterArn,
+		TargetBootstrapServers: "pkc-abc.us-east-1.aws.confluent.cloud:9092",
+		TopicNaming:            topicnaming.Mapping{Renames: map[string]string{"legacy-orders": "orders"}},
+		OutputDir:              outputDir,
+	})
+	require.NoError(t, generator.Run())
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "[orders-producer](orders_producer.md)")
+
+	playbook, err := os.ReadFile(filepath.Join(outputDir, "orders_producer.md"))
+	require.NoError(t, err)
+	content := string(playbook)
+	assert.Contains(t, content, "**IAM → API key.**")
+	assert.Contains(t, content, "bootstrap.servers=pkc-abc.us-east-1.aws.confluent.cloud:9092")
+	assert.Contains(t, content, "| `legacy-orders` | `orders` | produce |")
+	assert.Contains(t, content, "**Producers after promotion.**")
+	assert.NotContains(t, content, "**Consumers first.**")
+
+	billing, err := os.ReadFile(filepath.Join(outputDir, "billing.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(billing), "Topic names are unchanged on Confluent Cloud.")
+	assert.Contains(t, string(billing), "`billing-group`")
+}
+
+func TestClientPlaybooksGenerator_RunOAuth(t *testing.T) {
+	state := &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{newTestCluster()}},
+	}}}
+	outputDir := filepath.Join(t.TempDir(), "playbooks")
+
+	generator := NewClientPlaybooksGenerator(ClientPlaybooksOpts{
+		State:      state,
+		ClusterArn: testClusterArn,
+		OutputDir:  outputDir,
+		OAuth: &OAuthSettings{
+			TokenEndpointURL: "https://login.example.com/oauth2/default/v1/token",
+			IdentityPoolID:   "pool-AbCd",
+		},
+	})
+	require.NoError(t, generator.Run())
+
+	playbook, err := os.ReadFile(filepath.Join(outputDir, "orders_producer.md"))
+	require.NoError(t, err)
+	content := string(playbook)
+	assert.Contains(t, content, "**IAM → OAuth.**")
+	assert.Contains(t, content, "identity pool `pool-AbCd`")
+	assert.Contains(t, content, "| `sasl.mechanism` | `AWS_MSK_IAM` | `OAUTHBEARER` |")
+	assert.Contains(t, content, "sasl.oauthbearer.token.endpoint.url=https://login.example.com/oauth2/default/v1/token")
+	assert.Contains(t, content, `extension_logicalCluster="<logical-cluster-id>" extension_identityPoolId="pool-AbCd";`)
+	assert.Contains(t, content, "Register the application as a client of your identity provider")
+	assert.NotContains(t, content, "API key")
+}
+
+func TestClientPlaybooksGenerator_NoApplications(t *testing.T) {
+	cluster := types.DiscoveredCluster{Name: "orders", Arn: testClusterArn}
+	state := &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{cluster}},
+	}}}
+
+	err := NewClientPlaybooksGenerator(ClientPlaybooksOpts{State: state, ClusterArn: testClusterArn, OutputDir: t.TempDir()}).Run()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kcp scan client-inventory")
+}
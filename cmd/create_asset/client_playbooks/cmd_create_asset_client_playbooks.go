@@ -0,0 +1,167 @@
+package client_playbooks
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile              string
+	clusterArn             string
+	targetBootstrapServers string
+	topicNaming            utils.TopicNamingFlags
+	outputDir              string
+
+	oauthTokenEndpointURL string
+	oauthIdentityPoolID   string
+	targetClusterID       string
+)
+
+func NewClientPlaybooksCmd() *cobra.Command {
+	clientPlaybooksCmd := &cobra.Command{
+		Use:   "client-playbooks",
+		Short: "Generate a client migration playbook per application",
+		Long: "Generate one markdown playbook per client application of an MSK cluster, to hand to the team that owns it. Each playbook lists the new bootstrap servers, the auth change (e.g. IAM → API key) with the client properties to set, the topic name mapping and the recommended cutover steps.\n\n" +
+			"With `--oauth-token-endpoint-url` applications authenticate with OAuth tokens of your identity provider instead of API keys, mapped to the identity pool that `kcp create-asset target-infra --oauth-issuer` creates.\n\n" +
+			"Applications are identified by principal from the state file: Kafka ACLs (`kcp scan clusters`), IAM policies granting kafka-cluster actions (`kcp discover`) and clients seen in broker logs (`kcp scan client-inventory`). Nothing is read from AWS or the cluster.",
+		Example: `  kcp create-asset client-playbooks \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-bootstrap-servers pkc-xxxxx.us-east-1.aws.confluent.cloud:9092
+
+  # With the topic naming flags used for migrate-topics --mode new
+  kcp create-asset client-playbooks \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --topic-prefix msk. --topic-rename-file renames.csv
+
+  # Applications authenticate with OAuth instead of API keys
+  kcp create-asset client-playbooks \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --oauth-token-endpoint-url https://login.example.com/oauth2/default/v1/token \
+      --oauth-identity-pool-id pool-AbCd --target-cluster-id lkc-xyz789`,
+		SilenceErrors: true,
+		PreRunE:       preRunCreateClientPlaybooks,
+		RunE:          runCreateClientPlaybooks,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the MSK cluster discovery reports have been written to.")
+	requiredFlags.StringVar(&clusterArn, "cluster-arn", "", "The ARN of the MSK cluster whose client applications to generate playbooks for.")
+	clientPlaybooksCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&targetBootstrapServers, "target-bootstrap-servers", "", "The bootstrap servers of the target Confluent Cloud cluster (default: a placeholder to fill in).")
+	optionalFlags.StringVar(&outputDir, "output-dir", "", "Directory to output the playbooks to (default: <cluster-name>-client-playbooks)")
+	clientPlaybooksCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	oauthFlags := pflag.NewFlagSet("oauth", pflag.ExitOnError)
+	oauthFlags.SortFlags = false
+	oauthFlags.StringVar(&oauthTokenEndpointURL, "oauth-token-endpoint-url", "", "Token endpoint of the OIDC identity provider. Switches the playbooks from API keys to OAuth.")
+	oauthFlags.StringVar(&oauthIdentityPoolID, "oauth-identity-pool-id", "", "The identity pool applications map to, from the identity_pool_id output of target-infra (default: a placeholder to fill in).")
+	oauthFlags.StringVar(&targetClusterID, "target-cluster-id", "", "The ID of the target Confluent Cloud cluster, e.g. lkc-xyz789 (default: a placeholder to fill in).")
+	clientPlaybooksCmd.Flags().AddFlagSet(oauthFlags)
+	groups[oauthFlags] = "OAuth Flags"
+
+	topicNamingFlagSet := topicNaming.FlagSet()
+	clientPlaybooksCmd.Flags().AddFlagSet(topicNamingFlagSet)
+	groups[topicNamingFlagSet] = "Topic Naming Flags"
+
+	clientPlaybooksCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, oauthFlags, topicNamingFlagSet}
+		groupNames := []string{"Required Flags", "Optional Flags", "OAuth Flags", "Topic Naming Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = clientPlaybooksCmd.MarkFlagRequired("state-file")
+	_ = clientPlaybooksCmd.MarkFlagRequired("cluster-arn")
+
+	return clientPlaybooksCmd
+}
+
+func preRunCreateClientPlaybooks(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if oauthTokenEndpointURL == "" && (oauthIdentityPoolID != "" || targetClusterID != "") {
+		return fmt.Errorf("--oauth-identity-pool-id and --target-cluster-id require --oauth-token-endpoint-url")
+	}
+
+	return nil
+}
+
+func runCreateClientPlaybooks(cmd *cobra.Command, args []string) error {
+	opts, err := parseClientPlaybooksOpts()
+	if err != nil {
+		return fmt.Errorf("failed to parse client playbooks opts: %w", err)
+	}
+
+	if err := NewClientPlaybooksGenerator(*opts).Run(); err != nil {
+		return fmt.Errorf("failed to create client playbooks: %w", err)
+	}
+
+	return nil
+}
+
+func parseClientPlaybooksOpts() (*ClientPlaybooksOpts, error) {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state file: %w", err)
+	}
+
+	naming, err := topicNaming.Mapping()
+	if err != nil {
+		return nil, err
+	}
+
+	if outputDir == "" {
+		outputDir = fmt.Sprintf("%s-client-playbooks", utils.ExtractClusterNameFromArn(clusterArn))
+	}
+
+	var oauth *OAuthSettings
+	if oauthTokenEndpointURL != "" {
+		oauth = &OAuthSettings{
+			TokenEndpointURL: oauthTokenEndpointURL,
+			IdentityPoolID:   oauthIdentityPoolID,
+			ClusterID:        targetClusterID,
+		}
+	}
+
+	return &ClientPlaybooksOpts{
+		State:                  state,
+		ClusterArn:             clusterArn,
+		TargetBootstrapServers: targetBootstrapServers,
+		TopicNaming:            naming,
+		OutputDir:              outputDir,
+		OAuth:                  oauth,
+	}, nil
+}
@@ -2,12 +2,17 @@ package create_asset
 
 import (
 	"github.com/confluentinc/kcp/cmd/create_asset/bastion_host"
+	"github.com/confluentinc/kcp/cmd/create_asset/client_playbooks"
+	"github.com/confluentinc/kcp/cmd/create_asset/log_delivery"
 	"github.com/confluentinc/kcp/cmd/create_asset/migrate_acls"
 	"github.com/confluentinc/kcp/cmd/create_asset/migrate_connectors"
 	"github.com/confluentinc/kcp/cmd/create_asset/migrate_schemas"
 	"github.com/confluentinc/kcp/cmd/create_asset/migrate_topics"
 	"github.com/confluentinc/kcp/cmd/create_asset/migration_infra"
 	"github.com/confluentinc/kcp/cmd/create_asset/reverse_proxy"
+	"github.com/confluentinc/kcp/cmd/create_asset/scheduled_discovery"
+	"github.com/confluentinc/kcp/cmd/create_asset/source_import"
+	"github.com/confluentinc/kcp/cmd/create_asset/stream_processing"
 	targetinfra "github.com/confluentinc/kcp/cmd/create_asset/target_infra"
 	"github.com/spf13/cobra"
 )
@@ -22,12 +27,17 @@ func NewCreateAssetCmd() *cobra.Command {
 	// Add subcommands
 	createAssetCmd.AddCommand(
 		bastion_host.NewBastionHostCmd(),
+		client_playbooks.NewClientPlaybooksCmd(),
+		log_delivery.NewLogDeliveryCmd(),
 		migrate_acls.NewMigrateAclsCmd(),
 		migrate_connectors.NewMigrateConnectorsCmd(),
 		migrate_topics.NewMigrateTopicsCmd(),
 		migrate_schemas.NewMigrateSchemasCmd(),
 		migration_infra.NewMigrationInfraCmd(),
 		reverse_proxy.NewReverseProxyCmd(),
+		scheduled_discovery.NewScheduledDiscoveryCmd(),
+		source_import.NewSourceImportCmd(),
+		stream_processing.NewStreamProcessingCmd(),
 		targetinfra.NewTargetInfraCmd(),
 	)
 
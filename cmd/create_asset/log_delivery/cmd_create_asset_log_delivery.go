@@ -0,0 +1,131 @@
+package log_delivery
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile  string
+	clusterArn string
+	outputDir  string
+)
+
+func NewLogDeliveryCmd() *cobra.Command {
+	logDeliveryCmd := &cobra.Command{
+		Use:   "log-delivery",
+		Short: "Create Terraform that keeps log compliance when an MSK cluster's broker log delivery goes away",
+		Long: "Generate the Confluent Cloud replacement for the broker log delivery of an MSK provisioned cluster, so the log trail compliance depends on does not stop at cutover. The CloudWatch Logs group, Firehose stream and S3 bucket the cluster delivers to come from its `LoggingInfo` in the state file written by `kcp discover`; nothing is read from AWS.\n\n" +
+			"Confluent Cloud does not expose broker logs. Its audit log — authentication, authorization and management events for the whole organization, kept for seven days on a Confluent-managed cluster — is the record that replaces them. The generated Terraform creates an API key on the audit log cluster, stores it in Secrets Manager and checks that each destination still exists. The README lists, per destination, how to forward the `confluent-audit-log-events` topic there.\n\n" +
+			"Fill in the audit log IDs printed by `confluent audit-log describe` before applying.",
+		Example: `  kcp create-asset log-delivery \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: iampolicy.RenderSingle(
+				"`kcp create-asset log-delivery` itself only reads the local state file. The executor of `terraform plan`/`apply` on the generated project needs:",
+				[]string{
+					"secretsmanager:CreateSecret",
+					"secretsmanager:DeleteSecret",
+					"secretsmanager:DescribeSecret",
+					"secretsmanager:GetResourcePolicy",
+					"secretsmanager:GetSecretValue",
+					"secretsmanager:PutSecretValue",
+					"logs:DescribeLogGroups",
+					"firehose:DescribeDeliveryStream",
+					"s3:ListBucket",
+				},
+			),
+		},
+		SilenceErrors: true,
+		PreRunE:       preRunCreateLogDelivery,
+		RunE:          runCreateLogDelivery,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the MSK cluster discovery reports have been written to.")
+	requiredFlags.StringVar(&clusterArn, "cluster-arn", "", "The ARN of the MSK cluster whose broker log delivery to replace.")
+	logDeliveryCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&outputDir, "output-dir", "", "Directory to output the generated Terraform files to (default: <cluster-name>-log-delivery)")
+	logDeliveryCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	logDeliveryCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = logDeliveryCmd.MarkFlagRequired("state-file")
+	_ = logDeliveryCmd.MarkFlagRequired("cluster-arn")
+
+	return logDeliveryCmd
+}
+
+func preRunCreateLogDelivery(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runCreateLogDelivery(cmd *cobra.Command, args []string) error {
+	opts, err := parseLogDeliveryOpts()
+	if err != nil {
+		return fmt.Errorf("failed to parse log delivery opts: %w", err)
+	}
+
+	logDeliveryGenerator := NewLogDeliveryAssetGenerator(*opts)
+	if err := logDeliveryGenerator.Run(); err != nil {
+		return fmt.Errorf("failed to create log delivery assets: %w", err)
+	}
+
+	return nil
+}
+
+func parseLogDeliveryOpts() (*LogDeliveryOpts, error) {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state file: %w", err)
+	}
+
+	if outputDir == "" {
+		outputDir = fmt.Sprintf("%s-log-delivery", utils.ExtractClusterNameFromArn(clusterArn))
+	}
+
+	return &LogDeliveryOpts{
+		State:      state,
+		ClusterArn: clusterArn,
+		OutputDir:  outputDir,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package log_delivery
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/hcl"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+type LogDeliveryOpts struct {
+	State      *types.State
+	ClusterArn string
+	OutputDir  string
+}
+
+type LogDeliveryAssetGenerator struct {
+	opts LogDeliveryOpts
+}
+
+func NewLogDeliveryAssetGenerator(opts LogDeliveryOpts) *LogDeliveryAssetGenerator {
+	return &LogDeliveryAssetGenerator{opts: opts}
+}
+
+func (ld *LogDeliveryAssetGenerator) Run() error {
+	fmt.Printf("🚀 Generating log delivery assets\n")
+
+	cluster, err := ld.opts.State.GetClusterByArn(ld.opts.ClusterArn)
+	if err != nil {
+		return err
+	}
+
+	request, err := buildRequest(cluster)
+	if err != nil {
+		return err
+	}
+	if !request.Destinations.Enabled() {
+		fmt.Printf("✅ %s does not deliver broker logs; nothing to generate\n", cluster.Name)
+		return nil
+	}
+	for _, destination := range destinationRows(request.Destinations) {
+		fmt.Printf("   %s → %s\n", destination[0], destination[1])
+	}
+
+	terraformFiles, err := hcl.NewLogDeliveryHCLService().GenerateLogDeliveryFiles(request)
+	if err != nil {
+		return fmt.Errorf("failed to generate Terraform files: %w", err)
+	}
+
+	if err := utils.ValidateOutputDir(ld.opts.OutputDir); err != nil {
+		return err
+	}
+	slog.Debug("creating log delivery directory", "directory", ld.opts.OutputDir)
+	if err := os.MkdirAll(ld.opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create log delivery directory: %w", err)
+	}
+
+	if err := ld.writeTerraformFiles(ld.opts.OutputDir, terraformFiles); err != nil {
+		return fmt.Errorf("failed to write Terraform files: %w", err)
+	}
+	if err := summary.WriteFile(filepath.Join(ld.opts.OutputDir, "README.md"), []byte(generateReadme(request).String()), 0644); err != nil {
+		return fmt.Errorf("failed to write README.md: %w", err)
+	}
+
+	fmt.Printf("✅ Log delivery assets generated successfully: %s\n", ld.opts.OutputDir)
+	return nil
+}
+
+// buildRequest reads the broker log destinations from the discovered
+// cluster's LoggingInfo. Serverless clusters have no broker logs.
+func buildRequest(cluster *types.DiscoveredCluster) (hclrequests.LogDeliveryRequest, error) {
+	mskConfig := cluster.AWSClientInformation.MskClusterConfig
+	if mskConfig.ClusterType != kafkatypes.ClusterTypeProvisioned || mskConfig.Provisioned == nil {
+		return hclrequests.LogDeliveryRequest{}, fmt.Errorf("cluster %s is not a provisioned MSK cluster; only provisioned clusters deliver broker logs", cluster.Name)
+	}
+
+	return hclrequests.LogDeliveryRequest{
+		Region:            cluster.Region,
+		SourceClusterName: cluster.Name,
+		Destinations:      hclrequests.NewBrokerLogDelivery(mskConfig),
+	}, nil
+}
+
+func destinationRows(destinations hclrequests.BrokerLogDelivery) [][]string {
+	var rows [][]string
+	if destinations.CloudWatchLogGroup != "" {
+		rows = append(rows, []string{"CloudWatch Logs", destinations.CloudWatchLogGroup})
+	}
+	if destinations.FirehoseDeliveryStream != "" {
+		rows = append(rows, []string{"Firehose", destinations.FirehoseDeliveryStream})
+	}
+	if destinations.S3Bucket != "" {
+		rows = append(rows, []string{"S3", fmt.Sprintf("s3://%s/%s", destinations.S3Bucket, destinations.S3Prefix)})
+	}
+	return rows
+}
+
+// generateReadme explains what replaces broker logs on Confluent Cloud and,
+// per destination, how to forward the audit log there.
+func generateReadme(request hclrequests.LogDeliveryRequest) *markdown.Markdown {
+	destinations := request.Destinations
+
+	md := markdown.New()
+	md.AddHeading(fmt.Sprintf("Log delivery for %s", request.SourceClusterName), 1)
+	md.AddParagraph(fmt.Sprintf("%s delivers its broker logs to:", request.SourceClusterName))
+	md.AddTable([]string{"Destination", "Target"}, destinationRows(destinations))
+	md.AddParagraph("")
+
+	md.AddHeading("What changes at cutover", 2)
+	md.AddParagraph("Confluent Cloud does not expose broker logs. The record that replaces them for compliance is the organization's audit log: authentication, authorization and management events, written to the `" + hcl.AuditLogTopic + "` topic of a Confluent-managed audit log cluster and kept for seven days. Forward it to the destinations above so retention and the tooling that reads them carry over. Keep the MSK broker log delivery on until the source cluster is decommissioned.")
+
+	md.AddHeading("Apply", 2)
+	md.AddList([]string{
+		"Run `confluent audit-log describe` and copy the environment, cluster, service account and bootstrap servers into `inputs.auto.tfvars` (`audit_log_environment_id`, `audit_log_cluster_id`, `audit_log_service_account_id`, `audit_log_bootstrap_endpoint`).",
+		"Use a Confluent Cloud API key of an OrganizationAdmin: only it can create keys for the audit log service account.",
+		"Run `terraform init` and `terraform apply`. The plan fails if a destination no longer exists.",
+	})
+
+	md.AddHeading("Forwarding", 2)
+	md.AddParagraph("Fully managed connectors cannot read the audit log cluster, so the forwarder runs in your AWS account. It reads the Secrets Manager secret in the `audit_log_credentials_secret_arn` output, which holds the API key as `username`/`password` together with `bootstrap_servers` and `topic` (SASL/PLAIN over TLS).")
+	var steps []string
+	if destinations.CloudWatchLogGroup != "" {
+		steps = append(steps, fmt.Sprintf("**CloudWatch Logs** (`%s`): a Lambda function with a self-managed Apache Kafka event source mapping on `%s` (`BASIC_AUTH` set to the secret) that writes each batch to the log group with `PutLogEvents`.", destinations.CloudWatchLogGroup, hcl.AuditLogTopic))
+	}
+	if destinations.FirehoseDeliveryStream != "" {
+		steps = append(steps, fmt.Sprintf("**Firehose** (`%s`): the same Lambda pattern, calling `PutRecordBatch` on the stream. Whatever the stream delivers to today keeps receiving events.", destinations.FirehoseDeliveryStream))
+	}
+	if destinations.S3Bucket != "" {
+		steps = append(steps, fmt.Sprintf("**S3** (`s3://%s/%s`): the Amazon S3 Sink connector on self-managed Kafka Connect, with `topics.dir` under the existing prefix so audit events sit next to the broker log archive.", destinations.S3Bucket, destinations.S3Prefix))
+	}
+	md.AddList(steps)
+
+	return md
+}
+
+func (ld *LogDeliveryAssetGenerator) writeTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
+	fileContents := []struct {
+		name    string
+		content string
+	}{
+		{"main.tf", files.MainTf},
+		{"providers.tf", files.ProvidersTf},
+		{"variables.tf", files.VariablesTf},
+		{"outputs.tf", files.OutputsTf},
+		{"inputs.auto.tfvars", files.InputsAutoTfvars},
+	}
+
+	for _, file := range fileContents {
+		if file.content == "" {
+			continue
+		}
+		if err := summary.WriteFile(filepath.Join(outputDir, file.name), []byte(file.content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		slog.Debug("wrote terraform file", "file", file.name)
+	}
+
+	return nil
+}
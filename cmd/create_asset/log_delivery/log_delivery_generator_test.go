@@ -0,0 +1,100 @@
+package log_delivery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClusterArn = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1"
+
+func newTestState(cluster kafkatypes.Cluster) *types.State {
+	return &types.State{
+		MSKSources: &types.MSKSourcesState{
+			Regions: []types.DiscoveredRegion{{
+				Name: "us-east-1",
+				Clusters: []types.DiscoveredCluster{{
+					Name:                 "orders",
+					Arn:                  testClusterArn,
+					Region:               "us-east-1",
+					AWSClientInformation: types.AWSClientInformation{MskClusterConfig: cluster},
+				}},
+			}},
+		},
+	}
+}
+
+func provisionedCluster(brokerLogs *kafkatypes.BrokerLogs) kafkatypes.Cluster {
+	return kafkatypes.Cluster{
+		ClusterType: kafkatypes.ClusterTypeProvisioned,
+		Provisioned: &kafkatypes.Provisioned{LoggingInfo: &kafkatypes.LoggingInfo{BrokerLogs: brokerLogs}},
+	}
+}
+
+func TestRun_WritesTerraformAndReadmePerDestination(t *testing.T) {
+	cluster := provisionedCluster(&kafkatypes.BrokerLogs{
+		CloudWatchLogs: &kafkatypes.CloudWatchLogs{Enabled: aws.Bool(true), LogGroup: aws.String("/msk/orders")},
+		Firehose:       &kafkatypes.Firehose{Enabled: aws.Bool(false), DeliveryStream: aws.String("stale")},
+		S3:             &kafkatypes.S3{Enabled: aws.Bool(true), Bucket: aws.String("orders-logs"), Prefix: aws.String("brokers/")},
+	})
+	outputDir := filepath.Join(t.TempDir(), "orders-log-delivery")
+
+	err := NewLogDeliveryAssetGenerator(LogDeliveryOpts{State: newTestState(cluster), ClusterArn: testClusterArn, OutputDir: outputDir}).Run()
+	require.NoError(t, err)
+
+	mainTf, err := os.ReadFile(filepath.Join(outputDir, "main.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainTf), `resource "confluent_api_key" "audit_log"`)
+	assert.Contains(t, string(mainTf), `data "aws_cloudwatch_log_group" "broker_logs"`)
+	assert.Contains(t, string(mainTf), `data "aws_s3_bucket" "broker_logs"`)
+	assert.NotContains(t, string(mainTf), "aws_kinesis_firehose_delivery_stream", "disabled destinations are not carried over")
+
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "confluent audit-log describe")
+	assert.Contains(t, string(readme), "**CloudWatch Logs** (`/msk/orders`)")
+	assert.Contains(t, string(readme), "**S3** (`s3://orders-logs/brokers/`)")
+	assert.NotContains(t, string(readme), "**Firehose**")
+}
+
+func TestRun_NoBrokerLogDelivery(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "orders-log-delivery")
+
+	err := NewLogDeliveryAssetGenerator(LogDeliveryOpts{State: newTestState(provisionedCluster(nil)), ClusterArn: testClusterArn, OutputDir: outputDir}).Run()
+	require.NoError(t, err)
+
+	_, err = os.Stat(outputDir)
+	assert.True(t, os.IsNotExist(err), "nothing is written when the cluster delivers no broker logs")
+}
+
+func TestBuildRequest_RejectsServerlessCluster(t *testing.T) {
+	state := newTestState(kafkatypes.Cluster{ClusterType: kafkatypes.ClusterTypeServerless})
+	cluster, err := state.GetClusterByArn(testClusterArn)
+	require.NoError(t, err)
+
+	_, err = buildRequest(cluster)
+	assert.ErrorContains(t, err, "not a provisioned MSK cluster")
+}
+
+func TestBuildRequest_FirehoseOnly(t *testing.T) {
+	state := newTestState(provisionedCluster(&kafkatypes.BrokerLogs{
+		Firehose: &kafkatypes.Firehose{Enabled: aws.Bool(true), DeliveryStream: aws.String("orders-broker-logs")},
+	}))
+	cluster, err := state.GetClusterByArn(testClusterArn)
+	require.NoError(t, err)
+
+	request, err := buildRequest(cluster)
+	require.NoError(t, err)
+	assert.Equal(t, hclrequests.LogDeliveryRequest{
+		Region:            "us-east-1",
+		SourceClusterName: "orders",
+		Destinations:      hclrequests.BrokerLogDelivery{FirehoseDeliveryStream: "orders-broker-logs"},
+	}, request)
+}
@@ -22,13 +22,14 @@ var (
 	preventDestroy            bool
 	targetClusterId           string
 	targetClusterRestEndpoint string
+	topicNaming               utils.TopicNamingFlags
 )
 
 func NewMigrateIamAclsCmd() *cobra.Command {
 	aclsCmd := &cobra.Command{
 		Use:   "iam",
 		Short: "Convert IAM ACLs to Confluent Cloud IAM ACLs.",
-		Long:  "Convert IAM ACLs from IAM roles or users to Confluent Cloud IAM ACLs as individual Terraform resources.",
+		Long:  "Convert IAM ACLs from IAM roles or users to Confluent Cloud IAM ACLs as individual Terraform resources. When topics are renamed on Confluent Cloud, pass the same topic naming flags as to migrate-topics: LITERAL topic ACLs take the destination name and PREFIXED topic ACLs take --topic-prefix.",
 		Example: `  # From an IAM role
   kcp create-asset migrate-acls iam \
       --role-arn arn:aws:iam::123456789012:role/MyKafkaRole \
@@ -84,11 +85,15 @@ func NewMigrateIamAclsCmd() *cobra.Command {
 	aclsCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
+	topicNamingFlagSet := topicNaming.FlagSet()
+	aclsCmd.Flags().AddFlagSet(topicNamingFlagSet)
+	groups[topicNamingFlagSet] = "Topic Naming Flags"
+
 	aclsCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Short)
 
-		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
-		groupNames := []string{"Required Flags", "Optional Flags"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, topicNamingFlagSet}
+		groupNames := []string{"Required Flags", "Optional Flags", "Topic Naming Flags"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -120,6 +125,10 @@ func preRunMigrateIamAcls(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if _, err := topicNaming.Mapping(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -162,6 +171,11 @@ func parseMigrateIamAclsOpts() (*MigrateIamAclsOpts, error) {
 		principalArns = principals
 	}
 
+	naming, err := topicNaming.Mapping()
+	if err != nil {
+		return nil, err
+	}
+
 	opts := MigrateIamAclsOpts{
 		PrincipalArns:             principalArns,
 		TargetClusterId:           targetClusterId,
@@ -169,6 +183,7 @@ func parseMigrateIamAclsOpts() (*MigrateIamAclsOpts, error) {
 		OutputDir:                 outputDir,
 		SkipAuditReport:           skipAuditReport,
 		PreventDestroy:            preventDestroy,
+		TopicNaming:               naming,
 	}
 
 	return &opts, nil
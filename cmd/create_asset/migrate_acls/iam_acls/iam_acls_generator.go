@@ -14,6 +14,7 @@ import (
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	iamservice "github.com/confluentinc/kcp/internal/services/iam"
 	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 )
@@ -25,6 +26,9 @@ type MigrateIamAclsOpts struct {
 	OutputDir                 string
 	SkipAuditReport           bool
 	PreventDestroy            bool
+	// TopicNaming renames the topic resources of the ACLs to match the
+	// topics created by migrate-topics.
+	TopicNaming topicnaming.Mapping
 }
 
 type IamAclsGenerator struct {
@@ -65,7 +69,7 @@ func (ig *IamAclsGenerator) Run() error {
 			continue
 		}
 
-		for _, acl := range extractedACLs {
+		for _, acl := range ig.opts.TopicNaming.RenameAcls(extractedACLs) {
 			principal := acl.Principal
 			allAclsByPrincipal[principal] = append(allAclsByPrincipal[principal], acl)
 		}
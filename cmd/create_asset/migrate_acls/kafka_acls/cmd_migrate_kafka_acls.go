@@ -20,19 +20,49 @@ var (
 	outputDir                 string
 	skipAuditReport           bool
 	preventDestroy            bool
+	targetEnvironmentId       string
+	generateApiKeys           bool
+	generateRoleBindings      bool
+	topicNaming               utils.TopicNamingFlags
 )
 
 func NewConvertKafkaAclsCmd() *cobra.Command {
 	aclsCmd := &cobra.Command{
 		Use:   "kafka",
 		Short: "Convert Kafka ACLs to Confluent Cloud ACLs.",
-		Long:  "Convert Kafka ACLs to Confluent Cloud ACLs as individual Terraform resources.",
+		Long: `Convert Kafka ACLs to Confluent Cloud ACLs as individual Terraform resources.
+
+Each principal found in the ACLs gets a Confluent Cloud service account. To give migrating applications a ready-made identity on the target:
+
+- ` + "`--generate-api-keys`" + ` adds a Kafka API key owned by each service account. The key and secret are exposed as Terraform outputs.
+- ` + "`--generate-role-bindings`" + ` adds RBAC role bindings derived from each principal's ALLOW ACLs: Read maps to DeveloperRead, Write to DeveloperWrite and All to ResourceOwner on the matching topic, consumer group or transactional ID. Other ACLs are only migrated as ACLs.
+
+Both need ` + "`--target-environment-id`" + `.
+
+When topics are renamed on Confluent Cloud, pass the same topic naming flags as to ` + "`migrate-topics`" + `: LITERAL topic ACLs take the destination name and PREFIXED topic ACLs take ` + "`--topic-prefix`" + `.`,
 		Example: `  kcp create-asset migrate-acls kafka \
       --state-file kcp-state.json \
       --source-type msk \
       --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
       --target-cluster-id lkc-xyz123 \
-      --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.confluent.cloud:443`,
+      --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.confluent.cloud:443
+
+  # Also create an API key and role bindings for every migrated principal
+  kcp create-asset migrate-acls kafka \
+      --state-file kcp-state.json \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-cluster-id lkc-xyz123 \
+      --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.confluent.cloud:443 \
+      --target-environment-id env-abc123 \
+      --generate-api-keys --generate-role-bindings
+
+  # With the topic renames used for migrate-topics
+  kcp create-asset migrate-acls kafka \
+      --state-file kcp-state.json \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-cluster-id lkc-xyz123 \
+      --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.confluent.cloud:443 \
+      --topic-prefix msk. --topic-rename-file renames.csv`,
 		SilenceErrors: true,
 		PreRunE:       preRunConvertKafkaAcls,
 		RunE:          runConvertKafkaAcls,
@@ -60,14 +90,21 @@ func NewConvertKafkaAclsCmd() *cobra.Command {
 	optionalFlags.StringVar(&outputDir, "output-dir", "", "The directory where the Confluent Cloud Terraform ACL assets will be written to")
 	optionalFlags.BoolVar(&skipAuditReport, "skip-audit-report", false, "Skip generating an audit report of the converted ACLs")
 	optionalFlags.BoolVar(&preventDestroy, "prevent-destroy", true, "Whether to set lifecycle { prevent_destroy = true } on generated Terraform resources")
+	optionalFlags.StringVar(&targetEnvironmentId, "target-environment-id", "", "The Confluent Cloud environment ID of the target cluster (e.g., env-xxxxxx). Required with --generate-api-keys or --generate-role-bindings.")
+	optionalFlags.BoolVar(&generateApiKeys, "generate-api-keys", false, "Generate a Kafka API key for each migrated principal's service account")
+	optionalFlags.BoolVar(&generateRoleBindings, "generate-role-bindings", false, "Generate RBAC role bindings derived from each migrated principal's ALLOW ACLs")
 	aclsCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
+	topicNamingFlagSet := topicNaming.FlagSet()
+	aclsCmd.Flags().AddFlagSet(topicNamingFlagSet)
+	groups[topicNamingFlagSet] = "Topic Naming Flags"
+
 	aclsCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Short)
 
-		flagOrder := []*pflag.FlagSet{requiredFlags, sourceFlags, optionalFlags}
-		groupNames := []string{"Required Flags", "Source Flags", "Optional Flags"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, sourceFlags, optionalFlags, topicNamingFlagSet}
+		groupNames := []string{"Required Flags", "Source Flags", "Optional Flags", "Topic Naming Flags"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -94,6 +131,14 @@ func preRunConvertKafkaAcls(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if (generateApiKeys || generateRoleBindings) && targetEnvironmentId == "" {
+		return fmt.Errorf("--target-environment-id is required with --generate-api-keys or --generate-role-bindings")
+	}
+
+	if _, err := topicNaming.Mapping(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -151,6 +196,11 @@ func parseMigrateKafkaAclsOpts() (*MigrateKafkaAclsOpts, error) {
 		return nil, fmt.Errorf("invalid --source-type: %s (must be 'msk' or 'apache-kafka')", sourceType)
 	}
 
+	naming, err := topicNaming.Mapping()
+	if err != nil {
+		return nil, err
+	}
+
 	if len(kafkaAdminInfo.Acls) == 0 {
 		return nil, fmt.Errorf("cluster %s has no ACLs within the state file: %s", clusterName, stateFile)
 	}
@@ -163,6 +213,10 @@ func parseMigrateKafkaAclsOpts() (*MigrateKafkaAclsOpts, error) {
 		OutputDir:                 outputDir,
 		SkipAuditReport:           skipAuditReport,
 		PreventDestroy:            preventDestroy,
+		TargetEnvironmentId:       targetEnvironmentId,
+		GenerateApiKeys:           generateApiKeys,
+		GenerateRoleBindings:      generateRoleBindings,
+		TopicNaming:               naming,
 	}
 
 	return &opts, nil
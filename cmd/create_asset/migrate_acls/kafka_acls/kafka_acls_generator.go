@@ -10,6 +10,7 @@ import (
 	"github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 )
@@ -22,6 +23,12 @@ type MigrateKafkaAclsOpts struct {
 	OutputDir                 string
 	SkipAuditReport           bool
 	PreventDestroy            bool
+	TargetEnvironmentId       string
+	GenerateApiKeys           bool
+	GenerateRoleBindings      bool
+	// TopicNaming renames the topic resources of the ACLs to match the
+	// topics created by migrate-topics.
+	TopicNaming topicnaming.Mapping
 }
 
 type KafkaAclsGenerator struct {
@@ -50,7 +57,7 @@ func (kg *KafkaAclsGenerator) Run() error {
 	}
 
 	aclsByPrincipal := make(map[string][]types.Acls)
-	for _, acl := range kg.opts.KafkaAcls {
+	for _, acl := range kg.opts.TopicNaming.RenameAcls(kg.opts.KafkaAcls) {
 		principal := utils.CleanPrincipalName(acl.Principal)
 		aclsByPrincipal[principal] = append(aclsByPrincipal[principal], acl)
 	}
@@ -65,6 +72,9 @@ func (kg *KafkaAclsGenerator) Run() error {
 		TargetClusterId:           kg.opts.TargetClusterId,
 		TargetClusterRestEndpoint: kg.opts.TargetClusterRestEndpoint,
 		PreventDestroy:            kg.opts.PreventDestroy,
+		GenerateApiKeys:           kg.opts.GenerateApiKeys,
+		GenerateRoleBindings:      kg.opts.GenerateRoleBindings,
+		TargetEnvironmentId:       kg.opts.TargetEnvironmentId,
 		AclsByPrincipal:           aclsByPrincipal,
 	}
 
@@ -92,6 +102,9 @@ func (kg *KafkaAclsGenerator) Run() error {
 	}
 
 	fmt.Printf("✅ Kafka ACLs Terraform files generated: %s (%d principals, %d ACLs)\n", outputDir, len(aclsByPrincipal), totalAcls)
+	if kg.opts.GenerateApiKeys {
+		fmt.Printf("   API keys are exposed as Terraform outputs; read a secret with: terraform output -raw <principal>_api_key_secret\n")
+	}
 
 	return nil
 }
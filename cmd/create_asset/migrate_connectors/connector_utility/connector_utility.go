@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/fatih/color"
@@ -79,6 +80,7 @@ func (cu *ConnectorUtility) Run() error {
 		if err := file.Close(); err != nil {
 			return fmt.Errorf("failed to close file %s: %w", filepath, err)
 		}
+		summary.FileWritten(filepath)
 
 		totalConnectors += len(connectorConfigs.Connectors)
 		fmt.Printf("  ✅ Generated: %s (%d connector(s))\n", filename, len(connectorConfigs.Connectors))
@@ -20,20 +20,31 @@ var (
 	ccApiKey        string
 	ccApiSecret     string
 	outputDir       string
+
+	localTranslation bool
 )
 
 func NewMigrateMskConnectorsCmd() *cobra.Command {
 	mskConnectorsCmd := &cobra.Command{
 		Use:   "msk",
 		Short: "Migrate MSK Connect connectors to Confluent Cloud",
-		Long:  "Generate Terraform configuration that recreates MSK Connect connectors as Confluent Cloud fully-managed connectors. Uses the Confluent translate/config API to convert connector configs.",
+		Long: "Generate Terraform configuration that recreates MSK Connect connectors as Confluent Cloud fully-managed connectors. Uses the Confluent translate/config API to convert connector configs, or kcp's built-in mapping table (S3 sink, Debezium CDC, JDBC) with `--local-translation`.\n\n" +
+			"**Output:** one `<connector>-connector.tf` per connector plus a `connector-compatibility-report.md` grading each connector by the translation that ran, listing the properties needing review and the connectors whose translation failed.",
 		Example: `  kcp create-asset migrate-connectors msk \
       --state-file kcp-state.json \
       --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
       --cc-environment-id env-a1bcde \
       --cc-cluster-id lkc-xyz123 \
       --cc-api-key ABCDEFGHIJKLMNOP \
-      --cc-api-secret xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx`,
+      --cc-api-secret xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+
+  # Translate offline with the built-in mapping table (no API credentials needed)
+  kcp create-asset migrate-connectors msk \
+      --state-file kcp-state.json \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --cc-environment-id env-a1bcde \
+      --cc-cluster-id lkc-xyz123 \
+      --local-translation`,
 		Annotations: map[string]string{
 			iampolicy.AnnotationKey: iampolicy.RenderSingle("", []string{
 				"kafkaconnect:ListConnectors",
@@ -55,14 +66,16 @@ func NewMigrateMskConnectorsCmd() *cobra.Command {
 	requiredFlags.StringVar(&clusterId, "cluster-id", "", "The ARN of the MSK cluster.")
 	requiredFlags.StringVar(&ccEnvironmentId, "cc-environment-id", "", "The ID of the Confluent Cloud environment to migrate connectors to.")
 	requiredFlags.StringVar(&ccClusterId, "cc-cluster-id", "", "The ID of the Confluent Cloud cluster to migrate connectors to.")
-	requiredFlags.StringVar(&ccApiKey, "cc-api-key", "", "The API key for the Confluent Cloud cluster to migrate connectors to.")
-	requiredFlags.StringVar(&ccApiSecret, "cc-api-secret", "", "The API secret for the Confluent Cloud cluster to migrate connectors to.")
+	requiredFlags.StringVar(&ccApiKey, "cc-api-key", "", "The API key for the Confluent Cloud cluster to migrate connectors to. Not required with --local-translation.")
+	requiredFlags.StringVar(&ccApiSecret, "cc-api-secret", "", "The API secret for the Confluent Cloud cluster to migrate connectors to. Not required with --local-translation.")
+	utils.AddSecretStdinFlag(requiredFlags, "cc-api-secret")
 	mskConnectorsCmd.Flags().AddFlagSet(requiredFlags)
 	groups[requiredFlags] = "Required Flags"
 
 	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
 	optionalFlags.SortFlags = false
 	optionalFlags.StringVar(&outputDir, "output-dir", "", "The directory where the Confluent Cloud Terraform connector assets will be written to")
+	optionalFlags.BoolVar(&localTranslation, "local-translation", false, "Translate connector configs with kcp's built-in mapping table instead of the Confluent Cloud translate/config API.")
 	mskConnectorsCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
@@ -88,8 +101,6 @@ func NewMigrateMskConnectorsCmd() *cobra.Command {
 	_ = mskConnectorsCmd.MarkFlagRequired("cluster-id")
 	_ = mskConnectorsCmd.MarkFlagRequired("cc-environment-id")
 	_ = mskConnectorsCmd.MarkFlagRequired("cc-cluster-id")
-	_ = mskConnectorsCmd.MarkFlagRequired("cc-api-key")
-	_ = mskConnectorsCmd.MarkFlagRequired("cc-api-secret")
 
 	return mskConnectorsCmd
 }
@@ -99,6 +110,11 @@ func preRunMigrateMskConnectors(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// The API credentials are only needed when translating through the API.
+	if !localTranslation && (ccApiKey == "" || ccApiSecret == "") {
+		return fmt.Errorf("--cc-api-key and --cc-api-secret are required unless --local-translation is set")
+	}
+
 	return nil
 }
 
@@ -148,6 +164,8 @@ func parseMigrateMskConnectorsOpts() (*MigrateMskConnectorOpts, error) {
 		CcApiSecret:   ccApiSecret,
 		Connectors:    connectors,
 		OutputDir:     outputDir,
+
+		LocalTranslation: localTranslation,
 	}
 
 	return &opts, nil
@@ -14,8 +14,13 @@ import (
 	"strings"
 	"text/template"
 
+	kafkaconnecttypes "github.com/aws/aws-sdk-go-v2/service/kafkaconnect/types"
+	"github.com/confluentinc/kcp/internal/audit"
 	"github.com/confluentinc/kcp/internal/redact"
+	"github.com/confluentinc/kcp/internal/services/connector_mapping"
 	"github.com/confluentinc/kcp/internal/services/hcl"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	connector_utils "github.com/confluentinc/kcp/internal/utils"
 )
@@ -28,14 +33,28 @@ var assetsFs embed.FS
 // so tests can point translation at a local stub.
 const defaultTranslateBaseURL = "https://api.confluent.cloud"
 
+// compatibilityReportFilename is written alongside the generated connector
+// Terraform in every run, whichever translation path produced it.
+const compatibilityReportFilename = "connector-compatibility-report.md"
+
 type TemplateData struct {
 	ConnectorName   string
 	EnvironmentId   string
 	ClusterId       string
 	ConnectorConfig map[string]interface{}
+	// SensitiveConfig holds the keys redact.IsSensitive matches, rendered
+	// under config_sensitive so their values stay out of plan output.
+	SensitiveConfig map[string]interface{}
 	Warnings        []Warning
+	// WarningSource names what produced Warnings in the file header.
+	WarningSource string
 }
 
+const (
+	warningSourceTranslateAPI = "the connector config translation endpoint"
+	warningSourceLocalMapping = "the local connector mapping (--local-translation)"
+)
+
 type TranslateResponse struct {
 	Config   map[string]interface{} `json:"config"`
 	Warnings []Warning              `json:"warnings"`
@@ -55,6 +74,10 @@ type MigrateMskConnectorOpts struct {
 
 	Connectors []types.ConnectorSummary
 	OutputDir  string
+
+	// LocalTranslation translates configs with kcp's built-in mapping table
+	// instead of the Confluent Cloud translate/config API.
+	LocalTranslation bool
 }
 
 type MskConnectorMigrator struct {
@@ -67,6 +90,8 @@ type MskConnectorMigrator struct {
 	Connectors []types.ConnectorSummary
 	OutputDir  string
 
+	LocalTranslation bool
+
 	// baseURL is the host the translate endpoint is called under; defaults to
 	// defaultTranslateBaseURL and is overridable in tests.
 	baseURL string
@@ -80,7 +105,9 @@ func NewMskConnectorMigrator(opts MigrateMskConnectorOpts) *MskConnectorMigrator
 		CcApiSecret:   opts.CcApiSecret,
 		Connectors:    opts.Connectors,
 		OutputDir:     opts.OutputDir,
-		baseURL:       defaultTranslateBaseURL,
+
+		LocalTranslation: opts.LocalTranslation,
+		baseURL:          defaultTranslateBaseURL,
 	}
 }
 
@@ -122,6 +149,18 @@ func (mc *MskConnectorMigrator) Run() error {
 		fmt.Printf("⚠️  %d of %d connector(s) contain redacted sensitive fields (%s) — replace with real values in the generated Terraform before applying\n", redacted, len(mc.Connectors), redact.Placeholder)
 	}
 
+	// A connector that is FAILED on MSK fails the same way once recreated.
+	for _, connector := range mc.Connectors {
+		if connector.ConnectorState != string(kafkaconnecttypes.ConnectorStateFailed) {
+			continue
+		}
+		reason := "re-run `kcp discover` for diagnostics"
+		if d := connector.Diagnostics; d != nil && d.StateMessage != "" {
+			reason = d.StateMessage
+		}
+		fmt.Printf("⚠️  Connector %s is FAILED on MSK (%s) — fix the root cause before applying its generated Terraform\n", connector.ConnectorName, reason)
+	}
+
 	// Write shared Terraform infrastructure files (providers.tf, variables.tf)
 	if err := hcl.WriteMigrateConnectorsInfraFiles(mc.OutputDir); err != nil {
 		return err
@@ -139,8 +178,27 @@ func (mc *MskConnectorMigrator) Run() error {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
+	compatibility := make([]connector_mapping.Result, 0, len(mc.Connectors))
+	generated := 0
 	for _, connector := range mc.Connectors {
-		translatedConfig, warnings, err := mc.translateConnectorConfig(connector)
+		var (
+			result           connector_mapping.Result
+			translatedConfig map[string]any
+			warnings         []Warning
+			err              error
+		)
+		if mc.LocalTranslation {
+			result = connector_mapping.Translate(connector.ConnectorName, connector.ConnectorConfiguration)
+			translatedConfig, warnings, err = fromLocalMapping(result)
+		} else {
+			translatedConfig, warnings, err = mc.translateConnectorConfig(connector)
+			result = fromTranslateResponse(connector, translatedConfig, warnings)
+		}
+		if err != nil && result.Compatibility != connector_mapping.CompatibilityNone {
+			result.Compatibility = connector_mapping.CompatibilityFailed
+			result.Error = err.Error()
+		}
+		compatibility = append(compatibility, result)
 		if err != nil {
 			slog.Warn(fmt.Sprintf("failed to translate connector %s: %v", connector.ConnectorName, err))
 			continue
@@ -158,12 +216,19 @@ func (mc *MskConnectorMigrator) Run() error {
 		filename := fmt.Sprintf("%s-connector.tf", connector_utils.SanitizeConnectorFilename(connector.ConnectorName))
 		path := filepath.Join(mc.OutputDir, filename)
 
+		nonsensitiveConfig, sensitiveConfig := splitSensitiveConfig(translatedConfig)
+		warningSource := warningSourceTranslateAPI
+		if mc.LocalTranslation {
+			warningSource = warningSourceLocalMapping
+		}
 		templateData := TemplateData{
 			ConnectorName:   connector.ConnectorName,
 			EnvironmentId:   mc.EnvironmentId,
 			ClusterId:       mc.ClusterId,
-			ConnectorConfig: translatedConfig,
+			ConnectorConfig: nonsensitiveConfig,
+			SensitiveConfig: sensitiveConfig,
 			Warnings:        warnings,
+			WarningSource:   warningSource,
 		}
 
 		if err := writeConnectorFile(tmpl, path, templateData); err != nil {
@@ -171,13 +236,78 @@ func (mc *MskConnectorMigrator) Run() error {
 		}
 
 		slog.Debug(fmt.Sprintf("generated: %s", filename))
+		generated++
+	}
+
+	reportPath := filepath.Join(mc.OutputDir, compatibilityReportFilename)
+	if err := connector_mapping.CompatibilityReport(compatibility).Print(markdown.PrintOptions{ToTerminal: false, ToFile: reportPath}); err != nil {
+		return fmt.Errorf("failed to write connector compatibility report: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully generated connector files for %d connectors in %s\n", len(mc.Connectors), mc.OutputDir)
+	fmt.Printf("✅ Successfully generated connector files for %d of %d connectors in %s\n", generated, len(mc.Connectors), mc.OutputDir)
 
 	return nil
 }
 
+// splitSensitiveConfig separates the keys redact.IsSensitive matches, such as
+// database.password, from the rest of a translated config. Operators replace
+// their redacted placeholders with real values, which must then never be
+// printed by terraform plan.
+func splitSensitiveConfig(config map[string]any) (nonsensitive, sensitive map[string]any) {
+	nonsensitive = make(map[string]any, len(config))
+	sensitive = make(map[string]any)
+	for k, v := range config {
+		if redact.IsSensitive(k) {
+			sensitive[k] = v
+			continue
+		}
+		nonsensitive[k] = v
+	}
+	return nonsensitive, sensitive
+}
+
+// fromLocalMapping adapts a local mapping result to the shape the connector
+// template expects: unmapped properties surface as template warnings, exactly
+// like the translate API's warnings do.
+func fromLocalMapping(result connector_mapping.Result) (map[string]any, []Warning, error) {
+	if result.Compatibility == connector_mapping.CompatibilityNone {
+		return nil, nil, fmt.Errorf("no fully-managed equivalent for connector class %q", result.ConnectorClass)
+	}
+
+	config := make(map[string]any, len(result.Config))
+	for k, v := range result.Config {
+		config[k] = v
+	}
+
+	var warnings []Warning
+	for _, u := range result.Unmapped {
+		warnings = append(warnings, Warning{Field: u.Key, Message: u.Reason})
+	}
+
+	return config, warnings, nil
+}
+
+// fromTranslateResponse grades a connector the translate API handled: the
+// API's warnings are the properties that need manual review. On a failed
+// call config is nil and the caller marks the result failed.
+func fromTranslateResponse(connector types.ConnectorSummary, config map[string]any, warnings []Warning) connector_mapping.Result {
+	result := connector_mapping.Result{
+		ConnectorName:  connector.ConnectorName,
+		ConnectorClass: connector.ConnectorConfiguration["connector.class"],
+		Compatibility:  connector_mapping.CompatibilityFull,
+	}
+	if plugin, ok := config["connector.class"].(string); ok {
+		result.PluginName = plugin
+	}
+	for _, w := range warnings {
+		result.Unmapped = append(result.Unmapped, connector_mapping.UnmappedProperty{Key: w.Field, Reason: w.Message})
+	}
+	if len(result.Unmapped) > 0 {
+		result.Compatibility = connector_mapping.CompatibilityPartial
+	}
+	return result
+}
+
 // writeConnectorFile renders templateData into a single connector .tf file at
 // path. It is a standalone function (not inlined in the Run loop) so the file
 // handle is closed when this returns — once per connector — rather than via a
@@ -199,6 +329,7 @@ func writeConnectorFile(tmpl *template.Template, path string, templateData Templ
 		return fmt.Errorf("failed to execute template for connector %s: %w", templateData.ConnectorName, err)
 	}
 
+	summary.FileWritten(path)
 	return nil
 }
 
@@ -240,7 +371,7 @@ func (mc *MskConnectorMigrator) translateConnectorConfig(connector types.Connect
 	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", mc.CcApiKey, mc.CcApiSecret)))
 	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", auth))
 
-	client := &http.Client{}
+	client := &http.Client{Transport: audit.Transport(audit.SystemConfluentCloud, nil)}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to execute HTTP request: %w", err)
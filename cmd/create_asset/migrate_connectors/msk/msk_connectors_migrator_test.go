@@ -106,6 +106,32 @@ func TestMskConnectorMigrator_Run_GeneratedTerraformIsLeakFree(t *testing.T) {
 	// (fail-closed) rather than as a working credential.
 	assert.Contains(t, string(tf), fmt.Sprintf("%q = %q", "database.password", redact.Placeholder),
 		"sensitive field must render as the redaction placeholder in the generated Terraform")
+
+	// Once the operator fills it in, the value must not show in plan output, so
+	// it belongs under config_sensitive rather than config_nonsensitive.
+	sensitiveBlock, nonsensitiveBlock, found := strings.Cut(string(tf), "config_nonsensitive")
+	require.True(t, found)
+	assert.Contains(t, sensitiveBlock, `"database.password"`)
+	assert.NotContains(t, nonsensitiveBlock, `"database.password"`)
+	assert.Contains(t, nonsensitiveBlock, `"tasks.max" = "3"`)
+}
+
+func TestSplitSensitiveConfig(t *testing.T) {
+	nonsensitive, sensitive := splitSensitiveConfig(map[string]any{
+		"connection.password": "<kcp-redacted>",
+		"database.password":   "<kcp-redacted>",
+		"topics":              "orders",
+		"tasks.max":           "3",
+	})
+
+	assert.Equal(t, map[string]any{
+		"connection.password": "<kcp-redacted>",
+		"database.password":   "<kcp-redacted>",
+	}, sensitive)
+	assert.Equal(t, map[string]any{
+		"topics":    "orders",
+		"tasks.max": "3",
+	}, nonsensitive)
 }
 
 // Path traversal: a connector name carrying "../" (attacker-controllable via a
@@ -655,3 +681,76 @@ func TestMskConnectorMigrator_MultipleConnectors(t *testing.T) {
 		fmt.Printf("Expected error in test environment: %v\n", err)
 	}
 }
+
+// In the default API mode the compatibility report grades what the translate
+// API returned, and a connector whose translation failed is reported as
+// failed rather than graded by the local mapping table.
+func TestMskConnectorMigrator_Run_CompatibilityReportFollowsTranslateAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/connector-plugins/S3_SINK/") {
+			http.Error(w, "plugin unavailable", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TranslateResponse{
+			Config:   map[string]any{"connector.class": "DatagenSource"},
+			Warnings: []Warning{{Field: "quickstart", Message: "review the quickstart"}},
+		})
+	}))
+	defer server.Close()
+
+	outDir := filepath.Join(t.TempDir(), "out")
+	migrator := NewMskConnectorMigrator(MigrateMskConnectorOpts{
+		EnvironmentId: "env-123",
+		ClusterId:     "lkc-123",
+		Connectors: []types.ConnectorSummary{
+			{ConnectorName: "datagen", ConnectorConfiguration: map[string]string{"connector.class": "io.confluent.kafka.connect.datagen.DatagenConnector"}},
+			{ConnectorName: "s3", ConnectorConfiguration: map[string]string{"connector.class": "io.confluent.connect.s3.S3SinkConnector"}},
+		},
+		OutputDir: outDir,
+	})
+	migrator.baseURL = server.URL
+
+	require.NoError(t, migrator.Run())
+
+	report, err := os.ReadFile(filepath.Join(outDir, compatibilityReportFilename))
+	require.NoError(t, err)
+	assert.Contains(t, string(report), "| datagen | io.confluent.kafka.connect.datagen.DatagenConnector | DatagenSource | partial |")
+	assert.Contains(t, string(report), "| s3 | io.confluent.connect.s3.S3SinkConnector | - | failed |")
+	assert.Contains(t, string(report), "Translation failed: api request failed with status 400")
+	assert.NotContains(t, string(report), "| none |")
+	assert.NoFileExists(t, filepath.Join(outDir, "s3-connector.tf"))
+}
+
+func TestMskConnectorMigrator_Run_LocalTranslation(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "out")
+	migrator := NewMskConnectorMigrator(MigrateMskConnectorOpts{
+		EnvironmentId:    "env-123",
+		ClusterId:        "lkc-123",
+		LocalTranslation: true,
+		Connectors: []types.ConnectorSummary{
+			{
+				ConnectorName: "pg-cdc",
+				ConnectorConfiguration: map[string]string{
+					"connector.class":              "io.debezium.connector.postgresql.PostgresConnector",
+					"database.hostname":            "db.internal",
+					"database.password":            redact.Placeholder,
+					"database.history.kafka.topic": "history",
+				},
+			},
+		},
+		OutputDir: outDir,
+	})
+
+	require.NoError(t, migrator.Run())
+
+	tf, err := os.ReadFile(filepath.Join(outDir, "pg-cdc-connector.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(tf), "warnings were returned by "+warningSourceLocalMapping)
+	assert.NotContains(t, string(tf), warningSourceTranslateAPI)
+
+	sensitiveBlock, nonsensitiveBlock, found := strings.Cut(string(tf), "config_nonsensitive")
+	require.True(t, found)
+	assert.Contains(t, sensitiveBlock, fmt.Sprintf("%q = %q", "database.password", redact.Placeholder))
+	assert.NotContains(t, nonsensitiveBlock, "database.password")
+}
@@ -14,8 +14,10 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/confluentinc/kcp/internal/audit"
 	"github.com/confluentinc/kcp/internal/redact"
 	"github.com/confluentinc/kcp/internal/services/hcl"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	connector_utils "github.com/confluentinc/kcp/internal/utils"
 )
@@ -199,6 +201,7 @@ func writeConnectorFile(tmpl *template.Template, path string, templateData Templ
 		return fmt.Errorf("failed to execute template for connector %s: %w", templateData.ConnectorName, err)
 	}
 
+	summary.FileWritten(path)
 	return nil
 }
 
@@ -250,7 +253,7 @@ func (mc *SelfManagedConnectorMigrator) translateConnectorConfig(connector types
 	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", mc.CcApiKey, mc.CcApiSecret)))
 	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", auth))
 
-	client := &http.Client{}
+	client := &http.Client{Transport: audit.Transport(audit.SystemConfluentCloud, nil)}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to execute HTTP request: %w", err)
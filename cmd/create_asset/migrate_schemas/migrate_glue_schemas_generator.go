@@ -11,6 +11,7 @@ import (
 
 	hclservice "github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 )
 
@@ -72,7 +73,7 @@ func (g *MigrateGlueSchemasAssetGenerator) Run() error {
 				continue
 			}
 			path := filepath.Join(g.outputDir, name)
-			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			if err := summary.WriteFile(path, []byte(content), 0644); err != nil {
 				return fmt.Errorf("failed to write %s: %w", path, err)
 			}
 		}
@@ -94,7 +95,7 @@ func (g *MigrateGlueSchemasAssetGenerator) Run() error {
 			if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
 				return fmt.Errorf("failed to create directory for %s: %w", fullPath, err)
 			}
-			if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			if err := summary.WriteFile(fullPath, []byte(content), 0644); err != nil {
 				return fmt.Errorf("failed to write %s: %w", fullPath, err)
 			}
 		}
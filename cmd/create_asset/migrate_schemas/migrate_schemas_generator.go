@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 )
@@ -86,13 +87,10 @@ func (ms *MigrateSchemasAssetGenerator) copyFiles(sourceDir, destDir string) err
 			return nil
 		}
 
-		// Calculate relative path from source directory
-		relPath, err := filepath.Rel(sourceDir, path)
-		if err != nil {
-			return fmt.Errorf("failed to get relative path: %w", err)
-		}
-
-		destPath := filepath.Join(destDir, relPath)
+		// Embedded paths always use forward slashes, whatever the OS; convert
+		// only the destination to the platform separator.
+		relPath := strings.TrimPrefix(path, sourceDir+"/")
+		destPath := filepath.Join(destDir, filepath.FromSlash(relPath))
 
 		if d.IsDir() {
 			return os.MkdirAll(destPath, 0755)
@@ -104,7 +102,7 @@ func (ms *MigrateSchemasAssetGenerator) copyFiles(sourceDir, destDir string) err
 			return fmt.Errorf("failed to read embedded file %s: %w", path, err)
 		}
 
-		if err := os.WriteFile(destPath, content, 0644); err != nil {
+		if err := summary.WriteFile(destPath, content, 0644); err != nil {
 			return fmt.Errorf("failed to write file %s: %w", destPath, err)
 		}
 
@@ -155,7 +153,7 @@ func (ms *MigrateSchemasAssetGenerator) generateInputsTfvars(terraformDir string
 
 	// Write the generated content to inputs.auto.tfvars
 	tfvarsPath := filepath.Join(terraformDir, "inputs.auto.tfvars")
-	if err := os.WriteFile(tfvarsPath, []byte(buf.String()), 0644); err != nil {
+	if err := summary.WriteFile(tfvarsPath, []byte(buf.String()), 0644); err != nil {
 		return fmt.Errorf("failed to write tfvars file: %w", err)
 	}
 
@@ -0,0 +1,32 @@
+package migrate_schemas
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrateSchemasAssetGenerator_RunsFromAnyDirectory guards against assets
+// being read relative to the source tree: CI containers run kcp from a
+// working directory unrelated to where the binary is installed.
+func TestMigrateSchemasAssetGenerator_RunsFromAnyDirectory(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	generator := NewMigrateSchemasAssetGenerator(MigrateSchemasOpts{
+		SchemaRegistry:   types.SchemaRegistryInformation{URL: "https://sr.example.com"},
+		CCSRRestEndpoint: "https://psrc-abc123.us-east-1.aws.confluent.cloud",
+		Exporters:        []SchemaExporter{{Name: "exporter-1", ContextType: "NONE", Subjects: []string{"orders-value"}}},
+	})
+	require.NoError(t, generator.Run())
+
+	for _, name := range []string{"main.tf", "providers.tf", "variables.tf", "inputs.auto.tfvars"} {
+		_, err := os.Stat(filepath.Join("migrate_schemas", name))
+		assert.NoError(t, err, name)
+	}
+	_, err := os.Stat(filepath.Join("migrate_schemas", "inputs.auto.tfvars.go.tmpl"))
+	assert.True(t, os.IsNotExist(err), "templates must not be copied")
+}
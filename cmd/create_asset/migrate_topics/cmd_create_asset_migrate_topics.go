@@ -1,6 +1,7 @@
 package migrate_topics
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/targetcheck"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -26,13 +29,23 @@ var (
 	mode                      string
 	topicsInclude             []string
 	topicsExclude             []string
+	format                    string
+	topicNaming               utils.TopicNamingFlags
+	clusterApiKey             string
+	clusterApiSecret          string
+)
+
+// Output formats for --format.
+const (
+	formatTerraform = "terraform"
+	formatYAML      = "yaml"
 )
 
 func NewMigrateTopicsCmd() *cobra.Command {
 	migrationCmd := &cobra.Command{
 		Use:   "migrate-topics",
 		Short: "Create assets for the migrate topics",
-		Long:  "Create Terraform files for migrating topics to a target Confluent Cloud cluster. Supports --mode mirror (cluster-link mirror topics, forwards data) and --mode new (plain Confluent Cloud topics, no data).",
+		Long:  "Create Terraform files for migrating topics to a target Confluent Cloud cluster. Supports --mode mirror (cluster-link mirror topics, forwards data) and --mode new (plain Confluent Cloud topics, no data). In --mode new, topics can be renamed with --topic-prefix, --topic-rename, --topic-rename-file and --topic-rename-rule, and emitted as a YAML manifest plus confluent CLI script with --format yaml. In --mode mirror, the cluster link can only add a prefix, so the only renaming accepted is one every selected topic maps to as a single prefix, which must match the link's --cluster-link-prefix. --topic-name-policy fails generation when any destination name breaks the naming convention. With --cluster-api-key/--cluster-api-secret, the target cluster is checked first for topic-name collisions, existing mirror topics and conflicting cluster links, and generation stops on any collision that would fail the apply.",
 		Example: `  # Mirror mode (forwards data via cluster link)
   kcp create-asset migrate-topics \
       --mode mirror \
@@ -53,7 +66,31 @@ func NewMigrateTopicsCmd() *cobra.Command {
       --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
       --target-cluster-id lkc-xyz123 \
       --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.private.confluent.cloud:443 \
-      --topics-include 'orders.*' --topics-exclude '*.dlq'`,
+      --topics-include 'orders.*' --topics-exclude '*.dlq'
+
+  # New mode as a YAML manifest for the confluent CLI, with renamed topics
+  kcp create-asset migrate-topics \
+      --mode new \
+      --cc-type commercial \
+      --state-file kcp-state.json \
+      --source-type msk \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-cluster-id lkc-xyz123 \
+      --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.private.confluent.cloud:443 \
+      --format yaml --topic-prefix msk. --topic-rename legacy-orders=orders \
+      --topic-rename-rule 'legacy\.(.*)=orders.$1' --topic-name-policy '[a-z]+\.[a-z0-9.-]+'
+
+  # Check the target cluster for collisions before generating
+  kcp create-asset migrate-topics \
+      --mode mirror \
+      --cc-type commercial \
+      --state-file kcp-state.json \
+      --source-type msk \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-cluster-id lkc-xyz123 \
+      --target-rest-endpoint https://lkc-xyz123.eu-west-3.aws.private.confluent.cloud:443 \
+      --cluster-link-name msk-to-cc-link \
+      --cluster-api-key ABCDEFGHIJKLMNOP --cluster-api-secret xxxx`,
 		SilenceErrors: true,
 		PreRunE:       preRunMigrateTopics,
 		RunE:          runMigrateTopics,
@@ -81,14 +118,22 @@ func NewMigrateTopicsCmd() *cobra.Command {
 	optionalFlags.StringVar(&outputDir, "output-dir", "migrate_topics", "The directory to output the Terraform files to. (default: 'migrate_topics')")
 	optionalFlags.StringSliceVar(&topicsInclude, "topics-include", []string{}, "Glob patterns of topics to include (comma separated or repeated flag, e.g. --topics-include 'orders.*,events.*'). Empty = all non-internal topics.")
 	optionalFlags.StringSliceVar(&topicsExclude, "topics-exclude", []string{}, "Glob patterns of topics to exclude (comma separated or repeated flag, e.g. --topics-exclude '*.dlq'). Exclude wins on overlap with include.")
+	optionalFlags.StringVar(&format, "format", formatTerraform, "Output format: 'terraform' (confluent_kafka_topic resources) or 'yaml' (topic manifest plus confluent CLI script). 'yaml' requires --mode new.")
+	optionalFlags.StringVar(&clusterApiKey, "cluster-api-key", "", "API key for the target cluster. When set with --cluster-api-secret, the target cluster is checked for topic and cluster link collisions before generating.")
+	optionalFlags.StringVar(&clusterApiSecret, "cluster-api-secret", "", "API secret for the target cluster.")
+	utils.AddSecretStdinFlag(optionalFlags, "cluster-api-secret")
 	migrationCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
+	topicNamingFlagSet := topicNaming.FlagSet()
+	migrationCmd.Flags().AddFlagSet(topicNamingFlagSet)
+	groups[topicNamingFlagSet] = "Topic Naming Flags"
+
 	migrationCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Short)
 
-		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
-		groupNames := []string{"Required Flags", "Optional Flags"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, topicNamingFlagSet}
+		groupNames := []string{"Required Flags", "Optional Flags", "Topic Naming Flags"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -126,6 +171,17 @@ func preRunMigrateTopics(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := validateFormatFlag(mode, format); err != nil {
+		return err
+	}
+	if _, err := topicNaming.Mapping(); err != nil {
+		return err
+	}
+
+	if (clusterApiKey == "") != (clusterApiSecret == "") {
+		return fmt.Errorf("--cluster-api-key and --cluster-api-secret must be provided together")
+	}
+
 	return nil
 }
 
@@ -135,6 +191,13 @@ func runMigrateTopics(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse migrate topics opts: %v", err)
 	}
 
+	if clusterApiKey != "" {
+		checker := targetcheck.NewChecker(nil)
+		if err := checkTarget(cmd.Context(), checker, *opts, clusterApiKey, clusterApiSecret); err != nil {
+			return err
+		}
+	}
+
 	migrateTopicsAssetGenerator := NewMigrateTopicsAssetGenerator(*opts)
 	if err := migrateTopicsAssetGenerator.Run(); err != nil {
 		return fmt.Errorf("failed to create migration assets: %v", err)
@@ -206,18 +269,73 @@ func parseMigrateTopicsOpts() (*MigrateTopicsOpts, error) {
 		return nil, noMatchError(allTopics, internalTopicsToInclude, topicsInclude, topicsExclude)
 	}
 
+	naming, err := topicNaming.Mapping()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTopicNaming(selected, mode, naming); err != nil {
+		return nil, err
+	}
+
 	opts := MigrateTopicsOpts{
 		Topics:                    selected,
 		TargetClusterId:           targetClusterId,
 		TargetClusterRestEndpoint: targetClusterRestEndpoint,
 		ClusterLinkName:           clusterLinkName,
+		SourceClusterId:           kafkaAdminInfo.ClusterID,
 		OutputDir:                 outputDir,
 		Mode:                      mode,
+		Format:                    format,
+		TopicNaming:               naming,
 	}
 
 	return &opts, nil
 }
 
+// checkTarget runs the target cluster pre-check for the topics about to be
+// generated, prints every finding, and fails when any would break the apply.
+func checkTarget(ctx context.Context, checker *targetcheck.Checker, opts MigrateTopicsOpts, apiKey, apiSecret string) error {
+	fmt.Printf("🔍 Checking target cluster %s for collisions\n", opts.TargetClusterId)
+
+	destinations := make([]string, len(opts.Topics))
+	for i, t := range opts.Topics {
+		destinations[i] = opts.TopicNaming.Destination(t.Name)
+	}
+
+	findings, err := checker.Check(ctx, targetcheck.Config{
+		RestEndpoint: opts.TargetClusterRestEndpoint,
+		ClusterID:    opts.TargetClusterId,
+		APIKey:       apiKey,
+		APISecret:    apiSecret,
+	}, targetcheck.Request{
+		Mirror:          opts.Mode == hclrequests.MigrateTopicsModeMirror,
+		LinkName:        opts.ClusterLinkName,
+		SourceClusterID: opts.SourceClusterId,
+		Topics:          destinations,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check target cluster: %w", err)
+	}
+
+	for _, f := range findings {
+		icon := "⚠️"
+		if f.Severity == targetcheck.SeverityError {
+			icon = "❌"
+		}
+		if f.Topic != "" {
+			fmt.Printf("%s %s: %s\n", icon, f.Topic, f.Message)
+		} else {
+			fmt.Printf("%s %s\n", icon, f.Message)
+		}
+	}
+
+	if errCount := targetcheck.CountErrors(findings); errCount > 0 {
+		return fmt.Errorf("target cluster check found %d collision(s) that would fail the apply", errCount)
+	}
+	fmt.Printf("✅ Target cluster check passed (%d warning(s))\n", len(findings))
+	return nil
+}
+
 // selectTopics applies the migrate-topics selection pipeline:
 //  1. Drop __*-prefixed topics, except those in internalTopicsToInclude
 //     (currently just __consumer_offsets).
@@ -307,3 +425,38 @@ func validateModeFlags(ccType, mode, clusterLinkName string) error {
 	}
 	return nil
 }
+
+// validateFormatFlag enforces the --format / --mode combinations. Mirror
+// topics are created by the cluster link, so the YAML manifest is --mode new
+// only.
+func validateFormatFlag(mode, format string) error {
+	switch format {
+	case formatTerraform:
+	case formatYAML:
+		if mode != hclrequests.MigrateTopicsModeNew {
+			return fmt.Errorf("--format %s requires --mode %s", formatYAML, hclrequests.MigrateTopicsModeNew)
+		}
+	default:
+		return fmt.Errorf("invalid --format: %q (values: %s, %s)", format, formatTerraform, formatYAML)
+	}
+	return nil
+}
+
+// validateTopicNaming checks the topic naming flags against the selected
+// topics. Mirror topics take their name from the cluster link, which can only
+// add one prefix, so in --mode mirror the mapping must reduce to a prefix.
+func validateTopicNaming(selected []types.TopicDetails, mode string, naming topicnaming.Mapping) error {
+	names := make([]string, len(selected))
+	for i, t := range selected {
+		names[i] = t.Name
+	}
+	if err := naming.Validate(names); err != nil {
+		return err
+	}
+	if mode == hclrequests.MigrateTopicsModeMirror {
+		if _, ok := naming.LinkPrefix(names); !ok {
+			return fmt.Errorf("--mode %s can only add a prefix to topic names (the cluster link's cluster.link.prefix): use --mode %s to rename topics", hclrequests.MigrateTopicsModeMirror, hclrequests.MigrateTopicsModeNew)
+		}
+	}
+	return nil
+}
@@ -1,10 +1,16 @@
 package migrate_topics
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/confluentinc/kcp/internal/services/targetcheck"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
 	"github.com/confluentinc/kcp/internal/types"
 )
 
@@ -331,3 +337,114 @@ func TestSelectTopics_PreservesTopicDetails(t *testing.T) {
 		t.Errorf("expected cleanup.policy=compact, got %v", got[0].Configurations)
 	}
 }
+
+func TestValidateFormatFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mode    string
+		format  string
+		wantErr string
+	}{
+		{name: "terraform mirror is valid", mode: "mirror", format: "terraform"},
+		{name: "yaml new is valid", mode: "new", format: "yaml"},
+		{name: "yaml mirror is rejected", mode: "mirror", format: "yaml", wantErr: "--format yaml requires --mode new"},
+		{name: "unknown format is rejected", mode: "new", format: "json", wantErr: `invalid --format: "json"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateFormatFlag(tt.mode, tt.format)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateTopicNaming(t *testing.T) {
+	t.Parallel()
+
+	selected := []types.TopicDetails{{Name: "orders"}, {Name: "legacy-orders"}, {Name: "events"}}
+	rule, err := topicnaming.ParseRule(`legacy-(.*)=$1-v1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		mode    string
+		naming  topicnaming.Mapping
+		wantErr string
+	}{
+		{name: "new mode with prefix and renames is valid", mode: "new", naming: topicnaming.Mapping{Prefix: "msk.", Renames: map[string]string{"legacy-orders": "orders-v1"}}},
+		{name: "new mode with a rule is valid", mode: "new", naming: topicnaming.Mapping{Rules: []topicnaming.Rule{rule}}},
+		{name: "mirror mode with a prefix is valid", mode: "mirror", naming: topicnaming.Mapping{Prefix: "msk."}},
+		{name: "mirror mode with a rename is rejected", mode: "mirror", naming: topicnaming.Mapping{Renames: map[string]string{"legacy-orders": "orders-v1"}}, wantErr: "can only add a prefix"},
+		{name: "collision is rejected", mode: "new", naming: topicnaming.Mapping{Renames: map[string]string{"legacy-orders": "orders"}}, wantErr: `would both be created as "orders"`},
+		{name: "unknown source is rejected", mode: "new", naming: topicnaming.Mapping{Renames: map[string]string{"typo": "orders-v1"}}, wantErr: `references "typo"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateTopicNaming(selected, tt.mode, tt.naming)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"cluster_id":"lkc-1"}`)
+	})
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1/topics", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"data":[{"topic_name":"msk.orders"}]}`)
+	})
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1/links", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"data":[]}`)
+	})
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1/links/-/mirrors", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"data":[]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checker := targetcheck.NewChecker(server.Client())
+	opts := MigrateTopicsOpts{
+		Topics:                    []types.TopicDetails{{Name: "orders"}, {Name: "events"}},
+		TargetClusterId:           "lkc-1",
+		TargetClusterRestEndpoint: server.URL,
+		Mode:                      "new",
+	}
+
+	if err := checkTarget(context.Background(), checker, opts, "key", "secret"); err != nil {
+		t.Fatalf("expected no error without a prefix, got %v", err)
+	}
+
+	// The destination name, not the source name, is what collides.
+	opts.TopicNaming = topicnaming.Mapping{Prefix: "msk."}
+	err := checkTarget(context.Background(), checker, opts, "key", "secret")
+	if err == nil || !strings.Contains(err.Error(), "found 1 collision(s)") {
+		t.Fatalf("expected a collision error, got %v", err)
+	}
+}
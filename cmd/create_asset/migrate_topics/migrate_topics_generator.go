@@ -9,6 +9,9 @@ import (
 	"github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/topic_manifest"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 )
@@ -20,8 +23,11 @@ type MigrateTopicsOpts struct {
 	TargetClusterId           string
 	TargetClusterRestEndpoint string
 	ClusterLinkName           string
+	SourceClusterId           string
 	OutputDir                 string
 	Mode                      string
+	Format                    string
+	TopicNaming               topicnaming.Mapping
 }
 
 type MigrateTopicsAssetGenerator struct {
@@ -35,7 +41,11 @@ func NewMigrateTopicsAssetGenerator(opts MigrateTopicsOpts) *MigrateTopicsAssetG
 }
 
 func (mt *MigrateTopicsAssetGenerator) Run() error {
-	fmt.Printf("🚀 Generating Terraform files for migrate-topics (mode=%s)\n", mt.opts.Mode)
+	if mt.opts.Format == formatYAML {
+		fmt.Printf("🚀 Generating topic manifest for migrate-topics (mode=%s)\n", mt.opts.Mode)
+	} else {
+		fmt.Printf("🚀 Generating Terraform files for migrate-topics (mode=%s)\n", mt.opts.Mode)
+	}
 
 	outputDir := mt.opts.OutputDir
 	if outputDir == "" {
@@ -54,14 +64,26 @@ func (mt *MigrateTopicsAssetGenerator) Run() error {
 	}
 
 	request := hclrequests.MirrorTopicsRequest{
-		SelectedTopics:            selectedNames,
 		Topics:                    mt.opts.Topics,
 		ClusterLinkName:           mt.opts.ClusterLinkName,
 		TargetClusterId:           mt.opts.TargetClusterId,
 		TargetClusterRestEndpoint: mt.opts.TargetClusterRestEndpoint,
 		Mode:                      mt.opts.Mode,
+		TopicNamePrefix:           mt.opts.TopicNaming.Prefix,
+		TopicRenames:              mt.opts.TopicNaming.ResolvedRenames(selectedNames),
 	}
 
+	if mt.opts.Format == formatYAML {
+		if err := mt.writeManifest(outputDir, request); err != nil {
+			return fmt.Errorf("failed to write topic manifest: %w", err)
+		}
+		fmt.Printf("✅ migrate-topics manifest generated: %s (%d topics)\n", outputDir, len(mt.opts.Topics))
+		fmt.Println(newModeCLINote)
+		return nil
+	}
+
+	request.SelectedTopics = selectedNames
+
 	hclService := hcl.NewMigrationScriptsHCLService()
 	project, err := hclService.GenerateMirrorTopicsFiles(request)
 	if err != nil {
@@ -90,21 +112,21 @@ func (mt *MigrateTopicsAssetGenerator) writeProject(outputDir string, project hc
 	folder := project.Folders[0]
 
 	if folder.ProvidersTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(folder.ProvidersTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(folder.ProvidersTf), 0644); err != nil {
 			return fmt.Errorf("failed to write providers.tf: %w", err)
 		}
 		slog.Debug("wrote providers.tf")
 	}
 
 	if folder.VariablesTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(folder.VariablesTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(folder.VariablesTf), 0644); err != nil {
 			return fmt.Errorf("failed to write variables.tf: %w", err)
 		}
 		slog.Debug("wrote variables.tf")
 	}
 
 	for name, content := range folder.AdditionalFiles {
-		if err := os.WriteFile(filepath.Join(outputDir, name), []byte(content), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, name), []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write %s: %w", name, err)
 		}
 		slog.Debug("wrote per-topic file", "file", name)
@@ -112,3 +134,25 @@ func (mt *MigrateTopicsAssetGenerator) writeProject(outputDir string, project hc
 
 	return nil
 }
+
+// writeManifest writes topics.yaml and the create-topics.sh script that
+// creates the same topics with the confluent CLI.
+func (mt *MigrateTopicsAssetGenerator) writeManifest(outputDir string, request hclrequests.MirrorTopicsRequest) error {
+	manifest := topic_manifest.Build(mt.opts.Topics, request.DestinationTopicName, mt.opts.TargetClusterId, mt.opts.TargetClusterRestEndpoint)
+
+	manifestYAML, err := manifest.YAML()
+	if err != nil {
+		return err
+	}
+	if err := summary.WriteFile(filepath.Join(outputDir, "topics.yaml"), manifestYAML, 0644); err != nil {
+		return fmt.Errorf("failed to write topics.yaml: %w", err)
+	}
+	slog.Debug("wrote topics.yaml")
+
+	if err := summary.WriteFile(filepath.Join(outputDir, "create-topics.sh"), []byte(manifest.CreateScript()), 0755); err != nil {
+		return fmt.Errorf("failed to write create-topics.sh: %w", err)
+	}
+	slog.Debug("wrote create-topics.sh")
+
+	return nil
+}
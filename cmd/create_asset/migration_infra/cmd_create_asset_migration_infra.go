@@ -11,8 +11,11 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/linkbandwidth"
+	"github.com/confluentinc/kcp/internal/services/report"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -24,6 +27,15 @@ var (
 	ccType             string
 	migrationInfraType string
 	clusterLinkName    string
+	clusterLinkMode    string
+	clusterLinkPrefix  string
+	sourceRestEndpoint string
+
+	clusterLinkConsumerOffsetSync           bool
+	clusterLinkConsumerOffsetSyncMs         int
+	clusterLinkAclSync                      bool
+	clusterLinkAutoCreateMirrorTopicFilters []string
+	topicMappingFile                        string
 
 	sourceType               string
 	clusterId                string
@@ -44,12 +56,42 @@ var (
 	extOutboundSecurityGroupId string
 
 	jumpClusterInstanceType        string
+	linkThroughputMBps             float64
 	jumpClusterBrokerStorage       int
+	jumpClusterAmi                 string
+	jumpClusterSpotInstances       bool
+	jumpClusterCABundleFile        string
+	jumpClusterCABundle            string
+	jumpClusterHTTPProxy           string
+	jumpClusterHTTPSProxy          string
+	jumpClusterNoProxy             string
 	jumpClusterBrokerSubnetCidr    []net.IPNet
 	jumpClusterSetupHostSubnetCidr net.IPNet
 
 	jumpClusterIamAuthRoleName string
+	jumpClusterProvisioner     string
+	skipSubnetCapacityCheck    bool
+	checkServiceQuotas         bool
+	validateTerraform          string
 	targetClusterType          string
+
+	monitoring              bool
+	monitoringAlarmTopicArn string
+
+	refresh bool
+	dryRun  bool
+
+	connectivity         string
+	confluentNetworkCidr net.IPNet
+	transitGatewayId     string
+
+	tfBackendFlags utils.TerraformBackendFlags
+	topicNaming    utils.TopicNamingFlags
+)
+
+const (
+	clusterLinkModeDestination   = "destination"
+	clusterLinkModeBidirectional = "bidirectional"
 )
 
 func NewMigrationInfraCmd() *cobra.Command {
@@ -66,7 +108,40 @@ Type options:
 4. Private MSK endpoints — Jump Cluster (SASL/SCRAM)
 5. Private MSK endpoints — Jump Cluster (IAM, MSK only)
 
-> **Note:** External Outbound Cluster Linking (Types 2 and 3) is only supported for Enterprise clusters. Dedicated clusters with private MSK endpoints must use Jump Clusters (Type 4 or 5). Dedicated clusters with public MSK endpoints can use Type 1.`,
+> **Note:** External Outbound Cluster Linking (Types 2 and 3) is only supported for Enterprise clusters. Dedicated clusters with private MSK endpoints must use Jump Clusters (Type 4 or 5). Dedicated clusters with public MSK endpoints can use Type 1.
+
+For Types 4 and 5, ` + "`--connectivity`" + ` selects how the jump cluster VPC reaches Confluent Cloud: ` + "`privatelink`" + ` (the default) reuses the VPC endpoint given by ` + "`--existing-private-link-vpce-id`" + `, while ` + "`peering`" + ` and ` + "`transit-gateway`" + ` create a Confluent Cloud network with ` + "`--confluent-network-cidr`" + ` plus the VPC peering or transit gateway attachment and the route table entries to reach it.
+
+For Types 4 and 5, ` + "`--jump-cluster-provisioner ansible`" + ` keeps only the networking in Terraform and generates Ansible playbooks (under ` + "`<output-dir>/ansible`" + `) that launch and configure the jump cluster and setup host instances, for environments where Terraform may not manage EC2 instances.
+
+For Type 1, ` + "`--cluster-link-mode bidirectional`" + ` creates a BIDIRECTIONAL cluster link instead of a destination-only one: Terraform also creates the reverse link on the source cluster through ` + "`--source-rest-endpoint`" + `, so topics can be mirrored back to the source as a fallback. The source cluster must be able to host cluster links (Confluent Platform 7.5 or later), so bidirectional links are only available for ` + "`--source-type apache-kafka`" + `. ` + "`--cluster-link-prefix`" + ` prefixes the names of the mirror topics created by the link.
+
+For Type 1, ` + "`--topic-mapping-file`" + ` splits the source cluster's topics across several Confluent Cloud clusters. Each destination other than ` + "`--target-cluster-id`" + ` gets its own cluster link module, auto-creating mirror topics for the destination's topic filters (literal names, or prefixes ending in ` + "`*`" + `); a destination whose ID is ` + "`--target-cluster-id`" + ` only adds topic filters to the main link. A topic can only be mirrored to one destination, so overlapping filters are rejected, and scanned topics no destination covers are reported as a warning. Each additional destination's cluster ID, endpoints, link name and API key are root Terraform variables suffixed with its name (which defaults to the cluster ID):
+
+    destinations:
+      - target_cluster_id: lkc-w89xyz
+        topics: ["orders.*"]
+      - name: payments
+        target_cluster_id: lkc-pay123
+        target_rest_endpoint: https://lkc-pay123.us-east-1.aws.confluent.cloud:443
+        cluster_link_name: msk-to-payments
+        topics: ["payments.*", refunds]
+
+` + "`--tf-backend s3`" + ` or ` + "`--tf-backend cloud`" + ` writes a remote state backend (an S3 bucket, or a Terraform Cloud workspace) into the generated providers.tf, so the project does not have to be edited before ` + "`terraform init`" + ` in a team setup.
+
+The cluster link authenticates to Confluent Cloud with the cluster API key even when ` + "`kcp create-asset target-infra --oauth-issuer`" + ` federated the target cluster with an identity provider. The identity pool it creates maps the tokens of client applications and is only granted DeveloperRead and DeveloperWrite, which cannot create a cluster link; the link itself runs on the Confluent Cloud brokers and needs no client credentials. The pool's role bindings cover every topic, mirror topics included, so OAuth clients can read them before promotion; ` + "`kcp create-asset client-playbooks --oauth-token-endpoint-url`" + ` writes their connection settings.
+
+For MSK sources, the subnets recorded by ` + "`kcp discover`" + ` are checked before anything is generated: brokers spread unevenly across availability zones are reported as warnings, and jump cluster subnet CIDRs that are too small or overlap existing subnets, or an external outbound subnet without a free IP address, stop generation unless ` + "`--skip-subnet-capacity-check`" + ` is set.
+
+` + "`--check-service-quotas`" + ` also queries AWS Service Quotas in the source region before anything is generated and reports, per quota, whether the jump cluster or cluster link host instances (standard on-demand or spot vCPUs), the NAT gateway's Elastic IP and the network interfaces fit in what the account has left. A quota that would be exceeded stops generation.
+
+For Types 4 and 5 against MSK, the jump cluster instance type defaults to the smallest m5 size whose baseline network bandwidth carries the cluster link: the larger of ` + "`--link-throughput-mbps`" + ` and the peak produce rate plus 30% headroom, shared across one jump cluster broker per MSK broker. It falls back to the MSK broker type when the state file has neither partition sizes nor produce metrics; ` + "`--jump-cluster-instance-type`" + ` overrides it. ` + "`kcp report plan`" + ` shows the same estimate with the initial sync duration.
+
+Once written, the Terraform is validated so a template that renders invalid HCL fails generation rather than ` + "`terraform apply`" + `. With ` + "`--validate auto`" + ` (the default) every .tf and .tfvars file is parsed with kcp's built-in HCL parser, then formatted with ` + "`terraform fmt`" + ` when a terraform binary is on PATH. ` + "`--validate terraform`" + ` also runs ` + "`terraform init -backend=false`" + ` and ` + "`terraform validate`" + `, which needs terraform and downloads the providers; ` + "`embedded`" + ` only parses and ` + "`off`" + ` skips validation.
+
+For MSK sources, ` + "`--monitoring`" + ` adds a ` + "`monitoring`" + ` module with a CloudWatch dashboard for the migration period (source cluster throughput, cluster link lag derived with metric math from the bytes produced and read, consumer group time lag, partition health and, for Types 4 and 5, jump cluster CPU and status checks) and alarms on offline partitions, a missing active controller and failing jump cluster status checks. ` + "`--monitoring-alarm-topic-arn`" + ` sends the alarms to an SNS topic. The broker throughput widgets need PER_BROKER enhanced monitoring or higher on the MSK cluster.
+
+` + "`--refresh`" + ` regenerates the Terraform in an existing output directory, e.g. after a kcp upgrade or a change of flags. Files kcp wrote and nobody edited since are rewritten or, when no longer generated, removed. A file the user edited is kept and the generated version written beside it as ` + "`<file>.kcp-new`" + ` to merge by hand; files kcp did not write are left alone. ` + "`--dry-run`" + ` with ` + "`--refresh`" + ` prints what would change, with a unified diff, and writes nothing.`,
 		Example: `  # Type 4 — Jump Cluster with SASL/SCRAM, against a private MSK
   kcp create-asset migration-infra \
       --state-file kcp-state.json \
@@ -94,7 +169,24 @@ Type options:
       --type 1 \
       --cluster-link-name simple-link \
       --target-cluster-id lkc-w89xyz \
-      --target-rest-endpoint https://lkc-w89xyz.us-east-1.aws.confluent.cloud:443`,
+      --target-rest-endpoint https://lkc-w89xyz.us-east-1.aws.confluent.cloud:443
+
+  # Type 1 — Bidirectional cluster link with a Confluent Platform source
+  kcp create-asset migration-infra \
+      --state-file kcp-state.json \
+      --cc-type commercial \
+      --source-type apache-kafka \
+      --cluster-id my-cp-cluster \
+      --vpc-id vpc-0abc123def456789 \
+      --region us-east-1 \
+      --type 1 \
+      --cluster-link-name bidirectional-link \
+      --cluster-link-mode bidirectional \
+      --cluster-link-prefix cp- \
+      --source-rest-endpoint https://cp-kafka.example.com:8090 \
+      --target-cluster-id lkc-w89xyz \
+      --target-rest-endpoint https://lkc-w89xyz.us-east-1.aws.confluent.cloud:443 \
+      --target-bootstrap-endpoint lkc-w89xyz.us-east-1.aws.confluent.cloud:9092`,
 		Annotations: map[string]string{
 			iampolicy.AnnotationKey: iamAnnotation(),
 		},
@@ -127,12 +219,32 @@ Type options:
 	optionalFlags.SortFlags = false
 	optionalFlags.BoolVar(&existingInternetGateway, "existing-internet-gateway", false, "Whether to use an existing internet gateway. (default: false)")
 	optionalFlags.StringVar(&outputDir, "output-dir", "", "The directory to output the migration infrastructure assets to. (default: 'migration-infra')")
+	optionalFlags.StringVar(&jumpClusterProvisioner, "jump-cluster-provisioner", "terraform", "How the jump cluster EC2 instances are provisioned for types 4 and 5: 'terraform' or 'ansible'. With 'ansible', Terraform only creates the networking and Ansible playbooks are generated under <output-dir>/ansible.")
+	optionalFlags.StringVar(&validateTerraform, "validate", hcl.ValidateAuto, "How to validate the generated Terraform: 'auto' (parse, then `terraform fmt` if terraform is on PATH), 'terraform' (also `terraform init -backend=false` and `terraform validate`), 'embedded' (parse only) or 'off'.")
+	optionalFlags.BoolVar(&skipSubnetCapacityCheck, "skip-subnet-capacity-check", false, "Generate the assets even if the subnet capacity check against the state file finds errors. (default: false)")
+	optionalFlags.BoolVar(&checkServiceQuotas, "check-service-quotas", false, "Query AWS Service Quotas in the source region and stop if the EC2 instances, Elastic IPs and network interfaces of the generated infrastructure do not fit in the remaining quota. Needs AWS credentials. (default: false)")
+	optionalFlags.BoolVar(&monitoring, "monitoring", false, "Add a monitoring module with a CloudWatch dashboard and alarms for the source MSK cluster and, for types 4 and 5, the jump cluster instances. MSK sources only. (default: false)")
+	optionalFlags.StringVar(&monitoringAlarmTopicArn, "monitoring-alarm-topic-arn", "", "[Optional] The ARN of an SNS topic the --monitoring alarms notify when they fire and recover.")
+	optionalFlags.BoolVar(&refresh, "refresh", false, "Regenerate the Terraform in an existing --output-dir, keeping files the user edited or added. Not supported with '--jump-cluster-provisioner ansible'. (default: false)")
+	optionalFlags.BoolVar(&dryRun, "dry-run", false, "With --refresh, print the changes and their diff without writing them. (default: false)")
 	migrationInfraCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
+	tfBackendFlagSet := tfBackendFlags.FlagSet("kcp/migration-infra/terraform.tfstate")
+	migrationInfraCmd.Flags().AddFlagSet(tfBackendFlagSet)
+	groups[tfBackendFlagSet] = "Terraform State Backend Flags"
+
 	baseFlags := pflag.NewFlagSet("base", pflag.ExitOnError)
 	baseFlags.SortFlags = false
 	baseFlags.StringVar(&clusterLinkName, "cluster-link-name", "", "The name of the cluster link that will be created as part of the migration.")
+	baseFlags.StringVar(&clusterLinkMode, "cluster-link-mode", clusterLinkModeDestination, "[Optional] The cluster link mode for type 1: 'destination' or 'bidirectional'. Bidirectional also creates the reverse link on the source cluster (Apache Kafka sources running Confluent Platform 7.5+ only).")
+	baseFlags.StringVar(&clusterLinkPrefix, "cluster-link-prefix", "", "[Optional] The prefix prepended to mirror topic names created by the type 1 cluster link.")
+	baseFlags.BoolVar(&clusterLinkConsumerOffsetSync, "cluster-link-consumer-offset-sync", false, "[Optional] Sync the committed offsets of all consumer groups over the type 1 cluster link. (default: false)")
+	baseFlags.IntVar(&clusterLinkConsumerOffsetSyncMs, "cluster-link-consumer-offset-sync-ms", 0, "[Optional] How often, in milliseconds, the type 1 cluster link syncs consumer offsets. Requires --cluster-link-consumer-offset-sync. (default: the link default)")
+	baseFlags.BoolVar(&clusterLinkAclSync, "cluster-link-acl-sync", false, "[Optional] Sync all ACLs over the type 1 cluster link. (default: false)")
+	baseFlags.StringSliceVar(&clusterLinkAutoCreateMirrorTopicFilters, "cluster-link-auto-create-mirror-topics", []string{}, "[Optional] Topics the type 1 cluster link creates mirror topics for automatically: a literal name, '*' for every topic, or a prefix ending in '*'. Can be repeated or comma-separated.")
+	baseFlags.StringVar(&topicMappingFile, "topic-mapping-file", "", "[Optional] A YAML file splitting the source cluster's topics across several Confluent Cloud clusters, with one type 1 cluster link per destination.")
+	baseFlags.StringVar(&sourceRestEndpoint, "source-rest-endpoint", "", "The Confluent REST endpoint of the source cluster, used to create the reverse link. (required for 'bidirectional' cluster links)")
 	baseFlags.StringVar(&targetClusterId, "target-cluster-id", "", "The Confluent Cloud cluster ID.")
 	baseFlags.StringVar(&targetRestEndpoint, "target-rest-endpoint", "", "The Confluent Cloud cluster REST endpoint.")
 	baseFlags.StringVar(&targetClusterType, "target-cluster-type", "", "The Confluent Cloud target cluster type ('dedicated' or 'enterprise').")
@@ -140,6 +252,12 @@ Type options:
 	migrationInfraCmd.Flags().AddFlagSet(baseFlags)
 	groups[baseFlags] = "Base Flags"
 
+	// The topic naming flags of migrate-topics, so the type 1 cluster link
+	// prefix is derived from the same naming convention.
+	topicNamingFlagSet := topicNaming.FlagSet()
+	migrationInfraCmd.Flags().AddFlagSet(topicNamingFlagSet)
+	groups[topicNamingFlagSet] = "Topic Naming Flags"
+
 	typeTwoThreeFlags := pflag.NewFlagSet("type-two-three", pflag.ExitOnError)
 	typeTwoThreeFlags.SortFlags = false
 	typeTwoThreeFlags.StringVar(&extOutboundSubnetId, "subnet-id", "", "[Optional] Subnet ID for the EC2 instance that provisions the cluster link. (default:  MSK broker #1 subnet).")
@@ -153,8 +271,18 @@ Type options:
 	typeFourFlags.StringVar(&existingPrivateLinkVpceId, "existing-private-link-vpce-id", "", "The ID of the existing VPC endpoint for the Private Link connection to Confluent Cloud.")
 	typeFourFlags.IPNetSliceVar(&jumpClusterBrokerSubnetCidr, "jump-cluster-broker-subnet-cidr", []net.IPNet{}, "The CIDR blocks to use for the jump cluster broker subnets. You should provide as many CIDRs as the MSK cluster has broker nodes.")
 	typeFourFlags.IPNetVar(&jumpClusterSetupHostSubnetCidr, "jump-cluster-setup-host-subnet-cidr", net.IPNet{}, "The CIDR block to use for the jump cluster setup host subnet.")
-	typeFourFlags.StringVar(&jumpClusterInstanceType, "jump-cluster-instance-type", "", "[Optional] The instance type to use for the jump cluster. (default: MSK broker type).")
-	typeFourFlags.IntVar(&jumpClusterBrokerStorage, "jump-cluster-broker-storage", 0, "[Optional] The storage size to use for the jump cluster brokers. (default: MSK cluster broker storage size).")
+	typeFourFlags.StringVar(&jumpClusterInstanceType, "jump-cluster-instance-type", "", "[Optional] The instance type to use for the jump cluster. (default: sized from the cluster's data volume and produce rate, or the MSK broker type when neither was scanned).")
+	typeFourFlags.Float64Var(&linkThroughputMBps, "link-throughput-mbps", 100, "[Optional] The sustained cluster link throughput, in MB/s, the default jump cluster instance type is sized for.")
+	typeFourFlags.IntVar(&jumpClusterBrokerStorage, "jump-cluster-broker-storage", 0, "[Optional] The root volume size, in GiB, to use for the jump cluster brokers. (default: MSK cluster broker storage size).")
+	typeFourFlags.StringVar(&jumpClusterAmi, "jump-cluster-ami", hclrequests.JumpClusterAmiRHEL, "[Optional] The operating system of the jump cluster brokers: 'rhel' (RHEL 9.6), 'al2023' (Amazon Linux 2023) or 'ubuntu' (Ubuntu 22.04 LTS).")
+	typeFourFlags.BoolVar(&jumpClusterSpotInstances, "jump-cluster-spot-instances", false, "[Optional] Launch the jump cluster brokers as spot instances instead of on-demand. An interrupted broker is terminated and must be replaced. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFourFlags.StringVar(&jumpClusterCABundleFile, "jump-cluster-ca-bundle", "", "[Optional] Path to a PEM file of corporate CA certificates (e.g. of a TLS-inspecting egress proxy) to install in the trust store of the jump cluster brokers and setup host. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFourFlags.StringVar(&jumpClusterHTTPProxy, "jump-cluster-http-proxy", "", "[Optional] The HTTP proxy URL the jump cluster brokers and setup host use for outbound HTTP. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFourFlags.StringVar(&jumpClusterHTTPSProxy, "jump-cluster-https-proxy", "", "[Optional] The HTTP proxy URL the jump cluster brokers and setup host use for outbound HTTPS. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFourFlags.StringVar(&jumpClusterNoProxy, "jump-cluster-no-proxy", "", "[Optional] Comma-separated hosts and domains reached without the proxy, in addition to localhost, the instance metadata endpoint and '.internal'.")
+	typeFourFlags.StringVar(&connectivity, "connectivity", hclrequests.ConnectivityPrivateLink, "[Optional] How the jump cluster VPC reaches Confluent Cloud: 'privatelink' (existing VPC endpoint), 'peering' or 'transit-gateway'.")
+	typeFourFlags.IPNetVar(&confluentNetworkCidr, "confluent-network-cidr", net.IPNet{}, "The CIDR block of the Confluent Cloud network created for 'peering' and 'transit-gateway' connectivity. Must not overlap with the VPC.")
+	typeFourFlags.StringVar(&transitGatewayId, "transit-gateway-id", "", "The ID of the existing transit gateway the VPC is attached to. (required for 'transit-gateway' connectivity)")
 	migrationInfraCmd.Flags().AddFlagSet(typeFourFlags)
 	groups[typeFourFlags] = "Type Four Flags"
 
@@ -165,14 +293,24 @@ Type options:
 	typeFiveFlags.IPNetSliceVar(&jumpClusterBrokerSubnetCidr, "jump-cluster-broker-subnet-cidr", []net.IPNet{}, "The CIDR blocks to use for the jump cluster broker subnets. You should provide as many CIDRs as the MSK cluster has broker nodes.")
 	typeFiveFlags.IPNetVar(&jumpClusterSetupHostSubnetCidr, "jump-cluster-setup-host-subnet-cidr", net.IPNet{}, "The CIDR block to use for the jump cluster setup host subnet.")
 	typeFiveFlags.StringVar(&jumpClusterIamAuthRoleName, "jump-cluster-iam-auth-role-name", "", " The IAM role name to authenticate the cluster link between MSK and the jump cluster.")
-	typeFiveFlags.StringVar(&jumpClusterInstanceType, "jump-cluster-instance-type", "", "[Optional] The instance type to use for the jump cluster. (default: MSK broker type).")
-	typeFiveFlags.IntVar(&jumpClusterBrokerStorage, "jump-cluster-broker-storage", 0, "[Optional] The storage size to use for the jump cluster brokers. (default: MSK cluster broker storage size).")
+	typeFiveFlags.StringVar(&jumpClusterInstanceType, "jump-cluster-instance-type", "", "[Optional] The instance type to use for the jump cluster. (default: sized from the cluster's data volume and produce rate, or the MSK broker type when neither was scanned).")
+	typeFiveFlags.Float64Var(&linkThroughputMBps, "link-throughput-mbps", 100, "[Optional] The sustained cluster link throughput, in MB/s, the default jump cluster instance type is sized for.")
+	typeFiveFlags.IntVar(&jumpClusterBrokerStorage, "jump-cluster-broker-storage", 0, "[Optional] The root volume size, in GiB, to use for the jump cluster brokers. (default: MSK cluster broker storage size).")
+	typeFiveFlags.StringVar(&jumpClusterAmi, "jump-cluster-ami", hclrequests.JumpClusterAmiRHEL, "[Optional] The operating system of the jump cluster brokers: 'rhel' (RHEL 9.6), 'al2023' (Amazon Linux 2023) or 'ubuntu' (Ubuntu 22.04 LTS).")
+	typeFiveFlags.BoolVar(&jumpClusterSpotInstances, "jump-cluster-spot-instances", false, "[Optional] Launch the jump cluster brokers as spot instances instead of on-demand. An interrupted broker is terminated and must be replaced. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFiveFlags.StringVar(&jumpClusterCABundleFile, "jump-cluster-ca-bundle", "", "[Optional] Path to a PEM file of corporate CA certificates (e.g. of a TLS-inspecting egress proxy) to install in the trust store of the jump cluster brokers and setup host. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFiveFlags.StringVar(&jumpClusterHTTPProxy, "jump-cluster-http-proxy", "", "[Optional] The HTTP proxy URL the jump cluster brokers and setup host use for outbound HTTP. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFiveFlags.StringVar(&jumpClusterHTTPSProxy, "jump-cluster-https-proxy", "", "[Optional] The HTTP proxy URL the jump cluster brokers and setup host use for outbound HTTPS. Not supported with '--jump-cluster-provisioner ansible'.")
+	typeFiveFlags.StringVar(&jumpClusterNoProxy, "jump-cluster-no-proxy", "", "[Optional] Comma-separated hosts and domains reached without the proxy, in addition to localhost, the instance metadata endpoint and '.internal'.")
+	typeFiveFlags.StringVar(&connectivity, "connectivity", hclrequests.ConnectivityPrivateLink, "[Optional] How the jump cluster VPC reaches Confluent Cloud: 'privatelink' (existing VPC endpoint), 'peering' or 'transit-gateway'.")
+	typeFiveFlags.IPNetVar(&confluentNetworkCidr, "confluent-network-cidr", net.IPNet{}, "The CIDR block of the Confluent Cloud network created for 'peering' and 'transit-gateway' connectivity. Must not overlap with the VPC.")
+	typeFiveFlags.StringVar(&transitGatewayId, "transit-gateway-id", "", "The ID of the existing transit gateway the VPC is attached to. (required for 'transit-gateway' connectivity)")
 	migrationInfraCmd.Flags().AddFlagSet(typeFiveFlags)
 	groups[typeFiveFlags] = "Type Five Flags"
 
 	migrationInfraCmd.SetUsageFunc(func(c *cobra.Command) error {
-		flagOrder := []*pflag.FlagSet{requiredFlags, oskFlags, optionalFlags, baseFlags, typeTwoThreeFlags, typeFourFlags, typeFiveFlags}
-		groupNames := []string{"Required Flags", "Apache Kafka Flags", "Optional Flags", "Base Migration Flags", "Type Two/Three Flags", "Type Four Flags", "Type Five Flags"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, oskFlags, optionalFlags, tfBackendFlagSet, baseFlags, topicNamingFlagSet, typeTwoThreeFlags, typeFourFlags, typeFiveFlags}
+		groupNames := []string{"Required Flags", "Apache Kafka Flags", "Optional Flags", "Terraform State Backend Flags", "Base Migration Flags", "Topic Naming Flags", "Type Two/Three Flags", "Type Four Flags", "Type Five Flags"}
 
 		/*
 			Type 1 = `HasPublicMskEndpoints` = true
@@ -238,6 +376,47 @@ func preRunMigrationInfra(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid --type: %v", err)
 	}
 
+	switch jumpClusterProvisioner {
+	case provisionerTerraform:
+	case provisionerAnsible:
+		if targetType != types.JumpClusterSaslScram && targetType != types.JumpClusterIam {
+			return fmt.Errorf("--jump-cluster-provisioner ansible is only supported for jump cluster types (4 and 5)")
+		}
+	default:
+		return fmt.Errorf("invalid --jump-cluster-provisioner '%s': must be 'terraform' or 'ansible'", jumpClusterProvisioner)
+	}
+
+	if err := validateJumpClusterInstances(targetType, jumpClusterAmi, jumpClusterSpotInstances, jumpClusterProvisioner); err != nil {
+		return err
+	}
+
+	if err := validateJumpClusterEgress(targetType, jumpClusterCABundleFile, jumpClusterHTTPProxy, jumpClusterHTTPSProxy, jumpClusterNoProxy, jumpClusterProvisioner); err != nil {
+		return err
+	}
+	if jumpClusterCABundleFile != "" {
+		bundle, err := readJumpClusterCABundle(jumpClusterCABundleFile)
+		if err != nil {
+			return err
+		}
+		jumpClusterCABundle = bundle
+	}
+
+	if err := validateMonitoring(sourceType, jumpClusterProvisioner, monitoring, monitoringAlarmTopicArn); err != nil {
+		return err
+	}
+
+	if err := validateRefresh(jumpClusterProvisioner, refresh, dryRun); err != nil {
+		return err
+	}
+
+	if !slices.Contains(hcl.ValidationModes, validateTerraform) {
+		return fmt.Errorf("invalid --validate '%s': must be one of: %s", validateTerraform, strings.Join(hcl.ValidationModes, ", "))
+	}
+
+	if linkThroughputMBps <= 0 {
+		return fmt.Errorf("--link-throughput-mbps must be greater than 0")
+	}
+
 	if (targetType == types.ExternalOutboundClusterLink || targetType == types.ExternalOutboundClusterLinkPlaintext) && targetClusterType == "dedicated" {
 		return fmt.Errorf("external outbound cluster linking (Type 2/3) is not supported for dedicated clusters. Please use jump clusters (Type 4 or 5) for private networking, or Type 1 (Cluster Link) if your MSK brokers are publicly accessible")
 	}
@@ -255,21 +434,295 @@ func preRunMigrationInfra(cmd *cobra.Command, args []string) error {
 
 	case types.JumpClusterSaslScram:
 		_ = cmd.MarkFlagRequired("target-bootstrap-endpoint")
-		_ = cmd.MarkFlagRequired("existing-private-link-vpce-id")
 		_ = cmd.MarkFlagRequired("jump-cluster-broker-subnet-cidr")
 		_ = cmd.MarkFlagRequired("jump-cluster-setup-host-subnet-cidr")
 
 	case types.JumpClusterIam:
 		_ = cmd.MarkFlagRequired("target-bootstrap-endpoint")
-		_ = cmd.MarkFlagRequired("existing-private-link-vpce-id")
 		_ = cmd.MarkFlagRequired("jump-cluster-broker-subnet-cidr")
 		_ = cmd.MarkFlagRequired("jump-cluster-setup-host-subnet-cidr")
 		_ = cmd.MarkFlagRequired("jump-cluster-iam-auth-role-name")
 	}
 
+	if err := validateConnectivity(targetType, connectivity); err != nil {
+		return err
+	}
+
+	if _, err := tfBackendFlags.TerraformBackend(); err != nil {
+		return err
+	}
+
+	if err := validateClusterLink(targetType, sourceType, clusterLinkMode, clusterLinkPrefix); err != nil {
+		return err
+	}
+	if err := validateClusterLinkTuning(targetType, clusterLinkConsumerOffsetSync, clusterLinkConsumerOffsetSyncMs, clusterLinkAclSync, clusterLinkAutoCreateMirrorTopicFilters); err != nil {
+		return err
+	}
+	if topicMappingFile != "" && targetType != types.PublicMskEndpoints {
+		return fmt.Errorf("--topic-mapping-file is only supported for type 1")
+	}
+	naming, err := topicNaming.Mapping()
+	if err != nil {
+		return err
+	}
+	if !naming.IsIdentity() && targetType != types.PublicMskEndpoints {
+		return fmt.Errorf("the topic naming flags are only supported for type 1")
+	}
+	if clusterLinkMode == clusterLinkModeBidirectional {
+		_ = cmd.MarkFlagRequired("source-rest-endpoint")
+		_ = cmd.MarkFlagRequired("target-bootstrap-endpoint")
+	}
+
+	if targetType == types.JumpClusterSaslScram || targetType == types.JumpClusterIam {
+		switch connectivity {
+		case hclrequests.ConnectivityPrivateLink:
+			_ = cmd.MarkFlagRequired("existing-private-link-vpce-id")
+		case hclrequests.ConnectivityPeering:
+			_ = cmd.MarkFlagRequired("confluent-network-cidr")
+		case hclrequests.ConnectivityTransitGateway:
+			_ = cmd.MarkFlagRequired("confluent-network-cidr")
+			_ = cmd.MarkFlagRequired("transit-gateway-id")
+		}
+	}
+
+	return nil
+}
+
+// validateConnectivity checks --connectivity. Only the jump cluster types
+// (4 and 5) generate private networking towards Confluent Cloud, so any value
+// other than the privatelink default is rejected for the other types.
+func validateConnectivity(targetType types.MigrationType, connectivity string) error {
+	switch connectivity {
+	case hclrequests.ConnectivityPrivateLink:
+		return nil
+	case hclrequests.ConnectivityPeering, hclrequests.ConnectivityTransitGateway:
+		if targetType != types.JumpClusterSaslScram && targetType != types.JumpClusterIam {
+			return fmt.Errorf("--connectivity %s is only supported for jump cluster types (4 and 5)", connectivity)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --connectivity '%s': must be '%s', '%s' or '%s'", connectivity, hclrequests.ConnectivityPrivateLink, hclrequests.ConnectivityPeering, hclrequests.ConnectivityTransitGateway)
+	}
+}
+
+// validateJumpClusterInstances checks --jump-cluster-ami and
+// --jump-cluster-spot-instances. Both only apply to the jump cluster types
+// (4 and 5), and the Ansible provisioner only launches on-demand instances.
+func validateJumpClusterInstances(targetType types.MigrationType, ami string, spot bool, provisioner string) error {
+	switch ami {
+	case hclrequests.JumpClusterAmiRHEL, hclrequests.JumpClusterAmiAL2023, hclrequests.JumpClusterAmiUbuntu:
+	default:
+		return fmt.Errorf("invalid --jump-cluster-ami '%s': must be '%s', '%s' or '%s'", ami, hclrequests.JumpClusterAmiRHEL, hclrequests.JumpClusterAmiAL2023, hclrequests.JumpClusterAmiUbuntu)
+	}
+
+	isJumpCluster := targetType == types.JumpClusterSaslScram || targetType == types.JumpClusterIam
+	if ami != hclrequests.JumpClusterAmiRHEL && !isJumpCluster {
+		return fmt.Errorf("--jump-cluster-ami is only supported for jump cluster types (4 and 5)")
+	}
+	if spot {
+		if !isJumpCluster {
+			return fmt.Errorf("--jump-cluster-spot-instances is only supported for jump cluster types (4 and 5)")
+		}
+		if provisioner == provisionerAnsible {
+			return fmt.Errorf("--jump-cluster-spot-instances is not supported with --jump-cluster-provisioner ansible")
+		}
+	}
 	return nil
 }
 
+// validateJumpClusterEgress checks --jump-cluster-ca-bundle and the
+// --jump-cluster-*-proxy flags. They are applied by the user data of the
+// Terraform-managed jump cluster instances, so the Ansible provisioner
+// ignores them.
+func validateJumpClusterEgress(targetType types.MigrationType, caBundleFile, httpProxy, httpsProxy, noProxy, provisioner string) error {
+	if caBundleFile == "" && httpProxy == "" && httpsProxy == "" && noProxy == "" {
+		return nil
+	}
+	if targetType != types.JumpClusterSaslScram && targetType != types.JumpClusterIam {
+		return fmt.Errorf("--jump-cluster-ca-bundle and the --jump-cluster-*-proxy flags are only supported for jump cluster types (4 and 5)")
+	}
+	if provisioner == provisionerAnsible {
+		return fmt.Errorf("--jump-cluster-ca-bundle and the --jump-cluster-*-proxy flags are not supported with --jump-cluster-provisioner ansible")
+	}
+	for flag, value := range map[string]string{"--jump-cluster-http-proxy": httpProxy, "--jump-cluster-https-proxy": httpsProxy} {
+		if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("invalid %s '%s': must be an http:// or https:// URL", flag, value)
+		}
+	}
+	if noProxy != "" && httpProxy == "" && httpsProxy == "" {
+		return fmt.Errorf("--jump-cluster-no-proxy requires --jump-cluster-http-proxy or --jump-cluster-https-proxy")
+	}
+	return nil
+}
+
+// readJumpClusterCABundle reads the --jump-cluster-ca-bundle PEM file.
+func readJumpClusterCABundle(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --jump-cluster-ca-bundle: %w", err)
+	}
+	bundle := strings.TrimSpace(string(data))
+	if !strings.Contains(bundle, "-----BEGIN CERTIFICATE-----") {
+		return "", fmt.Errorf("invalid --jump-cluster-ca-bundle '%s': no PEM certificate found", path)
+	}
+	return bundle, nil
+}
+
+// validateMonitoring checks --monitoring and --monitoring-alarm-topic-arn. The
+// dashboard and alarms are built on the AWS/Kafka CloudWatch metrics, which
+// only MSK publishes, and the jump cluster instances they watch are not
+// created by Terraform with the Ansible provisioner.
+func validateMonitoring(sourceType, provisioner string, enabled bool, alarmTopicArn string) error {
+	if alarmTopicArn != "" {
+		if !enabled {
+			return fmt.Errorf("--monitoring-alarm-topic-arn requires --monitoring")
+		}
+		if !strings.HasPrefix(alarmTopicArn, "arn:") || !strings.Contains(alarmTopicArn, ":sns:") {
+			return fmt.Errorf("invalid --monitoring-alarm-topic-arn '%s': must be the ARN of an SNS topic", alarmTopicArn)
+		}
+	}
+	if !enabled {
+		return nil
+	}
+	if source, _ := types.ParseSourceTypeFlag(sourceType); source != types.SourceTypeMSK {
+		return fmt.Errorf("--monitoring is only supported for --source-type msk: the dashboard and alarms use the AWS/Kafka CloudWatch metrics")
+	}
+	if provisioner == provisionerAnsible {
+		return fmt.Errorf("--monitoring is not supported with --jump-cluster-provisioner ansible")
+	}
+	return nil
+}
+
+// validateRefresh checks --refresh and --dry-run. The Ansible playbooks are
+// not tracked in the manifest, so a project with them cannot be refreshed.
+func validateRefresh(provisioner string, refresh, dryRun bool) error {
+	if dryRun && !refresh {
+		return fmt.Errorf("--dry-run requires --refresh")
+	}
+	if refresh && provisioner == provisionerAnsible {
+		return fmt.Errorf("--refresh is not supported with --jump-cluster-provisioner ansible")
+	}
+	return nil
+}
+
+// validateClusterLink checks --cluster-link-mode and --cluster-link-prefix.
+// Both only apply to the Type 1 cluster link. A bidirectional link needs a
+// source cluster that can host the reverse link, which MSK cannot.
+func validateClusterLink(targetType types.MigrationType, sourceType, mode, prefix string) error {
+	switch mode {
+	case clusterLinkModeDestination:
+	case clusterLinkModeBidirectional:
+		if targetType != types.PublicMskEndpoints {
+			return fmt.Errorf("--cluster-link-mode %s is only supported for type 1", mode)
+		}
+		if source, _ := types.ParseSourceTypeFlag(sourceType); source != types.SourceTypeOSK {
+			return fmt.Errorf("--cluster-link-mode %s requires --source-type apache-kafka: MSK cannot host the reverse cluster link, the source must run Confluent Platform 7.5 or later", mode)
+		}
+	default:
+		return fmt.Errorf("invalid --cluster-link-mode '%s': must be '%s' or '%s'", mode, clusterLinkModeDestination, clusterLinkModeBidirectional)
+	}
+
+	if prefix != "" && targetType != types.PublicMskEndpoints {
+		return fmt.Errorf("--cluster-link-prefix is only supported for type 1")
+	}
+
+	return nil
+}
+
+// validateClusterLinkTuning checks the cluster link tuning flags, which only
+// apply to the Type 1 cluster link.
+func validateClusterLinkTuning(targetType types.MigrationType, offsetSync bool, offsetSyncMs int, aclSync bool, autoCreateFilters []string) error {
+	if offsetSyncMs < 0 {
+		return fmt.Errorf("invalid --cluster-link-consumer-offset-sync-ms %d: must be positive", offsetSyncMs)
+	}
+	if offsetSyncMs > 0 && !offsetSync {
+		return fmt.Errorf("--cluster-link-consumer-offset-sync-ms requires --cluster-link-consumer-offset-sync")
+	}
+	for _, filter := range autoCreateFilters {
+		if strings.TrimSpace(filter) == "" {
+			return fmt.Errorf("invalid --cluster-link-auto-create-mirror-topics: topic filters must not be empty")
+		}
+	}
+
+	if (offsetSync || aclSync || len(autoCreateFilters) > 0) && targetType != types.PublicMskEndpoints {
+		return fmt.Errorf("the cluster link tuning flags are only supported for type 1")
+	}
+
+	return nil
+}
+
+// applyTopicNaming derives the Type 1 cluster link prefix from the topic
+// naming flags. A cluster link can only prepend one prefix to every mirror
+// topic, so a mapping that renames any scanned topic otherwise is rejected.
+func applyTopicNaming(request *hclrequests.MigrationWizardRequest, topics *types.Topics) error {
+	naming, err := topicNaming.Mapping()
+	if err != nil || naming.IsIdentity() {
+		return err
+	}
+
+	var names []string
+	if topics != nil {
+		for _, t := range topics.Details {
+			if !strings.HasPrefix(t.Name, "__") {
+				names = append(names, t.Name)
+			}
+		}
+	}
+	if err := naming.Validate(names); err != nil {
+		return fmt.Errorf("invalid topic naming: %w", err)
+	}
+	prefix, ok := naming.LinkPrefix(names)
+	if !ok {
+		return fmt.Errorf("the cluster link can only add a prefix to mirror topic names: rename topics with migrate-topics --mode new instead")
+	}
+	if request.ClusterLinkPrefix != "" && request.ClusterLinkPrefix != prefix {
+		return fmt.Errorf("--cluster-link-prefix %q conflicts with --topic-prefix %q", request.ClusterLinkPrefix, prefix)
+	}
+	request.ClusterLinkPrefix = prefix
+	return nil
+}
+
+// applyClusterLink copies the --cluster-link-mode and tuning inputs onto a
+// Type 1 request.
+func applyClusterLink(request *hclrequests.MigrationWizardRequest) {
+	request.ClusterLinkPrefix = clusterLinkPrefix
+	request.ClusterLinkConsumerOffsetSync = clusterLinkConsumerOffsetSync
+	request.ClusterLinkConsumerOffsetSyncMs = clusterLinkConsumerOffsetSyncMs
+	request.ClusterLinkAclSync = clusterLinkAclSync
+	request.ClusterLinkAutoCreateMirrorTopicFilters = clusterLinkAutoCreateMirrorTopicFilters
+	if clusterLinkMode == clusterLinkModeBidirectional {
+		request.ClusterLinkMode = hclrequests.ClusterLinkModeBidirectional
+		request.SourceRestEndpoint = sourceRestEndpoint
+		request.TargetBootstrapEndpoint = targetBootstrapEndpoint
+		return
+	}
+	request.ClusterLinkMode = hclrequests.ClusterLinkModeDestination
+}
+
+// applyJumpClusterInstances copies the --jump-cluster-ami,
+// --jump-cluster-spot-instances, CA bundle and proxy inputs onto a jump
+// cluster request.
+func applyJumpClusterInstances(request *hclrequests.MigrationWizardRequest) {
+	request.JumpClusterAmi = jumpClusterAmi
+	request.JumpClusterSpotInstances = jumpClusterSpotInstances
+	request.JumpClusterCABundle = jumpClusterCABundle
+	request.JumpClusterHTTPProxy = jumpClusterHTTPProxy
+	request.JumpClusterHTTPSProxy = jumpClusterHTTPSProxy
+	request.JumpClusterNoProxy = jumpClusterNoProxy
+}
+
+// applyConnectivity copies the --connectivity inputs onto a jump cluster
+// request. The Private Link endpoint is only used in privatelink mode.
+func applyConnectivity(request *hclrequests.MigrationWizardRequest) {
+	request.Connectivity = connectivity
+	if connectivity == hclrequests.ConnectivityPrivateLink {
+		request.ExistingPrivateLinkVpceId = existingPrivateLinkVpceId
+		return
+	}
+	request.ConfluentNetworkCidr = confluentNetworkCidr.String()
+	request.TransitGatewayId = transitGatewayId
+}
+
 // validateMigrationInfraDestination enforces the required --cc-type
 // declaration and refuses migration-infra entirely when targeting Confluent
 // Cloud for Government: every migration type relies on Cluster Linking, which
@@ -321,6 +774,8 @@ func runMigrationInfra(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse migration infra options: %w", err)
 	}
+	// Validated in preRunMigrationInfra.
+	opts.MigrationWizardRequest.Backend, _ = tfBackendFlags.TerraformBackend()
 
 	generator := NewMigrationInfraAssetGenerator(*opts)
 	if err := generator.Run(); err != nil {
@@ -385,9 +840,21 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 			TargetClusterId:          targetClusterId,
 			TargetRestEndpoint:       targetRestEndpoint,
 			SourceSaslScramMechanism: "SCRAM-SHA-512",
+
+			MonitoringEnabled:       monitoring,
+			SourceClusterName:       cluster.Name,
+			MonitoringAlarmTopicArn: monitoringAlarmTopicArn,
 		},
 		OutputDir:     outputDir,
 		MigrationType: targetType,
+		Provisioner:   jumpClusterProvisioner,
+		Validate:      validateTerraform,
+		Refresh:       refresh,
+		DryRun:        dryRun,
+
+		SourceSubnets:           cluster.AWSClientInformation.ClusterNetworking.Subnets,
+		SkipSubnetCapacityCheck: skipSubnetCapacityCheck,
+		CheckServiceQuotas:      checkServiceQuotas,
 	}
 
 	slog.Debug("using MSK default SASL/SCRAM mechanism", "mechanism", opts.MigrationWizardRequest.SourceSaslScramMechanism)
@@ -408,6 +875,13 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		opts.MigrationWizardRequest.UseJumpClusters = false
 
 		opts.MigrationWizardRequest.SourceSaslScramBootstrapServers = bootstrapBrokers
+		applyClusterLink(&opts.MigrationWizardRequest)
+		if err := applyTopicNaming(&opts.MigrationWizardRequest, cluster.KafkaAdminClientInformation.Topics); err != nil {
+			return nil, err
+		}
+		if err := applyTopicMappingFile(&opts.MigrationWizardRequest, cluster.KafkaAdminClientInformation.Topics); err != nil {
+			return nil, err
+		}
 
 	case types.ExternalOutboundClusterLink:
 		opts.MigrationWizardRequest.HasPublicEndpoints = false
@@ -481,7 +955,7 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		}
 
 		if jumpClusterInstanceType == "" {
-			jumpClusterInstanceType = strings.TrimPrefix(aws.ToString(cluster.AWSClientInformation.MskClusterConfig.Provisioned.BrokerNodeGroupInfo.InstanceType), "kafka.")
+			jumpClusterInstanceType = defaultJumpClusterInstanceType(state, cluster, linkThroughputMBps)
 		}
 
 		if jumpClusterBrokerStorage == 0 {
@@ -489,15 +963,17 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		}
 
 		opts.MigrationWizardRequest.TargetBootstrapEndpoint = targetBootstrapEndpoint
-		opts.MigrationWizardRequest.ExistingPrivateLinkVpceId = existingPrivateLinkVpceId
+		applyConnectivity(&opts.MigrationWizardRequest)
 
 		opts.MigrationWizardRequest.JumpClusterBrokerSubnetCidr = convertIpToStrings(jumpClusterBrokerSubnetCidr)
 		opts.MigrationWizardRequest.JumpClusterSetupHostSubnetCidr = jumpClusterSetupHostSubnetCidr.String()
 		opts.MigrationWizardRequest.JumpClusterInstanceType = jumpClusterInstanceType
 		opts.MigrationWizardRequest.JumpClusterBrokerStorage = jumpClusterBrokerStorage
+		applyJumpClusterInstances(&opts.MigrationWizardRequest)
 
 		opts.MigrationWizardRequest.JumpClusterAuthType = "sasl_scram"
 		opts.MigrationWizardRequest.SourceSaslScramBootstrapServers = bootstrapBrokers
+		opts.MigrationWizardRequest.SourceCACertificate = sourceCACertificate(cluster.Name, cluster.KafkaAdminClientInformation)
 
 	case types.JumpClusterIam:
 		opts.MigrationWizardRequest.HasPublicEndpoints = false
@@ -508,7 +984,7 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		}
 
 		if jumpClusterInstanceType == "" {
-			jumpClusterInstanceType = strings.TrimPrefix(aws.ToString(cluster.AWSClientInformation.MskClusterConfig.Provisioned.BrokerNodeGroupInfo.InstanceType), "kafka.")
+			jumpClusterInstanceType = defaultJumpClusterInstanceType(state, cluster, linkThroughputMBps)
 		}
 
 		if jumpClusterBrokerStorage == 0 {
@@ -516,12 +992,13 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		}
 
 		opts.MigrationWizardRequest.TargetBootstrapEndpoint = targetBootstrapEndpoint
-		opts.MigrationWizardRequest.ExistingPrivateLinkVpceId = existingPrivateLinkVpceId
+		applyConnectivity(&opts.MigrationWizardRequest)
 
 		opts.MigrationWizardRequest.JumpClusterBrokerSubnetCidr = convertIpToStrings(jumpClusterBrokerSubnetCidr)
 		opts.MigrationWizardRequest.JumpClusterSetupHostSubnetCidr = jumpClusterSetupHostSubnetCidr.String()
 		opts.MigrationWizardRequest.JumpClusterInstanceType = jumpClusterInstanceType
 		opts.MigrationWizardRequest.JumpClusterBrokerStorage = jumpClusterBrokerStorage
+		applyJumpClusterInstances(&opts.MigrationWizardRequest)
 
 		opts.MigrationWizardRequest.JumpClusterAuthType = "iam"
 		opts.MigrationWizardRequest.SourceSaslIamBootstrapServers = bootstrapBrokers
@@ -531,6 +1008,39 @@ func parseMSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 	return opts, nil
 }
 
+// defaultJumpClusterInstanceType sizes the jump cluster brokers, one per
+// MSK broker, for the bandwidth the cluster link needs: the larger of the
+// link throughput and the peak produce rate plus headroom. Without
+// partition sizes or produce metrics in the state file it falls back to
+// the MSK broker type.
+func defaultJumpClusterInstanceType(state types.State, cluster *types.DiscoveredCluster, linkThroughputMBps float64) string {
+	brokerType := strings.TrimPrefix(aws.ToString(cluster.AWSClientInformation.MskClusterConfig.Provisioned.BrokerNodeGroupInfo.InstanceType), "kafka.")
+
+	reportService := report.NewReportService()
+	metrics, err := reportService.FilterMetrics(reportService.ProcessState(state), cluster.Region, cluster.Name, nil, nil)
+	if err != nil {
+		slog.Debug("no processed metrics for jump cluster sizing", "cluster", cluster.Name, "error", err)
+		metrics = &types.ProcessedClusterMetrics{}
+	}
+	in, ok := linkbandwidth.NewInput(*metrics, cluster.KafkaAdminClientInformation, linkThroughputMBps, linkbandwidth.DefaultHeadroom)
+	if !ok {
+		slog.Info("no data volume or produce metrics to size the jump cluster from, using the MSK broker type", "instance_type", brokerType)
+		return brokerType
+	}
+
+	estimate := linkbandwidth.Calculate(in)
+	instanceType, fits := linkbandwidth.JumpClusterInstanceType(cluster.ClusterMetrics.MetricMetadata.NumberOfBrokerNodes, estimate.RequiredMBps)
+	if !fits {
+		slog.Warn("⚠️ no jump cluster instance type carries the bandwidth the cluster link needs, using the largest", "required_mbps", estimate.RequiredMBps, "instance_type", instanceType.Name)
+	}
+	slog.Info("sized jump cluster instance type from the cluster link bandwidth",
+		"instance_type", instanceType.Name,
+		"required_mbps", fmt.Sprintf("%.1f", estimate.RequiredMBps),
+		"steady_state_mbps", fmt.Sprintf("%.1f", estimate.SteadyStateMBps),
+		"can_catch_up", estimate.CanCatchUp)
+	return instanceType.Name
+}
+
 func parseOSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 	targetType, _ := types.ToMigrationType(migrationInfraType)
 
@@ -569,6 +1079,12 @@ func parseOSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		},
 		OutputDir:     outputDir,
 		MigrationType: targetType,
+		Provisioner:   jumpClusterProvisioner,
+		Validate:      validateTerraform,
+		Refresh:       refresh,
+		DryRun:        dryRun,
+
+		CheckServiceQuotas: checkServiceQuotas,
 	}
 
 	switch {
@@ -596,6 +1112,13 @@ func parseOSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		opts.MigrationWizardRequest.HasPublicEndpoints = true
 		opts.MigrationWizardRequest.UseJumpClusters = false
 		opts.MigrationWizardRequest.SourceSaslScramBootstrapServers = bootstrapServers
+		applyClusterLink(&opts.MigrationWizardRequest)
+		if err := applyTopicNaming(&opts.MigrationWizardRequest, oskCluster.KafkaAdminClientInformation.Topics); err != nil {
+			return nil, err
+		}
+		if err := applyTopicMappingFile(&opts.MigrationWizardRequest, oskCluster.KafkaAdminClientInformation.Topics); err != nil {
+			return nil, err
+		}
 
 	case types.ExternalOutboundClusterLink:
 		opts.MigrationWizardRequest.HasPublicEndpoints = false
@@ -627,18 +1150,36 @@ func parseOSKMigrationInfraOpts() (*MigrationInfraOpts, error) {
 		}
 		opts.MigrationWizardRequest.TargetEnvironmentId = targetEnvironmentId
 		opts.MigrationWizardRequest.TargetBootstrapEndpoint = targetBootstrapEndpoint
-		opts.MigrationWizardRequest.ExistingPrivateLinkVpceId = existingPrivateLinkVpceId
+		applyConnectivity(&opts.MigrationWizardRequest)
 		opts.MigrationWizardRequest.JumpClusterBrokerSubnetCidr = convertIpToStrings(jumpClusterBrokerSubnetCidr)
 		opts.MigrationWizardRequest.JumpClusterSetupHostSubnetCidr = jumpClusterSetupHostSubnetCidr.String()
 		opts.MigrationWizardRequest.JumpClusterInstanceType = jumpClusterInstanceType
 		opts.MigrationWizardRequest.JumpClusterBrokerStorage = jumpClusterBrokerStorage
+		applyJumpClusterInstances(&opts.MigrationWizardRequest)
 		opts.MigrationWizardRequest.JumpClusterAuthType = "sasl_scram"
 		opts.MigrationWizardRequest.SourceSaslScramBootstrapServers = bootstrapServers
+		opts.MigrationWizardRequest.SourceCACertificate = sourceCACertificate(oskCluster.ID, oskCluster.KafkaAdminClientInformation)
 	}
 
 	return opts, nil
 }
 
+// sourceCACertificate is the private CA bundle recorded by `kcp scan clusters
+// --inspect-tls` for the source cluster, which the jump cluster must trust to
+// reach the source brokers. Empty when the cluster wasn't inspected or its
+// brokers use a public CA.
+func sourceCACertificate(clusterName string, info types.KafkaAdminClientInformation) string {
+	if info.TLSInspection == nil {
+		slog.Debug("no TLS inspection in state; the jump cluster trusts the system CAs only", "cluster", clusterName)
+		return ""
+	}
+	bundle := info.TLSInspection.PrivateCABundle()
+	if bundle != "" {
+		fmt.Printf("✅ Source cluster '%s' brokers use a private CA; adding it to the jump cluster trust store\n", clusterName)
+	}
+	return bundle
+}
+
 func buildOSKExtOutboundBrokers(cluster *types.OSKDiscoveredCluster) ([]hclrequests.ExtOutboundClusterKafkaBroker, error) {
 	if len(cluster.BootstrapServers) == 0 {
 		return nil, fmt.Errorf("no bootstrap servers found for Apache Kafka cluster %s", cluster.ID)
@@ -3,6 +3,10 @@ package migration_infra
 import (
 	"strings"
 	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
 )
 
 // TestValidateMigrationInfraDestination covers the --cc-type gate. The gate is
@@ -100,3 +104,337 @@ func TestValidateMigrationInfraDestination(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateConnectivity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		targetType   types.MigrationType
+		connectivity string
+		wantErr      string // substring; empty means no error expected
+	}{
+		{name: "privatelink default on public type", targetType: types.PublicMskEndpoints, connectivity: "privatelink"},
+		{name: "peering on jump cluster sasl/scram", targetType: types.JumpClusterSaslScram, connectivity: "peering"},
+		{name: "transit gateway on jump cluster iam", targetType: types.JumpClusterIam, connectivity: "transit-gateway"},
+		{name: "peering rejected for external outbound", targetType: types.ExternalOutboundClusterLink, connectivity: "peering", wantErr: "only supported for jump cluster types"},
+		{name: "invalid value rejected", targetType: types.JumpClusterIam, connectivity: "vpn", wantErr: "invalid --connectivity"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateConnectivity(tt.targetType, tt.connectivity)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateConnectivity(%q) unexpected error: %v", tt.connectivity, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateConnectivity(%q) error = %v, want substring %q", tt.connectivity, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateJumpClusterInstances(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		targetType  types.MigrationType
+		ami         string
+		spot        bool
+		provisioner string
+		wantErr     string // substring; empty means no error expected
+	}{
+		{name: "rhel default on public type", targetType: types.PublicMskEndpoints, ami: "rhel", provisioner: "terraform"},
+		{name: "ubuntu spot on jump cluster iam", targetType: types.JumpClusterIam, ami: "ubuntu", spot: true, provisioner: "terraform"},
+		{name: "al2023 with ansible", targetType: types.JumpClusterSaslScram, ami: "al2023", provisioner: "ansible"},
+		{name: "invalid ami rejected", targetType: types.JumpClusterIam, ami: "debian", provisioner: "terraform", wantErr: "invalid --jump-cluster-ami"},
+		{name: "ami rejected for external outbound", targetType: types.ExternalOutboundClusterLink, ami: "ubuntu", provisioner: "terraform", wantErr: "only supported for jump cluster types"},
+		{name: "spot rejected for public type", targetType: types.PublicMskEndpoints, ami: "rhel", spot: true, provisioner: "terraform", wantErr: "only supported for jump cluster types"},
+		{name: "spot rejected with ansible", targetType: types.JumpClusterIam, ami: "rhel", spot: true, provisioner: "ansible", wantErr: "not supported with --jump-cluster-provisioner ansible"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateJumpClusterInstances(tt.targetType, tt.ami, tt.spot, tt.provisioner)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateJumpClusterInstances(%q, %v) unexpected error: %v", tt.ami, tt.spot, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateJumpClusterInstances(%q, %v) error = %v, want substring %q", tt.ami, tt.spot, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateJumpClusterEgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		targetType  types.MigrationType
+		caBundle    string
+		httpProxy   string
+		httpsProxy  string
+		noProxy     string
+		provisioner string
+		wantErr     string // substring; empty means no error expected
+	}{
+		{name: "nothing set on public type", targetType: types.PublicMskEndpoints, provisioner: "terraform"},
+		{name: "proxies and ca bundle on jump cluster", targetType: types.JumpClusterSaslScram, caBundle: "ca.pem", httpProxy: "http://proxy:3128", httpsProxy: "http://proxy:3128", noProxy: ".corp.example.com", provisioner: "terraform"},
+		{name: "rejected for external outbound", targetType: types.ExternalOutboundClusterLink, httpsProxy: "http://proxy:3128", provisioner: "terraform", wantErr: "only supported for jump cluster types"},
+		{name: "rejected with ansible", targetType: types.JumpClusterIam, caBundle: "ca.pem", provisioner: "ansible", wantErr: "not supported with --jump-cluster-provisioner ansible"},
+		{name: "proxy without scheme", targetType: types.JumpClusterIam, httpProxy: "proxy:3128", provisioner: "terraform", wantErr: "invalid --jump-cluster-http-proxy"},
+		{name: "no proxy without a proxy", targetType: types.JumpClusterIam, noProxy: ".corp.example.com", provisioner: "terraform", wantErr: "--jump-cluster-no-proxy requires"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateJumpClusterEgress(tt.targetType, tt.caBundle, tt.httpProxy, tt.httpsProxy, tt.noProxy, tt.provisioner)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateJumpClusterEgress() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateJumpClusterEgress() error = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateClusterLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		targetType types.MigrationType
+		sourceType string
+		mode       string
+		prefix     string
+		wantErr    string // substring; empty means no error expected
+	}{
+		{name: "destination default", targetType: types.PublicMskEndpoints, sourceType: "msk", mode: "destination"},
+		{name: "destination on jump cluster", targetType: types.JumpClusterSaslScram, sourceType: "msk", mode: "destination"},
+		{name: "bidirectional with apache kafka", targetType: types.PublicMskEndpoints, sourceType: "apache-kafka", mode: "bidirectional", prefix: "cp-"},
+		{name: "bidirectional rejected for msk", targetType: types.PublicMskEndpoints, sourceType: "msk", mode: "bidirectional", wantErr: "requires --source-type apache-kafka"},
+		{name: "bidirectional rejected for jump cluster", targetType: types.JumpClusterSaslScram, sourceType: "apache-kafka", mode: "bidirectional", wantErr: "only supported for type 1"},
+		{name: "prefix rejected for external outbound", targetType: types.ExternalOutboundClusterLink, sourceType: "msk", mode: "destination", prefix: "msk-", wantErr: "--cluster-link-prefix is only supported for type 1"},
+		{name: "invalid mode rejected", targetType: types.PublicMskEndpoints, sourceType: "msk", mode: "source", wantErr: "invalid --cluster-link-mode"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateClusterLink(tt.targetType, tt.sourceType, tt.mode, tt.prefix)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateClusterLink(%q) unexpected error: %v", tt.mode, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateClusterLink(%q) error = %v, want substring %q", tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateClusterLinkTuning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		targetType        types.MigrationType
+		offsetSync        bool
+		offsetSyncMs      int
+		aclSync           bool
+		autoCreateFilters []string
+		wantErr           string // substring; empty means no error expected
+	}{
+		{name: "defaults on jump cluster", targetType: types.JumpClusterSaslScram},
+		{name: "all options on type 1", targetType: types.PublicMskEndpoints, offsetSync: true, offsetSyncMs: 5000, aclSync: true, autoCreateFilters: []string{"orders", "payments.*"}},
+		{name: "interval without offset sync", targetType: types.PublicMskEndpoints, offsetSyncMs: 5000, wantErr: "requires --cluster-link-consumer-offset-sync"},
+		{name: "negative interval", targetType: types.PublicMskEndpoints, offsetSync: true, offsetSyncMs: -1, wantErr: "must be positive"},
+		{name: "empty topic filter", targetType: types.PublicMskEndpoints, autoCreateFilters: []string{" "}, wantErr: "must not be empty"},
+		{name: "acl sync rejected for jump cluster", targetType: types.JumpClusterIam, aclSync: true, wantErr: "only supported for type 1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateClusterLinkTuning(tt.targetType, tt.offsetSync, tt.offsetSyncMs, tt.aclSync, tt.autoCreateFilters)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateClusterLinkTuning() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateClusterLinkTuning() error = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMonitoring(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		sourceType    string
+		provisioner   string
+		enabled       bool
+		alarmTopicArn string
+		wantErr       string // substring; empty means no error expected
+	}{
+		{name: "disabled", sourceType: "apache-kafka", provisioner: provisionerAnsible},
+		{name: "msk with alarm topic", sourceType: "msk", provisioner: provisionerTerraform, enabled: true, alarmTopicArn: "arn:aws:sns:us-east-1:123456789012:alerts"},
+		{name: "apache kafka source", sourceType: "apache-kafka", provisioner: provisionerTerraform, enabled: true, wantErr: "only supported for --source-type msk"},
+		{name: "ansible provisioner", sourceType: "msk", provisioner: provisionerAnsible, enabled: true, wantErr: "not supported with --jump-cluster-provisioner ansible"},
+		{name: "alarm topic without monitoring", sourceType: "msk", provisioner: provisionerTerraform, alarmTopicArn: "arn:aws:sns:us-east-1:123456789012:alerts", wantErr: "requires --monitoring"},
+		{name: "alarm topic not an SNS ARN", sourceType: "msk", provisioner: provisionerTerraform, enabled: true, alarmTopicArn: "alerts", wantErr: "must be the ARN of an SNS topic"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateMonitoring(tt.sourceType, tt.provisioner, tt.enabled, tt.alarmTopicArn)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateMonitoring() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateMonitoring() error = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRefresh(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		provisioner string
+		refresh     bool
+		dryRun      bool
+		wantErr     string // substring; empty means no error expected
+	}{
+		{name: "no refresh", provisioner: provisionerAnsible},
+		{name: "refresh dry run", provisioner: provisionerTerraform, refresh: true, dryRun: true},
+		{name: "dry run without refresh", provisioner: provisionerTerraform, dryRun: true, wantErr: "--dry-run requires --refresh"},
+		{name: "ansible provisioner", provisioner: provisionerAnsible, refresh: true, wantErr: "not supported with --jump-cluster-provisioner ansible"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateRefresh(tt.provisioner, tt.refresh, tt.dryRun)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateRefresh() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateRefresh() error = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyTopicNaming(t *testing.T) {
+	t.Cleanup(func() { topicNaming = utils.TopicNamingFlags{} })
+	topics := &types.Topics{Details: []types.TopicDetails{{Name: "orders"}, {Name: "events"}, {Name: "__consumer_offsets"}}}
+
+	tests := []struct {
+		name       string
+		naming     utils.TopicNamingFlags
+		linkPrefix string
+		want       string
+		wantErr    string
+	}{
+		{name: "no naming keeps the link prefix", linkPrefix: "src.", want: "src."},
+		{name: "topic prefix becomes the link prefix", naming: utils.TopicNamingFlags{Prefix: "msk."}, want: "msk."},
+		{name: "matching link prefix", naming: utils.TopicNamingFlags{Prefix: "msk."}, linkPrefix: "msk.", want: "msk."},
+		{name: "conflicting link prefix", naming: utils.TopicNamingFlags{Prefix: "msk."}, linkPrefix: "src.", wantErr: "conflicts with --topic-prefix"},
+		{name: "rename is not a prefix", naming: utils.TopicNamingFlags{Renames: map[string]string{"orders": "orders.v1"}}, wantErr: "can only add a prefix"},
+		{name: "policy violation", naming: utils.TopicNamingFlags{Prefix: "msk-", Policy: `msk\..+`}, wantErr: "do not match the naming policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topicNaming = tt.naming
+			request := hclrequests.MigrationWizardRequest{ClusterLinkPrefix: tt.linkPrefix}
+			err := applyTopicNaming(&request, topics)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("applyTopicNaming() error = %v, want substring %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyTopicNaming() unexpected error: %v", err)
+			}
+			if request.ClusterLinkPrefix != tt.want {
+				t.Errorf("ClusterLinkPrefix = %q, want %q", request.ClusterLinkPrefix, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceQuotaDemand(t *testing.T) {
+	t.Parallel()
+
+	jump := serviceQuotaDemand(hclrequests.MigrationWizardRequest{
+		UseJumpClusters:             true,
+		VpcId:                       "vpc-123",
+		JumpClusterInstanceType:     "m5.xlarge",
+		JumpClusterSpotInstances:    true,
+		JumpClusterBrokerSubnetCidr: []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+	})
+	if len(jump.Instances) != 4 {
+		t.Fatalf("jump cluster instances = %d, want 4", len(jump.Instances))
+	}
+	for _, instance := range jump.Instances[:3] {
+		if instance.InstanceType != "m5.xlarge" || !instance.Spot {
+			t.Errorf("broker instance = %+v, want spot m5.xlarge", instance)
+		}
+	}
+	if setup := jump.Instances[3]; setup.InstanceType != "t2.medium" || setup.Spot {
+		t.Errorf("setup host instance = %+v, want on-demand t2.medium", setup)
+	}
+	if jump.ElasticIPs != 1 || jump.NetworkInterfaces != 5 || jump.VpcID != "vpc-123" {
+		t.Errorf("jump cluster demand = %+v, want 1 Elastic IP and 5 network interfaces in vpc-123", jump)
+	}
+
+	external := serviceQuotaDemand(hclrequests.MigrationWizardRequest{ExtOutboundSubnetId: "subnet-1", VpcId: "vpc-123"})
+	if len(external.Instances) != 1 || external.NetworkInterfaces != 1 || external.ElasticIPs != 0 {
+		t.Errorf("external outbound demand = %+v, want 1 instance and 1 network interface", external)
+	}
+
+	public := serviceQuotaDemand(hclrequests.MigrationWizardRequest{})
+	if len(public.Instances) != 0 || public.NetworkInterfaces != 0 || public.ElasticIPs != 0 {
+		t.Errorf("public demand = %+v, want empty", public)
+	}
+}
@@ -264,13 +264,40 @@ var type5EnterpriseAdditions = iampolicy.Union(
 // variants share the same policy value.
 var type5DedicatedAdditions = type5EnterpriseAdditions
 
+// monitoringAdditions — the CloudWatch dashboard and alarms added by
+// --monitoring, on top of any type (including Type 1, which otherwise needs
+// no AWS permissions). Not an iamlive capture: derived from the
+// aws_cloudwatch_dashboard and aws_cloudwatch_metric_alarm resources.
+var monitoringAdditions = iampolicy.Union([]string{
+	"cloudwatch:DeleteAlarms",
+	"cloudwatch:DeleteDashboards",
+	"cloudwatch:DescribeAlarms",
+	"cloudwatch:GetDashboard",
+	"cloudwatch:ListTagsForResource",
+	"cloudwatch:PutDashboard",
+	"cloudwatch:PutMetricAlarm",
+})
+
+// serviceQuotaCheckAdditions — read by kcp itself, not by Terraform, when
+// --check-service-quotas compares the generated resources with the region's
+// Service Quotas and current EC2 usage.
+var serviceQuotaCheckAdditions = iampolicy.Union([]string{
+	"ec2:DescribeAddresses",
+	"ec2:DescribeInstanceTypes",
+	"ec2:DescribeInstances",
+	"ec2:DescribeNetworkInterfaces",
+	"ec2:DescribeVpcEndpoints",
+	"servicequotas:GetAWSDefaultServiceQuota",
+	"servicequotas:GetServiceQuota",
+})
+
 // ---------------------------------------------------------------------------
 // Annotation assembly.
 // ---------------------------------------------------------------------------
 
 const migrationInfraIAMIntro = "`kcp create-asset migration-infra` itself only reads local configuration. " +
 	"The AWS IAM policy required to `terraform apply` / `terraform destroy` the generated output depends on `--type` and (for Types 4 & 5) the target cluster type. " +
-	"**Type 1** (public MSK + Confluent Cluster Link) provisions only Confluent Cloud resources and needs no AWS IAM permissions. " +
+	"**Type 1** (public MSK + Confluent Cluster Link) provisions only Confluent Cloud resources and needs no AWS IAM permissions unless `--monitoring` is set. " +
 	"For Types 2–5, apply the base policy below plus the matching variant block.\n\n" +
 	"!!! warning \"Scope down for production\"\n\n" +
 	"    The policies below use `\"Resource\": \"*\"` and include destructive actions (e.g. `ec2:TerminateInstances`, `ec2:DeleteNatGateway`, `elasticloadbalancing:DeleteLoadBalancer`). Narrow each statement to specific ARNs or `aws:ResourceTag` conditions before granting this policy to a CI/CD or pipeline role."
@@ -310,6 +337,16 @@ func iamAnnotation() string {
 				Summary:   "Jump cluster with IAM authentication to MSK, via Confluent PrivateLink to a Dedicated target. Same IAM-role caveat as the Enterprise Type 5 variant.",
 				Additions: type5DedicatedAdditions,
 			},
+			{
+				FlagHint:  "--monitoring (any type, MSK only)",
+				Summary:   "CloudWatch dashboard and alarms for the migration period. Add these actions to the policy of the selected type; Type 1 needs only these and `sts:GetCallerIdentity`.",
+				Additions: monitoringAdditions,
+			},
+			{
+				FlagHint:  "--check-service-quotas (any type)",
+				Summary:   "Read by `kcp create-asset migration-infra` itself, before generating anything, to compare the jump cluster or cluster link host resources with the region's Service Quotas and current usage.",
+				Additions: serviceQuotaCheckAdditions,
+			},
 		},
 	)
 }
@@ -290,6 +290,7 @@ func TestMigrationInfraFragmentsDisjointFromBase(t *testing.T) {
 		"type-4-dedicated":  type4DedicatedAdditions,
 		"type-5-enterprise": type5EnterpriseAdditions,
 		"type-5-dedicated":  type5DedicatedAdditions,
+		"monitoring":        monitoringAdditions,
 	} {
 		if overlap := iampolicy.Overlap(migrationInfraBase, additions); len(overlap) > 0 {
 			t.Errorf("%s additions overlap base: %v", name, overlap)
@@ -1,21 +1,50 @@
 package migration_infra
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 
+	"github.com/confluentinc/kcp/internal/services/ansible"
 	"github.com/confluentinc/kcp/internal/services/hcl"
+	hclaws "github.com/confluentinc/kcp/internal/services/hcl/aws"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/managedfiles"
+	"github.com/confluentinc/kcp/internal/services/service_quotas"
+	"github.com/confluentinc/kcp/internal/services/subnet_capacity"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 )
 
+const (
+	provisionerTerraform = "terraform"
+	provisionerAnsible   = "ansible"
+)
+
 type MigrationInfraOpts struct {
 	MigrationWizardRequest hclrequests.MigrationWizardRequest
 
 	OutputDir     string
 	MigrationType types.MigrationType
+	// Provisioner selects how jump cluster EC2 instances are created:
+	// "terraform" (default) or "ansible".
+	Provisioner string
+	// Validate is the hcl.Validate* mode the written Terraform is checked
+	// with.
+	Validate string
+	// Refresh regenerates the project in an existing OutputDir, keeping the
+	// files the user edited or added; DryRun only prints the changes.
+	Refresh bool
+	DryRun  bool
+	// SourceSubnets are the scanned subnets of the source cluster's brokers,
+	// one entry per broker. Nil for Apache Kafka sources.
+	SourceSubnets           []types.SubnetInfo
+	SkipSubnetCapacityCheck bool
+	// CheckServiceQuotas queries AWS Service Quotas for the region the
+	// infrastructure is created in before anything is written.
+	CheckServiceQuotas bool
 }
 
 type MigrationInfraAssetGenerator struct {
@@ -23,6 +52,14 @@ type MigrationInfraAssetGenerator struct {
 
 	outputDir     string
 	migrationType types.MigrationType
+	provisioner   string
+	validate      string
+	refresh       bool
+	dryRun        bool
+
+	sourceSubnets           []types.SubnetInfo
+	skipSubnetCapacityCheck bool
+	checkServiceQuotas      bool
 }
 
 func NewMigrationInfraAssetGenerator(opts MigrationInfraOpts) *MigrationInfraAssetGenerator {
@@ -30,16 +67,34 @@ func NewMigrationInfraAssetGenerator(opts MigrationInfraOpts) *MigrationInfraAss
 		MigrationWizardRequest: opts.MigrationWizardRequest,
 		outputDir:              opts.OutputDir,
 		migrationType:          opts.MigrationType,
+		provisioner:            opts.Provisioner,
+		validate:               opts.Validate,
+		refresh:                opts.Refresh,
+		dryRun:                 opts.DryRun,
+
+		sourceSubnets:           opts.SourceSubnets,
+		skipSubnetCapacityCheck: opts.SkipSubnetCapacityCheck,
+		checkServiceQuotas:      opts.CheckServiceQuotas,
 	}
 }
 
 func (mi *MigrationInfraAssetGenerator) Run() error {
 	fmt.Printf("🚀 Generating migration infrastructure (type: %v)\n", mi.migrationType)
 
+	if err := mi.checkSubnetCapacity(); err != nil {
+		return err
+	}
+	if err := mi.checkServiceQuotaHeadroom(); err != nil {
+		return err
+	}
+
 	outputDir := mi.outputDir
 	if outputDir == "" {
 		outputDir = "migration-infra"
 	}
+	if mi.refresh {
+		return mi.runRefresh(outputDir)
+	}
 	if err := utils.ValidateOutputDir(outputDir); err != nil {
 		return err
 	}
@@ -48,6 +103,10 @@ func (mi *MigrationInfraAssetGenerator) Run() error {
 		return fmt.Errorf("failed to create migration-infra directory: %w", err)
 	}
 
+	if mi.provisioner == provisionerAnsible && mi.MigrationWizardRequest.UseJumpClusters {
+		return mi.runWithAnsible(outputDir)
+	}
+
 	slog.Debug("generating Terraform configuration")
 	hclService := hcl.NewMigrationInfraHCLService()
 	project := hclService.GenerateTerraformModules(mi.MigrationWizardRequest)
@@ -55,7 +114,183 @@ func (mi *MigrationInfraAssetGenerator) Run() error {
 	if err := hcl.WriteTerraformProject(outputDir, project); err != nil {
 		return fmt.Errorf("failed to write Terraform project: %w", err)
 	}
+	if err := mi.validateTerraform(outputDir); err != nil {
+		return err
+	}
 
 	fmt.Printf("✅ Migration infrastructure generated: %s\n", outputDir)
 	return nil
 }
+
+// runRefresh regenerates the Terraform project in outputDir in place. It is
+// rejected with the Ansible provisioner in preRunMigrationInfra.
+func (mi *MigrationInfraAssetGenerator) runRefresh(outputDir string) error {
+	slog.Debug("generating Terraform configuration")
+	hclService := hcl.NewMigrationInfraHCLService()
+	project := hclService.GenerateTerraformModules(mi.MigrationWizardRequest)
+
+	changes, err := hcl.RefreshTerraformProject(outputDir, project, mi.dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to refresh Terraform project: %w", err)
+	}
+	managedfiles.PrintChanges(changes, mi.dryRun)
+	if mi.dryRun {
+		fmt.Printf("✅ Dry run complete, nothing written: %s\n", outputDir)
+		return nil
+	}
+	if err := mi.validateTerraform(outputDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Migration infrastructure refreshed: %s\n", outputDir)
+	return nil
+}
+
+// runWithAnsible writes the networking-only Terraform project to outputDir and
+// the jump cluster playbooks to outputDir/ansible.
+func (mi *MigrationInfraAssetGenerator) runWithAnsible(outputDir string) error {
+	slog.Debug("generating networking Terraform configuration")
+	hclService := hcl.NewMigrationInfraHCLService()
+	project := hclService.GenerateNetworkingOnlyTerraformModules(mi.MigrationWizardRequest)
+
+	if err := hcl.WriteTerraformProject(outputDir, project); err != nil {
+		return fmt.Errorf("failed to write Terraform project: %w", err)
+	}
+	if err := mi.validateTerraform(outputDir); err != nil {
+		return err
+	}
+
+	slog.Debug("generating Ansible playbooks")
+	ansibleProject, err := ansible.NewAnsibleService().GenerateJumpClusterProject(mi.MigrationWizardRequest)
+	if err != nil {
+		return fmt.Errorf("failed to generate Ansible playbooks: %w", err)
+	}
+
+	ansibleDir := filepath.Join(outputDir, "ansible")
+	if err := ansible.WriteAnsibleProject(ansibleDir, ansibleProject); err != nil {
+		return fmt.Errorf("failed to write Ansible playbooks: %w", err)
+	}
+
+	fmt.Printf("✅ Migration infrastructure generated: %s (Terraform networking) and %s (Ansible jump cluster)\n", outputDir, ansibleDir)
+	return nil
+}
+
+// validateTerraform checks the Terraform written to outputDir with the
+// selected validation mode.
+func (mi *MigrationInfraAssetGenerator) validateTerraform(outputDir string) error {
+	slog.Debug("validating Terraform configuration", "mode", mi.validate)
+	if err := hcl.ValidateProject(context.Background(), outputDir, mi.validate, utils.CombinedExecRunner); err != nil {
+		return fmt.Errorf("generated Terraform in %s failed validation: %w", outputDir, err)
+	}
+	return nil
+}
+
+// checkSubnetCapacity reports subnet and availability zone problems that
+// would otherwise only surface during `terraform apply`. Errors block
+// generation unless the check is skipped; warnings are printed only.
+func (mi *MigrationInfraAssetGenerator) checkSubnetCapacity() error {
+	if mi.sourceSubnets == nil {
+		slog.Debug("no scanned subnets for source cluster, skipping subnet capacity check")
+		return nil
+	}
+
+	request := mi.MigrationWizardRequest
+	findings := subnet_capacity.CheckBrokerAZDistribution(mi.sourceSubnets)
+	if request.UseJumpClusters {
+		// One ENI per jump cluster broker; the setup host subnet also holds the NAT gateway.
+		findings = append(findings, subnet_capacity.CheckNewSubnets(request.JumpClusterBrokerSubnetCidr, 1, "jump cluster broker", mi.sourceSubnets)...)
+		findings = append(findings, subnet_capacity.CheckNewSubnets([]string{request.JumpClusterSetupHostSubnetCidr}, 2, "jump cluster setup host", mi.sourceSubnets)...)
+	} else if request.ExtOutboundSubnetId != "" {
+		findings = append(findings, subnet_capacity.CheckExistingSubnet(mi.sourceSubnets, request.ExtOutboundSubnetId, 1, "external outbound cluster link host")...)
+	}
+
+	for _, finding := range findings {
+		if finding.Severity == subnet_capacity.SeverityError {
+			fmt.Printf("❌ %s\n", finding.Message)
+		} else {
+			fmt.Printf("⚠️ %s\n", finding.Message)
+		}
+	}
+
+	if subnet_capacity.HasErrors(findings) {
+		if mi.skipSubnetCapacityCheck {
+			fmt.Println("⏭️ Continuing despite subnet capacity errors (--skip-subnet-capacity-check)")
+			return nil
+		}
+		return fmt.Errorf("subnet capacity check failed: fix the subnet CIDRs, re-run `kcp discover` if the state file is stale, or pass --skip-subnet-capacity-check")
+	}
+	return nil
+}
+
+// serviceQuotaDemand is what the generated infrastructure takes out of the
+// region's Service Quotas. Jump clusters launch the brokers and the setup
+// host, whose subnet holds a NAT gateway with an Elastic IP; the external
+// outbound types launch one cluster link host. Type 1 creates no AWS
+// resources.
+func serviceQuotaDemand(request hclrequests.MigrationWizardRequest) service_quotas.Demand {
+	if request.UseJumpClusters {
+		brokers := len(request.JumpClusterBrokerSubnetCidr)
+		demand := service_quotas.Demand{
+			ElasticIPs: 1,
+			// One per broker and setup host, plus the NAT gateway's.
+			NetworkInterfaces: brokers + 2,
+			VpcID:             request.VpcId,
+		}
+		for range brokers {
+			demand.Instances = append(demand.Instances, service_quotas.Instance{InstanceType: request.JumpClusterInstanceType, Spot: request.JumpClusterSpotInstances})
+		}
+		demand.Instances = append(demand.Instances, service_quotas.Instance{InstanceType: hclaws.HelperInstanceType})
+		return demand
+	}
+	if request.ExtOutboundSubnetId != "" {
+		return service_quotas.Demand{
+			Instances:         []service_quotas.Instance{{InstanceType: hclaws.HelperInstanceType}},
+			NetworkInterfaces: 1,
+			VpcID:             request.VpcId,
+		}
+	}
+	return service_quotas.Demand{}
+}
+
+// checkServiceQuotaHeadroom reports, per quota, whether the instances, Elastic IPs
+// and network interfaces of the generated infrastructure fit in what is left
+// of the region's Service Quotas. Quotas that would be exceeded block
+// generation; quotas that cannot be read are printed as warnings.
+func (mi *MigrationInfraAssetGenerator) checkServiceQuotaHeadroom() error {
+	if !mi.checkServiceQuotas {
+		return nil
+	}
+	demand := serviceQuotaDemand(mi.MigrationWizardRequest)
+	if len(demand.Instances) == 0 && demand.NetworkInterfaces == 0 {
+		fmt.Println("⏭️ No AWS resources to check Service Quotas for")
+		return nil
+	}
+
+	region := mi.MigrationWizardRequest.SourceRegion
+	checker, err := service_quotas.NewRegionChecker(region)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Checking Service Quotas in %s\n", region)
+	findings := checker.Check(context.Background(), demand)
+	printServiceQuotaFindings(findings)
+
+	if service_quotas.HasErrors(findings) {
+		return fmt.Errorf("service quota check failed: request the quota increases above, or free up capacity in %s", region)
+	}
+	return nil
+}
+
+func printServiceQuotaFindings(findings []service_quotas.Finding) {
+	for _, finding := range findings {
+		switch finding.Severity {
+		case service_quotas.SeverityError:
+			fmt.Printf("❌ %s\n", finding.Message)
+		case service_quotas.SeverityWarning:
+			fmt.Printf("⚠️ %s\n", finding.Message)
+		default:
+			fmt.Printf("✅ %s\n", finding.Message)
+		}
+	}
+}
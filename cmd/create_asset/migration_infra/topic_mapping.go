@@ -0,0 +1,206 @@
+package migration_infra
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/goccy/go-yaml"
+)
+
+// topicMapping is the --topic-mapping-file: the Confluent Cloud clusters a
+// Type 1 source cluster's topics are split across. A destination whose
+// target_cluster_id is --target-cluster-id only assigns topics to the main
+// cluster link; every other destination gets a cluster link of its own.
+//
+//	destinations:
+//	  - name: payments
+//	    target_cluster_id: lkc-pay123
+//	    target_rest_endpoint: https://lkc-pay123.us-east-1.aws.confluent.cloud:443
+//	    cluster_link_name: msk-to-payments
+//	    topics: ["payments.*", refunds]
+type topicMapping struct {
+	Destinations []topicMappingDestination `yaml:"destinations"`
+}
+
+// topicMappingDestination is one destination cluster. Name defaults to the
+// cluster ID and ClusterLinkName to --cluster-link-name. Topics are literal
+// names or prefixes ending in `*`, as for
+// --cluster-link-auto-create-mirror-topics.
+type topicMappingDestination struct {
+	Name                    string   `yaml:"name"`
+	TargetClusterID         string   `yaml:"target_cluster_id"`
+	TargetRestEndpoint      string   `yaml:"target_rest_endpoint"`
+	TargetBootstrapEndpoint string   `yaml:"target_bootstrap_endpoint"`
+	ClusterLinkName         string   `yaml:"cluster_link_name"`
+	Topics                  []string `yaml:"topics"`
+}
+
+// destinationNamePattern keeps destination names valid in Terraform module
+// and variable names.
+var destinationNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func loadTopicMapping(path string) (*topicMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topic mapping file %s: %w", path, err)
+	}
+	var mapping topicMapping
+	if err := yaml.UnmarshalWithOptions(data, &mapping, yaml.DisallowUnknownField()); err != nil {
+		return nil, fmt.Errorf("failed to parse topic mapping file %s: %w", path, err)
+	}
+	if len(mapping.Destinations) == 0 {
+		return nil, fmt.Errorf("topic mapping file %s has no destinations", path)
+	}
+	return &mapping, nil
+}
+
+// applyTopicMapping splits a Type 1 request's topics across the mapping's
+// destinations. Every topic can only be mirrored to one destination, so
+// topic filters of different destinations must not overlap.
+func applyTopicMapping(request *hclrequests.MigrationWizardRequest, mapping *topicMapping) error {
+	clusters := map[string]bool{request.TargetClusterId: true}
+	names := make(map[string]bool)
+	for i, d := range mapping.Destinations {
+		if d.TargetClusterID == "" {
+			return fmt.Errorf("topic mapping destination %d has no target_cluster_id", i+1)
+		}
+		if len(d.Topics) == 0 {
+			return fmt.Errorf("topic mapping destination %s has no topics", d.TargetClusterID)
+		}
+		for _, topic := range d.Topics {
+			if strings.TrimSpace(topic) == "" {
+				return fmt.Errorf("topic mapping destination %s: topics must not be empty", d.TargetClusterID)
+			}
+		}
+
+		if d.TargetClusterID == request.TargetClusterId {
+			request.ClusterLinkAutoCreateMirrorTopicFilters = append(request.ClusterLinkAutoCreateMirrorTopicFilters, d.Topics...)
+			continue
+		}
+		if clusters[d.TargetClusterID] {
+			return fmt.Errorf("topic mapping lists destination %s more than once", d.TargetClusterID)
+		}
+		clusters[d.TargetClusterID] = true
+
+		destination := hclrequests.ClusterLinkDestination{
+			Name:                    d.Name,
+			TargetClusterId:         d.TargetClusterID,
+			TargetRestEndpoint:      d.TargetRestEndpoint,
+			TargetBootstrapEndpoint: d.TargetBootstrapEndpoint,
+			ClusterLinkName:         d.ClusterLinkName,
+			TopicFilters:            d.Topics,
+		}
+		if destination.Name == "" {
+			destination.Name = strings.ReplaceAll(d.TargetClusterID, "-", "_")
+		}
+		if !destinationNamePattern.MatchString(destination.Name) {
+			return fmt.Errorf("invalid topic mapping destination name %q: must start with a letter and contain only letters, digits and underscores", destination.Name)
+		}
+		if names[destination.Name] {
+			return fmt.Errorf("topic mapping lists destination name %q more than once", destination.Name)
+		}
+		names[destination.Name] = true
+		if destination.ClusterLinkName == "" {
+			destination.ClusterLinkName = request.ClusterLinkName
+		}
+		if destination.TargetRestEndpoint == "" {
+			return fmt.Errorf("topic mapping destination %s has no target_rest_endpoint", d.TargetClusterID)
+		}
+		if request.IsBidirectionalLink() && destination.TargetBootstrapEndpoint == "" {
+			return fmt.Errorf("topic mapping destination %s has no target_bootstrap_endpoint, which bidirectional cluster links need", d.TargetClusterID)
+		}
+		request.AdditionalDestinations = append(request.AdditionalDestinations, destination)
+	}
+
+	return checkTopicFilterOverlap(*request)
+}
+
+// checkTopicFilterOverlap rejects a topic that more than one cluster link
+// would mirror.
+func checkTopicFilterOverlap(request hclrequests.MigrationWizardRequest) error {
+	type linkFilter struct{ cluster, filter string }
+	var filters []linkFilter
+	for _, filter := range request.ClusterLinkAutoCreateMirrorTopicFilters {
+		filters = append(filters, linkFilter{request.TargetClusterId, filter})
+	}
+	for _, destination := range request.AdditionalDestinations {
+		for _, filter := range destination.TopicFilters {
+			filters = append(filters, linkFilter{destination.TargetClusterId, filter})
+		}
+	}
+
+	for i, a := range filters {
+		for _, b := range filters[i+1:] {
+			if a.cluster != b.cluster && topicFiltersOverlap(a.filter, b.filter) {
+				return fmt.Errorf("topic %q for %s overlaps %q for %s: each topic can only be mirrored to one destination cluster", a.filter, a.cluster, b.filter, b.cluster)
+			}
+		}
+	}
+	return nil
+}
+
+// topicFiltersOverlap reports whether some topic name matches both filters.
+func topicFiltersOverlap(a, b string) bool {
+	aPrefix, aIsPrefix := strings.CutSuffix(a, "*")
+	bPrefix, bIsPrefix := strings.CutSuffix(b, "*")
+	switch {
+	case aIsPrefix && bIsPrefix:
+		return strings.HasPrefix(aPrefix, bPrefix) || strings.HasPrefix(bPrefix, aPrefix)
+	case aIsPrefix:
+		return strings.HasPrefix(b, aPrefix)
+	case bIsPrefix:
+		return strings.HasPrefix(a, bPrefix)
+	default:
+		return a == b
+	}
+}
+
+// warnUnmappedTopics warns about scanned topics that no cluster link of a
+// split migration mirrors, which would otherwise be left behind unnoticed.
+func warnUnmappedTopics(request hclrequests.MigrationWizardRequest, topics *types.Topics) {
+	if len(request.AdditionalDestinations) == 0 || topics == nil {
+		return
+	}
+
+	filters := slices.Clone(request.ClusterLinkAutoCreateMirrorTopicFilters)
+	for _, destination := range request.AdditionalDestinations {
+		filters = append(filters, destination.TopicFilters...)
+	}
+
+	var unmapped []string
+	for _, topic := range topics.Details {
+		if strings.HasPrefix(topic.Name, "__") {
+			continue
+		}
+		if !slices.ContainsFunc(filters, func(filter string) bool { return topicFiltersOverlap(filter, topic.Name) }) {
+			unmapped = append(unmapped, topic.Name)
+		}
+	}
+	if len(unmapped) > 0 {
+		slices.Sort(unmapped)
+		slog.Warn("⚠️ some scanned topics are not mapped to any destination cluster and will not be mirrored automatically", "count", len(unmapped), "topics", strings.Join(unmapped[:min(len(unmapped), 10)], ","))
+	}
+}
+
+// applyTopicMappingFile applies --topic-mapping-file, if set, to a Type 1
+// request for a cluster with the given scanned topics.
+func applyTopicMappingFile(request *hclrequests.MigrationWizardRequest, topics *types.Topics) error {
+	if topicMappingFile == "" {
+		return nil
+	}
+	mapping, err := loadTopicMapping(topicMappingFile)
+	if err != nil {
+		return err
+	}
+	if err := applyTopicMapping(request, mapping); err != nil {
+		return fmt.Errorf("invalid --topic-mapping-file: %w", err)
+	}
+	warnUnmappedTopics(*request, topics)
+	return nil
+}
@@ -0,0 +1,113 @@
+package migration_infra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+)
+
+func TestTopicFiltersOverlap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"orders", "orders", true},
+		{"orders", "payments", false},
+		{"orders.*", "orders.eu", true},
+		{"orders.*", "order", false},
+		{"orders.*", "orders.eu.*", true},
+		{"orders.*", "payments.*", false},
+		{"*", "payments", true},
+	}
+	for _, tt := range tests {
+		if got := topicFiltersOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("topicFiltersOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := topicFiltersOverlap(tt.b, tt.a); got != tt.want {
+			t.Errorf("topicFiltersOverlap(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTopicMapping(t *testing.T) {
+	t.Parallel()
+
+	payments := topicMappingDestination{TargetClusterID: "lkc-pay123", TargetRestEndpoint: "https://lkc-pay123:443", Topics: []string{"payments.*"}}
+
+	tests := []struct {
+		name          string
+		destinations  []topicMappingDestination
+		bidirectional bool
+		wantErr       string // substring; empty means no error expected
+	}{
+		{name: "main and additional destination", destinations: []topicMappingDestination{{TargetClusterID: "lkc-main", Topics: []string{"orders"}}, payments}},
+		{name: "missing cluster id", destinations: []topicMappingDestination{{Topics: []string{"orders"}}}, wantErr: "has no target_cluster_id"},
+		{name: "missing topics", destinations: []topicMappingDestination{{TargetClusterID: "lkc-pay123"}}, wantErr: "has no topics"},
+		{name: "missing rest endpoint", destinations: []topicMappingDestination{{TargetClusterID: "lkc-pay123", Topics: []string{"payments"}}}, wantErr: "has no target_rest_endpoint"},
+		{name: "bidirectional needs bootstrap", destinations: []topicMappingDestination{payments}, bidirectional: true, wantErr: "has no target_bootstrap_endpoint"},
+		{name: "invalid name", destinations: []topicMappingDestination{{Name: "pay-ments", TargetClusterID: "lkc-pay123", TargetRestEndpoint: "https://lkc-pay123:443", Topics: []string{"payments"}}}, wantErr: "invalid topic mapping destination name"},
+		{name: "duplicate destination", destinations: []topicMappingDestination{payments, payments}, wantErr: "more than once"},
+		{name: "overlap with main link", destinations: []topicMappingDestination{{TargetClusterID: "lkc-main", Topics: []string{"payments.eu"}}, payments}, wantErr: "can only be mirrored to one destination cluster"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := hclrequests.MigrationWizardRequest{TargetClusterId: "lkc-main", ClusterLinkName: "main-link"}
+			if tt.bidirectional {
+				request.ClusterLinkMode = hclrequests.ClusterLinkModeBidirectional
+			}
+			err := applyTopicMapping(&request, &topicMapping{Destinations: tt.destinations})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("applyTopicMapping() error = %v, want substring %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyTopicMapping() unexpected error: %v", err)
+			}
+			if got := request.ClusterLinkAutoCreateMirrorTopicFilters; len(got) != 1 || got[0] != "orders" {
+				t.Errorf("main link topic filters = %v, want [orders]", got)
+			}
+			if len(request.AdditionalDestinations) != 1 {
+				t.Fatalf("additional destinations = %v, want 1", request.AdditionalDestinations)
+			}
+			destination := request.AdditionalDestinations[0]
+			if destination.Name != "lkc_pay123" || destination.ClusterLinkName != "main-link" {
+				t.Errorf("destination = %+v, want name lkc_pay123 and link main-link", destination)
+			}
+		})
+	}
+}
+
+func TestLoadTopicMapping(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte("destinations:\n  - name: payments\n    target_cluster_id: lkc-pay123\n    topics: [\"payments.*\"]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := loadTopicMapping(valid)
+	if err != nil {
+		t.Fatalf("loadTopicMapping() unexpected error: %v", err)
+	}
+	if len(mapping.Destinations) != 1 || mapping.Destinations[0].TargetClusterID != "lkc-pay123" {
+		t.Errorf("loadTopicMapping() = %+v", mapping)
+	}
+
+	unknown := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(unknown, []byte("destinations:\n  - cluster: lkc-pay123\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTopicMapping(unknown); err == nil {
+		t.Error("loadTopicMapping() accepted an unknown field")
+	}
+}
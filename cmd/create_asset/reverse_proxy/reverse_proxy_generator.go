@@ -9,6 +9,7 @@ import (
 	"github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/utils"
 )
 
@@ -64,7 +65,7 @@ func (rp *ReverseProxyAssetGenerator) Run() error {
 	// Write user-data template
 	userDataTemplate := hclService.GenerateReverseProxyUserDataTemplate()
 	userDataPath := filepath.Join(outputDir, "reverse-proxy-user-data.tpl")
-	if err := os.WriteFile(userDataPath, []byte(userDataTemplate), 0644); err != nil {
+	if err := summary.WriteFile(userDataPath, []byte(userDataTemplate), 0644); err != nil {
 		return fmt.Errorf("failed to write user-data template: %w", err)
 	}
 	slog.Debug("wrote reverse-proxy-user-data.tpl")
@@ -72,7 +73,7 @@ func (rp *ReverseProxyAssetGenerator) Run() error {
 	// Write shell script from HCL service
 	scriptContent := hclService.GenerateReverseProxyShellScript()
 	scriptPath := filepath.Join(outputDir, "generate_dns_entries.sh")
-	if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
+	if err := summary.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
 		return fmt.Errorf("failed to write shell script: %w", err)
 	}
 	slog.Debug("wrote generate_dns_entries.sh")
@@ -84,28 +85,28 @@ func (rp *ReverseProxyAssetGenerator) Run() error {
 
 func (rp *ReverseProxyAssetGenerator) writeTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
 	if files.MainTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(files.MainTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(files.MainTf), 0644); err != nil {
 			return fmt.Errorf("failed to write main.tf: %w", err)
 		}
 		slog.Debug("wrote main.tf")
 	}
 
 	if files.ProvidersTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(files.ProvidersTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(files.ProvidersTf), 0644); err != nil {
 			return fmt.Errorf("failed to write providers.tf: %w", err)
 		}
 		slog.Debug("wrote providers.tf")
 	}
 
 	if files.VariablesTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(files.VariablesTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(files.VariablesTf), 0644); err != nil {
 			return fmt.Errorf("failed to write variables.tf: %w", err)
 		}
 		slog.Debug("wrote variables.tf")
 	}
 
 	if files.InputsAutoTfvars != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(files.InputsAutoTfvars), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(files.InputsAutoTfvars), 0644); err != nil {
 			return fmt.Errorf("failed to write inputs.auto.tfvars: %w", err)
 		}
 		slog.Debug("wrote inputs.auto.tfvars")
@@ -0,0 +1,203 @@
+package scheduled_discovery
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	region             string
+	kcpImage           string
+	stateBucket        string
+	subnetIds          []string
+	securityGroupIds   []string
+	assignPublicIp     bool
+	scheduleExpression string
+	statePrefix        string
+	discoverRegions    []string
+	clusterArns        []string
+	skipTopics         bool
+	skipCosts          bool
+	skipMetrics        bool
+	outputDir          string
+)
+
+var (
+	scheduleExpressionPattern = regexp.MustCompile(`^(rate|cron)\(.+\)$`)
+	bucketNamePattern         = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	// statePrefixPattern keeps the prefix to S3's safe key characters, which
+	// also keeps Terraform template sequences out of the generated files.
+	statePrefixPattern = regexp.MustCompile(`^[A-Za-z0-9!_.*'()/-]*$`)
+)
+
+func NewScheduledDiscoveryCmd() *cobra.Command {
+	scheduledDiscoveryCmd := &cobra.Command{
+		Use:   "scheduled-discovery",
+		Short: "Create Terraform that runs kcp discover on a schedule in AWS",
+		Long: `Generate Terraform that runs ` + "`kcp discover`" + ` on a schedule inside your AWS account, so the inventory stays current without depending on someone running kcp from their laptop.
+
+The generated Terraform provisions:
+
+- An S3 bucket for the state file, versioned so every run's inventory is kept, encrypted and with public access blocked.
+- An ECS cluster and a Fargate task definition that restores the last state file from the bucket, runs ` + "`kcp discover`" + ` against it and uploads the refreshed file, so data added by ` + "`kcp scan`" + ` commands is kept.
+- A task role with the least-privilege policy ` + "`kcp generate iam-policy`" + ` would give for the same flags, plus access to the state file.
+- An EventBridge rule that starts the task on ` + "`--schedule`" + `, and the role it starts the task with.
+
+The task runs the ` + "`--kcp-image`" + ` container image, whose entrypoint must be the kcp binary, in the given subnets. They need a route to the AWS APIs and the image registries, through a NAT gateway, VPC endpoints or, with ` + "`--assign-public-ip`" + `, an internet gateway. Discovery runs as an ECS task rather than a Lambda function so that large estates are not cut off by Lambda's 15 minute limit.`,
+		Example: `  kcp create-asset scheduled-discovery \
+      --region us-east-1 \
+      --kcp-image 123456789012.dkr.ecr.us-east-1.amazonaws.com/kcp:latest \
+      --state-bucket acme-kcp-inventory \
+      --subnet-ids subnet-aaa,subnet-bbb \
+      --discover-region us-east-1,eu-west-1 \
+      --schedule "cron(0 6 * * ? *)"`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: iampolicy.RenderSingle(
+				"`kcp create-asset scheduled-discovery` itself only reads local configuration. The executor of `terraform apply` on the generated project needs:",
+				[]string{
+					"s3:CreateBucket",
+					"s3:DeleteBucket",
+					"s3:PutBucketVersioning",
+					"s3:PutEncryptionConfiguration",
+					"s3:PutBucketPublicAccessBlock",
+					"s3:Get*",
+					"s3:ListBucket",
+					"logs:CreateLogGroup",
+					"logs:DeleteLogGroup",
+					"logs:PutRetentionPolicy",
+					"logs:DescribeLogGroups",
+					"logs:ListTagsForResource",
+					"ecs:CreateCluster",
+					"ecs:DeleteCluster",
+					"ecs:DescribeClusters",
+					"ecs:RegisterTaskDefinition",
+					"ecs:DeregisterTaskDefinition",
+					"ecs:DescribeTaskDefinition",
+					"iam:CreateRole",
+					"iam:DeleteRole",
+					"iam:GetRole",
+					"iam:PassRole",
+					"iam:PutRolePolicy",
+					"iam:GetRolePolicy",
+					"iam:DeleteRolePolicy",
+					"iam:AttachRolePolicy",
+					"iam:DetachRolePolicy",
+					"iam:ListRolePolicies",
+					"iam:ListAttachedRolePolicies",
+					"iam:ListInstanceProfilesForRole",
+					"events:PutRule",
+					"events:DeleteRule",
+					"events:DescribeRule",
+					"events:PutTargets",
+					"events:RemoveTargets",
+					"events:ListTargetsByRule",
+					"events:ListTagsForResource",
+				},
+			),
+		},
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunCreateScheduledDiscovery,
+		RunE:          runCreateScheduledDiscovery,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&region, "region", "", "AWS region the scheduled discovery is provisioned in")
+	requiredFlags.StringVar(&kcpImage, "kcp-image", "", "Container image whose entrypoint is the kcp binary, e.g. an image in your ECR registry")
+	requiredFlags.StringVar(&stateBucket, "state-bucket", "", "Name of the S3 bucket to create for the state file")
+	requiredFlags.StringSliceVar(&subnetIds, "subnet-ids", []string{}, "Subnets the discovery task runs in (comma separated list or repeated flag)")
+	scheduledDiscoveryCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&scheduleExpression, "schedule", "rate(1 day)", "EventBridge schedule expression the discovery runs on, e.g. rate(12 hours) or cron(0 6 * * ? *)")
+	optionalFlags.StringSliceVar(&discoverRegions, "discover-region", []string{}, "The region(s) to discover (comma separated list or repeated flag). Defaults to --region. Mutually exclusive with --cluster-arn.")
+	optionalFlags.StringSliceVar(&clusterArns, "cluster-arn", []string{}, "Discover only the specified MSK cluster ARN(s) (comma separated or repeated flag). Mutually exclusive with --discover-region.")
+	optionalFlags.BoolVar(&skipTopics, "skip-topics", false, "Skips the topic discovery through the AWS MSK API")
+	optionalFlags.BoolVar(&skipCosts, "skip-costs", false, "Skips the cost discovery through the AWS Cost Explorer API")
+	optionalFlags.BoolVar(&skipMetrics, "skip-metrics", false, "Skips the metrics discovery through the AWS CloudWatch API")
+	optionalFlags.StringSliceVar(&securityGroupIds, "security-group-ids", []string{}, "Security groups of the discovery task (comma separated list). Defaults to the VPC's default security group.")
+	optionalFlags.BoolVar(&assignPublicIp, "assign-public-ip", false, "Give the task a public IP, needed in public subnets without a NAT gateway")
+	optionalFlags.StringVar(&statePrefix, "state-prefix", "kcp", "Prefix the state file is kept under in the bucket")
+	optionalFlags.StringVar(&outputDir, "output-dir", "scheduled_discovery", "Directory to output the generated Terraform files to")
+	scheduledDiscoveryCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	scheduledDiscoveryCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = scheduledDiscoveryCmd.MarkFlagRequired("region")
+	_ = scheduledDiscoveryCmd.MarkFlagRequired("kcp-image")
+	_ = scheduledDiscoveryCmd.MarkFlagRequired("state-bucket")
+	_ = scheduledDiscoveryCmd.MarkFlagRequired("subnet-ids")
+	scheduledDiscoveryCmd.MarkFlagsMutuallyExclusive("discover-region", "cluster-arn")
+
+	return scheduledDiscoveryCmd
+}
+
+func preRunCreateScheduledDiscovery(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if !scheduleExpressionPattern.MatchString(scheduleExpression) {
+		return fmt.Errorf("--schedule must be a rate(...) or cron(...) expression, got '%s'", scheduleExpression)
+	}
+	if !bucketNamePattern.MatchString(stateBucket) {
+		return fmt.Errorf("--state-bucket '%s' is not a valid S3 bucket name", stateBucket)
+	}
+	if !statePrefixPattern.MatchString(statePrefix) {
+		return fmt.Errorf("--state-prefix '%s' may only contain letters, digits and !_.*'()/-", statePrefix)
+	}
+
+	return nil
+}
+
+func runCreateScheduledDiscovery(cmd *cobra.Command, args []string) error {
+	opts := ScheduledDiscoveryOpts{
+		Region:             region,
+		KcpImage:           kcpImage,
+		ScheduleExpression: scheduleExpression,
+		SubnetIds:          subnetIds,
+		SecurityGroupIds:   securityGroupIds,
+		AssignPublicIp:     assignPublicIp,
+		StateBucketName:    stateBucket,
+		StatePrefix:        statePrefix,
+		DiscoverRegions:    discoverRegions,
+		ClusterArns:        clusterArns,
+		SkipTopics:         skipTopics,
+		SkipCosts:          skipCosts,
+		SkipMetrics:        skipMetrics,
+		OutputDir:          outputDir,
+	}
+
+	if err := NewScheduledDiscoveryAssetGenerator(opts).Run(); err != nil {
+		return fmt.Errorf("failed to create scheduled discovery assets: %w", err)
+	}
+
+	return nil
+}
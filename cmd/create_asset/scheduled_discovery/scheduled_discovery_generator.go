@@ -0,0 +1,172 @@
+package scheduled_discovery
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/hcl"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+type ScheduledDiscoveryOpts struct {
+	Region             string
+	KcpImage           string
+	ScheduleExpression string
+	SubnetIds          []string
+	SecurityGroupIds   []string
+	AssignPublicIp     bool
+	StateBucketName    string
+	StatePrefix        string
+	DiscoverRegions    []string
+	ClusterArns        []string
+	SkipTopics         bool
+	SkipCosts          bool
+	SkipMetrics        bool
+	OutputDir          string
+}
+
+type ScheduledDiscoveryAssetGenerator struct {
+	opts ScheduledDiscoveryOpts
+}
+
+func NewScheduledDiscoveryAssetGenerator(opts ScheduledDiscoveryOpts) *ScheduledDiscoveryAssetGenerator {
+	return &ScheduledDiscoveryAssetGenerator{opts: opts}
+}
+
+func (sd *ScheduledDiscoveryAssetGenerator) Run() error {
+	fmt.Printf("🚀 Generating scheduled discovery assets\n")
+
+	request, err := sd.buildRequest()
+	if err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOutputDir(sd.opts.OutputDir); err != nil {
+		return err
+	}
+	slog.Debug("creating scheduled discovery directory", "directory", sd.opts.OutputDir)
+	if err := os.MkdirAll(sd.opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create scheduled discovery directory: %w", err)
+	}
+
+	hclService := hcl.NewScheduledDiscoveryHCLService()
+	terraformFiles, err := hclService.GenerateScheduledDiscoveryFiles(request)
+	if err != nil {
+		return fmt.Errorf("failed to generate Terraform files: %w", err)
+	}
+
+	if err := sd.writeTerraformFiles(sd.opts.OutputDir, terraformFiles); err != nil {
+		return fmt.Errorf("failed to write Terraform files: %w", err)
+	}
+
+	policyPath := filepath.Join(sd.opts.OutputDir, hcl.TaskRolePolicyFileName)
+	if err := summary.WriteFile(policyPath, []byte(hclService.GenerateTaskRolePolicy(request)), 0644); err != nil {
+		return fmt.Errorf("failed to write task role policy: %w", err)
+	}
+	slog.Debug("wrote " + hcl.TaskRolePolicyFileName)
+
+	fmt.Printf("✅ Scheduled discovery assets generated successfully: %s\n", sd.opts.OutputDir)
+	return nil
+}
+
+// buildRequest turns the options into the `kcp discover` arguments the task
+// runs with and a task role policy scoped to exactly what those arguments
+// discover, plus the state file in the bucket.
+func (sd *ScheduledDiscoveryAssetGenerator) buildRequest() (hclrequests.ScheduledDiscoveryRequest, error) {
+	var discoverArgs []string
+	discoverRegions := sd.opts.DiscoverRegions
+	if len(sd.opts.ClusterArns) > 0 {
+		discoverArgs = append(discoverArgs, "--cluster-arn", strings.Join(sd.opts.ClusterArns, ","))
+		discoverRegions = nil
+	} else {
+		if len(discoverRegions) == 0 {
+			discoverRegions = []string{sd.opts.Region}
+		}
+		discoverArgs = append(discoverArgs, "--region", strings.Join(discoverRegions, ","))
+	}
+	if sd.opts.SkipTopics {
+		discoverArgs = append(discoverArgs, "--skip-topics")
+	}
+	if sd.opts.SkipCosts {
+		discoverArgs = append(discoverArgs, "--skip-costs")
+	}
+	if sd.opts.SkipMetrics {
+		discoverArgs = append(discoverArgs, "--skip-metrics")
+	}
+
+	partition := partitionOf(sd.opts.Region)
+	statements, err := iampolicy.ScanPolicy(iampolicy.ScanPolicyOpts{
+		Partition:   partition,
+		Regions:     discoverRegions,
+		ClusterArns: sd.opts.ClusterArns,
+		SkipTopics:  sd.opts.SkipTopics,
+		SkipCosts:   sd.opts.SkipCosts,
+		SkipMetrics: sd.opts.SkipMetrics,
+	})
+	if err != nil {
+		return hclrequests.ScheduledDiscoveryRequest{}, fmt.Errorf("failed to build task role policy: %w", err)
+	}
+	statements = append(statements, iampolicy.Statement{
+		Sid:       "StateFileAccess",
+		Actions:   []string{"s3:GetObject", "s3:PutObject"},
+		Resources: []string{fmt.Sprintf("arn:%s:s3:::%s", partition, path.Join(sd.opts.StateBucketName, sd.opts.StatePrefix, "kcp-state.json"))},
+	})
+
+	return hclrequests.ScheduledDiscoveryRequest{
+		Region:             sd.opts.Region,
+		KcpImage:           sd.opts.KcpImage,
+		ScheduleExpression: sd.opts.ScheduleExpression,
+		SubnetIds:          sd.opts.SubnetIds,
+		SecurityGroupIds:   sd.opts.SecurityGroupIds,
+		AssignPublicIp:     sd.opts.AssignPublicIp,
+		StateBucketName:    sd.opts.StateBucketName,
+		StatePrefix:        sd.opts.StatePrefix,
+		DiscoverArgs:       discoverArgs,
+		TaskRolePolicy:     iampolicy.PolicyJSON(statements),
+	}, nil
+}
+
+// partitionOf returns the AWS partition of a region.
+func partitionOf(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	default:
+		return "aws"
+	}
+}
+
+func (sd *ScheduledDiscoveryAssetGenerator) writeTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
+	fileContents := []struct {
+		name    string
+		content string
+	}{
+		{"main.tf", files.MainTf},
+		{"providers.tf", files.ProvidersTf},
+		{"variables.tf", files.VariablesTf},
+		{"outputs.tf", files.OutputsTf},
+		{"inputs.auto.tfvars", files.InputsAutoTfvars},
+	}
+
+	for _, file := range fileContents {
+		if file.content == "" {
+			continue
+		}
+		if err := summary.WriteFile(filepath.Join(outputDir, file.name), []byte(file.content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		slog.Debug("wrote terraform file", "file", file.name)
+	}
+
+	return nil
+}
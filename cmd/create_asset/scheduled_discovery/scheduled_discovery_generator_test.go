@@ -0,0 +1,68 @@
+package scheduled_discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRequest_DefaultsToDeploymentRegion(t *testing.T) {
+	generator := NewScheduledDiscoveryAssetGenerator(ScheduledDiscoveryOpts{
+		Region:          "us-east-1",
+		StateBucketName: "acme-kcp",
+		StatePrefix:     "inventory",
+		SkipCosts:       true,
+	})
+
+	request, err := generator.buildRequest()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"--region", "us-east-1", "--skip-costs"}, request.DiscoverArgs)
+	assert.Contains(t, request.TaskRolePolicy, `"arn:aws:s3:::acme-kcp/inventory/kcp-state.json"`)
+	assert.Contains(t, request.TaskRolePolicy, `"arn:aws:kafka:us-east-1:*:cluster/*/*"`)
+	assert.NotContains(t, request.TaskRolePolicy, "ce:GetCostAndUsage")
+}
+
+func TestBuildRequest_ClusterArnsScopePolicy(t *testing.T) {
+	clusterArn := "arn:aws-us-gov:kafka:us-gov-west-1:123456789012:cluster/orders/abc-1"
+	generator := NewScheduledDiscoveryAssetGenerator(ScheduledDiscoveryOpts{
+		Region:          "us-gov-west-1",
+		StateBucketName: "acme-kcp",
+		DiscoverRegions: []string{"us-gov-east-1"},
+		ClusterArns:     []string{clusterArn},
+	})
+
+	request, err := generator.buildRequest()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"--cluster-arn", clusterArn}, request.DiscoverArgs)
+	assert.Contains(t, request.TaskRolePolicy, `"Resource": "`+clusterArn+`"`)
+	assert.Contains(t, request.TaskRolePolicy, `"arn:aws-us-gov:s3:::acme-kcp/kcp-state.json"`)
+	assert.NotContains(t, request.TaskRolePolicy, "us-gov-east-1")
+}
+
+func TestRun_WritesTerraformAndPolicy(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "scheduled_discovery")
+	generator := NewScheduledDiscoveryAssetGenerator(ScheduledDiscoveryOpts{
+		Region:             "us-east-1",
+		KcpImage:           "123456789012.dkr.ecr.us-east-1.amazonaws.com/kcp:latest",
+		ScheduleExpression: "rate(1 day)",
+		SubnetIds:          []string{"subnet-aaa"},
+		StateBucketName:    "acme-kcp",
+		StatePrefix:        "kcp",
+		OutputDir:          outputDir,
+	})
+
+	require.NoError(t, generator.Run())
+
+	for _, name := range []string{"main.tf", "providers.tf", "variables.tf", "outputs.tf", "inputs.auto.tfvars", "task-role-policy.json"} {
+		assert.FileExists(t, filepath.Join(outputDir, name))
+	}
+	mainTf, err := os.ReadFile(filepath.Join(outputDir, "main.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainTf), `"discover", "--region", "us-east-1", "--output-dir", "/kcp"`)
+	assert.Contains(t, string(mainTf), `state_file_uri = "s3://${aws_s3_bucket.state.bucket}/kcp/kcp-state.json"`)
+}
@@ -0,0 +1,127 @@
+package source_import
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile  string
+	clusterArn string
+	outputDir  string
+)
+
+func NewSourceImportCmd() *cobra.Command {
+	sourceImportCmd := &cobra.Command{
+		Use:   "source-import",
+		Short: "Create Terraform that imports the existing MSK cluster",
+		Long: "Generate Terraform describing an existing MSK provisioned cluster (`aws_msk_cluster`, its `aws_msk_configuration` and `aws_msk_scram_secret_association`) together with `import` blocks, so a cluster that has been managed by hand can be brought under IaC control as part of the migration program.\n\n" +
+			"The cluster attributes come from the state file written by `kcp discover`; nothing is read from AWS. Run `terraform plan` after generating — a clean import shows no changes, and any diff highlights drift between the discovered and live cluster. The generated cluster carries `prevent_destroy` so it cannot be removed by a stray `terraform destroy`.\n\n" +
+			"Requires Terraform 1.5 or later (for `import` blocks).",
+		Example: `  kcp create-asset source-import \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: iampolicy.RenderSingle(
+				"`kcp create-asset source-import` itself only reads the local state file. The executor of `terraform plan`/`apply` on the generated project needs:",
+				[]string{
+					"kafka:DescribeClusterV2",
+					"kafka:DescribeConfiguration",
+					"kafka:DescribeConfigurationRevision",
+					"kafka:ListScramSecrets",
+					"kafka:ListTagsForResource",
+				},
+			),
+		},
+		SilenceErrors: true,
+		PreRunE:       preRunCreateSourceImport,
+		RunE:          runCreateSourceImport,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the MSK cluster discovery reports have been written to.")
+	requiredFlags.StringVar(&clusterArn, "cluster-arn", "", "The ARN of the MSK cluster to generate import Terraform for.")
+	sourceImportCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&outputDir, "output-dir", "", "Directory to output the generated Terraform files to (default: <cluster-name>-import)")
+	sourceImportCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	sourceImportCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = sourceImportCmd.MarkFlagRequired("state-file")
+	_ = sourceImportCmd.MarkFlagRequired("cluster-arn")
+
+	return sourceImportCmd
+}
+
+func preRunCreateSourceImport(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runCreateSourceImport(cmd *cobra.Command, args []string) error {
+	opts, err := parseSourceImportOpts()
+	if err != nil {
+		return fmt.Errorf("failed to parse source import opts: %w", err)
+	}
+
+	sourceImportGenerator := NewSourceImportAssetGenerator(*opts)
+	if err := sourceImportGenerator.Run(); err != nil {
+		return fmt.Errorf("failed to create source import assets: %w", err)
+	}
+
+	return nil
+}
+
+func parseSourceImportOpts() (*SourceImportOpts, error) {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state file: %w", err)
+	}
+
+	if outputDir == "" {
+		outputDir = fmt.Sprintf("%s-import", utils.ExtractClusterNameFromArn(clusterArn))
+	}
+
+	return &SourceImportOpts{
+		State:      state,
+		ClusterArn: clusterArn,
+		OutputDir:  outputDir,
+	}, nil
+}
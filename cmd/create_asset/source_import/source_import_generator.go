@@ -0,0 +1,195 @@
+package source_import
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/hcl"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+type SourceImportOpts struct {
+	State      *types.State
+	ClusterArn string
+	OutputDir  string
+}
+
+type SourceImportAssetGenerator struct {
+	opts SourceImportOpts
+}
+
+func NewSourceImportAssetGenerator(opts SourceImportOpts) *SourceImportAssetGenerator {
+	return &SourceImportAssetGenerator{opts: opts}
+}
+
+func (si *SourceImportAssetGenerator) Run() error {
+	fmt.Printf("🚀 Generating source cluster import assets\n")
+
+	cluster, err := si.opts.State.GetClusterByArn(si.opts.ClusterArn)
+	if err != nil {
+		return err
+	}
+
+	request, err := si.buildRequest(cluster)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOutputDir(si.opts.OutputDir); err != nil {
+		return err
+	}
+	slog.Debug("creating source import directory", "directory", si.opts.OutputDir)
+	if err := os.MkdirAll(si.opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create source import directory: %w", err)
+	}
+
+	terraformFiles, err := hcl.NewSourceImportHCLService().GenerateSourceImportFiles(request)
+	if err != nil {
+		return fmt.Errorf("failed to generate Terraform files: %w", err)
+	}
+
+	if err := si.writeTerraformFiles(si.opts.OutputDir, terraformFiles); err != nil {
+		return fmt.Errorf("failed to write Terraform files: %w", err)
+	}
+
+	fmt.Printf("✅ Source cluster import assets generated successfully: %s\n", si.opts.OutputDir)
+	return nil
+}
+
+// buildRequest maps the discovered MSK cluster onto the import request. Only
+// provisioned clusters are supported; the AWS provider has no import path for
+// serverless clusters that round-trips without changes.
+func (si *SourceImportAssetGenerator) buildRequest(cluster *types.DiscoveredCluster) (hclrequests.SourceClusterImportRequest, error) {
+	mskConfig := cluster.AWSClientInformation.MskClusterConfig
+	if mskConfig.ClusterType != kafkatypes.ClusterTypeProvisioned || mskConfig.Provisioned == nil {
+		return hclrequests.SourceClusterImportRequest{}, fmt.Errorf("cluster %s is not a provisioned MSK cluster; only provisioned clusters can be imported", cluster.Name)
+	}
+	provisioned := mskConfig.Provisioned
+
+	request := hclrequests.SourceClusterImportRequest{
+		Region:                    cluster.Region,
+		ClusterArn:                cluster.Arn,
+		ClusterName:               cluster.Name,
+		NumberOfBrokerNodes:       int(aws.ToInt32(provisioned.NumberOfBrokerNodes)),
+		EnhancedMonitoring:        string(provisioned.EnhancedMonitoring),
+		Tags:                      mskConfig.Tags,
+		EncryptionInTransitClient: string(utils.GetClientBrokerEncryptionInTransit(mskConfig)),
+		// MSK defaults in-cluster encryption to on when the field is absent.
+		EncryptionInTransitInCluster: true,
+		BrokerLogs:                   hclrequests.NewBrokerLogDelivery(mskConfig),
+	}
+
+	if brokers := provisioned.BrokerNodeGroupInfo; brokers != nil {
+		request.InstanceType = aws.ToString(brokers.InstanceType)
+		request.ClientSubnets = brokers.ClientSubnets
+		request.SecurityGroups = brokers.SecurityGroups
+		if brokers.StorageInfo != nil && brokers.StorageInfo.EbsStorageInfo != nil {
+			request.VolumeSizeGiB = int(aws.ToInt32(brokers.StorageInfo.EbsStorageInfo.VolumeSize))
+		}
+	}
+
+	if encryption := provisioned.EncryptionInfo; encryption != nil {
+		if encryption.EncryptionAtRest != nil {
+			request.EncryptionAtRestKmsKeyArn = aws.ToString(encryption.EncryptionAtRest.DataVolumeKMSKeyId)
+		}
+		if encryption.EncryptionInTransit != nil && encryption.EncryptionInTransit.InCluster != nil {
+			request.EncryptionInTransitInCluster = *encryption.EncryptionInTransit.InCluster
+		}
+	}
+
+	if auth := provisioned.ClientAuthentication; auth != nil {
+		if auth.Sasl != nil {
+			request.SaslIam = auth.Sasl.Iam != nil && aws.ToBool(auth.Sasl.Iam.Enabled)
+			request.SaslScram = auth.Sasl.Scram != nil && aws.ToBool(auth.Sasl.Scram.Enabled)
+		}
+		if auth.Tls != nil && aws.ToBool(auth.Tls.Enabled) {
+			request.TlsCertificateAuthorityArns = auth.Tls.CertificateAuthorityArnList
+		}
+		request.Unauthenticated = auth.Unauthenticated != nil && aws.ToBool(auth.Unauthenticated.Enabled)
+	}
+
+	if request.SaslScram {
+		request.ScramSecretArns = cluster.AWSClientInformation.ScramSecrets
+	}
+
+	// The raw MSK version string (e.g. 3.6.0.1, 3.7.x.kraft) is kept as-is:
+	// aws_msk_cluster must match it exactly for the import to plan cleanly.
+	software := provisioned.CurrentBrokerSoftwareInfo
+	if software != nil {
+		request.KafkaVersion = aws.ToString(software.KafkaVersion)
+	}
+
+	if software != nil && aws.ToString(software.ConfigurationArn) != "" {
+		configArn := aws.ToString(software.ConfigurationArn)
+		request.ConfigurationArn = configArn
+		request.ConfigurationName = extractConfigurationNameFromArn(configArn)
+		request.ConfigurationRevision = aws.ToInt64(software.ConfigurationRevision)
+		request.ServerProperties = si.findServerProperties(cluster.Region, configArn, request.ConfigurationRevision)
+		if request.ServerProperties == "" {
+			fmt.Printf("⚠️ Configuration %s revision %d not found in state file; server_properties will be empty and show a diff on plan\n", configArn, request.ConfigurationRevision)
+		}
+	}
+
+	return request, nil
+}
+
+func (si *SourceImportAssetGenerator) findServerProperties(regionName, configArn string, revision int64) string {
+	if si.opts.State.MSKSources == nil {
+		return ""
+	}
+	for _, region := range si.opts.State.MSKSources.Regions {
+		if region.Name != regionName {
+			continue
+		}
+		for _, config := range region.Configurations {
+			if aws.ToString(config.Arn) == configArn && aws.ToInt64(config.Revision) == revision {
+				return string(config.ServerProperties)
+			}
+		}
+	}
+	return ""
+}
+
+// extractConfigurationNameFromArn returns the configuration name from an ARN of
+// the form arn:aws:kafka:region:account:configuration/<name>/<uuid>.
+func extractConfigurationNameFromArn(arn string) string {
+	parts := strings.Split(arn, "/")
+	if len(parts) >= 2 {
+		return parts[1]
+	}
+	return arn
+}
+
+func (si *SourceImportAssetGenerator) writeTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
+	fileContents := []struct {
+		name    string
+		content string
+	}{
+		{"main.tf", files.MainTf},
+		{"providers.tf", files.ProvidersTf},
+		{"variables.tf", files.VariablesTf},
+		{"outputs.tf", files.OutputsTf},
+		{"inputs.auto.tfvars", files.InputsAutoTfvars},
+	}
+
+	for _, file := range fileContents {
+		if file.content == "" {
+			continue
+		}
+		if err := summary.WriteFile(filepath.Join(outputDir, file.name), []byte(file.content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		slog.Debug("wrote terraform file", "file", file.name)
+	}
+
+	return nil
+}
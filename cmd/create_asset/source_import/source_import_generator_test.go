@@ -0,0 +1,113 @@
+package source_import
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClusterArn = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1"
+	testConfigArn  = "arn:aws:kafka:us-east-1:123456789012:configuration/orders-config/def-2"
+)
+
+func newTestState(cluster kafkatypes.Cluster, scramSecrets []string) *types.State {
+	return &types.State{
+		MSKSources: &types.MSKSourcesState{
+			Regions: []types.DiscoveredRegion{{
+				Name: "us-east-1",
+				Configurations: []kafka.DescribeConfigurationRevisionOutput{
+					{Arn: aws.String(testConfigArn), Revision: aws.Int64(1), ServerProperties: []byte("old=1\n")},
+					{Arn: aws.String(testConfigArn), Revision: aws.Int64(2), ServerProperties: []byte("auto.create.topics.enable=false\n")},
+				},
+				Clusters: []types.DiscoveredCluster{{
+					Name:   "orders",
+					Arn:    testClusterArn,
+					Region: "us-east-1",
+					AWSClientInformation: types.AWSClientInformation{
+						MskClusterConfig: cluster,
+						ScramSecrets:     scramSecrets,
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func TestBuildRequest_ProvisionedCluster(t *testing.T) {
+	cluster := kafkatypes.Cluster{
+		ClusterType: kafkatypes.ClusterTypeProvisioned,
+		Tags:        map[string]string{"team": "payments"},
+		Provisioned: &kafkatypes.Provisioned{
+			NumberOfBrokerNodes: aws.Int32(3),
+			BrokerNodeGroupInfo: &kafkatypes.BrokerNodeGroupInfo{
+				InstanceType:   aws.String("kafka.m5.large"),
+				ClientSubnets:  []string{"subnet-a", "subnet-b"},
+				SecurityGroups: []string{"sg-aaa"},
+				StorageInfo: &kafkatypes.StorageInfo{
+					EbsStorageInfo: &kafkatypes.EBSStorageInfo{VolumeSize: aws.Int32(500)},
+				},
+			},
+			CurrentBrokerSoftwareInfo: &kafkatypes.BrokerSoftwareInfo{
+				KafkaVersion:          aws.String("3.6.0.1"),
+				ConfigurationArn:      aws.String(testConfigArn),
+				ConfigurationRevision: aws.Int64(2),
+			},
+			ClientAuthentication: &kafkatypes.ClientAuthentication{
+				Sasl: &kafkatypes.Sasl{
+					Iam:   &kafkatypes.Iam{Enabled: aws.Bool(true)},
+					Scram: &kafkatypes.Scram{Enabled: aws.Bool(true)},
+				},
+			},
+			EncryptionInfo: &kafkatypes.EncryptionInfo{
+				EncryptionInTransit: &kafkatypes.EncryptionInTransit{
+					ClientBroker: kafkatypes.ClientBrokerTls,
+					InCluster:    aws.Bool(false),
+				},
+			},
+			LoggingInfo: &kafkatypes.LoggingInfo{
+				BrokerLogs: &kafkatypes.BrokerLogs{
+					CloudWatchLogs: &kafkatypes.CloudWatchLogs{Enabled: aws.Bool(true), LogGroup: aws.String("/msk/orders")},
+					Firehose:       &kafkatypes.Firehose{Enabled: aws.Bool(false), DeliveryStream: aws.String("stale")},
+					S3:             &kafkatypes.S3{Enabled: aws.Bool(true), Bucket: aws.String("orders-logs"), Prefix: aws.String("brokers/")},
+				},
+			},
+		},
+	}
+	secrets := []string{"arn:aws:secretsmanager:us-east-1:123456789012:secret:AmazonMSK_orders"}
+	generator := NewSourceImportAssetGenerator(SourceImportOpts{State: newTestState(cluster, secrets), ClusterArn: testClusterArn})
+
+	discovered, err := generator.opts.State.GetClusterByArn(testClusterArn)
+	require.NoError(t, err)
+	request, err := generator.buildRequest(discovered)
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.6.0.1", request.KafkaVersion)
+	assert.Equal(t, 3, request.NumberOfBrokerNodes)
+	assert.Equal(t, 500, request.VolumeSizeGiB)
+	assert.Equal(t, "orders-config", request.ConfigurationName)
+	assert.Equal(t, int64(2), request.ConfigurationRevision)
+	assert.Equal(t, "auto.create.topics.enable=false\n", request.ServerProperties)
+	assert.False(t, request.EncryptionInTransitInCluster)
+	assert.True(t, request.SaslIam)
+	assert.True(t, request.SaslScram)
+	assert.Equal(t, secrets, request.ScramSecretArns)
+	assert.Equal(t, map[string]string{"team": "payments"}, request.Tags)
+	assert.Equal(t, hclrequests.BrokerLogDelivery{CloudWatchLogGroup: "/msk/orders", S3Bucket: "orders-logs", S3Prefix: "brokers/"}, request.BrokerLogs)
+}
+
+func TestBuildRequest_RejectsServerlessCluster(t *testing.T) {
+	cluster := kafkatypes.Cluster{ClusterType: kafkatypes.ClusterTypeServerless}
+	generator := NewSourceImportAssetGenerator(SourceImportOpts{State: newTestState(cluster, nil), ClusterArn: testClusterArn})
+
+	discovered, err := generator.opts.State.GetClusterByArn(testClusterArn)
+	require.NoError(t, err)
+	_, err = generator.buildRequest(discovered)
+	assert.ErrorContains(t, err, "not a provisioned MSK cluster")
+}
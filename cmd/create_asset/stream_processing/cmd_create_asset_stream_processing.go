@@ -0,0 +1,177 @@
+package stream_processing
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/confluentinc/kcp/internal/services/consumer_offsets"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile           string
+	sourceType          string
+	clusterId           string
+	targetEnvironmentId string
+	targetClusterId     string
+	targetRegion        string
+	offsetsFile         string
+	ksqlEngine          string
+	outputDir           string
+)
+
+func NewStreamProcessingCmd() *cobra.Command {
+	streamProcessingCmd := &cobra.Command{
+		Use:   "stream-processing",
+		Short: "Create placeholder Flink compute pools and ksqlDB clusters for detected stream-processing workloads",
+		Long: "Generate Terraform scaffolding in the target Confluent Cloud environment for the Kafka Streams applications and KSQL/ksqlDB clusters running against a source cluster, so porting stream processing is planned alongside the data migration instead of discovered at cutover.\n\n" +
+			"Workloads are detected from naming conventions: `_confluent-ksql-<service.id>` consumer groups and internal topics mark a KSQL/ksqlDB cluster; consumer groups that own `-changelog`/`-repartition` topics, generated `KSTREAM-`/`KTABLE-` internal topic names and `StreamThread` consumer client IDs mark a Kafka Streams application. Topics and client IDs come from the state file. Consumer groups are not part of the state file: pass an export from `kcp migration offsets export` with `--offsets-file` to include them.\n\n" +
+			"Each Kafka Streams application gets a Flink compute pool and each KSQL cluster a ksqlDB cluster (or a Flink compute pool with `--ksql-engine flink`). The resources only reserve capacity — the topologies and queries still have to be ported by hand.",
+		Example: `  kcp create-asset stream-processing \
+      --state-file kcp-state.json \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --offsets-file consumer-offsets.json \
+      --target-environment-id env-abc123 \
+      --target-cluster-id lkc-xyz123 \
+      --target-region us-east-1`,
+		SilenceErrors: true,
+		PreRunE:       preRunCreateStreamProcessing,
+		RunE:          runCreateStreamProcessing,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the cluster discovery reports have been written to.")
+	requiredFlags.StringVar(&clusterId, "cluster-id", "", "The source cluster: an MSK cluster ARN, or an Apache Kafka cluster ID with --source-type apache-kafka.")
+	requiredFlags.StringVar(&targetEnvironmentId, "target-environment-id", "", "The Confluent Cloud environment ID to create the placeholders in.")
+	streamProcessingCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&sourceType, "source-type", "msk", "Source type: 'msk' or 'apache-kafka'")
+	optionalFlags.StringVar(&offsetsFile, "offsets-file", "", "Path to a consumer offsets export written by `kcp migration offsets export`, used for its consumer group IDs.")
+	optionalFlags.StringVar(&ksqlEngine, "ksql-engine", hclrequests.StreamProcessingEngineKsqlDB, "Engine to scaffold for detected KSQL/ksqlDB clusters: 'ksqldb' or 'flink'.")
+	optionalFlags.StringVar(&targetClusterId, "target-cluster-id", "", "The Confluent Cloud cluster ID ksqlDB clusters run against. Required when a workload is placed on ksqlDB.")
+	optionalFlags.StringVar(&targetRegion, "target-region", "", "The AWS region of the Flink compute pools. Required when a workload is placed on Flink.")
+	optionalFlags.StringVar(&outputDir, "output-dir", "", "Directory to output the generated Terraform files to (default: <cluster-name>-stream-processing)")
+	streamProcessingCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	streamProcessingCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = streamProcessingCmd.MarkFlagRequired("state-file")
+	_ = streamProcessingCmd.MarkFlagRequired("cluster-id")
+	_ = streamProcessingCmd.MarkFlagRequired("target-environment-id")
+
+	return streamProcessingCmd
+}
+
+func preRunCreateStreamProcessing(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	switch ksqlEngine {
+	case hclrequests.StreamProcessingEngineKsqlDB, hclrequests.StreamProcessingEngineFlink:
+	default:
+		return fmt.Errorf("invalid --ksql-engine '%s': must be '%s' or '%s'", ksqlEngine, hclrequests.StreamProcessingEngineKsqlDB, hclrequests.StreamProcessingEngineFlink)
+	}
+
+	return nil
+}
+
+func runCreateStreamProcessing(cmd *cobra.Command, args []string) error {
+	opts, err := parseStreamProcessingOpts()
+	if err != nil {
+		return fmt.Errorf("failed to parse stream processing opts: %w", err)
+	}
+
+	streamProcessingGenerator := NewStreamProcessingAssetGenerator(*opts)
+	if err := streamProcessingGenerator.Run(); err != nil {
+		return fmt.Errorf("failed to create stream processing assets: %w", err)
+	}
+
+	return nil
+}
+
+func parseStreamProcessingOpts() (*StreamProcessingOpts, error) {
+	normalizedSourceType, err := types.ParseSourceTypeFlag(sourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state file: %w", err)
+	}
+
+	opts := &StreamProcessingOpts{
+		KsqlEngine:          ksqlEngine,
+		TargetEnvironmentId: targetEnvironmentId,
+		TargetClusterId:     targetClusterId,
+		TargetRegion:        targetRegion,
+		OutputDir:           outputDir,
+	}
+
+	switch normalizedSourceType {
+	case types.SourceTypeMSK:
+		cluster, err := state.GetClusterByArn(clusterId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster: %w", err)
+		}
+		opts.SourceClusterName = cluster.Name
+		opts.KafkaAdminInfo = cluster.KafkaAdminClientInformation
+		opts.DiscoveredClients = cluster.DiscoveredClients
+	case types.SourceTypeOSK:
+		cluster, err := state.GetOSKClusterByID(clusterId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Apache Kafka cluster: %w", err)
+		}
+		opts.SourceClusterName = cluster.ID
+		opts.KafkaAdminInfo = cluster.KafkaAdminClientInformation
+		opts.DiscoveredClients = cluster.DiscoveredClients
+	}
+
+	if offsetsFile != "" {
+		export, err := consumer_offsets.ReadExport(offsetsFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range export.Groups {
+			opts.ConsumerGroupIDs = append(opts.ConsumerGroupIDs, group.GroupID)
+		}
+	}
+
+	if opts.OutputDir == "" {
+		opts.OutputDir = fmt.Sprintf("%s-stream-processing", opts.SourceClusterName)
+	}
+
+	return opts, nil
+}
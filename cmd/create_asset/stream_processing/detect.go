@@ -0,0 +1,168 @@
+package stream_processing
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+)
+
+// ksqlDB (and the older Confluent KSQL) prefix every group and internal topic
+// with `_confluent-ksql-<service.id>`. Persistent and transient query groups
+// continue with `query_`/`transient_`; the command topic is
+// `<service.id>_command_topic`. service.id conventionally ends in `_`.
+var ksqlNamePattern = regexp.MustCompile(`^_confluent-ksql-(.+?)(?:query_|transient_|_command_topic$)`)
+
+// Kafka Streams internal topics are `<application.id>-<name>-changelog` and
+// `<application.id>-<name>-repartition`. Unnamed processors get generated
+// `KSTREAM-…`/`KTABLE-…` names, which pin down where the application.id ends
+// even without knowing the consumer group.
+var (
+	streamsInternalTopicPattern  = regexp.MustCompile(`-(?:changelog|repartition)$`)
+	streamsGeneratedTopicPattern = regexp.MustCompile(`^(.+?)-(?:KSTREAM|KTABLE)-[A-Z-]+-\d+-(?:changelog|repartition)$`)
+)
+
+// Kafka Streams derives consumer client.ids from the application.id:
+// `<application.id>-<process uuid>-StreamThread-<n>-[restore-]consumer`.
+var streamsClientIdPattern = regexp.MustCompile(`^(.+)-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}-StreamThread-\d+-(?:restore-)?consumer$`)
+
+// maxEvidenceExamples caps how many names each evidence line quotes.
+const maxEvidenceExamples = 3
+
+type workloadKey struct {
+	kind string
+	name string
+}
+
+type workloadSignals struct {
+	groups  []string
+	topics  []string
+	clients []string
+}
+
+// detectWorkloads applies the naming heuristics to the source's consumer
+// group IDs, topic names and consumer client IDs, returning one workload per
+// Kafka Streams application.id or ksqlDB service id, sorted by kind then
+// name. Engines are left empty for the caller to assign.
+func detectWorkloads(groupIDs, topics, clientIDs []string) []hclrequests.StreamProcessingWorkload {
+	signals := map[workloadKey]*workloadSignals{}
+	add := func(kind, name string) *workloadSignals {
+		key := workloadKey{kind: kind, name: name}
+		if signals[key] == nil {
+			signals[key] = &workloadSignals{}
+		}
+		return signals[key]
+	}
+
+	// applicationIDs maps each Streams internal topic to the application.id
+	// its generated name pins down, or "" when the topic name is user-chosen.
+	applicationIDs := map[string]string{}
+	for _, topic := range topics {
+		if m := ksqlNamePattern.FindStringSubmatch(topic); m != nil {
+			s := add(hclrequests.StreamProcessingKindKsql, ksqlServiceName(m[1]))
+			s.topics = append(s.topics, topic)
+			continue
+		}
+		if !streamsInternalTopicPattern.MatchString(topic) {
+			continue
+		}
+		applicationIDs[topic] = ""
+		if m := streamsGeneratedTopicPattern.FindStringSubmatch(topic); m != nil {
+			applicationIDs[topic] = m[1]
+			s := add(hclrequests.StreamProcessingKindKafkaStreams, m[1])
+			s.topics = append(s.topics, topic)
+		}
+	}
+
+	for _, group := range groupIDs {
+		if m := ksqlNamePattern.FindStringSubmatch(group); m != nil {
+			s := add(hclrequests.StreamProcessingKindKsql, ksqlServiceName(m[1]))
+			s.groups = append(s.groups, group)
+			continue
+		}
+		// A group is a Streams application.id when it prefixes internal
+		// topics whose generated names do not point at a different app.
+		var owned []string
+		for topic, applicationID := range applicationIDs {
+			if strings.HasPrefix(topic, group+"-") && (applicationID == "" || applicationID == group) {
+				owned = append(owned, topic)
+			}
+		}
+		if len(owned) == 0 {
+			continue
+		}
+		s := add(hclrequests.StreamProcessingKindKafkaStreams, group)
+		s.groups = append(s.groups, group)
+		for _, topic := range owned {
+			if !slices.Contains(s.topics, topic) {
+				s.topics = append(s.topics, topic)
+			}
+		}
+	}
+
+	for _, clientID := range clientIDs {
+		if m := streamsClientIdPattern.FindStringSubmatch(clientID); m != nil {
+			s := add(hclrequests.StreamProcessingKindKafkaStreams, m[1])
+			if !slices.Contains(s.clients, clientID) {
+				s.clients = append(s.clients, clientID)
+			}
+		}
+	}
+
+	workloads := make([]hclrequests.StreamProcessingWorkload, 0, len(signals))
+	for key, s := range signals {
+		workloads = append(workloads, hclrequests.StreamProcessingWorkload{
+			Name:     key.name,
+			Kind:     key.kind,
+			Evidence: s.evidence(),
+		})
+	}
+	slices.SortFunc(workloads, func(a, b hclrequests.StreamProcessingWorkload) int {
+		if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return workloads
+}
+
+// ksqlServiceName drops the trailing `_` ksqlDB service ids conventionally
+// carry, so `default_` is reported as `default`.
+func ksqlServiceName(serviceID string) string {
+	if name := strings.TrimSuffix(serviceID, "_"); name != "" {
+		return name
+	}
+	return serviceID
+}
+
+func (s *workloadSignals) evidence() []string {
+	var evidence []string
+	if len(s.groups) > 0 {
+		evidence = append(evidence, fmt.Sprintf("consumer groups: %s", quoteExamples(s.groups)))
+	}
+	if len(s.topics) > 0 {
+		evidence = append(evidence, fmt.Sprintf("internal topics: %s", quoteExamples(s.topics)))
+	}
+	if len(s.clients) > 0 {
+		evidence = append(evidence, fmt.Sprintf("consumer client IDs: %s", quoteExamples(s.clients)))
+	}
+	return evidence
+}
+
+func quoteExamples(names []string) string {
+	sorted := slices.Clone(names)
+	slices.Sort(sorted)
+
+	shown := sorted[:min(len(sorted), maxEvidenceExamples)]
+	quoted := make([]string, len(shown))
+	for i, name := range shown {
+		quoted[i] = "`" + name + "`"
+	}
+	out := strings.Join(quoted, ", ")
+	if extra := len(sorted) - len(shown); extra > 0 {
+		out += fmt.Sprintf(" and %d more", extra)
+	}
+	return out
+}
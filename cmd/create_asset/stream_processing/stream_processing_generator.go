@@ -0,0 +1,132 @@
+package stream_processing
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/confluentinc/kcp/internal/services/hcl"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+type StreamProcessingOpts struct {
+	SourceClusterName string
+	KafkaAdminInfo    types.KafkaAdminClientInformation
+	DiscoveredClients []types.DiscoveredClient
+	// ConsumerGroupIDs come from a `kcp migration offsets export` file; the
+	// state file does not record consumer groups.
+	ConsumerGroupIDs []string
+
+	KsqlEngine          string
+	TargetEnvironmentId string
+	TargetClusterId     string
+	TargetRegion        string
+	OutputDir           string
+}
+
+type StreamProcessingAssetGenerator struct {
+	opts StreamProcessingOpts
+}
+
+func NewStreamProcessingAssetGenerator(opts StreamProcessingOpts) *StreamProcessingAssetGenerator {
+	return &StreamProcessingAssetGenerator{opts: opts}
+}
+
+func (sp *StreamProcessingAssetGenerator) Run() error {
+	fmt.Printf("🚀 Generating stream-processing placeholder assets\n")
+
+	request := sp.buildRequest()
+	if len(request.Workloads) == 0 {
+		fmt.Printf("✅ No Kafka Streams or KSQL workloads detected on %s; nothing to generate\n", sp.opts.SourceClusterName)
+		return nil
+	}
+
+	for _, workload := range request.Workloads {
+		fmt.Printf("   %s %s → %s\n", workload.Kind, workload.Name, workload.Engine)
+	}
+
+	terraformFiles, err := hcl.NewStreamProcessingHCLService().GenerateStreamProcessingFiles(request)
+	if err != nil {
+		return fmt.Errorf("failed to generate Terraform files: %w", err)
+	}
+
+	if err := utils.ValidateOutputDir(sp.opts.OutputDir); err != nil {
+		return err
+	}
+	slog.Debug("creating stream processing directory", "directory", sp.opts.OutputDir)
+	if err := os.MkdirAll(sp.opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create stream processing directory: %w", err)
+	}
+
+	if err := sp.writeTerraformFiles(sp.opts.OutputDir, terraformFiles); err != nil {
+		return fmt.Errorf("failed to write Terraform files: %w", err)
+	}
+
+	fmt.Printf("✅ Stream-processing placeholder assets generated successfully: %s\n", sp.opts.OutputDir)
+	return nil
+}
+
+// buildRequest runs the naming heuristics over the source cluster and places
+// each detected workload on an engine: Kafka Streams applications on Flink,
+// KSQL/ksqlDB clusters on the engine chosen with --ksql-engine.
+func (sp *StreamProcessingAssetGenerator) buildRequest() hclrequests.StreamProcessingRequest {
+	var topics []string
+	if sp.opts.KafkaAdminInfo.Topics != nil {
+		for _, topic := range sp.opts.KafkaAdminInfo.Topics.Details {
+			topics = append(topics, topic.Name)
+		}
+	}
+
+	var clientIDs []string
+	for _, client := range sp.opts.DiscoveredClients {
+		if client.ClientId != "" {
+			clientIDs = append(clientIDs, client.ClientId)
+		}
+	}
+
+	workloads := detectWorkloads(sp.opts.ConsumerGroupIDs, topics, clientIDs)
+	for i := range workloads {
+		workloads[i].Engine = hclrequests.StreamProcessingEngineFlink
+		if workloads[i].Kind == hclrequests.StreamProcessingKindKsql {
+			workloads[i].Engine = sp.opts.KsqlEngine
+		}
+	}
+
+	return hclrequests.StreamProcessingRequest{
+		SourceClusterName:   sp.opts.SourceClusterName,
+		TargetEnvironmentId: sp.opts.TargetEnvironmentId,
+		TargetClusterId:     sp.opts.TargetClusterId,
+		TargetRegion:        sp.opts.TargetRegion,
+		Workloads:           workloads,
+	}
+}
+
+func (sp *StreamProcessingAssetGenerator) writeTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
+	fileContents := []struct {
+		name    string
+		content string
+	}{
+		{"main.tf", files.MainTf},
+		{"providers.tf", files.ProvidersTf},
+		{"variables.tf", files.VariablesTf},
+		{"outputs.tf", files.OutputsTf},
+		{"inputs.auto.tfvars", files.InputsAutoTfvars},
+	}
+
+	for _, file := range fileContents {
+		if file.content == "" {
+			continue
+		}
+		if err := summary.WriteFile(filepath.Join(outputDir, file.name), []byte(file.content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		slog.Debug("wrote terraform file", "file", file.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package stream_processing
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func workloadNames(workloads []hclrequests.StreamProcessingWorkload) []string {
+	names := make([]string, 0, len(workloads))
+	for _, w := range workloads {
+		names = append(names, w.Kind+"/"+w.Name)
+	}
+	return names
+}
+
+func TestDetectWorkloads(t *testing.T) {
+	groups := []string{
+		"orders",
+		"fraud-scoring",
+		"_confluent-ksql-default_query_CSAS_PAYMENTS_1",
+		"_confluent-ksql-default_transient_123",
+		"billing-consumer",
+	}
+	topics := []string{
+		"orders",
+		"orders-app-KSTREAM-AGGREGATE-STATE-STORE-0000000003-changelog",
+		"fraud-scoring-scores-store-changelog",
+		"fraud-scoring-by-account-repartition",
+		"_confluent-ksql-default__command_topic",
+		"_confluent-ksql-analytics_query_CTAS_TOTALS_2-Aggregate-changelog",
+		"payments",
+	}
+	clientIDs := []string{
+		"inventory-0b6c3c7e-6a5d-4f43-9d1e-3f1f7f9d2a10-StreamThread-1-consumer",
+		"inventory-0b6c3c7e-6a5d-4f43-9d1e-3f1f7f9d2a10-StreamThread-1-restore-consumer",
+		"billing-consumer-1",
+	}
+
+	workloads := detectWorkloads(groups, topics, clientIDs)
+
+	assert.Equal(t, []string{
+		"kafka_streams/fraud-scoring",
+		"kafka_streams/inventory",
+		"kafka_streams/orders-app",
+		"ksql/analytics",
+		"ksql/default",
+	}, workloadNames(workloads))
+
+	fraud := workloads[0]
+	assert.Equal(t, []string{
+		"consumer groups: `fraud-scoring`",
+		"internal topics: `fraud-scoring-by-account-repartition`, `fraud-scoring-scores-store-changelog`",
+	}, fraud.Evidence)
+	assert.Equal(t, []string{
+		"consumer groups: `_confluent-ksql-default_query_CSAS_PAYMENTS_1`, `_confluent-ksql-default_transient_123`",
+		"internal topics: `_confluent-ksql-default__command_topic`",
+	}, workloads[4].Evidence)
+}
+
+func TestDetectWorkloads_NoSignals(t *testing.T) {
+	workloads := detectWorkloads([]string{"billing"}, []string{"billing", "orders-changelog"}, []string{"billing-1"})
+	assert.Empty(t, workloads)
+}
+
+func TestQuoteExamples_CapsList(t *testing.T) {
+	assert.Equal(t, "`a`, `b`, `c` and 2 more", quoteExamples([]string{"e", "d", "c", "b", "a"}))
+}
+
+func TestBuildRequest_AssignsEngines(t *testing.T) {
+	opts := StreamProcessingOpts{
+		SourceClusterName: "orders",
+		KafkaAdminInfo: types.KafkaAdminClientInformation{
+			Topics: &types.Topics{Details: []types.TopicDetails{
+				{Name: "_confluent-ksql-default__command_topic"},
+				{Name: "orders-app-KSTREAM-REDUCE-STATE-STORE-0000000002-repartition"},
+			}},
+		},
+		KsqlEngine:          hclrequests.StreamProcessingEngineFlink,
+		TargetEnvironmentId: "env-abc123",
+		TargetRegion:        "us-east-1",
+	}
+
+	request := NewStreamProcessingAssetGenerator(opts).buildRequest()
+
+	require.Len(t, request.Workloads, 2)
+	for _, w := range request.Workloads {
+		assert.Equal(t, hclrequests.StreamProcessingEngineFlink, w.Engine, w.Name)
+	}
+	assert.False(t, request.HasEngine(hclrequests.StreamProcessingEngineKsqlDB))
+	assert.Equal(t, "us-east-1", request.TargetRegion)
+}
@@ -1,6 +1,7 @@
 package targetinfra
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -10,6 +11,8 @@ import (
 	"github.com/confluentinc/kcp/internal/services/hcl"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/managedfiles"
+	"github.com/confluentinc/kcp/internal/services/service_quotas"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -37,10 +40,23 @@ var (
 	useExistingRoute53Zone bool
 	vpcId                  string
 	subnetCidrs            []string
+	zonalDnsRecords        bool
+	dnsVpcIds              []string
+	dnsShareAccountIds     []string
+	checkServiceQuotas     bool
 
 	preventDestroy bool
 
+	oauthIssuer        string
+	oauthJwksUri       string
+	oauthIdentityClaim string
+	oauthPoolFilter    string
+
 	outputDir string
+	refresh   bool
+	dryRun    bool
+
+	tfBackendFlags utils.TerraformBackendFlags
 )
 
 type TargetInfraOpts struct {
@@ -59,13 +75,18 @@ type TargetInfraOpts struct {
 	PreventDestroy         bool
 	VpcId                  string
 	SubnetCidrs            []string
+	ZonalDnsRecords        bool
+	DnsVpcIds              []string
+	DnsShareAccountIds     []string
+	OAuth                  *hclrequests.OAuthConfig
+	Backend                *hclrequests.TerraformBackend
 }
 
 func NewTargetInfraCmd() *cobra.Command {
 	targetInfraCmd := &cobra.Command{
 		Use:   "target-infra",
 		Short: "Create a target infrastructure asset",
-		Long:  "Create Terraform assets for Confluent Cloud target infrastructure including environment, cluster, and private link setup. Infrastructure provisioning is controlled by --needs-environment, --needs-cluster and --needs-private-link.",
+		Long:  "Create Terraform assets for Confluent Cloud target infrastructure including environment, cluster, and private link setup. Infrastructure provisioning is controlled by --needs-environment, --needs-cluster and --needs-private-link. --oauth-issuer federates the cluster with an OIDC identity provider: it adds an identity provider, an identity pool and role bindings letting the pool's clients read and write every topic and consumer group, so they authenticate with OAuth rather than API keys. Pass its identity_pool_id output to `kcp create-asset client-playbooks --oauth-identity-pool-id`; the migration-infra cluster link keeps using the cluster API key, as the pool's bindings cannot create a link. --tf-backend writes an S3 or Terraform Cloud remote state backend into the generated providers.tf. --refresh regenerates an existing --output-dir in place: files kcp wrote and nobody edited are rewritten, an edited file is kept with the generated version written beside it as <file>.kcp-new, and files kcp did not write are left alone. --dry-run with --refresh prints the diff instead of writing.",
 		Example: `  # Full provision from a kcp-state file (creates environment, cluster and private link)
   kcp create-asset target-infra \
       --state-file kcp-state.json \
@@ -79,7 +100,35 @@ func NewTargetInfraCmd() *cobra.Command {
   kcp create-asset target-infra \
       --aws-region us-east-1 --vpc-id vpc-xxxxxxxx \
       --env-id env-abc123 --cluster-id lkc-xyz789 --cluster-type dedicated \
-      --needs-private-link --subnet-cidrs 10.0.0.0/16,10.0.1.0/16,10.0.2.0/16`,
+      --needs-private-link --subnet-cidrs 10.0.0.0/16,10.0.1.0/16,10.0.2.0/16
+
+  # Private link reachable from two more VPCs in this account and from other accounts' VPCs
+  kcp create-asset target-infra \
+      --aws-region us-east-1 --vpc-id vpc-xxxxxxxx \
+      --env-id env-abc123 --needs-cluster --cluster-name example-cluster --cluster-type dedicated \
+      --needs-private-link --subnet-cidrs 10.0.0.0/16,10.0.1.0/16,10.0.2.0/16 --zonal-dns-records \
+      --dns-vpc-ids vpc-yyyyyyyy,vpc-zzzzzzzz --dns-share-account-ids 111111111111,222222222222
+
+  # Keep the Terraform state in S3, locked with DynamoDB
+  kcp create-asset target-infra \
+      --aws-region us-east-1 --vpc-id vpc-xxxxxxxx \
+      --env-id env-abc123 --cluster-id lkc-xyz789 --cluster-type dedicated \
+      --tf-backend s3 --tf-backend-bucket my-tf-state --tf-backend-region us-east-1 \
+      --tf-backend-dynamodb-table my-tf-locks
+
+  # Clients authenticate with OAuth tokens of an OIDC identity provider
+  kcp create-asset target-infra \
+      --aws-region us-east-1 --vpc-id vpc-xxxxxxxx \
+      --env-id env-abc123 --needs-cluster --cluster-name example-cluster --cluster-type enterprise \
+      --oauth-issuer https://login.example.com/oauth2/default \
+      --oauth-jwks-uri https://login.example.com/oauth2/default/v1/keys \
+      --oauth-identity-pool-filter 'claims.aud == "confluent"'
+
+  # Preview, then apply, regenerating an existing project after a kcp upgrade
+  kcp create-asset target-infra \
+      --aws-region us-east-1 --vpc-id vpc-xxxxxxxx \
+      --env-id env-abc123 --cluster-id lkc-xyz789 --cluster-type dedicated \
+      --output-dir target_infra --refresh --dry-run`,
 		Annotations: map[string]string{
 			iampolicy.AnnotationKey: iamAnnotation(),
 		},
@@ -129,15 +178,34 @@ func NewTargetInfraCmd() *cobra.Command {
 	privateLinkFlags.BoolVar(&needsPrivateLink, "needs-private-link", false, "Setup private link (requires --subnet-cidrs). Required for Enterprise clusters.")
 	privateLinkFlags.StringSliceVar(&subnetCidrs, "subnet-cidrs", []string{}, "Subnet CIDRs for private link (required with --needs-private-link)")
 	privateLinkFlags.BoolVar(&useExistingRoute53Zone, "use-existing-route53-zone", false, "Use an existing Route53 hosted zone instead of creating a new one")
+	privateLinkFlags.BoolVar(&zonalDnsRecords, "zonal-dns-records", false, "Add a record per availability zone so clients of a multi-zone dedicated cluster stay in their zone (dedicated only)")
+	privateLinkFlags.StringSliceVar(&dnsVpcIds, "dns-vpc-ids", []string{}, "Further VPCs in the same account to associate with the private hosted zone")
+	privateLinkFlags.StringSliceVar(&dnsShareAccountIds, "dns-share-account-ids", []string{}, "AWS accounts to share a Route 53 Resolver rule for the cluster's DNS domain with, for their VPCs to resolve it (requires 2+ --subnet-cidrs)")
+	privateLinkFlags.BoolVar(&checkServiceQuotas, "check-service-quotas", false, "Query AWS Service Quotas and stop if the PrivateLink VPC endpoint and its network interfaces do not fit in the remaining quota of the region and VPC. Needs AWS credentials.")
 	targetInfraCmd.Flags().AddFlagSet(privateLinkFlags)
 	groups[privateLinkFlags] = "Private Link"
 
+	oauthFlags := pflag.NewFlagSet("oauth", pflag.ExitOnError)
+	oauthFlags.SortFlags = false
+	oauthFlags.StringVar(&oauthIssuer, "oauth-issuer", "", "Issuer URI of the OIDC identity provider clients authenticate with (requires --oauth-jwks-uri and --oauth-identity-pool-filter)")
+	oauthFlags.StringVar(&oauthJwksUri, "oauth-jwks-uri", "", "JWKS URI of the OIDC identity provider")
+	oauthFlags.StringVar(&oauthIdentityClaim, "oauth-identity-claim", hclrequests.DefaultOAuthIdentityClaim, "Token claim that identifies a client in the identity pool")
+	oauthFlags.StringVar(&oauthPoolFilter, "oauth-identity-pool-filter", "", "CEL expression over the token claims selecting the tokens mapped to the identity pool, e.g. 'claims.aud == \"confluent\"'")
+	targetInfraCmd.Flags().AddFlagSet(oauthFlags)
+	groups[oauthFlags] = "OAuth"
+
 	outputFlags := pflag.NewFlagSet("output", pflag.ExitOnError)
 	outputFlags.SortFlags = false
 	outputFlags.StringVar(&outputDir, "output-dir", "target_infra", "Output directory for generated Terraform files")
+	outputFlags.BoolVar(&refresh, "refresh", false, "Regenerate the project in an existing --output-dir, keeping files the user edited or added")
+	outputFlags.BoolVar(&dryRun, "dry-run", false, "With --refresh, print the changes and their diff without writing them")
 	targetInfraCmd.Flags().AddFlagSet(outputFlags)
 	groups[outputFlags] = "Output"
 
+	tfBackendFlagSet := tfBackendFlags.FlagSet("kcp/target-infra/terraform.tfstate")
+	targetInfraCmd.Flags().AddFlagSet(tfBackendFlagSet)
+	groups[tfBackendFlagSet] = "Terraform State Backend"
+
 	targetInfraCmd.MarkFlagsMutuallyExclusive("env-name", "env-id")
 	targetInfraCmd.MarkFlagsMutuallyExclusive("cluster-id", "cluster-name")
 	targetInfraCmd.MarkFlagsMutuallyExclusive("cluster-id", "cluster-type")
@@ -145,8 +213,8 @@ func NewTargetInfraCmd() *cobra.Command {
 	targetInfraCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Long)
 
-		flagOrder := []*pflag.FlagSet{stateFileFlags, manualConfigFlags, envFlags, clusterFlags, privateLinkFlags, outputFlags}
-		groupNames := []string{"State File (Optional)", "Manual Configuration (when not using state file)", "Target Environment", "Target Cluster", "Private Link", "Output"}
+		flagOrder := []*pflag.FlagSet{stateFileFlags, manualConfigFlags, envFlags, clusterFlags, privateLinkFlags, oauthFlags, outputFlags, tfBackendFlagSet}
+		groupNames := []string{"State File (Optional)", "Manual Configuration (when not using state file)", "Target Environment", "Target Cluster", "Private Link", "OAuth", "Output", "Terraform State Backend"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -217,6 +285,36 @@ func preRunCreateTargetInfra(cmd *cobra.Command, args []string) error {
 		if len(subnetCidrs) == 0 {
 			return fmt.Errorf("--subnet-cidrs is required with --needs-private-link")
 		}
+		if zonalDnsRecords {
+			if clusterType != "dedicated" {
+				return fmt.Errorf("--zonal-dns-records requires --cluster-type dedicated")
+			}
+			if useExistingRoute53Zone {
+				return fmt.Errorf("--zonal-dns-records cannot be used with --use-existing-route53-zone")
+			}
+		}
+		// A Route 53 Resolver endpoint needs at least two IP addresses, one per private link subnet.
+		if len(dnsShareAccountIds) > 0 && len(subnetCidrs) < 2 {
+			return fmt.Errorf("--dns-share-account-ids requires at least two --subnet-cidrs")
+		}
+	} else if zonalDnsRecords || len(dnsVpcIds) > 0 || len(dnsShareAccountIds) > 0 {
+		return fmt.Errorf("--zonal-dns-records, --dns-vpc-ids and --dns-share-account-ids require --needs-private-link")
+	}
+
+	if oauthIssuer != "" {
+		if oauthJwksUri == "" || oauthPoolFilter == "" {
+			return fmt.Errorf("--oauth-jwks-uri and --oauth-identity-pool-filter are required with --oauth-issuer")
+		}
+	} else if oauthJwksUri != "" || oauthPoolFilter != "" {
+		return fmt.Errorf("--oauth-jwks-uri and --oauth-identity-pool-filter require --oauth-issuer")
+	}
+
+	if _, err := tfBackendFlags.TerraformBackend(); err != nil {
+		return err
+	}
+
+	if dryRun && !refresh {
+		return fmt.Errorf("--dry-run requires --refresh")
 	}
 
 	return nil
@@ -270,12 +368,37 @@ func runCreateTargetInfra(cmd *cobra.Command, args []string) error {
 		PreventDestroy:         opts.PreventDestroy,
 		VpcId:                  opts.VpcId,
 		SubnetCidrRanges:       opts.SubnetCidrs,
+		ZonalDnsRecords:        opts.ZonalDnsRecords,
+		DnsVpcIds:              opts.DnsVpcIds,
+		DnsShareAccountIds:     opts.DnsShareAccountIds,
+		OAuth:                  opts.OAuth,
+		Backend:                opts.Backend,
+	}
+
+	if checkServiceQuotas {
+		if err := checkServiceQuotaHeadroom(request); err != nil {
+			return err
+		}
 	}
 
 	slog.Debug("generating Terraform configuration")
 	hclService := hcl.NewTargetInfraHCLService()
 	project := hclService.GenerateTerraformFiles(request)
 
+	if refresh {
+		changes, err := hcl.RefreshTerraformProject(outputDir, project, dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to refresh Terraform project: %w", err)
+		}
+		managedfiles.PrintChanges(changes, dryRun)
+		if dryRun {
+			fmt.Printf("✅ Dry run complete, nothing written: %s\n", outputDir)
+			return nil
+		}
+		fmt.Printf("✅ Target infrastructure refreshed: %s\n", outputDir)
+		return nil
+	}
+
 	if err := utils.ValidateOutputDir(outputDir); err != nil {
 		return err
 	}
@@ -293,6 +416,19 @@ func runCreateTargetInfra(cmd *cobra.Command, args []string) error {
 }
 
 func parseTargetInfraOpts() *TargetInfraOpts {
+	// Validated in preRunCreateTargetInfra.
+	backend, _ := tfBackendFlags.TerraformBackend()
+
+	var oauth *hclrequests.OAuthConfig
+	if oauthIssuer != "" {
+		oauth = &hclrequests.OAuthConfig{
+			Issuer:        oauthIssuer,
+			JwksUri:       oauthJwksUri,
+			IdentityClaim: oauthIdentityClaim,
+			Filter:        oauthPoolFilter,
+		}
+	}
+
 	return &TargetInfraOpts{
 		NeedsEnvironment:       needsEnvironment,
 		EnvironmentName:        environmentName,
@@ -309,5 +445,64 @@ func parseTargetInfraOpts() *TargetInfraOpts {
 		PreventDestroy:         preventDestroy,
 		VpcId:                  vpcId,
 		SubnetCidrs:            subnetCidrs,
+		ZonalDnsRecords:        zonalDnsRecords,
+		DnsVpcIds:              dnsVpcIds,
+		DnsShareAccountIds:     dnsShareAccountIds,
+		OAuth:                  oauth,
+		Backend:                backend,
+	}
+}
+
+// serviceQuotaDemand is what the generated PrivateLink networking takes out
+// of the region's Service Quotas: one interface VPC endpoint with a network
+// interface per subnet, and, when the DNS domain is shared, inbound and
+// outbound resolver endpoints with one more per subnet each.
+func serviceQuotaDemand(request hclrequests.TargetClusterWizardRequest) service_quotas.Demand {
+	if !request.NeedsPrivateLink {
+		return service_quotas.Demand{}
 	}
+	subnets := len(request.SubnetCidrRanges)
+	demand := service_quotas.Demand{
+		InterfaceEndpoints: 1,
+		NetworkInterfaces:  subnets,
+		VpcID:              request.VpcId,
+	}
+	if len(request.DnsShareAccountIds) > 0 {
+		demand.NetworkInterfaces += 2 * subnets
+	}
+	return demand
+}
+
+// checkServiceQuotaHeadroom reports, per quota, whether the generated
+// PrivateLink networking fits in what is left of the Service Quotas. Quotas
+// that would be exceeded stop generation.
+func checkServiceQuotaHeadroom(request hclrequests.TargetClusterWizardRequest) error {
+	demand := serviceQuotaDemand(request)
+	if demand.InterfaceEndpoints == 0 {
+		fmt.Println("⏭️ No AWS resources to check Service Quotas for (--needs-private-link is not set)")
+		return nil
+	}
+
+	checker, err := service_quotas.NewRegionChecker(request.AwsRegion)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Checking Service Quotas in %s\n", request.AwsRegion)
+	findings := checker.Check(context.Background(), demand)
+	for _, finding := range findings {
+		switch finding.Severity {
+		case service_quotas.SeverityError:
+			fmt.Printf("❌ %s\n", finding.Message)
+		case service_quotas.SeverityWarning:
+			fmt.Printf("⚠️ %s\n", finding.Message)
+		default:
+			fmt.Printf("✅ %s\n", finding.Message)
+		}
+	}
+
+	if service_quotas.HasErrors(findings) {
+		return fmt.Errorf("service quota check failed: request the quota increases above, or free up capacity in %s", request.AwsRegion)
+	}
+	return nil
 }
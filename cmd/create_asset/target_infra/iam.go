@@ -75,11 +75,58 @@ var (
 	// targetInfraDedicatedAdditions — Dedicated private-networking reuses
 	// the caller's existing subnets; no extra permissions beyond base.
 	targetInfraDedicatedAdditions []string
+
+	// targetInfraZonalDnsAdditions — zonal records look up the name of
+	// each availability zone ID the VPC endpoint's subnets are in.
+	targetInfraZonalDnsAdditions = []string{
+		"ec2:DescribeAvailabilityZones",
+	}
+
+	// targetInfraDnsVpcAdditions — associating further VPCs with the
+	// private hosted zone, and detaching them on destroy.
+	targetInfraDnsVpcAdditions = []string{
+		"route53:DisassociateVPCFromHostedZone",
+	}
+
+	// targetInfraDnsShareAdditions — Route 53 Resolver endpoints (which
+	// place ENIs in the private link subnets) and a forwarding rule
+	// shared with other accounts through AWS RAM.
+	targetInfraDnsShareAdditions = []string{
+		"ec2:CreateNetworkInterface",
+		"ec2:CreateNetworkInterfacePermission",
+		"ec2:DeleteNetworkInterface",
+		"ram:AssociateResourceShare",
+		"ram:CreateResourceShare",
+		"ram:DeleteResourceShare",
+		"ram:DisassociateResourceShare",
+		"ram:GetResourceShareAssociations",
+		"ram:GetResourceShares",
+		"route53resolver:CreateResolverEndpoint",
+		"route53resolver:CreateResolverRule",
+		"route53resolver:DeleteResolverEndpoint",
+		"route53resolver:DeleteResolverRule",
+		"route53resolver:GetResolverEndpoint",
+		"route53resolver:GetResolverRule",
+		"route53resolver:GetResolverRulePolicy",
+		"route53resolver:ListResolverEndpointIpAddresses",
+		"route53resolver:ListTagsForResource",
+		"route53resolver:PutResolverRulePolicy",
+	}
+
+	// targetInfraServiceQuotaCheckAdditions — read by kcp itself, not by
+	// Terraform, when --check-service-quotas compares the PrivateLink
+	// networking with the region's Service Quotas and current usage.
+	targetInfraServiceQuotaCheckAdditions = []string{
+		"ec2:DescribeNetworkInterfaces",
+		"ec2:DescribeVpcEndpoints",
+		"servicequotas:GetAWSDefaultServiceQuota",
+		"servicequotas:GetServiceQuota",
+	}
 )
 
 const targetInfraIAMIntro = "`kcp create-asset target-infra` itself only reads local configuration. " +
 	"The generated Terraform provisions Confluent Cloud resources and (when `--needs-private-link` is set) AWS networking — VPC endpoint, security group, and optionally a Route53 private hosted zone with alias records. " +
-	"The executor of `terraform apply` / `terraform destroy` needs the base policy below plus the addition matching the chosen `--cluster-type`.\n\n" +
+	"The executor of `terraform apply` / `terraform destroy` needs the base policy below plus the addition matching the chosen `--cluster-type`, and those of any DNS sharing flags used.\n\n" +
 	"!!! warning \"Scope down for production\"\n\n" +
 	"    The policies below use `\"Resource\": \"*\"`. Narrow each statement to specific ARNs or `aws:ResourceTag` conditions before granting this policy to a CI/CD or pipeline role."
 
@@ -98,6 +145,26 @@ func iamAnnotation() string {
 				Summary:   "Dedicated clusters with PrivateLink reuse the caller's existing subnets and VPC endpoints.",
 				Additions: targetInfraDedicatedAdditions,
 			},
+			{
+				FlagHint:  "--zonal-dns-records",
+				Summary:   "Zonal records resolve the availability zone name of each zone the VPC endpoint is in.",
+				Additions: targetInfraZonalDnsAdditions,
+			},
+			{
+				FlagHint:  "--dns-vpc-ids",
+				Summary:   "Further VPCs in the account are associated with the private hosted zone.",
+				Additions: targetInfraDnsVpcAdditions,
+			},
+			{
+				FlagHint:  "--dns-share-account-ids",
+				Summary:   "Route 53 Resolver endpoints in the private link VPC and a forwarding rule for the cluster's DNS domain, shared with other accounts through AWS RAM.",
+				Additions: targetInfraDnsShareAdditions,
+			},
+			{
+				FlagHint:  "--check-service-quotas",
+				Summary:   "Read by `kcp create-asset target-infra` itself, before generating anything, to compare the PrivateLink VPC endpoint and its network interfaces with the region's Service Quotas and current usage.",
+				Additions: targetInfraServiceQuotaCheckAdditions,
+			},
 		},
 	)
 }
@@ -17,6 +17,9 @@ func TestTargetInfraIAMFragmentsDisjointFromBase(t *testing.T) {
 	for name, additions := range map[string][]string{
 		"enterprise": targetInfraEnterpriseAdditions,
 		"dedicated":  targetInfraDedicatedAdditions,
+		"zonal-dns":  targetInfraZonalDnsAdditions,
+		"dns-vpc":    targetInfraDnsVpcAdditions,
+		"dns-share":  targetInfraDnsShareAdditions,
 	} {
 		if overlap := iampolicy.Overlap(targetInfraBase, additions); len(overlap) > 0 {
 			t.Errorf("%s additions overlap base: %v", name, overlap)
@@ -0,0 +1,20 @@
+package dev
+
+import (
+	"github.com/confluentinc/kcp/cmd/dev/seed"
+	"github.com/spf13/cobra"
+)
+
+func NewDevCmd() *cobra.Command {
+	devCmd := &cobra.Command{
+		Use:           "dev",
+		Short:         "Local development and testing helpers",
+		Long:          "Helpers for trying kcp and testing it in CI without an AWS account. Subcommands: `seed` (fake MSK and Kafka resources in LocalStack and a local broker).",
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	devCmd.AddCommand(seed.NewDevSeedCmd())
+
+	return devCmd
+}
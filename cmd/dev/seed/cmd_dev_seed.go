@@ -0,0 +1,184 @@
+package seed
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/devseed"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	localstackURL    string
+	region           string
+	bootstrapServers []string
+	kafkaImage       string
+	skipAWS          bool
+	skipKafka        bool
+)
+
+func NewDevSeedCmd() *cobra.Command {
+	seedCmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Provision fake MSK and Kafka resources in LocalStack and a local broker",
+		Long: `Provision a small, fake MSK estate so discover, scan, report and generate can be exercised end to end without an AWS account.
+
+In LocalStack (reached at ` + "`--localstack-url`" + `, never real AWS) seed creates a VPC, two subnets and a two-broker MSK cluster named ` + "`kcp-dev-seed`" + `. LocalStack only emulates MSK in its paid tiers; with the community edition pass ` + "`--skip-aws`" + `.
+
+It then starts a single-node Kafka broker in Docker with testcontainers, or uses ` + "`--bootstrap-servers`" + `, and creates a few topics, fills them with records and commits consumer group offsets halfway through them. The container is left running for later commands and is removed with ` + "`docker rm -f <container id>`" + `.
+
+Re-running seed reuses the resources it created before.`,
+		Example: `  # Seed LocalStack and a new Kafka container, then run the usual flow against them
+  kcp dev seed
+  kcp discover --region us-east-1 --endpoint-url http://localhost:4566 --skip-costs
+
+  # Seed a broker that is already running, without LocalStack
+  kcp dev seed --skip-aws --bootstrap-servers localhost:9092`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunDevSeed,
+		RunE:          runDevSeed,
+	}
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&localstackURL, "localstack-url", "http://localhost:4566", "LocalStack endpoint to create the AWS resources in. Every AWS call seed makes goes here.")
+	optionalFlags.StringVar(&region, "region", "us-east-1", "AWS region to create the LocalStack resources in.")
+	optionalFlags.StringSliceVar(&bootstrapServers, "bootstrap-servers", []string{}, "Seed this plaintext Kafka broker (host:port, comma separated) instead of starting a container.")
+	optionalFlags.StringVar(&kafkaImage, "kafka-image", devseed.DefaultKafkaImage, "confluent-local image to start the Kafka container from.")
+	optionalFlags.BoolVar(&skipAWS, "skip-aws", false, "Do not create LocalStack resources.")
+	optionalFlags.BoolVar(&skipKafka, "skip-kafka", false, "Do not start or seed a Kafka broker.")
+	seedCmd.Flags().AddFlagSet(optionalFlags)
+
+	seedCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{optionalFlags}
+		groupNames := []string{"Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	seedCmd.MarkFlagsMutuallyExclusive("skip-aws", "skip-kafka")
+	seedCmd.MarkFlagsMutuallyExclusive("skip-kafka", "bootstrap-servers")
+	seedCmd.MarkFlagsMutuallyExclusive("bootstrap-servers", "kafka-image")
+
+	return seedCmd
+}
+
+func preRunDevSeed(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if !skipAWS {
+		if localstackURL == "" {
+			return fmt.Errorf("--localstack-url must not be empty")
+		}
+		// Seed only ever talks to LocalStack, whatever --endpoint-url and
+		// --service-endpoint-url say.
+		if err := client.SetAWSEndpointURL(localstackURL); err != nil {
+			return err
+		}
+		if err := client.SetAWSServiceEndpointURLs(nil); err != nil {
+			return err
+		}
+		// LocalStack accepts any credentials; supply some when none are set.
+		for name, value := range map[string]string{"AWS_ACCESS_KEY_ID": "test", "AWS_SECRET_ACCESS_KEY": "test"} {
+			if _, ok := os.LookupEnv(name); !ok {
+				_ = os.Setenv(name, value)
+			}
+		}
+	}
+	return nil
+}
+
+func runDevSeed(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	fixture := devseed.DefaultFixture()
+
+	if !skipAWS {
+		ec2Client, err := client.NewEC2Client(region)
+		if err != nil {
+			return fmt.Errorf("failed to create EC2 client: %w", err)
+		}
+		mskClient, err := client.NewMSKClient(region, 8, 1)
+		if err != nil {
+			return fmt.Errorf("failed to create MSK client: %w", err)
+		}
+
+		slog.Info("seeding LocalStack", "endpoint", localstackURL, "region", region)
+		result, err := devseed.SeedAWS(ctx, ec2Client, mskClient, region, fixture)
+		if err != nil {
+			return err
+		}
+		verb := "Reused"
+		if result.ClusterCreated {
+			verb = "Created"
+		}
+		fmt.Printf("✅ %s MSK cluster %s in %s (VPC %s, subnets %s)\n", verb, result.ClusterArn, localstackURL, result.VpcID, strings.Join(result.SubnetIDs, ", "))
+	}
+
+	var brokers []string
+	if !skipKafka {
+		brokers = bootstrapServers
+		if len(brokers) == 0 {
+			slog.Info("starting Kafka container", "image", kafkaImage)
+			container, err := devseed.StartKafka(ctx, kafkaImage)
+			if err != nil {
+				return err
+			}
+			brokers = container.BootstrapServers
+			fmt.Printf("✅ Started Kafka container at %s (remove with: docker rm -f %s)\n", strings.Join(brokers, ","), container.ID)
+		}
+
+		kafkaClient, err := client.NewKafkaClient(brokers, "",
+			client.WithUnauthenticatedPlaintextAuth(),
+			client.WithSaramaConfig(func(config *sarama.Config) {
+				config.Producer.Return.Successes = true
+				config.Producer.RequiredAcks = sarama.WaitForAll
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to Kafka at %s: %w", strings.Join(brokers, ","), err)
+		}
+		defer func() { _ = kafkaClient.Close() }()
+
+		result, err := devseed.SeedKafka(ctx, kafkaClient, fixture)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Seeded Kafka at %s: %d topics created, %d already present, %d records produced, offsets committed for %s\n",
+			strings.Join(brokers, ","), len(result.TopicsCreated), len(result.TopicsExisting), result.RecordsProduced, strings.Join(result.GroupsCommitted, ", "))
+	}
+
+	printNextSteps(brokers)
+	return nil
+}
+
+func printNextSteps(brokers []string) {
+	fmt.Println("\nNext steps:")
+	if !skipAWS {
+		fmt.Printf("  kcp discover --region %s --endpoint-url %s --skip-costs\n", region, localstackURL)
+	}
+	if len(brokers) > 0 {
+		fmt.Printf("  kcp scan kafka --bootstrap-servers %s --use-unauthenticated-plaintext\n", strings.Join(brokers, ","))
+	}
+	fmt.Println("  kcp report plan --state-file kcp-state.json")
+}
@@ -0,0 +1,166 @@
+package discover
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+const checkpointDirName = ".kcp-discover-checkpoint"
+
+// checkpointManifest records the options a checkpoint was written with. A
+// checkpoint is only resumed by a run with identical options, otherwise the
+// resumed clusters would not match what the flags asked for.
+type checkpointManifest struct {
+	Regions            []string                  `json:"regions"`
+	ClusterArns        []string                  `json:"cluster_arns"`
+	ClusterTypes       []kafkatypes.ClusterType  `json:"cluster_types,omitempty"`
+	States             []kafkatypes.ClusterState `json:"states,omitempty"`
+	SkipCosts          bool                      `json:"skip_costs"`
+	SkipMetrics        bool                      `json:"skip_metrics"`
+	SkipTopics         bool                      `json:"skip_topics"`
+	ScanSecrets        bool                      `json:"scan_secrets,omitempty"`
+	MetricsGranularity string                    `json:"metrics_granularity"`
+	Since              time.Time                 `json:"since"`
+}
+
+// regionCheckpoint is the output of the region phase. ClusterArns is held
+// separately because DiscoveredRegion does not serialise it.
+type regionCheckpoint struct {
+	Region      types.DiscoveredRegion `json:"region"`
+	ClusterArns []string               `json:"cluster_arns"`
+}
+
+// discoverCheckpoint persists each completed phase of a discover run — the
+// region phase (configurations, costs, cluster list) and every cluster — so
+// that a run interrupted partway can be resumed with --resume.
+//
+// Layout: <dir>/manifest.json, <dir>/<region>/region.json and
+// <dir>/<region>/clusters/<sha256(arn)>.json.
+type discoverCheckpoint struct {
+	dir string
+}
+
+// openDiscoverCheckpoint returns the checkpoint for this run. Without resume,
+// any previous checkpoint is discarded. With resume, the previous checkpoint
+// is kept when its manifest matches; otherwise the run starts fresh.
+func openDiscoverCheckpoint(dir string, manifest checkpointManifest, resume bool) (*discoverCheckpoint, error) {
+	cp := &discoverCheckpoint{dir: dir}
+
+	if resume {
+		var previous checkpointManifest
+		err := readCheckpointFile(filepath.Join(dir, "manifest.json"), &previous)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			fmt.Printf("⚠️  No checkpoint found in %s, running a full discover\n", dir)
+		case err != nil:
+			fmt.Printf("⚠️  Ignoring unreadable checkpoint in %s, running a full discover\n", dir)
+			slog.Debug("failed to read checkpoint manifest", "dir", dir, "error", err)
+		case !reflect.DeepEqual(normalizeManifest(previous), normalizeManifest(manifest)):
+			fmt.Printf("⚠️  Checkpoint in %s was written with different discover options, running a full discover\n", dir)
+		default:
+			fmt.Printf("🔍 Resuming discover from checkpoint %s\n", dir)
+			return cp, nil
+		}
+	}
+
+	if err := cp.clear(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	if err := utils.WriteCheckpointFile(filepath.Join(dir, "manifest.json"), manifest); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+func (cp *discoverCheckpoint) regionPath(region string) string {
+	return filepath.Join(cp.dir, region, "region.json")
+}
+
+func (cp *discoverCheckpoint) clusterPath(region, clusterArn string) string {
+	sum := sha256.Sum256([]byte(clusterArn))
+	return filepath.Join(cp.dir, region, "clusters", hex.EncodeToString(sum[:])+".json")
+}
+
+// loadRegion returns the checkpointed region phase, if any.
+func (cp *discoverCheckpoint) loadRegion(region string) (*types.DiscoveredRegion, bool) {
+	var checkpoint regionCheckpoint
+	if err := readCheckpointFile(cp.regionPath(region), &checkpoint); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Debug("ignoring unreadable region checkpoint", "region", region, "error", err)
+		}
+		return nil, false
+	}
+	checkpoint.Region.ClusterArns = checkpoint.ClusterArns
+	return &checkpoint.Region, true
+}
+
+func (cp *discoverCheckpoint) saveRegion(region types.DiscoveredRegion) error {
+	return utils.WriteCheckpointFile(cp.regionPath(region.Name), regionCheckpoint{
+		Region:      region,
+		ClusterArns: region.ClusterArns,
+	})
+}
+
+// loadCluster returns the checkpointed cluster, if any.
+func (cp *discoverCheckpoint) loadCluster(region, clusterArn string) (*types.DiscoveredCluster, bool) {
+	var cluster types.DiscoveredCluster
+	if err := readCheckpointFile(cp.clusterPath(region, clusterArn), &cluster); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Debug("ignoring unreadable cluster checkpoint", "cluster", clusterArn, "error", err)
+		}
+		return nil, false
+	}
+	return &cluster, true
+}
+
+func (cp *discoverCheckpoint) saveCluster(region string, cluster types.DiscoveredCluster) error {
+	return utils.WriteCheckpointFile(cp.clusterPath(region, cluster.Arn), cluster)
+}
+
+func (cp *discoverCheckpoint) clear() error {
+	if err := os.RemoveAll(cp.dir); err != nil {
+		return fmt.Errorf("failed to remove checkpoint directory: %w", err)
+	}
+	return nil
+}
+
+// normalizeManifest makes nil and empty slices compare equal.
+func normalizeManifest(m checkpointManifest) checkpointManifest {
+	if len(m.Regions) == 0 {
+		m.Regions = nil
+	}
+	if len(m.ClusterArns) == 0 {
+		m.ClusterArns = nil
+	}
+	if len(m.ClusterTypes) == 0 {
+		m.ClusterTypes = nil
+	}
+	if len(m.States) == 0 {
+		m.States = nil
+	}
+	m.Since = m.Since.UTC()
+	return m
+}
+
+func readCheckpointFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,122 @@
+package discover
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCheckpointManifest() checkpointManifest {
+	return checkpointManifest{
+		Regions:            []string{testRegion},
+		MetricsGranularity: "1d",
+	}
+}
+
+func TestDiscoverCheckpoint_RoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), checkpointDirName)
+
+	cp, err := openDiscoverCheckpoint(dir, testCheckpointManifest(), false)
+	require.NoError(t, err)
+
+	_, ok := cp.loadRegion(testRegion)
+	assert.False(t, ok)
+	_, ok = cp.loadCluster(testRegion, testClusterArn)
+	assert.False(t, ok)
+
+	require.NoError(t, cp.saveRegion(types.DiscoveredRegion{Name: testRegion, ClusterArns: []string{testClusterArn}}))
+	require.NoError(t, cp.saveCluster(testRegion, types.DiscoveredCluster{Name: testClusterName, Arn: testClusterArn, Region: testRegion}))
+
+	region, ok := cp.loadRegion(testRegion)
+	require.True(t, ok)
+	assert.Equal(t, []string{testClusterArn}, region.ClusterArns)
+
+	cluster, ok := cp.loadCluster(testRegion, testClusterArn)
+	require.True(t, ok)
+	assert.Equal(t, testClusterName, cluster.Name)
+
+	info, err := os.Stat(cp.clusterPath(testRegion, testClusterArn))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	require.NoError(t, cp.clear())
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestOpenDiscoverCheckpoint(t *testing.T) {
+	seed := func(t *testing.T) string {
+		dir := filepath.Join(t.TempDir(), checkpointDirName)
+		cp, err := openDiscoverCheckpoint(dir, testCheckpointManifest(), false)
+		require.NoError(t, err)
+		require.NoError(t, cp.saveCluster(testRegion, types.DiscoveredCluster{Name: testClusterName, Arn: testClusterArn}))
+		return dir
+	}
+
+	t.Run("resume with the same options keeps completed clusters", func(t *testing.T) {
+		dir := seed(t)
+		cp, err := openDiscoverCheckpoint(dir, testCheckpointManifest(), true)
+		require.NoError(t, err)
+		_, ok := cp.loadCluster(testRegion, testClusterArn)
+		assert.True(t, ok)
+	})
+
+	t.Run("resume with different options starts fresh", func(t *testing.T) {
+		dir := seed(t)
+		manifest := testCheckpointManifest()
+		manifest.SkipTopics = true
+		cp, err := openDiscoverCheckpoint(dir, manifest, true)
+		require.NoError(t, err)
+		_, ok := cp.loadCluster(testRegion, testClusterArn)
+		assert.False(t, ok)
+	})
+
+	t.Run("run without resume discards the previous checkpoint", func(t *testing.T) {
+		dir := seed(t)
+		cp, err := openDiscoverCheckpoint(dir, testCheckpointManifest(), false)
+		require.NoError(t, err)
+		_, ok := cp.loadCluster(testRegion, testClusterArn)
+		assert.False(t, ok)
+	})
+
+	t.Run("resume without a checkpoint starts fresh", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), checkpointDirName)
+		cp, err := openDiscoverCheckpoint(dir, testCheckpointManifest(), true)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, "manifest.json"))
+		assert.NoError(t, err)
+		_, ok := cp.loadRegion(testRegion)
+		assert.False(t, ok)
+	})
+}
+
+func TestDiscoverer_DiscoverClusterWithCheckpoint(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), checkpointDirName)
+	cp, err := openDiscoverCheckpoint(dir, testCheckpointManifest(), false)
+	require.NoError(t, err)
+
+	describeCalls := 0
+	msk, ec2svc, metrics := defaultStubs()
+	msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+		describeCalls++
+		return buildFullServerlessCluster(), nil
+	}
+	clusterDiscoverer := newTestClusterDiscoverer(msk, ec2svc, metrics)
+	d := NewDiscoverer(DiscovererOpts{SkipMetrics: true, SkipTopics: true})
+
+	first, err := d.discoverClusterWithCheckpoint(context.Background(), cp, *clusterDiscoverer, testClusterArn, testRegion)
+	require.NoError(t, err)
+	assert.Equal(t, 1, describeCalls)
+
+	resumed, err := d.discoverClusterWithCheckpoint(context.Background(), cp, *clusterDiscoverer, testClusterArn, testRegion)
+	require.NoError(t, err)
+	assert.Equal(t, 1, describeCalls, "checkpointed cluster must not be discovered again")
+	assert.Equal(t, first.Name, resumed.Name)
+	assert.Equal(t, first.Arn, resumed.Arn)
+}
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/aws/aws-sdk-go-v2/service/kafkaconnect"
@@ -17,19 +19,23 @@ import (
 	"github.com/confluentinc/kcp/internal/redact"
 	"github.com/confluentinc/kcp/internal/services/metrics"
 	"github.com/confluentinc/kcp/internal/types"
+	"golang.org/x/sync/errgroup"
 )
 
 type ClusterDiscovererMSKService interface {
 	DescribeClusterV2(ctx context.Context, clusterArn string) (*kafka.DescribeClusterV2Output, error)
+	ListTagsForResource(ctx context.Context, resourceArn string) (map[string]string, error)
 	GetBootstrapBrokers(ctx context.Context, clusterArn string) (*kafka.GetBootstrapBrokersOutput, error)
 	ListClientVpcConnections(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.ClientVpcConnection, error)
 	ListClusterOperationsV2(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.ClusterOperationV2Summary, error)
+	DescribeClusterOperationV2(ctx context.Context, operationArn string) (*kafkatypes.ClusterOperationV2, error)
 	ListNodes(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.NodeInfo, error)
 	ListScramSecrets(ctx context.Context, clusterArn string, maxResults int32) ([]string, error)
 	GetClusterPolicy(ctx context.Context, clusterArn string) (*kafka.GetClusterPolicyOutput, error)
 	GetCompatibleKafkaVersions(ctx context.Context, clusterArn string) (*kafka.GetCompatibleKafkaVersionsOutput, error)
 	IsFetchFromFollowerEnabled(ctx context.Context, cluster kafkatypes.Cluster) (bool, error)
 	GetTopicsWithConfigs(ctx context.Context, clusterArn string) ([]types.TopicDetails, error)
+	RefreshTopicsWithConfigs(ctx context.Context, clusterArn string, known []types.TopicDetails) ([]types.TopicDetails, error)
 }
 
 type ClusterDiscovererMetricService interface {
@@ -39,11 +45,19 @@ type ClusterDiscovererMetricService interface {
 
 type ClusterDiscovererEC2Service interface {
 	DescribeSubnets(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error)
+	DescribeNetworkInterfaces(ctx context.Context, networkInterfaceIds []string) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DescribeSecurityGroups(ctx context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error)
 }
 
 type ClusterDiscovererMSKConnectService interface {
 	ListConnectors(ctx context.Context, params *kafkaconnect.ListConnectorsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorsOutput, error)
 	DescribeConnector(ctx context.Context, params *kafkaconnect.DescribeConnectorInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOutput, error)
+	ListConnectorOperations(ctx context.Context, params *kafkaconnect.ListConnectorOperationsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorOperationsOutput, error)
+	DescribeConnectorOperation(ctx context.Context, params *kafkaconnect.DescribeConnectorOperationInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOperationOutput, error)
+}
+
+type ClusterDiscovererIAMAccessService interface {
+	GetClusterAccess(ctx context.Context, clusterArn string) ([]types.IAMPrincipalAccess, error)
 }
 
 type ClusterDiscoverer struct {
@@ -51,14 +65,20 @@ type ClusterDiscoverer struct {
 	ec2Service        ClusterDiscovererEC2Service
 	metricService     ClusterDiscovererMetricService
 	mskConnectService ClusterDiscovererMSKConnectService
+	// iamAccessService is nil when the IAM client could not be created; IAM
+	// access analysis is then skipped.
+	iamAccessService ClusterDiscovererIAMAccessService
+	pageSizes        PageSizes
 }
 
-func NewClusterDiscoverer(mskService ClusterDiscovererMSKService, ec2Service ClusterDiscovererEC2Service, metricService ClusterDiscovererMetricService, mskConnectService ClusterDiscovererMSKConnectService) ClusterDiscoverer {
+func NewClusterDiscoverer(mskService ClusterDiscovererMSKService, ec2Service ClusterDiscovererEC2Service, metricService ClusterDiscovererMetricService, mskConnectService ClusterDiscovererMSKConnectService, iamAccessService ClusterDiscovererIAMAccessService, pageSizes PageSizes) ClusterDiscoverer {
 	return ClusterDiscoverer{
 		mskService:        mskService,
 		ec2Service:        ec2Service,
 		metricService:     metricService,
 		mskConnectService: mskConnectService,
+		iamAccessService:  iamAccessService,
+		pageSizes:         pageSizes,
 	}
 }
 
@@ -89,6 +109,91 @@ func (cd *ClusterDiscoverer) Discover(ctx context.Context, clusterArn, region st
 	}, nil
 }
 
+// DiscoverSince refreshes a cluster that a previous discover already wrote to
+// state. When the cluster version is unchanged and no cluster operation started
+// after since, the cluster configuration, networking, nodes, SCRAM secrets,
+// policy and metrics are carried over from previous, and only the cluster
+// operations, client VPC connections, topics and connectors are fetched again.
+// Client VPC connections are accepted and rejected without a new cluster
+// version, so they can't be carried over. Topics are listed and only new or
+// resized topics are described. Otherwise it falls back to a full Discover.
+func (cd *ClusterDiscoverer) DiscoverSince(ctx context.Context, previous types.DiscoveredCluster, since time.Time, skipTopics bool, skipMetrics bool, metricsGranularity string) (*types.DiscoveredCluster, error) {
+	cluster, err := cd.describeCluster(ctx, previous.Arn)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.ClusterInfo == nil {
+		return nil, fmt.Errorf("describeClusterV2 returned nil ClusterInfo for %s", previous.Arn)
+	}
+
+	operations, err := cd.scanClusterOperations(ctx, previous.Arn)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason := clusterChangedSince(previous, *cluster.ClusterInfo, operations, since); reason != "" {
+		fmt.Printf("  🔍 %s, running full discovery\n", reason)
+		return cd.Discover(ctx, previous.Arn, previous.Region, skipTopics, skipMetrics, metricsGranularity)
+	}
+	fmt.Printf("  ⏭️  No cluster changes since %s, reusing cluster configuration and metrics\n", since.Format(time.RFC3339))
+
+	refreshed := previous
+	refreshed.AWSClientInformation.MskClusterConfig = *cluster.ClusterInfo
+	refreshed.AWSClientInformation.MskClusterConfig.Tags = cd.scanClusterTags(ctx, previous.Arn, cluster.ClusterInfo.Tags)
+	refreshed.AWSClientInformation.ClusterOperations = operations
+	refreshed.AWSClientInformation.InFlightOperations = cd.describeInFlightOperations(ctx, operations)
+
+	connections, err := cd.scanClusterVpcConnections(ctx, previous.Arn)
+	if err != nil {
+		return nil, err
+	}
+	refreshed.AWSClientInformation.ClientVpcConnections = connections
+
+	switch {
+	case skipTopics:
+		fmt.Printf("  ⏭️  Skipping topic discovery\n")
+	case cluster.ClusterInfo.ClusterType == kafkatypes.ClusterTypeServerless:
+		slog.Debug("⏭️ skipping topic discovery for MSK Serverless cluster", "clusterArn", previous.Arn)
+	default:
+		fmt.Printf("  🔍 Refreshing topics\n")
+		var known []types.TopicDetails
+		if previous.KafkaAdminClientInformation.Topics != nil {
+			known = previous.KafkaAdminClientInformation.Topics.Details
+		}
+		topics, err := cd.mskService.RefreshTopicsWithConfigs(ctx, previous.Arn, known)
+		if err != nil {
+			// Non-fatal, as in discoverTopics: keep the topics from state.
+			slog.Warn("⚠️ failed to refresh topics; keeping topics from the previous discover", "error", err)
+		} else {
+			refreshed.KafkaAdminClientInformation.SetTopics(topics)
+		}
+	}
+
+	connectors, err := cd.discoverMatchingConnectors(ctx, &refreshed.AWSClientInformation)
+	if err != nil {
+		return nil, err
+	}
+	refreshed.AWSClientInformation.Connectors = connectors
+	refreshed.AWSClientInformation.IAMAccess = cd.discoverIAMAccess(ctx, *cluster.ClusterInfo)
+
+	return &refreshed, nil
+}
+
+// clusterChangedSince returns why previous can no longer be reused, or "" if
+// the cluster version is unchanged and no operation started after since.
+func clusterChangedSince(previous types.DiscoveredCluster, current kafkatypes.Cluster, operations []kafkatypes.ClusterOperationV2Summary, since time.Time) string {
+	previousVersion := aws.ToString(previous.AWSClientInformation.MskClusterConfig.CurrentVersion)
+	if previousVersion == "" || previousVersion != aws.ToString(current.CurrentVersion) {
+		return "Cluster version changed"
+	}
+	for _, operation := range operations {
+		if operation.StartTime != nil && operation.StartTime.After(since) {
+			return fmt.Sprintf("Cluster operation %s started at %s", aws.ToString(operation.OperationType), operation.StartTime.Format(time.RFC3339))
+		}
+	}
+	return ""
+}
+
 func (cd *ClusterDiscoverer) discoverAWSClientInformation(ctx context.Context, clusterArn string, skipTopics bool) (*types.AWSClientInformation, *types.KafkaAdminClientInformation, error) {
 	awsClientInfo := types.AWSClientInformation{}
 	kafkaClientInfo := types.KafkaAdminClientInformation{}
@@ -111,57 +216,98 @@ func (cd *ClusterDiscoverer) discoverAWSClientInformation(ctx context.Context, c
 		slog.Warn("⚠️ MSK Serverless cluster; skipping unsupported scans (VPC connections, nodes, SCRAM secrets, compatible versions, networking, topics)")
 	}
 
-	brokers, err := cd.getBootstrapBrokers(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.BootstrapBrokers = *brokers
+	// The sub-scans below only need DescribeClusterV2, so they run
+	// concurrently, each writing its own fields. Every call still waits on
+	// the AWS clients' rate limiters, so --api-rate-limit holds. Networking
+	// needs the node list and connector matching the bootstrap brokers, so
+	// those run after their input in the same goroutine. The first error
+	// cancels the rest.
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		awsClientInfo.MskClusterConfig.Tags = cd.scanClusterTags(gctx, clusterArn, cluster.ClusterInfo.Tags)
+		return nil
+	})
+
+	g.Go(func() error {
+		brokers, err := cd.getBootstrapBrokers(gctx, clusterArn)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.BootstrapBrokers = *brokers
 
-	connections, err := cd.scanClusterVpcConnections(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.ClientVpcConnections = connections
+		connectors, err := cd.discoverMatchingConnectors(gctx, &awsClientInfo)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.Connectors = connectors
+		return nil
+	})
 
-	operations, err := cd.scanClusterOperations(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.ClusterOperations = operations
+	g.Go(func() error {
+		connections, err := cd.scanClusterVpcConnections(gctx, clusterArn)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.ClientVpcConnections = connections
+		return nil
+	})
 
-	nodes, err := cd.scanClusterNodes(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.Nodes = nodes
+	g.Go(func() error {
+		operations, err := cd.scanClusterOperations(gctx, clusterArn)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.ClusterOperations = operations
+		awsClientInfo.InFlightOperations = cd.describeInFlightOperations(gctx, operations)
+		return nil
+	})
 
-	scramSecrets, err := cd.scanClusterScramSecrets(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.ScramSecrets = scramSecrets
+	g.Go(func() error {
+		nodes, err := cd.scanClusterNodes(gctx, clusterArn)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.Nodes = nodes
 
-	policy, err := cd.getClusterPolicy(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.Policy = *policy
+		if isServerless {
+			slog.Debug("⏭️ skipping networking scan for MSK Serverless cluster")
+			return nil
+		}
+		networking, err := cd.scanNetworkingInfo(gctx, cluster, nodes)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.ClusterNetworking = networking
+		return nil
+	})
 
-	versions, err := cd.getCompatibleKafkaVersions(ctx, clusterArn)
-	if err != nil {
-		return nil, nil, err
-	}
-	awsClientInfo.CompatibleVersions = *versions
+	g.Go(func() error {
+		scramSecrets, err := cd.scanClusterScramSecrets(gctx, clusterArn)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.ScramSecrets = scramSecrets
+		return nil
+	})
 
-	if isServerless {
-		slog.Debug("⏭️ skipping networking scan for MSK Serverless cluster")
-	} else {
-		networking, err := cd.scanNetworkingInfo(ctx, cluster, nodes)
+	g.Go(func() error {
+		policy, err := cd.getClusterPolicy(gctx, clusterArn)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
-		awsClientInfo.ClusterNetworking = networking
-	}
+		awsClientInfo.Policy = *policy
+		return nil
+	})
+
+	g.Go(func() error {
+		versions, err := cd.getCompatibleKafkaVersions(gctx, clusterArn)
+		if err != nil {
+			return err
+		}
+		awsClientInfo.CompatibleVersions = *versions
+		return nil
+	})
 
 	switch {
 	case skipTopics:
@@ -173,22 +319,59 @@ func (cd *ClusterDiscoverer) discoverAWSClientInformation(ctx context.Context, c
 		// serializes as "topics": null; all consumers nil-guard it.
 		slog.Debug("⏭️ skipping topic discovery for MSK Serverless cluster", "clusterArn", clusterArn)
 	default:
-		topics, err := cd.discoverTopics(ctx, clusterArn)
-		if err != nil {
-			return nil, nil, err
-		}
-		kafkaClientInfo.SetTopics(topics)
+		g.Go(func() error {
+			topics, err := cd.discoverTopics(gctx, clusterArn)
+			if err != nil {
+				return err
+			}
+			kafkaClientInfo.SetTopics(topics)
+			return nil
+		})
 	}
 
-	connectors, err := cd.discoverMatchingConnectors(ctx, &awsClientInfo)
-	if err != nil {
+	g.Go(func() error {
+		awsClientInfo.IAMAccess = cd.discoverIAMAccess(gctx, *cluster.ClusterInfo)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
 		return nil, nil, err
 	}
-	awsClientInfo.Connectors = connectors
 
 	return &awsClientInfo, &kafkaClientInfo, nil
 }
 
+// discoverIAMAccess maps the IAM principals granted kafka-cluster actions on
+// a cluster with SASL/IAM enabled. Such clusters usually have no Kafka ACLs,
+// so this mapping is what the migrated ACLs have to reproduce. Failures are
+// non-fatal: a warning is logged and the mapping is left empty.
+func (cd *ClusterDiscoverer) discoverIAMAccess(ctx context.Context, cluster kafkatypes.Cluster) []types.IAMPrincipalAccess {
+	if cd.iamAccessService == nil || !clusterUsesIAMAuth(cluster) {
+		return nil
+	}
+
+	fmt.Printf("  🔍 Analyzing IAM policies for kafka-cluster access\n")
+	access, err := cd.iamAccessService.GetClusterAccess(ctx, aws.ToString(cluster.ClusterArn))
+	if err != nil {
+		slog.Warn("⚠️ failed to analyze IAM policies for kafka-cluster access; skipping", "clusterArn", aws.ToString(cluster.ClusterArn), "error", err)
+		return nil
+	}
+	return access
+}
+
+// clusterUsesIAMAuth reports whether SASL/IAM client authentication is
+// enabled. Serverless clusters only support IAM.
+func clusterUsesIAMAuth(cluster kafkatypes.Cluster) bool {
+	if cluster.ClusterType == kafkatypes.ClusterTypeServerless {
+		return true
+	}
+	if cluster.Provisioned == nil || cluster.Provisioned.ClientAuthentication == nil {
+		return false
+	}
+	sasl := cluster.Provisioned.ClientAuthentication.Sasl
+	return sasl != nil && sasl.Iam != nil && aws.ToBool(sasl.Iam.Enabled)
+}
+
 // discoverMatchingConnectors lists MSK Connect connectors and returns those whose
 // bootstrap servers match this cluster. Sensitive config values are redacted
 // before the connector summary is built, so raw secrets never enter the state
@@ -263,6 +446,10 @@ func (cd *ClusterDiscoverer) discoverMatchingConnectors(ctx context.Context, aws
 			totalRedacted += redactedCount
 
 			fmt.Printf("    ✅ Found connector %s\n", aws.ToString(connector.ConnectorName))
+			var diagnostics *types.ConnectorDiagnostics
+			if connector.ConnectorState == kafkaconnecttypes.ConnectorStateFailed {
+				diagnostics = cd.diagnoseFailedConnector(ctx, describeConnector)
+			}
 			matchingConnectors = append(matchingConnectors, types.ConnectorSummary{
 				ConnectorArn:                     aws.ToString(connector.ConnectorArn),
 				ConnectorName:                    aws.ToString(connector.ConnectorName),
@@ -273,6 +460,7 @@ func (cd *ClusterDiscoverer) discoverMatchingConnectors(ctx context.Context, aws
 				Capacity:                         *connector.Capacity,
 				Plugins:                          describeConnector.Plugins,
 				ConnectorConfiguration:           redactedConfig,
+				Diagnostics:                      diagnostics,
 			})
 		}
 
@@ -290,6 +478,62 @@ func (cd *ClusterDiscoverer) discoverMatchingConnectors(ctx context.Context, aws
 	return matchingConnectors, nil
 }
 
+// diagnoseFailedConnector records why a FAILED connector failed, its operation
+// history and the role and log delivery it runs with. Operation history is
+// best effort: the connector is still reported when it can't be listed.
+func (cd *ClusterDiscoverer) diagnoseFailedConnector(ctx context.Context, connector *kafkaconnect.DescribeConnectorOutput) *types.ConnectorDiagnostics {
+	diagnostics := &types.ConnectorDiagnostics{
+		ServiceExecutionRoleArn: aws.ToString(connector.ServiceExecutionRoleArn),
+	}
+	if connector.StateDescription != nil {
+		diagnostics.StateCode = aws.ToString(connector.StateDescription.Code)
+		diagnostics.StateMessage = aws.ToString(connector.StateDescription.Message)
+	}
+	if connector.LogDelivery != nil {
+		diagnostics.LogDelivery = connector.LogDelivery.WorkerLogDelivery
+	}
+	fmt.Printf("    ⚠️ Connector %s is FAILED: %s\n", aws.ToString(connector.ConnectorName), diagnostics.StateMessage)
+
+	operations, err := cd.mskConnectService.ListConnectorOperations(ctx, &kafkaconnect.ListConnectorOperationsInput{
+		ConnectorArn: connector.ConnectorArn,
+	})
+	if err != nil {
+		slog.Warn("failed to list connector operations", "connectorArn", aws.ToString(connector.ConnectorArn), "error", err)
+		return diagnostics
+	}
+
+	for _, summary := range operations.ConnectorOperations {
+		operation := types.ConnectorOperation{
+			OperationArn:   aws.ToString(summary.ConnectorOperationArn),
+			OperationType:  string(summary.ConnectorOperationType),
+			OperationState: string(summary.ConnectorOperationState),
+		}
+		if summary.CreationTime != nil {
+			operation.CreationTime = summary.CreationTime.Format(time.RFC3339)
+		}
+		if summary.EndTime != nil {
+			operation.EndTime = summary.EndTime.Format(time.RFC3339)
+		}
+
+		// Only failed operations carry an error worth a DescribeConnectorOperation call.
+		if summary.ConnectorOperationState == kafkaconnecttypes.ConnectorOperationStateUpdateFailed ||
+			summary.ConnectorOperationState == kafkaconnecttypes.ConnectorOperationStateRollbackFailed {
+			detail, err := cd.mskConnectService.DescribeConnectorOperation(ctx, &kafkaconnect.DescribeConnectorOperationInput{
+				ConnectorOperationArn: summary.ConnectorOperationArn,
+			})
+			if err != nil {
+				slog.Warn("failed to describe connector operation", "operationArn", operation.OperationArn, "error", err)
+			} else if detail.ErrorInfo != nil {
+				operation.ErrorCode = aws.ToString(detail.ErrorInfo.Code)
+				operation.ErrorMessage = aws.ToString(detail.ErrorInfo.Message)
+			}
+		}
+		diagnostics.Operations = append(diagnostics.Operations, operation)
+	}
+
+	return diagnostics
+}
+
 // connectorAuthType maps an MSK Connect connector's authentication/encryption
 // settings to the cluster AuthType used for bootstrap-broker matching.
 func connectorAuthType(connector kafkaconnecttypes.ConnectorSummary) (types.AuthType, error) {
@@ -344,6 +588,21 @@ func (cd *ClusterDiscoverer) describeCluster(ctx context.Context, clusterArn str
 	return cluster, nil
 }
 
+// scanClusterTags lists the cluster's tags, which drive owner inference in
+// reports (see types.InferOwnerFromTags). Tags are not needed for migration,
+// so a failure (typically a missing kafka:ListTagsForResource permission)
+// only warns and keeps the tags DescribeClusterV2 returned.
+func (cd *ClusterDiscoverer) scanClusterTags(ctx context.Context, clusterArn string, described map[string]string) map[string]string {
+	slog.Debug("scanning for cluster tags", "clusterArn", clusterArn)
+
+	tags, err := cd.mskService.ListTagsForResource(ctx, clusterArn)
+	if err != nil {
+		slog.Warn("⚠️ failed to list cluster tags; owner inference will use tags from DescribeClusterV2", "clusterArn", clusterArn, "error", err)
+		return described
+	}
+	return tags
+}
+
 func (cd *ClusterDiscoverer) getBootstrapBrokers(ctx context.Context, clusterArn string) (*kafka.GetBootstrapBrokersOutput, error) {
 	slog.Debug("scanning for bootstrap brokers", "clusterArn", clusterArn)
 
@@ -357,7 +616,7 @@ func (cd *ClusterDiscoverer) getBootstrapBrokers(ctx context.Context, clusterArn
 func (cd *ClusterDiscoverer) scanClusterVpcConnections(ctx context.Context, clusterArn string) ([]kafkatypes.ClientVpcConnection, error) {
 	slog.Debug("scanning for client vpc connections", "clusterArn", clusterArn)
 
-	connections, err := cd.mskService.ListClientVpcConnections(ctx, clusterArn, int32(100))
+	connections, err := cd.mskService.ListClientVpcConnections(ctx, clusterArn, cd.pageSizes.vpcConnections())
 	if err != nil {
 		// Check if it's an MSK Serverless VPC connectivity error - this should be handled gracefully
 		if strings.Contains(err.Error(), "This Region doesn't currently support VPC connectivity with Amazon MSK Serverless clusters") {
@@ -372,17 +631,41 @@ func (cd *ClusterDiscoverer) scanClusterVpcConnections(ctx context.Context, clus
 func (cd *ClusterDiscoverer) scanClusterOperations(ctx context.Context, clusterArn string) ([]kafkatypes.ClusterOperationV2Summary, error) {
 	slog.Debug("scanning for cluster operations", "clusterArn", clusterArn)
 
-	operations, err := cd.mskService.ListClusterOperationsV2(ctx, clusterArn, int32(100))
+	operations, err := cd.mskService.ListClusterOperationsV2(ctx, clusterArn, cd.pageSizes.operations())
 	if err != nil {
 		return nil, fmt.Errorf("failed listing operations: %v", err)
 	}
 	return operations, nil
 }
 
+// describeInFlightOperations fetches the full detail of every pending or
+// in-progress operation and warns about it: metrics gathered while brokers
+// roll are not steady state, and changing the cluster mid-operation is
+// risky. A failed describe is non-fatal; the summary stays in
+// ClusterOperations.
+func (cd *ClusterDiscoverer) describeInFlightOperations(ctx context.Context, operations []kafkatypes.ClusterOperationV2Summary) []kafkatypes.ClusterOperationV2 {
+	var inFlight []kafkatypes.ClusterOperationV2
+	for _, summary := range operations {
+		if !types.IsClusterOperationInFlight(aws.ToString(summary.OperationState)) {
+			continue
+		}
+		fmt.Printf("  ⚠️ Cluster operation %s is %s (started %s); metrics may not reflect steady state and the cluster should not be changed until it completes\n",
+			aws.ToString(summary.OperationType), aws.ToString(summary.OperationState), aws.ToTime(summary.StartTime).Format(time.RFC3339))
+
+		operation, err := cd.mskService.DescribeClusterOperationV2(ctx, aws.ToString(summary.OperationArn))
+		if err != nil {
+			slog.Warn("⚠️ failed to describe in-flight cluster operation", "operationArn", aws.ToString(summary.OperationArn), "error", err)
+			continue
+		}
+		inFlight = append(inFlight, *operation)
+	}
+	return inFlight
+}
+
 func (cd *ClusterDiscoverer) scanClusterNodes(ctx context.Context, clusterArn string) ([]kafkatypes.NodeInfo, error) {
 	slog.Debug("scanning for cluster nodes", "clusterArn", clusterArn)
 
-	nodes, err := cd.mskService.ListNodes(ctx, clusterArn, int32(100))
+	nodes, err := cd.mskService.ListNodes(ctx, clusterArn, cd.pageSizes.nodes())
 	if err != nil {
 		// Check if it's an MSK Serverless error - this should be handled gracefully
 		if strings.Contains(err.Error(), "This operation cannot be performed on serverless clusters.") {
@@ -398,7 +681,7 @@ func (cd *ClusterDiscoverer) scanClusterNodes(ctx context.Context, clusterArn st
 func (cd *ClusterDiscoverer) scanClusterScramSecrets(ctx context.Context, clusterArn string) ([]string, error) {
 	slog.Debug("scanning for cluster scram secrets", "clusterArn", clusterArn)
 
-	secrets, err := cd.mskService.ListScramSecrets(ctx, clusterArn, int32(100))
+	secrets, err := cd.mskService.ListScramSecrets(ctx, clusterArn, maxPageSize)
 	if err != nil {
 		// Check if it's an MSK Serverless error - this should be handled gracefully
 		if strings.Contains(err.Error(), "This operation cannot be performed on serverless clusters.") {
@@ -463,14 +746,166 @@ func (cd *ClusterDiscoverer) scanNetworkingInfo(ctx context.Context, cluster *ka
 
 	subnetInfo := cd.createCombinedSubnetBrokerInfo(nodes, subnetDetails)
 
+	// The ENI and security group details only inform networking changes, so a
+	// denied lookup is logged rather than failing the scan.
+	brokerENIs, err := cd.scanBrokerENIs(ctx, nodes)
+	if err != nil {
+		slog.Warn("⚠️ failed to describe broker network interfaces, skipping security group rules", "error", err)
+	}
+	var securityGroupRules []types.SecurityGroupInboundRule
+	if len(brokerENIs) > 0 {
+		securityGroupRules, err = cd.scanSecurityGroupRules(ctx, brokerENIs)
+		if err != nil {
+			slog.Warn("⚠️ failed to describe broker security groups", "error", err)
+		}
+	}
+
 	return types.ClusterNetworking{
-		VpcId:          vpcId,
-		SubnetIds:      subnetIds,
-		SecurityGroups: securityGroups,
-		Subnets:        subnetInfo,
+		VpcId:              vpcId,
+		SubnetIds:          subnetIds,
+		SecurityGroups:     securityGroups,
+		Subnets:            subnetInfo,
+		BrokerENIs:         brokerENIs,
+		SecurityGroupRules: securityGroupRules,
 	}, nil
 }
 
+// scanBrokerENIs resolves the ENI each broker serves clients on and the
+// security groups attached to it.
+func (cd *ClusterDiscoverer) scanBrokerENIs(ctx context.Context, nodes []kafkatypes.NodeInfo) ([]types.BrokerENI, error) {
+	brokerIds := map[string]int{}
+	var eniIds []string
+	for _, node := range nodes {
+		if node.NodeType != kafkatypes.NodeTypeBroker || node.BrokerNodeInfo == nil || node.BrokerNodeInfo.AttachedENIId == nil {
+			continue
+		}
+		eniId := aws.ToString(node.BrokerNodeInfo.AttachedENIId)
+		brokerId := 0
+		if node.BrokerNodeInfo.BrokerId != nil {
+			brokerId = int(*node.BrokerNodeInfo.BrokerId)
+		}
+		brokerIds[eniId] = brokerId
+		eniIds = append(eniIds, eniId)
+	}
+	if len(eniIds) == 0 {
+		return nil, nil
+	}
+
+	result, err := cd.ec2Service.DescribeNetworkInterfaces(ctx, eniIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe network interfaces: %v", err)
+	}
+
+	var brokerENIs []types.BrokerENI
+	for _, eni := range result.NetworkInterfaces {
+		eniId := aws.ToString(eni.NetworkInterfaceId)
+		groupIds := make([]string, 0, len(eni.Groups))
+		for _, group := range eni.Groups {
+			groupIds = append(groupIds, aws.ToString(group.GroupId))
+		}
+		brokerENIs = append(brokerENIs, types.BrokerENI{
+			BrokerId:           brokerIds[eniId],
+			NetworkInterfaceId: eniId,
+			SubnetId:           aws.ToString(eni.SubnetId),
+			PrivateIpAddress:   aws.ToString(eni.PrivateIpAddress),
+			SecurityGroupIds:   groupIds,
+		})
+	}
+	slices.SortFunc(brokerENIs, func(a, b types.BrokerENI) int { return a.BrokerId - b.BrokerId })
+
+	return brokerENIs, nil
+}
+
+// scanSecurityGroupRules returns the inbound rules of the broker ENIs'
+// security groups that let traffic reach a Kafka broker port, one per source.
+func (cd *ClusterDiscoverer) scanSecurityGroupRules(ctx context.Context, brokerENIs []types.BrokerENI) ([]types.SecurityGroupInboundRule, error) {
+	var groupIds []string
+	for _, eni := range brokerENIs {
+		for _, groupId := range eni.SecurityGroupIds {
+			if !slices.Contains(groupIds, groupId) {
+				groupIds = append(groupIds, groupId)
+			}
+		}
+	}
+	if len(groupIds) == 0 {
+		return nil, nil
+	}
+
+	result, err := cd.ec2Service.DescribeSecurityGroups(ctx, groupIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe security groups: %v", err)
+	}
+
+	var rules []types.SecurityGroupInboundRule
+	for _, group := range result.SecurityGroups {
+		for _, permission := range group.IpPermissions {
+			if !allowsKafkaBrokerPort(permission) {
+				continue
+			}
+			rules = append(rules, inboundRuleSources(aws.ToString(group.GroupId), permission)...)
+		}
+	}
+
+	return rules, nil
+}
+
+// allowsKafkaBrokerPort reports whether an inbound permission lets TCP traffic
+// reach any of the Kafka broker ports.
+func allowsKafkaBrokerPort(permission ec2types.IpPermission) bool {
+	switch aws.ToString(permission.IpProtocol) {
+	case "-1":
+		return true
+	case "tcp", "6":
+	default:
+		return false
+	}
+	for _, port := range types.MSKBrokerPorts {
+		if aws.ToInt32(permission.FromPort) <= port && port <= aws.ToInt32(permission.ToPort) {
+			return true
+		}
+	}
+	return false
+}
+
+// inboundRuleSources flattens an inbound permission into one rule per source.
+func inboundRuleSources(groupId string, permission ec2types.IpPermission) []types.SecurityGroupInboundRule {
+	rule := types.SecurityGroupInboundRule{
+		SecurityGroupId: groupId,
+		Protocol:        aws.ToString(permission.IpProtocol),
+		FromPort:        -1,
+		ToPort:          -1,
+	}
+	if permission.FromPort != nil {
+		rule.FromPort = *permission.FromPort
+	}
+	if permission.ToPort != nil {
+		rule.ToPort = *permission.ToPort
+	}
+
+	withSource := func(sourceType, source string, description *string) types.SecurityGroupInboundRule {
+		r := rule
+		r.SourceType = sourceType
+		r.Source = source
+		r.Description = aws.ToString(description)
+		return r
+	}
+
+	var rules []types.SecurityGroupInboundRule
+	for _, r := range permission.IpRanges {
+		rules = append(rules, withSource("cidr", aws.ToString(r.CidrIp), r.Description))
+	}
+	for _, r := range permission.Ipv6Ranges {
+		rules = append(rules, withSource("ipv6-cidr", aws.ToString(r.CidrIpv6), r.Description))
+	}
+	for _, r := range permission.UserIdGroupPairs {
+		rules = append(rules, withSource("security-group", aws.ToString(r.GroupId), r.Description))
+	}
+	for _, r := range permission.PrefixListIds {
+		rules = append(rules, withSource("prefix-list", aws.ToString(r.PrefixListId), r.Description))
+	}
+	return rules
+}
+
 func (cd *ClusterDiscoverer) getVpcIdFromSubnets(ctx context.Context, subnetIds []string) (string, error) {
 	if len(subnetIds) == 0 {
 		return "", fmt.Errorf("no subnets provided, cannot determine VPC ID")
@@ -497,9 +932,10 @@ func (cd *ClusterDiscoverer) getSubnetDetails(ctx context.Context, subnetIds []s
 	subnets := make(map[string]types.SubnetInfo)
 	for _, subnet := range result.Subnets {
 		subnetInfo := types.SubnetInfo{
-			SubnetId:         aws.ToString(subnet.SubnetId),
-			AvailabilityZone: aws.ToString(subnet.AvailabilityZone),
-			CidrBlock:        aws.ToString(subnet.CidrBlock),
+			SubnetId:                aws.ToString(subnet.SubnetId),
+			AvailabilityZone:        aws.ToString(subnet.AvailabilityZone),
+			CidrBlock:               aws.ToString(subnet.CidrBlock),
+			AvailableIpAddressCount: subnet.AvailableIpAddressCount,
 		}
 		subnets[subnetInfo.SubnetId] = subnetInfo
 	}
@@ -535,11 +971,10 @@ func (cd *ClusterDiscoverer) createCombinedSubnetBrokerInfo(nodes []kafkatypes.N
 }
 
 func (cd *ClusterDiscoverer) discoverMetrics(ctx context.Context, clusterArn string, metricsGranularity string) (*types.ClusterMetrics, error) {
-	// TODO: this issues a second DescribeClusterV2 call for the same cluster, and also
-	// drops the caller's ctx by using context.Background(). Consider refactoring to
-	// accept the already-fetched cluster from discoverAWSClientInformation to eliminate
-	// the redundant API call and restore correct context propagation.
-	cluster, err := cd.mskService.DescribeClusterV2(context.Background(), clusterArn)
+	// TODO: this issues a second DescribeClusterV2 call for the same cluster. Consider
+	// refactoring to accept the already-fetched cluster from discoverAWSClientInformation
+	// to eliminate the redundant API call.
+	cluster, err := cd.mskService.DescribeClusterV2(ctx, clusterArn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get clusters: %v", err)
 	}
@@ -547,7 +982,7 @@ func (cd *ClusterDiscoverer) discoverMetrics(ctx context.Context, clusterArn str
 		return nil, fmt.Errorf("describeClusterV2 returned nil ClusterInfo for %s", clusterArn)
 	}
 
-	followerFetching, err := cd.mskService.IsFetchFromFollowerEnabled(context.Background(), *cluster.ClusterInfo)
+	followerFetching, err := cd.mskService.IsFetchFromFollowerEnabled(ctx, *cluster.ClusterInfo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if follower fetching is enabled: %v", err)
 	}
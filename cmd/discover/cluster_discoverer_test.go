@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -19,12 +20,12 @@ import (
 )
 
 func newTestClusterDiscoverer(msk *stubMSKService, ec2svc *stubEC2Service, metrics *stubMetricService) *ClusterDiscoverer {
-	cd := NewClusterDiscoverer(msk, ec2svc, metrics, &stubMSKConnectService{})
+	cd := NewClusterDiscoverer(msk, ec2svc, metrics, &stubMSKConnectService{}, nil, PageSizes{})
 	return &cd
 }
 
 func newTestClusterDiscovererWithConnect(msk *stubMSKService, ec2svc *stubEC2Service, metrics *stubMetricService, connect *stubMSKConnectService) *ClusterDiscoverer {
-	cd := NewClusterDiscoverer(msk, ec2svc, metrics, connect)
+	cd := NewClusterDiscoverer(msk, ec2svc, metrics, connect, nil, PageSizes{})
 	return &cd
 }
 
@@ -109,6 +110,100 @@ func TestClusterDiscoverer_ServerlessCluster(t *testing.T) {
 	assert.Equal(t, testClusterName, result.Name)
 }
 
+func TestClusterDiscoverer_ClusterTags(t *testing.T) {
+	newCluster := func() *kafka.DescribeClusterV2Output {
+		cluster := buildFullServerlessCluster()
+		cluster.ClusterInfo.Tags = map[string]string{"team": "from-describe"}
+		return cluster
+	}
+
+	t.Run("tags come from ListTagsForResource", func(t *testing.T) {
+		msk, ec2svc, metrics := defaultStubs()
+		msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+			return newCluster(), nil
+		}
+		var taggedArn string
+		msk.listTagsForResourceFn = func(_ context.Context, resourceArn string) (map[string]string, error) {
+			taggedArn = resourceArn
+			return map[string]string{"team": "payments", "env": "prod"}, nil
+		}
+
+		cd := newTestClusterDiscoverer(msk, ec2svc, metrics)
+		result, err := cd.Discover(context.Background(), testClusterArn, testRegion, true, true, "60s")
+
+		require.NoError(t, err)
+		assert.Equal(t, testClusterArn, taggedArn)
+		assert.Equal(t, map[string]string{"team": "payments", "env": "prod"}, result.AWSClientInformation.MskClusterConfig.Tags)
+	})
+
+	t.Run("list tags failure keeps described tags", func(t *testing.T) {
+		msk, ec2svc, metrics := defaultStubs()
+		msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+			return newCluster(), nil
+		}
+		msk.listTagsForResourceFn = func(_ context.Context, _ string) (map[string]string, error) {
+			return nil, errors.New("AccessDeniedException")
+		}
+
+		cd := newTestClusterDiscoverer(msk, ec2svc, metrics)
+		result, err := cd.Discover(context.Background(), testClusterArn, testRegion, true, true, "60s")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "from-describe"}, result.AWSClientInformation.MskClusterConfig.Tags)
+	})
+}
+
+func TestClusterDiscoverer_SubScansRunConcurrently(t *testing.T) {
+	msk, ec2svc, metrics := defaultStubs()
+	msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+		return buildFullServerlessCluster(), nil
+	}
+
+	// Each sub-scan waits for the other to start, so the discover only
+	// finishes if both are in flight at once.
+	nodesStarted, secretsStarted := make(chan struct{}), make(chan struct{})
+	waitFor := func(ctx context.Context, started chan struct{}) error {
+		select {
+		case <-started:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	msk.listNodesFn = func(ctx context.Context, _ string, _ int32) ([]kafkatypes.NodeInfo, error) {
+		close(nodesStarted)
+		return []kafkatypes.NodeInfo{}, waitFor(ctx, secretsStarted)
+	}
+	msk.listScramSecretsFn = func(ctx context.Context, _ string, _ int32) ([]string, error) {
+		close(secretsStarted)
+		return []string{"AmazonMSK_orders"}, waitFor(ctx, nodesStarted)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cd := newTestClusterDiscoverer(msk, ec2svc, metrics)
+	result, err := cd.Discover(ctx, testClusterArn, testRegion, true, true, "60s")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AmazonMSK_orders"}, result.AWSClientInformation.ScramSecrets)
+}
+
+func TestClusterDiscoverer_SubScanErrorFailsDiscover(t *testing.T) {
+	msk, ec2svc, metrics := defaultStubs()
+	msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+		return buildFullServerlessCluster(), nil
+	}
+	msk.listScramSecretsFn = func(_ context.Context, _ string, _ int32) ([]string, error) {
+		return nil, errors.New("AccessDeniedException")
+	}
+
+	cd := newTestClusterDiscoverer(msk, ec2svc, metrics)
+	_, err := cd.Discover(context.Background(), testClusterArn, testRegion, true, true, "60s")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed listing secrets: AccessDeniedException")
+}
+
 func TestClusterDiscoverer_SkipMetrics(t *testing.T) {
 	// skipMetrics=true — metric service should never be called.
 	msk, ec2svc, metrics := defaultStubs()
@@ -207,3 +302,255 @@ func TestDiscoverTopics_LogsClusterArnOnFailure(t *testing.T) {
 	assert.True(t, found,
 		"discoverTopics must record clusterArn on a DEBUG line when topic listing fails; got:\n%s", out)
 }
+
+func TestClusterDiscoverer_DiscoverSince(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	newPrevious := func() types.DiscoveredCluster {
+		previous := types.DiscoveredCluster{
+			Name:   testClusterName,
+			Arn:    testClusterArn,
+			Region: testRegion,
+			AWSClientInformation: types.AWSClientInformation{
+				MskClusterConfig:  *buildFullProvisionedCluster().ClusterInfo,
+				ClusterNetworking: types.ClusterNetworking{VpcId: "vpc-previous"},
+			},
+			ClusterMetrics: types.ClusterMetrics{MetricMetadata: types.MetricMetadata{KafkaVersion: "3.6.0"}},
+		}
+		previous.AWSClientInformation.MskClusterConfig.CurrentVersion = aws.String("K1")
+		previous.KafkaAdminClientInformation.SetTopics([]types.TopicDetails{{Name: "orders", Partitions: 6, ReplicationFactor: 3}})
+		return previous
+	}
+
+	newStubs := func(currentVersion string, operationStart time.Time) (*stubMSKService, *stubEC2Service, *bool) {
+		msk, ec2svc, _ := defaultStubs()
+		msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+			cluster := buildFullProvisionedCluster()
+			cluster.ClusterInfo.CurrentVersion = aws.String(currentVersion)
+			return cluster, nil
+		}
+		msk.listClusterOperationsV2Fn = func(_ context.Context, _ string, _ int32) ([]kafkatypes.ClusterOperationV2Summary, error) {
+			return []kafkatypes.ClusterOperationV2Summary{{OperationType: aws.String("UPDATE_BROKER_COUNT"), StartTime: aws.Time(operationStart)}}, nil
+		}
+		fullDiscover := false
+		ec2svc.describeSubnetsFn = func(_ context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error) {
+			fullDiscover = true
+			return &ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{{SubnetId: aws.String(subnetIds[0]), VpcId: aws.String("vpc-current")}}}, nil
+		}
+		return msk, ec2svc, &fullDiscover
+	}
+
+	t.Run("unchanged cluster reuses stored data and refreshes topics and vpc connections", func(t *testing.T) {
+		msk, ec2svc, fullDiscover := newStubs("K1", since.Add(-time.Hour))
+		var knownTopics []types.TopicDetails
+		msk.refreshTopicsWithConfigsFn = func(_ context.Context, _ string, known []types.TopicDetails) ([]types.TopicDetails, error) {
+			knownTopics = known
+			return append(known, types.TopicDetails{Name: "payments", Partitions: 3, ReplicationFactor: 3}), nil
+		}
+
+		msk.listClientVpcConnectionsFn = func(_ context.Context, _ string, _ int32) ([]kafkatypes.ClientVpcConnection, error) {
+			return []kafkatypes.ClientVpcConnection{{VpcConnectionArn: aws.String("arn:aws:kafka:us-east-1:210987654321:vpc-connection/x"), State: kafkatypes.VpcConnectionStateRejected}}, nil
+		}
+		previous := newPrevious()
+		previous.AWSClientInformation.ClientVpcConnections = []kafkatypes.ClientVpcConnection{{VpcConnectionArn: aws.String("arn:aws:kafka:us-east-1:210987654321:vpc-connection/x"), State: kafkatypes.VpcConnectionStateAvailable}}
+
+		cd := newTestClusterDiscoverer(msk, ec2svc, &stubMetricService{})
+		result, err := cd.DiscoverSince(context.Background(), previous, since, false, false, "1d")
+
+		require.NoError(t, err)
+		assert.False(t, *fullDiscover)
+		require.Len(t, result.AWSClientInformation.ClientVpcConnections, 1)
+		assert.Equal(t, kafkatypes.VpcConnectionStateRejected, result.AWSClientInformation.ClientVpcConnections[0].State, "client VPC connection state is refreshed")
+		assert.Equal(t, "vpc-previous", result.AWSClientInformation.ClusterNetworking.VpcId)
+		assert.Equal(t, "3.6.0", result.ClusterMetrics.MetricMetadata.KafkaVersion)
+		assert.Len(t, result.AWSClientInformation.ClusterOperations, 1)
+		require.Len(t, knownTopics, 1)
+		assert.Equal(t, "orders", knownTopics[0].Name)
+		assert.Equal(t, 2, result.KafkaAdminClientInformation.Topics.Summary.Topics)
+	})
+
+	t.Run("topic refresh failure keeps stored topics", func(t *testing.T) {
+		msk, ec2svc, _ := newStubs("K1", since.Add(-time.Hour))
+		msk.refreshTopicsWithConfigsFn = func(_ context.Context, _ string, _ []types.TopicDetails) ([]types.TopicDetails, error) {
+			return nil, errors.New("throttled")
+		}
+
+		cd := newTestClusterDiscoverer(msk, ec2svc, &stubMetricService{})
+		result, err := cd.DiscoverSince(context.Background(), newPrevious(), since, false, true, "1d")
+
+		require.NoError(t, err)
+		require.Len(t, result.KafkaAdminClientInformation.Topics.Details, 1)
+		assert.Equal(t, "orders", result.KafkaAdminClientInformation.Topics.Details[0].Name)
+	})
+
+	t.Run("version change runs full discovery", func(t *testing.T) {
+		msk, ec2svc, fullDiscover := newStubs("K2", since.Add(-time.Hour))
+
+		cd := newTestClusterDiscoverer(msk, ec2svc, &stubMetricService{})
+		result, err := cd.DiscoverSince(context.Background(), newPrevious(), since, true, true, "1d")
+
+		require.NoError(t, err)
+		assert.True(t, *fullDiscover)
+		assert.Equal(t, "vpc-current", result.AWSClientInformation.ClusterNetworking.VpcId)
+	})
+
+	t.Run("operation after since runs full discovery", func(t *testing.T) {
+		msk, ec2svc, fullDiscover := newStubs("K1", since.Add(time.Hour))
+
+		cd := newTestClusterDiscoverer(msk, ec2svc, &stubMetricService{})
+		_, err := cd.DiscoverSince(context.Background(), newPrevious(), since, true, true, "1d")
+
+		require.NoError(t, err)
+		assert.True(t, *fullDiscover)
+	})
+}
+
+func TestClusterDiscoverer_InFlightOperations(t *testing.T) {
+	msk, ec2svc, _ := defaultStubs()
+	msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+		return buildFullServerlessCluster(), nil
+	}
+	msk.listClusterOperationsV2Fn = func(_ context.Context, _ string, _ int32) ([]kafkatypes.ClusterOperationV2Summary, error) {
+		return []kafkatypes.ClusterOperationV2Summary{
+			{OperationArn: aws.String("op-done"), OperationType: aws.String("UPDATE_BROKER_COUNT"), OperationState: aws.String("UPDATE_COMPLETE")},
+			{OperationArn: aws.String("op-running"), OperationType: aws.String("UPDATE_BROKER_STORAGE"), OperationState: aws.String("UPDATE_IN_PROGRESS")},
+			{OperationArn: aws.String("op-failing"), OperationType: aws.String("REBOOT_NODE"), OperationState: aws.String("PENDING")},
+		}, nil
+	}
+	var described []string
+	msk.describeClusterOpV2Fn = func(_ context.Context, operationArn string) (*kafkatypes.ClusterOperationV2, error) {
+		described = append(described, operationArn)
+		if operationArn == "op-failing" {
+			return nil, errors.New("throttled")
+		}
+		return &kafkatypes.ClusterOperationV2{OperationArn: aws.String(operationArn)}, nil
+	}
+
+	cd := newTestClusterDiscoverer(msk, ec2svc, &stubMetricService{})
+	result, err := cd.Discover(context.Background(), testClusterArn, testRegion, true, true, "60s")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op-running", "op-failing"}, described)
+	assert.Len(t, result.AWSClientInformation.ClusterOperations, 3)
+	require.Len(t, result.AWSClientInformation.InFlightOperations, 1)
+	assert.Equal(t, "op-running", aws.ToString(result.AWSClientInformation.InFlightOperations[0].OperationArn))
+}
+
+func TestClusterDiscoverer_IAMAccess(t *testing.T) {
+	access := []types.IAMPrincipalAccess{{
+		PrincipalArn:  "arn:aws:iam::123456789012:role/orders-producer",
+		PrincipalName: "orders-producer",
+		PrincipalType: "role",
+		Permissions: []types.IAMKafkaPermission{
+			{Policy: "orders", Actions: []string{"kafka-cluster:Connect"}, Resources: []string{"*"}},
+		},
+	}}
+
+	t.Run("IAM-enabled cluster captures the principal mapping", func(t *testing.T) {
+		msk, ec2svc, metrics := defaultStubs()
+		msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+			return buildFullServerlessCluster(), nil
+		}
+		var analyzedArn string
+		iamSvc := &stubIAMAccessService{getClusterAccessFn: func(_ context.Context, clusterArn string) ([]types.IAMPrincipalAccess, error) {
+			analyzedArn = clusterArn
+			return access, nil
+		}}
+
+		cd := NewClusterDiscoverer(msk, ec2svc, metrics, &stubMSKConnectService{}, iamSvc, PageSizes{})
+		result, err := cd.Discover(context.Background(), testClusterArn, testRegion, true, true, "60s")
+
+		require.NoError(t, err)
+		assert.Equal(t, testClusterArn, analyzedArn)
+		assert.Equal(t, access, result.AWSClientInformation.IAMAccess)
+	})
+
+	t.Run("analysis failure is non-fatal", func(t *testing.T) {
+		msk, ec2svc, metrics := defaultStubs()
+		msk.describeClusterV2Fn = func(_ context.Context, _ string) (*kafka.DescribeClusterV2Output, error) {
+			return buildFullServerlessCluster(), nil
+		}
+		iamSvc := &stubIAMAccessService{getClusterAccessFn: func(_ context.Context, _ string) ([]types.IAMPrincipalAccess, error) {
+			return nil, errors.New("AccessDenied: iam:GetAccountAuthorizationDetails")
+		}}
+
+		cd := NewClusterDiscoverer(msk, ec2svc, metrics, &stubMSKConnectService{}, iamSvc, PageSizes{})
+		result, err := cd.Discover(context.Background(), testClusterArn, testRegion, true, true, "60s")
+
+		require.NoError(t, err)
+		assert.Empty(t, result.AWSClientInformation.IAMAccess)
+	})
+
+	t.Run("cluster without SASL/IAM is not analyzed", func(t *testing.T) {
+		cluster := buildFullProvisionedCluster().ClusterInfo
+		cluster.Provisioned.ClientAuthentication.Sasl.Iam.Enabled = aws.Bool(false)
+		assert.False(t, clusterUsesIAMAuth(*cluster))
+		cluster.Provisioned.ClientAuthentication.Sasl.Iam.Enabled = aws.Bool(true)
+		assert.True(t, clusterUsesIAMAuth(*cluster))
+	})
+}
+
+func brokerNode(brokerId float64, eniId string) kafkatypes.NodeInfo {
+	return kafkatypes.NodeInfo{
+		NodeType: kafkatypes.NodeTypeBroker,
+		BrokerNodeInfo: &kafkatypes.BrokerNodeInfo{
+			BrokerId:      aws.Float64(brokerId),
+			AttachedENIId: aws.String(eniId),
+			ClientSubnet:  aws.String("subnet-1"),
+		},
+	}
+}
+
+func TestScanNetworkingInfo_BrokerENIsAndKafkaPortRules(t *testing.T) {
+	_, ec2svc, _ := defaultStubs()
+	ec2svc.describeSubnetsFn = func(_ context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error) {
+		return &ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-12345")}}}, nil
+	}
+	ec2svc.describeNetworkInterfacesFn = func(_ context.Context, eniIds []string) (*ec2.DescribeNetworkInterfacesOutput, error) {
+		assert.ElementsMatch(t, []string{"eni-2", "eni-1"}, eniIds)
+		return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []ec2types.NetworkInterface{
+			{NetworkInterfaceId: aws.String("eni-2"), SubnetId: aws.String("subnet-1"), PrivateIpAddress: aws.String("10.0.0.12"), Groups: []ec2types.GroupIdentifier{{GroupId: aws.String("sg-msk")}}},
+			{NetworkInterfaceId: aws.String("eni-1"), SubnetId: aws.String("subnet-1"), PrivateIpAddress: aws.String("10.0.0.11"), Groups: []ec2types.GroupIdentifier{{GroupId: aws.String("sg-msk")}}},
+		}}, nil
+	}
+	ec2svc.describeSecurityGroupsFn = func(_ context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error) {
+		assert.Equal(t, []string{"sg-msk"}, groupIds)
+		return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []ec2types.SecurityGroup{{
+			GroupId: aws.String("sg-msk"),
+			IpPermissions: []ec2types.IpPermission{
+				{IpProtocol: aws.String("tcp"), FromPort: aws.Int32(9098), ToPort: aws.Int32(9098), IpRanges: []ec2types.IpRange{{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("clients")}}},
+				{IpProtocol: aws.String("tcp"), FromPort: aws.Int32(22), ToPort: aws.Int32(22), IpRanges: []ec2types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+				{IpProtocol: aws.String("-1"), UserIdGroupPairs: []ec2types.UserIdGroupPair{{GroupId: aws.String("sg-msk")}}},
+			},
+		}}}, nil
+	}
+
+	cd := newTestClusterDiscoverer(&stubMSKService{}, ec2svc, &stubMetricService{})
+	networking, err := cd.scanNetworkingInfo(context.Background(), buildFullProvisionedCluster(), []kafkatypes.NodeInfo{brokerNode(2, "eni-2"), brokerNode(1, "eni-1")})
+
+	require.NoError(t, err)
+	require.Len(t, networking.BrokerENIs, 2)
+	assert.Equal(t, types.BrokerENI{BrokerId: 1, NetworkInterfaceId: "eni-1", SubnetId: "subnet-1", PrivateIpAddress: "10.0.0.11", SecurityGroupIds: []string{"sg-msk"}}, networking.BrokerENIs[0])
+	assert.Equal(t, []types.SecurityGroupInboundRule{
+		{SecurityGroupId: "sg-msk", Protocol: "tcp", FromPort: 9098, ToPort: 9098, SourceType: "cidr", Source: "10.0.0.0/16", Description: "clients"},
+		{SecurityGroupId: "sg-msk", Protocol: "-1", FromPort: -1, ToPort: -1, SourceType: "security-group", Source: "sg-msk"},
+	}, networking.SecurityGroupRules)
+}
+
+func TestScanNetworkingInfo_DeniedENILookupDoesNotFailScan(t *testing.T) {
+	_, ec2svc, _ := defaultStubs()
+	ec2svc.describeSubnetsFn = func(_ context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error) {
+		return &ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-12345")}}}, nil
+	}
+	ec2svc.describeNetworkInterfacesFn = func(_ context.Context, _ []string) (*ec2.DescribeNetworkInterfacesOutput, error) {
+		return nil, errors.New("UnauthorizedOperation")
+	}
+
+	cd := newTestClusterDiscoverer(&stubMSKService{}, ec2svc, &stubMetricService{})
+	networking, err := cd.scanNetworkingInfo(context.Background(), buildFullProvisionedCluster(), []kafkatypes.NodeInfo{brokerNode(1, "eni-1")})
+
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-12345", networking.VpcId)
+	assert.Empty(t, networking.BrokerENIs)
+	assert.Empty(t, networking.SecurityGroupRules)
+}
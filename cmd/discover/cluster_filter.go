@@ -0,0 +1,72 @@
+package discover
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+)
+
+// ClusterFilter scopes full-region discovery to MSK clusters of the given
+// types and states. An empty list matches everything.
+type ClusterFilter struct {
+	ClusterTypes []kafkatypes.ClusterType
+	States       []kafkatypes.ClusterState
+}
+
+// parseClusterFilter validates --cluster-types (provisioned, serverless)
+// and --states (ACTIVE, UPDATING, ...), both case-insensitive.
+func parseClusterFilter(clusterTypes, states []string) (ClusterFilter, error) {
+	var filter ClusterFilter
+	for _, raw := range clusterTypes {
+		clusterType := kafkatypes.ClusterType(strings.ToUpper(strings.TrimSpace(raw)))
+		if !slices.Contains(clusterType.Values(), clusterType) {
+			return ClusterFilter{}, fmt.Errorf("invalid --cluster-types value %q: must be one of: provisioned, serverless", raw)
+		}
+		if !slices.Contains(filter.ClusterTypes, clusterType) {
+			filter.ClusterTypes = append(filter.ClusterTypes, clusterType)
+		}
+	}
+	for _, raw := range states {
+		state := kafkatypes.ClusterState(strings.ToUpper(strings.TrimSpace(raw)))
+		if !slices.Contains(state.Values(), state) {
+			valid := make([]string, 0, len(state.Values()))
+			for _, v := range state.Values() {
+				valid = append(valid, string(v))
+			}
+			return ClusterFilter{}, fmt.Errorf("invalid --states value %q: must be one of: %s", raw, strings.Join(valid, ", "))
+		}
+		if !slices.Contains(filter.States, state) {
+			filter.States = append(filter.States, state)
+		}
+	}
+	return filter, nil
+}
+
+// isZero reports whether the filter matches every cluster.
+func (f ClusterFilter) isZero() bool {
+	return len(f.ClusterTypes) == 0 && len(f.States) == 0
+}
+
+// apiClusterType is the type ListClustersV2 can filter on server-side. Its
+// ClusterTypeFilter takes a single type, so it is empty unless exactly one
+// type was asked for.
+func (f ClusterFilter) apiClusterType() kafkatypes.ClusterType {
+	if len(f.ClusterTypes) == 1 {
+		return f.ClusterTypes[0]
+	}
+	return ""
+}
+
+// matches applies the filter client-side. ListClustersV2 has no state
+// filter, and a multi-type filter can't be expressed server-side.
+func (f ClusterFilter) matches(cluster kafkatypes.Cluster) bool {
+	if len(f.ClusterTypes) > 0 && !slices.Contains(f.ClusterTypes, cluster.ClusterType) {
+		return false
+	}
+	if len(f.States) > 0 && !slices.Contains(f.States, cluster.State) {
+		return false
+	}
+	return true
+}
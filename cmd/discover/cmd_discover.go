@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/notify"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -20,7 +23,7 @@ const (
 
 func discoverIAMAnnotation() string {
 	return iampolicy.RenderStatements(
-		"The following policy covers a full run. If you pass `--skip-topics`, `--skip-costs`, or `--skip-metrics`, the corresponding statements can be omitted.",
+		"The following policy covers a full run. If you pass `--skip-topics`, `--skip-costs`, or `--skip-metrics`, the corresponding statements can be omitted. The Secrets Manager statement is only needed with `--scan-secrets`.",
 		[]iampolicy.Statement{
 			{
 				Sid: "MSKScanPermissions",
@@ -32,9 +35,11 @@ func discoverIAMAnnotation() string {
 					"kafka:GetBootstrapBrokers",
 					"kafka:ListConfigurations",
 					"kafka:DescribeClusterV2",
+					"kafka:ListTagsForResource",
 					"kafka:ListKafkaVersions",
 					"kafka:ListNodes",
 					"kafka:ListClusterOperationsV2",
+					"kafka:DescribeClusterOperationV2",
 					"kafka:ListScramSecrets",
 					"kafka:ListClientVpcConnections",
 					"kafka:GetClusterPolicy",
@@ -68,14 +73,34 @@ func discoverIAMAnnotation() string {
 				},
 			},
 			{
-				Sid:     "MSKNetworkingScanPermission",
-				Actions: []string{"ec2:DescribeSubnets"},
+				Sid: "MSKNetworkingScanPermission",
+				Actions: []string{
+					"ec2:DescribeSubnets",
+					"ec2:DescribeNetworkInterfaces",
+					"ec2:DescribeSecurityGroups",
+				},
 			},
 			{
 				Sid: "MSKConnectScanPermissions",
 				Actions: []string{
 					"kafkaconnect:ListConnectors",
 					"kafkaconnect:DescribeConnector",
+					"kafkaconnect:ListConnectorOperations",
+					"kafkaconnect:DescribeConnectorOperation",
+				},
+			},
+			{
+				Sid: "IAMAccessScanPermissions",
+				Actions: []string{
+					"iam:GetAccountAuthorizationDetails",
+					"iam:SimulatePrincipalPolicy",
+				},
+			},
+			{
+				Sid: "SecretsManagerScanPermissions",
+				Actions: []string{
+					"secretsmanager:ListSecrets",
+					"secretsmanager:GetResourcePolicy",
 				},
 			},
 		},
@@ -89,6 +114,14 @@ var (
 	skipTopics         bool
 	metricsGranularity string
 	clusterArns        []string
+	clusterTypes       []string
+	clusterStates      []string
+	scanSecrets        bool
+	since              string
+	resume             bool
+	outputDir          string
+	notifyOpts         notify.Options
+	pageSizes          PageSizes
 )
 
 func NewDiscoverCmd() *cobra.Command {
@@ -121,11 +154,38 @@ func NewDiscoverCmd() *cobra.Command {
 
   The finer the granularity, the more detailed the metrics data, but also more data is stored in the state-file, resulting in state-file growth. Coarser granularity is recommended for averaging workloads over longer time periods, but will smooth out spikes, while finer granularity is recommended for analyzing more bursty workloads and uncovering spikes over short time periods.
 
+  # Scope a large account to the active and updating provisioned clusters; ListClustersV2
+  # filters on the cluster type, the states are filtered client-side
+  kcp discover --region us-east-1 --cluster-types provisioned --states ACTIVE,UPDATING
+
+  # Also record the Secrets Manager secrets holding Kafka credentials (SCRAM or tagged for
+  # Kafka/MSK) so the plan lists what to re-issue as Confluent Cloud API keys; values are never read
+  kcp discover --region us-east-1 --scan-secrets
+
   # Discover a single cluster (region inferred from the ARN); create or replace it in state
   kcp discover --cluster-arn arn:aws:kafka:us-east-1:123456789012:cluster/my-cluster/uuid
 
   # Re-discover one cluster at a finer metrics granularity without touching other clusters
   kcp discover --cluster-arn arn:aws:kafka:us-east-1:123456789012:cluster/my-cluster/uuid --metrics-granularity 60s
+
+  # Daily re-discover: clusters with no version change or cluster operation in the last 24h keep
+  # their configuration and metrics from kcp-state.json; only operations, connectors and new or
+  # resized topics are fetched again
+  kcp discover --region us-east-1 --since 24h
+
+  # Pick up a run that was interrupted (network blip, expired credentials) where it left off
+  kcp discover --region us-east-1 --region eu-west-3 --resume
+
+  # Write kcp-state.json and msk-credentials.yaml to a CI workspace (or set OUTPUT_DIR)
+  kcp discover --region us-east-1 --output-dir /workspace/kcp
+
+  # Smaller pages for an account whose cluster and operation listings time out
+  # (or set PAGE_SIZE_CLUSTERS / PAGE_SIZE_OPERATIONS)
+  kcp discover --region us-east-1 --page-size-clusters 20 --page-size-operations 25
+
+  # Nightly cron run that reports success or failure to an SNS topic and a Slack webhook
+  kcp discover --region us-east-1 --notify sns:arn:aws:sns:us-east-1:123456789012:kcp-scans \
+      --notify-webhook https://hooks.slack.com/services/T000/B000/XXXX
   `,
 		Annotations: map[string]string{
 			iampolicy.AnnotationKey: discoverIAMAnnotation(),
@@ -133,7 +193,7 @@ func NewDiscoverCmd() *cobra.Command {
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 		PreRunE:       preRunDiscover,
-		RunE:          runDiscover,
+		RunE:          notify.WrapRunE(&notifyOpts, runDiscover),
 	}
 
 	groups := map[*pflag.FlagSet]string{}
@@ -154,18 +214,37 @@ func NewDiscoverCmd() *cobra.Command {
 	optionalFlags.BoolVar(&skipCosts, "skip-costs", false, "Skips the cost discovery through the AWS Cost Explorer API")
 	optionalFlags.BoolVar(&skipMetrics, "skip-metrics", false, "Skips the metrics discovery through the AWS CloudWatch API")
 	optionalFlags.StringVar(&metricsGranularity, "metrics-granularity", "1d", "The granularity for which to query for CloudWatch metrics. Valid values: 60s, 5m, 1h, 1d. The maximum time range for each granularity is: 60s = 15 days, 5m = 63 days, 1h = 365 days, 1d = 365 days.")
+	optionalFlags.StringSliceVar(&clusterTypes, "cluster-types", []string{}, "Discover only clusters of these types: provisioned, serverless (comma separated or repeated flag). Clusters outside the filter are left as they are in the state file.")
+	optionalFlags.StringSliceVar(&clusterStates, "states", []string{}, "Discover only clusters in these states, e.g. ACTIVE,UPDATING (comma separated or repeated flag). Clusters outside the filter are left as they are in the state file.")
+	optionalFlags.BoolVar(&scanSecrets, "scan-secrets", false, "Also scan AWS Secrets Manager for secrets holding Kafka credentials: MSK SCRAM secrets and secrets tagged for Kafka or MSK. Only metadata (rotation, resource policy principals, last access) is recorded, never secret values.")
+	optionalFlags.StringVar(&since, "since", "", "Incrementally refresh clusters already in kcp-state.json, reusing their stored data unless the cluster changed after this time. An RFC3339 timestamp or a duration before now (e.g. 24h, 7d).")
+	optionalFlags.BoolVar(&resume, "resume", false, "Resume an interrupted or partially failed discover from its checkpoint ("+checkpointDirName+"), re-discovering only the regions and clusters that did not complete. Requires the same flags as the original run.")
+	optionalFlags.StringVar(&outputDir, "output-dir", ".", "Directory to write "+stateFileName+", "+credentialsFileName+" and the discover checkpoint to, e.g. a mounted volume or CI workspace. An existing state file there is refreshed.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
 	discoverCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
+	pageSizeFlags := pflag.NewFlagSet("page-size", pflag.ExitOnError)
+	pageSizeFlags.SortFlags = false
+	pageSizeFlags.Int32Var(&pageSizes.Clusters, "page-size-clusters", maxPageSize, "MaxResults per page when listing a region's MSK clusters (1-100). Lower it if the calls time out.")
+	pageSizeFlags.Int32Var(&pageSizes.Nodes, "page-size-nodes", maxPageSize, "MaxResults per page when listing a cluster's broker nodes (1-100).")
+	pageSizeFlags.Int32Var(&pageSizes.Operations, "page-size-operations", maxPageSize, "MaxResults per page when listing a cluster's operations (1-100). Clusters with a long operation history make the most of these calls.")
+	pageSizeFlags.Int32Var(&pageSizes.VpcConnections, "page-size-vpc-connections", maxPageSize, "MaxResults per page when listing a cluster's client VPC connections (1-100).")
+	discoverCmd.Flags().AddFlagSet(pageSizeFlags)
+	groups[pageSizeFlags] = "Page Size Flags"
+
 	discoverCmd.MarkFlagsMutuallyExclusive("skip-metrics", "metrics-granularity")
 	discoverCmd.MarkFlagsMutuallyExclusive("region", "cluster-arn")
+	discoverCmd.MarkFlagsMutuallyExclusive("cluster-arn", "cluster-types")
+	discoverCmd.MarkFlagsMutuallyExclusive("cluster-arn", "states")
 	discoverCmd.MarkFlagsOneRequired("region", "cluster-arn")
 
 	discoverCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Short)
 
-		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
-		groupNames := []string{"Required Flags (provide exactly one)", "Optional Flags"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, pageSizeFlags}
+		groupNames := []string{"Required Flags (provide exactly one)", "Optional Flags", "Page Size Flags"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -187,6 +266,10 @@ func preRunDiscover(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
+
 	// Validate metrics granularity.
 	switch metricsGranularity {
 	case "60s", "5m", "1h", "1d":
@@ -194,6 +277,20 @@ func preRunDiscover(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid metrics-granularity %q: must be one of: 60s, 5m, 1h, 1d", metricsGranularity)
 	}
 
+	if since != "" {
+		if _, err := utils.ParseSince(since, time.Now()); err != nil {
+			return fmt.Errorf("invalid --since %q: %w", since, err)
+		}
+	}
+
+	if _, err := parseClusterFilter(clusterTypes, clusterStates); err != nil {
+		return err
+	}
+
+	if err := pageSizes.validate(); err != nil {
+		return err
+	}
+
 	// Validate cluster ARNs are well-formed (region is parsed from each ARN).
 	if len(clusterArns) > 0 {
 		if _, err := regionsFromClusterArns(clusterArns); err != nil {
@@ -212,7 +309,7 @@ func runDiscover(cmd *cobra.Command, args []string) error {
 
 	discoverer := NewDiscoverer(*opts)
 
-	if err := discoverer.Run(); err != nil {
+	if err := discoverer.Run(cmd.Context()); err != nil {
 		return fmt.Errorf("failed to discover: %v", err)
 	}
 
@@ -223,8 +320,14 @@ func parseDiscoverOpts() (*DiscovererOpts, error) {
 	var state *types.State
 	var credentials *types.Credentials
 
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %v", err)
+	}
+	statePath := filepath.Join(outputDir, stateFileName)
+	credentialsPath := filepath.Join(outputDir, credentialsFileName)
+
 	// Check if existing state file exists
-	if _, err := os.Stat(stateFileName); os.IsNotExist(err) {
+	if _, err := os.Stat(statePath); os.IsNotExist(err) {
 		// No state file found - start fresh
 		slog.Debug("starting with fresh state")
 	} else if err != nil {
@@ -232,16 +335,16 @@ func parseDiscoverOpts() (*DiscovererOpts, error) {
 		return nil, fmt.Errorf("failed to check state file: %v", err)
 	} else {
 		// State file exists - load it
-		slog.Debug("Found existing state file, attempting to load it", "file", stateFileName)
-		state, err = types.NewStateFromFile(stateFileName)
+		slog.Debug("Found existing state file, attempting to load it", "file", statePath)
+		state, err = types.NewStateFromFile(statePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load existing state file: %v", err)
 		}
-		slog.Debug("Loaded existing state file", "file", stateFileName)
+		slog.Debug("Loaded existing state file", "file", statePath)
 	}
 
 	// Check if existing credentials file exists
-	if _, err := os.Stat(credentialsFileName); os.IsNotExist(err) {
+	if _, err := os.Stat(credentialsPath); os.IsNotExist(err) {
 		// No credentials file found - start fresh
 		slog.Debug("starting with fresh credentials")
 	} else if err != nil {
@@ -250,11 +353,23 @@ func parseDiscoverOpts() (*DiscovererOpts, error) {
 	} else {
 		// Credentials file exists - load it
 		var errs []error
-		credentials, errs = types.NewCredentialsFromFile(credentialsFileName)
+		credentials, errs = types.NewCredentialsFromFile(credentialsPath)
 		if len(errs) > 0 {
 			return nil, fmt.Errorf("failed to load existing credentials file: %v", errs)
 		}
-		slog.Debug("using existing credentials file", "file", credentialsFileName)
+		slog.Debug("using existing credentials file", "file", credentialsPath)
+	}
+
+	var sinceTime time.Time
+	if since != "" {
+		var err error
+		sinceTime, err = utils.ParseSince(since, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since %q: %w", since, err)
+		}
+		if state == nil {
+			fmt.Printf("⚠️  --since has no effect without an existing %s; running a full discover\n", statePath)
+		}
 	}
 
 	// In targeted mode regions are inferred from the cluster ARNs; otherwise use --region.
@@ -267,6 +382,11 @@ func parseDiscoverOpts() (*DiscovererOpts, error) {
 		effectiveRegions = derived
 	}
 
+	clusterFilter, err := parseClusterFilter(clusterTypes, clusterStates)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DiscovererOpts{
 		Regions:            effectiveRegions,
 		SkipCosts:          skipCosts,
@@ -276,5 +396,11 @@ func parseDiscoverOpts() (*DiscovererOpts, error) {
 		Credentials:        credentials,
 		MetricsGranularity: metricsGranularity,
 		ClusterArns:        clusterArns,
+		ClusterFilter:      clusterFilter,
+		ScanSecrets:        scanSecrets,
+		Since:              sinceTime,
+		Resume:             resume,
+		OutputDir:          outputDir,
+		PageSizes:          pageSizes,
 	}, nil
 }
@@ -256,6 +256,78 @@ func TestDiscoverMatchingConnectors_DoesNotLogRawSecret(t *testing.T) {
 	assert.NotContains(t, logBuf.String(), "hunter2", "raw secret must never be logged")
 }
 
+func TestDiscoverMatchingConnectors_DiagnosesFailedConnectors(t *testing.T) {
+	failed := iamConnectorSummary("pg-sink")
+	failed.ConnectorState = kafkaconnecttypes.ConnectorStateFailed
+	running := iamConnectorSummary("s3-sink")
+
+	var listedOps []string
+	connect := &stubMSKConnectService{
+		listConnectorsFn: func(context.Context, *kafkaconnect.ListConnectorsInput, ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorsOutput, error) {
+			return &kafkaconnect.ListConnectorsOutput{Connectors: []kafkaconnecttypes.ConnectorSummary{failed, running}}, nil
+		},
+		describeConnectorFn: func(_ context.Context, params *kafkaconnect.DescribeConnectorInput, _ ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOutput, error) {
+			return &kafkaconnect.DescribeConnectorOutput{
+				ConnectorArn:            params.ConnectorArn,
+				ServiceExecutionRoleArn: aws.String("arn:aws:iam::123:role/pg-sink"),
+				StateDescription:        &kafkaconnecttypes.StateDescription{Code: aws.String("CONNECTOR_FAILED"), Message: aws.String("unable to reach database")},
+				LogDelivery: &kafkaconnecttypes.LogDeliveryDescription{WorkerLogDelivery: &kafkaconnecttypes.WorkerLogDeliveryDescription{
+					CloudWatchLogs: &kafkaconnecttypes.CloudWatchLogsLogDeliveryDescription{Enabled: true, LogGroup: aws.String("/msk/connect/pg-sink")},
+				}},
+			}, nil
+		},
+		listConnectorOpsFn: func(_ context.Context, params *kafkaconnect.ListConnectorOperationsInput, _ ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorOperationsOutput, error) {
+			listedOps = append(listedOps, aws.ToString(params.ConnectorArn))
+			return &kafkaconnect.ListConnectorOperationsOutput{ConnectorOperations: []kafkaconnecttypes.ConnectorOperationSummary{
+				{ConnectorOperationArn: aws.String("op-2"), ConnectorOperationType: kafkaconnecttypes.ConnectorOperationTypeUpdateConnectorConfiguration, ConnectorOperationState: kafkaconnecttypes.ConnectorOperationStateUpdateFailed},
+				{ConnectorOperationArn: aws.String("op-1"), ConnectorOperationType: kafkaconnecttypes.ConnectorOperationTypeUpdateWorkerSetting, ConnectorOperationState: kafkaconnecttypes.ConnectorOperationStateUpdateComplete},
+			}}, nil
+		},
+		describeConnOpFn: func(_ context.Context, params *kafkaconnect.DescribeConnectorOperationInput, _ ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOperationOutput, error) {
+			require.Equal(t, "op-2", aws.ToString(params.ConnectorOperationArn), "only failed operations are described")
+			return &kafkaconnect.DescribeConnectorOperationOutput{ErrorInfo: &kafkaconnecttypes.StateDescription{Code: aws.String("InvalidConfig"), Message: aws.String("bad connection.url")}}, nil
+		},
+	}
+	msk, ec2svc, metrics := defaultStubs()
+	cd := newTestClusterDiscovererWithConnect(msk, ec2svc, metrics, connect)
+
+	connectors, err := cd.discoverMatchingConnectors(context.Background(), awsClientInfoWithIAMBrokers())
+	require.NoError(t, err)
+	require.Len(t, connectors, 2)
+	assert.Nil(t, connectors[1].Diagnostics, "running connectors carry no diagnostics")
+	assert.Equal(t, []string{aws.ToString(failed.ConnectorArn)}, listedOps)
+
+	d := connectors[0].Diagnostics
+	require.NotNil(t, d)
+	assert.Equal(t, "CONNECTOR_FAILED", d.StateCode)
+	assert.Equal(t, "unable to reach database", d.StateMessage)
+	assert.Equal(t, "arn:aws:iam::123:role/pg-sink", d.ServiceExecutionRoleArn)
+	assert.Equal(t, []string{"CloudWatch Logs /msk/connect/pg-sink"}, d.LogDestinations())
+	require.Len(t, d.Operations, 2)
+	assert.Equal(t, "bad connection.url", d.Operations[0].ErrorMessage)
+	assert.Empty(t, d.Operations[1].ErrorCode)
+}
+
+func TestDiscoverMatchingConnectors_FailedConnectorKeptWhenOperationsUnavailable(t *testing.T) {
+	failed := iamConnectorSummary("pg-sink")
+	failed.ConnectorState = kafkaconnecttypes.ConnectorStateFailed
+	connect := &stubMSKConnectService{
+		listConnectorsFn:    listOneConnector(failed),
+		describeConnectorFn: describeWithConfig(map[string]string{"tasks.max": "1"}),
+		listConnectorOpsFn: func(context.Context, *kafkaconnect.ListConnectorOperationsInput, ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorOperationsOutput, error) {
+			return nil, errors.New("AccessDeniedException")
+		},
+	}
+	msk, ec2svc, metrics := defaultStubs()
+	cd := newTestClusterDiscovererWithConnect(msk, ec2svc, metrics, connect)
+
+	connectors, err := cd.discoverMatchingConnectors(context.Background(), awsClientInfoWithIAMBrokers())
+	require.NoError(t, err)
+	require.Len(t, connectors, 1)
+	require.NotNil(t, connectors[0].Diagnostics)
+	assert.Empty(t, connectors[0].Diagnostics.Operations)
+}
+
 // bootstrapMatches must compare host:port entries by exact equality, not substring
 // containment, so a cluster broker address can't spuriously match an unrelated
 // connector whose bootstrap host merely shares a DNS prefix/suffix.
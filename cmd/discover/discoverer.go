@@ -4,19 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/confluentinc/kcp/internal/client"
 	"github.com/confluentinc/kcp/internal/services/cost"
 	"github.com/confluentinc/kcp/internal/services/ec2"
+	"github.com/confluentinc/kcp/internal/services/iam"
+	"github.com/confluentinc/kcp/internal/services/kafka_secrets"
 	"github.com/confluentinc/kcp/internal/services/markdown"
 	"github.com/confluentinc/kcp/internal/services/metrics"
+	"github.com/confluentinc/kcp/internal/services/metricshistory"
 	"github.com/confluentinc/kcp/internal/services/msk"
 	"github.com/confluentinc/kcp/internal/services/msk_connect"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/tracing"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type DiscovererOpts struct {
@@ -28,6 +36,23 @@ type DiscovererOpts struct {
 	Credentials        *types.Credentials
 	MetricsGranularity string
 	ClusterArns        []string
+	// ClusterFilter scopes full-region discovery to clusters of the given
+	// types and states; clusters outside it are left untouched in State.
+	ClusterFilter ClusterFilter
+	// ScanSecrets also records the Secrets Manager secrets that hold Kafka
+	// credentials in each region.
+	ScanSecrets bool
+	// Since enables incremental discovery: clusters already in State with no
+	// changes after Since reuse their stored data. Zero means a full discover.
+	Since time.Time
+	// Resume picks up from the checkpoint left by an interrupted or partially
+	// failed run instead of discovering every region and cluster again.
+	Resume bool
+	// OutputDir is where the state file, credentials file and checkpoint are
+	// written. Empty means the working directory.
+	OutputDir string
+	// PageSizes sets the MaxResults of the paginated MSK list calls.
+	PageSizes PageSizes
 }
 
 type Discoverer struct {
@@ -39,6 +64,13 @@ type Discoverer struct {
 	credentials        *types.Credentials
 	metricsGranularity string
 	clusterArns        []string
+	clusterFilter      ClusterFilter
+	scanSecrets        bool
+	since              time.Time
+	resume             bool
+	outputDir          string
+	checkpointDir      string
+	pageSizes          PageSizes
 }
 
 func NewDiscoverer(opts DiscovererOpts) *Discoverer {
@@ -51,20 +83,42 @@ func NewDiscoverer(opts DiscovererOpts) *Discoverer {
 		credentials:        opts.Credentials,
 		metricsGranularity: opts.MetricsGranularity,
 		clusterArns:        opts.ClusterArns,
+		clusterFilter:      opts.ClusterFilter,
+		scanSecrets:        opts.ScanSecrets,
+		since:              opts.Since,
+		resume:             opts.Resume,
+		outputDir:          opts.OutputDir,
+		checkpointDir:      filepath.Join(opts.OutputDir, checkpointDirName),
+		pageSizes:          opts.PageSizes,
 	}
 }
 
-func (d *Discoverer) Run() error {
+// StatePath is where Run writes the state file for outputDir.
+func StatePath(outputDir string) string {
+	return filepath.Join(outputDir, stateFileName)
+}
+
+// CredentialsPath is where Run writes the credentials file for outputDir.
+func CredentialsPath(outputDir string) string {
+	return filepath.Join(outputDir, credentialsFileName)
+}
+
+func (d *Discoverer) Run(ctx context.Context) error {
 	fmt.Printf("🚀 Starting discover\n")
 
-	if err := d.discoverRegions(); err != nil {
+	if err := d.discoverRegions(ctx); err != nil {
 		slog.Error("failed to discover regions", "error", err)
 	}
 
+	// An interrupted discover still wrote what it finished; fail the
+	// command so scripts don't mistake it for a complete run.
+	if ctx.Err() != nil {
+		return fmt.Errorf("discover interrupted: %w", context.Cause(ctx))
+	}
 	return nil
 }
 
-func (d *Discoverer) discoverRegions() error {
+func (d *Discoverer) discoverRegions(ctx context.Context) error {
 	regionsWithoutClusters := []string{}
 	// initialize state/credentials from existing state/credentials if passed in
 	state := types.NewStateFrom(d.state)
@@ -72,12 +126,35 @@ func (d *Discoverer) discoverRegions() error {
 
 	matchedArns := map[string]bool{}
 
+	checkpoint, err := openDiscoverCheckpoint(d.checkpointDir, d.checkpointManifest(), d.resume)
+	if err != nil {
+		slog.Warn("⚠️ failed to open discover checkpoint; this run cannot be resumed", "error", err)
+		checkpoint = nil
+	}
+	failures := 0
+	interrupted := false
+
+	// IAM is global, so one service (and one account authorization fetch) is
+	// shared by every region.
+	var iamAccessService ClusterDiscovererIAMAccessService
+	if iamClient, err := client.NewIAMClient(); err != nil {
+		slog.Warn("⚠️ failed to create iam client; skipping IAM access analysis", "error", err)
+	} else {
+		iamAccessService = iam.NewKafkaAccessService(iamClient)
+	}
+
 	for _, region := range d.regions {
+		if ctx.Err() != nil {
+			interrupted = true
+			break
+		}
+
 		// Using conservative rate limits to avoid AWS 429 Too Many Requests errors
 		// 8 requests per second with burst of 1 -
 		mskClient, err := client.NewMSKClient(region, 8, 1) // At the time of writing 8 requests is safe without rate limits. However, with the failed topics retry logic, we could bump this.
 		if err != nil {
 			slog.Error("failed to create msk client", "region", region, "error", err)
+			failures++
 			continue
 		}
 		mskService := msk.NewMSKService(mskClient)
@@ -85,6 +162,7 @@ func (d *Discoverer) discoverRegions() error {
 		costExplorerClient, err := client.NewCostExplorerClient(region)
 		if err != nil {
 			slog.Error("failed to create cost explorer client", "region", region, "error", err)
+			failures++
 			continue
 		}
 		costService := cost.NewCostService(costExplorerClient)
@@ -92,6 +170,7 @@ func (d *Discoverer) discoverRegions() error {
 		cloudWatchClient, err := client.NewCloudWatchClient(region)
 		if err != nil {
 			slog.Error("failed to create cloudwatch client", "region", region, "error", err)
+			failures++
 			continue
 		}
 		metricService := metrics.NewMetricService(cloudWatchClient)
@@ -99,49 +178,78 @@ func (d *Discoverer) discoverRegions() error {
 		ec2Service, err := ec2.NewEC2Service(region)
 		if err != nil {
 			slog.Error("failed to create ec2 service", "region", region, "error", err)
+			failures++
 			continue
 		}
 
 		mskConnectClient, err := client.NewMSKConnectClient(region)
 		if err != nil {
 			slog.Error("failed to create msk connect client", "region", region, "error", err)
+			failures++
 			continue
 		}
 		mskConnectService := msk_connect.NewMSKConnectService(mskConnectClient)
 
 		// discover region-level resources (costs, configurations, cluster ARNs)
-		regionDiscoverer := NewRegionDiscoverer(mskService, costService)
-		discoveredRegion, err := regionDiscoverer.Discover(context.Background(), region, d.skipCosts)
+		regionDiscoverer := NewRegionDiscoverer(mskService, costService, d.clusterFilter, d.pageSizes)
+		discoveredRegion, err := d.discoverRegion(ctx, checkpoint, regionDiscoverer, region)
 		if err != nil {
+			if ctx.Err() != nil {
+				interrupted = true
+				break
+			}
 			slog.Error("failed to discover region", "region", region, "error", err)
+			failures++
 			continue
 		}
 
 		// discover detailed cluster information for each cluster in the region
-		clusterDiscoverer := NewClusterDiscoverer(mskService, ec2Service, metricService, mskConnectService)
+		clusterDiscoverer := NewClusterDiscoverer(mskService, ec2Service, metricService, mskConnectService, iamAccessService, d.pageSizes)
 		discoveredClusters := []types.DiscoveredCluster{}
 
 		arnsToDiscover := filterArnsToDiscover(discoveredRegion.ClusterArns, d.clusterArns)
 		for _, clusterArn := range arnsToDiscover {
 			matchedArns[clusterArn] = true
-			discoveredCluster, err := clusterDiscoverer.Discover(context.Background(), clusterArn, region, d.skipTopics, d.skipMetrics, d.metricsGranularity)
+			if ctx.Err() != nil {
+				interrupted = true
+				break
+			}
+			discoveredCluster, err := d.discoverClusterWithCheckpoint(ctx, checkpoint, clusterDiscoverer, clusterArn, region)
 			if err != nil {
+				if ctx.Err() != nil {
+					interrupted = true
+					break
+				}
 				slog.Error("failed to discover cluster", "cluster", clusterArn, "error", err)
+				failures++
 				continue
 			}
 			discoveredClusters = append(discoveredClusters, *discoveredCluster)
 		}
 
 		discoveredRegion.Clusters = discoveredClusters
+		summary.Count("regions_discovered", 1)
+		summary.Count("clusters_discovered", len(discoveredClusters))
+
+		if d.scanSecrets && !interrupted {
+			discoveredRegion.KafkaSecrets = d.discoverKafkaSecrets(ctx, region, discoveredClusters)
+		}
 
 		// generate credential configurations for connecting to clusters
 		regionAuth, err := d.captureCredentialOptions(discoveredRegion.Clusters, region)
 		if err != nil {
 			slog.Error("failed to get region entry", "region", region, "error", err)
+			failures++
 			continue
 		}
 
-		persistDiscoveredRegion(state, credentials, *discoveredRegion, *regionAuth, len(d.clusterArns) > 0)
+		// A filtered scan only saw part of the region, so like targeted mode it
+		// must not drop the clusters it filtered out from state; neither may an
+		// interrupted one drop the clusters it did not get to.
+		persistDiscoveredRegion(state, credentials, *discoveredRegion, *regionAuth, len(d.clusterArns) > 0 || !d.clusterFilter.isZero() || interrupted)
+		if interrupted {
+			break
+		}
 
 		// track regions with/without clusters for reporting (full-region mode only;
 		// in targeted mode an unmatched ARN is reported via the warning below instead)
@@ -151,19 +259,36 @@ func (d *Discoverer) discoverRegions() error {
 	}
 
 	for _, requested := range d.clusterArns {
-		if !matchedArns[requested] {
+		if !matchedArns[requested] && !interrupted {
 			fmt.Printf("  ⚠️  Cluster ARN not found among discovered clusters: %s\n", requested)
 		}
 	}
 
-	if err := state.WriteToFile(stateFileName); err != nil {
+	summary.Count("failures", failures)
+
+	statePath := StatePath(d.outputDir)
+	if err := state.WriteToFile(statePath); err != nil {
 		return fmt.Errorf("failed to write state to file: %w", err)
 	}
+	recordMetricsHistory(statePath, state)
 
-	if err := credentials.WriteToFile(credentialsFileName); err != nil {
+	if err := credentials.WriteToFile(CredentialsPath(d.outputDir)); err != nil {
 		return fmt.Errorf("failed to write creds.yaml file: %w", err)
 	}
 
+	if checkpoint != nil {
+		switch {
+		case interrupted:
+			fmt.Printf("⚠️  Discover interrupted (%v); saved the clusters it finished. Re-run with --resume to continue from the checkpoint\n", context.Cause(ctx))
+		case failures == 0:
+			if err := checkpoint.clear(); err != nil {
+				slog.Warn("⚠️ failed to remove discover checkpoint", "error", err)
+			}
+		default:
+			fmt.Printf("⚠️  %d region or cluster discoveries failed; re-run with --resume to retry them without re-discovering completed clusters\n", failures)
+		}
+	}
+
 	// TODO: in future uncomment if users want to generate report commands or else delete this and the WriteReportCommands code
 	// if err := state.WriteReportCommands(reportCommandsFileName, stateFileName); err != nil {
 	// 	return fmt.Errorf("failed to write report commands to file: %w", err)
@@ -183,6 +308,122 @@ func (d *Discoverer) discoverRegions() error {
 	return nil
 }
 
+// recordMetricsHistory keeps this run's metrics and costs beside the state
+// file for the trend sections of the reports. The state file is already
+// written, so a failure only costs the trend.
+func recordMetricsHistory(statePath string, state *types.State) {
+	added, err := metricshistory.Record(metricshistory.PathFor(statePath), state)
+	if err != nil {
+		slog.Warn("⚠️ failed to record metrics history", "error", err)
+		return
+	}
+	if added > 0 {
+		slog.Debug("recorded metrics history", "snapshots", added, "path", metricshistory.PathFor(statePath))
+	}
+}
+
+func (d *Discoverer) checkpointManifest() checkpointManifest {
+	return checkpointManifest{
+		Regions:            d.regions,
+		ClusterArns:        d.clusterArns,
+		ClusterTypes:       d.clusterFilter.ClusterTypes,
+		States:             d.clusterFilter.States,
+		ScanSecrets:        d.scanSecrets,
+		SkipCosts:          d.skipCosts,
+		SkipMetrics:        d.skipMetrics,
+		SkipTopics:         d.skipTopics,
+		MetricsGranularity: d.metricsGranularity,
+		Since:              d.since,
+	}
+}
+
+// discoverKafkaSecrets lists the region's secrets that hold Kafka
+// credentials. The scan is optional, so a failure only warns and leaves the
+// region's secrets unscanned (nil), keeping any from a previous run.
+func (d *Discoverer) discoverKafkaSecrets(ctx context.Context, region string, clusters []types.DiscoveredCluster) []types.KafkaSecret {
+	secretsManagerClient, err := client.NewSecretsManagerClient(region)
+	if err != nil {
+		slog.Warn("⚠️ failed to create secrets manager client; skipping Kafka secrets scan", "region", region, "error", err)
+		return nil
+	}
+
+	scramClusters := map[string][]string{}
+	for _, cluster := range clusters {
+		for _, secretArn := range cluster.AWSClientInformation.ScramSecrets {
+			scramClusters[secretArn] = append(scramClusters[secretArn], cluster.Arn)
+		}
+	}
+
+	secrets, err := kafka_secrets.NewKafkaSecretsService(secretsManagerClient).ListKafkaSecrets(ctx, scramClusters)
+	if err != nil {
+		slog.Warn("⚠️ failed to scan Secrets Manager for Kafka secrets", "region", region, "error", err)
+		return nil
+	}
+	fmt.Printf("  ✅ Found %d Kafka secret(s) in region %s\n", len(secrets), region)
+	return secrets
+}
+
+// discoverRegion runs the region phase, or reuses it from the checkpoint.
+func (d *Discoverer) discoverRegion(ctx context.Context, checkpoint *discoverCheckpoint, regionDiscoverer *RegionDiscoverer, region string) (_ *types.DiscoveredRegion, err error) {
+	ctx, span := tracing.Start(ctx, "discover.region", attribute.String("cloud.region", region))
+	defer func() { tracing.End(span, err) }()
+
+	if checkpoint != nil {
+		if discoveredRegion, ok := checkpoint.loadRegion(region); ok {
+			fmt.Printf("⏭️  Resuming region %s from checkpoint\n", region)
+			return discoveredRegion, nil
+		}
+	}
+
+	discoveredRegion, err := regionDiscoverer.Discover(ctx, region, d.skipCosts)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint != nil {
+		if err := checkpoint.saveRegion(*discoveredRegion); err != nil {
+			slog.Warn("⚠️ failed to checkpoint region", "region", region, "error", err)
+		}
+	}
+	return discoveredRegion, nil
+}
+
+// discoverClusterWithCheckpoint discovers a cluster, or reuses it from the
+// checkpoint when a previous run already completed it.
+func (d *Discoverer) discoverClusterWithCheckpoint(ctx context.Context, checkpoint *discoverCheckpoint, clusterDiscoverer ClusterDiscoverer, clusterArn, region string) (_ *types.DiscoveredCluster, err error) {
+	ctx, span := tracing.Start(ctx, "discover.cluster", attribute.String("cloud.region", region), attribute.String("kafka.cluster", clusterArn))
+	defer func() { tracing.End(span, err) }()
+
+	if checkpoint != nil {
+		if discoveredCluster, ok := checkpoint.loadCluster(region, clusterArn); ok {
+			fmt.Printf("  ⏭️  Resuming cluster %s from checkpoint\n", discoveredCluster.Name)
+			return discoveredCluster, nil
+		}
+	}
+
+	discoveredCluster, err := d.discoverCluster(ctx, clusterDiscoverer, clusterArn, region)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint != nil {
+		if err := checkpoint.saveCluster(region, *discoveredCluster); err != nil {
+			slog.Warn("⚠️ failed to checkpoint cluster", "cluster", clusterArn, "error", err)
+		}
+	}
+	return discoveredCluster, nil
+}
+
+// discoverCluster runs an incremental discover when --since is set and the
+// cluster is already in state, and a full discover otherwise.
+func (d *Discoverer) discoverCluster(ctx context.Context, clusterDiscoverer ClusterDiscoverer, clusterArn, region string) (*types.DiscoveredCluster, error) {
+	if !d.since.IsZero() && d.state != nil {
+		if previous, err := d.state.GetClusterByArn(clusterArn); err == nil {
+			return clusterDiscoverer.DiscoverSince(ctx, *previous, d.since, d.skipTopics, d.skipMetrics, d.metricsGranularity)
+		}
+		slog.Debug("cluster not in state; running full discovery", "cluster", clusterArn)
+	}
+	return clusterDiscoverer.Discover(ctx, clusterArn, region, d.skipTopics, d.skipMetrics, d.metricsGranularity)
+}
+
 func (d *Discoverer) captureCredentialOptions(clusters []types.DiscoveredCluster, region string) (*types.RegionAuth, error) {
 	clusterAuths := []types.ClusterAuth{}
 
@@ -1,12 +1,15 @@
 package discover
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDiscoverer_getAvailableClusterAuthOptions(t *testing.T) {
@@ -293,3 +296,27 @@ func withUnauthenticatedTLS() clusterOption {
 		}
 	}
 }
+
+func TestParseDiscoverOpts_OutputDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "workspace", "kcp")
+	previous := outputDir
+	outputDir = dir
+	t.Cleanup(func() { outputDir = previous })
+
+	// The output directory is created on first use.
+	opts, err := parseDiscoverOpts()
+	require.NoError(t, err)
+	assert.Nil(t, opts.State)
+	assert.Equal(t, dir, opts.OutputDir)
+	_, err = os.Stat(dir)
+	require.NoError(t, err)
+
+	// An existing state file in the output directory is refreshed, not replaced.
+	require.NoError(t, types.NewStateFrom(nil).WriteToFile(filepath.Join(dir, stateFileName)))
+	opts, err = parseDiscoverOpts()
+	require.NoError(t, err)
+	assert.NotNil(t, opts.State)
+
+	d := NewDiscoverer(*opts)
+	assert.Equal(t, filepath.Join(dir, checkpointDirName), d.checkpointDir)
+}
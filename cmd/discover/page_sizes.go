@@ -0,0 +1,49 @@
+package discover
+
+import "fmt"
+
+// maxPageSize is the largest MaxResults the paginated MSK list APIs accept,
+// and the page size discover requests by default.
+const maxPageSize int32 = 100
+
+// PageSizes are the MaxResults discover requests per page from each family
+// of paginated MSK APIs. Full pages make the fewest calls against the MSK API
+// rate limits in large accounts; smaller pages keep each call short where
+// requests time out. Zero means maxPageSize.
+type PageSizes struct {
+	Clusters       int32
+	Nodes          int32
+	Operations     int32
+	VpcConnections int32
+}
+
+// validate checks each page size is within what the MSK API accepts, naming
+// the flag that sets it.
+func (p PageSizes) validate() error {
+	for _, f := range []struct {
+		flag string
+		size int32
+	}{
+		{"--page-size-clusters", p.Clusters},
+		{"--page-size-nodes", p.Nodes},
+		{"--page-size-operations", p.Operations},
+		{"--page-size-vpc-connections", p.VpcConnections},
+	} {
+		if f.size < 1 || f.size > maxPageSize {
+			return fmt.Errorf("invalid %s %d: must be between 1 and %d", f.flag, f.size, maxPageSize)
+		}
+	}
+	return nil
+}
+
+func (p PageSizes) clusters() int32       { return orMaxPageSize(p.Clusters) }
+func (p PageSizes) nodes() int32          { return orMaxPageSize(p.Nodes) }
+func (p PageSizes) operations() int32     { return orMaxPageSize(p.Operations) }
+func (p PageSizes) vpcConnections() int32 { return orMaxPageSize(p.VpcConnections) }
+
+func orMaxPageSize(size int32) int32 {
+	if size == 0 {
+		return maxPageSize
+	}
+	return size
+}
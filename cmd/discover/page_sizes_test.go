@@ -0,0 +1,52 @@
+package discover
+
+import (
+	"context"
+	"testing"
+
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageSizes_Validate(t *testing.T) {
+	valid := PageSizes{Clusters: 100, Nodes: 1, Operations: 50, VpcConnections: 100}
+	require.NoError(t, valid.validate())
+
+	tooLarge := valid
+	tooLarge.Operations = 500
+	assert.EqualError(t, tooLarge.validate(), "invalid --page-size-operations 500: must be between 1 and 100")
+
+	zero := valid
+	zero.VpcConnections = 0
+	assert.EqualError(t, zero.validate(), "invalid --page-size-vpc-connections 0: must be between 1 and 100")
+}
+
+func TestClusterDiscoverer_PageSizes(t *testing.T) {
+	var gotNodes, gotOperations, gotVpcConnections int32
+	msk, ec2svc, metrics := defaultStubs()
+	msk.listNodesFn = func(_ context.Context, _ string, maxResults int32) ([]kafkatypes.NodeInfo, error) {
+		gotNodes = maxResults
+		return nil, nil
+	}
+	msk.listClusterOperationsV2Fn = func(_ context.Context, _ string, maxResults int32) ([]kafkatypes.ClusterOperationV2Summary, error) {
+		gotOperations = maxResults
+		return nil, nil
+	}
+	msk.listClientVpcConnectionsFn = func(_ context.Context, _ string, maxResults int32) ([]kafkatypes.ClientVpcConnection, error) {
+		gotVpcConnections = maxResults
+		return nil, nil
+	}
+	cd := NewClusterDiscoverer(msk, ec2svc, metrics, &stubMSKConnectService{}, nil, PageSizes{Nodes: 10, Operations: 25})
+
+	_, err := cd.scanClusterNodes(context.Background(), testClusterArn)
+	require.NoError(t, err)
+	_, err = cd.scanClusterOperations(context.Background(), testClusterArn)
+	require.NoError(t, err)
+	_, err = cd.scanClusterVpcConnections(context.Background(), testClusterArn)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(10), gotNodes)
+	assert.Equal(t, int32(25), gotOperations)
+	assert.Equal(t, maxPageSize, gotVpcConnections)
+}
@@ -3,6 +3,7 @@ package discover
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -15,8 +16,9 @@ import (
 )
 
 type RegionDiscovererMSKService interface {
-	ListClusters(ctx context.Context, maxResults int32) ([]kafkatypes.Cluster, error)
+	ListClusters(ctx context.Context, maxResults int32, clusterType kafkatypes.ClusterType) ([]kafkatypes.Cluster, error)
 	GetConfigurations(ctx context.Context, maxResults int32) ([]kafka.DescribeConfigurationRevisionOutput, error)
+	GetReplicators(ctx context.Context, maxResults int32) ([]kafka.DescribeReplicatorOutput, error)
 }
 
 type RegionDiscovererCostService interface {
@@ -24,14 +26,18 @@ type RegionDiscovererCostService interface {
 }
 
 type RegionDiscoverer struct {
-	mskService  RegionDiscovererMSKService
-	costService RegionDiscovererCostService
+	mskService    RegionDiscovererMSKService
+	costService   RegionDiscovererCostService
+	clusterFilter ClusterFilter
+	pageSizes     PageSizes
 }
 
-func NewRegionDiscoverer(mskService RegionDiscovererMSKService, costService RegionDiscovererCostService) *RegionDiscoverer {
+func NewRegionDiscoverer(mskService RegionDiscovererMSKService, costService RegionDiscovererCostService, clusterFilter ClusterFilter, pageSizes PageSizes) *RegionDiscoverer {
 	return &RegionDiscoverer{
-		mskService:  mskService,
-		costService: costService,
+		mskService:    mskService,
+		costService:   costService,
+		clusterFilter: clusterFilter,
+		pageSizes:     pageSizes,
 	}
 }
 
@@ -41,13 +47,12 @@ func (rd *RegionDiscoverer) Discover(ctx context.Context, region string, skipCos
 		Name: region,
 	}
 
-	maxResults := int32(100)
-
-	configurations, err := rd.discoverConfigurations(ctx, maxResults)
+	configurations, err := rd.discoverConfigurations(ctx, maxPageSize)
 	if err != nil {
 		return nil, err
 	}
 	discoveredRegion.Configurations = configurations
+	discoveredRegion.Replicators = rd.discoverReplicators(ctx, region, maxPageSize)
 
 	if skipCosts {
 		fmt.Printf("  ⏭️  Skipping cost discovery\n")
@@ -60,7 +65,7 @@ func (rd *RegionDiscoverer) Discover(ctx context.Context, region string, skipCos
 		discoveredRegion.Costs = *regionCosts
 	}
 
-	clusterArns, err := rd.discoverClusterArns(ctx, maxResults)
+	clusterArns, err := rd.discoverClusterArns(ctx, rd.pageSizes.clusters())
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +82,18 @@ func (rd *RegionDiscoverer) discoverConfigurations(ctx context.Context, maxResul
 	return configurations, nil
 }
 
+// discoverReplicators is non-fatal: replicators only feed the plan's
+// replication mapping, so a missing kafka:ListReplicators grant should not
+// fail the whole region.
+func (rd *RegionDiscoverer) discoverReplicators(ctx context.Context, region string, maxResults int32) []kafka.DescribeReplicatorOutput {
+	replicators, err := rd.mskService.GetReplicators(ctx, maxResults)
+	if err != nil {
+		slog.Warn("⚠️ failed to discover MSK replicators", "region", region, "error", err)
+		return nil
+	}
+	return replicators
+}
+
 func (rd *RegionDiscoverer) discoverCosts(ctx context.Context, region string) (*types.CostInformation, error) {
 	// todo - include tags in future?
 	tags := []string{}
@@ -99,15 +116,23 @@ func (rd *RegionDiscoverer) discoverCosts(ctx context.Context, region string) (*
 func (rd *RegionDiscoverer) discoverClusterArns(ctx context.Context, maxResults int32) ([]string, error) {
 	fmt.Printf("  🔍 Listing clusters\n")
 
-	clusters, err := rd.mskService.ListClusters(ctx, maxResults)
+	clusters, err := rd.mskService.ListClusters(ctx, maxResults, rd.clusterFilter.apiClusterType())
 	if err != nil {
 		return nil, err
 	}
 
 	clusterArns := []string{}
+	skipped := 0
 	for _, cluster := range clusters {
+		if !rd.clusterFilter.matches(cluster) {
+			skipped++
+			continue
+		}
 		clusterArns = append(clusterArns, aws.ToString(cluster.ClusterArn))
 	}
+	if skipped > 0 {
+		fmt.Printf("  ⏭️  Skipping %d cluster(s) outside --cluster-types / --states\n", skipped)
+	}
 
 	return clusterArns, nil
 }
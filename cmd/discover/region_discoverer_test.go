@@ -17,7 +17,7 @@ import (
 
 func TestRegionDiscoverer_HappyPath(t *testing.T) {
 	msk := &stubRegionMSKService{
-		listClustersFn: func(_ context.Context, _ int32) ([]kafkatypes.Cluster, error) {
+		listClustersFn: func(_ context.Context, _ int32, _ kafkatypes.ClusterType) ([]kafkatypes.Cluster, error) {
 			return []kafkatypes.Cluster{
 				{ClusterArn: aws.String(testClusterArn)},
 			}, nil
@@ -25,7 +25,7 @@ func TestRegionDiscoverer_HappyPath(t *testing.T) {
 	}
 	cost := &stubCostService{}
 
-	rd := NewRegionDiscoverer(msk, cost)
+	rd := NewRegionDiscoverer(msk, cost, ClusterFilter{}, PageSizes{})
 	result, err := rd.Discover(context.Background(), testRegion, true /* skipCosts */)
 
 	require.NoError(t, err)
@@ -36,13 +36,13 @@ func TestRegionDiscoverer_HappyPath(t *testing.T) {
 
 func TestRegionDiscoverer_EmptyClusterList(t *testing.T) {
 	msk := &stubRegionMSKService{
-		listClustersFn: func(_ context.Context, _ int32) ([]kafkatypes.Cluster, error) {
+		listClustersFn: func(_ context.Context, _ int32, _ kafkatypes.ClusterType) ([]kafkatypes.Cluster, error) {
 			return []kafkatypes.Cluster{}, nil
 		},
 	}
 	cost := &stubCostService{}
 
-	rd := NewRegionDiscoverer(msk, cost)
+	rd := NewRegionDiscoverer(msk, cost, ClusterFilter{}, PageSizes{})
 	result, err := rd.Discover(context.Background(), testRegion, true)
 
 	require.NoError(t, err)
@@ -59,7 +59,7 @@ func TestRegionDiscoverer_SkipCosts(t *testing.T) {
 		},
 	}
 
-	rd := NewRegionDiscoverer(msk, cost)
+	rd := NewRegionDiscoverer(msk, cost, ClusterFilter{}, PageSizes{})
 	_, err := rd.Discover(context.Background(), testRegion, true /* skipCosts=true */)
 
 	require.NoError(t, err)
@@ -74,7 +74,7 @@ func TestRegionDiscoverer_CostAPIError(t *testing.T) {
 		},
 	}
 
-	rd := NewRegionDiscoverer(msk, cost)
+	rd := NewRegionDiscoverer(msk, cost, ClusterFilter{}, PageSizes{})
 	_, err := rd.Discover(context.Background(), testRegion, false /* skipCosts=false, will call cost API */)
 
 	require.Error(t, err)
@@ -91,9 +91,116 @@ func TestRegionDiscoverer_ConfigurationsIncluded(t *testing.T) {
 	}
 	cost := &stubCostService{}
 
-	rd := NewRegionDiscoverer(msk, cost)
+	rd := NewRegionDiscoverer(msk, cost, ClusterFilter{}, PageSizes{})
 	result, err := rd.Discover(context.Background(), testRegion, true)
 
 	require.NoError(t, err)
 	require.Len(t, result.Configurations, 1)
 }
+
+func TestRegionDiscoverer_Replicators(t *testing.T) {
+	t.Run("replicators included", func(t *testing.T) {
+		msk := &stubRegionMSKService{
+			getReplicatorsFn: func(_ context.Context, _ int32) ([]kafka.DescribeReplicatorOutput, error) {
+				return []kafka.DescribeReplicatorOutput{
+					{ReplicatorName: aws.String("orders-to-dr")},
+				}, nil
+			},
+		}
+
+		rd := NewRegionDiscoverer(msk, &stubCostService{}, ClusterFilter{}, PageSizes{})
+		result, err := rd.Discover(context.Background(), testRegion, true)
+
+		require.NoError(t, err)
+		require.Len(t, result.Replicators, 1)
+		assert.Equal(t, "orders-to-dr", aws.ToString(result.Replicators[0].ReplicatorName))
+	})
+
+	t.Run("replicator API error is non-fatal", func(t *testing.T) {
+		msk := &stubRegionMSKService{
+			getReplicatorsFn: func(_ context.Context, _ int32) ([]kafka.DescribeReplicatorOutput, error) {
+				return nil, errors.New("AccessDeniedException")
+			},
+		}
+
+		rd := NewRegionDiscoverer(msk, &stubCostService{}, ClusterFilter{}, PageSizes{})
+		result, err := rd.Discover(context.Background(), testRegion, true)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Replicators)
+	})
+}
+
+func TestRegionDiscoverer_ClusterFilter(t *testing.T) {
+	clusters := []kafkatypes.Cluster{
+		{ClusterArn: aws.String("arn:provisioned-active"), ClusterType: kafkatypes.ClusterTypeProvisioned, State: kafkatypes.ClusterStateActive},
+		{ClusterArn: aws.String("arn:provisioned-failed"), ClusterType: kafkatypes.ClusterTypeProvisioned, State: kafkatypes.ClusterStateFailed},
+		{ClusterArn: aws.String("arn:serverless-active"), ClusterType: kafkatypes.ClusterTypeServerless, State: kafkatypes.ClusterStateActive},
+	}
+
+	tests := []struct {
+		name        string
+		types       []string
+		states      []string
+		wantAPIType kafkatypes.ClusterType
+		wantArns    []string
+	}{
+		{"no filter", nil, nil, "", []string{"arn:provisioned-active", "arn:provisioned-failed", "arn:serverless-active"}},
+		{"one type goes to the API", []string{"provisioned"}, nil, kafkatypes.ClusterTypeProvisioned, []string{"arn:provisioned-active", "arn:provisioned-failed"}},
+		{"several types are filtered client-side", []string{"provisioned", "SERVERLESS"}, nil, "", []string{"arn:provisioned-active", "arn:provisioned-failed", "arn:serverless-active"}},
+		{"states are filtered client-side", nil, []string{"active", "UPDATING"}, "", []string{"arn:provisioned-active", "arn:serverless-active"}},
+		{"type and state", []string{"serverless"}, []string{"ACTIVE"}, kafkatypes.ClusterTypeServerless, []string{"arn:serverless-active"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseClusterFilter(tt.types, tt.states)
+			require.NoError(t, err)
+
+			var gotAPIType kafkatypes.ClusterType
+			msk := &stubRegionMSKService{
+				listClustersFn: func(_ context.Context, _ int32, clusterType kafkatypes.ClusterType) ([]kafkatypes.Cluster, error) {
+					gotAPIType = clusterType
+					// The stub ignores the type filter, so the client-side check still has to hold.
+					return clusters, nil
+				},
+			}
+
+			result, err := NewRegionDiscoverer(msk, &stubCostService{}, filter, PageSizes{}).Discover(context.Background(), testRegion, true)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAPIType, gotAPIType)
+			assert.Equal(t, tt.wantArns, result.ClusterArns)
+		})
+	}
+}
+
+func TestParseClusterFilter_RejectsUnknownValues(t *testing.T) {
+	_, err := parseClusterFilter([]string{"express"}, nil)
+	assert.ErrorContains(t, err, `invalid --cluster-types value "express": must be one of: provisioned, serverless`)
+
+	_, err = parseClusterFilter(nil, []string{"RUNNING"})
+	assert.ErrorContains(t, err, `invalid --states value "RUNNING": must be one of: ACTIVE,`)
+}
+
+func TestRegionDiscoverer_PageSizes(t *testing.T) {
+	var gotClusters, gotConfigurations int32
+	msk := &stubRegionMSKService{
+		listClustersFn: func(_ context.Context, maxResults int32, _ kafkatypes.ClusterType) ([]kafkatypes.Cluster, error) {
+			gotClusters = maxResults
+			return nil, nil
+		},
+		getConfigurationsFn: func(_ context.Context, maxResults int32) ([]kafka.DescribeConfigurationRevisionOutput, error) {
+			gotConfigurations = maxResults
+			return nil, nil
+		},
+	}
+
+	_, err := NewRegionDiscoverer(msk, &stubCostService{}, ClusterFilter{}, PageSizes{Clusters: 20}).Discover(context.Background(), testRegion, true)
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), gotClusters)
+	assert.Equal(t, maxPageSize, gotConfigurations, "configurations are not tunable")
+
+	_, err = NewRegionDiscoverer(msk, &stubCostService{}, ClusterFilter{}, PageSizes{}).Discover(context.Background(), testRegion, true)
+	require.NoError(t, err)
+	assert.Equal(t, maxPageSize, gotClusters, "an unset page size is the API maximum")
+}
@@ -15,20 +15,23 @@ import (
 )
 
 // ── stubMSKService ─────────────────────────────────────────────────────────────
-// Implements ClusterDiscovererMSKService (10 methods).
+// Implements ClusterDiscovererMSKService (13 methods).
 // Unset function fields return safe empty defaults.
 
 type stubMSKService struct {
 	describeClusterV2Fn          func(ctx context.Context, clusterArn string) (*kafka.DescribeClusterV2Output, error)
+	listTagsForResourceFn        func(ctx context.Context, resourceArn string) (map[string]string, error)
 	getBootstrapBrokersFn        func(ctx context.Context, clusterArn string) (*kafka.GetBootstrapBrokersOutput, error)
 	listClientVpcConnectionsFn   func(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.ClientVpcConnection, error)
 	listClusterOperationsV2Fn    func(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.ClusterOperationV2Summary, error)
+	describeClusterOpV2Fn        func(ctx context.Context, operationArn string) (*kafkatypes.ClusterOperationV2, error)
 	listNodesFn                  func(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.NodeInfo, error)
 	listScramSecretsFn           func(ctx context.Context, clusterArn string, maxResults int32) ([]string, error)
 	getClusterPolicyFn           func(ctx context.Context, clusterArn string) (*kafka.GetClusterPolicyOutput, error)
 	getCompatibleKafkaVersionsFn func(ctx context.Context, clusterArn string) (*kafka.GetCompatibleKafkaVersionsOutput, error)
 	isFetchFromFollowerEnabledFn func(ctx context.Context, cluster kafkatypes.Cluster) (bool, error)
 	getTopicsWithConfigsFn       func(ctx context.Context, clusterArn string) ([]types.TopicDetails, error)
+	refreshTopicsWithConfigsFn   func(ctx context.Context, clusterArn string, known []types.TopicDetails) ([]types.TopicDetails, error)
 }
 
 // DescribeClusterV2 default returns an empty output with ClusterInfo == nil.
@@ -57,6 +60,12 @@ func (s *stubMSKService) ListClusterOperationsV2(ctx context.Context, clusterArn
 	}
 	return []kafkatypes.ClusterOperationV2Summary{}, nil
 }
+func (s *stubMSKService) DescribeClusterOperationV2(ctx context.Context, operationArn string) (*kafkatypes.ClusterOperationV2, error) {
+	if s.describeClusterOpV2Fn != nil {
+		return s.describeClusterOpV2Fn(ctx, operationArn)
+	}
+	return &kafkatypes.ClusterOperationV2{OperationArn: aws.String(operationArn)}, nil
+}
 func (s *stubMSKService) ListNodes(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.NodeInfo, error) {
 	if s.listNodesFn != nil {
 		return s.listNodesFn(ctx, clusterArn, maxResults)
@@ -93,6 +102,18 @@ func (s *stubMSKService) GetTopicsWithConfigs(ctx context.Context, clusterArn st
 	}
 	return []types.TopicDetails{}, nil
 }
+func (s *stubMSKService) ListTagsForResource(ctx context.Context, resourceArn string) (map[string]string, error) {
+	if s.listTagsForResourceFn != nil {
+		return s.listTagsForResourceFn(ctx, resourceArn)
+	}
+	return map[string]string{}, nil
+}
+func (s *stubMSKService) RefreshTopicsWithConfigs(ctx context.Context, clusterArn string, known []types.TopicDetails) ([]types.TopicDetails, error) {
+	if s.refreshTopicsWithConfigsFn != nil {
+		return s.refreshTopicsWithConfigsFn(ctx, clusterArn, known)
+	}
+	return known, nil
+}
 
 // ── stubMetricService ──────────────────────────────────────────────────────────
 // Implements ClusterDiscovererMetricService (2 methods).
@@ -116,10 +137,12 @@ func (s *stubMetricService) ProcessServerlessCluster(ctx context.Context, cluste
 }
 
 // ── stubEC2Service ─────────────────────────────────────────────────────────────
-// Implements ClusterDiscovererEC2Service (1 method).
+// Implements ClusterDiscovererEC2Service (3 methods).
 
 type stubEC2Service struct {
-	describeSubnetsFn func(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error)
+	describeSubnetsFn           func(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error)
+	describeNetworkInterfacesFn func(ctx context.Context, networkInterfaceIds []string) (*ec2.DescribeNetworkInterfacesOutput, error)
+	describeSecurityGroupsFn    func(ctx context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error)
 }
 
 func (s *stubEC2Service) DescribeSubnets(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error) {
@@ -129,12 +152,28 @@ func (s *stubEC2Service) DescribeSubnets(ctx context.Context, subnetIds []string
 	return &ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{}}, nil
 }
 
+func (s *stubEC2Service) DescribeNetworkInterfaces(ctx context.Context, networkInterfaceIds []string) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if s.describeNetworkInterfacesFn != nil {
+		return s.describeNetworkInterfacesFn(ctx, networkInterfaceIds)
+	}
+	return &ec2.DescribeNetworkInterfacesOutput{}, nil
+}
+
+func (s *stubEC2Service) DescribeSecurityGroups(ctx context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if s.describeSecurityGroupsFn != nil {
+		return s.describeSecurityGroupsFn(ctx, groupIds)
+	}
+	return &ec2.DescribeSecurityGroupsOutput{}, nil
+}
+
 // ── stubMSKConnectService ──────────────────────────────────────────────────────
-// Implements ClusterDiscovererMSKConnectService (2 methods).
+// Implements ClusterDiscovererMSKConnectService (4 methods).
 
 type stubMSKConnectService struct {
 	listConnectorsFn    func(ctx context.Context, params *kafkaconnect.ListConnectorsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorsOutput, error)
 	describeConnectorFn func(ctx context.Context, params *kafkaconnect.DescribeConnectorInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOutput, error)
+	listConnectorOpsFn  func(ctx context.Context, params *kafkaconnect.ListConnectorOperationsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorOperationsOutput, error)
+	describeConnOpFn    func(ctx context.Context, params *kafkaconnect.DescribeConnectorOperationInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOperationOutput, error)
 }
 
 func (s *stubMSKConnectService) ListConnectors(ctx context.Context, params *kafkaconnect.ListConnectorsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorsOutput, error) {
@@ -151,17 +190,46 @@ func (s *stubMSKConnectService) DescribeConnector(ctx context.Context, params *k
 	return &kafkaconnect.DescribeConnectorOutput{}, nil
 }
 
+func (s *stubMSKConnectService) ListConnectorOperations(ctx context.Context, params *kafkaconnect.ListConnectorOperationsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorOperationsOutput, error) {
+	if s.listConnectorOpsFn != nil {
+		return s.listConnectorOpsFn(ctx, params, optFns...)
+	}
+	return &kafkaconnect.ListConnectorOperationsOutput{}, nil
+}
+
+func (s *stubMSKConnectService) DescribeConnectorOperation(ctx context.Context, params *kafkaconnect.DescribeConnectorOperationInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOperationOutput, error) {
+	if s.describeConnOpFn != nil {
+		return s.describeConnOpFn(ctx, params, optFns...)
+	}
+	return &kafkaconnect.DescribeConnectorOperationOutput{}, nil
+}
+
+// ── stubIAMAccessService ───────────────────────────────────────────────────────
+// Implements ClusterDiscovererIAMAccessService (1 method).
+
+type stubIAMAccessService struct {
+	getClusterAccessFn func(ctx context.Context, clusterArn string) ([]types.IAMPrincipalAccess, error)
+}
+
+func (s *stubIAMAccessService) GetClusterAccess(ctx context.Context, clusterArn string) ([]types.IAMPrincipalAccess, error) {
+	if s.getClusterAccessFn != nil {
+		return s.getClusterAccessFn(ctx, clusterArn)
+	}
+	return nil, nil
+}
+
 // ── stubRegionMSKService ───────────────────────────────────────────────────────
-// Implements RegionDiscovererMSKService (2 methods).
+// Implements RegionDiscovererMSKService (3 methods).
 
 type stubRegionMSKService struct {
-	listClustersFn      func(ctx context.Context, maxResults int32) ([]kafkatypes.Cluster, error)
+	listClustersFn      func(ctx context.Context, maxResults int32, clusterType kafkatypes.ClusterType) ([]kafkatypes.Cluster, error)
 	getConfigurationsFn func(ctx context.Context, maxResults int32) ([]kafka.DescribeConfigurationRevisionOutput, error)
+	getReplicatorsFn    func(ctx context.Context, maxResults int32) ([]kafka.DescribeReplicatorOutput, error)
 }
 
-func (s *stubRegionMSKService) ListClusters(ctx context.Context, maxResults int32) ([]kafkatypes.Cluster, error) {
+func (s *stubRegionMSKService) ListClusters(ctx context.Context, maxResults int32, clusterType kafkatypes.ClusterType) ([]kafkatypes.Cluster, error) {
 	if s.listClustersFn != nil {
-		return s.listClustersFn(ctx, maxResults)
+		return s.listClustersFn(ctx, maxResults, clusterType)
 	}
 	return []kafkatypes.Cluster{}, nil
 }
@@ -171,6 +239,12 @@ func (s *stubRegionMSKService) GetConfigurations(ctx context.Context, maxResults
 	}
 	return []kafka.DescribeConfigurationRevisionOutput{}, nil
 }
+func (s *stubRegionMSKService) GetReplicators(ctx context.Context, maxResults int32) ([]kafka.DescribeReplicatorOutput, error) {
+	if s.getReplicatorsFn != nil {
+		return s.getReplicatorsFn(ctx, maxResults)
+	}
+	return nil, nil
+}
 
 // ── stubCostService ────────────────────────────────────────────────────────────
 // Implements RegionDiscovererCostService (1 method).
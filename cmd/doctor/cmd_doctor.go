@@ -0,0 +1,181 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/audit"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/doctor"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	iamCommands []string
+	region      string
+	ccApiKey    string
+	ccApiSecret string
+	skipAWS     bool
+)
+
+func NewDoctorCmd() *cobra.Command {
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the local environment before running kcp",
+		Long: `Check that the local environment is ready for kcp and print a fix for every problem found.
+
+The checks are:
+
+- **AWS credentials** — resolves the caller identity of the default AWS credential chain.
+- **IAM permissions** — simulates, with ` + "`iam:SimulatePrincipalPolicy`" + `, every action in the AWS IAM Permissions section of each command given by ` + "`--iam-command`" + ` (default: ` + "`discover`" + `) against the caller's role or user.
+- **Confluent Cloud API key** — when ` + "`--cc-api-key`" + ` is given, verifies it is a valid Cloud API key.
+- **Terraform** — verifies a ` + "`terraform`" + ` binary of at least version ` + doctor.MinTerraformVersion + ` is on PATH.
+
+The command exits non-zero when any check fails; warnings do not affect the exit code.`,
+		Example: `  # Check credentials, discover permissions and Terraform
+  kcp doctor
+
+  # Also check the permissions needed to generate migration infrastructure, and a Cloud API key
+  kcp doctor --iam-command discover --iam-command "create-asset migration-infra" \
+      --cc-api-key ABCDEFGHIJKLMNOP --cc-api-secret <secret>`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: iampolicy.RenderSingle(
+				"Only required for the IAM permissions check. Without it the check is reported as a warning.",
+				[]string{"iam:GetRole", "iam:SimulatePrincipalPolicy"},
+			),
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunDoctor,
+		RunE:          runDoctor,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringSliceVar(&iamCommands, "iam-command", []string{"discover"}, "The kcp command(s) whose documented AWS IAM permissions are simulated, e.g. 'discover' or 'create-asset migration-infra'. Repeat the flag for several commands.")
+	optionalFlags.StringVar(&region, "region", "", "The AWS region used for the credentials check. (default: the AWS config region, or us-east-1)")
+	optionalFlags.StringVar(&ccApiKey, "cc-api-key", "", "A Confluent Cloud API key to validate.")
+	optionalFlags.StringVar(&ccApiSecret, "cc-api-secret", "", "The secret of the Confluent Cloud API key.")
+	utils.AddSecretStdinFlag(optionalFlags, "cc-api-secret")
+	optionalFlags.BoolVar(&skipAWS, "skip-aws", false, "Skip the AWS credentials and IAM permissions checks, e.g. for Apache Kafka-only migrations. (default: false)")
+	doctorCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	doctorCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{optionalFlags}
+		groupNames := []string{"Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	return doctorCmd
+}
+
+func preRunDoctor(cmd *cobra.Command, args []string) error {
+	return utils.BindEnvToFlags(cmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	fmt.Println("🔍 Checking the kcp environment")
+
+	var results []doctor.Result
+	if skipAWS {
+		results = append(results, doctor.Result{Check: "AWS credentials", Status: doctor.StatusSkip, Detail: "--skip-aws"})
+	} else {
+		results = append(results, runAWSChecks(ctx, cmd.Root())...)
+	}
+	results = append(results, doctor.CheckConfluentCloudAPIKey(ctx, &http.Client{Timeout: 30 * time.Second, Transport: audit.Transport(audit.SystemConfluentCloud, nil)}, doctor.DefaultConfluentCloudBaseURL, ccApiKey, ccApiSecret))
+	results = append(results, doctor.CheckTerraform(ctx, utils.ExecRunner))
+
+	for _, result := range results {
+		printResult(result)
+	}
+
+	if failures := doctor.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d checks failed", failures, len(results))
+	}
+	fmt.Println("\n✅ Environment looks ready for kcp")
+	return nil
+}
+
+// runAWSChecks verifies credentials and, when they work, simulates the IAM
+// policy documented by each --iam-command against the caller.
+func runAWSChecks(ctx context.Context, root *cobra.Command) []doctor.Result {
+	stsClient, err := client.NewSTSClient(region)
+	if err != nil {
+		return []doctor.Result{{Check: "AWS credentials", Status: doctor.StatusFail, Detail: err.Error(), Fix: "check ~/.aws/config and AWS_PROFILE"}}
+	}
+
+	callerArn, credentialsResult := doctor.CheckAWSCredentials(ctx, stsClient)
+	results := []doctor.Result{credentialsResult}
+	if callerArn == "" {
+		return results
+	}
+
+	iamClient, err := client.NewIAMClient()
+	if err != nil {
+		return append(results, doctor.Result{Check: "IAM permissions", Status: doctor.StatusWarn, Detail: err.Error()})
+	}
+
+	for _, command := range iamCommands {
+		actions, err := documentedIAMActions(root, command)
+		if err != nil {
+			results = append(results, doctor.Result{
+				Check:  fmt.Sprintf("IAM permissions for `kcp %s`", command),
+				Status: doctor.StatusWarn,
+				Detail: err.Error(),
+				Fix:    "pass a kcp command path to --iam-command, e.g. 'discover' or 'create-asset migration-infra'",
+			})
+			continue
+		}
+		results = append(results, doctor.CheckIAMPermissions(ctx, iamClient, callerArn, command, actions))
+	}
+	return results
+}
+
+// documentedIAMActions returns the actions in the AWS IAM Permissions
+// annotation of the command at path (e.g. "create-asset migration-infra").
+func documentedIAMActions(root *cobra.Command, path string) ([]string, error) {
+	command, remaining, err := root.Find(strings.Fields(path))
+	if err != nil || len(remaining) > 0 || command == root {
+		return nil, fmt.Errorf("unknown kcp command %q", path)
+	}
+	return iampolicy.ActionsFromAnnotation(command.Annotations[iampolicy.AnnotationKey])
+}
+
+func printResult(result doctor.Result) {
+	icon := map[doctor.Status]string{
+		doctor.StatusPass: "✅",
+		doctor.StatusWarn: "⚠️",
+		doctor.StatusFail: "❌",
+		doctor.StatusSkip: "⏭️",
+	}[result.Status]
+
+	fmt.Printf("%s %s: %s\n", icon, result.Check, result.Detail)
+	if result.Fix != "" && result.Status != doctor.StatusPass {
+		fmt.Printf("   Fix: %s\n", result.Fix)
+	}
+}
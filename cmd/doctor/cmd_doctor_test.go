@@ -0,0 +1,37 @@
+package doctor
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentedIAMActions(t *testing.T) {
+	root := &cobra.Command{Use: "kcp"}
+	createAsset := &cobra.Command{Use: "create-asset"}
+	createAsset.AddCommand(&cobra.Command{
+		Use: "migration-infra",
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: iampolicy.Render("", []string{"ec2:DescribeVpcs"}, []iampolicy.Variant{
+				{FlagHint: "--type 4", Additions: []string{"ec2:RunInstances"}},
+			}),
+		},
+	})
+	root.AddCommand(createAsset, &cobra.Command{Use: "version"})
+
+	actions, err := documentedIAMActions(root, "create-asset migration-infra")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ec2:DescribeVpcs", "ec2:RunInstances"}, actions)
+
+	actions, err = documentedIAMActions(root, "version")
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+
+	_, err = documentedIAMActions(root, "create-asset nope")
+	assert.Error(t, err)
+	_, err = documentedIAMActions(root, "")
+	assert.Error(t, err)
+}
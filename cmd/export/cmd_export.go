@@ -0,0 +1,129 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/confluentinc/kcp/internal/services/export"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	formatXLSX = "xlsx"
+	formatCSV  = "csv"
+
+	inventoryPrefix = "kcp-inventory"
+)
+
+var (
+	stateFile string
+	format    string
+	outputDir string
+)
+
+func NewExportCmd() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the state file as an inventory workbook",
+		Long: `Export the clusters, topics, ACLs, connectors and costs recorded in a kcp state file as spreadsheet tables, so migration workstreams can be tracked without transcribing the JSON.
+
+**Output:** ` + "`--format xlsx`" + ` writes ` + "`kcp-inventory.xlsx`" + ` with one sheet per table; ` + "`--format csv`" + ` writes one ` + "`kcp-inventory-<table>.csv`" + ` file per table. Both go to ` + "`--output-dir`" + `.`,
+		Example: `  # Excel workbook in the current directory
+  kcp export --state-file kcp-state.json
+
+  # One CSV file per table
+  kcp export --state-file kcp-state.json --format csv --output-dir inventory`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunExport,
+		RunE:          runExport,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file to export.")
+	exportCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&format, "format", formatXLSX, "The export format: xlsx or csv.")
+	optionalFlags.StringVar(&outputDir, "output-dir", ".", "The directory to write the inventory to.")
+	exportCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	exportCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = exportCmd.MarkFlagRequired("state-file")
+
+	return exportCmd
+}
+
+func preRunExport(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if format != formatXLSX && format != formatCSV {
+		return fmt.Errorf("invalid --format '%s': expected xlsx or csv", format)
+	}
+	return nil
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load state file: %v", err)
+	}
+
+	sheets := export.BuildInventory(report.NewReportService().ProcessState(*state))
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+
+	switch format {
+	case formatCSV:
+		paths, err := export.WriteCSV(sheets, outputDir, inventoryPrefix)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			fmt.Printf("✅ Wrote %s\n", path)
+		}
+	default:
+		path := filepath.Join(outputDir, inventoryPrefix+".xlsx")
+		if err := export.WriteXLSX(sheets, path); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Wrote %s\n", path)
+	}
+
+	for _, sheet := range sheets {
+		fmt.Printf("  %s: %d row(s)\n", sheet.Name, len(sheet.Rows))
+	}
+	return nil
+}
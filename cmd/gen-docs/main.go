@@ -37,6 +37,7 @@ var commandOrder = map[string][]string{
 		"create-asset",
 		"migration",
 		"ui",
+		"serve",
 		"docs",
 		"update",
 		"version",
@@ -58,6 +59,7 @@ var commandOrder = map[string][]string{
 	},
 	"kcp scan": {
 		"clusters",
+		"kafka",
 		"client-inventory",
 		"schema-registry",
 	},
@@ -0,0 +1,24 @@
+package generate
+
+import (
+	"github.com/confluentinc/kcp/cmd/generate/iam_policy"
+	"github.com/confluentinc/kcp/cmd/generate/runbook"
+	"github.com/spf13/cobra"
+)
+
+func NewGenerateCmd() *cobra.Command {
+	generateCmd := &cobra.Command{
+		Use:           "generate",
+		Short:         "Generate supporting files for running kcp",
+		Long:          "Generate supporting files, such as IAM policies that prepare an environment for running kcp and per-cluster migration runbooks",
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	generateCmd.AddCommand(
+		iam_policy.NewGenerateIAMPolicyCmd(),
+		runbook.NewGenerateRunbookCmd(),
+	)
+
+	return generateCmd
+}
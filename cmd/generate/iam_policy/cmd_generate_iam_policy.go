@@ -0,0 +1,130 @@
+package iam_policy
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	regions           []string
+	clusterArns       []string
+	accountId         string
+	skipTopics        bool
+	skipCosts         bool
+	skipMetrics       bool
+	scanSecrets       bool
+	glueRegistries    []string
+	brokerLogsBuckets []string
+	outputFile        string
+)
+
+func NewGenerateIAMPolicyCmd() *cobra.Command {
+	iamPolicyCmd := &cobra.Command{
+		Use:   "iam-policy",
+		Short: "Generate a least-privilege IAM policy for kcp discovery and scans",
+		Long: `Generate a least-privilege AWS IAM policy document covering the API calls made by ` + "`kcp discover`" + ` and the MSK side of ` + "`kcp scan`" + `, so a dedicated role for kcp can be provisioned ahead of time.
+
+The policy is scoped to what is in scope for the migration:
+
+- ` + "`--region`" + ` pins every regional statement with an ` + "`aws:RequestedRegion`" + ` condition and scopes per-region ARNs.
+- ` + "`--cluster-arn`" + ` restricts the per-cluster MSK and ` + "`kafka-cluster`" + ` actions to those clusters and their topics. The clusters' regions and account are added automatically.
+- ` + "`--skip-topics`" + `, ` + "`--skip-costs`" + ` and ` + "`--skip-metrics`" + ` drop the statements for the matching ` + "`kcp discover`" + ` flags.
+- ` + "`--scan-secrets`" + ` adds the Secrets Manager reads of ` + "`kcp discover --scan-secrets`" + `.
+- ` + "`--glue-registry`" + ` and ` + "`--broker-logs-bucket`" + ` add access for ` + "`kcp scan schema-registry --sr-type glue`" + ` and ` + "`kcp scan client-inventory`" + `.
+
+List calls, EC2 and CloudWatch reads do not support resource-level permissions and are granted on ` + "`*`" + ` within the regions in scope. Cost Explorer is a global service and cannot be region-restricted.`,
+		Example: `  # Policy for discovering every cluster in two regions
+  kcp generate iam-policy --region us-east-1,eu-west-1
+
+  # Policy restricted to one cluster, without cost data
+  kcp generate iam-policy \
+      --cluster-arn arn:aws:kafka:us-east-1:123456789012:cluster/my-cluster/abc-5 \
+      --skip-costs --output kcp-discovery-policy.json`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunGenerateIAMPolicy,
+		RunE:          runGenerateIAMPolicy,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	scopeFlags := pflag.NewFlagSet("scope", pflag.ExitOnError)
+	scopeFlags.SortFlags = false
+	scopeFlags.StringSliceVar(&regions, "region", []string{}, "The AWS region(s) kcp runs against (comma separated list or repeated flag).")
+	scopeFlags.StringSliceVar(&clusterArns, "cluster-arn", []string{}, "The ARN(s) of the MSK clusters in scope (comma separated list or repeated flag). (default: all clusters)")
+	scopeFlags.StringVar(&accountId, "account-id", "", "The AWS account ID used in generated ARNs. (default: taken from --cluster-arn, otherwise any account)")
+	iamPolicyCmd.Flags().AddFlagSet(scopeFlags)
+	groups[scopeFlags] = "Scope Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.BoolVar(&skipTopics, "skip-topics", false, "Omit the topic statement, matching `kcp discover --skip-topics`.")
+	optionalFlags.BoolVar(&skipCosts, "skip-costs", false, "Omit the Cost Explorer statement, matching `kcp discover --skip-costs`.")
+	optionalFlags.BoolVar(&skipMetrics, "skip-metrics", false, "Omit the CloudWatch statement, matching `kcp discover --skip-metrics`.")
+	optionalFlags.BoolVar(&scanSecrets, "scan-secrets", false, "Add the Secrets Manager statement, matching `kcp discover --scan-secrets`.")
+	optionalFlags.StringSliceVar(&glueRegistries, "glue-registry", []string{}, "AWS Glue schema registry name(s) to grant `kcp scan schema-registry` access to.")
+	optionalFlags.StringSliceVar(&brokerLogsBuckets, "broker-logs-bucket", []string{}, "S3 bucket(s) holding MSK broker logs to grant `kcp scan client-inventory` access to.")
+	optionalFlags.StringVar(&outputFile, "output", "kcp-iam-policy.json", "The file to write the policy document to.")
+	iamPolicyCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	iamPolicyCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{scopeFlags, optionalFlags}
+		groupNames := []string{"Scope Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	return iamPolicyCmd
+}
+
+func preRunGenerateIAMPolicy(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runGenerateIAMPolicy(cmd *cobra.Command, args []string) error {
+	statements, err := iampolicy.ScanPolicy(iampolicy.ScanPolicyOpts{
+		AccountID:         accountId,
+		Regions:           regions,
+		ClusterArns:       clusterArns,
+		SkipTopics:        skipTopics,
+		SkipCosts:         skipCosts,
+		SkipMetrics:       skipMetrics,
+		ScanSecrets:       scanSecrets,
+		GlueRegistries:    glueRegistries,
+		BrokerLogsBuckets: brokerLogsBuckets,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build IAM policy: %w", err)
+	}
+
+	if err := summary.WriteFile(outputFile, []byte(iampolicy.PolicyJSON(statements)+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write IAM policy: %w", err)
+	}
+
+	fmt.Printf("✅ IAM policy written to %s\n", outputFile)
+	if len(regions) == 0 && len(clusterArns) == 0 {
+		fmt.Println("⚠️ No --region or --cluster-arn given: the policy allows every region and cluster in the account")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package iam_policy
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/cmd/discover"
+	"github.com/confluentinc/kcp/cmd/scan/client_inventory"
+	"github.com/confluentinc/kcp/cmd/scan/clusters"
+	"github.com/confluentinc/kcp/cmd/scan/schema_registry"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScanPolicyCoversDocumentedPermissions keeps the generated policy in
+// step with the permissions the discover and scan commands document: a new
+// action added to one of those annotations must also be added to ScanPolicy.
+func TestScanPolicyCoversDocumentedPermissions(t *testing.T) {
+	statements, err := iampolicy.ScanPolicy(iampolicy.ScanPolicyOpts{
+		Regions:           []string{"us-east-1"},
+		ScanSecrets:       true,
+		GlueRegistries:    []string{"registry"},
+		BrokerLogsBuckets: []string{"bucket"},
+	})
+	require.NoError(t, err)
+
+	granted := map[string]bool{}
+	for _, s := range statements {
+		for _, action := range s.Actions {
+			granted[action] = true
+		}
+	}
+
+	for _, cmd := range []*cobra.Command{
+		discover.NewDiscoverCmd(),
+		clusters.NewScanClustersCmd(),
+		client_inventory.NewScanClientInventoryCmd(),
+		schema_registry.NewScanSchemaRegistryCmd(),
+	} {
+		documented, err := iampolicy.ActionsFromAnnotation(cmd.Annotations[iampolicy.AnnotationKey])
+		require.NoError(t, err)
+		require.NotEmpty(t, documented, cmd.Name())
+		for _, action := range documented {
+			assert.True(t, granted[action], "%s documents %s but the generated policy does not grant it", cmd.Name(), action)
+		}
+	}
+}
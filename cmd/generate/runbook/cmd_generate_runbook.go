@@ -0,0 +1,146 @@
+package runbook
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile              string
+	clusterArn             string
+	targetEnvironmentId    string
+	targetClusterId        string
+	targetRestEndpoint     string
+	targetBootstrapServers string
+	clusterLinkName        string
+	outputDir              string
+)
+
+func NewGenerateRunbookCmd() *cobra.Command {
+	runbookCmd := &cobra.Command{
+		Use:   "runbook",
+		Short: "Generate a migration runbook for one MSK cluster",
+		Long: "Generate a runbook directory for migrating one MSK cluster to Confluent Cloud, templated from the scanned data in the state file:\n\n" +
+			"- `01-preflight.sh` checks the tools, that the cluster is ACTIVE, that its bootstrap brokers are reachable and the Confluent Cloud login.\n" +
+			"- `02-terraform-apply-order.md` lists the `kcp create-asset` commands, pre-filled for the cluster, in the order to `terraform apply` their output.\n" +
+			"- `03-verify-links.sh` checks the cluster link and that every scanned topic has an ACTIVE mirror topic.\n" +
+			"- `04-cutover-checklist.md` holds the go/no-go checks, the cutover steps and the topics, consumer groups and connectors to move.\n" +
+			"- `05-rollback.md` covers moving clients back to MSK before and after the mirror topics are promoted.\n\n" +
+			"Target values not passed as flags are written as placeholders, so the runbook can be generated before the target cluster exists. Nothing is read from AWS or the cluster.",
+		Example: `  kcp generate runbook \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5
+
+  # With the target cluster already provisioned
+  kcp generate runbook \
+      --state-file kcp-state.json \
+      --cluster-arn arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-environment-id env-abc123 --target-cluster-id lkc-xyz123 \
+      --target-rest-endpoint https://lkc-xyz123.us-east-1.aws.confluent.cloud:443 \
+      --target-bootstrap-servers lkc-xyz123.us-east-1.aws.confluent.cloud:9092`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunGenerateRunbook,
+		RunE:          runGenerateRunbook,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the MSK cluster discovery reports have been written to.")
+	requiredFlags.StringVar(&clusterArn, "cluster-arn", "", "The ARN of the MSK cluster to generate the runbook for.")
+	runbookCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&targetEnvironmentId, "target-environment-id", "", "The Confluent Cloud environment ID (default: a placeholder to fill in).")
+	optionalFlags.StringVar(&targetClusterId, "target-cluster-id", "", "The Confluent Cloud cluster ID (default: a placeholder to fill in).")
+	optionalFlags.StringVar(&targetRestEndpoint, "target-rest-endpoint", "", "The Confluent Cloud cluster REST endpoint (default: a placeholder to fill in).")
+	optionalFlags.StringVar(&targetBootstrapServers, "target-bootstrap-servers", "", "The bootstrap servers of the target Confluent Cloud cluster (default: a placeholder to fill in).")
+	optionalFlags.StringVar(&clusterLinkName, "cluster-link-name", "", "The name of the cluster link (default: <cluster-name>-link)")
+	optionalFlags.StringVar(&outputDir, "output-dir", "", "Directory to output the runbook to (default: <cluster-name>-runbook)")
+	runbookCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	runbookCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = runbookCmd.MarkFlagRequired("state-file")
+	_ = runbookCmd.MarkFlagRequired("cluster-arn")
+
+	return runbookCmd
+}
+
+func preRunGenerateRunbook(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runGenerateRunbook(cmd *cobra.Command, args []string) error {
+	opts, err := parseRunbookOpts()
+	if err != nil {
+		return fmt.Errorf("failed to parse runbook opts: %w", err)
+	}
+
+	if err := NewRunbookGenerator(*opts).Run(); err != nil {
+		return fmt.Errorf("failed to generate runbook: %w", err)
+	}
+
+	return nil
+}
+
+func parseRunbookOpts() (*RunbookOpts, error) {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state file: %w", err)
+	}
+
+	clusterName := utils.ExtractClusterNameFromArn(clusterArn)
+	if clusterLinkName == "" {
+		clusterLinkName = fmt.Sprintf("%s-link", clusterName)
+	}
+	if outputDir == "" {
+		outputDir = fmt.Sprintf("%s-runbook", clusterName)
+	}
+
+	return &RunbookOpts{
+		State:                  state,
+		StateFile:              stateFile,
+		ClusterArn:             clusterArn,
+		TargetEnvironmentId:    targetEnvironmentId,
+		TargetClusterId:        targetClusterId,
+		TargetRestEndpoint:     targetRestEndpoint,
+		TargetBootstrapServers: targetBootstrapServers,
+		ClusterLinkName:        clusterLinkName,
+		OutputDir:              outputDir,
+	}, nil
+}
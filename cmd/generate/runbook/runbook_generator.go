@@ -0,0 +1,543 @@
+package runbook
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+// Placeholders written for the target values not passed as flags, so the
+// runbook can be generated before the target cluster exists.
+const (
+	targetEnvironmentPlaceholder  = "<env-id>"
+	targetClusterPlaceholder      = "<lkc-id>"
+	targetRestEndpointPlaceholder = "<confluent-cloud-rest-endpoint>"
+	targetBootstrapPlaceholder    = "<confluent-cloud-bootstrap-servers>"
+)
+
+type RunbookOpts struct {
+	State *types.State
+	// StateFile is the path the kcp commands in the runbook are given.
+	StateFile              string
+	ClusterArn             string
+	TargetEnvironmentId    string
+	TargetClusterId        string
+	TargetRestEndpoint     string
+	TargetBootstrapServers string
+	ClusterLinkName        string
+	OutputDir              string
+}
+
+type RunbookGenerator struct {
+	opts RunbookOpts
+}
+
+func NewRunbookGenerator(opts RunbookOpts) *RunbookGenerator {
+	return &RunbookGenerator{opts: opts}
+}
+
+// runbookFile is one file of the runbook directory.
+type runbookFile struct {
+	name        string
+	description string
+	content     string
+	executable  bool
+}
+
+func (g *RunbookGenerator) Run() error {
+	fmt.Printf("🚀 Generating migration runbook\n")
+
+	cluster, err := g.opts.State.GetClusterByArn(g.opts.ClusterArn)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOutputDir(g.opts.OutputDir); err != nil {
+		return err
+	}
+	slog.Debug("creating runbook directory", "directory", g.opts.OutputDir)
+	if err := os.MkdirAll(g.opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create runbook directory: %w", err)
+	}
+
+	files := g.render(cluster)
+	for _, file := range files {
+		mode := os.FileMode(0644)
+		if file.executable {
+			mode = 0755
+		}
+		if err := summary.WriteFile(filepath.Join(g.opts.OutputDir, file.name), []byte(file.content), mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	fmt.Printf("✅ Migration runbook generated: %s (%d files)\n", g.opts.OutputDir, len(files))
+	return nil
+}
+
+// render builds every file of the runbook, README.md last so it can index
+// the others.
+func (g *RunbookGenerator) render(cluster *types.DiscoveredCluster) []runbookFile {
+	source := newSourceSummary(cluster)
+	files := []runbookFile{
+		{name: "01-preflight.sh", description: "Checks the tools, the source cluster state, broker connectivity and the Confluent Cloud login.", content: g.preflightScript(source), executable: true},
+		{name: "02-terraform-apply-order.md", description: "The `kcp create-asset` commands and the order to `terraform apply` their output in.", content: g.applyOrder(source).String()},
+		{name: "03-verify-links.sh", description: "Describes the cluster link and every mirror topic on Confluent Cloud.", content: g.verifyLinksScript(source), executable: true},
+		{name: "04-cutover-checklist.md", description: "The go/no-go checks and cutover steps, with the topics, consumer groups and connectors to move.", content: g.cutoverChecklist(source).String()},
+		{name: "05-rollback.md", description: "How to move clients back to MSK, before and after the mirror topics are promoted.", content: g.rollback(source).String()},
+	}
+
+	readme := markdown.New()
+	readme.AddHeading(fmt.Sprintf("Migration runbook: %s", cluster.Name), 1)
+	readme.AddParagraph(fmt.Sprintf("Runbook for migrating MSK cluster `%s` (%s) to Confluent Cloud, generated from the kcp state file. Work through the files in order; the scripts exit non-zero when a check fails.", cluster.Arn, cluster.Region))
+	readme.AddTable([]string{"Source", "Value"}, [][]string{
+		{"Kafka version", valueOr(source.kafkaVersion, "unknown")},
+		{"Client auth", valueOr(string(source.auth), "unknown")},
+		{"Bootstrap servers", valueOr(strings.Join(source.brokers, ","), "unknown")},
+		{"Topics", fmt.Sprintf("%d", len(source.topics))},
+		{"Consumer groups", fmt.Sprintf("%d", len(source.groups))},
+		{"Connectors", fmt.Sprintf("%d", len(source.connectors))},
+	})
+	if len(cluster.AWSClientInformation.InFlightOperations) > 0 {
+		readme.AddParagraph(fmt.Sprintf("**Warning:** %d cluster operation(s) were in flight when the cluster was discovered. Wait for them to finish before starting.", len(cluster.AWSClientInformation.InFlightOperations)))
+	}
+	var rows [][]string
+	for _, file := range files {
+		rows = append(rows, []string{fmt.Sprintf("[%s](%s)", file.name, file.name), file.description})
+	}
+	readme.AddTable([]string{"File", "Purpose"}, rows)
+	readme.AddParagraph("Values not known when the runbook was generated are written as `<placeholders>`; the scripts also read them from the environment (e.g. `TARGET_CLUSTER_ID=lkc-xxxxx ./03-verify-links.sh`).")
+
+	return append(files, runbookFile{name: "README.md", content: readme.String()})
+}
+
+// sourceSummary is what the runbook needs to know about the source cluster,
+// read from the state file.
+type sourceSummary struct {
+	name         string
+	arn          string
+	region       string
+	kafkaVersion string
+	auth         types.AuthType
+	brokers      []string
+	topics       []types.TopicDetails
+	groups       []string
+	connectors   []string
+}
+
+func newSourceSummary(cluster *types.DiscoveredCluster) sourceSummary {
+	source := sourceSummary{
+		name:         cluster.Name,
+		arn:          cluster.Arn,
+		region:       cluster.Region,
+		kafkaVersion: utils.GetKafkaVersion(cluster.AWSClientInformation),
+	}
+	source.auth, source.brokers = clientAuth(cluster)
+
+	if cluster.KafkaAdminClientInformation.Topics != nil {
+		for _, topic := range cluster.KafkaAdminClientInformation.Topics.Details {
+			if !strings.HasPrefix(topic.Name, "__") {
+				source.topics = append(source.topics, topic)
+			}
+		}
+	}
+	sort.Slice(source.topics, func(i, j int) bool { return source.topics[i].Name < source.topics[j].Name })
+
+	for _, group := range cluster.KafkaAdminClientInformation.ConsumerGroups {
+		source.groups = append(source.groups, group.GroupID)
+	}
+	sort.Strings(source.groups)
+
+	for _, connector := range cluster.AWSClientInformation.Connectors {
+		source.connectors = append(source.connectors, fmt.Sprintf("%s (MSK Connect)", connector.ConnectorName))
+	}
+	if smc := cluster.KafkaAdminClientInformation.SelfManagedConnectors; smc != nil {
+		for _, connector := range smc.Connectors {
+			source.connectors = append(source.connectors, fmt.Sprintf("%s (self-managed)", connector.Name))
+		}
+	}
+	sort.Strings(source.connectors)
+	return source
+}
+
+// clientAuth picks the auth type the runbook connects with: the one the last
+// `kcp scan clusters` used, otherwise the first one the cluster has brokers
+// for, IAM first.
+func clientAuth(cluster *types.DiscoveredCluster) (types.AuthType, []string) {
+	candidates := []types.AuthType{
+		types.AuthTypeIAM,
+		types.AuthTypeSASLSCRAM,
+		types.AuthTypeTLS,
+		types.AuthTypeUnauthenticatedTLS,
+		types.AuthTypeUnauthenticatedPlaintext,
+	}
+	if scanned := cluster.KafkaAdminClientInformation.AuthType; scanned != "" {
+		candidates = append([]types.AuthType{scanned}, candidates...)
+	}
+	for _, auth := range candidates {
+		if brokers, err := cluster.AWSClientInformation.GetBootstrapBrokersForAuthType(auth); err == nil && len(brokers) > 0 {
+			return auth, brokers
+		}
+	}
+	return "", nil
+}
+
+// valueOr returns value, or fallback (usually a placeholder) when it is empty.
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// shellHeader starts a generated bash script: the description, strict mode
+// and the variables the script reads, each overridable from the environment.
+func shellHeader(description string, vars [][2]string) string {
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&b, "# %s\n", description)
+	b.WriteString("# Generated by `kcp generate runbook` from the kcp state file.\n")
+	b.WriteString("set -euo pipefail\n\n")
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%s=\"${%s:-%s}\"\n", v[0], v[0], v[1])
+	}
+	b.WriteString(`
+failures=0
+pass() { echo "  ok    $*"; }
+fail() { echo "  FAIL  $*"; failures=$((failures + 1)); }
+require_set() {
+  for name in "$@"; do
+    case "${!name}" in
+      "<"*) echo "${name} is not set: export it or edit this script"; exit 1 ;;
+    esac
+  done
+}
+`)
+	return b.String()
+}
+
+const shellFooter = `
+echo
+if [ "$failures" -gt 0 ]; then
+  echo "${failures} check(s) failed"
+  exit 1
+fi
+echo "All checks passed"
+`
+
+func (g *RunbookGenerator) preflightScript(source sourceSummary) string {
+	var b strings.Builder
+	b.WriteString(shellHeader(fmt.Sprintf("Preflight checks before migrating MSK cluster %s to Confluent Cloud.", source.name), [][2]string{
+		{"CLUSTER_ARN", source.arn},
+		{"AWS_REGION", source.region},
+		{"BOOTSTRAP_SERVERS", valueOr(strings.Join(source.brokers, ","), "<msk-bootstrap-servers>")},
+		{"TARGET_ENVIRONMENT_ID", valueOr(g.opts.TargetEnvironmentId, targetEnvironmentPlaceholder)},
+		{"TARGET_CLUSTER_ID", valueOr(g.opts.TargetClusterId, targetClusterPlaceholder)},
+	}))
+	b.WriteString(`
+echo "Tools"
+for tool in aws terraform confluent kcp; do
+  if command -v "$tool" >/dev/null 2>&1; then pass "$tool"; else fail "$tool is not on PATH"; fi
+done
+
+echo "Source cluster"
+state="$(aws kafka describe-cluster-v2 --cluster-arn "$CLUSTER_ARN" --region "$AWS_REGION" --query 'ClusterInfo.State' --output text 2>/dev/null || echo UNREACHABLE)"
+if [ "$state" = "ACTIVE" ]; then pass "cluster is ACTIVE"; else fail "cluster state is ${state}, expected ACTIVE"; fi
+
+echo "Broker connectivity (run from a host that can reach the brokers)"
+for broker in ${BOOTSTRAP_SERVERS//,/ }; do
+  host="${broker%:*}"
+  port="${broker##*:}"
+  if timeout 5 bash -c "</dev/tcp/${host}/${port}" 2>/dev/null; then pass "$broker"; else fail "$broker is not reachable"; fi
+done
+
+echo "Confluent Cloud"
+if confluent environment list >/dev/null 2>&1; then pass "logged in"; else fail "not logged in: run confluent login"; fi
+case "$TARGET_CLUSTER_ID" in
+  "<"*) echo "  skip  target cluster not created yet" ;;
+  *)
+    if confluent kafka cluster describe "$TARGET_CLUSTER_ID" --environment "$TARGET_ENVIRONMENT_ID" >/dev/null 2>&1; then
+      pass "target cluster ${TARGET_CLUSTER_ID}"
+    else
+      fail "target cluster ${TARGET_CLUSTER_ID} not found in ${TARGET_ENVIRONMENT_ID}"
+    fi
+    ;;
+esac
+`)
+	b.WriteString(shellFooter)
+	return b.String()
+}
+
+func (g *RunbookGenerator) applyOrder(source sourceSummary) *markdown.Markdown {
+	environmentId := valueOr(g.opts.TargetEnvironmentId, targetEnvironmentPlaceholder)
+	clusterId := valueOr(g.opts.TargetClusterId, targetClusterPlaceholder)
+	restEndpoint := valueOr(g.opts.TargetRestEndpoint, targetRestEndpointPlaceholder)
+	bootstrap := valueOr(g.opts.TargetBootstrapServers, targetBootstrapPlaceholder)
+	stateFile := g.opts.StateFile
+
+	md := markdown.New()
+	md.AddHeading(fmt.Sprintf("Terraform apply order: %s", source.name), 1)
+	md.AddParagraph("Generate each asset, review it, then apply it before generating the next: later assets need the IDs and endpoints the earlier ones create. Run `terraform init && terraform plan` in the asset directory, review the plan, then `terraform apply`.")
+
+	type step struct {
+		title   string
+		note    string
+		command string
+		dir     string
+	}
+	steps := []step{
+		{
+			title: "Target infrastructure",
+			note:  "Skip if the environment, cluster and networking already exist; pass their IDs to the next steps instead.",
+			command: fmt.Sprintf(`kcp create-asset target-infra \
+    --state-file %s \
+    --source-cluster-id %s \
+    --env-id %s \
+    --needs-cluster --cluster-name %s --cluster-type enterprise \
+    --output-dir target_infra`, stateFile, source.arn, environmentId, source.name),
+			dir: "target_infra",
+		},
+		{
+			title: "Migration infrastructure (cluster link)",
+			note:  "Pick `--type` from `kcp report plan` or the README for the cluster's networking and auth.",
+			command: fmt.Sprintf(`kcp create-asset migration-infra \
+    --state-file %s \
+    --cc-type commercial \
+    --source-type msk \
+    --cluster-id %s \
+    --type <type> \
+    --cluster-link-name %s \
+    --target-environment-id %s \
+    --target-cluster-id %s \
+    --target-rest-endpoint %s \
+    --target-bootstrap-endpoint %s \
+    --output-dir migration-infra`, stateFile, source.arn, g.opts.ClusterLinkName, environmentId, clusterId, restEndpoint, bootstrap),
+			dir: "migration-infra",
+		},
+		{
+			title: "Schemas",
+			note:  "Only if the cluster's clients use a schema registry; see `kcp create-asset migrate-schemas --help` for the Glue variant.",
+			command: fmt.Sprintf(`kcp create-asset migrate-schemas \
+    --state-file %s \
+    --cc-type commercial \
+    --url <schema-registry-url> \
+    --cc-sr-rest-endpoint <confluent-cloud-schema-registry-endpoint>`, stateFile),
+			dir: "migrate_schemas",
+		},
+		{
+			title: "Mirror topics",
+			note:  fmt.Sprintf("Creates a mirror topic on the cluster link for each of the %d topics.", len(source.topics)),
+			command: fmt.Sprintf(`kcp create-asset migrate-topics \
+    --mode mirror \
+    --cc-type commercial \
+    --state-file %s \
+    --source-type msk \
+    --cluster-id %s \
+    --target-cluster-id %s \
+    --target-rest-endpoint %s \
+    --cluster-link-name %s`, stateFile, source.arn, clusterId, restEndpoint, g.opts.ClusterLinkName),
+			dir: "migrate_topics",
+		},
+		{
+			title:   "ACLs",
+			note:    g.aclNote(source.auth),
+			command: aclCommand(source.auth, stateFile, source.arn, clusterId, restEndpoint, environmentId),
+			dir:     "migrate_acls",
+		},
+	}
+	if len(source.connectors) > 0 {
+		steps = append(steps, step{
+			title: "Connectors",
+			note:  fmt.Sprintf("Recreates the %d connector(s) on Confluent Cloud. Apply at cutover, after the source connectors are stopped, so both don't run at once.", len(source.connectors)),
+			command: fmt.Sprintf(`kcp create-asset migrate-connectors msk \
+    --state-file %s \
+    --cluster-id %s \
+    --cc-environment-id %s \
+    --cc-cluster-id %s \
+    --cc-api-key <api-key> \
+    --cc-api-secret <api-secret> \
+    --output-dir migrate_connectors`, stateFile, source.arn, environmentId, clusterId),
+			dir: "migrate_connectors",
+		})
+	}
+
+	for i, s := range steps {
+		md.AddHeading(fmt.Sprintf("%d. %s", i+1, s.title), 2)
+		md.AddParagraph(s.note)
+		md.AddCodeBlock(fmt.Sprintf("%s\n\ncd %s && terraform init && terraform plan -out tfplan && terraform apply tfplan && cd -", s.command, s.dir), "bash")
+	}
+
+	md.AddParagraph("Then run `03-verify-links.sh` to check the cluster link and mirror topics.")
+	return md
+}
+
+func (g *RunbookGenerator) aclNote(auth types.AuthType) string {
+	if auth == types.AuthTypeIAM {
+		return "The cluster uses SASL/IAM: convert the IAM policies of each client role or user (`--role-arn` / `--user-arn`) into ACLs for its service account."
+	}
+	return "Recreates the cluster's Kafka ACLs for the matching service accounts."
+}
+
+func aclCommand(auth types.AuthType, stateFile, clusterArn, clusterId, restEndpoint, environmentId string) string {
+	if auth == types.AuthTypeIAM {
+		return fmt.Sprintf(`kcp create-asset migrate-acls iam \
+    --role-arn <client-role-arn> \
+    --state-file %s \
+    --cluster-id %s \
+    --target-cluster-id %s \
+    --target-rest-endpoint %s \
+    --output-dir migrate_acls`, stateFile, clusterArn, clusterId, restEndpoint)
+	}
+	return fmt.Sprintf(`kcp create-asset migrate-acls kafka \
+    --state-file %s \
+    --source-type msk \
+    --cluster-id %s \
+    --target-cluster-id %s \
+    --target-rest-endpoint %s \
+    --target-environment-id %s \
+    --output-dir migrate_acls`, stateFile, clusterArn, clusterId, restEndpoint, environmentId)
+}
+
+func (g *RunbookGenerator) verifyLinksScript(source sourceSummary) string {
+	var b strings.Builder
+	b.WriteString(shellHeader(fmt.Sprintf("Verifies the cluster link from MSK cluster %s and its mirror topics on Confluent Cloud.", source.name), [][2]string{
+		{"TARGET_ENVIRONMENT_ID", valueOr(g.opts.TargetEnvironmentId, targetEnvironmentPlaceholder)},
+		{"TARGET_CLUSTER_ID", valueOr(g.opts.TargetClusterId, targetClusterPlaceholder)},
+		{"CLUSTER_LINK_NAME", g.opts.ClusterLinkName},
+	}))
+
+	b.WriteString("\nTOPICS=(\n")
+	for _, topic := range source.topics {
+		fmt.Fprintf(&b, "  %s\n", shellQuote(topic.Name))
+	}
+	b.WriteString(")\n")
+
+	b.WriteString(`
+require_set TARGET_ENVIRONMENT_ID TARGET_CLUSTER_ID
+target=(--cluster "$TARGET_CLUSTER_ID" --environment "$TARGET_ENVIRONMENT_ID")
+
+echo "Cluster link"
+if confluent kafka link describe "$CLUSTER_LINK_NAME" "${target[@]}"; then pass "$CLUSTER_LINK_NAME"; else fail "cluster link $CLUSTER_LINK_NAME not found"; fi
+
+echo "Mirror topics"
+for topic in "${TOPICS[@]}"; do
+  status="$(confluent kafka mirror describe "$topic" --link "$CLUSTER_LINK_NAME" "${target[@]}" -o json 2>/dev/null | grep -o '"mirror_status": *"[A-Z_]*"' | head -1 | grep -o '[A-Z_]*"$' | tr -d '"' || true)"
+  case "$status" in
+    ACTIVE) pass "$topic" ;;
+    "") fail "$topic has no mirror topic on link $CLUSTER_LINK_NAME" ;;
+    *) fail "$topic mirror status is $status" ;;
+  esac
+done
+
+echo
+echo "Watch the mirror lag fall to zero before cutover:"
+echo "  kcp migration lag-check --rest-endpoint <rest-endpoint> --cluster-id $TARGET_CLUSTER_ID --cluster-link-name $CLUSTER_LINK_NAME --cluster-api-key <key> --cluster-api-secret <secret>"
+`)
+	b.WriteString(shellFooter)
+	return b.String()
+}
+
+func (g *RunbookGenerator) cutoverChecklist(source sourceSummary) *markdown.Markdown {
+	bootstrap := valueOr(g.opts.TargetBootstrapServers, targetBootstrapPlaceholder)
+
+	md := markdown.New()
+	md.AddHeading(fmt.Sprintf("Cutover checklist: %s", source.name), 1)
+
+	md.AddHeading("Go / no-go", 2)
+	md.AddList([]string{
+		"[ ] `01-preflight.sh` passes",
+		"[ ] Every asset in `02-terraform-apply-order.md` is applied, except connectors",
+		"[ ] `03-verify-links.sh` passes: every mirror topic is `ACTIVE`",
+		"[ ] Mirror lag is zero or steady near zero (`kcp migration lag-check`)",
+		"[ ] API keys and ACLs are delivered to every application team",
+		fmt.Sprintf("[ ] Client playbooks handed out (`kcp create-asset client-playbooks --state-file %s --cluster-arn %s`)", g.opts.StateFile, source.arn),
+		"[ ] Rollback owner named and `05-rollback.md` reviewed",
+	})
+
+	md.AddHeading("Cutover", 2)
+	md.AddList([]string{
+		"1. Stop the producers on MSK (or fence them with `kcp migration init` / `kcp migration execute` when a gateway fronts the cluster).",
+		fmt.Sprintf("2. Wait for the mirror lag to reach zero, then stop the consumers. If consumer offset sync is off, export their offsets: `kcp migration offsets export --source-bootstrap %s`.", valueOr(strings.Join(source.brokers, ","), "<msk-bootstrap-servers>")),
+		fmt.Sprintf("3. Promote the mirror topics: `confluent kafka mirror promote <topics> --link %s --cluster %s`.", g.opts.ClusterLinkName, valueOr(g.opts.TargetClusterId, targetClusterPlaceholder)),
+		"4. If offsets were exported, apply them: `kcp migration offsets apply`.",
+		fmt.Sprintf("5. Start the consumers against `%s`, then the producers.", bootstrap),
+		"6. Apply the connector assets, if any, and check each connector is `RUNNING`.",
+		"7. Watch consumer lag and client errors on Confluent Cloud; keep MSK running until sign-off.",
+	})
+
+	md.AddHeading(fmt.Sprintf("Topics (%d)", len(source.topics)), 2)
+	if len(source.topics) == 0 {
+		md.AddParagraph("_No topics in the state file: run `kcp scan clusters` and regenerate the runbook._")
+	} else {
+		var rows [][]string
+		for _, topic := range source.topics {
+			rows = append(rows, []string{"[ ]", "`" + topic.Name + "`", fmt.Sprintf("%d", topic.Partitions), fmt.Sprintf("%d", topic.ReplicationFactor)})
+		}
+		md.AddTable([]string{"Promoted", "Topic", "Partitions", "Replication factor"}, rows)
+	}
+
+	md.AddHeading(fmt.Sprintf("Consumer groups (%d)", len(source.groups)), 2)
+	if len(source.groups) == 0 {
+		md.AddParagraph("_No consumer groups in the state file._")
+	} else {
+		var items []string
+		for _, group := range source.groups {
+			items = append(items, "[ ] `"+group+"` resumed on Confluent Cloud")
+		}
+		md.AddList(items)
+	}
+
+	if len(source.connectors) > 0 {
+		md.AddHeading(fmt.Sprintf("Connectors (%d)", len(source.connectors)), 2)
+		var items []string
+		for _, connector := range source.connectors {
+			items = append(items, "[ ] "+connector+" stopped on the source and running on Confluent Cloud")
+		}
+		md.AddList(items)
+	}
+	return md
+}
+
+func (g *RunbookGenerator) rollback(source sourceSummary) *markdown.Markdown {
+	clusterId := valueOr(g.opts.TargetClusterId, targetClusterPlaceholder)
+	brokers := valueOr(strings.Join(source.brokers, ","), "<msk-bootstrap-servers>")
+
+	md := markdown.New()
+	md.AddHeading(fmt.Sprintf("Rollback: %s", source.name), 1)
+	md.AddParagraph(fmt.Sprintf("Keep MSK cluster `%s` and its clients' MSK configuration until the migration is signed off. Which steps apply depends on whether the mirror topics were promoted.", source.arn))
+
+	md.AddHeading("Before the mirror topics are promoted", 2)
+	md.AddParagraph("Nothing has been written to Confluent Cloud yet, so rolling back only moves clients:")
+	md.AddList([]string{
+		fmt.Sprintf("1. Point any moved consumers back at `%s` with their MSK auth settings. Their MSK offsets are unchanged.", brokers),
+		"2. Restart the producers on MSK.",
+		"3. Leave the cluster link running, or remove it with `terraform destroy` in `migrate_topics` and `migration-infra`.",
+	})
+
+	md.AddHeading("After the mirror topics are promoted", 2)
+	md.AddParagraph("Promoted topics are writable and no longer receive data from MSK, so anything produced to Confluent Cloud since cutover is not on MSK:")
+	md.AddList([]string{
+		"1. Stop the producers on Confluent Cloud.",
+		fmt.Sprintf("2. Copy the records written since cutover back to MSK, e.g. with a cluster link from `%s` to MSK (`--cluster-link-mode bidirectional` on a type 1 migration) or MirrorMaker 2.", clusterId),
+		"3. Reset the consumer groups on MSK to the positions they reached on Confluent Cloud (`kafka-consumer-groups --reset-offsets --to-datetime`), so they neither skip nor replay records.",
+		fmt.Sprintf("4. Point the producers and consumers back at `%s` with their MSK auth settings.", brokers),
+		"5. Stop the connectors on Confluent Cloud and restart them on the source, if any were moved.",
+	})
+
+	md.AddHeading("Tearing down the target", 2)
+	md.AddParagraph("Destroy in the reverse of the apply order once nothing uses the target cluster: `migrate_connectors`, `migrate_acls`, `migrate_topics`, `migrate_schemas`, `migration-infra`, then `target_infra`. `target_infra` sets `prevent_destroy` by default; regenerate it with `--prevent-destroy=false` first.")
+	return md
+}
+
+// shellQuote wraps s in single quotes for a bash array literal.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
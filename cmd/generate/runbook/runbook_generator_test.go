@@ -0,0 +1,113 @@
+package runbook
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClusterArn = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/0a1b2c3d-4e5f-6789-abcd-ef0123456789-2"
+
+func newTestState() *types.State {
+	cluster := types.DiscoveredCluster{
+		Name:   "orders",
+		Arn:    testClusterArn,
+		Region: "us-east-1",
+		AWSClientInformation: types.AWSClientInformation{
+			BootstrapBrokers: kafka.GetBootstrapBrokersOutput{
+				BootstrapBrokerStringSaslScram: aws.String("b-1.orders.kafka.us-east-1.amazonaws.com:9096,b-2.orders.kafka.us-east-1.amazonaws.com:9096"),
+				BootstrapBrokerStringSaslIam:   aws.String("b-1.orders.kafka.us-east-1.amazonaws.com:9098"),
+			},
+			Connectors: []types.ConnectorSummary{{ConnectorName: "orders-s3-sink"}},
+		},
+	}
+	cluster.KafkaAdminClientInformation.AuthType = types.AuthTypeSASLSCRAM
+	cluster.KafkaAdminClientInformation.Topics = &types.Topics{Details: []types.TopicDetails{
+		{Name: "payments", Partitions: 6, ReplicationFactor: 3},
+		{Name: "__consumer_offsets", Partitions: 50, ReplicationFactor: 3},
+		{Name: "orders", Partitions: 12, ReplicationFactor: 3},
+	}}
+	cluster.KafkaAdminClientInformation.ConsumerGroups = []types.ConsumerGroup{{GroupID: "billing"}}
+
+	return &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{cluster}},
+	}}}
+}
+
+func TestRunbookGenerator_Run(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "runbook")
+	generator := NewRunbookGenerator(RunbookOpts{
+		State:           newTestState(),
+		StateFile:       "kcp-state.json",
+		ClusterArn:      testClusterArn,
+		TargetClusterId: "lkc-xyz123",
+		ClusterLinkName: "orders-link",
+		OutputDir:       outputDir,
+	})
+	require.NoError(t, generator.Run())
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	preflight := read("01-preflight.sh")
+	assert.Contains(t, preflight, `CLUSTER_ARN="${CLUSTER_ARN:-`+testClusterArn+`}"`)
+	assert.Contains(t, preflight, "b-1.orders.kafka.us-east-1.amazonaws.com:9096,b-2.orders.kafka.us-east-1.amazonaws.com:9096", "the scanned auth type's brokers are checked")
+	assert.Contains(t, preflight, `TARGET_ENVIRONMENT_ID="${TARGET_ENVIRONMENT_ID:-<env-id>}"`, "unset target values are placeholders")
+
+	applyOrder := read("02-terraform-apply-order.md")
+	assert.Contains(t, applyOrder, "--target-cluster-id lkc-xyz123")
+	assert.Contains(t, applyOrder, "kcp create-asset migrate-acls kafka")
+	assert.Contains(t, applyOrder, "kcp create-asset migrate-connectors msk", "clusters with connectors get a connectors step")
+	assert.Less(t, strings.Index(applyOrder, "target-infra"), strings.Index(applyOrder, "migrate-topics"))
+
+	verify := read("03-verify-links.sh")
+	assert.Contains(t, verify, "  'orders'\n  'payments'\n")
+	assert.NotContains(t, verify, "__consumer_offsets", "internal topics are not mirrored")
+
+	checklist := read("04-cutover-checklist.md")
+	assert.Contains(t, checklist, "`billing`")
+	assert.Contains(t, checklist, "orders-s3-sink (MSK Connect)")
+
+	assert.Contains(t, read("05-rollback.md"), "b-1.orders.kafka.us-east-1.amazonaws.com:9096")
+	assert.Contains(t, read("README.md"), "[01-preflight.sh](01-preflight.sh)")
+
+	info, err := os.Stat(filepath.Join(outputDir, "01-preflight.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "scripts are executable")
+
+	if bash, err := exec.LookPath("bash"); err == nil {
+		for _, script := range []string{"01-preflight.sh", "03-verify-links.sh"} {
+			out, err := exec.Command(bash, "-n", filepath.Join(outputDir, script)).CombinedOutput()
+			assert.NoError(t, err, "%s: %s", script, out)
+		}
+	}
+}
+
+func TestRunbookGenerator_Run_UnknownCluster(t *testing.T) {
+	err := NewRunbookGenerator(RunbookOpts{
+		State:      newTestState(),
+		ClusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/missing/abc-1",
+		OutputDir:  t.TempDir(),
+	}).Run()
+	assert.Error(t, err)
+}
+
+func TestClientAuth_FallsBackToAvailableBrokers(t *testing.T) {
+	cluster := newTestState().MSKSources.Regions[0].Clusters[0]
+	cluster.KafkaAdminClientInformation.AuthType = ""
+
+	auth, brokers := clientAuth(&cluster)
+	assert.Equal(t, types.AuthTypeIAM, auth)
+	assert.Equal(t, []string{"b-1.orders.kafka.us-east-1.amazonaws.com:9098"}, brokers)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"time"
 
+	"github.com/confluentinc/kcp/internal/client"
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
@@ -17,6 +19,7 @@ var (
 	stateFile       string
 	credentialsFile string
 	outputFile      string
+	connection      client.ConnectionSettings
 )
 
 func healthcheckIAMAnnotation() string {
@@ -90,6 +93,14 @@ Source-specific notes:
 	optionalFlags.StringVar(&outputFile, "output", "", "Path for the generated markdown report. Defaults to ./healthcheck-<cluster-id>-<timestamp>.md.")
 	healthcheckCmd.Flags().AddFlagSet(optionalFlags)
 
+	kafkaClientFlags := pflag.NewFlagSet("kafka-client", pflag.ExitOnError)
+	kafkaClientFlags.SortFlags = false
+	kafkaClientFlags.DurationVar(&connection.DialTimeout, "kafka-dial-timeout", 10*time.Second, "How long to wait for a broker connection before giving up.")
+	kafkaClientFlags.DurationVar(&connection.ReadTimeout, "kafka-read-timeout", 30*time.Second, "How long to wait for a broker response before giving up.")
+	kafkaClientFlags.StringVar(&connection.KafkaVersion, "kafka-version", "", "Kafka protocol version to use (e.g. 2.8.1). Default: the MSK cluster's version, or 3.6.0 for Apache Kafka.")
+	kafkaClientFlags.BoolVar(&connection.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for Kafka connections (Apache Kafka only).")
+	healthcheckCmd.Flags().AddFlagSet(kafkaClientFlags)
+
 	_ = healthcheckCmd.MarkFlagRequired("source-type")
 	_ = healthcheckCmd.MarkFlagRequired("credentials-file")
 
@@ -109,6 +120,13 @@ func preRunHealthcheck(cmd *cobra.Command, args []string) error {
 	}
 	sourceType = string(normalizedSourceType)
 
+	if err := connection.Validate(); err != nil {
+		return err
+	}
+	if connection.InsecureSkipTLSVerify && sourceType != "osk" {
+		return fmt.Errorf("--insecure-skip-tls-verify is only supported for Apache Kafka sources (--source-type apache-kafka)")
+	}
+
 	// Credentials file naming convention — warn rather than reject so a
 	// renamed file still works, mirroring `scan clusters`.
 	if sourceType == "msk" && filepath.Base(credentialsFile) != "msk-credentials.yaml" {
@@ -53,6 +53,7 @@ func runHealthcheck(cmd *cobra.Command, args []string) error {
 	scanOpts := sources.ScanOptions{
 		SkipTopics: false,
 		SkipACLs:   false,
+		Connection: connection,
 		State:      state,
 	}
 
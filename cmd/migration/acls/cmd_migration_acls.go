@@ -0,0 +1,293 @@
+package acls
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/aclapply"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile             string
+	sourceType            string
+	clusterId             string
+	targetBootstrap       string
+	clusterApiKey         string
+	clusterApiSecret      string
+	principalMapping      map[string]string
+	principalMappingFile  string
+	topicNaming           utils.TopicNamingFlags
+	dryRun                bool
+	diff                  bool
+	insecureSkipTLSVerify bool
+)
+
+func NewMigrationAclsCmd() *cobra.Command {
+	aclsCmd := &cobra.Command{
+		Use:   "acls",
+		Short: "Create the source cluster's Kafka ACLs directly on the destination cluster",
+		Long: `Create the Kafka ACLs discovered on the source cluster directly on the destination cluster through the Kafka admin API. This is an alternative to ` + "`kcp create-asset migrate-acls kafka`" + ` for users who don't manage ACLs in Terraform.
+
+Source principals don't exist on Confluent Cloud, so each must be mapped to the service account (or identity pool) that replaces it, with --principal-mapping or --principal-mapping-file. A mapping without a type prefix is taken as a user, e.g. sa-abc123 becomes User:sa-abc123. ACLs of unmapped principals, and of resource types Confluent Cloud does not support, are skipped and listed.
+
+The destination's ACLs are listed first and only missing ACLs are created, so the command can be re-run safely. Use --dry-run to print the translated ACLs without connecting, or --diff to compare them with the destination without changing anything.
+
+When topics are renamed on Confluent Cloud, pass the same topic naming flags as to ` + "`migrate-topics`" + `: LITERAL topic ACLs take the destination name and PREFIXED topic ACLs take ` + "`--topic-prefix`" + `.`,
+		Example: `  # Compare, then create, the ACLs of an MSK cluster's principals
+  kcp migrate acls \
+      --from-state kcp-state.json \
+      --cluster-id arn:aws:kafka:us-east-1:XXX:cluster/my-cluster/abc-5 \
+      --target-bootstrap pkc-abc123.us-east-1.aws.confluent.cloud:9092 \
+      --cluster-api-key ABCDEFGHIJKLMNOP --cluster-api-secret-stdin \
+      --principal-mapping User:orders-app=sa-abc123,User:billing-app=sa-def456 \
+      --diff
+
+  # Certificate principals contain commas: map them in a source,target CSV file
+  kcp migrate acls \
+      --from-state kcp-state.json \
+      --source-type apache-kafka --cluster-id on-prem-cluster \
+      --target-bootstrap pkc-abc123.us-east-1.aws.confluent.cloud:9092 \
+      --principal-mapping-file principals.csv \
+      --dry-run`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunMigrationAcls,
+		RunE:          runMigrationAcls,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "from-state", "", "The path to the kcp state file holding the source cluster's ACLs.")
+	requiredFlags.StringVar(&clusterId, "cluster-id", "", "The source cluster identifier (ARN for MSK, cluster ID from credentials file for Apache Kafka).")
+	requiredFlags.StringVar(&targetBootstrap, "target-bootstrap", "", "Destination cluster bootstrap endpoint (e.g. pkc-abc123.us-east-1.aws.confluent.cloud:9092).")
+	aclsCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	targetFlags := pflag.NewFlagSet("target", pflag.ExitOnError)
+	targetFlags.SortFlags = false
+	targetFlags.StringVar(&clusterApiKey, "cluster-api-key", "", "API key for the destination cluster, with permission to create ACLs. Not needed with --dry-run.")
+	targetFlags.StringVar(&clusterApiSecret, "cluster-api-secret", "", "API secret for the destination cluster. Not needed with --dry-run.")
+	utils.AddSecretStdinFlag(targetFlags, "cluster-api-secret")
+	targetFlags.BoolVar(&insecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for Kafka connections.")
+	aclsCmd.Flags().AddFlagSet(targetFlags)
+	groups[targetFlags] = "Target Flags"
+
+	sourceFlags := pflag.NewFlagSet("source", pflag.ExitOnError)
+	sourceFlags.SortFlags = false
+	sourceFlags.StringVar(&sourceType, "source-type", "msk", "The source type (msk or apache-kafka).")
+	aclsCmd.Flags().AddFlagSet(sourceFlags)
+	groups[sourceFlags] = "Source Flags"
+
+	principalFlags := pflag.NewFlagSet("principals", pflag.ExitOnError)
+	principalFlags.SortFlags = false
+	principalFlags.StringToStringVar(&principalMapping, "principal-mapping", map[string]string{}, "Destination principals as source=target pairs (comma separated or repeated flag), e.g. User:orders-app=sa-abc123.")
+	principalFlags.StringVar(&principalMappingFile, "principal-mapping-file", "", "A CSV file of source,target principal rows, merged with --principal-mapping. Use it for principals that contain commas, such as certificate DNs.")
+	aclsCmd.Flags().AddFlagSet(principalFlags)
+	groups[principalFlags] = "Principal Mapping Flags"
+
+	topicNamingFlagSet := topicNaming.FlagSet()
+	aclsCmd.Flags().AddFlagSet(topicNamingFlagSet)
+	groups[topicNamingFlagSet] = "Topic Naming Flags"
+
+	outputFlags := pflag.NewFlagSet("output", pflag.ExitOnError)
+	outputFlags.SortFlags = false
+	outputFlags.BoolVar(&dryRun, "dry-run", false, "Print the translated ACLs without connecting to the destination cluster")
+	outputFlags.BoolVar(&diff, "diff", false, "Compare the translated ACLs with the destination cluster's without creating anything")
+	aclsCmd.Flags().AddFlagSet(outputFlags)
+	groups[outputFlags] = "Output"
+
+	aclsCmd.MarkFlagsMutuallyExclusive("dry-run", "diff")
+	_ = aclsCmd.MarkFlagRequired("from-state")
+	_ = aclsCmd.MarkFlagRequired("cluster-id")
+	_ = aclsCmd.MarkFlagRequired("target-bootstrap")
+
+	aclsCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, targetFlags, sourceFlags, principalFlags, topicNamingFlagSet, outputFlags}
+		groupNames := []string{"Required Flags", "Target Flags", "Source Flags", "Principal Mapping Flags", "Topic Naming Flags", "Output"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	return aclsCmd
+}
+
+func preRunMigrationAcls(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if !dryRun && (clusterApiKey == "" || clusterApiSecret == "") {
+		return fmt.Errorf("--cluster-api-key and --cluster-api-secret are required unless --dry-run is set")
+	}
+
+	if _, err := topicNaming.Mapping(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runMigrationAcls(cmd *cobra.Command, args []string) error {
+	clusterName, sourceAcls, err := loadSourceAcls()
+	if err != nil {
+		return err
+	}
+
+	principals, err := loadPrincipalMapping()
+	if err != nil {
+		return err
+	}
+	naming, err := topicNaming.Mapping()
+	if err != nil {
+		return err
+	}
+
+	translated, skipped := aclapply.Translate(sourceAcls, naming, principals)
+	for _, s := range skipped {
+		fmt.Printf("⚠️ Skipping %s %s %s:%s for %s: %s\n", s.Acl.PermissionType, s.Acl.Operation, s.Acl.ResourceType, s.Acl.ResourceName, s.Acl.Principal, s.Reason)
+	}
+	if len(translated) == 0 {
+		return fmt.Errorf("none of the %d ACLs of cluster %s can be migrated: map their principals with --principal-mapping or --principal-mapping-file", len(sourceAcls), clusterName)
+	}
+
+	if dryRun {
+		fmt.Printf("🔍 %d ACLs of %s translated for %s\n", len(translated), clusterName, targetBootstrap)
+		for _, acl := range translated {
+			fmt.Printf("  + %s\n", acl)
+		}
+		fmt.Println("\nRun without --dry-run to create them, or with --diff to compare them with the destination cluster.")
+		return nil
+	}
+
+	opts := []client.AdminOption{client.WithSASLPlainAuth(clusterApiKey, clusterApiSecret)}
+	if insecureSkipTLSVerify {
+		opts = append(opts, client.WithInsecureSkipVerify())
+	}
+	admin, err := client.NewKafkaAdmin(strings.Split(targetBootstrap, ","), kafkatypes.ClientBrokerTls, "", "2.6.0", opts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to destination cluster: %w", err)
+	}
+	defer func() { _ = admin.Close() }()
+
+	if diff {
+		fmt.Printf("🔍 Comparing %d ACLs of %s with %s\n", len(translated), clusterName, targetBootstrap)
+		actions, err := aclapply.Diff(admin, translated)
+		if err != nil {
+			return err
+		}
+		printActions(actions)
+		fmt.Println("\nRun without --diff to create the missing ACLs.")
+		return nil
+	}
+
+	fmt.Printf("🚀 Creating %d ACLs of %s on %s\n", len(translated), clusterName, targetBootstrap)
+	actions, err := aclapply.Apply(admin, translated)
+	if err != nil {
+		return err
+	}
+	printActions(actions)
+	if failed := aclapply.Failed(actions); failed > 0 {
+		return fmt.Errorf("the destination cluster rejected %d of %d ACLs", failed, len(actions))
+	}
+	fmt.Println("\n✅ ACLs migrated")
+	return nil
+}
+
+func printActions(actions []aclapply.Action) {
+	missing := 0
+	for _, action := range actions {
+		switch {
+		case action.Exists:
+			fmt.Printf("  = %s already exists\n", action.Acl)
+		case action.Err != nil:
+			fmt.Printf("  ❌ %s: %v\n", action.Acl, action.Err)
+		case action.Created:
+			fmt.Printf("  + %s created\n", action.Acl)
+		default:
+			fmt.Printf("  + %s will be created\n", action.Acl)
+			missing++
+		}
+	}
+	if missing > 0 {
+		fmt.Printf("\n%d of %d ACLs are missing on the destination cluster\n", missing, len(actions))
+	}
+}
+
+func loadSourceAcls() (string, []types.Acls, error) {
+	normalizedSourceType, err := types.ParseSourceTypeFlag(sourceType)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return "", nil, fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	var clusterName string
+	var acls []types.Acls
+	switch normalizedSourceType {
+	case types.SourceTypeMSK:
+		cluster, err := state.GetClusterByArn(clusterId)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get cluster: %w", err)
+		}
+		clusterName, acls = cluster.Name, cluster.KafkaAdminClientInformation.Acls
+	case types.SourceTypeOSK:
+		cluster, err := state.GetOSKClusterByID(clusterId)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get Apache Kafka cluster: %w", err)
+		}
+		clusterName, acls = cluster.ID, cluster.KafkaAdminClientInformation.Acls
+	}
+
+	if len(acls) == 0 {
+		return "", nil, fmt.Errorf("cluster %s has no ACLs within the state file: %s", clusterName, stateFile)
+	}
+	return clusterName, acls, nil
+}
+
+func loadPrincipalMapping() (map[string]string, error) {
+	principals := make(map[string]string)
+	if principalMappingFile != "" {
+		mapping, err := aclapply.LoadPrincipalMappingCSV(principalMappingFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --principal-mapping-file: %w", err)
+		}
+		principals = mapping
+	}
+	for source, target := range principalMapping {
+		if target == "" {
+			return nil, fmt.Errorf("--principal-mapping %s= has an empty target principal", source)
+		}
+		if previous, exists := principals[source]; exists && previous != target {
+			return nil, fmt.Errorf("--principal-mapping %s=%s conflicts with --principal-mapping-file, which maps it to %q", source, target, previous)
+		}
+		principals[source] = target
+	}
+	return principals, nil
+}
@@ -1,18 +1,22 @@
 package migration
 
 import (
+	"github.com/confluentinc/kcp/cmd/migration/acls"
 	"github.com/confluentinc/kcp/cmd/migration/execute"
 	i "github.com/confluentinc/kcp/cmd/migration/init"
 	"github.com/confluentinc/kcp/cmd/migration/lagcheck"
 	"github.com/confluentinc/kcp/cmd/migration/list"
+	"github.com/confluentinc/kcp/cmd/migration/offsets"
+	"github.com/confluentinc/kcp/cmd/migration/status"
 
 	"github.com/spf13/cobra"
 )
 
 func NewMigrationCmd() *cobra.Command {
 	migrationCmd := &cobra.Command{
-		Use:   "migration",
-		Short: "Commands for migrating using CPC Gateway.",
+		Use:     "migration",
+		Aliases: []string{"migrate"},
+		Short:   "Commands for migrating using CPC Gateway.",
 		Long: `Execute end-to-end Kafka migrations to Confluent Cloud using the Confluent Platform Connect (CPC) Gateway.
 
 The migration workflow follows a defined lifecycle managed by a finite state machine:
@@ -25,6 +29,8 @@ The migration workflow follows a defined lifecycle managed by a finite state mac
 6. **Promote Topics** — promote mirror topics at zero lag.
 7. **Switch Gateway** — apply the switchover gateway CR to route traffic to Confluent Cloud.
 
+Use ` + "`kcp migration status`" + ` at any point before execution to see per-partition mirror lag and whether it is safe to cut over. Use ` + "`kcp migration offsets`" + ` to export consumer group offsets from the source and replay them on Confluent Cloud at cutover. Use ` + "`kcp migration acls`" + ` to create the source cluster's ACLs directly on the destination cluster.
+
 If execution is interrupted at any step, re-running ` + "`kcp migration execute`" + ` resumes from the last completed step.
 
 Supporting documentation:
@@ -40,6 +46,9 @@ Supporting documentation:
 		execute.NewMigrationExecuteCmd(),
 		lagcheck.NewMigrationLagCheckCmd(),
 		list.NewMigrationListCmd(),
+		offsets.NewMigrationOffsetsCmd(),
+		acls.NewMigrationAclsCmd(),
+		status.NewMigrationStatusCmd(),
 	)
 
 	return migrationCmd
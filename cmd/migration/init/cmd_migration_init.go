@@ -110,6 +110,7 @@ All flags can be provided via environment variables using uppercase names with u
 	requiredFlags.StringVar(&clusterLinkName, "cluster-link-name", "", "Name of the cluster link on the destination cluster.")
 	requiredFlags.StringVar(&clusterApiKey, "cluster-api-key", "", "API key for authenticating with the destination cluster.")
 	requiredFlags.StringVar(&clusterApiSecret, "cluster-api-secret", "", "API secret for authenticating with the destination cluster.")
+	utils.AddSecretStdinFlag(requiredFlags, "cluster-api-secret")
 	requiredFlags.StringVar(&fencedCrYamlPath, "fenced-cr-yaml", "", "Path to the gateway CR YAML that blocks traffic during migration.")
 	requiredFlags.StringVar(&switchoverCrYamlPath, "switchover-cr-yaml", "", "Path to the gateway CR YAML that routes traffic to Confluent Cloud.")
 
@@ -146,6 +147,7 @@ All flags can be provided via environment variables using uppercase names with u
 	saslScramFlags.SortFlags = false
 	saslScramFlags.StringVar(&saslScramUsername, "sasl-scram-username", "", "SASL/SCRAM username for the source MSK cluster.")
 	saslScramFlags.StringVar(&saslScramPassword, "sasl-scram-password", "", "SASL/SCRAM password for the source MSK cluster.")
+	utils.AddSecretStdinFlag(saslScramFlags, "sasl-scram-password")
 	migrationInitCmd.Flags().AddFlagSet(saslScramFlags)
 	groups[saslScramFlags] = "SASL/SCRAM Flags"
 
@@ -154,6 +156,7 @@ All flags can be provided via environment variables using uppercase names with u
 	saslPlainFlags.SortFlags = false
 	saslPlainFlags.StringVar(&saslPlainUsername, "sasl-plain-username", "", "SASL/PLAIN username for the source cluster.")
 	saslPlainFlags.StringVar(&saslPlainPassword, "sasl-plain-password", "", "SASL/PLAIN password for the source cluster.")
+	utils.AddSecretStdinFlag(saslPlainFlags, "sasl-plain-password")
 	migrationInitCmd.Flags().AddFlagSet(saslPlainFlags)
 	groups[saslPlainFlags] = "SASL/PLAIN Flags"
 
@@ -46,6 +46,7 @@ All flags can be provided via environment variables (uppercase, with underscores
 	requiredFlags.StringVar(&linkName, "cluster-link-name", "", "Cluster link name")
 	requiredFlags.StringVar(&apiKey, "cluster-api-key", "", "Cluster link API key")
 	requiredFlags.StringVar(&apiSecret, "cluster-api-secret", "", "Cluster link API secret")
+	utils.AddSecretStdinFlag(requiredFlags, "cluster-api-secret")
 	cmd.Flags().AddFlagSet(requiredFlags)
 
 	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
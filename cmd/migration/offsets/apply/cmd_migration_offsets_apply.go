@@ -0,0 +1,150 @@
+package apply
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/services/consumer_offsets"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	applyModeScript = "script"
+	applyModeCommit = "commit"
+)
+
+var (
+	offsetsFile           string
+	clusterBootstrap      string
+	clusterApiKey         string
+	clusterApiSecret      string
+	topicPrefix           string
+	translation           string
+	mode                  string
+	outputDir             string
+	insecureSkipTLSVerify bool
+)
+
+func NewMigrationOffsetsApplyCmd() *cobra.Command {
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Translate exported consumer group offsets and replay them on Confluent Cloud",
+		Long: `Translate consumer group offsets exported with 'kcp migration offsets export' onto the
+destination mirror topics and replay them.
+
+Translation modes:
+  offset     reuse the source offset (exact for Cluster Linking mirror topics, which preserve offsets)
+  timestamp  resolve the destination offset from the timestamp of the last consumed record
+
+A translated offset is never set beyond the destination log end offset.
+
+Apply modes:
+  script  write a kafka-consumer-groups reset script and per-group CSV files for review (default)
+  commit  commit the offsets directly through each group's coordinator
+
+Consumers in the affected groups must be stopped on the destination cluster, and the
+cluster link's consumer offset sync must be disabled for them, or the committed
+offsets will be rejected or overwritten.`,
+		Example: `  # Review a reset script before running it
+  kcp migration offsets apply \
+      --cluster-bootstrap pkc-abc123.us-east-1.aws.confluent.cloud:9092 \
+      --cluster-api-key ABCDEFGHIJKLMNOP \
+      --cluster-api-secret xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+
+  # Commit directly, translating by timestamp into prefixed mirror topics
+  kcp migration offsets apply \
+      --cluster-bootstrap pkc-abc123.us-east-1.aws.confluent.cloud:9092 \
+      --cluster-api-key ABCDEFGHIJKLMNOP --cluster-api-secret xxxx \
+      --topic-prefix msk- --translation timestamp --mode commit`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunMigrationOffsetsApply,
+		RunE:          runMigrationOffsetsApply,
+	}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&clusterBootstrap, "cluster-bootstrap", "", "Confluent Cloud Kafka bootstrap endpoint (e.g. pkc-abc123.us-east-1.aws.confluent.cloud:9092).")
+	requiredFlags.StringVar(&clusterApiKey, "cluster-api-key", "", "API key for authenticating with the destination cluster.")
+	requiredFlags.StringVar(&clusterApiSecret, "cluster-api-secret", "", "API secret for authenticating with the destination cluster.")
+	utils.AddSecretStdinFlag(requiredFlags, "cluster-api-secret")
+	applyCmd.Flags().AddFlagSet(requiredFlags)
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&offsetsFile, "offsets-file", "consumer-offsets.json", "Path to the file written by 'kcp migration offsets export'.")
+	optionalFlags.StringVar(&topicPrefix, "topic-prefix", "", "Prefix the cluster link adds to mirror topic names (cluster.link.prefix), if any.")
+	optionalFlags.StringVar(&translation, "translation", string(consumer_offsets.TranslationOffset), "How to translate offsets: 'offset' or 'timestamp'.")
+	optionalFlags.StringVar(&mode, "mode", applyModeScript, "How to replay offsets: 'script' to generate a reset script, 'commit' to commit directly.")
+	optionalFlags.StringVar(&outputDir, "output-dir", "consumer-offset-reset", "Directory to write the reset script to (script mode only).")
+	optionalFlags.BoolVar(&insecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for Kafka connections.")
+	applyCmd.Flags().AddFlagSet(optionalFlags)
+
+	applyCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+		fmt.Printf("Required Flags:\n%s\n", requiredFlags.FlagUsages())
+		fmt.Printf("Optional Flags:\n%s\n", optionalFlags.FlagUsages())
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+		return nil
+	})
+
+	_ = applyCmd.MarkFlagRequired("cluster-bootstrap")
+	_ = applyCmd.MarkFlagRequired("cluster-api-key")
+	_ = applyCmd.MarkFlagRequired("cluster-api-secret")
+
+	return applyCmd
+}
+
+func preRunMigrationOffsetsApply(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if _, err := consumer_offsets.ParseTranslationMode(translation); err != nil {
+		return err
+	}
+
+	switch mode {
+	case applyModeScript, applyModeCommit:
+		// valid
+	default:
+		return fmt.Errorf("invalid --mode %q: must be %q or %q", mode, applyModeScript, applyModeCommit)
+	}
+
+	return nil
+}
+
+func runMigrationOffsetsApply(cmd *cobra.Command, args []string) error {
+	opts, err := parseOffsetsApplierOpts()
+	if err != nil {
+		return err
+	}
+
+	return NewOffsetsApplier(opts).Run()
+}
+
+func parseOffsetsApplierOpts() (OffsetsApplierOpts, error) {
+	export, err := consumer_offsets.ReadExport(offsetsFile)
+	if err != nil {
+		return OffsetsApplierOpts{}, fmt.Errorf("failed to load offsets file %q: %w\nRun 'kcp migration offsets export' first", offsetsFile, err)
+	}
+
+	translationMode, err := consumer_offsets.ParseTranslationMode(translation)
+	if err != nil {
+		return OffsetsApplierOpts{}, err
+	}
+
+	return OffsetsApplierOpts{
+		Export:                *export,
+		ClusterBootstrap:      clusterBootstrap,
+		ClusterApiKey:         clusterApiKey,
+		ClusterApiSecret:      clusterApiSecret,
+		TopicPrefix:           topicPrefix,
+		Translation:           translationMode,
+		Commit:                mode == applyModeCommit,
+		OutputDir:             outputDir,
+		InsecureSkipTLSVerify: insecureSkipTLSVerify,
+	}, nil
+}
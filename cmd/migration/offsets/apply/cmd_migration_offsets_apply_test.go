@@ -0,0 +1,52 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationOffsetsApply_InvalidMode_ReturnsError(t *testing.T) {
+	cmd := NewMigrationOffsetsApplyCmd()
+	cmd.SetArgs([]string{
+		"--cluster-bootstrap", "pkc-abc:9092",
+		"--cluster-api-key", "key",
+		"--cluster-api-secret", "secret",
+		"--mode", "push",
+	})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --mode "push"`)
+}
+
+func TestMigrationOffsetsApply_InvalidTranslation_ReturnsError(t *testing.T) {
+	cmd := NewMigrationOffsetsApplyCmd()
+	cmd.SetArgs([]string{
+		"--cluster-bootstrap", "pkc-abc:9092",
+		"--cluster-api-key", "key",
+		"--cluster-api-secret", "secret",
+		"--mode", "script",
+		"--translation", "guess",
+	})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid translation mode "guess"`)
+}
+
+func TestMigrationOffsetsApply_MissingOffsetsFile_ReturnsError(t *testing.T) {
+	cmd := NewMigrationOffsetsApplyCmd()
+	cmd.SetArgs([]string{
+		"--cluster-bootstrap", "pkc-abc:9092",
+		"--cluster-api-key", "key",
+		"--cluster-api-secret", "secret",
+		"--translation", "offset",
+		"--offsets-file", t.TempDir() + "/missing.json",
+	})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kcp migration offsets export")
+}
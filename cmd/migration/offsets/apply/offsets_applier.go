@@ -0,0 +1,106 @@
+package apply
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/consumer_offsets"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+type OffsetsApplierOpts struct {
+	Export                consumer_offsets.Export
+	ClusterBootstrap      string
+	ClusterApiKey         string
+	ClusterApiSecret      string
+	TopicPrefix           string
+	Translation           consumer_offsets.TranslationMode
+	Commit                bool
+	OutputDir             string
+	InsecureSkipTLSVerify bool
+}
+
+type OffsetsApplier struct {
+	opts OffsetsApplierOpts
+}
+
+func NewOffsetsApplier(opts OffsetsApplierOpts) *OffsetsApplier {
+	return &OffsetsApplier{
+		opts: opts,
+	}
+}
+
+func (a *OffsetsApplier) Run() error {
+	fmt.Printf("🚀 Translating consumer group offsets exported at %s\n", a.opts.Export.ExportedAt.Format("2006-01-02 15:04:05 MST"))
+
+	ccBrokers := strings.Split(a.opts.ClusterBootstrap, ",")
+	destOpts := []client.AdminOption{client.WithSASLPlainAuth(a.opts.ClusterApiKey, a.opts.ClusterApiSecret)}
+	if a.opts.InsecureSkipTLSVerify {
+		destOpts = append(destOpts, client.WithInsecureSkipVerify())
+	}
+
+	slog.Debug("connecting to destination cluster (Confluent Cloud)", "brokers", len(ccBrokers))
+	destClient, err := client.NewKafkaClient(ccBrokers, "", destOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to destination cluster: %w", err)
+	}
+	defer func() { _ = destClient.Close() }()
+
+	translated := consumer_offsets.Translate(&a.opts.Export, destClient, consumer_offsets.TranslateOpts{
+		Mode:        a.opts.Translation,
+		TopicPrefix: a.opts.TopicPrefix,
+	})
+
+	for _, t := range translated {
+		switch {
+		case t.Skipped:
+			fmt.Printf("⚠️ Skipping %s %s/%d: %s\n", t.GroupID, t.DestinationTopic, t.Partition, t.Note)
+		case t.Note != "":
+			slog.Debug("translated consumer offset", "group", t.GroupID, "topic", t.DestinationTopic, "partition", t.Partition,
+				"source_offset", t.SourceOffset, "destination_offset", t.DestinationOffset, "note", t.Note)
+		}
+	}
+	applied := len(translated) - consumer_offsets.CountSkipped(translated)
+
+	if a.opts.Commit {
+		if err := consumer_offsets.Commit(destClient, translated); err != nil {
+			return fmt.Errorf("failed to commit consumer group offsets: %w\nEnsure consumers in these groups are stopped on the destination cluster", err)
+		}
+		fmt.Printf("✅ Committed %d partition offsets on %s\n", applied, a.opts.ClusterBootstrap)
+		return nil
+	}
+
+	if err := a.writeScript(translated); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Wrote reset script for %d partition offsets to %s\n", applied, filepath.Join(a.opts.OutputDir, "reset-consumer-offsets.sh"))
+	return nil
+}
+
+func (a *OffsetsApplier) writeScript(translated []consumer_offsets.TranslatedOffset) error {
+	if err := utils.ValidateOutputDir(a.opts.OutputDir); err != nil {
+		return err
+	}
+
+	for relPath, content := range consumer_offsets.GenerateScript(translated, a.opts.ClusterBootstrap) {
+		path := filepath.Join(a.opts.OutputDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+		}
+
+		perm := os.FileMode(0644)
+		if strings.HasSuffix(path, ".sh") {
+			perm = 0755
+		}
+		slog.Debug("writing consumer offset reset file", "path", path)
+		if err := summary.WriteFile(path, []byte(content), perm); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
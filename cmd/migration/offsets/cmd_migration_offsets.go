@@ -0,0 +1,27 @@
+package offsets
+
+import (
+	"github.com/confluentinc/kcp/cmd/migration/offsets/apply"
+	"github.com/confluentinc/kcp/cmd/migration/offsets/export"
+	"github.com/spf13/cobra"
+)
+
+func NewMigrationOffsetsCmd() *cobra.Command {
+	offsetsCmd := &cobra.Command{
+		Use:   "offsets",
+		Short: "Export, translate and replay consumer group offsets",
+		Long: `Carry consumer group positions from the source cluster to Confluent Cloud at cutover.
+
+1. ` + "`kcp migration offsets export`" + ` snapshots committed offsets on the source cluster, together with the timestamp of the last record each group consumed.
+2. ` + "`kcp migration offsets apply`" + ` translates the snapshot onto the destination mirror topics and either commits it directly or writes a kafka-consumer-groups reset script for review.`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	offsetsCmd.AddCommand(
+		export.NewMigrationOffsetsExportCmd(),
+		apply.NewMigrationOffsetsApplyCmd(),
+	)
+
+	return offsetsCmd
+}
@@ -0,0 +1,219 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	sourceBootstrap             string
+	outputFile                  string
+	groups                      []string
+	awsRegion                   string
+	useSaslIam                  bool
+	useSaslScram                bool
+	useSaslPlain                bool
+	useTls                      bool
+	useUnauthenticatedTLS       bool
+	useUnauthenticatedPlaintext bool
+
+	saslScramUsername  string
+	saslScramPassword  string
+	saslScramMechanism string
+
+	saslPlainUsername string
+	saslPlainPassword string
+
+	tlsCaCert             string
+	tlsClientCert         string
+	tlsClientKey          string
+	insecureSkipTLSVerify bool
+)
+
+func NewMigrationOffsetsExportCmd() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export committed consumer group offsets from the source cluster",
+		Long: `Export the committed offsets of consumer groups on the source cluster to a JSON file.
+
+For every committed partition offset the timestamp of the last consumed record is
+recorded as well, so the offsets can later be translated by timestamp when the
+destination topic does not preserve source offsets.
+
+Run the export after consumers on the source have been stopped so the snapshot
+reflects their final positions.`,
+		Example: `  # All consumer groups, MSK source with IAM auth
+  kcp migration offsets export \
+      --source-bootstrap b-1.my-cluster.kafka.us-east-1.amazonaws.com:9098 \
+      --use-sasl-iam --aws-region us-east-1
+
+  # Selected groups only
+  kcp migration offsets export \
+      --source-bootstrap broker1:9096 --groups orders-service,billing-service \
+      --use-sasl-scram --sasl-scram-username kafkauser --sasl-scram-password kafkapass`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunMigrationOffsetsExport,
+		RunE:          runMigrationOffsetsExport,
+	}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&sourceBootstrap, "source-bootstrap", "", "Bootstrap server(s) of the source Kafka cluster (e.g. broker1:9092,broker2:9092).")
+	exportCmd.Flags().AddFlagSet(requiredFlags)
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&outputFile, "output-file", "consumer-offsets.json", "Path of the JSON file to write the exported offsets to.")
+	optionalFlags.StringSliceVar(&groups, "groups", []string{}, "Consumer groups to export (comma separated). Defaults to every group on the cluster.")
+	optionalFlags.BoolVar(&insecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for Kafka connections.")
+	exportCmd.Flags().AddFlagSet(optionalFlags)
+
+	authFlags := pflag.NewFlagSet("auth", pflag.ExitOnError)
+	authFlags.SortFlags = false
+	authFlags.BoolVar(&useSaslIam, "use-sasl-iam", false, "Use IAM authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useSaslScram, "use-sasl-scram", false, "Use SASL/SCRAM authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useSaslPlain, "use-sasl-plain", false, "Use SASL/PLAIN authentication for the source cluster.")
+	authFlags.BoolVar(&useTls, "use-tls", false, "Use TLS authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useUnauthenticatedTLS, "use-unauthenticated-tls", false, "Use unauthenticated (TLS encryption) for the source MSK cluster.")
+	authFlags.BoolVar(&useUnauthenticatedPlaintext, "use-unauthenticated-plaintext", false, "Use unauthenticated (plaintext) for the source MSK cluster.")
+	exportCmd.Flags().AddFlagSet(authFlags)
+
+	saslScramFlags := pflag.NewFlagSet("sasl-scram", pflag.ExitOnError)
+	saslScramFlags.SortFlags = false
+	saslScramFlags.StringVar(&saslScramUsername, "sasl-scram-username", "", "SASL/SCRAM username for the source MSK cluster.")
+	saslScramFlags.StringVar(&saslScramPassword, "sasl-scram-password", "", "SASL/SCRAM password for the source MSK cluster.")
+	utils.AddSecretStdinFlag(saslScramFlags, "sasl-scram-password")
+	saslScramFlags.StringVar(&saslScramMechanism, "sasl-scram-mechanism", "SHA512", "SASL/SCRAM mechanism (SHA256 or SHA512). Defaults to SHA512 for MSK compatibility.")
+	exportCmd.Flags().AddFlagSet(saslScramFlags)
+
+	saslPlainFlags := pflag.NewFlagSet("sasl-plain", pflag.ExitOnError)
+	saslPlainFlags.SortFlags = false
+	saslPlainFlags.StringVar(&saslPlainUsername, "sasl-plain-username", "", "SASL/PLAIN username for the source cluster.")
+	saslPlainFlags.StringVar(&saslPlainPassword, "sasl-plain-password", "", "SASL/PLAIN password for the source cluster.")
+	utils.AddSecretStdinFlag(saslPlainFlags, "sasl-plain-password")
+	exportCmd.Flags().AddFlagSet(saslPlainFlags)
+
+	iamFlags := pflag.NewFlagSet("iam", pflag.ExitOnError)
+	iamFlags.SortFlags = false
+	iamFlags.StringVar(&awsRegion, "aws-region", "", "AWS region of the source MSK cluster (e.g. us-east-1).")
+	exportCmd.Flags().AddFlagSet(iamFlags)
+
+	tlsFlags := pflag.NewFlagSet("tls", pflag.ExitOnError)
+	tlsFlags.SortFlags = false
+	tlsFlags.StringVar(&tlsCaCert, "tls-ca-cert", "", "Path to the TLS CA certificate for the source MSK cluster.")
+	tlsFlags.StringVar(&tlsClientCert, "tls-client-cert", "", "Path to the TLS client certificate for the source MSK cluster.")
+	tlsFlags.StringVar(&tlsClientKey, "tls-client-key", "", "Path to the TLS client key for the source MSK cluster.")
+	exportCmd.Flags().AddFlagSet(tlsFlags)
+
+	exportCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, authFlags, iamFlags, saslScramFlags, saslPlainFlags, tlsFlags}
+		groupNames := []string{"Required Flags", "Optional Flags", "Source Cluster Authentication Flags", "IAM Flags", "SASL/SCRAM Flags", "SASL/PLAIN Flags", "TLS Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = exportCmd.MarkFlagRequired("source-bootstrap")
+	exportCmd.MarkFlagsMutuallyExclusive("use-sasl-iam", "use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+	exportCmd.MarkFlagsOneRequired("use-sasl-iam", "use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+
+	exportCmd.MarkFlagsRequiredTogether("sasl-scram-username", "sasl-scram-password")
+	exportCmd.MarkFlagsRequiredTogether("sasl-plain-username", "sasl-plain-password")
+	exportCmd.MarkFlagsRequiredTogether("tls-ca-cert", "tls-client-cert", "tls-client-key")
+
+	return exportCmd
+}
+
+func preRunMigrationOffsetsExport(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if useSaslIam {
+		_ = cmd.MarkFlagRequired("aws-region")
+	}
+
+	if useSaslScram {
+		_ = cmd.MarkFlagRequired("sasl-scram-username")
+		_ = cmd.MarkFlagRequired("sasl-scram-password")
+		switch saslScramMechanism {
+		case "SHA256", "SHA512":
+			// valid
+		default:
+			return fmt.Errorf("invalid --sasl-scram-mechanism %q: must be SHA256 or SHA512", saslScramMechanism)
+		}
+	}
+
+	if useSaslPlain {
+		_ = cmd.MarkFlagRequired("sasl-plain-username")
+		_ = cmd.MarkFlagRequired("sasl-plain-password")
+	}
+
+	if useTls {
+		_ = cmd.MarkFlagRequired("tls-ca-cert")
+		_ = cmd.MarkFlagRequired("tls-client-cert")
+		_ = cmd.MarkFlagRequired("tls-client-key")
+	}
+
+	return nil
+}
+
+func runMigrationOffsetsExport(cmd *cobra.Command, args []string) error {
+	opts := parseOffsetsExporterOpts()
+
+	return NewOffsetsExporter(opts).Run(cmd.Context())
+}
+
+func resolveAuthType() types.AuthType {
+	switch {
+	case useSaslIam:
+		return types.AuthTypeIAM
+	case useSaslScram:
+		return types.AuthTypeSASLSCRAM
+	case useSaslPlain:
+		return types.AuthTypeSASLPlain
+	case useTls:
+		return types.AuthTypeTLS
+	case useUnauthenticatedTLS:
+		return types.AuthTypeUnauthenticatedTLS
+	case useUnauthenticatedPlaintext:
+		return types.AuthTypeUnauthenticatedPlaintext
+	default:
+		panic("unreachable: MarkFlagsOneRequired guarantees an auth flag is set")
+	}
+}
+
+func parseOffsetsExporterOpts() OffsetsExporterOpts {
+	return OffsetsExporterOpts{
+		SourceBootstrap:       sourceBootstrap,
+		OutputFile:            outputFile,
+		Groups:                groups,
+		AWSRegion:             awsRegion,
+		AuthType:              resolveAuthType(),
+		SaslScramUsername:     saslScramUsername,
+		SaslScramPassword:     saslScramPassword,
+		SaslScramMechanism:    saslScramMechanism,
+		SaslPlainUsername:     saslPlainUsername,
+		SaslPlainPassword:     saslPlainPassword,
+		TlsCaCert:             tlsCaCert,
+		TlsClientCert:         tlsClientCert,
+		TlsClientKey:          tlsClientKey,
+		InsecureSkipTLSVerify: insecureSkipTLSVerify,
+	}
+}
@@ -0,0 +1,248 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/services/migration"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	migrationStateFile          string
+	migrationId                 string
+	lagThreshold                int64
+	clusterApiKey               string
+	clusterApiSecret            string
+	awsRegion                   string
+	useSaslIam                  bool
+	useSaslScram                bool
+	useSaslPlain                bool
+	useTls                      bool
+	useUnauthenticatedTLS       bool
+	useUnauthenticatedPlaintext bool
+
+	saslScramUsername  string
+	saslScramPassword  string
+	saslScramMechanism string
+
+	saslPlainUsername string
+	saslPlainPassword string
+
+	tlsCaCert             string
+	tlsClientCert         string
+	tlsClientKey          string
+	insecureSkipTLSVerify bool
+)
+
+func NewMigrationStatusCmd() *cobra.Command {
+	migrationStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Report per-partition mirror topic sync status for a migration",
+		Long: `Report whether the mirror topics of an initialized migration are caught up with the source cluster.
+
+This command reads the migration from the state file, lists the mirror topics on the
+cluster link, and fetches the log end offset of every partition from both the source
+cluster and the destination Confluent Cloud cluster. Each partition is reported with
+the lag observed by the cluster link and the offset gap observed by kcp, followed by
+a verdict on whether it is safe to cut producers and consumers over.
+
+The command is read-only and can be run at any point before 'kcp migration execute'.
+
+Credentials (cluster-api-key, cluster-api-secret) are intentionally not stored in
+the migration state file and must be provided each time.`,
+		Example: `  # MSK source with IAM auth
+  kcp migration status \
+      --migration-id migration-a1b2c3d4-e5f6-7890-abcd-ef1234567890 \
+      --cluster-api-key ABCDEFGHIJKLMNOP \
+      --cluster-api-secret xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx \
+      --use-sasl-iam --aws-region us-east-1`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunMigrationStatus,
+		RunE:          runMigrationStatus,
+	}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&migrationId, "migration-id", "", "ID of the migration to report on (from 'kcp migration list').")
+	requiredFlags.StringVar(&clusterApiKey, "cluster-api-key", "", "API key for authenticating with the destination cluster.")
+	requiredFlags.StringVar(&clusterApiSecret, "cluster-api-secret", "", "API secret for authenticating with the destination cluster.")
+	utils.AddSecretStdinFlag(requiredFlags, "cluster-api-secret")
+	migrationStatusCmd.Flags().AddFlagSet(requiredFlags)
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&migrationStateFile, "migration-state-file", "migration-state.json", "Path to the migration state file.")
+	optionalFlags.Int64Var(&lagThreshold, "lag-threshold", 0, "Total replication lag (sum across all mirror partitions) at or below which cutover is reported as safe.")
+	optionalFlags.BoolVar(&insecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for REST endpoint and Kafka connections.")
+	migrationStatusCmd.Flags().AddFlagSet(optionalFlags)
+
+	// Authentication flags.
+	authFlags := pflag.NewFlagSet("auth", pflag.ExitOnError)
+	authFlags.SortFlags = false
+	authFlags.BoolVar(&useSaslIam, "use-sasl-iam", false, "Use IAM authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useSaslScram, "use-sasl-scram", false, "Use SASL/SCRAM authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useSaslPlain, "use-sasl-plain", false, "Use SASL/PLAIN authentication for the source cluster.")
+	authFlags.BoolVar(&useTls, "use-tls", false, "Use TLS authentication for the source MSK cluster.")
+	authFlags.BoolVar(&useUnauthenticatedTLS, "use-unauthenticated-tls", false, "Use unauthenticated (TLS encryption) for the source MSK cluster.")
+	authFlags.BoolVar(&useUnauthenticatedPlaintext, "use-unauthenticated-plaintext", false, "Use unauthenticated (plaintext) for the source MSK cluster.")
+	migrationStatusCmd.Flags().AddFlagSet(authFlags)
+
+	// SASL/SCRAM credential flags.
+	saslScramFlags := pflag.NewFlagSet("sasl-scram", pflag.ExitOnError)
+	saslScramFlags.SortFlags = false
+	saslScramFlags.StringVar(&saslScramUsername, "sasl-scram-username", "", "SASL/SCRAM username for the source MSK cluster.")
+	saslScramFlags.StringVar(&saslScramPassword, "sasl-scram-password", "", "SASL/SCRAM password for the source MSK cluster.")
+	utils.AddSecretStdinFlag(saslScramFlags, "sasl-scram-password")
+	saslScramFlags.StringVar(&saslScramMechanism, "sasl-scram-mechanism", "SHA512", "SASL/SCRAM mechanism (SHA256 or SHA512). Defaults to SHA512 for MSK compatibility.")
+	migrationStatusCmd.Flags().AddFlagSet(saslScramFlags)
+
+	// SASL/PLAIN credential flags.
+	saslPlainFlags := pflag.NewFlagSet("sasl-plain", pflag.ExitOnError)
+	saslPlainFlags.SortFlags = false
+	saslPlainFlags.StringVar(&saslPlainUsername, "sasl-plain-username", "", "SASL/PLAIN username for the source cluster.")
+	saslPlainFlags.StringVar(&saslPlainPassword, "sasl-plain-password", "", "SASL/PLAIN password for the source cluster.")
+	utils.AddSecretStdinFlag(saslPlainFlags, "sasl-plain-password")
+	migrationStatusCmd.Flags().AddFlagSet(saslPlainFlags)
+
+	// IAM credential flags.
+	iamFlags := pflag.NewFlagSet("iam", pflag.ExitOnError)
+	iamFlags.SortFlags = false
+	iamFlags.StringVar(&awsRegion, "aws-region", "", "AWS region of the source MSK cluster (e.g. us-east-1).")
+	migrationStatusCmd.Flags().AddFlagSet(iamFlags)
+
+	// TLS credential flags.
+	tlsFlags := pflag.NewFlagSet("tls", pflag.ExitOnError)
+	tlsFlags.SortFlags = false
+	tlsFlags.StringVar(&tlsCaCert, "tls-ca-cert", "", "Path to the TLS CA certificate for the source MSK cluster.")
+	tlsFlags.StringVar(&tlsClientCert, "tls-client-cert", "", "Path to the TLS client certificate for the source MSK cluster.")
+	tlsFlags.StringVar(&tlsClientKey, "tls-client-key", "", "Path to the TLS client key for the source MSK cluster.")
+	migrationStatusCmd.Flags().AddFlagSet(tlsFlags)
+
+	migrationStatusCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, authFlags, iamFlags, saslScramFlags, saslPlainFlags, tlsFlags}
+		groupNames := []string{"Required Flags", "Optional Flags", "Source Cluster Authentication Flags", "IAM Flags", "SASL/SCRAM Flags", "SASL/PLAIN Flags", "TLS Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = migrationStatusCmd.MarkFlagRequired("migration-id")
+	_ = migrationStatusCmd.MarkFlagRequired("cluster-api-key")
+	_ = migrationStatusCmd.MarkFlagRequired("cluster-api-secret")
+	migrationStatusCmd.MarkFlagsMutuallyExclusive("use-sasl-iam", "use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+	migrationStatusCmd.MarkFlagsOneRequired("use-sasl-iam", "use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+
+	migrationStatusCmd.MarkFlagsRequiredTogether("sasl-scram-username", "sasl-scram-password")
+	migrationStatusCmd.MarkFlagsRequiredTogether("sasl-plain-username", "sasl-plain-password")
+	migrationStatusCmd.MarkFlagsRequiredTogether("tls-ca-cert", "tls-client-cert", "tls-client-key")
+
+	return migrationStatusCmd
+}
+
+func preRunMigrationStatus(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if useSaslIam {
+		_ = cmd.MarkFlagRequired("aws-region")
+	}
+
+	if useSaslScram {
+		_ = cmd.MarkFlagRequired("sasl-scram-username")
+		_ = cmd.MarkFlagRequired("sasl-scram-password")
+		switch saslScramMechanism {
+		case "SHA256", "SHA512":
+			// valid
+		default:
+			return fmt.Errorf("invalid --sasl-scram-mechanism %q: must be SHA256 or SHA512", saslScramMechanism)
+		}
+	}
+
+	if useSaslPlain {
+		_ = cmd.MarkFlagRequired("sasl-plain-username")
+		_ = cmd.MarkFlagRequired("sasl-plain-password")
+	}
+
+	if useTls {
+		_ = cmd.MarkFlagRequired("tls-ca-cert")
+		_ = cmd.MarkFlagRequired("tls-client-cert")
+		_ = cmd.MarkFlagRequired("tls-client-key")
+	}
+
+	if lagThreshold < 0 {
+		return fmt.Errorf("--lag-threshold must not be negative (got %d)", lagThreshold)
+	}
+
+	return nil
+}
+
+func runMigrationStatus(cmd *cobra.Command, args []string) error {
+	migrationState, err := migration.NewMigrationStateFromFile(migrationStateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load migration state file %q: %w\nRun 'kcp migration init' to create a new migration first", migrationStateFile, err)
+	}
+
+	config, err := migrationState.GetMigrationById(migrationId)
+	if err != nil {
+		return fmt.Errorf("migration '%s' not found in %s\nRun 'kcp migration list' to see available migrations", migrationId, migrationStateFile)
+	}
+
+	opts := parseMigrationStatusOpts(*config)
+
+	return NewMigrationStatus(opts).Run(cmd.Context())
+}
+
+func resolveAuthType() types.AuthType {
+	switch {
+	case useSaslIam:
+		return types.AuthTypeIAM
+	case useSaslScram:
+		return types.AuthTypeSASLSCRAM
+	case useSaslPlain:
+		return types.AuthTypeSASLPlain
+	case useTls:
+		return types.AuthTypeTLS
+	case useUnauthenticatedTLS:
+		return types.AuthTypeUnauthenticatedTLS
+	case useUnauthenticatedPlaintext:
+		return types.AuthTypeUnauthenticatedPlaintext
+	default:
+		panic("unreachable: MarkFlagsOneRequired guarantees an auth flag is set")
+	}
+}
+
+func parseMigrationStatusOpts(config migration.MigrationConfig) MigrationStatusOpts {
+	return MigrationStatusOpts{
+		MigrationConfig:       config,
+		ClusterApiKey:         clusterApiKey,
+		ClusterApiSecret:      clusterApiSecret,
+		LagThreshold:          lagThreshold,
+		AWSRegion:             awsRegion,
+		AuthType:              resolveAuthType(),
+		SaslScramUsername:     saslScramUsername,
+		SaslScramPassword:     saslScramPassword,
+		SaslScramMechanism:    saslScramMechanism,
+		SaslPlainUsername:     saslPlainUsername,
+		SaslPlainPassword:     saslPlainPassword,
+		TlsCaCert:             tlsCaCert,
+		TlsClientCert:         tlsClientCert,
+		TlsClientKey:          tlsClientKey,
+		InsecureSkipTLSVerify: insecureSkipTLSVerify,
+	}
+}
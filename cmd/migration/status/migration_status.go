@@ -0,0 +1,353 @@
+package status
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/clusterlink"
+	"github.com/confluentinc/kcp/internal/services/migration"
+	"github.com/confluentinc/kcp/internal/services/offset"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/fatih/color"
+)
+
+// Per-partition sync states reported by `kcp migration status`.
+const (
+	PartitionInSync  = "IN_SYNC"
+	PartitionLagging = "LAGGING"
+	// PartitionUnknown means one side did not report the partition (e.g. the
+	// source topic has more partitions than the mirror).
+	PartitionUnknown = "UNKNOWN"
+)
+
+type MigrationStatusOpts struct {
+	MigrationConfig       migration.MigrationConfig
+	ClusterApiKey         string
+	ClusterApiSecret      string
+	LagThreshold          int64
+	AWSRegion             string
+	AuthType              types.AuthType
+	SaslScramUsername     string
+	SaslScramPassword     string
+	SaslScramMechanism    string
+	SaslPlainUsername     string
+	SaslPlainPassword     string
+	TlsCaCert             string
+	TlsClientCert         string
+	TlsClientKey          string
+	InsecureSkipTLSVerify bool
+}
+
+type MigrationStatus struct {
+	opts MigrationStatusOpts
+}
+
+func NewMigrationStatus(opts MigrationStatusOpts) *MigrationStatus {
+	return &MigrationStatus{
+		opts: opts,
+	}
+}
+
+// PartitionStatus compares one mirror partition against its source.
+type PartitionStatus struct {
+	Partition         int32
+	SourceOffset      int64
+	DestinationOffset int64
+	// LinkLag is the lag reported by the cluster link itself; OffsetGap is
+	// the difference between the source and destination log end offsets
+	// observed by kcp. Both must be zero for the partition to be in sync.
+	LinkLag   int64
+	OffsetGap int64
+	Status    string
+}
+
+// TopicStatus is the sync status of a single mirror topic.
+type TopicStatus struct {
+	Topic        string
+	MirrorStatus string
+	TotalLag     int64
+	Partitions   []PartitionStatus
+}
+
+// InSync reports whether the mirror is active and every partition is caught up.
+func (t TopicStatus) InSync() bool {
+	if t.MirrorStatus != clusterlink.MirrorStatusActive {
+		return false
+	}
+	for _, p := range t.Partitions {
+		if p.Status != PartitionInSync {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *MigrationStatus) Run(ctx context.Context) error {
+	config := m.opts.MigrationConfig
+
+	fmt.Printf("🔍 Checking mirror topic sync status for migration %s (cluster link %s)\n", config.MigrationId, config.ClusterLinkName)
+
+	httpClient := http.DefaultClient
+	if m.opts.InsecureSkipTLSVerify {
+		httpClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // user-controlled flag
+			},
+		}
+	}
+	clusterLinkService := clusterlink.NewConfluentCloudService(httpClient)
+	clusterLinkConfig := migration.BuildClusterLinkConfig(&config, m.opts.ClusterApiKey, m.opts.ClusterApiSecret)
+
+	mirrors, err := clusterLinkService.ListMirrorTopics(ctx, clusterLinkConfig)
+	if err != nil {
+		return fmt.Errorf("failed to list mirror topics: %w", err)
+	}
+	if len(mirrors) == 0 {
+		fmt.Printf("⚠️ No mirror topics found on cluster link %s\n", config.ClusterLinkName)
+		return nil
+	}
+
+	sourceOffset, err := m.createSourceOffset()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sourceOffset.Close() }()
+
+	destinationOffset, err := m.createDestinationOffset()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = destinationOffset.Close() }()
+
+	statuses, err := collectTopicStatuses(ctx, mirrors, sourceOffset, destinationOffset)
+	if err != nil {
+		return err
+	}
+
+	printTopicStatuses(statuses)
+
+	printCutoverVerdict(statuses, m.opts.LagThreshold)
+	return nil
+}
+
+// collectTopicStatuses fetches source and destination log end offsets for
+// every mirror topic and combines them with the link-reported lag. The source
+// is queried by the source topic name, which differs from the mirror topic's
+// when the link adds a prefix.
+func collectTopicStatuses(ctx context.Context, mirrors []clusterlink.MirrorTopic, source, destination offset.Provider) ([]TopicStatus, error) {
+	sourceTopics := make([]string, 0, len(mirrors))
+	destinationTopics := make([]string, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		sourceTopics = append(sourceTopics, mirror.SourceTopic())
+		destinationTopics = append(destinationTopics, mirror.MirrorTopicName)
+	}
+
+	sourceOffsets, err := source.GetMany(ctx, sourceTopics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source offsets: %w", err)
+	}
+	destinationOffsets, err := destination.GetMany(ctx, destinationTopics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination offsets: %w", err)
+	}
+
+	return buildTopicStatuses(mirrors, sourceOffsets, destinationOffsets), nil
+}
+
+// buildTopicStatuses joins the cluster link mirror view with the offsets
+// observed on each side, keyed by source and mirror topic name. Topics are sorted by name and partitions by ID so
+// repeated runs produce comparable output.
+func buildTopicStatuses(mirrors []clusterlink.MirrorTopic, sourceOffsets, destinationOffsets map[string]map[int32]int64) []TopicStatus {
+	statuses := make([]TopicStatus, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		linkLags := make(map[int32]int64, len(mirror.MirrorLags))
+		for _, lag := range mirror.MirrorLags {
+			linkLags[int32(lag.Partition)] = int64(lag.Lag)
+		}
+
+		topicSourceOffsets := sourceOffsets[mirror.SourceTopic()]
+		partitionIDs := make([]int32, 0, len(topicSourceOffsets))
+		for p := range topicSourceOffsets {
+			partitionIDs = append(partitionIDs, p)
+		}
+		for p := range destinationOffsets[mirror.MirrorTopicName] {
+			if !slices.Contains(partitionIDs, p) {
+				partitionIDs = append(partitionIDs, p)
+			}
+		}
+		slices.Sort(partitionIDs)
+
+		topicStatus := TopicStatus{
+			Topic:        mirror.MirrorTopicName,
+			MirrorStatus: mirror.MirrorStatus,
+			Partitions:   make([]PartitionStatus, 0, len(partitionIDs)),
+		}
+		for _, p := range partitionIDs {
+			sourceOffset, sourceOk := topicSourceOffsets[p]
+			destinationOffset, destinationOk := destinationOffsets[mirror.MirrorTopicName][p]
+			partitionStatus := PartitionStatus{
+				Partition:         p,
+				SourceOffset:      sourceOffset,
+				DestinationOffset: destinationOffset,
+				LinkLag:           linkLags[p],
+			}
+
+			switch {
+			case !sourceOk || !destinationOk:
+				partitionStatus.Status = PartitionUnknown
+			default:
+				partitionStatus.OffsetGap = max(sourceOffset-destinationOffset, 0)
+				if partitionStatus.LinkLag == 0 && partitionStatus.OffsetGap == 0 {
+					partitionStatus.Status = PartitionInSync
+				} else {
+					partitionStatus.Status = PartitionLagging
+				}
+			}
+
+			topicStatus.TotalLag += max(partitionStatus.LinkLag, partitionStatus.OffsetGap)
+			topicStatus.Partitions = append(topicStatus.Partitions, partitionStatus)
+		}
+
+		statuses = append(statuses, topicStatus)
+	}
+
+	slices.SortFunc(statuses, func(a, b TopicStatus) int {
+		return strings.Compare(a.Topic, b.Topic)
+	})
+	return statuses
+}
+
+func printTopicStatuses(statuses []TopicStatus) {
+	for _, topic := range statuses {
+		fmt.Printf("\n%s %s %s\n",
+			color.New(color.Bold).Sprint(topic.Topic),
+			color.HiBlackString("mirror=%s", topic.MirrorStatus),
+			color.HiBlackString("total_lag=%d", topic.TotalLag))
+		fmt.Printf("    %-10s %15s %15s %10s %10s  %s\n", "PARTITION", "SOURCE_LEO", "DEST_LEO", "LINK_LAG", "GAP", "STATUS")
+		for _, p := range topic.Partitions {
+			fmt.Printf("    %-10d %15d %15d %10d %10d  %s\n",
+				p.Partition, p.SourceOffset, p.DestinationOffset, p.LinkLag, p.OffsetGap, colorPartitionStatus(p.Status))
+		}
+	}
+	fmt.Println()
+}
+
+func colorPartitionStatus(status string) string {
+	switch status {
+	case PartitionInSync:
+		return color.GreenString(status)
+	case PartitionLagging:
+		return color.YellowString(status)
+	default:
+		return color.RedString(status)
+	}
+}
+
+// printCutoverVerdict summarises whether producers and consumers can be cut
+// over. A lagging link is a status, not a failure, so nothing is returned.
+func printCutoverVerdict(statuses []TopicStatus, lagThreshold int64) {
+	var inSync int
+	var totalLag int64
+	var blocking []string
+	for _, topic := range statuses {
+		totalLag += topic.TotalLag
+		if topic.InSync() {
+			inSync++
+			continue
+		}
+		if topic.MirrorStatus != clusterlink.MirrorStatusActive {
+			blocking = append(blocking, fmt.Sprintf("%s (status: %s)", topic.Topic, topic.MirrorStatus))
+			continue
+		}
+		for _, p := range topic.Partitions {
+			if p.Status == PartitionUnknown {
+				blocking = append(blocking, fmt.Sprintf("%s (partition %d not reported by both clusters)", topic.Topic, p.Partition))
+				break
+			}
+		}
+	}
+
+	slog.Debug("mirror topic sync status", "topics", len(statuses), "in_sync", inSync, "total_lag", totalLag)
+
+	switch {
+	case len(blocking) > 0:
+		fmt.Printf("⚠️ Not safe to cut over: %d mirror topic(s) need attention:\n", len(blocking))
+		for _, b := range blocking {
+			fmt.Printf("    - %s\n", b)
+		}
+	case totalLag > lagThreshold:
+		fmt.Printf("⚠️ Not safe to cut over: total lag %d exceeds threshold %d (%d/%d topics in sync)\n", totalLag, lagThreshold, inSync, len(statuses))
+	default:
+		fmt.Printf("✅ Safe to cut over: %d/%d mirror topics in sync, total lag %d (threshold %d)\n", inSync, len(statuses), totalLag, lagThreshold)
+	}
+}
+
+func (m *MigrationStatus) createSourceOffset() (*offset.Service, error) {
+	authType := m.opts.AuthType
+	brokerAddresses := strings.Split(m.opts.MigrationConfig.SourceBootstrap, ",")
+
+	clusterAuth := types.ClusterAuth{}
+	switch authType {
+	case types.AuthTypeSASLSCRAM:
+		clusterAuth.AuthMethod.SASLScram = &types.SASLScramConfig{
+			Use:       true,
+			Username:  m.opts.SaslScramUsername,
+			Password:  m.opts.SaslScramPassword,
+			Mechanism: m.opts.SaslScramMechanism,
+		}
+	case types.AuthTypeTLS:
+		clusterAuth.AuthMethod.TLS = &types.TLSConfig{
+			Use:        true,
+			CACert:     m.opts.TlsCaCert,
+			ClientCert: m.opts.TlsClientCert,
+			ClientKey:  m.opts.TlsClientKey,
+		}
+	case types.AuthTypeSASLPlain:
+		clusterAuth.AuthMethod.SASLPlain = &types.SASLPlainConfig{
+			Use:      true,
+			Username: m.opts.SaslPlainUsername,
+			Password: m.opts.SaslPlainPassword,
+		}
+	case types.AuthTypeIAM:
+		clusterAuth.AuthMethod.IAM = &types.IAMConfig{Use: true}
+	case types.AuthTypeUnauthenticatedTLS:
+		clusterAuth.AuthMethod.UnauthenticatedTLS = &types.UnauthenticatedTLSConfig{Use: true}
+	case types.AuthTypeUnauthenticatedPlaintext:
+		clusterAuth.AuthMethod.UnauthenticatedPlaintext = &types.UnauthenticatedPlaintextConfig{Use: true}
+	}
+
+	opts := []client.AdminOption{client.AdminOptionForAuth(authType, clusterAuth)}
+	if m.opts.InsecureSkipTLSVerify {
+		opts = append(opts, client.WithInsecureSkipVerify())
+	}
+
+	slog.Debug("connecting to source cluster", "brokers", len(brokerAddresses), "auth_type", authType, "region", m.opts.AWSRegion)
+	sourceClient, err := client.NewKafkaClient(brokerAddresses, m.opts.AWSRegion, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to source cluster: %w", err)
+	}
+
+	return offset.NewOffsetService(sourceClient), nil
+}
+
+func (m *MigrationStatus) createDestinationOffset() (*offset.Service, error) {
+	ccBrokers := strings.Split(m.opts.MigrationConfig.ClusterBootstrap, ",")
+	slog.Debug("connecting to destination cluster (Confluent Cloud)", "brokers", len(ccBrokers))
+
+	destOpts := []client.AdminOption{client.WithSASLPlainAuth(m.opts.ClusterApiKey, m.opts.ClusterApiSecret)}
+	if m.opts.InsecureSkipTLSVerify {
+		destOpts = append(destOpts, client.WithInsecureSkipVerify())
+	}
+	destClient, err := client.NewKafkaClient(ccBrokers, "", destOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to destination cluster: %w", err)
+	}
+
+	return offset.NewOffsetService(destClient), nil
+}
@@ -0,0 +1,160 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/clusterlink"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOffsetProvider struct {
+	offsets map[string]map[int32]int64
+	err     error
+}
+
+func (f *fakeOffsetProvider) GetMany(_ context.Context, _ []string) (map[string]map[int32]int64, error) {
+	return f.offsets, f.err
+}
+
+// recordingOffsetProvider records the topics it is asked for.
+type recordingOffsetProvider struct {
+	offsets map[string]map[int32]int64
+	topics  []string
+}
+
+func (r *recordingOffsetProvider) GetMany(_ context.Context, topics []string) (map[string]map[int32]int64, error) {
+	r.topics = topics
+	return r.offsets, nil
+}
+
+func TestBuildTopicStatuses_InSyncAndLagging(t *testing.T) {
+	mirrors := []clusterlink.MirrorTopic{
+		{
+			MirrorTopicName: "payments",
+			MirrorStatus:    clusterlink.MirrorStatusActive,
+			MirrorLags:      []clusterlink.MirrorLag{{Partition: 0, Lag: 0}, {Partition: 1, Lag: 5}},
+		},
+		{
+			MirrorTopicName: "orders",
+			MirrorStatus:    clusterlink.MirrorStatusActive,
+			MirrorLags:      []clusterlink.MirrorLag{{Partition: 0, Lag: 0}},
+		},
+	}
+	source := map[string]map[int32]int64{
+		"payments": {0: 100, 1: 205},
+		"orders":   {0: 42},
+	}
+	destination := map[string]map[int32]int64{
+		"payments": {0: 100, 1: 200},
+		"orders":   {0: 42},
+	}
+
+	statuses := buildTopicStatuses(mirrors, source, destination)
+	require.Len(t, statuses, 2)
+
+	// Sorted by topic name.
+	assert.Equal(t, "orders", statuses[0].Topic)
+	assert.True(t, statuses[0].InSync())
+	assert.Equal(t, int64(0), statuses[0].TotalLag)
+
+	payments := statuses[1]
+	assert.False(t, payments.InSync())
+	assert.Equal(t, int64(5), payments.TotalLag)
+	require.Len(t, payments.Partitions, 2)
+	assert.Equal(t, PartitionInSync, payments.Partitions[0].Status)
+	assert.Equal(t, PartitionLagging, payments.Partitions[1].Status)
+	assert.Equal(t, int64(5), payments.Partitions[1].OffsetGap)
+}
+
+func TestBuildTopicStatuses_OffsetGapWithoutLinkLag(t *testing.T) {
+	// The link can report zero lag between fetches while the source has
+	// already moved on; the offset gap still marks the partition as lagging.
+	mirrors := []clusterlink.MirrorTopic{
+		{MirrorTopicName: "events", MirrorStatus: clusterlink.MirrorStatusActive},
+	}
+	statuses := buildTopicStatuses(mirrors,
+		map[string]map[int32]int64{"events": {0: 10}},
+		map[string]map[int32]int64{"events": {0: 7}})
+
+	require.Len(t, statuses, 1)
+	assert.Equal(t, PartitionLagging, statuses[0].Partitions[0].Status)
+	assert.Equal(t, int64(3), statuses[0].TotalLag)
+}
+
+func TestBuildTopicStatuses_MissingPartitionIsUnknown(t *testing.T) {
+	mirrors := []clusterlink.MirrorTopic{
+		{MirrorTopicName: "events", MirrorStatus: clusterlink.MirrorStatusActive},
+	}
+	statuses := buildTopicStatuses(mirrors,
+		map[string]map[int32]int64{"events": {0: 10, 1: 4}},
+		map[string]map[int32]int64{"events": {0: 10}})
+
+	require.Len(t, statuses[0].Partitions, 2)
+	assert.Equal(t, PartitionInSync, statuses[0].Partitions[0].Status)
+	assert.Equal(t, PartitionUnknown, statuses[0].Partitions[1].Status)
+	assert.False(t, statuses[0].InSync())
+}
+
+func TestCollectTopicStatuses_PrefixedMirrorUsesSourceTopicName(t *testing.T) {
+	// A link with --cluster-link-prefix mirrors "orders" as "msk-orders".
+	mirrors := []clusterlink.MirrorTopic{
+		{MirrorTopicName: "msk-orders", SourceTopicName: "orders", MirrorStatus: clusterlink.MirrorStatusActive},
+	}
+	source := &recordingOffsetProvider{offsets: map[string]map[int32]int64{"orders": {0: 50, 1: 20}}}
+	destination := &recordingOffsetProvider{offsets: map[string]map[int32]int64{"msk-orders": {0: 45, 1: 20}}}
+
+	statuses, err := collectTopicStatuses(context.Background(), mirrors, source, destination)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"orders"}, source.topics)
+	assert.Equal(t, []string{"msk-orders"}, destination.topics)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "msk-orders", statuses[0].Topic)
+	require.Len(t, statuses[0].Partitions, 2)
+	assert.Equal(t, PartitionLagging, statuses[0].Partitions[0].Status)
+	assert.Equal(t, int64(5), statuses[0].Partitions[0].OffsetGap)
+	assert.Equal(t, PartitionInSync, statuses[0].Partitions[1].Status)
+}
+
+func TestTopicStatus_InactiveMirrorIsNotInSync(t *testing.T) {
+	status := TopicStatus{
+		Topic:        "events",
+		MirrorStatus: "PAUSED",
+		Partitions:   []PartitionStatus{{Partition: 0, Status: PartitionInSync}},
+	}
+	assert.False(t, status.InSync())
+}
+
+func TestCollectTopicStatuses_PropagatesOffsetErrors(t *testing.T) {
+	mirrors := []clusterlink.MirrorTopic{{MirrorTopicName: "events", MirrorStatus: clusterlink.MirrorStatusActive}}
+	ok := &fakeOffsetProvider{offsets: map[string]map[int32]int64{"events": {0: 1}}}
+	broken := &fakeOffsetProvider{err: errors.New("broker unreachable")}
+
+	_, err := collectTopicStatuses(context.Background(), mirrors, broken, ok)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get source offsets")
+
+	_, err = collectTopicStatuses(context.Background(), mirrors, ok, broken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get destination offsets")
+
+	statuses, err := collectTopicStatuses(context.Background(), mirrors, ok, ok)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].InSync())
+}
+
+func TestMigrationStatus_NoAuthFlag_ReturnsError(t *testing.T) {
+	cmd := NewMigrationStatusCmd()
+	cmd.SetArgs([]string{
+		"--migration-id", "test-migration",
+		"--cluster-api-key", "key",
+		"--cluster-api-secret", "secret",
+	})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one of the flags")
+}
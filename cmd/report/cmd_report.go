@@ -1,9 +1,14 @@
 package report
 
 import (
+	"github.com/confluentinc/kcp/cmd/report/compatibility"
 	"github.com/confluentinc/kcp/cmd/report/costs"
+	"github.com/confluentinc/kcp/cmd/report/dependencies"
+	"github.com/confluentinc/kcp/cmd/report/idle"
 	"github.com/confluentinc/kcp/cmd/report/metrics"
+	"github.com/confluentinc/kcp/cmd/report/open_monitoring"
 	"github.com/confluentinc/kcp/cmd/report/plan"
+	"github.com/confluentinc/kcp/cmd/report/rightsizing"
 	"github.com/spf13/cobra"
 )
 
@@ -11,7 +16,7 @@ func NewReportCmd() *cobra.Command {
 	reportCmd := &cobra.Command{
 		Use:           "report",
 		Short:         "Generate reports (costs, metrics, migration plan) from kcp scan data",
-		Long:          "Generate reports from the data collected by `kcp discover` / `kcp scan ...`. Subcommands: `costs` (AWS bill reconciliation), `metrics` (CloudWatch throughput aggregates), `plan` (deterministic migration plan).",
+		Long:          "Generate reports from the data collected by `kcp discover` / `kcp scan ...`. Subcommands: `costs` (AWS bill reconciliation), `metrics` (CloudWatch throughput aggregates), `plan` (deterministic migration plan), `dependencies` (data flows between clusters and migration order), `compatibility` (clients on protocol versions Confluent Cloud no longer supports), `rightsizing` (cheaper MSK broker configurations for the migration period), `idle` (topics and consumer groups unused for N days), `open-monitoring` (MSK Prometheus exporters and scrape configs for watching mirror lag).",
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 	}
@@ -19,6 +24,11 @@ func NewReportCmd() *cobra.Command {
 	reportCmd.AddCommand(costs.NewReportCostsCmd())
 	reportCmd.AddCommand(metrics.NewReportMetricsCmd())
 	reportCmd.AddCommand(plan.NewReportPlanCmd())
+	reportCmd.AddCommand(dependencies.NewReportDependenciesCmd())
+	reportCmd.AddCommand(compatibility.NewReportCompatibilityCmd())
+	reportCmd.AddCommand(rightsizing.NewReportRightsizingCmd())
+	reportCmd.AddCommand(idle.NewReportIdleCmd())
+	reportCmd.AddCommand(open_monitoring.NewReportOpenMonitoringCmd())
 
 	return reportCmd
 }
@@ -0,0 +1,177 @@
+package compatibility
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/compatibility"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile string
+	uploadTo  string
+)
+
+func NewReportCompatibilityCmd() *cobra.Command {
+	reportCompatibilityCmd := &cobra.Command{
+		Use:   "compatibility",
+		Short: "Flag clients on protocol versions Confluent Cloud no longer supports",
+		Long: "Check the source clusters in the state file for clients that may not work against Confluent Cloud. For every client `kcp scan client-inventory` found in the broker logs, the oldest Produce (producers) or Fetch (consumers) API version it sent is compared with what Confluent Cloud accepts.\n\n" +
+			"Clients below the versions removed in Apache Kafka 4.0 (KIP-896: Produce v0-v2, Fetch v0-v3, i.e. clients older than 0.11) are errors: Confluent Cloud rejects them. Clients below the Apache Kafka 2.1 client baseline (Produce v7, Fetch v10) are warnings. Each flagged client comes with remediation notes for its client library, recognised by its default client.id. On sources older than Kafka 2.1 the broker caps the versions clients send, so only errors are reported.\n\n" +
+			"**Output:** writes a `compatibility_report_YYYY-MM-DD_HH-MM-SS.md` file and prints a summary per cluster.",
+		Example: `  # Record client API versions, then check them
+  kcp scan client-inventory --s3-uri s3://my-cluster-logs/AWSLogs/000123456789/KafkaBrokerLogs/us-east-1/msk-cluster-xxxx-5/2025-08-13-14/ --state-file kcp-state.json
+  kcp report compatibility --state-file kcp-state.json`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunReportCompatibility,
+		RunE:          runReportCompatibility,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the cluster discovery reports have been written to.")
+	reportCompatibilityCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the report to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	reportCompatibilityCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	reportCompatibilityCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = reportCompatibilityCmd.MarkFlagRequired("state-file")
+
+	return reportCompatibilityCmd
+}
+
+func preRunReportCompatibility(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runReportCompatibility(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	results := compatibility.Check(state)
+	if len(results) == 0 {
+		return fmt.Errorf("no clusters found in state file: run `kcp discover` first")
+	}
+
+	fileName := fmt.Sprintf("compatibility_report_%s.md", time.Now().Format("2006-01-02_15-04-05"))
+	if err := generateReport(results).Print(markdown.PrintOptions{ToTerminal: false, ToFile: fileName}); err != nil {
+		return fmt.Errorf("failed to write markdown report: %v", err)
+	}
+
+	for _, r := range results {
+		switch {
+		case r.ClientsWithVersion == 0:
+			fmt.Printf("⏭️ %s: no client API versions recorded\n", r.Name)
+		case len(r.Findings) == 0:
+			fmt.Printf("✅ %s: %d client(s) checked, none flagged\n", r.Name, r.ClientsWithVersion)
+		default:
+			fmt.Printf("⚠️ %s: %d client(s) flagged, %d rejected by Confluent Cloud\n", r.Name, len(r.Findings), r.Errors())
+		}
+	}
+	fmt.Printf("✅ Compatibility report written to %s\n", fileName)
+
+	if err := sink.UploadArtifacts(cmd.Context(), uploadTo, fileName); err != nil {
+		return fmt.Errorf("failed to upload compatibility report: %v", err)
+	}
+	return nil
+}
+
+func generateReport(results []compatibility.ClusterResult) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading("Client Compatibility Report", 1)
+	md.AddParagraph("*Clients are checked on the oldest Produce or Fetch API version seen in the broker logs. **error**: Confluent Cloud rejects the version (removed in Apache Kafka 4.0, KIP-896). **warning**: older than Apache Kafka 2.1 clients, accepted today but unsupported.*")
+
+	for _, r := range results {
+		md.AddHeading(fmt.Sprintf("%s (%s)", r.Name, r.Source), 2)
+
+		kafkaVersion := r.KafkaVersion
+		if kafkaVersion == "" {
+			kafkaVersion = "unknown"
+		}
+		md.AddTable([]string{"Kafka Version", "Clients", "With API Version", "Flagged", "Errors"}, [][]string{{
+			kafkaVersion,
+			strconv.Itoa(r.Clients),
+			strconv.Itoa(r.ClientsWithVersion),
+			strconv.Itoa(len(r.Findings)),
+			strconv.Itoa(r.Errors()),
+		}})
+		md.AddParagraph("")
+
+		if len(r.Notes) > 0 {
+			md.AddList(r.Notes)
+		}
+
+		if len(r.Findings) == 0 {
+			if r.ClientsWithVersion > 0 {
+				md.AddParagraph("No clients flagged.")
+			}
+			continue
+		}
+
+		var rows [][]string
+		for _, f := range r.Findings {
+			rows = append(rows, []string{
+				string(f.Severity),
+				"`" + f.ClientId + "`",
+				f.Principal,
+				f.Role,
+				fmt.Sprintf("%s v%d", f.API, f.ApiVersion),
+				f.Message,
+			})
+		}
+		md.AddTable([]string{"Severity", "Client ID", "Principal", "Role", "Oldest Version", "Issue"}, rows)
+		md.AddParagraph("")
+
+		md.AddHeading("Remediation", 3)
+		var remediations []string
+		for _, f := range r.Findings {
+			remediations = append(remediations, fmt.Sprintf("`%s` (%s): %s", f.ClientId, f.Role, f.Remediation))
+		}
+		md.AddList(remediations)
+	}
+	return md
+}
@@ -0,0 +1,174 @@
+package costs
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/ccpricing"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// ccComparison holds the Confluent Cloud projection of every cluster in
+// the reported regions, over the report period.
+type ccComparison struct {
+	hours       float64
+	projections map[string][]ccpricing.ClusterProjection // by region
+	skipped     []string                                 // "cluster (region): reason"
+}
+
+// projectConfluentCloud prices each cluster's measured throughput and
+// storage over the report period with the price sheet. Clusters without
+// throughput metrics are listed as skipped rather than priced at zero.
+func (r *CostReporter) projectConfluentCloud(processedState report.ProcessedState) *ccComparison {
+	comparison := &ccComparison{
+		hours:       r.endDate.Sub(*r.startDate).Hours(),
+		projections: map[string][]ccpricing.ClusterProjection{},
+	}
+
+	for _, source := range processedState.Sources {
+		if source.Type != types.SourceTypeMSK || source.MSKData == nil {
+			continue
+		}
+		for _, region := range source.MSKData.Regions {
+			if !r.includesRegion(region.Name) {
+				continue
+			}
+			for _, cluster := range region.Clusters {
+				metrics, err := r.reportService.FilterMetrics(processedState, region.Name, cluster.Name, r.startDate, r.endDate)
+				if err != nil {
+					slog.Warn("⚠️ failed to read cluster metrics for the Confluent Cloud comparison", "cluster", cluster.Name, "region", region.Name, "error", err)
+					comparison.skipped = append(comparison.skipped, fmt.Sprintf("%s (%s): %v", cluster.Name, region.Name, err))
+					continue
+				}
+				usage, ok := ccpricing.UsageFromMetrics(cluster.Name, region.Name, metrics.Aggregates, cluster.KafkaAdminClientInformation)
+				if !ok {
+					comparison.skipped = append(comparison.skipped, fmt.Sprintf("%s (%s): no throughput metrics in the report period, run `kcp scan metrics`", cluster.Name, region.Name))
+					continue
+				}
+				projection, err := r.priceSheet.Project(usage, comparison.hours)
+				if err != nil {
+					comparison.skipped = append(comparison.skipped, fmt.Sprintf("%s (%s): %v", cluster.Name, region.Name, err))
+					continue
+				}
+				comparison.projections[region.Name] = append(comparison.projections[region.Name], projection)
+			}
+		}
+	}
+
+	return comparison
+}
+
+func (r *CostReporter) includesRegion(name string) bool {
+	for _, region := range r.regions {
+		if strings.EqualFold(region, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCCComparison sets each region's AWS spend beside the projected
+// Confluent Cloud cost for the same period. Unblended cost is the
+// on-demand rate; net amortized spreads reservations, savings plans and
+// credits over the period, so it is the fairer comparison for accounts
+// with commitments.
+func (r *CostReporter) addCCComparison(md *markdown.Markdown, regionCostData []report.ProcessedRegionCosts, comparison *ccComparison) {
+	sheet := r.priceSheet
+	currency := sheet.Currency
+
+	md.AddHeading("Confluent Cloud Cost Comparison", 2)
+	md.AddParagraph(fmt.Sprintf("*AWS spend over the report period beside the projected Confluent Cloud cost of the same workload over the same %.0f hours, priced from the price sheet (%s, last verified %s). Projections are list-price estimates, not a quote.*",
+		comparison.hours, sheet.Source, sheet.LastVerified))
+	md.AddParagraph("")
+
+	withCommit := sheet.CommitDiscount > 0
+	headers := []string{"Region", fmt.Sprintf("AWS Unblended (%s)", currency), fmt.Sprintf("AWS Net Amortized (%s)", currency), fmt.Sprintf("Confluent Cloud List (%s)", currency)}
+	if withCommit {
+		headers = append(headers, fmt.Sprintf("Confluent Cloud Committed (%s)", currency))
+	}
+	headers = append(headers, fmt.Sprintf("Difference vs Net Amortized (%s)", currency))
+
+	var rows [][]string
+	var totalUnblended, totalAmortized, totalCC float64
+	for _, regionData := range regionCostData {
+		totals := r.calculateRegionTotalsAllTypes(regionData)
+		unblended, amortized := totals[0], totals[3]
+
+		var ccList float64
+		for _, projection := range comparison.projections[regionData.Region] {
+			ccList += projection.Total()
+		}
+
+		rows = append(rows, r.comparisonRow(regionData.Region, unblended, amortized, ccList, withCommit, false))
+		totalUnblended += unblended
+		totalAmortized += amortized
+		totalCC += ccList
+	}
+	rows = append(rows, r.comparisonRow("Overall Total", totalUnblended, totalAmortized, totalCC, withCommit, true))
+	md.AddTable(headers, rows)
+	md.AddParagraph("")
+
+	var clusterRows [][]string
+	for _, regionData := range regionCostData {
+		for _, p := range comparison.projections[regionData.Region] {
+			clusterRows = append(clusterRows, []string{
+				p.Usage.ClusterName,
+				p.Usage.Region,
+				fmt.Sprintf("%s, %d %s", p.ClusterType, p.Units, unitName(p.ClusterType)),
+				fmt.Sprintf("%.1f / %.1f", p.Usage.P95IngressMBps, p.Usage.P95EgressMBps),
+				fmt.Sprintf("%.1f / %.1f", p.IngressGB, p.EgressGB),
+				fmt.Sprintf("%.1f", p.Usage.StorageGB),
+				r.formatCurrency(&p.ComputeCost),
+				r.formatCurrency(&p.NetworkingCost),
+				r.formatCurrency(&p.StorageCost),
+				fmt.Sprintf("**%.2f**", p.Total()),
+			})
+		}
+	}
+	if len(clusterRows) > 0 {
+		md.AddHeading("Projection by Cluster", 3)
+		md.AddTable([]string{"Cluster", "Region", "Confluent Cloud Size", "P95 In / Out (MBps)", "Transfer In / Out (GB)", "Storage (GB)",
+			fmt.Sprintf("Compute (%s)", currency), fmt.Sprintf("Networking (%s)", currency), fmt.Sprintf("Storage (%s)", currency), fmt.Sprintf("Total (%s)", currency)}, clusterRows)
+		md.AddParagraph("")
+	}
+
+	if len(comparison.skipped) > 0 {
+		md.AddParagraph("**Clusters not projected** (their AWS spend is still in the totals above):")
+		md.AddList(comparison.skipped)
+	}
+
+	md.AddParagraph(fmt.Sprintf("*Sizing takes the largest of p95 ingress, p95 egress and partition count against per-unit capacity, plus %.0f%% headroom, on Enterprise up to %d eCKU and on Dedicated beyond. Transfer uses average throughput; storage is average broker disk usage divided by the topics' replication factor, plus tiered storage. AWS totals cover every service in this report, including data transfer and networking.*",
+		sheet.HeadroomFraction*100, sheet.Enterprise.MaxUnits))
+	md.AddParagraph("")
+	md.AddParagraph("---")
+	md.AddParagraph("")
+}
+
+func (r *CostReporter) comparisonRow(label string, unblended, amortized, ccList float64, withCommit, bold bool) []string {
+	format := func(v float64) string {
+		if bold {
+			return fmt.Sprintf("**%.2f**", v)
+		}
+		return r.formatCurrency(&v)
+	}
+	if bold {
+		label = "**" + label + "**"
+	}
+	ccCompared := ccList
+	row := []string{label, format(unblended), format(amortized), format(ccList)}
+	if withCommit {
+		ccCompared = r.priceSheet.Committed(ccList)
+		row = append(row, format(ccCompared))
+	}
+	return append(row, format(ccCompared-amortized))
+}
+
+func unitName(clusterType ccpricing.ClusterType) string {
+	if clusterType == ccpricing.ClusterTypeDedicated {
+		return "CKU"
+	}
+	return "eCKU"
+}
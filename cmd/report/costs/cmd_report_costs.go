@@ -2,11 +2,15 @@ package costs
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
+	"github.com/confluentinc/kcp/internal/services/ccpricing"
 	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/metricshistory"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -18,6 +22,10 @@ var (
 	start     string
 	end       string
 	regions   []string
+	uploadTo  string
+
+	priceSheet string
+	byTopic    bool
 )
 
 func NewReportCostsCmd() *cobra.Command {
@@ -26,7 +34,10 @@ func NewReportCostsCmd() *cobra.Command {
 		Short: "Generate a report of costs for given region(s)",
 		Long: "Generate a report of costs for the given region(s) based on the data collected by `kcp discover`.\n\n" +
 			"`--region`, `--start`, and `--end` are all optional. If none are supplied, costs for every region in the state file over the last 31 full days are reported. If you supply `--start`, you must also supply `--end`.\n\n" +
-			"**Output:** writes a `cost_report_YYYY-MM-DD_HH-MM-SS.md` file in the current working directory with cost analysis for the selected regions and time period.",
+			"The report also projects what the measured workload would cost on Confluent Cloud over the same period and sets it beside the AWS spend per region. Projections use the throughput and storage collected by `kcp scan metrics` and a bundled sheet of list prices; pass `--price-sheet` to price with your own rates or commitment discount.\n\n" +
+			"With `--by-topic` the report also splits each cluster's share of the region's MSK spend across its topics, in proportion to their bytes in, bytes out and storage, so chargeback owners can see which topics drive spend. Per-topic throughput needs `PER_TOPIC_PER_BROKER` enhanced monitoring (or higher) when `kcp discover` runs; storage comes from the partition sizes `kcp scan clusters` records.\n\n" +
+			"**Output:** writes a `cost_report_YYYY-MM-DD_HH-MM-SS.md` file in the current working directory with cost analysis for the selected regions and time period.\n\n" +
+			"**Trends:** every `kcp discover` records its costs in `kcp-metrics-history.jsonl` next to the state file. Once the recorded scans cover two full months, each region gets a month-over-month cost trend.",
 		Example: `  # Default: all regions in the state file for the last 31 days
   kcp report costs --state-file kcp-state.json
 
@@ -35,7 +46,13 @@ func NewReportCostsCmd() *cobra.Command {
 
   # Specific regions and date range (all three must be supplied together)
   kcp report costs --state-file kcp-state.json \
-      --region us-east-1,eu-west-3 --start 2024-01-01 --end 2024-01-31`,
+      --region us-east-1,eu-west-3 --start 2024-01-01 --end 2024-01-31
+
+  # Price the Confluent Cloud comparison with negotiated rates
+  kcp report costs --state-file kcp-state.json --price-sheet my-prices.yaml
+
+  # Allocate each cluster's cost across its topics
+  kcp report costs --state-file kcp-state.json --by-topic`,
 		SilenceErrors: true,
 		PreRunE:       preRunReportCosts,
 		RunE:          runReportCosts,
@@ -54,6 +71,9 @@ func NewReportCostsCmd() *cobra.Command {
 	optionalFlags.StringSliceVar(&regions, "region", []string{}, "The AWS region(s) to include in the report (comma separated list or repeated flag).  If not provided, all regions in the state file will be included.")
 	optionalFlags.StringVar(&start, "start", "", "inclusive start date for cost report (YYYY-MM-DD).  (Defaults to 31 days prior to today)")
 	optionalFlags.StringVar(&end, "end", "", "exclusive end date for cost report (YYYY-MM-DD).  (Defaults to today).")
+	optionalFlags.StringVar(&priceSheet, "price-sheet", "", "Path to a YAML price sheet for the Confluent Cloud comparison. Fields it sets replace the bundled list prices (e.g. unit_hourly, commit_discount).")
+	optionalFlags.BoolVar(&byTopic, "by-topic", false, "Also allocate each cluster's cost across its topics in proportion to their bytes in, bytes out and storage.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the report to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
 	reportCostsCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
@@ -86,6 +106,9 @@ func preRunReportCosts(cmd *cobra.Command, args []string) error {
 	if err := utils.BindEnvToFlags(cmd); err != nil {
 		return err
 	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -162,11 +185,25 @@ func parseCostReporterOpts() (*CostReporterOpts, error) {
 		}
 	}
 
+	sheet, err := ccpricing.LoadPriceSheet(priceSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := metricshistory.Load(metricshistory.PathFor(stateFile))
+	if err != nil {
+		slog.Warn("⚠️ ignoring metrics history, trends are left out of the report", "error", err)
+	}
+
 	opts := CostReporterOpts{
-		Regions:   regions,
-		State:     state,
-		StartDate: startDate,
-		EndDate:   endDate,
+		Regions:    regions,
+		State:      state,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		UploadTo:   uploadTo,
+		PriceSheet: sheet,
+		ByTopic:    byTopic,
+		History:    history,
 	}
 
 	return &opts, nil
@@ -1,19 +1,24 @@
 package costs
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/services/ccpricing"
 	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/metricshistory"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 )
 
 type ReportService interface {
 	ProcessState(state types.State) report.ProcessedState
 	FilterRegionCosts(processedState report.ProcessedState, regionName string, startTime, endTime *time.Time) (*report.ProcessedRegionCosts, error)
+	FilterMetrics(processedState report.ProcessedState, regionName, clusterName string, startTime, endTime *time.Time) (*types.ProcessedClusterMetrics, error)
 }
 
 type CostReporterOpts struct {
@@ -21,6 +26,13 @@ type CostReporterOpts struct {
 	State     *types.State
 	StartDate *time.Time
 	EndDate   *time.Time
+	UploadTo  string
+	// PriceSheet prices the Confluent Cloud comparison; nil omits it.
+	PriceSheet *ccpricing.PriceSheet
+	// ByTopic adds the cost allocation by topic.
+	ByTopic bool
+	// History holds the earlier cost scans the trend sections are built from.
+	History []metricshistory.Snapshot
 }
 
 type CostReporter struct {
@@ -31,6 +43,11 @@ type CostReporter struct {
 	state     *types.State
 	startDate *time.Time
 	endDate   *time.Time
+	uploadTo  string
+
+	priceSheet *ccpricing.PriceSheet
+	byTopic    bool
+	history    []metricshistory.Snapshot
 }
 
 func NewCostReporter(reportService ReportService, markdownService markdown.Markdown, opts CostReporterOpts) *CostReporter {
@@ -42,6 +59,11 @@ func NewCostReporter(reportService ReportService, markdownService markdown.Markd
 		state:     opts.State,
 		startDate: opts.StartDate,
 		endDate:   opts.EndDate,
+		uploadTo:  opts.UploadTo,
+
+		priceSheet: opts.PriceSheet,
+		byTopic:    opts.ByTopic,
+		history:    opts.History,
 	}
 }
 
@@ -60,16 +82,30 @@ func (r *CostReporter) Run() error {
 		regionCostData = append(regionCostData, *regionCosts)
 	}
 
+	var comparison *ccComparison
+	if r.priceSheet != nil {
+		comparison = r.projectConfluentCloud(processedState)
+	}
+
+	var allocation *topicAllocation
+	if r.byTopic {
+		allocation = r.allocateByTopic(processedState, regionCostData)
+	}
+
 	fileName := fmt.Sprintf("cost_report_%s.md", time.Now().Format("2006-01-02_15-04-05"))
-	markdownReport := r.generateReport(regionCostData)
+	markdownReport := r.generateReport(regionCostData, comparison, allocation)
 	if err := markdownReport.Print(markdown.PrintOptions{ToTerminal: false, ToFile: fileName}); err != nil {
 		return fmt.Errorf("failed to write markdown report: %v", err)
 	}
 
+	if err := sink.UploadArtifacts(context.Background(), r.uploadTo, fileName); err != nil {
+		return fmt.Errorf("failed to upload markdown report: %v", err)
+	}
+
 	return nil
 }
 
-func (r *CostReporter) generateReport(regionCostData []report.ProcessedRegionCosts) *markdown.Markdown {
+func (r *CostReporter) generateReport(regionCostData []report.ProcessedRegionCosts, comparison *ccComparison, allocation *topicAllocation) *markdown.Markdown {
 	md := markdown.New()
 	// Add main report header
 	md.AddHeading("AWS Cost Report", 1)
@@ -109,6 +145,14 @@ func (r *CostReporter) generateReport(regionCostData []report.ProcessedRegionCos
 	// Add cost summary section
 	r.addCostSummary(md, regionCostData)
 
+	if comparison != nil {
+		r.addCCComparison(md, regionCostData, comparison)
+	}
+
+	if allocation != nil {
+		r.addTopicAllocation(md, allocation)
+	}
+
 	// Process each region
 	for _, regionData := range regionCostData {
 		r.addRegionSection(md, regionData.Region, regionData)
@@ -132,11 +176,27 @@ func (r *CostReporter) addRegionSection(md *markdown.Markdown, regionName string
 	r.addServiceAggregates(md, types.ServiceEC2Other, regionCosts.Aggregates.EC2Other)
 	r.addServiceAggregates(md, types.ServiceAWSCertificateManager, regionCosts.Aggregates.AWSCertificateManager)
 
+	r.addCostTrend(md, regionName)
+
 	md.AddParagraph("")
 	md.AddParagraph("---")
 	md.AddParagraph("")
 }
 
+// addCostTrend shows the region's month-over-month spend across the recorded
+// cost scans. It is omitted until they cover two full months.
+func (r *CostReporter) addCostTrend(md *markdown.Markdown, regionName string) {
+	trend := metricshistory.CostTrend(r.history, regionName)
+	if trend == nil || len(trend.Points) < 2 {
+		return
+	}
+
+	md.AddHeading("▪ Month-over-Month Cost Trend", 3)
+	headers, rows := metricshistory.TrendTable([]metricshistory.Trend{*trend}, 12)
+	md.AddTable(headers, rows)
+	md.AddParagraph(fmt.Sprintf("*Unblended cost of each full month recorded in %s. Growth is the compound monthly rate from the first to the last month.*", metricshistory.FileName))
+}
+
 func (r *CostReporter) addServiceAggregates(md *markdown.Markdown, serviceName string, aggregates report.ServiceCostAggregates) {
 	md.AddHeading(fmt.Sprintf("▪ %s", serviceName), 3)
 
@@ -0,0 +1,141 @@
+package costs
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// topicAllocation holds the cost allocation by topic of every cluster in the
+// reported regions, over the report period.
+type topicAllocation struct {
+	clusters []clusterTopicAllocation
+	skipped  []string // "cluster (region): reason"
+}
+
+type clusterTopicAllocation struct {
+	cluster    string
+	region     string
+	brokers    int
+	allocation *report.TopicCostAllocation
+}
+
+// allocateByTopic splits each region's MSK net amortized spend across its
+// clusters by broker count, then each cluster's share across its topics
+// with report.AllocateTopicCost. Clusters without brokers (Serverless) or
+// without per-topic data are listed as skipped.
+func (r *CostReporter) allocateByTopic(processedState report.ProcessedState, regionCostData []report.ProcessedRegionCosts) *topicAllocation {
+	allocation := &topicAllocation{}
+
+	for _, regionData := range regionCostData {
+		region := findProcessedRegion(processedState, regionData.Region)
+		if region == nil {
+			continue
+		}
+
+		var mskSpend float64
+		for _, cost := range regionData.Results {
+			if cost.Service == types.ServiceMSK {
+				mskSpend += cost.Values.NetAmortizedCost
+			}
+		}
+
+		type candidate struct {
+			cluster report.ProcessedCluster
+			metrics *types.ProcessedClusterMetrics
+		}
+		var candidates []candidate
+		totalBrokers := 0
+		for _, cluster := range region.Clusters {
+			metrics, err := r.reportService.FilterMetrics(processedState, region.Name, cluster.Name, r.startDate, r.endDate)
+			if err != nil {
+				slog.Warn("⚠️ failed to read cluster metrics for the cost allocation by topic", "cluster", cluster.Name, "region", region.Name, "error", err)
+				allocation.skipped = append(allocation.skipped, fmt.Sprintf("%s (%s): %v", cluster.Name, region.Name, err))
+				continue
+			}
+			if metrics.Metadata.NumberOfBrokerNodes == 0 {
+				allocation.skipped = append(allocation.skipped, fmt.Sprintf("%s (%s): no brokers to share the region's spend by, e.g. MSK Serverless", cluster.Name, region.Name))
+				continue
+			}
+			candidates = append(candidates, candidate{cluster: cluster, metrics: metrics})
+			totalBrokers += metrics.Metadata.NumberOfBrokerNodes
+		}
+
+		for _, c := range candidates {
+			brokers := c.metrics.Metadata.NumberOfBrokerNodes
+			clusterCost := mskSpend * float64(brokers) / float64(totalBrokers)
+
+			var topics []types.TopicDetails
+			if c.cluster.KafkaAdminClientInformation.Topics != nil {
+				topics = c.cluster.KafkaAdminClientInformation.Topics.Details
+			}
+			topicCosts := report.AllocateTopicCost(clusterCost, topics, c.metrics.Aggregates)
+			if topicCosts == nil {
+				allocation.skipped = append(allocation.skipped, fmt.Sprintf("%s (%s): no per-topic throughput or partition sizes, enable `PER_TOPIC_PER_BROKER` monitoring before `kcp discover` or run `kcp scan clusters`", c.cluster.Name, region.Name))
+				continue
+			}
+			allocation.clusters = append(allocation.clusters, clusterTopicAllocation{
+				cluster:    c.cluster.Name,
+				region:     region.Name,
+				brokers:    brokers,
+				allocation: topicCosts,
+			})
+		}
+	}
+
+	return allocation
+}
+
+func findProcessedRegion(processedState report.ProcessedState, name string) *report.ProcessedRegion {
+	for _, source := range processedState.Sources {
+		if source.Type != types.SourceTypeMSK || source.MSKData == nil {
+			continue
+		}
+		for i, region := range source.MSKData.Regions {
+			if strings.EqualFold(region.Name, name) {
+				return &source.MSKData.Regions[i]
+			}
+		}
+	}
+	return nil
+}
+
+// addTopicAllocation lists, per cluster, the topics and the share of the
+// cluster's cost each carries, most expensive first.
+func (r *CostReporter) addTopicAllocation(md *markdown.Markdown, allocation *topicAllocation) {
+	md.AddHeading("Cost Allocation by Topic", 2)
+	md.AddParagraph("*Each region's MSK net amortized spend is shared across its clusters by broker count, then across each cluster's topics in proportion to their average bytes in, bytes out and storage (all replicas), each measured dimension weighing equally. Use it to see which topics drive spend and to prioritize consolidation before migrating; it is an apportionment of the bill, not a metered cost.*")
+	md.AddParagraph("")
+
+	for _, c := range allocation.clusters {
+		md.AddHeading(fmt.Sprintf("%s (%s)", c.cluster, c.region), 3)
+		md.AddParagraph(fmt.Sprintf("**Allocated cluster cost:** %s across %d broker(s), split by %s.",
+			r.formatCurrency(&c.allocation.ClusterCost), c.brokers, strings.Join(c.allocation.Dimensions, ", ")))
+
+		var rows [][]string
+		for _, topic := range c.allocation.Topics {
+			rows = append(rows, []string{
+				topic.Topic,
+				fmt.Sprintf("%.3f", topic.BytesInPerSec/1024/1024),
+				fmt.Sprintf("%.3f", topic.BytesOutPerSec/1024/1024),
+				fmt.Sprintf("%.2f", float64(topic.StorageBytes)/1024/1024/1024),
+				fmt.Sprintf("%.1f", topic.Share*100),
+				r.formatCurrency(&topic.Cost),
+			})
+		}
+		md.AddTable([]string{"Topic", "Avg In (MBps)", "Avg Out (MBps)", "Storage (GB)", "Share (%)", "Allocated Cost ($)"}, rows)
+		md.AddParagraph("")
+	}
+
+	if len(allocation.skipped) > 0 {
+		md.AddParagraph("**Clusters not allocated by topic:**")
+		md.AddList(allocation.skipped)
+	}
+
+	md.AddParagraph("---")
+	md.AddParagraph("")
+}
@@ -0,0 +1,177 @@
+package dependencies
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/dependencies"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile string
+	format    string
+	uploadTo  string
+)
+
+func NewReportDependenciesCmd() *cobra.Command {
+	reportDependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Map the data flows between clusters and the order to migrate them in",
+		Long: "Map the data flows between the clusters in the state file — MSK Replicator flows, MirrorMaker consumer groups and connectors, and connectors that read from one cluster and write to another — into a dependency graph, and derive a safe migration order from it.\n\n" +
+			"Clusters are ordered downstream first: a cluster is migrated no later than the clusters it reads from, so a cluster link on Confluent Cloud can keep pulling from a source still on MSK. Clusters that replicate to each other are migrated together. Clusters a flow names but the state file doesn't hold are drawn dashed; a MirrorMaker consumer group shows its source only, since the group doesn't record where it writes.\n\n" +
+			"**Output:** with `--format mermaid` (default) writes a `cluster_dependencies_YYYY-MM-DD_HH-MM-SS.md` file with a Mermaid flowchart, the flows and the migration order; with `--format dot` writes a `cluster_dependencies_YYYY-MM-DD_HH-MM-SS.dot` Graphviz file and prints the migration order.",
+		Example: `  # Mermaid flowchart and migration order in a markdown report
+  kcp report dependencies --state-file kcp-state.json
+
+  # Graphviz DOT, rendered to SVG
+  kcp report dependencies --state-file kcp-state.json --format dot
+  dot -Tsvg cluster_dependencies_*.dot -o dependencies.svg`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunReportDependencies,
+		RunE:          runReportDependencies,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the cluster discovery reports have been written to.")
+	reportDependenciesCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&format, "format", "mermaid", "Graph format: 'mermaid' (markdown report) or 'dot' (Graphviz).")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the output to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	reportDependenciesCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	reportDependenciesCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = reportDependenciesCmd.MarkFlagRequired("state-file")
+
+	return reportDependenciesCmd
+}
+
+func preRunReportDependencies(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+	format = strings.ToLower(format)
+	if format != "mermaid" && format != "dot" {
+		return fmt.Errorf("invalid --format %q: expected 'mermaid' or 'dot'", format)
+	}
+	return nil
+}
+
+func runReportDependencies(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	graph := dependencies.Build(report.NewReportService().ProcessState(*state))
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	var fileName string
+	if format == "dot" {
+		fileName = fmt.Sprintf("cluster_dependencies_%s.dot", timestamp)
+		if err := summary.WriteFile(fileName, []byte(graph.DOT()), 0644); err != nil {
+			return fmt.Errorf("failed to write dependency graph: %v", err)
+		}
+		printMigrationOrder(graph)
+	} else {
+		fileName = fmt.Sprintf("cluster_dependencies_%s.md", timestamp)
+		if err := generateReport(graph).Print(markdown.PrintOptions{ToTerminal: false, ToFile: fileName}); err != nil {
+			return fmt.Errorf("failed to write markdown report: %v", err)
+		}
+	}
+	fmt.Printf("✅ Cluster dependencies written to %s\n", fileName)
+
+	if err := sink.UploadArtifacts(cmd.Context(), uploadTo, fileName); err != nil {
+		return fmt.Errorf("failed to upload dependency graph: %v", err)
+	}
+	return nil
+}
+
+func generateReport(graph *dependencies.Graph) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading("Cluster Dependencies", 1)
+
+	if len(graph.Edges) == 0 {
+		md.AddParagraph("No data flows between clusters were found: no MSK Replicators, MirrorMaker consumer groups or connectors writing to another cluster. The clusters can be migrated in any order.")
+		return md
+	}
+
+	md.AddParagraph("*Data flows between clusters, from the cluster read to the cluster written. Dashed clusters are not in the state file.*")
+	md.AddCodeBlock(graph.Mermaid(), "mermaid")
+
+	md.AddHeading("Flows", 2)
+	var rows [][]string
+	for _, e := range graph.Edges {
+		rows = append(rows, []string{graph.NodeName(e.From), graph.NodeName(e.To), e.Label()})
+	}
+	md.AddTable([]string{"From", "To", "Via"}, rows)
+	md.AddParagraph("")
+
+	md.AddHeading("Migration Order", 2)
+	md.AddParagraph("*Downstream clusters first, so a cluster link on Confluent Cloud can keep pulling from a source still on MSK. Clusters in the same step replicate to each other and move together; clusters without flows can be migrated in any order.*")
+	md.AddList(orderSteps(graph))
+	return md
+}
+
+func printMigrationOrder(graph *dependencies.Graph) {
+	if len(graph.Order) == 0 {
+		fmt.Println("No data flows between discovered clusters: they can be migrated in any order.")
+		return
+	}
+	fmt.Println("Migration order (downstream first):")
+	for _, step := range orderSteps(graph) {
+		fmt.Printf("  %s\n", step)
+	}
+}
+
+func orderSteps(graph *dependencies.Graph) []string {
+	var steps []string
+	for i, step := range graph.Order {
+		names := make([]string, len(step))
+		for j, id := range step {
+			names[j] = "`" + graph.NodeName(id) + "`"
+		}
+		steps = append(steps, fmt.Sprintf("Step %d: %s", i+1, strings.Join(names, ", ")))
+	}
+	return steps
+}
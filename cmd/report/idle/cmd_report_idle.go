@@ -0,0 +1,164 @@
+package idle
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/idle"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile string
+	idleDays  int
+	uploadTo  string
+)
+
+func NewReportIdleCmd() *cobra.Command {
+	reportIdleCmd := &cobra.Command{
+		Use:   "idle",
+		Short: "Find topics and consumer groups that have been idle for N days",
+		Long: "Find the topics nobody writes to and the consumer groups nobody consumes with any more, so they can be pruned before migrating instead of mirrored to Confluent Cloud.\n\n" +
+			"`kcp scan clusters` records each topic's end offsets and each consumer group's committed offsets, and every rescan carries forward since when they have not moved. A topic is idle when nothing was ever produced to it, when its end offsets have not moved for `--idle-days`, or when the newest message `--sample-messages` read is older than that. A consumer group is idle when it has no members and has not committed for `--idle-days`, or never committed at all. Scan the clusters again at least `--idle-days` after the first scan for the offsets to tell.\n\n" +
+			"**Output:** writes an `idle_resources_report_YYYY-MM-DD_HH-MM-SS.md` file listing the idle topics, with their size, and the idle consumer groups of each cluster.",
+		Example: `  # Topics and groups idle for 30 days
+  kcp report idle --state-file kcp-state.json
+
+  # A stricter threshold
+  kcp report idle --state-file kcp-state.json --idle-days 90`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunReportIdle,
+		RunE:          runReportIdle,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the cluster scan reports have been written to.")
+	reportIdleCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.IntVar(&idleDays, "idle-days", 30, "Days without traffic or commits after which a topic or consumer group counts as idle.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the output to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	reportIdleCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	reportIdleCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = reportIdleCmd.MarkFlagRequired("state-file")
+
+	return reportIdleCmd
+}
+
+func preRunReportIdle(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+	if idleDays < 1 {
+		return fmt.Errorf("invalid --idle-days %d: must be at least 1", idleDays)
+	}
+	return nil
+}
+
+func runReportIdle(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	result := idle.Find(report.NewReportService().ProcessState(*state), time.Duration(idleDays)*24*time.Hour, time.Now().UTC())
+
+	fileName := fmt.Sprintf("idle_resources_report_%s.md", time.Now().Format("2006-01-02_15-04-05"))
+	if err := generateReport(result, idleDays).Print(markdown.PrintOptions{ToTerminal: false, ToFile: fileName}); err != nil {
+		return fmt.Errorf("failed to write markdown report: %v", err)
+	}
+	summary.Count("idle_resources", len(result.Findings))
+	fmt.Printf("✅ Idle resources report written to %s\n", fileName)
+
+	if err := sink.UploadArtifacts(cmd.Context(), uploadTo, fileName); err != nil {
+		return fmt.Errorf("failed to upload idle resources report: %v", err)
+	}
+	return nil
+}
+
+func generateReport(result idle.Result, idleDays int) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading("Idle Topics and Consumer Groups", 1)
+	md.AddParagraph(fmt.Sprintf("*Topics without new messages and consumer groups without members or commits for %d days or more. Prune them before migrating rather than mirroring dead data; confirm with their owners first, since a topic written once a quarter looks idle too.*", idleDays))
+
+	if result.TopicsChecked == 0 && result.GroupsChecked == 0 {
+		md.AddParagraph("No topic or consumer group offsets were recorded. Run `kcp scan clusters`, and again after the idle period, to find idle resources.")
+		return md
+	}
+
+	var topicRows, groupRows [][]string
+	for _, f := range result.Findings {
+		if f.Kind == idle.KindTopic {
+			topicRows = append(topicRows, []string{f.Cluster, f.Name, size(f.Bytes), f.Reason})
+		} else {
+			groupRows = append(groupRows, []string{f.Cluster, f.Name, f.Reason})
+		}
+	}
+
+	md.AddParagraph(fmt.Sprintf("%d of %d topics and %d of %d consumer groups are idle.", len(topicRows), result.TopicsChecked, len(groupRows), result.GroupsChecked))
+	if result.AwaitingHistory > 0 {
+		md.AddParagraph(fmt.Sprintf("*%d topics or groups have not been observed unchanged for %d days yet; scan the clusters again later to tell whether they are idle.*", result.AwaitingHistory, idleDays))
+	}
+
+	md.AddHeading("Idle Topics", 2)
+	if len(topicRows) == 0 {
+		md.AddParagraph("None.")
+	} else {
+		md.AddTable([]string{"Cluster", "Topic", "Size", "Reason"}, topicRows, 0)
+		md.AddParagraph("")
+	}
+
+	md.AddHeading("Idle Consumer Groups", 2)
+	if len(groupRows) == 0 {
+		md.AddParagraph("None.")
+	} else {
+		md.AddTable([]string{"Cluster", "Consumer Group", "Reason"}, groupRows, 0)
+	}
+	return md
+}
+
+func size(bytes int64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f GB", float64(bytes)/(1024*1024*1024))
+}
@@ -2,10 +2,13 @@ package metrics
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
+	"github.com/confluentinc/kcp/internal/services/metricshistory"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -18,6 +21,9 @@ var (
 	end        string
 	clusterIds []string
 	sourceType string
+	uploadTo   string
+
+	ownerTagKeys []string
 )
 
 func NewReportMetricsCmd() *cobra.Command {
@@ -26,7 +32,8 @@ func NewReportMetricsCmd() *cobra.Command {
 		Short: "Generate a report of metrics for given cluster(s)",
 		Long: "Generate a report of metrics for the given cluster(s) based on the data collected by `kcp discover` or `kcp scan clusters`.\n\n" +
 			"`--start` and `--end` must be provided together if specified. If neither `--cluster-id` nor `--source-type` is given, metrics for all clusters (both MSK and Apache Kafka) are included. `--cluster-id` and `--source-type` are mutually exclusive.\n\n" +
-			"**Output:** writes a `metric_report_YYYY-MM-DD_HH-MM-SS.md` file in the current working directory with metrics analysis for the selected clusters and time period.",
+			"**Output:** writes a `metric_report_YYYY-MM-DD_HH-MM-SS.md` file in the current working directory with metrics analysis for the selected clusters and time period.\n\n" +
+			"**Trends:** every `kcp discover` and `kcp scan clusters` records its metrics in `kcp-metrics-history.jsonl` next to the state file. Once the recorded scans span two months, each cluster gets a month-over-month trend of throughput, partitions and storage.",
 		Example: `  # All clusters (MSK and Apache Kafka) in the state file
   kcp report metrics --state-file kcp-state.json
 
@@ -40,7 +47,10 @@ func NewReportMetricsCmd() *cobra.Command {
 
   # All Apache Kafka clusters, custom date range
   kcp report metrics --state-file kcp-state.json --source-type apache-kafka \
-      --start 2024-01-01 --end 2024-01-31`,
+      --start 2024-01-01 --end 2024-01-31
+
+  # Group MSK clusters by a custom ownership tag
+  kcp report metrics --state-file kcp-state.json --owner-tag-keys squad,team`,
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 		PreRunE:       preRunReportMetrics,
@@ -61,6 +71,8 @@ func NewReportMetricsCmd() *cobra.Command {
 	optionalFlags.StringSliceVar(&clusterIds, "cluster-id", []string{}, "The cluster identifier(s) to include in the report (comma separated list or repeated flag). Accepts both MSK ARNs and Apache Kafka cluster IDs.")
 	optionalFlags.StringVar(&start, "start", "", "inclusive start date for metrics report (YYYY-MM-DD).  (Defaults to 31 days prior to today)")
 	optionalFlags.StringVar(&end, "end", "", "exclusive end date for metrics report (YYYY-MM-DD).  (Defaults to today).")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the report to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.StringSliceVar(&ownerTagKeys, "owner-tag-keys", types.DefaultOwnerTagKeys, "MSK cluster tag keys checked, in order, to infer the owning team used to group clusters in the report (matched case-insensitively).")
 	reportMetricsCmd.Flags().AddFlagSet(optionalFlags)
 	groups[optionalFlags] = "Optional Flags"
 
@@ -95,6 +107,9 @@ func preRunReportMetrics(cmd *cobra.Command, args []string) error {
 	if err := utils.BindEnvToFlags(cmd); err != nil {
 		return err
 	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
 
 	// Validate and normalize source type if provided. "apache-kafka" is the
 	// user-facing value; internally the source is represented by the "osk" token.
@@ -198,12 +213,21 @@ func parseMetricReporterOpts() (*MetricReporterOpts, error) {
 		allClusterIds = clusterIds
 	}
 
+	history, err := metricshistory.Load(metricshistory.PathFor(stateFile))
+	if err != nil {
+		slog.Warn("⚠️ ignoring metrics history, trends are left out of the report", "error", err)
+	}
+
 	opts := MetricReporterOpts{
 		ClusterIds: allClusterIds,
 		State:      state,
 		StartDate:  startDate,
 		EndDate:    endDate,
 		SourceType: sourceType,
+		UploadTo:   uploadTo,
+
+		OwnerTagKeys: ownerTagKeys,
+		History:      history,
 	}
 
 	return &opts, nil
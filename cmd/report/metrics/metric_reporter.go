@@ -1,16 +1,27 @@
 package metrics
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/confluentinc/kcp/internal/build_info"
 	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/metricshistory"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 )
 
+// unassignedOwner groups MSK clusters without an owner tag.
+const unassignedOwner = "Unassigned"
+
+// trendMonths is the number of most recent months shown in trend tables.
+const trendMonths = 12
+
 type ReportService interface {
 	ProcessState(state types.State) report.ProcessedState
 	FilterClusterMetrics(processedState report.ProcessedState, clusterArn string, sourceType string, startTime, endTime *time.Time) (*types.ProcessedClusterMetrics, error)
@@ -22,27 +33,38 @@ type MetricReporterOpts struct {
 	StartDate  *time.Time
 	EndDate    *time.Time
 	SourceType string
+	UploadTo   string
+	// OwnerTagKeys are the MSK cluster tag keys used to infer the owning team.
+	OwnerTagKeys []string
+	// History holds the earlier metrics scans the trend sections are built from.
+	History []metricshistory.Snapshot
 }
 
 type MetricReporter struct {
 	reportService ReportService
 
-	clusterIds []string
-	state      *types.State
-	startDate  *time.Time
-	endDate    *time.Time
-	sourceType string
+	clusterIds   []string
+	state        *types.State
+	startDate    *time.Time
+	endDate      *time.Time
+	sourceType   string
+	uploadTo     string
+	ownerTagKeys []string
+	history      []metricshistory.Snapshot
 }
 
 func NewMetricReporter(reportService ReportService, opts MetricReporterOpts) *MetricReporter {
 	return &MetricReporter{
 		reportService: reportService,
 
-		clusterIds: opts.ClusterIds,
-		state:      opts.State,
-		startDate:  opts.StartDate,
-		endDate:    opts.EndDate,
-		sourceType: opts.SourceType,
+		clusterIds:   opts.ClusterIds,
+		state:        opts.State,
+		startDate:    opts.StartDate,
+		endDate:      opts.EndDate,
+		sourceType:   opts.SourceType,
+		uploadTo:     opts.UploadTo,
+		ownerTagKeys: opts.OwnerTagKeys,
+		history:      opts.History,
 	}
 }
 
@@ -68,6 +90,10 @@ func (r *MetricReporter) Run() error {
 		return fmt.Errorf("failed to write markdown report: %v", err)
 	}
 
+	if err := sink.UploadArtifacts(context.Background(), r.uploadTo, fileName); err != nil {
+		return fmt.Errorf("failed to upload markdown report: %v", err)
+	}
+
 	return nil
 }
 
@@ -96,6 +122,8 @@ func (r *MetricReporter) generateReport(clusters []types.ProcessedClusterMetrics
 		}
 	}
 
+	r.addOwnerSummarySection(md, clusters)
+
 	md.AddHorizontalRule()
 
 	// Process each cluster in this region
@@ -137,6 +165,72 @@ func (r *MetricReporter) determineReportTitle(clusters []types.ProcessedClusterM
 	return "AWS MSK Metrics Report"
 }
 
+// ownerOf infers the owning team of an MSK cluster from its tags.
+func (r *MetricReporter) ownerOf(clusterMetrics types.ProcessedClusterMetrics) string {
+	if owner := types.InferOwnerFromTags(r.clusterTags(clusterMetrics.ClusterArn), r.effectiveOwnerTagKeys()); owner != "" {
+		return owner
+	}
+	return unassignedOwner
+}
+
+// addOwnerSummarySection groups the MSK clusters in the report by owning team.
+// It is omitted when no cluster carries an owner tag.
+func (r *MetricReporter) addOwnerSummarySection(md *markdown.Markdown, clusters []types.ProcessedClusterMetrics) {
+	clustersByOwner := map[string][]string{}
+	anyOwned := false
+	for _, cluster := range clusters {
+		if !r.isMSKCluster(cluster.ClusterArn) {
+			continue
+		}
+		owner := r.ownerOf(cluster)
+		if owner != unassignedOwner {
+			anyOwned = true
+		}
+		clustersByOwner[owner] = append(clustersByOwner[owner], utils.ExtractClusterNameFromArn(cluster.ClusterArn))
+	}
+	if !anyOwned {
+		return
+	}
+
+	owners := make([]string, 0, len(clustersByOwner))
+	for owner := range clustersByOwner {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	md.AddHeading("Clusters by Owner", 2)
+	var tableData [][]string
+	for _, owner := range owners {
+		names := clustersByOwner[owner]
+		sort.Strings(names)
+		tableData = append(tableData, []string{owner, fmt.Sprintf("%d", len(names)), strings.Join(names, ", ")})
+	}
+	md.AddTable([]string{"Owner", "Clusters", "Cluster Names"}, tableData)
+	md.AddParagraph(fmt.Sprintf("*Owner inferred from cluster tags: %s.*", strings.Join(r.effectiveOwnerTagKeys(), ", ")))
+}
+
+// clusterTags returns the tags discovered for an MSK cluster in the state file.
+func (r *MetricReporter) clusterTags(clusterArn string) map[string]string {
+	if r.state == nil || r.state.MSKSources == nil {
+		return nil
+	}
+	for _, region := range r.state.MSKSources.Regions {
+		for _, cluster := range region.Clusters {
+			if strings.EqualFold(cluster.Arn, clusterArn) {
+				return cluster.AWSClientInformation.MskClusterConfig.Tags
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MetricReporter) effectiveOwnerTagKeys() []string {
+	if len(r.ownerTagKeys) == 0 {
+		return types.DefaultOwnerTagKeys
+	}
+	return r.ownerTagKeys
+}
+
 // isMSKCluster checks if a cluster identifier is an MSK ARN
 func (r *MetricReporter) isMSKCluster(clusterArn string) bool {
 	return len(clusterArn) > 4 && clusterArn[:4] == "arn:"
@@ -150,6 +244,7 @@ func (r *MetricReporter) addClusterSection(md *markdown.Markdown, clusterMetrics
 		md.AddHeading(fmt.Sprintf("Cluster Name: %s", utils.ExtractClusterNameFromArn(clusterMetrics.ClusterArn)), 3)
 		md.AddParagraph(fmt.Sprintf("**Cluster ARN**: %s", clusterMetrics.ClusterArn))
 		md.AddParagraph(fmt.Sprintf("**Region**: %s", clusterMetrics.Region))
+		md.AddParagraph(fmt.Sprintf("**Owner**: %s", r.ownerOf(clusterMetrics)))
 	} else {
 		// OSK cluster - show ID instead of ARN
 		md.AddHeading(fmt.Sprintf("Cluster: %s", clusterMetrics.ClusterArn), 3)
@@ -213,6 +308,10 @@ func (r *MetricReporter) addClusterSection(md *markdown.Markdown, clusterMetrics
 		md.AddParagraph("*No metric aggregates available for this cluster.*")
 	}
 
+	r.addStorageHeadroomSection(md, clusterMetrics.Aggregates)
+
+	r.addTrendSection(md, clusterMetrics.ClusterArn)
+
 	// Add individual metric values
 	r.addIndividualMetricsSection(md, clusterMetrics.Metrics)
 
@@ -220,6 +319,56 @@ func (r *MetricReporter) addClusterSection(md *markdown.Markdown, clusterMetrics
 	r.addQueryDetailsSection(md, clusterMetrics.QueryInfo)
 }
 
+// addStorageHeadroomSection flags brokers whose peak KafkaDataLogsDiskUsed
+// leaves little room for the extra retention a link-based migration needs.
+func (r *MetricReporter) addStorageHeadroomSection(md *markdown.Markdown, aggregates map[string]types.MetricAggregate) {
+	headroom := report.AnalyzeStorageHeadroom(aggregates)
+	if headroom == nil {
+		return
+	}
+
+	md.AddHeading("Storage Headroom", 4)
+
+	headers := []string{"Broker ID", "Peak Disk Used (%)", "Headroom (%)", "Status"}
+	var tableData [][]string
+	for _, broker := range headroom.Brokers {
+		tableData = append(tableData, []string{
+			broker.BrokerID,
+			fmt.Sprintf("%.2f", broker.MaxUsedPercent),
+			fmt.Sprintf("%.2f", broker.HeadroomPercent),
+			string(broker.Status),
+		})
+	}
+	md.AddTable(headers, tableData)
+
+	switch headroom.Status {
+	case report.StorageHeadroomCritical:
+		md.AddParagraph(fmt.Sprintf("**⚠️ Cluster is close to its storage limit** (peak %.2f%% used, critical at %.0f%%). "+
+			"Expand broker storage or reduce retention before creating cluster links, and size the target cluster on throughput rather than current storage.",
+			headroom.MaxUsedPercent, report.StorageHeadroomCriticalPercent))
+	case report.StorageHeadroomWarning:
+		md.AddParagraph(fmt.Sprintf("**⚠️ Storage headroom is limited** (peak %.2f%% used, warning at %.0f%%). "+
+			"Review retention and consider throughput-based sizing for the link-based migration.",
+			headroom.MaxUsedPercent, report.StorageHeadroomWarningPercent))
+	default:
+		md.AddParagraph(fmt.Sprintf("All brokers are below %.0f%% disk usage.", report.StorageHeadroomWarningPercent))
+	}
+}
+
+// addTrendSection shows the cluster's month-over-month growth across the
+// recorded metrics scans. It is omitted until they span two months.
+func (r *MetricReporter) addTrendSection(md *markdown.Markdown, clusterID string) {
+	trends := metricshistory.ClusterTrends(r.history, clusterID)
+	if !metricshistory.HasHistory(trends) {
+		return
+	}
+
+	md.AddHeading("Month-over-Month Trends", 4)
+	headers, rows := metricshistory.TrendTable(trends, trendMonths)
+	md.AddTable(headers, rows)
+	md.AddParagraph(fmt.Sprintf("*Monthly values from every scan recorded in %s. Growth is the compound monthly rate from the first to the last month; size the target cluster for the projected load at cutover, not the current one.*", metricshistory.FileName))
+}
+
 func (r *MetricReporter) addIndividualMetricsSection(md *markdown.Markdown, metrics []types.ProcessedMetric) {
 	if len(metrics) == 0 {
 		md.AddParagraph("*No individual metric data available for this cluster.*")
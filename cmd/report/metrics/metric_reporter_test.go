@@ -183,6 +183,113 @@ func TestGenerateReport_MSKCluster(t *testing.T) {
 	assert.NotContains(t, report, "Location:")
 }
 
+func TestGenerateReport_StorageHeadroom(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	reporter := NewMetricReporter(nil, MetricReporterOpts{
+		State:     &types.State{},
+		StartDate: &startTime,
+		EndDate:   &endTime,
+	})
+
+	newCluster := func(aggregates map[string]types.MetricAggregate) []types.ProcessedClusterMetrics {
+		return []types.ProcessedClusterMetrics{{
+			ClusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/test-msk/abc-123",
+			Region:     "us-east-1",
+			Aggregates: aggregates,
+		}}
+	}
+
+	t.Run("flags brokers close to the storage limit", func(t *testing.T) {
+		report := reporter.generateReport(newCluster(map[string]types.MetricAggregate{
+			types.BrokerDiskUsedLabelPrefix + "1": {Maximum: ptr(40.0)},
+			types.BrokerDiskUsedLabelPrefix + "2": {Maximum: ptr(90.0)},
+		})).String()
+
+		assert.Contains(t, report, "Storage Headroom")
+		assert.Contains(t, report, "CRITICAL")
+		assert.Contains(t, report, "close to its storage limit")
+		assert.Contains(t, report, "throughput")
+	})
+
+	t.Run("healthy cluster", func(t *testing.T) {
+		report := reporter.generateReport(newCluster(map[string]types.MetricAggregate{
+			types.BrokerDiskUsedLabelPrefix + "1": {Maximum: ptr(40.0)},
+		})).String()
+
+		assert.Contains(t, report, "Storage Headroom")
+		assert.Contains(t, report, "All brokers are below 70% disk usage.")
+	})
+
+	t.Run("omitted without per-broker data", func(t *testing.T) {
+		report := reporter.generateReport(newCluster(map[string]types.MetricAggregate{
+			"BytesInPerSec": {Maximum: ptr(1000.0)},
+		})).String()
+
+		assert.NotContains(t, report, "Storage Headroom")
+	})
+}
+
+func TestGenerateReport_ClustersByOwner(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	mskCluster := func(name string, tags map[string]string) types.DiscoveredCluster {
+		cluster := types.DiscoveredCluster{Arn: "arn:aws:kafka:us-east-1:123456789012:cluster/" + name + "/abc"}
+		cluster.AWSClientInformation.MskClusterConfig.Tags = tags
+		return cluster
+	}
+	state := &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{{
+		Name: "us-east-1",
+		Clusters: []types.DiscoveredCluster{
+			mskCluster("orders", map[string]string{"Team": "payments"}),
+			mskCluster("ledger", map[string]string{"owner": "payments", "squad": "ledger"}),
+			mskCluster("scratch", nil),
+		},
+	}}}}
+
+	newReporter := func(ownerTagKeys []string) *MetricReporter {
+		return NewMetricReporter(nil, MetricReporterOpts{
+			State:        state,
+			StartDate:    &startTime,
+			EndDate:      &endTime,
+			OwnerTagKeys: ownerTagKeys,
+		})
+	}
+
+	clusters := []types.ProcessedClusterMetrics{
+		{ClusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc"},
+		{ClusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/ledger/abc"},
+		{ClusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/scratch/abc"},
+		{ClusterArn: "osk-cluster-1"},
+	}
+
+	t.Run("groups MSK clusters by inferred owner", func(t *testing.T) {
+		report := newReporter(nil).generateReport(clusters).String()
+
+		assert.Contains(t, report, "Clusters by Owner")
+		assert.Contains(t, report, "| payments | 2 | ledger, orders |")
+		assert.Contains(t, report, "| Unassigned | 1 | scratch |")
+		assert.Contains(t, report, "**Owner**: payments")
+		assert.NotContains(t, report, "osk-cluster-1 |")
+	})
+
+	t.Run("custom owner tag keys", func(t *testing.T) {
+		report := newReporter([]string{"squad"}).generateReport(clusters).String()
+
+		assert.Contains(t, report, "| ledger | 1 | ledger |")
+		assert.Contains(t, report, "| Unassigned | 2 | orders, scratch |")
+	})
+
+	t.Run("omitted when no cluster has an owner tag", func(t *testing.T) {
+		report := newReporter(nil).generateReport(clusters[2:]).String()
+
+		assert.NotContains(t, report, "Clusters by Owner")
+		assert.Contains(t, report, "**Owner**: Unassigned")
+	})
+}
+
 func TestGenerateReport_OSKCluster(t *testing.T) {
 	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
@@ -0,0 +1,184 @@
+package open_monitoring
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/openmonitoring"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile        string
+	targetClusterIDs []string
+	uploadTo         string
+)
+
+func NewReportOpenMonitoringCmd() *cobra.Command {
+	reportOpenMonitoringCmd := &cobra.Command{
+		Use:   "open-monitoring",
+		Short: "Report the MSK Open Monitoring exporters and generate Prometheus scrape configs for the migration",
+		Long: "Report whether the Open Monitoring with Prometheus exporters are enabled on each MSK cluster, recommend what to enable to watch the migration, and generate a Prometheus scrape config for it.\n\n" +
+			"`kcp scan clusters` records whether MSK runs the JMX exporter (port 11001) and the node exporter (port 11002) on the brokers. The scrape config has a job per enabled exporter of each cluster, targeting its brokers. With `--target-cluster-id` it also scrapes the Confluent Cloud Metrics API export endpoint for those clusters, whose `" + openmonitoring.MirrorLagMetric + "` is the mirror lag of each mirror topic; fill in a Cloud API key with the MetricsViewer role.\n\n" +
+			"**Output:** writes an `open_monitoring_report_YYYY-MM-DD_HH-MM-SS.md` file with the exporters and recommendations of each cluster, and a `prometheus_scrape_configs_YYYY-MM-DD_HH-MM-SS.yml` file to merge into prometheus.yml.",
+		Example: `  kcp report open-monitoring --state-file kcp-state.json
+
+  # Also scrape the mirror lag of the target clusters
+  kcp report open-monitoring --state-file kcp-state.json --target-cluster-id lkc-abc123`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunReportOpenMonitoring,
+		RunE:          runReportOpenMonitoring,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the cluster scan reports have been written to.")
+	reportOpenMonitoringCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringSliceVar(&targetClusterIDs, "target-cluster-id", nil, "The ID of a target Confluent Cloud cluster, e.g. lkc-abc123, to scrape the mirror lag of. Repeatable.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the output to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	reportOpenMonitoringCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	reportOpenMonitoringCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = reportOpenMonitoringCmd.MarkFlagRequired("state-file")
+
+	return reportOpenMonitoringCmd
+}
+
+func preRunReportOpenMonitoring(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+	for _, id := range targetClusterIDs {
+		if !strings.HasPrefix(id, "lkc-") {
+			return fmt.Errorf("invalid --target-cluster-id '%s': must be a Confluent Cloud cluster ID (lkc-...)", id)
+		}
+	}
+	return nil
+}
+
+func runReportOpenMonitoring(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	clusters := openmonitoring.Analyze(report.NewReportService().ProcessState(*state))
+	scrapeConfig, err := openmonitoring.BuildScrapeConfig(clusters, targetClusterIDs).Marshal()
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	scrapeFileName := fmt.Sprintf("prometheus_scrape_configs_%s.yml", timestamp)
+	if err := summary.WriteFile(scrapeFileName, scrapeConfig, 0644); err != nil {
+		return fmt.Errorf("failed to write scrape config: %v", err)
+	}
+
+	fileName := fmt.Sprintf("open_monitoring_report_%s.md", timestamp)
+	if err := generateReport(clusters, scrapeFileName).Print(markdown.PrintOptions{ToTerminal: false, ToFile: fileName}); err != nil {
+		return fmt.Errorf("failed to write markdown report: %v", err)
+	}
+
+	disabled := 0
+	for _, c := range clusters {
+		if c.Settings != nil && !c.Settings.Enabled() {
+			disabled++
+		}
+	}
+	summary.Count("open_monitoring_disabled_clusters", disabled)
+	fmt.Printf("✅ Open Monitoring report written to %s\n", fileName)
+	fmt.Printf("✅ Prometheus scrape configs written to %s\n", scrapeFileName)
+
+	if err := sink.UploadArtifacts(cmd.Context(), uploadTo, fileName, scrapeFileName); err != nil {
+		return fmt.Errorf("failed to upload open monitoring report: %v", err)
+	}
+	return nil
+}
+
+func generateReport(clusters []openmonitoring.Cluster, scrapeFileName string) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading("MSK Open Monitoring", 1)
+	md.AddParagraph("*The Prometheus exporters MSK runs on the brokers of each cluster. Scrape them during the migration to see the load the cluster link puts on the source brokers, next to the mirror lag Confluent Cloud reports.*")
+
+	if len(clusters) == 0 {
+		md.AddParagraph("No MSK clusters were found in the state file. Run `kcp discover` and `kcp scan clusters` first.")
+		return md
+	}
+
+	rows := make([][]string, 0, len(clusters))
+	for _, c := range clusters {
+		jmx, node, brokers := "-", "-", "-"
+		if c.Settings != nil {
+			jmx, node = enabled(c.Settings.JmxExporterEnabled), enabled(c.Settings.NodeExporterEnabled)
+			brokers = fmt.Sprintf("%d", len(c.Settings.BrokerHosts))
+		}
+		rows = append(rows, []string{c.Region, c.Name, jmx, node, brokers})
+	}
+	md.AddTable([]string{"Region", "Cluster", "JMX Exporter", "Node Exporter", "Brokers"}, rows, 0)
+	md.AddParagraph("")
+
+	md.AddHeading("Recommendations", 2)
+	found := false
+	for _, c := range clusters {
+		if len(c.Recommendations) == 0 {
+			continue
+		}
+		found = true
+		md.AddHeading(c.Name, 3)
+		md.AddList(c.Recommendations)
+	}
+	if !found {
+		md.AddParagraph("None.")
+	}
+
+	md.AddHeading("Scrape Configs", 2)
+	md.AddParagraph(fmt.Sprintf("Merge the jobs in `%s` into the `scrape_configs` of prometheus.yml. For mirror lag, alert on `%s` growing while the cluster link is mirroring, and on it not reaching 0 before cutover.", scrapeFileName, openmonitoring.MirrorLagMetric))
+	return md
+}
+
+func enabled(on bool) string {
+	if on {
+		return "✅ enabled"
+	}
+	return "❌ disabled"
+}
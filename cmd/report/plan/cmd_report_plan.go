@@ -10,6 +10,8 @@ import (
 
 	"github.com/confluentinc/kcp/internal/services/plan"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -22,6 +24,9 @@ var (
 	outputDir  string
 	output     string
 	configPath string
+	uploadTo   string
+	splitBy    string
+	audience   string
 )
 
 func NewReportPlanCmd() *cobra.Command {
@@ -30,7 +35,10 @@ func NewReportPlanCmd() *cobra.Command {
 		Short: "Generate a Migration Plan to migrate to Confluent Cloud (Experimental / WIP)",
 		Long: "Generate a Migration Plan to migrate to Confluent Cloud from a kcp state file produced by `kcp scan` (Experimental / WIP). " +
 			"The plan provides technical recommendations on target cluster sizing, networking, authentication, and migration approach for each source cluster, and surfaces open questions to capture your intent so the generated plan fits your use case.\n\n" +
-			"**Output:** writes `plan.md` and/or `plan.json` to `--output-dir` (default `./plan-output`).",
+			"**Output:** writes `plan.md` and/or `plan.json` to `--output-dir` (default `./plan-output`). " +
+			"With `--split-by tag:<key>`, writes one plan per value of that cluster tag (MSK) or label (Apache Kafka) instead, each under `--output-dir/<value>` and covering only those clusters, their topics and connectors; clusters without the tag go under `untagged`.\n\n" +
+			"**Audience:** `--audience app-team` writes `app-team-plan.md` / `app-team-plan.json` instead, for the teams owning client applications. " +
+			"It keeps only client auth changes, topics and consumer-group semantics, leaves out sizing, networking and cost, and redacts AWS account IDs, ARNs (reduced to their resource part, e.g. `role/orders-app`), subnets and security groups.",
 		Example: `  # Minimal: state file in, plan.md/plan.json out
   kcp report plan --state-file kcp-state.json
 
@@ -38,7 +46,13 @@ func NewReportPlanCmd() *cobra.Command {
   kcp report plan --state-file kcp-state.json --plan-inputs plan-inputs.yaml
 
   # JSON only
-  kcp report plan --state-file kcp-state.json --output json`,
+  kcp report plan --state-file kcp-state.json --output json
+
+  # One plan per owning team, from each cluster's "team" tag or label
+  kcp report plan --state-file kcp-state.json --split-by tag:team
+
+  # Per-team plans for the application teams, without infrastructure details
+  kcp report plan --state-file kcp-state.json --split-by tag:team --audience app-team`,
 		SilenceErrors: true,
 		SilenceUsage:  true, // don't dump --help on runtime errors (only flag-parse errors should surface usage)
 		PreRunE:       preRunReportPlan,
@@ -58,6 +72,9 @@ func NewReportPlanCmd() *cobra.Command {
 	optionalFlags.StringVar(&planInputs, "plan-inputs", "", "Path to plan-inputs.yaml with your overrides. All fields optional.")
 	optionalFlags.StringVar(&outputDir, "output-dir", "./plan-output", "Directory to write plan.md / plan.json into.")
 	optionalFlags.StringVar(&output, "output", "md,json", "Comma-separated output formats: md, json, or both.")
+	optionalFlags.StringVar(&splitBy, "split-by", "", "Write one plan per owning team instead of one for the fleet: 'tag:<key>' groups clusters by the value of that MSK cluster tag or Apache Kafka cluster label.")
+	optionalFlags.StringVar(&audience, "audience", string(plan.AudiencePlatform), "Who the plan is for: 'platform' (the full plan) or 'app-team' (client auth, topic and consumer-group changes only, with AWS account IDs, ARNs, subnets and security groups redacted).")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the plan files to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
 	optionalFlags.StringVar(&configPath, "config", "", "Path to a plan-config.yaml override. Embedded config is the default.")
 	reportPlanCmd.Flags().AddFlagSet(optionalFlags)
 	_ = reportPlanCmd.Flags().MarkHidden("config")
@@ -82,10 +99,24 @@ func NewReportPlanCmd() *cobra.Command {
 }
 
 func preRunReportPlan(cmd *cobra.Command, _ []string) error {
-	return utils.BindEnvToFlags(cmd)
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+	return nil
 }
 
-func runReportPlan(_ *cobra.Command, _ []string) error {
+func runReportPlan(cmd *cobra.Command, _ []string) error {
+	splitTagKey, err := parseSplitBy(splitBy)
+	if err != nil {
+		return err
+	}
+	planAudience, err := plan.ParseAudience(audience)
+	if err != nil {
+		return err
+	}
 	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
 		return fmt.Errorf("state file does not exist: %s", stateFile)
 	}
@@ -108,46 +139,150 @@ func runReportPlan(_ *cobra.Command, _ []string) error {
 	}
 	inputs := plan.ResolvePlanInputs(rawInputs, cfg)
 
+	writeMD, writeJSON, err := parseOutputFormats(output)
+	if err != nil {
+		return err
+	}
+
+	var written []string
+	if splitTagKey == "" {
+		written, err = writePlan(*state, cfg, inputs, outputDir, planAudience, writeMD, writeJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, group := range report.SplitStateByTag(*state, splitTagKey) {
+			groupInputs := inputsForGroup(inputs, group.State)
+			paths, err := writePlan(group.State, cfg, groupInputs, filepath.Join(outputDir, groupDirName(group.Name)), planAudience, writeMD, writeJSON)
+			if err != nil {
+				return fmt.Errorf("plan for %s=%s: %w", splitTagKey, group.Name, err)
+			}
+			written = append(written, paths...)
+		}
+	}
+
+	if err := sink.UploadArtifacts(cmd.Context(), uploadTo, written...); err != nil {
+		return fmt.Errorf("upload --upload-to %s: %w", uploadTo, err)
+	}
+	return nil
+}
+
+// writePlan builds the plan for state and writes the requested formats for
+// audience to dir, returning the paths written.
+func writePlan(state types.State, cfg *plan.PlanConfig, inputs plan.PlanInputsResolved, dir string, audience plan.Audience, writeMD, writeJSON bool) ([]string, error) {
 	rs := report.NewReportService()
-	processed := rs.ProcessState(*state)
+	processed := rs.ProcessState(state)
 
 	svc := plan.NewPlanService(cfg, nil)
 	p, err := svc.Build(processed, inputs, stateFile)
 	if err != nil {
-		return fmt.Errorf("build plan: %w", err)
+		return nil, fmt.Errorf("build plan: %w", err)
 	}
 
-	writeMD, writeJSON, err := parseOutputFormats(output)
-	if err != nil {
-		return err
+	fileStem := "plan"
+	renderMarkdown := func() ([]byte, error) { return plan.RenderMarkdown(p, cfg) }
+	if audience == plan.AudienceAppTeam {
+		p, err = plan.ForAppTeam(p)
+		if err != nil {
+			return nil, err
+		}
+		fileStem = "app-team-plan"
+		renderMarkdown = func() ([]byte, error) { return plan.RenderAppTeamMarkdown(p) }
 	}
-	if err := os.MkdirAll(outputDir, 0o755); err != nil {
-		return fmt.Errorf("create --output-dir %s: %w", outputDir, err)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create --output-dir %s: %w", dir, err)
 	}
 
+	var written []string
+
 	if writeMD {
-		data, err := plan.RenderMarkdown(p, cfg)
+		data, err := renderMarkdown()
 		if err != nil {
-			return err
+			return nil, err
 		}
-		path := filepath.Join(outputDir, "plan.md")
-		if err := os.WriteFile(path, data, 0o644); err != nil {
-			return fmt.Errorf("write plan.md: %w", err)
+		path := filepath.Join(dir, fileStem+".md")
+		if err := summary.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("write %s.md: %w", fileStem, err)
 		}
 		fmt.Println("wrote", path)
+		written = append(written, path)
 	}
 	if writeJSON {
 		data, err := plan.RenderJSON(p)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		path := filepath.Join(outputDir, "plan.json")
-		if err := os.WriteFile(path, data, 0o644); err != nil {
-			return fmt.Errorf("write plan.json: %w", err)
+		path := filepath.Join(dir, fileStem+".json")
+		if err := summary.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("write %s.json: %w", fileStem, err)
 		}
 		fmt.Println("wrote", path)
+		written = append(written, path)
 	}
-	return nil
+	return written, nil
+}
+
+// parseSplitBy validates --split-by and returns the tag key to split on, or ""
+// when the plan is not split.
+func parseSplitBy(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	key, ok := strings.CutPrefix(raw, "tag:")
+	if !ok || strings.TrimSpace(key) == "" {
+		return "", fmt.Errorf("--split-by %q is invalid; use tag:<key>, for example tag:team", raw)
+	}
+	return strings.TrimSpace(key), nil
+}
+
+// inputsForGroup keeps only the per-cluster plan-inputs overrides for the
+// group's clusters, so a team's plan does not flag the other teams' overrides
+// as unknown clusters.
+func inputsForGroup(inputs plan.PlanInputsResolved, state types.State) plan.PlanInputsResolved {
+	if inputs.Raw == nil || len(inputs.Raw.Clusters) == 0 {
+		return inputs
+	}
+	names := map[string]bool{}
+	if state.MSKSources != nil {
+		for _, region := range state.MSKSources.Regions {
+			for _, cluster := range region.Clusters {
+				names[cluster.Name] = true
+			}
+		}
+	}
+	if state.OSKSources != nil {
+		for _, cluster := range state.OSKSources.Clusters {
+			names[cluster.ID] = true
+		}
+	}
+
+	raw := *inputs.Raw
+	raw.Clusters = map[string]plan.ClusterPlanInputs{}
+	for name, clusterInputs := range inputs.Raw.Clusters {
+		if names[name] {
+			raw.Clusters[name] = clusterInputs
+		}
+	}
+	inputs.Raw = &raw
+	return inputs
+}
+
+// groupDirName turns a tag value into a directory name: anything other than
+// letters, digits, '.', '_' and '-' becomes '-'.
+func groupDirName(name string) string {
+	dir := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+	if strings.Trim(dir, ".") == "" {
+		return "-"
+	}
+	return dir
 }
 
 // loadState reads the state file and tolerates two pre-0.7 layouts the
@@ -3,6 +3,8 @@ package plan
 import (
 	"testing"
 
+	"github.com/confluentinc/kcp/internal/services/plan"
+	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -90,3 +92,40 @@ func TestIsPostLegacyVersion(t *testing.T) {
 		assert.Equal(t, c.want, isPostLegacyVersion(c.v), c.v)
 	}
 }
+
+func TestParseSplitBy(t *testing.T) {
+	key, err := parseSplitBy("")
+	require.NoError(t, err)
+	assert.Empty(t, key)
+
+	key, err = parseSplitBy("tag:team")
+	require.NoError(t, err)
+	assert.Equal(t, "team", key)
+
+	for _, raw := range []string{"team", "tag:", "label:team"} {
+		_, err := parseSplitBy(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestGroupDirName(t *testing.T) {
+	assert.Equal(t, "payments", groupDirName("payments"))
+	assert.Equal(t, "data-platform-eu", groupDirName("data platform/eu"))
+	assert.Equal(t, "-", groupDirName(".."))
+}
+
+func TestInputsForGroup_KeepsOnlyGroupClusterOverrides(t *testing.T) {
+	inputs := plan.PlanInputsResolved{Raw: &plan.PlanInputs{Clusters: map[string]plan.ClusterPlanInputs{
+		"orders": {},
+		"clicks": {},
+	}}}
+	state := types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{{Name: "orders"}}},
+	}}}
+
+	got := inputsForGroup(inputs, state)
+
+	assert.Len(t, got.Raw.Clusters, 1)
+	assert.Contains(t, got.Raw.Clusters, "orders")
+	assert.Len(t, inputs.Raw.Clusters, 2, "the fleet-wide inputs must not be modified")
+}
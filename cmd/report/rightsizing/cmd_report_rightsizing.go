@@ -0,0 +1,246 @@
+package rightsizing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/markdown"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/services/rightsizing"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile  string
+	priceSheet string
+	clusterIds []string
+	uploadTo   string
+)
+
+func NewReportRightsizingCmd() *cobra.Command {
+	reportRightsizingCmd := &cobra.Command{
+		Use:   "rightsizing",
+		Short: "Recommend cheaper MSK broker configurations for the migration period",
+		Long: "Recommend, for each provisioned MSK cluster in the state file, the cheapest broker fleet that still carries its measured workload while the migration to Confluent Cloud runs: fewer brokers, a smaller or Graviton instance type, and no provisioned storage throughput where the volume baseline suffices. The recommendation keeps headroom above the peak ingress, egress and partition count from the collected CloudWatch metrics, keeps at least one broker per AZ and as many brokers as the highest topic replication factor, and grows the broker volumes when fewer brokers would hold the data above the storage warning level — EBS volumes cannot shrink.\n\n" +
+			"Costs and savings are at the MSK list prices of the embedded price sheet; supply your own with `--price-sheet` to match your region or discounts. Serverless clusters, express brokers and clusters without throughput metrics are listed as skipped.\n\n" +
+			"**Output:** writes a `msk_rightsizing_report_YYYY-MM-DD_HH-MM-SS.md` file with the recommended configuration, projected monthly savings and the steps to apply it for each cluster.",
+		Example: `  # Rightsize every MSK cluster in the state file
+  kcp report rightsizing --state-file kcp-state.json
+
+  # One cluster, at the prices of your own sheet
+  kcp report rightsizing --state-file kcp-state.json \
+      --cluster-id arn:aws:kafka:us-east-1:123456789012:cluster/orders/1 \
+      --price-sheet eu-west-1-prices.yaml`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunReportRightsizing,
+		RunE:          runReportRightsizing,
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the cluster discovery reports have been written to.")
+	reportRightsizingCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&priceSheet, "price-sheet", "", "Path to an MSK price sheet YAML overriding the embedded list prices and broker capacities.")
+	optionalFlags.StringSliceVar(&clusterIds, "cluster-id", []string{}, "The MSK cluster ARN(s) to include in the report (comma separated list or repeated flag). Defaults to every MSK cluster.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the output to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	reportRightsizingCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	reportRightsizingCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = reportRightsizingCmd.MarkFlagRequired("state-file")
+
+	return reportRightsizingCmd
+}
+
+func preRunReportRightsizing(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runReportRightsizing(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
+		return fmt.Errorf("state file does not exist: %s", stateFile)
+	}
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+	sheet, err := rightsizing.LoadPriceSheet(priceSheet)
+	if err != nil {
+		return err
+	}
+
+	recommendations := filterClusters(rightsizing.Analyze(report.NewReportService().ProcessState(*state), sheet), clusterIds)
+	if len(clusterIds) > 0 && len(recommendations) == 0 {
+		return fmt.Errorf("no MSK cluster in the state file matches --cluster-id %s", strings.Join(clusterIds, ","))
+	}
+
+	fileName := fmt.Sprintf("msk_rightsizing_report_%s.md", time.Now().Format("2006-01-02_15-04-05"))
+	if err := generateReport(recommendations, sheet).Print(markdown.PrintOptions{ToTerminal: false, ToFile: fileName}); err != nil {
+		return fmt.Errorf("failed to write markdown report: %v", err)
+	}
+	summary.Count("clusters_rightsized", countStatus(recommendations, rightsizing.StatusRightsize))
+	fmt.Printf("✅ MSK rightsizing report written to %s\n", fileName)
+
+	if err := sink.UploadArtifacts(cmd.Context(), uploadTo, fileName); err != nil {
+		return fmt.Errorf("failed to upload rightsizing report: %v", err)
+	}
+	return nil
+}
+
+func filterClusters(recommendations []rightsizing.Recommendation, arns []string) []rightsizing.Recommendation {
+	if len(arns) == 0 {
+		return recommendations
+	}
+	var out []rightsizing.Recommendation
+	for _, r := range recommendations {
+		for _, arn := range arns {
+			if strings.EqualFold(r.ClusterArn, arn) {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func countStatus(recommendations []rightsizing.Recommendation, status rightsizing.Status) int {
+	n := 0
+	for _, r := range recommendations {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func generateReport(recommendations []rightsizing.Recommendation, sheet *rightsizing.PriceSheet) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading("MSK Rightsizing", 1)
+	md.AddParagraph(fmt.Sprintf("*Monthly costs at the %s list prices of the price sheet verified %s (%s), with %.0f%% headroom above the measured peaks. Broker instances, EBS storage and provisioned storage throughput only; data transfer is unchanged.*",
+		sheet.Currency, sheet.LastVerified, sheet.Source, sheet.HeadroomFraction*100))
+
+	if len(recommendations) == 0 {
+		md.AddParagraph("No MSK clusters were found in the state file.")
+		return md
+	}
+
+	var rows [][]string
+	var skipped []rightsizing.Recommendation
+	var totalCurrent, totalSavings float64
+	for _, r := range recommendations {
+		if r.Status == rightsizing.StatusSkipped {
+			skipped = append(skipped, r)
+			continue
+		}
+		recommended := "no change"
+		if r.Status == rightsizing.StatusRightsize {
+			recommended = r.Recommended.String()
+		}
+		rows = append(rows, []string{
+			r.ClusterName, r.Region, r.Current.String(), recommended,
+			money(r.CurrentMonthly), money(r.RecommendedMonthly), money(r.MonthlySavings()),
+		})
+		totalCurrent += r.CurrentMonthly
+		totalSavings += r.MonthlySavings()
+	}
+
+	if len(rows) > 0 {
+		md.AddHeading("Summary", 2)
+		md.AddTable([]string{"Cluster", "Region", "Current", "Recommended", "Current / month", "Recommended / month", "Savings / month"}, rows)
+		md.AddParagraph("")
+		md.AddParagraph(fmt.Sprintf("**Projected savings:** %s per month (%.0f%% of %s).", money(totalSavings), percent(totalSavings, totalCurrent), money(totalCurrent)))
+
+		md.AddHeading("Clusters", 2)
+		for _, r := range recommendations {
+			if r.Status != rightsizing.StatusSkipped {
+				addCluster(md, r)
+			}
+		}
+	}
+
+	if len(skipped) > 0 {
+		md.AddHeading("Skipped Clusters", 2)
+		var skippedRows [][]string
+		for _, r := range skipped {
+			skippedRows = append(skippedRows, []string{r.ClusterName, r.Region, r.Reason})
+		}
+		md.AddTable([]string{"Cluster", "Region", "Reason"}, skippedRows)
+	}
+	return md
+}
+
+func addCluster(md *markdown.Markdown, r rightsizing.Recommendation) {
+	md.AddHeading(r.ClusterName, 3)
+	md.AddParagraph(fmt.Sprintf("Measured peaks: %.1f MB/s in, %.1f MB/s out, %d partition replicas, %.0f GB stored, replication factor up to %d.",
+		r.Demand.PeakIngressMBps, r.Demand.PeakEgressMBps, r.Demand.PartitionReplicas, r.Demand.StorageUsedGB, r.Demand.MaxReplicationFactor))
+
+	if r.Status == rightsizing.StatusRightSized {
+		md.AddParagraph(fmt.Sprintf("Right-sized: %s.", r.Reason))
+		return
+	}
+
+	md.AddTable([]string{"", "Current", "Recommended"}, [][]string{
+		{"Brokers", fmt.Sprintf("%d", r.Current.Brokers), fmt.Sprintf("%d", r.Recommended.Brokers)},
+		{"Instance type", r.Current.InstanceType, r.Recommended.InstanceType},
+		{"Volume per broker", fmt.Sprintf("%d GB", r.Current.VolumeGB), fmt.Sprintf("%d GB", r.Recommended.VolumeGB)},
+		{"Provisioned throughput", throughput(r.Current.ProvisionedThroughputMBps), throughput(r.Recommended.ProvisionedThroughputMBps)},
+		{"Monthly cost", money(r.CurrentMonthly), money(r.RecommendedMonthly)},
+	})
+	md.AddParagraph("")
+	md.AddList(r.Notes)
+}
+
+func money(v float64) string {
+	return fmt.Sprintf("$%.2f", v)
+}
+
+func percent(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return part / whole * 100
+}
+
+func throughput(mbps int) string {
+	if mbps == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("%d MB/s", mbps)
+}
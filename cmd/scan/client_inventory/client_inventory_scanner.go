@@ -45,7 +45,10 @@ type RequestMetadata struct {
 	Principal    string
 	Auth         string
 	ApiKey       string
-	Timestamp    time.Time
+	// ApiVersion is the version of the request's API; 0 when the log line
+	// does not record it.
+	ApiVersion int
+	Timestamp  time.Time
 }
 
 func NewClientInventoryScanner(s3Service S3Service, state types.State, opts ClientInventoryScannerOpts) (*ClientInventoryScanner, error) {
@@ -57,12 +60,10 @@ func NewClientInventoryScanner(s3Service S3Service, state types.State, opts Clie
 	}, nil
 }
 
-func (cis *ClientInventoryScanner) Run() error {
+func (cis *ClientInventoryScanner) Run(ctx context.Context) error {
 	fmt.Printf("🚀 Starting client inventory scan for %s\n", cis.opts.S3Uri)
 	slog.Info("🔍 scanning client inventory", "s3_uri", cis.opts.S3Uri, "region", cis.opts.Region, "cluster", cis.opts.ClusterName)
 
-	ctx := context.Background()
-
 	bucket, prefix, err := cis.s3Service.ParseS3URI(cis.opts.S3Uri)
 	if err != nil {
 		return fmt.Errorf("failed to parse S3 URI: %w", err)
@@ -80,8 +81,11 @@ func (cis *ClientInventoryScanner) Run() error {
 		return nil
 	}
 
-	discoveredClients := cis.handleLogFiles(ctx, bucket, logFiles)
+	discoveredClients, processed := cis.handleLogFiles(ctx, bucket, logFiles)
 
+	// Clients are merged into the ones already in state, so an interrupted
+	// scan saves the clients of the files it finished and a re-run adds the
+	// rest.
 	if err := cis.state.UpsertDiscoveredClients(cis.opts.Region, cis.opts.ClusterName, discoveredClients); err != nil {
 		return fmt.Errorf("failed to upsert discovered clients: %w", err)
 	}
@@ -90,19 +94,35 @@ func (cis *ClientInventoryScanner) Run() error {
 		return fmt.Errorf("failed to persist state file: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		fmt.Printf("⚠️  Client inventory scan interrupted (%v); saved the clients of %d of %d log files. Re-run to scan the rest\n", context.Cause(ctx), processed, len(logFiles))
+		return fmt.Errorf("client inventory scan interrupted: %w", context.Cause(ctx))
+	}
+
 	slog.Info("✅ client inventory scan complete", "region", cis.opts.Region, "cluster", cis.opts.ClusterName, "discovered_clients", len(discoveredClients))
 	return nil
 }
 
-func (cis *ClientInventoryScanner) handleLogFiles(ctx context.Context, bucket string, logFiles []string) []types.DiscoveredClient {
+// handleLogFiles returns the clients found in logFiles and how many files it
+// processed, which is fewer than len(logFiles) once ctx is cancelled.
+func (cis *ClientInventoryScanner) handleLogFiles(ctx context.Context, bucket string, logFiles []string) ([]types.DiscoveredClient, int) {
 	requestMetadataByCompositeKey := make(map[string]*RequestMetadata)
 
+	processed := 0
 	for _, file := range logFiles {
+		if ctx.Err() != nil {
+			break
+		}
 		requestsMetadata, err := cis.handleLogFile(ctx, bucket, file)
 		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			processed++
 			slog.Error("failed to extract API requests", "file", file, "error", err)
 			continue
 		}
+		processed++
 
 		fmt.Printf("  🔍 Parsed log file %s: found %d matching log lines\n", file, len(requestsMetadata))
 		slog.Debug("🔍 parsed log file", "file", file, "matching_lines", len(requestsMetadata))
@@ -118,10 +138,13 @@ func (cis *ClientInventoryScanner) handleLogFiles(ctx context.Context, bucket st
 				continue
 			}
 
-			// store the most recent request
+			// store the most recent request, keeping the oldest API version
+			// the client used, which is what a compatibility check needs
+			apiVersion := types.OldestApiVersion(existingRequestMetadata.ApiVersion, metadata.ApiVersion)
 			if metadata.Timestamp.After(existingRequestMetadata.Timestamp) {
 				requestMetadataByCompositeKey[compositeKey] = &metadata
 			}
+			requestMetadataByCompositeKey[compositeKey].ApiVersion = apiVersion
 		}
 	}
 
@@ -134,13 +157,14 @@ func (cis *ClientInventoryScanner) handleLogFiles(ctx context.Context, bucket st
 			Topic:        metadata.Topic,
 			Auth:         metadata.Auth,
 			Principal:    metadata.Principal,
+			ApiVersion:   metadata.ApiVersion,
 			Timestamp:    metadata.Timestamp,
 		}
 
 		discoveredClients = append(discoveredClients, discoveredClient)
 	}
 
-	return discoveredClients
+	return discoveredClients, processed
 }
 
 func (cis *ClientInventoryScanner) handleLogFile(ctx context.Context, bucket, key string) ([]RequestMetadata, error) {
@@ -5,7 +5,9 @@ import (
 
 	"github.com/confluentinc/kcp/internal/client"
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/notify"
 	"github.com/confluentinc/kcp/internal/services/s3"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/spf13/cobra"
@@ -13,8 +15,11 @@ import (
 )
 
 var (
-	s3Uri     string
-	stateFile string
+	s3Uri      string
+	stateFile  string
+	uploadTo   string
+	bundle     bool
+	notifyOpts notify.Options
 )
 
 func clientInventoryIAMAnnotation() string {
@@ -46,7 +51,7 @@ Each unique client is keyed by ` + "`clientID|topic|role|auth|principal`" + ` 
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 		PreRunE:       preRunScanClientInventory,
-		RunE:          runScanClientInventory,
+		RunE:          notify.WrapRunE(&notifyOpts, runScanClientInventory),
 	}
 
 	groups := map[*pflag.FlagSet]string{}
@@ -60,11 +65,20 @@ Each unique client is keyed by ` + "`clientID|topic|role|auth|principal`" + ` 
 
 	groups[requiredFlags] = "Required Flags"
 
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
+	clientInventoryCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
 	clientInventoryCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Short)
 
-		flagOrder := []*pflag.FlagSet{requiredFlags}
-		groupNames := []string{"Required Flags"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -88,6 +102,13 @@ func preRunScanClientInventory(cmd *cobra.Command, args []string) error {
 	if err := utils.BindEnvToFlags(cmd); err != nil {
 		return err
 	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -115,10 +136,14 @@ func runScanClientInventory(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create client inventory scanner: %v", err)
 	}
 
-	if err := clientInventoryScanner.Run(); err != nil {
+	if err := clientInventoryScanner.Run(cmd.Context()); err != nil {
 		return err
 	}
 
+	if err := sink.DeliverArtifacts(cmd.Context(), uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -24,6 +25,7 @@ var (
 var (
 	TimestampPattern     = regexp.MustCompile(`^\[([^\]]+)\]`)
 	ApiKeyPattern        = regexp.MustCompile(`apiKey=([^,\)]+)`)
+	ApiVersionPattern    = regexp.MustCompile(`apiVersion=(\d+)`)
 	ClientIdPattern      = regexp.MustCompile(`clientId=([^,\)]+)`)
 	BrokerFetcherPattern = regexp.MustCompile(`broker-(\d+)-fetcher-(\d+)`)
 
@@ -44,6 +46,7 @@ type KafkaApiTraceLineParser struct{}
 
 func (p *KafkaApiTraceLineParser) Parse(line string, lineNumber int, fileName string) (*RequestMetadata, error) {
 	apiKey := extractField(line, ApiKeyPattern)
+	apiVersion, _ := strconv.Atoi(extractField(line, ApiVersionPattern))
 	clientId := extractField(line, ClientIdPattern)
 
 	if apiKey != "FETCH" && apiKey != "PRODUCE" {
@@ -85,6 +88,7 @@ func (p *KafkaApiTraceLineParser) Parse(line string, lineNumber int, fileName st
 		Principal:    principal,
 		Auth:         auth,
 		ApiKey:       apiKey,
+		ApiVersion:   apiVersion,
 		Timestamp:    timestamp,
 	}
 
@@ -22,13 +22,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 1,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "TESTING_PRODUCER-1",
-				Topic:     "customers1",
-				Auth:      "IAM",
-				Principal: "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
+				Timestamp:  time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "TESTING_PRODUCER-1",
+				Topic:      "customers1",
+				Auth:       "IAM",
+				Principal:  "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
 			},
 		},
 		{
@@ -37,13 +38,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 2,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 8, 7, 14, 34, 27, 495000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "producer_with_sasl_scram-9",
-				Topic:     "test-topic-1",
-				Auth:      "SASL_SCRAM",
-				Principal: "User:kafka-user-2",
+				Timestamp:  time.Date(2025, 8, 7, 14, 34, 27, 495000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "producer_with_sasl_scram-9",
+				Topic:      "test-topic-1",
+				Auth:       "SASL_SCRAM",
+				Principal:  "User:kafka-user-2",
 			},
 		},
 		{
@@ -52,13 +54,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 1,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 8, 13, 12, 11, 26, 271000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "sarama",
-				Topic:     "test-topic-1",
-				Auth:      "TLS",
-				Principal: "User:CN=kcp_tls_testing",
+				Timestamp:  time.Date(2025, 8, 13, 12, 11, 26, 271000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "sarama",
+				Topic:      "test-topic-1",
+				Auth:       "TLS",
+				Principal:  "User:CN=kcp_tls_testing",
 			},
 		},
 		{
@@ -67,13 +70,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 1,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 8, 15, 8, 51, 4, 815000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "sarama",
-				Topic:     "test-topic-1",
-				Auth:      "UNAUTHENTICATED",
-				Principal: "User:ANONYMOUS",
+				Timestamp:  time.Date(2025, 8, 15, 8, 51, 4, 815000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "sarama",
+				Topic:      "test-topic-1",
+				Auth:       "UNAUTHENTICATED",
+				Principal:  "User:ANONYMOUS",
 			},
 		},
 		{
@@ -82,13 +86,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 8,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "TESTING_PRODUCER-1",
-				Topic:     "test-topic",
-				Auth:      "IAM",
-				Principal: "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
+				Timestamp:  time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "TESTING_PRODUCER-1",
+				Topic:      "test-topic",
+				Auth:       "IAM",
+				Principal:  "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
 			},
 		},
 		{
@@ -97,13 +102,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 9,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "TESTING_PRODUCER-1",
-				Topic:     "test_topic",
-				Auth:      "IAM",
-				Principal: "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
+				Timestamp:  time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "TESTING_PRODUCER-1",
+				Topic:      "test_topic",
+				Auth:       "IAM",
+				Principal:  "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
 			},
 		},
 		{
@@ -112,13 +118,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 10,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
-				Role:      "Producer",
-				ApiKey:    "PRODUCE",
-				ClientId:  "TESTING_PRODUCER-1",
-				Topic:     "test-topic-1",
-				Auth:      "IAM",
-				Principal: "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
+				Timestamp:  time.Date(2025, 7, 25, 14, 45, 53, 662000000, time.UTC),
+				Role:       "Producer",
+				ApiKey:     "PRODUCE",
+				ApiVersion: 7,
+				ClientId:   "TESTING_PRODUCER-1",
+				Topic:      "test-topic-1",
+				Auth:       "IAM",
+				Principal:  "arn:aws:sts::635910096382:assumed-role/AWSReservedSSO_nonprod-administrator_b3955bd58a347b7b/me@confluent.io",
 			},
 		},
 		{
@@ -135,13 +142,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 1,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 8, 8, 7, 30, 42, 834000000, time.UTC),
-				Role:      "Consumer",
-				ApiKey:    "FETCH",
-				ClientId:  "glenns_consumer_with_sasl_scram",
-				Topic:     "test-topic-1",
-				Auth:      "SASL_SCRAM",
-				Principal: "User:kafka-user-2",
+				Timestamp:  time.Date(2025, 8, 8, 7, 30, 42, 834000000, time.UTC),
+				Role:       "Consumer",
+				ApiKey:     "FETCH",
+				ApiVersion: 11,
+				ClientId:   "glenns_consumer_with_sasl_scram",
+				Topic:      "test-topic-1",
+				Auth:       "SASL_SCRAM",
+				Principal:  "User:kafka-user-2",
 			},
 		},
 		{
@@ -150,13 +158,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 1,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 8, 13, 12, 11, 38, 87000000, time.UTC),
-				Role:      "Consumer",
-				ApiKey:    "FETCH",
-				ClientId:  "sarama",
-				Topic:     "test-topic-1",
-				Auth:      "TLS",
-				Principal: "User:CN=kcp_tls_testing",
+				Timestamp:  time.Date(2025, 8, 13, 12, 11, 38, 87000000, time.UTC),
+				Role:       "Consumer",
+				ApiKey:     "FETCH",
+				ApiVersion: 11,
+				ClientId:   "sarama",
+				Topic:      "test-topic-1",
+				Auth:       "TLS",
+				Principal:  "User:CN=kcp_tls_testing",
 			},
 		},
 		{
@@ -165,13 +174,14 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			lineNumber: 1,
 			fileName:   "test.log",
 			expectedResult: &RequestMetadata{
-				Timestamp: time.Date(2025, 8, 13, 12, 11, 38, 87000000, time.UTC),
-				Role:      "Consumer",
-				ApiKey:    "FETCH",
-				ClientId:  "sarama",
-				Topic:     "test-topic-1",
-				Auth:      "TLS",
-				Principal: "User:CN=kcp testing",
+				Timestamp:  time.Date(2025, 8, 13, 12, 11, 38, 87000000, time.UTC),
+				Role:       "Consumer",
+				ApiKey:     "FETCH",
+				ApiVersion: 11,
+				ClientId:   "sarama",
+				Topic:      "test-topic-1",
+				Auth:       "TLS",
+				Principal:  "User:CN=kcp testing",
 			},
 		},
 	}
@@ -231,6 +241,10 @@ func TestKafkaApiTraceLineParser_Parse(t *testing.T) {
 			if result.Principal != tt.expectedResult.Principal {
 				t.Errorf("expected Principal %q, got %q", tt.expectedResult.Principal, result.Principal)
 			}
+
+			if result.ApiVersion != tt.expectedResult.ApiVersion {
+				t.Errorf("expected ApiVersion %d, got %d", tt.expectedResult.ApiVersion, result.ApiVersion)
+			}
 		})
 	}
 }
@@ -0,0 +1,155 @@
+package cloudtrail_clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/cloudtrail"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+type CloudTrailClientsScannerOpts struct {
+	StateFile string
+	Regions   []string
+	StartTime time.Time
+	EndTime   time.Time
+	// Athena, when set, queries a trail's logs instead of each region's
+	// event history.
+	Athena *cloudtrail.AthenaQueryOpts
+}
+
+// EventLookup reads one region's CloudTrail event history.
+type EventLookup interface {
+	LookupKafkaEvents(ctx context.Context, start, end time.Time) ([]cloudtrail.KafkaEvent, error)
+}
+
+// EventQuery queries a trail's logs with Athena.
+type EventQuery interface {
+	QueryKafkaEvents(ctx context.Context, opts cloudtrail.AthenaQueryOpts, start, end time.Time) ([]cloudtrail.KafkaEvent, error)
+}
+
+type CloudTrailClientsScanner struct {
+	lookups map[string]EventLookup
+	query   EventQuery
+	state   *types.State
+	opts    CloudTrailClientsScannerOpts
+}
+
+// NewCloudTrailClientsScanner returns a scanner reading the event history of
+// each region in lookups or, when opts.Athena is set, querying with query.
+func NewCloudTrailClientsScanner(lookups map[string]EventLookup, query EventQuery, state *types.State, opts CloudTrailClientsScannerOpts) *CloudTrailClientsScanner {
+	return &CloudTrailClientsScanner{
+		lookups: lookups,
+		query:   query,
+		state:   state,
+		opts:    opts,
+	}
+}
+
+func (s *CloudTrailClientsScanner) Run(ctx context.Context) error {
+	fmt.Printf("🚀 Starting CloudTrail client scan from %s to %s\n", s.opts.StartTime.Format(time.RFC3339), s.opts.EndTime.Format(time.RFC3339))
+
+	source := types.CloudTrailSourceLookupEvents
+	var events []cloudtrail.KafkaEvent
+	regions := s.opts.Regions
+	if s.opts.Athena != nil {
+		source = types.CloudTrailSourceAthena
+		slog.Info("🔍 querying CloudTrail logs with Athena", "table", s.opts.Athena.Table)
+		queried, err := s.query.QueryKafkaEvents(ctx, *s.opts.Athena, s.opts.StartTime, s.opts.EndTime)
+		if err != nil {
+			return fmt.Errorf("failed to query CloudTrail logs: %w", err)
+		}
+		events = queried
+	} else {
+		var scanned []string
+		var errs []error
+		for _, region := range regions {
+			if ctx.Err() != nil {
+				break
+			}
+			slog.Info("🔍 looking up CloudTrail events", "region", region)
+			found, err := s.lookups[region].LookupKafkaEvents(ctx, s.opts.StartTime, s.opts.EndTime)
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				slog.Warn("⚠️ failed to look up CloudTrail events; skipping region", "region", region, "error", err)
+				errs = append(errs, fmt.Errorf("%s: %w", region, err))
+				continue
+			}
+			events = append(events, found...)
+			scanned = append(scanned, region)
+		}
+		if ctx.Err() != nil {
+			return s.saveInterruptedLookup(ctx, regions, scanned, events)
+		}
+		if len(scanned) == 0 {
+			return fmt.Errorf("failed to look up CloudTrail events in any region: %w", errors.Join(errs...))
+		}
+		regions = scanned
+	}
+	slog.Info("🔍 found CloudTrail events naming a cluster", "count", len(events))
+
+	s.recordActivity(source, regions, cloudtrail.ClientActivity(events))
+
+	if err := s.state.PersistStateFile(s.opts.StateFile); err != nil {
+		return fmt.Errorf("failed to persist state file: %w", err)
+	}
+
+	slog.Info("✅ CloudTrail client scan complete", "regions", len(regions))
+	return nil
+}
+
+// saveInterruptedLookup records the activity of the regions whose lookups
+// finished before ctx was cancelled, so an interrupted scan keeps them, and
+// returns the interruption error. An Athena query is a single call, so an
+// interrupted one has nothing to save.
+func (s *CloudTrailClientsScanner) saveInterruptedLookup(ctx context.Context, regions, scanned []string, events []cloudtrail.KafkaEvent) error {
+	if len(scanned) > 0 {
+		s.recordActivity(types.CloudTrailSourceLookupEvents, scanned, cloudtrail.ClientActivity(events))
+		if err := s.state.PersistStateFile(s.opts.StateFile); err != nil {
+			return fmt.Errorf("failed to persist state file: %w", err)
+		}
+	}
+	remaining := slices.DeleteFunc(slices.Clone(regions), func(region string) bool { return slices.Contains(scanned, region) })
+	fmt.Printf("⚠️  CloudTrail client scan interrupted (%v); saved %d of %d regions. Re-run with --region %s to scan the rest\n", context.Cause(ctx), len(scanned), len(regions), strings.Join(remaining, ","))
+	return fmt.Errorf("CloudTrail client scan interrupted: %w", context.Cause(ctx))
+}
+
+// recordActivity replaces the CloudTrail activity of every cluster in the
+// scanned regions, including clusters nothing called.
+func (s *CloudTrailClientsScanner) recordActivity(source string, regions []string, activity map[string][]types.CloudTrailPrincipalActivity) {
+	recorded := make(map[string]bool)
+	for i := range s.state.MSKSources.Regions {
+		region := &s.state.MSKSources.Regions[i]
+		if !slices.Contains(regions, region.Name) {
+			continue
+		}
+		for j := range region.Clusters {
+			cluster := &region.Clusters[j]
+			principals := activity[cluster.Arn]
+			if principals == nil {
+				principals = []types.CloudTrailPrincipalActivity{}
+			}
+			cluster.CloudTrailActivity = &types.CloudTrailClientActivity{
+				Source:     source,
+				StartTime:  s.opts.StartTime,
+				EndTime:    s.opts.EndTime,
+				Principals: principals,
+			}
+			recorded[cluster.Arn] = true
+			fmt.Printf("  ✅ %s: %d principal(s)\n", cluster.Name, len(principals))
+		}
+	}
+
+	for clusterArn := range activity {
+		if !recorded[clusterArn] {
+			slog.Info("⏭️ skipping CloudTrail events for a cluster not in the scanned regions of the state file", "cluster_arn", clusterArn)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package cloudtrail_clients
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/cloudtrail"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ordersArn   = "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1"
+	paymentsArn = "arn:aws:kafka:us-east-1:000000000000:cluster/payments/abc-2"
+	eventsArn   = "arn:aws:kafka:eu-west-1:000000000000:cluster/events/abc-3"
+)
+
+type mockLookup struct {
+	events []cloudtrail.KafkaEvent
+	err    error
+}
+
+func (m *mockLookup) LookupKafkaEvents(context.Context, time.Time, time.Time) ([]cloudtrail.KafkaEvent, error) {
+	return m.events, m.err
+}
+
+type mockQuery struct {
+	opts   cloudtrail.AthenaQueryOpts
+	events []cloudtrail.KafkaEvent
+}
+
+func (m *mockQuery) QueryKafkaEvents(_ context.Context, opts cloudtrail.AthenaQueryOpts, _, _ time.Time) ([]cloudtrail.KafkaEvent, error) {
+	m.opts = opts
+	return m.events, nil
+}
+
+func testState() *types.State {
+	return &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{{Name: "orders", Arn: ordersArn}, {Name: "payments", Arn: paymentsArn}}},
+		{Name: "eu-west-1", Clusters: []types.DiscoveredCluster{{Name: "events", Arn: eventsArn}}},
+	}}}
+}
+
+func testOpts(t *testing.T, regions ...string) CloudTrailClientsScannerOpts {
+	end := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	return CloudTrailClientsScannerOpts{
+		StateFile: filepath.Join(t.TempDir(), "kcp-state.json"),
+		Regions:   regions,
+		StartTime: end.AddDate(0, 0, -7),
+		EndTime:   end,
+	}
+}
+
+func appEvent(clusterArn string) cloudtrail.KafkaEvent {
+	seen := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	return cloudtrail.KafkaEvent{
+		PrincipalArn: "arn:aws:iam::000000000000:role/orders-app",
+		SourceIP:     "10.0.1.7",
+		EventName:    "GetBootstrapBrokers",
+		ClusterArn:   clusterArn,
+		Count:        1,
+		FirstSeen:    seen,
+		LastSeen:     seen,
+	}
+}
+
+func TestScanner_Run_LookupEvents(t *testing.T) {
+	state := testState()
+	lookups := map[string]EventLookup{
+		"us-east-1": &mockLookup{events: []cloudtrail.KafkaEvent{appEvent(ordersArn), appEvent("arn:aws:kafka:us-east-1:000000000000:cluster/gone/abc-9")}},
+		"eu-west-1": &mockLookup{err: errors.New("AccessDenied")},
+	}
+	opts := testOpts(t, "us-east-1", "eu-west-1")
+
+	require.NoError(t, NewCloudTrailClientsScanner(lookups, nil, state, opts).Run(context.Background()))
+
+	orders := state.MSKSources.Regions[0].Clusters[0].CloudTrailActivity
+	require.NotNil(t, orders)
+	assert.Equal(t, types.CloudTrailSourceLookupEvents, orders.Source)
+	assert.Equal(t, opts.StartTime, orders.StartTime)
+	require.Len(t, orders.Principals, 1)
+	assert.Equal(t, []string{"10.0.1.7"}, orders.Principals[0].SourceIPs)
+
+	// Nothing called payments, which is recorded; eu-west-1 failed and is left alone.
+	payments := state.MSKSources.Regions[0].Clusters[1].CloudTrailActivity
+	require.NotNil(t, payments)
+	assert.Empty(t, payments.Principals)
+	assert.Nil(t, state.MSKSources.Regions[1].Clusters[0].CloudTrailActivity)
+
+	persisted, err := types.NewStateFromFile(opts.StateFile)
+	require.NoError(t, err)
+	assert.Len(t, persisted.MSKSources.Regions[0].Clusters[0].CloudTrailActivity.Principals, 1)
+}
+
+func TestScanner_Run_AllRegionsFail(t *testing.T) {
+	lookups := map[string]EventLookup{"us-east-1": &mockLookup{err: errors.New("AccessDenied")}}
+
+	err := NewCloudTrailClientsScanner(lookups, nil, testState(), testOpts(t, "us-east-1")).Run(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestScanner_Run_Athena(t *testing.T) {
+	state := testState()
+	query := &mockQuery{events: []cloudtrail.KafkaEvent{appEvent(ordersArn), appEvent(eventsArn)}}
+	opts := testOpts(t, "us-east-1")
+	opts.Athena = &cloudtrail.AthenaQueryOpts{Table: "default.cloudtrail_logs"}
+
+	require.NoError(t, NewCloudTrailClientsScanner(nil, query, state, opts).Run(context.Background()))
+
+	assert.Equal(t, "default.cloudtrail_logs", query.opts.Table)
+	orders := state.MSKSources.Regions[0].Clusters[0].CloudTrailActivity
+	require.NotNil(t, orders)
+	assert.Equal(t, types.CloudTrailSourceAthena, orders.Source)
+	assert.Len(t, orders.Principals, 1)
+	// eu-west-1 was not in scope, so its events are not recorded.
+	assert.Nil(t, state.MSKSources.Regions[1].Clusters[0].CloudTrailActivity)
+}
+
+func TestParseScanCloudTrailClientsOpts(t *testing.T) {
+	t.Cleanup(func() { regions, days, athenaTable = nil, 7, "" })
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	days = 30
+	opts, err := parseScanCloudTrailClientsOpts(testState(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us-east-1", "eu-west-1"}, opts.Regions)
+	assert.Equal(t, now.AddDate(0, 0, -30), opts.StartTime)
+	assert.Nil(t, opts.Athena)
+
+	regions = []string{"eu-west-1"}
+	athenaTable = "cloudtrail_logs"
+	opts, err = parseScanCloudTrailClientsOpts(testState(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-west-1"}, opts.Regions)
+	require.NotNil(t, opts.Athena)
+	assert.Equal(t, "cloudtrail_logs", opts.Athena.Table)
+
+	regions = []string{"ap-south-1"}
+	_, err = parseScanCloudTrailClientsOpts(testState(), now)
+	assert.ErrorContains(t, err, "region ap-south-1 not found")
+
+	_, err = parseScanCloudTrailClientsOpts(&types.State{}, now)
+	assert.ErrorContains(t, err, "run `kcp discover` first")
+}
+
+// cancellingLookup cancels the scan while its region is being looked up, like
+// Ctrl-C during the call.
+type cancellingLookup struct {
+	cancel context.CancelFunc
+}
+
+func (m *cancellingLookup) LookupKafkaEvents(ctx context.Context, _, _ time.Time) ([]cloudtrail.KafkaEvent, error) {
+	m.cancel()
+	return nil, ctx.Err()
+}
+
+func TestScanner_Run_InterruptedSavesFinishedRegions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	state := testState()
+	lookups := map[string]EventLookup{
+		"us-east-1": &mockLookup{events: []cloudtrail.KafkaEvent{appEvent(ordersArn)}},
+		"eu-west-1": &cancellingLookup{cancel: cancel},
+	}
+	opts := testOpts(t, "us-east-1", "eu-west-1")
+
+	err := NewCloudTrailClientsScanner(lookups, nil, state, opts).Run(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	saved, err := types.NewStateFromFile(opts.StateFile)
+	require.NoError(t, err)
+	orders := saved.MSKSources.Regions[0].Clusters[0].CloudTrailActivity
+	require.NotNil(t, orders, "the finished region is saved")
+	assert.Len(t, orders.Principals, 1)
+	assert.Nil(t, saved.MSKSources.Regions[1].Clusters[0].CloudTrailActivity, "the interrupted region is left untouched")
+}
@@ -0,0 +1,240 @@
+package cloudtrail_clients
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/athena"
+	"github.com/confluentinc/kcp/internal/services/cloudtrail"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/notify"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// maxLookupDays is how far back CloudTrail event history goes.
+const maxLookupDays = 90
+
+var (
+	stateFile            string
+	regions              []string
+	days                 int
+	athenaTable          string
+	athenaWorkGroup      string
+	athenaOutputLocation string
+	athenaRegion         string
+	uploadTo             string
+	bundle               bool
+	notifyOpts           notify.Options
+)
+
+func cloudTrailClientsIAMAnnotation() string {
+	return iampolicy.Render("",
+		[]string{"cloudtrail:LookupEvents"},
+		[]iampolicy.Variant{{
+			FlagHint:  "--athena-table",
+			Summary:   "Queries the trail's logs with Athena instead of the event history, so `cloudtrail:LookupEvents` is not needed. Also grant read access to the trail's S3 bucket and write access to the query result location.",
+			Additions: []string{"athena:StartQueryExecution", "athena:GetQueryExecution", "athena:GetQueryResults", "glue:GetDatabase", "glue:GetTable"},
+		}},
+	)
+}
+
+func NewScanCloudTrailClientsCmd() *cobra.Command {
+	cloudTrailClientsCmd := &cobra.Command{
+		Use:   "cloudtrail-clients",
+		Short: "Scan CloudTrail for the IAM principals using each MSK cluster",
+		Long: `Scan CloudTrail for MSK API calls, such as the GetBootstrapBrokers call clients make to find the brokers, to identify the IAM principals and source IPs that actually use each discovered cluster.
+
+By default each region's CloudTrail event history is read, which needs no trail but only holds the last 90 days of management events. With ` + "`--athena-table`" + `, an Athena table over a trail's logs is queried instead. This also finds kafka-cluster data events, the Connect, ReadData and WriteData calls of IAM-authenticated clients, if the trail logs MSK data events.
+
+Assumed-role sessions are attributed to their role. The activity of every cluster in the scanned regions is replaced on each run, and a cluster no principal called is recorded with no principals. Calls made by ` + "`kcp discover`" + ` itself show up too.`,
+		Example: `  # Read the last 7 days of event history in every region of the state file
+  kcp scan cloudtrail-clients --state-file kcp-state.json
+
+  # Query 30 days of a trail's logs with Athena
+  kcp scan cloudtrail-clients \
+      --state-file kcp-state.json \
+      --days 30 \
+      --athena-table default.cloudtrail_logs \
+      --athena-output-location s3://my-athena-results/kcp/`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: cloudTrailClientsIAMAnnotation(),
+		},
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunScanCloudTrailClients,
+		RunE:          notify.WrapRunE(&notifyOpts, runScanCloudTrailClients),
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the CloudTrail client activity will be written to.")
+	cloudTrailClientsCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringSliceVar(&regions, "region", []string{}, "The regions to scan (comma separated list or repeated flag). Defaults to every region in the state file.")
+	optionalFlags.IntVar(&days, "days", 7, "How many days back to look for calls. At most 90 without --athena-table.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
+	cloudTrailClientsCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	athenaFlags := pflag.NewFlagSet("athena", pflag.ExitOnError)
+	athenaFlags.SortFlags = false
+	athenaFlags.StringVar(&athenaTable, "athena-table", "", "The Athena table over a CloudTrail trail's logs, as table or database.table.")
+	athenaFlags.StringVar(&athenaWorkGroup, "athena-workgroup", "", "The Athena workgroup to run the query in. Defaults to the primary workgroup.")
+	athenaFlags.StringVar(&athenaOutputLocation, "athena-output-location", "", "The S3 location query results are written to (s3://bucket/prefix). Optional when the workgroup sets one.")
+	athenaFlags.StringVar(&athenaRegion, "athena-region", "", "The region of the Athena table. Defaults to the first region scanned.")
+	cloudTrailClientsCmd.Flags().AddFlagSet(athenaFlags)
+	groups[athenaFlags] = "Athena Flags"
+
+	cloudTrailClientsCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, athenaFlags}
+		groupNames := []string{"Required Flags", "Optional Flags", "Athena Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = cloudTrailClientsCmd.MarkFlagRequired("state-file")
+
+	return cloudTrailClientsCmd
+}
+
+func preRunScanCloudTrailClients(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	if athenaTable == "" {
+		if days > maxLookupDays {
+			return fmt.Errorf("--days can be at most %d without --athena-table, as CloudTrail event history only goes back %d days", maxLookupDays, maxLookupDays)
+		}
+		for _, name := range []string{"athena-workgroup", "athena-output-location", "athena-region"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s requires --athena-table", name)
+			}
+		}
+	}
+	if athenaOutputLocation != "" && !strings.HasPrefix(athenaOutputLocation, "s3://") {
+		return fmt.Errorf("--athena-output-location must be an s3:// location")
+	}
+
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runScanCloudTrailClients(cmd *cobra.Command, args []string) error {
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	opts, err := parseScanCloudTrailClientsOpts(state, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	lookups := make(map[string]EventLookup)
+	var query EventQuery
+	if opts.Athena != nil {
+		region := athenaRegion
+		if region == "" {
+			region = opts.Regions[0]
+		}
+		athenaClient, err := client.NewAthenaClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create Athena client: %w", err)
+		}
+		query = cloudtrail.NewAthenaService(athena.NewAthenaService(athenaClient))
+	} else {
+		for _, region := range opts.Regions {
+			cloudTrailClient, err := client.NewCloudTrailClient(region)
+			if err != nil {
+				return fmt.Errorf("failed to create CloudTrail client for region %s: %w", region, err)
+			}
+			lookups[region] = cloudtrail.NewCloudTrailService(cloudTrailClient)
+		}
+	}
+
+	scanner := NewCloudTrailClientsScanner(lookups, query, state, *opts)
+	if err := scanner.Run(cmd.Context()); err != nil {
+		return err
+	}
+
+	if err := sink.DeliverArtifacts(cmd.Context(), uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
+	return nil
+}
+
+// parseScanCloudTrailClientsOpts scopes the scan to the --region regions, or
+// every region in the state file, looking --days back from now.
+func parseScanCloudTrailClientsOpts(state *types.State, now time.Time) (*CloudTrailClientsScannerOpts, error) {
+	if state.MSKSources == nil || len(state.MSKSources.Regions) == 0 {
+		return nil, fmt.Errorf("no MSK regions found in state file %s, run `kcp discover` first", stateFile)
+	}
+
+	var known []string
+	for _, region := range state.MSKSources.Regions {
+		known = append(known, region.Name)
+	}
+	scanned := known
+	if len(regions) > 0 {
+		for _, region := range regions {
+			if !slices.Contains(known, region) {
+				return nil, fmt.Errorf("region %s not found in state file %s", region, stateFile)
+			}
+		}
+		scanned = regions
+	}
+
+	opts := CloudTrailClientsScannerOpts{
+		StateFile: stateFile,
+		Regions:   scanned,
+		StartTime: now.AddDate(0, 0, -days),
+		EndTime:   now,
+	}
+	if athenaTable != "" {
+		opts.Athena = &cloudtrail.AthenaQueryOpts{
+			Table: athenaTable,
+			QueryOpts: athena.QueryOpts{
+				WorkGroup:      athenaWorkGroup,
+				OutputLocation: athenaOutputLocation,
+			},
+		}
+	}
+	return &opts, nil
+}
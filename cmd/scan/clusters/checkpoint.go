@@ -0,0 +1,113 @@
+package clusters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+const checkpointFileName = ".kcp-scan-checkpoint.json"
+
+// scanCheckpoint records which clusters an interrupted scan already saved to
+// the state file, and the options it ran with. A checkpoint is only resumed
+// by a scan with identical options, otherwise the skipped clusters would hold
+// differently scanned data.
+type scanCheckpoint struct {
+	SourceType            string   `json:"source_type"`
+	CredentialsFile       string   `json:"credentials_file"`
+	SkipTopics            bool     `json:"skip_topics"`
+	SkipACLs              bool     `json:"skip_acls"`
+	SampleMessages        int      `json:"sample_messages"`
+	AuthType              string   `json:"auth_type,omitempty"`
+	InspectTLS            bool     `json:"inspect_tls,omitempty"`
+	Plugins               []string `json:"plugins,omitempty"`
+	SSMBastionInstanceID  string   `json:"ssm_bastion_instance_id,omitempty"`
+	KafkaVersion          string   `json:"kafka_version,omitempty"`
+	InsecureSkipTLSVerify bool     `json:"insecure_skip_tls_verify,omitempty"`
+	MetricsSource         string   `json:"metrics_source,omitempty"`
+	MetricsDuration       string   `json:"metrics_duration,omitempty"`
+	MetricsRange          string   `json:"metrics_range,omitempty"`
+	Scanned               []string `json:"scanned,omitempty"`
+}
+
+// checkpointPath keeps the checkpoint next to the state file it belongs to.
+func checkpointPath(stateFile string) string {
+	return filepath.Join(filepath.Dir(stateFile), checkpointFileName)
+}
+
+// sameOptions reports whether two checkpoints were written by scans with the
+// same options.
+func (cp scanCheckpoint) sameOptions(other scanCheckpoint) bool {
+	return cp.SourceType == other.SourceType &&
+		cp.CredentialsFile == other.CredentialsFile &&
+		cp.SkipTopics == other.SkipTopics &&
+		cp.SkipACLs == other.SkipACLs &&
+		cp.SampleMessages == other.SampleMessages &&
+		cp.AuthType == other.AuthType &&
+		cp.InspectTLS == other.InspectTLS &&
+		slices.Equal(cp.Plugins, other.Plugins) &&
+		cp.SSMBastionInstanceID == other.SSMBastionInstanceID &&
+		cp.KafkaVersion == other.KafkaVersion &&
+		cp.InsecureSkipTLSVerify == other.InsecureSkipTLSVerify &&
+		cp.MetricsSource == other.MetricsSource &&
+		cp.MetricsDuration == other.MetricsDuration &&
+		cp.MetricsRange == other.MetricsRange
+}
+
+// loadScanCheckpoint returns the clusters a previous, interrupted scan with
+// the same options saved, keyed by cluster ID. A missing, unreadable or
+// mismatched checkpoint means a full scan.
+func loadScanCheckpoint(path string, current scanCheckpoint) map[string]bool {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("⚠️  No checkpoint found in %s, running a full scan\n", path)
+		return nil
+	}
+	var previous scanCheckpoint
+	if err == nil {
+		err = json.Unmarshal(data, &previous)
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Ignoring unreadable checkpoint %s, running a full scan\n", path)
+		slog.Debug("failed to read scan checkpoint", "path", path, "error", err)
+		return nil
+	}
+	if !previous.sameOptions(current) {
+		fmt.Printf("⚠️  Checkpoint %s was written with different scan options, running a full scan\n", path)
+		return nil
+	}
+
+	fmt.Printf("🔍 Resuming scan from checkpoint %s: skipping %d cluster(s) already scanned\n", path, len(previous.Scanned))
+	scanned := make(map[string]bool, len(previous.Scanned))
+	for _, id := range previous.Scanned {
+		scanned[id] = true
+	}
+	return scanned
+}
+
+// writeScanCheckpoint records the scanned clusters, replacing the checkpoint
+// atomically so a second interruption never leaves a truncated one.
+func writeScanCheckpoint(path string, cp scanCheckpoint, scanned map[string]bool) error {
+	cp.Scanned = make([]string, 0, len(scanned))
+	for id := range scanned {
+		cp.Scanned = append(cp.Scanned, id)
+	}
+	sort.Strings(cp.Scanned)
+
+	return utils.WriteCheckpointFile(path, cp)
+}
+
+func clearScanCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove scan checkpoint: %w", err)
+	}
+	return nil
+}
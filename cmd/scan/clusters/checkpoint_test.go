@@ -0,0 +1,59 @@
+package clusters
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanCheckpoint_RoundTrip(t *testing.T) {
+	path := checkpointPath(filepath.Join(t.TempDir(), "kcp-state.json"))
+	options := scanCheckpoint{SourceType: "msk", CredentialsFile: "/tmp/msk-credentials.yaml", SampleMessages: 5}
+
+	require.NoError(t, writeScanCheckpoint(path, options, map[string]bool{"arn:b": true, "arn:a": true}))
+
+	scanned := loadScanCheckpoint(path, options)
+	assert.Equal(t, map[string]bool{"arn:a": true, "arn:b": true}, scanned)
+
+	require.NoError(t, clearScanCheckpoint(path))
+	assert.Nil(t, loadScanCheckpoint(path, options), "a cleared checkpoint means a full scan")
+	assert.NoError(t, clearScanCheckpoint(path), "clearing a missing checkpoint is not an error")
+}
+
+func TestScanCheckpoint_DifferentOptionsRunFullScan(t *testing.T) {
+	path := checkpointPath(filepath.Join(t.TempDir(), "kcp-state.json"))
+	options := scanCheckpoint{SourceType: "msk", CredentialsFile: "/tmp/msk-credentials.yaml"}
+	require.NoError(t, writeScanCheckpoint(path, options, map[string]bool{"arn:a": true}))
+
+	options.SkipTopics = true
+	assert.Nil(t, loadScanCheckpoint(path, options))
+}
+
+func TestScanCheckpoint_ConnectionOptionsMustMatch(t *testing.T) {
+	options := scanCheckpoint{SourceType: "osk", CredentialsFile: "/tmp/osk-credentials.yaml", Plugins: []string{"owners=./owners.sh"}, KafkaVersion: "3.6.0"}
+
+	tests := []struct {
+		name   string
+		change func(cp *scanCheckpoint)
+	}{
+		{"plugin", func(cp *scanCheckpoint) { cp.Plugins = []string{"owners=./other.sh"} }},
+		{"kafka version", func(cp *scanCheckpoint) { cp.KafkaVersion = "2.8.0" }},
+		{"insecure skip verify", func(cp *scanCheckpoint) { cp.InsecureSkipTLSVerify = true }},
+		{"ssm bastion", func(cp *scanCheckpoint) { cp.SSMBastionInstanceID = "i-0123456789abcdef0" }},
+		{"inspect tls", func(cp *scanCheckpoint) { cp.InspectTLS = true }},
+		{"metrics", func(cp *scanCheckpoint) { cp.MetricsSource = "jolokia" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := checkpointPath(filepath.Join(t.TempDir(), "kcp-state.json"))
+			require.NoError(t, writeScanCheckpoint(path, options, map[string]bool{"cluster-a": true}))
+
+			changed := options
+			tt.change(&changed)
+			assert.Nil(t, loadScanCheckpoint(path, changed))
+			assert.NotNil(t, loadScanCheckpoint(path, options))
+		})
+	}
+}
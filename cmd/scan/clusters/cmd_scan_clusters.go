@@ -2,20 +2,30 @@ package clusters
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/confluentinc/kcp/internal/build_info"
 	"github.com/confluentinc/kcp/internal/client"
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
 	jmx "github.com/confluentinc/kcp/internal/services/jmx"
+	kafkaservice "github.com/confluentinc/kcp/internal/services/kafka"
+	"github.com/confluentinc/kcp/internal/services/metricshistory"
+	"github.com/confluentinc/kcp/internal/services/notify"
 	prometheussvc "github.com/confluentinc/kcp/internal/services/prometheus"
+	"github.com/confluentinc/kcp/internal/services/scanplugin"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/services/ssmtunnel"
+	"github.com/confluentinc/kcp/internal/services/tlsinspect"
 	"github.com/confluentinc/kcp/internal/sources"
 	"github.com/confluentinc/kcp/internal/sources/msk"
 	"github.com/confluentinc/kcp/internal/sources/osk"
+	"github.com/confluentinc/kcp/internal/summary"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 
@@ -23,16 +33,30 @@ import (
 	"github.com/spf13/pflag"
 )
 
+const authTypeAuto = "auto"
+
 var (
 	stateFile       string
 	credentialsFile string
 	sourceType      string
 	skipTopics      bool
 	skipACLs        bool
+	authType        string
+	ssmBastion      string
 	metricsSource   string
 	metricsDuration string
 	metricsInterval string
 	metricsRange    string
+	uploadTo        string
+	bundle          bool
+	inspectTLS      bool
+	failOnUnhealthy bool
+	resume          bool
+	sampleMessages  int
+	pluginFlags     []string
+	plugins         []scanplugin.Plugin
+	notifyOpts      notify.Options
+	connection      client.ConnectionSettings
 )
 
 func scanClustersIAMAnnotation() string {
@@ -64,9 +88,15 @@ func NewScanClustersCmd() *cobra.Command {
 
 Source-specific notes:
 
-- ` + "`--source-type msk`" + ` reads cluster connection details from the ` + "`msk-credentials.yaml`" + ` file produced by ` + "`kcp discover`" + `. SCRAM is forced to SHA-512 (the only mechanism MSK supports).
+- ` + "`--source-type msk`" + ` reads cluster connection details from the ` + "`msk-credentials.yaml`" + ` file produced by ` + "`kcp discover`" + `. SCRAM is forced to SHA-512 (the only mechanism MSK supports). With ` + "`--auth-type auto`" + `, every method discover wrote to the file (the methods the cluster advertises) is tried in turn — IAM, SASL/SCRAM, TLS, then unauthenticated — and the one that connected is recorded as ` + "`auth_type`" + ` in the state file. With ` + "`--ssm-bastion-instance-id`" + `, broker connections go through Session Manager port forwarding on that instance, which must be in the cluster's region, reach the brokers and run the SSM agent; the ` + "`session-manager-plugin`" + ` must be installed locally.
+- ` + "`--inspect-tls`" + ` also opens a TLS connection to every broker endpoint and records its DNS resolution and certificate chain (subjects, SANs, expiry) in the state file. Chains that don't verify against the system roots are flagged as issued by a private CA; ` + "`kcp create-asset migration-infra`" + ` adds that CA to the SASL/SCRAM jump cluster trust store.
+- ` + "`--sample-messages`" + ` reads up to that many of each topic's most recent messages (at most 1000, for at most 10 seconds per topic) to infer how values are serialized: Confluent Schema Registry framing with its schema IDs (JSON Schema, or Avro/Protobuf — look the IDs up in the registry to tell those apart), AWS Glue Schema Registry framing with its schema version IDs, Avro container files, plain JSON, text or other binary. Partitions are read directly, without a consumer group or committed offsets, and message contents are not stored — only the format counts and schema IDs, under each topic's ` + "`serialization`" + `. Internal topics (` + "`__*`" + `) are skipped.
+- Every scan checks partition health: the under-replicated and offline partitions and the partition reassignments in flight, recorded under ` + "`kafka_admin_client_information.partition_health`" + `. Mirroring from a degraded cluster yields misleading lag signals, so unhealthy clusters are warned about at the end of the scan; ` + "`--fail-on-unhealthy`" + ` fails the scan instead, for pipelines that should stop there. MSK Serverless clusters are not checked.
+- ` + "`--plugin`" + ` runs an external scanner against each scanned cluster, for organisation-specific checks. The plugin is any executable: kcp writes the cluster context (source type, name, ID, bootstrap servers and the scanned topics, ACLs and consumer groups — never credentials) to its stdin as a JSON object, and it must write a JSON object to stdout within 2 minutes. The output is recorded under ` + "`kafka_admin_client_information.plugins.<name>`" + `, where the name is the executable's file name without its extension, or set it with ` + "`--plugin name=path`" + `. A failing plugin is reported and skipped without failing the scan.
 - ` + "`--source-type apache-kafka`" + ` reads from a hand-authored ` + "`apache-kafka-credentials.yaml`" + ` file. SASL/SCRAM defaults to SHA-256 — set ` + "`auth_method.sasl_scram.mechanism: SHA512`" + ` if your cluster requires SHA-512. The full schema and worked examples are documented at [Apache Kafka configuration → Credentials](../../apache-kafka-configuration/credentials.md).
 
+Interrupting a scan with Ctrl-C (or SIGTERM, or when ` + "`--timeout`" + ` elapses) stops it after the cluster being scanned: the clusters it finished are saved to the state file and listed in a checkpoint (` + "`" + checkpointFileName + "`" + ` next to the state file), and ` + "`--resume`" + ` with the same flags scans only the rest. TLS inspection, plugins and metrics collection are skipped for an interrupted scan.
+
 Metrics collection (Apache Kafka only):
 
 - ` + "`--metrics jolokia`" + ` polls each broker's Jolokia HTTP endpoint live for the duration set by ` + "`--metrics-duration`" + ` (interval: ` + "`--metrics-interval`" + `, default 10s).
@@ -76,9 +106,35 @@ Both backends produce the same metric shape and feed reports and the UI. See [Ap
 		Example: `  # Scan an MSK cluster (credentials from kcp discover)
   kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml
 
+  # Scan an MSK cluster with whichever advertised auth method connects first
+  kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml --auth-type auto
+
+  # Scan an MSK cluster behind PrivateLink from a laptop, through a bastion in the cluster VPC
+  kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml --ssm-bastion-instance-id i-0abc123def4567890
+
   # Scan an Apache Kafka cluster (hand-authored credentials)
   kcp scan clusters --source-type apache-kafka --state-file kcp-state.json --credentials-file apache-kafka-credentials.yaml
 
+  # Also record each broker's certificate chain and flag private CAs and expiring certificates
+  kcp scan clusters --source-type apache-kafka --state-file kcp-state.json \
+      --credentials-file apache-kafka-credentials.yaml --inspect-tls
+
+  # Infer each topic's serialization format and schema IDs from its 20 most recent messages
+  kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml --sample-messages 20
+
+  # Run an in-house scanner and record its findings under plugins.naming-policy
+  kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml \
+      --plugin ./naming-policy.sh
+
+  # Fail fast against unhealthy brokers and pin the protocol version
+  kcp scan clusters --source-type apache-kafka --state-file kcp-state.json \
+      --credentials-file apache-kafka-credentials.yaml \
+      --kafka-dial-timeout 5s --kafka-read-timeout 10s --kafka-version 2.8.1
+
+  # Give up after two hours, then pick up where the scan stopped
+  kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml --timeout 2h
+  kcp scan clusters --source-type msk --state-file kcp-state.json --credentials-file msk-credentials.yaml --resume
+
   # Apache Kafka with live Jolokia metric collection
   kcp scan clusters --source-type apache-kafka --state-file kcp-state.json \
       --credentials-file apache-kafka-credentials.yaml \
@@ -94,7 +150,7 @@ Both backends produce the same metric shape and feed reports and the UI. See [Ap
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 		PreRunE:       preRunScanClusters,
-		RunE:          runScanClusters,
+		RunE:          notify.WrapRunE(&notifyOpts, runScanClusters),
 	}
 
 	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
@@ -108,6 +164,17 @@ Both backends produce the same metric shape and feed reports and the UI. See [Ap
 	optionalFlags.SortFlags = false
 	optionalFlags.BoolVar(&skipTopics, "skip-topics", false, "Skip topic discovery")
 	optionalFlags.BoolVar(&skipACLs, "skip-acls", false, "Skip ACL discovery")
+	optionalFlags.StringVar(&authType, "auth-type", "", "Set to 'auto' to try each auth method in the credentials file in turn (IAM, SASL/SCRAM, TLS, then unauthenticated) and scan with the first that connects, instead of the one marked 'use: true' (MSK only)")
+	optionalFlags.StringVar(&ssmBastion, "ssm-bastion-instance-id", "", "Connect to the brokers through SSM Session Manager port forwarding on this EC2 instance, for clusters without direct VPC connectivity (MSK only). Requires the session-manager-plugin and ssm:StartSession and ssm:TerminateSession.")
+	optionalFlags.IntVar(&sampleMessages, "sample-messages", 0, "Read up to this many of each topic's most recent messages (max 1000) to infer its serialization format and schema IDs. Messages are not stored. Requires kafka-cluster:ReadData for MSK IAM auth, or Read on the topics.")
+	optionalFlags.BoolVar(&inspectTLS, "inspect-tls", false, "Connect to each broker endpoint over TLS and record its certificate chain, expiry and SANs, flagging private CAs and certificates expiring within 30 days. Not supported with --ssm-bastion-instance-id.")
+	optionalFlags.BoolVar(&failOnUnhealthy, "fail-on-unhealthy", false, "Fail the scan, after saving the state file, when a cluster has under-replicated or offline partitions or partition reassignments in flight. Without it they are only warned about.")
+	optionalFlags.BoolVar(&resume, "resume", false, "Resume an interrupted scan from its checkpoint ("+checkpointFileName+" next to the state file), scanning only the clusters it did not finish. Requires the same flags as the original run.")
+	optionalFlags.StringArrayVar(&pluginFlags, "plugin", []string{}, "Run this executable against each scanned cluster and record the JSON object it writes to stdout in the state file, as path or name=path (repeatable). It receives the cluster context as JSON on stdin.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
 	scanClustersCmd.Flags().AddFlagSet(optionalFlags)
 
 	metricsFlags := pflag.NewFlagSet("metrics", pflag.ExitOnError)
@@ -118,6 +185,14 @@ Both backends produce the same metric shape and feed reports and the UI. See [Ap
 	metricsFlags.StringVar(&metricsRange, "metrics-range", "", "Day range to query from Prometheus (e.g. 7d, 30d). Required with --metrics prometheus.")
 	scanClustersCmd.Flags().AddFlagSet(metricsFlags)
 
+	kafkaClientFlags := pflag.NewFlagSet("kafka-client", pflag.ExitOnError)
+	kafkaClientFlags.SortFlags = false
+	kafkaClientFlags.DurationVar(&connection.DialTimeout, "kafka-dial-timeout", 10*time.Second, "How long to wait for a broker connection before giving up. Lower it to fail fast against unreachable brokers.")
+	kafkaClientFlags.DurationVar(&connection.ReadTimeout, "kafka-read-timeout", 30*time.Second, "How long to wait for a broker response before giving up. Lower it to fail fast against unresponsive brokers.")
+	kafkaClientFlags.StringVar(&connection.KafkaVersion, "kafka-version", "", "Kafka protocol version to use (e.g. 2.8.1), for brokers that reject the API versions kcp picks. Default: the MSK cluster's version, or 3.6.0 for Apache Kafka.")
+	kafkaClientFlags.BoolVar(&connection.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification for Kafka connections, e.g. for brokers with certificates from a private CA (Apache Kafka only).")
+	scanClustersCmd.Flags().AddFlagSet(kafkaClientFlags)
+
 	_ = scanClustersCmd.MarkFlagRequired("source-type")
 	_ = scanClustersCmd.MarkFlagRequired("credentials-file")
 
@@ -129,6 +204,13 @@ func preRunScanClusters(cmd *cobra.Command, args []string) error {
 	if err := utils.BindEnvToFlags(cmd); err != nil {
 		return err
 	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
 
 	// Validate and normalize source type. "apache-kafka" is the user-facing value;
 	// internally the source is represented by the "osk" token.
@@ -138,6 +220,46 @@ func preRunScanClusters(cmd *cobra.Command, args []string) error {
 	}
 	sourceType = string(normalizedSourceType)
 
+	if authType != "" {
+		if authType != authTypeAuto {
+			return fmt.Errorf("invalid --auth-type '%s': must be '%s'", authType, authTypeAuto)
+		}
+		if sourceType != "msk" {
+			return fmt.Errorf("--auth-type auto is only supported for MSK sources (--source-type msk)")
+		}
+	}
+
+	if err := connection.Validate(); err != nil {
+		return err
+	}
+	if connection.InsecureSkipTLSVerify && sourceType != "osk" {
+		return fmt.Errorf("--insecure-skip-tls-verify is only supported for Apache Kafka sources (--source-type apache-kafka); MSK brokers use AWS-managed certificates")
+	}
+
+	if ssmBastion != "" {
+		if sourceType != "msk" {
+			return fmt.Errorf("--ssm-bastion-instance-id is only supported for MSK sources (--source-type msk)")
+		}
+		if inspectTLS {
+			return fmt.Errorf("--inspect-tls cannot be used with --ssm-bastion-instance-id: the brokers are only reachable through the tunnel")
+		}
+		if err := ssmtunnel.CheckPlugin(); err != nil {
+			return err
+		}
+	}
+
+	if sampleMessages < 0 || sampleMessages > kafkaservice.MaxSampleMessages {
+		return fmt.Errorf("invalid --sample-messages %d: must be between 0 and %d", sampleMessages, kafkaservice.MaxSampleMessages)
+	}
+	if sampleMessages > 0 && skipTopics {
+		return fmt.Errorf("--sample-messages cannot be used with --skip-topics")
+	}
+
+	plugins, err = scanplugin.ParsePlugins(pluginFlags)
+	if err != nil {
+		return err
+	}
+
 	// Validate credentials file naming convention
 	if sourceType == "msk" && filepath.Base(credentialsFile) != "msk-credentials.yaml" {
 		slog.Warn("credentials file should be named 'msk-credentials.yaml' for MSK sources", "file", credentialsFile)
@@ -192,10 +314,10 @@ func preRunScanClusters(cmd *cobra.Command, args []string) error {
 }
 
 func runScanClusters(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load or create state file
-	state, err := loadOrCreateState(stateFile)
+	state, err := LoadOrCreateState(stateFile)
 	if err != nil {
 		return fmt.Errorf("failed to load state file: %w", err)
 	}
@@ -230,11 +352,41 @@ func runScanClusters(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\nℹ️  Apache Kafka credentials file format & metrics options: %sapache-kafka-configuration/\n", build_info.DocsURL())
 	}
 
+	checkpointFile := checkpointPath(stateFile)
+	checkpoint := scanCheckpoint{
+		SourceType:            sourceType,
+		CredentialsFile:       credentialsFile,
+		SkipTopics:            skipTopics,
+		SkipACLs:              skipACLs,
+		SampleMessages:        sampleMessages,
+		AuthType:              authType,
+		InspectTLS:            inspectTLS,
+		Plugins:               pluginFlags,
+		SSMBastionInstanceID:  ssmBastion,
+		KafkaVersion:          connection.KafkaVersion,
+		InsecureSkipTLSVerify: connection.InsecureSkipTLSVerify,
+		MetricsSource:         metricsSource,
+		MetricsDuration:       metricsDuration,
+		MetricsRange:          metricsRange,
+	}
+	if abs, err := filepath.Abs(credentialsFile); err == nil {
+		checkpoint.CredentialsFile = abs
+	}
+	var alreadyScanned map[string]bool
+	if resume {
+		alreadyScanned = loadScanCheckpoint(checkpointFile, checkpoint)
+	}
+
 	// Perform scan
 	scanOpts := sources.ScanOptions{
-		SkipTopics: skipTopics,
-		SkipACLs:   skipACLs,
-		State:      state,
+		SkipTopics:           skipTopics,
+		SkipACLs:             skipACLs,
+		SampleMessages:       sampleMessages,
+		AutoAuth:             authType == authTypeAuto,
+		State:                state,
+		SSMBastionInstanceID: ssmBastion,
+		Connection:           connection,
+		SkipClusters:         alreadyScanned,
 	}
 
 	slog.Info("starting cluster scan", "source", sourceType)
@@ -243,13 +395,27 @@ func runScanClusters(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
+	summary.Count("clusters_scanned", len(scanResult.Clusters))
+
+	// An interrupted scan saves what it finished and stops; the follow-up
+	// steps would only fail on the cancelled context.
+	interrupted := ctx.Err() != nil
+
+	if inspectTLS && !interrupted {
+		inspectBrokerTLS(ctx, scanResult)
+	}
+
+	if len(plugins) > 0 && !interrupted {
+		runScanPlugins(ctx, plugins, scanResult)
+	}
+
 	// Merge scan results into state
-	if err := mergeResultsIntoState(state, scanResult); err != nil {
+	if err := sources.MergeResultsIntoState(state, scanResult); err != nil {
 		return fmt.Errorf("failed to merge scan results: %w", err)
 	}
 
 	// Collect metrics if enabled
-	if metricsSource != "" && sourceType == "osk" {
+	if metricsSource != "" && sourceType == "osk" && !interrupted {
 		if err := collectMetrics(ctx, state, credentialsFile); err != nil {
 			slog.Warn("metrics collection failed", "error", err)
 			fmt.Printf("\n⚠️  Metrics collection failed: %v\n", err)
@@ -260,136 +426,188 @@ func runScanClusters(cmd *cobra.Command, args []string) error {
 	if err := state.PersistStateFile(stateFile); err != nil {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
+	// Keep the metrics beside the state file for the reports' trend sections.
+	if added, err := metricshistory.Record(metricshistory.PathFor(stateFile), state); err != nil {
+		slog.Warn("⚠️ failed to record metrics history", "error", err)
+	} else if added > 0 {
+		slog.Debug("recorded metrics history", "snapshots", added)
+	}
+
+	if interrupted {
+		return saveInterruptedScan(ctx, checkpointFile, checkpoint, alreadyScanned, clusters, scanResult)
+	}
+	if err := clearScanCheckpoint(checkpointFile); err != nil {
+		slog.Warn("⚠️ failed to remove scan checkpoint", "error", err)
+	}
 
 	slog.Info("scan completed successfully", "clusters", len(scanResult.Clusters), "state_file", stateFile)
 	fmt.Printf("\n✅ Scan completed successfully\n")
 	fmt.Printf("   Scanned %d cluster(s)\n", len(scanResult.Clusters))
+	if scanOpts.AutoAuth {
+		for _, cluster := range scanResult.Clusters {
+			fmt.Printf("   %s: connected using %s\n", cluster.Identifier.Name, cluster.KafkaAdminInfo.AuthType)
+		}
+	}
+	for _, cluster := range scanResult.Clusters {
+		if om := cluster.KafkaAdminInfo.OpenMonitoring; om != nil && !om.Enabled() {
+			fmt.Printf("   %s: Open Monitoring exporters are off, see `kcp report open-monitoring` to watch the migration\n", cluster.Identifier.Name)
+		}
+	}
 	fmt.Printf("   State file: %s\n\n", stateFile)
 
+	if err := sink.DeliverArtifacts(ctx, uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
+	unhealthy := reportPartitionHealth(scanResult)
+	if len(unhealthy) > 0 && failOnUnhealthy {
+		return fmt.Errorf("%d cluster(s) have unhealthy partitions: %s", len(unhealthy), strings.Join(unhealthy, ", "))
+	}
+
 	return nil
 }
 
-// loadOrCreateState loads existing state or creates a new one.
-// Only creates a new state when the file does not exist — all other errors
-// (corrupt JSON, permission denied, etc.) are returned to the caller to
-// avoid silently discarding an existing state file.
-func loadOrCreateState(stateFilePath string) (*types.State, error) {
-	if _, err := os.Stat(stateFilePath); os.IsNotExist(err) {
-		slog.Debug("creating new state file", "file", stateFilePath)
-		state := types.NewStateFrom(nil)
-		state.SchemaRegistries = &types.SchemaRegistriesState{}
-		return state, nil
+// saveInterruptedScan records the clusters scanned so far, including those a
+// resumed scan skipped, in the checkpoint and fails the command with the
+// clusters left to scan.
+func saveInterruptedScan(ctx context.Context, checkpointFile string, checkpoint scanCheckpoint, alreadyScanned map[string]bool, clusters []sources.ClusterIdentifier, result *sources.ScanResult) error {
+	scanned := make(map[string]bool, len(alreadyScanned)+len(result.Clusters))
+	for id := range alreadyScanned {
+		scanned[id] = true
+	}
+	for _, c := range result.Clusters {
+		scanned[c.Identifier.UniqueID] = true
+	}
+	var remaining []string
+	for _, c := range clusters {
+		if !scanned[c.UniqueID] {
+			remaining = append(remaining, c.Name)
+		}
 	}
 
-	state, err := types.NewStateFromFile(stateFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load state file: %w", err)
+	if err := writeScanCheckpoint(checkpointFile, checkpoint, scanned); err != nil {
+		slog.Warn("⚠️ failed to write scan checkpoint; this scan cannot be resumed", "error", err)
+	} else {
+		fmt.Printf("\n⚠️  Scan interrupted: saved %d cluster(s) to %s; re-run with --resume to scan the other %d\n", len(result.Clusters), stateFile, len(remaining))
 	}
-	slog.Debug("loaded existing state file", "file", stateFilePath)
-	return state, nil
+	return fmt.Errorf("scan interrupted (%w) before scanning %d cluster(s): %s", context.Cause(ctx), len(remaining), strings.Join(remaining, ", "))
 }
 
-// mergeResultsIntoState merges scan results into the state file
-func mergeResultsIntoState(state *types.State, result *sources.ScanResult) error {
-	switch result.SourceType {
-	case types.SourceTypeMSK:
-		return mergeMSKResults(state, result)
-	case types.SourceTypeOSK:
-		return mergeOSKResults(state, result)
-	default:
-		return fmt.Errorf("unsupported source type: %s", result.SourceType)
+// reportPartitionHealth warns about each scanned cluster with under-replicated
+// or offline partitions or reassignments in flight, and returns their names.
+func reportPartitionHealth(result *sources.ScanResult) []string {
+	var unhealthy []string
+	for _, c := range result.Clusters {
+		if c.KafkaAdminInfo == nil || c.KafkaAdminInfo.PartitionHealth.Healthy() {
+			continue
+		}
+		if len(unhealthy) == 0 {
+			fmt.Printf("⚠️  Unhealthy source clusters — mirroring from them yields misleading lag signals, fix them before migrating:\n")
+		}
+		unhealthy = append(unhealthy, c.Identifier.Name)
+		fmt.Printf("   ⚠️  %s:\n", c.Identifier.Name)
+		for _, problem := range c.KafkaAdminInfo.PartitionHealth.Problems() {
+			slog.Warn("unhealthy cluster", "cluster", c.Identifier.Name, "problem", problem)
+			fmt.Printf("      - %s\n", problem)
+		}
 	}
-}
-
-// mergeMSKResults merges MSK scan results into state
-func mergeMSKResults(state *types.State, result *sources.ScanResult) error {
-	if state.MSKSources == nil {
-		return fmt.Errorf("no MSK sources in state; run 'kcp discover' before scanning MSK clusters")
+	if len(unhealthy) > 0 {
+		fmt.Println()
 	}
+	return unhealthy
+}
 
-	// Index scanned results by ARN for O(1) lookup
-	scannedByARN := make(map[string]*types.KafkaAdminClientInformation, len(result.Clusters))
+// inspectBrokerTLS records the TLS inspection of each scanned cluster's
+// brokers on its scan result, so it is merged into state with the rest of the
+// admin info. Brokers discovered from cluster metadata are preferred; the
+// bootstrap servers are the fallback when the scan didn't get that far.
+func inspectBrokerTLS(ctx context.Context, result *sources.ScanResult) {
+	inspector := tlsinspect.NewInspector()
+	fmt.Printf("\n🔍 Inspecting broker TLS endpoints...\n")
 	for i := range result.Clusters {
 		c := &result.Clusters[i]
-		scannedByARN[c.Identifier.UniqueID] = c.KafkaAdminInfo
-	}
-
-	// Apply results into state in-place. Merge old admin info into the new
-	// scan result before overwriting so previously-discovered data (topics,
-	// ACLs, self-managed connectors) is preserved when the new scan returns
-	// empty/nil for those fields. Mirrors the OSK merge path; ensures a
-	// re-run of `kcp scan clusters` does not wipe data already in state.
-	for i := range state.MSKSources.Regions {
-		for j := range state.MSKSources.Regions[i].Clusters {
-			cluster := &state.MSKSources.Regions[i].Clusters[j]
-			if info, ok := scannedByARN[cluster.Arn]; ok {
-				info.MergeFrom(cluster.KafkaAdminClientInformation)
-				cluster.KafkaAdminClientInformation = *info
-			}
+		if c.KafkaAdminInfo == nil {
+			continue
 		}
-	}
-
-	slog.Debug("merged MSK scan results", "clusters_scanned", len(result.Clusters))
-	return nil
-}
-
-// mergeOSKResults merges OSK scan results into state
-func mergeOSKResults(state *types.State, result *sources.ScanResult) error {
-	if state.OSKSources == nil {
-		state.OSKSources = &types.OSKSourcesState{
-			Clusters: []types.OSKDiscoveredCluster{},
+		addresses := c.KafkaAdminInfo.DiscoveredBrokers
+		if len(addresses) == 0 {
+			addresses = c.Identifier.BootstrapServers
+		}
+		if len(addresses) == 0 {
+			slog.Warn("no broker endpoints to inspect", "cluster", c.Identifier.Name)
+			continue
 		}
-	}
 
-	// Build index of existing clusters by ID for efficient lookup
-	existingIndex := make(map[string]int)
-	for i := range state.OSKSources.Clusters {
-		existingIndex[state.OSKSources.Clusters[i].ID] = i
+		c.KafkaAdminInfo.TLSInspection = inspector.Inspect(ctx, addresses)
+		warnings := tlsinspect.Warnings(c.KafkaAdminInfo.TLSInspection, time.Now())
+		if len(warnings) == 0 {
+			fmt.Printf("   ✅ %s: %d endpoint(s), no TLS findings\n", c.Identifier.Name, len(addresses))
+			continue
+		}
+		fmt.Printf("   ⚠️  %s: %d endpoint(s)\n", c.Identifier.Name, len(addresses))
+		for _, w := range warnings {
+			fmt.Printf("      - %s\n", w)
+		}
 	}
+}
 
-	// Separate scan results into updates and new clusters to avoid pointer
-	// invalidation: appending to the slice may reallocate the backing array,
-	// which would invalidate any pointers taken before the append.
-	var newClusters []types.OSKDiscoveredCluster
-
-	for _, clusterResult := range result.Clusters {
-		metadata, ok := clusterResult.SourceSpecificData.(types.OSKClusterMetadata)
-		if !ok {
-			return fmt.Errorf("invalid source-specific data for Apache Kafka cluster")
+// runScanPlugins runs each --plugin against each scanned cluster and records
+// its output on the scan result, so it is merged into state with the rest of
+// the admin info. A failing plugin is reported and skipped: org-specific
+// checks shouldn't cost the user the scan itself.
+func runScanPlugins(ctx context.Context, plugins []scanplugin.Plugin, result *sources.ScanResult) {
+	runner := scanplugin.NewRunner()
+	fmt.Printf("\n🔍 Running %d scan plugin(s)...\n", len(plugins))
+	for i := range result.Clusters {
+		c := &result.Clusters[i]
+		if c.KafkaAdminInfo == nil {
+			continue
 		}
-
-		newCluster := types.OSKDiscoveredCluster{
-			ID:                          clusterResult.Identifier.UniqueID,
-			BootstrapServers:            clusterResult.Identifier.BootstrapServers,
-			KafkaAdminClientInformation: *clusterResult.KafkaAdminInfo,
-			Metadata:                    metadata,
+		// Plugins see what kcp scanned, not each other's output.
+		info := *c.KafkaAdminInfo
+		info.Plugins = nil
+		input := scanplugin.ClusterContext{
+			SourceType:       string(result.SourceType),
+			Name:             c.Identifier.Name,
+			ID:               c.Identifier.UniqueID,
+			BootstrapServers: c.Identifier.BootstrapServers,
+			KafkaAdminInfo:   &info,
+			KCPVersion:       build_info.Version,
 		}
-
-		if idx, exists := existingIndex[newCluster.ID]; exists {
-			existing := state.OSKSources.Clusters[idx]
-
-			// Merge KafkaAdminClientInformation: preserve old topics/ACLs/connectors
-			// if the new scan returned empty results (e.g. transient permission failure)
-			newCluster.KafkaAdminClientInformation.MergeFrom(existing.KafkaAdminClientInformation)
-
-			// Preserve discovered clients and metrics from prior scans
-			newCluster.DiscoveredClients = existing.DiscoveredClients
-			if newCluster.ClusterMetrics == nil {
-				newCluster.ClusterMetrics = existing.ClusterMetrics
+		for _, plugin := range plugins {
+			output, err := runner.Run(ctx, plugin, input)
+			if err != nil {
+				slog.Warn("scan plugin failed", "plugin", plugin.Name, "cluster", c.Identifier.Name, "error", err)
+				fmt.Printf("   ⚠️  %s on %s: %v\n", plugin.Name, c.Identifier.Name, err)
+				continue
 			}
-
-			// Update in-place by index
-			state.OSKSources.Clusters[idx] = newCluster
-		} else {
-			newClusters = append(newClusters, newCluster)
+			if c.KafkaAdminInfo.Plugins == nil {
+				c.KafkaAdminInfo.Plugins = make(map[string]json.RawMessage, len(plugins))
+			}
+			c.KafkaAdminInfo.Plugins[plugin.Name] = output
+			fmt.Printf("   ✅ %s on %s\n", plugin.Name, c.Identifier.Name)
 		}
 	}
+}
 
-	// Append new clusters after all in-place updates are done
-	state.OSKSources.Clusters = append(state.OSKSources.Clusters, newClusters...)
+// LoadOrCreateState loads existing state or creates a new one.
+// Only creates a new state when the file does not exist — all other errors
+// (corrupt JSON, permission denied, etc.) are returned to the caller to
+// avoid silently discarding an existing state file.
+func LoadOrCreateState(stateFilePath string) (*types.State, error) {
+	if _, err := os.Stat(stateFilePath); os.IsNotExist(err) {
+		slog.Debug("creating new state file", "file", stateFilePath)
+		state := types.NewStateFrom(nil)
+		state.SchemaRegistries = &types.SchemaRegistriesState{}
+		return state, nil
+	}
 
-	slog.Debug("merged Apache Kafka scan results", "clusters", len(result.Clusters))
-	return nil
+	state, err := types.NewStateFromFile(stateFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state file: %w", err)
+	}
+	slog.Debug("loaded existing state file", "file", stateFilePath)
+	return state, nil
 }
 
 func collectMetrics(ctx context.Context, state *types.State, credentialsFilePath string) error {
@@ -0,0 +1,41 @@
+package clusters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/sources"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectBrokerTLS_FallsBackToBootstrapServersAndSurvivesMerge(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	broker := strings.TrimPrefix(srv.URL, "https://")
+
+	result := &sources.ScanResult{
+		SourceType: types.SourceTypeOSK,
+		Clusters: []sources.ClusterScanResult{
+			{
+				Identifier:         sources.ClusterIdentifier{Name: "orders", UniqueID: "orders", BootstrapServers: []string{broker}},
+				KafkaAdminInfo:     &types.KafkaAdminClientInformation{ClusterID: "abc"},
+				SourceSpecificData: types.OSKClusterMetadata{},
+			},
+		},
+	}
+
+	inspectBrokerTLS(context.Background(), result)
+
+	state := &types.State{}
+	require.NoError(t, sources.MergeResultsIntoState(state, result))
+	inspection := state.OSKSources.Clusters[0].KafkaAdminClientInformation.TLSInspection
+	require.NotNil(t, inspection)
+	require.Len(t, inspection.Endpoints, 1)
+	assert.Equal(t, broker, inspection.Endpoints[0].Address)
+	assert.True(t, inspection.Endpoints[0].PrivateCA)
+}
@@ -13,7 +13,7 @@ func TestLoadOrCreateState_NewFile_StampsVersion(t *testing.T) {
 	// Use a path that does not exist to trigger new state creation
 	path := t.TempDir() + "/kcp-state.json"
 
-	state, err := loadOrCreateState(path)
+	state, err := LoadOrCreateState(path)
 
 	require.NoError(t, err)
 	require.NotNil(t, state)
@@ -25,7 +25,7 @@ func TestLoadOrCreateState_NewFile_StampsVersion(t *testing.T) {
 func TestLoadOrCreateState_NewFile_InitialisesRequiredFields(t *testing.T) {
 	path := t.TempDir() + "/kcp-state.json"
 
-	state, err := loadOrCreateState(path)
+	state, err := LoadOrCreateState(path)
 
 	require.NoError(t, err)
 	require.NotNil(t, state.MSKSources, "MSKSources should be initialised")
@@ -45,7 +45,7 @@ func TestLoadOrCreateState_ExistingFile_LoadsState(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, tmpFile.Close())
 
-	state, err := loadOrCreateState(tmpFile.Name())
+	state, err := LoadOrCreateState(tmpFile.Name())
 
 	require.NoError(t, err)
 	require.NotNil(t, state)
@@ -61,7 +61,7 @@ func TestLoadOrCreateState_CorruptFile_ReturnsError(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, tmpFile.Close())
 
-	_, err = loadOrCreateState(tmpFile.Name())
+	_, err = LoadOrCreateState(tmpFile.Name())
 
 	assert.Error(t, err, "corrupt state file should return error")
 }
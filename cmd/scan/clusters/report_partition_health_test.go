@@ -0,0 +1,39 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/sources"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPartitionHealth_ReturnsUnhealthyClusters(t *testing.T) {
+	result := &sources.ScanResult{
+		SourceType: types.SourceTypeMSK,
+		Clusters: []sources.ClusterScanResult{
+			{
+				Identifier:     sources.ClusterIdentifier{Name: "orders"},
+				KafkaAdminInfo: &types.KafkaAdminClientInformation{PartitionHealth: &types.PartitionHealth{Partitions: 6}},
+			},
+			{
+				Identifier:     sources.ClusterIdentifier{Name: "payments"},
+				KafkaAdminInfo: &types.KafkaAdminClientInformation{PartitionHealth: &types.PartitionHealth{Partitions: 6, UnderReplicatedPartitions: 2}},
+			},
+			{
+				// Serverless clusters are not checked.
+				Identifier:     sources.ClusterIdentifier{Name: "serverless"},
+				KafkaAdminInfo: &types.KafkaAdminClientInformation{},
+			},
+			{
+				Identifier: sources.ClusterIdentifier{Name: "rebalancing"},
+				KafkaAdminInfo: &types.KafkaAdminClientInformation{PartitionHealth: &types.PartitionHealth{
+					Partitions:    3,
+					Reassignments: []types.PartitionReassignment{{Topic: "orders", Partition: 1}},
+				}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"payments", "rebalancing"}, reportPartitionHealth(result))
+}
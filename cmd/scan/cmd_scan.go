@@ -2,7 +2,10 @@ package scan
 
 import (
 	"github.com/confluentinc/kcp/cmd/scan/client_inventory"
+	"github.com/confluentinc/kcp/cmd/scan/cloudtrail_clients"
 	"github.com/confluentinc/kcp/cmd/scan/clusters"
+	"github.com/confluentinc/kcp/cmd/scan/flow_logs"
+	"github.com/confluentinc/kcp/cmd/scan/kafka"
 	"github.com/confluentinc/kcp/cmd/scan/schema_registry"
 	"github.com/confluentinc/kcp/cmd/scan/self_managed_connectors"
 	"github.com/spf13/cobra"
@@ -19,7 +22,10 @@ func NewScanCmd() *cobra.Command {
 
 	scanCmd.AddCommand(
 		client_inventory.NewScanClientInventoryCmd(),
+		cloudtrail_clients.NewScanCloudTrailClientsCmd(),
 		clusters.NewScanClustersCmd(),
+		flow_logs.NewScanFlowLogsCmd(),
+		kafka.NewScanKafkaCmd(),
 		schema_registry.NewScanSchemaRegistryCmd(),
 		self_managed_connectors.NewScanSelfManagedConnectorsCmd(),
 	)
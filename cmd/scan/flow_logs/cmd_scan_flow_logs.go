@@ -0,0 +1,226 @@
+package flow_logs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/athena"
+	"github.com/confluentinc/kcp/internal/services/flowlogs"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/notify"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	stateFile            string
+	clusterArns          []string
+	days                 int
+	cidrPrefix           int
+	athenaTable          string
+	athenaWorkGroup      string
+	athenaOutputLocation string
+	athenaRegion         string
+	uploadTo             string
+	bundle               bool
+	notifyOpts           notify.Options
+)
+
+func flowLogsIAMAnnotation() string {
+	return iampolicy.RenderSingle(
+		"Also grant read access to the S3 bucket the flow logs are delivered to and write access to the query result location.",
+		[]string{"athena:StartQueryExecution", "athena:GetQueryExecution", "athena:GetQueryResults", "glue:GetDatabase", "glue:GetTable"},
+	)
+}
+
+func NewScanFlowLogsCmd() *cobra.Command {
+	flowLogsCmd := &cobra.Command{
+		Use:   "flow-logs",
+		Short: "Scan VPC Flow Logs for the clients connecting to each MSK cluster",
+		Long: `Query VPC Flow Logs with Athena for the accepted traffic to each MSK cluster's broker ENIs on Kafka ports, to find the client addresses, and the networks they are in, that connect to the cluster.
+
+Use it where ACLs, IAM policies and CloudTrail do not tell the clients apart, e.g. on unauthenticated listeners or when applications share credentials, to find consumers nobody documented.
+
+Prerequisites:
+
+- Flow logs for the brokers' subnets or VPC must be delivered to S3, with an Athena table over them created with the AWS documentation's CREATE TABLE statement for the default log format.
+- The broker ENIs must be in the state file, which ` + "`kcp discover`" + ` records when it can describe network interfaces.
+
+Traffic between a cluster's own brokers is left out. Each run replaces the flow log traffic of the scanned clusters.`,
+		Example: `  kcp scan flow-logs \
+      --state-file kcp-state.json \
+      --athena-table default.vpc_flow_logs \
+      --athena-output-location s3://my-athena-results/kcp/ \
+      --days 14`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: flowLogsIAMAnnotation(),
+		},
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunScanFlowLogs,
+		RunE:          notify.WrapRunE(&notifyOpts, runScanFlowLogs),
+	}
+
+	groups := map[*pflag.FlagSet]string{}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file where the flow log traffic will be written to.")
+	requiredFlags.StringVar(&athenaTable, "athena-table", "", "The Athena table over the VPC Flow Logs, as table or database.table.")
+	flowLogsCmd.Flags().AddFlagSet(requiredFlags)
+	groups[requiredFlags] = "Required Flags"
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringSliceVar(&clusterArns, "cluster-arn", []string{}, "The MSK clusters to scan (comma separated list or repeated flag). Defaults to every cluster in the state file with broker ENIs.")
+	optionalFlags.IntVar(&days, "days", 7, "How many days back to look for traffic.")
+	optionalFlags.IntVar(&cidrPrefix, "client-cidr-prefix", 24, "The prefix length IPv4 client addresses are grouped into networks by. IPv6 addresses are grouped by /64.")
+	optionalFlags.StringVar(&athenaWorkGroup, "athena-workgroup", "", "The Athena workgroup to run the query in. Defaults to the primary workgroup.")
+	optionalFlags.StringVar(&athenaOutputLocation, "athena-output-location", "", "The S3 location query results are written to (s3://bucket/prefix). Optional when the workgroup sets one.")
+	optionalFlags.StringVar(&athenaRegion, "athena-region", "", "The region of the Athena table. Defaults to the region of the first cluster scanned.")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
+	flowLogsCmd.Flags().AddFlagSet(optionalFlags)
+	groups[optionalFlags] = "Optional Flags"
+
+	flowLogsCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags}
+		groupNames := []string{"Required Flags", "Optional Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = flowLogsCmd.MarkFlagRequired("state-file")
+	_ = flowLogsCmd.MarkFlagRequired("athena-table")
+
+	return flowLogsCmd
+}
+
+func preRunScanFlowLogs(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	if cidrPrefix < 8 || cidrPrefix > 32 {
+		return fmt.Errorf("--client-cidr-prefix must be between 8 and 32")
+	}
+	if err := athena.ValidateTableName(athenaTable); err != nil {
+		return err
+	}
+	if athenaOutputLocation != "" && !strings.HasPrefix(athenaOutputLocation, "s3://") {
+		return fmt.Errorf("--athena-output-location must be an s3:// location")
+	}
+
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runScanFlowLogs(cmd *cobra.Command, args []string) error {
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load existing state file: %v", err)
+	}
+
+	opts, region, err := parseScanFlowLogsOpts(state, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	athenaClient, err := client.NewAthenaClient(region)
+	if err != nil {
+		return fmt.Errorf("failed to create Athena client: %w", err)
+	}
+
+	scanner := NewFlowLogsScanner(flowlogs.NewFlowLogsService(athena.NewAthenaService(athenaClient)), state, *opts)
+	if err := scanner.Run(cmd.Context()); err != nil {
+		return err
+	}
+
+	if err := sink.DeliverArtifacts(cmd.Context(), uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
+	return nil
+}
+
+// parseScanFlowLogsOpts selects the --cluster-arn clusters, or every cluster
+// with broker ENIs, and returns the region to query Athena in.
+func parseScanFlowLogsOpts(state *types.State, now time.Time) (*FlowLogsScannerOpts, string, error) {
+	if state.MSKSources == nil {
+		return nil, "", fmt.Errorf("no MSK clusters found in state file %s, run `kcp discover` first", stateFile)
+	}
+
+	selected := clusterArns
+	var regions []string
+	if len(selected) > 0 {
+		for _, clusterArn := range selected {
+			cluster, err := state.GetClusterByArn(clusterArn)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(cluster.AWSClientInformation.ClusterNetworking.BrokerENIs) == 0 {
+				return nil, "", fmt.Errorf("cluster %s has no broker ENIs in state file %s, re-run `kcp discover` with ec2:DescribeNetworkInterfaces allowed", clusterArn, stateFile)
+			}
+			regions = append(regions, cluster.Region)
+		}
+	} else {
+		for _, region := range state.MSKSources.Regions {
+			for _, cluster := range region.Clusters {
+				if len(cluster.AWSClientInformation.ClusterNetworking.BrokerENIs) > 0 {
+					selected = append(selected, cluster.Arn)
+					regions = append(regions, region.Name)
+				}
+			}
+		}
+		if len(selected) == 0 {
+			return nil, "", fmt.Errorf("no clusters with broker ENIs found in state file %s, re-run `kcp discover` with ec2:DescribeNetworkInterfaces allowed", stateFile)
+		}
+	}
+
+	region := athenaRegion
+	if region == "" {
+		region = regions[0]
+	}
+
+	return &FlowLogsScannerOpts{
+		StateFile:   stateFile,
+		ClusterArns: selected,
+		StartTime:   now.AddDate(0, 0, -days),
+		EndTime:     now,
+		Query: flowlogs.QueryOpts{
+			Table: athenaTable,
+			QueryOpts: athena.QueryOpts{
+				WorkGroup:      athenaWorkGroup,
+				OutputLocation: athenaOutputLocation,
+			},
+		},
+		CIDRPrefix: cidrPrefix,
+	}, region, nil
+}
@@ -0,0 +1,82 @@
+package flow_logs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/flowlogs"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+type FlowLogsScannerOpts struct {
+	StateFile   string
+	ClusterArns []string
+	StartTime   time.Time
+	EndTime     time.Time
+	Query       flowlogs.QueryOpts
+	CIDRPrefix  int
+}
+
+// FlowQuery queries VPC Flow Logs for traffic to broker ENIs.
+type FlowQuery interface {
+	QueryBrokerFlows(ctx context.Context, opts flowlogs.QueryOpts, brokers []types.BrokerENI, start, end time.Time) ([]flowlogs.BrokerFlow, error)
+}
+
+type FlowLogsScanner struct {
+	query FlowQuery
+	state *types.State
+	opts  FlowLogsScannerOpts
+}
+
+func NewFlowLogsScanner(query FlowQuery, state *types.State, opts FlowLogsScannerOpts) *FlowLogsScanner {
+	return &FlowLogsScanner{
+		query: query,
+		state: state,
+		opts:  opts,
+	}
+}
+
+// Run queries the flows to every selected cluster's brokers at once and
+// records each cluster's client traffic. Nothing is recorded until the one
+// query returns, so an interrupted scan has no partial result to save.
+func (s *FlowLogsScanner) Run(ctx context.Context) error {
+	fmt.Printf("🚀 Starting VPC Flow Logs scan from %s to %s\n", s.opts.StartTime.Format(time.RFC3339), s.opts.EndTime.Format(time.RFC3339))
+
+	clusters := make([]*types.DiscoveredCluster, 0, len(s.opts.ClusterArns))
+	var brokers []types.BrokerENI
+	for _, clusterArn := range s.opts.ClusterArns {
+		cluster, err := s.state.GetClusterByArn(clusterArn)
+		if err != nil {
+			return err
+		}
+		clusters = append(clusters, cluster)
+		brokers = append(brokers, cluster.AWSClientInformation.ClusterNetworking.BrokerENIs...)
+	}
+
+	slog.Info("🔍 querying VPC Flow Logs with Athena", "table", s.opts.Query.Table, "clusters", len(clusters), "broker_enis", len(brokers))
+	flows, err := s.query.QueryBrokerFlows(ctx, s.opts.Query, brokers, s.opts.StartTime, s.opts.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to query VPC Flow Logs: %w", err)
+	}
+	slog.Info("🔍 found flows to broker ENIs", "count", len(flows))
+
+	for _, cluster := range clusters {
+		clients, cidrs := flowlogs.ClientTraffic(cluster.AWSClientInformation.ClusterNetworking.BrokerENIs, flows, s.opts.CIDRPrefix)
+		cluster.FlowLogTraffic = &types.FlowLogTraffic{
+			StartTime:   s.opts.StartTime,
+			EndTime:     s.opts.EndTime,
+			Clients:     clients,
+			ClientCIDRs: cidrs,
+		}
+		fmt.Printf("  ✅ %s: %d client address(es) in %d network(s)\n", cluster.Name, len(clients), len(cidrs))
+	}
+
+	if err := s.state.PersistStateFile(s.opts.StateFile); err != nil {
+		return fmt.Errorf("failed to persist state file: %w", err)
+	}
+
+	slog.Info("✅ VPC Flow Logs scan complete", "clusters", len(clusters))
+	return nil
+}
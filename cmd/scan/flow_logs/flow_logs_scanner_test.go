@@ -0,0 +1,112 @@
+package flow_logs
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/flowlogs"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ordersArn   = "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1"
+	paymentsArn = "arn:aws:kafka:us-east-1:000000000000:cluster/payments/abc-2"
+	legacyArn   = "arn:aws:kafka:eu-west-1:000000000000:cluster/legacy/abc-3"
+)
+
+type mockFlowQuery struct {
+	brokers []types.BrokerENI
+	flows   []flowlogs.BrokerFlow
+	err     error
+}
+
+func (m *mockFlowQuery) QueryBrokerFlows(_ context.Context, _ flowlogs.QueryOpts, brokers []types.BrokerENI, _, _ time.Time) ([]flowlogs.BrokerFlow, error) {
+	m.brokers = brokers
+	return m.flows, m.err
+}
+
+func clusterWithENIs(name, arn, region string, enis ...types.BrokerENI) types.DiscoveredCluster {
+	cluster := types.DiscoveredCluster{Name: name, Arn: arn, Region: region}
+	cluster.AWSClientInformation.ClusterNetworking.BrokerENIs = enis
+	return cluster
+}
+
+func testState() *types.State {
+	return &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{
+			clusterWithENIs("orders", ordersArn, "us-east-1", types.BrokerENI{NetworkInterfaceId: "eni-0a1", PrivateIpAddress: "10.0.1.10"}),
+			clusterWithENIs("payments", paymentsArn, "us-east-1", types.BrokerENI{NetworkInterfaceId: "eni-0b1", PrivateIpAddress: "10.0.3.10"}),
+		}},
+		{Name: "eu-west-1", Clusters: []types.DiscoveredCluster{clusterWithENIs("legacy", legacyArn, "eu-west-1")}},
+	}}}
+}
+
+func TestScanner_Run(t *testing.T) {
+	state := testState()
+	seen := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	query := &mockFlowQuery{flows: []flowlogs.BrokerFlow{
+		{ENI: "eni-0a1", Address: "10.0.5.20", Port: 9098, Flows: 1, Bytes: 100, FirstSeen: seen, LastSeen: seen},
+	}}
+	opts := FlowLogsScannerOpts{
+		StateFile:   filepath.Join(t.TempDir(), "kcp-state.json"),
+		ClusterArns: []string{ordersArn, paymentsArn},
+		StartTime:   seen.AddDate(0, 0, -7),
+		EndTime:     seen,
+		CIDRPrefix:  24,
+	}
+
+	require.NoError(t, NewFlowLogsScanner(query, state, opts).Run(context.Background()))
+
+	assert.Len(t, query.brokers, 2, "one query covers every selected cluster's brokers")
+	orders := state.MSKSources.Regions[0].Clusters[0].FlowLogTraffic
+	require.NotNil(t, orders)
+	require.Len(t, orders.Clients, 1)
+	assert.Equal(t, "10.0.5.20", orders.Clients[0].Address)
+	assert.Equal(t, []types.FlowLogClientCIDR{{CIDR: "10.0.5.0/24", Clients: 1, Bytes: 100}}, orders.ClientCIDRs)
+	payments := state.MSKSources.Regions[0].Clusters[1].FlowLogTraffic
+	require.NotNil(t, payments)
+	assert.Empty(t, payments.Clients)
+
+	persisted, err := types.NewStateFromFile(opts.StateFile)
+	require.NoError(t, err)
+	assert.NotNil(t, persisted.MSKSources.Regions[0].Clusters[0].FlowLogTraffic)
+}
+
+func TestScanner_Run_QueryFails(t *testing.T) {
+	state := testState()
+	query := &mockFlowQuery{err: errors.New("table not found")}
+	opts := FlowLogsScannerOpts{StateFile: filepath.Join(t.TempDir(), "kcp-state.json"), ClusterArns: []string{ordersArn}}
+
+	err := NewFlowLogsScanner(query, state, opts).Run(context.Background())
+
+	assert.ErrorContains(t, err, "table not found")
+	assert.Nil(t, state.MSKSources.Regions[0].Clusters[0].FlowLogTraffic)
+}
+
+func TestParseScanFlowLogsOpts(t *testing.T) {
+	t.Cleanup(func() { clusterArns, athenaRegion, days = nil, "", 7 })
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	days = 7
+
+	opts, region, err := parseScanFlowLogsOpts(testState(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ordersArn, paymentsArn}, opts.ClusterArns, "clusters without broker ENIs are skipped")
+	assert.Equal(t, "us-east-1", region)
+	assert.Equal(t, now.AddDate(0, 0, -7), opts.StartTime)
+
+	clusterArns = []string{legacyArn}
+	_, _, err = parseScanFlowLogsOpts(testState(), now)
+	assert.ErrorContains(t, err, "has no broker ENIs")
+
+	clusterArns = []string{paymentsArn}
+	athenaRegion = "us-west-2"
+	opts, region, err = parseScanFlowLogsOpts(testState(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{paymentsArn}, opts.ClusterArns)
+	assert.Equal(t, "us-west-2", region)
+}
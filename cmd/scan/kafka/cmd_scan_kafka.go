@@ -0,0 +1,272 @@
+package kafka
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/confluentinc/kcp/cmd/scan/clusters"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/services/sink"
+	"github.com/confluentinc/kcp/internal/sources"
+	"github.com/confluentinc/kcp/internal/sources/osk"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	bootstrapServers []string
+	stateFile        string
+	clusterID        string
+	environment      string
+	location         string
+	skipTopics       bool
+	skipACLs         bool
+	uploadTo         string
+	bundle           bool
+	connection       client.ConnectionSettings
+
+	useSaslScram                bool
+	useSaslPlain                bool
+	useTls                      bool
+	useUnauthenticatedTLS       bool
+	useUnauthenticatedPlaintext bool
+
+	saslScramUsername  string
+	saslScramPassword  string
+	saslScramMechanism string
+
+	saslPlainUsername string
+	saslPlainPassword string
+
+	tlsCaCert     string
+	tlsClientCert string
+	tlsClientKey  string
+)
+
+func NewScanKafkaCmd() *cobra.Command {
+	scanKafkaCmd := &cobra.Command{
+		Use:   "kafka",
+		Short: "Scan a self-managed Kafka cluster by its bootstrap servers",
+		Long: `Scan a self-managed Apache Kafka cluster — on EC2, on-premises or anywhere else outside MSK — using only the Kafka Admin API. No AWS APIs are called.
+
+The scan collects the same topics, topic configurations, ACLs and consumer groups as ` + "`kcp scan clusters --source-type apache-kafka`" + ` and stores them in the state file under the Apache Kafka sources, next to any MSK clusters from ` + "`kcp discover`" + `, so a mixed estate is assessed in one report. Connection details come from flags instead of an ` + "`apache-kafka-credentials.yaml`" + ` file, which suits scanning one cluster at a time; use ` + "`kcp scan clusters`" + ` for several clusters or for metrics collection.
+
+The cluster is recorded under ` + "`--cluster-id`" + `, defaulting to the host of the first bootstrap server. Re-running with the same ID updates the cluster in place.`,
+		Example: `  # Unauthenticated plaintext listener
+  kcp scan kafka --bootstrap-servers broker1:9092,broker2:9092 --use-unauthenticated-plaintext
+
+  # SASL/SCRAM over TLS, recorded under a chosen ID
+  kcp scan kafka --bootstrap-servers broker1.internal:9096 --cluster-id orders-prod \
+      --use-sasl-scram --sasl-scram-username kcp --sasl-scram-password "$SCRAM_PASSWORD"
+
+  # Mutual TLS
+  kcp scan kafka --bootstrap-servers broker1.internal:9094 --use-tls \
+      --tls-ca-cert ca.pem --tls-client-cert client.pem --tls-client-key client.key`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		PreRunE:       preRunScanKafka,
+		RunE:          runScanKafka,
+	}
+
+	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
+	requiredFlags.SortFlags = false
+	requiredFlags.StringSliceVar(&bootstrapServers, "bootstrap-servers", []string{}, "Bootstrap servers of the Kafka cluster as host:port (comma separated).")
+	scanKafkaCmd.Flags().AddFlagSet(requiredFlags)
+
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&stateFile, "state-file", "kcp-state.json", "Path to the KCP state file. Created when it doesn't exist.")
+	optionalFlags.StringVar(&clusterID, "cluster-id", "", "ID to record the cluster under in the state file. Default: the host of the first bootstrap server.")
+	optionalFlags.StringVar(&environment, "environment", "", "Environment label for the cluster in reports, e.g. production.")
+	optionalFlags.StringVar(&location, "location", "", "Location label for the cluster in reports, e.g. us-east-1 or dc-london.")
+	optionalFlags.BoolVar(&skipTopics, "skip-topics", false, "Skip topic discovery")
+	optionalFlags.BoolVar(&skipACLs, "skip-acls", false, "Skip ACL discovery")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	scanKafkaCmd.Flags().AddFlagSet(optionalFlags)
+
+	authFlags := pflag.NewFlagSet("auth", pflag.ExitOnError)
+	authFlags.SortFlags = false
+	authFlags.BoolVar(&useSaslScram, "use-sasl-scram", false, "Use SASL/SCRAM authentication.")
+	authFlags.BoolVar(&useSaslPlain, "use-sasl-plain", false, "Use SASL/PLAIN authentication.")
+	authFlags.BoolVar(&useTls, "use-tls", false, "Use TLS (mutual TLS) authentication.")
+	authFlags.BoolVar(&useUnauthenticatedTLS, "use-unauthenticated-tls", false, "Use unauthenticated (TLS encryption).")
+	authFlags.BoolVar(&useUnauthenticatedPlaintext, "use-unauthenticated-plaintext", false, "Use unauthenticated (plaintext).")
+	scanKafkaCmd.Flags().AddFlagSet(authFlags)
+
+	saslScramFlags := pflag.NewFlagSet("sasl-scram", pflag.ExitOnError)
+	saslScramFlags.SortFlags = false
+	saslScramFlags.StringVar(&saslScramUsername, "sasl-scram-username", "", "SASL/SCRAM username.")
+	saslScramFlags.StringVar(&saslScramPassword, "sasl-scram-password", "", "SASL/SCRAM password.")
+	utils.AddSecretStdinFlag(saslScramFlags, "sasl-scram-password")
+	saslScramFlags.StringVar(&saslScramMechanism, "sasl-scram-mechanism", "SHA256", "SASL/SCRAM mechanism (SHA256 or SHA512).")
+	scanKafkaCmd.Flags().AddFlagSet(saslScramFlags)
+
+	saslPlainFlags := pflag.NewFlagSet("sasl-plain", pflag.ExitOnError)
+	saslPlainFlags.SortFlags = false
+	saslPlainFlags.StringVar(&saslPlainUsername, "sasl-plain-username", "", "SASL/PLAIN username.")
+	saslPlainFlags.StringVar(&saslPlainPassword, "sasl-plain-password", "", "SASL/PLAIN password.")
+	utils.AddSecretStdinFlag(saslPlainFlags, "sasl-plain-password")
+	scanKafkaCmd.Flags().AddFlagSet(saslPlainFlags)
+
+	tlsFlags := pflag.NewFlagSet("tls", pflag.ExitOnError)
+	tlsFlags.SortFlags = false
+	tlsFlags.StringVar(&tlsCaCert, "tls-ca-cert", "", "Path to the CA certificate that signed the broker certificates. Default: the system trust store.")
+	tlsFlags.StringVar(&tlsClientCert, "tls-client-cert", "", "Path to the TLS client certificate.")
+	tlsFlags.StringVar(&tlsClientKey, "tls-client-key", "", "Path to the TLS client key.")
+	scanKafkaCmd.Flags().AddFlagSet(tlsFlags)
+
+	kafkaClientFlags := pflag.NewFlagSet("kafka-client", pflag.ExitOnError)
+	kafkaClientFlags.SortFlags = false
+	kafkaClientFlags.DurationVar(&connection.DialTimeout, "kafka-dial-timeout", 10*time.Second, "How long to wait for a broker connection before giving up. Lower it to fail fast against unreachable brokers.")
+	kafkaClientFlags.DurationVar(&connection.ReadTimeout, "kafka-read-timeout", 30*time.Second, "How long to wait for a broker response before giving up. Lower it to fail fast against unresponsive brokers.")
+	kafkaClientFlags.StringVar(&connection.KafkaVersion, "kafka-version", "", "Kafka protocol version to use (e.g. 2.8.1), for brokers that reject the API versions kcp picks. Default: 3.6.0.")
+	kafkaClientFlags.BoolVar(&connection.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS certificate verification, e.g. for brokers with certificates from a private CA.")
+	scanKafkaCmd.Flags().AddFlagSet(kafkaClientFlags)
+
+	scanKafkaCmd.SetUsageFunc(func(c *cobra.Command) error {
+		fmt.Printf("%s\n\n", c.Short)
+
+		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, authFlags, saslScramFlags, saslPlainFlags, tlsFlags, kafkaClientFlags}
+		groupNames := []string{"Required Flags", "Optional Flags", "Authentication Flags", "SASL/SCRAM Flags", "SASL/PLAIN Flags", "TLS Flags", "Kafka Client Flags"}
+
+		for i, fs := range flagOrder {
+			usage := fs.FlagUsages()
+			if usage != "" {
+				fmt.Printf("%s:\n%s\n", groupNames[i], usage)
+			}
+		}
+
+		fmt.Println("All flags can be provided via environment variables (uppercase, with underscores).")
+
+		return nil
+	})
+
+	_ = scanKafkaCmd.MarkFlagRequired("bootstrap-servers")
+	scanKafkaCmd.MarkFlagsMutuallyExclusive("use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+	scanKafkaCmd.MarkFlagsOneRequired("use-sasl-scram", "use-sasl-plain", "use-tls", "use-unauthenticated-tls", "use-unauthenticated-plaintext")
+
+	scanKafkaCmd.MarkFlagsRequiredTogether("sasl-scram-username", "sasl-scram-password")
+	scanKafkaCmd.MarkFlagsRequiredTogether("sasl-plain-username", "sasl-plain-password")
+	scanKafkaCmd.MarkFlagsRequiredTogether("tls-client-cert", "tls-client-key")
+
+	return scanKafkaCmd
+}
+
+func preRunScanKafka(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if useSaslScram {
+		_ = cmd.MarkFlagRequired("sasl-scram-username")
+		_ = cmd.MarkFlagRequired("sasl-scram-password")
+	}
+
+	if useSaslPlain {
+		_ = cmd.MarkFlagRequired("sasl-plain-username")
+		_ = cmd.MarkFlagRequired("sasl-plain-password")
+	}
+
+	if useTls {
+		_ = cmd.MarkFlagRequired("tls-client-cert")
+		_ = cmd.MarkFlagRequired("tls-client-key")
+	}
+
+	if clusterID == "" && len(bootstrapServers) > 0 {
+		clusterID = defaultClusterID(bootstrapServers[0])
+	}
+
+	return connection.Validate()
+}
+
+func runScanKafka(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	state, err := clusters.LoadOrCreateState(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load state file: %w", err)
+	}
+
+	source, err := osk.NewOSKSourceFromCredentials(types.OSKCredentials{
+		Clusters: []types.OSKClusterAuth{clusterAuth()},
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("starting Apache Kafka cluster scan", "cluster", clusterID, "bootstrap_servers", bootstrapServers)
+	scanResult, err := source.Scan(ctx, sources.ScanOptions{
+		SkipTopics: skipTopics,
+		SkipACLs:   skipACLs,
+		State:      state,
+		Connection: connection,
+	})
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	if err := sources.MergeResultsIntoState(state, scanResult); err != nil {
+		return fmt.Errorf("failed to merge scan results: %w", err)
+	}
+
+	if err := state.PersistStateFile(stateFile); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	slog.Info("scan completed successfully", "cluster", clusterID, "state_file", stateFile)
+	fmt.Printf("\n✅ Scan completed successfully\n")
+	fmt.Printf("   Cluster: %s\n", clusterID)
+	fmt.Printf("   State file: %s\n\n", stateFile)
+
+	if err := sink.DeliverArtifacts(ctx, uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
+	return nil
+}
+
+// clusterAuth builds the single-cluster credentials entry the flags
+// describe, as it would appear in apache-kafka-credentials.yaml.
+func clusterAuth() types.OSKClusterAuth {
+	auth := types.OSKClusterAuth{
+		ID:               clusterID,
+		BootstrapServers: bootstrapServers,
+		Metadata: types.OSKCredentialMetadata{
+			Environment: environment,
+			Location:    location,
+		},
+	}
+
+	switch {
+	case useSaslScram:
+		auth.AuthMethod.SASLScram = &types.SASLScramConfig{Use: true, Username: saslScramUsername, Password: saslScramPassword, Mechanism: saslScramMechanism}
+	case useSaslPlain:
+		auth.AuthMethod.SASLPlain = &types.SASLPlainConfig{Use: true, Username: saslPlainUsername, Password: saslPlainPassword}
+	case useTls:
+		auth.AuthMethod.TLS = &types.TLSConfig{Use: true, CACert: tlsCaCert, ClientCert: tlsClientCert, ClientKey: tlsClientKey}
+	case useUnauthenticatedTLS:
+		auth.AuthMethod.UnauthenticatedTLS = &types.UnauthenticatedTLSConfig{Use: true}
+	case useUnauthenticatedPlaintext:
+		auth.AuthMethod.UnauthenticatedPlaintext = &types.UnauthenticatedPlaintextConfig{Use: true}
+	}
+
+	return auth
+}
+
+// defaultClusterID is the host of a bootstrap server, or the server as given
+// when it isn't host:port (the credentials validation then rejects it).
+func defaultClusterID(server string) string {
+	host, _, err := net.SplitHostPort(server)
+	if err != nil || host == "" {
+		return server
+	}
+	return host
+}
@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/sources/osk"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClusterID(t *testing.T) {
+	assert.Equal(t, "broker1.internal", defaultClusterID("broker1.internal:9092"))
+	assert.Equal(t, "::1", defaultClusterID("[::1]:9092"))
+	assert.Equal(t, "broker1", defaultClusterID("broker1"))
+}
+
+func TestClusterAuth_SASLScram(t *testing.T) {
+	t.Cleanup(func() {
+		clusterID, bootstrapServers, environment = "", nil, ""
+		useSaslScram, saslScramUsername, saslScramPassword, saslScramMechanism = false, "", "", ""
+	})
+	clusterID, bootstrapServers, environment = "orders-prod", []string{"broker1:9096", "broker2:9096"}, "production"
+	useSaslScram, saslScramUsername, saslScramPassword, saslScramMechanism = true, "kcp", "secret", "SHA512"
+
+	auth := clusterAuth()
+
+	assert.Equal(t, "orders-prod", auth.ID)
+	assert.Equal(t, []string{"broker1:9096", "broker2:9096"}, auth.BootstrapServers)
+	assert.Equal(t, "production", auth.Metadata.Environment)
+	selected, err := auth.GetSelectedAuthType()
+	require.NoError(t, err)
+	assert.Equal(t, types.AuthTypeSASLSCRAM, selected)
+	assert.Equal(t, &types.SASLScramConfig{Use: true, Username: "kcp", Password: "secret", Mechanism: "SHA512"}, auth.AuthMethod.SASLScram)
+
+	_, err = osk.NewOSKSourceFromCredentials(types.OSKCredentials{Clusters: []types.OSKClusterAuth{auth}})
+	assert.NoError(t, err)
+}
+
+func TestClusterAuth_InvalidBootstrapServerRejected(t *testing.T) {
+	t.Cleanup(func() {
+		clusterID, bootstrapServers, useUnauthenticatedPlaintext = "", nil, false
+	})
+	clusterID, bootstrapServers, useUnauthenticatedPlaintext = "broker1", []string{"broker1"}, true
+
+	_, err := osk.NewOSKSourceFromCredentials(types.OSKCredentials{Clusters: []types.OSKClusterAuth{clusterAuth()}})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid bootstrap server format 'broker1'")
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/confluentinc/kcp/internal/client"
 	glue_service "github.com/confluentinc/kcp/internal/services/glue_schema_registry"
 	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/notify"
 	"github.com/confluentinc/kcp/internal/services/schema_registry"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 
@@ -25,6 +27,9 @@ var (
 	password           string
 	registryName       string
 	region             string
+	uploadTo           string
+	bundle             bool
+	notifyOpts         notify.Options
 )
 
 func schemaRegistryIAMAnnotation() string {
@@ -66,7 +71,7 @@ func NewScanSchemaRegistryCmd() *cobra.Command {
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 		PreRunE:       preRunScanSchemaRegistry,
-		RunE:          runScanSchemaRegistry,
+		RunE:          notify.WrapRunE(&notifyOpts, runScanSchemaRegistry),
 	}
 
 	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
@@ -82,6 +87,7 @@ func NewScanSchemaRegistryCmd() *cobra.Command {
 	confluentFlags.BoolVar(&useBasicAuth, "use-basic-auth", false, "Use Basic Authentication")
 	confluentFlags.StringVar(&username, "username", "", "The username to use for Basic Authentication")
 	confluentFlags.StringVar(&password, "password", "", "The password to use for Basic Authentication")
+	utils.AddSecretStdinFlag(confluentFlags, "password")
 	schemaRegistryCmd.Flags().AddFlagSet(confluentFlags)
 
 	glueFlags := pflag.NewFlagSet("glue", pflag.ExitOnError)
@@ -90,14 +96,23 @@ func NewScanSchemaRegistryCmd() *cobra.Command {
 	glueFlags.StringVar(&region, "region", "", "The AWS region where the Glue Schema Registry is located.")
 	schemaRegistryCmd.Flags().AddFlagSet(glueFlags)
 
+	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
+	optionalFlags.SortFlags = false
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
+	schemaRegistryCmd.Flags().AddFlagSet(optionalFlags)
+
 	schemaRegistryCmd.SetUsageFunc(func(c *cobra.Command) error {
 		fmt.Printf("%s\n\n", c.Short)
 
-		flagOrder := []*pflag.FlagSet{requiredFlags, confluentFlags, glueFlags}
+		flagOrder := []*pflag.FlagSet{requiredFlags, confluentFlags, glueFlags, optionalFlags}
 		groupNames := []string{
 			"Required Flags",
 			"Confluent Flags (--sr-type=confluent)",
 			"Glue Flags (--sr-type=glue)",
+			"Optional Flags",
 		}
 
 		for i, fs := range flagOrder {
@@ -127,6 +142,13 @@ func preRunScanSchemaRegistry(cmd *cobra.Command, args []string) error {
 	if err := utils.BindEnvToFlags(cmd); err != nil {
 		return err
 	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
 
 	switch srType {
 	case "confluent":
@@ -159,12 +181,21 @@ func preRunScanSchemaRegistry(cmd *cobra.Command, args []string) error {
 }
 
 func runScanSchemaRegistry(cmd *cobra.Command, args []string) error {
+	var err error
 	switch srType {
 	case "confluent":
-		return runScanConfluentSchemaRegistry()
+		err = runScanConfluentSchemaRegistry()
 	case "glue":
-		return runScanGlueSchemaRegistry(cmd.Context())
+		err = runScanGlueSchemaRegistry(cmd.Context())
 	}
+	if err != nil {
+		return err
+	}
+
+	if err := sink.DeliverArtifacts(cmd.Context(), uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
 	return nil
 }
 
@@ -1,11 +1,17 @@
 package self_managed_connectors
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/confluentinc/kcp/internal/services/ec2"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+	"github.com/confluentinc/kcp/internal/services/notify"
+	"github.com/confluentinc/kcp/internal/services/sink"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
 
@@ -14,10 +20,15 @@ import (
 )
 
 var (
-	stateFile      string
-	connectRestURL string
-	clusterID      string
-	sourceType     string
+	stateFile       string
+	connectRestURLs []string
+	clusterID       string
+	sourceType      string
+
+	connectEC2Tags   []string
+	connectEC2Region string
+	connectEC2Port   int
+	connectEC2Scheme string
 
 	useSaslScram       bool
 	useTls             bool
@@ -35,13 +46,19 @@ var (
 	metricsInterval string
 	metricsRange    string
 	credentialsFile string
+
+	uploadTo   string
+	bundle     bool
+	notifyOpts notify.Options
 )
 
 func NewScanSelfManagedConnectorsCmd() *cobra.Command {
 	selfManagedConnectorsCmd := &cobra.Command{
 		Use:   "self-managed-connectors",
 		Short: "Scan self-managed Kafka Connect cluster for connector information",
-		Long:  "Scan a self-managed Kafka Connect cluster using its REST API to discover connector configurations and status. Sensitive config values are redacted before being written to the state file.",
+		Long: `Scan self-managed Kafka Connect clusters, on EC2, EKS or elsewhere, using their REST API to discover connector configurations, status and task states. Sensitive config values are redacted before being written to the state file.
+
+` + "`--connect-rest-url`" + ` can be repeated to scan several Connect clusters serving the same Kafka cluster, e.g. the Service or load balancer URL of each Connect deployment on EKS. ` + "`--connect-ec2-tag`" + ` instead finds the Connect workers among the running EC2 instances with the given tags and scans each through its private IP. Connectors are merged by name into the cluster's self_managed_connectors, with the endpoint each was read from recorded as connect_url.`,
 		Example: `  # Scan connectors for an MSK cluster (auto-detected from ARN format)
   kcp scan self-managed-connectors \
     --state-file kcp-state.json \
@@ -73,23 +90,53 @@ func NewScanSelfManagedConnectorsCmd() *cobra.Command {
     --cluster-id my-cluster \
     --use-unauthenticated \
     --metrics jolokia --metrics-duration 5m --metrics-interval 10s \
-    --credentials-file osk-credentials.yaml`,
+    --credentials-file osk-credentials.yaml
+
+  # Scan two Connect clusters serving the same MSK cluster
+  kcp scan self-managed-connectors \
+    --state-file kcp-state.json \
+    --connect-rest-url http://connect-orders:8083 \
+    --connect-rest-url http://connect-payments:8083 \
+    --cluster-id arn:aws:kafka:us-east-1:123456789012:cluster/my-cluster/abc-123 \
+    --use-unauthenticated
+
+  # Find the Connect workers by EC2 tag (the region defaults to the MSK cluster's)
+  kcp scan self-managed-connectors \
+    --state-file kcp-state.json \
+    --connect-ec2-tag role=kafka-connect \
+    --cluster-id arn:aws:kafka:us-east-1:123456789012:cluster/my-cluster/abc-123 \
+    --use-unauthenticated`,
+		Annotations: map[string]string{
+			iampolicy.AnnotationKey: selfManagedConnectorsIAMAnnotation(),
+		},
 		SilenceErrors: true,
 		PreRunE:       preRunScanSelfManagedConnectors,
-		RunE:          runScanSelfManagedConnectors,
+		RunE:          notify.WrapRunE(&notifyOpts, runScanSelfManagedConnectors),
 		Hidden:        false,
 	}
 
 	requiredFlags := pflag.NewFlagSet("required", pflag.ExitOnError)
 	requiredFlags.SortFlags = false
 	requiredFlags.StringVar(&stateFile, "state-file", "", "The path to the kcp state file to update with connector information.")
-	requiredFlags.StringVar(&connectRestURL, "connect-rest-url", "", "The Kafka Connect REST API URL (e.g., http://localhost:8083).")
 	requiredFlags.StringVar(&clusterID, "cluster-id", "", "The cluster identifier in the state file. Accepts both MSK ARNs (arn:aws:kafka:...) and OSK cluster IDs.")
 	selfManagedConnectorsCmd.Flags().AddFlagSet(requiredFlags)
 
+	endpointFlags := pflag.NewFlagSet("endpoints", pflag.ExitOnError)
+	endpointFlags.SortFlags = false
+	endpointFlags.StringSliceVar(&connectRestURLs, "connect-rest-url", []string{}, "The Kafka Connect REST API URL (e.g., http://localhost:8083). Can be repeated to scan several Connect clusters.")
+	endpointFlags.StringSliceVar(&connectEC2Tags, "connect-ec2-tag", []string{}, "Find the Connect workers among the running EC2 instances with this tag, as key=value or key (repeatable; instances must match every tag).")
+	endpointFlags.StringVar(&connectEC2Region, "connect-ec2-region", "", "The AWS region of the tagged Connect workers. Defaults to the region of an MSK --cluster-id.")
+	endpointFlags.IntVar(&connectEC2Port, "connect-ec2-port", 8083, "The Connect REST port of the tagged workers.")
+	endpointFlags.StringVar(&connectEC2Scheme, "connect-ec2-scheme", "http", "The Connect REST scheme of the tagged workers: 'http' or 'https'.")
+	selfManagedConnectorsCmd.Flags().AddFlagSet(endpointFlags)
+
 	optionalFlags := pflag.NewFlagSet("optional", pflag.ExitOnError)
 	optionalFlags.SortFlags = false
 	optionalFlags.StringVar(&sourceType, "source-type", "", "Source type: 'msk' or 'osk'. If not specified, auto-detects from cluster-id format (ARN = MSK, non-ARN = OSK).")
+	optionalFlags.StringVar(&uploadTo, "upload-to", "", "Also upload the state file to this S3 location (s3://bucket/prefix), encrypted with SSE-S3. Requires s3:GetBucketLocation and s3:PutObject.")
+	optionalFlags.BoolVar(&bundle, "bundle", false, "Also write the state file and the audit log to a single compressed tar.gz, with a manifest of SHA-256 checksums, e.g. to attach to a ticket or send to support. With --upload-to, the bundle is uploaded instead of the state file.")
+	optionalFlags.StringSliceVar(&notifyOpts.Targets, "notify", []string{}, "Notify an SNS topic when the command completes or fails, as sns:<topic arn> (repeatable). Requires sns:Publish.")
+	optionalFlags.StringSliceVar(&notifyOpts.Webhooks, "notify-webhook", []string{}, "POST a JSON completion event to this URL when the command completes or fails, e.g. a Slack incoming webhook (repeatable).")
 	selfManagedConnectorsCmd.Flags().AddFlagSet(optionalFlags)
 
 	authMethodFlags := pflag.NewFlagSet("auth-method", pflag.ExitOnError)
@@ -103,6 +150,7 @@ func NewScanSelfManagedConnectorsCmd() *cobra.Command {
 	saslScramFlags.SortFlags = false
 	saslScramFlags.StringVar(&saslScramUsername, "sasl-scram-username", "", "SASL/SCRAM username (required when using --use-sasl-scram).")
 	saslScramFlags.StringVar(&saslScramPassword, "sasl-scram-password", "", "SASL/SCRAM password (required when using --use-sasl-scram).")
+	utils.AddSecretStdinFlag(saslScramFlags, "sasl-scram-password")
 	selfManagedConnectorsCmd.Flags().AddFlagSet(saslScramFlags)
 
 	tlsFlags := pflag.NewFlagSet("tls", pflag.ExitOnError)
@@ -128,8 +176,8 @@ func NewScanSelfManagedConnectorsCmd() *cobra.Command {
 			fmt.Printf("Examples:\n%s\n\n", c.Example)
 		}
 
-		flagOrder := []*pflag.FlagSet{requiredFlags, optionalFlags, authMethodFlags, saslScramFlags, tlsFlags, metricsFlags}
-		groupNames := []string{"Required Flags", "Optional Flags", "Authentication Method (choose one)", "SASL/SCRAM Credentials", "TLS Credentials", "Metrics Collection"}
+		flagOrder := []*pflag.FlagSet{requiredFlags, endpointFlags, optionalFlags, authMethodFlags, saslScramFlags, tlsFlags, metricsFlags}
+		groupNames := []string{"Required Flags", "Connect Endpoints (at least one of --connect-rest-url, --connect-ec2-tag)", "Optional Flags", "Authentication Method (choose one)", "SASL/SCRAM Credentials", "TLS Credentials", "Metrics Collection"}
 
 		for i, fs := range flagOrder {
 			usage := fs.FlagUsages()
@@ -144,8 +192,8 @@ func NewScanSelfManagedConnectorsCmd() *cobra.Command {
 	})
 
 	_ = selfManagedConnectorsCmd.MarkFlagRequired("state-file")
-	_ = selfManagedConnectorsCmd.MarkFlagRequired("connect-rest-url")
 	_ = selfManagedConnectorsCmd.MarkFlagRequired("cluster-id")
+	selfManagedConnectorsCmd.MarkFlagsOneRequired("connect-rest-url", "connect-ec2-tag")
 
 	selfManagedConnectorsCmd.MarkFlagsMutuallyExclusive("use-sasl-scram", "use-tls", "use-unauthenticated")
 	selfManagedConnectorsCmd.MarkFlagsOneRequired("use-sasl-scram", "use-tls", "use-unauthenticated")
@@ -158,6 +206,23 @@ func preRunScanSelfManagedConnectors(cmd *cobra.Command, args []string) error {
 	if err := utils.BindEnvToFlags(cmd); err != nil {
 		return err
 	}
+	if err := sink.ValidateUploadTo(uploadTo); err != nil {
+		return err
+	}
+
+	if err := notifyOpts.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := parseEC2TagFilters(connectEC2Tags); err != nil {
+		return err
+	}
+	if connectEC2Scheme != "http" && connectEC2Scheme != "https" {
+		return fmt.Errorf("invalid --connect-ec2-scheme '%s': must be 'http' or 'https'", connectEC2Scheme)
+	}
+	if connectEC2Port <= 0 || connectEC2Port > 65535 {
+		return fmt.Errorf("invalid --connect-ec2-port %d", connectEC2Port)
+	}
 
 	if useSaslScram {
 		_ = cmd.MarkFlagRequired("sasl-scram-username")
@@ -218,7 +283,7 @@ func preRunScanSelfManagedConnectors(cmd *cobra.Command, args []string) error {
 }
 
 func runScanSelfManagedConnectors(cmd *cobra.Command, args []string) error {
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("failed to parse scan self-managed connectors opts: %v", err)
 	}
@@ -227,14 +292,18 @@ func runScanSelfManagedConnectors(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create self-managed connectors scanner: %v", err)
 	}
-	if err := scanner.Run(); err != nil {
+	if err := scanner.Run(cmd.Context()); err != nil {
 		return fmt.Errorf("failed to scan self-managed connectors: %v", err)
 	}
 
+	if err := sink.DeliverArtifacts(cmd.Context(), uploadTo, bundle, cmd.CommandPath(), stateFile); err != nil {
+		return fmt.Errorf("failed to deliver state file: %w", err)
+	}
+
 	return nil
 }
 
-func parseScanSelfManagedConnectorsOpts() (*SelfManagedConnectorsScannerOpts, error) {
+func parseScanSelfManagedConnectorsOpts(ctx context.Context) (*SelfManagedConnectorsScannerOpts, error) {
 	if _, err := os.Stat(stateFile); os.IsNotExist(err) {
 		return nil, fmt.Errorf("state file does not exist: %s", stateFile)
 	}
@@ -244,8 +313,6 @@ func parseScanSelfManagedConnectorsOpts() (*SelfManagedConnectorsScannerOpts, er
 		return nil, fmt.Errorf("failed to load existing state file: %v", err)
 	}
 
-	normalizedURL := normaliseConnectURL(connectRestURL)
-
 	var authMethod types.ConnectAuthMethod
 	switch {
 	case useSaslScram:
@@ -323,14 +390,19 @@ func parseScanSelfManagedConnectorsOpts() (*SelfManagedConnectorsScannerOpts, er
 		}
 	}
 
+	urls, err := resolveConnectURLs(ctx, clusterArn)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := SelfManagedConnectorsScannerOpts{
-		StateFile:      stateFile,
-		State:          state,
-		ConnectRestURL: normalizedURL,
-		SourceType:     detectedSourceType,
-		ClusterArn:     clusterArn,
-		ClusterID:      oskClusterID,
-		AuthMethod:     authMethod,
+		StateFile:       stateFile,
+		State:           state,
+		ConnectRestURLs: urls,
+		SourceType:      detectedSourceType,
+		ClusterArn:      clusterArn,
+		ClusterID:       oskClusterID,
+		AuthMethod:      authMethod,
 		SaslScramAuth: types.ConnectSaslScramAuth{
 			Username: saslScramUsername,
 			Password: saslScramPassword,
@@ -350,6 +422,47 @@ func parseScanSelfManagedConnectorsOpts() (*SelfManagedConnectorsScannerOpts, er
 	return &opts, nil
 }
 
+// resolveConnectURLs returns the --connect-rest-url endpoints followed by the
+// Connect workers found with --connect-ec2-tag, without duplicates.
+func resolveConnectURLs(ctx context.Context, clusterArn string) ([]string, error) {
+	var urls []string
+	for _, url := range connectRestURLs {
+		urls = append(urls, normaliseConnectURL(url))
+	}
+
+	if len(connectEC2Tags) > 0 {
+		tags, err := parseEC2TagFilters(connectEC2Tags)
+		if err != nil {
+			return nil, err
+		}
+		region, err := resolveEC2Region(connectEC2Region, clusterArn)
+		if err != nil {
+			return nil, err
+		}
+		ec2Service, err := ec2.NewEC2Service(region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create EC2 service: %v", err)
+		}
+		discovered, err := discoverConnectURLs(ctx, ec2Service, tags, connectEC2Scheme, connectEC2Port)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("🔍 Found %d Connect workers tagged %s in %s\n", len(discovered), strings.Join(connectEC2Tags, ","), region)
+		if len(discovered) == 0 && len(urls) == 0 {
+			return nil, fmt.Errorf("no running EC2 instances in %s match --connect-ec2-tag %s", region, strings.Join(connectEC2Tags, ","))
+		}
+		urls = append(urls, discovered...)
+	}
+
+	var unique []string
+	for _, url := range urls {
+		if !slices.Contains(unique, url) {
+			unique = append(unique, url)
+		}
+	}
+	return unique, nil
+}
+
 func normaliseConnectURL(url string) string {
 	// If the URL already has http:// or https://, return as-is
 	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
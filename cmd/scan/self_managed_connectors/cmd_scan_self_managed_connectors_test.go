@@ -1,6 +1,7 @@
 package self_managed_connectors
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -147,11 +148,11 @@ func resetCmdVars() { NewScanSelfManagedConnectorsCmd() }
 func TestParseOpts_NoMetrics_NilCreds(t *testing.T) {
 	resetCmdVars()
 	stateFile = writeStateFileWithCluster(t)
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testArn
 	useUnauthenticated = true
 
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.NoError(t, err)
 	require.Empty(t, opts.MetricsSource, "no --metrics ⇒ empty source")
 	require.Nil(t, opts.MetricsClusterCreds, "no --metrics ⇒ no creds resolved")
@@ -160,14 +161,14 @@ func TestParseOpts_NoMetrics_NilCreds(t *testing.T) {
 func TestParseOpts_Jolokia_ResolvesClusterCreds(t *testing.T) {
 	resetCmdVars()
 	stateFile = writeStateFileWithCluster(t)
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testArn
 	useUnauthenticated = true
 	metricsSource = "jolokia"
 	metricsDuration = "5m"
 	credentialsFile = writeOSKCredsFile(t, testArn, jolokiaCredsSection)
 
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, "jolokia", opts.MetricsSource)
 	require.Equal(t, "5m", opts.MetricsDuration)
@@ -180,14 +181,14 @@ func TestParseOpts_Jolokia_ResolvesClusterCreds(t *testing.T) {
 func TestParseOpts_Prometheus_ResolvesClusterCreds(t *testing.T) {
 	resetCmdVars()
 	stateFile = writeStateFileWithCluster(t)
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testArn
 	useUnauthenticated = true
 	metricsSource = "prometheus"
 	metricsRange = "7d"
 	credentialsFile = writeOSKCredsFile(t, testArn, prometheusCredsSection)
 
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, "prometheus", opts.MetricsSource)
 	require.Equal(t, "7d", opts.MetricsRange)
@@ -200,7 +201,7 @@ func TestParseOpts_Prometheus_ResolvesClusterCreds(t *testing.T) {
 func TestParseOpts_NoMatchingCluster_ErrorsWithoutSecret(t *testing.T) {
 	resetCmdVars()
 	stateFile = writeStateFileWithCluster(t)
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testArn
 	useUnauthenticated = true
 	metricsSource = "jolokia"
@@ -216,7 +217,7 @@ func TestParseOpts_NoMatchingCluster_ErrorsWithoutSecret(t *testing.T) {
 `, secret)
 	credentialsFile = writeOSKCredsFile(t, "a-different-cluster-id", credsSection)
 
-	_, err := parseScanSelfManagedConnectorsOpts()
+	_, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "no matching cluster entry")
 	require.NotContains(t, err.Error(), secret, "credential value must not leak into error (R11)")
@@ -0,0 +1,73 @@
+package self_managed_connectors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/confluentinc/kcp/internal/services/iampolicy"
+)
+
+func selfManagedConnectorsIAMAnnotation() string {
+	return iampolicy.RenderSingle(
+		"Only required for `--connect-ec2-tag`, which looks up the Connect workers with the AWS default credential chain.",
+		[]string{"ec2:DescribeInstances"},
+	)
+}
+
+// instanceDescriber finds the EC2 instances Connect workers run on.
+type instanceDescriber interface {
+	DescribeRunningInstancesByTags(ctx context.Context, tags map[string]string) ([]ec2types.Instance, error)
+}
+
+// parseEC2TagFilters parses --connect-ec2-tag values: key=value, or a bare key
+// matching any value.
+func parseEC2TagFilters(values []string) (map[string]string, error) {
+	tags := make(map[string]string, len(values))
+	for _, value := range values {
+		key, tagValue, _ := strings.Cut(value, "=")
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --connect-ec2-tag '%s': must be key=value or key", value)
+		}
+		tags[key] = tagValue
+	}
+	return tags, nil
+}
+
+// resolveEC2Region returns the region to look Connect workers up in:
+// --connect-ec2-region, or else the region of an MSK cluster ARN.
+func resolveEC2Region(region, clusterArn string) (string, error) {
+	if region != "" {
+		return region, nil
+	}
+	if parsed, err := arn.Parse(clusterArn); err == nil && parsed.Region != "" {
+		return parsed.Region, nil
+	}
+	return "", fmt.Errorf("--connect-ec2-region is required with --connect-ec2-tag for Apache Kafka clusters")
+}
+
+// discoverConnectURLs returns the REST URLs of the Connect workers running on
+// the instances tagged with tags, addressed by private IP.
+func discoverConnectURLs(ctx context.Context, describer instanceDescriber, tags map[string]string, scheme string, port int) ([]string, error) {
+	instances, err := describer.DescribeRunningInstancesByTags(ctx, tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe EC2 instances: %w", err)
+	}
+
+	var urls []string
+	for _, instance := range instances {
+		ip := aws.ToString(instance.PrivateIpAddress)
+		if ip == "" {
+			continue
+		}
+		urls = append(urls, fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(ip, strconv.Itoa(port))))
+	}
+	slices.Sort(urls)
+	return slices.Compact(urls), nil
+}
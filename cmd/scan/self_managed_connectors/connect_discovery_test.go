@@ -0,0 +1,56 @@
+package self_managed_connectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInstanceDescriber struct {
+	tags      map[string]string
+	instances []ec2types.Instance
+}
+
+func (f *fakeInstanceDescriber) DescribeRunningInstancesByTags(_ context.Context, tags map[string]string) ([]ec2types.Instance, error) {
+	f.tags = tags
+	return f.instances, nil
+}
+
+func TestParseEC2TagFilters(t *testing.T) {
+	tags, err := parseEC2TagFilters([]string{"role=kafka-connect", "env=prod=eu", "connect"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"role": "kafka-connect", "env": "prod=eu", "connect": ""}, tags)
+
+	_, err = parseEC2TagFilters([]string{"=kafka-connect"})
+	assert.ErrorContains(t, err, "invalid --connect-ec2-tag")
+}
+
+func TestResolveEC2Region(t *testing.T) {
+	region, err := resolveEC2Region("", "arn:aws:kafka:eu-west-1:123456789012:cluster/orders/abc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", region)
+
+	region, err = resolveEC2Region("us-east-2", "arn:aws:kafka:eu-west-1:123456789012:cluster/orders/abc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-2", region, "--connect-ec2-region wins over the cluster's region")
+
+	_, err = resolveEC2Region("", "")
+	assert.ErrorContains(t, err, "--connect-ec2-region is required")
+}
+
+func TestDiscoverConnectURLs(t *testing.T) {
+	describer := &fakeInstanceDescriber{instances: []ec2types.Instance{
+		{InstanceId: aws.String("i-2"), PrivateIpAddress: aws.String("10.0.1.12")},
+		{InstanceId: aws.String("i-1"), PrivateIpAddress: aws.String("10.0.1.11")},
+		{InstanceId: aws.String("i-3")},
+	}}
+
+	urls, err := discoverConnectURLs(context.Background(), describer, map[string]string{"role": "kafka-connect"}, "https", 8443)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://10.0.1.11:8443", "https://10.0.1.12:8443"}, urls, "instances without a private IP are skipped")
+	assert.Equal(t, map[string]string{"role": "kafka-connect"}, describer.tags)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -26,6 +27,12 @@ type ConnectAPIClient interface {
 	GetConnectorStatus(name string) (map[string]any, error)
 }
 
+// connectEndpoint is one Connect REST endpoint to scan.
+type connectEndpoint struct {
+	url    string
+	client ConnectAPIClient
+}
+
 type HTTPConnectClient struct {
 	baseURL    string
 	httpClient *http.Client
@@ -34,15 +41,18 @@ type HTTPConnectClient struct {
 }
 
 type SelfManagedConnectorsScannerOpts struct {
-	StateFile      string
-	State          *types.State
-	ConnectRestURL string
-	SourceType     types.SourceType
-	ClusterArn     string
-	ClusterID      string
-	AuthMethod     types.ConnectAuthMethod
-	SaslScramAuth  types.ConnectSaslScramAuth
-	TlsAuth        types.ConnectTlsAuth
+	StateFile string
+	State     *types.State
+	// ConnectRestURLs are the Connect REST endpoints to scan: workers of one
+	// Connect cluster, or of several Connect clusters serving the same Kafka
+	// cluster.
+	ConnectRestURLs []string
+	SourceType      types.SourceType
+	ClusterArn      string
+	ClusterID       string
+	AuthMethod      types.ConnectAuthMethod
+	SaslScramAuth   types.ConnectSaslScramAuth
+	TlsAuth         types.ConnectTlsAuth
 
 	MetricsSource       string
 	MetricsClusterCreds *types.OSKClusterAuth
@@ -57,7 +67,7 @@ type SelfManagedConnectorsScanner struct {
 	SourceType types.SourceType
 	ClusterArn string
 	ClusterID  string
-	client     ConnectAPIClient
+	endpoints  []connectEndpoint
 
 	metricsSource       string
 	metricsClusterCreds *types.OSKClusterAuth
@@ -72,11 +82,17 @@ func NewSelfManagedConnectorsScanner(opts SelfManagedConnectorsScannerOpts) (*Se
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	connectClient := &HTTPConnectClient{
-		baseURL:    opts.ConnectRestURL,
-		httpClient: httpClient,
-		authMethod: opts.AuthMethod,
-		saslAuth:   opts.SaslScramAuth,
+	var endpoints []connectEndpoint
+	for _, url := range opts.ConnectRestURLs {
+		endpoints = append(endpoints, connectEndpoint{
+			url: url,
+			client: &HTTPConnectClient{
+				baseURL:    url,
+				httpClient: httpClient,
+				authMethod: opts.AuthMethod,
+				saslAuth:   opts.SaslScramAuth,
+			},
+		})
 	}
 
 	return &SelfManagedConnectorsScanner{
@@ -85,7 +101,7 @@ func NewSelfManagedConnectorsScanner(opts SelfManagedConnectorsScannerOpts) (*Se
 		SourceType:          opts.SourceType,
 		ClusterArn:          opts.ClusterArn,
 		ClusterID:           opts.ClusterID,
-		client:              connectClient,
+		endpoints:           endpoints,
 		metricsSource:       opts.MetricsSource,
 		metricsClusterCreds: opts.MetricsClusterCreds,
 		metricsDuration:     opts.MetricsDuration,
@@ -127,39 +143,69 @@ func createHTTPClient(authMethod types.ConnectAuthMethod, tlsAuth types.ConnectT
 	return client, nil
 }
 
-func (s *SelfManagedConnectorsScanner) Run() error {
-	if s.client == nil {
+func (s *SelfManagedConnectorsScanner) Run(ctx context.Context) error {
+	if len(s.endpoints) == 0 {
 		return fmt.Errorf("connect API client not initialized")
 	}
 
 	clusterName := utils.GetClusterDisplayName(s.SourceType, s.ClusterArn, s.ClusterID)
 	fmt.Printf("🚀 Starting self-managed connector scan for cluster %s\n", clusterName)
-	slog.Info("🔍 scanning self-managed connectors", "cluster", clusterName)
-
-	connectorNames, err := s.client.ListConnectors()
-	if err != nil {
-		return fmt.Errorf("failed to list connectors: %v", err)
-	}
-
-	fmt.Printf("  🔍 Found %d connectors\n", len(connectorNames))
-	slog.Info("🔍 found connectors", "count", len(connectorNames))
-
-	if len(connectorNames) == 0 {
-		fmt.Printf("  ⏭️  No connectors found for cluster %s, skipping\n", clusterName)
-		slog.Info("⏭️ no connectors found; skipping", "cluster", clusterName)
-		return nil
-	}
+	slog.Info("🔍 scanning self-managed connectors", "cluster", clusterName, "endpoints", len(s.endpoints))
 
 	connectors := []types.SelfManagedConnector{}
 	totalRedacted := 0
-	for _, name := range connectorNames {
-		connector, redactedCount, err := s.getConnectorDetails(name)
+	found := 0
+	// Workers of the same Connect cluster all list its connectors, so each
+	// connector is only read from the first endpoint that lists it.
+	seen := make(map[string]bool)
+	var listErrs []error
+scan:
+	for _, endpoint := range s.endpoints {
+		if ctx.Err() != nil {
+			break
+		}
+		connectorNames, err := endpoint.client.ListConnectors()
 		if err != nil {
-			slog.Warn(fmt.Sprintf("⚠️ failed to get connector details for connector %s: %v", name, err))
+			listErrs = append(listErrs, fmt.Errorf("%s: %v", endpoint.url, err))
+			slog.Warn("⚠️ failed to list connectors", "endpoint", endpoint.url, "error", err)
 			continue
 		}
-		totalRedacted += redactedCount
-		connectors = append(connectors, connector)
+		if len(s.endpoints) > 1 {
+			fmt.Printf("  🔍 Found %d connectors at %s\n", len(connectorNames), endpoint.url)
+		} else {
+			fmt.Printf("  🔍 Found %d connectors\n", len(connectorNames))
+		}
+		slog.Info("🔍 found connectors", "endpoint", endpoint.url, "count", len(connectorNames))
+
+		for _, name := range connectorNames {
+			if ctx.Err() != nil {
+				break scan
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			found++
+			connector, redactedCount, err := s.getConnectorDetails(endpoint, name)
+			if err != nil {
+				slog.Warn(fmt.Sprintf("⚠️ failed to get connector details for connector %s: %v", name, err))
+				continue
+			}
+			totalRedacted += redactedCount
+			connectors = append(connectors, connector)
+		}
+	}
+	if ctx.Err() != nil {
+		return s.saveInterruptedScan(ctx, clusterName, connectors)
+	}
+	if len(listErrs) == len(s.endpoints) {
+		return fmt.Errorf("failed to list connectors: %v", errors.Join(listErrs...))
+	}
+
+	if found == 0 {
+		fmt.Printf("  ⏭️  No connectors found for cluster %s, skipping\n", clusterName)
+		slog.Info("⏭️ no connectors found; skipping", "cluster", clusterName)
+		return nil
 	}
 
 	fmt.Printf("  ✅ Successfully retrieved connector details for %d connectors\n", len(connectors))
@@ -177,7 +223,7 @@ func (s *SelfManagedConnectorsScanner) Run() error {
 	// discovery errors). Runs without --metrics skip this entirely.
 	if s.metricsSource != "" {
 		slog.Info("collecting Connect worker metrics", "source", s.metricsSource, "cluster", clusterName)
-		metrics, err := s.collectConnectMetrics(context.Background())
+		metrics, err := s.collectConnectMetrics(ctx)
 		if err != nil {
 			slog.Warn("Connect metrics collection failed; connectors persisted without metrics", "source", s.metricsSource, "error", err)
 			fmt.Printf("  ⚠️  Connect metrics collection failed; connectors persisted without metrics\n")
@@ -198,25 +244,27 @@ func (s *SelfManagedConnectorsScanner) Run() error {
 	return nil
 }
 
-// getConnectorDetails fetches a connector's config and status. The config is
-// redacted (sensitive values replaced) before it is stored on the connector, so
-// raw secrets never enter the persisted state. The connector's worker_id (when
-// present) is captured as ConnectHost for per-host grouping in the UI. Returns
-// the connector, the number of redacted fields, and any error.
-func (s *SelfManagedConnectorsScanner) getConnectorDetails(name string) (types.SelfManagedConnector, int, error) {
-	slog.Debug("🔍 fetching connector details", "connector", name)
+// getConnectorDetails fetches a connector's config and status from endpoint.
+// The config is redacted (sensitive values replaced) before it is stored on the
+// connector, so raw secrets never enter the persisted state. The connector's
+// worker_id (when present) is captured as ConnectHost for per-host grouping in
+// the UI, and the task states are recorded alongside. Returns the connector,
+// the number of redacted fields, and any error.
+func (s *SelfManagedConnectorsScanner) getConnectorDetails(endpoint connectEndpoint, name string) (types.SelfManagedConnector, int, error) {
+	slog.Debug("🔍 fetching connector details", "connector", name, "endpoint", endpoint.url)
 	connector := types.SelfManagedConnector{
-		Name: name,
+		Name:       name,
+		ConnectURL: endpoint.url,
 	}
 
-	config, err := s.client.GetConnectorConfig(name)
+	config, err := endpoint.client.GetConnectorConfig(name)
 	if err != nil {
 		return connector, 0, fmt.Errorf("failed to get config: %w", err)
 	}
 	redactedConfig, redactedCount := redact.RedactAnyMap(config)
 	connector.Config = redactedConfig
 
-	status, err := s.client.GetConnectorStatus(name)
+	status, err := endpoint.client.GetConnectorStatus(name)
 	if err != nil {
 		slog.Warn(fmt.Sprintf("⚠️ failed to get connector status for connector %s: %v", name, err))
 	} else {
@@ -228,11 +276,35 @@ func (s *SelfManagedConnectorsScanner) getConnectorDetails(name string) (types.S
 				connector.ConnectHost = workerID
 			}
 		}
+		connector.Tasks = parseTaskStatuses(status["tasks"])
 	}
 
 	return connector, redactedCount, nil
 }
 
+// parseTaskStatuses reads the tasks array of a connector status response.
+func parseTaskStatuses(raw any) []types.SelfManagedConnectorTask {
+	entries, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	var tasks []types.SelfManagedConnectorTask
+	for _, entry := range entries {
+		fields, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		var task types.SelfManagedConnectorTask
+		if id, ok := fields["id"].(float64); ok {
+			task.ID = int(id)
+		}
+		task.State, _ = fields["state"].(string)
+		task.WorkerID, _ = fields["worker_id"].(string)
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
 func (c *HTTPConnectClient) ListConnectors() ([]string, error) {
 	url := fmt.Sprintf("%s/connectors", c.baseURL)
 
@@ -350,6 +422,24 @@ func (s *SelfManagedConnectorsScanner) resolveKafkaAdminInfo() (*types.KafkaAdmi
 	}
 }
 
+// saveInterruptedScan merges the connectors read before ctx was cancelled
+// into state, keeping the stored connectors this scan did not reach, and
+// returns the interruption error. Metrics are not collected.
+func (s *SelfManagedConnectorsScanner) saveInterruptedScan(ctx context.Context, clusterName string, connectors []types.SelfManagedConnector) error {
+	if len(connectors) > 0 {
+		info, err := s.resolveKafkaAdminInfo()
+		if err != nil {
+			return fmt.Errorf("failed to update state: %v", err)
+		}
+		info.MergeSelfManagedConnectors(connectors)
+		if err := s.State.PersistStateFile(s.StateFile); err != nil {
+			return fmt.Errorf("failed to save state file: %v", err)
+		}
+	}
+	fmt.Printf("⚠️  Self-managed connector scan of cluster %s interrupted (%v); saved %d connector(s). Re-run to scan the rest\n", clusterName, context.Cause(ctx), len(connectors))
+	return fmt.Errorf("self-managed connector scan interrupted: %w", context.Cause(ctx))
+}
+
 func (s *SelfManagedConnectorsScanner) updateStateWithConnectors(connectors []types.SelfManagedConnector) error {
 	info, err := s.resolveKafkaAdminInfo()
 	if err != nil {
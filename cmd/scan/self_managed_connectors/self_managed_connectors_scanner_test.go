@@ -57,7 +57,7 @@ func newScannerWithClient(t *testing.T, st *types.State, arn string, client Conn
 		State:      st,
 		SourceType: types.SourceTypeMSK,
 		ClusterArn: arn,
-		client:     client,
+		endpoints:  []connectEndpoint{{url: "http://connect:8083", client: client}},
 	}, stateFile
 }
 
@@ -84,7 +84,7 @@ func TestScanner_RedactsConfigBeforePersist(t *testing.T) {
 	st := stateWithCluster()
 	scanner, stateFile := newScannerWithClient(t, st, testArn, client)
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -108,7 +108,7 @@ func TestScanner_NoConnectors(t *testing.T) {
 	}
 	st := stateWithCluster()
 	scanner, _ := newScannerWithClient(t, st, testArn, client)
-	require.NoError(t, scanner.Run(), "empty connector list is not an error")
+	require.NoError(t, scanner.Run(context.Background()), "empty connector list is not an error")
 }
 
 func TestScanner_ClusterArnNotFound(t *testing.T) {
@@ -119,7 +119,7 @@ func TestScanner_ClusterArnNotFound(t *testing.T) {
 	}
 	st := stateWithCluster()
 	scanner, _ := newScannerWithClient(t, st, "arn:aws:kafka:us-east-1:999:cluster/missing/x", client)
-	require.Error(t, scanner.Run(), "cluster ARN not present in state is an error")
+	require.Error(t, scanner.Run(context.Background()), "cluster ARN not present in state is an error")
 }
 
 func TestScanner_DoesNotLogRawSecret(t *testing.T) {
@@ -135,7 +135,7 @@ func TestScanner_DoesNotLogRawSecret(t *testing.T) {
 	}
 	st := stateWithCluster()
 	scanner, _ := newScannerWithClient(t, st, testArn, client)
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 	assert.NotContains(t, buf.String(), "hunter2", "raw secret must never be logged")
 }
 
@@ -150,8 +150,8 @@ func TestScanner_GetConnectorDetails_CapturesWorkerID(t *testing.T) {
 			return map[string]any{"connector": map[string]any{"state": "RUNNING", "worker_id": "connect-worker-1:8083"}}, nil
 		},
 	}
-	s := &SelfManagedConnectorsScanner{client: client}
-	conn, _, err := s.getConnectorDetails("c1")
+	s := &SelfManagedConnectorsScanner{}
+	conn, _, err := s.getConnectorDetails(connectEndpoint{client: client}, "c1")
 	require.NoError(t, err)
 	assert.Equal(t, "RUNNING", conn.State)
 	assert.Equal(t, "connect-worker-1:8083", conn.ConnectHost, "ConnectHost populated from connector.worker_id")
@@ -164,8 +164,8 @@ func TestScanner_GetConnectorDetails_MissingWorkerID(t *testing.T) {
 			return map[string]any{"connector": map[string]any{"state": "RUNNING"}}, nil
 		},
 	}
-	s := &SelfManagedConnectorsScanner{client: client}
-	conn, _, err := s.getConnectorDetails("c1")
+	s := &SelfManagedConnectorsScanner{}
+	conn, _, err := s.getConnectorDetails(connectEndpoint{client: client}, "c1")
 	require.NoError(t, err)
 	assert.Equal(t, "", conn.ConnectHost, "absent worker_id leaves ConnectHost empty")
 }
@@ -177,8 +177,8 @@ func TestScanner_GetConnectorDetails_NonStringWorkerID(t *testing.T) {
 			return map[string]any{"connector": map[string]any{"state": "RUNNING", "worker_id": nil}}, nil
 		},
 	}
-	s := &SelfManagedConnectorsScanner{client: client}
-	conn, _, err := s.getConnectorDetails("c1")
+	s := &SelfManagedConnectorsScanner{}
+	conn, _, err := s.getConnectorDetails(connectEndpoint{client: client}, "c1")
 	require.NoError(t, err)
 	assert.Equal(t, "", conn.ConnectHost, "non-string worker_id is ignored")
 }
@@ -279,8 +279,8 @@ func TestScanner_Run_OSK_PersistsConnectors(t *testing.T) {
 	}
 	st := stateWithOSKCluster()
 	stateFile := filepath.Join(t.TempDir(), "kcp-state.json")
-	s := &SelfManagedConnectorsScanner{StateFile: stateFile, State: st, SourceType: types.SourceTypeOSK, ClusterID: testOSKID, client: client}
-	require.NoError(t, s.Run())
+	s := &SelfManagedConnectorsScanner{StateFile: stateFile, State: st, SourceType: types.SourceTypeOSK, ClusterID: testOSKID, endpoints: []connectEndpoint{{client: client}}}
+	require.NoError(t, s.Run(context.Background()))
 
 	cl, err := st.GetOSKClusterByID(testOSKID)
 	require.NoError(t, err)
@@ -288,6 +288,62 @@ func TestScanner_Run_OSK_PersistsConnectors(t *testing.T) {
 	assert.Len(t, cl.KafkaAdminClientInformation.SelfManagedConnectors.Connectors, 1)
 }
 
+func TestScanner_Run_MultipleEndpoints(t *testing.T) {
+	endpointClient := func(names ...string) *mockConnectClient {
+		return &mockConnectClient{
+			listFn:   func() ([]string, error) { return names, nil },
+			configFn: func(string) (map[string]any, error) { return map[string]any{"tasks.max": "2"}, nil },
+			statusFn: func(string) (map[string]any, error) {
+				return map[string]any{
+					"connector": map[string]any{"state": "RUNNING", "worker_id": "10.0.0.1:8083"},
+					"tasks": []any{
+						map[string]any{"id": float64(0), "state": "RUNNING", "worker_id": "10.0.0.1:8083"},
+						map[string]any{"id": float64(1), "state": "FAILED", "worker_id": "10.0.0.2:8083"},
+					},
+				}, nil
+			},
+		}
+	}
+	unreachable := &mockConnectClient{listFn: func() ([]string, error) { return nil, errors.New("connection refused") }}
+	st := stateWithCluster()
+	s, _ := newScannerWithClient(t, st, testArn, nil)
+	s.endpoints = []connectEndpoint{
+		{url: "http://10.0.0.1:8083", client: endpointClient("orders-sink", "payments-source")},
+		{url: "http://10.0.0.2:8083", client: endpointClient("orders-sink", "payments-source")},
+		{url: "http://10.0.0.3:8083", client: unreachable},
+		{url: "http://connect-b:8083", client: endpointClient("audit-sink")},
+	}
+	require.NoError(t, s.Run(context.Background()), "an unreachable endpoint must not fail the scan")
+
+	cl, _ := st.GetClusterByArn(testArn)
+	connectors := cl.KafkaAdminClientInformation.SelfManagedConnectors.Connectors
+	require.Len(t, connectors, 3, "connectors listed by several workers are recorded once")
+	urls := map[string]string{}
+	for _, c := range connectors {
+		urls[c.Name] = c.ConnectURL
+	}
+	assert.Equal(t, map[string]string{
+		"orders-sink":     "http://10.0.0.1:8083",
+		"payments-source": "http://10.0.0.1:8083",
+		"audit-sink":      "http://connect-b:8083",
+	}, urls)
+	assert.Equal(t, []types.SelfManagedConnectorTask{
+		{ID: 0, State: "RUNNING", WorkerID: "10.0.0.1:8083"},
+		{ID: 1, State: "FAILED", WorkerID: "10.0.0.2:8083"},
+	}, connectors[0].Tasks)
+}
+
+func TestScanner_Run_AllEndpointsUnreachable(t *testing.T) {
+	unreachable := &mockConnectClient{listFn: func() ([]string, error) { return nil, errors.New("connection refused") }}
+	s, _ := newScannerWithClient(t, stateWithCluster(), testArn, nil)
+	s.endpoints = []connectEndpoint{{url: "http://a:8083", client: unreachable}, {url: "http://b:8083", client: unreachable}}
+
+	err := s.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "http://a:8083")
+	assert.Contains(t, err.Error(), "http://b:8083")
+}
+
 func TestScanner_Run_PartialFailure(t *testing.T) {
 	client := &mockConnectClient{
 		listFn: func() ([]string, error) { return []string{"good", "bad", "good2"}, nil },
@@ -303,13 +359,47 @@ func TestScanner_Run_PartialFailure(t *testing.T) {
 	}
 	st := stateWithCluster()
 	s, _ := newScannerWithClient(t, st, testArn, client)
-	require.NoError(t, s.Run(), "a single connector failure must not fail the whole scan")
+	require.NoError(t, s.Run(context.Background()), "a single connector failure must not fail the whole scan")
 
 	cl, _ := st.GetClusterByArn(testArn)
 	require.NotNil(t, cl.KafkaAdminClientInformation.SelfManagedConnectors)
 	assert.Len(t, cl.KafkaAdminClientInformation.SelfManagedConnectors.Connectors, 2, "only the healthy connectors are recorded")
 }
 
+func TestScanner_Run_InterruptedSavesConnectorsRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &mockConnectClient{
+		listFn: func() ([]string, error) { return []string{"orders-sink", "payments-source"}, nil },
+		configFn: func(name string) (map[string]any, error) {
+			// Ctrl-C arrives while the first connector is being read.
+			cancel()
+			return map[string]any{"tasks.max": "1"}, nil
+		},
+		statusFn: func(string) (map[string]any, error) {
+			return map[string]any{"connector": map[string]any{"state": "RUNNING"}}, nil
+		},
+	}
+	st := stateWithCluster()
+	cl, _ := st.GetClusterByArn(testArn)
+	cl.KafkaAdminClientInformation.SetSelfManagedConnectors([]types.SelfManagedConnector{{Name: "payments-source", State: "PAUSED"}})
+	s, stateFile := newScannerWithClient(t, st, testArn, client)
+
+	err := s.Run(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	saved, err := types.NewStateFromFile(stateFile)
+	require.NoError(t, err)
+	savedCluster, err := saved.GetClusterByArn(testArn)
+	require.NoError(t, err)
+	states := map[string]string{}
+	for _, c := range savedCluster.KafkaAdminClientInformation.SelfManagedConnectors.Connectors {
+		states[c.Name] = c.State
+	}
+	assert.Equal(t, map[string]string{"orders-sink": "RUNNING", "payments-source": "PAUSED"}, states,
+		"the connector read is saved and the one not reached keeps its stored entry")
+}
+
 // A metrics-collection failure must never abort the connector scan: connectors
 // are already persisted and the metrics error is logged as a warning only.
 func TestScanner_Run_MetricsFailureDoesNotAbortScan(t *testing.T) {
@@ -325,9 +415,9 @@ func TestScanner_Run_MetricsFailureDoesNotAbortScan(t *testing.T) {
 	// metricsSource is set but no creds are provided, so collectConnectMetrics errors.
 	s := &SelfManagedConnectorsScanner{
 		StateFile: stateFile, State: st, SourceType: types.SourceTypeMSK, ClusterArn: testArn,
-		client: client, metricsSource: "jolokia", metricsClusterCreds: nil,
+		endpoints: []connectEndpoint{{client: client}}, metricsSource: "jolokia", metricsClusterCreds: nil,
 	}
-	require.NoError(t, s.Run(), "metrics collection failure must not abort the scan")
+	require.NoError(t, s.Run(context.Background()), "metrics collection failure must not abort the scan")
 
 	cl, _ := st.GetClusterByArn(testArn)
 	require.NotNil(t, cl.KafkaAdminClientInformation.SelfManagedConnectors)
@@ -410,8 +500,8 @@ func TestNewScanner_TLSCertLoadFailureReturnsError(t *testing.T) {
 	// A bad TLS cert path must surface as an error from the constructor, not a
 	// later nil-pointer panic when the scanner runs.
 	opts := SelfManagedConnectorsScannerOpts{
-		ConnectRestURL: "http://localhost:8083",
-		AuthMethod:     types.ConnectAuthMethodTls,
+		ConnectRestURLs: []string{"http://localhost:8083"},
+		AuthMethod:      types.ConnectAuthMethodTls,
 		TlsAuth: types.ConnectTlsAuth{
 			CACert:     filepath.Join(t.TempDir(), "missing-ca.pem"),
 			ClientCert: filepath.Join(t.TempDir(), "missing-cert.pem"),
@@ -614,7 +704,7 @@ func TestRun_JolokiaMetricsAttached(t *testing.T) {
 		Jolokia: &types.JolokiaConfig{Endpoints: []string{srv.URL}},
 	}
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -640,7 +730,7 @@ func TestRun_PrometheusMetricsAttached(t *testing.T) {
 		Prometheus: &types.PrometheusConfig{URL: srv.URL},
 	}
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -658,7 +748,7 @@ func TestRun_NoMetrics_MetricsNil(t *testing.T) {
 	st := stateWithCluster()
 	scanner, _ := newScannerWithClient(t, st, testArn, connectMockClient())
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -682,7 +772,7 @@ func TestRun_JolokiaUnreachable_NonFatal(t *testing.T) {
 		Jolokia: &types.JolokiaConfig{Endpoints: []string{addr}},
 	}
 
-	require.NoError(t, scanner.Run(), "metrics failure must not fail the scan")
+	require.NoError(t, scanner.Run(context.Background()), "metrics failure must not fail the scan")
 
 	data, err := os.ReadFile(stateFile)
 	require.NoError(t, err)
@@ -706,7 +796,7 @@ func TestRun_JolokiaMalformed_NonFatal(t *testing.T) {
 		Jolokia: &types.JolokiaConfig{Endpoints: []string{srv.URL}},
 	}
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	data, err := os.ReadFile(stateFile)
 	require.NoError(t, err)
@@ -738,7 +828,7 @@ func TestRun_MetricsFailure_NoSecretInLogs(t *testing.T) {
 		},
 	}
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 	require.NotContains(t, buf.String(), secret, "credential value must never appear in logs (R11)")
 }
 
@@ -761,7 +851,7 @@ func TestRun_JolokiaTLSHonored(t *testing.T) {
 		},
 	}
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -786,11 +876,11 @@ func TestRun_ReRunWithoutMetrics_PreservesMetrics(t *testing.T) {
 	st1, err := types.NewStateFromFile(stateFile)
 	require.NoError(t, err)
 	scanner1 := &SelfManagedConnectorsScanner{
-		StateFile: stateFile, State: st1, SourceType: types.SourceTypeMSK, ClusterArn: testArn, client: connectMockClient(),
+		StateFile: stateFile, State: st1, SourceType: types.SourceTypeMSK, ClusterArn: testArn, endpoints: []connectEndpoint{{client: connectMockClient()}},
 		metricsSource: "jolokia", metricsDuration: "500ms", metricsInterval: "100ms",
 		metricsClusterCreds: &types.OSKClusterAuth{ID: testArn, Jolokia: &types.JolokiaConfig{Endpoints: []string{srv.URL}}},
 	}
-	require.NoError(t, scanner1.Run())
+	require.NoError(t, scanner1.Run(context.Background()))
 
 	st2, err := types.NewStateFromFile(stateFile)
 	require.NoError(t, err)
@@ -800,9 +890,9 @@ func TestRun_ReRunWithoutMetrics_PreservesMetrics(t *testing.T) {
 
 	// Run 2: re-scan WITHOUT --metrics on the reloaded state.
 	scanner2 := &SelfManagedConnectorsScanner{
-		StateFile: stateFile, State: st2, SourceType: types.SourceTypeMSK, ClusterArn: testArn, client: connectMockClient(),
+		StateFile: stateFile, State: st2, SourceType: types.SourceTypeMSK, ClusterArn: testArn, endpoints: []connectEndpoint{{client: connectMockClient()}},
 	}
-	require.NoError(t, scanner2.Run())
+	require.NoError(t, scanner2.Run(context.Background()))
 
 	st3, err := types.NewStateFromFile(stateFile)
 	require.NoError(t, err)
@@ -831,7 +921,7 @@ func TestRun_ZeroConnectorsWithMetrics_SkipsMetrics(t *testing.T) {
 	scanner.metricsInterval = "100ms"
 	scanner.metricsClusterCreds = &types.OSKClusterAuth{ID: testArn, Jolokia: &types.JolokiaConfig{Endpoints: []string{srv.URL}}}
 
-	require.NoError(t, scanner.Run(), "zero connectors is not an error")
+	require.NoError(t, scanner.Run(context.Background()), "zero connectors is not an error")
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -856,7 +946,7 @@ func TestRun_PrometheusTLSHonored(t *testing.T) {
 		},
 	}
 
-	require.NoError(t, scanner.Run())
+	require.NoError(t, scanner.Run(context.Background()))
 
 	cluster, err := st.GetClusterByArn(testArn)
 	require.NoError(t, err)
@@ -932,7 +1022,11 @@ func TestCmd_PrometheusRejectsBadRange(t *testing.T) {
 
 func resetCmdGlobals() {
 	stateFile = ""
-	connectRestURL = ""
+	connectRestURLs = nil
+	connectEC2Tags = nil
+	connectEC2Region = ""
+	connectEC2Port = 8083
+	connectEC2Scheme = "http"
 	clusterID = ""
 	sourceType = ""
 	useSaslScram = false
@@ -960,11 +1054,11 @@ func writeStateFile(t *testing.T, st *types.State) string {
 func TestParseOpts_AutoDetectsMSKFromArn(t *testing.T) {
 	resetCmdGlobals()
 	stateFile = writeStateFile(t, stateWithCluster())
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testArn
 	useUnauthenticated = true
 
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, types.SourceTypeMSK, opts.SourceType)
 	assert.Equal(t, testArn, opts.ClusterArn)
@@ -974,11 +1068,11 @@ func TestParseOpts_AutoDetectsMSKFromArn(t *testing.T) {
 func TestParseOpts_AutoDetectsOSKFromNonArn(t *testing.T) {
 	resetCmdGlobals()
 	stateFile = writeStateFile(t, stateWithOSKCluster())
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testOSKID
 	useUnauthenticated = true
 
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, types.SourceTypeOSK, opts.SourceType)
 	assert.Equal(t, testOSKID, opts.ClusterID)
@@ -990,12 +1084,12 @@ func TestParseOpts_ExplicitSourceTypeOverridesAutoDetect(t *testing.T) {
 	resetCmdGlobals()
 	st := &types.State{OSKSources: &types.OSKSourcesState{Clusters: []types.OSKDiscoveredCluster{{ID: testArn}}}}
 	stateFile = writeStateFile(t, st)
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testArn
 	sourceType = "osk"
 	useUnauthenticated = true
 
-	opts, err := parseScanSelfManagedConnectorsOpts()
+	opts, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, types.SourceTypeOSK, opts.SourceType)
 	assert.Equal(t, testArn, opts.ClusterID)
@@ -1004,12 +1098,12 @@ func TestParseOpts_ExplicitSourceTypeOverridesAutoDetect(t *testing.T) {
 func TestParseOpts_InvalidSourceTypeRejected(t *testing.T) {
 	resetCmdGlobals()
 	stateFile = writeStateFile(t, stateWithOSKCluster())
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testOSKID
 	sourceType = "bogus"
 	useUnauthenticated = true
 
-	_, err := parseScanSelfManagedConnectorsOpts()
+	_, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "source-type")
 }
@@ -1017,11 +1111,11 @@ func TestParseOpts_InvalidSourceTypeRejected(t *testing.T) {
 func TestParseOpts_ClusterNotInState(t *testing.T) {
 	resetCmdGlobals()
 	stateFile = writeStateFile(t, stateWithOSKCluster())
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = "not-present"
 	useUnauthenticated = true
 
-	_, err := parseScanSelfManagedConnectorsOpts()
+	_, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.Error(t, err)
 }
 
@@ -1030,7 +1124,7 @@ func TestParseOpts_ClusterNotInState(t *testing.T) {
 func TestParseOpts_MetricsClusterMissingFromCredsFile(t *testing.T) {
 	resetCmdGlobals()
 	stateFile = writeStateFile(t, stateWithOSKCluster())
-	connectRestURL = "http://localhost:8083"
+	connectRestURLs = []string{"http://localhost:8083"}
 	clusterID = testOSKID
 	useUnauthenticated = true
 	metricsSource = "jolokia"
@@ -1050,7 +1144,7 @@ func TestParseOpts_MetricsClusterMissingFromCredsFile(t *testing.T) {
 	require.NoError(t, os.WriteFile(credsPath, []byte(credsYAML), 0o600))
 	credentialsFile = credsPath
 
-	_, err := parseScanSelfManagedConnectorsOpts()
+	_, err := parseScanSelfManagedConnectorsOpts(context.Background())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "credentials file")
 }
@@ -0,0 +1,109 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	host     string
+	port     string
+	token    string
+	queueLen int
+)
+
+func NewServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve discovery, scans and reports over an HTTP API",
+		Long: `Starts an HTTP API that runs kcp discover, scan and report commands as jobs, so platform portals can trigger them programmatically and poll for results instead of shelling out to the binary.
+
+Jobs take the same flags as the CLI, limited per command to those that shape what is read: flags that redirect output or send results elsewhere (--output-dir, --upload-to, --notify-webhook, --otlp-endpoint), change redaction or auditing, or load plugins are rejected, and --state-file is always the kcp-state.json of the current directory. Passwords and secrets are not flags: send them in the request's "secrets" object, e.g. {"command": "scan kafka", "flags": {...}, "secrets": {"sasl-scram-password": "..."}}. They reach the command through its environment and never appear in job arguments, logs or API responses. GET /api/v1/commands lists the flags and secrets each command accepts. Jobs run one at a time in the current directory, sharing kcp-state.json and the credentials files just as consecutive CLI runs would.
+
+Endpoints:
+  GET  /health                       Liveness check (no auth)
+  GET  /api/v1/commands              Commands a job may run and the flags and secrets each accepts
+  POST /api/v1/jobs                  Queue a job: {"command": "discover", "flags": {"region": ["us-east-1"], "skip-costs": true}}
+  GET  /api/v1/jobs                  List jobs
+  GET  /api/v1/jobs/{id}             Job status, exit code and the files it wrote
+  GET  /api/v1/jobs/{id}/output      Combined stdout/stderr of the job
+  GET  /api/v1/jobs/{id}/files/{path} Download a file the job wrote
+  GET  /api/v1/state                 Current kcp-state.json
+
+When --token is set, /api/v1 requests must send "Authorization: Bearer <token>". --token is required unless --host is a loopback address.`,
+		Example: `  # Listen on localhost:5557
+  kcp serve
+
+  # Listen on all interfaces with a bearer token
+  kcp serve --host 0.0.0.0 --token "$KCP_SERVE_TOKEN"
+
+  # Queue a discover job and poll it
+  curl -X POST localhost:5557/api/v1/jobs -d '{"command":"discover","flags":{"region":["us-east-1"]}}' -H 'Content-Type: application/json'
+  curl localhost:5557/api/v1/jobs/<id>`,
+		SilenceErrors: true,
+		PreRunE:       preRunServe,
+		RunE:          runServe,
+	}
+
+	cmd.Flags().StringVar(&host, "host", "localhost", "Address to listen on")
+	cmd.Flags().StringVarP(&port, "port", "p", "5557", "Port to listen on")
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token required on /api/v1 requests (required when --host is not a loopback address)")
+	cmd.Flags().IntVar(&queueLen, "queue-length", 100, "Maximum number of queued jobs")
+
+	return cmd
+}
+
+func preRunServe(cmd *cobra.Command, args []string) error {
+	if err := utils.BindEnvToFlags(cmd); err != nil {
+		return err
+	}
+
+	if token == "" && !isLoopback(host) {
+		return fmt.Errorf("--token is required when --host %s is not a loopback address", host)
+	}
+
+	return nil
+}
+
+// isLoopback reports whether host only accepts connections from this
+// machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the kcp binary: %v", err)
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %v", err)
+	}
+
+	server := NewServer(execRunner{binary: binary}, ServerOpts{
+		Host:     host,
+		Port:     port,
+		Token:    token,
+		WorkDir:  workDir,
+		QueueLen: queueLen,
+	})
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.Run(ctx); err != nil {
+		return fmt.Errorf("failed to start the server: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,610 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/labstack/echo/v4"
+)
+
+// jobCommand is a kcp command a job may run and the flags a request may set
+// on it.
+type jobCommand struct {
+	path  []string
+	flags []string
+	// secrets are the credential flags a request may set through its
+	// secrets field. They reach the job as environment variables, never as
+	// arguments.
+	secrets []string
+	// stateFile pins --state-file to the kcp-state.json in the work
+	// directory, which discover writes and every job shares.
+	stateFile bool
+}
+
+// allowedCommands are the kcp commands a job may run, keyed by the command
+// path a request names. Everything else (serve, update, migration, ...) is
+// rejected. Each command only accepts the flags listed for it: flags that
+// choose where results go or who receives them (--output-dir, --upload-to,
+// --notify-webhook, --state-file), load plugins or change redaction and
+// auditing are never forwarded, so a job can only read from the clusters and
+// write into the work directory. Passwords and secrets are never flags: they
+// are listed as secrets and passed in the job's environment.
+var allowedCommands = map[string]jobCommand{
+	"discover": {path: []string{"discover"}, flags: []string{
+		"cluster-arn", "cluster-types", "metrics-granularity", "page-size-clusters", "page-size-nodes",
+		"page-size-operations", "page-size-vpc-connections", "region", "resume", "scan-secrets", "since",
+		"skip-costs", "skip-metrics", "skip-topics", "states",
+	}},
+	"scan clusters": {path: []string{"scan", "clusters"}, stateFile: true, flags: []string{
+		"auth-type", "bundle", "credentials-file", "fail-on-unhealthy", "insecure-skip-tls-verify", "inspect-tls",
+		"kafka-dial-timeout", "kafka-read-timeout", "kafka-version", "metrics", "metrics-duration", "metrics-interval",
+		"metrics-range", "resume", "sample-messages", "skip-acls", "skip-topics", "source-type", "ssm-bastion-instance-id",
+	}},
+	"scan client-inventory": {path: []string{"scan", "client-inventory"}, stateFile: true, flags: []string{
+		"bundle", "s3-uri",
+	}},
+	"scan cloudtrail-clients": {path: []string{"scan", "cloudtrail-clients"}, stateFile: true, flags: []string{
+		"athena-output-location", "athena-region", "athena-table", "athena-workgroup", "bundle", "days", "region",
+	}},
+	"scan flow-logs": {path: []string{"scan", "flow-logs"}, stateFile: true, flags: []string{
+		"athena-output-location", "athena-region", "athena-table", "athena-workgroup", "bundle", "client-cidr-prefix",
+		"cluster-arn", "days",
+	}},
+	"scan kafka": {path: []string{"scan", "kafka"}, stateFile: true, flags: []string{
+		"bootstrap-servers", "bundle", "cluster-id", "environment", "insecure-skip-tls-verify", "kafka-dial-timeout",
+		"kafka-read-timeout", "kafka-version", "location", "sasl-plain-username", "sasl-scram-mechanism",
+		"sasl-scram-username", "skip-acls", "skip-topics", "tls-ca-cert", "tls-client-cert", "tls-client-key",
+		"use-sasl-plain", "use-sasl-scram", "use-tls", "use-unauthenticated-plaintext", "use-unauthenticated-tls",
+	}, secrets: []string{"sasl-plain-password", "sasl-scram-password"}},
+	"scan schema-registry": {path: []string{"scan", "schema-registry"}, stateFile: true, flags: []string{
+		"bundle", "region", "registry-name", "sr-type", "url", "use-basic-auth", "use-unauthenticated", "username",
+	}, secrets: []string{"password"}},
+	"scan self-managed-connectors": {path: []string{"scan", "self-managed-connectors"}, stateFile: true, flags: []string{
+		"bundle", "cluster-id", "connect-ec2-port", "connect-ec2-region", "connect-ec2-scheme", "connect-ec2-tag",
+		"connect-rest-url", "credentials-file", "metrics", "metrics-duration", "metrics-interval", "metrics-range",
+		"sasl-scram-username", "source-type", "tls-ca-cert", "tls-client-cert", "tls-client-key", "use-sasl-scram",
+		"use-tls", "use-unauthenticated",
+	}, secrets: []string{"sasl-scram-password"}},
+	"report metrics": {path: []string{"report", "metrics"}, stateFile: true, flags: []string{
+		"cluster-id", "end", "owner-tag-keys", "source-type", "start",
+	}},
+	"report costs": {path: []string{"report", "costs"}, stateFile: true, flags: []string{
+		"by-topic", "end", "price-sheet", "region", "start",
+	}},
+	"report plan": {path: []string{"report", "plan"}, stateFile: true, flags: []string{
+		"audience", "config", "output", "plan-inputs", "split-by",
+	}},
+	"report dependencies":    {path: []string{"report", "dependencies"}, stateFile: true, flags: []string{"format"}},
+	"report compatibility":   {path: []string{"report", "compatibility"}, stateFile: true},
+	"report idle":            {path: []string{"report", "idle"}, stateFile: true, flags: []string{"idle-days"}},
+	"report open-monitoring": {path: []string{"report", "open-monitoring"}, stateFile: true, flags: []string{"target-cluster-id"}},
+	"report rightsizing": {path: []string{"report", "rightsizing"}, stateFile: true, flags: []string{
+		"cluster-id", "price-sheet",
+	}},
+}
+
+// globalJobFlags are the root command flags any job may set.
+var globalJobFlags = []string{"api-rate-limit", "api-service-rate-limit", "redact-pattern", "timeout", "verbose"}
+
+// jobStateFile is the state file every job reads and writes.
+const jobStateFile = "kcp-state.json"
+
+var flagNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// JobRequest is the body of POST /api/v1/jobs. Flags take the same names and
+// values as the CLI flags of the command, without the leading dashes:
+// booleans, strings, numbers, or lists for repeatable flags. Secrets holds
+// the command's password and secret flags, keyed the same way; they are
+// never echoed back.
+type JobRequest struct {
+	Command string            `json:"command"`
+	Flags   map[string]any    `json:"flags"`
+	Secrets map[string]string `json:"secrets"`
+}
+
+type Job struct {
+	ID         string     `json:"id"`
+	Command    string     `json:"command"`
+	Args       []string   `json:"args"`
+	Status     JobStatus  `json:"status"`
+	ExitCode   *int       `json:"exit_code,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Files      []string   `json:"files,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// env carries the job's secrets to the command. It is not part of the
+	// JSON view and is dropped once the job has run.
+	env    []string
+	output bytes.Buffer
+}
+
+// JobRunner runs a kcp command line in dir with env added to the server's
+// environment, writing combined output to out.
+type JobRunner interface {
+	Run(ctx context.Context, dir string, args, env []string, out *bytes.Buffer) (exitCode int, err error)
+}
+
+// execRunner runs jobs by re-invoking the kcp binary, so every job gets
+// fresh flag state and the exact behaviour of the CLI.
+type execRunner struct {
+	binary string
+}
+
+func (r execRunner) Run(ctx context.Context, dir string, args, env []string, out *bytes.Buffer) (int, error) {
+	cmd := exec.CommandContext(ctx, r.binary, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+type ServerOpts struct {
+	Host     string
+	Port     string
+	Token    string
+	WorkDir  string
+	QueueLen int
+}
+
+// Server exposes kcp discovery, scans and reports as an HTTP job API. Jobs
+// run one at a time in WorkDir, the same way consecutive CLI invocations
+// would, so they share kcp-state.json and credentials files.
+type Server struct {
+	runner  JobRunner
+	host    string
+	port    string
+	token   string
+	workDir string
+
+	mu    sync.RWMutex
+	jobs  map[string]*Job
+	order []string
+	queue chan *Job
+}
+
+func NewServer(runner JobRunner, opts ServerOpts) *Server {
+	queueLen := opts.QueueLen
+	if queueLen <= 0 {
+		queueLen = 100
+	}
+	return &Server{
+		runner:  runner,
+		host:    opts.Host,
+		port:    opts.Port,
+		token:   opts.Token,
+		workDir: opts.WorkDir,
+		jobs:    make(map[string]*Job),
+		queue:   make(chan *Job, queueLen),
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	go s.work(ctx)
+
+	e := s.newEcho()
+	serverAddr := fmt.Sprintf("%s:%s", s.host, s.port)
+	fmt.Printf("🚀 kcp serve is listening on %s\n", color.New(color.FgGreen).Sprintf("http://%s", serverAddr))
+	if s.token == "" {
+		fmt.Printf("⚠️  No --token set; the API is unauthenticated\n")
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = e.Shutdown(shutdownCtx)
+	}()
+
+	if err := e.Start(serverAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) newEcho() *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	e.GET("/health", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]any{
+			"status":    "healthy",
+			"service":   "kcp-serve",
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		})
+	})
+
+	api := e.Group("/api/v1", s.authenticate)
+	api.GET("/commands", s.handleListCommands)
+	api.POST("/jobs", s.handleCreateJob)
+	api.GET("/jobs", s.handleListJobs)
+	api.GET("/jobs/:id", s.handleGetJob)
+	api.GET("/jobs/:id/output", s.handleGetJobOutput)
+	api.GET("/jobs/:id/files/*", s.handleGetJobFile)
+	api.GET("/state", s.handleGetState)
+
+	return e
+}
+
+func (s *Server) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if s.token == "" {
+			return next(c)
+		}
+		provided := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(s.token)) != 1 {
+			return c.JSON(http.StatusUnauthorized, map[string]any{"error": "Unauthorized"})
+		}
+		return next(c)
+	}
+}
+
+// work runs queued jobs one at a time until ctx is cancelled.
+func (s *Server) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.queue:
+			s.runJob(ctx, job)
+		}
+	}
+}
+
+func (s *Server) runJob(ctx context.Context, job *Job) {
+	started := time.Now()
+	s.mu.Lock()
+	job.Status = JobStatusRunning
+	job.StartedAt = &started
+	env := job.env
+	job.env = nil
+	s.mu.Unlock()
+	slog.Info("running job", "id", job.ID, "args", strings.Join(job.Args, " "))
+
+	before := s.snapshotFiles()
+	var output bytes.Buffer
+	exitCode, err := s.runner.Run(ctx, s.workDir, job.Args, env, &output)
+	files := s.changedFiles(before)
+
+	finished := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.output = output
+	job.FinishedAt = &finished
+	job.Files = files
+	switch {
+	case err != nil:
+		job.Status = JobStatusFailed
+		job.Error = err.Error()
+	case exitCode != 0:
+		job.Status = JobStatusFailed
+		job.ExitCode = &exitCode
+		job.Error = fmt.Sprintf("command exited with status %d", exitCode)
+	default:
+		job.Status = JobStatusSucceeded
+		job.ExitCode = &exitCode
+	}
+	slog.Info("job finished", "id", job.ID, "status", job.Status)
+}
+
+// snapshotFiles records the modification time of every file under the work
+// directory, keyed by path relative to it. Hidden files and directories
+// (checkpoints, temp files) and kcp.log are skipped.
+func (s *Server) snapshotFiles() map[string]time.Time {
+	files := make(map[string]time.Time)
+	_ = filepath.WalkDir(s.workDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if path != s.workDir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), "kcp.log") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if rel, err := filepath.Rel(s.workDir, path); err == nil {
+			files[filepath.ToSlash(rel)] = info.ModTime()
+		}
+		return nil
+	})
+	return files
+}
+
+// changedFiles lists files that are new or modified relative to before.
+// Comparing snapshots rather than against the job start time avoids missing
+// writes on filesystems with coarse timestamps.
+func (s *Server) changedFiles(before map[string]time.Time) []string {
+	var files []string
+	for path, modTime := range s.snapshotFiles() {
+		if previous, ok := before[path]; !ok || !previous.Equal(modTime) {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// buildJobArgs converts a job request into kcp command-line arguments.
+func buildJobArgs(req JobRequest) ([]string, error) {
+	command, ok := allowedCommands[strings.Join(strings.Fields(req.Command), " ")]
+	if !ok {
+		return nil, fmt.Errorf("unsupported command %q, expected one of: %s", req.Command, strings.Join(supportedCommands(), ", "))
+	}
+
+	args := append([]string{}, command.path...)
+	if command.stateFile {
+		args = append(args, "--state-file="+jobStateFile)
+	}
+
+	names := make([]string, 0, len(req.Flags))
+	for name := range req.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !flagNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid flag name %q", name)
+		}
+		if slices.Contains(command.secrets, name) {
+			return nil, fmt.Errorf("flag %q is a secret: pass it in the request's secrets, not its flags", name)
+		}
+		if !slices.Contains(command.flags, name) && !slices.Contains(globalJobFlags, name) {
+			return nil, fmt.Errorf("flag %q is not allowed for %s jobs", name, strings.Join(command.path, " "))
+		}
+		switch value := req.Flags[name].(type) {
+		case bool:
+			args = append(args, fmt.Sprintf("--%s=%t", name, value))
+		case string:
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		case float64:
+			args = append(args, fmt.Sprintf("--%s=%v", name, value))
+		case []any:
+			for _, item := range value {
+				switch item := item.(type) {
+				case string:
+					args = append(args, fmt.Sprintf("--%s=%s", name, item))
+				case float64:
+					args = append(args, fmt.Sprintf("--%s=%v", name, item))
+				default:
+					return nil, fmt.Errorf("flag %q: list values must be strings or numbers", name)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("flag %q: value must be a boolean, string, number or list", name)
+		}
+	}
+	return args, nil
+}
+
+// buildJobEnv converts a job request's secrets into the environment
+// variables BindEnvToFlags reads, e.g. sasl-scram-password becomes
+// SASL_SCRAM_PASSWORD, so they never appear on the command line.
+func buildJobEnv(req JobRequest) ([]string, error) {
+	command := allowedCommands[strings.Join(strings.Fields(req.Command), " ")]
+
+	names := make([]string, 0, len(req.Secrets))
+	for name := range req.Secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	env := make([]string, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(command.secrets, name) {
+			return nil, fmt.Errorf("secret %q is not accepted for %s jobs", name, strings.Join(command.path, " "))
+		}
+		envVarName := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		env = append(env, envVarName+"="+req.Secrets[name])
+	}
+	return env, nil
+}
+
+func supportedCommands() []string {
+	commands := make([]string, 0, len(allowedCommands))
+	for command := range allowedCommands {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *Server) handleListCommands(c echo.Context) error {
+	flags := make(map[string][]string, len(allowedCommands))
+	secrets := make(map[string][]string)
+	for name, command := range allowedCommands {
+		flags[name] = append([]string{}, command.flags...)
+		if len(command.secrets) > 0 {
+			secrets[name] = append([]string{}, command.secrets...)
+		}
+	}
+	return c.JSON(http.StatusOK, map[string]any{
+		"commands":     supportedCommands(),
+		"flags":        flags,
+		"secrets":      secrets,
+		"global_flags": globalJobFlags,
+	})
+}
+
+func (s *Server) handleCreateJob(c echo.Context) error {
+	var req JobRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]any{
+			"error":   "Invalid request body",
+			"message": err.Error(),
+		})
+	}
+
+	args, err := buildJobArgs(req)
+	var env []string
+	if err == nil {
+		env, err = buildJobEnv(req)
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]any{
+			"error":   "Invalid job",
+			"message": err.Error(),
+		})
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]any{
+			"error":   "Failed to create job",
+			"message": err.Error(),
+		})
+	}
+
+	job := &Job{
+		ID:        id,
+		Command:   strings.Join(strings.Fields(req.Command), " "),
+		Args:      args,
+		Status:    JobStatusQueued,
+		CreatedAt: time.Now(),
+		env:       env,
+	}
+
+	s.mu.Lock()
+	select {
+	case s.queue <- job:
+	default:
+		s.mu.Unlock()
+		return c.JSON(http.StatusServiceUnavailable, map[string]any{"error": "Job queue is full, retry later"})
+	}
+	s.jobs[id] = job
+	s.order = append(s.order, id)
+	snapshot := job.snapshot()
+	s.mu.Unlock()
+
+	c.Response().Header().Set(echo.HeaderLocation, "/api/v1/jobs/"+id)
+	return c.JSON(http.StatusAccepted, snapshot)
+}
+
+func (s *Server) handleListJobs(c echo.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	jobs := make([]Job, 0, len(s.order))
+	for _, id := range s.order {
+		jobs = append(jobs, s.jobs[id].snapshot())
+	}
+	return c.JSON(http.StatusOK, map[string]any{"jobs": jobs})
+}
+
+func (s *Server) handleGetJob(c echo.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[c.Param("id")]
+	if !ok {
+		return c.JSON(http.StatusNotFound, map[string]any{"error": "Job not found"})
+	}
+	return c.JSON(http.StatusOK, job.snapshot())
+}
+
+func (s *Server) handleGetJobOutput(c echo.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[c.Param("id")]
+	if !ok {
+		return c.JSON(http.StatusNotFound, map[string]any{"error": "Job not found"})
+	}
+	return c.String(http.StatusOK, job.output.String())
+}
+
+// handleGetJobFile serves a file the job wrote. Only files listed on the job
+// can be fetched, which also rules out path traversal.
+func (s *Server) handleGetJobFile(c echo.Context) error {
+	s.mu.RLock()
+	job, ok := s.jobs[c.Param("id")]
+	var files []string
+	if ok {
+		files = job.Files
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return c.JSON(http.StatusNotFound, map[string]any{"error": "Job not found"})
+	}
+
+	name := c.Param("*")
+	for _, file := range files {
+		if file == name {
+			return c.File(filepath.Join(s.workDir, filepath.FromSlash(file)))
+		}
+	}
+	return c.JSON(http.StatusNotFound, map[string]any{"error": "File not produced by this job"})
+}
+
+func (s *Server) handleGetState(c echo.Context) error {
+	path := filepath.Join(s.workDir, "kcp-state.json")
+	if _, err := os.Stat(path); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]any{"error": "No state file yet, run a discover or scan job first"})
+	}
+	return c.File(path)
+}
+
+func (j *Job) snapshot() Job {
+	return Job{
+		ID:         j.ID,
+		Command:    j.Command,
+		Args:       j.Args,
+		Status:     j.Status,
+		ExitCode:   j.ExitCode,
+		Error:      j.Error,
+		Files:      j.Files,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+	}
+}
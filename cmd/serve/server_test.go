@@ -0,0 +1,283 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner records the args it was called with and writes files into the
+// work directory the way a real kcp command would.
+type fakeRunner struct {
+	args     chan []string
+	env      []string
+	exitCode int
+	files    map[string]string
+}
+
+func (r *fakeRunner) Run(ctx context.Context, dir string, args, env []string, out *bytes.Buffer) (int, error) {
+	r.env = env
+	for name, content := range r.files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return -1, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return -1, err
+		}
+	}
+	out.WriteString("ran " + strings.Join(args, " "))
+	r.args <- args
+	return r.exitCode, nil
+}
+
+func TestBuildJobArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     JobRequest
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "discover with list, bool and string flags",
+			req: JobRequest{Command: "discover", Flags: map[string]any{
+				"region":              []any{"us-east-1", "eu-west-1"},
+				"skip-costs":          true,
+				"metrics-granularity": "hourly",
+			}},
+			want: []string{"discover", "--metrics-granularity=hourly", "--region=us-east-1", "--region=eu-west-1", "--skip-costs=true"},
+		},
+		{
+			name: "nested command with number flag",
+			req:  JobRequest{Command: "scan  clusters", Flags: map[string]any{"sample-messages": float64(10), "skip-topics": false}},
+			want: []string{"scan", "clusters", "--state-file=kcp-state.json", "--sample-messages=10", "--skip-topics=false"},
+		},
+		{
+			name: "global flag",
+			req:  JobRequest{Command: "report compatibility", Flags: map[string]any{"timeout": "10m"}},
+			want: []string{"report", "compatibility", "--state-file=kcp-state.json", "--timeout=10m"},
+		},
+		{
+			name: "report with its own flag",
+			req:  JobRequest{Command: "report idle", Flags: map[string]any{"idle-days": float64(14)}},
+			want: []string{"report", "idle", "--state-file=kcp-state.json", "--idle-days=14"},
+		},
+		{
+			name:    "notification not allowed",
+			req:     JobRequest{Command: "scan flow-logs", Flags: map[string]any{"notify-webhook": "https://hooks.example.com/x"}},
+			wantErr: `flag "notify-webhook" is not allowed for scan flow-logs jobs`,
+		},
+		{
+			name:    "secret as flag not allowed",
+			req:     JobRequest{Command: "scan kafka", Flags: map[string]any{"sasl-scram-password": "hunter2"}},
+			wantErr: "pass it in the request's secrets",
+		},
+		{
+			name:    "output redirection not allowed",
+			req:     JobRequest{Command: "scan clusters", Flags: map[string]any{"upload-to": "s3://elsewhere/kcp"}},
+			wantErr: `flag "upload-to" is not allowed for scan clusters jobs`,
+		},
+		{
+			name:    "state file pinned",
+			req:     JobRequest{Command: "report costs", Flags: map[string]any{"state-file": "/tmp/other.json"}},
+			wantErr: "not allowed",
+		},
+		{
+			name:    "global flag not allowed",
+			req:     JobRequest{Command: "discover", Flags: map[string]any{"otlp-endpoint": "http://collector:4318"}},
+			wantErr: "not allowed",
+		},
+		{
+			name:    "command not allowed",
+			req:     JobRequest{Command: "migration execute"},
+			wantErr: "unsupported command",
+		},
+		{
+			name:    "flag name injection",
+			req:     JobRequest{Command: "discover", Flags: map[string]any{"region --state-file": "x"}},
+			wantErr: "invalid flag name",
+		},
+		{
+			name:    "object value",
+			req:     JobRequest{Command: "discover", Flags: map[string]any{"region": map[string]any{}}},
+			wantErr: "value must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildJobArgs(tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestServer(t *testing.T, runner JobRunner, token string) (*Server, http.Handler) {
+	t.Helper()
+	s := NewServer(runner, ServerOpts{Token: token, WorkDir: t.TempDir()})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go s.work(ctx)
+	return s, s.newEcho()
+}
+
+func doRequest(handler http.Handler, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func waitForJob(t *testing.T, handler http.Handler, id string) Job {
+	t.Helper()
+	var job Job
+	require.Eventually(t, func() bool {
+		rec := doRequest(handler, http.MethodGet, "/api/v1/jobs/"+id, "", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+		return job.Status == JobStatusSucceeded || job.Status == JobStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestServer_JobLifecycle(t *testing.T) {
+	runner := &fakeRunner{
+		args:  make(chan []string, 1),
+		files: map[string]string{"kcp-state.json": `{"msk_sources":{}}`, "reports/metrics.md": "# report"},
+	}
+	_, handler := newTestServer(t, runner, "")
+
+	rec := doRequest(handler, http.MethodPost, "/api/v1/jobs", `{"command":"discover","flags":{"region":["us-east-1"]}}`, "")
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var created Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "/api/v1/jobs/"+created.ID, rec.Header().Get("Location"))
+	assert.Equal(t, []string{"discover", "--region=us-east-1"}, <-runner.args)
+
+	job := waitForJob(t, handler, created.ID)
+	assert.Equal(t, JobStatusSucceeded, job.Status)
+	require.NotNil(t, job.ExitCode)
+	assert.Equal(t, 0, *job.ExitCode)
+	assert.Equal(t, []string{"kcp-state.json", "reports/metrics.md"}, job.Files)
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/jobs/"+created.ID+"/output", "", "")
+	assert.Equal(t, "ran discover --region=us-east-1", rec.Body.String())
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/jobs/"+created.ID+"/files/reports/metrics.md", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "# report", rec.Body.String())
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/jobs/"+created.ID+"/files/../etc/passwd", "", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/state", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"msk_sources":{}}`, rec.Body.String())
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/jobs", "", "")
+	assert.Contains(t, rec.Body.String(), created.ID)
+}
+
+func TestServer_FailedJob(t *testing.T) {
+	runner := &fakeRunner{args: make(chan []string, 1), exitCode: 1}
+	_, handler := newTestServer(t, runner, "")
+
+	rec := doRequest(handler, http.MethodPost, "/api/v1/jobs", `{"command":"report costs"}`, "")
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var created Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	<-runner.args
+
+	job := waitForJob(t, handler, created.ID)
+	assert.Equal(t, JobStatusFailed, job.Status)
+	require.NotNil(t, job.ExitCode)
+	assert.Equal(t, 1, *job.ExitCode)
+}
+
+func TestServer_RejectsInvalidJob(t *testing.T) {
+	_, handler := newTestServer(t, &fakeRunner{args: make(chan []string, 1)}, "")
+
+	rec := doRequest(handler, http.MethodPost, "/api/v1/jobs", `{"command":"update"}`, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unsupported command")
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/jobs/missing", "", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestBuildJobEnv(t *testing.T) {
+	env, err := buildJobEnv(JobRequest{Command: "scan kafka", Secrets: map[string]string{
+		"sasl-scram-password": "hunter2",
+		"sasl-plain-password": "correct-horse",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SASL_PLAIN_PASSWORD=correct-horse", "SASL_SCRAM_PASSWORD=hunter2"}, env)
+
+	_, err = buildJobEnv(JobRequest{Command: "discover", Secrets: map[string]string{"sasl-scram-password": "hunter2"}})
+	assert.ErrorContains(t, err, `secret "sasl-scram-password" is not accepted for discover jobs`)
+}
+
+func TestServer_SecretsNeverExposed(t *testing.T) {
+	runner := &fakeRunner{args: make(chan []string, 1)}
+	_, handler := newTestServer(t, runner, "")
+
+	body := `{"command":"scan kafka","flags":{"bootstrap-servers":["broker:9092"],"use-sasl-scram":true,"sasl-scram-username":"kcp"},"secrets":{"sasl-scram-password":"hunter2"}}`
+	rec := doRequest(handler, http.MethodPost, "/api/v1/jobs", body, "")
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "hunter2")
+	var created Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	args := <-runner.args
+	assert.NotContains(t, strings.Join(args, " "), "hunter2")
+	waitForJob(t, handler, created.ID)
+	assert.Contains(t, runner.env, "SASL_SCRAM_PASSWORD=hunter2")
+
+	for _, path := range []string{"/api/v1/jobs", "/api/v1/jobs/" + created.ID, "/api/v1/jobs/" + created.ID + "/output"} {
+		rec = doRequest(handler, http.MethodGet, path, "", "")
+		require.Equal(t, http.StatusOK, rec.Code, path)
+		assert.NotContains(t, rec.Body.String(), "hunter2", path)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	for host, want := range map[string]bool{
+		"localhost": true,
+		"127.0.0.1": true,
+		"::1":       true,
+		"0.0.0.0":   false,
+		"10.0.0.5":  false,
+		"kcp.local": false,
+	} {
+		assert.Equal(t, want, isLoopback(host), host)
+	}
+}
+
+func TestServer_Token(t *testing.T) {
+	_, handler := newTestServer(t, &fakeRunner{args: make(chan []string, 1)}, "secret")
+
+	assert.Equal(t, http.StatusOK, doRequest(handler, http.MethodGet, "/health", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(handler, http.MethodGet, "/api/v1/jobs", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(handler, http.MethodGet, "/api/v1/jobs", "", "wrong").Code)
+	assert.Equal(t, http.StatusOK, doRequest(handler, http.MethodGet, "/api/v1/jobs", "", "secret").Code)
+}
@@ -0,0 +1,69 @@
+package annotate
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/confluentinc/kcp/internal/state/overrides"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/spf13/cobra"
+)
+
+func NewStateAnnotateCmd() *cobra.Command {
+	var stateFile, overridesFile string
+	cmd := &cobra.Command{
+		Use:   "annotate",
+		Short: "Apply a manual overrides file to a kcp-state.json file",
+		Long: `Applies a user-maintained overrides file to a state file and records it there, so manual knowledge discovery cannot find, such as clusters that are out of scope, the environment each cluster moves to, or corrected owner tags, is kept apart from the discovered data.
+
+The overrides file is a JSON array of JSON Patch-style operations (add, replace or remove). An operation's path is a JSON Pointer into the state file, or into one cluster when it names the cluster by MSK ARN, MSK cluster name or OSK cluster ID:
+
+  [
+    {"op": "add", "cluster": "orders-prod", "path": "/annotations/scope", "value": "out-of-scope"},
+    {"op": "add", "cluster": "orders-prod", "path": "/annotations/target_environment_id", "value": "env-abc123"},
+    {"op": "replace", "cluster": "arn:aws:kafka:us-east-1:123456789012:cluster/payments/abc", "path": "/aws_client_information/msk_cluster_config/Tags/owner", "value": "payments-team"}
+  ]
+
+The recorded operations are re-applied every time the state file is loaded, so they survive discover and scan runs rewriting the data they correct; operations that no longer apply, e.g. to a deleted cluster, are skipped with a warning. Re-run annotate with the whole file after editing it: it replaces the recorded operations. Dropping an operation does not undo what it changed until that data is discovered again.`,
+		Example:       `  kcp state annotate --state-file kcp-state.json --overrides-file kcp-overrides.json`,
+		SilenceErrors: true,
+		SilenceUsage:  true, // an override that does not apply is not a usage error — don't dump the flags
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			data, err := os.ReadFile(overridesFile)
+			if err != nil {
+				return fmt.Errorf("failed to read overrides file: %w", err)
+			}
+			ops, err := overrides.Parse(data)
+			if err != nil {
+				return fmt.Errorf("invalid overrides file %s: %w", overridesFile, err)
+			}
+
+			state, err := types.NewStateFromFile(stateFile)
+			if err != nil {
+				return err
+			}
+			absPath, err := filepath.Abs(overridesFile)
+			if err != nil {
+				absPath = overridesFile
+			}
+			if err := state.ApplyOverrides(absPath, ops); err != nil {
+				return fmt.Errorf("failed to apply overrides file %s: %w", overridesFile, err)
+			}
+			if err := state.PersistStateFile(stateFile); err != nil {
+				return err
+			}
+
+			slog.Info("✅ applied manual overrides", "state_file", stateFile, "overrides_file", overridesFile, "operations", len(ops))
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✅ applied %d override(s) from %s to %s\n", len(ops), overridesFile, stateFile)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&stateFile, "state-file", "", "Path to the state file to annotate in place (required)")
+	cmd.Flags().StringVar(&overridesFile, "overrides-file", "", "Path to the overrides file, a JSON array of add, replace and remove operations (required)")
+	_ = cmd.MarkFlagRequired("state-file")
+	_ = cmd.MarkFlagRequired("overrides-file")
+	return cmd
+}
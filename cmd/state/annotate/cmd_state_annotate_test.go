@@ -0,0 +1,77 @@
+package annotate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+func TestStateAnnotateCmd_AppliesOverrides(t *testing.T) {
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "kcp-state.json")
+	if err := os.WriteFile(stateFile, []byte(`{"msk_sources":{"regions":[{"name":"us-east-1","clusters":[{"name":"orders","arn":"arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1","region":"us-east-1"}]}]},"kcp_build_info":{"version":"0.9.9","commit":"x","date":"y"},"timestamp":"2026-10-17T00:00:00Z"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	overridesFile := filepath.Join(dir, "kcp-overrides.json")
+	if err := os.WriteFile(overridesFile, []byte(`[
+		{"op": "add", "cluster": "orders", "path": "/annotations/scope", "value": "out-of-scope"},
+		{"op": "add", "cluster": "orders", "path": "/annotations/target_environment_id", "value": "env-abc123"}
+	]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewStateAnnotateCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--state-file", stateFile, "--overrides-file", overridesFile})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(out.String(), "✅ applied 2 override(s)") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := state.MSKSources.Regions[0].Clusters[0].Annotations
+	if annotations[types.AnnotationScope] != "out-of-scope" || annotations[types.AnnotationTargetEnvironmentId] != "env-abc123" {
+		t.Errorf("annotations = %v", annotations)
+	}
+	if state.ManualOverrides == nil || state.ManualOverrides.File != overridesFile {
+		t.Errorf("manual_overrides = %+v", state.ManualOverrides)
+	}
+}
+
+func TestStateAnnotateCmd_InvalidOverridesLeaveStateUntouched(t *testing.T) {
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "kcp-state.json")
+	original := []byte(`{"msk_sources":{"regions":[]},"kcp_build_info":{"version":"0.9.9","commit":"x","date":"y"},"timestamp":"2026-10-17T00:00:00Z"}`)
+	if err := os.WriteFile(stateFile, original, 0600); err != nil {
+		t.Fatal(err)
+	}
+	overridesFile := filepath.Join(dir, "kcp-overrides.json")
+	if err := os.WriteFile(overridesFile, []byte(`[{"op": "add", "cluster": "orders", "path": "/annotations/scope", "value": "out-of-scope"}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewStateAnnotateCmd()
+	cmd.SetArgs([]string{"--state-file", stateFile, "--overrides-file", overridesFile})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cluster orders not found") {
+		t.Fatalf("error = %v, want cluster orders not found", err)
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, original) {
+		t.Errorf("state file was rewritten after a failed apply:\n%s", data)
+	}
+}
@@ -0,0 +1,128 @@
+package classify
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/environment"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/spf13/cobra"
+)
+
+func NewStateClassifyCmd() *cobra.Command {
+	var stateFile, mappingFile string
+	var tagKeys []string
+	cmd := &cobra.Command{
+		Use:   "classify",
+		Short: "Assign each cluster in a kcp-state.json file to a business environment (dev, stage, prod)",
+		Long: `Classifies every MSK and Apache Kafka cluster in a state file into a business environment and records it as the cluster's "environment" annotation. ` + "`kcp report plan`" + ` then builds migration waves per environment, dev first and prod last, and totals the sizing recommendations per environment.
+
+A cluster is classified by the first of:
+
+  1. the mapping file (--mapping-file), matching MSK cluster names, MSK ARNs or Apache Kafka cluster IDs against glob patterns:
+
+       environments:
+         prod: [orders-prod, "payments-*"]
+         dev: ["*-dev"]
+
+  2. the MSK cluster's tags (--tag-key, default: environment, env, stage — compared case-insensitively).
+
+Common spellings are normalized (production → prod, staging/qa/uat → stage, development/test → dev); other values are kept as written. Clusters neither source classifies keep the environment they already have. An "environment" annotation set with ` + "`kcp state annotate`" + ` is re-applied on every load, so it always wins over classify.`,
+		Example: `  kcp state classify --state-file kcp-state.json
+  kcp state classify --state-file kcp-state.json --mapping-file environments.yaml
+  kcp state classify --state-file kcp-state.json --tag-key Stage`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var mapping *environment.Mapping
+			if mappingFile != "" {
+				m, err := environment.LoadMapping(mappingFile)
+				if err != nil {
+					return err
+				}
+				mapping = m
+			}
+
+			state, err := types.NewStateFromFile(stateFile)
+			if err != nil {
+				return err
+			}
+
+			counts, unclassified := classifyState(state, mapping, tagKeys)
+			if err := state.PersistStateFile(stateFile); err != nil {
+				return err
+			}
+
+			slog.Info("✅ classified clusters by environment", "state_file", stateFile, "unclassified", len(unclassified))
+			printSummary(cmd.OutOrStdout(), counts, unclassified)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&stateFile, "state-file", "", "Path to the state file to classify in place (required)")
+	cmd.Flags().StringVar(&mappingFile, "mapping-file", "", "YAML file assigning cluster names, ARNs or IDs (globs allowed) to environments; takes precedence over tags")
+	cmd.Flags().StringSliceVar(&tagKeys, "tag-key", environment.DefaultTagKeys, "MSK cluster tag keys holding the environment, tried in order (case-insensitive)")
+	_ = cmd.MarkFlagRequired("state-file")
+	return cmd
+}
+
+// classifyState sets the environment annotation of every cluster the mapping
+// or its tags classify. It returns the number of clusters per environment,
+// counting clusters that keep an earlier classification, and the names of the
+// clusters left without one.
+func classifyState(state *types.State, mapping *environment.Mapping, tagKeys []string) (map[string]int, []string) {
+	counts := map[string]int{}
+	var unclassified []string
+
+	record := func(name string, annotations *map[string]string, env string) {
+		if env != "" {
+			if *annotations == nil {
+				*annotations = map[string]string{}
+			}
+			(*annotations)[types.AnnotationEnvironment] = env
+		}
+		if current := (*annotations)[types.AnnotationEnvironment]; current != "" {
+			counts[current]++
+			return
+		}
+		unclassified = append(unclassified, name)
+	}
+
+	if state.MSKSources != nil {
+		for i := range state.MSKSources.Regions {
+			for j := range state.MSKSources.Regions[i].Clusters {
+				cluster := &state.MSKSources.Regions[i].Clusters[j]
+				env := mapping.Lookup(cluster.Name, cluster.Arn)
+				if env == "" {
+					env = environment.FromTags(cluster.AWSClientInformation.MskClusterConfig.Tags, tagKeys)
+				}
+				record(cluster.Name, &cluster.Annotations, env)
+			}
+		}
+	}
+	if state.OSKSources != nil {
+		for i := range state.OSKSources.Clusters {
+			cluster := &state.OSKSources.Clusters[i]
+			record(cluster.ID, &cluster.Annotations, mapping.Lookup(cluster.ID))
+		}
+	}
+	return counts, unclassified
+}
+
+func printSummary(w io.Writer, counts map[string]int, unclassified []string) {
+	envs := make([]string, 0, len(counts))
+	for env := range counts {
+		envs = append(envs, env)
+	}
+	sort.Slice(envs, func(i, j int) bool { return environment.Less(envs[i], envs[j]) })
+
+	for _, env := range envs {
+		_, _ = fmt.Fprintf(w, "✅ %s: %d cluster(s)\n", env, counts[env])
+	}
+	if len(unclassified) > 0 {
+		_, _ = fmt.Fprintf(w, "⚠️ %d cluster(s) not classified: %s\n", len(unclassified), strings.Join(unclassified, ", "))
+	}
+}
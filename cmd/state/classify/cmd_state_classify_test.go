@@ -0,0 +1,84 @@
+package classify
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+func TestStateClassifyCmd_MappingThenTags(t *testing.T) {
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "kcp-state.json")
+	if err := os.WriteFile(stateFile, []byte(`{"msk_sources":{"regions":[{"name":"us-east-1","clusters":[
+		{"name":"orders","arn":"arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1","region":"us-east-1","aws_client_information":{"msk_cluster_config":{"Tags":{"Environment":"Production"}}}},
+		{"name":"payments-qa","arn":"arn:aws:kafka:us-east-1:000000000000:cluster/payments-qa/abc-2","region":"us-east-1","aws_client_information":{"msk_cluster_config":{"Tags":{"env":"prod"}}}},
+		{"name":"scratch","arn":"arn:aws:kafka:us-east-1:000000000000:cluster/scratch/abc-3","region":"us-east-1"}
+	]}]},"kcp_build_info":{"version":"0.9.9","commit":"x","date":"y"},"timestamp":"2026-10-17T00:00:00Z"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mappingFile := filepath.Join(dir, "environments.yaml")
+	if err := os.WriteFile(mappingFile, []byte("environments:\n  staging: [\"*-qa\"]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewStateClassifyCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--state-file", stateFile, "--mapping-file", mappingFile})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	for _, want := range []string{"✅ stage: 1 cluster(s)", "✅ prod: 1 cluster(s)", "⚠️ 1 cluster(s) not classified: scratch"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want %q", out.String(), want)
+		}
+	}
+
+	state, err := types.NewStateFromFile(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, cluster := range state.MSKSources.Regions[0].Clusters {
+		got[cluster.Name] = cluster.Annotations[types.AnnotationEnvironment]
+	}
+	// The mapping wins over the payments-qa "env: prod" tag.
+	want := map[string]string{"orders": "prod", "payments-qa": "stage", "scratch": ""}
+	for name, env := range want {
+		if got[name] != env {
+			t.Errorf("%s environment = %q, want %q", name, got[name], env)
+		}
+	}
+}
+
+func TestStateClassifyCmd_InvalidMappingLeavesStateUntouched(t *testing.T) {
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "kcp-state.json")
+	original := []byte(`{"msk_sources":{"regions":[]},"kcp_build_info":{"version":"0.9.9","commit":"x","date":"y"},"timestamp":"2026-10-17T00:00:00Z"}`)
+	if err := os.WriteFile(stateFile, original, 0600); err != nil {
+		t.Fatal(err)
+	}
+	mappingFile := filepath.Join(dir, "environments.yaml")
+	if err := os.WriteFile(mappingFile, []byte("environments:\n  prod: [\"orders-[\"]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewStateClassifyCmd()
+	cmd.SetArgs([]string{"--state-file", stateFile, "--mapping-file", mappingFile})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid pattern") {
+		t.Fatalf("error = %v, want invalid pattern", err)
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, original) {
+		t.Errorf("state file was rewritten after a failed classify:\n%s", data)
+	}
+}
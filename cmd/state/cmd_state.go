@@ -1,7 +1,10 @@
 package state
 
 import (
+	"github.com/confluentinc/kcp/cmd/state/annotate"
+	"github.com/confluentinc/kcp/cmd/state/classify"
 	"github.com/confluentinc/kcp/cmd/state/upgrade"
+	"github.com/confluentinc/kcp/cmd/state/validate"
 	"github.com/confluentinc/kcp/cmd/state/version"
 	"github.com/spf13/cobra"
 )
@@ -10,12 +13,15 @@ func NewStateCmd() *cobra.Command {
 	stateCmd := &cobra.Command{
 		Use:           "state",
 		Short:         "Operate on kcp-state.json files",
-		Long:          "Commands for inspecting and migrating KCP state files.",
+		Long:          "Commands for inspecting, validating, migrating, annotating and classifying KCP state files.",
 		SilenceErrors: true,
 		Args:          cobra.NoArgs,
 	}
 	stateCmd.AddCommand(
+		annotate.NewStateAnnotateCmd(),
+		classify.NewStateClassifyCmd(),
 		upgrade.NewStateUpgradeCmd(),
+		validate.NewStateValidateCmd(),
 		version.NewStateVersionCmd(),
 	)
 	return stateCmd
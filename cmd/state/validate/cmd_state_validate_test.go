@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStateValidateCmd_ReportsEachFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "kcp-state.json")
+	if err := os.WriteFile(valid, []byte(`{"msk_sources":{"regions":[]},"kcp_build_info":{"version":"0.8.5","commit":"deadbee","date":"2026-06-17T00:00:00Z"},"timestamp":"2026-05-14T00:00:00Z"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	merged := filepath.Join(dir, "merged.json")
+	if err := os.WriteFile(merged, []byte("{\n  \"msk_sources\": {\"regions\": []},\n  \"regoins\": []\n}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewStateValidateCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--state-file", valid, "--state-file", merged})
+	err := cmd.Execute()
+	if err == nil || err.Error() != "1 of 2 files failed validation" {
+		t.Fatalf("error = %v, want 1 of 2 files failed validation", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"✅ " + valid + ": valid kcp state file",
+		"❌ " + merged + ": 1 problem in kcp state file",
+		`3:3 regoins: unknown field "regoins" in types.State`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q\n--- output ---\n%s", want, got)
+		}
+	}
+}
+
+func TestStateValidateCmd_MissingFile(t *testing.T) {
+	cmd := NewStateValidateCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--state-file", filepath.Join(t.TempDir(), "missing.json")})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+	if !strings.Contains(out.String(), "no such file or directory") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/confluentinc/kcp/cmd/ui/frontend"
 	"github.com/confluentinc/kcp/internal/services/hcl"
+	hclaws "github.com/confluentinc/kcp/internal/services/hcl/aws"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
 	"github.com/confluentinc/kcp/internal/services/report"
@@ -521,6 +522,10 @@ func validatePrivateLinkRequest(req hclrequests.MigrationWizardRequest) error {
 		return fmt.Errorf("invalid configuration: missing required fields: %s", strings.Join(missingFields, ", "))
 	}
 
+	if _, err := hclaws.GetJumpClusterAmi(req.JumpClusterAmiFamily()); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return nil
 }
 
@@ -612,6 +617,13 @@ func (ui *UI) handleTargetClusterAssets(c echo.Context) error {
 		}
 	}
 
+	if req.OAuth != nil && (req.OAuth.Issuer == "" || req.OAuth.JwksUri == "" || req.OAuth.Filter == "") {
+		return c.JSON(http.StatusBadRequest, map[string]any{
+			"error":   "Missing required fields",
+			"message": "oauth.issuer, oauth.jwks_uri and oauth.filter are required for OAuth",
+		})
+	}
+
 	// Apply defaults for dedicated cluster settings
 	if req.ClusterType == "dedicated" {
 		if req.ClusterAvailability == "" {
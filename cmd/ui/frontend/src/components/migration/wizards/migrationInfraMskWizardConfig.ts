@@ -3,6 +3,8 @@ import { getClusterDataByArn } from '@/stores/store'
 import {
   targetClusterProperties,
   targetClusterUiSchema,
+  clusterLinkTuningProperties,
+  clusterLinkTuningUiSchema,
   jumpClusterTargetProperties,
   jumpClusterTargetUiSchema,
   destinationGatingStates,
@@ -123,6 +125,7 @@ export const createMigrationInfraMskWizardConfig = (clusterArn: string): WizardC
                 title: 'Source SASL/SCRAM Mechanism',
                 default: cluster?.kafka_admin_client_information?.sasl_mechanism || 'SCRAM-SHA-512',
               },
+              ...clusterLinkTuningProperties(),
             },
             required: [
               'target_cluster_id',
@@ -134,6 +137,7 @@ export const createMigrationInfraMskWizardConfig = (clusterArn: string): WizardC
           },
           uiSchema: {
             ...targetClusterUiSchema(),
+            ...clusterLinkTuningUiSchema(),
             source_cluster_id: {
               'ui:disabled': true,
             },
@@ -706,6 +710,22 @@ export const createMigrationInfraMskWizardConfig = (clusterArn: string): WizardC
                   cluster?.aws_client_information?.msk_cluster_config?.Provisioned
                     ?.BrokerNodeGroupInfo?.StorageInfo?.EbsStorageInfo?.VolumeSize || 500,
               },
+              jump_cluster_ami: {
+                type: 'string',
+                title: 'Broker Operating System',
+                default: 'rhel',
+                oneOf: [
+                  { title: 'RHEL 9.6', const: 'rhel' },
+                  { title: 'Amazon Linux 2023', const: 'al2023' },
+                  { title: 'Ubuntu 22.04 LTS', const: 'ubuntu' },
+                ],
+              },
+              jump_cluster_spot_instances: {
+                type: 'boolean',
+                title: 'Use spot instances for the brokers',
+                description: 'An interrupted spot broker is terminated and must be replaced.',
+                default: false,
+              },
               jump_cluster_broker_subnet_cidr: {
                 type: 'array',
                 title: 'Broker Subnet CIDR Range',
@@ -1,6 +1,6 @@
 import type { WizardConfig } from './types'
 import { getOSKClusterDataById } from '@/stores/store'
-import { targetClusterProperties, targetClusterUiSchema, jumpClusterTargetProperties, jumpClusterTargetUiSchema, destinationGatingStates, destinationGuards, CC_GOV_PRODUCT_NAME } from './sharedWizardSchemas'
+import { targetClusterProperties, targetClusterUiSchema, clusterLinkTuningProperties, clusterLinkTuningUiSchema, jumpClusterTargetProperties, jumpClusterTargetUiSchema, destinationGatingStates, destinationGuards, CC_GOV_PRODUCT_NAME } from './sharedWizardSchemas'
 
 export const createMigrationInfraOskWizardConfig = (clusterId: string): WizardConfig => {
   const cluster = getOSKClusterDataById(clusterId)
@@ -102,11 +102,13 @@ export const createMigrationInfraOskWizardConfig = (clusterId: string): WizardCo
                 title: 'Source SASL/SCRAM Mechanism',
                 default: saslMechanism || undefined,
               },
+              ...clusterLinkTuningProperties(),
             },
             required: ['target_cluster_id', 'target_rest_endpoint', 'cluster_link_name', 'source_cluster_id', 'source_sasl_scram_bootstrap_servers'],
           },
           uiSchema: {
             ...targetClusterUiSchema(),
+            ...clusterLinkTuningUiSchema(),
             cluster_link_name: {
               'ui:placeholder': 'e.g., osk-to-cc-migration-link',
             },
@@ -370,6 +372,22 @@ export const createMigrationInfraOskWizardConfig = (clusterId: string): WizardCo
                 type: 'number',
                 title: 'Storage per Broker (GB)',
               },
+              jump_cluster_ami: {
+                type: 'string',
+                title: 'Broker Operating System',
+                default: 'rhel',
+                oneOf: [
+                  { title: 'RHEL 9.6', const: 'rhel' },
+                  { title: 'Amazon Linux 2023', const: 'al2023' },
+                  { title: 'Ubuntu 22.04 LTS', const: 'ubuntu' },
+                ],
+              },
+              jump_cluster_spot_instances: {
+                type: 'boolean',
+                title: 'Use spot instances for the brokers',
+                description: 'An interrupted spot broker is terminated and must be replaced.',
+                default: false,
+              },
               jump_cluster_broker_subnet_cidr: {
                 type: 'array',
                 title: 'Broker Subnet CIDR Range',
@@ -127,6 +127,53 @@ export const targetClusterUiSchema = () => ({
   },
 })
 
+/** Optional cluster link tuning properties for the public cluster link path */
+export const clusterLinkTuningProperties = () => ({
+  cluster_link_prefix: {
+    type: 'string' as const,
+    title: 'Mirror Topic Prefix (optional)',
+  },
+  cluster_link_consumer_offset_sync: {
+    type: 'boolean' as const,
+    title: 'Sync consumer group offsets',
+    default: false,
+  },
+  cluster_link_consumer_offset_sync_ms: {
+    type: 'integer' as const,
+    title: 'Consumer Offset Sync Interval in ms (optional)',
+    minimum: 1,
+  },
+  cluster_link_acl_sync: {
+    type: 'boolean' as const,
+    title: 'Sync ACLs',
+    default: false,
+  },
+  cluster_link_auto_create_mirror_topic_filters: {
+    type: 'array' as const,
+    title: 'Auto-Create Mirror Topics For (optional)',
+    description: "A topic name, '*' for every topic, or a prefix ending in '*'.",
+    items: {
+      type: 'string' as const,
+    },
+  },
+})
+
+export const clusterLinkTuningUiSchema = () => ({
+  cluster_link_prefix: {
+    'ui:placeholder': 'e.g., source-',
+  },
+  cluster_link_consumer_offset_sync_ms: {
+    'ui:placeholder': 'e.g., 30000',
+  },
+  cluster_link_auto_create_mirror_topic_filters: {
+    'ui:options': {
+      addable: true,
+      orderable: false,
+      removable: true,
+    },
+  },
+})
+
 /** Additional target properties for jump cluster and external outbound paths */
 export const jumpClusterTargetProperties = () => ({
   target_environment_id: {
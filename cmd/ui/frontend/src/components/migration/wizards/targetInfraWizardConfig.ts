@@ -323,7 +323,7 @@ export const createTargetInfraWizardConfig = (clusterKey: string, sourceType: 'm
               actions: 'save_step_data',
             },
             {
-              target: 'confirmation',
+              target: 'oauth_question',
               guard: 'does_not_need_private_link',
               actions: 'save_step_data',
             },
@@ -399,7 +399,7 @@ export const createTargetInfraWizardConfig = (clusterKey: string, sourceType: 'm
         },
         on: {
           NEXT: {
-            target: 'confirmation',
+            target: 'oauth_question',
             actions: 'save_step_data',
           },
           BACK: {
@@ -408,6 +408,113 @@ export const createTargetInfraWizardConfig = (clusterKey: string, sourceType: 'm
           },
         },
       },
+      oauth_question: {
+        meta: {
+          title: 'Client authentication',
+          schema: {
+            type: 'object',
+            properties: {
+              uses_oauth: {
+                type: 'boolean',
+                title: 'Authenticate clients with OAuth tokens of your identity provider instead of API keys',
+                default: false,
+              },
+            },
+            required: ['uses_oauth'],
+          },
+          uiSchema: {
+            uses_oauth: {
+              'ui:widget': 'radio',
+            },
+          },
+        },
+        on: {
+          NEXT: [
+            {
+              target: 'create_oauth',
+              guard: 'uses_oauth',
+              actions: 'save_step_data',
+            },
+            {
+              target: 'confirmation',
+              guard: 'does_not_use_oauth',
+              actions: 'save_step_data',
+            },
+          ],
+          BACK: [
+            {
+              target: 'create_private_link',
+              guard: 'came_from_create_private_link',
+              actions: 'undo_save_step_data',
+            },
+            {
+              target: 'private_link_question',
+              actions: 'undo_save_step_data',
+            },
+          ],
+        },
+      },
+      create_oauth: {
+        meta: {
+          title: 'OAuth Configuration',
+          description:
+            'Enter your OIDC identity provider. Tokens matching the filter map to an identity pool that can read and write every topic and consumer group of the cluster.',
+          schema: {
+            type: 'object',
+            properties: {
+              oauth: {
+                type: 'object',
+                title: 'Identity provider',
+                properties: {
+                  issuer: {
+                    type: 'string',
+                    title: 'Issuer URI',
+                  },
+                  jwks_uri: {
+                    type: 'string',
+                    title: 'JWKS URI',
+                  },
+                  identity_claim: {
+                    type: 'string',
+                    title: 'Identity claim',
+                    default: 'claims.sub',
+                  },
+                  filter: {
+                    type: 'string',
+                    title: 'Identity pool filter',
+                    description: 'CEL expression over the token claims selecting the tokens mapped to the identity pool.',
+                  },
+                },
+                required: ['issuer', 'jwks_uri', 'filter'],
+              },
+            },
+            required: ['oauth'],
+          },
+          uiSchema: {
+            oauth: {
+              issuer: {
+                'ui:placeholder': 'e.g., https://login.example.com/oauth2/default',
+              },
+              jwks_uri: {
+                'ui:placeholder': 'e.g., https://login.example.com/oauth2/default/v1/keys',
+              },
+              filter: {
+                'ui:placeholder': 'e.g., claims.aud == "confluent"',
+              },
+            },
+          },
+        },
+        on: {
+          NEXT: {
+            target: 'confirmation',
+            actions: 'save_step_data',
+          },
+          BACK: {
+            target: 'oauth_question',
+            actions: 'undo_save_step_data',
+          },
+        },
+      },
       confirmation: {
         meta: {
           title: 'Review Configuration',
@@ -419,13 +526,13 @@ export const createTargetInfraWizardConfig = (clusterKey: string, sourceType: 'm
           },
           BACK: [
             {
-              target: 'create_private_link',
-              guard: 'came_from_create_private_link',
+              target: 'create_oauth',
+              guard: 'came_from_create_oauth',
               actions: 'undo_save_step_data',
             },
             {
-              target: 'private_link_question',
-              guard: 'came_from_private_link_question',
+              target: 'oauth_question',
+              guard: 'came_from_oauth_question',
               actions: 'undo_save_step_data',
             },
             {
@@ -463,6 +570,12 @@ export const createTargetInfraWizardConfig = (clusterKey: string, sourceType: 'm
       needs_private_link: ({ event }) => {
         return event.data?.needs_private_link === true
       },
+      uses_oauth: ({ event }) => {
+        return event.data?.uses_oauth === true
+      },
+      does_not_use_oauth: ({ event }) => {
+        return event.data?.uses_oauth === false
+      },
       came_from_create_environment: ({ context }) => {
         return context.previousStep === 'create_environment'
       },
@@ -475,8 +588,11 @@ export const createTargetInfraWizardConfig = (clusterKey: string, sourceType: 'm
       came_from_create_private_link: ({ context }) => {
         return context.previousStep === 'create_private_link'
       },
-      came_from_private_link_question: ({ context }) => {
-        return context.previousStep === 'private_link_question'
+      came_from_oauth_question: ({ context }) => {
+        return context.previousStep === 'oauth_question'
+      },
+      came_from_create_oauth: ({ context }) => {
+        return context.previousStep === 'create_oauth'
       },
     },
 
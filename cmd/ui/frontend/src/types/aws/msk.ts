@@ -158,6 +158,26 @@ export interface ClusterNetworking {
   subnet_ids?: string[]
   security_groups?: string[]
   subnets?: SubnetInfo[]
+  broker_enis?: BrokerENI[]
+  security_group_rules?: SecurityGroupInboundRule[]
+}
+
+export interface BrokerENI {
+  broker_id: number
+  network_interface_id: string
+  subnet_id: string
+  private_ip_address: string
+  security_group_ids: string[]
+}
+
+export interface SecurityGroupInboundRule {
+  security_group_id: string
+  protocol: string
+  from_port: number
+  to_port: number
+  source_type: 'cidr' | 'ipv6-cidr' | 'security-group' | 'prefix-list'
+  source: string
+  description?: string
 }
 
 export interface SubnetInfo {
@@ -166,6 +186,7 @@ export interface SubnetInfo {
   availability_zone: string
   private_ip_address: string
   cidr_block: string
+  available_ip_address_count?: number
 }
 
 /**
@@ -264,6 +285,42 @@ export interface MSKConnector {
     }
   }>
   connector_configuration: Record<string, string>
+  diagnostics?: MSKConnectorDiagnostics
+}
+
+/**
+ * Diagnostics captured for FAILED MSK Connect connectors
+ */
+export interface MSKConnectorDiagnostics {
+  state_code?: string
+  state_message?: string
+  service_execution_role_arn?: string
+  log_delivery?: {
+    CloudWatchLogs?: { Enabled: boolean; LogGroup?: string }
+    Firehose?: { Enabled: boolean; DeliveryStream?: string }
+    S3?: { Enabled: boolean; Bucket?: string; Prefix?: string }
+  }
+  operations?: Array<{
+    operation_arn: string
+    operation_type: string
+    operation_state: string
+    creation_time?: string
+    end_time?: string
+    error_code?: string
+    error_message?: string
+  }>
+}
+
+/**
+ * Confluent Cloud status of a discovered connector, from the connector catalog
+ */
+export interface ConnectorAvailability {
+  name: string
+  source: 'msk_connect' | 'self_managed'
+  connector_class: string
+  plugin?: string
+  availability: 'supported' | 'partially-supported' | 'self-managed-only'
+  note?: string
 }
 
 /**
@@ -301,6 +358,29 @@ export interface TopicConfiguration {
   [key: string]: string
 }
 
+/**
+ * Topic Serialization (inferred by scan clusters --sample-messages)
+ */
+export interface TopicSerialization {
+  sampled_at: string
+  sampled_messages: number
+  format: string
+  formats: Record<string, number>
+  schema_ids?: number[]
+  glue_schema_version_ids?: string[]
+}
+
+/**
+ * Offset Activity (end offsets of a topic, committed offsets of a consumer
+ * group, tracked across scan clusters runs)
+ */
+export interface OffsetActivity {
+  observed_at: string
+  offset: number
+  unchanged_since: string
+  last_produced_at?: string
+}
+
 /**
  * Topic Detail
  */
@@ -309,6 +389,9 @@ export interface Topic {
   partitions: number
   replication_factor: number
   configurations: TopicConfiguration
+  partition_sizes?: number[]
+  serialization?: TopicSerialization
+  activity?: OffsetActivity
 }
 
 /**
@@ -319,6 +402,19 @@ export interface TopicsInfo {
   details: Topic[]
 }
 
+/**
+ * Consumer Group
+ */
+export interface ConsumerGroup {
+  group_id: string
+  state: string
+  protocol_type?: string
+  members: number
+  static_instance_ids?: string[]
+  client_racks?: Record<string, number>
+  activity?: OffsetActivity
+}
+
 /**
  * Self-Managed Connector
  */
@@ -327,6 +423,17 @@ export interface SelfManagedConnector {
   config: Record<string, string>
   state: string
   connect_host: string
+  connect_url?: string
+  tasks?: SelfManagedConnectorTask[]
+}
+
+/**
+ * Self-Managed Connector Task
+ */
+export interface SelfManagedConnectorTask {
+  id: number
+  state: string
+  worker_id?: string
 }
 
 /**
@@ -352,6 +459,81 @@ export interface SelfManagedConnectors {
   }
 }
 
+/**
+ * Broker endpoint TLS inspection (kcp scan clusters --inspect-tls)
+ */
+export interface TLSCertificate {
+  subject: string
+  issuer: string
+  serial_number: string
+  not_before: string
+  not_after: string
+  dns_names?: string[]
+  ip_addresses?: string[]
+  is_ca: boolean
+  sha256_fingerprint: string
+  pem: string
+}
+
+export interface TLSEndpoint {
+  address: string
+  resolved_ips?: string[]
+  tls_version?: string
+  certificates?: TLSCertificate[]
+  private_ca: boolean
+  hostname_mismatch?: boolean
+  error?: string
+}
+
+export interface TLSInspection {
+  inspected_at: string
+  endpoints: TLSEndpoint[]
+}
+
+export interface UnhealthyPartition {
+  topic: string
+  partition: number
+  leader: number
+  replicas: number[]
+  isr: number[]
+  offline_replicas?: number[]
+  offline: boolean
+}
+
+export interface PartitionReassignment {
+  topic: string
+  partition: number
+  replicas: number[]
+  adding_replicas?: number[]
+  removing_replicas?: number[]
+}
+
+export interface PartitionHealth {
+  checked_at: string
+  partitions: number
+  under_replicated_partitions: number
+  offline_partitions: number
+  unhealthy_partitions?: UnhealthyPartition[]
+  reassignments?: PartitionReassignment[]
+  reassignments_error?: string
+}
+
+export interface BrokerRack {
+  broker_id: number
+  rack?: string
+  leader_partitions: number
+}
+
+/**
+ * Open Monitoring (Prometheus exporters MSK runs on the brokers, recorded by
+ * scan clusters)
+ */
+export interface OpenMonitoring {
+  jmx_exporter_enabled: boolean
+  node_exporter_enabled: boolean
+  broker_hosts?: string[]
+}
+
 /**
  * Kafka Admin Client Information
  */
@@ -359,9 +541,17 @@ export interface KafkaAdminInfo {
   cluster_id?: string
   discovered_brokers?: string[]
   sasl_mechanism?: string
+  auth_type?: string
   topics?: TopicsInfo
   acls?: KafkaACL[]
+  consumer_groups?: ConsumerGroup[]
   self_managed_connectors?: SelfManagedConnectors
+  tls_inspection?: TLSInspection
+  partition_health?: PartitionHealth
+  broker_racks?: BrokerRack[]
+  open_monitoring?: OpenMonitoring
+  // Output of each `kcp scan clusters --plugin` scanner, keyed by plugin name
+  plugins?: Record<string, Record<string, unknown>>
   [key: string]: unknown
 }
 
@@ -383,10 +573,70 @@ export interface MSKConfiguration {
   State?: string
 }
 
+/**
+ * MSK Replicator (from DescribeReplicator response)
+ */
+export interface MSKReplicator {
+  ReplicatorArn?: string
+  ReplicatorName?: string
+  ReplicatorState?: string
+  KafkaClusters?: Array<{
+    AmazonMskCluster?: { MskClusterArn?: string }
+    KafkaClusterAlias?: string
+  }>
+  ReplicationInfoList?: Array<{
+    SourceKafkaClusterAlias?: string
+    TargetKafkaClusterAlias?: string
+    TargetCompressionType?: string
+    TopicReplication?: {
+      TopicsToReplicate?: string[]
+      TopicsToExclude?: string[]
+      CopyAccessControlListsForTopics?: boolean
+      CopyTopicConfigurations?: boolean
+      DetectAndCopyNewTopics?: boolean
+      StartingPosition?: { Type?: string }
+      TopicNameConfiguration?: { Type?: string }
+    }
+    ConsumerGroupReplication?: {
+      ConsumerGroupsToReplicate?: string[]
+      ConsumerGroupsToExclude?: string[]
+      DetectAndCopyNewConsumerGroups?: boolean
+      SynchroniseConsumerGroupOffsets?: boolean
+    }
+  }>
+  [key: string]: unknown
+}
+
+/**
+ * Secrets Manager secret holding a Kafka credential (metadata only, from kcp discover --scan-secrets)
+ */
+export interface KafkaSecret {
+  arn: string
+  name: string
+  description?: string
+  kms_key_id?: string
+  tags?: Record<string, string>
+  owning_service?: string
+  matched_by: Array<'scram_name_prefix' | 'scram_association' | 'tag'>
+  scram_cluster_arns?: string[]
+  rotation_enabled: boolean
+  rotation_lambda_arn?: string
+  rotation_after_days?: number
+  rotation_schedule?: string
+  created_date?: string
+  last_changed_date?: string
+  last_rotated_date?: string
+  next_rotation_date?: string
+  last_accessed_date?: string
+  policy_principals?: string[]
+}
+
 /**
  * Region Data (contains configurations and other region-specific data)
  */
 export interface RegionData {
   configurations?: MSKConfiguration[]
+  replicators?: MSKReplicator[]
+  kafka_secrets?: KafkaSecret[]
   [key: string]: unknown
 }
@@ -1,4 +1,4 @@
-import type { MSKClusterConfig, MSKConnector, KafkaAdminInfo, MSKConfiguration, ClusterNetworking, Nodes } from './aws/msk'
+import type { MSKClusterConfig, MSKConnector, ConnectorAvailability, KafkaAdminInfo, MSKConfiguration, MSKReplicator, ClusterNetworking, Nodes } from './aws/msk'
 import type { CostsApiResponse } from './api/costs'
 import type { ApiMetadata } from './api/common'
 
@@ -27,6 +27,11 @@ export interface Cluster {
   }
   kafka_admin_client_information: KafkaAdminInfo
   discovered_clients: DiscoveredClient[]
+  connector_availability?: ConnectorAvailability[]
+  environment?: string
+  cloudtrail_activity?: CloudTrailClientActivity
+  flow_log_traffic?: FlowLogTraffic
+  annotations?: Record<string, string>
   timestamp?: string
 }
 
@@ -37,12 +42,54 @@ export interface DiscoveredClient {
   topic: string
   auth: string
   principal: string
+  api_version?: number
   timestamp: string
 }
 
+export interface CloudTrailClientActivity {
+  source: 'lookup-events' | 'athena'
+  start_time: string
+  end_time: string
+  principals: CloudTrailPrincipalActivity[]
+}
+
+export interface CloudTrailPrincipalActivity {
+  principal_arn: string
+  source_ips: string[] | null
+  event_names: string[]
+  event_count: number
+  first_seen: string
+  last_seen: string
+}
+
+export interface FlowLogTraffic {
+  start_time: string
+  end_time: string
+  clients: FlowLogClient[]
+  client_cidrs: FlowLogClientCIDR[]
+}
+
+export interface FlowLogClient {
+  address: string
+  broker_enis: string[]
+  ports: number[]
+  flows: number
+  packets: number
+  bytes: number
+  first_seen: string
+  last_seen: string
+}
+
+export interface FlowLogClientCIDR {
+  cidr: string
+  clients: number
+  bytes: number
+}
+
 export interface Region {
   name: string
   configurations?: MSKConfiguration[]
+  replicators?: MSKReplicator[]
   costs?: CostsApiResponse
   clusters?: Array<Cluster>
 }
@@ -59,6 +106,7 @@ export type {
   TopicsInfo,
   SelfManagedConnector,
   MSKConfiguration,
+  MSKReplicator,
   RegionData,
 } from './aws/msk'
 
@@ -25,6 +25,7 @@ export interface OSKCluster {
   kafka_admin_client_information: KafkaAdminInfo
   discovered_clients: DiscoveredClient[]
   metadata: OSKClusterMetadata
+  annotations?: Record<string, string>
 }
 
 /**
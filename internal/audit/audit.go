@@ -0,0 +1,169 @@
+// Package audit appends one JSON line per AWS, Kafka and Confluent Cloud API
+// call kcp makes to an audit log: when it ran, under which command, what was
+// called with which (redacted) parameters, how long it took and whether it
+// succeeded. The log is only ever appended to, so successive runs in the same
+// directory build a single history that compliance teams can review.
+package audit
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/redact"
+)
+
+// DefaultPath is where the audit log is written when --audit-log is not set:
+// the working directory, next to kcp-state.json and kcp.log.
+const DefaultPath = "kcp-audit.jsonl"
+
+// Systems an audited call goes to.
+const (
+	SystemAWS            = "aws"
+	SystemKafka          = "kafka"
+	SystemConfluentCloud = "confluent_cloud"
+)
+
+// Results of an audited call.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Entry is one line of the audit log. Callers fill in what identifies the
+// call; Record sets the timing, command, parameters and result.
+type Entry struct {
+	Time       time.Time       `json:"time"`
+	Command    string          `json:"command,omitempty"`
+	System     string          `json:"system"`
+	Service    string          `json:"service,omitempty"`
+	Operation  string          `json:"operation"`
+	Region     string          `json:"region,omitempty"`
+	Target     string          `json:"target,omitempty"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	DurationMs int64           `json:"duration_ms"`
+	Result     string          `json:"result"`
+	Status     int             `json:"status,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	path    string
+	command string
+	file    *os.File
+	// failed is set once opening or writing the log fails, so the warning is
+	// logged once rather than for every call.
+	failed bool
+)
+
+// Setup enables the audit log at logPath for commandPath. The file is opened
+// on the first recorded call — commands that make no API calls leave nothing
+// behind — created 0600 when missing and only ever appended to. An empty
+// logPath disables auditing. Close must be called before exit.
+func Setup(logPath, commandPath string) {
+	mu.Lock()
+	defer mu.Unlock()
+	closeLocked()
+	path, command, failed = logPath, commandPath, false
+}
+
+// Enabled reports whether calls are being recorded, so callers can skip
+// building parameters nobody will read.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return path != "" && !failed
+}
+
+// Path returns the audit log path, or "" when auditing is disabled, so
+// commands can ship the log alongside their artifacts.
+func Path() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return path
+}
+
+// Record appends e for a call that started at start and ended with err.
+// params is marshalled to JSON and redacted as strictly as an uploaded
+// document — --redact-pattern and --no-redact apply — so credentials never
+// reach the log; pass nil when the call has none.
+func Record(e Entry, params any, start time.Time, err error) {
+	if !Enabled() {
+		return
+	}
+
+	e.Time = start.UTC()
+	e.DurationMs = time.Since(start).Milliseconds()
+	e.Params = redactedParams(params)
+	e.Result = ResultSuccess
+	if err != nil {
+		e.Result = ResultError
+		e.Error = err.Error()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	e.Command = command
+	line, marshalErr := json.Marshal(e)
+	if marshalErr != nil {
+		slog.Debug("failed to marshal audit entry", "operation", e.Operation, "error", marshalErr)
+		return
+	}
+	writeLocked(append(line, '\n'))
+}
+
+func redactedParams(params any) json.RawMessage {
+	if params == nil {
+		return nil
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		slog.Debug("failed to marshal audit parameters", "error", err)
+		return json.RawMessage(`"<unserializable>"`)
+	}
+	redacted, _, err := redact.JSON(data, redact.ScopeUpload)
+	if err != nil {
+		return json.RawMessage(`"<unserializable>"`)
+	}
+	return redacted
+}
+
+func writeLocked(line []byte) {
+	if path == "" || failed {
+		return
+	}
+	if file == nil {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			failed = true
+			slog.Warn("failed to open audit log; API calls will not be audited", "path", path, "error", err)
+			return
+		}
+		file = f
+	}
+	// A single write per line keeps entries whole when O_APPEND writers from
+	// concurrent kcp processes share the file.
+	if _, err := file.Write(line); err != nil {
+		failed = true
+		slog.Warn("failed to write audit log; API calls will no longer be audited", "path", path, "error", err)
+	}
+}
+
+// Close closes the audit log file, if it was opened.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	return closeLocked()
+}
+
+func closeLocked() error {
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
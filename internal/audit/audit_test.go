@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEntries(t *testing.T, path string) []Entry {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func setupAudit(t *testing.T, path, command string) {
+	t.Helper()
+	Setup(path, command)
+	t.Cleanup(func() {
+		_ = Close()
+		Setup("", "")
+	})
+}
+
+func TestRecord_AppendsAcrossRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kcp-audit.jsonl")
+
+	setupAudit(t, path, "kcp scan clusters")
+	Record(Entry{System: SystemAWS, Service: "Kafka", Operation: "ListClustersV2", Region: "us-east-1"}, nil, time.Now(), nil)
+	require.NoError(t, Close())
+
+	Setup(path, "kcp migration execute")
+	Record(Entry{System: SystemKafka, Operation: "OffsetCommit", Target: "b-1:9098"}, nil, time.Now(), errors.New("coordinator not available"))
+	require.NoError(t, Close())
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "kcp scan clusters", entries[0].Command)
+	assert.Equal(t, "ListClustersV2", entries[0].Operation)
+	assert.Equal(t, ResultSuccess, entries[0].Result)
+	assert.Equal(t, "kcp migration execute", entries[1].Command)
+	assert.Equal(t, ResultError, entries[1].Result)
+	assert.Equal(t, "coordinator not available", entries[1].Error)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestRecord_RedactsParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kcp-audit.jsonl")
+	setupAudit(t, path, "kcp scan clusters")
+
+	Record(Entry{System: SystemAWS, Service: "Kafka", Operation: "BatchAssociateScramSecret"}, map[string]any{
+		"ClusterArn": "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1",
+		"Password":   "hunter2",
+	}, time.Now(), nil)
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 1)
+	assert.NotContains(t, string(entries[0].Params), "hunter2")
+	assert.Contains(t, string(entries[0].Params), "cluster/orders/abc-1")
+}
+
+func TestRecord_DisabledWritesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kcp-audit.jsonl")
+	setupAudit(t, "", "kcp scan clusters")
+
+	assert.False(t, Enabled())
+	Record(Entry{System: SystemAWS, Operation: "ListClustersV2"}, nil, time.Now(), nil)
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTransport_RecordsRequestWithoutHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "kcp-audit.jsonl")
+	setupAudit(t, path, "kcp migration status")
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/kafka/v3/clusters/lkc-1/links/orders?page_size=10", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("KEY", "SECRET")
+	resp, err := (&http.Client{Transport: Transport(SystemConfluentCloud, nil)}).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 1)
+	assert.Equal(t, SystemConfluentCloud, entries[0].System)
+	assert.Equal(t, "GET /kafka/v3/clusters/lkc-1/links/orders", entries[0].Operation)
+	assert.Equal(t, http.StatusNotFound, entries[0].Status)
+	assert.JSONEq(t, `{"page_size":["10"]}`, string(entries[0].Params))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "SECRET")
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"net/http"
+	"time"
+)
+
+// Doer is the single-method HTTP client interface the REST services take.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// WrapHTTPClient records every request made through client against system.
+func WrapHTTPClient(system string, client Doer) Doer {
+	return doerFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := client.Do(req)
+		recordHTTP(system, req, resp, start, err)
+		return resp, err
+	})
+}
+
+// Transport records every request sent through base, or through
+// http.DefaultTransport when base is nil, against system.
+func Transport(system string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := base.RoundTrip(req)
+		recordHTTP(system, req, resp, start, err)
+		return resp, err
+	})
+}
+
+// recordHTTP logs the method, path and query of a request and the response
+// status. Headers and bodies are never recorded: they carry the API key and
+// secret and, for writes, whole resource definitions.
+func recordHTTP(system string, req *http.Request, resp *http.Response, start time.Time, err error) {
+	if !Enabled() {
+		return
+	}
+	entry := Entry{
+		System:    system,
+		Operation: req.Method + " " + req.URL.Path,
+		Target:    req.URL.Host,
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+	}
+	var params any
+	if query := req.URL.Query(); len(query) > 0 {
+		params = query
+	}
+	Record(entry, params, start, err)
+}
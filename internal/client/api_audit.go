@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/confluentinc/kcp/internal/audit"
+)
+
+// withAPIAudit records every AWS API call in the audit log with its input
+// parameters. Like withAPITracing it sits at the end of the initialize step,
+// so one entry covers a call's retries and its duration includes rate-limit
+// waits.
+func withAPIAudit() func(*config.LoadOptions) error {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("KcpAPIAudit",
+				func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+					start := time.Now()
+					out, metadata, err := next.HandleInitialize(ctx, in)
+					audit.Record(audit.Entry{
+						System:    audit.SystemAWS,
+						Service:   awsmiddleware.GetServiceID(ctx),
+						Operation: awsmiddleware.GetOperationName(ctx),
+						Region:    awsmiddleware.GetRegion(ctx),
+					}, in.Parameters, start, err)
+					return out, metadata, err
+				}), middleware.After)
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	"github.com/confluentinc/kcp/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAPIAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kcp-audit.jsonl")
+	audit.Setup(path, "kcp scan clusters")
+	t.Cleanup(func() {
+		_ = audit.Close()
+		audit.Setup("", "")
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"clusterInfoList":[]}`))
+	}))
+	defer server.Close()
+
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion("eu-west-1"),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("id", "secret", "")),
+		config.WithRetryMaxAttempts(1),
+		withAPIAudit(),
+	)
+	require.NoError(t, err)
+	mskClient := kafka.NewFromConfig(cfg, func(o *kafka.Options) { o.BaseEndpoint = aws.String(server.URL) })
+
+	_, err = mskClient.ListClustersV2(context.Background(), &kafka.ListClustersV2Input{ClusterNameFilter: aws.String("orders")})
+	require.NoError(t, err)
+	require.NoError(t, audit.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+	var entry audit.Entry
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+	assert.False(t, scanner.Scan(), "one call writes one entry")
+
+	assert.Equal(t, audit.SystemAWS, entry.System)
+	assert.Equal(t, "Kafka", entry.Service)
+	assert.Equal(t, "ListClustersV2", entry.Operation)
+	assert.Equal(t, "eu-west-1", entry.Region)
+	assert.Equal(t, audit.ResultSuccess, entry.Result)
+	assert.Contains(t, string(entry.Params), `"ClusterNameFilter":"orders"`)
+}
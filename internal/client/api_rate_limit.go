@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// Service keys accepted by --api-service-rate-limit. AWS clients outside these
+// services (EC2, MSK Connect, S3, Glue, IAM, STS, SNS) share the global limit.
+const (
+	APIServiceKafka        = "kafka"
+	APIServiceCloudWatch   = "cloudwatch"
+	APIServiceCostExplorer = "ce"
+)
+
+var apiServices = []string{APIServiceKafka, APIServiceCloudWatch, APIServiceCostExplorer}
+
+// apiLimiters holds the token buckets shared by every AWS client in the
+// process. A service with an override gets its own bucket; all other calls
+// draw from the global bucket. A nil bucket means unlimited.
+var apiLimiters struct {
+	mu       sync.RWMutex
+	global   *rate.Limiter
+	services map[string]*rate.Limiter
+}
+
+// SetAPIRateLimits configures the requests-per-second budget for AWS API
+// calls. global applies to every AWS client; perService overrides it for
+// the kafka, cloudwatch and ce services. A limit of 0 means unlimited.
+func SetAPIRateLimits(global float64, perService map[string]float64) error {
+	if global < 0 {
+		return fmt.Errorf("api rate limit must not be negative, got %v", global)
+	}
+
+	services := make(map[string]*rate.Limiter, len(perService))
+	for service, limit := range perService {
+		if !slices.Contains(apiServices, service) {
+			return fmt.Errorf("unknown service '%s' for api rate limit, expected one of: %s", service, strings.Join(apiServices, ", "))
+		}
+		if limit < 0 {
+			return fmt.Errorf("api rate limit for %s must not be negative, got %v", service, limit)
+		}
+		services[service] = newAPILimiter(limit)
+	}
+
+	apiLimiters.mu.Lock()
+	defer apiLimiters.mu.Unlock()
+	apiLimiters.global = newAPILimiter(global)
+	apiLimiters.services = services
+	return nil
+}
+
+// ParseAPIServiceRateLimits converts service=limit pairs (as parsed by a
+// StringToString flag) into per-service limits.
+func ParseAPIServiceRateLimits(raw map[string]string) (map[string]float64, error) {
+	limits := make(map[string]float64, len(raw))
+	keys := make([]string, 0, len(raw))
+	for service := range raw {
+		keys = append(keys, service)
+	}
+	sort.Strings(keys)
+
+	for _, service := range keys {
+		limit, err := strconv.ParseFloat(strings.TrimSpace(raw[service]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api rate limit '%s' for %s: expected requests per second", raw[service], service)
+		}
+		limits[strings.ToLower(strings.TrimSpace(service))] = limit
+	}
+	return limits, nil
+}
+
+// newAPILimiter returns a token bucket refilled at limit requests per second
+// that holds up to one second of tokens, or nil when limit is 0.
+func newAPILimiter(limit float64) *rate.Limiter {
+	if limit == 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), max(1, int(math.Ceil(limit))))
+}
+
+func apiLimiterFor(service string) *rate.Limiter {
+	apiLimiters.mu.RLock()
+	defer apiLimiters.mu.RUnlock()
+	if limiter, ok := apiLimiters.services[service]; ok {
+		return limiter
+	}
+	return apiLimiters.global
+}
+
+// withAPIRateLimit installs the shared limiter on an AWS client's middleware
+// stack. It sits after the SDK retryer so every attempt, retries included,
+// spends a token. The limiter is looked up per call, so clients created
+// before SetAPIRateLimits still honour it.
+func withAPIRateLimit(service string) func(*config.LoadOptions) error {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("KcpAPIRateLimit",
+				func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					if limiter := apiLimiterFor(service); limiter != nil {
+						if err := limiter.Wait(ctx); err != nil {
+							return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("api rate limiter cancelled: %w", err)
+						}
+					}
+					return next.HandleFinalize(ctx, in)
+				}), middleware.After)
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestSetAPIRateLimits(t *testing.T) {
+	t.Cleanup(func() { _ = SetAPIRateLimits(0, nil) })
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		require.NoError(t, SetAPIRateLimits(0, nil))
+		assert.Nil(t, apiLimiterFor(APIServiceKafka))
+		assert.Nil(t, apiLimiterFor(""))
+	})
+
+	t.Run("per-service override replaces the global bucket", func(t *testing.T) {
+		require.NoError(t, SetAPIRateLimits(10, map[string]float64{APIServiceCloudWatch: 2.5}))
+
+		global := apiLimiterFor("")
+		require.NotNil(t, global)
+		assert.Equal(t, rate.Limit(10), global.Limit())
+		assert.Equal(t, 10, global.Burst())
+		assert.Same(t, global, apiLimiterFor(APIServiceKafka))
+
+		cloudWatch := apiLimiterFor(APIServiceCloudWatch)
+		require.NotNil(t, cloudWatch)
+		assert.Equal(t, rate.Limit(2.5), cloudWatch.Limit())
+		assert.Equal(t, 3, cloudWatch.Burst())
+	})
+
+	t.Run("zero override makes a service unlimited", func(t *testing.T) {
+		require.NoError(t, SetAPIRateLimits(10, map[string]float64{APIServiceCostExplorer: 0}))
+		assert.Nil(t, apiLimiterFor(APIServiceCostExplorer))
+		assert.NotNil(t, apiLimiterFor(APIServiceKafka))
+	})
+
+	t.Run("rejects invalid limits", func(t *testing.T) {
+		assert.ErrorContains(t, SetAPIRateLimits(-1, nil), "must not be negative")
+		assert.ErrorContains(t, SetAPIRateLimits(0, map[string]float64{"kafka": -1}), "must not be negative")
+		assert.ErrorContains(t, SetAPIRateLimits(0, map[string]float64{"ec2": 1}), "unknown service 'ec2'")
+	})
+}
+
+func TestParseAPIServiceRateLimits(t *testing.T) {
+	limits, err := ParseAPIServiceRateLimits(map[string]string{"Kafka": "5", "cloudwatch": " 0.5 "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"kafka": 5, "cloudwatch": 0.5}, limits)
+
+	_, err = ParseAPIServiceRateLimits(map[string]string{"ce": "fast"})
+	assert.ErrorContains(t, err, "invalid api rate limit 'fast' for ce")
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/confluentinc/kcp/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// withAPITracing opens a span around every AWS API call. It sits at the end
+// of the initialize step, so the span covers rate-limit waits and SDK retries
+// and is named after the service and operation, e.g. "Kafka.ListClustersV2".
+func withAPITracing() func(*config.LoadOptions) error {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("KcpAPITracing",
+				func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+					service := awsmiddleware.GetServiceID(ctx)
+					operation := awsmiddleware.GetOperationName(ctx)
+					ctx, span := tracing.Start(ctx, service+"."+operation,
+						attribute.String("rpc.system", "aws-api"),
+						attribute.String("rpc.service", service),
+						attribute.String("rpc.method", operation),
+						attribute.String("cloud.region", awsmiddleware.GetRegion(ctx)),
+					)
+					out, metadata, err := next.HandleInitialize(ctx, in)
+					tracing.End(span, err)
+					return out, metadata, err
+				}), middleware.After)
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithAPITracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"clusterInfoList":[]}`))
+	}))
+	defer server.Close()
+
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion("eu-west-1"),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("id", "secret", "")),
+		config.WithRetryMaxAttempts(1),
+		withAPITracing(),
+	)
+	require.NoError(t, err)
+	mskClient := kafka.NewFromConfig(cfg, func(o *kafka.Options) { o.BaseEndpoint = aws.String(server.URL) })
+
+	_, err = mskClient.ListClustersV2(context.Background(), &kafka.ListClustersV2Input{})
+	require.NoError(t, err)
+
+	status = http.StatusBadRequest
+	_, err = mskClient.ListClustersV2(context.Background(), &kafka.ListClustersV2Input{})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "Kafka.ListClustersV2", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("cloud.region", "eu-west-1"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("rpc.method", "ListClustersV2"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code, "a failed call marks its span as an error")
+}
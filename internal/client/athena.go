@@ -0,0 +1,20 @@
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+)
+
+func NewAthenaClient(region string) (*athena.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "athena")
+	if err != nil {
+		return nil, err
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+
+	return athena.NewFromConfig(cfg), nil
+}
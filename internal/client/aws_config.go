@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// Service keys accepted by --service-endpoint-url, named like the AWS CLI
+// commands. The kafka, cloudwatch and ce keys are shared with
+// --api-service-rate-limit.
+var endpointServices = []string{
+	"athena", APIServiceCostExplorer, "cloudtrail", APIServiceCloudWatch, "ec2", "glue", "iam", APIServiceKafka, "kafkaconnect",
+	"s3", "secretsmanager", "service-quotas", "sns", "ssm", "sts",
+}
+
+// awsEndpoint holds the --endpoint-url and --service-endpoint-url overrides
+// shared by every AWS client in the process. An empty URL leaves endpoint
+// resolution to the SDK.
+var awsEndpoint struct {
+	mu       sync.RWMutex
+	url      string
+	services map[string]string
+}
+
+// SetAWSEndpointURL sends every AWS API call to endpointURL instead of the
+// service's regional endpoint, e.g. http://localhost:4566 for LocalStack or
+// a VPC interface endpoint. An empty URL keeps the endpoints configured by
+// endpoint_url in ~/.aws/config or AWS_ENDPOINT_URL(_<SERVICE>).
+func SetAWSEndpointURL(endpointURL string) error {
+	if endpointURL != "" {
+		if err := validateEndpointURL(endpointURL); err != nil {
+			return err
+		}
+	}
+
+	awsEndpoint.mu.Lock()
+	defer awsEndpoint.mu.Unlock()
+	awsEndpoint.url = endpointURL
+	return nil
+}
+
+// SetAWSServiceEndpointURLs sends the API calls of each service to its own
+// endpoint, e.g. kafka to the MSK VPC interface endpoint, overriding
+// SetAWSEndpointURL for that service. Services without an entry are
+// unaffected.
+func SetAWSServiceEndpointURLs(endpointURLs map[string]string) error {
+	services := make(map[string]string, len(endpointURLs))
+	for service, endpointURL := range endpointURLs {
+		service = strings.ToLower(strings.TrimSpace(service))
+		if !slices.Contains(endpointServices, service) {
+			return fmt.Errorf("unknown service '%s' for endpoint url, expected one of: %s", service, strings.Join(endpointServices, ", "))
+		}
+		if err := validateEndpointURL(endpointURL); err != nil {
+			return err
+		}
+		services[service] = endpointURL
+	}
+
+	awsEndpoint.mu.Lock()
+	defer awsEndpoint.mu.Unlock()
+	awsEndpoint.services = services
+	return nil
+}
+
+func validateEndpointURL(endpointURL string) error {
+	parsed, err := url.Parse(endpointURL)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint url '%s': %w", endpointURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid endpoint url '%s': expected http(s)://host[:port]", endpointURL)
+	}
+	return nil
+}
+
+// awsEndpointURL returns the endpoint override for service: its
+// --service-endpoint-url entry, else --endpoint-url.
+func awsEndpointURL(service string) string {
+	awsEndpoint.mu.RLock()
+	defer awsEndpoint.mu.RUnlock()
+	if endpointURL, ok := awsEndpoint.services[service]; ok {
+		return endpointURL
+	}
+	return awsEndpoint.url
+}
+
+// loadAWSConfig loads the shared AWS configuration for service with kcp's
+// rate limiting, tracing and audit middleware. Settings from the environment
+// and the selected ~/.aws/config profile, such as max_attempts, retry_mode
+// and endpoint_url, are honored; --service-endpoint-url and --endpoint-url
+// take precedence over them.
+func loadAWSConfig(ctx context.Context, service string) (aws.Config, error) {
+	opts := []func(*config.LoadOptions) error{
+		withAPIRateLimit(service),
+		withAPITracing(),
+		withAPIAudit(),
+	}
+	if endpointURL := awsEndpointURL(service); endpointURL != "" {
+		opts = append(opts, config.WithBaseEndpoint(endpointURL))
+	}
+	return config.LoadDefaultConfig(ctx, opts...)
+}
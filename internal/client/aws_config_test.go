@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useSharedConfig points the SDK at a temporary ~/.aws/config holding
+// profile and clears environment overrides of its settings.
+func useSharedConfig(t *testing.T, profile string) {
+	t.Helper()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(configPath, []byte("[default]\nregion = us-east-1\n"+profile), 0600))
+	t.Setenv("AWS_CONFIG_FILE", configPath)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	for _, name := range []string{"AWS_PROFILE", "AWS_MAX_ATTEMPTS", "AWS_RETRY_MODE", "AWS_ENDPOINT_URL"} {
+		t.Setenv(name, "")
+		require.NoError(t, os.Unsetenv(name))
+	}
+}
+
+func TestLoadAWSConfig_HonorsSharedConfigProfile(t *testing.T) {
+	useSharedConfig(t, "[profile localstack]\nmax_attempts = 7\nretry_mode = adaptive\nendpoint_url = http://localhost:4566\n")
+	t.Setenv("AWS_PROFILE", "localstack")
+
+	cfg, err := loadAWSConfig(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.RetryMaxAttempts)
+	assert.Equal(t, aws.RetryModeAdaptive, cfg.RetryMode)
+	assert.Equal(t, "http://localhost:4566", aws.ToString(cfg.BaseEndpoint))
+
+	msk, err := NewMSKClient("", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 7, msk.Options().Retryer.MaxAttempts(), "the profile's max_attempts replaces kcp's MSK default")
+
+	s3Client, err := NewS3Client("")
+	require.NoError(t, err)
+	assert.True(t, s3Client.Options().UsePathStyle)
+}
+
+func TestLoadAWSConfig_EndpointURLOverride(t *testing.T) {
+	useSharedConfig(t, "")
+	t.Cleanup(func() { _ = SetAWSEndpointURL("") })
+
+	cfg, err := loadAWSConfig(context.Background(), "")
+	require.NoError(t, err)
+	assert.Nil(t, cfg.BaseEndpoint)
+
+	msk, err := NewMSKClient("", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, msk.Options().Retryer.MaxAttempts())
+
+	require.NoError(t, SetAWSEndpointURL("https://vpce-0abc.kafka.us-east-1.vpce.amazonaws.com"))
+	cfg, err = loadAWSConfig(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://vpce-0abc.kafka.us-east-1.vpce.amazonaws.com", aws.ToString(cfg.BaseEndpoint))
+}
+
+func TestSetAWSEndpointURL_RejectsInvalidURLs(t *testing.T) {
+	t.Cleanup(func() { _ = SetAWSEndpointURL("") })
+
+	assert.ErrorContains(t, SetAWSEndpointURL("localhost:4566"), "expected http(s)://host[:port]")
+	assert.ErrorContains(t, SetAWSEndpointURL("http://"), "expected http(s)://host[:port]")
+	assert.NoError(t, SetAWSEndpointURL("http://localhost:4566"))
+	assert.Equal(t, "http://localhost:4566", awsEndpointURL(""))
+}
+
+func TestSetAWSServiceEndpointURLs(t *testing.T) {
+	useSharedConfig(t, "")
+	t.Cleanup(func() {
+		_ = SetAWSEndpointURL("")
+		_ = SetAWSServiceEndpointURLs(nil)
+	})
+
+	require.NoError(t, SetAWSEndpointURL("http://localhost:4566"))
+	require.NoError(t, SetAWSServiceEndpointURLs(map[string]string{"Kafka": "https://vpce-0abc.kafka.us-east-1.vpce.amazonaws.com"}))
+
+	msk, err := NewMSKClient("", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "https://vpce-0abc.kafka.us-east-1.vpce.amazonaws.com", aws.ToString(msk.Options().BaseEndpoint))
+
+	s3Client, err := NewS3Client("")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4566", aws.ToString(s3Client.Options().BaseEndpoint), "services without an entry use --endpoint-url")
+
+	assert.ErrorContains(t, SetAWSServiceEndpointURLs(map[string]string{"msk": "https://vpce-0abc.kafka.us-east-1.vpce.amazonaws.com"}), "unknown service 'msk'")
+	assert.ErrorContains(t, SetAWSServiceEndpointURLs(map[string]string{"kafka": "vpce-0abc"}), "expected http(s)://host[:port]")
+}
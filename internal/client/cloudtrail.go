@@ -0,0 +1,20 @@
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+)
+
+func NewCloudTrailClient(region string) (*cloudtrail.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "cloudtrail")
+	if err != nil {
+		return nil, err
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+
+	return cloudtrail.NewFromConfig(cfg), nil
+}
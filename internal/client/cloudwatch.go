@@ -4,12 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 )
 
 func NewCloudWatchClient(region string) (*cloudwatch.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := loadAWSConfig(context.TODO(), APIServiceCloudWatch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %v", err)
 	}
@@ -4,12 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
 func NewEC2Client(region string) (*ec2.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := loadAWSConfig(context.TODO(), "ec2")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %v", err)
 	}
@@ -4,12 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 )
 
 func NewGlueClient(ctx context.Context, region string) (*glue.Client, error) {
-	cfg, err := config.LoadDefaultConfig(ctx)
+	cfg, err := loadAWSConfig(ctx, "glue")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
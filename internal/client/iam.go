@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
 
 func NewIAMClient() (*iam.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := loadAWSConfig(context.TODO(), "iam")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %v", err)
 	}
 
+	// IAM is a global service, so any region signs requests correctly; fall
+	// back to us-east-1 rather than failing every call when none is configured.
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
 	iamClient := iam.NewFromConfig(cfg)
 
 	return iamClient, nil
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/aws/aws-msk-iam-sasl-signer-go/signer"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/audit"
 	"github.com/confluentinc/kcp/internal/types"
+	"golang.org/x/net/proxy"
 )
 
 // AdminConfig holds the configuration for creating a Kafka admin client
@@ -28,6 +31,11 @@ type AdminConfig struct {
 	clientCertFile        string
 	clientKeyFile         string
 	disableTLS            bool
+	dialer                proxy.Dialer
+	configure             func(*sarama.Config)
+	dialTimeout           time.Duration
+	readTimeout           time.Duration
+	kafkaVersion          string
 }
 
 // AdminOption is a function type for configuring the Kafka admin client
@@ -100,6 +108,77 @@ func WithInsecureSkipVerify() AdminOption {
 	}
 }
 
+// WithDialer routes broker connections through dialer, which receives the
+// broker addresses unchanged, e.g. to reach brokers through an SSM tunnel.
+func WithDialer(dialer proxy.Dialer) AdminOption {
+	return func(config *AdminConfig) {
+		config.dialer = dialer
+	}
+}
+
+// ConnectionSettings are user overrides of the connection defaults set in
+// configureCommonSettings. Zero values keep the defaults.
+type ConnectionSettings struct {
+	DialTimeout time.Duration
+	ReadTimeout time.Duration
+	// KafkaVersion is the protocol version to speak, e.g. "2.8.1", instead of
+	// the one derived from the cluster.
+	KafkaVersion          string
+	InsecureSkipTLSVerify bool
+}
+
+// Validate checks that the timeouts are not negative and the Kafka version parses.
+func (s ConnectionSettings) Validate() error {
+	if s.DialTimeout < 0 || s.ReadTimeout < 0 {
+		return fmt.Errorf("Kafka client timeouts must not be negative")
+	}
+	if s.KafkaVersion != "" {
+		if _, err := sarama.ParseKafkaVersion(s.KafkaVersion); err != nil {
+			return fmt.Errorf("invalid Kafka version '%s': %w", s.KafkaVersion, err)
+		}
+	}
+	return nil
+}
+
+// AdminOptions returns the options that apply s. They must come after the auth
+// option, which sets its own TLS verification.
+func (s ConnectionSettings) AdminOptions() []AdminOption {
+	opts := []AdminOption{WithTimeouts(s.DialTimeout, s.ReadTimeout)}
+	if s.KafkaVersion != "" {
+		opts = append(opts, WithKafkaVersion(s.KafkaVersion))
+	}
+	if s.InsecureSkipTLSVerify {
+		opts = append(opts, WithInsecureSkipVerify())
+	}
+	return opts
+}
+
+// WithTimeouts overrides the broker dial and read timeouts, so connections to
+// unhealthy brokers fail fast instead of waiting out the defaults. A zero
+// duration keeps the default.
+func WithTimeouts(dial, read time.Duration) AdminOption {
+	return func(config *AdminConfig) {
+		config.dialTimeout = dial
+		config.readTimeout = read
+	}
+}
+
+// WithKafkaVersion overrides the Kafka protocol version the client speaks,
+// e.g. for brokers that reject the API versions of the derived one.
+func WithKafkaVersion(version string) AdminOption {
+	return func(config *AdminConfig) {
+		config.kafkaVersion = version
+	}
+}
+
+// WithSaramaConfig applies configure to the sarama config after the auth and
+// common settings, e.g. to turn on producer acks for a client that produces.
+func WithSaramaConfig(configure func(*sarama.Config)) AdminOption {
+	return func(config *AdminConfig) {
+		config.configure = configure
+	}
+}
+
 // AdminOptionForAuthMethod maps an auth type + method config to the corresponding
 // AdminOption. skipTLSVerify applies to SASL/SCRAM (MSK passes false — AWS-managed
 // certs; Apache Kafka passes its InsecureSkipTLSVerify).
@@ -247,6 +326,23 @@ func configureCommonSettings(config *sarama.Config, clientID string, kafkaVersio
 	config.Metadata.Retry.Backoff = 250 * time.Millisecond
 }
 
+func configureTimeouts(config *sarama.Config, dialTimeout, readTimeout time.Duration) {
+	if dialTimeout > 0 {
+		config.Net.DialTimeout = dialTimeout
+	}
+	if readTimeout > 0 {
+		config.Net.ReadTimeout = readTimeout
+	}
+}
+
+func configureDialer(config *sarama.Config, dialer proxy.Dialer) {
+	if dialer == nil {
+		return
+	}
+	config.Net.Proxy.Enable = true
+	config.Net.Proxy.Dialer = dialer
+}
+
 // ClusterKafkaMetadata represents cluster information including brokers, controller, and cluster ID
 type ClusterKafkaMetadata struct {
 	Brokers      []*sarama.Broker
@@ -260,6 +356,14 @@ type KafkaAdmin interface {
 	GetClusterKafkaMetadata() (*ClusterKafkaMetadata, error)
 	DescribeConfig() ([]sarama.ConfigEntry, error)
 	ListAcls() ([]sarama.ResourceAcls, error)
+	CreateAcls(creations []*sarama.AclCreation) ([]error, error)
+	DescribeLogDirs(brokerIDs []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error)
+	DescribeConsumerGroups() ([]*sarama.GroupDescription, error)
+	DescribeTopicPartitions() ([]*sarama.TopicMetadata, error)
+	ListPartitionReassignments(partitions map[string][]int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error)
+	SampleMessages(topic string, maxMessages int, timeout time.Duration) ([]SampledMessage, error)
+	ListEndOffsets(topics []string) (map[string]int64, error)
+	ListConsumerGroupOffsets(groupID string) (map[string]map[int32]int64, error)
 	Close() error
 }
 
@@ -277,6 +381,7 @@ func (m *MSKAccessTokenProvider) Token() (*sarama.AccessToken, error) {
 // KafkaAdminClient wraps sarama.ClusterAdmin to implement our KafkaAdmin interface
 type KafkaAdminClient struct {
 	admin           sarama.ClusterAdmin
+	client          sarama.Client // the admin's client, for SampleMessages and ListEndOffsets
 	region          string
 	config          AdminConfig
 	saramaConfig    *sarama.Config
@@ -289,7 +394,9 @@ A custom implementation of the ListTopics() function in Sarama that returns all
 instead of just overridden configs. This was done to reduce the number of requests to the broker.
 https://github.com/IBM/sarama/blob/main/admin.go#L349
 */
-func (k *KafkaAdminClient) ListTopicsWithConfigs() (map[string]sarama.TopicDetail, error) {
+func (k *KafkaAdminClient) ListTopicsWithConfigs() (_ map[string]sarama.TopicDetail, err error) {
+	defer k.recordCall("ListTopicsWithConfigs", nil, time.Now(), &err)
+
 	// Get controller to use as a connection broker to avoid opening a new broker connection
 	controller, err := k.admin.Controller()
 	if err != nil {
@@ -364,14 +471,19 @@ func (k *KafkaAdminClient) ListTopicsWithConfigs() (map[string]sarama.TopicDetai
 	return topicsDetailsMap, nil
 }
 
-func (k *KafkaAdminClient) DescribeConfig() ([]sarama.ConfigEntry, error) {
-	return k.admin.DescribeConfig(sarama.ConfigResource{
+func (k *KafkaAdminClient) DescribeConfig() (_ []sarama.ConfigEntry, err error) {
+	resource := sarama.ConfigResource{
 		Type: sarama.ConfigResourceType(sarama.ConfigResourceType(sarama.BrokerResource)),
 		Name: "1",
-	})
+	}
+	defer k.recordCall("DescribeConfig", map[string]string{"resource_type": "broker", "resource_name": resource.Name}, time.Now(), &err)
+
+	return k.admin.DescribeConfig(resource)
 }
 
-func (k *KafkaAdminClient) GetClusterKafkaMetadata() (*ClusterKafkaMetadata, error) {
+func (k *KafkaAdminClient) GetClusterKafkaMetadata() (_ *ClusterKafkaMetadata, err error) {
+	defer k.recordCall("DescribeCluster", nil, time.Now(), &err)
+
 	brokers, controllerID, err := k.admin.DescribeCluster()
 	if err != nil {
 		return nil, err
@@ -418,7 +530,9 @@ func (k *KafkaAdminClient) getClusterIDFromBroker(broker *sarama.Broker) (string
 	return *metadata.ClusterID, nil
 }
 
-func (k *KafkaAdminClient) ListAcls() ([]sarama.ResourceAcls, error) {
+func (k *KafkaAdminClient) ListAcls() (_ []sarama.ResourceAcls, err error) {
+	defer k.recordCall("ListAcls", nil, time.Now(), &err)
+
 	aclFilter := sarama.AclFilter{
 		// nil means any resource name, principal, or host.
 		ResourceType:              sarama.AclResourceAny,
@@ -438,10 +552,284 @@ func (k *KafkaAdminClient) ListAcls() ([]sarama.ResourceAcls, error) {
 	return result, nil
 }
 
+// CreateAcls creates the ACLs in one request to the controller. Unlike
+// sarama's ClusterAdmin.CreateACLs, which drops them, it returns the broker's
+// error for each creation, in order (nil when created).
+func (k *KafkaAdminClient) CreateAcls(creations []*sarama.AclCreation) (_ []error, err error) {
+	defer k.recordCall("CreateAcls", map[string]int{"acls": len(creations)}, time.Now(), &err)
+
+	request := &sarama.CreateAclsRequest{AclCreations: creations}
+	if k.saramaConfig.Version.IsAtLeast(sarama.V2_0_0_0) {
+		request.Version = 1
+	}
+
+	controller, err := k.admin.Controller()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller: %w", err)
+	}
+	response, err := controller.CreateAcls(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ACLs: %w", err)
+	}
+
+	results := make([]error, len(creations))
+	for i, r := range response.AclCreationResponses {
+		if i >= len(results) || r.Err == sarama.ErrNoError {
+			continue
+		}
+		if r.ErrMsg != nil && *r.ErrMsg != "" {
+			results[i] = fmt.Errorf("%w: %s", r.Err, *r.ErrMsg)
+		} else {
+			results[i] = r.Err
+		}
+	}
+	return results, nil
+}
+
+// DescribeLogDirs returns the log directories of each broker with the on-disk
+// size of every partition replica they hold.
+func (k *KafkaAdminClient) DescribeLogDirs(brokerIDs []int32) (_ map[int32][]sarama.DescribeLogDirsResponseDirMetadata, err error) {
+	defer k.recordCall("DescribeLogDirs", map[string][]int32{"broker_ids": brokerIDs}, time.Now(), &err)
+
+	return k.admin.DescribeLogDirs(brokerIDs)
+}
+
+// DescribeConsumerGroups lists every consumer group on the cluster and
+// describes them, members included.
+func (k *KafkaAdminClient) DescribeConsumerGroups() (_ []*sarama.GroupDescription, err error) {
+	defer k.recordCall("DescribeConsumerGroups", nil, time.Now(), &err)
+
+	groups, err := k.admin.ListConsumerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consumer groups: %w", err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	groupIDs := make([]string, 0, len(groups))
+	for groupID := range groups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	descriptions, err := k.admin.DescribeConsumerGroups(groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe consumer groups: %w", err)
+	}
+	return descriptions, nil
+}
+
+// DescribeTopicPartitions returns the metadata of every topic, internal topics
+// included, with each partition's leader, replicas, ISR and offline replicas.
+func (k *KafkaAdminClient) DescribeTopicPartitions() (_ []*sarama.TopicMetadata, err error) {
+	defer k.recordCall("DescribeTopicPartitions", nil, time.Now(), &err)
+
+	controller, err := k.admin.Controller()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller: %w", err)
+	}
+	metadataResp, err := controller.GetMetadata(sarama.NewMetadataRequest(k.saramaConfig.Version, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+	return metadataResp.Topics, nil
+}
+
+// ListPartitionReassignments returns the reassignments in flight among the
+// given partitions, by topic and partition. It needs Kafka 2.4 or later.
+func (k *KafkaAdminClient) ListPartitionReassignments(partitions map[string][]int32) (_ map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, err error) {
+	defer k.recordCall("ListPartitionReassignments", map[string]int{"topics": len(partitions)}, time.Now(), &err)
+
+	if len(partitions) == 0 {
+		return nil, nil
+	}
+	// sarama's ClusterAdmin lists one topic per request; one request for all
+	// of them saves a round trip per topic.
+	request := &sarama.ListPartitionReassignmentsRequest{TimeoutMs: 60000}
+	for topic, ids := range partitions {
+		request.AddBlock(topic, ids)
+	}
+
+	controller, err := k.admin.Controller()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller: %w", err)
+	}
+	response, err := controller.ListPartitionReassignments(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partition reassignments: %w", err)
+	}
+	if response.ErrorCode != sarama.ErrNoError {
+		return nil, fmt.Errorf("failed to list partition reassignments: %w", response.ErrorCode)
+	}
+	return response.TopicStatus, nil
+}
+
+// SampledMessage is one message read by SampleMessages.
+type SampledMessage struct {
+	Value     []byte
+	Timestamp time.Time
+}
+
+// SampleMessages reads the values and timestamps of up to maxMessages of the
+// most recent messages on topic, spread across its partitions. It consumes partitions
+// directly, without joining a consumer group or committing offsets, and
+// returns what it has read when timeout elapses.
+func (k *KafkaAdminClient) SampleMessages(topic string, maxMessages int, timeout time.Duration) (_ []SampledMessage, err error) {
+	defer k.recordCall("SampleMessages", map[string]any{"topic": topic, "max_messages": maxMessages}, time.Now(), &err)
+
+	if k.client == nil {
+		return nil, fmt.Errorf("message sampling is not supported by this admin client")
+	}
+	partitions, err := k.client.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topic, err)
+	}
+	if len(partitions) == 0 || maxMessages <= 0 {
+		return nil, nil
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(k.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer func() { _ = consumer.Close() }()
+
+	perPartition := int64((maxMessages + len(partitions) - 1) / len(partitions))
+	deadline := time.After(timeout)
+	values := make([]SampledMessage, 0, maxMessages)
+	for _, partition := range partitions {
+		if len(values) >= maxMessages {
+			break
+		}
+		oldest, err := k.client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return values, fmt.Errorf("failed to get oldest offset of %s/%d: %w", topic, partition, err)
+		}
+		newest, err := k.client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return values, fmt.Errorf("failed to get newest offset of %s/%d: %w", topic, partition, err)
+		}
+		if newest <= oldest {
+			continue
+		}
+
+		pc, err := consumer.ConsumePartition(topic, partition, max(newest-perPartition, oldest))
+		if err != nil {
+			return values, fmt.Errorf("failed to consume %s/%d: %w", topic, partition, err)
+		}
+		done := false
+		for read := int64(0); read < perPartition && len(values) < maxMessages && !done; read++ {
+			select {
+			case msg := <-pc.Messages():
+				values = append(values, SampledMessage{Value: msg.Value, Timestamp: msg.Timestamp})
+				// Compaction and transaction markers leave offset gaps, so stop
+				// at the end of the partition rather than counting messages.
+				done = msg.Offset >= newest-1
+			case <-deadline:
+				_ = pc.Close()
+				return values, nil
+			}
+		}
+		_ = pc.Close()
+	}
+	return values, nil
+}
+
+// ListEndOffsets returns the sum over partitions of each topic's end offset,
+// the offset the next message produced will get. It asks each partition
+// leader once for all the partitions it leads.
+func (k *KafkaAdminClient) ListEndOffsets(topics []string) (_ map[string]int64, err error) {
+	defer k.recordCall("ListEndOffsets", map[string]int{"topics": len(topics)}, time.Now(), &err)
+
+	if k.client == nil {
+		return nil, fmt.Errorf("listing offsets is not supported by this admin client")
+	}
+	requests := make(map[*sarama.Broker]*sarama.OffsetRequest)
+	for _, topic := range topics {
+		partitions, err := k.client.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topic, err)
+		}
+		for _, partition := range partitions {
+			leader, err := k.client.Leader(topic, partition)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get leader of %s/%d: %w", topic, partition, err)
+			}
+			if requests[leader] == nil {
+				requests[leader] = sarama.NewOffsetRequest(k.saramaConfig.Version)
+			}
+			requests[leader].AddBlock(topic, partition, sarama.OffsetNewest, 1)
+		}
+	}
+
+	endOffsets := make(map[string]int64, len(topics))
+	for leader, request := range requests {
+		response, err := leader.GetAvailableOffsets(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list offsets on broker %d: %w", leader.ID(), err)
+		}
+		for topic, blocks := range response.Blocks {
+			for partition, block := range blocks {
+				if block.Err != sarama.ErrNoError {
+					return nil, fmt.Errorf("failed to list offset of %s/%d: %w", topic, partition, block.Err)
+				}
+				if request.Version == 0 {
+					if len(block.Offsets) > 0 {
+						endOffsets[topic] += block.Offsets[0]
+					}
+					continue
+				}
+				endOffsets[topic] += block.Offset
+			}
+		}
+	}
+	return endOffsets, nil
+}
+
+// ListConsumerGroupOffsets returns the offsets groupID has committed, by
+// topic and partition. Partitions the group has no commit for are left out.
+func (k *KafkaAdminClient) ListConsumerGroupOffsets(groupID string) (_ map[string]map[int32]int64, err error) {
+	defer k.recordCall("ListConsumerGroupOffsets", map[string]string{"group_id": groupID}, time.Now(), &err)
+
+	response, err := k.admin.ListConsumerGroupOffsets(groupID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch offsets of consumer group %s: %w", groupID, err)
+	}
+	if response.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("failed to fetch offsets of consumer group %s: %w", groupID, response.Err)
+	}
+	offsets := make(map[string]map[int32]int64, len(response.Blocks))
+	for topic, blocks := range response.Blocks {
+		for partition, block := range blocks {
+			if block.Err != sarama.ErrNoError || block.Offset < 0 {
+				continue
+			}
+			if offsets[topic] == nil {
+				offsets[topic] = make(map[int32]int64, len(blocks))
+			}
+			offsets[topic][partition] = block.Offset
+		}
+	}
+	return offsets, nil
+}
+
 func (k *KafkaAdminClient) Close() error {
 	return k.admin.Close()
 }
 
+// recordCall writes an admin request to the audit log. It is deferred with a
+// pointer to the method's named error so the entry carries the final result.
+func (k *KafkaAdminClient) recordCall(operation string, params any, start time.Time, err *error) {
+	recordKafkaCall(k.brokerAddresses, k.region, operation, params, start, *err)
+}
+
+func recordKafkaCall(brokerAddresses []string, region, operation string, params any, start time.Time, err error) {
+	audit.Record(audit.Entry{
+		System:    audit.SystemKafka,
+		Operation: operation,
+		Region:    region,
+		Target:    strings.Join(brokerAddresses, ","),
+	}, params, start, err)
+}
+
 // NewKafkaClient creates a sarama.Client (not a ClusterAdmin) for offset fetching.
 // Uses the same auth configuration options as NewKafkaAdmin.
 func NewKafkaClient(brokerAddresses []string, region string, opts ...AdminOption) (sarama.Client, error) {
@@ -452,8 +840,18 @@ func NewKafkaClient(brokerAddresses []string, region string, opts ...AdminOption
 		opt(&config)
 	}
 
+	saramaKafkaVersion := sarama.V2_6_0_0
+	if config.kafkaVersion != "" {
+		version, err := sarama.ParseKafkaVersion(config.kafkaVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Kafka version: %w", err)
+		}
+		saramaKafkaVersion = version
+	}
+
 	saramaConfig := sarama.NewConfig()
-	configureCommonSettings(saramaConfig, "kcp-cli", sarama.V2_6_0_0)
+	configureCommonSettings(saramaConfig, "kcp-cli", saramaKafkaVersion)
+	configureTimeouts(saramaConfig, config.dialTimeout, config.readTimeout)
 
 	switch config.authType {
 	case types.AuthTypeIAM:
@@ -474,7 +872,14 @@ func NewKafkaClient(brokerAddresses []string, region string, opts ...AdminOption
 		return nil, fmt.Errorf("auth type %v not supported", config.authType)
 	}
 
+	configureDialer(saramaConfig, config.dialer)
+	if config.configure != nil {
+		config.configure(saramaConfig)
+	}
+
+	start := time.Now()
 	client, err := sarama.NewClient(brokerAddresses, saramaConfig)
+	recordKafkaCall(brokerAddresses, region, "Connect", map[string]any{"auth_type": config.authType, "client_id": saramaConfig.ClientID}, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kafka client: authType=%v brokerAddresses=%v error=%w", config.authType, brokerAddresses, err)
 	}
@@ -494,6 +899,9 @@ func NewKafkaAdmin(brokerAddresses []string, clientBrokerEncryptionInTransit kaf
 		opt(&config)
 	}
 
+	if config.kafkaVersion != "" {
+		kafkaVersion = config.kafkaVersion
+	}
 	saramaKafkaVersion, err := sarama.ParseKafkaVersion(kafkaVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse Kafka version: %v", err)
@@ -501,6 +909,7 @@ func NewKafkaAdmin(brokerAddresses []string, clientBrokerEncryptionInTransit kaf
 
 	saramaConfig := sarama.NewConfig()
 	configureCommonSettings(saramaConfig, "kcp-cli", saramaKafkaVersion)
+	configureTimeouts(saramaConfig, config.dialTimeout, config.readTimeout)
 
 	switch config.authType {
 	case types.AuthTypeIAM:
@@ -524,13 +933,31 @@ func NewKafkaAdmin(brokerAddresses []string, clientBrokerEncryptionInTransit kaf
 		return nil, fmt.Errorf("auth type: %v not yet supported", config.authType)
 	}
 
-	admin, err := sarama.NewClusterAdmin(brokerAddresses, saramaConfig)
+	configureDialer(saramaConfig, config.dialer)
+	if config.configure != nil {
+		config.configure(saramaConfig)
+	}
+
+	// Build the admin from an explicit client (as sarama.NewClusterAdmin
+	// does internally) so SampleMessages can consume over the same
+	// connections. Closing the admin closes the client.
+	start := time.Now()
+	kafkaClient, err := sarama.NewClient(brokerAddresses, saramaConfig)
+	var admin sarama.ClusterAdmin
+	if err == nil {
+		admin, err = sarama.NewClusterAdminFromClient(kafkaClient)
+		if err != nil {
+			_ = kafkaClient.Close()
+		}
+	}
+	recordKafkaCall(brokerAddresses, region, "Connect", map[string]any{"auth_type": config.authType, "client_id": saramaConfig.ClientID}, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create admin client: authType=%v brokerAddresses=%v error=%v", config.authType, brokerAddresses, err)
 	}
 
 	return &KafkaAdminClient{
 		admin:           admin,
+		client:          kafkaClient,
 		region:          region,
 		config:          config,
 		saramaConfig:    saramaConfig,
@@ -332,6 +332,40 @@ func TestConfigureCommonSettings(t *testing.T) {
 	assert.Equal(t, 250*time.Millisecond, config.Metadata.Retry.Backoff)
 }
 
+func TestConfigureTimeouts(t *testing.T) {
+	config := sarama.NewConfig()
+	configureCommonSettings(config, "test-client", sarama.V4_0_0_0)
+
+	configureTimeouts(config, 0, 5*time.Second)
+
+	assert.Equal(t, 10*time.Second, config.Net.DialTimeout, "zero keeps the default")
+	assert.Equal(t, 5*time.Second, config.Net.ReadTimeout)
+}
+
+func TestConnectionSettings(t *testing.T) {
+	settings := ConnectionSettings{
+		DialTimeout:           3 * time.Second,
+		ReadTimeout:           8 * time.Second,
+		KafkaVersion:          "2.8.1",
+		InsecureSkipTLSVerify: true,
+	}
+	require.NoError(t, settings.Validate())
+
+	config := AdminConfig{}
+	WithSASLSCRAMAuth("user", "pass", "SHA512", false)(&config)
+	for _, opt := range settings.AdminOptions() {
+		opt(&config)
+	}
+	assert.Equal(t, 3*time.Second, config.dialTimeout)
+	assert.Equal(t, 8*time.Second, config.readTimeout)
+	assert.Equal(t, "2.8.1", config.kafkaVersion)
+	assert.True(t, config.insecureSkipTLSVerify, "overrides the auth option's TLS verification")
+
+	assert.Len(t, ConnectionSettings{}.AdminOptions(), 1, "defaults only set the zero timeouts")
+	assert.Error(t, ConnectionSettings{KafkaVersion: "not-a-version"}.Validate())
+	assert.Error(t, ConnectionSettings{ReadTimeout: -time.Second}.Validate())
+}
+
 func TestConfigureSASLTypeOAuthAuthentication(t *testing.T) {
 	config := sarama.NewConfig()
 	region := "us-west-2"
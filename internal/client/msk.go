@@ -8,7 +8,6 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 	"golang.org/x/time/rate"
 )
@@ -19,17 +18,21 @@ type RateLimitedMSKClient struct {
 }
 
 func NewMSKClient(region string, requestsPerSecond float64, burstSize int) (*RateLimitedMSKClient, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		// https://docs.aws.amazon.com/sdk-for-go/v2/developer-guide/configure-retries-timeouts.html
-		config.WithRetryer(func() aws.Retryer {
+	cfg, err := loadAWSConfig(context.TODO(), APIServiceKafka)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	// https://docs.aws.amazon.com/sdk-for-go/v2/developer-guide/configure-retries-timeouts.html
+	// A profile's max_attempts or retry_mode (or AWS_MAX_ATTEMPTS /
+	// AWS_RETRY_MODE) takes precedence over kcp's default retryer.
+	if cfg.RetryMaxAttempts == 0 && cfg.RetryMode == "" {
+		cfg.Retryer = func() aws.Retryer {
 			return retry.NewStandard(func(opts *retry.StandardOptions) {
 				opts.MaxAttempts = 3
 				opts.MaxBackoff = 20 * time.Second
 			})
-		}),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+		}
 	}
 
 	if region != "" {
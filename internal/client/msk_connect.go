@@ -4,12 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/kafkaconnect"
 )
 
 func NewMSKConnectClient(region string) (*kafkaconnect.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := loadAWSConfig(context.TODO(), "kafkaconnect")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %v", err)
 	}
@@ -3,12 +3,11 @@ package client
 import (
 	"context"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 func NewS3Client(region string) (*s3.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := loadAWSConfig(context.TODO(), "s3")
 	if err != nil {
 		return nil, err
 	}
@@ -17,5 +16,9 @@ func NewS3Client(region string) (*s3.Client, error) {
 		cfg.Region = region
 	}
 
-	return s3.NewFromConfig(cfg), nil
+	// LocalStack and other custom endpoints serve buckets by path rather than
+	// by virtual-hosted subdomain.
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = cfg.BaseEndpoint != nil
+	}), nil
 }
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+func NewSecretsManagerClient(region string) (*secretsmanager.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "secretsmanager")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+
+	return secretsmanager.NewFromConfig(cfg), nil
+}
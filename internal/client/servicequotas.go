@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+)
+
+func NewServiceQuotasClient(region string) (*servicequotas.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "service-quotas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+
+	serviceQuotasClient := servicequotas.NewFromConfig(cfg)
+
+	return serviceQuotasClient, nil
+}
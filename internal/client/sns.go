@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+func NewSNSClient(region string) (*sns.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "sns")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+
+	snsClient := sns.NewFromConfig(cfg)
+
+	return snsClient, nil
+}
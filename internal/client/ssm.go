@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func NewSSMClient(region string) (*ssm.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "ssm")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+
+	ssmClient := ssm.NewFromConfig(cfg)
+
+	return ssmClient, nil
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// NewSTSClient returns an STS client for region, falling back to the default
+// config's region and then us-east-1: STS needs a region to resolve its
+// endpoint, but GetCallerIdentity answers the same from any of them.
+func NewSTSClient(region string) (*sts.Client, error) {
+	cfg, err := loadAWSConfig(context.TODO(), "sts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if region != "" {
+		cfg.Region = region
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	stsClient := sts.NewFromConfig(cfg)
+
+	return stsClient, nil
+}
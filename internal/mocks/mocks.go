@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -13,11 +14,19 @@ import (
 
 // MockKafkaAdmin is a mock implementation of the KafkaAdmin interface
 type MockKafkaAdmin struct {
-	ListTopicsWithConfigsFunc   func() (map[string]sarama.TopicDetail, error)
-	GetClusterKafkaMetadataFunc func() (*client.ClusterKafkaMetadata, error)
-	DescribeConfigFunc          func() ([]sarama.ConfigEntry, error)
-	ListAclsFunc                func() ([]sarama.ResourceAcls, error)
-	CloseFunc                   func() error
+	ListTopicsWithConfigsFunc      func() (map[string]sarama.TopicDetail, error)
+	GetClusterKafkaMetadataFunc    func() (*client.ClusterKafkaMetadata, error)
+	DescribeConfigFunc             func() ([]sarama.ConfigEntry, error)
+	ListAclsFunc                   func() ([]sarama.ResourceAcls, error)
+	CreateAclsFunc                 func(creations []*sarama.AclCreation) ([]error, error)
+	DescribeLogDirsFunc            func(brokerIDs []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error)
+	DescribeConsumerGroupsFunc     func() ([]*sarama.GroupDescription, error)
+	DescribeTopicPartitionsFunc    func() ([]*sarama.TopicMetadata, error)
+	ListPartitionReassignmentsFunc func(partitions map[string][]int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error)
+	SampleMessagesFunc             func(topic string, maxMessages int, timeout time.Duration) ([]client.SampledMessage, error)
+	ListEndOffsetsFunc             func(topics []string) (map[string]int64, error)
+	ListConsumerGroupOffsetsFunc   func(groupID string) (map[string]map[int32]int64, error)
+	CloseFunc                      func() error
 }
 
 func (m *MockKafkaAdmin) ListTopicsWithConfigs() (map[string]sarama.TopicDetail, error) {
@@ -36,6 +45,38 @@ func (m *MockKafkaAdmin) ListAcls() ([]sarama.ResourceAcls, error) {
 	return m.ListAclsFunc()
 }
 
+func (m *MockKafkaAdmin) CreateAcls(creations []*sarama.AclCreation) ([]error, error) {
+	return m.CreateAclsFunc(creations)
+}
+
+func (m *MockKafkaAdmin) DescribeLogDirs(brokerIDs []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	return m.DescribeLogDirsFunc(brokerIDs)
+}
+
+func (m *MockKafkaAdmin) DescribeConsumerGroups() ([]*sarama.GroupDescription, error) {
+	return m.DescribeConsumerGroupsFunc()
+}
+
+func (m *MockKafkaAdmin) DescribeTopicPartitions() ([]*sarama.TopicMetadata, error) {
+	return m.DescribeTopicPartitionsFunc()
+}
+
+func (m *MockKafkaAdmin) ListPartitionReassignments(partitions map[string][]int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	return m.ListPartitionReassignmentsFunc(partitions)
+}
+
+func (m *MockKafkaAdmin) SampleMessages(topic string, maxMessages int, timeout time.Duration) ([]client.SampledMessage, error) {
+	return m.SampleMessagesFunc(topic, maxMessages, timeout)
+}
+
+func (m *MockKafkaAdmin) ListEndOffsets(topics []string) (map[string]int64, error) {
+	return m.ListEndOffsetsFunc(topics)
+}
+
+func (m *MockKafkaAdmin) ListConsumerGroupOffsets(groupID string) (map[string]map[int32]int64, error) {
+	return m.ListConsumerGroupOffsetsFunc(groupID)
+}
+
 func (m *MockKafkaAdmin) Close() error {
 	return m.CloseFunc()
 }
@@ -91,9 +132,19 @@ func (m *MockMSKService) ListScramSecrets(ctx context.Context, clusterArn string
 
 // MockEC2Service is a mock implementation of the EC2Service interface
 type MockEC2Service struct {
-	DescribeSubnetsFunc func(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error)
+	DescribeSubnetsFunc           func(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error)
+	DescribeNetworkInterfacesFunc func(ctx context.Context, networkInterfaceIds []string) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DescribeSecurityGroupsFunc    func(ctx context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error)
 }
 
 func (m *MockEC2Service) DescribeSubnets(ctx context.Context, subnetIds []string) (*ec2.DescribeSubnetsOutput, error) {
 	return m.DescribeSubnetsFunc(ctx, subnetIds)
 }
+
+func (m *MockEC2Service) DescribeNetworkInterfaces(ctx context.Context, networkInterfaceIds []string) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return m.DescribeNetworkInterfacesFunc(ctx, networkInterfaceIds)
+}
+
+func (m *MockEC2Service) DescribeSecurityGroups(ctx context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return m.DescribeSecurityGroupsFunc(ctx, groupIds)
+}
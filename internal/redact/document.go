@@ -0,0 +1,231 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Scope is where a redacted JSON document is headed.
+type Scope int
+
+const (
+	// ScopeFile is a file kcp writes locally and may read back, such as the
+	// state file.
+	ScopeFile Scope = iota
+	// ScopeUpload is a copy leaving the machine (--upload-to).
+	ScopeUpload
+)
+
+// UploadOnlyPaths are state fields that are not credentials themselves but
+// describe how to reach them, or who may. They stay in the local state file
+// because later commands read them back (create-asset source-import needs the
+// SCRAM secret ARNs) and are redacted from every uploaded copy.
+var UploadOnlyPaths = []string{
+	"msk_sources.regions.clusters.aws_client_information.ScramSecrets",
+	"msk_sources.regions.clusters.aws_client_information.policy",
+}
+
+var (
+	configMu     sync.RWMutex
+	enabled      = true
+	userPatterns []*regexp.Regexp
+	uploadOnly   = mustCompilePatterns(UploadOnlyPaths)
+)
+
+// Configure sets how JSON documents are redacted for the rest of the process:
+// whether redaction is on at all (--no-redact turns it off) and additional key
+// patterns (--redact-pattern) to redact on top of the built-in blacklist.
+//
+// A pattern is a case-insensitive glob where `*` matches any run of characters,
+// dots included. It matches a key when it matches either the key itself or the
+// key's dotted path from the document root, e.g. `*.password` or
+// `msk_sources.regions.clusters.name`. Array levels add nothing to the path.
+func Configure(on bool, patterns []string) error {
+	compiled, err := compilePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	enabled = on
+	userPatterns = compiled
+	return nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return nil, errors.New("redact pattern must not be empty")
+		}
+		expr := "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(p), `\*`, ".*") + "$"
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func mustCompilePatterns(patterns []string) []*regexp.Regexp {
+	compiled, err := compilePatterns(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+func matchesAny(patterns []*regexp.Regexp, key, path string) bool {
+	for _, re := range patterns {
+		if re.MatchString(key) || re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON returns a copy of the JSON document data with the values of sensitive
+// keys replaced by Placeholder, plus the number of keys redacted. A key is
+// sensitive when IsSensitive reports it, when it matches a pattern passed to
+// Configure, or — for ScopeUpload — when its path is one of UploadOnlyPaths.
+// UploadOnlyPaths are exempt from the built-in blacklist for ScopeFile.
+//
+// Key order, numbers and the shape of the document are preserved, so the
+// output still loads into the type it was marshalled from: only string leaves
+// beneath a sensitive key are replaced, and values that are null, empty or
+// already redacted are left alone and not counted. When redaction is disabled
+// data is returned unchanged.
+func JSON(data []byte, scope Scope) ([]byte, int, error) {
+	configMu.RLock()
+	on, patterns := enabled, userPatterns
+	configMu.RUnlock()
+	if !on {
+		return data, 0, nil
+	}
+
+	w := &documentRedactor{scope: scope, patterns: patterns}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := w.value(dec, "", false); err != nil {
+		return nil, 0, fmt.Errorf("failed to redact json: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, 0, errors.New("failed to redact json: trailing data after document")
+	}
+	return w.out.Bytes(), w.count, nil
+}
+
+// documentRedactor re-emits a JSON token stream, replacing string leaves
+// beneath sensitive keys.
+type documentRedactor struct {
+	scope    Scope
+	patterns []*regexp.Regexp
+	out      bytes.Buffer
+	count    int
+	// replaced is set when a string leaf is replaced, so a sensitive key is
+	// only counted when it actually held something.
+	replaced bool
+}
+
+func (w *documentRedactor) sensitive(key, path string) bool {
+	if matchesAny(w.patterns, key, path) {
+		return true
+	}
+	if matchesAny(uploadOnly, key, path) {
+		return w.scope == ScopeUpload
+	}
+	return IsSensitive(key)
+}
+
+// value copies the next value from dec, redacting every string within it when
+// redacting is set.
+func (w *documentRedactor) value(dec *json.Decoder, path string, redacting bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			w.out.WriteByte('{')
+			for i := 0; dec.More(); i++ {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key := keyTok.(string)
+				if i > 0 {
+					w.out.WriteByte(',')
+				}
+				w.writeJSON(key)
+				w.out.WriteByte(':')
+
+				keyPath := key
+				if path != "" {
+					keyPath = path + "." + key
+				}
+				if redacting || !w.sensitive(key, keyPath) {
+					if err := w.value(dec, keyPath, redacting); err != nil {
+						return err
+					}
+					continue
+				}
+				w.replaced = false
+				if err := w.value(dec, keyPath, true); err != nil {
+					return err
+				}
+				if w.replaced {
+					w.count++
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+			w.out.WriteByte('}')
+		case '[':
+			w.out.WriteByte('[')
+			for i := 0; dec.More(); i++ {
+				if i > 0 {
+					w.out.WriteByte(',')
+				}
+				if err := w.value(dec, path, redacting); err != nil {
+					return err
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+			w.out.WriteByte(']')
+		}
+	case string:
+		if redacting && t != "" && t != Placeholder {
+			t = Placeholder
+			w.replaced = true
+		}
+		w.writeJSON(t)
+	case json.Number:
+		w.out.WriteString(t.String())
+	default:
+		// bool or nil
+		w.writeJSON(t)
+	}
+	return nil
+}
+
+// writeJSON encodes v the way json.Marshal does, so a redacted document is
+// byte-identical to the input wherever nothing was redacted.
+func (w *documentRedactor) writeJSON(v any) {
+	data, _ := json.Marshal(v)
+	w.out.Write(data)
+}
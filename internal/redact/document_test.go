@@ -0,0 +1,138 @@
+package redact
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// configure sets the process-wide redaction config for one test and restores
+// the default afterwards.
+func configure(t *testing.T, on bool, patterns ...string) {
+	t.Helper()
+	if err := Configure(on, patterns); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { _ = Configure(true, nil) })
+}
+
+const stateDoc = `{"schema_version":8,"msk_sources":{"regions":[{"name":"us-east-1","clusters":[{"name":"orders",` +
+	`"aws_client_information":{"ScramSecrets":["arn:aws:secretsmanager:us-east-1:000000000000:secret:AmazonMSK_orders"],` +
+	`"policy":{"CurrentVersion":"K1","Policy":"{\"Version\":\"2012-10-17\"}"},"bootstrap_brokers":{"BootstrapBrokerStringSaslScram":"b-1:9096"}},` +
+	`"kafka_admin_client_information":{"topics":{"details":[{"name":"orders","partitions":6,"configurations":{"cleanup.policy":"delete","sasl.jaas.config":"org.apache.kafka...;"}}]}}}]}]}}`
+
+func TestJSON_FileScopeKeepsUploadOnlyPaths(t *testing.T) {
+	out, count, err := JSON([]byte(stateDoc), ScopeFile)
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+	want := `"configurations":{"cleanup.policy":"delete","sasl.jaas.config":"\u003ckcp-redacted\u003e"}`
+	if !json.Valid(out) || !strings.Contains(string(out), want) {
+		t.Errorf("output missing %s:\n%s", want, out)
+	}
+	if !strings.Contains(string(out), "AmazonMSK_orders") || !strings.Contains(string(out), `"CurrentVersion":"K1"`) {
+		t.Errorf("SCRAM secret ARNs and the cluster policy must stay in the local file:\n%s", out)
+	}
+}
+
+func TestJSON_UploadScopeRedactsUploadOnlyPathsKeepingShape(t *testing.T) {
+	out, count, err := JSON([]byte(stateDoc), ScopeUpload)
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+
+	var doc struct {
+		MSKSources struct {
+			Regions []struct {
+				Clusters []struct {
+					AWSClientInformation struct {
+						ScramSecrets []string          `json:"ScramSecrets"`
+						Policy       map[string]string `json:"policy"`
+					} `json:"aws_client_information"`
+				} `json:"clusters"`
+			} `json:"regions"`
+		} `json:"msk_sources"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("redacted document no longer loads into its type: %v", err)
+	}
+	info := doc.MSKSources.Regions[0].Clusters[0].AWSClientInformation
+	if !reflect.DeepEqual(info.ScramSecrets, []string{Placeholder}) {
+		t.Errorf("ScramSecrets = %v", info.ScramSecrets)
+	}
+	if !reflect.DeepEqual(info.Policy, map[string]string{"CurrentVersion": Placeholder, "Policy": Placeholder}) {
+		t.Errorf("policy = %v", info.Policy)
+	}
+	if !strings.Contains(string(out), `"partitions":6`) || !strings.Contains(string(out), "b-1:9096") {
+		t.Errorf("non-sensitive values must be untouched:\n%s", out)
+	}
+}
+
+func TestJSON_UserPatterns(t *testing.T) {
+	configure(t, true, "*.bootstrap_brokers", "NAME")
+
+	out, count, err := JSON([]byte(stateDoc), ScopeFile)
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	// sasl.jaas.config, bootstrap_brokers, and the region, cluster and topic names.
+	if count != 5 {
+		t.Errorf("count = %d, want 5:\n%s", count, out)
+	}
+	if strings.Contains(string(out), "b-1:9096") || strings.Contains(string(out), `"name":"orders"`) {
+		t.Errorf("user patterns not applied:\n%s", out)
+	}
+}
+
+func TestJSON_UnchangedWhenNothingSensitive(t *testing.T) {
+	in := `{"b":1.50,"a":[true,null,"x<y"],"c":{"password":"","token":null,"secret":"` + "\\u003ckcp-redacted\\u003e" + `"}}`
+	canonical, err := json.Marshal(json.RawMessage(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, count, err := JSON(canonical, ScopeUpload)
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count = %d, want 0 (empty, null and already-redacted values)", count)
+	}
+	if string(out) != string(canonical) {
+		t.Errorf("output changed:\n got %s\nwant %s", out, canonical)
+	}
+}
+
+func TestJSON_Disabled(t *testing.T) {
+	configure(t, false)
+
+	out, count, err := JSON([]byte(stateDoc), ScopeUpload)
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if count != 0 || string(out) != stateDoc {
+		t.Errorf("disabled redaction changed the document (count %d)", count)
+	}
+}
+
+func TestJSON_InvalidDocument(t *testing.T) {
+	for _, in := range []string{`{"a":`, `{"a":1} {"b":2}`} {
+		if _, _, err := JSON([]byte(in), ScopeFile); err == nil {
+			t.Errorf("JSON(%q) = nil error, want error", in)
+		}
+	}
+}
+
+func TestConfigure_RejectsEmptyPattern(t *testing.T) {
+	t.Cleanup(func() { _ = Configure(true, nil) })
+	if err := Configure(true, []string{"*.password", " "}); err == nil {
+		t.Error("Configure with an empty pattern = nil error, want error")
+	}
+}
@@ -0,0 +1,81 @@
+package redact
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// Infrastructure redaction removes AWS account and network identifiers from
+// text, for documents handed to application teams rather than the platform
+// team (kcp report plan --audience app-team). Unlike key-based document
+// redaction it matches values, since identifiers are embedded in free text
+// such as notes and policy names.
+var (
+	// arnPattern captures the resource part of an ARN, e.g. role/orders-app
+	// in arn:aws:iam::123456789012:role/orders-app.
+	arnPattern = regexp.MustCompile("arn:aws[a-z-]*:[a-z0-9-]*:[a-z0-9-]*:[0-9]*:([^\\s\"'`|,;()\\[\\]]+)")
+	// accountIDPattern matches a bare 12-digit AWS account ID.
+	accountIDPattern = regexp.MustCompile(`\b[0-9]{12}\b`)
+	// networkIDPattern matches VPC, subnet, security group, ENI and related
+	// network resource IDs.
+	networkIDPattern = regexp.MustCompile(`\b(?:subnet|sg|vpc|vpce|eni|rtb|igw|nat|tgw|pcx)-[0-9a-f]{8,17}\b`)
+)
+
+// Infrastructure returns s with ARNs reduced to their resource part (the
+// account ID and region are dropped, so a principal such as role/orders-app
+// stays recognisable) and bare account IDs and network resource IDs replaced
+// by Placeholder.
+func Infrastructure(s string) string {
+	s = arnPattern.ReplaceAllString(s, "${1}")
+	s = accountIDPattern.ReplaceAllString(s, Placeholder)
+	return networkIDPattern.ReplaceAllString(s, Placeholder)
+}
+
+// InfrastructureFields applies Infrastructure to every exported string
+// reachable from v, which must be a pointer, walking structs, pointers,
+// slices and map values in place. Map keys are left as they are.
+func InfrastructureFields(v any) {
+	redactFields(reflect.ValueOf(v))
+}
+
+func redactFields(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			redactFields(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		// The value inside an interface isn't addressable; redact a copy and
+		// store it back.
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		redactFields(elem)
+		v.Set(elem)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			redactFields(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			redactFields(v.Index(i))
+		}
+	case reflect.Map:
+		if !v.CanSet() {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			redactFields(elem)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(Infrastructure(v.String()))
+		}
+	}
+}
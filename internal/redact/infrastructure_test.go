@@ -0,0 +1,57 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfrastructure(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"arn:aws:iam::123456789012:role/orders-app", "role/orders-app"},
+		{"User:arn:aws:sts::123456789012:assumed-role/etl/session", "User:assumed-role/etl/session"},
+		{"`arn:aws:kafka:us-east-1:123456789012:topic/prod/abc-1/orders`", "`topic/prod/abc-1/orders`"},
+		{"account 123456789012 owns it", "account <kcp-redacted> owns it"},
+		{"reachable from subnet-0abc1234def567890 via sg-1234abcd", "reachable from <kcp-redacted> via <kcp-redacted>"},
+		{"orders topic with 1234567890123 bytes", "orders topic with 1234567890123 bytes"},
+		{"User:orders-producer", "User:orders-producer"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Infrastructure(tt.in), tt.in)
+	}
+}
+
+func TestInfrastructureFields(t *testing.T) {
+	type grant struct {
+		Principal string
+		Count     int
+	}
+	type doc struct {
+		Name     string
+		Grants   []grant
+		Notes    map[string]string
+		Optional *grant
+		Any      any
+		hidden   string
+	}
+	d := doc{
+		Name:     "vpc-0123456789abcdef0",
+		Grants:   []grant{{Principal: "arn:aws:iam::123456789012:role/app", Count: 123456789012}},
+		Notes:    map[string]string{"arn:aws:iam::123456789012:root": "account 123456789012"},
+		Optional: &grant{Principal: "arn:aws:iam::123456789012:user/bob"},
+		Any:      "sg-1234abcd",
+		hidden:   "sg-1234abcd",
+	}
+
+	InfrastructureFields(&d)
+
+	assert.Equal(t, Placeholder, d.Name)
+	assert.Equal(t, grant{Principal: "role/app", Count: 123456789012}, d.Grants[0])
+	assert.Equal(t, map[string]string{"arn:aws:iam::123456789012:root": "account " + Placeholder}, d.Notes)
+	assert.Equal(t, "user/bob", d.Optional.Principal)
+	assert.Equal(t, Placeholder, d.Any)
+	assert.Equal(t, "sg-1234abcd", d.hidden)
+}
@@ -1,8 +1,11 @@
 // Package redact removes sensitive values from connector configurations before
-// they are persisted to the state file or written to logs. Redaction is
-// always-on and one-way: the original value is never recoverable from the
-// redacted output. Matching is fail-closed — a key that looks sensitive is
-// redacted even at the cost of occasionally redacting a benign value.
+// they are persisted to the state file or written to logs, and from every JSON
+// document kcp writes or uploads (see JSON). Connector config redaction is
+// always-on; document redaction is on by default and configurable with
+// --redact-pattern / --no-redact. Redaction is one-way: the original value is
+// never recoverable from the redacted output. Matching is fail-closed — a key
+// that looks sensitive is redacted even at the cost of occasionally redacting a
+// benign value.
 package redact
 
 import (
@@ -0,0 +1,255 @@
+// Package aclapply creates the Kafka ACLs discovered on a source cluster
+// directly on the destination cluster through the Kafka admin API, for users
+// who don't manage ACLs in Terraform. ACLs are translated the way `kcp
+// create-asset migrate-acls kafka` translates them, and existing ACLs are
+// listed first, so re-running an apply only creates what is still missing.
+package aclapply
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// supportedResourceTypes are the ACL resource types Confluent Cloud accepts.
+var supportedResourceTypes = map[string]bool{
+	"topic":           true,
+	"group":           true,
+	"cluster":         true,
+	"transactionalid": true,
+}
+
+// Admin is the part of the Kafka admin client an apply needs.
+type Admin interface {
+	ListAcls() ([]sarama.ResourceAcls, error)
+	CreateAcls(creations []*sarama.AclCreation) ([]error, error)
+}
+
+// Acl is one translated ACL for the destination cluster.
+type Acl struct {
+	ResourceType   sarama.AclResourceType
+	ResourceName   string
+	PatternType    sarama.AclResourcePatternType
+	Principal      string
+	Host           string
+	Operation      sarama.AclOperation
+	PermissionType sarama.AclPermissionType
+	// SourcePrincipal is the principal the ACL was granted to on the source.
+	SourcePrincipal string
+}
+
+func (a Acl) String() string {
+	return fmt.Sprintf("%s %s %s:%s(%s) %s from %s", a.PermissionType.String(), a.Operation.String(), a.ResourceType.String(), a.ResourceName, strings.ToUpper(a.PatternType.String()), a.Principal, a.Host)
+}
+
+func (a Acl) key() string {
+	return strings.Join([]string{a.ResourceType.String(), a.ResourceName, a.PatternType.String(), a.Principal, a.Host, a.Operation.String(), a.PermissionType.String()}, "\x00")
+}
+
+// Skipped is a source ACL that cannot be created on the destination.
+type Skipped struct {
+	Acl    types.Acls
+	Reason string
+}
+
+// Action is one translated ACL, either already on the destination or (to
+// be) created. Err is set when the destination rejected the creation.
+type Action struct {
+	Acl     Acl
+	Exists  bool
+	Created bool
+	Err     error
+}
+
+// Translate converts the source ACLs for the destination: topic resources are
+// renamed by naming, and principals are replaced by their mapping, keyed by
+// source principal with or without the "User:" prefix. ACLs of unmapped
+// principals or of resource types Confluent Cloud does not support are
+// skipped. ACLs that translate to the same destination ACL are created once.
+func Translate(acls []types.Acls, naming topicnaming.Mapping, principals map[string]string) ([]Acl, []Skipped) {
+	var translated []Acl
+	var skipped []Skipped
+	seen := make(map[string]bool)
+
+	for i, acl := range naming.RenameAcls(acls) {
+		source := acls[i]
+		if !supportedResourceTypes[strings.ToLower(acl.ResourceType)] {
+			skipped = append(skipped, Skipped{Acl: source, Reason: fmt.Sprintf("resource type %s is not supported on Confluent Cloud", acl.ResourceType)})
+			continue
+		}
+		principal, ok := mapPrincipal(acl.Principal, principals)
+		if !ok {
+			skipped = append(skipped, Skipped{Acl: source, Reason: "no principal mapping"})
+			continue
+		}
+
+		target := Acl{ResourceName: acl.ResourceName, Principal: principal, Host: acl.Host, SourcePrincipal: acl.Principal}
+		if target.Host == "" {
+			target.Host = "*"
+		}
+		if err := errors.Join(
+			target.ResourceType.UnmarshalText([]byte(acl.ResourceType)),
+			target.PatternType.UnmarshalText([]byte(acl.ResourcePatternType)),
+			target.Operation.UnmarshalText([]byte(acl.Operation)),
+			target.PermissionType.UnmarshalText([]byte(acl.PermissionType)),
+		); err != nil {
+			skipped = append(skipped, Skipped{Acl: source, Reason: err.Error()})
+			continue
+		}
+
+		if seen[target.key()] {
+			continue
+		}
+		seen[target.key()] = true
+		translated = append(translated, target)
+	}
+
+	sort.SliceStable(translated, func(i, j int) bool {
+		if translated[i].Principal != translated[j].Principal {
+			return translated[i].Principal < translated[j].Principal
+		}
+		return translated[i].key() < translated[j].key()
+	})
+	return translated, skipped
+}
+
+func mapPrincipal(principal string, principals map[string]string) (string, bool) {
+	target, ok := principals[principal]
+	if !ok {
+		target, ok = principals[strings.TrimPrefix(principal, "User:")]
+	}
+	if !ok || target == "" {
+		return "", false
+	}
+	if !strings.Contains(target, ":") {
+		target = "User:" + target
+	}
+	return target, true
+}
+
+// Diff compares the translated ACLs with those on the destination without
+// changing anything.
+func Diff(admin Admin, acls []Acl) ([]Action, error) {
+	existing, err := admin.ListAcls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ACLs on the destination cluster: %w", err)
+	}
+	present := make(map[string]bool)
+	for _, resource := range existing {
+		for _, acl := range resource.Acls {
+			present[Acl{
+				ResourceType:   resource.ResourceType,
+				ResourceName:   resource.ResourceName,
+				PatternType:    resource.ResourcePatternType,
+				Principal:      acl.Principal,
+				Host:           acl.Host,
+				Operation:      acl.Operation,
+				PermissionType: acl.PermissionType,
+			}.key()] = true
+		}
+	}
+
+	actions := make([]Action, len(acls))
+	for i, acl := range acls {
+		actions[i] = Action{Acl: acl, Exists: present[acl.key()]}
+	}
+	return actions, nil
+}
+
+// Apply creates the translated ACLs missing on the destination. Creations
+// the destination rejects are reported on their action, not as an error.
+func Apply(admin Admin, acls []Acl) ([]Action, error) {
+	actions, err := Diff(admin, acls)
+	if err != nil {
+		return nil, err
+	}
+
+	var creations []*sarama.AclCreation
+	var pending []int
+	for i, action := range actions {
+		if action.Exists {
+			continue
+		}
+		acl := action.Acl
+		creations = append(creations, &sarama.AclCreation{
+			Resource: sarama.Resource{ResourceType: acl.ResourceType, ResourceName: acl.ResourceName, ResourcePatternType: acl.PatternType},
+			Acl:      sarama.Acl{Principal: acl.Principal, Host: acl.Host, Operation: acl.Operation, PermissionType: acl.PermissionType},
+		})
+		pending = append(pending, i)
+	}
+	if len(creations) == 0 {
+		return actions, nil
+	}
+
+	results, err := admin.CreateAcls(creations)
+	if err != nil {
+		return nil, err
+	}
+	for n, i := range pending {
+		if n < len(results) && results[n] != nil {
+			actions[i].Err = results[n]
+			continue
+		}
+		actions[i].Created = true
+	}
+	return actions, nil
+}
+
+// Failed counts the actions whose creation the destination rejected.
+func Failed(actions []Action) int {
+	count := 0
+	for _, action := range actions {
+		if action.Err != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadPrincipalMappingCSV reads principal mappings from a CSV file of
+// source,target rows, for principals such as certificate DNs that contain
+// commas. A first row of "source,target" is treated as a header, and lines
+// starting with '#' are ignored.
+func LoadPrincipalMappingCSV(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open principal mapping file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	principals := make(map[string]string)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse principal mapping file %s: %w", path, err)
+		}
+		source, target := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if row == 1 && strings.EqualFold(source, "source") && strings.EqualFold(target, "target") {
+			continue
+		}
+		if source == "" || target == "" {
+			return nil, fmt.Errorf("principal mapping file %s: row %d has an empty source or target principal", path, row)
+		}
+		if previous, exists := principals[source]; exists && previous != target {
+			return nil, fmt.Errorf("principal mapping file %s: %q is mapped to both %q and %q", path, source, previous, target)
+		}
+		principals[source] = target
+	}
+	return principals, nil
+}
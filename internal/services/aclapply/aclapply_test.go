@@ -0,0 +1,122 @@
+package aclapply
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/mocks"
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sourceAcls = []types.Acls{
+	{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "Literal", Principal: "User:orders-app", Host: "*", Operation: "Write", PermissionType: "Allow"},
+	{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "Literal", Principal: "User:orders-app", Host: "*", Operation: "Describe", PermissionType: "Allow"},
+	{ResourceType: "Group", ResourceName: "billing", ResourcePatternType: "Prefixed", Principal: "User:billing-app", Host: "*", Operation: "Read", PermissionType: "Allow"},
+	{ResourceType: "DelegationToken", ResourceName: "token", ResourcePatternType: "Literal", Principal: "User:orders-app", Host: "*", Operation: "Describe", PermissionType: "Allow"},
+	{ResourceType: "Topic", ResourceName: "audit", ResourcePatternType: "Literal", Principal: "User:unmapped", Host: "*", Operation: "Read", PermissionType: "Allow"},
+}
+
+var principals = map[string]string{
+	"User:orders-app": "sa-abc123",
+	"billing-app":     "User:sa-def456",
+}
+
+func TestTranslate(t *testing.T) {
+	translated, skipped := Translate(sourceAcls, topicnaming.Mapping{Prefix: "msk."}, principals)
+
+	require.Len(t, translated, 3)
+	assert.Equal(t, "User:sa-abc123", translated[0].Principal)
+	assert.Equal(t, "msk.orders", translated[0].ResourceName)
+	assert.Equal(t, sarama.AclResourceTopic, translated[0].ResourceType)
+	assert.Equal(t, "User:orders-app", translated[0].SourcePrincipal)
+
+	assert.Equal(t, "User:sa-def456", translated[2].Principal)
+	assert.Equal(t, sarama.AclResourceGroup, translated[2].ResourceType)
+	assert.Equal(t, sarama.AclPatternPrefixed, translated[2].PatternType)
+	assert.Equal(t, "billing", translated[2].ResourceName, "only topic resources are renamed")
+
+	require.Len(t, skipped, 2)
+	assert.Contains(t, skipped[0].Reason, "DelegationToken")
+	assert.Equal(t, "no principal mapping", skipped[1].Reason)
+	assert.Equal(t, "audit", skipped[1].Acl.ResourceName, "skipped ACLs keep their source name")
+}
+
+func TestTranslate_DeduplicatesMergedPrincipals(t *testing.T) {
+	acls := []types.Acls{
+		{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "Literal", Principal: "User:a", Host: "*", Operation: "Read", PermissionType: "Allow"},
+		{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "Literal", Principal: "User:b", Host: "*", Operation: "Read", PermissionType: "Allow"},
+	}
+	translated, _ := Translate(acls, topicnaming.Mapping{}, map[string]string{"User:a": "sa-1", "User:b": "sa-1"})
+	assert.Len(t, translated, 1)
+}
+
+func TestApply_CreatesOnlyMissingAcls(t *testing.T) {
+	translated, _ := Translate(sourceAcls, topicnaming.Mapping{}, principals)
+	require.Len(t, translated, 3)
+
+	var created []*sarama.AclCreation
+	admin := &mocks.MockKafkaAdmin{
+		ListAclsFunc: func() ([]sarama.ResourceAcls, error) {
+			return []sarama.ResourceAcls{{
+				Resource: sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "orders", ResourcePatternType: sarama.AclPatternLiteral},
+				Acls:     []*sarama.Acl{{Principal: "User:sa-abc123", Host: "*", Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionAllow}},
+			}}, nil
+		},
+		CreateAclsFunc: func(creations []*sarama.AclCreation) ([]error, error) {
+			created = creations
+			return []error{nil, errors.New("INVALID_REQUEST: unknown principal")}, nil
+		},
+	}
+
+	actions, err := Apply(admin, translated)
+	require.NoError(t, err)
+	require.Len(t, actions, 3)
+	require.Len(t, created, 2, "the existing Write ACL is not re-created")
+
+	exists := 0
+	for _, action := range actions {
+		if action.Exists {
+			exists++
+			assert.Equal(t, sarama.AclOperationWrite, action.Acl.Operation)
+		}
+	}
+	assert.Equal(t, 1, exists)
+	assert.Equal(t, 1, Failed(actions))
+}
+
+func TestDiff_DoesNotCreate(t *testing.T) {
+	translated, _ := Translate(sourceAcls, topicnaming.Mapping{}, principals)
+	admin := &mocks.MockKafkaAdmin{
+		ListAclsFunc: func() ([]sarama.ResourceAcls, error) { return nil, nil },
+		CreateAclsFunc: func(creations []*sarama.AclCreation) ([]error, error) {
+			t.Fatal("diff must not create ACLs")
+			return nil, nil
+		},
+	}
+
+	actions, err := Diff(admin, translated)
+	require.NoError(t, err)
+	for _, action := range actions {
+		assert.False(t, action.Exists)
+		assert.False(t, action.Created)
+	}
+}
+
+func TestLoadPrincipalMappingCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "principals.csv")
+	require.NoError(t, os.WriteFile(path, []byte("source,target\n# certificate principals\n\"User:CN=orders,OU=apps\",sa-abc123\n"), 0644))
+
+	mapping, err := LoadPrincipalMappingCSV(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"User:CN=orders,OU=apps": "sa-abc123"}, mapping)
+
+	require.NoError(t, os.WriteFile(path, []byte("a,sa-1\na,sa-2\n"), 0644))
+	_, err = LoadPrincipalMappingCSV(path)
+	assert.ErrorContains(t, err, "mapped to both")
+}
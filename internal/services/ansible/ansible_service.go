@@ -0,0 +1,176 @@
+// Package ansible generates Ansible playbooks and inventories as an
+// alternative to the Terraform EC2 modules, for environments where Terraform
+// is not permitted to manage EC2 instances.
+package ansible
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/summary"
+)
+
+//go:embed templates/*
+var templatesFS embed.FS
+
+// AnsibleProject maps file paths (relative to the project directory) to their
+// contents.
+type AnsibleProject struct {
+	Files map[string]string
+}
+
+type AnsibleService struct{}
+
+func NewAnsibleService() *AnsibleService {
+	return &AnsibleService{}
+}
+
+// jumpClusterTemplateData is rendered into the Go templates. The templates use
+// [[ ]] delimiters so the Jinja {{ }} expressions pass through untouched.
+type jumpClusterTemplateData struct {
+	Region                  string
+	AuthType                string
+	InstanceType            string
+	BrokerStorage           int
+	BrokerCount             int
+	BrokerAmi               aws.JumpClusterAmi
+	IamAuthRoleName         string
+	SourceClusterId         string
+	SourceBootstrapBrokers  string
+	SaslScramMechanism      string
+	SourceCACertificate     bool
+	TargetClusterId         string
+	TargetBootstrapEndpoint string
+	TargetRestEndpoint      string
+	ClusterLinkName         string
+}
+
+// GenerateJumpClusterProject emits the playbooks that replace the
+// jump_cluster_setup_host and jump_cluster Terraform modules. The networking
+// module (and therefore the subnets, security group and SSH key the playbooks
+// use) stays in Terraform.
+func (as *AnsibleService) GenerateJumpClusterProject(request hclrequests.MigrationWizardRequest) (AnsibleProject, error) {
+	if request.JumpClusterSpotInstances {
+		return AnsibleProject{}, fmt.Errorf("spot jump cluster instances are not supported by the Ansible provisioner")
+	}
+	brokerAmi, err := aws.GetJumpClusterAmi(request.JumpClusterAmiFamily())
+	if err != nil {
+		return AnsibleProject{}, err
+	}
+
+	data := jumpClusterTemplateData{
+		Region:                  request.SourceRegion,
+		AuthType:                request.JumpClusterAuthType,
+		InstanceType:            request.JumpClusterInstanceType,
+		BrokerStorage:           request.JumpClusterBrokerStorage,
+		BrokerCount:             len(request.JumpClusterBrokerSubnetCidr),
+		BrokerAmi:               brokerAmi,
+		SourceClusterId:         request.SourceClusterId,
+		SourceBootstrapBrokers:  sourceBootstrapBrokers(request),
+		TargetClusterId:         request.TargetClusterId,
+		TargetBootstrapEndpoint: request.TargetBootstrapEndpoint,
+		TargetRestEndpoint:      request.TargetRestEndpoint,
+		ClusterLinkName:         request.ClusterLinkName,
+	}
+	switch request.JumpClusterAuthType {
+	case "sasl_scram":
+		data.SaslScramMechanism = request.SourceSaslScramMechanism
+		data.SourceCACertificate = request.SourceCACertificate != ""
+	case "iam":
+		data.IamAuthRoleName = request.JumpClusterIamAuthRoleName
+	default:
+		return AnsibleProject{}, fmt.Errorf("unsupported jump cluster auth type: %q", request.JumpClusterAuthType)
+	}
+
+	project := AnsibleProject{Files: map[string]string{}}
+
+	rendered := map[string]string{
+		"README.md":          "README.md.tmpl",
+		"group_vars/all.yml": "group_vars_all.yml.tmpl",
+		"provision.yml":      "provision.yml.tmpl",
+		"site.yml":           "site.yml.tmpl",
+	}
+	for dest, src := range rendered {
+		content, err := renderTemplate(src, data)
+		if err != nil {
+			return AnsibleProject{}, err
+		}
+		project.Files[dest] = content
+	}
+
+	static := map[string]string{
+		"ansible.cfg":                          "ansible.cfg",
+		"requirements.yml":                     "requirements.yml",
+		"wait-for-hosts-ready.yml":             "wait-for-hosts-ready.yml",
+		"cluster-link-setup.yml":               "cluster-link-setup.yml",
+		"templates/hosts.yml.j2":               "hosts.yml.j2",
+		"templates/create-cluster-links.sh.j2": "create-cluster-links.sh.j2",
+	}
+	if request.JumpClusterAuthType == "iam" {
+		static["jar-deployment.yml"] = "jar-deployment.yml"
+	}
+	for dest, src := range static {
+		content, err := templatesFS.ReadFile("templates/" + src)
+		if err != nil {
+			return AnsibleProject{}, fmt.Errorf("failed to read template %s: %w", src, err)
+		}
+		project.Files[dest] = string(content)
+	}
+	if data.SourceCACertificate {
+		project.Files["files/source-ca.pem"] = request.SourceCACertificate
+	}
+
+	return project, nil
+}
+
+func renderTemplate(name string, data jumpClusterTemplateData) (string, error) {
+	tmpl, err := template.New(name).Delims("[[", "]]").ParseFS(templatesFS, "templates/"+name)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// sourceBootstrapBrokers mirrors the source_cluster_bootstrap_brokers value the
+// jump_cluster Terraform module receives.
+func sourceBootstrapBrokers(request hclrequests.MigrationWizardRequest) string {
+	switch request.JumpClusterAuthType {
+	case "sasl_scram":
+		return request.SourceSaslScramBootstrapServers
+	case "plaintext":
+		return request.SourcePlaintextBootstrapServers
+	default:
+		return request.SourceSaslIamBootstrapServers
+	}
+}
+
+// WriteAnsibleProject writes every project file under outputDir, creating
+// subdirectories as needed.
+func WriteAnsibleProject(outputDir string, project AnsibleProject) error {
+	for name, content := range project.Files {
+		path := filepath.Join(outputDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := summary.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		slog.Debug("wrote ansible file", "file", name)
+	}
+	if err := os.MkdirAll(filepath.Join(outputDir, "inventory"), 0755); err != nil {
+		return fmt.Errorf("failed to create inventory directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package ansible
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jumpClusterRequest(authType string) hclrequests.MigrationWizardRequest {
+	return hclrequests.MigrationWizardRequest{
+		UseJumpClusters:                 true,
+		JumpClusterAuthType:             authType,
+		JumpClusterInstanceType:         "kafka.m5.large",
+		JumpClusterBrokerStorage:        100,
+		JumpClusterBrokerSubnetCidr:     []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		JumpClusterIamAuthRoleName:      "msk-iam-role",
+		SourceClusterId:                 "msk-cluster-123",
+		SourceSaslIamBootstrapServers:   "b-1.msk.example:9098",
+		SourceSaslScramBootstrapServers: "b-1.msk.example:9096",
+		SourceSaslScramMechanism:        "SCRAM-SHA-512",
+		SourceRegion:                    "us-east-1",
+		TargetClusterId:                 "lkc-xyz789",
+		TargetRestEndpoint:              "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		TargetBootstrapEndpoint:         "pkc-abc123.us-east-1.aws.confluent.cloud:9092",
+		ClusterLinkName:                 "msk-to-cc-link",
+	}
+}
+
+func TestGenerateJumpClusterProject_Iam(t *testing.T) {
+	project, err := NewAnsibleService().GenerateJumpClusterProject(jumpClusterRequest("iam"))
+	require.NoError(t, err)
+
+	for _, name := range []string{"README.md", "ansible.cfg", "requirements.yml", "group_vars/all.yml", "provision.yml", "site.yml", "jar-deployment.yml", "cluster-link-setup.yml", "templates/hosts.yml.j2", "templates/create-cluster-links.sh.j2"} {
+		assert.Contains(t, project.Files, name)
+	}
+
+	vars := project.Files["group_vars/all.yml"]
+	assert.Contains(t, vars, `source_cluster_bootstrap_brokers: "b-1.msk.example:9098"`)
+	assert.Contains(t, vars, `jump_cluster_iam_auth_role_name: "msk-iam-role"`)
+	assert.NotContains(t, vars, "SOURCE_SASL_SCRAM_USERNAME")
+
+	assert.Contains(t, project.Files["provision.yml"], `iam_instance_profile: "{{ jump_cluster_iam_auth_role_name }}"`)
+	assert.Contains(t, project.Files["site.yml"], "jar-deployment.yml")
+}
+
+func TestGenerateJumpClusterProject_SaslScram(t *testing.T) {
+	project, err := NewAnsibleService().GenerateJumpClusterProject(jumpClusterRequest("sasl_scram"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, project.Files, "jar-deployment.yml")
+	assert.NotContains(t, project.Files["site.yml"], "jar-deployment.yml")
+	assert.NotContains(t, project.Files["provision.yml"], "iam_instance_profile")
+
+	vars := project.Files["group_vars/all.yml"]
+	assert.Contains(t, vars, `source_cluster_bootstrap_brokers: "b-1.msk.example:9096"`)
+	assert.Contains(t, vars, `source_sasl_scram_mechanism: "SCRAM-SHA-512"`)
+	assert.Contains(t, vars, "SOURCE_SASL_SCRAM_PASSWORD")
+	assert.NotContains(t, vars, "source_ca_certificate_file")
+	assert.NotContains(t, project.Files, "files/source-ca.pem")
+}
+
+func TestGenerateJumpClusterProject_SaslScramPrivateCA(t *testing.T) {
+	request := jumpClusterRequest("sasl_scram")
+	request.SourceCACertificate = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+	project, err := NewAnsibleService().GenerateJumpClusterProject(request)
+	require.NoError(t, err)
+
+	assert.Equal(t, request.SourceCACertificate, project.Files["files/source-ca.pem"])
+	assert.Contains(t, project.Files["group_vars/all.yml"], `source_ca_certificate_file: "{{ playbook_dir }}/files/source-ca.pem"`)
+	assert.Contains(t, project.Files["templates/create-cluster-links.sh.j2"], "ssl.truststore.location=/etc/pki/kcp/source-ca.pem")
+}
+
+func TestGenerateJumpClusterProject_UbuntuAmi(t *testing.T) {
+	request := jumpClusterRequest("iam")
+	request.JumpClusterAmi = "ubuntu"
+
+	project, err := NewAnsibleService().GenerateJumpClusterProject(request)
+	require.NoError(t, err)
+
+	vars := project.Files["group_vars/all.yml"]
+	assert.Contains(t, vars, `jump_cluster_os_user: "ubuntu"`)
+	assert.Contains(t, vars, `jump_cluster_python_interpreter: "/usr/bin/python3"`)
+	assert.Contains(t, project.Files["provision.yml"], `owners: ["099720109477"]`)
+	assert.Contains(t, project.Files["provision.yml"], "device_name: /dev/sda1")
+}
+
+func TestGenerateJumpClusterProject_RejectsSpotInstances(t *testing.T) {
+	request := jumpClusterRequest("iam")
+	request.JumpClusterSpotInstances = true
+
+	_, err := NewAnsibleService().GenerateJumpClusterProject(request)
+	assert.ErrorContains(t, err, "spot")
+}
+
+func TestGenerateJumpClusterProject_RejectsUnknownAuthType(t *testing.T) {
+	_, err := NewAnsibleService().GenerateJumpClusterProject(jumpClusterRequest("plaintext"))
+	assert.Error(t, err)
+}
+
+func TestWriteAnsibleProject(t *testing.T) {
+	project, err := NewAnsibleService().GenerateJumpClusterProject(jumpClusterRequest("iam"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, WriteAnsibleProject(dir, project))
+
+	_, err = os.Stat(filepath.Join(dir, "group_vars", "all.yml"))
+	assert.NoError(t, err)
+	info, err := os.Stat(filepath.Join(dir, "inventory"))
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
@@ -0,0 +1,120 @@
+package athena
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	athenatypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
+)
+
+// athenaAPI is the subset of the Athena client used by AthenaService.
+// *athena.Client satisfies it.
+type athenaAPI interface {
+	StartQueryExecution(ctx context.Context, in *athena.StartQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.StartQueryExecutionOutput, error)
+	GetQueryExecution(ctx context.Context, in *athena.GetQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.GetQueryExecutionOutput, error)
+	athena.GetQueryResultsAPIClient
+}
+
+// tableNamePattern is a table or database.table name, which is spliced into
+// queries and so must not be able to end them.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)?$`)
+
+// ValidateTableName rejects anything but a table or database.table name.
+func ValidateTableName(name string) error {
+	if !tableNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid Athena table name '%s': must be table or database.table", name)
+	}
+	return nil
+}
+
+// QueryOpts says where a query runs.
+type QueryOpts struct {
+	// WorkGroup defaults to the primary workgroup.
+	WorkGroup string
+	// OutputLocation is the s3:// location query results are written to.
+	// Optional when the workgroup sets one.
+	OutputLocation string
+}
+
+type AthenaService struct {
+	client       athenaAPI
+	pollInterval time.Duration
+}
+
+func NewAthenaService(client *athena.Client) *AthenaService {
+	return &AthenaService{client: client, pollInterval: 2 * time.Second}
+}
+
+// Query runs query, waits for it to finish and returns its result rows,
+// without the header row, as strings. NULLs are returned as "".
+func (s *AthenaService) Query(ctx context.Context, query string, opts QueryOpts) ([][]string, error) {
+	input := &athena.StartQueryExecutionInput{QueryString: aws.String(query)}
+	if opts.WorkGroup != "" {
+		input.WorkGroup = aws.String(opts.WorkGroup)
+	}
+	if opts.OutputLocation != "" {
+		input.ResultConfiguration = &athenatypes.ResultConfiguration{OutputLocation: aws.String(opts.OutputLocation)}
+	}
+	started, err := s.client.StartQueryExecution(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start Athena query: %w", err)
+	}
+
+	if err := s.waitForQuery(ctx, started.QueryExecutionId); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	header := true
+	paginator := athena.NewGetQueryResultsPaginator(s.client, &athena.GetQueryResultsInput{QueryExecutionId: started.QueryExecutionId})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Athena query results: %w", err)
+		}
+		if page.ResultSet == nil {
+			continue
+		}
+		for _, row := range page.ResultSet.Rows {
+			// The first row of the first page holds the column names.
+			if header {
+				header = false
+				continue
+			}
+			values := make([]string, len(row.Data))
+			for i, datum := range row.Data {
+				values[i] = aws.ToString(datum.VarCharValue)
+			}
+			rows = append(rows, values)
+		}
+	}
+	return rows, nil
+}
+
+func (s *AthenaService) waitForQuery(ctx context.Context, queryExecutionID *string) error {
+	for {
+		out, err := s.client.GetQueryExecution(ctx, &athena.GetQueryExecutionInput{QueryExecutionId: queryExecutionID})
+		if err != nil {
+			return fmt.Errorf("failed to get Athena query status: %w", err)
+		}
+		if out.QueryExecution != nil && out.QueryExecution.Status != nil {
+			status := out.QueryExecution.Status
+			switch status.State {
+			case athenatypes.QueryExecutionStateSucceeded:
+				return nil
+			case athenatypes.QueryExecutionStateFailed, athenatypes.QueryExecutionStateCancelled:
+				return fmt.Errorf("Athena query %s %s: %s", aws.ToString(queryExecutionID), status.State, aws.ToString(status.StateChangeReason))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
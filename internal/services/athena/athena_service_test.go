@@ -0,0 +1,92 @@
+package athena
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	athenatypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAthena struct {
+	started *athena.StartQueryExecutionInput
+	states  []athenatypes.QueryExecutionState
+	pages   [][]athenatypes.Row
+	calls   int
+}
+
+func (f *fakeAthena) StartQueryExecution(_ context.Context, in *athena.StartQueryExecutionInput, _ ...func(*athena.Options)) (*athena.StartQueryExecutionOutput, error) {
+	f.started = in
+	return &athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("query-1")}, nil
+}
+
+func (f *fakeAthena) GetQueryExecution(_ context.Context, _ *athena.GetQueryExecutionInput, _ ...func(*athena.Options)) (*athena.GetQueryExecutionOutput, error) {
+	state := f.states[0]
+	if len(f.states) > 1 {
+		f.states = f.states[1:]
+	}
+	return &athena.GetQueryExecutionOutput{QueryExecution: &athenatypes.QueryExecution{
+		Status: &athenatypes.QueryExecutionStatus{State: state, StateChangeReason: aws.String("table not found")},
+	}}, nil
+}
+
+func (f *fakeAthena) GetQueryResults(_ context.Context, _ *athena.GetQueryResultsInput, _ ...func(*athena.Options)) (*athena.GetQueryResultsOutput, error) {
+	out := &athena.GetQueryResultsOutput{ResultSet: &athenatypes.ResultSet{Rows: f.pages[f.calls]}}
+	f.calls++
+	if f.calls < len(f.pages) {
+		out.NextToken = aws.String("next")
+	}
+	return out, nil
+}
+
+func row(values ...*string) athenatypes.Row {
+	var r athenatypes.Row
+	for _, v := range values {
+		r.Data = append(r.Data, athenatypes.Datum{VarCharValue: v})
+	}
+	return r
+}
+
+func TestQuery(t *testing.T) {
+	fake := &fakeAthena{
+		states: []athenatypes.QueryExecutionState{athenatypes.QueryExecutionStateRunning, athenatypes.QueryExecutionStateSucceeded},
+		pages: [][]athenatypes.Row{
+			{row(aws.String("principal"), aws.String("events")), row(aws.String("arn:aws:iam::000000000000:role/app"), aws.String("42"))},
+			{row(aws.String("arn:aws:iam::000000000000:user/admin"), nil)},
+		},
+	}
+	service := &AthenaService{client: fake}
+
+	rows, err := service.Query(context.Background(), "SELECT 1", QueryOpts{WorkGroup: "kcp", OutputLocation: "s3://results/"})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"arn:aws:iam::000000000000:role/app", "42"},
+		{"arn:aws:iam::000000000000:user/admin", ""},
+	}, rows)
+	assert.Equal(t, "SELECT 1", aws.ToString(fake.started.QueryString))
+	assert.Equal(t, "kcp", aws.ToString(fake.started.WorkGroup))
+	assert.Equal(t, "s3://results/", aws.ToString(fake.started.ResultConfiguration.OutputLocation))
+}
+
+func TestQuery_Failed(t *testing.T) {
+	fake := &fakeAthena{states: []athenatypes.QueryExecutionState{athenatypes.QueryExecutionStateFailed}}
+	service := &AthenaService{client: fake}
+
+	_, err := service.Query(context.Background(), "SELECT 1", QueryOpts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "table not found")
+	assert.Nil(t, fake.started.WorkGroup)
+	assert.Nil(t, fake.started.ResultConfiguration)
+}
+
+func TestValidateTableName(t *testing.T) {
+	assert.NoError(t, ValidateTableName("cloudtrail_logs"))
+	assert.NoError(t, ValidateTableName("default.vpc_flow_logs"))
+	assert.Error(t, ValidateTableName("logs; DROP TABLE logs"))
+	assert.Error(t, ValidateTableName("a.b.c"))
+	assert.Error(t, ValidateTableName(""))
+}
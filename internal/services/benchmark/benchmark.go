@@ -0,0 +1,396 @@
+// Package benchmark produces to and consumes from a designated test topic for
+// a fixed duration and measures the throughput and latency achieved, so the
+// network path a migration will use (PrivateLink, jump clusters) can be
+// validated before any real data moves over it.
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// MaxMessageSize keeps generated records under the default broker and topic
+// max.message.bytes (1 MiB), leaving room for the key and record overhead.
+const MaxMessageSize = 1000 * 1000
+
+const defaultDrainTimeout = 30 * time.Second
+
+// ErrTopicHasRecords is returned when the benchmark topic already holds
+// records and Config.Force is not set.
+var ErrTopicHasRecords = errors.New("topic already holds records")
+
+type Config struct {
+	Topic       string
+	MessageSize int
+	// Rate is the target produce rate in messages per second; 0 produces as
+	// fast as the cluster acknowledges.
+	Rate     int
+	Duration time.Duration
+	// DrainTimeout bounds how long the consumer may take to catch up once
+	// producing stops. Defaults to 30s.
+	DrainTimeout time.Duration
+	// Force benchmarks a topic that already holds records, such as those of
+	// an earlier run. A topic with committed consumer-group offsets is
+	// refused regardless.
+	Force bool
+}
+
+func (c Config) Validate() error {
+	if c.Topic == "" {
+		return errors.New("topic must not be empty")
+	}
+	if c.MessageSize < 1 || c.MessageSize > MaxMessageSize {
+		return fmt.Errorf("message size must be between 1 and %d bytes, got %d", MaxMessageSize, c.MessageSize)
+	}
+	if c.Rate < 0 {
+		return fmt.Errorf("rate must not be negative, got %d", c.Rate)
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", c.Duration)
+	}
+	return nil
+}
+
+// Latency summarises a latency distribution; all zero when nothing was
+// measured.
+type Latency struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+type Result struct {
+	Topic       string `json:"topic"`
+	Partitions  int    `json:"partitions"`
+	MessageSize int    `json:"message_size"`
+	TargetRate  int    `json:"target_rate"`
+
+	// ProduceElapsed runs from the first send to the last acknowledgement,
+	// ConsumeElapsed from the first send to the last record consumed.
+	ProduceElapsed time.Duration `json:"produce_elapsed"`
+	ConsumeElapsed time.Duration `json:"consume_elapsed"`
+
+	Produced      int64  `json:"produced"`
+	ProduceErrors int64  `json:"produce_errors"`
+	FirstError    string `json:"first_error,omitempty"`
+	Consumed      int64  `json:"consumed"`
+
+	// ProduceLatency is send to acknowledgement; EndToEndLatency is send to
+	// consumed, at the millisecond precision of record timestamps.
+	ProduceLatency  Latency `json:"produce_latency"`
+	EndToEndLatency Latency `json:"end_to_end_latency"`
+}
+
+func (r Result) ProducedPerSecond() float64 {
+	return perSecond(float64(r.Produced), r.ProduceElapsed)
+}
+
+func (r Result) ProducedMBPerSecond() float64 {
+	return perSecond(float64(r.Produced*int64(r.MessageSize))/1e6, r.ProduceElapsed)
+}
+
+func (r Result) ConsumedMBPerSecond() float64 {
+	return perSecond(float64(r.Consumed*int64(r.MessageSize))/1e6, r.ConsumeElapsed)
+}
+
+// TargetMet reports whether a rate-limited run kept up with its target rate,
+// allowing 5% for pacing and ramp-up. Unthrottled runs always meet it.
+func (r Result) TargetMet() bool {
+	return r.TargetRate == 0 || r.ProducedPerSecond() >= 0.95*float64(r.TargetRate)
+}
+
+func perSecond(v float64, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return v / d.Seconds()
+}
+
+// Run benchmarks cfg.Topic through client, which must be configured with
+// Producer.Return.Successes. Records are keyed with a per-run prefix, so
+// other traffic on the topic is not counted; the topic is left in place and
+// the records expire with its retention. Run refuses a topic that any
+// consumer group has committed offsets on, and one that already holds
+// records unless cfg.Force is set, so random records never reach a topic
+// real consumers read.
+func Run(ctx context.Context, client sarama.Client, cfg Config) (*Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.DrainTimeout == 0 {
+		cfg.DrainTimeout = defaultDrainTimeout
+	}
+
+	partitions, err := client.Partitions(cfg.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions of topic %s: %w", cfg.Topic, err)
+	}
+	if err := checkTopicUnused(client, cfg.Topic, partitions, cfg.Force); err != nil {
+		return nil, err
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer func() { _ = consumer.Close() }()
+
+	runKey := []byte(fmt.Sprintf("kcp-benchmark-%d-", time.Now().UnixNano()))
+	rec := &recorder{}
+
+	// Consume from the current end of each partition so only this run's
+	// records are read back.
+	consumeCtx, stopConsuming := context.WithCancel(ctx)
+	defer stopConsuming()
+	var consumers sync.WaitGroup
+	for _, partition := range partitions {
+		offset, err := client.GetOffset(cfg.Topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get newest offset of %s/%d: %w", cfg.Topic, partition, err)
+		}
+		pc, err := consumer.ConsumePartition(cfg.Topic, partition, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to consume %s/%d: %w", cfg.Topic, partition, err)
+		}
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			defer func() { _ = pc.Close() }()
+			for {
+				select {
+				case msg, ok := <-pc.Messages():
+					if !ok {
+						return
+					}
+					if bytes.HasPrefix(msg.Key, runKey) {
+						rec.consumed(time.Since(msg.Timestamp))
+					}
+				case <-consumeCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create producer: %w", err)
+	}
+	var acks sync.WaitGroup
+	acks.Add(2)
+	go func() {
+		defer acks.Done()
+		for msg := range producer.Successes() {
+			rec.acked(time.Since(msg.Metadata.(time.Time)))
+		}
+	}()
+	go func() {
+		defer acks.Done()
+		for perr := range producer.Errors() {
+			rec.failed(perr.Err)
+		}
+	}()
+
+	payload := make([]byte, cfg.MessageSize)
+	_, _ = rand.Read(payload) // random bytes: compression gets no help
+	rec.start = time.Now()
+	slog.Debug("benchmark producing", "topic", cfg.Topic, "partitions", len(partitions), "rate", cfg.Rate, "duration", cfg.Duration)
+
+	var sent int64
+produce:
+	for {
+		elapsed := time.Since(rec.start)
+		if elapsed >= cfg.Duration || ctx.Err() != nil {
+			break
+		}
+		if sent >= due(elapsed, cfg.Rate) {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		now := time.Now()
+		msg := &sarama.ProducerMessage{
+			Topic:     cfg.Topic,
+			Key:       sarama.ByteEncoder(strconv.AppendInt(append([]byte{}, runKey...), sent, 10)),
+			Value:     sarama.ByteEncoder(payload),
+			Timestamp: now,
+			Metadata:  now,
+		}
+		select {
+		case producer.Input() <- msg:
+			sent++
+		case <-ctx.Done():
+			break produce
+		}
+	}
+	producer.AsyncClose()
+	acks.Wait()
+
+	// Give the consumers until DrainTimeout to read back everything acked.
+	drainDeadline := time.Now().Add(cfg.DrainTimeout)
+	for rec.consumedCount() < rec.ackedCount() && time.Now().Before(drainDeadline) && ctx.Err() == nil {
+		time.Sleep(100 * time.Millisecond)
+	}
+	stopConsuming()
+	consumers.Wait()
+
+	result := rec.result()
+	result.Topic = cfg.Topic
+	result.Partitions = len(partitions)
+	result.MessageSize = cfg.MessageSize
+	result.TargetRate = cfg.Rate
+	return &result, ctx.Err()
+}
+
+// checkTopicUnused refuses a topic that looks like it carries real traffic:
+// one a consumer group has committed offsets on, or, unless force is set, one
+// that already holds records.
+func checkTopicUnused(client sarama.Client, topic string, partitions []int32, force bool) error {
+	// The admin shares client, and closing it would close the caller's client.
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster admin: %w", err)
+	}
+	groups, err := admin.ListConsumerGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list consumer groups to check topic %s is unused: %w", topic, err)
+	}
+	groupIDs := make([]string, 0, len(groups))
+	for groupID := range groups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+	for _, groupID := range groupIDs {
+		offsets, err := admin.ListConsumerGroupOffsets(groupID, map[string][]int32{topic: partitions})
+		if err != nil {
+			return fmt.Errorf("failed to get offsets of consumer group %s to check topic %s is unused: %w", groupID, topic, err)
+		}
+		for _, block := range offsets.Blocks[topic] {
+			if block.Offset >= 0 {
+				return fmt.Errorf("consumer group %s has committed offsets on topic %s; benchmark records would reach its consumers, use a dedicated test topic", groupID, topic)
+			}
+		}
+	}
+
+	if force {
+		return nil
+	}
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return fmt.Errorf("failed to get oldest offset of %s/%d: %w", topic, partition, err)
+		}
+		newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return fmt.Errorf("failed to get newest offset of %s/%d: %w", topic, partition, err)
+		}
+		if newest > oldest {
+			return fmt.Errorf("%w: %s/%d has %d", ErrTopicHasRecords, topic, partition, newest-oldest)
+		}
+	}
+	return nil
+}
+
+// due is how many messages should have been sent after elapsed at rate
+// messages per second; unlimited when rate is 0.
+func due(elapsed time.Duration, rate int) int64 {
+	if rate == 0 {
+		return 1<<63 - 1
+	}
+	return int64(elapsed.Seconds()*float64(rate)) + 1
+}
+
+// recorder collects the measurements of the producer and consumer
+// goroutines.
+type recorder struct {
+	start time.Time
+
+	mu           sync.Mutex
+	produceLat   []time.Duration
+	endToEndLat  []time.Duration
+	errors       int64
+	firstError   error
+	lastAck      time.Time
+	lastConsumed time.Time
+}
+
+func (r *recorder) acked(latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.produceLat = append(r.produceLat, latency)
+	r.lastAck = time.Now()
+}
+
+func (r *recorder) failed(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors++
+	if r.firstError == nil {
+		r.firstError = err
+	}
+}
+
+func (r *recorder) consumed(latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endToEndLat = append(r.endToEndLat, latency)
+	r.lastConsumed = time.Now()
+}
+
+func (r *recorder) ackedCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.produceLat)
+}
+
+func (r *recorder) consumedCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.endToEndLat)
+}
+
+func (r *recorder) result() Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := Result{
+		Produced:        int64(len(r.produceLat)),
+		ProduceErrors:   r.errors,
+		Consumed:        int64(len(r.endToEndLat)),
+		ProduceLatency:  summarize(r.produceLat),
+		EndToEndLatency: summarize(r.endToEndLat),
+	}
+	if r.firstError != nil {
+		result.FirstError = r.firstError.Error()
+	}
+	if !r.lastAck.IsZero() {
+		result.ProduceElapsed = r.lastAck.Sub(r.start)
+	}
+	if !r.lastConsumed.IsZero() {
+		result.ConsumeElapsed = r.lastConsumed.Sub(r.start)
+	}
+	return result
+}
+
+// summarize returns the nearest-rank percentiles of samples.
+func summarize(samples []time.Duration) Latency {
+	if len(samples) == 0 {
+		return Latency{}
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p float64) time.Duration {
+		return sorted[max(int(math.Ceil(p*float64(len(sorted))))-1, 0)]
+	}
+	return Latency{P50: rank(0.50), P95: rank(0.95), P99: rank(0.99), Max: sorted[len(sorted)-1]}
+}
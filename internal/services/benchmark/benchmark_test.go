@@ -0,0 +1,163 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockCluster starts a MockBroker leading one empty partition of topic,
+// which acknowledges every produce request, returns empty fetches and knows
+// no consumer groups. The responses handlers returns override the defaults.
+func newMockCluster(t *testing.T, topic string, handlers func(broker *sarama.MockBroker) map[string]sarama.MockResponse) sarama.Client {
+	t.Helper()
+
+	broker := sarama.NewMockBroker(t, 1)
+	responses := map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset(topic, 0, sarama.OffsetOldest, 0).
+			SetOffset(topic, 0, sarama.OffsetNewest, 0),
+		"ListGroupsRequest": sarama.NewMockListGroupsResponse(t),
+		"ProduceRequest":    sarama.NewMockProduceResponse(t),
+		"FetchRequest":      sarama.NewMockFetchResponse(t, 1),
+	}
+	if handlers != nil {
+		for name, response := range handlers(broker) {
+			responses[name] = response
+		}
+	}
+	broker.SetHandlerByMap(responses)
+
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_6_0_0
+	cfg.Producer.Return.Successes = true
+	client, err := sarama.NewClient([]string{broker.Addr()}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+		broker.Close()
+	})
+	return client
+}
+
+func TestRun_ProducesAtTargetRate(t *testing.T) {
+	client := newMockCluster(t, "kcp-bench", nil)
+
+	result, err := Run(context.Background(), client, Config{
+		Topic:        "kcp-bench",
+		MessageSize:  512,
+		Rate:         200,
+		Duration:     500 * time.Millisecond,
+		DrainTimeout: 100 * time.Millisecond,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Partitions)
+	assert.Zero(t, result.ProduceErrors)
+	assert.InDelta(t, 100, result.Produced, 15, "200 msg/s for 0.5s")
+	assert.Zero(t, result.Consumed, "the mock broker returns no records")
+	assert.Positive(t, result.ProduceLatency.Max)
+	assert.Zero(t, result.EndToEndLatency)
+}
+
+func TestRun_RefusesTopicWithRecords(t *testing.T) {
+	client := newMockCluster(t, "kcp-bench", func(*sarama.MockBroker) map[string]sarama.MockResponse {
+		return map[string]sarama.MockResponse{
+			"OffsetRequest": sarama.NewMockOffsetResponse(t).
+				SetOffset("kcp-bench", 0, sarama.OffsetOldest, 10).
+				SetOffset("kcp-bench", 0, sarama.OffsetNewest, 42),
+		}
+	})
+	cfg := Config{
+		Topic:        "kcp-bench",
+		MessageSize:  512,
+		Rate:         100,
+		Duration:     100 * time.Millisecond,
+		DrainTimeout: 10 * time.Millisecond,
+	}
+
+	_, err := Run(context.Background(), client, cfg)
+	require.ErrorIs(t, err, ErrTopicHasRecords)
+	assert.Contains(t, err.Error(), "kcp-bench/0 has 32")
+
+	cfg.Force = true
+	result, err := Run(context.Background(), client, cfg)
+	require.NoError(t, err)
+	assert.Positive(t, result.Produced)
+}
+
+func TestRun_RefusesTopicWithConsumerGroupOffsets(t *testing.T) {
+	client := newMockCluster(t, "orders", func(broker *sarama.MockBroker) map[string]sarama.MockResponse {
+		return map[string]sarama.MockResponse{
+			"ListGroupsRequest": sarama.NewMockListGroupsResponse(t).
+				AddGroup("billing", "consumer").
+				AddGroup("orders-app", "consumer"),
+			"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+				SetCoordinator(sarama.CoordinatorGroup, "billing", broker).
+				SetCoordinator(sarama.CoordinatorGroup, "orders-app", broker),
+			"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+				SetOffset("billing", "orders", 0, -1, "", sarama.ErrNoError).
+				SetOffset("orders-app", "orders", 0, 17, "", sarama.ErrNoError),
+		}
+	})
+
+	_, err := Run(context.Background(), client, Config{
+		Topic:       "orders",
+		MessageSize: 512,
+		Duration:    100 * time.Millisecond,
+		Force:       true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "consumer group orders-app has committed offsets on topic orders")
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{Topic: "t", MessageSize: 1024, Duration: time.Minute}
+	require.NoError(t, valid.Validate())
+
+	for name, mutate := range map[string]func(*Config){
+		"no topic":          func(c *Config) { c.Topic = "" },
+		"empty messages":    func(c *Config) { c.MessageSize = 0 },
+		"oversize messages": func(c *Config) { c.MessageSize = MaxMessageSize + 1 },
+		"negative rate":     func(c *Config) { c.Rate = -1 },
+		"no duration":       func(c *Config) { c.Duration = 0 },
+	} {
+		cfg := valid
+		mutate(&cfg)
+		assert.Error(t, cfg.Validate(), name)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var samples []time.Duration
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, Latency{P50: 50 * time.Millisecond, P95: 95 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}, summarize(samples))
+	assert.Equal(t, 100*time.Millisecond, samples[0], "samples are not reordered")
+	assert.Equal(t, Latency{}, summarize(nil))
+}
+
+func TestResultRates(t *testing.T) {
+	r := Result{MessageSize: 1000, TargetRate: 1000, Produced: 9000, ProduceElapsed: 10 * time.Second, Consumed: 10000, ConsumeElapsed: 5 * time.Second}
+
+	assert.InDelta(t, 900, r.ProducedPerSecond(), 0.001)
+	assert.InDelta(t, 0.9, r.ProducedMBPerSecond(), 0.001)
+	assert.InDelta(t, 2, r.ConsumedMBPerSecond(), 0.001)
+	assert.False(t, r.TargetMet())
+
+	r.TargetRate = 0
+	assert.True(t, r.TargetMet())
+	assert.Zero(t, Result{}.ProducedPerSecond())
+}
@@ -0,0 +1,111 @@
+// Package ccpricing projects what the workload measured on a source
+// cluster would cost on Confluent Cloud, from a price sheet of list
+// prices, so `kcp report costs` can set MSK spend side by side with
+// its Confluent Cloud equivalent.
+package ccpricing
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+)
+
+//go:embed price-sheet.yaml
+var embeddedPriceSheet []byte
+
+// expectedSchemaVersion is the schema_version this loader understands.
+const expectedSchemaVersion = 1
+
+// PriceSheet is the deserialized price-sheet.yaml. The embedded copy is
+// the default; a user-supplied file replaces only the fields it sets.
+type PriceSheet struct {
+	SchemaVersion int    `yaml:"schema_version"`
+	Currency      string `yaml:"currency"`
+	Source        string `yaml:"source"`
+	LastVerified  string `yaml:"last_verified"`
+
+	Enterprise       ClusterPricing    `yaml:"enterprise"`
+	Dedicated        ClusterPricing    `yaml:"dedicated"`
+	Networking       NetworkingPricing `yaml:"networking"`
+	Storage          StoragePricing    `yaml:"storage"`
+	HeadroomFraction float64           `yaml:"headroom_fraction"`
+	CommitDiscount   float64           `yaml:"commit_discount"`
+}
+
+// ClusterPricing is the hourly price and capacity of one capacity unit
+// (eCKU for Enterprise, CKU for Dedicated).
+type ClusterPricing struct {
+	UnitHourly         float64 `yaml:"unit_hourly"`
+	PerUnitIngressMBps float64 `yaml:"per_unit_ingress_mbps"`
+	PerUnitEgressMBps  float64 `yaml:"per_unit_egress_mbps"`
+	PerUnitPartitions  int     `yaml:"per_unit_partitions"`
+	MaxUnits           int     `yaml:"max_units"`
+}
+
+type NetworkingPricing struct {
+	IngressPerGB float64 `yaml:"ingress_per_gb"`
+	EgressPerGB  float64 `yaml:"egress_per_gb"`
+}
+
+type StoragePricing struct {
+	PerGBMonth float64 `yaml:"per_gb_month"`
+}
+
+// LoadPriceSheet returns the embedded price sheet, with overridePath
+// (when non-empty) unmarshalled on top of it.
+func LoadPriceSheet(overridePath string) (*PriceSheet, error) {
+	sheet := &PriceSheet{}
+	if err := yaml.Unmarshal(embeddedPriceSheet, sheet); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded price sheet: %w", err)
+	}
+
+	if overridePath != "" {
+		data, err := os.ReadFile(overridePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read price sheet %s: %w", overridePath, err)
+		}
+		if err := yaml.Unmarshal(data, sheet); err != nil {
+			return nil, fmt.Errorf("failed to parse price sheet %s: %w", overridePath, err)
+		}
+	}
+
+	if err := sheet.Validate(); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+func (p *PriceSheet) Validate() error {
+	if p.SchemaVersion != expectedSchemaVersion {
+		return fmt.Errorf("price sheet schema_version %d does not match expected %d", p.SchemaVersion, expectedSchemaVersion)
+	}
+	if p.Currency == "" {
+		return fmt.Errorf("price sheet currency must be non-empty")
+	}
+	for name, c := range map[string]ClusterPricing{"enterprise": p.Enterprise, "dedicated": p.Dedicated} {
+		if c.UnitHourly < 0 {
+			return fmt.Errorf("price sheet %s.unit_hourly must be >= 0 (got %v)", name, c.UnitHourly)
+		}
+		if c.PerUnitIngressMBps <= 0 || c.PerUnitEgressMBps <= 0 || c.PerUnitPartitions <= 0 {
+			return fmt.Errorf("price sheet %s per-unit ingress, egress and partition capacity must be > 0", name)
+		}
+		if c.MaxUnits < 1 {
+			return fmt.Errorf("price sheet %s.max_units must be >= 1 (got %v)", name, c.MaxUnits)
+		}
+	}
+	if p.Networking.IngressPerGB < 0 || p.Networking.EgressPerGB < 0 {
+		return fmt.Errorf("price sheet networking prices must be >= 0")
+	}
+	if p.Storage.PerGBMonth < 0 {
+		return fmt.Errorf("price sheet storage.per_gb_month must be >= 0 (got %v)", p.Storage.PerGBMonth)
+	}
+	if p.HeadroomFraction < 0 || p.HeadroomFraction > 1 {
+		return fmt.Errorf("price sheet headroom_fraction must be in [0, 1] (got %v)", p.HeadroomFraction)
+	}
+	if p.CommitDiscount < 0 || p.CommitDiscount >= 1 {
+		return fmt.Errorf("price sheet commit_discount must be in [0, 1) (got %v)", p.CommitDiscount)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ccpricing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPriceSheetEmbedded(t *testing.T) {
+	sheet, err := LoadPriceSheet("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", sheet.Currency)
+	assert.Equal(t, 60.0, sheet.Enterprise.PerUnitIngressMBps)
+	assert.Equal(t, 32, sheet.Enterprise.MaxUnits)
+	assert.Zero(t, sheet.CommitDiscount)
+}
+
+func TestLoadPriceSheetOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+enterprise:
+  unit_hourly: 1.5
+commit_discount: 0.2
+`), 0o644))
+
+	sheet, err := LoadPriceSheet(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, sheet.Enterprise.UnitHourly)
+	assert.Equal(t, 0.2, sheet.CommitDiscount)
+	// Untouched fields keep the embedded values.
+	assert.Equal(t, 180.0, sheet.Enterprise.PerUnitEgressMBps)
+	assert.InDelta(t, 80.0, sheet.Committed(100), 0.001)
+}
+
+func TestLoadPriceSheetErrors(t *testing.T) {
+	t.Run("missing file is wrapped", func(t *testing.T) {
+		_, err := LoadPriceSheet("/no/such/prices.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read price sheet")
+	})
+
+	t.Run("commit discount of 100% rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "prices.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("commit_discount: 1\n"), 0o644))
+		_, err := LoadPriceSheet(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "commit_discount must be in [0, 1)")
+	})
+}
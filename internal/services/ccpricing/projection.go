@@ -0,0 +1,160 @@
+package ccpricing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+const (
+	bytesPerMB    = 1024 * 1024
+	mbPerGB       = 1024
+	hoursPerMonth = 730
+	// defaultReplicationFactor divides replicated broker storage down
+	// to logical storage when the scan recorded no topics.
+	defaultReplicationFactor = 3
+)
+
+type ClusterType string
+
+const (
+	ClusterTypeEnterprise ClusterType = "Enterprise"
+	ClusterTypeDedicated  ClusterType = "Dedicated"
+)
+
+// ClusterUsage is the workload measured on one source cluster over the
+// report period.
+type ClusterUsage struct {
+	ClusterName string
+	Region      string
+	// P95 throughput sizes the cluster; average throughput is what
+	// crosses the network over the period.
+	P95IngressMBps float64
+	P95EgressMBps  float64
+	AvgIngressMBps float64
+	AvgEgressMBps  float64
+	Partitions     int
+	// StorageGB is logical (pre-replication) storage, local plus remote.
+	StorageGB float64
+}
+
+// ClusterProjection is the Confluent Cloud cost of a ClusterUsage over
+// a period.
+type ClusterProjection struct {
+	Usage          ClusterUsage
+	ClusterType    ClusterType
+	Units          int
+	IngressGB      float64
+	EgressGB       float64
+	ComputeCost    float64
+	NetworkingCost float64
+	StorageCost    float64
+}
+
+func (p ClusterProjection) Total() float64 {
+	return p.ComputeCost + p.NetworkingCost + p.StorageCost
+}
+
+// UsageFromMetrics reads a cluster's usage from its metric aggregates
+// over the report period. ok is false when throughput was not collected
+// (`kcp scan metrics` has not run for the cluster).
+func UsageFromMetrics(clusterName, region string, aggregates map[string]types.MetricAggregate, adminInfo types.KafkaAdminClientInformation) (usage ClusterUsage, ok bool) {
+	in, haveIn := aggregates["BytesInPerSec"]
+	out, haveOut := aggregates["BytesOutPerSec"]
+	if !haveIn || !haveOut || in.P95 == nil || out.P95 == nil {
+		return ClusterUsage{}, false
+	}
+
+	usage = ClusterUsage{
+		ClusterName:    clusterName,
+		Region:         region,
+		P95IngressMBps: *in.P95 / bytesPerMB,
+		P95EgressMBps:  *out.P95 / bytesPerMB,
+		AvgIngressMBps: valueOr(in.Average, *in.P95) / bytesPerMB,
+		AvgEgressMBps:  valueOr(out.Average, *out.P95) / bytesPerMB,
+	}
+	if adminInfo.Topics != nil {
+		usage.Partitions = adminInfo.Topics.Summary.TotalPartitions
+	}
+
+	// Broker disk usage counts every replica; Confluent Cloud bills
+	// storage before replication. Tiered storage holds one copy.
+	if local, ok := aggregates["TotalLocalStorageUsage(GB)"]; ok && local.Average != nil {
+		usage.StorageGB += *local.Average / averageReplicationFactor(adminInfo.Topics)
+	}
+	if remote, ok := aggregates["TotalRemoteStorageUsage(GB)"]; ok && remote.Average != nil {
+		usage.StorageGB += *remote.Average
+	}
+	return usage, true
+}
+
+func valueOr(v *float64, fallback float64) float64 {
+	if v == nil {
+		return fallback
+	}
+	return *v
+}
+
+// averageReplicationFactor weighs each user topic's replication factor
+// by its partition count.
+func averageReplicationFactor(topics *types.Topics) float64 {
+	if topics == nil {
+		return defaultReplicationFactor
+	}
+	var partitions, replicas int
+	for _, topic := range topics.Details {
+		if strings.HasPrefix(topic.Name, "__") || topic.ReplicationFactor < 1 {
+			continue
+		}
+		partitions += topic.Partitions
+		replicas += topic.Partitions * topic.ReplicationFactor
+	}
+	if partitions == 0 {
+		return defaultReplicationFactor
+	}
+	return float64(replicas) / float64(partitions)
+}
+
+// Project prices usage on Confluent Cloud over hours. The cluster is
+// sized like the plan sizes it — the largest of the ingress, egress and
+// partition ratios at p95 plus headroom — on Enterprise, moving to
+// Dedicated when the workload needs more eCKU than Enterprise offers.
+func (p *PriceSheet) Project(usage ClusterUsage, hours float64) (ClusterProjection, error) {
+	projection := ClusterProjection{Usage: usage, ClusterType: ClusterTypeEnterprise}
+
+	pricing := p.Enterprise
+	projection.Units = p.units(usage, pricing)
+	if projection.Units > pricing.MaxUnits {
+		projection.ClusterType = ClusterTypeDedicated
+		pricing = p.Dedicated
+		projection.Units = p.units(usage, pricing)
+		if projection.Units > pricing.MaxUnits {
+			return ClusterProjection{}, fmt.Errorf("cluster %s needs %d CKU, more than the %d a Dedicated cluster offers", usage.ClusterName, projection.Units, pricing.MaxUnits)
+		}
+	}
+
+	seconds := hours * 3600
+	projection.IngressGB = usage.AvgIngressMBps * seconds / mbPerGB
+	projection.EgressGB = usage.AvgEgressMBps * seconds / mbPerGB
+
+	projection.ComputeCost = float64(projection.Units) * pricing.UnitHourly * hours
+	projection.NetworkingCost = projection.IngressGB*p.Networking.IngressPerGB + projection.EgressGB*p.Networking.EgressPerGB
+	projection.StorageCost = usage.StorageGB * p.Storage.PerGBMonth * hours / hoursPerMonth
+	return projection, nil
+}
+
+func (p *PriceSheet) units(usage ClusterUsage, pricing ClusterPricing) int {
+	ratio := math.Max(
+		math.Max(usage.P95IngressMBps/pricing.PerUnitIngressMBps, usage.P95EgressMBps/pricing.PerUnitEgressMBps),
+		float64(usage.Partitions)/float64(pricing.PerUnitPartitions),
+	)
+	units := int(math.Ceil(ratio * (1 + p.HeadroomFraction)))
+	return max(units, 1)
+}
+
+// Committed applies the annual commitment discount to a list price.
+func (p *PriceSheet) Committed(listPrice float64) float64 {
+	return listPrice * (1 - p.CommitDiscount)
+}
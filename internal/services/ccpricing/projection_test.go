@@ -0,0 +1,72 @@
+package ccpricing
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func TestUsageFromMetrics(t *testing.T) {
+	aggregates := map[string]types.MetricAggregate{
+		"BytesInPerSec":               {Average: ptr(5 * bytesPerMB), P95: ptr(20 * bytesPerMB)},
+		"BytesOutPerSec":              {Average: ptr(10 * bytesPerMB), P95: ptr(40 * bytesPerMB)},
+		"TotalLocalStorageUsage(GB)":  {Average: ptr(300)},
+		"TotalRemoteStorageUsage(GB)": {Average: ptr(50)},
+	}
+	adminInfo := types.KafkaAdminClientInformation{}
+	adminInfo.SetTopics([]types.TopicDetails{
+		{Name: "orders", Partitions: 10, ReplicationFactor: 3},
+		{Name: "__consumer_offsets", Partitions: 50, ReplicationFactor: 1},
+	})
+
+	usage, ok := UsageFromMetrics("orders", "us-east-1", aggregates, adminInfo)
+
+	require.True(t, ok)
+	assert.InDelta(t, 20.0, usage.P95IngressMBps, 0.001)
+	assert.InDelta(t, 10.0, usage.AvgEgressMBps, 0.001)
+	assert.Equal(t, 10, usage.Partitions)
+	// 300 GB of replicas at RF 3, plus 50 GB tiered.
+	assert.InDelta(t, 150.0, usage.StorageGB, 0.001)
+
+	_, ok = UsageFromMetrics("orders", "us-east-1", map[string]types.MetricAggregate{}, adminInfo)
+	assert.False(t, ok, "no throughput metrics, nothing to project")
+}
+
+func TestProject(t *testing.T) {
+	sheet, err := LoadPriceSheet("")
+	require.NoError(t, err)
+
+	t.Run("enterprise sized on the dominant ratio", func(t *testing.T) {
+		usage := ClusterUsage{ClusterName: "orders", P95IngressMBps: 100, P95EgressMBps: 90, AvgIngressMBps: 1, AvgEgressMBps: 2, Partitions: 500, StorageGB: 730}
+
+		projection, err := sheet.Project(usage, hoursPerMonth)
+
+		require.NoError(t, err)
+		assert.Equal(t, ClusterTypeEnterprise, projection.ClusterType)
+		// ingress 100/60 = 1.67 dominates; CEIL(1.67 * 1.30) = 3
+		assert.Equal(t, 3, projection.Units)
+		assert.InDelta(t, 3*sheet.Enterprise.UnitHourly*hoursPerMonth, projection.ComputeCost, 0.001)
+		assert.InDelta(t, hoursPerMonth*3600.0/1024, projection.IngressGB, 0.001)
+		assert.InDelta(t, (projection.IngressGB+projection.EgressGB)*sheet.Networking.IngressPerGB, projection.NetworkingCost, 0.001)
+		assert.InDelta(t, 730*sheet.Storage.PerGBMonth, projection.StorageCost, 0.001)
+		assert.InDelta(t, projection.ComputeCost+projection.NetworkingCost+projection.StorageCost, projection.Total(), 0.001)
+	})
+
+	t.Run("beyond enterprise moves to dedicated", func(t *testing.T) {
+		projection, err := sheet.Project(ClusterUsage{ClusterName: "firehose", P95IngressMBps: 2000}, 24)
+
+		require.NoError(t, err)
+		assert.Equal(t, ClusterTypeDedicated, projection.ClusterType)
+		assert.Equal(t, 44, projection.Units)
+	})
+
+	t.Run("beyond dedicated is an error", func(t *testing.T) {
+		_, err := sheet.Project(ClusterUsage{ClusterName: "firehose", P95IngressMBps: 10000}, 24)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "more than the 152")
+	})
+}
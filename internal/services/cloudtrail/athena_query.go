@@ -0,0 +1,112 @@
+package cloudtrail
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/athena"
+)
+
+// athenaQuerier runs Athena queries. *athena.AthenaService satisfies it.
+type athenaQuerier interface {
+	Query(ctx context.Context, query string, opts athena.QueryOpts) ([][]string, error)
+}
+
+// AthenaQueryOpts locates the Athena table over a CloudTrail trail's logs, as
+// created from the CloudTrail console or the AWS documentation's
+// CREATE TABLE statement.
+type AthenaQueryOpts struct {
+	Table string
+	athena.QueryOpts
+}
+
+// AthenaService queries a trail's logs with Athena. Unlike event history it
+// sees kafka-cluster data events and reaches back as far as the trail does.
+type AthenaService struct {
+	athena athenaQuerier
+}
+
+func NewAthenaService(athena *athena.AthenaService) *AthenaService {
+	return &AthenaService{athena: athena}
+}
+
+// kafkaEventsQuery counts the calls naming a cluster since start, grouped as
+// KafkaEvent groups them. The cluster is named by the request parameters of
+// MSK API calls and by the resources of kafka-cluster data events.
+const kafkaEventsQuery = `SELECT
+  coalesce(useridentity.sessioncontext.sessionissuer.arn, useridentity.arn) AS principal_arn,
+  sourceipaddress,
+  eventsource,
+  eventname,
+  coalesce(json_extract_scalar(requestparameters, '$.clusterArn'), element_at(resources, 1).arn) AS resource_arn,
+  count(*) AS events,
+  min(eventtime) AS first_seen,
+  max(eventtime) AS last_seen
+FROM %s
+WHERE eventsource IN ('%s', '%s')
+  AND eventtime >= '%s'
+  AND eventtime < '%s'
+GROUP BY 1, 2, 3, 4, 5`
+
+// QueryKafkaEvents returns the MSK API calls and kafka-cluster data events
+// between start and end that name a cluster.
+func (s *AthenaService) QueryKafkaEvents(ctx context.Context, opts AthenaQueryOpts, start, end time.Time) ([]KafkaEvent, error) {
+	if err := athena.ValidateTableName(opts.Table); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.athena.Query(ctx, fmt.Sprintf(kafkaEventsQuery, opts.Table, mskEventSource, kafkaClusterEventSource,
+		start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)), opts.QueryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []KafkaEvent
+	for _, row := range rows {
+		event, ok, err := parseResultRow(row)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// parseResultRow reads a row of kafkaEventsQuery's results.
+func parseResultRow(row []string) (KafkaEvent, bool, error) {
+	if len(row) != 8 {
+		return KafkaEvent{}, false, fmt.Errorf("unexpected Athena result row with %d columns", len(row))
+	}
+
+	clusterArn := clusterArnOf(row[4])
+	if clusterArn == "" {
+		return KafkaEvent{}, false, nil
+	}
+	count, err := strconv.Atoi(row[5])
+	if err != nil {
+		return KafkaEvent{}, false, fmt.Errorf("invalid event count '%s' in Athena results: %w", row[5], err)
+	}
+	firstSeen, err := time.Parse(time.RFC3339, row[6])
+	if err != nil {
+		return KafkaEvent{}, false, fmt.Errorf("invalid event time '%s' in Athena results: %w", row[6], err)
+	}
+	lastSeen, err := time.Parse(time.RFC3339, row[7])
+	if err != nil {
+		return KafkaEvent{}, false, fmt.Errorf("invalid event time '%s' in Athena results: %w", row[7], err)
+	}
+
+	return KafkaEvent{
+		PrincipalArn: row[0],
+		SourceIP:     row[1],
+		EventSource:  row[2],
+		EventName:    row[3],
+		ClusterArn:   clusterArn,
+		Count:        count,
+		FirstSeen:    firstSeen,
+		LastSeen:     lastSeen,
+	}, true, nil
+}
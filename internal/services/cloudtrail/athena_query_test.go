@@ -0,0 +1,59 @@
+package cloudtrail
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/athena"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQuerier struct {
+	query string
+	opts  athena.QueryOpts
+	rows  [][]string
+}
+
+func (f *fakeQuerier) Query(_ context.Context, query string, opts athena.QueryOpts) ([][]string, error) {
+	f.query, f.opts = query, opts
+	return f.rows, nil
+}
+
+func TestQueryKafkaEvents(t *testing.T) {
+	fake := &fakeQuerier{rows: [][]string{
+		{"arn:aws:iam::000000000000:role/orders-app", "10.0.1.7", "kafka-cluster.amazonaws.com", "ReadData",
+			"arn:aws:kafka:us-east-1:000000000000:topic/orders/abc-1/payments", "42", "2026-10-01T00:00:00Z", "2026-10-02T00:00:00Z"},
+		{"arn:aws:iam::000000000000:user/admin", "10.0.1.9", "kafka.amazonaws.com", "ListClustersV2", "", "1", "2026-10-01T00:00:00Z", "2026-10-01T00:00:00Z"},
+	}}
+	service := &AthenaService{athena: fake}
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	opts := AthenaQueryOpts{Table: "default.cloudtrail_logs", QueryOpts: athena.QueryOpts{WorkGroup: "kcp"}}
+
+	events, err := service.QueryKafkaEvents(context.Background(), opts, start, start.AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, []KafkaEvent{{
+		PrincipalArn: "arn:aws:iam::000000000000:role/orders-app",
+		SourceIP:     "10.0.1.7",
+		EventSource:  "kafka-cluster.amazonaws.com",
+		EventName:    "ReadData",
+		ClusterArn:   ordersArn,
+		Count:        42,
+		FirstSeen:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		LastSeen:     time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+	}}, events)
+	assert.Contains(t, fake.query, "FROM default.cloudtrail_logs")
+	assert.Contains(t, fake.query, "eventtime >= '2026-09-01T00:00:00Z'")
+	assert.Equal(t, "kcp", fake.opts.WorkGroup)
+}
+
+func TestQueryKafkaEvents_InvalidTable(t *testing.T) {
+	fake := &fakeQuerier{}
+	service := &AthenaService{athena: fake}
+
+	_, err := service.QueryKafkaEvents(context.Background(), AthenaQueryOpts{Table: "logs; DROP TABLE logs"}, time.Now(), time.Now())
+	require.Error(t, err)
+	assert.Empty(t, fake.query)
+}
@@ -0,0 +1,56 @@
+package cloudtrail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	cloudtrailtypes "github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+// CloudTrailService reads a region's CloudTrail event history, the last 90
+// days of management events, which needs no trail. *cloudtrail.Client
+// satisfies its client.
+type CloudTrailService struct {
+	client cloudtrail.LookupEventsAPIClient
+}
+
+func NewCloudTrailService(client *cloudtrail.Client) *CloudTrailService {
+	return &CloudTrailService{client: client}
+}
+
+// LookupKafkaEvents returns the MSK API calls between start and end that name
+// a cluster. Event history only holds management events, so kafka-cluster
+// data events need a trail queried with Athena instead.
+func (s *CloudTrailService) LookupKafkaEvents(ctx context.Context, start, end time.Time) ([]KafkaEvent, error) {
+	paginator := cloudtrail.NewLookupEventsPaginator(s.client, &cloudtrail.LookupEventsInput{
+		LookupAttributes: []cloudtrailtypes.LookupAttribute{{
+			AttributeKey:   cloudtrailtypes.LookupAttributeKeyEventSource,
+			AttributeValue: aws.String(mskEventSource),
+		}},
+		StartTime: aws.Time(start),
+		EndTime:   aws.Time(end),
+	})
+
+	var events []KafkaEvent
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up CloudTrail events: %w", err)
+		}
+		for _, e := range page.Events {
+			event, ok, err := parseKafkaEvent(aws.ToString(e.CloudTrailEvent))
+			if err != nil {
+				slog.Warn("⚠️ skipping unreadable CloudTrail event", "event_id", aws.ToString(e.EventId), "error", err)
+				continue
+			}
+			if ok {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
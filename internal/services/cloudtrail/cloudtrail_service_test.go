@@ -0,0 +1,60 @@
+package cloudtrail
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	cloudtrailtypes "github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLookupEvents struct {
+	pages  []*cloudtrail.LookupEventsOutput
+	inputs []*cloudtrail.LookupEventsInput
+}
+
+func (f *fakeLookupEvents) LookupEvents(_ context.Context, in *cloudtrail.LookupEventsInput, _ ...func(*cloudtrail.Options)) (*cloudtrail.LookupEventsOutput, error) {
+	f.inputs = append(f.inputs, in)
+	page := f.pages[len(f.inputs)-1]
+	return page, nil
+}
+
+func TestLookupKafkaEvents(t *testing.T) {
+	record := func(name string) *string {
+		return aws.String(`{"eventTime":"2026-10-01T12:00:00Z","eventName":"` + name + `","sourceIPAddress":"10.0.1.7","userIdentity":{"arn":"arn:aws:iam::000000000000:user/app"},"requestParameters":{"clusterArn":"` + ordersArn + `"}}`)
+	}
+	fake := &fakeLookupEvents{pages: []*cloudtrail.LookupEventsOutput{
+		{
+			Events: []cloudtrailtypes.Event{
+				{CloudTrailEvent: record("GetBootstrapBrokers")},
+				{CloudTrailEvent: aws.String(`{"eventName":"ListClustersV2"}`)},
+			},
+			NextToken: aws.String("page-2"),
+		},
+		{
+			Events: []cloudtrailtypes.Event{
+				{EventId: aws.String("broken"), CloudTrailEvent: aws.String("{")},
+				{CloudTrailEvent: record("DescribeClusterV2")},
+			},
+		},
+	}}
+	service := &CloudTrailService{client: fake}
+	start := time.Date(2026, 9, 24, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	events, err := service.LookupKafkaEvents(context.Background(), start, end)
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "GetBootstrapBrokers", events[0].EventName)
+	assert.Equal(t, "DescribeClusterV2", events[1].EventName)
+	require.Len(t, fake.inputs, 2)
+	assert.Equal(t, "kafka.amazonaws.com", aws.ToString(fake.inputs[0].LookupAttributes[0].AttributeValue))
+	assert.Equal(t, start, aws.ToTime(fake.inputs[0].StartTime))
+	assert.Equal(t, end, aws.ToTime(fake.inputs[0].EndTime))
+	assert.Equal(t, "page-2", aws.ToString(fake.inputs[1].NextToken))
+}
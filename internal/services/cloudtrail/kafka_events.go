@@ -0,0 +1,169 @@
+package cloudtrail
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// Event sources of the MSK control plane (GetBootstrapBrokers,
+// DescribeCluster, ...) and of the kafka-cluster data plane actions that
+// IAM-authenticated clients are authorized for. CloudTrail only records the
+// latter when a trail logs MSK data events.
+const (
+	mskEventSource          = "kafka.amazonaws.com"
+	kafkaClusterEventSource = "kafka-cluster.amazonaws.com"
+)
+
+// KafkaEvent is one or more identical CloudTrail events, calls by the same
+// principal from the same address, naming the same cluster.
+type KafkaEvent struct {
+	PrincipalArn string
+	SourceIP     string
+	EventSource  string
+	EventName    string
+	ClusterArn   string
+	Count        int
+	FirstSeen    time.Time
+	LastSeen     time.Time
+}
+
+// cloudTrailRecord holds the fields of a CloudTrail event record that say
+// who called what on which cluster.
+type cloudTrailRecord struct {
+	EventTime       time.Time `json:"eventTime"`
+	EventSource     string    `json:"eventSource"`
+	EventName       string    `json:"eventName"`
+	SourceIPAddress string    `json:"sourceIPAddress"`
+	UserIdentity    struct {
+		Type           string `json:"type"`
+		Arn            string `json:"arn"`
+		SessionContext struct {
+			SessionIssuer struct {
+				Arn string `json:"arn"`
+			} `json:"sessionIssuer"`
+		} `json:"sessionContext"`
+	} `json:"userIdentity"`
+	RequestParameters struct {
+		ClusterArn string `json:"clusterArn"`
+	} `json:"requestParameters"`
+	Resources []struct {
+		Arn string `json:"ARN"`
+	} `json:"resources"`
+}
+
+// parseKafkaEvent parses a CloudTrail event record. ok is false for events
+// that name no cluster, such as ListClustersV2.
+func parseKafkaEvent(record string) (event KafkaEvent, ok bool, err error) {
+	var r cloudTrailRecord
+	if err := json.Unmarshal([]byte(record), &r); err != nil {
+		return KafkaEvent{}, false, fmt.Errorf("failed to parse CloudTrail event: %w", err)
+	}
+
+	resourceArn := r.RequestParameters.ClusterArn
+	if resourceArn == "" && len(r.Resources) > 0 {
+		resourceArn = r.Resources[0].Arn
+	}
+	clusterArn := clusterArnOf(resourceArn)
+	if clusterArn == "" {
+		return KafkaEvent{}, false, nil
+	}
+
+	return KafkaEvent{
+		PrincipalArn: principalArn(r.UserIdentity.Arn, r.UserIdentity.SessionContext.SessionIssuer.Arn),
+		SourceIP:     r.SourceIPAddress,
+		EventSource:  r.EventSource,
+		EventName:    r.EventName,
+		ClusterArn:   clusterArn,
+		Count:        1,
+		FirstSeen:    r.EventTime,
+		LastSeen:     r.EventTime,
+	}, true, nil
+}
+
+// principalArn attributes an assumed-role session to the role that issued
+// it, so the sessions of every instance of an application count as one
+// principal.
+func principalArn(identityArn, sessionIssuerArn string) string {
+	if sessionIssuerArn != "" {
+		return sessionIssuerArn
+	}
+	return identityArn
+}
+
+// clusterArnOf returns the ARN of the MSK cluster an MSK resource ARN
+// belongs to: the cluster itself, or one of its topics, groups or
+// transactional IDs (arn:aws:kafka:<region>:<account>:topic/<cluster
+// name>/<cluster uuid>/<topic>). Other ARNs return "".
+func clusterArnOf(resourceArn string) string {
+	parsed, err := arn.Parse(resourceArn)
+	if err != nil || parsed.Service != "kafka" {
+		return ""
+	}
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	switch parts[0] {
+	case "cluster", "topic", "group", "transactional-id":
+	default:
+		return ""
+	}
+	parsed.Resource = strings.Join([]string{"cluster", parts[1], parts[2]}, "/")
+	return parsed.String()
+}
+
+// ClientActivity groups events by cluster and then by principal. Clusters
+// without events are left out.
+func ClientActivity(events []KafkaEvent) map[string][]types.CloudTrailPrincipalActivity {
+	byCluster := make(map[string]map[string]*types.CloudTrailPrincipalActivity)
+	for _, event := range events {
+		principals, ok := byCluster[event.ClusterArn]
+		if !ok {
+			principals = make(map[string]*types.CloudTrailPrincipalActivity)
+			byCluster[event.ClusterArn] = principals
+		}
+		activity, ok := principals[event.PrincipalArn]
+		if !ok {
+			activity = &types.CloudTrailPrincipalActivity{
+				PrincipalArn: event.PrincipalArn,
+				FirstSeen:    event.FirstSeen,
+				LastSeen:     event.LastSeen,
+			}
+			principals[event.PrincipalArn] = activity
+		}
+		if event.SourceIP != "" && !slices.Contains(activity.SourceIPs, event.SourceIP) {
+			activity.SourceIPs = append(activity.SourceIPs, event.SourceIP)
+		}
+		if !slices.Contains(activity.EventNames, event.EventName) {
+			activity.EventNames = append(activity.EventNames, event.EventName)
+		}
+		activity.EventCount += event.Count
+		if event.FirstSeen.Before(activity.FirstSeen) {
+			activity.FirstSeen = event.FirstSeen
+		}
+		if event.LastSeen.After(activity.LastSeen) {
+			activity.LastSeen = event.LastSeen
+		}
+	}
+
+	result := make(map[string][]types.CloudTrailPrincipalActivity, len(byCluster))
+	for clusterArn, principals := range byCluster {
+		activities := make([]types.CloudTrailPrincipalActivity, 0, len(principals))
+		for _, activity := range principals {
+			slices.Sort(activity.SourceIPs)
+			slices.Sort(activity.EventNames)
+			activities = append(activities, *activity)
+		}
+		slices.SortFunc(activities, func(a, b types.CloudTrailPrincipalActivity) int {
+			return strings.Compare(a.PrincipalArn, b.PrincipalArn)
+		})
+		result[clusterArn] = activities
+	}
+	return result
+}
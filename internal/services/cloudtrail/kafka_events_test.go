@@ -0,0 +1,119 @@
+package cloudtrail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ordersArn = "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1"
+
+func TestParseKafkaEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		want   KafkaEvent
+		wantOK bool
+	}{
+		{
+			name: "assumed role attributed to its role",
+			record: `{"eventTime":"2026-10-01T12:00:00Z","eventSource":"kafka.amazonaws.com","eventName":"GetBootstrapBrokers","sourceIPAddress":"10.0.1.7",
+				"userIdentity":{"type":"AssumedRole","arn":"arn:aws:sts::000000000000:assumed-role/orders-app/i-0abc","sessionContext":{"sessionIssuer":{"arn":"arn:aws:iam::000000000000:role/apps/orders-app"}}},
+				"requestParameters":{"clusterArn":"` + ordersArn + `"}}`,
+			want: KafkaEvent{
+				PrincipalArn: "arn:aws:iam::000000000000:role/apps/orders-app",
+				SourceIP:     "10.0.1.7",
+				EventSource:  "kafka.amazonaws.com",
+				EventName:    "GetBootstrapBrokers",
+				ClusterArn:   ordersArn,
+				Count:        1,
+				FirstSeen:    time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+				LastSeen:     time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+			},
+			wantOK: true,
+		},
+		{
+			name: "data event on a topic",
+			record: `{"eventTime":"2026-10-01T12:00:00Z","eventSource":"kafka-cluster.amazonaws.com","eventName":"WriteData","sourceIPAddress":"10.0.1.8",
+				"userIdentity":{"type":"IAMUser","arn":"arn:aws:iam::000000000000:user/loader"},
+				"resources":[{"ARN":"arn:aws:kafka:us-east-1:000000000000:topic/orders/abc-1/payments"}]}`,
+			want: KafkaEvent{
+				PrincipalArn: "arn:aws:iam::000000000000:user/loader",
+				SourceIP:     "10.0.1.8",
+				EventSource:  "kafka-cluster.amazonaws.com",
+				EventName:    "WriteData",
+				ClusterArn:   ordersArn,
+				Count:        1,
+				FirstSeen:    time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+				LastSeen:     time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+			},
+			wantOK: true,
+		},
+		{
+			name:   "no cluster",
+			record: `{"eventTime":"2026-10-01T12:00:00Z","eventSource":"kafka.amazonaws.com","eventName":"ListClustersV2","userIdentity":{"arn":"arn:aws:iam::000000000000:user/admin"},"requestParameters":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := parseKafkaEvent(tt.record)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, _, err := parseKafkaEvent("not json")
+	assert.Error(t, err)
+}
+
+func TestClusterArnOf(t *testing.T) {
+	tests := map[string]string{
+		ordersArn: ordersArn,
+		"arn:aws:kafka:us-east-1:000000000000:topic/orders/abc-1/payments":        ordersArn,
+		"arn:aws:kafka:us-east-1:000000000000:group/orders/abc-1/payments-reader": ordersArn,
+		"arn:aws:kafka:us-east-1:000000000000:configuration/orders-config/xyz":    "",
+		"arn:aws:s3:::bucket/key/more":                                            "",
+		"not an arn":                                                              "",
+	}
+	for resourceArn, want := range tests {
+		assert.Equal(t, want, clusterArnOf(resourceArn), resourceArn)
+	}
+}
+
+func TestClientActivity(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	app := "arn:aws:iam::000000000000:role/orders-app"
+	events := []KafkaEvent{
+		{PrincipalArn: app, SourceIP: "10.0.1.8", EventName: "GetBootstrapBrokers", ClusterArn: ordersArn, Count: 1, FirstSeen: day(3), LastSeen: day(3)},
+		{PrincipalArn: app, SourceIP: "10.0.1.7", EventName: "GetBootstrapBrokers", ClusterArn: ordersArn, Count: 4, FirstSeen: day(1), LastSeen: day(2)},
+		{PrincipalArn: app, SourceIP: "10.0.1.7", EventName: "DescribeClusterV2", ClusterArn: ordersArn, Count: 1, FirstSeen: day(2), LastSeen: day(2)},
+		{PrincipalArn: "arn:aws:iam::000000000000:user/admin", EventName: "DescribeClusterV2", ClusterArn: ordersArn, Count: 1, FirstSeen: day(5), LastSeen: day(5)},
+	}
+
+	got := ClientActivity(events)
+
+	assert.Equal(t, map[string][]types.CloudTrailPrincipalActivity{
+		ordersArn: {
+			{
+				PrincipalArn: "arn:aws:iam::000000000000:role/orders-app",
+				SourceIPs:    []string{"10.0.1.7", "10.0.1.8"},
+				EventNames:   []string{"DescribeClusterV2", "GetBootstrapBrokers"},
+				EventCount:   6,
+				FirstSeen:    day(1),
+				LastSeen:     day(3),
+			},
+			{
+				PrincipalArn: "arn:aws:iam::000000000000:user/admin",
+				EventNames:   []string{"DescribeClusterV2"},
+				EventCount:   1,
+				FirstSeen:    day(5),
+				LastSeen:     day(5),
+			},
+		},
+	}, got)
+}
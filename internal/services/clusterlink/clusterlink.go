@@ -13,6 +13,8 @@ import (
 	"net/url"
 	"slices"
 	"time"
+
+	"github.com/confluentinc/kcp/internal/audit"
 )
 
 // httpStatusError is returned by doRequest/doPostRequest when the server
@@ -59,10 +61,21 @@ type MirrorLag struct {
 
 type MirrorTopic struct {
 	MirrorTopicName string      `json:"mirror_topic_name"`
+	SourceTopicName string      `json:"source_topic_name"`
 	MirrorStatus    string      `json:"mirror_status"`
 	MirrorLags      []MirrorLag `json:"mirror_lags"`
 }
 
+// SourceTopic is the name of the mirrored topic on the source cluster. It
+// differs from MirrorTopicName when the link adds a prefix; a response
+// without source_topic_name falls back to the mirror topic's own name.
+func (m MirrorTopic) SourceTopic() string {
+	if m.SourceTopicName != "" {
+		return m.SourceTopicName
+	}
+	return m.MirrorTopicName
+}
+
 const (
 	// Mirror topic status constants
 	MirrorStatusActive = "ACTIVE"
@@ -145,7 +158,7 @@ func NewConfluentCloudService(httpClient HTTPClient) *ConfluentCloudService {
 		httpClient = http.DefaultClient
 	}
 	return &ConfluentCloudService{
-		httpClient: httpClient,
+		httpClient: audit.WrapHTTPClient(audit.SystemConfluentCloud, httpClient),
 	}
 }
 
@@ -181,7 +181,7 @@ func TestListMirrorTopics_Success(t *testing.T) {
 			"data": []map[string]interface{}{
 				{"mirror_topic_name": "topic-1", "mirror_status": "ACTIVE", "mirror_lags": []map[string]interface{}{{"partition": 0, "lag": 0}}},
 				{"mirror_topic_name": "topic-2", "mirror_status": "PAUSED", "mirror_lags": []map[string]interface{}{}},
-				{"mirror_topic_name": "topic-3", "mirror_status": "ACTIVE", "mirror_lags": []map[string]interface{}{{"partition": 0, "lag": 5}}},
+				{"mirror_topic_name": "msk-topic-3", "source_topic_name": "topic-3", "mirror_status": "ACTIVE", "mirror_lags": []map[string]interface{}{{"partition": 0, "lag": 5}}},
 			},
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -204,6 +204,8 @@ func TestListMirrorTopics_Success(t *testing.T) {
 	assert.Equal(t, "topic-1", topics[0].MirrorTopicName)
 	assert.Equal(t, "PAUSED", topics[1].MirrorStatus)
 	assert.Equal(t, 5, topics[2].MirrorLags[0].Lag)
+	assert.Equal(t, "topic-3", topics[2].SourceTopic())
+	assert.Equal(t, "topic-1", topics[0].SourceTopic(), "falls back to the mirror topic name")
 }
 
 func TestListMirrorTopics_FiltersByTopics(t *testing.T) {
@@ -0,0 +1,246 @@
+// Package compatibility checks the source clusters in a kcp state file for
+// clients that may not work against Confluent Cloud: the source Kafka
+// version, and the Produce and Fetch API versions `kcp scan client-inventory`
+// saw each client use in the broker logs.
+package compatibility
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// Severity ranks a finding.
+type Severity string
+
+const (
+	// SeverityError marks a client Confluent Cloud rejects.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a client that works today but is unsupported.
+	SeverityWarning Severity = "warning"
+)
+
+// apiFloor holds the API versions of one request type that decide client
+// compatibility. Removed is the lowest version Apache Kafka 4.0, and so
+// Confluent Cloud, still accepts (KIP-896); Baseline is the version Apache
+// Kafka 2.1 clients send, the oldest client release Apache Kafka 4.0 supports.
+type apiFloor struct {
+	API      string
+	Removed  int
+	Baseline int
+}
+
+// apiFloors is keyed by the DiscoveredClient role the API version was seen on.
+var apiFloors = map[string]apiFloor{
+	"Producer": {API: "Produce", Removed: 3, Baseline: 7},
+	"Consumer": {API: "Fetch", Removed: 4, Baseline: 10},
+}
+
+// baselineKafkaVersion is the broker version below which the broker, not the
+// client, caps the API versions seen in the logs: clients negotiate down to
+// the newest version both sides support.
+const baselineKafkaVersion = "2.1.0"
+
+// ClientFinding is one client flagged by the check.
+type ClientFinding struct {
+	ClientId    string
+	Principal   string
+	Role        string
+	Topic       string
+	API         string
+	ApiVersion  int
+	Severity    Severity
+	Message     string
+	Remediation string
+}
+
+// ClusterResult is the check of one source cluster.
+type ClusterResult struct {
+	Name         string
+	Source       types.SourceType
+	KafkaVersion string
+	// Clients counts the discovered clients and ClientsWithVersion those
+	// with a recorded API version.
+	Clients            int
+	ClientsWithVersion int
+	Notes              []string
+	Findings           []ClientFinding
+}
+
+// Errors counts the findings Confluent Cloud would reject.
+func (r ClusterResult) Errors() int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Check runs the compatibility check on every MSK and Apache Kafka cluster
+// in the state file.
+func Check(state *types.State) []ClusterResult {
+	var results []ClusterResult
+	if state.MSKSources != nil {
+		for _, region := range state.MSKSources.Regions {
+			for _, cluster := range region.Clusters {
+				results = append(results, checkCluster(cluster.Name, types.SourceTypeMSK, mskKafkaVersion(cluster), cluster.DiscoveredClients))
+			}
+		}
+	}
+	if state.OSKSources != nil {
+		for _, cluster := range state.OSKSources.Clusters {
+			results = append(results, checkCluster(cluster.ID, types.SourceTypeOSK, cluster.Metadata.KafkaVersion, cluster.DiscoveredClients))
+		}
+	}
+	return results
+}
+
+func checkCluster(name string, source types.SourceType, kafkaVersion string, clients []types.DiscoveredClient) ClusterResult {
+	result := ClusterResult{Name: name, Source: source, KafkaVersion: kafkaVersion, Clients: len(clients)}
+
+	brokerCapped := kafkaVersion != "" && !versionAtLeast(kafkaVersion, baselineKafkaVersion)
+	switch {
+	case kafkaVersion == "":
+		result.Notes = append(result.Notes, "The source Kafka version is not recorded: re-run `kcp discover`, or for Apache Kafka clusters set `/metadata/kafka_version` with `kcp state annotate`.")
+	case brokerCapped:
+		result.Notes = append(result.Notes, fmt.Sprintf("The source runs Kafka %s, older than %s. Clients negotiate down to the broker's API versions, so the versions seen in the logs may be older than the clients: only clients below the versions Confluent Cloud rejects are flagged.", kafkaVersion, baselineKafkaVersion))
+	}
+
+	if len(clients) == 0 {
+		result.Notes = append(result.Notes, "No clients discovered: run `kcp scan client-inventory` on broker logs with `kafka.server.KafkaApis` TRACE logging to check the clients' API versions.")
+		return result
+	}
+
+	// A client appears once per topic it uses; check it once per role.
+	seen := map[string]bool{}
+	for _, c := range clients {
+		if c.ApiVersion == 0 {
+			continue
+		}
+		result.ClientsWithVersion++
+
+		floor, ok := apiFloors[c.Role]
+		if !ok {
+			continue
+		}
+		key := c.ClientId + "|" + c.Principal + "|" + c.Role
+		if seen[key] {
+			continue
+		}
+
+		finding := ClientFinding{
+			ClientId:   c.ClientId,
+			Principal:  c.Principal,
+			Role:       c.Role,
+			Topic:      c.Topic,
+			API:        floor.API,
+			ApiVersion: c.ApiVersion,
+		}
+		switch {
+		case c.ApiVersion < floor.Removed:
+			finding.Severity = SeverityError
+			finding.Message = fmt.Sprintf("%s v%d was removed in Apache Kafka 4.0 (KIP-896): Confluent Cloud rejects it, the client needs %s v%d or later", floor.API, c.ApiVersion, floor.API, floor.Removed)
+		case c.ApiVersion < floor.Baseline && !brokerCapped:
+			finding.Severity = SeverityWarning
+			finding.Message = fmt.Sprintf("%s v%d predates Apache Kafka 2.1 clients (v%d): accepted today, but clients older than 2.1 are unsupported", floor.API, c.ApiVersion, floor.Baseline)
+		default:
+			continue
+		}
+		finding.Remediation = remediation(c.ClientId)
+		seen[key] = true
+		result.Findings = append(result.Findings, finding)
+	}
+
+	if result.ClientsWithVersion < result.Clients {
+		result.Notes = append(result.Notes, fmt.Sprintf("%d of %d discovered clients have no API version recorded: re-run `kcp scan client-inventory` to record it.", result.Clients-result.ClientsWithVersion, result.Clients))
+	}
+
+	sort.Slice(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityError
+		}
+		if a.ClientId != b.ClientId {
+			return a.ClientId < b.ClientId
+		}
+		return a.Role < b.Role
+	})
+	return result
+}
+
+// clientLibraries recognises client libraries by the default client.id they
+// send, for library-specific remediation.
+var clientLibraries = []struct {
+	pattern     *regexp.Regexp
+	remediation string
+}{
+	{regexp.MustCompile(`^sarama$`), "Sarama picks its protocol from `Config.Version`: set it to at least `sarama.V2_1_0_0` (older releases default to a pre-0.11 version) and upgrade to a maintained release of github.com/IBM/sarama."},
+	{regexp.MustCompile(`^rdkafka$`), "Upgrade librdkafka (or the client wrapping it) and remove any `api.version.request=false` or `broker.version.fallback` setting, which pins an old protocol."},
+	{regexp.MustCompile(`^kafka-python`), "Upgrade kafka-python and remove any explicit `api_version` setting, or move to confluent-kafka-python."},
+	{regexp.MustCompile(`^(producer|consumer)-\d+$|^consumer-.+-\d+$`), "Upgrade the Java kafka-clients dependency to 2.1 or later (a current 3.x release is recommended)."},
+}
+
+// remediation returns the upgrade advice for a client.
+func remediation(clientId string) string {
+	for _, library := range clientLibraries {
+		if library.pattern.MatchString(clientId) {
+			return library.remediation
+		}
+	}
+	return "Upgrade the client library to a release based on Apache Kafka 2.1 or later, and remove any setting that pins the protocol version."
+}
+
+func mskKafkaVersion(cluster types.DiscoveredCluster) string {
+	provisioned := cluster.AWSClientInformation.MskClusterConfig.Provisioned
+	if cluster.AWSClientInformation.MskClusterConfig.ClusterType == kafkatypes.ClusterTypeServerless || provisioned == nil || provisioned.CurrentBrokerSoftwareInfo == nil {
+		return ""
+	}
+	return aws.ToString(provisioned.CurrentBrokerSoftwareInfo.KafkaVersion)
+}
+
+// versionAtLeast reports whether the dotted version have is at least floor.
+// Non-numeric segments (e.g. MSK's "3.8.x") count as 0; an unparseable
+// version is reported as not clearing the floor.
+func versionAtLeast(have, floor string) bool {
+	h, f := versionSegments(have), versionSegments(floor)
+	if h == nil || f == nil {
+		return false
+	}
+	for i := 0; i < max(len(h), len(f)); i++ {
+		var hv, fv int
+		if i < len(h) {
+			hv = h[i]
+		}
+		if i < len(f) {
+			fv = f[i]
+		}
+		if hv != fv {
+			return hv > fv
+		}
+	}
+	return true
+}
+
+func versionSegments(s string) []int {
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	segments := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			if i == 0 {
+				return nil
+			}
+			n = 0
+		}
+		segments[i] = n
+	}
+	return segments
+}
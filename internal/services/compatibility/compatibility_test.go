@@ -0,0 +1,87 @@
+package compatibility
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mskState(kafkaVersion string, clients ...types.DiscoveredClient) *types.State {
+	cluster := types.DiscoveredCluster{Name: "orders", DiscoveredClients: clients}
+	cluster.AWSClientInformation.MskClusterConfig.ClusterType = kafkatypes.ClusterTypeProvisioned
+	cluster.AWSClientInformation.MskClusterConfig.Provisioned = &kafkatypes.Provisioned{
+		CurrentBrokerSoftwareInfo: &kafkatypes.BrokerSoftwareInfo{KafkaVersion: aws.String(kafkaVersion)},
+	}
+	return &types.State{MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []types.DiscoveredCluster{cluster}},
+	}}}
+}
+
+func TestCheck_FlagsOldClients(t *testing.T) {
+	state := mskState("3.6.0",
+		types.DiscoveredClient{ClientId: "sarama", Role: "Producer", Topic: "orders", ApiVersion: 2},
+		types.DiscoveredClient{ClientId: "sarama", Role: "Producer", Topic: "payments", ApiVersion: 2},
+		types.DiscoveredClient{ClientId: "consumer-billing-1", Role: "Consumer", Topic: "orders", ApiVersion: 6},
+		types.DiscoveredClient{ClientId: "stock-levels", Role: "Producer", Topic: "stock", ApiVersion: 9},
+		types.DiscoveredClient{ClientId: "legacy", Role: "Consumer", Topic: "stock"},
+	)
+
+	results := Check(state)
+	require.Len(t, results, 1)
+	r := results[0]
+	assert.Equal(t, "3.6.0", r.KafkaVersion)
+	assert.Equal(t, 5, r.Clients)
+	assert.Equal(t, 4, r.ClientsWithVersion)
+	assert.Equal(t, 1, r.Errors())
+
+	require.Len(t, r.Findings, 2, "sarama is reported once across its topics")
+	assert.Equal(t, SeverityError, r.Findings[0].Severity)
+	assert.Equal(t, "sarama", r.Findings[0].ClientId)
+	assert.Equal(t, "Produce", r.Findings[0].API)
+	assert.Contains(t, r.Findings[0].Remediation, "V2_1_0_0")
+
+	assert.Equal(t, SeverityWarning, r.Findings[1].Severity)
+	assert.Equal(t, "consumer-billing-1", r.Findings[1].ClientId)
+	assert.Contains(t, r.Findings[1].Remediation, "kafka-clients")
+
+	assert.Contains(t, r.Notes[len(r.Notes)-1], "1 of 5 discovered clients have no API version")
+}
+
+func TestCheck_OldBrokerReportsErrorsOnly(t *testing.T) {
+	state := mskState("1.1.1",
+		types.DiscoveredClient{ClientId: "rdkafka", Role: "Producer", ApiVersion: 5},
+		types.DiscoveredClient{ClientId: "rdkafka", Role: "Consumer", ApiVersion: 3},
+	)
+
+	r := Check(state)[0]
+	require.Len(t, r.Findings, 1)
+	assert.Equal(t, SeverityError, r.Findings[0].Severity)
+	assert.Equal(t, "Consumer", r.Findings[0].Role)
+	assert.Contains(t, r.Findings[0].Remediation, "api.version.request")
+	assert.Contains(t, r.Notes[0], "older than 2.1.0")
+}
+
+func TestCheck_NoClients(t *testing.T) {
+	state := &types.State{OSKSources: &types.OSKSourcesState{Clusters: []types.OSKDiscoveredCluster{
+		{ID: "on-prem", Metadata: types.OSKClusterMetadata{KafkaVersion: "3.7.0"}},
+	}}}
+
+	r := Check(state)[0]
+	assert.Equal(t, types.SourceTypeOSK, r.Source)
+	assert.Empty(t, r.Findings)
+	require.Len(t, r.Notes, 1)
+	assert.Contains(t, r.Notes[0], "kcp scan client-inventory")
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	assert.True(t, versionAtLeast("2.1.0", "2.1.0"))
+	assert.True(t, versionAtLeast("3.8.x", "2.1.0"))
+	assert.True(t, versionAtLeast("2.8.2.tiered", "2.1.0"))
+	assert.False(t, versionAtLeast("1.1.1", "2.1.0"))
+	assert.False(t, versionAtLeast("2.0", "2.1.0"))
+	assert.False(t, versionAtLeast("unknown", "2.1.0"))
+}
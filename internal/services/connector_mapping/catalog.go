@@ -0,0 +1,81 @@
+package connector_mapping
+
+// Availability is how a connector class can run once the source moves to
+// Confluent Cloud.
+type Availability string
+
+const (
+	// AvailabilitySupported means a fully-managed connector replaces the
+	// class with equivalent behaviour.
+	AvailabilitySupported Availability = "supported"
+	// AvailabilityPartial means a fully-managed connector covers the class,
+	// but with gaps: a deprecated class that moves to a successor plugin,
+	// or one whose features are only partly available.
+	AvailabilityPartial Availability = "partially-supported"
+	// AvailabilitySelfManaged means there is no fully-managed equivalent;
+	// the connector keeps running on a self-managed Connect cluster (or is
+	// replaced by something else entirely, e.g. Cluster Linking).
+	AvailabilitySelfManaged Availability = "self-managed-only"
+)
+
+// CatalogEntry is what is known about one connector class on Confluent Cloud.
+type CatalogEntry struct {
+	Class        string
+	Plugin       string
+	Availability Availability
+	Note         string
+}
+
+// catalog lists connector classes commonly found on MSK Connect and
+// self-managed Connect clusters with their fully-managed status. Classes that
+// are not listed are treated as self-managed-only: most are custom or
+// community plugins that Confluent Cloud can only run as custom connectors.
+var catalog = map[string]CatalogEntry{
+	"io.confluent.connect.s3.S3SinkConnector":                          {Plugin: "S3_SINK", Availability: AvailabilitySupported},
+	"io.confluent.connect.s3.source.S3SourceConnector":                 {Plugin: "S3Source", Availability: AvailabilitySupported},
+	"io.debezium.connector.mysql.MySqlConnector":                       {Plugin: "MySqlCdcSourceV2", Availability: AvailabilitySupported},
+	"io.debezium.connector.postgresql.PostgresConnector":               {Plugin: "PostgresCdcSourceV2", Availability: AvailabilitySupported},
+	"io.debezium.connector.sqlserver.SqlServerConnector":               {Plugin: "SqlServerCdcSourceV2", Availability: AvailabilitySupported},
+	"io.debezium.connector.mongodb.MongoDbConnector":                   {Plugin: "MongoDbAtlasSource", Availability: AvailabilityPartial, Note: "the fully-managed MongoDB source reads change streams from MongoDB Atlas only; Debezium's event format differs"},
+	"io.confluent.connect.jdbc.JdbcSourceConnector":                    {Availability: AvailabilityPartial, Note: "replaced by a database-specific plugin (MySQL, PostgreSQL, SQL Server or Oracle); other databases need a self-managed connector"},
+	"io.confluent.connect.jdbc.JdbcSinkConnector":                      {Availability: AvailabilityPartial, Note: "replaced by a database-specific plugin (MySQL, PostgreSQL, SQL Server or Oracle); other databases need a self-managed connector"},
+	"io.confluent.connect.elasticsearch.ElasticsearchSinkConnector":    {Plugin: "ElasticsearchSink", Availability: AvailabilitySupported},
+	"io.confluent.connect.http.HttpSinkConnector":                      {Plugin: "HttpSinkV2", Availability: AvailabilitySupported},
+	"io.confluent.connect.aws.lambda.AwsLambdaSinkConnector":           {Plugin: "LambdaSink", Availability: AvailabilitySupported},
+	"io.confluent.connect.aws.dynamodb.DynamoDbSinkConnector":          {Plugin: "DynamoDbSink", Availability: AvailabilitySupported},
+	"io.confluent.connect.aws.redshift.RedshiftSinkConnector":          {Plugin: "RedshiftSink", Availability: AvailabilitySupported},
+	"io.confluent.connect.kinesis.KinesisSourceConnector":              {Plugin: "KinesisSource", Availability: AvailabilitySupported},
+	"io.confluent.connect.sqs.source.SqsSourceConnector":               {Plugin: "SqsSource", Availability: AvailabilitySupported},
+	"io.confluent.connect.aws.cloudwatch.AwsCloudWatchSourceConnector": {Plugin: "CloudWatchLogsSource", Availability: AvailabilitySupported},
+	"io.confluent.connect.splunk.SplunkSinkConnector":                  {Plugin: "SplunkSink", Availability: AvailabilitySupported},
+	"com.splunk.kafka.connect.SplunkSinkConnector":                     {Plugin: "SplunkSink", Availability: AvailabilityPartial, Note: "Splunk's own HEC connector maps onto the fully-managed Splunk sink, which has fewer HEC options"},
+	"com.snowflake.kafka.connector.SnowflakeSinkConnector":             {Plugin: "SnowflakeSink", Availability: AvailabilitySupported},
+	"com.mongodb.kafka.connect.MongoSinkConnector":                     {Plugin: "MongoDbAtlasSink", Availability: AvailabilityPartial, Note: "the fully-managed MongoDB sink writes to MongoDB Atlas only"},
+	"com.mongodb.kafka.connect.MongoSourceConnector":                   {Plugin: "MongoDbAtlasSource", Availability: AvailabilityPartial, Note: "the fully-managed MongoDB source reads from MongoDB Atlas only"},
+	"com.wepay.kafka.connect.bigquery.BigQuerySinkConnector":           {Plugin: "BigQueryStorageSink", Availability: AvailabilityPartial, Note: "the legacy BigQuery sink is deprecated; move to the Storage Write API (V2) sink, which names tables and types columns differently"},
+	"io.confluent.connect.gcs.GcsSinkConnector":                        {Plugin: "GcsSink", Availability: AvailabilitySupported},
+	"io.confluent.connect.azure.blob.AzureBlobStorageSinkConnector":    {Plugin: "AzureBlobSink", Availability: AvailabilitySupported},
+	"io.confluent.kafka.connect.datagen.DatagenConnector":              {Plugin: "DatagenSource", Availability: AvailabilitySupported},
+	"io.lenses.streamreactor.connect.aws.s3.sink.S3SinkConnector":      {Plugin: "S3_SINK", Availability: AvailabilityPartial, Note: "Lenses' KCQL configuration has no fully-managed equivalent; rewrite it as S3 sink properties"},
+	"com.amazon.kinesis.kafka.AmazonKinesisSinkConnector":              {Availability: AvailabilitySelfManaged, Note: "there is no fully-managed Kinesis sink"},
+	"org.apache.kafka.connect.mirror.MirrorSourceConnector":            {Availability: AvailabilitySelfManaged, Note: "replace MirrorMaker 2 with Cluster Linking rather than a connector"},
+	"org.apache.kafka.connect.mirror.MirrorCheckpointConnector":        {Availability: AvailabilitySelfManaged, Note: "replace MirrorMaker 2 with Cluster Linking rather than a connector"},
+	"org.apache.kafka.connect.mirror.MirrorHeartbeatConnector":         {Availability: AvailabilitySelfManaged, Note: "replace MirrorMaker 2 with Cluster Linking rather than a connector"},
+	"org.apache.kafka.connect.file.FileStreamSourceConnector":          {Availability: AvailabilitySelfManaged, Note: "the FileStream example connectors read and write local files and are not shipped on the Connect classpath since Apache Kafka 3.2"},
+	"org.apache.kafka.connect.file.FileStreamSinkConnector":            {Availability: AvailabilitySelfManaged, Note: "the FileStream example connectors read and write local files and are not shipped on the Connect classpath since Apache Kafka 3.2"},
+}
+
+// Classify returns the catalog entry of connectorClass. Unknown and empty
+// classes are self-managed-only.
+func Classify(connectorClass string) CatalogEntry {
+	entry, ok := catalog[connectorClass]
+	if !ok {
+		note := "not in the fully-managed catalog; run it on a self-managed Connect cluster or as a custom connector"
+		if connectorClass == "" {
+			note = "the connector config has no connector.class"
+		}
+		return CatalogEntry{Class: connectorClass, Availability: AvailabilitySelfManaged, Note: note}
+	}
+	entry.Class = connectorClass
+	return entry
+}
@@ -0,0 +1,37 @@
+package connector_mapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify_CatalogedClass(t *testing.T) {
+	entry := Classify("com.wepay.kafka.connect.bigquery.BigQuerySinkConnector")
+
+	assert.Equal(t, AvailabilityPartial, entry.Availability)
+	assert.Equal(t, "BigQueryStorageSink", entry.Plugin)
+	assert.Equal(t, "com.wepay.kafka.connect.bigquery.BigQuerySinkConnector", entry.Class)
+	assert.Contains(t, entry.Note, "deprecated")
+}
+
+func TestClassify_UnknownAndMissingClassesAreSelfManaged(t *testing.T) {
+	unknown := Classify("com.example.CustomSourceConnector")
+	assert.Equal(t, AvailabilitySelfManaged, unknown.Availability)
+	assert.Contains(t, unknown.Note, "not in the fully-managed catalog")
+
+	missing := Classify("")
+	assert.Equal(t, AvailabilitySelfManaged, missing.Availability)
+	assert.Contains(t, missing.Note, "no connector.class")
+}
+
+// Every class the translation table maps must have a fully-managed plugin
+// in the catalog too, or the compatibility report and the plan disagree.
+func TestCatalog_CoversTranslationTable(t *testing.T) {
+	for class := range classMappings {
+		entry, ok := catalog[class]
+		if assert.True(t, ok, class) {
+			assert.NotEqual(t, AvailabilitySelfManaged, entry.Availability, class)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package connector_mapping
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/services/markdown"
+)
+
+// CompatibilityReport renders the per-connector translation outcome as a
+// markdown document. Connectors are listed in the order given.
+func CompatibilityReport(results []Result) *markdown.Markdown {
+	md := markdown.New()
+	md.AddHeading("Connector Compatibility Report", 1)
+	md.AddParagraph(fmt.Sprintf("*Generated by kcp (version: %s, commit: %s, built: %s)*",
+		build_info.Version,
+		build_info.Commit,
+		build_info.Date))
+
+	counts := map[Compatibility]int{}
+	for _, r := range results {
+		counts[r.Compatibility]++
+	}
+
+	md.AddHeading("Summary", 2)
+	md.AddTable([]string{"Compatibility", "Connectors"}, [][]string{
+		{"Full", fmt.Sprintf("%d", counts[CompatibilityFull])},
+		{"Partial (manual review required)", fmt.Sprintf("%d", counts[CompatibilityPartial])},
+		{"None (no fully-managed equivalent)", fmt.Sprintf("%d", counts[CompatibilityNone])},
+		{"Failed (no Terraform generated)", fmt.Sprintf("%d", counts[CompatibilityFailed])},
+	})
+
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		plugin := r.PluginName
+		if plugin == "" {
+			plugin = "-"
+		}
+		rows = append(rows, []string{r.ConnectorName, r.ConnectorClass, plugin, string(r.Compatibility)})
+	}
+	md.AddHeading("Connectors", 2)
+	md.AddTable([]string{"Connector", "Source Class", "Fully-Managed Plugin", "Compatibility"}, rows)
+
+	for _, r := range results {
+		if len(r.Unmapped) == 0 && r.Error == "" {
+			continue
+		}
+		md.AddHeading(r.ConnectorName, 3)
+		if r.Error != "" {
+			md.AddParagraph(fmt.Sprintf("Translation failed: %s", r.Error))
+			continue
+		}
+		unmapped := make([][]string, 0, len(r.Unmapped))
+		for _, u := range r.Unmapped {
+			unmapped = append(unmapped, []string{fmt.Sprintf("`%s`", u.Key), u.Reason})
+		}
+		md.AddTable([]string{"Property", "Reason"}, unmapped)
+	}
+
+	return md
+}
@@ -0,0 +1,441 @@
+package connector_mapping
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Compatibility grades how faithfully a self-managed connector config can be
+// expressed as a Confluent Cloud fully-managed connector.
+type Compatibility string
+
+const (
+	// CompatibilityFull means every source property was mapped (or is safely
+	// dropped because Confluent Cloud manages it).
+	CompatibilityFull Compatibility = "full"
+	// CompatibilityPartial means the connector class is supported but one or
+	// more properties have no fully-managed equivalent and need manual review.
+	CompatibilityPartial Compatibility = "partial"
+	// CompatibilityNone means there is no fully-managed equivalent for the
+	// connector class at all.
+	CompatibilityNone Compatibility = "none"
+	// CompatibilityFailed means translation failed and no Terraform was
+	// generated for the connector; Result.Error says why.
+	CompatibilityFailed Compatibility = "failed"
+)
+
+// UnmappedProperty is a source property that could not be carried over.
+type UnmappedProperty struct {
+	Key    string
+	Reason string
+}
+
+// Result is the translation of one connector config.
+type Result struct {
+	ConnectorName  string
+	ConnectorClass string
+	PluginName     string
+	Compatibility  Compatibility
+	Config         map[string]string
+	Unmapped       []UnmappedProperty
+	Error          string
+}
+
+// classMapping describes how one self-managed connector class maps onto a
+// fully-managed plugin. properties renames source keys to target keys (an empty
+// target keeps the source key as-is); unsupported lists keys that have no
+// fully-managed equivalent, with the reason surfaced in the compatibility report.
+type classMapping struct {
+	pluginName  string
+	sink        bool
+	properties  map[string]string
+	unsupported map[string]string
+	// resolve picks the plugin from the config when one class fans out to
+	// several fully-managed plugins (e.g. the generic JDBC connectors).
+	resolve func(config map[string]string) (string, bool)
+}
+
+// frameworkProperties are Kafka Connect worker/framework keys that Confluent
+// Cloud manages itself; they are dropped without being reported as gaps.
+var frameworkProperties = map[string]bool{
+	"connector.class":                     true,
+	"name":                                true,
+	"key.converter.schemas.enable":        true,
+	"value.converter.schemas.enable":      true,
+	"errors.log.enable":                   true,
+	"errors.log.include.messages":         true,
+	"consumer.override.bootstrap.servers": true,
+	"producer.override.bootstrap.servers": true,
+}
+
+// converterFormats maps converter classes onto the fully-managed data format names.
+var converterFormats = map[string]string{
+	"io.confluent.connect.avro.AvroConverter":                "AVRO",
+	"io.confluent.connect.protobuf.ProtobufConverter":        "PROTOBUF",
+	"io.confluent.connect.json.JsonSchemaConverter":          "JSON_SR",
+	"org.apache.kafka.connect.json.JsonConverter":            "JSON",
+	"org.apache.kafka.connect.storage.StringConverter":       "STRING",
+	"org.apache.kafka.connect.converters.ByteArrayConverter": "BYTES",
+}
+
+// s3FormatClasses maps the S3 sink's format.class onto the fully-managed output.data.format.
+var s3FormatClasses = map[string]string{
+	"io.confluent.connect.s3.format.avro.AvroFormat":           "AVRO",
+	"io.confluent.connect.s3.format.json.JsonFormat":           "JSON",
+	"io.confluent.connect.s3.format.parquet.ParquetFormat":     "PARQUET",
+	"io.confluent.connect.s3.format.bytearray.ByteArrayFormat": "BYTES",
+}
+
+var debeziumProperties = map[string]string{
+	"database.hostname":     "",
+	"database.port":         "",
+	"database.user":         "",
+	"database.password":     "",
+	"database.dbname":       "",
+	"database.names":        "",
+	"database.server.id":    "",
+	"database.sslmode":      "",
+	"database.include.list": "",
+	"database.exclude.list": "",
+	"database.server.name":  "topic.prefix",
+	"topic.prefix":          "",
+	"table.include.list":    "",
+	"table.exclude.list":    "",
+	"table.whitelist":       "table.include.list",
+	"table.blacklist":       "table.exclude.list",
+	"column.exclude.list":   "",
+	"snapshot.mode":         "",
+	"slot.name":             "",
+	"plugin.name":           "",
+	"publication.name":      "",
+	"decimal.handling.mode": "",
+	"time.precision.mode":   "",
+	"tombstones.on.delete":  "",
+	"heartbeat.interval.ms": "",
+	"tasks.max":             "",
+	"transforms":            "",
+	"topics.include":        "",
+}
+
+var debeziumUnsupported = map[string]string{
+	"database.history.kafka.bootstrap.servers":        "schema history is stored in the managed cluster; no bootstrap override is possible",
+	"database.history.kafka.topic":                    "schema history topic is managed by Confluent Cloud",
+	"schema.history.internal.kafka.bootstrap.servers": "schema history is stored in the managed cluster; no bootstrap override is possible",
+	"schema.history.internal.kafka.topic":             "schema history topic is managed by Confluent Cloud",
+	"database.history.producer.security.protocol":     "client security for the history topic is managed by Confluent Cloud",
+	"database.history.consumer.security.protocol":     "client security for the history topic is managed by Confluent Cloud",
+	"database.history.producer.sasl.mechanism":        "client security for the history topic is managed by Confluent Cloud",
+	"database.history.consumer.sasl.mechanism":        "client security for the history topic is managed by Confluent Cloud",
+	"database.history.producer.sasl.jaas.config":      "client security for the history topic is managed by Confluent Cloud",
+	"database.history.consumer.sasl.jaas.config":      "client security for the history topic is managed by Confluent Cloud",
+}
+
+var jdbcSourceProperties = map[string]string{
+	"connection.user":          "",
+	"connection.password":      "",
+	"table.whitelist":          "table.include.list",
+	"table.blacklist":          "table.exclude.list",
+	"table.include.list":       "",
+	"table.exclude.list":       "",
+	"mode":                     "",
+	"incrementing.column.name": "",
+	"timestamp.column.name":    "",
+	"topic.prefix":             "",
+	"poll.interval.ms":         "",
+	"batch.max.rows":           "",
+	"db.timezone":              "",
+	"numeric.mapping":          "",
+	"query":                    "",
+	"tasks.max":                "",
+	"transforms":               "",
+}
+
+var jdbcSinkProperties = map[string]string{
+	"connection.user":     "",
+	"connection.password": "",
+	"topics":              "",
+	"topics.regex":        "",
+	"insert.mode":         "",
+	"pk.mode":             "",
+	"pk.fields":           "",
+	"auto.create":         "",
+	"auto.evolve":         "",
+	"table.name.format":   "",
+	"batch.size":          "",
+	"delete.enabled":      "",
+	"db.timezone":         "",
+	"tasks.max":           "",
+	"transforms":          "",
+}
+
+var jdbcUnsupported = map[string]string{
+	"dialect.name":          "the fully-managed plugin is database specific; the dialect is implied by the plugin",
+	"connection.attempts":   "connection retries are managed by Confluent Cloud",
+	"connection.backoff.ms": "connection retries are managed by Confluent Cloud",
+}
+
+// jdbcSourcePlugins and jdbcSinkPlugins pick the fully-managed plugin from the
+// JDBC URL scheme; the prefixes are disjoint so order does not matter.
+var jdbcSourcePlugins = []struct{ scheme, plugin string }{
+	{"jdbc:mysql:", "MySqlSource"},
+	{"jdbc:postgresql:", "PostgresSource"},
+	{"jdbc:sqlserver:", "MicrosoftSqlServerSource"},
+	{"jdbc:oracle:", "OracleDatabaseSource"},
+}
+
+var jdbcSinkPlugins = []struct{ scheme, plugin string }{
+	{"jdbc:mysql:", "MySqlSink"},
+	{"jdbc:postgresql:", "PostgresSink"},
+	{"jdbc:sqlserver:", "MicrosoftSqlServerSink"},
+	{"jdbc:oracle:", "OracleDatabaseSink"},
+}
+
+func resolveJdbcPlugin(plugins []struct{ scheme, plugin string }) func(map[string]string) (string, bool) {
+	return func(config map[string]string) (string, bool) {
+		url := strings.ToLower(config["connection.url"])
+		for _, p := range plugins {
+			if strings.HasPrefix(url, p.scheme) {
+				return p.plugin, true
+			}
+		}
+		return "", false
+	}
+}
+
+// classMappings is the translation table. Keep entries limited to connectors
+// whose property mapping has been checked against the fully-managed docs;
+// anything else falls back to the translate/config API.
+var classMappings = map[string]classMapping{
+	"io.confluent.connect.s3.S3SinkConnector": {
+		pluginName: "S3_SINK",
+		sink:       true,
+		properties: map[string]string{
+			"topics":                      "",
+			"topics.regex":                "",
+			"s3.bucket.name":              "",
+			"s3.region":                   "",
+			"flush.size":                  "",
+			"rotate.interval.ms":          "",
+			"rotate.schedule.interval.ms": "",
+			"topics.dir":                  "",
+			"path.format":                 "",
+			"partition.duration.ms":       "",
+			"timestamp.field":             "",
+			"locale":                      "",
+			"timezone":                    "",
+			"s3.compression.type":         "compression.codec",
+			"tasks.max":                   "",
+			"transforms":                  "",
+		},
+		unsupported: map[string]string{
+			"storage.class":                 "storage is always S3 for the fully-managed sink",
+			"s3.credentials.provider.class": "use an IAM role (provider integration) or access keys on the fully-managed connector",
+			"partitioner.class":             "only time-based and default partitioning are available; set time.interval instead",
+			"s3.sse.customer.key":           "SSE-C is not supported; use SSE-KMS",
+			"s3.part.size":                  "part size is managed by Confluent Cloud",
+		},
+	},
+	"io.debezium.connector.mysql.MySqlConnector": {
+		pluginName:  "MySqlCdcSourceV2",
+		properties:  debeziumProperties,
+		unsupported: debeziumUnsupported,
+	},
+	"io.debezium.connector.postgresql.PostgresConnector": {
+		pluginName:  "PostgresCdcSourceV2",
+		properties:  debeziumProperties,
+		unsupported: debeziumUnsupported,
+	},
+	"io.debezium.connector.sqlserver.SqlServerConnector": {
+		pluginName:  "SqlServerCdcSourceV2",
+		properties:  debeziumProperties,
+		unsupported: debeziumUnsupported,
+	},
+	"io.confluent.connect.jdbc.JdbcSourceConnector": {
+		properties:  jdbcSourceProperties,
+		unsupported: jdbcUnsupported,
+		resolve:     resolveJdbcPlugin(jdbcSourcePlugins),
+	},
+	"io.confluent.connect.jdbc.JdbcSinkConnector": {
+		sink:        true,
+		properties:  jdbcSinkProperties,
+		unsupported: jdbcUnsupported,
+		resolve:     resolveJdbcPlugin(jdbcSinkPlugins),
+	},
+}
+
+// IsSupported reports whether the local translation table knows the connector class.
+func IsSupported(connectorClass string) bool {
+	_, ok := classMappings[connectorClass]
+	return ok
+}
+
+// Translate maps a self-managed connector config onto the fully-managed plugin
+// config using the local translation table. It never errors: anything it cannot
+// map is recorded in Result.Unmapped and reflected in Result.Compatibility.
+func Translate(connectorName string, config map[string]string) Result {
+	connectorClass := config["connector.class"]
+	result := Result{
+		ConnectorName:  connectorName,
+		ConnectorClass: connectorClass,
+		Config:         map[string]string{},
+	}
+
+	mapping, ok := classMappings[connectorClass]
+	if !ok {
+		result.Compatibility = CompatibilityNone
+		result.Unmapped = append(result.Unmapped, UnmappedProperty{
+			Key:    "connector.class",
+			Reason: fmt.Sprintf("no fully-managed equivalent is known for %q", connectorClass),
+		})
+		return result
+	}
+
+	result.PluginName = mapping.pluginName
+	if mapping.resolve != nil {
+		plugin, ok := mapping.resolve(config)
+		if !ok {
+			result.Compatibility = CompatibilityNone
+			result.Unmapped = append(result.Unmapped, UnmappedProperty{
+				Key:    "connection.url",
+				Reason: "the JDBC URL does not identify a database with a fully-managed connector",
+			})
+			return result
+		}
+		result.PluginName = plugin
+	}
+	result.Config["connector.class"] = result.PluginName
+	result.Config["name"] = connectorName
+
+	for _, key := range sortedKeys(config) {
+		value := config[key]
+		if frameworkProperties[key] {
+			continue
+		}
+		if target, ok := mapping.properties[key]; ok {
+			if target == "" {
+				target = key
+			}
+			result.Config[target] = value
+			continue
+		}
+		if reason, ok := mapping.unsupported[key]; ok {
+			result.Unmapped = append(result.Unmapped, UnmappedProperty{Key: key, Reason: reason})
+			continue
+		}
+		if translateCommon(key, value, mapping.sink, result.Config) {
+			continue
+		}
+		if strings.HasPrefix(key, "transforms.") {
+			// SMT definitions carry over verbatim; Confluent Cloud supports the
+			// common Apache Kafka SMTs under the same property names.
+			result.Config[key] = value
+			continue
+		}
+		result.Unmapped = append(result.Unmapped, UnmappedProperty{
+			Key:    key,
+			Reason: "no fully-managed equivalent in the translation table",
+		})
+	}
+
+	if mapping.resolve != nil {
+		if host, port, db, ok := parseJdbcURL(config["connection.url"]); ok {
+			result.Config["connection.host"] = host
+			if port != "" {
+				result.Config["connection.port"] = port
+			}
+			if db != "" {
+				result.Config["db.name"] = db
+			}
+		} else {
+			result.Unmapped = append(result.Unmapped, UnmappedProperty{
+				Key:    "connection.url",
+				Reason: "could not split the JDBC URL into host/port/database",
+			})
+		}
+	}
+
+	result.Compatibility = CompatibilityFull
+	if len(result.Unmapped) > 0 {
+		result.Compatibility = CompatibilityPartial
+	}
+	return result
+}
+
+// translateCommon handles keys that map the same way regardless of connector
+// class: converters become fully-managed data formats (input.* for sinks,
+// output.* for sources), and the S3 sink's format.class becomes
+// output.data.format. It reports whether the key was consumed.
+func translateCommon(key, value string, sink bool, out map[string]string) bool {
+	direction := "output"
+	if sink {
+		direction = "input"
+	}
+	switch key {
+	case "key.converter", "value.converter":
+		format, ok := converterFormats[value]
+		if !ok {
+			return false
+		}
+		if key == "key.converter" {
+			out[direction+".key.format"] = format
+		} else {
+			out[direction+".data.format"] = format
+		}
+		return true
+	case "format.class":
+		format, ok := s3FormatClasses[value]
+		if !ok {
+			return false
+		}
+		out["output.data.format"] = format
+		return true
+	case "connection.url":
+		// Split into connection.host/connection.port/db.name after the main loop.
+		return true
+	}
+	// Schema Registry wiring is implicit on the fully-managed connector.
+	return (strings.HasPrefix(key, "key.converter.") || strings.HasPrefix(key, "value.converter.")) &&
+		strings.Contains(key, "schema.registry")
+}
+
+// parseJdbcURL extracts host, port and database from the common JDBC URL forms
+// (jdbc:mysql://h:3306/db, jdbc:postgresql://h:5432/db,
+// jdbc:sqlserver://h:1433;databaseName=db).
+func parseJdbcURL(url string) (host, port, db string, ok bool) {
+	idx := strings.Index(url, "//")
+	if idx < 0 {
+		return "", "", "", false
+	}
+	rest := url[idx+2:]
+	if q := strings.IndexAny(rest, "?"); q >= 0 {
+		rest = rest[:q]
+	}
+	var params string
+	if semi := strings.Index(rest, ";"); semi >= 0 {
+		rest, params = rest[:semi], rest[semi+1:]
+	}
+	hostPort := rest
+	if slash := strings.Index(rest, "/"); slash >= 0 {
+		hostPort, db = rest[:slash], rest[slash+1:]
+	}
+	host = hostPort
+	if colon := strings.LastIndex(hostPort, ":"); colon >= 0 {
+		host, port = hostPort[:colon], hostPort[colon+1:]
+	}
+	for _, p := range strings.Split(params, ";") {
+		if k, v, found := strings.Cut(p, "="); found && strings.EqualFold(k, "databaseName") {
+			db = v
+		}
+	}
+	return host, port, db, host != ""
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
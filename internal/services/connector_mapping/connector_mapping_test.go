@@ -0,0 +1,105 @@
+package connector_mapping
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate_S3SinkFullyMapped(t *testing.T) {
+	result := Translate("orders-archive", map[string]string{
+		"connector.class":                "io.confluent.connect.s3.S3SinkConnector",
+		"topics":                         "orders",
+		"s3.bucket.name":                 "archive",
+		"s3.region":                      "us-east-1",
+		"flush.size":                     "1000",
+		"format.class":                   "io.confluent.connect.s3.format.json.JsonFormat",
+		"value.converter":                "org.apache.kafka.connect.json.JsonConverter",
+		"value.converter.schemas.enable": "false",
+		"tasks.max":                      "2",
+	})
+
+	assert.Equal(t, CompatibilityFull, result.Compatibility)
+	assert.Equal(t, "S3_SINK", result.PluginName)
+	assert.Equal(t, "S3_SINK", result.Config["connector.class"])
+	assert.Equal(t, "orders-archive", result.Config["name"])
+	assert.Equal(t, "JSON", result.Config["output.data.format"])
+	assert.Equal(t, "JSON", result.Config["input.data.format"])
+	assert.Equal(t, "archive", result.Config["s3.bucket.name"])
+	assert.Empty(t, result.Unmapped)
+}
+
+func TestTranslate_DebeziumReportsHistoryTopicAsUnmapped(t *testing.T) {
+	result := Translate("cdc", map[string]string{
+		"connector.class":                          "io.debezium.connector.mysql.MySqlConnector",
+		"database.hostname":                        "db.internal",
+		"database.server.name":                     "inventory",
+		"table.whitelist":                          "inventory.orders",
+		"database.history.kafka.bootstrap.servers": "b-1.msk:9092",
+	})
+
+	assert.Equal(t, CompatibilityPartial, result.Compatibility)
+	assert.Equal(t, "MySqlCdcSourceV2", result.PluginName)
+	assert.Equal(t, "inventory", result.Config["topic.prefix"])
+	assert.Equal(t, "inventory.orders", result.Config["table.include.list"])
+	if assert.Len(t, result.Unmapped, 1) {
+		assert.Equal(t, "database.history.kafka.bootstrap.servers", result.Unmapped[0].Key)
+	}
+}
+
+func TestTranslate_JdbcResolvesPluginFromURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		class      string
+		url        string
+		wantPlugin string
+		wantHost   string
+		wantPort   string
+		wantDB     string
+	}{
+		{"postgres source", "io.confluent.connect.jdbc.JdbcSourceConnector", "jdbc:postgresql://pg.internal:5432/shop", "PostgresSource", "pg.internal", "5432", "shop"},
+		{"mysql sink", "io.confluent.connect.jdbc.JdbcSinkConnector", "jdbc:mysql://my.internal:3306/shop?useSSL=true", "MySqlSink", "my.internal", "3306", "shop"},
+		{"sqlserver sink", "io.confluent.connect.jdbc.JdbcSinkConnector", "jdbc:sqlserver://ms.internal:1433;databaseName=shop", "MicrosoftSqlServerSink", "ms.internal", "1433", "shop"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Translate("jdbc", map[string]string{
+				"connector.class": tt.class,
+				"connection.url":  tt.url,
+			})
+			assert.Equal(t, CompatibilityFull, result.Compatibility)
+			assert.Equal(t, tt.wantPlugin, result.PluginName)
+			assert.Equal(t, tt.wantHost, result.Config["connection.host"])
+			assert.Equal(t, tt.wantPort, result.Config["connection.port"])
+			assert.Equal(t, tt.wantDB, result.Config["db.name"])
+		})
+	}
+}
+
+func TestTranslate_UnknownDatabaseAndClass(t *testing.T) {
+	jdbc := Translate("db2", map[string]string{
+		"connector.class": "io.confluent.connect.jdbc.JdbcSourceConnector",
+		"connection.url":  "jdbc:db2://db2.internal:50000/shop",
+	})
+	assert.Equal(t, CompatibilityNone, jdbc.Compatibility)
+
+	custom := Translate("custom", map[string]string{"connector.class": "com.example.CustomConnector"})
+	assert.Equal(t, CompatibilityNone, custom.Compatibility)
+	assert.False(t, IsSupported("com.example.CustomConnector"))
+}
+
+func TestCompatibilityReport_ListsUnmappedProperties(t *testing.T) {
+	results := []Result{
+		Translate("cdc", map[string]string{
+			"connector.class":              "io.debezium.connector.postgresql.PostgresConnector",
+			"database.history.kafka.topic": "history",
+		}),
+		Translate("custom", map[string]string{"connector.class": "com.example.CustomConnector"}),
+	}
+
+	report := CompatibilityReport(results).String()
+	for _, want := range []string{"Connector Compatibility Report", "PostgresCdcSourceV2", "`database.history.kafka.topic`", "com.example.CustomConnector", "none"} {
+		assert.True(t, strings.Contains(report, want), "report missing %q", want)
+	}
+}
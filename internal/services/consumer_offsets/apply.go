@@ -0,0 +1,140 @@
+package consumer_offsets
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/audit"
+	"github.com/confluentinc/kcp/internal/build_info"
+)
+
+// CoordinatorClient is the subset of sarama.Client used to commit offsets.
+type CoordinatorClient interface {
+	Coordinator(consumerGroup string) (*sarama.Broker, error)
+}
+
+// Commit writes the translated offsets to the destination cluster, one
+// OffsetCommit request per group sent to that group's coordinator. Skipped
+// entries are ignored. The commit is a standalone (generation -1) commit, so
+// the broker rejects it while the group has active members: consumers must
+// be stopped on the destination before offsets are replayed.
+func Commit(client CoordinatorClient, translated []TranslatedOffset) error {
+	byGroup := groupTranslated(translated)
+
+	groupIDs := make([]string, 0, len(byGroup))
+	for groupID := range byGroup {
+		groupIDs = append(groupIDs, groupID)
+	}
+	slices.Sort(groupIDs)
+
+	for _, groupID := range groupIDs {
+		offsets := byGroup[groupID]
+
+		coordinator, err := client.Coordinator(groupID)
+		if err != nil {
+			return fmt.Errorf("failed to find coordinator for consumer group %q: %w", groupID, err)
+		}
+
+		request := &sarama.OffsetCommitRequest{
+			Version:                 2,
+			ConsumerGroup:           groupID,
+			ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+			RetentionTime:           -1,
+		}
+		for _, o := range offsets {
+			request.AddBlock(o.DestinationTopic, o.Partition, o.DestinationOffset, sarama.ReceiveTime, "")
+		}
+
+		start := time.Now()
+		response, err := coordinator.CommitOffset(request)
+		audit.Record(audit.Entry{
+			System:    audit.SystemKafka,
+			Operation: "OffsetCommit",
+			Target:    coordinator.Addr(),
+		}, map[string]any{"group_id": groupID, "partitions": len(offsets)}, start, err)
+		if err != nil {
+			return fmt.Errorf("failed to commit offsets for consumer group %q: %w", groupID, err)
+		}
+		for topic, partitions := range response.Errors {
+			for partition, kerr := range partitions {
+				if kerr != sarama.ErrNoError {
+					return fmt.Errorf("failed to commit offset for consumer group %q on %s/%d: %w", groupID, topic, partition, kerr)
+				}
+			}
+		}
+
+		slog.Debug("committed consumer group offsets", "group", groupID, "partitions", len(offsets))
+	}
+
+	return nil
+}
+
+// groupTranslated buckets the non-skipped offsets by consumer group.
+func groupTranslated(translated []TranslatedOffset) map[string][]TranslatedOffset {
+	byGroup := make(map[string][]TranslatedOffset)
+	for _, t := range translated {
+		if t.Skipped {
+			continue
+		}
+		byGroup[t.GroupID] = append(byGroup[t.GroupID], t)
+	}
+	return byGroup
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// GenerateScript renders a kafka-consumer-groups reset script plus one
+// topic,partition,offset CSV per group (the --from-file format), keyed by
+// path relative to the output directory.
+func GenerateScript(translated []TranslatedOffset, bootstrap string) map[string]string {
+	byGroup := groupTranslated(translated)
+
+	groupIDs := make([]string, 0, len(byGroup))
+	for groupID := range byGroup {
+		groupIDs = append(groupIDs, groupID)
+	}
+	slices.Sort(groupIDs)
+
+	files := make(map[string]string, len(groupIDs)+1)
+
+	var script strings.Builder
+	script.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&script, "# Generated by kcp (version: %s, commit: %s).\n", build_info.Version, build_info.Commit)
+	script.WriteString("# Replays consumer group offsets translated from the source cluster.\n")
+	script.WriteString("# Stop all consumers in these groups on the destination cluster before running.\n")
+	script.WriteString("set -euo pipefail\n\n")
+	// An assignment is not word-split, so the default can stay single-quoted
+	// inside the unquoted expansion.
+	fmt.Fprintf(&script, "BOOTSTRAP_SERVER=${BOOTSTRAP_SERVER:-%s}\n", shellQuote(bootstrap))
+	script.WriteString("COMMAND_CONFIG=\"${COMMAND_CONFIG:-client.properties}\"\n")
+	script.WriteString("# Set EXECUTE=--dry-run to preview the reset without applying it.\n")
+	script.WriteString("EXECUTE=\"${EXECUTE:---execute}\"\n\n")
+	script.WriteString("cd \"$(dirname \"$0\")\"\n")
+
+	for i, groupID := range groupIDs {
+		csvPath := fmt.Sprintf("offsets/%03d-%s.csv", i+1, unsafeFileChars.ReplaceAllString(groupID, "_"))
+
+		var csv strings.Builder
+		for _, o := range byGroup[groupID] {
+			fmt.Fprintf(&csv, "%s,%d,%d\n", o.DestinationTopic, o.Partition, o.DestinationOffset)
+		}
+		files[csvPath] = csv.String()
+
+		fmt.Fprintf(&script, "\necho \"Resetting offsets for consumer group:\" %s\n", shellQuote(groupID))
+		script.WriteString("kafka-consumer-groups --bootstrap-server \"$BOOTSTRAP_SERVER\" --command-config \"$COMMAND_CONFIG\" \\\n")
+		fmt.Fprintf(&script, "  --group %s --reset-offsets --from-file %s \"$EXECUTE\"\n", shellQuote(groupID), csvPath)
+	}
+
+	files["reset-consumer-offsets.sh"] = script.String()
+	return files
+}
+
+// shellQuote wraps s in single quotes for bash.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
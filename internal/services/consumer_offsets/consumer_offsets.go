@@ -0,0 +1,225 @@
+package consumer_offsets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/confluentinc/kcp/internal/audit"
+	"github.com/confluentinc/kcp/internal/summary"
+)
+
+// recordTimestampTimeout bounds the single-record fetch used to look up the
+// timestamp of the last consumed record of a partition.
+const recordTimestampTimeout = 10 * time.Second
+
+// Export is the on-disk snapshot of committed consumer group offsets taken
+// from the source cluster.
+type Export struct {
+	SourceBootstrap string         `json:"source_bootstrap"`
+	ExportedAt      time.Time      `json:"exported_at"`
+	Groups          []GroupOffsets `json:"groups"`
+}
+
+type GroupOffsets struct {
+	GroupID    string            `json:"group_id"`
+	State      string            `json:"state"`
+	Partitions []PartitionOffset `json:"partitions"`
+}
+
+// PartitionOffset is a committed offset. LastConsumedTimestamp is the
+// timestamp of the record just before the committed offset (the last record
+// the group processed); it is nil when that record no longer exists on the
+// source or the group has not consumed anything yet.
+type PartitionOffset struct {
+	Topic                 string     `json:"topic"`
+	Partition             int32      `json:"partition"`
+	Offset                int64      `json:"offset"`
+	Metadata              string     `json:"metadata,omitempty"`
+	LastConsumedTimestamp *time.Time `json:"last_consumed_timestamp,omitempty"`
+}
+
+// Exporter reads committed consumer group offsets from a Kafka cluster.
+type Exporter struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+}
+
+// NewExporter creates an Exporter on top of an existing client. Closing the
+// exporter does not close the client.
+func NewExporter(client sarama.Client) (*Exporter, error) {
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster admin: %w", err)
+	}
+	return &Exporter{client: client, admin: admin}, nil
+}
+
+// Export snapshots the committed offsets of every consumer group, or only of
+// the given groups when groupIDs is non-empty. Groups are sorted by ID and
+// partitions by topic then partition so exports diff cleanly.
+func (e *Exporter) Export(ctx context.Context, groupIDs []string) (*Export, error) {
+	if len(groupIDs) == 0 {
+		start := time.Now()
+		listed, err := e.admin.ListConsumerGroups()
+		e.record("ListConsumerGroups", nil, start, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list consumer groups: %w", err)
+		}
+		for groupID := range listed {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	slices.Sort(groupIDs)
+
+	states := make(map[string]string, len(groupIDs))
+	if len(groupIDs) > 0 {
+		start := time.Now()
+		descriptions, err := e.admin.DescribeConsumerGroups(groupIDs)
+		e.record("DescribeConsumerGroups", map[string][]string{"group_ids": groupIDs}, start, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe consumer groups: %w", err)
+		}
+		for _, d := range descriptions {
+			states[d.GroupId] = d.State
+		}
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(e.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer func() { _ = consumer.Close() }()
+
+	export := &Export{
+		ExportedAt: time.Now().UTC(),
+		Groups:     make([]GroupOffsets, 0, len(groupIDs)),
+	}
+	for _, groupID := range groupIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		response, err := e.admin.ListConsumerGroupOffsets(groupID, nil)
+		e.record("ListConsumerGroupOffsets", map[string]string{"group_id": groupID}, start, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list offsets for consumer group %q: %w", groupID, err)
+		}
+
+		group := GroupOffsets{GroupID: groupID, State: states[groupID]}
+		for topic, blocks := range response.Blocks {
+			for partition, block := range blocks {
+				if block.Err != sarama.ErrNoError {
+					return nil, fmt.Errorf("offset error for consumer group %q on %s/%d: %v", groupID, topic, partition, block.Err)
+				}
+				// -1 means the group has no committed offset for this partition.
+				if block.Offset < 0 {
+					continue
+				}
+				group.Partitions = append(group.Partitions, PartitionOffset{
+					Topic:                 topic,
+					Partition:             partition,
+					Offset:                block.Offset,
+					Metadata:              block.Metadata,
+					LastConsumedTimestamp: lastConsumedTimestamp(consumer, topic, partition, block.Offset),
+				})
+			}
+		}
+		sortPartitions(group.Partitions)
+
+		slog.Debug("exported consumer group offsets", "group", groupID, "partitions", len(group.Partitions))
+		export.Groups = append(export.Groups, group)
+	}
+
+	return export, nil
+}
+
+// record writes a group-level admin request to the audit log. Per-partition
+// record fetches are not recorded: they are reads of data the offsets already
+// describe and would dwarf the log on large clusters.
+func (e *Exporter) record(operation string, params any, start time.Time, err error) {
+	if !audit.Enabled() {
+		return
+	}
+	addrs := make([]string, 0, len(e.client.Brokers()))
+	for _, broker := range e.client.Brokers() {
+		addrs = append(addrs, broker.Addr())
+	}
+	audit.Record(audit.Entry{
+		System:    audit.SystemKafka,
+		Operation: operation,
+		Target:    strings.Join(addrs, ","),
+	}, params, start, err)
+}
+
+// lastConsumedTimestamp fetches the record at offset-1 and returns its
+// timestamp. Failures are not fatal: the offset itself is still exported and
+// only timestamp-based translation loses its input.
+func lastConsumedTimestamp(consumer sarama.Consumer, topic string, partition int32, offset int64) *time.Time {
+	if offset <= 0 {
+		return nil
+	}
+
+	pc, err := consumer.ConsumePartition(topic, partition, offset-1)
+	if err != nil {
+		slog.Debug("could not fetch last consumed record", "topic", topic, "partition", partition, "offset", offset-1, "error", err)
+		return nil
+	}
+	defer func() { _ = pc.Close() }()
+
+	select {
+	case msg := <-pc.Messages():
+		ts := msg.Timestamp.UTC()
+		return &ts
+	case err := <-pc.Errors():
+		slog.Debug("could not fetch last consumed record", "topic", topic, "partition", partition, "offset", offset-1, "error", err)
+	case <-time.After(recordTimestampTimeout):
+		slog.Debug("timed out fetching last consumed record", "topic", topic, "partition", partition, "offset", offset-1)
+	}
+	return nil
+}
+
+func sortPartitions(partitions []PartitionOffset) {
+	slices.SortFunc(partitions, func(a, b PartitionOffset) int {
+		if c := strings.Compare(a.Topic, b.Topic); c != 0 {
+			return c
+		}
+		return int(a.Partition - b.Partition)
+	})
+}
+
+// WriteExport writes the export as indented JSON.
+func WriteExport(path string, export *Export) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal consumer offsets: %w", err)
+	}
+	if err := summary.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write consumer offsets file: %w", err)
+	}
+	return nil
+}
+
+// ReadExport loads an export previously written by WriteExport.
+func ReadExport(path string) (*Export, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read consumer offsets file: %w", err)
+	}
+	var export Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse consumer offsets file: %w", err)
+	}
+	if len(export.Groups) == 0 {
+		return nil, errors.New("consumer offsets file contains no consumer groups")
+	}
+	return &export, nil
+}
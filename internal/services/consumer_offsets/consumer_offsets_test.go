@@ -0,0 +1,210 @@
+package consumer_offsets
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLookup serves log end offsets and timestamp lookups per topic/partition.
+type fakeLookup struct {
+	logEnd map[string]map[int32]int64
+	byTime map[string]map[int32]int64
+}
+
+func (f *fakeLookup) GetOffset(topic string, partition int32, ts int64) (int64, error) {
+	source := f.byTime
+	if ts == sarama.OffsetNewest {
+		source = f.logEnd
+	}
+	offsets, ok := source[topic]
+	if !ok {
+		return 0, errors.New("unknown topic")
+	}
+	return offsets[partition], nil
+}
+
+func exportFixture() *Export {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &Export{
+		Groups: []GroupOffsets{
+			{GroupID: "billing", Partitions: []PartitionOffset{
+				{Topic: "orders", Partition: 0, Offset: 100, LastConsumedTimestamp: &ts},
+				{Topic: "orders", Partition: 1, Offset: 250, LastConsumedTimestamp: &ts},
+			}},
+			{GroupID: "audit", Partitions: []PartitionOffset{
+				{Topic: "legacy", Partition: 0, Offset: 7},
+			}},
+		},
+	}
+}
+
+func TestTranslate_OffsetModeClampsToLogEnd(t *testing.T) {
+	lookup := &fakeLookup{logEnd: map[string]map[int32]int64{
+		"msk-orders": {0: 120, 1: 200},
+	}}
+
+	translated := Translate(exportFixture(), lookup, TranslateOpts{Mode: TranslationOffset, TopicPrefix: "msk-"})
+	require.Len(t, translated, 3)
+
+	assert.Equal(t, "msk-orders", translated[0].DestinationTopic)
+	assert.Equal(t, int64(100), translated[0].DestinationOffset)
+	assert.Empty(t, translated[0].Note)
+
+	assert.Equal(t, int64(200), translated[1].DestinationOffset)
+	assert.Contains(t, translated[1].Note, "clamped")
+
+	// msk-legacy is not mirrored.
+	assert.True(t, translated[2].Skipped)
+	assert.Equal(t, 1, CountSkipped(translated))
+}
+
+func TestTranslate_TimestampMode(t *testing.T) {
+	lookup := &fakeLookup{
+		logEnd: map[string]map[int32]int64{"orders": {0: 500, 1: 500}, "legacy": {0: 10}},
+		byTime: map[string]map[int32]int64{"orders": {0: 340, 1: -1}, "legacy": {0: 3}},
+	}
+
+	translated := Translate(exportFixture(), lookup, TranslateOpts{Mode: TranslationTimestamp})
+	require.Len(t, translated, 3)
+
+	assert.Equal(t, int64(340), translated[0].DestinationOffset)
+	// No record at or after the timestamp: the group was caught up.
+	assert.Equal(t, int64(500), translated[1].DestinationOffset)
+	// No timestamp was exported for the audit group.
+	assert.True(t, translated[2].Skipped)
+}
+
+func TestParseTranslationMode(t *testing.T) {
+	mode, err := ParseTranslationMode("timestamp")
+	require.NoError(t, err)
+	assert.Equal(t, TranslationTimestamp, mode)
+
+	_, err = ParseTranslationMode("bogus")
+	assert.Error(t, err)
+}
+
+func TestGenerateScript(t *testing.T) {
+	translated := []TranslatedOffset{
+		{GroupID: "billing", DestinationTopic: "orders", Partition: 0, DestinationOffset: 100},
+		{GroupID: "billing", DestinationTopic: "orders", Partition: 1, DestinationOffset: 200},
+		{GroupID: "team's group", DestinationTopic: "events", Partition: 0, DestinationOffset: 5},
+		{GroupID: "skipped", DestinationTopic: "gone", Partition: 0, Skipped: true},
+	}
+
+	files := GenerateScript(translated, "pkc-abc.confluent.cloud:9092")
+
+	assert.Equal(t, "orders,0,100\norders,1,200\n", files["offsets/001-billing.csv"])
+	assert.Equal(t, "events,0,5\n", files["offsets/002-team_s_group.csv"])
+	assert.Len(t, files, 3)
+
+	script := files["reset-consumer-offsets.sh"]
+	assert.True(t, strings.HasPrefix(script, "#!/usr/bin/env bash\n"))
+	assert.Contains(t, script, `BOOTSTRAP_SERVER=${BOOTSTRAP_SERVER:-'pkc-abc.confluent.cloud:9092'}`)
+	assert.Contains(t, script, "--group 'billing' --reset-offsets --from-file offsets/001-billing.csv")
+	assert.Contains(t, script, `--group 'team'\''s group'`)
+	assert.NotContains(t, script, "skipped")
+}
+
+// The bootstrap comes from a flag, so the script must treat it as data: bash
+// evaluates the generated assignment to exactly the given value.
+func TestGenerateScript_QuotesBootstrap(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	for _, bootstrap := range []string{
+		"pkc-abc.confluent.cloud:9092",
+		`broker:9092"; touch pwned; echo "`,
+		"broker:9092$(touch pwned)",
+		"team's broker:9092 `touch pwned`",
+	} {
+		script := GenerateScript(nil, bootstrap)["reset-consumer-offsets.sh"]
+		var assignment string
+		for _, line := range strings.Split(script, "\n") {
+			if strings.HasPrefix(line, "BOOTSTRAP_SERVER=") {
+				assignment = line
+			}
+		}
+		require.NotEmpty(t, assignment, bootstrap)
+
+		dir := t.TempDir()
+		cmd := exec.Command(bash, "-c", "unset BOOTSTRAP_SERVER; "+assignment+"; printf %s \"$BOOTSTRAP_SERVER\"")
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		require.NoError(t, err, bootstrap)
+		assert.Equal(t, bootstrap, string(out))
+		assert.NoFileExists(t, filepath.Join(dir, "pwned"), bootstrap)
+	}
+}
+
+func TestWriteAndReadExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "consumer-offsets.json")
+	export := exportFixture()
+	export.SourceBootstrap = "b-1:9098"
+
+	require.NoError(t, WriteExport(path, export))
+
+	read, err := ReadExport(path)
+	require.NoError(t, err)
+	assert.Equal(t, "b-1:9098", read.SourceBootstrap)
+	require.Len(t, read.Groups, 2)
+	assert.True(t, read.Groups[0].Partitions[0].LastConsumedTimestamp.Equal(*export.Groups[0].Partitions[0].LastConsumedTimestamp))
+
+	require.NoError(t, WriteExport(path, &Export{}))
+	_, err = ReadExport(path)
+	assert.ErrorContains(t, err, "no consumer groups")
+}
+
+func TestCommit_SendsOneRequestPerGroup(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("orders", 0, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "billing", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "audit", broker),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t).
+			SetError("billing", "orders", 0, sarama.ErrNoError).
+			SetError("audit", "orders", 0, sarama.ErrUnknownMemberId),
+	})
+
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_8_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, cfg)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	err = Commit(client, []TranslatedOffset{
+		{GroupID: "billing", DestinationTopic: "orders", Partition: 0, DestinationOffset: 42},
+	})
+	require.NoError(t, err)
+
+	var commits []*sarama.OffsetCommitRequest
+	for _, rr := range broker.History() {
+		if req, ok := rr.Request.(*sarama.OffsetCommitRequest); ok {
+			commits = append(commits, req)
+		}
+	}
+	require.Len(t, commits, 1)
+	assert.Equal(t, "billing", commits[0].ConsumerGroup)
+	assert.Equal(t, int32(sarama.GroupGenerationUndefined), commits[0].ConsumerGroupGeneration)
+
+	err = Commit(client, []TranslatedOffset{
+		{GroupID: "audit", DestinationTopic: "orders", Partition: 0, DestinationOffset: 1},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `consumer group "audit"`)
+}
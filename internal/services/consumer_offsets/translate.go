@@ -0,0 +1,125 @@
+package consumer_offsets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+type TranslationMode string
+
+const (
+	// TranslationOffset reuses the source offset as-is. Cluster Linking
+	// mirror topics preserve offsets, so this is exact for mirrors.
+	TranslationOffset TranslationMode = "offset"
+	// TranslationTimestamp looks up the destination offset of the last
+	// consumed record's timestamp. Use it for topics that were copied by a
+	// mechanism that does not preserve offsets.
+	TranslationTimestamp TranslationMode = "timestamp"
+)
+
+func ParseTranslationMode(s string) (TranslationMode, error) {
+	switch TranslationMode(s) {
+	case TranslationOffset, TranslationTimestamp:
+		return TranslationMode(s), nil
+	default:
+		return "", fmt.Errorf("invalid translation mode %q: must be %q or %q", s, TranslationOffset, TranslationTimestamp)
+	}
+}
+
+// OffsetLookup resolves offsets on the destination cluster. sarama.Client
+// satisfies it: time is a unix-millisecond timestamp or sarama.OffsetNewest.
+type OffsetLookup interface {
+	GetOffset(topic string, partitionID int32, time int64) (int64, error)
+}
+
+// TranslatedOffset is a source committed offset mapped onto the destination.
+// Skipped entries carry the reason in Note and must not be committed.
+type TranslatedOffset struct {
+	GroupID           string
+	SourceTopic       string
+	DestinationTopic  string
+	Partition         int32
+	SourceOffset      int64
+	DestinationOffset int64
+	Skipped           bool
+	Note              string
+}
+
+type TranslateOpts struct {
+	Mode TranslationMode
+	// TopicPrefix is the cluster link's cluster.link.prefix, if any.
+	TopicPrefix string
+}
+
+// Translate maps every exported offset onto the destination cluster. A
+// destination offset is never set past the destination log end offset: a
+// mirror that is still behind gets the log end offset and a note.
+func Translate(export *Export, lookup OffsetLookup, opts TranslateOpts) []TranslatedOffset {
+	var translated []TranslatedOffset
+	for _, group := range export.Groups {
+		for _, p := range group.Partitions {
+			t := TranslatedOffset{
+				GroupID:          group.GroupID,
+				SourceTopic:      p.Topic,
+				DestinationTopic: opts.TopicPrefix + p.Topic,
+				Partition:        p.Partition,
+				SourceOffset:     p.Offset,
+			}
+			translatePartition(&t, p, lookup, opts.Mode)
+			translated = append(translated, t)
+		}
+	}
+	return translated
+}
+
+func translatePartition(t *TranslatedOffset, p PartitionOffset, lookup OffsetLookup, mode TranslationMode) {
+	logEnd, err := lookup.GetOffset(t.DestinationTopic, t.Partition, sarama.OffsetNewest)
+	if err != nil {
+		t.Skipped = true
+		t.Note = fmt.Sprintf("destination partition unavailable: %v", err)
+		return
+	}
+
+	target := p.Offset
+	if mode == TranslationTimestamp {
+		if p.LastConsumedTimestamp == nil {
+			t.Skipped = true
+			t.Note = "no last consumed timestamp in export"
+			return
+		}
+		// Looking up the last consumed record's own timestamp (not +1ms)
+		// re-delivers records sharing that millisecond rather than skipping
+		// them: at-least-once is the safer failure mode.
+		byTime, err := lookup.GetOffset(t.DestinationTopic, t.Partition, p.LastConsumedTimestamp.UnixMilli())
+		if err != nil {
+			t.Skipped = true
+			t.Note = fmt.Sprintf("timestamp lookup failed: %v", err)
+			return
+		}
+		if byTime < 0 {
+			// No record at or after the timestamp: the group was caught up.
+			byTime = logEnd
+		}
+		target = byTime
+		t.Note = fmt.Sprintf("resolved from %s", p.LastConsumedTimestamp.Format(time.RFC3339Nano))
+	}
+
+	if target > logEnd {
+		t.Note = fmt.Sprintf("clamped to destination log end offset %d (mirror behind by %d)", logEnd, target-logEnd)
+		target = logEnd
+	}
+	t.DestinationOffset = target
+}
+
+// CountSkipped returns the number of offsets that could not be translated.
+func CountSkipped(translated []TranslatedOffset) int {
+	count := 0
+	for _, t := range translated {
+		if t.Skipped {
+			count++
+		}
+	}
+	return count
+}
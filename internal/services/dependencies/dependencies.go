@@ -0,0 +1,472 @@
+// Package dependencies maps the data flows between Kafka clusters in a kcp
+// state file — MSK Replicator flows, MirrorMaker and connectors that read
+// from one cluster and write to another — into a dependency graph, and
+// derives the order in which the clusters can be migrated without breaking
+// those flows.
+package dependencies
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// EdgeKind is the mechanism that moves data along an edge.
+type EdgeKind string
+
+const (
+	EdgeMSKReplicator EdgeKind = "msk_replicator"
+	EdgeMirrorMaker   EdgeKind = "mirror_maker"
+	EdgeConnector     EdgeKind = "connector"
+)
+
+func (k EdgeKind) label() string {
+	switch k {
+	case EdgeMSKReplicator:
+		return "MSK Replicator"
+	case EdgeMirrorMaker:
+		return "MirrorMaker"
+	default:
+		return "Connector"
+	}
+}
+
+// Node is a cluster in the graph. Clusters referenced by a flow but absent
+// from the state file are included with Discovered false, keyed by their
+// bootstrap hosts.
+type Node struct {
+	ID         string
+	Name       string
+	Region     string
+	Discovered bool
+}
+
+// Edge is one data flow: data is read from From and written to To.
+type Edge struct {
+	From string
+	To   string
+	Kind EdgeKind
+	// Via names the replicator, connector or consumer group behind the flow.
+	Via string
+}
+
+// Graph is the cross-cluster dependency graph of a state file.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+	// Order is the safe migration order of the discovered clusters that take
+	// part in a flow, as node IDs per step. A cluster is migrated no later
+	// than the clusters it reads from, so a cluster link on Confluent Cloud
+	// can keep pulling from a source still on MSK. Clusters replicating to
+	// each other share a step and move together.
+	Order [][]string
+}
+
+// mirrorMakerGroupPattern matches the consumer groups MirrorMaker 1 and
+// MirrorMaker 2 deployments are conventionally named after.
+var mirrorMakerGroupPattern = regexp.MustCompile(`(?i)mirror[-_.]?maker|^mm2[-_.]`)
+
+// builder accumulates nodes and edges while resolving bootstrap servers to
+// discovered clusters.
+type builder struct {
+	nodes  map[string]*Node
+	byHost map[string]string
+	edges  map[Edge]bool
+}
+
+// Build maps the flows between the clusters of a processed state.
+func Build(state report.ProcessedState) *Graph {
+	b := &builder{nodes: map[string]*Node{}, byHost: map[string]string{}, edges: map[Edge]bool{}}
+
+	// Register every discovered cluster first so flows resolve to them
+	// whichever cluster they were recorded on.
+	for _, src := range state.Sources {
+		if src.MSKData != nil {
+			for _, region := range src.MSKData.Regions {
+				for _, c := range region.Clusters {
+					b.addCluster(c.Arn, c.Name, region.Name, mskBootstrapServers(c))
+				}
+			}
+		}
+		if src.OSKData != nil {
+			for _, c := range src.OSKData.Clusters {
+				b.addCluster(c.ID, c.ID, "", c.BootstrapServers)
+			}
+		}
+	}
+
+	for _, src := range state.Sources {
+		if src.MSKData != nil {
+			for _, region := range src.MSKData.Regions {
+				b.addReplicators(region)
+				for _, c := range region.Clusters {
+					for _, connector := range c.AWSClientInformation.Connectors {
+						home := aws.ToString(connector.KafkaCluster.BootstrapServers)
+						b.addConnector(c.Arn, connector.ConnectorName, splitServers(home), stringConfig(connector.ConnectorConfiguration))
+					}
+					b.addAdminFlows(c.Arn, c.KafkaAdminClientInformation)
+				}
+			}
+		}
+		if src.OSKData != nil {
+			for _, c := range src.OSKData.Clusters {
+				b.addAdminFlows(c.ID, c.KafkaAdminClientInformation)
+			}
+		}
+	}
+
+	return b.graph()
+}
+
+func (b *builder) addCluster(id, name, region string, servers []string) {
+	if id == "" {
+		return
+	}
+	b.nodes[id] = &Node{ID: id, Name: name, Region: region, Discovered: true}
+	for _, host := range hosts(servers) {
+		b.byHost[host] = id
+	}
+}
+
+// addReplicators adds the flows of the region's MSK Replicators, whose
+// clusters are named by ARN.
+func (b *builder) addReplicators(region report.ProcessedRegion) {
+	for _, r := range region.Replicators {
+		aliases := map[string]string{}
+		for _, kc := range r.KafkaClusters {
+			if kc.AmazonMskCluster != nil {
+				aliases[aws.ToString(kc.KafkaClusterAlias)] = aws.ToString(kc.AmazonMskCluster.MskClusterArn)
+			}
+		}
+		for _, info := range r.ReplicationInfoList {
+			source := b.arnNode(aliases[aws.ToString(info.SourceKafkaClusterAlias)])
+			target := b.arnNode(aliases[aws.ToString(info.TargetKafkaClusterAlias)])
+			b.addEdge(source, target, EdgeMSKReplicator, aws.ToString(r.ReplicatorName))
+		}
+	}
+}
+
+// addAdminFlows adds the flows the admin scan of a cluster reveals: its
+// self-managed connectors, and consumer groups named like MirrorMaker,
+// whose target cluster is unknown.
+func (b *builder) addAdminFlows(clusterID string, info types.KafkaAdminClientInformation) {
+	if info.SelfManagedConnectors != nil {
+		for _, connector := range info.SelfManagedConnectors.Connectors {
+			b.addConnector(clusterID, connector.Name, nil, anyConfig(connector.Config))
+		}
+	}
+	for _, group := range info.ConsumerGroups {
+		if group.ProtocolType == "connect" || !mirrorMakerGroupPattern.MatchString(group.GroupID) {
+			continue
+		}
+		target := "unknown:" + clusterID + ":" + group.GroupID
+		b.nodes[target] = &Node{ID: target, Name: "unknown target of " + group.GroupID}
+		b.addEdge(clusterID, target, EdgeMirrorMaker, group.GroupID)
+	}
+}
+
+// addConnector adds a flow for a connector that reads from one cluster and
+// writes to another. A MirrorMaker 2 connector names both clusters; any
+// other connector reads and writes its home cluster unless its consumer or
+// producer is pointed elsewhere. homeServers is the cluster the connector's
+// worker is configured with, when known; otherwise the connector was
+// discovered on clusterID.
+func (b *builder) addConnector(clusterID, name string, homeServers []string, config map[string]string) {
+	home := clusterID
+	if len(homeServers) > 0 {
+		home = b.serversNode(homeServers)
+	}
+	source, target := home, home
+	if s := firstConfig(config, "source.cluster.bootstrap.servers", "consumer.override.bootstrap.servers"); s != "" {
+		source = b.serversNode(splitServers(s))
+	}
+	if s := firstConfig(config, "target.cluster.bootstrap.servers", "producer.override.bootstrap.servers"); s != "" {
+		target = b.serversNode(splitServers(s))
+	}
+	if source == target {
+		return
+	}
+	kind := EdgeConnector
+	if strings.Contains(config["connector.class"], ".mirror.") {
+		kind = EdgeMirrorMaker
+	}
+	b.addEdge(source, target, kind, name)
+}
+
+// arnNode returns the node of an MSK cluster ARN, adding an undiscovered
+// node for a cluster in another region or account.
+func (b *builder) arnNode(arn string) string {
+	if arn == "" {
+		return ""
+	}
+	if _, ok := b.nodes[arn]; !ok {
+		name := arn
+		if parts := strings.Split(arn, "/"); len(parts) >= 2 {
+			name = parts[1]
+		}
+		b.nodes[arn] = &Node{ID: arn, Name: name}
+	}
+	return arn
+}
+
+// serversNode resolves bootstrap servers to the discovered cluster sharing
+// a broker host, or to an undiscovered node named after the first host.
+func (b *builder) serversNode(servers []string) string {
+	hs := hosts(servers)
+	if len(hs) == 0 {
+		return ""
+	}
+	for _, host := range hs {
+		if id, ok := b.byHost[host]; ok {
+			return id
+		}
+	}
+	id := "external:" + strings.Join(hs, ",")
+	if _, ok := b.nodes[id]; !ok {
+		b.nodes[id] = &Node{ID: id, Name: hs[0]}
+		for _, host := range hs {
+			b.byHost[host] = id
+		}
+	}
+	return id
+}
+
+func (b *builder) addEdge(from, to string, kind EdgeKind, via string) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	b.edges[Edge{From: from, To: to, Kind: kind, Via: via}] = true
+}
+
+func (b *builder) graph() *Graph {
+	g := &Graph{}
+	linked := map[string]bool{}
+	for edge := range b.edges {
+		g.Edges = append(g.Edges, edge)
+		linked[edge.From], linked[edge.To] = true, true
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, c := g.Edges[i], g.Edges[j]
+		if a.From != c.From {
+			return a.From < c.From
+		}
+		if a.To != c.To {
+			return a.To < c.To
+		}
+		if a.Kind != c.Kind {
+			return a.Kind < c.Kind
+		}
+		return a.Via < c.Via
+	})
+	for id := range linked {
+		g.Nodes = append(g.Nodes, *b.nodes[id])
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	g.Order = migrationOrder(g)
+	return g
+}
+
+// migrationOrder groups the discovered clusters into strongly connected
+// components and orders the components downstream first: a component is
+// ready once every discovered cluster it writes to has been migrated.
+func migrationOrder(g *Graph) [][]string {
+	discovered := map[string]bool{}
+	for _, n := range g.Nodes {
+		if n.Discovered {
+			discovered[n.ID] = true
+		}
+	}
+	adjacent := map[string][]string{}
+	for _, e := range g.Edges {
+		if discovered[e.From] && discovered[e.To] {
+			adjacent[e.From] = append(adjacent[e.From], e.To)
+		}
+	}
+	var ids []string
+	for id := range discovered {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	components := stronglyConnected(ids, adjacent)
+	componentOf := map[string]int{}
+	for i, members := range components {
+		for _, id := range members {
+			componentOf[id] = i
+		}
+	}
+	// downstream[i] counts the components component i still writes to.
+	downstream := make([]map[int]bool, len(components))
+	upstream := make([]map[int]bool, len(components))
+	for i := range components {
+		downstream[i], upstream[i] = map[int]bool{}, map[int]bool{}
+	}
+	for from, tos := range adjacent {
+		for _, to := range tos {
+			if f, t := componentOf[from], componentOf[to]; f != t {
+				downstream[f][t] = true
+				upstream[t][f] = true
+			}
+		}
+	}
+
+	var order [][]string
+	done := make([]bool, len(components))
+	for remaining := len(components); remaining > 0; {
+		var ready []int
+		for i := range components {
+			if !done[i] && len(downstream[i]) == 0 {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			break
+		}
+		var step []string
+		for _, i := range ready {
+			done[i] = true
+			remaining--
+			step = append(step, components[i]...)
+			for u := range upstream[i] {
+				delete(downstream[u], i)
+			}
+		}
+		sort.Strings(step)
+		order = append(order, step)
+	}
+	return order
+}
+
+// stronglyConnected returns the strongly connected components of the graph
+// (Tarjan's algorithm), each sorted.
+func stronglyConnected(ids []string, adjacent map[string][]string) [][]string {
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var components [][]string
+	next := 0
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id], low[id] = next, next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, to := range adjacent[id] {
+			if _, seen := index[to]; !seen {
+				visit(to)
+				low[id] = min(low[id], low[to])
+			} else if onStack[to] {
+				low[id] = min(low[id], index[to])
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	return components
+}
+
+// NodeName returns the display name of a node ID.
+func (g *Graph) NodeName(id string) string {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n.Name
+		}
+	}
+	return id
+}
+
+func mskBootstrapServers(c report.ProcessedCluster) []string {
+	bb := c.AWSClientInformation.BootstrapBrokers
+	var servers []string
+	for _, s := range []*string{
+		bb.BootstrapBrokerString, bb.BootstrapBrokerStringTls, bb.BootstrapBrokerStringSaslScram, bb.BootstrapBrokerStringSaslIam,
+		bb.BootstrapBrokerStringPublicTls, bb.BootstrapBrokerStringPublicSaslScram, bb.BootstrapBrokerStringPublicSaslIam,
+		bb.BootstrapBrokerStringVpcConnectivityTls, bb.BootstrapBrokerStringVpcConnectivitySaslScram, bb.BootstrapBrokerStringVpcConnectivitySaslIam,
+	} {
+		servers = append(servers, splitServers(aws.ToString(s))...)
+	}
+	return servers
+}
+
+func splitServers(s string) []string {
+	var servers []string
+	for _, server := range strings.Split(s, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// hosts returns the sorted, lower-cased hosts of bootstrap servers. Ports
+// are dropped because an MSK broker listens on one port per authentication
+// method.
+func hosts(servers []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, server := range servers {
+		server = strings.ToLower(server)
+		if i := strings.Index(server, "://"); i >= 0 {
+			server = server[i+3:]
+		}
+		host := server
+		if i := strings.LastIndex(server, ":"); i >= 0 {
+			host = server[:i]
+		}
+		if host != "" && !seen[host] {
+			seen[host] = true
+			out = append(out, host)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func firstConfig(config map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v := strings.TrimSpace(config[key]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func stringConfig(config map[string]string) map[string]string {
+	if config == nil {
+		return map[string]string{}
+	}
+	return config
+}
+
+func anyConfig(config map[string]any) map[string]string {
+	out := make(map[string]string, len(config))
+	for k, v := range config {
+		out[k] = fmt.Sprint(v)
+	}
+	return out
+}
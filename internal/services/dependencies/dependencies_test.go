@@ -0,0 +1,146 @@
+package dependencies
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	kafkaconnecttypes "github.com/aws/aws-sdk-go-v2/service/kafkaconnect/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ordersArn   = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/1"
+	drArn       = "arn:aws:kafka:us-west-2:123456789012:cluster/orders-dr/2"
+	analyticArn = "arn:aws:kafka:us-east-1:123456789012:cluster/analytics/3"
+)
+
+func mskCluster(arn, name, bootstrap string) report.ProcessedCluster {
+	c := report.ProcessedCluster{Arn: arn, Name: name}
+	c.AWSClientInformation.BootstrapBrokers = kafka.GetBootstrapBrokersOutput{BootstrapBrokerStringSaslIam: aws.String(bootstrap)}
+	return c
+}
+
+func replicator(name, source, target string) kafka.DescribeReplicatorOutput {
+	return kafka.DescribeReplicatorOutput{
+		ReplicatorName: aws.String(name),
+		KafkaClusters: []kafkatypes.KafkaClusterDescription{
+			{KafkaClusterAlias: aws.String("src"), AmazonMskCluster: &kafkatypes.AmazonMskCluster{MskClusterArn: aws.String(source)}},
+			{KafkaClusterAlias: aws.String("dst"), AmazonMskCluster: &kafkatypes.AmazonMskCluster{MskClusterArn: aws.String(target)}},
+		},
+		ReplicationInfoList: []kafkatypes.ReplicationInfoDescription{
+			{SourceKafkaClusterAlias: aws.String("src"), TargetKafkaClusterAlias: aws.String("dst")},
+		},
+	}
+}
+
+func TestBuild_MapsFlowsAndOrdersDownstreamFirst(t *testing.T) {
+	orders := mskCluster(ordersArn, "orders", "b-1.orders.kafka.us-east-1.amazonaws.com:9098,b-2.orders.kafka.us-east-1.amazonaws.com:9098")
+	analytics := mskCluster(analyticArn, "analytics", "b-1.analytics.kafka.us-east-1.amazonaws.com:9098")
+	// An MSK Connect MirrorMaker 2 connector on analytics copying from orders.
+	analytics.AWSClientInformation.Connectors = []types.ConnectorSummary{{
+		ConnectorName: "orders-to-analytics",
+		KafkaCluster:  kafkaconnecttypes.ApacheKafkaClusterDescription{BootstrapServers: aws.String("b-1.analytics.kafka.us-east-1.amazonaws.com:9098")},
+		ConnectorConfiguration: map[string]string{
+			"connector.class":                  "org.apache.kafka.connect.mirror.MirrorSourceConnector",
+			"source.cluster.bootstrap.servers": "B-2.orders.kafka.us-east-1.amazonaws.com:9096",
+		},
+	}}
+	// A sink connector on analytics that stays on analytics.
+	analytics.KafkaAdminClientInformation.SelfManagedConnectors = &types.SelfManagedConnectors{Connectors: []types.SelfManagedConnector{
+		{Name: "s3-sink", Config: map[string]any{"connector.class": "io.confluent.connect.s3.S3SinkConnector"}},
+		{Name: "legacy-feed", Config: map[string]any{"producer.override.bootstrap.servers": "kafka-1.legacy.internal:9092"}},
+	}}
+	analytics.KafkaAdminClientInformation.ConsumerGroups = []types.ConsumerGroup{
+		{GroupID: "mirrormaker-analytics", ProtocolType: "consumer"},
+		{GroupID: "billing", ProtocolType: "consumer"},
+	}
+
+	state := report.ProcessedState{Sources: []report.ProcessedSource{{
+		Type: types.SourceTypeMSK,
+		MSKData: &report.ProcessedMSKSource{Regions: []report.ProcessedRegion{
+			{Name: "us-east-1", Clusters: []report.ProcessedCluster{orders, analytics}},
+			{Name: "us-west-2", Replicators: []kafka.DescribeReplicatorOutput{replicator("orders-dr", ordersArn, drArn)}},
+		}},
+	}}}
+
+	graph := Build(state)
+
+	require.Len(t, graph.Edges, 4)
+	labels := map[string]string{}
+	for _, e := range graph.Edges {
+		labels[e.Label()] = graph.NodeName(e.From) + " -> " + graph.NodeName(e.To)
+	}
+	assert.Equal(t, map[string]string{
+		"MSK Replicator: orders-dr":          "orders -> orders-dr",
+		"MirrorMaker: orders-to-analytics":   "orders -> analytics",
+		"Connector: legacy-feed":             "analytics -> kafka-1.legacy.internal",
+		"MirrorMaker: mirrormaker-analytics": "analytics -> unknown target of mirrormaker-analytics",
+	}, labels)
+
+	discovered := map[string]bool{}
+	for _, n := range graph.Nodes {
+		discovered[n.Name] = n.Discovered
+	}
+	assert.True(t, discovered["analytics"])
+	assert.False(t, discovered["orders-dr"], "a replicator target in another account or region isn't in the state")
+
+	assert.Equal(t, [][]string{{analyticArn}, {ordersArn}}, graph.Order, "analytics reads from orders, so it migrates first")
+}
+
+func TestBuild_BidirectionalClustersMigrateTogether(t *testing.T) {
+	state := report.ProcessedState{Sources: []report.ProcessedSource{{
+		Type: types.SourceTypeMSK,
+		MSKData: &report.ProcessedMSKSource{Regions: []report.ProcessedRegion{{
+			Name:     "us-east-1",
+			Clusters: []report.ProcessedCluster{mskCluster(ordersArn, "orders", ""), mskCluster(drArn, "orders-dr", ""), mskCluster(analyticArn, "analytics", "")},
+			Replicators: []kafka.DescribeReplicatorOutput{
+				replicator("east-west", ordersArn, drArn),
+				replicator("west-east", drArn, ordersArn),
+				replicator("to-analytics", drArn, analyticArn),
+			},
+		}}},
+	}}}
+
+	graph := Build(state)
+
+	assert.Equal(t, [][]string{{analyticArn}, {ordersArn, drArn}}, graph.Order)
+}
+
+func TestBuild_NoFlows(t *testing.T) {
+	state := report.ProcessedState{Sources: []report.ProcessedSource{{
+		Type:    types.SourceTypeOSK,
+		OSKData: &report.ProcessedOSKSource{Clusters: []report.ProcessedOSKCluster{{ID: "prod", BootstrapServers: []string{"kafka-1:9092"}}}},
+	}}}
+
+	graph := Build(state)
+
+	assert.Empty(t, graph.Edges)
+	assert.Empty(t, graph.Nodes)
+	assert.Empty(t, graph.Order)
+}
+
+func TestGraph_Render(t *testing.T) {
+	graph := &Graph{
+		Nodes: []Node{
+			{ID: ordersArn, Name: "orders", Region: "us-east-1", Discovered: true},
+			{ID: "external:kafka-1", Name: `kafka-1 "legacy"`},
+		},
+		Edges: []Edge{{From: ordersArn, To: "external:kafka-1", Kind: EdgeConnector, Via: "feed"}},
+	}
+
+	assert.Equal(t, "flowchart LR\n"+
+		"  n0[\"orders<br/>us-east-1\"]\n"+
+		"  n1(\"kafka-1 #quot;legacy#quot;\")\n"+
+		"  style n1 stroke-dasharray: 5 5\n"+
+		"  n0 -->|\"Connector: feed\"| n1\n", graph.Mermaid())
+
+	dot := graph.DOT()
+	assert.Contains(t, dot, `"`+ordersArn+`" [label="orders\nus-east-1"];`)
+	assert.Contains(t, dot, `"external:kafka-1" [label="kafka-1 \"legacy\"", style=dashed];`)
+	assert.Contains(t, dot, `"`+ordersArn+`" -> "external:kafka-1" [label="Connector: feed"];`)
+}
@@ -0,0 +1,68 @@
+package dependencies
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mermaid renders the graph as a Mermaid flowchart. Undiscovered clusters
+// are drawn with rounded, dashed outlines.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := map[string]string{}
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		label := mermaidEscape(n.Name)
+		if n.Region != "" {
+			label += "<br/>" + mermaidEscape(n.Region)
+		}
+		if n.Discovered {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[n.ID], label)
+		} else {
+			fmt.Fprintf(&b, "  %s(\"%s\")\n", ids[n.ID], label)
+			fmt.Fprintf(&b, "  style %s stroke-dasharray: 5 5\n", ids[n.ID])
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", ids[e.From], mermaidEscape(e.Label()), ids[e.To])
+	}
+	return b.String()
+}
+
+// DOT renders the graph in Graphviz DOT. Undiscovered clusters are drawn
+// dashed.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph kcp_cluster_dependencies {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		label := n.Name
+		if n.Region != "" {
+			label += "\n" + n.Region
+		}
+		style := ""
+		if !n.Discovered {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(n.ID), dotQuote(label), style)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Label()))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Label describes the mechanism and name of an edge, e.g.
+// "MSK Replicator: dr-replicator".
+func (e Edge) Label() string {
+	return e.Kind.label() + ": " + e.Via
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(s)
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,50 @@
+package devseed
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/testcontainers/testcontainers-go"
+	tckafka "github.com/testcontainers/testcontainers-go/modules/kafka"
+)
+
+// DefaultKafkaImage is the single-node KRaft broker the seed starts.
+const DefaultKafkaImage = "confluentinc/confluent-local:7.5.0"
+
+// KafkaContainer is a local broker started by StartKafka.
+type KafkaContainer struct {
+	ID               string
+	BootstrapServers []string
+}
+
+// StartKafka starts a single-node Kafka broker in Docker and returns its
+// host-reachable bootstrap servers. The container is left running after kcp
+// exits, so the seeded data can be scanned by later commands; remove it with
+// docker rm -f <ID>.
+func StartKafka(ctx context.Context, image string) (KafkaContainer, error) {
+	// The Ryuk reaper would remove the container as soon as kcp exits.
+	if _, ok := os.LookupEnv("TESTCONTAINERS_RYUK_DISABLED"); !ok {
+		if err := os.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true"); err != nil {
+			return KafkaContainer{}, err
+		}
+	}
+
+	container, err := tckafka.Run(ctx, image,
+		tckafka.WithClusterID(SeedTag),
+		testcontainers.WithLabels(map[string]string{SeedTag: "true"}),
+	)
+	if err != nil {
+		if container != nil {
+			_ = testcontainers.TerminateContainer(container)
+		}
+		return KafkaContainer{}, fmt.Errorf("failed to start Kafka container from %s (is Docker running?): %w", image, err)
+	}
+
+	brokers, err := container.Brokers(ctx)
+	if err != nil {
+		_ = testcontainers.TerminateContainer(container)
+		return KafkaContainer{}, fmt.Errorf("failed to get Kafka container bootstrap servers: %w", err)
+	}
+	return KafkaContainer{ID: container.GetContainerID(), BootstrapServers: brokers}, nil
+}
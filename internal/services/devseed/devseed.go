@@ -0,0 +1,350 @@
+// Package devseed provisions a small, fake MSK estate — a VPC, an MSK
+// cluster in LocalStack and topics, records and consumer groups on a local
+// Kafka broker — so the discover, scan, report and generate flows can be
+// exercised without an AWS account.
+package devseed
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+)
+
+// SeedTag marks every AWS resource created by the seed, so re-running it
+// reuses them instead of creating duplicates.
+const SeedTag = "kcp-dev-seed"
+
+// Topic is a topic to create on the local broker and fill with Records
+// keyed records.
+type Topic struct {
+	Name       string
+	Partitions int32
+	Records    int
+	Config     map[string]string
+}
+
+// ConsumerGroup commits offsets halfway through each of its topics, so
+// scans and reports see both consumed data and lag.
+type ConsumerGroup struct {
+	Name   string
+	Topics []string
+}
+
+// Fixture describes the resources a seed creates.
+type Fixture struct {
+	ClusterName    string
+	KafkaVersion   string
+	BrokerNodes    int32
+	VpcCidr        string
+	SubnetCidrs    []string
+	Topics         []Topic
+	ConsumerGroups []ConsumerGroup
+}
+
+// DefaultFixture is a two-broker MSK cluster across two subnets, and a
+// handful of topics with an order-processing flavour.
+func DefaultFixture() Fixture {
+	return Fixture{
+		ClusterName:  SeedTag,
+		KafkaVersion: "3.6.0",
+		BrokerNodes:  2,
+		VpcCidr:      "10.42.0.0/16",
+		SubnetCidrs:  []string{"10.42.1.0/24", "10.42.2.0/24"},
+		Topics: []Topic{
+			{Name: "orders", Partitions: 6, Records: 600},
+			{Name: "payments", Partitions: 3, Records: 300},
+			{Name: "inventory.updates", Partitions: 3, Records: 150},
+			{Name: "customers", Partitions: 1, Records: 50, Config: map[string]string{"cleanup.policy": "compact"}},
+		},
+		ConsumerGroups: []ConsumerGroup{
+			{Name: "order-service", Topics: []string{"orders", "inventory.updates"}},
+			{Name: "billing", Topics: []string{"payments"}},
+		},
+	}
+}
+
+// EC2API is the subset of the EC2 client the seed uses.
+type EC2API interface {
+	DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)
+	CreateVpc(ctx context.Context, params *ec2.CreateVpcInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcOutput, error)
+	DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
+	CreateSubnet(ctx context.Context, params *ec2.CreateSubnetInput, optFns ...func(*ec2.Options)) (*ec2.CreateSubnetOutput, error)
+}
+
+// MSKAPI is the subset of the MSK client the seed uses.
+type MSKAPI interface {
+	ListClustersV2(ctx context.Context, params *kafka.ListClustersV2Input, optFns ...func(*kafka.Options)) (*kafka.ListClustersV2Output, error)
+	CreateClusterV2(ctx context.Context, params *kafka.CreateClusterV2Input, optFns ...func(*kafka.Options)) (*kafka.CreateClusterV2Output, error)
+}
+
+// AWSResult lists the AWS resources the seed created or found.
+type AWSResult struct {
+	VpcID          string
+	SubnetIDs      []string
+	ClusterArn     string
+	ClusterCreated bool
+}
+
+// SeedAWS creates the fixture's VPC, subnets and MSK cluster in region,
+// reusing any that a previous seed left behind.
+func SeedAWS(ctx context.Context, ec2Client EC2API, mskClient MSKAPI, region string, fixture Fixture) (AWSResult, error) {
+	var result AWSResult
+
+	vpcID, err := ensureVpc(ctx, ec2Client, fixture.VpcCidr)
+	if err != nil {
+		return result, err
+	}
+	result.VpcID = vpcID
+
+	for i, cidr := range fixture.SubnetCidrs {
+		// Spread subnets across zones like a real MSK deployment.
+		zone := fmt.Sprintf("%s%c", region, 'a'+i%3)
+		subnetID, err := ensureSubnet(ctx, ec2Client, vpcID, cidr, zone)
+		if err != nil {
+			return result, err
+		}
+		result.SubnetIDs = append(result.SubnetIDs, subnetID)
+	}
+
+	listed, err := mskClient.ListClustersV2(ctx, &kafka.ListClustersV2Input{ClusterNameFilter: aws.String(fixture.ClusterName)})
+	if err != nil {
+		return result, mskError(err)
+	}
+	for _, cluster := range listed.ClusterInfoList {
+		if aws.ToString(cluster.ClusterName) == fixture.ClusterName {
+			result.ClusterArn = aws.ToString(cluster.ClusterArn)
+			return result, nil
+		}
+	}
+
+	created, err := mskClient.CreateClusterV2(ctx, &kafka.CreateClusterV2Input{
+		ClusterName: aws.String(fixture.ClusterName),
+		Provisioned: &kafkatypes.ProvisionedRequest{
+			KafkaVersion:        aws.String(fixture.KafkaVersion),
+			NumberOfBrokerNodes: aws.Int32(fixture.BrokerNodes),
+			BrokerNodeGroupInfo: &kafkatypes.BrokerNodeGroupInfo{
+				ClientSubnets: result.SubnetIDs,
+				InstanceType:  aws.String("kafka.m5.large"),
+			},
+			ClientAuthentication: &kafkatypes.ClientAuthentication{
+				Unauthenticated: &kafkatypes.Unauthenticated{Enabled: aws.Bool(true)},
+			},
+			EncryptionInfo: &kafkatypes.EncryptionInfo{
+				EncryptionInTransit: &kafkatypes.EncryptionInTransit{ClientBroker: kafkatypes.ClientBrokerTlsPlaintext},
+			},
+		},
+		Tags: map[string]string{SeedTag: "true"},
+	})
+	if err != nil {
+		return result, mskError(err)
+	}
+	result.ClusterArn = aws.ToString(created.ClusterArn)
+	result.ClusterCreated = true
+	return result, nil
+}
+
+func mskError(err error) error {
+	return fmt.Errorf("failed to provision the MSK cluster (LocalStack only emulates MSK in its paid tiers; use --skip-aws with the community edition): %w", err)
+}
+
+func seedTagSpec(resourceType ec2types.ResourceType) []ec2types.TagSpecification {
+	return []ec2types.TagSpecification{{
+		ResourceType: resourceType,
+		Tags:         []ec2types.Tag{{Key: aws.String(SeedTag), Value: aws.String("true")}},
+	}}
+}
+
+func ensureVpc(ctx context.Context, ec2Client EC2API, cidr string) (string, error) {
+	existing, err := ec2Client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("tag:" + SeedTag), Values: []string{"true"}}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe VPCs: %w", err)
+	}
+	if len(existing.Vpcs) > 0 {
+		return aws.ToString(existing.Vpcs[0].VpcId), nil
+	}
+
+	created, err := ec2Client.CreateVpc(ctx, &ec2.CreateVpcInput{
+		CidrBlock:         aws.String(cidr),
+		TagSpecifications: seedTagSpec(ec2types.ResourceTypeVpc),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create VPC %s: %w", cidr, err)
+	}
+	return aws.ToString(created.Vpc.VpcId), nil
+}
+
+func ensureSubnet(ctx context.Context, ec2Client EC2API, vpcID, cidr, zone string) (string, error) {
+	existing, err := ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("vpc-id"), Values: []string{vpcID}},
+			{Name: aws.String("cidr-block"), Values: []string{cidr}},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe subnets of %s: %w", vpcID, err)
+	}
+	if len(existing.Subnets) > 0 {
+		return aws.ToString(existing.Subnets[0].SubnetId), nil
+	}
+
+	created, err := ec2Client.CreateSubnet(ctx, &ec2.CreateSubnetInput{
+		VpcId:             aws.String(vpcID),
+		CidrBlock:         aws.String(cidr),
+		AvailabilityZone:  aws.String(zone),
+		TagSpecifications: seedTagSpec(ec2types.ResourceTypeSubnet),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create subnet %s in %s: %w", cidr, vpcID, err)
+	}
+	return aws.ToString(created.Subnet.SubnetId), nil
+}
+
+// KafkaResult summarises what the seed wrote to the local broker.
+type KafkaResult struct {
+	TopicsCreated   []string
+	TopicsExisting  []string
+	RecordsProduced int
+	GroupsCommitted []string
+}
+
+// SeedKafka creates the fixture's topics on the broker behind kafkaClient,
+// fills topics that are still empty and commits the consumer groups'
+// offsets. kafkaClient must be configured with Producer.Return.Successes;
+// it stays open for the caller to close.
+func SeedKafka(ctx context.Context, kafkaClient sarama.Client, fixture Fixture) (KafkaResult, error) {
+	var result KafkaResult
+
+	admin, err := sarama.NewClusterAdminFromClient(kafkaClient)
+	if err != nil {
+		return result, fmt.Errorf("failed to create cluster admin: %w", err)
+	}
+	existing, err := admin.ListTopics()
+	if err != nil {
+		return result, fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	for _, topic := range fixture.Topics {
+		if _, ok := existing[topic.Name]; ok {
+			result.TopicsExisting = append(result.TopicsExisting, topic.Name)
+			continue
+		}
+		detail := &sarama.TopicDetail{NumPartitions: topic.Partitions, ReplicationFactor: 1}
+		if len(topic.Config) > 0 {
+			detail.ConfigEntries = make(map[string]*string, len(topic.Config))
+			for key, value := range topic.Config {
+				detail.ConfigEntries[key] = &value
+			}
+		}
+		if err := admin.CreateTopic(topic.Name, detail, false); err != nil {
+			return result, fmt.Errorf("failed to create topic %s: %w", topic.Name, err)
+		}
+		result.TopicsCreated = append(result.TopicsCreated, topic.Name)
+	}
+	if err := kafkaClient.RefreshMetadata(); err != nil {
+		return result, fmt.Errorf("failed to refresh metadata: %w", err)
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(kafkaClient)
+	if err != nil {
+		return result, fmt.Errorf("failed to create producer: %w", err)
+	}
+	defer func() { _ = producer.Close() }()
+
+	for _, topic := range fixture.Topics {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		offsets, err := highWatermarks(kafkaClient, topic.Name)
+		if err != nil {
+			return result, err
+		}
+		var total int64
+		for _, offset := range offsets {
+			total += offset
+		}
+		if total > 0 {
+			continue
+		}
+
+		messages := make([]*sarama.ProducerMessage, 0, topic.Records)
+		for i := range topic.Records {
+			messages = append(messages, &sarama.ProducerMessage{
+				Topic: topic.Name,
+				Key:   sarama.StringEncoder(topic.Name + "-" + strconv.Itoa(i%50)),
+				Value: sarama.StringEncoder(fmt.Sprintf(`{"id":%d,"source":"%s"}`, i, SeedTag)),
+			})
+		}
+		if err := producer.SendMessages(messages); err != nil {
+			return result, fmt.Errorf("failed to produce to %s: %w", topic.Name, err)
+		}
+		result.RecordsProduced += len(messages)
+	}
+
+	for _, group := range fixture.ConsumerGroups {
+		if err := commitHalfway(kafkaClient, group); err != nil {
+			return result, err
+		}
+		result.GroupsCommitted = append(result.GroupsCommitted, group.Name)
+	}
+	return result, nil
+}
+
+func highWatermarks(kafkaClient sarama.Client, topic string) (map[int32]int64, error) {
+	partitions, err := kafkaClient.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions of %s: %w", topic, err)
+	}
+	offsets := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		offset, err := kafkaClient.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get offset of %s/%d: %w", topic, partition, err)
+		}
+		offsets[partition] = offset
+	}
+	return offsets, nil
+}
+
+func commitHalfway(kafkaClient sarama.Client, group ConsumerGroup) error {
+	offsetManager, err := sarama.NewOffsetManagerFromClient(group.Name, kafkaClient)
+	if err != nil {
+		return fmt.Errorf("failed to create offset manager for group %s: %w", group.Name, err)
+	}
+	defer func() { _ = offsetManager.Close() }()
+
+	var partitionManagers []sarama.PartitionOffsetManager
+	defer func() {
+		for _, partitionManager := range partitionManagers {
+			_ = partitionManager.Close()
+		}
+	}()
+
+	topics := slices.Clone(group.Topics)
+	slices.Sort(topics)
+	for _, topic := range topics {
+		offsets, err := highWatermarks(kafkaClient, topic)
+		if err != nil {
+			return err
+		}
+		for partition, offset := range offsets {
+			partitionManager, err := offsetManager.ManagePartition(topic, partition)
+			if err != nil {
+				return fmt.Errorf("failed to manage %s/%d for group %s: %w", topic, partition, group.Name, err)
+			}
+			partitionManagers = append(partitionManagers, partitionManager)
+			partitionManager.MarkOffset(offset/2, "")
+		}
+	}
+	offsetManager.Commit()
+	return nil
+}
@@ -0,0 +1,96 @@
+package devseed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLocalStack keeps the VPCs, subnets and clusters created through it.
+type fakeLocalStack struct {
+	vpcs       []ec2types.Vpc
+	subnets    []ec2types.Subnet
+	clusters   []kafkatypes.Cluster
+	createErr  error
+	lastCreate *kafka.CreateClusterV2Input
+}
+
+func (f *fakeLocalStack) DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	return &ec2.DescribeVpcsOutput{Vpcs: f.vpcs}, nil
+}
+
+func (f *fakeLocalStack) CreateVpc(ctx context.Context, params *ec2.CreateVpcInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcOutput, error) {
+	vpc := ec2types.Vpc{VpcId: aws.String(fmt.Sprintf("vpc-%d", len(f.vpcs))), CidrBlock: params.CidrBlock}
+	f.vpcs = append(f.vpcs, vpc)
+	return &ec2.CreateVpcOutput{Vpc: &vpc}, nil
+}
+
+func (f *fakeLocalStack) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	var matched []ec2types.Subnet
+	for _, subnet := range f.subnets {
+		if aws.ToString(subnet.CidrBlock) == params.Filters[1].Values[0] {
+			matched = append(matched, subnet)
+		}
+	}
+	return &ec2.DescribeSubnetsOutput{Subnets: matched}, nil
+}
+
+func (f *fakeLocalStack) CreateSubnet(ctx context.Context, params *ec2.CreateSubnetInput, optFns ...func(*ec2.Options)) (*ec2.CreateSubnetOutput, error) {
+	subnet := ec2types.Subnet{SubnetId: aws.String(fmt.Sprintf("subnet-%d", len(f.subnets))), CidrBlock: params.CidrBlock, AvailabilityZone: params.AvailabilityZone}
+	f.subnets = append(f.subnets, subnet)
+	return &ec2.CreateSubnetOutput{Subnet: &subnet}, nil
+}
+
+func (f *fakeLocalStack) ListClustersV2(ctx context.Context, params *kafka.ListClustersV2Input, optFns ...func(*kafka.Options)) (*kafka.ListClustersV2Output, error) {
+	return &kafka.ListClustersV2Output{ClusterInfoList: f.clusters}, nil
+}
+
+func (f *fakeLocalStack) CreateClusterV2(ctx context.Context, params *kafka.CreateClusterV2Input, optFns ...func(*kafka.Options)) (*kafka.CreateClusterV2Output, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.lastCreate = params
+	arn := "arn:aws:kafka:us-east-1:000000000000:cluster/" + aws.ToString(params.ClusterName) + "/1"
+	f.clusters = append(f.clusters, kafkatypes.Cluster{ClusterName: params.ClusterName, ClusterArn: aws.String(arn)})
+	return &kafka.CreateClusterV2Output{ClusterArn: aws.String(arn)}, nil
+}
+
+func TestSeedAWS_CreatesThenReuses(t *testing.T) {
+	fake := &fakeLocalStack{}
+	fixture := DefaultFixture()
+
+	first, err := SeedAWS(context.Background(), fake, fake, "us-east-1", fixture)
+	require.NoError(t, err)
+	assert.True(t, first.ClusterCreated)
+	assert.Equal(t, []string{"subnet-0", "subnet-1"}, first.SubnetIDs)
+	assert.Equal(t, "us-east-1b", aws.ToString(fake.subnets[1].AvailabilityZone))
+	require.NotNil(t, fake.lastCreate)
+	assert.Equal(t, first.SubnetIDs, fake.lastCreate.Provisioned.BrokerNodeGroupInfo.ClientSubnets)
+	assert.Equal(t, "true", fake.lastCreate.Tags[SeedTag])
+
+	second, err := SeedAWS(context.Background(), fake, fake, "us-east-1", fixture)
+	require.NoError(t, err)
+	assert.False(t, second.ClusterCreated)
+	assert.Equal(t, first.ClusterArn, second.ClusterArn)
+	assert.Equal(t, first.SubnetIDs, second.SubnetIDs)
+	assert.Len(t, fake.vpcs, 1)
+	assert.Len(t, fake.subnets, 2)
+	assert.Len(t, fake.clusters, 1)
+}
+
+func TestSeedAWS_ExplainsMissingMSKSupport(t *testing.T) {
+	fake := &fakeLocalStack{createErr: errors.New("API for service 'kafka' not yet implemented or pro feature")}
+
+	_, err := SeedAWS(context.Background(), fake, fake, "us-east-1", DefaultFixture())
+	assert.ErrorContains(t, err, "--skip-aws")
+	assert.ErrorContains(t, err, "pro feature")
+}
@@ -0,0 +1,65 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DefaultConfluentCloudBaseURL is the Confluent Cloud API host the API key
+// check calls. Overridable so tests can point it at a local stub.
+const DefaultConfluentCloudBaseURL = "https://api.confluent.cloud"
+
+// CheckConfluentCloudAPIKey verifies a Cloud API key by listing one
+// environment. Cluster-scoped (Kafka) API keys are rejected by this endpoint,
+// which is exactly the mix-up the check exists to catch.
+func CheckConfluentCloudAPIKey(ctx context.Context, httpClient *http.Client, baseURL, apiKey, apiSecret string) Result {
+	result := Result{Check: "Confluent Cloud API key"}
+
+	if apiKey == "" && apiSecret == "" {
+		result.Status = StatusSkip
+		result.Detail = "no --cc-api-key given"
+		return result
+	}
+	if apiKey == "" || apiSecret == "" {
+		result.Status = StatusFail
+		result.Detail = "only one of --cc-api-key and --cc-api-secret was given"
+		result.Fix = "pass both --cc-api-key and --cc-api-secret"
+		return result
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/org/v2/environments?page_size=1", nil)
+	if err != nil {
+		result.Status = StatusWarn
+		result.Detail = fmt.Sprintf("failed to build request: %v", err)
+		return result
+	}
+	req.SetBasicAuth(apiKey, apiSecret)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		result.Status = StatusWarn
+		result.Detail = fmt.Sprintf("could not reach %s: %v", baseURL, err)
+		result.Fix = "check network access and any HTTPS proxy settings (HTTPS_PROXY)"
+		return result
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		result.Status = StatusPass
+		result.Detail = fmt.Sprintf("API key %s is valid", apiKey)
+	case resp.StatusCode == http.StatusUnauthorized:
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("API key %s was rejected (invalid, deleted, or a cluster API key rather than a Cloud API key)", apiKey)
+		result.Fix = "create a Cloud API key with `confluent api-key create --resource cloud`"
+	case resp.StatusCode == http.StatusForbidden:
+		result.Status = StatusWarn
+		result.Detail = fmt.Sprintf("API key %s is valid but cannot list environments", apiKey)
+		result.Fix = "grant the key's service account the EnvironmentAdmin or OrganizationAdmin role for the target environment"
+	default:
+		result.Status = StatusWarn
+		result.Detail = fmt.Sprintf("unexpected response from Confluent Cloud: %s", resp.Status)
+	}
+	return result
+}
@@ -0,0 +1,178 @@
+// Package doctor implements the environment checks behind `kcp doctor`: AWS
+// credentials, the IAM permissions a command documents, Confluent Cloud API
+// key validity and local prerequisites. Each check returns a Result carrying
+// an actionable fix rather than an error, so one failing check never hides
+// the others.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+type Result struct {
+	Check  string
+	Status Status
+	Detail string
+	// Fix is the action that resolves a warn or fail result.
+	Fix string
+}
+
+// CountFailures returns the number of failed checks.
+func CountFailures(results []Result) int {
+	count := 0
+	for _, r := range results {
+		if r.Status == StatusFail {
+			count++
+		}
+	}
+	return count
+}
+
+// CallerIdentityAPI is the subset of sts.Client used to verify credentials.
+type CallerIdentityAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// IAMSimulationAPI is the subset of iam.Client used to simulate a policy.
+type IAMSimulationAPI interface {
+	GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error)
+	SimulatePrincipalPolicy(ctx context.Context, params *iam.SimulatePrincipalPolicyInput, optFns ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error)
+}
+
+// CheckAWSCredentials resolves the caller identity of the default credential
+// chain. The returned ARN is empty when the check fails.
+func CheckAWSCredentials(ctx context.Context, api CallerIdentityAPI) (string, Result) {
+	result := Result{Check: "AWS credentials"}
+
+	output, err := api.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("no valid AWS credentials: %v", err)
+		result.Fix = "configure credentials with `aws configure`, `aws sso login` or the AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN environment variables, and check AWS_PROFILE"
+		return "", result
+	}
+
+	callerArn := aws.ToString(output.Arn)
+	result.Status = StatusPass
+	result.Detail = fmt.Sprintf("authenticated as %s (account %s)", callerArn, aws.ToString(output.Account))
+	return callerArn, result
+}
+
+// CheckIAMPermissions simulates actions, the policy documented for the given
+// kcp command, against the caller's identity-based policies. Simulation does
+// not cover resource policies or permission boundaries set elsewhere, so a
+// pass is strong evidence rather than a guarantee.
+func CheckIAMPermissions(ctx context.Context, api IAMSimulationAPI, callerArn, command string, actions []string) Result {
+	result := Result{Check: fmt.Sprintf("IAM permissions for `kcp %s`", command)}
+
+	if len(actions) == 0 {
+		result.Status = StatusSkip
+		result.Detail = "command documents no AWS IAM permissions"
+		return result
+	}
+
+	principalArn, err := simulationPrincipal(ctx, api, callerArn)
+	if err != nil {
+		result.Status = StatusWarn
+		result.Detail = fmt.Sprintf("cannot simulate permissions: %v", err)
+		result.Fix = fmt.Sprintf("compare your policy with the AWS IAM Permissions section of the `kcp %s` docs", command)
+		return result
+	}
+	if principalArn == "" {
+		result.Status = StatusPass
+		result.Detail = "root account credentials have every permission (use an IAM role or user for day-to-day work)"
+		return result
+	}
+
+	var denied []string
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(api, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalArn),
+		ActionNames:     actions,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			result.Status = StatusWarn
+			result.Detail = fmt.Sprintf("failed to simulate policy for %s: %v", principalArn, err)
+			if isAccessDenied(err) {
+				result.Fix = "grant iam:SimulatePrincipalPolicy (and iam:GetRole for roles) so kcp doctor can verify permissions"
+			}
+			return result
+		}
+		for _, evaluation := range page.EvaluationResults {
+			if evaluation.EvalDecision != iamtypes.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, aws.ToString(evaluation.EvalActionName))
+			}
+		}
+	}
+
+	if len(denied) > 0 {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("%s is denied %d of %d actions: %s", principalArn, len(denied), len(actions), strings.Join(denied, ", "))
+		result.Fix = fmt.Sprintf("attach the policy from the AWS IAM Permissions section of the `kcp %s` docs to %s", command, principalArn)
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Detail = fmt.Sprintf("%s is allowed all %d actions", principalArn, len(actions))
+	return result
+}
+
+// simulationPrincipal maps a caller identity ARN to the IAM ARN that
+// SimulatePrincipalPolicy accepts. Assumed-role sessions map to their role,
+// looked up by name because the session ARN drops the role path. Root
+// credentials return an empty ARN: they cannot be simulated and need not be.
+func simulationPrincipal(ctx context.Context, api IAMSimulationAPI, callerArn string) (string, error) {
+	// arn:<partition>:<service>::<account>:<resource>
+	parts := strings.SplitN(callerArn, ":", 6)
+	if len(parts) != 6 {
+		return "", fmt.Errorf("unrecognised caller ARN %q", callerArn)
+	}
+	service, resource := parts[2], parts[5]
+
+	switch {
+	case service == "iam" && resource == "root":
+		return "", nil
+	case service == "iam" && strings.HasPrefix(resource, "user/"):
+		return callerArn, nil
+	case service == "sts" && strings.HasPrefix(resource, "assumed-role/"):
+		segments := strings.Split(resource, "/")
+		if len(segments) < 2 {
+			return "", fmt.Errorf("unrecognised assumed-role ARN %q", callerArn)
+		}
+		output, err := api.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(segments[1])})
+		if err != nil {
+			return "", fmt.Errorf("failed to look up role %s: %w", segments[1], err)
+		}
+		return aws.ToString(output.Role.Arn), nil
+	default:
+		return "", fmt.Errorf("caller %s is not an IAM user or role", callerArn)
+	}
+}
+
+func isAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		return code == "AccessDenied" || code == "AccessDeniedException"
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSTS struct {
+	arn string
+	err error
+}
+
+func (f fakeSTS) GetCallerIdentity(context.Context, *sts.GetCallerIdentityInput, ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sts.GetCallerIdentityOutput{Arn: aws.String(f.arn), Account: aws.String("123456789012")}, nil
+}
+
+type fakeIAM struct {
+	roleArn     string
+	denied      map[string]bool
+	simulateErr error
+
+	simulatedPrincipal string
+}
+
+func (f *fakeIAM) GetRole(_ context.Context, params *iam.GetRoleInput, _ ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	return &iam.GetRoleOutput{Role: &iamtypes.Role{Arn: aws.String(f.roleArn), RoleName: params.RoleName}}, nil
+}
+
+func (f *fakeIAM) SimulatePrincipalPolicy(_ context.Context, params *iam.SimulatePrincipalPolicyInput, _ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+	if f.simulateErr != nil {
+		return nil, f.simulateErr
+	}
+	f.simulatedPrincipal = aws.ToString(params.PolicySourceArn)
+
+	output := &iam.SimulatePrincipalPolicyOutput{}
+	for _, action := range params.ActionNames {
+		decision := iamtypes.PolicyEvaluationDecisionTypeAllowed
+		if f.denied[action] {
+			decision = iamtypes.PolicyEvaluationDecisionTypeImplicitDeny
+		}
+		output.EvaluationResults = append(output.EvaluationResults, iamtypes.EvaluationResult{
+			EvalActionName: aws.String(action),
+			EvalDecision:   decision,
+		})
+	}
+	return output, nil
+}
+
+func TestCheckAWSCredentials(t *testing.T) {
+	arn, result := CheckAWSCredentials(context.Background(), fakeSTS{arn: "arn:aws:iam::123456789012:user/alice"})
+	assert.Equal(t, "arn:aws:iam::123456789012:user/alice", arn)
+	assert.Equal(t, StatusPass, result.Status)
+	assert.Contains(t, result.Detail, "123456789012")
+
+	arn, result = CheckAWSCredentials(context.Background(), fakeSTS{err: errors.New("no credentials")})
+	assert.Empty(t, arn)
+	assert.Equal(t, StatusFail, result.Status)
+	assert.NotEmpty(t, result.Fix)
+}
+
+func TestCheckIAMPermissions(t *testing.T) {
+	actions := []string{"kafka:ListClustersV2", "ec2:DescribeSubnets"}
+
+	t.Run("assumed role resolves to role ARN with path", func(t *testing.T) {
+		api := &fakeIAM{roleArn: "arn:aws:iam::123456789012:role/platform/kcp"}
+		result := CheckIAMPermissions(context.Background(), api, "arn:aws:sts::123456789012:assumed-role/kcp/session", "discover", actions)
+		assert.Equal(t, StatusPass, result.Status)
+		assert.Equal(t, "arn:aws:iam::123456789012:role/platform/kcp", api.simulatedPrincipal)
+	})
+
+	t.Run("denied actions fail with the list", func(t *testing.T) {
+		api := &fakeIAM{denied: map[string]bool{"ec2:DescribeSubnets": true}}
+		result := CheckIAMPermissions(context.Background(), api, "arn:aws:iam::123456789012:user/alice", "discover", actions)
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Detail, "denied 1 of 2 actions: ec2:DescribeSubnets")
+		assert.Contains(t, result.Fix, "`kcp discover`")
+	})
+
+	t.Run("simulation access denied is a warning", func(t *testing.T) {
+		api := &fakeIAM{simulateErr: &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}}
+		result := CheckIAMPermissions(context.Background(), api, "arn:aws:iam::123456789012:user/alice", "discover", actions)
+		assert.Equal(t, StatusWarn, result.Status)
+		assert.Contains(t, result.Fix, "iam:SimulatePrincipalPolicy")
+	})
+
+	t.Run("root account passes without simulating", func(t *testing.T) {
+		api := &fakeIAM{}
+		result := CheckIAMPermissions(context.Background(), api, "arn:aws:iam::123456789012:root", "discover", actions)
+		assert.Equal(t, StatusPass, result.Status)
+		assert.Empty(t, api.simulatedPrincipal)
+	})
+
+	t.Run("no documented actions is skipped", func(t *testing.T) {
+		result := CheckIAMPermissions(context.Background(), &fakeIAM{}, "arn:aws:iam::123456789012:user/alice", "version", nil)
+		assert.Equal(t, StatusSkip, result.Status)
+	})
+}
+
+func TestCheckConfluentCloudAPIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		secret     string
+		statusCode int
+		want       Status
+	}{
+		{name: "valid", key: "KEY", secret: "SECRET", statusCode: http.StatusOK, want: StatusPass},
+		{name: "rejected", key: "KEY", secret: "SECRET", statusCode: http.StatusUnauthorized, want: StatusFail},
+		{name: "missing role", key: "KEY", secret: "SECRET", statusCode: http.StatusForbidden, want: StatusWarn},
+		{name: "not given", want: StatusSkip},
+		{name: "secret missing", key: "KEY", want: StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key, secret, ok := r.BasicAuth()
+				require.True(t, ok)
+				assert.Equal(t, tt.key, key)
+				assert.Equal(t, tt.secret, secret)
+				assert.Equal(t, "/org/v2/environments", r.URL.Path)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			result := CheckConfluentCloudAPIKey(context.Background(), server.Client(), server.URL, tt.key, tt.secret)
+			assert.Equal(t, tt.want, result.Status)
+		})
+	}
+}
+
+func TestCheckTerraform(t *testing.T) {
+	runner := func(output string, err error) utils.CommandRunner {
+		return func(_ context.Context, _, name string, args ...string) ([]byte, error) {
+			assert.Equal(t, "terraform", name)
+			assert.Equal(t, []string{"version", "-json"}, args)
+			return []byte(output), err
+		}
+	}
+
+	tests := []struct {
+		name   string
+		runner utils.CommandRunner
+		want   Status
+	}{
+		{name: "current", runner: runner(`{"terraform_version":"1.9.5"}`, nil), want: StatusPass},
+		{name: "minimum", runner: runner(`{"terraform_version":"1.5.0"}`, nil), want: StatusPass},
+		{name: "too old", runner: runner(`{"terraform_version":"1.4.7"}`, nil), want: StatusFail},
+		{name: "not installed", runner: runner("", fmt.Errorf("exec: %w", exec.ErrNotFound)), want: StatusFail},
+		{name: "unparseable", runner: runner("Terraform v1.9.5", nil), want: StatusWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CheckTerraform(context.Background(), tt.runner)
+			assert.Equal(t, tt.want, result.Status, result.Detail)
+		})
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	assert.True(t, versionAtLeast("1.10.0", "1.5.0"))
+	assert.True(t, versionAtLeast("v1.5.0", "1.5.0"))
+	assert.True(t, versionAtLeast("1.6.0-beta1", "1.5.0"))
+	assert.False(t, versionAtLeast("1.5.0-rc1", "1.5.1"))
+	assert.False(t, versionAtLeast("0.15.5", "1.5.0"))
+}
+
+func TestCountFailures(t *testing.T) {
+	assert.Equal(t, 1, CountFailures([]Result{{Status: StatusPass}, {Status: StatusFail}, {Status: StatusWarn}}))
+}
@@ -0,0 +1,77 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/utils"
+)
+
+// MinTerraformVersion matches the required_version kcp writes into the
+// Terraform it generates.
+const MinTerraformVersion = "1.5.0"
+
+// CheckTerraform verifies that a terraform binary is on PATH and new enough
+// to apply the assets kcp generates.
+func CheckTerraform(ctx context.Context, run utils.CommandRunner) Result {
+	result := Result{Check: "Terraform"}
+	installFix := fmt.Sprintf("install Terraform %s or later: https://developer.hashicorp.com/terraform/install", MinTerraformVersion)
+
+	output, err := run(ctx, "", "terraform", "version", "-json")
+	if err != nil {
+		result.Status = StatusFail
+		if errors.Is(err, exec.ErrNotFound) {
+			result.Detail = "terraform binary not found on PATH"
+		} else {
+			result.Detail = fmt.Sprintf("failed to run `terraform version`: %v", err)
+		}
+		result.Fix = installFix
+		return result
+	}
+
+	var version struct {
+		TerraformVersion string `json:"terraform_version"`
+	}
+	if err := json.Unmarshal(output, &version); err != nil || version.TerraformVersion == "" {
+		result.Status = StatusWarn
+		result.Detail = "could not parse `terraform version -json` output"
+		result.Fix = installFix
+		return result
+	}
+
+	if !versionAtLeast(version.TerraformVersion, MinTerraformVersion) {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("terraform %s is older than the required %s", version.TerraformVersion, MinTerraformVersion)
+		result.Fix = installFix
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Detail = fmt.Sprintf("terraform %s", version.TerraformVersion)
+	return result
+}
+
+// versionAtLeast compares dotted major.minor.patch versions, ignoring any
+// pre-release suffix. Unparseable components compare as zero.
+func versionAtLeast(version, minimum string) bool {
+	parse := func(v string) [3]int {
+		var out [3]int
+		v, _, _ = strings.Cut(strings.TrimPrefix(v, "v"), "-")
+		for i, part := range strings.SplitN(v, ".", 3) {
+			out[i], _ = strconv.Atoi(part)
+		}
+		return out
+	}
+	got, want := parse(version), parse(minimum)
+	for i := range got {
+		if got[i] != want[i] {
+			return got[i] > want[i]
+		}
+	}
+	return true
+}
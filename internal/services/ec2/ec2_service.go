@@ -2,8 +2,11 @@ package ec2
 
 import (
 	"context"
+	"sort"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/confluentinc/kcp/internal/client"
 )
 
@@ -25,3 +28,54 @@ func (e *EC2Service) DescribeSubnets(ctx context.Context, subnetIds []string) (*
 	}
 	return e.client.DescribeSubnets(ctx, input)
 }
+
+func (e *EC2Service) DescribeNetworkInterfaces(ctx context.Context, networkInterfaceIds []string) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: networkInterfaceIds,
+	}
+	return e.client.DescribeNetworkInterfaces(ctx, input)
+}
+
+func (e *EC2Service) DescribeSecurityGroups(ctx context.Context, groupIds []string) (*ec2.DescribeSecurityGroupsOutput, error) {
+	input := &ec2.DescribeSecurityGroupsInput{
+		GroupIds: groupIds,
+	}
+	return e.client.DescribeSecurityGroups(ctx, input)
+}
+
+// DescribeRunningInstancesByTags returns the running instances carrying every
+// tag in tags. An empty value matches any value of that tag key.
+func (e *EC2Service) DescribeRunningInstancesByTags(ctx context.Context, tags map[string]string) ([]ec2types.Instance, error) {
+	input := &ec2.DescribeInstancesInput{Filters: instanceTagFilters(tags)}
+
+	var instances []ec2types.Instance
+	paginator := ec2.NewDescribeInstancesPaginator(e.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range page.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+	}
+	return instances, nil
+}
+
+func instanceTagFilters(tags map[string]string) []ec2types.Filter {
+	filters := []ec2types.Filter{{Name: aws.String("instance-state-name"), Values: []string{"running"}}}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if tags[key] == "" {
+			filters = append(filters, ec2types.Filter{Name: aws.String("tag-key"), Values: []string{key}})
+			continue
+		}
+		filters = append(filters, ec2types.Filter{Name: aws.String("tag:" + key), Values: []string{tags[key]}})
+	}
+	return filters
+}
@@ -131,3 +131,13 @@ func TestEC2Service_DescribeSubnets(t *testing.T) {
 		})
 	}
 }
+
+func TestInstanceTagFilters(t *testing.T) {
+	filters := instanceTagFilters(map[string]string{"role": "kafka-connect", "connect": ""})
+
+	assert.Equal(t, []types.Filter{
+		{Name: aws.String("instance-state-name"), Values: []string{"running"}},
+		{Name: aws.String("tag-key"), Values: []string{"connect"}},
+		{Name: aws.String("tag:role"), Values: []string{"kafka-connect"}},
+	}, filters)
+}
@@ -0,0 +1,155 @@
+// Package environment classifies source clusters into business environments
+// (dev, stage, prod) from their tags or a mapping file, so migration waves and
+// sizing can be planned per environment rather than per flat region list.
+package environment
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// The canonical environments. Other values are kept as written (lowercased)
+// and order after these.
+const (
+	Dev   = "dev"
+	Stage = "stage"
+	Prod  = "prod"
+)
+
+// DefaultTagKeys are the cluster tag keys read when no --tag-key is given,
+// compared case-insensitively, first match wins.
+var DefaultTagKeys = []string{"environment", "env", "stage"}
+
+// aliases maps common spellings onto the canonical environments.
+var aliases = map[string]string{
+	"dev":         Dev,
+	"develop":     Dev,
+	"development": Dev,
+	"sandbox":     Dev,
+	"test":        Dev,
+	"testing":     Dev,
+	"stage":       Stage,
+	"staging":     Stage,
+	"stg":         Stage,
+	"qa":          Stage,
+	"uat":         Stage,
+	"preprod":     Stage,
+	"pre-prod":    Stage,
+	"prod":        Prod,
+	"production":  Prod,
+	"prd":         Prod,
+	"live":        Prod,
+}
+
+// Normalize returns the canonical name of an environment value, or the
+// trimmed, lowercased value when it is not a known alias.
+func Normalize(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if canonical, ok := aliases[value]; ok {
+		return canonical
+	}
+	return value
+}
+
+// Rank orders environments for migration: dev first, prod last among the
+// canonical ones, then custom environments, then unclassified clusters.
+func Rank(env string) int {
+	switch env {
+	case Dev:
+		return 0
+	case Stage:
+		return 1
+	case Prod:
+		return 2
+	case "":
+		return 4
+	default:
+		return 3
+	}
+}
+
+// Less orders environments by Rank, custom environments alphabetically.
+func Less(a, b string) bool {
+	if Rank(a) != Rank(b) {
+		return Rank(a) < Rank(b)
+	}
+	return a < b
+}
+
+// FromTags returns the normalized value of the first of keys present in tags,
+// matching keys case-insensitively, or "" when none is.
+func FromTags(tags map[string]string, keys []string) string {
+	for _, key := range keys {
+		for tag, value := range tags {
+			if strings.EqualFold(tag, key) && strings.TrimSpace(value) != "" {
+				return Normalize(value)
+			}
+		}
+	}
+	return ""
+}
+
+// Mapping assigns clusters to environments by name. Each environment lists
+// MSK cluster names, MSK ARNs or OSK cluster IDs, as path.Match globs:
+//
+//	environments:
+//	  prod: [orders-prod, "payments-*"]
+//	  dev: ["*-dev"]
+type Mapping struct {
+	Environments map[string][]string `yaml:"environments"`
+}
+
+// LoadMapping reads a mapping file and rejects malformed glob patterns up
+// front, so a typo does not silently leave clusters unclassified.
+func LoadMapping(file string) (*Mapping, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environment mapping file: %w", err)
+	}
+	var m Mapping
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid environment mapping file %s: %w", file, err)
+	}
+	if len(m.Environments) == 0 {
+		return nil, fmt.Errorf("environment mapping file %s has no environments", file)
+	}
+	for env, patterns := range m.Environments {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("environment mapping file %s: invalid pattern %q under %s: %w", file, pattern, env, err)
+			}
+		}
+	}
+	return &m, nil
+}
+
+// Lookup returns the normalized environment whose patterns match any of ids
+// (e.g. a cluster's name and ARN), or "" when none does. Environments are
+// tried in Rank order, so a cluster matched by both dev and prod patterns is
+// dev.
+func (m *Mapping) Lookup(ids ...string) string {
+	if m == nil {
+		return ""
+	}
+	envs := make([]string, 0, len(m.Environments))
+	for env := range m.Environments {
+		envs = append(envs, env)
+	}
+	sort.Slice(envs, func(i, j int) bool { return Less(Normalize(envs[i]), Normalize(envs[j])) })
+
+	for _, env := range envs {
+		for _, pattern := range m.Environments[env] {
+			for _, id := range ids {
+				if matched, _ := path.Match(pattern, id); matched && id != "" {
+					return Normalize(env)
+				}
+			}
+		}
+	}
+	return ""
+}
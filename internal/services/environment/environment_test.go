@@ -0,0 +1,77 @@
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"Production": Prod,
+		" prd ":      Prod,
+		"STAGING":    Stage,
+		"uat":        Stage,
+		"Dev":        Dev,
+		"sandbox":    Dev,
+		"DR":         "dr",
+		"":           "",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, Normalize(in), in)
+	}
+}
+
+func TestFromTags(t *testing.T) {
+	tags := map[string]string{"Team": "payments", "ENV": "Production", "Stage": "qa"}
+
+	assert.Equal(t, Prod, FromTags(tags, DefaultTagKeys), "env is tried before stage")
+	assert.Equal(t, Stage, FromTags(tags, []string{"stage"}))
+	assert.Empty(t, FromTags(tags, []string{"environment"}))
+	assert.Empty(t, FromTags(map[string]string{"environment": "  "}, DefaultTagKeys), "blank values do not classify")
+}
+
+func TestLessOrdersCanonicalThenCustomThenUnclassified(t *testing.T) {
+	envs := []string{"", "prod", "dr", "stage", "dev"}
+	sort.Slice(envs, func(i, j int) bool { return Less(envs[i], envs[j]) })
+	assert.Equal(t, []string{"dev", "stage", "prod", "dr", ""}, envs)
+}
+
+func TestMappingLookup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "environments.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`environments:
+  production: [orders-prod, "payments-*"]
+  development: ["*-dev", "payments-sandbox"]
+  stage: ["arn:aws:kafka:us-east-1:123456789012:cluster/billing/*"]
+`), 0600))
+
+	mapping, err := LoadMapping(file)
+	require.NoError(t, err)
+
+	assert.Equal(t, Prod, mapping.Lookup("orders-prod"))
+	assert.Equal(t, Prod, mapping.Lookup("payments-live"))
+	assert.Equal(t, Dev, mapping.Lookup("payments-sandbox"), "dev patterns are tried before prod ones")
+	assert.Equal(t, Stage, mapping.Lookup("billing", "arn:aws:kafka:us-east-1:123456789012:cluster/billing/abc-1"))
+	assert.Empty(t, mapping.Lookup("inventory", ""))
+
+	var none *Mapping
+	assert.Empty(t, none.Lookup("orders-prod"))
+}
+
+func TestLoadMapping_RejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	badPattern := filepath.Join(dir, "bad-pattern.yaml")
+	require.NoError(t, os.WriteFile(badPattern, []byte("environments:\n  prod: [\"orders-[\"]\n"), 0600))
+	_, err := LoadMapping(badPattern)
+	assert.ErrorContains(t, err, `invalid pattern "orders-["`)
+
+	empty := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(empty, []byte("environments: {}\n"), 0600))
+	_, err = LoadMapping(empty)
+	assert.ErrorContains(t, err, "has no environments")
+}
@@ -0,0 +1,229 @@
+// Package export flattens a kcp state file into inventory tables — clusters,
+// topics, ACLs, connectors and costs — and writes them as an Excel workbook
+// or CSV files, for migration programs tracked in spreadsheets.
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+// Sheet is one inventory table. Cells are strings, ints or floats so the
+// workbook keeps numbers sortable.
+type Sheet struct {
+	Name   string
+	Header []string
+	Rows   [][]any
+}
+
+// sourceRef identifies the cluster a row belongs to.
+type sourceRef struct {
+	source  string
+	region  string
+	cluster string
+}
+
+func (s sourceRef) cells() []any {
+	return []any{s.source, s.region, s.cluster}
+}
+
+var sourceHeader = []string{"Source", "Region", "Cluster"}
+
+// BuildInventory returns the clusters, topics, ACLs, connectors and costs
+// sheets, in that order. Sheets with no rows are still returned so the
+// workbook layout is the same for every state file.
+func BuildInventory(state report.ProcessedState) []Sheet {
+	clusters := Sheet{Name: "Clusters", Header: append(append([]string{}, sourceHeader...),
+		"ARN / ID", "Type", "Kafka Version", "Instance Type", "Brokers", "Storage Mode", "Auth", "Topics", "Partitions", "ACLs", "Connectors")}
+	topics := Sheet{Name: "Topics", Header: append(append([]string{}, sourceHeader...),
+		"Topic", "Partitions", "Replication Factor", "Cleanup Policy", "Retention (ms)", "Size (GB)")}
+	acls := Sheet{Name: "ACLs", Header: append(append([]string{}, sourceHeader...),
+		"Principal", "Resource Type", "Resource Name", "Pattern Type", "Operation", "Permission", "Host")}
+	connectors := Sheet{Name: "Connectors", Header: append(append([]string{}, sourceHeader...),
+		"Connector", "Kind", "State", "Connector Class", "Tasks Max", "Failure Reason")}
+	costs := Sheet{Name: "Costs", Header: []string{"Region", "Start", "End", "Service", "Usage Type",
+		"Unblended Cost", "Blended Cost", "Amortized Cost", "Net Amortized Cost", "Net Unblended Cost"}}
+
+	for _, source := range state.Sources {
+		if source.MSKData != nil {
+			for _, region := range source.MSKData.Regions {
+				for _, c := range region.Clusters {
+					ref := sourceRef{source: "MSK", region: region.Name, cluster: c.Name}
+					clusters.Rows = append(clusters.Rows, mskClusterRow(ref, c))
+					topics.Rows = append(topics.Rows, topicRows(ref, c.KafkaAdminClientInformation)...)
+					acls.Rows = append(acls.Rows, aclRows(ref, c.KafkaAdminClientInformation)...)
+					connectors.Rows = append(connectors.Rows, mskConnectorRows(ref, c.AWSClientInformation.Connectors)...)
+					connectors.Rows = append(connectors.Rows, selfManagedConnectorRows(ref, c.KafkaAdminClientInformation)...)
+				}
+				costs.Rows = append(costs.Rows, costRows(region)...)
+			}
+		}
+		if source.OSKData != nil {
+			for _, c := range source.OSKData.Clusters {
+				ref := sourceRef{source: "Apache Kafka", region: c.Metadata.Location, cluster: c.ID}
+				clusters.Rows = append(clusters.Rows, oskClusterRow(ref, c))
+				topics.Rows = append(topics.Rows, topicRows(ref, c.KafkaAdminClientInformation)...)
+				acls.Rows = append(acls.Rows, aclRows(ref, c.KafkaAdminClientInformation)...)
+				connectors.Rows = append(connectors.Rows, selfManagedConnectorRows(ref, c.KafkaAdminClientInformation)...)
+			}
+		}
+	}
+
+	return []Sheet{clusters, topics, acls, connectors, costs}
+}
+
+func mskClusterRow(ref sourceRef, c report.ProcessedCluster) []any {
+	info := c.AWSClientInformation.MskClusterConfig
+	var kafkaVersion, instanceType, storageMode string
+	var brokers int
+	if prov := info.Provisioned; prov != nil {
+		if prov.CurrentBrokerSoftwareInfo != nil {
+			kafkaVersion = aws.ToString(prov.CurrentBrokerSoftwareInfo.KafkaVersion)
+		}
+		if prov.BrokerNodeGroupInfo != nil {
+			instanceType = aws.ToString(prov.BrokerNodeGroupInfo.InstanceType)
+		}
+		brokers = int(aws.ToInt32(prov.NumberOfBrokerNodes))
+		storageMode = string(prov.StorageMode)
+	}
+	clusterType := string(info.ClusterType)
+	if clusterType == "" {
+		clusterType = string(kafkatypes.ClusterTypeProvisioned)
+	}
+
+	admin := c.KafkaAdminClientInformation
+	row := ref.cells()
+	return append(row, c.Arn, clusterType, kafkaVersion, instanceType, brokers, storageMode, string(admin.AuthType),
+		topicCount(admin), partitionCount(admin), len(admin.Acls), len(c.AWSClientInformation.Connectors)+selfManagedCount(admin))
+}
+
+func oskClusterRow(ref sourceRef, c report.ProcessedOSKCluster) []any {
+	admin := c.KafkaAdminClientInformation
+	row := ref.cells()
+	return append(row, c.ID, "OSK", c.Metadata.KafkaVersion, "", len(c.BootstrapServers), "", string(admin.AuthType),
+		topicCount(admin), partitionCount(admin), len(admin.Acls), selfManagedCount(admin))
+}
+
+func topicCount(admin types.KafkaAdminClientInformation) int {
+	if admin.Topics == nil {
+		return 0
+	}
+	return len(admin.Topics.Details)
+}
+
+func partitionCount(admin types.KafkaAdminClientInformation) int {
+	if admin.Topics == nil {
+		return 0
+	}
+	total := 0
+	for _, t := range admin.Topics.Details {
+		total += t.Partitions
+	}
+	return total
+}
+
+func selfManagedCount(admin types.KafkaAdminClientInformation) int {
+	if admin.SelfManagedConnectors == nil {
+		return 0
+	}
+	return len(admin.SelfManagedConnectors.Connectors)
+}
+
+func topicRows(ref sourceRef, admin types.KafkaAdminClientInformation) [][]any {
+	if admin.Topics == nil {
+		return nil
+	}
+	var rows [][]any
+	for _, t := range admin.Topics.Details {
+		// Size is left blank rather than 0 when log dirs weren't scanned.
+		var size any = ""
+		if len(t.PartitionSizes) > 0 {
+			var total int64
+			for _, s := range t.PartitionSizes {
+				total += s
+			}
+			size = float64(total) / bytesPerGB
+		}
+		row := ref.cells()
+		rows = append(rows, append(row, t.Name, t.Partitions, t.ReplicationFactor,
+			topicConfig(t, "cleanup.policy"), topicConfig(t, "retention.ms"), size))
+	}
+	return rows
+}
+
+func topicConfig(t types.TopicDetails, key string) string {
+	return aws.ToString(t.Configurations[key])
+}
+
+func aclRows(ref sourceRef, admin types.KafkaAdminClientInformation) [][]any {
+	var rows [][]any
+	for _, a := range admin.Acls {
+		row := ref.cells()
+		rows = append(rows, append(row, a.Principal, a.ResourceType, a.ResourceName, a.ResourcePatternType, a.Operation, a.PermissionType, a.Host))
+	}
+	return rows
+}
+
+func mskConnectorRows(ref sourceRef, connectors []types.ConnectorSummary) [][]any {
+	var rows [][]any
+	for _, c := range connectors {
+		var reason string
+		if c.Diagnostics != nil {
+			reason = strings.TrimSpace(c.Diagnostics.StateCode + " " + c.Diagnostics.StateMessage)
+		}
+		row := ref.cells()
+		rows = append(rows, append(row, c.ConnectorName, "MSK Connect", c.ConnectorState,
+			c.ConnectorConfiguration["connector.class"], c.ConnectorConfiguration["tasks.max"], reason))
+	}
+	return rows
+}
+
+func selfManagedConnectorRows(ref sourceRef, admin types.KafkaAdminClientInformation) [][]any {
+	if admin.SelfManagedConnectors == nil {
+		return nil
+	}
+	var rows [][]any
+	for _, c := range admin.SelfManagedConnectors.Connectors {
+		row := ref.cells()
+		rows = append(rows, append(row, c.Name, "Self-managed Connect", c.State,
+			configString(c.Config, "connector.class"), configString(c.Config, "tasks.max"), ""))
+	}
+	return rows
+}
+
+func configString(config map[string]any, key string) string {
+	v, ok := config[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func costRows(region report.ProcessedRegion) [][]any {
+	results := append([]report.ProcessedCost(nil), region.Costs.Results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Start != results[j].Start {
+			return results[i].Start < results[j].Start
+		}
+		if results[i].Service != results[j].Service {
+			return results[i].Service < results[j].Service
+		}
+		return results[i].UsageType < results[j].UsageType
+	})
+
+	var rows [][]any
+	for _, r := range results {
+		v := r.Values
+		rows = append(rows, []any{region.Name, r.Start, r.End, r.Service, r.UsageType,
+			v.UnblendedCost, v.BlendedCost, v.AmortizedCost, v.NetAmortizedCost, v.NetUnblendedCost})
+	}
+	return rows
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func testState() report.ProcessedState {
+	msk := report.ProcessedCluster{Name: "orders", Arn: "arn:aws:kafka:us-east-1:123:cluster/orders/abc", Region: "us-east-1"}
+	msk.AWSClientInformation.MskClusterConfig = kafkatypes.Cluster{
+		ClusterType: kafkatypes.ClusterTypeProvisioned,
+		Provisioned: &kafkatypes.Provisioned{
+			CurrentBrokerSoftwareInfo: &kafkatypes.BrokerSoftwareInfo{KafkaVersion: aws.String("3.6.0")},
+			BrokerNodeGroupInfo:       &kafkatypes.BrokerNodeGroupInfo{InstanceType: aws.String("kafka.m5.large")},
+			NumberOfBrokerNodes:       aws.Int32(3),
+		},
+	}
+	msk.AWSClientInformation.Connectors = []types.ConnectorSummary{{
+		ConnectorName:          "pg-sink",
+		ConnectorState:         "FAILED",
+		ConnectorConfiguration: map[string]string{"connector.class": "io.confluent.connect.jdbc.JdbcSinkConnector", "tasks.max": "2"},
+		Diagnostics:            &types.ConnectorDiagnostics{StateCode: "CONNECTOR_FAILED", StateMessage: "unable to reach database"},
+	}}
+	msk.KafkaAdminClientInformation = types.KafkaAdminClientInformation{
+		AuthType: types.AuthTypeIAM,
+		Topics: &types.Topics{Details: []types.TopicDetails{
+			{Name: "orders", Partitions: 2, ReplicationFactor: 3, Configurations: map[string]*string{"cleanup.policy": aws.String("delete")}, PartitionSizes: []int64{bytesPerGB, bytesPerGB}},
+			{Name: "payments", Partitions: 4, ReplicationFactor: 3},
+		}},
+		Acls: []types.Acls{{Principal: "User:app", ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "LITERAL", Operation: "Read", PermissionType: "Allow", Host: "*"}},
+	}
+
+	osk := report.ProcessedOSKCluster{ID: "onprem", BootstrapServers: []string{"a:9092", "b:9092"}, Metadata: types.OSKClusterMetadata{Location: "dc1", KafkaVersion: "3.5.1"}}
+	osk.KafkaAdminClientInformation.SelfManagedConnectors = &types.SelfManagedConnectors{Connectors: []types.SelfManagedConnector{
+		{Name: "s3-sink", State: "RUNNING", Config: map[string]any{"connector.class": "io.confluent.connect.s3.S3SinkConnector"}},
+	}}
+
+	region := report.ProcessedRegion{Name: "us-east-1", Clusters: []report.ProcessedCluster{msk}}
+	region.Costs.Results = []report.ProcessedCost{
+		{Start: "2026-02-01", End: "2026-03-01", Service: "Amazon Managed Streaming for Apache Kafka", UsageType: "USE1-Kafka.m5.large", Values: report.ProcessedCostBreakdown{UnblendedCost: 300}},
+		{Start: "2026-01-01", End: "2026-02-01", Service: "Amazon Managed Streaming for Apache Kafka", UsageType: "USE1-Kafka.m5.large", Values: report.ProcessedCostBreakdown{UnblendedCost: 250.5}},
+	}
+
+	return report.ProcessedState{Sources: []report.ProcessedSource{
+		{Type: types.SourceTypeMSK, MSKData: &report.ProcessedMSKSource{Regions: []report.ProcessedRegion{region}}},
+		{Type: types.SourceTypeOSK, OSKData: &report.ProcessedOSKSource{Clusters: []report.ProcessedOSKCluster{osk}}},
+	}}
+}
+
+func sheetByName(t *testing.T, sheets []Sheet, name string) Sheet {
+	t.Helper()
+	for _, s := range sheets {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("sheet %s not found", name)
+	return Sheet{}
+}
+
+func TestBuildInventory(t *testing.T) {
+	sheets := BuildInventory(testState())
+
+	names := make([]string, len(sheets))
+	for i, s := range sheets {
+		names[i] = s.Name
+		for _, row := range s.Rows {
+			require.Len(t, row, len(s.Header), "row width must match the %s header", s.Name)
+		}
+	}
+	assert.Equal(t, []string{"Clusters", "Topics", "ACLs", "Connectors", "Costs"}, names)
+
+	clusters := sheetByName(t, sheets, "Clusters")
+	require.Len(t, clusters.Rows, 2)
+	assert.Equal(t, []any{"MSK", "us-east-1", "orders", "arn:aws:kafka:us-east-1:123:cluster/orders/abc", "PROVISIONED", "3.6.0", "kafka.m5.large", 3, "", "SASL/IAM", 2, 6, 1, 1}, clusters.Rows[0])
+	assert.Equal(t, []any{"Apache Kafka", "dc1", "onprem", "onprem", "OSK", "3.5.1", "", 2, "", "", 0, 0, 0, 1}, clusters.Rows[1])
+
+	topics := sheetByName(t, sheets, "Topics")
+	require.Len(t, topics.Rows, 2)
+	assert.Equal(t, []any{"MSK", "us-east-1", "orders", "orders", 2, 3, "delete", "", 2.0}, topics.Rows[0])
+	assert.Equal(t, "", topics.Rows[1][8], "size is blank when log dirs weren't scanned")
+
+	connectors := sheetByName(t, sheets, "Connectors")
+	require.Len(t, connectors.Rows, 2)
+	assert.Equal(t, []any{"MSK", "us-east-1", "orders", "pg-sink", "MSK Connect", "FAILED", "io.confluent.connect.jdbc.JdbcSinkConnector", "2", "CONNECTOR_FAILED unable to reach database"}, connectors.Rows[0])
+	assert.Equal(t, "Self-managed Connect", connectors.Rows[1][4])
+
+	costs := sheetByName(t, sheets, "Costs")
+	require.Len(t, costs.Rows, 2)
+	assert.Equal(t, "2026-01-01", costs.Rows[0][1], "costs are sorted by period")
+}
+
+func TestWriteXLSX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.xlsx")
+	require.NoError(t, WriteXLSX(BuildInventory(testState()), path))
+
+	f, err := excelize.OpenFile(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	assert.Equal(t, []string{"Clusters", "Topics", "ACLs", "Connectors", "Costs"}, f.GetSheetList())
+	rows, err := f.GetRows("Topics")
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "Topic", rows[0][3])
+	assert.Equal(t, "orders", rows[1][3])
+	assert.Equal(t, "2", rows[1][4])
+}
+
+func TestWriteCSV(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := WriteCSV(BuildInventory(testState()), dir, "kcp-inventory")
+	require.NoError(t, err)
+	require.Len(t, paths, 5)
+	assert.Equal(t, filepath.Join(dir, "kcp-inventory-acls.csv"), paths[2])
+
+	file, err := os.Open(filepath.Join(dir, "kcp-inventory-costs.csv"))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "250.5", records[1][5])
+}
@@ -0,0 +1,131 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/confluentinc/kcp/internal/summary"
+)
+
+// WriteXLSX writes every sheet to one workbook with a bold, frozen and
+// filterable header row.
+func WriteXLSX(sheets []Sheet, path string) error {
+	f := excelize.NewFile()
+	defer func() { _ = f.Close() }()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %w", err)
+	}
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet.Name); err != nil {
+				return fmt.Errorf("failed to name sheet %s: %w", sheet.Name, err)
+			}
+		} else if _, err := f.NewSheet(sheet.Name); err != nil {
+			return fmt.Errorf("failed to create sheet %s: %w", sheet.Name, err)
+		}
+		if err := writeSheet(f, sheet, headerStyle); err != nil {
+			return fmt.Errorf("failed to write sheet %s: %w", sheet.Name, err)
+		}
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save workbook %s: %w", path, err)
+	}
+	summary.FileWritten(path)
+	return nil
+}
+
+func writeSheet(f *excelize.File, sheet Sheet, headerStyle int) error {
+	header := make([]any, len(sheet.Header))
+	for i, h := range sheet.Header {
+		header[i] = h
+	}
+	if err := f.SetSheetRow(sheet.Name, "A1", &header); err != nil {
+		return err
+	}
+	for i, row := range sheet.Rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(sheet.Name, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	lastHeader, err := excelize.CoordinatesToCellName(len(sheet.Header), 1)
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet.Name, "A1", lastHeader, headerStyle); err != nil {
+		return err
+	}
+	if err := f.SetPanes(sheet.Name, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	lastCell, err := excelize.CoordinatesToCellName(len(sheet.Header), len(sheet.Rows)+1)
+	if err != nil {
+		return err
+	}
+	return f.AutoFilter(sheet.Name, "A1:"+lastCell, nil)
+}
+
+// WriteCSV writes each sheet to <dir>/<prefix>-<sheet>.csv and returns the
+// paths written.
+func WriteCSV(sheets []Sheet, dir, prefix string) ([]string, error) {
+	var paths []string
+	for _, sheet := range sheets {
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.csv", prefix, strings.ToLower(sheet.Name)))
+		if err := writeCSVFile(sheet, path); err != nil {
+			return paths, err
+		}
+		summary.FileWritten(path)
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeCSVFile(sheet Sheet, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(sheet.Header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	for _, row := range sheet.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = csvCell(cell)
+		}
+		if err := w.Write(record); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func csvCell(cell any) string {
+	switch v := cell.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,240 @@
+package flowlogs
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/athena"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// athenaQuerier runs Athena queries. *athena.AthenaService satisfies it.
+type athenaQuerier interface {
+	Query(ctx context.Context, query string, opts athena.QueryOpts) ([][]string, error)
+}
+
+// QueryOpts locates the Athena table over VPC Flow Logs delivered to S3, as
+// created by the AWS documentation's CREATE TABLE statement for the default
+// log format.
+type QueryOpts struct {
+	Table string
+	athena.QueryOpts
+}
+
+// BrokerFlow is the accepted traffic from one address to one broker ENI port.
+type BrokerFlow struct {
+	ENI       string
+	Address   string
+	Port      int32
+	Flows     int64
+	Packets   int64
+	Bytes     int64
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+type FlowLogsService struct {
+	athena athenaQuerier
+}
+
+func NewFlowLogsService(athena *athena.AthenaService) *FlowLogsService {
+	return &FlowLogsService{athena: athena}
+}
+
+// eniPattern keeps ENI IDs, which are spliced into the query, to their form.
+var eniPattern = regexp.MustCompile(`^eni-[0-9a-f]+$`)
+
+// brokerFlowsQuery sums the accepted flows to the broker addresses on Kafka
+// ports, per broker ENI, source address and port. Flow log start and end are
+// Unix seconds.
+const brokerFlowsQuery = `SELECT
+  interface_id,
+  srcaddr,
+  dstport,
+  count(*) AS flows,
+  sum(packets) AS packets,
+  sum(bytes) AS bytes,
+  min(start) AS first_seen,
+  max("end") AS last_seen
+FROM %s
+WHERE interface_id IN (%s)
+  AND dstaddr IN (%s)
+  AND dstport IN (%s)
+  AND action = 'ACCEPT'
+  AND start >= %d
+  AND start < %d
+GROUP BY 1, 2, 3`
+
+// QueryBrokerFlows returns the accepted traffic to the brokers' ENIs on MSK
+// broker ports between start and end.
+func (s *FlowLogsService) QueryBrokerFlows(ctx context.Context, opts QueryOpts, brokers []types.BrokerENI, start, end time.Time) ([]BrokerFlow, error) {
+	if err := athena.ValidateTableName(opts.Table); err != nil {
+		return nil, err
+	}
+	if len(brokers) == 0 {
+		return nil, nil
+	}
+
+	var enis, addresses []string
+	for _, broker := range brokers {
+		if !eniPattern.MatchString(broker.NetworkInterfaceId) {
+			return nil, fmt.Errorf("invalid broker ENI ID '%s'", broker.NetworkInterfaceId)
+		}
+		if _, err := netip.ParseAddr(broker.PrivateIpAddress); err != nil {
+			return nil, fmt.Errorf("invalid broker address '%s' for ENI %s", broker.PrivateIpAddress, broker.NetworkInterfaceId)
+		}
+		enis = append(enis, "'"+broker.NetworkInterfaceId+"'")
+		addresses = append(addresses, "'"+broker.PrivateIpAddress+"'")
+	}
+	var ports []string
+	for _, port := range types.MSKBrokerPorts {
+		ports = append(ports, strconv.Itoa(int(port)))
+	}
+
+	rows, err := s.athena.Query(ctx, fmt.Sprintf(brokerFlowsQuery, opts.Table,
+		strings.Join(enis, ", "), strings.Join(addresses, ", "), strings.Join(ports, ", "),
+		start.Unix(), end.Unix()), opts.QueryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]BrokerFlow, 0, len(rows))
+	for _, row := range rows {
+		flow, err := parseFlowRow(row)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
+
+// parseFlowRow reads a row of brokerFlowsQuery's results.
+func parseFlowRow(row []string) (BrokerFlow, error) {
+	if len(row) != 8 {
+		return BrokerFlow{}, fmt.Errorf("unexpected Athena result row with %d columns", len(row))
+	}
+	var numbers [6]int64
+	for i := range numbers {
+		n, err := strconv.ParseInt(row[i+2], 10, 64)
+		if err != nil {
+			return BrokerFlow{}, fmt.Errorf("invalid number '%s' in Athena results: %w", row[i+2], err)
+		}
+		numbers[i] = n
+	}
+	return BrokerFlow{
+		ENI:       row[0],
+		Address:   row[1],
+		Port:      int32(numbers[0]),
+		Flows:     numbers[1],
+		Packets:   numbers[2],
+		Bytes:     numbers[3],
+		FirstSeen: time.Unix(numbers[4], 0).UTC(),
+		LastSeen:  time.Unix(numbers[5], 0).UTC(),
+	}, nil
+}
+
+// ClientTraffic rolls up the flows to one cluster's brokers by client
+// address, and the client addresses into networks of cidrPrefix bits (IPv6
+// addresses into /64s). Traffic between the cluster's own brokers is
+// replication, not a client, and is left out.
+func ClientTraffic(brokers []types.BrokerENI, flows []BrokerFlow, cidrPrefix int) ([]types.FlowLogClient, []types.FlowLogClientCIDR) {
+	enis := make(map[string]bool, len(brokers))
+	brokerAddresses := make(map[string]bool, len(brokers))
+	for _, broker := range brokers {
+		enis[broker.NetworkInterfaceId] = true
+		brokerAddresses[broker.PrivateIpAddress] = true
+	}
+
+	byAddress := make(map[string]*types.FlowLogClient)
+	for _, flow := range flows {
+		if !enis[flow.ENI] || brokerAddresses[flow.Address] {
+			continue
+		}
+		client, ok := byAddress[flow.Address]
+		if !ok {
+			client = &types.FlowLogClient{Address: flow.Address, FirstSeen: flow.FirstSeen, LastSeen: flow.LastSeen}
+			byAddress[flow.Address] = client
+		}
+		if !slices.Contains(client.BrokerENIs, flow.ENI) {
+			client.BrokerENIs = append(client.BrokerENIs, flow.ENI)
+		}
+		if !slices.Contains(client.Ports, flow.Port) {
+			client.Ports = append(client.Ports, flow.Port)
+		}
+		client.Flows += flow.Flows
+		client.Packets += flow.Packets
+		client.Bytes += flow.Bytes
+		if flow.FirstSeen.Before(client.FirstSeen) {
+			client.FirstSeen = flow.FirstSeen
+		}
+		if flow.LastSeen.After(client.LastSeen) {
+			client.LastSeen = flow.LastSeen
+		}
+	}
+
+	clients := make([]types.FlowLogClient, 0, len(byAddress))
+	byCIDR := make(map[string]*types.FlowLogClientCIDR)
+	for _, client := range byAddress {
+		slices.Sort(client.BrokerENIs)
+		slices.Sort(client.Ports)
+		clients = append(clients, *client)
+
+		cidr := clientCIDR(client.Address, cidrPrefix)
+		network, ok := byCIDR[cidr]
+		if !ok {
+			network = &types.FlowLogClientCIDR{CIDR: cidr}
+			byCIDR[cidr] = network
+		}
+		network.Clients++
+		network.Bytes += client.Bytes
+	}
+	slices.SortFunc(clients, func(a, b types.FlowLogClient) int { return compareAddresses(a.Address, b.Address) })
+
+	cidrs := make([]types.FlowLogClientCIDR, 0, len(byCIDR))
+	for _, network := range byCIDR {
+		cidrs = append(cidrs, *network)
+	}
+	slices.SortFunc(cidrs, func(a, b types.FlowLogClientCIDR) int {
+		if a.Bytes != b.Bytes {
+			return cmp.Compare(b.Bytes, a.Bytes)
+		}
+		return strings.Compare(a.CIDR, b.CIDR)
+	})
+	return clients, cidrs
+}
+
+// clientCIDR is the network of address: cidrPrefix bits for IPv4 and /64 for
+// IPv6. Unparsable addresses are their own network.
+func clientCIDR(address string, cidrPrefix int) string {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return address
+	}
+	addr = addr.Unmap()
+	bits := cidrPrefix
+	if addr.Is6() {
+		bits = 64
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return address
+	}
+	return prefix.String()
+}
+
+func compareAddresses(a, b string) int {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return addrA.Compare(addrB)
+}
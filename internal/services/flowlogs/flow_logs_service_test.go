@@ -0,0 +1,100 @@
+package flowlogs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/athena"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQuerier struct {
+	query string
+	rows  [][]string
+}
+
+func (f *fakeQuerier) Query(_ context.Context, query string, _ athena.QueryOpts) ([][]string, error) {
+	f.query = query
+	return f.rows, nil
+}
+
+var brokers = []types.BrokerENI{
+	{BrokerId: 1, NetworkInterfaceId: "eni-0a1", PrivateIpAddress: "10.0.1.10"},
+	{BrokerId: 2, NetworkInterfaceId: "eni-0b2", PrivateIpAddress: "10.0.2.10"},
+}
+
+func TestQueryBrokerFlows(t *testing.T) {
+	fake := &fakeQuerier{rows: [][]string{
+		{"eni-0a1", "10.0.5.20", "9098", "12", "3400", "560000", "1759276800", "1759363200"},
+	}}
+	service := &FlowLogsService{athena: fake}
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	flows, err := service.QueryBrokerFlows(context.Background(), QueryOpts{Table: "default.vpc_flow_logs"}, brokers, start, start.AddDate(0, 0, 7))
+	require.NoError(t, err)
+
+	assert.Equal(t, []BrokerFlow{{
+		ENI:       "eni-0a1",
+		Address:   "10.0.5.20",
+		Port:      9098,
+		Flows:     12,
+		Packets:   3400,
+		Bytes:     560000,
+		FirstSeen: time.Unix(1759276800, 0).UTC(),
+		LastSeen:  time.Unix(1759363200, 0).UTC(),
+	}}, flows)
+	assert.Contains(t, fake.query, "FROM default.vpc_flow_logs")
+	assert.Contains(t, fake.query, "interface_id IN ('eni-0a1', 'eni-0b2')")
+	assert.Contains(t, fake.query, "dstaddr IN ('10.0.1.10', '10.0.2.10')")
+	assert.Contains(t, fake.query, "dstport IN (9092, 9094, 9096, 9098, 9194, 9196, 9198)")
+	assert.Contains(t, fake.query, "start >= 1790812800")
+}
+
+func TestQueryBrokerFlows_RejectsUnsafeInput(t *testing.T) {
+	fake := &fakeQuerier{}
+	service := &FlowLogsService{athena: fake}
+
+	_, err := service.QueryBrokerFlows(context.Background(), QueryOpts{Table: "flow_logs"},
+		[]types.BrokerENI{{NetworkInterfaceId: "eni-1') OR (1=1", PrivateIpAddress: "10.0.1.10"}}, time.Now(), time.Now())
+	assert.Error(t, err)
+	_, err = service.QueryBrokerFlows(context.Background(), QueryOpts{Table: "flow_logs"},
+		[]types.BrokerENI{{NetworkInterfaceId: "eni-0a1", PrivateIpAddress: "not-an-ip"}}, time.Now(), time.Now())
+	assert.Error(t, err)
+	assert.Empty(t, fake.query)
+}
+
+func TestClientTraffic(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	flows := []BrokerFlow{
+		{ENI: "eni-0a1", Address: "10.0.5.20", Port: 9098, Flows: 2, Packets: 20, Bytes: 1000, FirstSeen: day(2), LastSeen: day(3)},
+		{ENI: "eni-0b2", Address: "10.0.5.20", Port: 9098, Flows: 1, Packets: 10, Bytes: 500, FirstSeen: day(1), LastSeen: day(2)},
+		{ENI: "eni-0a1", Address: "10.0.5.7", Port: 9092, Flows: 1, Packets: 5, Bytes: 100, FirstSeen: day(4), LastSeen: day(4)},
+		{ENI: "eni-0a1", Address: "192.168.3.4", Port: 9094, Flows: 1, Packets: 50, Bytes: 9000, FirstSeen: day(4), LastSeen: day(4)},
+		// replication from the other broker
+		{ENI: "eni-0a1", Address: "10.0.2.10", Port: 9093, Flows: 100, Bytes: 1 << 30},
+		// another cluster's broker
+		{ENI: "eni-0zz", Address: "10.0.5.21", Port: 9098, Flows: 1, Bytes: 1},
+	}
+
+	clients, cidrs := ClientTraffic(brokers, flows, 24)
+
+	assert.Equal(t, []types.FlowLogClient{
+		{Address: "10.0.5.7", BrokerENIs: []string{"eni-0a1"}, Ports: []int32{9092}, Flows: 1, Packets: 5, Bytes: 100, FirstSeen: day(4), LastSeen: day(4)},
+		{Address: "10.0.5.20", BrokerENIs: []string{"eni-0a1", "eni-0b2"}, Ports: []int32{9098}, Flows: 3, Packets: 30, Bytes: 1500, FirstSeen: day(1), LastSeen: day(3)},
+		{Address: "192.168.3.4", BrokerENIs: []string{"eni-0a1"}, Ports: []int32{9094}, Flows: 1, Packets: 50, Bytes: 9000, FirstSeen: day(4), LastSeen: day(4)},
+	}, clients)
+	assert.Equal(t, []types.FlowLogClientCIDR{
+		{CIDR: "192.168.3.0/24", Clients: 1, Bytes: 9000},
+		{CIDR: "10.0.5.0/24", Clients: 2, Bytes: 1600},
+	}, cidrs)
+}
+
+func TestClientCIDR(t *testing.T) {
+	assert.Equal(t, "10.0.0.0/16", clientCIDR("10.0.5.20", 16))
+	assert.Equal(t, "10.0.5.0/24", clientCIDR("::ffff:10.0.5.20", 24))
+	assert.Equal(t, "2600:1f18:1:2::/64", clientCIDR("2600:1f18:1:2:3:4:5:6", 24))
+	assert.Equal(t, "-", clientCIDR("-", 24))
+}
@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// JumpClusterAmi describes an operating system the jump cluster brokers can
+// run: the AMI to look up, and what the user-data templates and Ansible need
+// to log in to the brokers and prepare them for the confluent.platform
+// collection.
+type JumpClusterAmi struct {
+	TfResourceName string
+	Description    string
+	Owner          string
+	NameFilter     string
+	RootDeviceName string
+
+	OSUser            string
+	PythonInterpreter string
+	// PythonInstallCommand installs PythonInterpreter, the Python modules the
+	// confluent.platform collection imports (packaging, PyYAML, setuptools)
+	// and nc. It runs as root.
+	PythonInstallCommand string
+}
+
+var jumpClusterAmis = map[string]JumpClusterAmi{
+	hclrequests.JumpClusterAmiRHEL: {
+		TfResourceName:       "red_hat_linux_ami",
+		Description:          "RHEL 9.6",
+		Owner:                "309956199498",
+		NameFilter:           "RHEL-9.6.0_HVM_GA-*",
+		RootDeviceName:       "/dev/sda1",
+		OSUser:               "ec2-user",
+		PythonInterpreter:    "/usr/bin/python3.11",
+		PythonInstallCommand: "dnf install -y python3.11 python3.11-pip nc && python3.11 -m pip install packaging PyYAML setuptools",
+	},
+	hclrequests.JumpClusterAmiAL2023: {
+		TfResourceName:       "amzn_linux_ami",
+		Description:          "Amazon Linux 2023",
+		Owner:                "137112412989",
+		NameFilter:           "al2023-ami-2023.*-kernel-6.1-x86_64",
+		RootDeviceName:       "/dev/xvda",
+		OSUser:               "ec2-user",
+		PythonInterpreter:    "/usr/bin/python3.11",
+		PythonInstallCommand: "dnf install -y python3.11 python3.11-pip nmap-ncat && python3.11 -m pip install packaging PyYAML setuptools",
+	},
+	hclrequests.JumpClusterAmiUbuntu: {
+		TfResourceName:       "ubuntu_ami",
+		Description:          "Ubuntu 22.04 LTS",
+		Owner:                "099720109477",
+		NameFilter:           "ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-*",
+		RootDeviceName:       "/dev/sda1",
+		OSUser:               "ubuntu",
+		PythonInterpreter:    "/usr/bin/python3",
+		PythonInstallCommand: "apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y python3 python3-packaging python3-yaml python3-setuptools netcat-openbsd",
+	},
+}
+
+// GetJumpClusterAmi returns the AMI for a MigrationWizardRequest.JumpClusterAmi
+// family (rhel, al2023 or ubuntu).
+func GetJumpClusterAmi(family string) (JumpClusterAmi, error) {
+	ami, ok := jumpClusterAmis[family]
+	if !ok {
+		return JumpClusterAmi{}, fmt.Errorf("unsupported jump cluster AMI %q: must be '%s', '%s' or '%s'", family, hclrequests.JumpClusterAmiRHEL, hclrequests.JumpClusterAmiAL2023, hclrequests.JumpClusterAmiUbuntu)
+	}
+	return ami, nil
+}
+
+// DataSourceId returns the reference to the AMI ID of the data source
+// GenerateDataResource emits.
+func (a JumpClusterAmi) DataSourceId() string {
+	return fmt.Sprintf("data.aws_ami.%s.id", a.TfResourceName)
+}
+
+// GenerateDataResource emits the aws_ami data source that resolves the most
+// recent x86_64 image of the AMI family.
+func (a JumpClusterAmi) GenerateDataResource() *hclwrite.Block {
+	return GenerateAmiDataResource(a.TfResourceName, a.Owner, true, map[string]string{
+		"name":                a.NameFilter,
+		"state":               "available",
+		"architecture":        "x86_64",
+		"virtualization-type": "hvm",
+	})
+}
+
+// JumpClusterAmiFor returns the AMI for request.JumpClusterAmiFamily(). An
+// unknown family falls back to RHEL; the command and the UI API reject it
+// before any assets are generated.
+func JumpClusterAmiFor(request hclrequests.MigrationWizardRequest) JumpClusterAmi {
+	ami, err := GetJumpClusterAmi(request.JumpClusterAmiFamily())
+	if err != nil {
+		return jumpClusterAmis[hclrequests.JumpClusterAmiRHEL]
+	}
+	return ami
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+//go:embed cloudwatch_templates/migration_dashboard.json.tpl
+var migrationDashboardTpl string
+
+// GenerateMigrationDashboardTpl returns the CloudWatch dashboard body
+// template. It expects region, cluster_name and jump_cluster_instance_ids (a
+// map of instance name to ID, empty when there is no jump cluster).
+func GenerateMigrationDashboardTpl() string {
+	return migrationDashboardTpl
+}
+
+func GenerateCloudWatchDashboardResource(tfResourceName, dashboardName, dashboardTemplatePath string, templateArgs map[string]hclwrite.Tokens) *hclwrite.Block {
+	dashboardBlock := hclwrite.NewBlock("resource", []string{"aws_cloudwatch_dashboard", tfResourceName})
+	dashboardBody := dashboardBlock.Body()
+	dashboardBody.SetAttributeRaw("dashboard_name", utils.TokensForStringTemplate(dashboardName))
+	dashboardBody.SetAttributeRaw("dashboard_body", utils.TokensForFunctionCall(
+		"templatefile",
+		utils.TokensForStringTemplate(fmt.Sprintf("${path.module}/%s", dashboardTemplatePath)),
+		utils.TokensForMap(templateArgs),
+	))
+
+	return dashboardBlock
+}
+
+// CloudWatchMetricAlarm describes a static-threshold alarm on a single metric.
+type CloudWatchMetricAlarm struct {
+	// AlarmName is an HCL string template; Dimensions values are HCL
+	// expressions.
+	AlarmName          string
+	Description        string
+	Namespace          string
+	MetricName         string
+	Dimensions         map[string]string
+	Statistic          string
+	ComparisonOperator string
+	Threshold          int64
+	EvaluationPeriods  int64
+	// ForEach, when set, is a map expression the alarm is repeated over.
+	ForEach string
+	// AlarmActionsVarName, when set, names a variable holding the SNS topic
+	// ARN notified when the alarm fires and recovers.
+	AlarmActionsVarName string
+}
+
+func GenerateCloudWatchMetricAlarmResource(tfResourceName string, alarm CloudWatchMetricAlarm) *hclwrite.Block {
+	alarmBlock := hclwrite.NewBlock("resource", []string{"aws_cloudwatch_metric_alarm", tfResourceName})
+	alarmBody := alarmBlock.Body()
+
+	if alarm.ForEach != "" {
+		alarmBody.SetAttributeRaw("for_each", utils.TokensForResourceReference(alarm.ForEach))
+		alarmBody.AppendNewline()
+	}
+
+	alarmBody.SetAttributeRaw("alarm_name", utils.TokensForStringTemplate(alarm.AlarmName))
+	alarmBody.SetAttributeValue("alarm_description", cty.StringVal(alarm.Description))
+	alarmBody.SetAttributeValue("namespace", cty.StringVal(alarm.Namespace))
+	alarmBody.SetAttributeValue("metric_name", cty.StringVal(alarm.MetricName))
+
+	// Dimension names such as "Cluster Name" are not valid identifiers, so
+	// the keys are written as quoted strings.
+	dimensionNames := make([]string, 0, len(alarm.Dimensions))
+	for name := range alarm.Dimensions {
+		dimensionNames = append(dimensionNames, name)
+	}
+	sort.Strings(dimensionNames)
+
+	var dimensions []hclwrite.ObjectAttrTokens
+	for _, name := range dimensionNames {
+		dimensions = append(dimensions, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForValue(cty.StringVal(name)),
+			Value: utils.TokensForResourceReference(alarm.Dimensions[name]),
+		})
+	}
+	alarmBody.SetAttributeRaw("dimensions", hclwrite.TokensForObject(dimensions))
+
+	alarmBody.SetAttributeValue("statistic", cty.StringVal(alarm.Statistic))
+	alarmBody.SetAttributeValue("period", cty.NumberIntVal(60))
+	alarmBody.SetAttributeValue("evaluation_periods", cty.NumberIntVal(alarm.EvaluationPeriods))
+	alarmBody.SetAttributeValue("threshold", cty.NumberIntVal(alarm.Threshold))
+	alarmBody.SetAttributeValue("comparison_operator", cty.StringVal(alarm.ComparisonOperator))
+	alarmBody.SetAttributeValue("treat_missing_data", cty.StringVal("notBreaching"))
+
+	if alarm.AlarmActionsVarName != "" {
+		alarmBody.AppendNewline()
+		alarmBody.SetAttributeRaw("alarm_actions", utils.TokensForVarReferenceList([]string{alarm.AlarmActionsVarName}))
+		alarmBody.SetAttributeRaw("ok_actions", utils.TokensForVarReferenceList([]string{alarm.AlarmActionsVarName}))
+	}
+
+	return alarmBlock
+}
@@ -0,0 +1,90 @@
+{
+  "widgets": [
+    {
+      "type": "metric",
+      "x": 0,
+      "y": 0,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "title": "Source cluster throughput",
+        "region": "${region}",
+        "view": "timeSeries",
+        "period": 60,
+        "metrics": [
+          [{ "id": "bytes_in", "label": "Bytes in/s", "expression": "SUM(SEARCH('{AWS/Kafka,\"Broker ID\",\"Cluster Name\"} MetricName=\"BytesInPerSec\" \"Cluster Name\"=\"${cluster_name}\"', 'Average', 60))" }],
+          [{ "id": "bytes_out", "label": "Bytes out/s", "expression": "SUM(SEARCH('{AWS/Kafka,\"Broker ID\",\"Cluster Name\"} MetricName=\"BytesOutPerSec\" \"Cluster Name\"=\"${cluster_name}\"', 'Average', 60))" }],
+          [{ "id": "messages_in", "label": "Messages in/s", "expression": "SUM(SEARCH('{AWS/Kafka,\"Broker ID\",\"Cluster Name\"} MetricName=\"MessagesInPerSec\" \"Cluster Name\"=\"${cluster_name}\"', 'Average', 60))", "yAxis": "right" }]
+        ]
+      }
+    },
+    {
+      "type": "metric",
+      "x": 12,
+      "y": 0,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "title": "Cluster link lag",
+        "region": "${region}",
+        "view": "timeSeries",
+        "period": 60,
+        "metrics": [
+          [{ "id": "lag_in", "expression": "SUM(SEARCH('{AWS/Kafka,\"Broker ID\",\"Cluster Name\"} MetricName=\"BytesInPerSec\" \"Cluster Name\"=\"${cluster_name}\"', 'Average', 60))", "visible": false }],
+          [{ "id": "lag_out", "expression": "SUM(SEARCH('{AWS/Kafka,\"Broker ID\",\"Cluster Name\"} MetricName=\"BytesOutPerSec\" \"Cluster Name\"=\"${cluster_name}\"', 'Average', 60))", "visible": false }],
+          [{ "id": "unread", "label": "Bytes/s produced but not read (in - out)", "expression": "IF(lag_in > lag_out, lag_in - lag_out, 0)" }],
+          [{ "id": "time_lag", "label": "Max consumer group time lag (s)", "expression": "MAX(SEARCH('{AWS/Kafka,\"Cluster Name\",\"Consumer Group\",Topic} MetricName=\"EstimatedMaxTimeLag\" \"Cluster Name\"=\"${cluster_name}\"', 'Maximum', 60))", "yAxis": "right" }]
+        ]
+      }
+    },
+    {
+      "type": "metric",
+      "x": 0,
+      "y": 6,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "title": "Source cluster health",
+        "region": "${region}",
+        "view": "timeSeries",
+        "period": 60,
+        "metrics": [
+          ["AWS/Kafka", "OfflinePartitionsCount", "Cluster Name", "${cluster_name}", { "stat": "Maximum" }],
+          ["AWS/Kafka", "ActiveControllerCount", "Cluster Name", "${cluster_name}", { "stat": "Maximum" }],
+          [{ "id": "under_replicated", "label": "UnderReplicatedPartitions", "expression": "SUM(SEARCH('{AWS/Kafka,\"Broker ID\",\"Cluster Name\"} MetricName=\"UnderReplicatedPartitions\" \"Cluster Name\"=\"${cluster_name}\"', 'Maximum', 60))" }]
+        ]
+      }
+    }%{ if length(jump_cluster_instance_ids) > 0 ~},
+    {
+      "type": "metric",
+      "x": 12,
+      "y": 6,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "title": "Jump cluster CPU",
+        "region": "${region}",
+        "view": "timeSeries",
+        "stat": "Average",
+        "period": 60,
+        "metrics": ${jsonencode([for name, id in jump_cluster_instance_ids : ["AWS/EC2", "CPUUtilization", "InstanceId", id, { label = name }]])}
+      }
+    },
+    {
+      "type": "metric",
+      "x": 0,
+      "y": 12,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "title": "Jump cluster status checks",
+        "region": "${region}",
+        "view": "timeSeries",
+        "stat": "Maximum",
+        "period": 60,
+        "metrics": ${jsonencode([for name, id in jump_cluster_instance_ids : ["AWS/EC2", "StatusCheckFailed", "InstanceId", id, { label = name }]])}
+      }
+    }%{ endif ~}
+
+  ]
+}
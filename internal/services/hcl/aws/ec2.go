@@ -12,6 +12,10 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// HelperInstanceType is the instance type of the jump cluster setup host and
+// the external outbound cluster link host.
+const HelperInstanceType = "t2.medium"
+
 // OptionalBlocksConfig represents optional configuration blocks for EC2 instances.
 // The map key is the block name (e.g., "root_block_device", "metadata_options"),
 // and the value is a map of attribute names to their values.
@@ -213,6 +217,9 @@ var jumpClusterWithSaslScramClusterLinksUserDataTpl string
 //go:embed ec2_user_data_templates/jump_cluster_with_iam_cluster_links_user_data.tpl
 var jumpClusterWithIamClusterLinksUserDataTpl string
 
+//go:embed ec2_user_data_templates/jump_cluster_egress_setup.tpl
+var jumpClusterEgressSetupTpl string
+
 //go:embed ec2_user_data_templates/create-external-outbound-cluster-link.tpl
 var createExternalOutboundClusterLinkTpl string
 
@@ -220,19 +227,30 @@ var createExternalOutboundClusterLinkTpl string
 var createExternalOutboundClusterLinkPlaintextTpl string
 
 func GenerateJumpClusterSaslScramSetupHostUserDataTpl() string {
-	return jumpClusterSaslScramSetupHostUserDataTpl
+	return withJumpClusterEgressSetup(jumpClusterSaslScramSetupHostUserDataTpl)
 }
 
 func GenerateJumpClusterSaslIamSetupHostUserDataTpl() string {
-	return jumpClusterSaslIamSetupHostUserDataTpl
+	return withJumpClusterEgressSetup(jumpClusterSaslIamSetupHostUserDataTpl)
 }
 
 func GenerateJumpClusterWithSaslScramClusterLinksUserDataTpl() string {
-	return jumpClusterWithSaslScramClusterLinksUserDataTpl
+	return withJumpClusterEgressSetup(jumpClusterWithSaslScramClusterLinksUserDataTpl)
 }
 
 func GenerateJumpClusterWithIamClusterLinksUserDataTpl() string {
-	return jumpClusterWithIamClusterLinksUserDataTpl
+	return withJumpClusterEgressSetup(jumpClusterWithIamClusterLinksUserDataTpl)
+}
+
+// withJumpClusterEgressSetup inserts the corporate CA bundle and proxy setup
+// right after the shebang of a jump cluster user-data template, so everything
+// the template downloads goes through it. The template must be rendered with
+// the jump_cluster_ca_bundle, jump_cluster_http_proxy,
+// jump_cluster_https_proxy and jump_cluster_no_proxy arguments; each is
+// empty when not used.
+func withJumpClusterEgressSetup(tpl string) string {
+	shebang, rest, _ := strings.Cut(tpl, "\n")
+	return shebang + "\n" + jumpClusterEgressSetupTpl + rest
 }
 
 func GenerateCreateExternalOutboundClusterLinkTpl() string {
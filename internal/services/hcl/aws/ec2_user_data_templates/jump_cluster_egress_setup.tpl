@@ -0,0 +1,52 @@
+%{ if jump_cluster_ca_bundle != "" ~}
+# Trust the corporate CA bundle (e.g. of a TLS-inspecting egress proxy) in the
+# system trust store used by the package manager, curl, wget and Ansible.
+if command -v update-ca-trust > /dev/null 2>&1; then
+  sudo tee /etc/pki/ca-trust/source/anchors/kcp-corporate-ca.pem > /dev/null << 'PEM'
+${jump_cluster_ca_bundle}
+PEM
+  sudo update-ca-trust extract
+else
+  sudo tee /usr/local/share/ca-certificates/kcp-corporate-ca.crt > /dev/null << 'PEM'
+${jump_cluster_ca_bundle}
+PEM
+  sudo update-ca-certificates
+fi
+
+%{ endif ~}
+%{ if jump_cluster_http_proxy != "" || jump_cluster_https_proxy != "" ~}
+# Send outbound HTTP(S) through the corporate proxy: for the rest of this
+# script, for SSH sessions (Ansible reads /etc/environment through pam_env)
+# and for the package manager. Instance metadata and VPC-internal names are
+# always reached directly.
+KCP_NO_PROXY="localhost,127.0.0.1,169.254.169.254,.internal%{ if jump_cluster_no_proxy != "" },${jump_cluster_no_proxy}%{ endif }"
+sudo tee -a /etc/environment > /dev/null << PROXY
+%{ if jump_cluster_http_proxy != "" ~}
+http_proxy=${jump_cluster_http_proxy}
+HTTP_PROXY=${jump_cluster_http_proxy}
+%{ endif ~}
+%{ if jump_cluster_https_proxy != "" ~}
+https_proxy=${jump_cluster_https_proxy}
+HTTPS_PROXY=${jump_cluster_https_proxy}
+%{ endif ~}
+no_proxy=$KCP_NO_PROXY
+NO_PROXY=$KCP_NO_PROXY
+PROXY
+set -a
+. /etc/environment
+set +a
+if [ -f /etc/dnf/dnf.conf ]; then
+  echo "proxy=%{ if jump_cluster_https_proxy != "" }${jump_cluster_https_proxy}%{ else }${jump_cluster_http_proxy}%{ endif }" | sudo tee -a /etc/dnf/dnf.conf > /dev/null
+fi
+if [ -d /etc/apt/apt.conf.d ]; then
+  sudo tee /etc/apt/apt.conf.d/95kcp-proxy > /dev/null << 'APT'
+%{ if jump_cluster_http_proxy != "" ~}
+Acquire::http::Proxy "${jump_cluster_http_proxy}";
+%{ endif ~}
+%{ if jump_cluster_https_proxy != "" ~}
+Acquire::https::Proxy "${jump_cluster_https_proxy}";
+%{ endif ~}
+APT
+fi
+
+%{ endif ~}
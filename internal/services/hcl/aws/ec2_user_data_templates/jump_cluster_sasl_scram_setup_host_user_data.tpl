@@ -39,19 +39,19 @@ schema_registry:
 all:
   vars:
     ansible_connection: ssh
-    ansible_user: ec2-user
+    ansible_user: ${broker_os_user}
     ansible_become: true
     ansible_ssh_private_key_file: /home/ec2-user/broker_key_rsa
-    ansible_python_interpreter: /usr/bin/python3.11
+    ansible_python_interpreter: ${broker_python_interpreter}
 EOF
 
 cat << 'EOF' > /home/ec2-user/wait-for-hosts-ready.yml
 ---
-- name: Wait for SSH and install Python 3.11 on jump cluster instances
+- name: Wait for SSH and install Python on jump cluster instances
   hosts: all
   gather_facts: no
   vars:
-    ansible_python_interpreter: /usr/bin/python3.11
+    ansible_python_interpreter: ${broker_python_interpreter}
 
   tasks:
     - name: Wait for SSH connection to be available
@@ -63,43 +63,38 @@ cat << 'EOF' > /home/ec2-user/wait-for-hosts-ready.yml
       delay: 15
       until: ssh_test.rc == 0
 
-    - name: Install Python 3.11 and pip
+    - name: Install Python, its modules (packaging, PyYAML, setuptools) and nc
       raw: |
         for i in $(seq 1 30); do
-          echo "Attempt $i/30: installing Python 3.11..."
-          if dnf install -y python3.11 python3.11-pip 2>&1; then
-            echo "Python 3.11 installed successfully"
+          echo "Attempt $i/30: installing Python..."
+          if bash -c "${broker_python_install_command}" 2>&1; then
+            echo "Python installed successfully"
             break
           fi
-          echo "dnf failed (likely lock contention), retrying in 10s..."
+          echo "Package install failed (likely lock contention), retrying in 10s..."
           sleep 10
         done
-        python3.11 --version
+        ${broker_python_interpreter} --version
       register: python_install
       changed_when: true
 
-    - name: Install Python modules (packaging, PyYAML, setuptools)
-      raw: python3.11 -m pip install packaging PyYAML setuptools
-      register: pip_install
-      changed_when: true
-
-    - name: Verify Python 3.11
-      raw: python3.11 --version
-      register: python311_check
+    - name: Verify Python
+      raw: ${broker_python_interpreter} --version
+      register: python_check
       changed_when: false
 
     - name: Verify all required modules
-      raw: python3.11 -c "import packaging, yaml, pkg_resources; print('All modules OK')"
+      raw: ${broker_python_interpreter} -c "import packaging, yaml, pkg_resources; print('All modules OK')"
       register: modules_check
       changed_when: false
 
-    - name: Test Ansible ping with Python 3.11
+    - name: Test Ansible ping
       ping:
       register: ansible_ping_test
 
     - name: Display readiness status
       debug:
-        msg: "{{ inventory_hostname }} is ready: {{ python311_check.stdout | trim }}, modules: {{ modules_check.stdout | trim }}"
+        msg: "{{ inventory_hostname }} is ready: {{ python_check.stdout | trim }}, modules: {{ modules_check.stdout | trim }}"
 EOF
 
 cat << 'EOF' > /home/ec2-user/cluster-link-setup.yml
@@ -140,14 +135,14 @@ cat << 'EOF' > /home/ec2-user/cluster-link-setup.yml
 
     - name: Verify cluster link script exists
       stat:
-        path: /home/ec2-user/create-cluster-links.sh
+        path: /home/${broker_os_user}/create-cluster-links.sh
       register: cluster_link_script
       when: inventory_hostname == groups['kafka_broker'][0]
 
     - name: Execute cluster link creation script
-      shell: /home/ec2-user/create-cluster-links.sh 2>/dev/null
+      shell: /home/${broker_os_user}/create-cluster-links.sh 2>/dev/null
       become: yes
-      become_user: ec2-user
+      become_user: ${broker_os_user}
       when:
         - inventory_hostname == groups['kafka_broker'][0]
         - cluster_link_script.stat.exists
@@ -168,7 +163,7 @@ cat << 'EOF' > /home/ec2-user/cluster-link-setup.yml
       shell: |
         kafka-cluster-links --bootstrap-server ${broker_ips[0]}:9092 --list 2>/dev/null
       become: yes
-      become_user: ec2-user
+      become_user: ${broker_os_user}
       register: cluster_links_list
       when: inventory_hostname == groups['kafka_broker'][0]
       tags:
@@ -1,16 +1,21 @@
 #!/bin/bash
-sudo su - ec2-user
-cd /home/ec2-user
-sudo dnf install python3.11 -y
-curl https://bootstrap.pypa.io/get-pip.py -o /home/ec2-user/get-pip.py
-sudo python3.11 /home/ec2-user/get-pip.py
-sudo python3.11 -m pip install packaging
-sudo python3.11 -m pip install PyYAML
-sudo yum install nc -y
+sudo su - ${os_user}
+cd /home/${os_user}
+sudo bash -c "${python_install_command}"
 
-cat > /home/ec2-user/create-cluster-links.sh << 'EOF'
+%{ if source_ca_certificate != "" ~}
+# The source brokers' certificates are issued by a private CA; every jump
+# cluster broker can host the source link, so each one gets the CA.
+sudo mkdir -p /etc/pki/kcp
+sudo tee /etc/pki/kcp/source-ca.pem > /dev/null << 'PEM'
+${source_ca_certificate}
+PEM
+sudo chmod 644 /etc/pki/kcp/source-ca.pem
+
+%{ endif ~}
+cat > /home/${os_user}/create-cluster-links.sh << 'EOF'
 #!/bin/bash
-cd /home/ec2-user/
+cd /home/${os_user}/
 
 #
 # Create Source -> CP cluster link
@@ -18,16 +23,20 @@ cd /home/ec2-user/
 echo "auto.create.mirror.topics.enable=true
 bootstrap.servers=${source_cluster_bootstrap_brokers}
 security.protocol=SASL_SSL
+%{ if source_ca_certificate != "" ~}
+ssl.truststore.type=PEM
+ssl.truststore.location=/etc/pki/kcp/source-ca.pem
+%{ endif ~}
 sasl.mechanism=${source_sasl_scram_mechanism}
 sasl.jaas.config=org.apache.kafka.common.security.scram.ScramLoginModule required \
   username=\"${source_sasl_scram_username}\" \
-  password=\"${source_sasl_scram_password}\";" > /home/ec2-user/client.properties
+  password=\"${source_sasl_scram_password}\";" > /home/${os_user}/client.properties
 
 echo "bootstrap.servers=`hostname`:9092
-security.protocol=PLAINTEXT" > /home/ec2-user/destination-cluster.properties
+security.protocol=PLAINTEXT" > /home/${os_user}/destination-cluster.properties
 
 # Create topic filters JSON to auto-mirror all topics. Note: this takes around ~5 mins.
-cat > /home/ec2-user/topic-filters.json << 'FILTERS'
+cat > /home/${os_user}/topic-filters.json << 'FILTERS'
 {
   "topicFilters": [
     {
@@ -39,7 +48,7 @@ cat > /home/ec2-user/topic-filters.json << 'FILTERS'
 }
 FILTERS
 
-kafka-cluster-links --bootstrap-server `hostname`:9092 --cluster-id ${source_cluster_id} --command-config destination-cluster.properties --create --link ${cluster_link_name}-source-cp --topic-filters-json-file /home/ec2-user/topic-filters.json --config-file client.properties
+kafka-cluster-links --bootstrap-server `hostname`:9092 --cluster-id ${source_cluster_id} --command-config destination-cluster.properties --create --link ${cluster_link_name}-source-cp --topic-filters-json-file /home/${os_user}/topic-filters.json --config-file client.properties
 
 #
 # Create CP -> CC destination cluster link
@@ -66,11 +75,11 @@ security.protocol=SASL_SSL
 sasl.mechanism=PLAIN
 sasl.jaas.config=org.apache.kafka.common.security.plain.PlainLoginModule required username='${confluent_cloud_cluster_key}' password='${confluent_cloud_cluster_secret}';
 local.listener.name=BROKER
-local.security.protocol=PLAINTEXT" > /home/ec2-user/cp-cc-link.properties
+local.security.protocol=PLAINTEXT" > /home/${os_user}/cp-cc-link.properties
 
 kafka-cluster-links --bootstrap-server `hostname`:9092 --create --link ${cluster_link_name} --config-file cp-cc-link.properties --cluster-id ${confluent_cloud_cluster_id} --command-config destination-cluster.properties
 
 EOF
 
-chmod +x /home/ec2-user/create-cluster-links.sh
-chown ec2-user:ec2-user /home/ec2-user/create-cluster-links.sh
+chmod +x /home/${os_user}/create-cluster-links.sh
+chown ${os_user}:${os_user} /home/${os_user}/create-cluster-links.sh
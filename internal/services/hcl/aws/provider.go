@@ -12,7 +12,7 @@ const (
 )
 
 var AwsProviderVariables = []hcltypes.TerraformVariable{
-	{Name: VarAwsRegion, Description: "The AWS region", Sensitive: false, Type: "string"},
+	{Name: VarAwsRegion, Description: "The AWS region", Sensitive: false, Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateAWSRegion(VarAwsRegion)}},
 }
 
 // AddRequiredProvider adds the AWS required provider to a required_providers block body.
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"fmt"
+
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
@@ -37,3 +39,58 @@ func GenerateRoute53RecordResource(tfResourceName, route53ZoneIdRef, recordName,
 
 	return route53RecordBlock
 }
+
+// IgnoreRoute53ZoneVpcChanges stops Terraform from removing VPC associations
+// made outside the zone's inline vpc block, i.e. by aws_route53_zone_association.
+func IgnoreRoute53ZoneVpcChanges(route53ZoneBlock *hclwrite.Block) {
+	route53ZoneBlock.Body().AppendNewline()
+	lifecycleBlock := route53ZoneBlock.Body().AppendNewBlock("lifecycle", nil)
+	lifecycleBlock.Body().SetAttributeRaw("ignore_changes", utils.TokensForList([]string{"vpc"}))
+}
+
+// GenerateRoute53ZoneAssociationResource associates the private hosted zone with
+// every VPC in a list variable, so they resolve the zone's records too.
+func GenerateRoute53ZoneAssociationResource(tfResourceName, route53ZoneIdRef, vpcIdsVarName string) *hclwrite.Block {
+	associationBlock := hclwrite.NewBlock("resource", []string{"aws_route53_zone_association", tfResourceName})
+	associationBlock.Body().SetAttributeRaw("for_each", utils.TokensForFunctionCall("toset", utils.TokensForVarReference(vpcIdsVarName)))
+	associationBlock.Body().AppendNewline()
+
+	associationBlock.Body().SetAttributeRaw("zone_id", utils.TokensForResourceReference(route53ZoneIdRef))
+	associationBlock.Body().SetAttributeRaw("vpc_id", utils.TokensForResourceReference("each.value"))
+
+	return associationBlock
+}
+
+// GenerateZonalAvailabilityZoneDataSource looks up the name of every zone ID that
+// holds one of the VPC endpoint's subnets, which are created in zone order.
+func GenerateZonalAvailabilityZoneDataSource(tfResourceName, zoneIdsVarName, subnetCidrsVarName string) *hclwrite.Block {
+	azBlock := hclwrite.NewBlock("data", []string{"aws_availability_zone", tfResourceName})
+	azBlock.Body().SetAttributeRaw("for_each", utils.TokensForResourceReference(
+		fmt.Sprintf("toset(slice(var.%[1]s, 0, min(length(var.%[1]s), length(var.%[2]s))))", zoneIdsVarName, subnetCidrsVarName),
+	))
+	azBlock.Body().SetAttributeRaw("zone_id", utils.TokensForResourceReference("each.key"))
+
+	return azBlock
+}
+
+// GenerateRoute53ZonalRecordResource writes a `*.<zone-id>` record per availability
+// zone pointing at the VPC endpoint's DNS name in that zone, so clients reach the
+// brokers of a multi-zone cluster without crossing zones. The zonal DNS name is the
+// regional one with the AZ name appended to its first label.
+func GenerateRoute53ZonalRecordResource(tfResourceName, route53ZoneIdRef, availabilityZonesRef, vpcEndpointDnsEntryRef string) *hclwrite.Block {
+	endpointPrefix := fmt.Sprintf(`split(".", %s)[0]`, vpcEndpointDnsEntryRef)
+
+	route53RecordBlock := hclwrite.NewBlock("resource", []string{"aws_route53_record", tfResourceName})
+	route53RecordBlock.Body().SetAttributeRaw("for_each", utils.TokensForResourceReference(availabilityZonesRef))
+	route53RecordBlock.Body().AppendNewline()
+
+	route53RecordBlock.Body().SetAttributeRaw("zone_id", utils.TokensForResourceReference(route53ZoneIdRef))
+	route53RecordBlock.Body().SetAttributeRaw("name", utils.TokensForStringTemplate("*.${each.key}"))
+	route53RecordBlock.Body().SetAttributeValue("type", cty.StringVal("CNAME"))
+	route53RecordBlock.Body().SetAttributeValue("ttl", cty.NumberIntVal(60))
+	route53RecordBlock.Body().SetAttributeRaw("records", utils.TokensForList([]string{
+		fmt.Sprintf(`format("%%s-%%s%%s", %s, each.value.name, trimprefix(%s, %s))`, endpointPrefix, vpcEndpointDnsEntryRef, endpointPrefix),
+	}))
+
+	return route53RecordBlock
+}
@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateResolverSecurityGroup allows DNS (TCP and UDP 53) from the given CIDR
+// ranges into the Route 53 Resolver endpoints.
+func GenerateResolverSecurityGroup(tfResourceName, vpcIdVarName, cidrRangesVarName string) *hclwrite.Block {
+	securityGroupBlock := hclwrite.NewBlock("resource", []string{"aws_security_group", tfResourceName})
+	securityGroupBlock.Body().SetAttributeRaw("vpc_id", utils.TokensForVarReference(vpcIdVarName))
+	securityGroupBlock.Body().AppendNewline()
+
+	for _, protocol := range []string{"tcp", "udp"} {
+		ingressBlock := securityGroupBlock.Body().AppendNewBlock("ingress", nil)
+		ingressBlock.Body().SetAttributeValue("from_port", cty.NumberIntVal(53))
+		ingressBlock.Body().SetAttributeValue("to_port", cty.NumberIntVal(53))
+		ingressBlock.Body().SetAttributeValue("protocol", cty.StringVal(protocol))
+		ingressBlock.Body().SetAttributeRaw("cidr_blocks", utils.TokensForVarReference(cidrRangesVarName))
+		securityGroupBlock.Body().AppendNewline()
+	}
+
+	egressBlock := securityGroupBlock.Body().AppendNewBlock("egress", nil)
+	egressBlock.Body().SetAttributeValue("from_port", cty.NumberIntVal(0))
+	egressBlock.Body().SetAttributeValue("to_port", cty.NumberIntVal(0))
+	egressBlock.Body().SetAttributeValue("protocol", cty.StringVal("-1"))
+	egressBlock.Body().SetAttributeValue("cidr_blocks", cty.ListVal([]cty.Value{cty.StringVal("0.0.0.0/0")}))
+
+	return securityGroupBlock
+}
+
+// GenerateResolverEndpointResource places a Route 53 Resolver endpoint with one IP
+// address in each of subnetCount subnets of a counted aws_subnet resource.
+// direction is "INBOUND" or "OUTBOUND".
+func GenerateResolverEndpointResource(tfResourceName, name, direction, securityGroupRef, subnetResourceRef string, subnetCount int) *hclwrite.Block {
+	endpointBlock := hclwrite.NewBlock("resource", []string{"aws_route53_resolver_endpoint", tfResourceName})
+	endpointBlock.Body().SetAttributeValue("name", cty.StringVal(name))
+	endpointBlock.Body().SetAttributeValue("direction", cty.StringVal(direction))
+	endpointBlock.Body().SetAttributeRaw("security_group_ids", utils.TokensForList([]string{securityGroupRef}))
+	endpointBlock.Body().AppendNewline()
+
+	for i := range subnetCount {
+		ipAddressBlock := endpointBlock.Body().AppendNewBlock("ip_address", nil)
+		ipAddressBlock.Body().SetAttributeRaw("subnet_id", utils.TokensForResourceReference(fmt.Sprintf("%s[%d].id", subnetResourceRef, i)))
+	}
+
+	return endpointBlock
+}
+
+// GenerateForwardResolverRuleResource forwards queries for domainNameRef through
+// the outbound endpoint to every IP address of the inbound endpoint, which answers
+// them from the private hosted zones of its VPC.
+func GenerateForwardResolverRuleResource(tfResourceName, name, domainNameRef, outboundEndpointRef, inboundEndpointRef string) *hclwrite.Block {
+	ruleBlock := hclwrite.NewBlock("resource", []string{"aws_route53_resolver_rule", tfResourceName})
+	ruleBlock.Body().SetAttributeValue("name", cty.StringVal(name))
+	ruleBlock.Body().SetAttributeRaw("domain_name", utils.TokensForResourceReference(domainNameRef))
+	ruleBlock.Body().SetAttributeValue("rule_type", cty.StringVal("FORWARD"))
+	ruleBlock.Body().SetAttributeRaw("resolver_endpoint_id", utils.TokensForResourceReference(outboundEndpointRef+".id"))
+	ruleBlock.Body().AppendNewline()
+
+	targetIpBlock := ruleBlock.Body().AppendNewBlock("dynamic", []string{"target_ip"})
+	targetIpBlock.Body().SetAttributeRaw("for_each", utils.TokensForResourceReference(inboundEndpointRef+".ip_address"))
+	contentBlock := targetIpBlock.Body().AppendNewBlock("content", nil)
+	contentBlock.Body().SetAttributeRaw("ip", utils.TokensForResourceReference("target_ip.value.ip"))
+
+	return ruleBlock
+}
+
+// GenerateRAMResourceShareResources shares a resource with every AWS account in a
+// list variable through AWS Resource Access Manager. The accounts accept the share
+// (or receive it automatically inside an AWS Organization) and then use the
+// resource as their own.
+func GenerateRAMResourceShareResources(tfResourceName, name, resourceArnRef, accountIdsVarName string) []*hclwrite.Block {
+	shareBlock := hclwrite.NewBlock("resource", []string{"aws_ram_resource_share", tfResourceName})
+	shareBlock.Body().SetAttributeValue("name", cty.StringVal(name))
+	shareBlock.Body().SetAttributeValue("allow_external_principals", cty.True)
+
+	resourceAssociationBlock := hclwrite.NewBlock("resource", []string{"aws_ram_resource_association", tfResourceName})
+	resourceAssociationBlock.Body().SetAttributeRaw("resource_arn", utils.TokensForResourceReference(resourceArnRef))
+	resourceAssociationBlock.Body().SetAttributeRaw("resource_share_arn", utils.TokensForResourceReference(fmt.Sprintf("aws_ram_resource_share.%s.arn", tfResourceName)))
+
+	principalAssociationBlock := hclwrite.NewBlock("resource", []string{"aws_ram_principal_association", tfResourceName})
+	principalAssociationBlock.Body().SetAttributeRaw("for_each", utils.TokensForFunctionCall("toset", utils.TokensForVarReference(accountIdsVarName)))
+	principalAssociationBlock.Body().AppendNewline()
+	principalAssociationBlock.Body().SetAttributeRaw("principal", utils.TokensForResourceReference("each.value"))
+	principalAssociationBlock.Body().SetAttributeRaw("resource_share_arn", utils.TokensForResourceReference(fmt.Sprintf("aws_ram_resource_share.%s.arn", tfResourceName)))
+
+	return []*hclwrite.Block{shareBlock, resourceAssociationBlock, principalAssociationBlock}
+}
@@ -37,3 +37,13 @@ func GenerateRouteTableAssociationResourceWithCount(tfResourceName, subnetIdRefe
 
 	return routeTableAssociationBlock
 }
+
+// AddRouteToRouteTable appends an inline route to a route table generated by GenerateRouteTableResource.
+// Inline routes cannot be mixed with standalone aws_route resources on the same table. targetAttribute names
+// the route target (e.g. "vpc_peering_connection_id" or "transit_gateway_id").
+func AddRouteToRouteTable(routeTableBlock *hclwrite.Block, destinationCidrReference, targetAttribute, targetReference string) {
+	routeBlock := hclwrite.NewBlock("route", nil)
+	routeBlock.Body().SetAttributeRaw("cidr_block", utils.TokensForResourceReference(destinationCidrReference))
+	routeBlock.Body().SetAttributeRaw(targetAttribute, utils.TokensForResourceReference(targetReference))
+	routeTableBlock.Body().AppendBlock(routeBlock)
+}
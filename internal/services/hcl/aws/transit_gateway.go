@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func GenerateTransitGatewayDataSource(tfResourceName, transitGatewayIdVarName string) *hclwrite.Block {
+	transitGatewayBlock := hclwrite.NewBlock("data", []string{"aws_ec2_transit_gateway", tfResourceName})
+	transitGatewayBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference(transitGatewayIdVarName))
+	return transitGatewayBlock
+}
+
+// GenerateRamResourceShareResource creates the AWS RAM share used to share a transit gateway with the
+// Confluent Cloud account, which is always external to the customer organization.
+func GenerateRamResourceShareResource(tfResourceName, name string) *hclwrite.Block {
+	shareBlock := hclwrite.NewBlock("resource", []string{"aws_ram_resource_share", tfResourceName})
+	shareBlock.Body().SetAttributeValue("name", cty.StringVal(name))
+	shareBlock.Body().SetAttributeValue("allow_external_principals", cty.True)
+	return shareBlock
+}
+
+func GenerateRamPrincipalAssociationResource(tfResourceName, principalRef, resourceShareArnRef string) *hclwrite.Block {
+	associationBlock := hclwrite.NewBlock("resource", []string{"aws_ram_principal_association", tfResourceName})
+	associationBlock.Body().SetAttributeRaw("principal", utils.TokensForResourceReference(principalRef))
+	associationBlock.Body().SetAttributeRaw("resource_share_arn", utils.TokensForResourceReference(resourceShareArnRef))
+	return associationBlock
+}
+
+func GenerateRamResourceAssociationResource(tfResourceName, resourceArnRef, resourceShareArnRef string) *hclwrite.Block {
+	associationBlock := hclwrite.NewBlock("resource", []string{"aws_ram_resource_association", tfResourceName})
+	associationBlock.Body().SetAttributeRaw("resource_arn", utils.TokensForResourceReference(resourceArnRef))
+	associationBlock.Body().SetAttributeRaw("resource_share_arn", utils.TokensForResourceReference(resourceShareArnRef))
+	return associationBlock
+}
+
+func GenerateTransitGatewayVpcAttachmentAccepterResource(tfResourceName, transitGatewayAttachmentIdRef string) *hclwrite.Block {
+	accepterBlock := hclwrite.NewBlock("resource", []string{"aws_ec2_transit_gateway_vpc_attachment_accepter", tfResourceName})
+	accepterBlock.Body().SetAttributeRaw("transit_gateway_attachment_id", utils.TokensForResourceReference(transitGatewayAttachmentIdRef))
+	return accepterBlock
+}
@@ -0,0 +1,23 @@
+package aws
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateVpcPeeringConnectionDataSource looks up the peering connection requested by Confluent Cloud
+// from its network VPC (vpcIdRef) to the customer VPC (peerVpcIdRef).
+func GenerateVpcPeeringConnectionDataSource(tfResourceName, vpcIdRef, peerVpcIdRef string) *hclwrite.Block {
+	peeringBlock := hclwrite.NewBlock("data", []string{"aws_vpc_peering_connection", tfResourceName})
+	peeringBlock.Body().SetAttributeRaw("vpc_id", utils.TokensForResourceReference(vpcIdRef))
+	peeringBlock.Body().SetAttributeRaw("peer_vpc_id", utils.TokensForResourceReference(peerVpcIdRef))
+	return peeringBlock
+}
+
+func GenerateVpcPeeringConnectionAccepterResource(tfResourceName, peeringConnectionIdRef string) *hclwrite.Block {
+	accepterBlock := hclwrite.NewBlock("resource", []string{"aws_vpc_peering_connection_accepter", tfResourceName})
+	accepterBlock.Body().SetAttributeRaw("vpc_peering_connection_id", utils.TokensForResourceReference(peeringConnectionIdRef))
+	accepterBlock.Body().SetAttributeValue("auto_accept", cty.True)
+	return accepterBlock
+}
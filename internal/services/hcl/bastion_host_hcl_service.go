@@ -263,9 +263,9 @@ func (s *BastionHostHCLService) generateProvidersTf() string {
 
 func (s *BastionHostHCLService) generateVariablesTf() string {
 	return GenerateVariablesTf([]hcltypes.TerraformVariable{
-		{Name: "vpc_id", Description: "The ID of the VPC", Type: "string"},
-		{Name: "public_subnet_cidr", Description: "CIDR block for the public subnet", Type: "string"},
-		{Name: "aws_region", Description: "The AWS region", Type: "string"},
+		{Name: "vpc_id", Description: "The ID of the VPC", Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("vpc_id", "vpc")}},
+		{Name: "public_subnet_cidr", Description: "CIDR block for the public subnet", Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDR("public_subnet_cidr")}},
+		{Name: "aws_region", Description: "The AWS region", Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateAWSRegion("aws_region")}},
 		{Name: "aws_security_group_ids", Description: "List of string of AWS Security Group Ids", Type: "list(string)"},
 	})
 }
@@ -75,3 +75,43 @@ func GenerateKafkaAPIKey(tfResourceName, envVarName, serviceAccountIdRef, servic
 
 	return apiKeyBlock
 }
+
+// GenerateClusterAPIKey creates a Kafka API key owned by an existing service
+// account resource and scoped to a confluent_kafka_cluster data source.
+func GenerateClusterAPIKey(tfResourceName, displayName, serviceAccountResourceName, clusterDataSourceName string, preventDestroy bool) *hclwrite.Block {
+	serviceAccountRef := "confluent_service_account." + serviceAccountResourceName
+	clusterRef := "data.confluent_kafka_cluster." + clusterDataSourceName
+
+	apiKeyBlock := hclwrite.NewBlock("resource", []string{"confluent_api_key", tfResourceName})
+	apiKeyBlock.Body().SetAttributeValue("display_name", cty.StringVal(displayName))
+	apiKeyBlock.Body().SetAttributeValue("description", cty.StringVal("Kafka API Key for "+displayName))
+	apiKeyBlock.Body().AppendNewline()
+
+	ownerBlock := hclwrite.NewBlock("owner", nil)
+	ownerBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(serviceAccountRef+".id"))
+	ownerBlock.Body().SetAttributeRaw("api_version", utils.TokensForResourceReference(serviceAccountRef+".api_version"))
+	ownerBlock.Body().SetAttributeRaw("kind", utils.TokensForResourceReference(serviceAccountRef+".kind"))
+	apiKeyBlock.Body().AppendBlock(ownerBlock)
+	apiKeyBlock.Body().AppendNewline()
+
+	managedResourceBlock := hclwrite.NewBlock("managed_resource", nil)
+	managedResourceBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(clusterRef+".id"))
+	managedResourceBlock.Body().SetAttributeRaw("api_version", utils.TokensForResourceReference(clusterRef+".api_version"))
+	managedResourceBlock.Body().SetAttributeRaw("kind", utils.TokensForResourceReference(clusterRef+".kind"))
+	managedResourceBlock.Body().AppendNewline()
+
+	environmentApiKeyBlock := hclwrite.NewBlock("environment", nil)
+	environmentApiKeyBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(clusterRef+".environment[0].id"))
+	managedResourceBlock.Body().AppendBlock(environmentApiKeyBlock)
+	apiKeyBlock.Body().AppendBlock(managedResourceBlock)
+	apiKeyBlock.Body().AppendNewline()
+
+	// Skip the readiness check: it lists topics, which fails from outside the
+	// VPC of a private cluster.
+	apiKeyBlock.Body().SetAttributeValue("disable_wait_for_ready", cty.BoolVal(true))
+	apiKeyBlock.Body().AppendNewline()
+
+	_ = utils.GenerateLifecycleBlock(apiKeyBlock, "prevent_destroy", preventDestroy)
+
+	return apiKeyBlock
+}
@@ -1,8 +1,12 @@
 package confluent
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/utils"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -26,8 +30,13 @@ SASL/SCRAM only supports the 'SCRAM-SHA-512' mechanism.
 
 Error: error creating Cluster Link: 401 Unauthorized: Unable to validate cluster link due to error: Client SASL mechanism
 'PLAIN' not enabled in the server, enabled mechanisms are [SCRAM-SHA-512]
+
+The link mode comes from linkModeVarName. In BIDIRECTIONAL mode the source cluster is passed as the remote cluster, and
+the matching link on the source side is created by GenerateReverseClusterLinkResource. An empty linkPrefixVarName
+creates the link without a cluster.link.prefix. tuningConfigs, from ClusterLinkTuningConfigs, are added to the link
+configs as they are.
 */
-func GenerateClusterLinkResource(tfResourceName, sourceClusterIdVarName, targetClusterIdVarName, targetClusterRestEndpointVarName, clusterLinkNameVarName, sourceSaslScramBootstrapServersVarName, sourceSaslScramMechanismVarName, sourceSaslScramUsernameVarName, sourceSaslScramPasswordVarName string) *hclwrite.Block {
+func GenerateClusterLinkResource(tfResourceName, sourceClusterIdVarName, targetClusterIdVarName, targetClusterRestEndpointVarName, clusterLinkNameVarName, linkModeVarName, linkPrefixVarName, sourceSaslScramBootstrapServersVarName, sourceSaslScramMechanismVarName, sourceSaslScramUsernameVarName, sourceSaslScramPasswordVarName string, tuningConfigs []ClusterLinkConfig) *hclwrite.Block {
 	resourceBlock := hclwrite.NewBlock("resource", []string{"null_resource", tfResourceName})
 
 	triggersMap := map[string]hclwrite.Tokens{
@@ -37,10 +46,14 @@ func GenerateClusterLinkResource(tfResourceName, sourceClusterIdVarName, targetC
 		"basic_auth_credentials":       utils.TokensForResourceReference("local.basic_auth_credentials"),
 		"target_cluster_rest_endpoint": utils.TokensForVarReference(targetClusterRestEndpointVarName),
 		"link_name":                    utils.TokensForVarReference(clusterLinkNameVarName),
+		"link_mode":                    utils.TokensForVarReference(linkModeVarName),
 		"source_sasl_scram_mechanism":  utils.TokensForVarReference(sourceSaslScramMechanismVarName),
 		"source_sasl_scram_username":   utils.TokensForVarReference(sourceSaslScramUsernameVarName),
 		"source_sasl_scram_password":   utils.TokensForVarReference(sourceSaslScramPasswordVarName),
 	}
+	if linkPrefixVarName != "" {
+		triggersMap["link_prefix"] = utils.TokensForVarReference(linkPrefixVarName)
+	}
 	resourceBlock.Body().SetAttributeRaw("triggers", utils.TokensForMap(triggersMap))
 
 	resourceBlock.Body().AppendNewline()
@@ -48,15 +61,9 @@ func GenerateClusterLinkResource(tfResourceName, sourceClusterIdVarName, targetC
 	provisionerBlock := resourceBlock.Body().AppendNewBlock("provisioner", []string{"local-exec"})
 
 	// Generate curl command using triggers map
-	curlCommand := generateCreateClusterLinkCurlCommand(triggersMap)
+	curlCommand := generateCreateClusterLinkCurlCommand(linkPrefixVarName != "", tuningConfigs)
 
-	provisionerBlock.Body().SetAttributeRaw("command", hclwrite.Tokens{
-		&hclwrite.Token{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<-EOT")},
-		&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
-		&hclwrite.Token{Type: hclsyntax.TokenStringLit, Bytes: []byte(curlCommand)},
-		&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
-		&hclwrite.Token{Type: hclsyntax.TokenCHeredoc, Bytes: []byte("EOT")},
-	})
+	provisionerBlock.Body().SetAttributeRaw("command", heredocTokens(curlCommand))
 
 	resourceBlock.Body().AppendNewline()
 
@@ -68,25 +75,19 @@ func GenerateClusterLinkResource(tfResourceName, sourceClusterIdVarName, targetC
 
 	destroyCurlCommand := generateDeleteClusterLinkCurlCommandForDestroy()
 
-	destroyProvisionerBlock.Body().SetAttributeRaw("command", hclwrite.Tokens{
-		&hclwrite.Token{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<-EOT")},
-		&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
-		&hclwrite.Token{Type: hclsyntax.TokenStringLit, Bytes: []byte(destroyCurlCommand)},
-		&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
-		&hclwrite.Token{Type: hclsyntax.TokenCHeredoc, Bytes: []byte("EOT")},
-	})
+	destroyProvisionerBlock.Body().SetAttributeRaw("command", heredocTokens(destroyCurlCommand))
 
 	return resourceBlock
 }
 
 // generateCreateClusterLinkCurlCommand generates a curl command using trigger references
-func generateCreateClusterLinkCurlCommand(triggersMap map[string]hclwrite.Tokens) string {
+func generateCreateClusterLinkCurlCommand(withPrefix bool, tuningConfigs []ClusterLinkConfig) string {
 	return `curl --request POST \
   --url '${self.triggers.target_cluster_rest_endpoint}/kafka/v3/clusters/${self.triggers.destination_cluster_id}/links/?link_name=${self.triggers.link_name}' \
   --header 'Authorization: Basic ${self.triggers.basic_auth_credentials}' \
   --header "Content-Type: application/json" \
   --data '{
-    "source_cluster_id": "${self.triggers.source_cluster_id}",
+    "%{if self.triggers.link_mode == "BIDIRECTIONAL"}remote_cluster_id%{else}source_cluster_id%{endif}": "${self.triggers.source_cluster_id}",
     "configs": [
       {
         "name": "bootstrap.servers",
@@ -94,8 +95,8 @@ func generateCreateClusterLinkCurlCommand(triggersMap map[string]hclwrite.Tokens
       },
       {
         "name": "link.mode",
-        "value": "DESTINATION"
-      },
+        "value": "${self.triggers.link_mode}"
+      },` + clusterLinkPrefixConfig(withPrefix) + clusterLinkTuningConfigEntries(tuningConfigs) + `
       {
         "name": "security.protocol",
         "value": "SASL_SSL"
@@ -117,3 +118,179 @@ func generateDeleteClusterLinkCurlCommandForDestroy() string {
   --url '${self.triggers.target_cluster_rest_endpoint}/kafka/v3/clusters/${self.triggers.destination_cluster_id}/links/${self.triggers.link_name}' \
   --header 'Authorization: Basic ${self.triggers.basic_auth_credentials}'`
 }
+
+// clusterLinkPrefixConfig returns the cluster.link.prefix entry of a create
+// link payload, read from the link_prefix trigger.
+func clusterLinkPrefixConfig(withPrefix bool) string {
+	if !withPrefix {
+		return ""
+	}
+	return `
+      {
+        "name": "cluster.link.prefix",
+        "value": "${self.triggers.link_prefix}"
+      },`
+}
+
+// ClusterLinkConfig is a single name/value entry of a create link payload.
+type ClusterLinkConfig struct {
+	Name  string
+	Value string
+}
+
+// ClusterLinkTuningConfigs returns the link configs for the cluster link tuning
+// options of a request, or none when they are all left at the link defaults.
+func ClusterLinkTuningConfigs(request hclrequests.MigrationWizardRequest) []ClusterLinkConfig {
+	var configs []ClusterLinkConfig
+	if request.ClusterLinkConsumerOffsetSync {
+		configs = append(configs,
+			ClusterLinkConfig{Name: "consumer.offset.sync.enable", Value: "true"},
+			ClusterLinkConfig{Name: "consumer.offset.group.filters", Value: `{"groupFilters":[{"name":"*","patternType":"LITERAL","filterType":"INCLUDE"}]}`},
+		)
+		if request.ClusterLinkConsumerOffsetSyncMs > 0 {
+			configs = append(configs, ClusterLinkConfig{Name: "consumer.offset.sync.ms", Value: strconv.Itoa(request.ClusterLinkConsumerOffsetSyncMs)})
+		}
+	}
+	if request.ClusterLinkAclSync {
+		configs = append(configs,
+			ClusterLinkConfig{Name: "acl.sync.enable", Value: "true"},
+			ClusterLinkConfig{Name: "acl.filters", Value: `{"aclFilters":[{"resourceFilter":{"resourceType":"any","patternType":"any"},"accessFilter":{"operation":"any","permissionType":"any"}}]}`},
+		)
+	}
+	if len(request.ClusterLinkAutoCreateMirrorTopicFilters) > 0 {
+		configs = append(configs,
+			ClusterLinkConfig{Name: "auto.create.mirror.topics.enable", Value: "true"},
+			ClusterLinkConfig{Name: "auto.create.mirror.topics.filters", Value: autoCreateMirrorTopicFilters(request.ClusterLinkAutoCreateMirrorTopicFilters)},
+		)
+	}
+	return configs
+}
+
+type topicFilter struct {
+	Name        string `json:"name"`
+	PatternType string `json:"patternType"`
+	FilterType  string `json:"filterType"`
+}
+
+// autoCreateMirrorTopicFilters encodes topic patterns as the JSON of
+// auto.create.mirror.topics.filters. A pattern ending in `*` is a prefix,
+// anything else (including `*` itself, which matches every topic) a literal.
+func autoCreateMirrorTopicFilters(patterns []string) string {
+	filters := make([]topicFilter, 0, len(patterns))
+	for _, pattern := range patterns {
+		filter := topicFilter{Name: pattern, PatternType: "LITERAL", FilterType: "INCLUDE"}
+		if len(pattern) > 1 && strings.HasSuffix(pattern, "*") {
+			filter.Name = strings.TrimSuffix(pattern, "*")
+			filter.PatternType = "PREFIXED"
+		}
+		filters = append(filters, filter)
+	}
+	encoded, _ := json.Marshal(map[string][]topicFilter{"topicFilters": filters})
+	return string(encoded)
+}
+
+// clusterLinkTuningConfigEntries returns the tuning entries of a create link
+// payload. Values are JSON-escaped, so the filter configs nest as strings.
+func clusterLinkTuningConfigEntries(configs []ClusterLinkConfig) string {
+	var b strings.Builder
+	for _, config := range configs {
+		value, _ := json.Marshal(config.Value)
+		fmt.Fprintf(&b, `
+      {
+        "name": "%s",
+        "value": %s
+      },`, config.Name, value)
+	}
+	return b.String()
+}
+
+/*
+GenerateReverseClusterLinkResource creates the source side of a BIDIRECTIONAL cluster link: a link with the same name on
+the source cluster, created through its Confluent REST endpoint, that connects back to the Confluent Cloud cluster with
+the cluster API key. This is what allows topics to be mirrored back to the source cluster for fallback, and requires a
+source cluster that can host cluster links (Confluent Platform 7.5 or later).
+*/
+func GenerateReverseClusterLinkResource(tfResourceName, sourceClusterIdVarName, sourceClusterRestEndpointVarName, sourceClusterRestAPIKeyVarName, sourceClusterRestAPISecretVarName, targetClusterIdVarName, targetClusterBootstrapEndpointVarName, ccClusterKeyVarName, ccClusterSecretVarName, clusterLinkNameVarName, linkPrefixVarName string) *hclwrite.Block {
+	resourceBlock := hclwrite.NewBlock("resource", []string{"null_resource", tfResourceName})
+
+	triggersMap := map[string]hclwrite.Tokens{
+		"source_cluster_id":            utils.TokensForVarReference(sourceClusterIdVarName),
+		"source_cluster_rest_endpoint": utils.TokensForVarReference(sourceClusterRestEndpointVarName),
+		"source_basic_auth_credentials": utils.TokensForFunctionCall(
+			"base64encode",
+			utils.TokensForStringTemplate(fmt.Sprintf("${var.%s}:${var.%s}", sourceClusterRestAPIKeyVarName, sourceClusterRestAPISecretVarName)),
+		),
+		"remote_cluster_id":         utils.TokensForVarReference(targetClusterIdVarName),
+		"remote_bootstrap_endpoint": utils.TokensForVarReference(targetClusterBootstrapEndpointVarName),
+		"remote_cluster_api_key":    utils.TokensForVarReference(ccClusterKeyVarName),
+		"remote_cluster_api_secret": utils.TokensForVarReference(ccClusterSecretVarName),
+		"link_name":                 utils.TokensForVarReference(clusterLinkNameVarName),
+	}
+	if linkPrefixVarName != "" {
+		triggersMap["link_prefix"] = utils.TokensForVarReference(linkPrefixVarName)
+	}
+	resourceBlock.Body().SetAttributeRaw("triggers", utils.TokensForMap(triggersMap))
+
+	resourceBlock.Body().AppendNewline()
+
+	provisionerBlock := resourceBlock.Body().AppendNewBlock("provisioner", []string{"local-exec"})
+	provisionerBlock.Body().SetAttributeRaw("command", heredocTokens(generateCreateReverseClusterLinkCurlCommand(linkPrefixVarName != "")))
+
+	resourceBlock.Body().AppendNewline()
+
+	destroyProvisionerBlock := resourceBlock.Body().AppendNewBlock("provisioner", []string{"local-exec"})
+	destroyProvisionerBlock.Body().SetAttributeRaw("when", hclwrite.Tokens{
+		&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte("destroy")},
+	})
+	destroyProvisionerBlock.Body().SetAttributeRaw("command", heredocTokens(generateDeleteReverseClusterLinkCurlCommandForDestroy()))
+
+	return resourceBlock
+}
+
+func generateCreateReverseClusterLinkCurlCommand(withPrefix bool) string {
+	return `curl --request POST \
+  --url '${self.triggers.source_cluster_rest_endpoint}/kafka/v3/clusters/${self.triggers.source_cluster_id}/links/?link_name=${self.triggers.link_name}' \
+  --header 'Authorization: Basic ${self.triggers.source_basic_auth_credentials}' \
+  --header "Content-Type: application/json" \
+  --data '{
+    "remote_cluster_id": "${self.triggers.remote_cluster_id}",
+    "configs": [
+      {
+        "name": "bootstrap.servers",
+        "value": "${self.triggers.remote_bootstrap_endpoint}"
+      },
+      {
+        "name": "link.mode",
+        "value": "BIDIRECTIONAL"
+      },` + clusterLinkPrefixConfig(withPrefix) + `
+      {
+        "name": "security.protocol",
+        "value": "SASL_SSL"
+      },
+      {
+        "name": "sasl.mechanism",
+        "value": "PLAIN"
+      },
+      {
+        "name": "sasl.jaas.config",
+        "value": "org.apache.kafka.common.security.plain.PlainLoginModule required username=\"${self.triggers.remote_cluster_api_key}\" password=\"${self.triggers.remote_cluster_api_secret}\";"
+      }
+    ]
+  }'`
+}
+
+func generateDeleteReverseClusterLinkCurlCommandForDestroy() string {
+	return `curl --request DELETE \
+  --url '${self.triggers.source_cluster_rest_endpoint}/kafka/v3/clusters/${self.triggers.source_cluster_id}/links/${self.triggers.link_name}' \
+  --header 'Authorization: Basic ${self.triggers.source_basic_auth_credentials}'`
+}
+
+func heredocTokens(command string) hclwrite.Tokens {
+	return hclwrite.Tokens{
+		&hclwrite.Token{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<-EOT")},
+		&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		&hclwrite.Token{Type: hclsyntax.TokenStringLit, Bytes: []byte(command)},
+		&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		&hclwrite.Token{Type: hclsyntax.TokenCHeredoc, Bytes: []byte("EOT")},
+	}
+}
@@ -0,0 +1,52 @@
+package confluent
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterLinkTuningConfigs_DefaultsAddNothing(t *testing.T) {
+	assert.Empty(t, ClusterLinkTuningConfigs(hclrequests.MigrationWizardRequest{ClusterLinkConsumerOffsetSyncMs: 5000}))
+}
+
+func TestClusterLinkTuningConfigs(t *testing.T) {
+	configs := ClusterLinkTuningConfigs(hclrequests.MigrationWizardRequest{
+		ClusterLinkConsumerOffsetSync:           true,
+		ClusterLinkConsumerOffsetSyncMs:         5000,
+		ClusterLinkAclSync:                      true,
+		ClusterLinkAutoCreateMirrorTopicFilters: []string{"orders", "payments.*", "*"},
+	})
+
+	values := map[string]string{}
+	for _, config := range configs {
+		values[config.Name] = config.Value
+	}
+	assert.Equal(t, "true", values["consumer.offset.sync.enable"])
+	assert.Equal(t, "5000", values["consumer.offset.sync.ms"])
+	assert.Contains(t, values["consumer.offset.group.filters"], `"name":"*"`)
+	assert.Equal(t, "true", values["acl.sync.enable"])
+	assert.Contains(t, values["acl.filters"], `"resourceType":"any"`)
+	assert.Equal(t, "true", values["auto.create.mirror.topics.enable"])
+	assert.Equal(t,
+		`{"topicFilters":[{"name":"orders","patternType":"LITERAL","filterType":"INCLUDE"},{"name":"payments.","patternType":"PREFIXED","filterType":"INCLUDE"},{"name":"*","patternType":"LITERAL","filterType":"INCLUDE"}]}`,
+		values["auto.create.mirror.topics.filters"])
+}
+
+func TestGenerateClusterLinkResource_RendersTuningConfigs(t *testing.T) {
+	configs := ClusterLinkTuningConfigs(hclrequests.MigrationWizardRequest{
+		ClusterLinkConsumerOffsetSync:           true,
+		ClusterLinkAutoCreateMirrorTopicFilters: []string{"orders"},
+	})
+
+	rendered := renderBlock(t, GenerateClusterLinkResource("link", "src", "dst", "rest", "name", "mode", "", "bootstrap", "mechanism", "user", "password", configs))
+
+	assert.Contains(t, rendered, `"name": "consumer.offset.sync.enable",
+        "value": "true"`)
+	assert.Contains(t, rendered, `"value": "{\"topicFilters\":[{\"name\":\"orders\",\"patternType\":\"LITERAL\",\"filterType\":\"INCLUDE\"}]}"`)
+	_, diags := hclparse.NewParser().ParseHCL([]byte(rendered), "main.tf")
+	require.False(t, diags.HasErrors(), diags.Error())
+}
@@ -22,10 +22,10 @@ const (
 // SchemaExporterVariables defines all the variables needed for schema exporter resources
 var SchemaExporterVariables = []hcltypes.TerraformVariable{
 	{Name: VarSourceSchemaRegistryID, Description: "ID of the source schema registry", Sensitive: false, Type: "string"},
-	{Name: VarSourceSchemaRegistryURL, Description: "URL of the source schema registry", Sensitive: false, Type: "string"},
+	{Name: VarSourceSchemaRegistryURL, Description: "URL of the source schema registry", Sensitive: false, Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL(VarSourceSchemaRegistryURL, "http", "https")}},
 	{Name: VarSourceSchemaRegistryUsername, Description: "Username for source schema registry authentication", Sensitive: false, Type: "string"},
 	{Name: VarSourceSchemaRegistryPassword, Description: "Password for source schema registry authentication", Sensitive: true, Type: "string"},
-	{Name: VarConfluentCloudSchemaRegistryURL, Description: "URL of the target schema registry (Confluent Cloud)", Sensitive: false, Type: "string"},
+	{Name: VarConfluentCloudSchemaRegistryURL, Description: "URL of the target schema registry (Confluent Cloud)", Sensitive: false, Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL(VarConfluentCloudSchemaRegistryURL, "https")}},
 	{Name: VarConfluentCloudSchemaRegistryAPIKey, Description: "API key for the target schema registry (Confluent Cloud)", Sensitive: false, Type: "string"},
 	{Name: VarConfluentCloudSchemaRegistrySecret, Description: "API secret for the target schema registry (Confluent Cloud)", Sensitive: true, Type: "string"},
 	{Name: VarSubjects, Description: "List of subjects to export", Sensitive: false, Type: "list(string)"},
@@ -0,0 +1,23 @@
+package confluent
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateFlinkComputePoolResource creates a Flink compute pool in an AWS region
+func GenerateFlinkComputePoolResource(tfResourceName, displayName, regionVarName, maxCfuVarName, environmentIdRef string) *hclwrite.Block {
+	computePoolBlock := hclwrite.NewBlock("resource", []string{"confluent_flink_compute_pool", tfResourceName})
+	computePoolBlock.Body().SetAttributeValue("display_name", cty.StringVal(displayName))
+	computePoolBlock.Body().SetAttributeValue("cloud", cty.StringVal("AWS"))
+	computePoolBlock.Body().SetAttributeRaw("region", utils.TokensForVarReference(regionVarName))
+	computePoolBlock.Body().SetAttributeRaw("max_cfu", utils.TokensForVarReference(maxCfuVarName))
+	computePoolBlock.Body().AppendNewline()
+
+	environmentBlock := hclwrite.NewBlock("environment", nil)
+	environmentBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(environmentIdRef))
+	computePoolBlock.Body().AppendBlock(environmentBlock)
+
+	return computePoolBlock
+}
@@ -0,0 +1,43 @@
+package confluent
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateIdentityProvider creates an OIDC identity provider resource whose
+// issuer and JWKS URI are read from the named variables
+func GenerateIdentityProvider(tfResourceName, name, description, issuerVarName, jwksUriVarName string, preventDestroy bool) *hclwrite.Block {
+	identityProviderBlock := hclwrite.NewBlock("resource", []string{"confluent_identity_provider", tfResourceName})
+	identityProviderBlock.Body().SetAttributeValue("display_name", cty.StringVal(name))
+	identityProviderBlock.Body().SetAttributeValue("description", cty.StringVal(description))
+	identityProviderBlock.Body().SetAttributeRaw("issuer", utils.TokensForVarReference(issuerVarName))
+	identityProviderBlock.Body().SetAttributeRaw("jwks_uri", utils.TokensForVarReference(jwksUriVarName))
+	identityProviderBlock.Body().AppendNewline()
+
+	_ = utils.GenerateLifecycleBlock(identityProviderBlock, "prevent_destroy", preventDestroy)
+
+	return identityProviderBlock
+}
+
+// GenerateIdentityPool creates an identity pool resource of an identity
+// provider. Tokens of the provider that pass the filter variable's CEL
+// expression map to the pool, identified by the identity claim variable.
+func GenerateIdentityPool(tfResourceName, name, description, identityProviderIdRef, identityClaimVarName, filterVarName string, preventDestroy bool) *hclwrite.Block {
+	identityPoolBlock := hclwrite.NewBlock("resource", []string{"confluent_identity_pool", tfResourceName})
+
+	identityProviderBlock := identityPoolBlock.Body().AppendNewBlock("identity_provider", nil)
+	identityProviderBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(identityProviderIdRef))
+	identityPoolBlock.Body().AppendNewline()
+
+	identityPoolBlock.Body().SetAttributeValue("display_name", cty.StringVal(name))
+	identityPoolBlock.Body().SetAttributeValue("description", cty.StringVal(description))
+	identityPoolBlock.Body().SetAttributeRaw("identity_claim", utils.TokensForVarReference(identityClaimVarName))
+	identityPoolBlock.Body().SetAttributeRaw("filter", utils.TokensForVarReference(filterVarName))
+	identityPoolBlock.Body().AppendNewline()
+
+	_ = utils.GenerateLifecycleBlock(identityPoolBlock, "prevent_destroy", preventDestroy)
+
+	return identityPoolBlock
+}
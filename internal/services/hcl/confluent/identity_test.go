@@ -0,0 +1,25 @@
+package confluent
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateIdentityPool(t *testing.T) {
+	rendered := renderBlock(t, GenerateIdentityProvider("oidc", "kcp-oidc", "OIDC provider", "oauth_issuer", "oauth_jwks_uri", true)) +
+		renderBlock(t, GenerateIdentityPool("pool", "kcp-pool", "Clients", "confluent_identity_provider.oidc.id", "oauth_identity_claim", "oauth_identity_pool_filter", false))
+	normalized := normalizeSpaces(rendered)
+
+	assert.Contains(t, rendered, `resource "confluent_identity_provider" "oidc"`)
+	assert.Contains(t, normalized, `issuer = var.oauth_issuer`)
+	assert.Contains(t, normalized, `jwks_uri = var.oauth_jwks_uri`)
+	assert.Contains(t, rendered, `prevent_destroy = true`)
+	assert.Contains(t, normalized, "identity_provider {\n id = confluent_identity_provider.oidc.id\n }")
+	assert.Contains(t, normalized, `identity_claim = var.oauth_identity_claim`)
+	assert.Contains(t, normalized, `filter = var.oauth_identity_pool_filter`)
+	_, diags := hclparse.NewParser().ParseHCL([]byte(rendered), "main.tf")
+	require.False(t, diags.HasErrors(), diags.Error())
+}
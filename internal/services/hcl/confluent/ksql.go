@@ -0,0 +1,33 @@
+package confluent
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateKsqlClusterResource creates a ksqlDB cluster that runs as credentialIdentityRef against an existing Kafka cluster
+func GenerateKsqlClusterResource(tfResourceName, displayName, csuVarName, kafkaClusterIdRef, credentialIdentityRef, environmentIdRef, dependsOnRoleBindingRef string) *hclwrite.Block {
+	ksqlBlock := hclwrite.NewBlock("resource", []string{"confluent_ksql_cluster", tfResourceName})
+	ksqlBlock.Body().SetAttributeValue("display_name", cty.StringVal(displayName))
+	ksqlBlock.Body().SetAttributeRaw("csu", utils.TokensForVarReference(csuVarName))
+	ksqlBlock.Body().AppendNewline()
+
+	kafkaClusterBlock := hclwrite.NewBlock("kafka_cluster", nil)
+	kafkaClusterBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(kafkaClusterIdRef))
+	ksqlBlock.Body().AppendBlock(kafkaClusterBlock)
+
+	credentialIdentityBlock := hclwrite.NewBlock("credential_identity", nil)
+	credentialIdentityBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(credentialIdentityRef))
+	ksqlBlock.Body().AppendBlock(credentialIdentityBlock)
+
+	environmentBlock := hclwrite.NewBlock("environment", nil)
+	environmentBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(environmentIdRef))
+	ksqlBlock.Body().AppendBlock(environmentBlock)
+	ksqlBlock.Body().AppendNewline()
+
+	// The ksqlDB cluster fails to provision if its identity cannot yet reach the Kafka cluster.
+	ksqlBlock.Body().SetAttributeRaw("depends_on", utils.TokensForList([]string{dependsOnRoleBindingRef}))
+
+	return ksqlBlock
+}
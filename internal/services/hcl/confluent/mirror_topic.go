@@ -6,7 +6,10 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-func GenerateMirrorTopic(tfResourceName, topicName, clusterLinkName, clusterId, clusterRestEndpoint string) *hclwrite.Block {
+// GenerateMirrorTopic renders a confluent_kafka_mirror_topic. mirrorTopicName
+// is only set when it differs from topicName, i.e. when the cluster link has
+// a cluster.link.prefix.
+func GenerateMirrorTopic(tfResourceName, topicName, mirrorTopicName, clusterLinkName, clusterId, clusterRestEndpoint string) *hclwrite.Block {
 	mirrorTopicBlock := hclwrite.NewBlock("resource", []string{"confluent_kafka_mirror_topic", tfResourceName})
 
 	sourceKafkaTopicBlock := hclwrite.NewBlock("source_kafka_topic", nil)
@@ -14,6 +17,11 @@ func GenerateMirrorTopic(tfResourceName, topicName, clusterLinkName, clusterId,
 	mirrorTopicBlock.Body().AppendBlock(sourceKafkaTopicBlock)
 	mirrorTopicBlock.Body().AppendNewline()
 
+	if mirrorTopicName != "" && mirrorTopicName != topicName {
+		mirrorTopicBlock.Body().SetAttributeValue("mirror_topic_name", cty.StringVal(mirrorTopicName))
+		mirrorTopicBlock.Body().AppendNewline()
+	}
+
 	clusterLinkBlock := hclwrite.NewBlock("cluster_link", nil)
 	clusterLinkBlock.Body().SetAttributeValue("link_name", cty.StringVal(clusterLinkName))
 	mirrorTopicBlock.Body().AppendBlock(clusterLinkBlock)
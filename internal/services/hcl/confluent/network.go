@@ -53,3 +53,21 @@ func GeneratePrivateLinkAccessResource(tfResourceName, displayName, awsAccountId
 
 	return plAccessBlock
 }
+
+// GenerateCidrNetworkResource creates a confluent_network resource for VPC peering or transit gateway
+// connectivity. Unlike Private Link networks, these need a CIDR that does not overlap with the peered VPC.
+func GenerateCidrNetworkResource(tfResourceName, displayName, connectionType, regionVarName, cidrVarName, environmentIdVarName string) *hclwrite.Block {
+	networkBlock := hclwrite.NewBlock("resource", []string{"confluent_network", tfResourceName})
+	networkBlock.Body().SetAttributeValue("display_name", cty.StringVal(displayName))
+	networkBlock.Body().SetAttributeValue("cloud", cty.StringVal("AWS"))
+	networkBlock.Body().SetAttributeRaw("region", utils.TokensForVarReference(regionVarName))
+	networkBlock.Body().SetAttributeRaw("cidr", utils.TokensForVarReference(cidrVarName))
+	networkBlock.Body().SetAttributeRaw("connection_types", utils.TokensForStringList([]string{connectionType}))
+	networkBlock.Body().AppendNewline()
+
+	environmentBlock := hclwrite.NewBlock("environment", nil)
+	environmentBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference(environmentIdVarName))
+	networkBlock.Body().AppendBlock(environmentBlock)
+
+	return networkBlock
+}
@@ -0,0 +1,34 @@
+package confluent
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GeneratePeeringResource creates a confluent_peering resource that requests a VPC peering connection
+// from the Confluent Cloud network to the customer VPC. routes are the customer CIDRs Confluent Cloud routes back to.
+func GeneratePeeringResource(tfResourceName, displayName, awsAccountIdRef, vpcIdVarName string, routes hclwrite.Tokens, regionVarName, environmentIdVarName, networkIdRef string) *hclwrite.Block {
+	peeringBlock := hclwrite.NewBlock("resource", []string{"confluent_peering", tfResourceName})
+	peeringBlock.Body().SetAttributeValue("display_name", cty.StringVal(displayName))
+	peeringBlock.Body().AppendNewline()
+
+	awsBlock := hclwrite.NewBlock("aws", nil)
+	awsBlock.Body().SetAttributeRaw("account", utils.TokensForResourceReference(awsAccountIdRef))
+	awsBlock.Body().SetAttributeRaw("vpc", utils.TokensForVarReference(vpcIdVarName))
+	awsBlock.Body().SetAttributeRaw("routes", routes)
+	awsBlock.Body().SetAttributeRaw("customer_region", utils.TokensForVarReference(regionVarName))
+	peeringBlock.Body().AppendBlock(awsBlock)
+	peeringBlock.Body().AppendNewline()
+
+	environmentBlock := hclwrite.NewBlock("environment", nil)
+	environmentBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference(environmentIdVarName))
+	peeringBlock.Body().AppendBlock(environmentBlock)
+	peeringBlock.Body().AppendNewline()
+
+	networkBlock := hclwrite.NewBlock("network", nil)
+	networkBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(networkIdRef))
+	peeringBlock.Body().AppendBlock(networkBlock)
+
+	return peeringBlock
+}
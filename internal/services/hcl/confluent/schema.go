@@ -22,7 +22,7 @@ func safePathName(name string) string {
 
 // GlueSchemaVariables defines the variables needed for Glue schema migration resources
 var GlueSchemaVariables = []hcltypes.TerraformVariable{
-	{Name: VarConfluentCloudSchemaRegistryURL, Description: "REST endpoint of the target Confluent Cloud Schema Registry", Sensitive: false, Type: "string"},
+	{Name: VarConfluentCloudSchemaRegistryURL, Description: "REST endpoint of the target Confluent Cloud Schema Registry", Sensitive: false, Type: "string", Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL(VarConfluentCloudSchemaRegistryURL, "https")}},
 	{Name: VarConfluentCloudSchemaRegistryAPIKey, Description: "API key for the target Confluent Cloud Schema Registry", Sensitive: false, Type: "string"},
 	{Name: VarConfluentCloudSchemaRegistrySecret, Description: "API secret for the target Confluent Cloud Schema Registry", Sensitive: true, Type: "string"},
 	{Name: "schema_registry_cluster_id", Description: "ID of the Confluent Cloud Schema Registry cluster", Sensitive: false, Type: "string"},
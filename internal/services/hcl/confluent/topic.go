@@ -41,13 +41,13 @@ var CCSupportedTopicConfigs = map[string]struct{}{
 	"confluent.value.subject.name.strategy": {},
 }
 
-// filterCCSupportedConfigs returns only the entries of src whose keys are in
+// FilterCCSupportedConfigs returns only the entries of src whose keys are in
 // CCSupportedTopicConfigs, with values dereferenced. Nil values (which represent
 // "CC default") are skipped so we never emit `key = ""` artifacts.
 //
 // replication.factor is not in the allow-list and is therefore dropped here —
 // no special-case branch needed.
-func filterCCSupportedConfigs(src map[string]*string) map[string]string {
+func FilterCCSupportedConfigs(src map[string]*string) map[string]string {
 	out := make(map[string]string, len(src))
 	for k, v := range src {
 		if _, ok := CCSupportedTopicConfigs[k]; !ok {
@@ -78,7 +78,7 @@ func GenerateNewTopic(tfResourceName, topicName string, partitions int, srcConfi
 	body.SetAttributeValue("partitions_count", cty.NumberIntVal(int64(partitions)))
 	body.SetAttributeValue("rest_endpoint", cty.StringVal(clusterRestEndpoint))
 
-	configs := filterCCSupportedConfigs(srcConfigs)
+	configs := FilterCCSupportedConfigs(srcConfigs)
 	if len(configs) > 0 {
 		ctyConfigs := make(map[string]cty.Value, len(configs))
 		for k, v := range configs {
@@ -141,7 +141,7 @@ func TestFilterCCSupportedConfigs(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := filterCCSupportedConfigs(tt.input)
+			got := FilterCCSupportedConfigs(tt.input)
 			assert.Equal(t, tt.expected, got)
 		})
 	}
@@ -0,0 +1,38 @@
+package confluent
+
+import (
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateTransitGatewayAttachmentResource creates a confluent_transit_gateway_attachment resource that attaches
+// the Confluent Cloud network to a transit gateway shared through AWS RAM. routes are the customer CIDRs
+// Confluent Cloud sends through the transit gateway.
+func GenerateTransitGatewayAttachmentResource(tfResourceName, displayName, ramResourceShareArnRef, transitGatewayIdVarName string, routes hclwrite.Tokens, environmentIdVarName, networkIdRef string, dependsOnRefs []string) *hclwrite.Block {
+	attachmentBlock := hclwrite.NewBlock("resource", []string{"confluent_transit_gateway_attachment", tfResourceName})
+	attachmentBlock.Body().SetAttributeValue("display_name", cty.StringVal(displayName))
+	attachmentBlock.Body().AppendNewline()
+
+	awsBlock := hclwrite.NewBlock("aws", nil)
+	awsBlock.Body().SetAttributeRaw("ram_resource_share_arn", utils.TokensForResourceReference(ramResourceShareArnRef))
+	awsBlock.Body().SetAttributeRaw("transit_gateway_id", utils.TokensForVarReference(transitGatewayIdVarName))
+	awsBlock.Body().SetAttributeRaw("routes", routes)
+	attachmentBlock.Body().AppendBlock(awsBlock)
+	attachmentBlock.Body().AppendNewline()
+
+	environmentBlock := hclwrite.NewBlock("environment", nil)
+	environmentBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference(environmentIdVarName))
+	attachmentBlock.Body().AppendBlock(environmentBlock)
+	attachmentBlock.Body().AppendNewline()
+
+	networkBlock := hclwrite.NewBlock("network", nil)
+	networkBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(networkIdRef))
+	attachmentBlock.Body().AppendBlock(networkBlock)
+	attachmentBlock.Body().AppendNewline()
+
+	// The RAM share must include the principal and the transit gateway before Confluent Cloud can accept it.
+	attachmentBlock.Body().SetAttributeRaw("depends_on", utils.TokensForList(dependsOnRefs))
+
+	return attachmentBlock
+}
@@ -23,23 +23,41 @@ func GenerateVariablesTf(tfVariables []hcltypes.TerraformVariable) string {
 			continue
 		}
 		varSeenVariables[v.Name] = true
-		variableBlock := rootBody.AppendNewBlock("variable", []string{v.Name})
-		variableBody := variableBlock.Body()
-		variableBody.SetAttributeRaw("type", utils.TokensForResourceReference(v.Type))
-
-		if v.Description != "" {
-			variableBody.SetAttributeValue("description", cty.StringVal(v.Description))
-		}
-
-		if v.Sensitive {
-			variableBody.SetAttributeValue("sensitive", cty.BoolVal(true))
-		}
+		appendVariableBlock(rootBody, v)
 		rootBody.AppendNewline()
 	}
 
 	return string(f.Bytes())
 }
 
+// appendVariableBlock adds the variable block for v to body and returns the
+// block body, so callers can add a default.
+func appendVariableBlock(body *hclwrite.Body, v hcltypes.TerraformVariable) *hclwrite.Body {
+	variableBody := body.AppendNewBlock("variable", []string{v.Name}).Body()
+	variableBody.SetAttributeRaw("type", utils.TokensForResourceReference(v.Type))
+
+	if v.Description != "" {
+		variableBody.SetAttributeValue("description", cty.StringVal(v.Description))
+	}
+
+	if v.Sensitive {
+		variableBody.SetAttributeValue("sensitive", cty.BoolVal(true))
+	}
+
+	if v.Optional {
+		variableBody.SetAttributeValue("default", cty.StringVal(""))
+	}
+
+	for _, validation := range v.Validations {
+		variableBody.AppendNewline()
+		validationBody := variableBody.AppendNewBlock("validation", nil).Body()
+		validationBody.SetAttributeRaw("condition", utils.TokensForResourceReference(validation.Condition))
+		validationBody.SetAttributeValue("error_message", cty.StringVal(validation.ErrorMessage))
+	}
+
+	return variableBody
+}
+
 // GenerateOutputsTf generates an outputs.tf file from a list of output definitions.
 func GenerateOutputsTf(tfOutputs []hcltypes.TerraformOutput) string {
 	f := hclwrite.NewEmptyFile()
@@ -79,6 +97,33 @@ func GenerateVersionsTf(providers ...func(*hclwrite.Body)) string {
 	return string(f.Bytes())
 }
 
+// appendBackendBlock adds the state backend selected by backend to the body of
+// a root module's terraform block. A nil backend leaves the default local state.
+func appendBackendBlock(terraformBody *hclwrite.Body, backend *hclrequests.TerraformBackend) {
+	if backend == nil {
+		return
+	}
+
+	switch backend.Type {
+	case hclrequests.TerraformBackendS3:
+		terraformBody.AppendNewline()
+		backendBody := terraformBody.AppendNewBlock("backend", []string{"s3"}).Body()
+		backendBody.SetAttributeValue("bucket", cty.StringVal(backend.Bucket))
+		backendBody.SetAttributeValue("key", cty.StringVal(backend.Key))
+		backendBody.SetAttributeValue("region", cty.StringVal(backend.Region))
+		backendBody.SetAttributeValue("encrypt", cty.True)
+		if backend.DynamoDBTable != "" {
+			backendBody.SetAttributeValue("dynamodb_table", cty.StringVal(backend.DynamoDBTable))
+		}
+	case hclrequests.TerraformBackendCloud:
+		terraformBody.AppendNewline()
+		cloudBody := terraformBody.AppendNewBlock("cloud", nil).Body()
+		cloudBody.SetAttributeValue("organization", cty.StringVal(backend.Organization))
+		cloudBody.AppendNewline()
+		cloudBody.AppendNewBlock("workspaces", nil).Body().SetAttributeValue("name", cty.StringVal(backend.Workspace))
+	}
+}
+
 // GenerateInputsAutoTfvars generates an inputs.auto.tfvars file from a map of variable names to values.
 // Supports string, []string, bool, int, and []ExtOutboundClusterKafkaBroker value types.
 func GenerateInputsAutoTfvars(values map[string]any) string {
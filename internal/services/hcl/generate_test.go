@@ -0,0 +1,46 @@
+//go:build terraform_validation
+
+package hcl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	hclv2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateVariablesTf_Validations(t *testing.T) {
+	variablesTf := GenerateVariablesTf([]hcltypes.TerraformVariable{
+		{
+			Name:        "subnet_cidrs",
+			Type:        "list(string)",
+			Description: "Subnet CIDRs",
+			Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDRList("subnet_cidrs")},
+		},
+		{
+			Name:        "cluster_id",
+			Type:        "string",
+			Description: "Cluster ID",
+			Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("cluster_id", "lkc")},
+		},
+		{
+			Name:        "api_secret",
+			Type:        "string",
+			Description: "API secret",
+			Sensitive:   true,
+		},
+	})
+
+	_, diags := hclwrite.ParseConfig([]byte(variablesTf), "variables.tf", hclv2.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	assert.Contains(t, variablesTf, `condition     = length(var.subnet_cidrs) > 0 && alltrue([for cidr in var.subnet_cidrs : can(cidrhost(cidr, 0))])`)
+	assert.Contains(t, variablesTf, `condition     = can(regex("^lkc-[0-9a-z]+$", var.cluster_id))`)
+	assert.Contains(t, variablesTf, `error_message = "The cluster_id value must be an ID starting with \"lkc-\"."`)
+	assert.Contains(t, variablesTf, "sensitive   = true")
+	assert.Equal(t, 2, strings.Count(variablesTf, "validation {"))
+}
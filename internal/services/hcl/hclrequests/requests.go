@@ -1,6 +1,10 @@
 package hclrequests
 
-import "github.com/confluentinc/kcp/internal/types"
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/types"
+)
 
 type TargetClusterWizardRequest struct {
 	AwsRegion              string   `json:"aws_region"`
@@ -17,8 +21,85 @@ type TargetClusterWizardRequest struct {
 	PreventDestroy         bool     `json:"prevent_destroy"`
 	VpcId                  string   `json:"vpc_id"`
 	SubnetCidrRanges       []string `json:"subnet_cidr_ranges"`
+	ZonalDnsRecords        bool     `json:"zonal_dns_records"`     // Dedicated only: a `*.<zone-id>` record per AZ keeps traffic in-zone
+	DnsVpcIds              []string `json:"dns_vpc_ids"`           // Same-account VPCs associated with the private hosted zone
+	DnsShareAccountIds     []string `json:"dns_share_account_ids"` // Accounts shared a Route 53 Resolver forwarding rule for the DNS domain
+
+	// OAuth, when set, lets clients authenticate to the target cluster with
+	// tokens of the customer's OIDC identity provider instead of API keys.
+	OAuth *OAuthConfig `json:"oauth,omitempty"`
+
+	Backend *TerraformBackend `json:"backend,omitempty"`
+}
+
+// DefaultOAuthIdentityClaim is the token claim that identifies a client in
+// the identity pool when OAuthConfig.IdentityClaim is empty.
+const DefaultOAuthIdentityClaim = "claims.sub"
+
+// OAuthConfig federates the target cluster with an OIDC identity provider.
+// Issuer and JwksUri describe the provider. Tokens it issues that match
+// Filter, a CEL expression over their claims, map to one identity pool, which
+// is granted read and write access to the cluster's topics and consumer
+// groups.
+type OAuthConfig struct {
+	Issuer        string `json:"issuer"`
+	JwksUri       string `json:"jwks_uri"`
+	IdentityClaim string `json:"identity_claim,omitempty"`
+	Filter        string `json:"filter"`
+}
+
+// Claim returns IdentityClaim, defaulting to claims.sub.
+func (c OAuthConfig) Claim() string {
+	if c.IdentityClaim == "" {
+		return DefaultOAuthIdentityClaim
+	}
+	return c.IdentityClaim
+}
+
+// Backend types for TerraformBackend.Type.
+const (
+	TerraformBackendS3    = "s3"
+	TerraformBackendCloud = "cloud"
+)
+
+// TerraformBackend selects where the generated root module keeps its state.
+// A nil backend keeps Terraform's default local state file. The s3 backend
+// uses Bucket, Key, Region and, for state locking, DynamoDBTable; the cloud
+// backend (Terraform Cloud / HCP Terraform) uses Organization and Workspace.
+type TerraformBackend struct {
+	Type string `json:"type"`
+
+	Bucket        string `json:"bucket,omitempty"`
+	Key           string `json:"key,omitempty"`
+	Region        string `json:"region,omitempty"`
+	DynamoDBTable string `json:"dynamodb_table,omitempty"`
+
+	Organization string `json:"organization,omitempty"`
+	Workspace    string `json:"workspace,omitempty"`
 }
 
+// Connectivity values for MigrationWizardRequest.Connectivity: how the jump
+// cluster VPC reaches the Confluent Cloud network.
+const (
+	ConnectivityPrivateLink    = "privatelink"
+	ConnectivityPeering        = "peering"
+	ConnectivityTransitGateway = "transit-gateway"
+)
+
+// Jump cluster AMIs for MigrationWizardRequest.JumpClusterAmi.
+const (
+	JumpClusterAmiRHEL   = "rhel"
+	JumpClusterAmiAL2023 = "al2023"
+	JumpClusterAmiUbuntu = "ubuntu"
+)
+
+// Cluster link modes for MigrationWizardRequest.ClusterLinkMode, as accepted
+// by the link.mode cluster link config.
+const (
+	ClusterLinkModeDestination   = "DESTINATION"
+	ClusterLinkModeBidirectional = "BIDIRECTIONAL"
+)
+
 type MigrationWizardRequest struct {
 	HasPublicEndpoints bool `json:"has_public_brokers"`
 
@@ -31,6 +112,14 @@ type MigrationWizardRequest struct {
 
 	ExistingPrivateLinkVpceId string `json:"existing_private_link_vpce_id"`
 
+	// Connectivity selects the private networking generated for jump clusters.
+	// Empty means privatelink, which reuses ExistingPrivateLinkVpceId. Peering and
+	// transit-gateway create a Confluent Cloud network with ConfluentNetworkCidr;
+	// transit-gateway attaches it to the existing TransitGatewayId.
+	Connectivity         string `json:"connectivity"`
+	ConfluentNetworkCidr string `json:"confluent_network_cidr"`
+	TransitGatewayId     string `json:"transit_gateway_id"`
+
 	HasExistingInternetGateway bool `json:"has_existing_internet_gateway"`
 
 	JumpClusterInstanceType        string   `json:"jump_cluster_instance_type"`
@@ -38,11 +127,30 @@ type MigrationWizardRequest struct {
 	JumpClusterBrokerSubnetCidr    []string `json:"jump_cluster_broker_subnet_cidr"`
 	JumpClusterSetupHostSubnetCidr string   `json:"jump_cluster_setup_host_subnet_cidr"`
 
+	// JumpClusterAmi is the operating system the jump cluster brokers run:
+	// rhel (the default when empty), al2023 or ubuntu. With
+	// JumpClusterSpotInstances the brokers are launched as spot instances
+	// rather than on-demand.
+	JumpClusterAmi           string `json:"jump_cluster_ami,omitempty"`
+	JumpClusterSpotInstances bool   `json:"jump_cluster_spot_instances,omitempty"`
+
+	// JumpClusterCABundle is a corporate CA bundle (PEM) and the proxy fields
+	// the HTTP(S)_PROXY and NO_PROXY settings that the setup host and the
+	// jump cluster brokers use in egress-restricted VPCs. All are optional.
+	JumpClusterCABundle   string `json:"jump_cluster_ca_bundle,omitempty"`
+	JumpClusterHTTPProxy  string `json:"jump_cluster_http_proxy,omitempty"`
+	JumpClusterHTTPSProxy string `json:"jump_cluster_https_proxy,omitempty"`
+	JumpClusterNoProxy    string `json:"jump_cluster_no_proxy,omitempty"`
+
 	JumpClusterAuthType             string `json:"jump_cluster_auth_type"`
 	SourceClusterId                 string `json:"source_cluster_id"`
 	JumpClusterIamAuthRoleName      string `json:"jump_cluster_iam_auth_role_name"`
 	SourceSaslScramBootstrapServers string `json:"source_sasl_scram_bootstrap_servers"`
 	SourceSaslScramMechanism        string `json:"source_sasl_scram_mechanism"`
+	// SourceCACertificate is the PEM bundle of the private CA that issued the
+	// source brokers' certificates, from `kcp scan clusters --inspect-tls`.
+	// When set, SASL/SCRAM jump clusters trust it on the source cluster link.
+	SourceCACertificate             string `json:"source_ca_certificate,omitempty"`
 	SourcePlaintextBootstrapServers string `json:"source_plaintext_bootstrap_servers"`
 	SourceSaslIamBootstrapServers   string `json:"source_sasl_iam_bootstrap_servers"`
 	SourceRegion                    string `json:"source_region"`
@@ -52,6 +160,107 @@ type MigrationWizardRequest struct {
 	TargetBootstrapEndpoint         string `json:"target_bootstrap_endpoint"`
 	ClusterLinkName                 string `json:"cluster_link_name"`
 	TargetClusterType               string `json:"target_cluster_type"`
+
+	// ClusterLinkMode is DESTINATION (the default when empty) or BIDIRECTIONAL.
+	// A bidirectional link also creates the reverse link on the source cluster,
+	// through SourceRestEndpoint, so topics can be mirrored back to it for
+	// fallback. ClusterLinkPrefix, when set, is prepended to mirror topic names
+	// on both sides.
+	ClusterLinkMode    string `json:"cluster_link_mode,omitempty"`
+	ClusterLinkPrefix  string `json:"cluster_link_prefix,omitempty"`
+	SourceRestEndpoint string `json:"source_rest_endpoint,omitempty"`
+
+	// The cluster link tuning options. Each one left at its zero value keeps
+	// the link default. ClusterLinkConsumerOffsetSync syncs the offsets of all
+	// consumer groups, every ClusterLinkConsumerOffsetSyncMs when set, and
+	// ClusterLinkAclSync syncs all ACLs. A non-empty
+	// ClusterLinkAutoCreateMirrorTopicFilters turns on auto-creation of mirror
+	// topics for the topics it matches: a literal name, `*` for every topic,
+	// or a prefix ending in `*`.
+	ClusterLinkConsumerOffsetSync           bool     `json:"cluster_link_consumer_offset_sync,omitempty"`
+	ClusterLinkConsumerOffsetSyncMs         int      `json:"cluster_link_consumer_offset_sync_ms,omitempty"`
+	ClusterLinkAclSync                      bool     `json:"cluster_link_acl_sync,omitempty"`
+	ClusterLinkAutoCreateMirrorTopicFilters []string `json:"cluster_link_auto_create_mirror_topic_filters,omitempty"`
+
+	// AdditionalDestinations split the source cluster's topics across more
+	// Confluent Cloud clusters than the target above. Each gets its own
+	// cluster link, which auto-creates mirror topics for its TopicFilters
+	// only; the target above keeps ClusterLinkAutoCreateMirrorTopicFilters.
+	AdditionalDestinations []ClusterLinkDestination `json:"additional_destinations,omitempty"`
+
+	// MonitoringEnabled adds a monitoring module with a CloudWatch dashboard
+	// and alarms for the source MSK cluster, named SourceClusterName in
+	// CloudWatch, and the jump cluster instances. Alarms notify
+	// MonitoringAlarmTopicArn when it is set.
+	MonitoringEnabled       bool   `json:"monitoring_enabled,omitempty"`
+	SourceClusterName       string `json:"source_cluster_name,omitempty"`
+	MonitoringAlarmTopicArn string `json:"monitoring_alarm_topic_arn,omitempty"`
+
+	Backend *TerraformBackend `json:"backend,omitempty"`
+}
+
+// ConnectivityMode returns Connectivity, defaulting to privatelink.
+func (r MigrationWizardRequest) ConnectivityMode() string {
+	if r.Connectivity == "" {
+		return ConnectivityPrivateLink
+	}
+	return r.Connectivity
+}
+
+// JumpClusterAmiFamily returns JumpClusterAmi, defaulting to rhel.
+func (r MigrationWizardRequest) JumpClusterAmiFamily() string {
+	if r.JumpClusterAmi == "" {
+		return JumpClusterAmiRHEL
+	}
+	return r.JumpClusterAmi
+}
+
+// LinkMode returns ClusterLinkMode, defaulting to DESTINATION.
+func (r MigrationWizardRequest) LinkMode() string {
+	if r.ClusterLinkMode == "" {
+		return ClusterLinkModeDestination
+	}
+	return r.ClusterLinkMode
+}
+
+// IsBidirectionalLink reports whether the cluster link is created in
+// BIDIRECTIONAL mode, with a reverse link on the source cluster.
+func (r MigrationWizardRequest) IsBidirectionalLink() bool {
+	return r.LinkMode() == ClusterLinkModeBidirectional
+}
+
+// ForDestination returns the request for the cluster link to one of
+// AdditionalDestinations: the same source and link settings, with the
+// destination's target cluster, link name and topic filters.
+func (r MigrationWizardRequest) ForDestination(destination ClusterLinkDestination) MigrationWizardRequest {
+	r.TargetClusterId = destination.TargetClusterId
+	r.TargetRestEndpoint = destination.TargetRestEndpoint
+	r.TargetBootstrapEndpoint = destination.TargetBootstrapEndpoint
+	r.ClusterLinkName = destination.ClusterLinkName
+	r.ClusterLinkAutoCreateMirrorTopicFilters = destination.TopicFilters
+	r.AdditionalDestinations = nil
+	return r
+}
+
+// CreatesConfluentNetwork reports whether the jump cluster networking creates
+// its own Confluent Cloud network (peering and transit-gateway) rather than
+// reusing an existing Private Link endpoint.
+func (r MigrationWizardRequest) CreatesConfluentNetwork() bool {
+	return r.ConnectivityMode() != ConnectivityPrivateLink
+}
+
+// ClusterLinkDestination is an additional Confluent Cloud cluster a Type 1
+// migration mirrors some of the source cluster's topics to. Name suffixes
+// the destination's Terraform module and root variables, so it must be a
+// valid Terraform identifier. TopicFilters take the same patterns as
+// ClusterLinkAutoCreateMirrorTopicFilters.
+type ClusterLinkDestination struct {
+	Name                    string   `json:"name"`
+	TargetClusterId         string   `json:"target_cluster_id"`
+	TargetRestEndpoint      string   `json:"target_rest_endpoint"`
+	TargetBootstrapEndpoint string   `json:"target_bootstrap_endpoint,omitempty"`
+	ClusterLinkName         string   `json:"cluster_link_name"`
+	TopicFilters            []string `json:"topic_filters"`
 }
 
 type ExtOutboundClusterKafkaBroker struct {
@@ -72,6 +281,13 @@ type MigrateAclsRequest struct {
 	TargetClusterRestEndpoint string   `json:"target_cluster_rest_endpoint"`
 	PreventDestroy            bool     `json:"prevent_destroy"`
 
+	// GenerateApiKeys adds a Kafka API key owned by each principal's service
+	// account. GenerateRoleBindings adds RBAC role bindings derived from the
+	// principal's ALLOW ACLs. Either requires TargetEnvironmentId.
+	GenerateApiKeys      bool   `json:"generate_api_keys,omitempty"`
+	GenerateRoleBindings bool   `json:"generate_role_bindings,omitempty"`
+	TargetEnvironmentId  string `json:"target_environment_id,omitempty"`
+
 	SourceType string `json:"source_type"`
 	ClusterId  string `json:"cluster_id"`
 
@@ -79,6 +295,12 @@ type MigrateAclsRequest struct {
 	AclsByPrincipal map[string][]types.Acls `json:"-"`
 }
 
+// GeneratesIdentities reports whether the request asks for API keys or role
+// bindings on top of the service accounts and ACLs.
+func (r MigrateAclsRequest) GeneratesIdentities() bool {
+	return r.GenerateApiKeys || r.GenerateRoleBindings
+}
+
 // MigrateTopicsMode values for MirrorTopicsRequest.Mode.
 const (
 	MigrateTopicsModeMirror = "mirror"
@@ -111,6 +333,21 @@ type MirrorTopicsRequest struct {
 	// directly and leaves these empty.
 	SourceType string `json:"source_type"`
 	ClusterId  string `json:"cluster_id"`
+
+	// TopicNamePrefix and TopicRenames change the destination topic names. A
+	// rename (source name -> destination name) takes precedence over the
+	// prefix. Mirror topics can only take a prefix, the link's
+	// cluster.link.prefix; renames are --mode new only.
+	TopicNamePrefix string            `json:"topic_name_prefix"`
+	TopicRenames    map[string]string `json:"topic_renames"`
+}
+
+// DestinationTopicName returns the name the source topic is created under.
+func (r MirrorTopicsRequest) DestinationTopicName(sourceName string) string {
+	if renamed, ok := r.TopicRenames[sourceName]; ok {
+		return renamed
+	}
+	return r.TopicNamePrefix + sourceName
 }
 
 type ReverseProxyRequest struct {
@@ -150,3 +387,139 @@ type GlueSchemaRegistryMigrationConfig struct {
 	Region       string             `json:"region"`
 	Schemas      []types.GlueSchema `json:"schemas"`
 }
+
+// SourceClusterImportRequest describes an existing MSK provisioned cluster as
+// discovered by kcp, flattened to the attributes the aws_msk_cluster,
+// aws_msk_configuration and aws_msk_scram_secret_association resources need.
+type SourceClusterImportRequest struct {
+	Region              string            `json:"region"`
+	ClusterArn          string            `json:"cluster_arn"`
+	ClusterName         string            `json:"cluster_name"`
+	KafkaVersion        string            `json:"kafka_version"`
+	NumberOfBrokerNodes int               `json:"number_of_broker_nodes"`
+	InstanceType        string            `json:"instance_type"`
+	ClientSubnets       []string          `json:"client_subnets"`
+	SecurityGroups      []string          `json:"security_groups"`
+	VolumeSizeGiB       int               `json:"volume_size_gib"`
+	EnhancedMonitoring  string            `json:"enhanced_monitoring"`
+	Tags                map[string]string `json:"tags"`
+
+	ConfigurationArn      string `json:"configuration_arn"`
+	ConfigurationName     string `json:"configuration_name"`
+	ConfigurationRevision int64  `json:"configuration_revision"`
+	ServerProperties      string `json:"server_properties"`
+
+	EncryptionAtRestKmsKeyArn    string `json:"encryption_at_rest_kms_key_arn"`
+	EncryptionInTransitClient    string `json:"encryption_in_transit_client_broker"`
+	EncryptionInTransitInCluster bool   `json:"encryption_in_transit_in_cluster"`
+
+	SaslIam                     bool     `json:"sasl_iam"`
+	SaslScram                   bool     `json:"sasl_scram"`
+	Unauthenticated             bool     `json:"unauthenticated"`
+	TlsCertificateAuthorityArns []string `json:"tls_certificate_authority_arns"`
+	ScramSecretArns             []string `json:"scram_secret_arns"`
+
+	BrokerLogs BrokerLogDelivery `json:"broker_logs"`
+}
+
+// BrokerLogDelivery is where an MSK cluster delivers its broker logs (the
+// cluster's LoggingInfo). An empty field means that destination is disabled.
+type BrokerLogDelivery struct {
+	CloudWatchLogGroup     string `json:"cloudwatch_log_group"`
+	FirehoseDeliveryStream string `json:"firehose_delivery_stream"`
+	S3Bucket               string `json:"s3_bucket"`
+	S3Prefix               string `json:"s3_prefix"`
+}
+
+// NewBrokerLogDelivery flattens the broker log destinations of a provisioned
+// MSK cluster. Serverless clusters have no broker logs.
+func NewBrokerLogDelivery(cluster kafkatypes.Cluster) BrokerLogDelivery {
+	var delivery BrokerLogDelivery
+	if cluster.Provisioned == nil || cluster.Provisioned.LoggingInfo == nil || cluster.Provisioned.LoggingInfo.BrokerLogs == nil {
+		return delivery
+	}
+	brokerLogs := cluster.Provisioned.LoggingInfo.BrokerLogs
+	if cw := brokerLogs.CloudWatchLogs; cw != nil && aws.ToBool(cw.Enabled) {
+		delivery.CloudWatchLogGroup = aws.ToString(cw.LogGroup)
+	}
+	if firehose := brokerLogs.Firehose; firehose != nil && aws.ToBool(firehose.Enabled) {
+		delivery.FirehoseDeliveryStream = aws.ToString(firehose.DeliveryStream)
+	}
+	if s3 := brokerLogs.S3; s3 != nil && aws.ToBool(s3.Enabled) {
+		delivery.S3Bucket = aws.ToString(s3.Bucket)
+		delivery.S3Prefix = aws.ToString(s3.Prefix)
+	}
+	return delivery
+}
+
+// Enabled reports whether broker logs are delivered anywhere.
+func (d BrokerLogDelivery) Enabled() bool {
+	return d.CloudWatchLogGroup != "" || d.FirehoseDeliveryStream != "" || d.S3Bucket != ""
+}
+
+// LogDeliveryRequest describes the Confluent Cloud audit log forwarding that
+// replaces the broker log delivery of a source MSK cluster. Destinations are
+// the existing AWS targets the source cluster delivers to.
+type LogDeliveryRequest struct {
+	Region            string            `json:"region"`
+	SourceClusterName string            `json:"source_cluster_name"`
+	Destinations      BrokerLogDelivery `json:"destinations"`
+}
+
+// Stream-processing workload kinds detected on the source, and the Confluent
+// Cloud engines a placeholder can be scaffolded on.
+const (
+	StreamProcessingKindKafkaStreams = "kafka_streams"
+	StreamProcessingKindKsql         = "ksql"
+
+	StreamProcessingEngineFlink  = "flink"
+	StreamProcessingEngineKsqlDB = "ksqldb"
+)
+
+// StreamProcessingWorkload is one Kafka Streams application (keyed by its
+// application.id) or ksqlDB/KSQL cluster (keyed by its service id) detected
+// on the source, and the engine its placeholder is generated for.
+type StreamProcessingWorkload struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+	Engine   string   `json:"engine"`
+	Evidence []string `json:"evidence"`
+}
+
+// StreamProcessingRequest describes the placeholder Flink compute pools and
+// ksqlDB clusters to scaffold in the target environment. TargetClusterId is
+// only needed when at least one workload is placed on ksqlDB.
+type StreamProcessingRequest struct {
+	SourceClusterName   string                     `json:"source_cluster_name"`
+	TargetEnvironmentId string                     `json:"target_environment_id"`
+	TargetClusterId     string                     `json:"target_cluster_id"`
+	TargetRegion        string                     `json:"target_region"`
+	Workloads           []StreamProcessingWorkload `json:"workloads"`
+}
+
+// HasEngine reports whether any workload is placed on engine.
+func (r StreamProcessingRequest) HasEngine(engine string) bool {
+	for _, w := range r.Workloads {
+		if w.Engine == engine {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduledDiscoveryRequest describes a Fargate task that runs `kcp discover`
+// on an EventBridge schedule and keeps the state file in an S3 bucket.
+// DiscoverArgs are the arguments after `kcp discover`, and TaskRolePolicy the
+// IAM policy document the task role is granted.
+type ScheduledDiscoveryRequest struct {
+	Region             string   `json:"region"`
+	KcpImage           string   `json:"kcp_image"`
+	ScheduleExpression string   `json:"schedule_expression"`
+	SubnetIds          []string `json:"subnet_ids"`
+	SecurityGroupIds   []string `json:"security_group_ids"`
+	AssignPublicIp     bool     `json:"assign_public_ip"`
+	StateBucketName    string   `json:"state_bucket_name"`
+	StatePrefix        string   `json:"state_prefix"`
+	DiscoverArgs       []string `json:"discover_args"`
+	TaskRolePolicy     string   `json:"task_role_policy"`
+}
@@ -35,6 +35,19 @@ type TerraformVariable struct {
 	Description string `json:"description"`
 	Sensitive   bool   `json:"sensitive"`
 	Type        string `json:"type"`
+	// Validations are written as validation blocks so bad inputs fail at plan
+	// time with a clear message instead of deep inside a provider call.
+	Validations []TerraformValidation `json:"validations,omitempty"`
+	// Optional string variables default to "", so Terraform does not prompt
+	// for them when inputs.auto.tfvars leaves them out.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// TerraformValidation is a variable validation block. Condition is an HCL
+// expression that may only refer to the variable being validated.
+type TerraformValidation struct {
+	Condition    string `json:"condition"`
+	ErrorMessage string `json:"error_message"`
 }
 
 type TerraformOutput struct {
@@ -0,0 +1,98 @@
+package hcltypes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateCIDR requires the string variable name to be an IPv4 or IPv6 CIDR block.
+func ValidateCIDR(name string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("can(cidrhost(var.%s, 0))", name),
+		ErrorMessage: fmt.Sprintf("The %s value must be a CIDR block, e.g. 10.0.1.0/24.", name),
+	}
+}
+
+// ValidateCIDRList requires the list(string) variable name to hold at least one
+// CIDR block and nothing else.
+func ValidateCIDRList(name string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("length(var.%[1]s) > 0 && alltrue([for cidr in var.%[1]s : can(cidrhost(cidr, 0))])", name),
+		ErrorMessage: fmt.Sprintf("The %s value must be a non-empty list of CIDR blocks, e.g. [\"10.0.1.0/24\"].", name),
+	}
+}
+
+// ValidateOneOf requires the string variable name to be one of values.
+func ValidateOneOf(name string, values ...string) TerraformValidation {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("contains([%s], var.%s)", strings.Join(quoted, ", "), name),
+		ErrorMessage: fmt.Sprintf("The %s value must be one of: %s.", name, strings.Join(values, ", ")),
+	}
+}
+
+// ValidateResourceID requires the string variable name to be an ID with the
+// given prefix, such as "vpc" for AWS VPC IDs or "lkc" for Confluent Cloud
+// cluster IDs.
+func ValidateResourceID(name, prefix string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("can(regex(\"^%s-[0-9a-z]+$\", var.%s))", prefix, name),
+		ErrorMessage: fmt.Sprintf("The %s value must be an ID starting with %q.", name, prefix+"-"),
+	}
+}
+
+// ValidateResourceIDList requires the list(string) variable name to hold only
+// IDs with the given prefix.
+func ValidateResourceIDList(name, prefix string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("alltrue([for id in var.%s : can(regex(\"^%s-[0-9a-z]+$\", id))])", name, prefix),
+		ErrorMessage: fmt.Sprintf("The %s value must be a list of IDs starting with %q.", name, prefix+"-"),
+	}
+}
+
+// ValidateAWSAccountIDList requires the list(string) variable name to hold
+// only 12-digit AWS account IDs.
+func ValidateAWSAccountIDList(name string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("alltrue([for id in var.%s : can(regex(\"^[0-9]{12}$\", id))])", name),
+		ErrorMessage: fmt.Sprintf("The %s value must be a list of 12-digit AWS account IDs.", name),
+	}
+}
+
+// ValidateAWSRegion requires the string variable name to look like an AWS
+// region code, including GovCloud regions.
+func ValidateAWSRegion(name string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("can(regex(\"^[a-z]{2}(-gov)?-[a-z]+-[0-9]+$\", var.%s))", name),
+		ErrorMessage: fmt.Sprintf("The %s value must be an AWS region code, e.g. us-east-1.", name),
+	}
+}
+
+// ValidatePositiveInteger requires the number variable name to be a whole
+// number of at least 1.
+func ValidatePositiveInteger(name string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("var.%[1]s >= 1 && floor(var.%[1]s) == var.%[1]s", name),
+		ErrorMessage: fmt.Sprintf("The %s value must be a whole number of at least 1.", name),
+	}
+}
+
+// ValidateURL requires the string variable name to be a URL using one of schemes.
+func ValidateURL(name string, schemes ...string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("can(regex(\"^(%s)://[^/]+\", var.%s))", strings.Join(schemes, "|"), name),
+		ErrorMessage: fmt.Sprintf("The %s value must be a %s URL.", name, strings.Join(schemes, " or ")),
+	}
+}
+
+// ValidateOptionalURL requires the string variable name to be empty or a URL
+// using one of schemes.
+func ValidateOptionalURL(name string, schemes ...string) TerraformValidation {
+	return TerraformValidation{
+		Condition:    fmt.Sprintf("var.%[2]s == \"\" || can(regex(\"^(%[1]s)://[^/]+\", var.%[2]s))", strings.Join(schemes, "|"), name),
+		ErrorMessage: fmt.Sprintf("The %s value must be empty or a %s URL.", name, strings.Join(schemes, " or ")),
+	}
+}
@@ -0,0 +1,195 @@
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/confluent"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	varAuditLogEnvironmentID     = "audit_log_environment_id"
+	varAuditLogClusterID         = "audit_log_cluster_id"
+	varAuditLogServiceAccountID  = "audit_log_service_account_id"
+	varAuditLogBootstrapEndpoint = "audit_log_bootstrap_endpoint"
+
+	// AuditLogTopic is the topic Confluent Cloud writes audit log events to
+	// on the audit log cluster.
+	AuditLogTopic = "confluent-audit-log-events"
+
+	auditLogResourceName = "audit_log"
+	brokerLogsDataName   = "broker_logs"
+)
+
+// LogDeliveryHCLService generates the Confluent Cloud side of replacing MSK
+// broker log delivery: an API key on the organization's audit log cluster,
+// stored in Secrets Manager for the forwarder, and lookups of the CloudWatch
+// Logs group, Firehose stream and S3 bucket the source cluster delivers to so
+// the forwarder keeps writing where existing log tooling already reads.
+type LogDeliveryHCLService struct{}
+
+func NewLogDeliveryHCLService() *LogDeliveryHCLService {
+	return &LogDeliveryHCLService{}
+}
+
+func (s *LogDeliveryHCLService) GenerateLogDeliveryFiles(request hclrequests.LogDeliveryRequest) (hcltypes.TerraformFiles, error) {
+	if !request.Destinations.Enabled() {
+		return hcltypes.TerraformFiles{}, fmt.Errorf("cluster %s has no broker log delivery to replace", request.SourceClusterName)
+	}
+
+	return hcltypes.TerraformFiles{
+		MainTf:           s.generateMainTf(request),
+		ProvidersTf:      s.generateProvidersTf(),
+		VariablesTf:      GenerateVariablesTf(s.variables()),
+		OutputsTf:        s.generateOutputsTf(request),
+		InputsAutoTfvars: GenerateInputsAutoTfvars(map[string]any{aws.VarAwsRegion: request.Region}),
+	}, nil
+}
+
+func (s *LogDeliveryHCLService) generateProvidersTf() string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	terraformBlock := rootBody.AppendNewBlock("terraform", nil)
+	requiredProvidersBlock := terraformBlock.Body().AppendNewBlock("required_providers", nil)
+	aws.AddRequiredProvider(requiredProvidersBlock.Body())
+	confluent.AddRequiredProvider(requiredProvidersBlock.Body())
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(confluent.GenerateProviderBlock())
+	rootBody.AppendNewline()
+
+	providerBlock := rootBody.AppendNewBlock("provider", []string{"aws"})
+	providerBlock.Body().SetAttributeRaw("region", utils.TokensForVarReference(aws.VarAwsRegion))
+
+	return string(f.Bytes())
+}
+
+func (s *LogDeliveryHCLService) generateMainTf(request hclrequests.LogDeliveryRequest) string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	// The audit log cluster lives in a Confluent-managed environment, so it
+	// is addressed by the IDs `confluent audit-log describe` prints rather
+	// than looked up; only its service account may own keys on it.
+	rootBody.AppendUnstructuredTokens(utils.TokensForComment("# Read access to the audit log cluster for the forwarder. Confluent Cloud has no\n# broker logs; its audit log is the compliance record that replaces them.\n"))
+	apiKeyBody := rootBody.AppendNewBlock("resource", []string{"confluent_api_key", auditLogResourceName}).Body()
+	apiKeyBody.SetAttributeValue("display_name", cty.StringVal(fmt.Sprintf("%s-audit-log-forwarder", request.SourceClusterName)))
+	apiKeyBody.SetAttributeValue("description", cty.StringVal(fmt.Sprintf("Forwards Confluent Cloud audit logs to the broker log destinations of %s", request.SourceClusterName)))
+	apiKeyBody.AppendNewline()
+
+	ownerBody := apiKeyBody.AppendNewBlock("owner", nil).Body()
+	ownerBody.SetAttributeRaw("id", utils.TokensForVarReference(varAuditLogServiceAccountID))
+	ownerBody.SetAttributeValue("api_version", cty.StringVal("iam/v2"))
+	ownerBody.SetAttributeValue("kind", cty.StringVal("ServiceAccount"))
+	apiKeyBody.AppendNewline()
+
+	managedResourceBody := apiKeyBody.AppendNewBlock("managed_resource", nil).Body()
+	managedResourceBody.SetAttributeRaw("id", utils.TokensForVarReference(varAuditLogClusterID))
+	managedResourceBody.SetAttributeValue("api_version", cty.StringVal("cmk/v2"))
+	managedResourceBody.SetAttributeValue("kind", cty.StringVal("Cluster"))
+	managedResourceBody.AppendNewline()
+	managedResourceBody.AppendNewBlock("environment", nil).Body().SetAttributeRaw("id", utils.TokensForVarReference(varAuditLogEnvironmentID))
+	apiKeyBody.AppendNewline()
+
+	// The key cannot list topics it was not granted, so skip the readiness
+	// check.
+	apiKeyBody.SetAttributeValue("disable_wait_for_ready", cty.True)
+	rootBody.AppendNewline()
+
+	secretBody := rootBody.AppendNewBlock("resource", []string{"aws_secretsmanager_secret", auditLogResourceName}).Body()
+	secretBody.SetAttributeValue("name_prefix", cty.StringVal(fmt.Sprintf("%s-audit-log-", request.SourceClusterName)))
+	secretBody.SetAttributeValue("description", cty.StringVal("Confluent Cloud audit log cluster credentials (SASL/PLAIN username and password)"))
+	rootBody.AppendNewline()
+
+	// username/password is the layout Lambda's self-managed Kafka event
+	// source (BASIC_AUTH) reads.
+	versionBody := rootBody.AppendNewBlock("resource", []string{"aws_secretsmanager_secret_version", auditLogResourceName}).Body()
+	versionBody.SetAttributeRaw("secret_id", utils.TokensForResourceReference(fmt.Sprintf("aws_secretsmanager_secret.%s.id", auditLogResourceName)))
+	versionBody.SetAttributeRaw("secret_string", utils.TokensForFunctionCall("jsonencode", utils.TokensForMap(map[string]hclwrite.Tokens{
+		"username":          utils.TokensForResourceReference(fmt.Sprintf("confluent_api_key.%s.id", auditLogResourceName)),
+		"password":          utils.TokensForResourceReference(fmt.Sprintf("confluent_api_key.%s.secret", auditLogResourceName)),
+		"bootstrap_servers": utils.TokensForVarReference(varAuditLogBootstrapEndpoint),
+		"topic":             utils.TokensForStringTemplate(AuditLogTopic),
+	})))
+	rootBody.AppendNewline()
+
+	// Data sources fail the plan if a destination has been removed since
+	// discovery, rather than the forwarder failing at runtime.
+	destinations := request.Destinations
+	if destinations.CloudWatchLogGroup != "" {
+		dataBody := rootBody.AppendNewBlock("data", []string{"aws_cloudwatch_log_group", brokerLogsDataName}).Body()
+		dataBody.SetAttributeValue("name", cty.StringVal(destinations.CloudWatchLogGroup))
+		rootBody.AppendNewline()
+	}
+	if destinations.FirehoseDeliveryStream != "" {
+		dataBody := rootBody.AppendNewBlock("data", []string{"aws_kinesis_firehose_delivery_stream", brokerLogsDataName}).Body()
+		dataBody.SetAttributeValue("name", cty.StringVal(destinations.FirehoseDeliveryStream))
+		rootBody.AppendNewline()
+	}
+	if destinations.S3Bucket != "" {
+		dataBody := rootBody.AppendNewBlock("data", []string{"aws_s3_bucket", brokerLogsDataName}).Body()
+		dataBody.SetAttributeValue("bucket", cty.StringVal(destinations.S3Bucket))
+		rootBody.AppendNewline()
+	}
+
+	return string(f.Bytes())
+}
+
+func (s *LogDeliveryHCLService) variables() []hcltypes.TerraformVariable {
+	variables := append([]hcltypes.TerraformVariable{}, confluent.ConfluentProviderVariables...)
+	variables = append(variables, aws.AwsProviderVariables...)
+	return append(variables,
+		hcltypes.TerraformVariable{
+			Name:        varAuditLogEnvironmentID,
+			Description: "ID of the environment of the audit log cluster (`confluent audit-log describe`).",
+			Type:        "string",
+			Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(varAuditLogEnvironmentID, "env")},
+		},
+		hcltypes.TerraformVariable{
+			Name:        varAuditLogClusterID,
+			Description: "ID of the audit log cluster (`confluent audit-log describe`).",
+			Type:        "string",
+			Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(varAuditLogClusterID, "lkc")},
+		},
+		hcltypes.TerraformVariable{
+			Name:        varAuditLogServiceAccountID,
+			Description: "ID of the audit log service account, the only principal that can own keys on the audit log cluster (`confluent audit-log describe`).",
+			Type:        "string",
+			Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(varAuditLogServiceAccountID, "sa")},
+		},
+		hcltypes.TerraformVariable{
+			Name:        varAuditLogBootstrapEndpoint,
+			Description: "Bootstrap servers of the audit log cluster (`confluent audit-log describe`).",
+			Type:        "string",
+		},
+	)
+}
+
+func (s *LogDeliveryHCLService) generateOutputsTf(request hclrequests.LogDeliveryRequest) string {
+	outputs := []hcltypes.TerraformOutput{
+		{Name: "audit_log_api_key", Description: "API key of the audit log forwarder", Value: fmt.Sprintf("confluent_api_key.%s.id", auditLogResourceName)},
+		{Name: "audit_log_credentials_secret_arn", Description: "Secrets Manager secret holding the audit log cluster credentials", Value: fmt.Sprintf("aws_secretsmanager_secret.%s.arn", auditLogResourceName)},
+	}
+	if request.Destinations.CloudWatchLogGroup != "" {
+		outputs = append(outputs, hcltypes.TerraformOutput{
+			Name: "cloudwatch_log_group_arn", Description: "CloudWatch Logs group the source cluster delivers broker logs to", Value: fmt.Sprintf("data.aws_cloudwatch_log_group.%s.arn", brokerLogsDataName),
+		})
+	}
+	if request.Destinations.FirehoseDeliveryStream != "" {
+		outputs = append(outputs, hcltypes.TerraformOutput{
+			Name: "firehose_delivery_stream_arn", Description: "Firehose stream the source cluster delivers broker logs to", Value: fmt.Sprintf("data.aws_kinesis_firehose_delivery_stream.%s.arn", brokerLogsDataName),
+		})
+	}
+	if request.Destinations.S3Bucket != "" {
+		outputs = append(outputs, hcltypes.TerraformOutput{
+			Name: "s3_bucket_arn", Description: "S3 bucket the source cluster delivers broker logs to", Value: fmt.Sprintf("data.aws_s3_bucket.%s.arn", brokerLogsDataName),
+		})
+	}
+	return GenerateOutputsTf(outputs)
+}
@@ -0,0 +1,43 @@
+//go:build terraform_validation
+
+package hcl
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+)
+
+func TestLogDelivery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		destinations hclrequests.BrokerLogDelivery
+	}{
+		{name: "cloudwatch_only", destinations: hclrequests.BrokerLogDelivery{CloudWatchLogGroup: "/msk/orders"}},
+		{name: "all_destinations", destinations: hclrequests.BrokerLogDelivery{
+			CloudWatchLogGroup:     "/msk/orders",
+			FirehoseDeliveryStream: "orders-broker-logs",
+			S3Bucket:               "orders-logs",
+			S3Prefix:               "brokers/",
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := NewLogDeliveryHCLService().GenerateLogDeliveryFiles(hclrequests.LogDeliveryRequest{
+				Region:            "us-east-1",
+				SourceClusterName: "orders",
+				Destinations:      tc.destinations,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			validateTerraformProject(t, terraformFilesToMap(files))
+		})
+	}
+}
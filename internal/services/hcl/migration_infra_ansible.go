@@ -0,0 +1,64 @@
+package hcl
+
+import (
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/hcl/modules"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// ============================================================================
+// Root-Level Generation - Private Migration - Jump Clusters provisioned by Ansible
+// ============================================================================
+
+// GenerateNetworkingOnlyTerraformModules returns the jump cluster Terraform
+// project without the jump_cluster_setup_host and jump_cluster modules. The
+// networking outputs are re-exported at the root so the Ansible playbooks can
+// read them with `terraform output -json`.
+func (mi *MigrationInfraHCLService) GenerateNetworkingOnlyTerraformModules(request hclrequests.MigrationWizardRequest) hcltypes.MigrationInfraTerraformProject {
+	return hcltypes.MigrationInfraTerraformProject{
+		MainTf:           mi.generateRootMainTfForAnsibleJumpClusters(request),
+		ProvidersTf:      mi.generateRootProvidersTfForPrivateMigrationInfrastructure(request),
+		VariablesTf:      GenerateVariablesTf(modules.GetAnsibleJumpClusterRootVariableDefinitions(request)),
+		OutputsTf:        mi.generateRootOutputsTfForAnsibleJumpClusters(),
+		InputsAutoTfvars: GenerateInputsAutoTfvars(modules.GetAnsibleJumpClusterRootVariableValues(request)),
+		Modules: []hcltypes.MigrationInfraTerraformModule{
+			{
+				Name:        "networking",
+				MainTf:      mi.generateNetworkingMainTf(request),
+				VariablesTf: mi.generateNetworkingVariablesTf(request),
+				OutputsTf:   mi.generateNetworkingOutputsTf(),
+				VersionsTf:  mi.generateNetworkingVersionsTf(request),
+			},
+		},
+	}
+}
+
+func (mi *MigrationInfraHCLService) generateRootMainTfForAnsibleJumpClusters(request hclrequests.MigrationWizardRequest) string {
+	f := hclwrite.NewEmptyFile()
+	mi.appendNetworkingModuleBlock(f.Body(), request)
+
+	return string(f.Bytes())
+}
+
+func (mi *MigrationInfraHCLService) generateRootOutputsTfForAnsibleJumpClusters() string {
+	var outputs []hcltypes.TerraformOutput
+	for _, output := range modules.GetNetworkingModuleOutputDefinitions() {
+		// The private key is already written to ./.ssh by the networking module.
+		if output.Sensitive {
+			continue
+		}
+		outputs = append(outputs, hcltypes.TerraformOutput{
+			Name:        output.Name,
+			Description: output.Description,
+			Value:       "module.networking." + output.Name,
+		})
+	}
+	outputs = append(outputs, hcltypes.TerraformOutput{
+		Name:        "region",
+		Description: "AWS region the networking resources were created in.",
+		Value:       "var." + aws.VarAwsRegion,
+	})
+	return GenerateOutputsTf(outputs)
+}
@@ -53,7 +53,7 @@ func (mi *MigrationInfraHCLService) generateRootMainTfForExternalOutboundCluster
 	return string(f.Bytes())
 }
 
-func (mi *MigrationInfraHCLService) generateRootProvidersTfForExternalOutboundClusterLinkingInfrastructure() string {
+func (mi *MigrationInfraHCLService) generateRootProvidersTfForExternalOutboundClusterLinkingInfrastructure(request hclrequests.MigrationWizardRequest) string {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
@@ -65,6 +65,7 @@ func (mi *MigrationInfraHCLService) generateRootProvidersTfForExternalOutboundCl
 
 	requiredProvidersBody.SetAttributeRaw(aws.GenerateRequiredProviderTokens())
 	requiredProvidersBody.SetAttributeRaw(confluent.GenerateRequiredProviderTokens())
+	appendBackendBlock(terraformBody, request.Backend)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(aws.GenerateProviderBlockWithVarAndDeploymentID(mi.DeploymentID))
@@ -114,7 +115,7 @@ func (mi *MigrationInfraHCLService) generateExternalOutboundClusterLinkMainTf(re
 	rootBody.AppendBlock(aws.GenerateEc2UserDataInstanceResource(
 		"external_outbound_cluster_link",
 		"data.aws_ami.amzn_linux_ami.id",
-		"t2.medium",
+		aws.HelperInstanceType,
 		modules.VarSubnetID,
 		modules.VarSecurityGroupID,
 		"", // No keypair needed as user will never need to access instance.
@@ -21,32 +21,45 @@ func NewMigrationInfraHCLService() *MigrationInfraHCLService {
 
 func (mi *MigrationInfraHCLService) GenerateTerraformModules(request hclrequests.MigrationWizardRequest) hcltypes.MigrationInfraTerraformProject {
 	if request.HasPublicEndpoints {
-		return mi.handlePublicMigrationInfrastructure(request)
+		return mi.withMonitoring(mi.handlePublicMigrationInfrastructure(request), request)
 	}
 
 	if request.UseJumpClusters {
-		return mi.handlePrivateMigrationInfrastructure(request)
+		return mi.withMonitoring(mi.handlePrivateMigrationInfrastructure(request), request)
 	}
 
-	return mi.handleExternalOutboundClusterLinkingInfrastructure(request)
+	return mi.withMonitoring(mi.handleExternalOutboundClusterLinkingInfrastructure(request), request)
 }
 
 func (mi *MigrationInfraHCLService) handlePublicMigrationInfrastructure(request hclrequests.MigrationWizardRequest) hcltypes.MigrationInfraTerraformProject {
 	requiredVariables := modules.GetMigrationInfraRootVariableDefinitions(request)
 
-	return hcltypes.MigrationInfraTerraformProject{
+	project := hcltypes.MigrationInfraTerraformProject{
 		MainTf:           mi.generateRootMainTfForPublicMigrationInfrastructure(request),
-		ProvidersTf:      mi.generateRootProvidersTfForClusterLink(),
+		ProvidersTf:      mi.generateRootProvidersTfForClusterLink(request),
 		VariablesTf:      GenerateVariablesTf(requiredVariables),
 		InputsAutoTfvars: mi.generateInputsAutoTfvars(request),
 		Modules: []hcltypes.MigrationInfraTerraformModule{
 			{
 				Name:        "cluster_link",
-				MainTf:      mi.generateClusterLinkMainTf(),
+				MainTf:      mi.generateClusterLinkMainTf(request),
 				VariablesTf: mi.generateClusterLinkVariablesTf(request),
 			},
 		},
 	}
+
+	// Each additional destination gets its own copy of the cluster link
+	// module, so its link only auto-creates mirror topics for its own topics.
+	for _, destination := range request.AdditionalDestinations {
+		destinationRequest := request.ForDestination(destination)
+		project.Modules = append(project.Modules, hcltypes.MigrationInfraTerraformModule{
+			Name:        clusterLinkDestinationModuleName(destination),
+			MainTf:      mi.generateClusterLinkMainTf(destinationRequest),
+			VariablesTf: mi.generateClusterLinkVariablesTf(destinationRequest),
+		})
+	}
+
+	return project
 }
 
 func (mi *MigrationInfraHCLService) handlePrivateMigrationInfrastructure(request hclrequests.MigrationWizardRequest) hcltypes.MigrationInfraTerraformProject {
@@ -54,14 +67,14 @@ func (mi *MigrationInfraHCLService) handlePrivateMigrationInfrastructure(request
 
 	return hcltypes.MigrationInfraTerraformProject{
 		MainTf:           mi.generateRootMainTfForPrivateMigrationInfrastructure(request),
-		ProvidersTf:      mi.generateRootProvidersTfForPrivateMigrationInfrastructure(),
+		ProvidersTf:      mi.generateRootProvidersTfForPrivateMigrationInfrastructure(request),
 		VariablesTf:      GenerateVariablesTf(requiredVariables),
 		ReadmeMd:         mi.generateJumpClusterReadmeMd(request),
 		InputsAutoTfvars: mi.generateInputsAutoTfvars(request),
 		Modules: []hcltypes.MigrationInfraTerraformModule{
 			{
 				Name:        "jump_cluster_setup_host",
-				MainTf:      mi.generateJumpClusterSetupHostMainTf(),
+				MainTf:      mi.generateJumpClusterSetupHostMainTf(request),
 				VariablesTf: mi.generateJumpClusterSetupHostVariablesTf(request),
 				VersionsTf:  mi.generateJumpClusterSetupHostVersionsTf(),
 				AdditionalFiles: map[string]string{
@@ -83,7 +96,7 @@ func (mi *MigrationInfraHCLService) handlePrivateMigrationInfrastructure(request
 				MainTf:      mi.generateNetworkingMainTf(request),
 				VariablesTf: mi.generateNetworkingVariablesTf(request),
 				OutputsTf:   mi.generateNetworkingOutputsTf(),
-				VersionsTf:  mi.generateNetworkingVersionsTf(),
+				VersionsTf:  mi.generateNetworkingVersionsTf(request),
 			},
 		},
 	}
@@ -94,7 +107,7 @@ func (mi *MigrationInfraHCLService) handleExternalOutboundClusterLinkingInfrastr
 
 	return hcltypes.MigrationInfraTerraformProject{
 		MainTf:           mi.generateRootMainTfForExternalOutboundClusterLinkingInfrastructure(request),
-		ProvidersTf:      mi.generateRootProvidersTfForExternalOutboundClusterLinkingInfrastructure(),
+		ProvidersTf:      mi.generateRootProvidersTfForExternalOutboundClusterLinkingInfrastructure(request),
 		VariablesTf:      GenerateVariablesTf(requiredVariables),
 		InputsAutoTfvars: mi.generateInputsAutoTfvars(request),
 		Modules: []hcltypes.MigrationInfraTerraformModule{
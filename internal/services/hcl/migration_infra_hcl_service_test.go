@@ -3,10 +3,19 @@
 package hcl
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	hclv2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
 )
 
 func TestMigrationInfra_Public(t *testing.T) {
@@ -29,6 +38,92 @@ func TestMigrationInfra_Public(t *testing.T) {
 	validateTerraformProject(t, files)
 }
 
+func TestMigrationInfra_PublicBidirectional(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := hclrequests.MigrationWizardRequest{
+		HasPublicEndpoints:      true,
+		SourceClusterId:         "cp-cluster-123",
+		SourceRegion:            "us-east-1",
+		TargetEnvironmentId:     "env-abc123",
+		TargetClusterId:         "lkc-xyz789",
+		TargetRestEndpoint:      "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		TargetBootstrapEndpoint: "pkc-abc123.us-east-1.aws.confluent.cloud:9092",
+		ClusterLinkName:         "cp-to-cc-link",
+		ClusterLinkMode:         hclrequests.ClusterLinkModeBidirectional,
+		ClusterLinkPrefix:       "cp-",
+		SourceRestEndpoint:      "https://cp-kafka.example.com:8090",
+	}
+
+	project := service.GenerateTerraformModules(request)
+	require.Len(t, project.Modules, 1)
+	require.Contains(t, project.Modules[0].MainTf, `resource "null_resource" "confluent_cluster_link_reverse"`)
+	require.Contains(t, project.Modules[0].MainTf, "cluster.link.prefix")
+	files := projectToFiles(project)
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_PublicLinkTuning(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := hclrequests.MigrationWizardRequest{
+		HasPublicEndpoints:                      true,
+		SourceClusterId:                         "msk-cluster-123",
+		SourceRegion:                            "us-east-1",
+		TargetEnvironmentId:                     "env-abc123",
+		TargetClusterId:                         "lkc-xyz789",
+		TargetRestEndpoint:                      "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		ClusterLinkName:                         "msk-to-cc-link",
+		ClusterLinkConsumerOffsetSync:           true,
+		ClusterLinkConsumerOffsetSyncMs:         10000,
+		ClusterLinkAclSync:                      true,
+		ClusterLinkAutoCreateMirrorTopicFilters: []string{"orders.*"},
+	}
+
+	project := service.GenerateTerraformModules(request)
+	require.Len(t, project.Modules, 1)
+	require.Contains(t, project.Modules[0].MainTf, "consumer.offset.sync.ms")
+	require.Contains(t, project.Modules[0].MainTf, "acl.sync.enable")
+	require.Contains(t, project.Modules[0].MainTf, "auto.create.mirror.topics.filters")
+	files := projectToFiles(project)
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_PublicMultipleDestinations(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := hclrequests.MigrationWizardRequest{
+		HasPublicEndpoints:                      true,
+		SourceClusterId:                         "msk-cluster-123",
+		SourceRegion:                            "us-east-1",
+		TargetEnvironmentId:                     "env-abc123",
+		TargetClusterId:                         "lkc-xyz789",
+		TargetRestEndpoint:                      "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		ClusterLinkName:                         "msk-to-cc-link",
+		ClusterLinkAutoCreateMirrorTopicFilters: []string{"orders.*"},
+		AdditionalDestinations: []hclrequests.ClusterLinkDestination{{
+			Name:               "payments",
+			TargetClusterId:    "lkc-pay123",
+			TargetRestEndpoint: "https://pkc-pay123.us-east-1.aws.confluent.cloud:443",
+			ClusterLinkName:    "msk-to-payments",
+			TopicFilters:       []string{"payments.*"},
+		}},
+	}
+
+	project := service.GenerateTerraformModules(request)
+	require.Len(t, project.Modules, 2)
+	require.Equal(t, "cluster_link_payments", project.Modules[1].Name)
+	require.Contains(t, project.Modules[1].MainTf, "payments.*")
+	require.NotContains(t, project.Modules[1].MainTf, "orders.*")
+	require.Contains(t, project.MainTf, `module "cluster_link_payments"`)
+	require.Contains(t, project.VariablesTf, `variable "target_cluster_id_payments"`)
+	files := projectToFiles(project)
+	validateTerraformProject(t, files)
+}
+
 func TestMigrationInfra_PrivateJumpCluster(t *testing.T) {
 	t.Parallel()
 
@@ -59,6 +154,130 @@ func TestMigrationInfra_PrivateJumpCluster(t *testing.T) {
 	validateTerraformProject(t, files)
 }
 
+func TestMigrationInfra_PrivateJumpClusterWithMonitoring(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := hclrequests.MigrationWizardRequest{
+		UseJumpClusters:                true,
+		VpcId:                          "vpc-0123456789abcdef0",
+		HasExistingInternetGateway:     true,
+		JumpClusterInstanceType:        "kafka.m5.large",
+		JumpClusterBrokerStorage:       100,
+		JumpClusterBrokerSubnetCidr:    []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		JumpClusterSetupHostSubnetCidr: "10.0.4.0/24",
+		JumpClusterAuthType:            "iam",
+		SourceClusterId:                "msk-cluster-123",
+		JumpClusterIamAuthRoleName:     "msk-iam-role",
+		SourceSaslIamBootstrapServers:  "b-1.mskcluster.abc123.c1.kafka.us-east-1.amazonaws.com:9098",
+		SourceRegion:                   "us-east-1",
+		TargetEnvironmentId:            "env-abc123",
+		TargetClusterId:                "lkc-xyz789",
+		TargetRestEndpoint:             "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		TargetBootstrapEndpoint:        "pkc-abc123.us-east-1.aws.confluent.cloud:9092",
+		ClusterLinkName:                "msk-to-cc-link",
+		MonitoringEnabled:              true,
+		SourceClusterName:              "orders-prod",
+		MonitoringAlarmTopicArn:        "arn:aws:sns:us-east-1:123456789012:migration-alerts",
+	}
+
+	project := service.GenerateTerraformModules(request)
+	monitoring := project.Modules[len(project.Modules)-1]
+	require.Equal(t, "monitoring", monitoring.Name)
+	require.Contains(t, project.MainTf, "jump_cluster_instance_ids  = module.jump_cluster.jump_cluster_instance_ids")
+	require.Contains(t, monitoring.MainTf, `resource "aws_cloudwatch_metric_alarm" "jump_cluster_status_check"`)
+	require.Contains(t, monitoring.MainTf, "alarm_actions = [var.monitoring_alarm_topic_arn]")
+	files := projectToFiles(project)
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_PublicWithMonitoring(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := hclrequests.MigrationWizardRequest{
+		HasPublicEndpoints:  true,
+		SourceClusterId:     "msk-cluster-123",
+		SourceRegion:        "us-east-1",
+		TargetEnvironmentId: "env-abc123",
+		TargetClusterId:     "lkc-xyz789",
+		TargetRestEndpoint:  "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		ClusterLinkName:     "msk-to-cc-link",
+		MonitoringEnabled:   true,
+		SourceClusterName:   "orders-prod",
+	}
+
+	project := service.GenerateTerraformModules(request)
+	require.Len(t, project.Modules, 2)
+	require.Contains(t, project.ProvidersTf, `provider "aws"`)
+	require.NotContains(t, project.Modules[1].MainTf, "jump_cluster_status_check")
+	require.NotContains(t, project.Modules[1].MainTf, "alarm_actions")
+	files := projectToFiles(project)
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationDashboardTpl_RendersValidJSON(t *testing.T) {
+	t.Parallel()
+
+	tpl, diags := hclsyntax.ParseTemplate([]byte(aws.GenerateMigrationDashboardTpl()), migrationDashboardTemplateFileName, hclv2.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	for name, instanceIDs := range map[string]cty.Value{
+		"without jump cluster": cty.MapValEmpty(cty.String),
+		"with jump cluster": cty.MapVal(map[string]cty.Value{
+			"broker-0": cty.StringVal("i-0123456789abcdef0"),
+			"broker-1": cty.StringVal("i-0123456789abcdef1"),
+		}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			rendered, diags := tpl.Value(&hclv2.EvalContext{
+				Variables: map[string]cty.Value{
+					"region":                    cty.StringVal("us-east-1"),
+					"cluster_name":              cty.StringVal("orders-prod"),
+					"jump_cluster_instance_ids": instanceIDs,
+				},
+				Functions: map[string]function.Function{
+					"jsonencode": stdlib.JSONEncodeFunc,
+					"length":     stdlib.LengthFunc,
+				},
+			})
+			require.False(t, diags.HasErrors(), diags.Error())
+
+			var dashboard struct {
+				Widgets []map[string]any `json:"widgets"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(rendered.AsString()), &dashboard))
+			if instanceIDs.LengthInt() == 0 {
+				require.Len(t, dashboard.Widgets, 3)
+			} else {
+				require.Len(t, dashboard.Widgets, 5)
+				require.Contains(t, rendered.AsString(), "i-0123456789abcdef1")
+			}
+		})
+	}
+}
+
+func TestMigrationInfra_PrivateJumpCluster_NetworkingOnly(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := hclrequests.MigrationWizardRequest{
+		UseJumpClusters:                true,
+		VpcId:                          "vpc-0123456789abcdef0",
+		HasExistingInternetGateway:     true,
+		JumpClusterBrokerSubnetCidr:    []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		JumpClusterSetupHostSubnetCidr: "10.0.4.0/24",
+		JumpClusterAuthType:            "iam",
+		ExistingPrivateLinkVpceId:      "vpce-0123456789abcdef0",
+		SourceRegion:                   "us-east-1",
+	}
+
+	project := service.GenerateNetworkingOnlyTerraformModules(request)
+	require.Len(t, project.Modules, 1)
+	files := projectToFiles(project)
+	validateTerraformProject(t, files)
+}
+
 func TestMigrationInfra_ExternalOutbound(t *testing.T) {
 	t.Parallel()
 
@@ -314,3 +533,207 @@ func TestMigrationInfra_JumpCluster_FiveSubnets(t *testing.T) {
 	files := projectToFiles(project)
 	validateTerraformProject(t, files)
 }
+
+func TestMigrationInfra_JumpCluster_SaslScramPrivateCA(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := jumpClusterConnectivityRequest("")
+	request.JumpClusterAuthType = "sasl_scram"
+	request.JumpClusterIamAuthRoleName = ""
+	request.SourceSaslIamBootstrapServers = ""
+	request.SourceSaslScramBootstrapServers = "broker-1.kafka.internal:9093"
+	request.SourceSaslScramMechanism = "SCRAM-SHA-256"
+	request.SourceCACertificate = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+	project := service.GenerateTerraformModules(request)
+	files := projectToFiles(project)
+
+	require.Contains(t, files["modules/jump_cluster/main.tf"], "source_ca_certificate")
+	require.Contains(t, files["modules/jump_cluster/variables.tf"], `variable "source_ca_certificate"`)
+	require.Contains(t, files["inputs.auto.tfvars"], `source_ca_certificate`)
+	require.Contains(t, files["inputs.auto.tfvars"], `BEGIN CERTIFICATE`)
+	tpl := files["modules/jump_cluster/jump-cluster-with-cluster-links-user-data.tpl"]
+	require.Contains(t, tpl, `%{ if source_ca_certificate != "" ~}`)
+	require.Contains(t, tpl, "ssl.truststore.location=/etc/pki/kcp/source-ca.pem")
+
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_JumpCluster_EgressProxyAndCABundle(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := jumpClusterConnectivityRequest("")
+	request.JumpClusterCABundle = "-----BEGIN CERTIFICATE-----\nMIIC\n-----END CERTIFICATE-----"
+	request.JumpClusterHTTPSProxy = "http://proxy.corp.example.com:3128"
+	request.JumpClusterNoProxy = ".corp.example.com"
+
+	project := service.GenerateTerraformModules(request)
+	files := projectToFiles(project)
+
+	require.Contains(t, files["variables.tf"], "variable \"jump_cluster_http_proxy\" {")
+	require.Contains(t, files["variables.tf"], `default     = ""`)
+	require.Contains(t, files["inputs.auto.tfvars"], "http://proxy.corp.example.com:3128")
+	require.NotContains(t, files["inputs.auto.tfvars"], "jump_cluster_http_proxy ", "unset optional variables keep their default")
+	require.Contains(t, files["modules/jump_cluster/main.tf"], "jump_cluster_https_proxy")
+	require.Contains(t, files["modules/jump_cluster_setup_host/main.tf"], "jump_cluster_ca_bundle")
+
+	tpl := files["modules/jump_cluster/jump-cluster-with-cluster-links-user-data.tpl"]
+	require.Contains(t, tpl, `%{ if jump_cluster_http_proxy != "" || jump_cluster_https_proxy != "" ~}`)
+	require.True(t, strings.HasPrefix(tpl, "#!/bin/bash\n%{ if jump_cluster_ca_bundle != \"\" ~}"), "the egress setup runs first, after the shebang")
+
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_JumpCluster_VariableValidations(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := jumpClusterConnectivityRequest("")
+	request.JumpClusterAuthType = "sasl_scram"
+	request.JumpClusterIamAuthRoleName = ""
+	request.SourceSaslIamBootstrapServers = ""
+	request.SourceSaslScramBootstrapServers = "broker-1.kafka.internal:9093"
+	request.SourceSaslScramMechanism = "SCRAM-SHA-512"
+
+	project := service.GenerateTerraformModules(request)
+	files := projectToFiles(project)
+
+	rootVariables := files["variables.tf"]
+	require.Contains(t, rootVariables, `can(regex("^vpc-[0-9a-z]+$", var.vpc_id))`)
+	require.Contains(t, rootVariables, `alltrue([for cidr in var.jump_cluster_broker_subnet_cidrs : can(cidrhost(cidr, 0))])`)
+	require.Contains(t, rootVariables, `can(regex("^[a-z]{2}(-gov)?-[a-z]+-[0-9]+$", var.aws_region))`)
+
+	jumpClusterVariables := files["modules/jump_cluster/variables.tf"]
+	require.Contains(t, jumpClusterVariables, "variable \"jump_cluster_broker_storage\" {\n  type        = number")
+	require.Contains(t, jumpClusterVariables, `contains(["SCRAM-SHA-256", "SCRAM-SHA-512"], var.source_sasl_scram_mechanism)`)
+
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_JumpCluster_UbuntuSpotInstances(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	request := jumpClusterConnectivityRequest("")
+	request.JumpClusterAmi = hclrequests.JumpClusterAmiUbuntu
+	request.JumpClusterSpotInstances = true
+
+	project := service.GenerateTerraformModules(request)
+	files := projectToFiles(project)
+
+	jumpCluster := files["modules/jump_cluster/main.tf"]
+	require.Contains(t, jumpCluster, `data "aws_ami" "ubuntu_ami"`)
+	require.Contains(t, jumpCluster, "ami                         = data.aws_ami.ubuntu_ami.id")
+	require.Contains(t, jumpCluster, `market_type = "spot"`)
+	require.NotContains(t, jumpCluster, "red_hat_linux_ami")
+	require.Contains(t, files["modules/jump_cluster/jump-cluster-with-cluster-links-user-data.tpl"], "cd /home/${os_user}")
+	require.Contains(t, files["inputs.auto.tfvars"], `jump_cluster_os_user`)
+	require.Contains(t, files["inputs.auto.tfvars"], `"ubuntu"`)
+	require.Contains(t, files["README.md"], "spot")
+
+	validateTerraformProject(t, files)
+}
+
+// Connectivity tests
+func jumpClusterConnectivityRequest(connectivity string) hclrequests.MigrationWizardRequest {
+	return hclrequests.MigrationWizardRequest{
+		UseJumpClusters:                true,
+		VpcId:                          "vpc-0123456789abcdef0",
+		HasExistingInternetGateway:     true,
+		JumpClusterInstanceType:        "kafka.m5.large",
+		JumpClusterBrokerStorage:       100,
+		JumpClusterBrokerSubnetCidr:    []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		JumpClusterSetupHostSubnetCidr: "10.0.4.0/24",
+		JumpClusterAuthType:            "iam",
+		SourceClusterId:                "msk-cluster-123",
+		JumpClusterIamAuthRoleName:     "msk-iam-role",
+		SourceSaslIamBootstrapServers:  "b-1.mskcluster.abc123.c1.kafka.us-east-1.amazonaws.com:9098",
+		SourceRegion:                   "us-east-1",
+		TargetEnvironmentId:            "env-abc123",
+		TargetClusterId:                "lkc-xyz789",
+		TargetRestEndpoint:             "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		TargetBootstrapEndpoint:        "pkc-abc123.us-east-1.aws.confluent.cloud:9092",
+		ClusterLinkName:                "msk-to-cc-link",
+		Connectivity:                   connectivity,
+		ConfluentNetworkCidr:           "10.200.0.0/16",
+		TransitGatewayId:               "tgw-0123456789abcdef0",
+	}
+}
+
+func TestMigrationInfra_JumpCluster_Peering(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	project := service.GenerateTerraformModules(jumpClusterConnectivityRequest(hclrequests.ConnectivityPeering))
+	files := projectToFiles(project)
+
+	networking := files["modules/networking/main.tf"]
+	require.Contains(t, networking, `connection_types = ["PEERING"]`)
+	require.Contains(t, networking, `resource "confluent_peering" "peering"`)
+	require.Contains(t, networking, `resource "aws_vpc_peering_connection_accepter" "confluent"`)
+	require.Contains(t, networking, `vpc_peering_connection_id = aws_vpc_peering_connection_accepter.confluent.id`)
+	require.NotContains(t, networking, "aws_vpc_endpoint")
+	require.Contains(t, files["modules/networking/versions.tf"], "confluentinc/confluent")
+	require.Contains(t, files["providers.tf"], `provider "confluent"`)
+	require.Contains(t, files["inputs.auto.tfvars"], `confluent_network_cidr`)
+	require.NotContains(t, files["inputs.auto.tfvars"], "existing_private_link_vpce_id")
+
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_JumpCluster_TransitGateway(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	project := service.GenerateTerraformModules(jumpClusterConnectivityRequest(hclrequests.ConnectivityTransitGateway))
+	files := projectToFiles(project)
+
+	networking := files["modules/networking/main.tf"]
+	require.Contains(t, networking, `connection_types = ["TRANSITGATEWAY"]`)
+	require.Contains(t, networking, `resource "aws_ram_resource_share" "confluent"`)
+	require.Contains(t, networking, `resource "confluent_transit_gateway_attachment" "transit_gateway"`)
+	require.Contains(t, networking, `resource "aws_ec2_transit_gateway_vpc_attachment_accepter" "confluent"`)
+	require.Contains(t, networking, `transit_gateway_id = data.aws_ec2_transit_gateway.existing.id`)
+	require.Contains(t, files["inputs.auto.tfvars"], `"tgw-0123456789abcdef0"`)
+
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_JumpCluster_PeeringNetworkingOnly(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	project := service.GenerateNetworkingOnlyTerraformModules(jumpClusterConnectivityRequest(hclrequests.ConnectivityPeering))
+	files := projectToFiles(project)
+
+	require.Contains(t, files["main.tf"], "confluent = confluent")
+	require.Contains(t, files["providers.tf"], `provider "confluent"`)
+
+	validateTerraformProject(t, files)
+}
+
+func TestMigrationInfra_CloudBackend(t *testing.T) {
+	t.Parallel()
+
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	backend := &hclrequests.TerraformBackend{Type: hclrequests.TerraformBackendCloud, Organization: "acme", Workspace: "kcp-migration-infra"}
+
+	public := hclrequests.MigrationWizardRequest{HasPublicEndpoints: true, ClusterLinkName: "msk-to-cc-link", Backend: backend}
+	jumpCluster := jumpClusterConnectivityRequest("")
+	jumpCluster.Backend = backend
+
+	for name, providers := range map[string]string{
+		"public":          service.GenerateTerraformModules(public).ProvidersTf,
+		"jump cluster":    service.GenerateTerraformModules(jumpCluster).ProvidersTf,
+		"networking only": service.GenerateNetworkingOnlyTerraformModules(jumpCluster).ProvidersTf,
+	} {
+		_, diags := hclwrite.ParseConfig([]byte(providers), "providers.tf", hclv2.InitialPos)
+		require.False(t, diags.HasErrors(), name)
+		require.Contains(t, providers, "cloud {", name)
+		require.Contains(t, providers, `organization = "acme"`, name)
+		require.Contains(t, providers, `name = "kcp-migration-infra"`, name)
+		require.NotContains(t, providers, "backend", name)
+	}
+}
@@ -0,0 +1,129 @@
+package hcl
+
+import (
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/hcl/modules"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const migrationDashboardTemplateFileName = "migration-dashboard.json.tpl"
+
+// ============================================================================
+// Monitoring Module Generation (optional, all migration types)
+// ============================================================================
+
+// withMonitoring adds the monitoring module to project when the request asks
+// for it. The module only reads CloudWatch metrics, so it is the same for
+// every migration type apart from the jump cluster widgets and alarms.
+func (mi *MigrationInfraHCLService) withMonitoring(project hcltypes.MigrationInfraTerraformProject, request hclrequests.MigrationWizardRequest) hcltypes.MigrationInfraTerraformProject {
+	if !request.MonitoringEnabled {
+		return project
+	}
+
+	project.MainTf = strings.TrimRight(project.MainTf, "\n") + "\n" + mi.generateRootMonitoringModuleBlock(request)
+	project.Modules = append(project.Modules, hcltypes.MigrationInfraTerraformModule{
+		Name:        "monitoring",
+		MainTf:      mi.generateMonitoringMainTf(request),
+		VariablesTf: GenerateVariablesTf(modules.GetMonitoringModuleVariableDefinitions(request)),
+		VersionsTf:  GenerateVersionsTf(aws.AddRequiredProvider),
+		AdditionalFiles: map[string]string{
+			migrationDashboardTemplateFileName: aws.GenerateMigrationDashboardTpl(),
+		},
+	})
+	return project
+}
+
+func (mi *MigrationInfraHCLService) generateRootMonitoringModuleBlock(request hclrequests.MigrationWizardRequest) string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+	rootBody.AppendNewline()
+
+	moduleBody := rootBody.AppendNewBlock("module", []string{"monitoring"}).Body()
+	moduleBody.SetAttributeValue("source", cty.StringVal("./monitoring"))
+	moduleBody.AppendNewline()
+
+	moduleBody.SetAttributeRaw("providers", utils.TokensForMap(map[string]hclwrite.Tokens{
+		"aws": utils.TokensForResourceReference("aws"),
+	}))
+	moduleBody.AppendNewline()
+
+	WriteModuleInputs(moduleBody, modules.GetMonitoringVariables(), request)
+
+	return string(f.Bytes())
+}
+
+func (mi *MigrationInfraHCLService) generateMonitoringMainTf(request hclrequests.MigrationWizardRequest) string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	namePrefix := "kcp-migration-${var." + modules.VarSourceClusterName + "}"
+
+	instanceIDs := utils.TokensForResourceReference("{}")
+	if request.UseJumpClusters {
+		instanceIDs = utils.TokensForVarReference(modules.VarJumpClusterInstanceIDs)
+	}
+	rootBody.AppendBlock(aws.GenerateCloudWatchDashboardResource("migration", namePrefix, migrationDashboardTemplateFileName, map[string]hclwrite.Tokens{
+		"region":                    utils.TokensForVarReference(modules.VarAWSRegion),
+		"cluster_name":              utils.TokensForVarReference(modules.VarSourceClusterName),
+		"jump_cluster_instance_ids": instanceIDs,
+	}))
+	rootBody.AppendNewline()
+
+	alarmActions := ""
+	if request.MonitoringAlarmTopicArn != "" {
+		alarmActions = modules.VarMonitoringAlarmTopicArn
+	}
+	clusterDimensions := map[string]string{"Cluster Name": "var." + modules.VarSourceClusterName}
+
+	rootBody.AppendBlock(aws.GenerateCloudWatchMetricAlarmResource("offline_partitions", aws.CloudWatchMetricAlarm{
+		AlarmName:           namePrefix + "-offline-partitions",
+		Description:         "The source cluster has offline partitions, so neither its clients nor the cluster link can use them.",
+		Namespace:           "AWS/Kafka",
+		MetricName:          "OfflinePartitionsCount",
+		Dimensions:          clusterDimensions,
+		Statistic:           "Maximum",
+		ComparisonOperator:  "GreaterThanThreshold",
+		Threshold:           0,
+		EvaluationPeriods:   1,
+		AlarmActionsVarName: alarmActions,
+	}))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateCloudWatchMetricAlarmResource("active_controller", aws.CloudWatchMetricAlarm{
+		AlarmName:           namePrefix + "-no-active-controller",
+		Description:         "The source cluster has no active controller.",
+		Namespace:           "AWS/Kafka",
+		MetricName:          "ActiveControllerCount",
+		Dimensions:          clusterDimensions,
+		Statistic:           "Maximum",
+		ComparisonOperator:  "LessThanThreshold",
+		Threshold:           1,
+		EvaluationPeriods:   3,
+		AlarmActionsVarName: alarmActions,
+	}))
+
+	if request.UseJumpClusters {
+		rootBody.AppendNewline()
+		rootBody.AppendBlock(aws.GenerateCloudWatchMetricAlarmResource("jump_cluster_status_check", aws.CloudWatchMetricAlarm{
+			AlarmName:           namePrefix + "-jump-cluster-${each.key}-status-check",
+			Description:         "A jump cluster instance is failing its EC2 status checks, which stalls the cluster links running through it.",
+			Namespace:           "AWS/EC2",
+			MetricName:          "StatusCheckFailed",
+			Dimensions:          map[string]string{"InstanceId": "each.value"},
+			Statistic:           "Maximum",
+			ComparisonOperator:  "GreaterThanThreshold",
+			Threshold:           0,
+			EvaluationPeriods:   2,
+			ForEach:             "var." + modules.VarJumpClusterInstanceIDs,
+			AlarmActionsVarName: alarmActions,
+		}))
+	}
+
+	return string(f.Bytes())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/confluent"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/modules"
 	"github.com/confluentinc/kcp/internal/services/hcl/other"
@@ -20,18 +21,7 @@ func (mi *MigrationInfraHCLService) generateRootMainTfForPrivateMigrationInfrast
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
-	networkingModuleBlock := rootBody.AppendNewBlock("module", []string{"networking"})
-	networkingModuleBody := networkingModuleBlock.Body()
-	networkingModuleBody.SetAttributeValue("source", cty.StringVal("./networking"))
-	networkingModuleBody.AppendNewline()
-
-	networkingModuleBody.SetAttributeRaw("providers", utils.TokensForMap(map[string]hclwrite.Tokens{
-		"aws": utils.TokensForResourceReference("aws"),
-	}))
-	networkingModuleBody.AppendNewline()
-
-	WriteModuleInputs(networkingModuleBody, modules.GetNetworkingVariables(), request)
-	rootBody.AppendNewline()
+	mi.appendNetworkingModuleBlock(rootBody, request)
 
 	setupHostModuleBlock := rootBody.AppendNewBlock("module", []string{"jump_cluster_setup_host"})
 	setupHostModuleBody := setupHostModuleBlock.Body()
@@ -65,7 +55,29 @@ func (mi *MigrationInfraHCLService) generateRootMainTfForPrivateMigrationInfrast
 	return string(f.Bytes())
 }
 
-func (mi *MigrationInfraHCLService) generateRootProvidersTfForPrivateMigrationInfrastructure() string {
+// appendNetworkingModuleBlock adds the networking module call shared by the
+// Terraform and Ansible jump cluster projects. The Confluent provider is only
+// passed through when the module creates a Confluent Cloud network.
+func (mi *MigrationInfraHCLService) appendNetworkingModuleBlock(rootBody *hclwrite.Body, request hclrequests.MigrationWizardRequest) {
+	networkingModuleBlock := rootBody.AppendNewBlock("module", []string{"networking"})
+	networkingModuleBody := networkingModuleBlock.Body()
+	networkingModuleBody.SetAttributeValue("source", cty.StringVal("./networking"))
+	networkingModuleBody.AppendNewline()
+
+	providers := map[string]hclwrite.Tokens{
+		"aws": utils.TokensForResourceReference("aws"),
+	}
+	if request.CreatesConfluentNetwork() {
+		providers["confluent"] = utils.TokensForResourceReference("confluent")
+	}
+	networkingModuleBody.SetAttributeRaw("providers", utils.TokensForMap(providers))
+	networkingModuleBody.AppendNewline()
+
+	WriteModuleInputs(networkingModuleBody, modules.GetNetworkingVariables(), request)
+	rootBody.AppendNewline()
+}
+
+func (mi *MigrationInfraHCLService) generateRootProvidersTfForPrivateMigrationInfrastructure(request hclrequests.MigrationWizardRequest) string {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
@@ -76,11 +88,20 @@ func (mi *MigrationInfraHCLService) generateRootProvidersTfForPrivateMigrationIn
 	requiredProvidersBody := requiredProvidersBlock.Body()
 
 	requiredProvidersBody.SetAttributeRaw(aws.GenerateRequiredProviderTokens())
+	if request.CreatesConfluentNetwork() {
+		requiredProvidersBody.SetAttributeRaw(confluent.GenerateRequiredProviderTokens())
+	}
+	appendBackendBlock(terraformBody, request.Backend)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(aws.GenerateProviderBlockWithVarAndDeploymentID(mi.DeploymentID))
 	rootBody.AppendNewline()
 
+	if request.CreatesConfluentNetwork() {
+		rootBody.AppendBlock(confluent.GenerateProviderBlock())
+		rootBody.AppendNewline()
+	}
+
 	return string(f.Bytes())
 }
 
@@ -113,7 +134,7 @@ You will be prompted for the following credentials during ` + "`terraform apply`
 - AWS credentials configured (via environment variables, AWS CLI profile, or IAM role)
 - Confluent Cloud API key and secret (Cloud Resource Management)
 - Confluent Cloud cluster API key and secret
-- Private Link setup between the AWS VPC (` + request.VpcId + `) and Confluent Cloud
+- ` + jumpClusterConnectivityPrerequisite(request) + `
 
 ## Required Credentials
 ` + credentialsSection + `
@@ -144,21 +165,71 @@ Terraform will prompt you for the required credentials listed above.
 
 After ` + "`terraform apply`" + ` completes, the following infrastructure is provisioned:
 
-- **Networking**: VPC subnets, security groups, NAT gateway, and SSH key pair
-- **Jump cluster brokers**: Confluent Platform Kafka instances deployed on EC2
+- **Networking**: VPC subnets, security groups, NAT gateway, and SSH key pair` + jumpClusterConnectivityResources(request) + `
+- **Jump cluster brokers**: Confluent Platform Kafka instances deployed on EC2` + jumpClusterInstanceResources(request) + jumpClusterSourceCAResources(request) + `
 - **Setup host**: An EC2 instance that runs Ansible playbooks to configure the jump cluster and establish cluster links between MSK, the jump cluster, and Confluent Cloud
-
+` + jumpClusterEgressResources(request) + `
 The setup host automatically orchestrates the full configuration — no manual Ansible execution is required.
 
 Note: Due to the nature of how the cluster link is created between the jump cluster and Confluent Cloud, the deletion of the cluster link will need to be manually performed using the Confluent Cloud CLI within the VPC network.
 `
 }
 
+func jumpClusterInstanceResources(request hclrequests.MigrationWizardRequest) string {
+	out := " (" + aws.JumpClusterAmiFor(request).Description
+	if request.JumpClusterSpotInstances {
+		out += ", spot instances"
+	}
+	out += ")"
+	if request.JumpClusterSpotInstances {
+		out += ". A spot interruption terminates the broker: `terraform apply` launches a replacement, which must then be configured again from the setup host with the Confluent Platform Ansible playbooks"
+	}
+	return out
+}
+
+func jumpClusterEgressResources(request hclrequests.MigrationWizardRequest) string {
+	if request.JumpClusterCABundle == "" && request.JumpClusterHTTPProxy == "" && request.JumpClusterHTTPSProxy == "" {
+		return ""
+	}
+	return "\nThe jump cluster brokers and setup host install the corporate CA bundle (`jump_cluster_ca_bundle`) and route outbound HTTP(S) through the proxies (`jump_cluster_http_proxy`, `jump_cluster_https_proxy`, except for `jump_cluster_no_proxy`) set in `inputs.auto.tfvars` before any package is downloaded. User data only runs at first boot, so changing them later does not reconfigure running instances.\n"
+}
+
+func jumpClusterSourceCAResources(request hclrequests.MigrationWizardRequest) string {
+	if request.SourceCACertificate == "" {
+		return ""
+	}
+	return ", each trusting the private CA that issued the source brokers' certificates (`source_ca_certificate`, installed at `/etc/pki/kcp/source-ca.pem`). Replace the CA in `inputs.auto.tfvars` if the one recorded by `kcp scan clusters --inspect-tls` is not the root your brokers chain to"
+}
+
+func jumpClusterConnectivityPrerequisite(request hclrequests.MigrationWizardRequest) string {
+	switch request.ConnectivityMode() {
+	case hclrequests.ConnectivityPeering:
+		return "A target Confluent Cloud dedicated cluster in the VPC peering network created by this configuration (CIDR " + request.ConfluentNetworkCidr + ", which must not overlap with the AWS VPC (" + request.VpcId + "))"
+	case hclrequests.ConnectivityTransitGateway:
+		return "The AWS VPC (" + request.VpcId + ") attached to the transit gateway (" + request.TransitGatewayId + "), and a target Confluent Cloud dedicated cluster in the transit gateway network created by this configuration (CIDR " + request.ConfluentNetworkCidr + ")"
+	default:
+		return "Private Link setup between the AWS VPC (" + request.VpcId + ") and Confluent Cloud"
+	}
+}
+
+func jumpClusterConnectivityResources(request hclrequests.MigrationWizardRequest) string {
+	switch request.ConnectivityMode() {
+	case hclrequests.ConnectivityPeering:
+		return ", plus a Confluent Cloud network, the VPC peering connection to it and the routes to its CIDR"
+	case hclrequests.ConnectivityTransitGateway:
+		return ", plus a Confluent Cloud network, the AWS RAM share of the transit gateway, the transit gateway attachment and the routes to its CIDR"
+	default:
+		return ""
+	}
+}
+
 // ============================================================================
 // Jump Cluster Setup Host Module Generation (Private)
 // ============================================================================
 
-func (mi *MigrationInfraHCLService) generateJumpClusterSetupHostMainTf() string {
+func (mi *MigrationInfraHCLService) generateJumpClusterSetupHostMainTf(request hclrequests.MigrationWizardRequest) string {
+	brokerAmi := aws.JumpClusterAmiFor(request)
+
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
@@ -173,15 +244,22 @@ func (mi *MigrationInfraHCLService) generateJumpClusterSetupHostMainTf() string
 	rootBody.AppendBlock(aws.GenerateEc2UserDataInstanceResource(
 		"jump_cluster_setup_host",
 		"data.aws_ami.amzn_linux_ami.id",
-		"t2.medium",
+		aws.HelperInstanceType,
 		modules.VarJumpClusterSetupHostSubnetID,
 		modules.VarJumpClusterSecurityGroupIDs,
 		modules.VarJumpClusterSSHKeyPairName,
 		"jump-cluster-setup-host-user-data.tpl",
 		true,
 		map[string]hclwrite.Tokens{
-			"broker_ips":  utils.TokensForVarReference(modules.VarJumpClusterInstancesPrivateDNS),
-			"private_key": utils.TokensForVarReference(modules.VarPrivateKey),
+			"broker_ips":                    utils.TokensForVarReference(modules.VarJumpClusterInstancesPrivateDNS),
+			"private_key":                   utils.TokensForVarReference(modules.VarPrivateKey),
+			"broker_os_user":                utils.TokensForVarReference(modules.VarJumpClusterOSUser),
+			"broker_python_interpreter":     hclwrite.TokensForValue(cty.StringVal(brokerAmi.PythonInterpreter)),
+			"broker_python_install_command": hclwrite.TokensForValue(cty.StringVal(brokerAmi.PythonInstallCommand)),
+			"jump_cluster_ca_bundle":        utils.TokensForVarReference(modules.VarJumpClusterCABundle),
+			"jump_cluster_http_proxy":       utils.TokensForVarReference(modules.VarJumpClusterHTTPProxy),
+			"jump_cluster_https_proxy":      utils.TokensForVarReference(modules.VarJumpClusterHTTPSProxy),
+			"jump_cluster_no_proxy":         utils.TokensForVarReference(modules.VarJumpClusterNoProxy),
 		},
 		nil,
 	))
@@ -215,12 +293,8 @@ func (mi *MigrationInfraHCLService) generateJumpClustersMainTf(request hclreques
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
-	rootBody.AppendBlock(aws.GenerateAmiDataResource("red_hat_linux_ami", "309956199498", true, map[string]string{
-		"name":                "RHEL-9.6.0_HVM_GA-*",
-		"state":               "available",
-		"architecture":        "x86_64",
-		"virtualization-type": "hvm",
-	}))
+	ami := aws.JumpClusterAmiFor(request)
+	rootBody.AppendBlock(ami.GenerateDataResource())
 	rootBody.AppendNewline()
 
 	commonUserDataArgs := map[string]hclwrite.Tokens{
@@ -232,6 +306,12 @@ func (mi *MigrationInfraHCLService) generateJumpClustersMainTf(request hclreques
 		"source_cluster_id":                          utils.TokensForVarReference(modules.VarMSKClusterID),
 		"source_cluster_bootstrap_brokers":           utils.TokensForVarReference(modules.VarMSKClusterBootstrapBrokers),
 		"cluster_link_name":                          utils.TokensForVarReference(modules.VarClusterLinkName),
+		"os_user":                                    utils.TokensForVarReference(modules.VarJumpClusterOSUser),
+		"python_install_command":                     hclwrite.TokensForValue(cty.StringVal(ami.PythonInstallCommand)),
+		"jump_cluster_ca_bundle":                     utils.TokensForVarReference(modules.VarJumpClusterCABundle),
+		"jump_cluster_http_proxy":                    utils.TokensForVarReference(modules.VarJumpClusterHTTPProxy),
+		"jump_cluster_https_proxy":                   utils.TokensForVarReference(modules.VarJumpClusterHTTPSProxy),
+		"jump_cluster_no_proxy":                      utils.TokensForVarReference(modules.VarJumpClusterNoProxy),
 	}
 
 	optionalBlocks := aws.OptionalBlocksConfig{
@@ -244,15 +324,23 @@ func (mi *MigrationInfraHCLService) generateJumpClustersMainTf(request hclreques
 			"http_put_response_hop_limit": cty.NumberIntVal(10),
 		},
 	}
+	if request.JumpClusterSpotInstances {
+		// One-time spot requests: an interrupted broker is terminated and
+		// comes back on the next `terraform apply`.
+		optionalBlocks["instance_market_options"] = map[string]any{
+			"market_type": cty.StringVal("spot"),
+		}
+	}
 
 	switch request.JumpClusterAuthType {
 	case "sasl_scram":
 		commonUserDataArgs["source_sasl_scram_username"] = utils.TokensForVarReference(modules.VarMSKSaslScramUsername)
 		commonUserDataArgs["source_sasl_scram_password"] = utils.TokensForVarReference(modules.VarMSKSaslScramPassword)
 		commonUserDataArgs["source_sasl_scram_mechanism"] = utils.TokensForVarReference(modules.VarMSKSaslScramMechanism)
+		commonUserDataArgs["source_ca_certificate"] = utils.TokensForVarReference(modules.VarSourceCACertificate)
 		rootBody.AppendBlock(aws.GenerateEc2UserDataInstanceResourceWithForEach(
 			"jump_cluster",
-			"data.aws_ami.red_hat_linux_ami.id",
+			ami.DataSourceId(),
 			modules.VarJumpClusterInstanceType,
 			modules.VarJumpClusterBrokerSubnetIDs,
 			modules.VarJumpClusterSecurityGroupIDs,
@@ -266,7 +354,7 @@ func (mi *MigrationInfraHCLService) generateJumpClustersMainTf(request hclreques
 	default: // iam
 		rootBody.AppendBlock(aws.GenerateEc2UserDataInstanceResourceWithForEach(
 			"jump_cluster",
-			"data.aws_ami.red_hat_linux_ami.id",
+			ami.DataSourceId(),
 			modules.VarJumpClusterInstanceType,
 			modules.VarJumpClusterBrokerSubnetIDs,
 			modules.VarJumpClusterSecurityGroupIDs,
@@ -347,18 +435,58 @@ func (mi *MigrationInfraHCLService) generateNetworkingMainTf(request hclrequests
 	rootBody.AppendBlock(aws.GenerateNATGatewayResource("nat_gw", "aws_eip.nat_eip.id", "aws_subnet.jump_cluster_setup_host_subnet.id"))
 	rootBody.AppendNewline()
 
-	rootBody.AppendBlock(aws.GenerateRouteTableResource("jump_cluster_setup_host_public_rt", aws.GetInternetGatewayReference(request.HasExistingInternetGateway, "internet_gateway"), modules.VarVpcID))
+	publicRouteTable := aws.GenerateRouteTableResource("jump_cluster_setup_host_public_rt", aws.GetInternetGatewayReference(request.HasExistingInternetGateway, "internet_gateway"), modules.VarVpcID)
+	privateRouteTable := aws.GenerateRouteTableResource("private_subnet_rt", "aws_nat_gateway.nat_gw.id", modules.VarVpcID)
+	if request.CreatesConfluentNetwork() {
+		// Both the brokers and the setup host reach Confluent Cloud directly.
+		cidrRef, targetAttribute, targetRef := confluentNetworkRoute(request)
+		aws.AddRouteToRouteTable(publicRouteTable, cidrRef, targetAttribute, targetRef)
+		aws.AddRouteToRouteTable(privateRouteTable, cidrRef, targetAttribute, targetRef)
+	}
+
+	rootBody.AppendBlock(publicRouteTable)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(aws.GenerateRouteTableAssociationResource("jump_cluster_setup_host_public_rt_association", aws.GenerateSubnetResourceReference("jump_cluster_setup_host_subnet"), "aws_route_table.jump_cluster_setup_host_public_rt.id"))
 	rootBody.AppendNewline()
 
-	rootBody.AppendBlock(aws.GenerateRouteTableResource("private_subnet_rt", "aws_nat_gateway.nat_gw.id", modules.VarVpcID))
+	rootBody.AppendBlock(privateRouteTable)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(aws.GenerateRouteTableAssociationResourceWithCount("jump_cluster_broker_route_table_assoc", aws.GenerateSubnetResourceReference("jump_cluster_broker_subnets"), "aws_route_table.private_subnet_rt.id"))
 	rootBody.AppendNewline()
 
+	switch request.ConnectivityMode() {
+	case hclrequests.ConnectivityPeering:
+		mi.appendPeeringConnectivity(rootBody)
+	case hclrequests.ConnectivityTransitGateway:
+		mi.appendTransitGatewayConnectivity(rootBody)
+	default:
+		mi.appendPrivateLinkConnectivity(rootBody)
+	}
+
+	rootBody.AppendBlock(other.GenerateTLSPrivateKeyResource("jump_cluster_ssh_key", "RSA", 4096))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(other.GenerateLocalFileResource("jump_cluster_ssh_key_private_key", "tls_private_key.jump_cluster_ssh_key.private_key_pem", "./.ssh/jump_cluster_ssh_key_private_key_rsa", "400"))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(other.GenerateLocalFileResource("jump_cluster_ssh_key_public_key", "tls_private_key.jump_cluster_ssh_key.public_key_openssh", "./.ssh/jump_cluster_ssh_key_public_key.pub", "400"))
+	rootBody.AppendNewline()
+
+	sshKeySuffix := mi.SSHKeySuffix
+	if sshKeySuffix == "" {
+		sshKeySuffix = utils.RandomString(5)
+	}
+	rootBody.AppendBlock(aws.GenerateKeyPairResource("jump_cluster_ssh_key", fmt.Sprintf("jump_cluster_ssh_key_%s", sshKeySuffix), "tls_private_key.jump_cluster_ssh_key.public_key_openssh"))
+	rootBody.AppendNewline()
+
+	return string(f.Bytes())
+}
+
+// appendPrivateLinkConnectivity opens the existing Private Link endpoint's
+// security group to the jump cluster.
+func (mi *MigrationInfraHCLService) appendPrivateLinkConnectivity(rootBody *hclwrite.Body) {
 	rootBody.AppendBlock(aws.GenerateVpcEndpointDataSource("existing_vpce", modules.VarExistingPrivateLinkVpceID))
 	rootBody.AppendNewline()
 
@@ -371,24 +499,84 @@ func (mi *MigrationInfraHCLService) generateNetworkingMainTf(request hclrequests
 		))
 		rootBody.AppendNewline()
 	}
+}
 
-	rootBody.AppendBlock(other.GenerateTLSPrivateKeyResource("jump_cluster_ssh_key", "RSA", 4096))
+// appendPeeringConnectivity creates a PEERING Confluent Cloud network and
+// accepts the peering connection Confluent Cloud requests to the VPC.
+func (mi *MigrationInfraHCLService) appendPeeringConnectivity(rootBody *hclwrite.Body) {
+	rootBody.AppendBlock(aws.GenerateCallerIdentityDataSource("current"))
 	rootBody.AppendNewline()
 
-	rootBody.AppendBlock(other.GenerateLocalFileResource("jump_cluster_ssh_key_private_key", "tls_private_key.jump_cluster_ssh_key.private_key_pem", "./.ssh/jump_cluster_ssh_key_private_key_rsa", "400"))
+	rootBody.AppendBlock(confluent.GenerateCidrNetworkResource("peering", "kcp-migration-peering-network", "PEERING", modules.VarAWSRegion, modules.VarConfluentNetworkCidr, modules.VarTargetEnvironmentID))
 	rootBody.AppendNewline()
 
-	rootBody.AppendBlock(other.GenerateLocalFileResource("jump_cluster_ssh_key_public_key", "tls_private_key.jump_cluster_ssh_key.public_key_openssh", "./.ssh/jump_cluster_ssh_key_public_key.pub", "400"))
+	rootBody.AppendBlock(confluent.GeneratePeeringResource(
+		"peering",
+		"kcp-migration-peering",
+		"data.aws_caller_identity.current.account_id",
+		modules.VarVpcID,
+		jumpClusterSubnetCidrTokens(),
+		modules.VarAWSRegion,
+		modules.VarTargetEnvironmentID,
+		"confluent_network.peering.id",
+	))
 	rootBody.AppendNewline()
 
-	sshKeySuffix := mi.SSHKeySuffix
-	if sshKeySuffix == "" {
-		sshKeySuffix = utils.RandomString(5)
-	}
-	rootBody.AppendBlock(aws.GenerateKeyPairResource("jump_cluster_ssh_key", fmt.Sprintf("jump_cluster_ssh_key_%s", sshKeySuffix), "tls_private_key.jump_cluster_ssh_key.public_key_openssh"))
+	rootBody.AppendBlock(aws.GenerateVpcPeeringConnectionDataSource("confluent", "confluent_network.peering.aws[0].vpc", "confluent_peering.peering.aws[0].vpc"))
 	rootBody.AppendNewline()
 
-	return string(f.Bytes())
+	rootBody.AppendBlock(aws.GenerateVpcPeeringConnectionAccepterResource("confluent", "data.aws_vpc_peering_connection.confluent.id"))
+	rootBody.AppendNewline()
+}
+
+// appendTransitGatewayConnectivity creates a TRANSITGATEWAY Confluent Cloud
+// network, shares the existing transit gateway with Confluent Cloud through AWS
+// RAM and accepts the resulting attachment.
+func (mi *MigrationInfraHCLService) appendTransitGatewayConnectivity(rootBody *hclwrite.Body) {
+	rootBody.AppendBlock(aws.GenerateTransitGatewayDataSource("existing", modules.VarTransitGatewayID))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(confluent.GenerateCidrNetworkResource("transit_gateway", "kcp-migration-transit-gateway-network", "TRANSITGATEWAY", modules.VarAWSRegion, modules.VarConfluentNetworkCidr, modules.VarTargetEnvironmentID))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateRamResourceShareResource("confluent", "kcp-migration-transit-gateway-share"))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateRamPrincipalAssociationResource("confluent", "confluent_network.transit_gateway.aws[0].account", "aws_ram_resource_share.confluent.arn"))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateRamResourceAssociationResource("transit_gateway", "data.aws_ec2_transit_gateway.existing.arn", "aws_ram_resource_share.confluent.arn"))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(confluent.GenerateTransitGatewayAttachmentResource(
+		"transit_gateway",
+		"kcp-migration-transit-gateway-attachment",
+		"aws_ram_resource_share.confluent.arn",
+		modules.VarTransitGatewayID,
+		jumpClusterSubnetCidrTokens(),
+		modules.VarTargetEnvironmentID,
+		"confluent_network.transit_gateway.id",
+		[]string{"aws_ram_principal_association.confluent", "aws_ram_resource_association.transit_gateway"},
+	))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateTransitGatewayVpcAttachmentAccepterResource("confluent", "confluent_transit_gateway_attachment.transit_gateway.aws[0].transit_gateway_attachment_id"))
+	rootBody.AppendNewline()
+}
+
+// confluentNetworkRoute returns the destination CIDR and target of the route
+// from the jump cluster subnets to the Confluent Cloud network.
+func confluentNetworkRoute(request hclrequests.MigrationWizardRequest) (cidrRef, targetAttribute, targetRef string) {
+	if request.ConnectivityMode() == hclrequests.ConnectivityTransitGateway {
+		return "confluent_network.transit_gateway.cidr", "transit_gateway_id", "data.aws_ec2_transit_gateway.existing.id"
+	}
+	return "confluent_network.peering.cidr", "vpc_peering_connection_id", "aws_vpc_peering_connection_accepter.confluent.id"
+}
+
+// jumpClusterSubnetCidrTokens lists the jump cluster subnet CIDRs, the routes
+// Confluent Cloud needs back into the VPC.
+func jumpClusterSubnetCidrTokens() hclwrite.Tokens {
+	return utils.TokensForResourceReference(fmt.Sprintf("concat(var.%s, [var.%s])", modules.VarJumpClusterBrokerSubnetCidrs, modules.VarJumpClusterSetupHostSubnetCidr))
 }
 
 func (mi *MigrationInfraHCLService) generateNetworkingVariablesTf(request hclrequests.MigrationWizardRequest) string {
@@ -399,6 +587,9 @@ func (mi *MigrationInfraHCLService) generateNetworkingOutputsTf() string {
 	return GenerateOutputsTf(modules.GetNetworkingModuleOutputDefinitions())
 }
 
-func (mi *MigrationInfraHCLService) generateNetworkingVersionsTf() string {
+func (mi *MigrationInfraHCLService) generateNetworkingVersionsTf(request hclrequests.MigrationWizardRequest) string {
+	if request.CreatesConfluentNetwork() {
+		return GenerateVersionsTf(aws.AddRequiredProvider, confluent.AddRequiredProvider)
+	}
 	return GenerateVersionsTf(aws.AddRequiredProvider)
 }
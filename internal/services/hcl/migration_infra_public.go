@@ -1,6 +1,7 @@
 package hcl
 
 import (
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
 	"github.com/confluentinc/kcp/internal/services/hcl/confluent"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/modules"
@@ -24,10 +25,25 @@ func (mi *MigrationInfraHCLService) generateRootMainTfForPublicMigrationInfrastr
 
 	WriteModuleInputs(moduleBody, modules.GetClusterLinkVariables(), request)
 
+	for _, destination := range request.AdditionalDestinations {
+		name := clusterLinkDestinationModuleName(destination)
+		rootBody.AppendNewline()
+		destinationBody := rootBody.AppendNewBlock("module", []string{name}).Body()
+		destinationBody.SetAttributeValue("source", cty.StringVal("./"+name))
+		destinationBody.AppendNewline()
+		WriteModuleInputs(destinationBody, modules.GetClusterLinkDestinationVariables(destination), request)
+	}
+
 	return string(f.Bytes())
 }
 
-func (mi *MigrationInfraHCLService) generateRootProvidersTfForClusterLink() string {
+// clusterLinkDestinationModuleName is the module, and directory, of the
+// cluster link to an additional destination.
+func clusterLinkDestinationModuleName(destination hclrequests.ClusterLinkDestination) string {
+	return "cluster_link_" + destination.Name
+}
+
+func (mi *MigrationInfraHCLService) generateRootProvidersTfForClusterLink(request hclrequests.MigrationWizardRequest) string {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
@@ -38,11 +54,21 @@ func (mi *MigrationInfraHCLService) generateRootProvidersTfForClusterLink() stri
 	requiredProvidersBody := requiredProvidersBlock.Body()
 
 	requiredProvidersBody.SetAttributeRaw(confluent.GenerateRequiredProviderTokens())
+	if request.MonitoringEnabled {
+		// The public cluster link only needs AWS for the CloudWatch monitoring.
+		requiredProvidersBody.SetAttributeRaw(aws.GenerateRequiredProviderTokens())
+	}
+	appendBackendBlock(terraformBody, request.Backend)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(confluent.GenerateProviderBlock())
 	rootBody.AppendNewline()
 
+	if request.MonitoringEnabled {
+		rootBody.AppendBlock(aws.GenerateProviderBlockWithVarAndDeploymentID(mi.DeploymentID))
+		rootBody.AppendNewline()
+	}
+
 	return string(f.Bytes())
 }
 
@@ -50,10 +76,15 @@ func (mi *MigrationInfraHCLService) generateRootProvidersTfForClusterLink() stri
 // Cluster Link Module Generation (Public)
 // ============================================================================
 
-func (mi *MigrationInfraHCLService) generateClusterLinkMainTf() string {
+func (mi *MigrationInfraHCLService) generateClusterLinkMainTf(request hclrequests.MigrationWizardRequest) string {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
+	linkPrefixVar := ""
+	if request.ClusterLinkPrefix != "" {
+		linkPrefixVar = modules.VarClusterLinkPrefix
+	}
+
 	rootBody.AppendBlock(confluent.GenerateClusterLinkLocals(
 		modules.VarConfluentCloudClusterAPIKey,
 		modules.VarConfluentCloudClusterAPISecret,
@@ -66,13 +97,35 @@ func (mi *MigrationInfraHCLService) generateClusterLinkMainTf() string {
 		modules.VarTargetClusterID,
 		modules.VarTargetClusterRestEndpoint,
 		modules.VarClusterLinkName,
+		modules.VarClusterLinkMode,
+		linkPrefixVar,
 		modules.VarMSKSaslScramBootstrapServers,
 		modules.VarMSKSaslScramMechanism,
 		modules.VarMSKSaslScramUsername,
 		modules.VarMSKSaslScramPassword,
+		confluent.ClusterLinkTuningConfigs(request),
 	))
 	rootBody.AppendNewline()
 
+	// A bidirectional link needs a matching link on the source cluster, which
+	// lets topics be mirrored back to it should the migration be rolled back.
+	if request.IsBidirectionalLink() {
+		rootBody.AppendBlock(confluent.GenerateReverseClusterLinkResource(
+			"confluent_cluster_link_reverse",
+			modules.VarMSKClusterID,
+			modules.VarSourceClusterRestEndpoint,
+			modules.VarSourceClusterRestAPIKey,
+			modules.VarSourceClusterRestAPISecret,
+			modules.VarTargetClusterID,
+			modules.VarTargetClusterBootstrapEndpoint,
+			modules.VarConfluentCloudClusterAPIKey,
+			modules.VarConfluentCloudClusterAPISecret,
+			modules.VarClusterLinkName,
+			linkPrefixVar,
+		))
+		rootBody.AppendNewline()
+	}
+
 	return string(f.Bytes())
 }
 
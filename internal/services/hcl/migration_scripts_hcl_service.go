@@ -47,12 +47,16 @@ func (s *MigrationScriptsHCLService) GenerateMirrorTopicsFiles(request hclreques
 		return hcltypes.MigrationScriptsTerraformProject{}, fmt.Errorf("invalid mode: %q (values: %s, %s)", request.Mode, hclrequests.MigrateTopicsModeMirror, hclrequests.MigrateTopicsModeNew)
 	}
 
+	if request.Mode == hclrequests.MigrateTopicsModeMirror && len(request.TopicRenames) > 0 {
+		return hcltypes.MigrationScriptsTerraformProject{}, fmt.Errorf("topic renaming is not supported in %s mode: the cluster link can only add a prefix to mirror topic names", hclrequests.MigrateTopicsModeMirror)
+	}
+
 	topics := topicsForRequest(request)
 
 	perTopicFiles := make(map[string]string, len(topics))
 	collisions := make(map[string][]string)
 	for _, topic := range topics {
-		filename := utils.FormatHclResourceName(topic.Name) + ".tf"
+		filename := utils.FormatHclResourceName(request.DestinationTopicName(topic.Name)) + ".tf"
 		if _, exists := perTopicFiles[filename]; exists {
 			collisions[filename] = append(collisions[filename], topic.Name)
 			continue
@@ -94,13 +98,15 @@ const newModeTopicHeader = `# Generated by kcp create-asset migrate-topics --mod
 
 // generateSingleNewTopicTf renders one confluent_kafka_topic resource block.
 // Source partitions and allow-listed configs are preserved; everything else
-// (including replication.factor) is dropped via the allow-list filter.
+// (including replication.factor) is dropped via the allow-list filter. The
+// resource is named after the destination topic name.
 func (s *MigrationScriptsHCLService) generateSingleNewTopicTf(topic types.TopicDetails, request hclrequests.MirrorTopicsRequest) string {
 	f := hclwrite.NewEmptyFile()
-	tfResourceName := utils.FormatHclResourceName(topic.Name)
+	destinationName := request.DestinationTopicName(topic.Name)
+	tfResourceName := utils.FormatHclResourceName(destinationName)
 	f.Body().AppendBlock(confluent.GenerateNewTopic(
 		tfResourceName,
-		topic.Name,
+		destinationName,
 		topic.Partitions,
 		topic.Configurations,
 		request.TargetClusterId,
@@ -126,12 +132,23 @@ func topicsForRequest(request hclrequests.MirrorTopicsRequest) []types.TopicDeta
 }
 
 func (s *MigrationScriptsHCLService) GenerateMigrateAclsFiles(request hclrequests.MigrateAclsRequest) (hcltypes.TerraformFiles, error) {
-	return hcltypes.TerraformFiles{
+	if request.GeneratesIdentities() && request.TargetEnvironmentId == "" {
+		return hcltypes.TerraformFiles{}, fmt.Errorf("a target environment ID is required to generate API keys or role bindings")
+	}
+
+	files := hcltypes.TerraformFiles{
 		PerPrincipalTf:   s.generatePerPrincipalACLsTf(request),
 		ProvidersTf:      s.GenerateProvidersTf(),
-		VariablesTf:      s.generateMigrateACLsVariablesTf(),
+		VariablesTf:      s.generateMigrateACLsVariablesTf(request),
 		InputsAutoTfvars: s.generateMigrateACLsInputsAutoTfvars(request),
-	}, nil
+	}
+	if request.GeneratesIdentities() {
+		files.MainTf = s.generateMigrateACLsMainTf()
+	}
+	if request.GenerateApiKeys {
+		files.OutputsTf = s.generateMigrateACLsOutputsTf(request)
+	}
+	return files, nil
 }
 
 func (s *MigrationScriptsHCLService) GenerateMigrateSchemasFiles(request hclrequests.MigrateSchemasRequest) (hcltypes.MigrationScriptsTerraformProject, error) {
@@ -186,11 +203,13 @@ func (s *MigrationScriptsHCLService) GenerateProvidersTf() string {
 
 // generateSingleMirrorTopicTf renders one mirror-topic resource block to a
 // stand-alone .tf file contents. Per-topic files compose with the shared
-// providers.tf / variables.tf to form a complete Terraform module.
+// providers.tf / variables.tf to form a complete Terraform module. With a
+// TopicNamePrefix the mirror topic is named as the link's prefix names it.
 func (s *MigrationScriptsHCLService) generateSingleMirrorTopicTf(topicName string, request hclrequests.MirrorTopicsRequest) string {
 	f := hclwrite.NewEmptyFile()
-	tfResourceName := utils.FormatHclResourceName(topicName)
-	f.Body().AppendBlock(confluent.GenerateMirrorTopic(tfResourceName, topicName, request.ClusterLinkName, request.TargetClusterId, request.TargetClusterRestEndpoint))
+	mirrorTopicName := request.DestinationTopicName(topicName)
+	tfResourceName := utils.FormatHclResourceName(mirrorTopicName)
+	f.Body().AppendBlock(confluent.GenerateMirrorTopic(tfResourceName, topicName, mirrorTopicName, request.ClusterLinkName, request.TargetClusterId, request.TargetClusterRestEndpoint))
 	return string(f.Bytes())
 }
 
@@ -274,6 +293,30 @@ func (s *MigrationScriptsHCLService) generatePerPrincipalACLsTf(request hclreque
 			aclIndex++
 		}
 
+		if request.GenerateRoleBindings {
+			for i, binding := range aclRoleBindings(acls) {
+				rootBody.AppendBlock(confluent.GenerateRoleBinding(
+					utils.FormatHclResourceName(fmt.Sprintf("%s_%s_%d", principal, binding.role, i)),
+					fmt.Sprintf("User:${confluent_service_account.%s.id}", serviceAccountResourceName),
+					binding.role,
+					utils.TokensForStringTemplate(fmt.Sprintf("${data.confluent_kafka_cluster.%s.rbac_crn}/kafka=${data.confluent_kafka_cluster.%s.id}/%s", migrateAclsTargetClusterDataSource, migrateAclsTargetClusterDataSource, binding.resource)),
+					request.PreventDestroy,
+				))
+				rootBody.AppendNewline()
+			}
+		}
+
+		if request.GenerateApiKeys {
+			rootBody.AppendBlock(confluent.GenerateClusterAPIKey(
+				principalAPIKeyResourceName(principal),
+				principal,
+				serviceAccountResourceName,
+				migrateAclsTargetClusterDataSource,
+				request.PreventDestroy,
+			))
+			rootBody.AppendNewline()
+		}
+
 		fileName := fmt.Sprintf("%s.tf", serviceAccountResourceName)
 		result[fileName] = string(f.Bytes())
 	}
@@ -281,37 +324,20 @@ func (s *MigrationScriptsHCLService) generatePerPrincipalACLsTf(request hclreque
 	return result
 }
 
-func (s *MigrationScriptsHCLService) generateMigrateACLsVariablesTf() string {
-	f := hclwrite.NewEmptyFile()
-	rootBody := f.Body()
-
-	variables := []struct {
-		name        string
-		description string
-		sensitive   bool
-	}{
-		{confluent.VarConfluentCloudAPIKey, "Confluent Cloud API Key", false},
-		{confluent.VarConfluentCloudAPISecret, "Confluent Cloud API Secret", true},
-		{"confluent_cloud_cluster_id", "Confluent Cloud cluster ID", false},
-		{"confluent_cloud_cluster_rest_endpoint", "Confluent Cloud cluster REST endpoint", false},
-		{"confluent_cloud_cluster_api_key", "Confluent Cloud cluster API key", false},
-		{"confluent_cloud_cluster_api_secret", "Confluent Cloud cluster API secret", true},
+func (s *MigrationScriptsHCLService) generateMigrateACLsVariablesTf(request hclrequests.MigrateAclsRequest) string {
+	variables := []hcltypes.TerraformVariable{
+		{Name: confluent.VarConfluentCloudAPIKey, Description: "Confluent Cloud API Key", Type: "string"},
+		{Name: confluent.VarConfluentCloudAPISecret, Description: "Confluent Cloud API Secret", Sensitive: true, Type: "string"},
+		{Name: "confluent_cloud_cluster_id", Description: "Confluent Cloud cluster ID", Type: "string"},
+		{Name: "confluent_cloud_cluster_rest_endpoint", Description: "Confluent Cloud cluster REST endpoint", Type: "string"},
+		{Name: "confluent_cloud_cluster_api_key", Description: "Confluent Cloud cluster API key", Type: "string"},
+		{Name: "confluent_cloud_cluster_api_secret", Description: "Confluent Cloud cluster API secret", Sensitive: true, Type: "string"},
 	}
-
-	for _, v := range variables {
-		variableBlock := rootBody.AppendNewBlock("variable", []string{v.name})
-		variableBody := variableBlock.Body()
-		variableBody.SetAttributeRaw("type", utils.TokensForResourceReference("string"))
-		if v.description != "" {
-			variableBody.SetAttributeValue("description", cty.StringVal(v.description))
-		}
-		if v.sensitive {
-			variableBody.SetAttributeValue("sensitive", cty.BoolVal(true))
-		}
-		rootBody.AppendNewline()
+	if request.GeneratesIdentities() {
+		variables = append(variables, hcltypes.TerraformVariable{Name: "confluent_cloud_environment_id", Description: "Confluent Cloud environment ID of the target cluster", Type: "string"})
 	}
 
-	return string(f.Bytes())
+	return GenerateVariablesTf(variables)
 }
 
 func (s *MigrationScriptsHCLService) generateMigrateACLsInputsAutoTfvars(request hclrequests.MigrateAclsRequest) string {
@@ -320,10 +346,118 @@ func (s *MigrationScriptsHCLService) generateMigrateACLsInputsAutoTfvars(request
 
 	rootBody.SetAttributeValue("confluent_cloud_cluster_id", cty.StringVal(request.TargetClusterId))
 	rootBody.SetAttributeValue("confluent_cloud_cluster_rest_endpoint", cty.StringVal(request.TargetClusterRestEndpoint))
+	if request.GeneratesIdentities() {
+		rootBody.SetAttributeValue("confluent_cloud_environment_id", cty.StringVal(request.TargetEnvironmentId))
+	}
+
+	return string(f.Bytes())
+}
+
+// migrateAclsTargetClusterDataSource names the confluent_kafka_cluster data
+// source that API keys and role bindings resolve the target cluster through.
+const migrateAclsTargetClusterDataSource = "target"
+
+func (s *MigrationScriptsHCLService) generateMigrateACLsMainTf() string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	clusterBlock := hclwrite.NewBlock("data", []string{"confluent_kafka_cluster", migrateAclsTargetClusterDataSource})
+	clusterBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference("confluent_cloud_cluster_id"))
+	environmentBlock := hclwrite.NewBlock("environment", nil)
+	environmentBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference("confluent_cloud_environment_id"))
+	clusterBlock.Body().AppendBlock(environmentBlock)
+	rootBody.AppendBlock(clusterBlock)
+	rootBody.AppendNewline()
 
 	return string(f.Bytes())
 }
 
+// generateMigrateACLsOutputsTf exposes each principal's generated API key so
+// it can be handed to the migrating application.
+func (s *MigrationScriptsHCLService) generateMigrateACLsOutputsTf(request hclrequests.MigrateAclsRequest) string {
+	principals := make([]string, 0, len(request.AclsByPrincipal))
+	for principal := range request.AclsByPrincipal {
+		principals = append(principals, principal)
+	}
+	slices.Sort(principals)
+
+	var outputs []hcltypes.TerraformOutput
+	for _, principal := range principals {
+		apiKeyRef := "confluent_api_key." + principalAPIKeyResourceName(principal)
+		outputs = append(outputs,
+			hcltypes.TerraformOutput{
+				Name:        principalAPIKeyResourceName(principal) + "_id",
+				Value:       apiKeyRef + ".id",
+				Description: "Kafka API key for " + principal,
+			},
+			hcltypes.TerraformOutput{
+				Name:        principalAPIKeyResourceName(principal) + "_secret",
+				Value:       apiKeyRef + ".secret",
+				Description: "Kafka API secret for " + principal,
+				Sensitive:   true,
+			},
+		)
+	}
+	return GenerateOutputsTf(outputs)
+}
+
+func principalAPIKeyResourceName(principal string) string {
+	return utils.FormatHclResourceName(principal) + "_api_key"
+}
+
+type aclRoleBinding struct {
+	role     string
+	resource string // CRN segment below the cluster, e.g. topic=orders*
+}
+
+// aclRoles maps lowercased ACL resource types and operations to the
+// Confluent Cloud RBAC role that grants the same access.
+var aclRoles = map[string]map[string]string{
+	"topic":           {"read": "DeveloperRead", "write": "DeveloperWrite", "all": "ResourceOwner"},
+	"group":           {"read": "DeveloperRead", "all": "ResourceOwner"},
+	"transactionalid": {"write": "DeveloperWrite", "all": "ResourceOwner"},
+}
+
+var aclRoleCRNSegments = map[string]string{
+	"topic":           "topic",
+	"group":           "group",
+	"transactionalid": "transactional-id",
+}
+
+// aclRoleBindings derives RBAC role bindings from a principal's ALLOW ACLs.
+// ACLs without a role equivalent (DENY, cluster, Describe-only) are left to
+// the generated ACLs. The result is deduplicated and sorted.
+func aclRoleBindings(acls []types.Acls) []aclRoleBinding {
+	seen := map[aclRoleBinding]bool{}
+	var bindings []aclRoleBinding
+	for _, acl := range acls {
+		if !strings.EqualFold(acl.PermissionType, "ALLOW") {
+			continue
+		}
+		resourceType := strings.ToLower(acl.ResourceType)
+		role, ok := aclRoles[resourceType][strings.ToLower(acl.Operation)]
+		if !ok {
+			continue
+		}
+		name := acl.ResourceName
+		if strings.EqualFold(acl.ResourcePatternType, "PREFIXED") && name != "*" {
+			name += "*"
+		}
+		binding := aclRoleBinding{role: role, resource: aclRoleCRNSegments[resourceType] + "=" + name}
+		if !seen[binding] {
+			seen[binding] = true
+			bindings = append(bindings, binding)
+		}
+	}
+	slices.SortFunc(bindings, func(a, b aclRoleBinding) int {
+		if a.resource != b.resource {
+			return strings.Compare(a.resource, b.resource)
+		}
+		return strings.Compare(a.role, b.role)
+	})
+	return bindings
+}
+
 // ============================================================================
 // Migrate Connectors Generation Methods
 // ============================================================================
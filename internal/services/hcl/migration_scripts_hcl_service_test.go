@@ -246,6 +246,69 @@ func TestGenerateMirrorTopicsFiles_NewMode_FilePerTopic(t *testing.T) {
 	}
 }
 
+func TestGenerateMirrorTopicsFiles_NewMode_AppliesPrefixAndRenames(t *testing.T) {
+	t.Parallel()
+
+	service := NewMigrationScriptsHCLService()
+	request := hclrequests.MirrorTopicsRequest{
+		Topics: []types.TopicDetails{
+			{Name: "orders", Partitions: 6},
+			{Name: "legacy-events", Partitions: 3},
+		},
+		TargetClusterId:           "lkc-xyz",
+		TargetClusterRestEndpoint: "https://cc.example.com:443",
+		Mode:                      hclrequests.MigrateTopicsModeNew,
+		TopicNamePrefix:           "msk.",
+		TopicRenames:              map[string]string{"legacy-events": "events"},
+	}
+
+	project, err := service.GenerateMirrorTopicsFiles(request)
+	require.NoError(t, err)
+	folder := project.Folders[0]
+
+	require.Contains(t, folder.AdditionalFiles, "msk_orders.tf")
+	assert.Contains(t, folder.AdditionalFiles["msk_orders.tf"], `topic_name       = "msk.orders"`)
+	require.Contains(t, folder.AdditionalFiles, "events.tf")
+	assert.Contains(t, folder.AdditionalFiles["events.tf"], `topic_name       = "events"`)
+}
+
+func TestGenerateMirrorTopicsFiles_MirrorMode_RejectsRenames(t *testing.T) {
+	t.Parallel()
+
+	service := NewMigrationScriptsHCLService()
+	request := hclrequests.MirrorTopicsRequest{
+		Topics:          []types.TopicDetails{{Name: "orders"}},
+		ClusterLinkName: "link",
+		Mode:            hclrequests.MigrateTopicsModeMirror,
+		TopicRenames:    map[string]string{"orders": "orders-v2"},
+	}
+
+	_, err := service.GenerateMirrorTopicsFiles(request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported in mirror mode")
+}
+
+func TestGenerateMirrorTopicsFiles_MirrorMode_AppliesLinkPrefix(t *testing.T) {
+	t.Parallel()
+
+	service := NewMigrationScriptsHCLService()
+	request := hclrequests.MirrorTopicsRequest{
+		Topics:          []types.TopicDetails{{Name: "orders"}},
+		ClusterLinkName: "link",
+		Mode:            hclrequests.MigrateTopicsModeMirror,
+		TopicNamePrefix: "msk.",
+	}
+
+	project, err := service.GenerateMirrorTopicsFiles(request)
+	require.NoError(t, err)
+	folder := project.Folders[0]
+
+	require.Contains(t, folder.AdditionalFiles, "msk_orders.tf")
+	content := folder.AdditionalFiles["msk_orders.tf"]
+	assert.Contains(t, content, `mirror_topic_name = "msk.orders"`)
+	assert.Contains(t, content, `topic_name = "orders"`)
+}
+
 func TestGenerateMirrorTopicsFiles_NewMode_NoReplicationFactorEver(t *testing.T) {
 	t.Parallel()
 
@@ -797,3 +860,72 @@ func TestGenerateMigrateAclsFiles_PrincipalWithSpecialCharacters(t *testing.T) {
 	fileMap := terraformFilesToMap(files)
 	validateTerraformProject(t, fileMap)
 }
+
+func TestGenerateMigrateAclsFiles_Identities(t *testing.T) {
+	t.Parallel()
+
+	request := hclrequests.MigrateAclsRequest{
+		SelectedPrincipals:        []string{"orders-app"},
+		TargetClusterId:           "lkc-abc123",
+		TargetClusterRestEndpoint: "https://test.confluent.cloud:443",
+		TargetEnvironmentId:       "env-abc123",
+		GenerateApiKeys:           true,
+		GenerateRoleBindings:      true,
+		AclsByPrincipal: map[string][]types.Acls{
+			"orders-app": {
+				{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "LITERAL", Operation: "Read", PermissionType: "ALLOW", Host: "*"},
+				{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "LITERAL", Operation: "Write", PermissionType: "ALLOW", Host: "*"},
+				{ResourceType: "Group", ResourceName: "orders-", ResourcePatternType: "PREFIXED", Operation: "Read", PermissionType: "ALLOW", Host: "*"},
+				{ResourceType: "Topic", ResourceName: "audit", ResourcePatternType: "LITERAL", Operation: "Read", PermissionType: "DENY", Host: "*"},
+				{ResourceType: "Cluster", ResourceName: "kafka-cluster", ResourcePatternType: "LITERAL", Operation: "IdempotentWrite", PermissionType: "ALLOW", Host: "*"},
+			},
+		},
+	}
+
+	service := NewMigrationScriptsHCLService()
+	files, err := service.GenerateMigrateAclsFiles(request)
+	require.NoError(t, err)
+
+	assert.Contains(t, files.MainTf, `data "confluent_kafka_cluster" "target"`)
+	assert.Contains(t, files.VariablesTf, `variable "confluent_cloud_environment_id"`)
+	assert.Contains(t, files.InputsAutoTfvars, `confluent_cloud_environment_id        = "env-abc123"`)
+	assert.Contains(t, files.OutputsTf, `output "orders_app_api_key_secret"`)
+
+	content := files.PerPrincipalTf["orders_app.tf"]
+	assert.Equal(t, 3, strings.Count(content, `resource "confluent_role_binding"`), content)
+	assert.Contains(t, content, `/kafka=${data.confluent_kafka_cluster.target.id}/topic=orders"`)
+	assert.Contains(t, content, `/kafka=${data.confluent_kafka_cluster.target.id}/group=orders-*"`)
+	assert.NotContains(t, content, "topic=audit")
+	assert.Contains(t, content, `resource "confluent_api_key" "orders_app_api_key"`)
+	assert.Contains(t, content, "confluent_service_account.orders_app.api_version")
+}
+
+func TestGenerateMigrateAclsFiles_IdentitiesRequireEnvironment(t *testing.T) {
+	t.Parallel()
+
+	service := NewMigrationScriptsHCLService()
+	_, err := service.GenerateMigrateAclsFiles(hclrequests.MigrateAclsRequest{GenerateApiKeys: true})
+	require.Error(t, err)
+
+	files, err := service.GenerateMigrateAclsFiles(hclrequests.MigrateAclsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, files.MainTf)
+	assert.Empty(t, files.OutputsTf)
+	assert.NotContains(t, files.VariablesTf, "confluent_cloud_environment_id")
+}
+
+func TestAclRoleBindings(t *testing.T) {
+	t.Parallel()
+
+	bindings := aclRoleBindings([]types.Acls{
+		{ResourceType: "Topic", ResourceName: "*", ResourcePatternType: "LITERAL", Operation: "All", PermissionType: "ALLOW"},
+		{ResourceType: "TransactionalId", ResourceName: "tx-", ResourcePatternType: "PREFIXED", Operation: "Write", PermissionType: "ALLOW"},
+		{ResourceType: "Topic", ResourceName: "orders", ResourcePatternType: "LITERAL", Operation: "Describe", PermissionType: "ALLOW"},
+		{ResourceType: "Topic", ResourceName: "*", ResourcePatternType: "LITERAL", Operation: "All", PermissionType: "ALLOW"},
+	})
+
+	assert.Equal(t, []aclRoleBinding{
+		{role: "ResourceOwner", resource: "topic=*"},
+		{role: "DeveloperWrite", resource: "transactional-id=tx-*"},
+	}, bindings)
+}
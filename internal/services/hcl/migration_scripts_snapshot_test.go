@@ -110,6 +110,50 @@ func TestMigrationScripts_MigrateACLs(t *testing.T) {
 	validateTerraformProject(t, fileMap)
 }
 
+func TestMigrationScripts_MigrateACLsWithIdentities(t *testing.T) {
+	t.Parallel()
+
+	service := NewMigrationScriptsHCLService()
+	request := hclrequests.MigrateAclsRequest{
+		SelectedPrincipals:        []string{"app_user"},
+		TargetClusterId:           "lkc-xyz789",
+		TargetClusterRestEndpoint: "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		TargetEnvironmentId:       "env-abc123",
+		GenerateApiKeys:           true,
+		GenerateRoleBindings:      true,
+		AclsByPrincipal: map[string][]types.Acls{
+			"app_user": {
+				{
+					ResourceType:        "Topic",
+					ResourceName:        "orders",
+					ResourcePatternType: "LITERAL",
+					Principal:           "User:app_user",
+					Host:                "*",
+					Operation:           "Write",
+					PermissionType:      "ALLOW",
+				},
+				{
+					ResourceType:        "Group",
+					ResourceName:        "my-group",
+					ResourcePatternType: "PREFIXED",
+					Principal:           "User:app_user",
+					Host:                "*",
+					Operation:           "Read",
+					PermissionType:      "ALLOW",
+				},
+			},
+		},
+	}
+
+	files, err := service.GenerateMigrateAclsFiles(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileMap := terraformFilesToMap(files)
+	validateTerraformProject(t, fileMap)
+}
+
 func TestMigrationScripts_MigrateConnectors(t *testing.T) {
 	t.Parallel()
 
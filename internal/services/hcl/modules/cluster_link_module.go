@@ -1,10 +1,16 @@
 package modules
 
 import (
+	"strings"
+
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
 )
 
+// GetClusterLinkVariables returns the cluster link module inputs. The link is
+// always created with the Confluent Cloud cluster API key: an OAuth identity
+// pool from target-infra only holds the clients' DeveloperRead and
+// DeveloperWrite bindings, which cannot create a link.
 func GetClusterLinkVariables() []ModuleVariable[hclrequests.MigrationWizardRequest] {
 	return []ModuleVariable[hclrequests.MigrationWizardRequest]{
 		{
@@ -79,12 +85,143 @@ func GetClusterLinkVariables() []ModuleVariable[hclrequests.MigrationWizardReque
 				Description: "The SASL/SCRAM mechanism of the source Kafka cluster (SCRAM-SHA-256 or SCRAM-SHA-512).",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOneOf("source_sasl_scram_mechanism", "SCRAM-SHA-256", "SCRAM-SHA-512")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.SourceSaslScramMechanism
 			},
 		},
+		{
+			Name:       SchemaClusterLinkMode.Name,
+			Definition: SchemaClusterLinkMode.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.LinkMode()
+			},
+		},
+		{
+			Name:       SchemaClusterLinkPrefix.Name,
+			Definition: SchemaClusterLinkPrefix.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.ClusterLinkPrefix
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.ClusterLinkPrefix != ""
+			},
+		},
+		{
+			Name:       SchemaTargetClusterBootstrapEndpoint.Name,
+			Definition: SchemaTargetClusterBootstrapEndpoint.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.TargetBootstrapEndpoint
+			},
+			Condition: isBidirectionalLink,
+		},
+		{
+			Name:       SchemaSourceClusterRestEndpoint.Name,
+			Definition: SchemaSourceClusterRestEndpoint.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.SourceRestEndpoint
+			},
+			Condition: isBidirectionalLink,
+		},
+		{
+			Name:       SchemaSourceClusterRestAPIKey.Name,
+			Definition: SchemaSourceClusterRestAPIKey.ToDefinition(),
+			ValueExtractor: func(_ hclrequests.MigrationWizardRequest) any {
+				return ""
+			},
+			Condition: isBidirectionalLink,
+		},
+		{
+			Name:       SchemaSourceClusterRestAPISecret.Name,
+			Definition: SchemaSourceClusterRestAPISecret.ToDefinition(),
+			ValueExtractor: func(_ hclrequests.MigrationWizardRequest) any {
+				return ""
+			},
+			Condition: isBidirectionalLink,
+		},
+	}
+}
+
+// clusterLinkDestinationVariables are the cluster link variables that differ
+// for each destination cluster. The rest describe the source cluster and the
+// link settings, which every destination shares.
+var clusterLinkDestinationVariables = map[string]bool{
+	SchemaConfluentCloudClusterAPIKey.Name:    true,
+	SchemaConfluentCloudClusterAPISecret.Name: true,
+	SchemaTargetClusterRestEndpoint.Name:      true,
+	SchemaTargetClusterID.Name:                true,
+	SchemaClusterLinkName.Name:                true,
+	SchemaTargetClusterBootstrapEndpoint.Name: true,
+}
+
+// GetClusterLinkDestinationVariables returns the cluster link module inputs
+// for one of the request's additional destinations. The destination's own
+// target cluster, link name and API key come from root variables suffixed
+// with its name; the source cluster variables are shared with the link to
+// the main target.
+func GetClusterLinkDestinationVariables(destination hclrequests.ClusterLinkDestination) []ModuleVariable[hclrequests.MigrationWizardRequest] {
+	var vars []ModuleVariable[hclrequests.MigrationWizardRequest]
+	for _, v := range GetClusterLinkVariables() {
+		if !clusterLinkDestinationVariables[v.Name] {
+			vars = append(vars, v)
+			continue
+		}
+		vars = append(vars, destinationVariable(v, destination))
 	}
+	return vars
+}
+
+// getClusterLinkDestinationRootVariables returns the root variables each
+// additional destination adds to a Type 1 project.
+func getClusterLinkDestinationRootVariables(request hclrequests.MigrationWizardRequest) []ModuleVariable[hclrequests.MigrationWizardRequest] {
+	var vars []ModuleVariable[hclrequests.MigrationWizardRequest]
+	for _, destination := range request.AdditionalDestinations {
+		for _, v := range GetClusterLinkVariables() {
+			if clusterLinkDestinationVariables[v.Name] {
+				vars = append(vars, destinationVariable(v, destination))
+			}
+		}
+	}
+	return vars
+}
+
+// destinationVariable renames v's root variable for destination and reads
+// its value from the destination's request.
+func destinationVariable(v ModuleVariable[hclrequests.MigrationWizardRequest], destination hclrequests.ClusterLinkDestination) ModuleVariable[hclrequests.MigrationWizardRequest] {
+	oldName := v.Definition.Name
+	newName := oldName + "_" + destination.Name
+
+	definition := v.Definition
+	definition.Name = newName
+	description, sentence := strings.CutSuffix(definition.Description, ".")
+	definition.Description = description + " (destination " + destination.Name + ")"
+	if sentence {
+		definition.Description += "."
+	}
+	definition.Validations = nil
+	for _, validation := range v.Definition.Validations {
+		validation.Condition = strings.ReplaceAll(validation.Condition, "var."+oldName, "var."+newName)
+		validation.ErrorMessage = strings.ReplaceAll(validation.ErrorMessage, oldName, newName)
+		definition.Validations = append(definition.Validations, validation)
+	}
+	v.Definition = definition
+
+	if extract := v.ValueExtractor; extract != nil {
+		v.ValueExtractor = func(request hclrequests.MigrationWizardRequest) any {
+			return extract(request.ForDestination(destination))
+		}
+	}
+	if condition := v.Condition; condition != nil {
+		v.Condition = func(request hclrequests.MigrationWizardRequest) bool {
+			return condition(request.ForDestination(destination))
+		}
+	}
+	return v
+}
+
+func isBidirectionalLink(request hclrequests.MigrationWizardRequest) bool {
+	return request.IsBidirectionalLink()
 }
 
 func GetClusterLinkModuleVariableDefinitions(request hclrequests.MigrationWizardRequest) []hcltypes.TerraformVariable {
@@ -29,6 +29,7 @@ func GetConfluentCloudVariables() []ModuleVariable[hclrequests.TargetClusterWiza
 				Description: "ID of the environment",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("environment_id", "env")},
 			},
 			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
 				return request.EnvironmentId
@@ -74,6 +75,7 @@ func GetConfluentCloudVariables() []ModuleVariable[hclrequests.TargetClusterWiza
 				Description: "Type of the cluster",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOneOf("cluster_type", "dedicated", "enterprise")},
 			},
 			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
 				return request.ClusterType
@@ -89,6 +91,7 @@ func GetConfluentCloudVariables() []ModuleVariable[hclrequests.TargetClusterWiza
 				Description: "Cluster availability zone type (SINGLE_ZONE or MULTI_ZONE)",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOneOf("cluster_availability", "SINGLE_ZONE", "MULTI_ZONE")},
 			},
 			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
 				return request.ClusterAvailability
@@ -104,6 +107,7 @@ func GetConfluentCloudVariables() []ModuleVariable[hclrequests.TargetClusterWiza
 				Description: "Number of CKUs for dedicated clusters",
 				Sensitive:   false,
 				Type:        "number",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidatePositiveInteger("cluster_cku")},
 			},
 			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
 				return request.ClusterCku
@@ -112,9 +116,67 @@ func GetConfluentCloudVariables() []ModuleVariable[hclrequests.TargetClusterWiza
 				return (request.NeedsEnvironment || request.NeedsCluster) && request.ClusterType == "dedicated"
 			},
 		},
+		{
+			Name: VarOAuthIssuer,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarOAuthIssuer,
+				Description: "Issuer URI of the OIDC identity provider whose tokens clients authenticate with",
+				Sensitive:   false,
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL(VarOAuthIssuer, "https")},
+			},
+			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
+				return request.OAuth.Issuer
+			},
+			Condition: usesOAuth,
+		},
+		{
+			Name: VarOAuthJwksURI,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarOAuthJwksURI,
+				Description: "JWKS URI of the OIDC identity provider, serving the keys its tokens are signed with",
+				Sensitive:   false,
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL(VarOAuthJwksURI, "https")},
+			},
+			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
+				return request.OAuth.JwksUri
+			},
+			Condition: usesOAuth,
+		},
+		{
+			Name: VarOAuthIdentityClaim,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarOAuthIdentityClaim,
+				Description: "Token claim that identifies a client in the identity pool",
+				Sensitive:   false,
+				Type:        "string",
+			},
+			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
+				return request.OAuth.Claim()
+			},
+			Condition: usesOAuth,
+		},
+		{
+			Name: VarOAuthPoolFilter,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarOAuthPoolFilter,
+				Description: "CEL expression over the token claims that selects the tokens mapped to the identity pool",
+				Sensitive:   false,
+				Type:        "string",
+			},
+			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
+				return request.OAuth.Filter
+			},
+			Condition: usesOAuth,
+		},
 	}
 }
 
+func usesOAuth(request hclrequests.TargetClusterWizardRequest) bool {
+	return request.OAuth != nil
+}
+
 func GetConfluentCloudVariableDefinitions(request hclrequests.TargetClusterWizardRequest) []hcltypes.TerraformVariable {
 	return ExtractModuleVariableDefinitions(GetConfluentCloudVariables(), request)
 }
@@ -125,6 +187,8 @@ type ConfluentCloudOutputParams struct {
 	ClusterName        string
 	ServiceAccountName string
 	KafkaAPIKeyName    string
+	IdentityProvider   string
+	IdentityPool       string
 }
 
 func GetConfluentCloudModuleOutputDefinitions(request hclrequests.TargetClusterWizardRequest, params ConfluentCloudOutputParams) []hcltypes.TerraformOutput {
@@ -185,6 +249,22 @@ func GetConfluentCloudModuleOutputDefinitions(request hclrequests.TargetClusterW
 		},
 	)
 
+	// OAuth outputs
+	if request.OAuth != nil {
+		definitions = append(definitions,
+			hcltypes.TerraformOutput{
+				Name:        "identity_provider_id",
+				Description: "ID of the OIDC identity provider",
+				Value:       fmt.Sprintf("confluent_identity_provider.%s.id", params.IdentityProvider),
+			},
+			hcltypes.TerraformOutput{
+				Name:        "identity_pool_id",
+				Description: "ID of the identity pool OAuth clients authenticate as",
+				Value:       fmt.Sprintf("confluent_identity_pool.%s.id", params.IdentityPool),
+			},
+		)
+	}
+
 	// Network outputs (only for dedicated clusters with private link)
 	if request.NeedsPrivateLink && request.ClusterType == "dedicated" {
 		definitions = append(definitions,
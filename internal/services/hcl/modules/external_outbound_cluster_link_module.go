@@ -14,6 +14,7 @@ func GetExternalOutboundClusterLinkingVariables() []ModuleVariable[hclrequests.M
 				Description: "The subnet ID where the EC2 instance will be launched.",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("subnet_id", "subnet")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.ExtOutboundSubnetId
@@ -27,6 +28,7 @@ func GetExternalOutboundClusterLinkingVariables() []ModuleVariable[hclrequests.M
 				Description: "The security group ID to attach to the EC2 instance.",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("security_group_id", "sg")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.ExtOutboundSecurityGroupId
@@ -134,6 +136,7 @@ func GetExternalOutboundClusterLinkingVariables() []ModuleVariable[hclrequests.M
 				Description: "The SASL/SCRAM mechanism of the source Kafka cluster (SCRAM-SHA-256 or SCRAM-SHA-512).",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOneOf("source_sasl_scram_mechanism", "SCRAM-SHA-256", "SCRAM-SHA-512")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.SourceSaslScramMechanism
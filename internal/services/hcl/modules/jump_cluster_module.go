@@ -1,12 +1,13 @@
 package modules
 
 import (
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
 )
 
 func GetJumpClusterVariables() []ModuleVariable[hclrequests.MigrationWizardRequest] {
-	return []ModuleVariable[hclrequests.MigrationWizardRequest]{
+	return append([]ModuleVariable[hclrequests.MigrationWizardRequest]{
 		{
 			Name: "jump_cluster_broker_subnet_ids",
 			Definition: hcltypes.TerraformVariable{
@@ -52,6 +53,14 @@ func GetJumpClusterVariables() []ModuleVariable[hclrequests.MigrationWizardReque
 			Condition:        nil,
 			FromModuleOutput: "networking",
 		},
+		{
+			Name:       SchemaJumpClusterOSUser.Name,
+			Definition: SchemaJumpClusterOSUser.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return aws.JumpClusterAmiFor(request).OSUser
+			},
+			Condition: nil,
+		},
 		{
 			Name: "jump_cluster_iam_auth_role_name",
 			Definition: hcltypes.TerraformVariable{
@@ -71,9 +80,10 @@ func GetJumpClusterVariables() []ModuleVariable[hclrequests.MigrationWizardReque
 			Name: "jump_cluster_broker_storage",
 			Definition: hcltypes.TerraformVariable{
 				Name:        "jump_cluster_broker_storage",
-				Description: "Storage size of the jump cluster broker instances.",
+				Description: "Root volume size, in GiB, of the jump cluster broker instances.",
 				Sensitive:   false,
-				Type:        "string",
+				Type:        "number",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidatePositiveInteger("jump_cluster_broker_storage")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.JumpClusterBrokerStorage
@@ -218,6 +228,7 @@ func GetJumpClusterVariables() []ModuleVariable[hclrequests.MigrationWizardReque
 				Description: "The SASL/SCRAM mechanism of the source Kafka cluster (SCRAM-SHA-256 or SCRAM-SHA-512).",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOneOf("source_sasl_scram_mechanism", "SCRAM-SHA-256", "SCRAM-SHA-512")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.SourceSaslScramMechanism
@@ -226,6 +237,21 @@ func GetJumpClusterVariables() []ModuleVariable[hclrequests.MigrationWizardReque
 				return request.JumpClusterAuthType == "sasl_scram"
 			},
 		},
+		{
+			Name: "source_ca_certificate",
+			Definition: hcltypes.TerraformVariable{
+				Name:        "source_ca_certificate",
+				Description: "PEM certificates of the private CA that issued the source Kafka cluster's broker certificates, added to the jump cluster trust store. Empty when the brokers use a public CA.",
+				Sensitive:   false,
+				Type:        "string",
+			},
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.SourceCACertificate
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.JumpClusterAuthType == "sasl_scram"
+			},
+		},
 		{
 			Name: "cluster_link_name",
 			Definition: hcltypes.TerraformVariable{
@@ -239,7 +265,7 @@ func GetJumpClusterVariables() []ModuleVariable[hclrequests.MigrationWizardReque
 			},
 			Condition: nil,
 		},
-	}
+	}, getJumpClusterEgressVariables()...)
 }
 
 func GetJumpClusterModuleVariableDefinitions(request hclrequests.MigrationWizardRequest) []hcltypes.TerraformVariable {
@@ -253,6 +279,12 @@ var JumpClusterModuleOutputs = []hcltypes.TerraformOutput{
 		Sensitive:   false,
 		Value:       "values(aws_instance.jump_cluster)[*].private_dns",
 	},
+	{
+		Name:        "jump_cluster_instance_ids",
+		Description: "IDs of the jump cluster instances, keyed by instance name.",
+		Sensitive:   false,
+		Value:       "{ for name, instance in aws_instance.jump_cluster : name => instance.id }",
+	},
 }
 
 func GetJumpClusterModuleOutputDefinitions() []hcltypes.TerraformOutput {
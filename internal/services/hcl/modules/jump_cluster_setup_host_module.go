@@ -1,12 +1,13 @@
 package modules
 
 import (
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
 )
 
 func GetJumpClusterSetupHostVariables() []ModuleVariable[hclrequests.MigrationWizardRequest] {
-	return []ModuleVariable[hclrequests.MigrationWizardRequest]{
+	return append([]ModuleVariable[hclrequests.MigrationWizardRequest]{
 		{
 			Name: "jump_cluster_setup_host_subnet_id",
 			Definition: hcltypes.TerraformVariable{
@@ -53,6 +54,14 @@ func GetJumpClusterSetupHostVariables() []ModuleVariable[hclrequests.MigrationWi
 			Condition:        nil,
 			FromModuleOutput: "jump_cluster",
 		},
+		{
+			Name:       SchemaJumpClusterOSUser.Name,
+			Definition: SchemaJumpClusterOSUser.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return aws.JumpClusterAmiFor(request).OSUser
+			},
+			Condition: nil,
+		},
 		{
 			Name: "private_key",
 			Definition: hcltypes.TerraformVariable{
@@ -67,6 +76,45 @@ func GetJumpClusterSetupHostVariables() []ModuleVariable[hclrequests.MigrationWi
 			Condition:        nil,
 			FromModuleOutput: "networking",
 		},
+	}, getJumpClusterEgressVariables()...)
+}
+
+// getJumpClusterEgressVariables are the optional corporate CA bundle and
+// proxy settings shared by the setup host and jump cluster modules.
+func getJumpClusterEgressVariables() []ModuleVariable[hclrequests.MigrationWizardRequest] {
+	return []ModuleVariable[hclrequests.MigrationWizardRequest]{
+		{
+			Name:       SchemaJumpClusterCABundle.Name,
+			Definition: SchemaJumpClusterCABundle.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.JumpClusterCABundle
+			},
+			Condition: nil,
+		},
+		{
+			Name:       SchemaJumpClusterHTTPProxy.Name,
+			Definition: SchemaJumpClusterHTTPProxy.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.JumpClusterHTTPProxy
+			},
+			Condition: nil,
+		},
+		{
+			Name:       SchemaJumpClusterHTTPSProxy.Name,
+			Definition: SchemaJumpClusterHTTPSProxy.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.JumpClusterHTTPSProxy
+			},
+			Condition: nil,
+		},
+		{
+			Name:       SchemaJumpClusterNoProxy.Name,
+			Definition: SchemaJumpClusterNoProxy.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.JumpClusterNoProxy
+			},
+			Condition: nil,
+		},
 	}
 }
 
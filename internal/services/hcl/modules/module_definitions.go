@@ -53,6 +53,7 @@ func collectMigrationInfraVars(request hclrequests.MigrationWizardRequest) []Mod
 	case request.HasPublicEndpoints:
 		allVars = append(allVars, GetPublicMigrationProviderVariables()...)
 		allVars = append(allVars, GetClusterLinkVariables()...)
+		allVars = append(allVars, getClusterLinkDestinationRootVariables(request)...)
 	case request.UseJumpClusters:
 		allVars = append(allVars, GetPrivateMigrationProviderVariables()...)
 		allVars = append(allVars, GetNetworkingVariables()...)
@@ -63,6 +64,9 @@ func collectMigrationInfraVars(request hclrequests.MigrationWizardRequest) []Mod
 		allVars = append(allVars, GetPrivateClusterLinkVariables()...)
 		allVars = append(allVars, GetExternalOutboundClusterLinkingVariables()...)
 	}
+	if request.MonitoringEnabled {
+		allVars = append(allVars, GetMonitoringVariables()...)
+	}
 	return allVars
 }
 
@@ -74,6 +78,24 @@ func GetMigrationInfraRootVariableDefinitions(request hclrequests.MigrationWizar
 	return extractVariableDefinitions(collectMigrationInfraVars(request), request)
 }
 
+// collectAnsibleJumpClusterVars covers the Terraform half of a jump cluster
+// migration whose EC2 instances are provisioned by Ansible: only the networking
+// module remains, so the jump cluster and setup host inputs are not prompted for.
+func collectAnsibleJumpClusterVars() []ModuleVariable[hclrequests.MigrationWizardRequest] {
+	var allVars []ModuleVariable[hclrequests.MigrationWizardRequest]
+	allVars = append(allVars, GetPrivateMigrationProviderVariables()...)
+	allVars = append(allVars, GetNetworkingVariables()...)
+	return allVars
+}
+
+func GetAnsibleJumpClusterRootVariableValues(request hclrequests.MigrationWizardRequest) map[string]any {
+	return extractVariableValues(collectAnsibleJumpClusterVars(), request)
+}
+
+func GetAnsibleJumpClusterRootVariableDefinitions(request hclrequests.MigrationWizardRequest) []hcltypes.TerraformVariable {
+	return extractVariableDefinitions(collectAnsibleJumpClusterVars(), request)
+}
+
 // ============================================================================
 // Helpers
 // ============================================================================
@@ -105,6 +127,10 @@ func extractVariableValues[R any](allVars []ModuleVariable[R], request R) map[st
 			if len(v) == 0 {
 				continue
 			}
+		case map[string]string:
+			if len(v) == 0 {
+				continue
+			}
 		case []hclrequests.ExtOutboundClusterKafkaBroker:
 			if len(v) == 0 {
 				continue
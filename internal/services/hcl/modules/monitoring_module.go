@@ -0,0 +1,67 @@
+package modules
+
+import (
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+)
+
+func GetMonitoringVariables() []ModuleVariable[hclrequests.MigrationWizardRequest] {
+	return []ModuleVariable[hclrequests.MigrationWizardRequest]{
+		{
+			Name:       SchemaAWSRegion.Name,
+			Definition: SchemaAWSRegion.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.SourceRegion
+			},
+			Condition: nil,
+		},
+		{
+			Name: VarSourceClusterName,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarSourceClusterName,
+				Description: "Name of the source MSK cluster, as used in the `Cluster Name` dimension of its CloudWatch metrics.",
+				Sensitive:   false,
+				Type:        "string",
+			},
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.SourceClusterName
+			},
+			Condition: nil,
+		},
+		{
+			Name: VarMonitoringAlarmTopicArn,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarMonitoringAlarmTopicArn,
+				Description: "ARN of the SNS topic notified when a migration alarm fires or recovers.",
+				Sensitive:   false,
+				Type:        "string",
+			},
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.MonitoringAlarmTopicArn
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.MonitoringAlarmTopicArn != ""
+			},
+		},
+		{
+			Name: VarJumpClusterInstanceIDs,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarJumpClusterInstanceIDs,
+				Description: "IDs of the jump cluster instances, keyed by instance name.",
+				Sensitive:   false,
+				Type:        "map(string)",
+			},
+			ValueExtractor: func(_ hclrequests.MigrationWizardRequest) any {
+				return map[string]string{} // Retrieved from jump cluster module output.
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.UseJumpClusters
+			},
+			FromModuleOutput: "jump_cluster",
+		},
+	}
+}
+
+func GetMonitoringModuleVariableDefinitions(request hclrequests.MigrationWizardRequest) []hcltypes.TerraformVariable {
+	return ExtractModuleVariableDefinitions(GetMonitoringVariables(), request)
+}
@@ -22,6 +22,7 @@ func GetNetworkingVariables() []ModuleVariable[hclrequests.MigrationWizardReques
 				Description: "CIDR ranges of the jump cluster broker subnets",
 				Sensitive:   false,
 				Type:        "list(string)",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDRList("jump_cluster_broker_subnet_cidrs")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.JumpClusterBrokerSubnetCidr
@@ -35,6 +36,7 @@ func GetNetworkingVariables() []ModuleVariable[hclrequests.MigrationWizardReques
 				Description: "CIDR block of the jump cluster setup host subnet",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDR("jump_cluster_setup_host_subnet_cidr")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.JumpClusterSetupHostSubnetCidr
@@ -48,11 +50,72 @@ func GetNetworkingVariables() []ModuleVariable[hclrequests.MigrationWizardReques
 				Description: "ID of the existing VPC endpoint for the Private Link connection to Confluent Cloud",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("existing_private_link_vpce_id", "vpce")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.ExistingPrivateLinkVpceId
 			},
-			Condition: nil,
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.ConnectivityMode() == hclrequests.ConnectivityPrivateLink
+			},
+		},
+		{
+			Name:       SchemaAWSRegion.Name,
+			Definition: SchemaAWSRegion.ToDefinition(),
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.SourceRegion
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.CreatesConfluentNetwork()
+			},
+		},
+		{
+			Name: VarTargetEnvironmentID,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarTargetEnvironmentID,
+				Description: "Target environment ID where the Confluent Cloud network is created.",
+				Sensitive:   false,
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(VarTargetEnvironmentID, "env")},
+			},
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.TargetEnvironmentId
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.CreatesConfluentNetwork()
+			},
+		},
+		{
+			Name: VarConfluentNetworkCidr,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarConfluentNetworkCidr,
+				Description: "CIDR block of the Confluent Cloud network. Must not overlap with the VPC or any peered network.",
+				Sensitive:   false,
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDR(VarConfluentNetworkCidr)},
+			},
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.ConfluentNetworkCidr
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.CreatesConfluentNetwork()
+			},
+		},
+		{
+			Name: VarTransitGatewayID,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarTransitGatewayID,
+				Description: "ID of the existing transit gateway the VPC is attached to, shared with Confluent Cloud through AWS RAM.",
+				Sensitive:   false,
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(VarTransitGatewayID, "tgw")},
+			},
+			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
+				return request.TransitGatewayId
+			},
+			Condition: func(request hclrequests.MigrationWizardRequest) bool {
+				return request.ConnectivityMode() == hclrequests.ConnectivityTransitGateway
+			},
 		},
 	}
 }
@@ -28,6 +28,7 @@ func GetPrivateClusterLinkVariables() []ModuleVariable[hclrequests.MigrationWiza
 				Description: "VPC ID of the source Kafka cluster that data will be migrated from.",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("aws_vpc_id", "vpc")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.VpcId
@@ -56,6 +57,7 @@ func GetPrivateClusterLinkVariables() []ModuleVariable[hclrequests.MigrationWiza
 				Description: "Target environment ID where Confluent Cloud cluster is located.",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("target_environment_id", "env")},
 			},
 			ValueExtractor: func(request hclrequests.MigrationWizardRequest) any {
 				return request.TargetEnvironmentId
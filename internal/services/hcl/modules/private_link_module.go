@@ -42,6 +42,7 @@ func GetTargetClusterPrivateLinkVariables() []ModuleVariable[hclrequests.TargetC
 				Description: "The CIDR ranges of the subnets that the private link connection is established in.",
 				Sensitive:   false,
 				Type:        "list(string)",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDRList("subnet_cidr_ranges")},
 			},
 			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
 				return request.SubnetCidrRanges
@@ -55,6 +56,7 @@ func GetTargetClusterPrivateLinkVariables() []ModuleVariable[hclrequests.TargetC
 				Description: "The ID of the environment that the private link connection is established in.",
 				Sensitive:   false,
 				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("environment_id", "env")},
 			},
 			ValueExtractor:   nil,
 			FromModuleOutput: "confluent_cloud",
@@ -116,17 +118,57 @@ func GetTargetClusterPrivateLinkVariables() []ModuleVariable[hclrequests.TargetC
 				return request.ClusterType == "dedicated"
 			},
 		},
+		{
+			Name: VarDnsVpcIDs,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarDnsVpcIDs,
+				Description: "IDs of further VPCs in the same account to associate with the private hosted zone, so they resolve the cluster's endpoints to the private link VPC endpoint.",
+				Sensitive:   false,
+				Type:        "list(string)",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceIDList(VarDnsVpcIDs, "vpc")},
+			},
+			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
+				return request.DnsVpcIds
+			},
+			Condition: func(request hclrequests.TargetClusterWizardRequest) bool {
+				return len(request.DnsVpcIds) > 0
+			},
+		},
+		{
+			Name: VarDnsShareAccountIDs,
+			Definition: hcltypes.TerraformVariable{
+				Name:        VarDnsShareAccountIDs,
+				Description: "IDs of AWS accounts to share the Route 53 Resolver forwarding rule for the cluster's DNS domain with. Each account associates the shared rule with its own VPCs.",
+				Sensitive:   false,
+				Type:        "list(string)",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateAWSAccountIDList(VarDnsShareAccountIDs)},
+			},
+			ValueExtractor: func(request hclrequests.TargetClusterWizardRequest) any {
+				return request.DnsShareAccountIds
+			},
+			Condition: func(request hclrequests.TargetClusterWizardRequest) bool {
+				return len(request.DnsShareAccountIds) > 0
+			},
+		},
 	}
 }
 
-func GetPrivateLinkModuleOutputDefinitions(vpcEndpointResourceName string) []hcltypes.TerraformOutput {
-	return []hcltypes.TerraformOutput{
+func GetPrivateLinkModuleOutputDefinitions(request hclrequests.TargetClusterWizardRequest, vpcEndpointResourceName, resolverRuleResourceName string) []hcltypes.TerraformOutput {
+	outputs := []hcltypes.TerraformOutput{
 		{
 			Name:        "vpc_endpoint_id",
 			Description: "ID of the AWS VPC Endpoint for the Private Link connection",
 			Value:       fmt.Sprintf("aws_vpc_endpoint.%s.id", vpcEndpointResourceName),
 		},
 	}
+	if len(request.DnsShareAccountIds) > 0 {
+		outputs = append(outputs, hcltypes.TerraformOutput{
+			Name:        "dns_resolver_rule_id",
+			Description: "ID of the shared Route 53 Resolver rule each account in dns_share_account_ids associates with its VPCs",
+			Value:       fmt.Sprintf("aws_route53_resolver_rule.%s.id", resolverRuleResourceName),
+		})
+	}
+	return outputs
 }
 
 func GetTargetClusterPrivateLinkModuleVariableDefinitions(request hclrequests.TargetClusterWizardRequest) []hcltypes.TerraformVariable {
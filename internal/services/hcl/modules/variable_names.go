@@ -14,6 +14,12 @@ const (
 	VarMSKSaslScramMechanism          = "source_sasl_scram_mechanism"
 	VarMSKSaslScramUsername           = "source_sasl_scram_username"
 	VarMSKSaslScramPassword           = "source_sasl_scram_password"
+	VarClusterLinkMode                = "cluster_link_mode"
+	VarClusterLinkPrefix              = "cluster_link_prefix"
+	VarTargetClusterBootstrapEndpoint = "target_cluster_bootstrap_endpoint"
+	VarSourceClusterRestEndpoint      = "source_cluster_rest_endpoint"
+	VarSourceClusterRestAPIKey        = "source_cluster_rest_api_key"
+	VarSourceClusterRestAPISecret     = "source_cluster_rest_api_secret"
 
 	// Jump Cluster Setup Host module variables
 	VarJumpClusterSetupHostSubnetID   = "jump_cluster_setup_host_subnet_id"
@@ -21,6 +27,7 @@ const (
 	VarJumpClusterSSHKeyPairName      = "jump_cluster_ssh_key_pair_name"
 	VarJumpClusterInstancesPrivateDNS = "jump_cluster_instances_private_dns"
 	VarPrivateKey                     = "private_key"
+	VarJumpClusterOSUser              = "jump_cluster_os_user"
 
 	// Jump Cluster module variables
 	VarJumpClusterBrokerSubnetIDs             = "jump_cluster_broker_subnet_ids"
@@ -31,12 +38,22 @@ const (
 	VarConfluentCloudClusterRestEndpoint      = "confluent_cloud_cluster_rest_endpoint"
 	VarMSKClusterBootstrapBrokers             = "source_cluster_bootstrap_brokers"
 	VarJumpClusterIAMAuthRoleName             = "jump_cluster_iam_auth_role_name"
+	VarSourceCACertificate                    = "source_ca_certificate"
+
+	// Jump Cluster Setup Host and Jump Cluster module egress variables
+	VarJumpClusterCABundle   = "jump_cluster_ca_bundle"
+	VarJumpClusterHTTPProxy  = "jump_cluster_http_proxy"
+	VarJumpClusterHTTPSProxy = "jump_cluster_https_proxy"
+	VarJumpClusterNoProxy    = "jump_cluster_no_proxy"
 
 	// Networking module variables
 	VarVpcID                          = "vpc_id"
 	VarJumpClusterBrokerSubnetCidrs   = "jump_cluster_broker_subnet_cidrs"
 	VarJumpClusterSetupHostSubnetCidr = "jump_cluster_setup_host_subnet_cidr"
 	VarExistingPrivateLinkVpceID      = "existing_private_link_vpce_id"
+	VarConfluentNetworkCidr           = "confluent_network_cidr"
+	VarTransitGatewayID               = "transit_gateway_id"
+	VarTargetEnvironmentID            = "target_environment_id"
 
 	// Confluent Cloud module variables
 	VarEnvironmentName = "environment_name"
@@ -44,15 +61,28 @@ const (
 	VarClusterName     = "cluster_name"
 	VarAWSRegion       = "aws_region"
 
+	// Confluent Cloud module OAuth variables
+	VarOAuthIssuer        = "oauth_issuer"
+	VarOAuthJwksURI       = "oauth_jwks_uri"
+	VarOAuthIdentityClaim = "oauth_identity_claim"
+	VarOAuthPoolFilter    = "oauth_identity_pool_filter"
+
 	// Private Link Target Cluster module variables
 	VarSubnetCidrRanges                  = "subnet_cidr_ranges"
 	VarNetworkID                         = "network_id"
 	VarNetworkDNSDomain                  = "network_dns_domain"
 	VarNetworkPrivateLinkEndpointService = "network_private_link_endpoint_service"
 	VarNetworkZones                      = "network_zones"
+	VarDnsVpcIDs                         = "dns_vpc_ids"
+	VarDnsShareAccountIDs                = "dns_share_account_ids"
 
 	// External Outbound Cluster Link module variables
 	VarSubnetID                   = "subnet_id"
 	VarSecurityGroupID            = "security_group_id"
 	VarMSKClusterBootstrapServers = "source_cluster_bootstrap_servers"
+
+	// Monitoring module variables
+	VarSourceClusterName       = "source_cluster_name"
+	VarJumpClusterInstanceIDs  = "jump_cluster_instance_ids"
+	VarMonitoringAlarmTopicArn = "monitoring_alarm_topic_arn"
 )
@@ -2,13 +2,15 @@ package modules
 
 import "github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
 
-// VariableSchema defines the metadata for a Terraform variable (name, type, description, sensitivity).
+// VariableSchema defines the metadata for a Terraform variable (name, type, description, sensitivity, validations).
 // Shared across modules to avoid duplicating variable definitions.
 type VariableSchema struct {
 	Name        string
 	Type        string
 	Description string
 	Sensitive   bool
+	Validations []hcltypes.TerraformValidation
+	Optional    bool
 }
 
 // ToDefinition converts a VariableSchema to a hcltypes.TerraformVariable.
@@ -18,6 +20,8 @@ func (v VariableSchema) ToDefinition() hcltypes.TerraformVariable {
 		Type:        v.Type,
 		Description: v.Description,
 		Sensitive:   v.Sensitive,
+		Validations: v.Validations,
+		Optional:    v.Optional,
 	}
 }
 
@@ -36,6 +40,7 @@ var (
 	SchemaAWSRegion = VariableSchema{
 		Name: "aws_region", Type: "string",
 		Description: "The AWS region", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateAWSRegion("aws_region")},
 	}
 )
 
@@ -76,10 +81,12 @@ var (
 	SchemaTargetClusterRestEndpoint = VariableSchema{
 		Name: "target_cluster_rest_endpoint", Type: "string",
 		Description: "The REST endpoint of the target Confluent Cloud cluster that data will be migrated to.", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL("target_cluster_rest_endpoint", "https")},
 	}
 	SchemaTargetClusterID = VariableSchema{
 		Name: "target_cluster_id", Type: "string",
 		Description: "The ID of the target Confluent Cloud cluster that data will be migrated to.", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("target_cluster_id", "lkc")},
 	}
 	SchemaClusterLinkName = VariableSchema{
 		Name: "cluster_link_name", Type: "string",
@@ -89,6 +96,32 @@ var (
 		Name: "source_cluster_id", Type: "string",
 		Description: "The ID of the source Kafka cluster that data will be migrated from.", Sensitive: false,
 	}
+	SchemaClusterLinkMode = VariableSchema{
+		Name: "cluster_link_mode", Type: "string",
+		Description: "The cluster link mode: DESTINATION, or BIDIRECTIONAL to also create the reverse link on the source cluster.", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOneOf("cluster_link_mode", "DESTINATION", "BIDIRECTIONAL")},
+	}
+	SchemaClusterLinkPrefix = VariableSchema{
+		Name: "cluster_link_prefix", Type: "string",
+		Description: "The prefix prepended to the names of mirror topics created by the cluster link.", Sensitive: false,
+	}
+	SchemaTargetClusterBootstrapEndpoint = VariableSchema{
+		Name: "target_cluster_bootstrap_endpoint", Type: "string",
+		Description: "The bootstrap endpoint of the target Confluent Cloud cluster, used by the reverse link on the source cluster.", Sensitive: false,
+	}
+	SchemaSourceClusterRestEndpoint = VariableSchema{
+		Name: "source_cluster_rest_endpoint", Type: "string",
+		Description: "The Confluent REST endpoint of the source cluster, used to create the reverse link.", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateURL("source_cluster_rest_endpoint", "http", "https")},
+	}
+	SchemaSourceClusterRestAPIKey = VariableSchema{
+		Name: "source_cluster_rest_api_key", Type: "string",
+		Description: "The username or API key for the source cluster REST endpoint.", Sensitive: false,
+	}
+	SchemaSourceClusterRestAPISecret = VariableSchema{
+		Name: "source_cluster_rest_api_secret", Type: "string",
+		Description: "The password or API secret for the source cluster REST endpoint.", Sensitive: true,
+	}
 )
 
 // Network variables
@@ -96,6 +129,7 @@ var (
 	SchemaVpcID = VariableSchema{
 		Name: "vpc_id", Type: "string",
 		Description: "ID of the VPC", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("vpc_id", "vpc")},
 	}
 )
 
@@ -109,6 +143,37 @@ var (
 		Name: "jump_cluster_ssh_key_pair_name", Type: "string",
 		Description: "Name of the AWS key pair for SSH access to the jump cluster (including setup host) instances.", Sensitive: false,
 	}
+	SchemaJumpClusterOSUser = VariableSchema{
+		Name: "jump_cluster_os_user", Type: "string",
+		Description: "Login user of the jump cluster broker AMI, used for SSH access and as the owner of the cluster link scripts.", Sensitive: false,
+	}
+)
+
+// Jump cluster egress variables, used by the user data of the setup host and
+// the jump cluster brokers in egress-restricted VPCs.
+var (
+	SchemaJumpClusterCABundle = VariableSchema{
+		Name: "jump_cluster_ca_bundle", Type: "string",
+		Description: "PEM certificates of a corporate CA, e.g. of a TLS-inspecting proxy, added to the system trust store of the jump cluster (including setup host) instances. Empty to keep the default trust store.", Sensitive: false,
+		Optional: true,
+	}
+	SchemaJumpClusterHTTPProxy = VariableSchema{
+		Name: "jump_cluster_http_proxy", Type: "string",
+		Description: "HTTP_PROXY for the jump cluster (including setup host) instances, e.g. http://proxy.example.com:3128. Empty for direct egress.", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOptionalURL("jump_cluster_http_proxy", "http", "https")},
+		Optional:    true,
+	}
+	SchemaJumpClusterHTTPSProxy = VariableSchema{
+		Name: "jump_cluster_https_proxy", Type: "string",
+		Description: "HTTPS_PROXY for the jump cluster (including setup host) instances. Empty for direct egress.", Sensitive: false,
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateOptionalURL("jump_cluster_https_proxy", "http", "https")},
+		Optional:    true,
+	}
+	SchemaJumpClusterNoProxy = VariableSchema{
+		Name: "jump_cluster_no_proxy", Type: "string",
+		Description: "Comma-separated hosts and domains the jump cluster (including setup host) instances reach without the proxy, in addition to localhost, instance metadata and *.internal.", Sensitive: false,
+		Optional: true,
+	}
 )
 
 // ExtractModuleVariableDefinitions extracts variable definitions for a module.
@@ -299,22 +299,14 @@ func (s *ReverseProxyHCLService) generateVariablesTf() string {
 	rootBody := f.Body()
 
 	variables := []hcltypes.TerraformVariable{
-		{Name: "vpc_id", Description: "The ID of the VPC", Type: "string", Sensitive: false},
-		{Name: "public_subnet_cidr", Description: "CIDR block for the public subnet", Type: "string", Sensitive: false},
+		{Name: "vpc_id", Description: "The ID of the VPC", Type: "string", Sensitive: false, Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID("vpc_id", "vpc")}},
+		{Name: "public_subnet_cidr", Description: "CIDR block for the public subnet", Type: "string", Sensitive: false, Validations: []hcltypes.TerraformValidation{hcltypes.ValidateCIDR("public_subnet_cidr")}},
 		{Name: "confluent_cloud_cluster_bootstrap_endpoint", Description: "The bootstrap endpoint of the Confluent cluster", Type: "string", Sensitive: false},
-		{Name: "aws_region", Description: "AWS Region", Type: "string", Sensitive: false},
+		{Name: "aws_region", Description: "AWS Region", Type: "string", Sensitive: false, Validations: []hcltypes.TerraformValidation{hcltypes.ValidateAWSRegion("aws_region")}},
 	}
 
 	for _, v := range variables {
-		variableBlock := rootBody.AppendNewBlock("variable", []string{v.Name})
-		variableBody := variableBlock.Body()
-		variableBody.SetAttributeRaw("type", utils.TokensForResourceReference(v.Type))
-		if v.Description != "" {
-			variableBody.SetAttributeValue("description", cty.StringVal(v.Description))
-		}
-		if v.Sensitive {
-			variableBody.SetAttributeValue("sensitive", cty.BoolVal(true))
-		}
+		variableBody := appendVariableBlock(rootBody, v)
 		// Add default for public_subnet_cidr and aws_region
 		if v.Name == "public_subnet_cidr" {
 			variableBody.SetAttributeValue("default", cty.StringVal("10.0.30.0/24"))
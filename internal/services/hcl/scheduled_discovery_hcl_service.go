@@ -0,0 +1,339 @@
+package hcl
+
+import (
+	"path"
+	"slices"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TaskRolePolicyFileName is the file next to main.tf holding the policy of the
+// scheduled discovery task role.
+const TaskRolePolicyFileName = "task-role-policy.json"
+
+const (
+	varKcpImage           = "kcp_image"
+	varAwsCliImage        = "aws_cli_image"
+	varScheduleExpression = "schedule_expression"
+	varSubnetIds          = "subnet_ids"
+	varSecurityGroupIds   = "security_group_ids"
+	varAssignPublicIp     = "assign_public_ip"
+
+	// defaultAwsCliImage copies the state file to and from S3 around the
+	// discover container, as the kcp image has no AWS CLI.
+	defaultAwsCliImage = "public.ecr.aws/aws-cli/aws-cli:latest"
+
+	scheduledDiscoveryName = "kcp-scheduled-discovery"
+	// stateMountPath is where the task's shared volume is mounted in every
+	// container, and the --output-dir of kcp discover.
+	stateMountPath     = "/kcp"
+	stateFileName      = "kcp-state.json"
+	stateVolumeName    = "state"
+	logRetentionDays   = 30
+	discoveryTaskCPU   = 1024
+	discoveryTaskMem   = 2048
+	ecsExecutionPolicy = "service-role/AmazonECSTaskExecutionRolePolicy"
+)
+
+// ScheduledDiscoveryHCLService generates Terraform that runs `kcp discover`
+// as an ECS Fargate task on an EventBridge schedule inside the customer
+// account. Each run restores the previous state file from S3, refreshes it and
+// uploads it again, so the inventory is kept current without anyone running
+// kcp by hand.
+type ScheduledDiscoveryHCLService struct {
+	// DeploymentID overrides the random deployment identifier in AWS provider tags.
+	// When empty, a random 8-character string is generated.
+	DeploymentID string
+}
+
+func NewScheduledDiscoveryHCLService() *ScheduledDiscoveryHCLService {
+	return &ScheduledDiscoveryHCLService{}
+}
+
+func (s *ScheduledDiscoveryHCLService) GenerateScheduledDiscoveryFiles(request hclrequests.ScheduledDiscoveryRequest) (hcltypes.TerraformFiles, error) {
+	return hcltypes.TerraformFiles{
+		MainTf:           s.generateMainTf(request),
+		ProvidersTf:      s.generateProvidersTf(),
+		VariablesTf:      s.generateVariablesTf(),
+		OutputsTf:        s.generateOutputsTf(),
+		InputsAutoTfvars: s.generateInputsAutoTfvars(request),
+	}, nil
+}
+
+// GenerateTaskRolePolicy returns the task role's policy document, which main.tf
+// reads from TaskRolePolicyFileName so it can be reviewed on its own.
+func (s *ScheduledDiscoveryHCLService) GenerateTaskRolePolicy(request hclrequests.ScheduledDiscoveryRequest) string {
+	return request.TaskRolePolicy + "\n"
+}
+
+func (s *ScheduledDiscoveryHCLService) generateMainTf(request hclrequests.ScheduledDiscoveryRequest) string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	rootBody.AppendNewBlock("data", []string{"aws_partition", "current"})
+	rootBody.AppendNewline()
+
+	localsBlock := rootBody.AppendNewBlock("locals", nil)
+	localsBlock.Body().SetAttributeRaw("state_file_uri", utils.TokensForStringTemplate(
+		"s3://${aws_s3_bucket.state.bucket}/"+path.Join(request.StatePrefix, stateFileName),
+	))
+	rootBody.AppendNewline()
+
+	// State bucket: versioned so every run's inventory is kept, encrypted and
+	// never public.
+	bucketBlock := rootBody.AppendNewBlock("resource", []string{"aws_s3_bucket", "state"})
+	bucketBlock.Body().SetAttributeValue("bucket", cty.StringVal(request.StateBucketName))
+	rootBody.AppendNewline()
+
+	versioningBody := rootBody.AppendNewBlock("resource", []string{"aws_s3_bucket_versioning", "state"}).Body()
+	SetResourceRef(versioningBody, "bucket", "aws_s3_bucket.state.id")
+	versioningBody.AppendNewBlock("versioning_configuration", nil).Body().SetAttributeValue("status", cty.StringVal("Enabled"))
+	rootBody.AppendNewline()
+
+	encryptionBody := rootBody.AppendNewBlock("resource", []string{"aws_s3_bucket_server_side_encryption_configuration", "state"}).Body()
+	SetResourceRef(encryptionBody, "bucket", "aws_s3_bucket.state.id")
+	encryptionBody.AppendNewBlock("rule", nil).Body().
+		AppendNewBlock("apply_server_side_encryption_by_default", nil).Body().
+		SetAttributeValue("sse_algorithm", cty.StringVal("AES256"))
+	rootBody.AppendNewline()
+
+	publicAccessBody := rootBody.AppendNewBlock("resource", []string{"aws_s3_bucket_public_access_block", "state"}).Body()
+	SetResourceRef(publicAccessBody, "bucket", "aws_s3_bucket.state.id")
+	for _, attr := range []string{"block_public_acls", "block_public_policy", "ignore_public_acls", "restrict_public_buckets"} {
+		publicAccessBody.SetAttributeValue(attr, cty.True)
+	}
+	rootBody.AppendNewline()
+
+	logGroupBody := rootBody.AppendNewBlock("resource", []string{"aws_cloudwatch_log_group", "discovery"}).Body()
+	logGroupBody.SetAttributeValue("name", cty.StringVal("/kcp/scheduled-discovery"))
+	logGroupBody.SetAttributeValue("retention_in_days", cty.NumberIntVal(logRetentionDays))
+	rootBody.AppendNewline()
+
+	clusterBody := rootBody.AppendNewBlock("resource", []string{"aws_ecs_cluster", "discovery"}).Body()
+	clusterBody.SetAttributeValue("name", cty.StringVal(scheduledDiscoveryName))
+	rootBody.AppendNewline()
+
+	// Execution role: lets ECS pull the images and write the container logs.
+	executionRoleBody := rootBody.AppendNewBlock("resource", []string{"aws_iam_role", "execution"}).Body()
+	executionRoleBody.SetAttributeValue("name_prefix", cty.StringVal("kcp-discovery-execution-"))
+	executionRoleBody.SetAttributeRaw("assume_role_policy", assumeRolePolicyTokens("ecs-tasks.amazonaws.com"))
+	rootBody.AppendNewline()
+
+	executionAttachmentBody := rootBody.AppendNewBlock("resource", []string{"aws_iam_role_policy_attachment", "execution"}).Body()
+	SetResourceRef(executionAttachmentBody, "role", "aws_iam_role.execution.name")
+	SetStringTemplate(executionAttachmentBody, "policy_arn", "arn:${data.aws_partition.current.partition}:iam::aws:policy/"+ecsExecutionPolicy)
+	rootBody.AppendNewline()
+
+	// Task role: the calls kcp discover makes, plus the state file in S3.
+	taskRoleBody := rootBody.AppendNewBlock("resource", []string{"aws_iam_role", "task"}).Body()
+	taskRoleBody.SetAttributeValue("name_prefix", cty.StringVal("kcp-discovery-task-"))
+	taskRoleBody.SetAttributeRaw("assume_role_policy", assumeRolePolicyTokens("ecs-tasks.amazonaws.com"))
+	rootBody.AppendNewline()
+
+	taskPolicyBody := rootBody.AppendNewBlock("resource", []string{"aws_iam_role_policy", "task"}).Body()
+	taskPolicyBody.SetAttributeValue("name", cty.StringVal("kcp-discover"))
+	SetResourceRef(taskPolicyBody, "role", "aws_iam_role.task.id")
+	taskPolicyBody.SetAttributeRaw("policy", utils.TokensForFunctionCall(
+		"file",
+		utils.TokensForStringTemplate("${path.module}/"+TaskRolePolicyFileName),
+	))
+	rootBody.AppendNewline()
+
+	taskDefinitionBlock := rootBody.AppendNewBlock("resource", []string{"aws_ecs_task_definition", "discovery"})
+	taskDefinitionBody := taskDefinitionBlock.Body()
+	taskDefinitionBody.SetAttributeValue("family", cty.StringVal(scheduledDiscoveryName))
+	taskDefinitionBody.SetAttributeRaw("requires_compatibilities", utils.TokensForStringList([]string{"FARGATE"}))
+	taskDefinitionBody.SetAttributeValue("network_mode", cty.StringVal("awsvpc"))
+	taskDefinitionBody.SetAttributeValue("cpu", cty.NumberIntVal(discoveryTaskCPU))
+	taskDefinitionBody.SetAttributeValue("memory", cty.NumberIntVal(discoveryTaskMem))
+	SetResourceRef(taskDefinitionBody, "execution_role_arn", "aws_iam_role.execution.arn")
+	SetResourceRef(taskDefinitionBody, "task_role_arn", "aws_iam_role.task.arn")
+	taskDefinitionBody.AppendNewline()
+	taskDefinitionBody.AppendNewBlock("volume", nil).Body().SetAttributeValue("name", cty.StringVal(stateVolumeName))
+	taskDefinitionBody.AppendNewline()
+	taskDefinitionBody.SetAttributeRaw("container_definitions", s.containerDefinitionsTokens(request))
+	rootBody.AppendNewline()
+
+	// EventBridge role: lets the schedule start the task with its roles.
+	eventsRoleBody := rootBody.AppendNewBlock("resource", []string{"aws_iam_role", "events"}).Body()
+	eventsRoleBody.SetAttributeValue("name_prefix", cty.StringVal("kcp-discovery-events-"))
+	eventsRoleBody.SetAttributeRaw("assume_role_policy", assumeRolePolicyTokens("events.amazonaws.com"))
+	rootBody.AppendNewline()
+
+	eventsPolicyBody := rootBody.AppendNewBlock("resource", []string{"aws_iam_role_policy", "events"}).Body()
+	eventsPolicyBody.SetAttributeValue("name", cty.StringVal("kcp-run-discovery-task"))
+	SetResourceRef(eventsPolicyBody, "role", "aws_iam_role.events.id")
+	eventsPolicyBody.SetAttributeRaw("policy", utils.TokensForFunctionCall("jsonencode", utils.TokensForMap(map[string]hclwrite.Tokens{
+		"Version": utils.TokensForStringTemplate("2012-10-17"),
+		"Statement": utils.TokensForBracketedList(
+			utils.TokensForMap(map[string]hclwrite.Tokens{
+				"Effect":   utils.TokensForStringTemplate("Allow"),
+				"Action":   utils.TokensForStringTemplate("ecs:RunTask"),
+				"Resource": utils.TokensForResourceReference("aws_ecs_task_definition.discovery.arn"),
+			}),
+			utils.TokensForMap(map[string]hclwrite.Tokens{
+				"Effect": utils.TokensForStringTemplate("Allow"),
+				"Action": utils.TokensForStringTemplate("iam:PassRole"),
+				"Resource": utils.TokensForBracketedList(
+					utils.TokensForResourceReference("aws_iam_role.execution.arn"),
+					utils.TokensForResourceReference("aws_iam_role.task.arn"),
+				),
+			}),
+		),
+	})))
+	rootBody.AppendNewline()
+
+	ruleBody := rootBody.AppendNewBlock("resource", []string{"aws_cloudwatch_event_rule", "discovery"}).Body()
+	ruleBody.SetAttributeValue("name", cty.StringVal(scheduledDiscoveryName))
+	ruleBody.SetAttributeValue("description", cty.StringVal("Runs kcp discover and uploads the state file to S3"))
+	SetVarRef(ruleBody, "schedule_expression", varScheduleExpression)
+	rootBody.AppendNewline()
+
+	targetBody := rootBody.AppendNewBlock("resource", []string{"aws_cloudwatch_event_target", "discovery"}).Body()
+	SetResourceRef(targetBody, "rule", "aws_cloudwatch_event_rule.discovery.name")
+	SetResourceRef(targetBody, "arn", "aws_ecs_cluster.discovery.arn")
+	SetResourceRef(targetBody, "role_arn", "aws_iam_role.events.arn")
+	targetBody.AppendNewline()
+	ecsTargetBody := targetBody.AppendNewBlock("ecs_target", nil).Body()
+	SetResourceRef(ecsTargetBody, "task_definition_arn", "aws_ecs_task_definition.discovery.arn")
+	ecsTargetBody.SetAttributeValue("launch_type", cty.StringVal("FARGATE"))
+	ecsTargetBody.SetAttributeValue("task_count", cty.NumberIntVal(1))
+	ecsTargetBody.AppendNewline()
+	networkBody := ecsTargetBody.AppendNewBlock("network_configuration", nil).Body()
+	SetVarRef(networkBody, "subnets", varSubnetIds)
+	SetVarRef(networkBody, "security_groups", varSecurityGroupIds)
+	SetVarRef(networkBody, "assign_public_ip", varAssignPublicIp)
+
+	return string(f.Bytes())
+}
+
+// containerDefinitionsTokens runs three containers over a shared volume:
+// fetch-state restores the last state file (and fails harmlessly on the first
+// run, when there is none), discover refreshes it, and upload-state copies it
+// back to S3 only when discover succeeded. upload-state is the only essential
+// container, so the task ends when it does.
+func (s *ScheduledDiscoveryHCLService) containerDefinitionsTokens(request hclrequests.ScheduledDiscoveryRequest) hclwrite.Tokens {
+	localStateFile := path.Join(stateMountPath, stateFileName)
+	discoverCommand := append([]string{"discover"}, request.DiscoverArgs...)
+	discoverCommand = append(discoverCommand, "--output-dir", stateMountPath)
+
+	fetch := containerDefinitionTokens("fetch-state", varAwsCliImage, false, hclwrite.Tokens{}, utils.TokensForBracketedList(
+		utils.TokensForStringTemplate("s3"),
+		utils.TokensForStringTemplate("cp"),
+		utils.TokensForResourceReference("local.state_file_uri"),
+		utils.TokensForStringTemplate(localStateFile),
+	))
+	discover := containerDefinitionTokens("discover", varKcpImage, false, dependsOnTokens("fetch-state", "COMPLETE"), utils.TokensForStringList(discoverCommand))
+	upload := containerDefinitionTokens("upload-state", varAwsCliImage, true, dependsOnTokens("discover", "SUCCESS"), utils.TokensForBracketedList(
+		utils.TokensForStringTemplate("s3"),
+		utils.TokensForStringTemplate("cp"),
+		utils.TokensForStringTemplate(localStateFile),
+		utils.TokensForResourceReference("local.state_file_uri"),
+		utils.TokensForStringTemplate("--sse"),
+		utils.TokensForStringTemplate("AES256"),
+	))
+
+	return utils.TokensForFunctionCall("jsonencode", utils.TokensForBracketedList(fetch, discover, upload))
+}
+
+// containerDefinitionTokens is one entry of an ECS container_definitions list.
+func containerDefinitionTokens(name, imageVar string, essential bool, dependsOn, command hclwrite.Tokens) hclwrite.Tokens {
+	definition := map[string]hclwrite.Tokens{
+		"name":      utils.TokensForStringTemplate(name),
+		"image":     utils.TokensForVarReference(imageVar),
+		"essential": hclwrite.TokensForValue(cty.BoolVal(essential)),
+		"command":   command,
+		"mountPoints": utils.TokensForBracketedList(utils.TokensForMap(map[string]hclwrite.Tokens{
+			"sourceVolume":  utils.TokensForStringTemplate(stateVolumeName),
+			"containerPath": utils.TokensForStringTemplate(stateMountPath),
+		})),
+		"logConfiguration": utils.TokensForMap(map[string]hclwrite.Tokens{
+			"logDriver": utils.TokensForStringTemplate("awslogs"),
+			"options": utils.TokensForMap(map[string]hclwrite.Tokens{
+				`"awslogs-group"`:         utils.TokensForResourceReference("aws_cloudwatch_log_group.discovery.name"),
+				`"awslogs-region"`:        utils.TokensForVarReference(aws.VarAwsRegion),
+				`"awslogs-stream-prefix"`: utils.TokensForStringTemplate(name),
+			}),
+		}),
+	}
+	if len(dependsOn) > 0 {
+		definition["dependsOn"] = dependsOn
+	}
+	return utils.TokensForMap(definition)
+}
+
+func dependsOnTokens(containerName, condition string) hclwrite.Tokens {
+	return utils.TokensForBracketedList(utils.TokensForMap(map[string]hclwrite.Tokens{
+		"containerName": utils.TokensForStringTemplate(containerName),
+		"condition":     utils.TokensForStringTemplate(condition),
+	}))
+}
+
+// assumeRolePolicyTokens is a jsonencode'd trust policy for an AWS service.
+func assumeRolePolicyTokens(service string) hclwrite.Tokens {
+	return utils.TokensForFunctionCall("jsonencode", utils.TokensForMap(map[string]hclwrite.Tokens{
+		"Version": utils.TokensForStringTemplate("2012-10-17"),
+		"Statement": utils.TokensForBracketedList(utils.TokensForMap(map[string]hclwrite.Tokens{
+			"Effect": utils.TokensForStringTemplate("Allow"),
+			"Action": utils.TokensForStringTemplate("sts:AssumeRole"),
+			"Principal": utils.TokensForMap(map[string]hclwrite.Tokens{
+				"Service": utils.TokensForStringTemplate(service),
+			}),
+		})),
+	}))
+}
+
+func (s *ScheduledDiscoveryHCLService) generateProvidersTf() string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	terraformBlock := rootBody.AppendNewBlock("terraform", nil)
+	requiredProvidersBlock := terraformBlock.Body().AppendNewBlock("required_providers", nil)
+	aws.AddRequiredProvider(requiredProvidersBlock.Body())
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateProviderBlockWithVarAndDeploymentID(s.DeploymentID))
+
+	return string(f.Bytes())
+}
+
+func (s *ScheduledDiscoveryHCLService) generateVariablesTf() string {
+	return GenerateVariablesTf(slices.Concat(aws.AwsProviderVariables, []hcltypes.TerraformVariable{
+		{Name: varKcpImage, Description: "Container image whose entrypoint is the kcp binary", Type: "string"},
+		{Name: varAwsCliImage, Description: "Container image whose entrypoint is the AWS CLI, used to copy the state file to and from S3", Type: "string"},
+		{Name: varScheduleExpression, Description: "EventBridge schedule expression the discovery runs on, e.g. rate(1 day) or cron(0 6 * * ? *)", Type: "string", Validations: []hcltypes.TerraformValidation{{
+			Condition:    `can(regex("^(rate|cron)\\(.+\\)$", var.` + varScheduleExpression + `))`,
+			ErrorMessage: "The " + varScheduleExpression + " value must be a rate(...) or cron(...) expression.",
+		}}},
+		{Name: varSubnetIds, Description: "Subnets the discovery task runs in. They need a route to the AWS APIs and the image registries", Type: "list(string)"},
+		{Name: varSecurityGroupIds, Description: "Security groups of the discovery task. It only makes outbound connections", Type: "list(string)"},
+		{Name: varAssignPublicIp, Description: "Whether to give the task a public IP, needed in public subnets without a NAT gateway", Type: "bool"},
+	}))
+}
+
+func (s *ScheduledDiscoveryHCLService) generateOutputsTf() string {
+	return GenerateOutputsTf([]hcltypes.TerraformOutput{
+		{Name: "state_bucket_name", Description: "S3 bucket the state file is kept in", Value: "aws_s3_bucket.state.bucket"},
+		{Name: "state_file_uri", Description: "S3 location of the state file each run refreshes", Value: "local.state_file_uri"},
+		{Name: "ecs_cluster_arn", Description: "ECS cluster the discovery task runs in", Value: "aws_ecs_cluster.discovery.arn"},
+		{Name: "task_definition_arn", Description: "ECS task definition of the discovery task", Value: "aws_ecs_task_definition.discovery.arn"},
+		{Name: "log_group_name", Description: "CloudWatch log group of the discovery task", Value: "aws_cloudwatch_log_group.discovery.name"},
+	})
+}
+
+func (s *ScheduledDiscoveryHCLService) generateInputsAutoTfvars(request hclrequests.ScheduledDiscoveryRequest) string {
+	return GenerateInputsAutoTfvars(map[string]any{
+		aws.VarAwsRegion:      request.Region,
+		varKcpImage:           request.KcpImage,
+		varAwsCliImage:        defaultAwsCliImage,
+		varScheduleExpression: request.ScheduleExpression,
+		varSubnetIds:          request.SubnetIds,
+		varSecurityGroupIds:   request.SecurityGroupIds,
+		varAssignPublicIp:     request.AssignPublicIp,
+	})
+}
@@ -0,0 +1,35 @@
+//go:build terraform_validation
+
+package hcl
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+)
+
+func TestScheduledDiscovery(t *testing.T) {
+	t.Parallel()
+
+	request := hclrequests.ScheduledDiscoveryRequest{
+		Region:             "us-east-1",
+		KcpImage:           "123456789012.dkr.ecr.us-east-1.amazonaws.com/kcp:latest",
+		ScheduleExpression: "rate(1 day)",
+		SubnetIds:          []string{"subnet-aaa", "subnet-bbb"},
+		SecurityGroupIds:   []string{"sg-aaa"},
+		StateBucketName:    "acme-kcp",
+		StatePrefix:        "kcp",
+		DiscoverArgs:       []string{"--region", "us-east-1"},
+		TaskRolePolicy:     `{"Version": "2012-10-17", "Statement": []}`,
+	}
+
+	service := &ScheduledDiscoveryHCLService{DeploymentID: "test1234"}
+	files, err := service.GenerateScheduledDiscoveryFiles(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project := terraformFilesToMap(files)
+	project[TaskRolePolicyFileName] = service.GenerateTaskRolePolicy(request)
+	validateTerraformProject(t, project)
+}
@@ -0,0 +1,199 @@
+package hcl
+
+import (
+	"github.com/confluentinc/kcp/internal/services/hcl/aws"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SourceImportHCLService generates Terraform that describes an existing MSK
+// cluster together with `import` blocks, so a hand-managed source cluster can
+// be adopted into Terraform state without being recreated.
+type SourceImportHCLService struct{}
+
+func NewSourceImportHCLService() *SourceImportHCLService {
+	return &SourceImportHCLService{}
+}
+
+func (s *SourceImportHCLService) GenerateSourceImportFiles(request hclrequests.SourceClusterImportRequest) (hcltypes.TerraformFiles, error) {
+	return hcltypes.TerraformFiles{
+		MainTf:           s.generateMainTf(request),
+		ProvidersTf:      s.generateProvidersTf(),
+		VariablesTf:      GenerateVariablesTf(aws.AwsProviderVariables),
+		OutputsTf:        s.generateOutputsTf(request),
+		InputsAutoTfvars: GenerateInputsAutoTfvars(map[string]any{aws.VarAwsRegion: request.Region}),
+	}, nil
+}
+
+func (s *SourceImportHCLService) generateProvidersTf() string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	terraformBlock := rootBody.AppendNewBlock("terraform", nil)
+	// import blocks need Terraform 1.5+.
+	terraformBlock.Body().SetAttributeValue("required_version", cty.StringVal(">= 1.5.0"))
+	requiredProvidersBlock := terraformBlock.Body().AppendNewBlock("required_providers", nil)
+	aws.AddRequiredProvider(requiredProvidersBlock.Body())
+	rootBody.AppendNewline()
+
+	// No default_tags here: adding tags kcp did not discover would make the
+	// first plan after import show a diff on every resource.
+	providerBlock := rootBody.AppendNewBlock("provider", []string{"aws"})
+	providerBlock.Body().SetAttributeRaw("region", utils.TokensForVarReference(aws.VarAwsRegion))
+
+	return string(f.Bytes())
+}
+
+func (s *SourceImportHCLService) generateMainTf(request hclrequests.SourceClusterImportRequest) string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	hasConfiguration := request.ConfigurationArn != ""
+	hasScramSecrets := request.SaslScram && len(request.ScramSecretArns) > 0
+
+	if hasConfiguration {
+		rootBody.AppendBlock(generateImportBlock("aws_msk_configuration.source", request.ConfigurationArn))
+		rootBody.AppendNewline()
+
+		configBlock := rootBody.AppendNewBlock("resource", []string{"aws_msk_configuration", "source"})
+		configBody := configBlock.Body()
+		configBody.SetAttributeValue("name", cty.StringVal(request.ConfigurationName))
+		configBody.SetAttributeValue("kafka_versions", cty.ListVal([]cty.Value{cty.StringVal(request.KafkaVersion)}))
+		configBody.SetAttributeValue("server_properties", cty.StringVal(request.ServerProperties))
+		rootBody.AppendNewline()
+	}
+
+	rootBody.AppendBlock(generateImportBlock("aws_msk_cluster.source", request.ClusterArn))
+	rootBody.AppendNewline()
+
+	clusterBlock := rootBody.AppendNewBlock("resource", []string{"aws_msk_cluster", "source"})
+	clusterBody := clusterBlock.Body()
+	clusterBody.SetAttributeValue("cluster_name", cty.StringVal(request.ClusterName))
+	clusterBody.SetAttributeValue("kafka_version", cty.StringVal(request.KafkaVersion))
+	clusterBody.SetAttributeValue("number_of_broker_nodes", cty.NumberIntVal(int64(request.NumberOfBrokerNodes)))
+	if request.EnhancedMonitoring != "" {
+		clusterBody.SetAttributeValue("enhanced_monitoring", cty.StringVal(request.EnhancedMonitoring))
+	}
+	clusterBody.AppendNewline()
+
+	brokerBody := clusterBody.AppendNewBlock("broker_node_group_info", nil).Body()
+	brokerBody.SetAttributeValue("instance_type", cty.StringVal(request.InstanceType))
+	brokerBody.SetAttributeRaw("client_subnets", utils.TokensForStringList(request.ClientSubnets))
+	brokerBody.SetAttributeRaw("security_groups", utils.TokensForStringList(request.SecurityGroups))
+	if request.VolumeSizeGiB > 0 {
+		storageBody := brokerBody.AppendNewBlock("storage_info", nil).Body()
+		ebsBody := storageBody.AppendNewBlock("ebs_storage_info", nil).Body()
+		ebsBody.SetAttributeValue("volume_size", cty.NumberIntVal(int64(request.VolumeSizeGiB)))
+	}
+	clusterBody.AppendNewline()
+
+	if hasConfiguration {
+		configInfoBody := clusterBody.AppendNewBlock("configuration_info", nil).Body()
+		configInfoBody.SetAttributeRaw("arn", utils.TokensForResourceReference("aws_msk_configuration.source.arn"))
+		configInfoBody.SetAttributeValue("revision", cty.NumberIntVal(request.ConfigurationRevision))
+		clusterBody.AppendNewline()
+	}
+
+	encryptionBody := clusterBody.AppendNewBlock("encryption_info", nil).Body()
+	if request.EncryptionAtRestKmsKeyArn != "" {
+		encryptionBody.SetAttributeValue("encryption_at_rest_kms_key_arn", cty.StringVal(request.EncryptionAtRestKmsKeyArn))
+	}
+	inTransitBody := encryptionBody.AppendNewBlock("encryption_in_transit", nil).Body()
+	if request.EncryptionInTransitClient != "" {
+		inTransitBody.SetAttributeValue("client_broker", cty.StringVal(request.EncryptionInTransitClient))
+	}
+	inTransitBody.SetAttributeValue("in_cluster", cty.BoolVal(request.EncryptionInTransitInCluster))
+	clusterBody.AppendNewline()
+
+	authBody := clusterBody.AppendNewBlock("client_authentication", nil).Body()
+	authBody.SetAttributeValue("unauthenticated", cty.BoolVal(request.Unauthenticated))
+	if request.SaslIam || request.SaslScram {
+		saslBody := authBody.AppendNewBlock("sasl", nil).Body()
+		saslBody.SetAttributeValue("iam", cty.BoolVal(request.SaslIam))
+		saslBody.SetAttributeValue("scram", cty.BoolVal(request.SaslScram))
+	}
+	if len(request.TlsCertificateAuthorityArns) > 0 {
+		tlsBody := authBody.AppendNewBlock("tls", nil).Body()
+		tlsBody.SetAttributeRaw("certificate_authority_arns", utils.TokensForStringList(request.TlsCertificateAuthorityArns))
+	}
+
+	if request.BrokerLogs.Enabled() {
+		clusterBody.AppendNewline()
+		brokerLogsBody := clusterBody.AppendNewBlock("logging_info", nil).Body().AppendNewBlock("broker_logs", nil).Body()
+		if request.BrokerLogs.CloudWatchLogGroup != "" {
+			cloudwatchBody := brokerLogsBody.AppendNewBlock("cloudwatch_logs", nil).Body()
+			cloudwatchBody.SetAttributeValue("enabled", cty.True)
+			cloudwatchBody.SetAttributeValue("log_group", cty.StringVal(request.BrokerLogs.CloudWatchLogGroup))
+		}
+		if request.BrokerLogs.FirehoseDeliveryStream != "" {
+			firehoseBody := brokerLogsBody.AppendNewBlock("firehose", nil).Body()
+			firehoseBody.SetAttributeValue("enabled", cty.True)
+			firehoseBody.SetAttributeValue("delivery_stream", cty.StringVal(request.BrokerLogs.FirehoseDeliveryStream))
+		}
+		if request.BrokerLogs.S3Bucket != "" {
+			s3Body := brokerLogsBody.AppendNewBlock("s3", nil).Body()
+			s3Body.SetAttributeValue("enabled", cty.True)
+			s3Body.SetAttributeValue("bucket", cty.StringVal(request.BrokerLogs.S3Bucket))
+			if request.BrokerLogs.S3Prefix != "" {
+				s3Body.SetAttributeValue("prefix", cty.StringVal(request.BrokerLogs.S3Prefix))
+			}
+		}
+	}
+
+	if len(request.Tags) > 0 {
+		clusterBody.AppendNewline()
+		clusterBody.SetAttributeRaw("tags", tokensForStringMap(request.Tags))
+	}
+
+	clusterBody.AppendNewline()
+	// An accidental destroy of the source cluster mid-migration is unrecoverable.
+	_ = utils.GenerateLifecycleBlock(clusterBlock, "prevent_destroy", true)
+	rootBody.AppendNewline()
+
+	if hasScramSecrets {
+		// The association is imported by cluster ARN.
+		rootBody.AppendBlock(generateImportBlock("aws_msk_scram_secret_association.source", request.ClusterArn))
+		rootBody.AppendNewline()
+
+		assocBody := rootBody.AppendNewBlock("resource", []string{"aws_msk_scram_secret_association", "source"}).Body()
+		assocBody.SetAttributeRaw("cluster_arn", utils.TokensForResourceReference("aws_msk_cluster.source.arn"))
+		assocBody.SetAttributeRaw("secret_arn_list", utils.TokensForStringList(request.ScramSecretArns))
+	}
+
+	return string(f.Bytes())
+}
+
+func (s *SourceImportHCLService) generateOutputsTf(request hclrequests.SourceClusterImportRequest) string {
+	outputs := []hcltypes.TerraformOutput{
+		{Name: "source_cluster_arn", Description: "ARN of the imported MSK cluster", Value: "aws_msk_cluster.source.arn"},
+		{Name: "source_cluster_bootstrap_brokers_sasl_iam", Description: "SASL/IAM bootstrap brokers of the imported MSK cluster", Value: "aws_msk_cluster.source.bootstrap_brokers_sasl_iam"},
+		{Name: "source_cluster_bootstrap_brokers_sasl_scram", Description: "SASL/SCRAM bootstrap brokers of the imported MSK cluster", Value: "aws_msk_cluster.source.bootstrap_brokers_sasl_scram"},
+	}
+	if request.ConfigurationArn != "" {
+		outputs = append(outputs, hcltypes.TerraformOutput{
+			Name: "source_configuration_arn", Description: "ARN of the imported MSK configuration", Value: "aws_msk_configuration.source.arn",
+		})
+	}
+	return GenerateOutputsTf(outputs)
+}
+
+// generateImportBlock returns `import { to = <address>  id = "<id>" }`.
+func generateImportBlock(address, id string) *hclwrite.Block {
+	importBlock := hclwrite.NewBlock("import", nil)
+	importBlock.Body().SetAttributeRaw("to", utils.TokensForResourceReference(address))
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(id))
+	return importBlock
+}
+
+// tokensForStringMap renders a map of literal strings (cty sorts the keys, so
+// the output is stable across runs).
+func tokensForStringMap(m map[string]string) hclwrite.Tokens {
+	vals := make(map[string]cty.Value, len(m))
+	for k, v := range m {
+		vals[k] = cty.StringVal(v)
+	}
+	return hclwrite.TokensForValue(cty.MapVal(vals))
+}
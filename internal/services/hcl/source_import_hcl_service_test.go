@@ -0,0 +1,64 @@
+//go:build terraform_validation
+
+package hcl
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+)
+
+func TestSourceImport(t *testing.T) {
+	t.Parallel()
+
+	base := hclrequests.SourceClusterImportRequest{
+		Region:                       "us-east-1",
+		ClusterArn:                   "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1",
+		ClusterName:                  "orders",
+		KafkaVersion:                 "3.6.0",
+		NumberOfBrokerNodes:          3,
+		InstanceType:                 "kafka.m5.large",
+		ClientSubnets:                []string{"subnet-a", "subnet-b", "subnet-c"},
+		SecurityGroups:               []string{"sg-aaa"},
+		VolumeSizeGiB:                100,
+		EncryptionInTransitClient:    "TLS",
+		EncryptionInTransitInCluster: true,
+		SaslIam:                      true,
+	}
+
+	withConfigAndScram := base
+	withConfigAndScram.ConfigurationArn = "arn:aws:kafka:us-east-1:123456789012:configuration/orders-config/def-2"
+	withConfigAndScram.ConfigurationName = "orders-config"
+	withConfigAndScram.ConfigurationRevision = 2
+	withConfigAndScram.ServerProperties = "auto.create.topics.enable=false\n"
+	withConfigAndScram.SaslScram = true
+	withConfigAndScram.ScramSecretArns = []string{"arn:aws:secretsmanager:us-east-1:123456789012:secret:AmazonMSK_orders-abc"}
+	withConfigAndScram.Tags = map[string]string{"team": "payments"}
+	withConfigAndScram.BrokerLogs = hclrequests.BrokerLogDelivery{
+		CloudWatchLogGroup:     "/msk/orders",
+		FirehoseDeliveryStream: "orders-broker-logs",
+		S3Bucket:               "orders-logs",
+		S3Prefix:               "brokers/",
+	}
+
+	cases := []struct {
+		name    string
+		request hclrequests.SourceClusterImportRequest
+	}{
+		{name: "cluster_only", request: base},
+		{name: "with_configuration_and_scram", request: withConfigAndScram},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := NewSourceImportHCLService().GenerateSourceImportFiles(tc.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			validateTerraformProject(t, terraformFilesToMap(files))
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package hcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/confluent"
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/hcl/modules"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+const (
+	varFlinkRegion = "flink_region"
+	varFlinkMaxCfu = "flink_max_cfu"
+	varKsqlDBCsu   = "ksqldb_csu"
+
+	// Starting sizes written to inputs.auto.tfvars. Revisit them per workload
+	// once the port has been load tested.
+	defaultFlinkMaxCfu = 10
+	defaultKsqlDBCsu   = 4
+
+	ksqlDBServiceAccountName = "ksqldb"
+	ksqlDBRoleBindingName    = "ksqldb_cluster_admin"
+	targetEnvironmentName    = "target"
+	targetClusterName        = "target"
+)
+
+// StreamProcessingHCLService generates placeholder Confluent Cloud Flink
+// compute pools and ksqlDB clusters for the stream-processing workloads kcp
+// detected on a source cluster. The resources only reserve capacity: the
+// Streams topologies and KSQL queries still have to be ported by hand.
+type StreamProcessingHCLService struct{}
+
+func NewStreamProcessingHCLService() *StreamProcessingHCLService {
+	return &StreamProcessingHCLService{}
+}
+
+func (s *StreamProcessingHCLService) GenerateStreamProcessingFiles(request hclrequests.StreamProcessingRequest) (hcltypes.TerraformFiles, error) {
+	if len(request.Workloads) == 0 {
+		return hcltypes.TerraformFiles{}, fmt.Errorf("no stream-processing workloads to generate placeholders for")
+	}
+	if request.HasEngine(hclrequests.StreamProcessingEngineKsqlDB) && request.TargetClusterId == "" {
+		return hcltypes.TerraformFiles{}, fmt.Errorf("a target cluster ID is required to place workloads on ksqlDB")
+	}
+	if request.HasEngine(hclrequests.StreamProcessingEngineFlink) && request.TargetRegion == "" {
+		return hcltypes.TerraformFiles{}, fmt.Errorf("a target region is required to place workloads on Flink")
+	}
+
+	return hcltypes.TerraformFiles{
+		MainTf:           s.generateMainTf(request),
+		ProvidersTf:      s.generateProvidersTf(),
+		VariablesTf:      GenerateVariablesTf(s.variables(request)),
+		OutputsTf:        s.generateOutputsTf(request),
+		InputsAutoTfvars: GenerateInputsAutoTfvars(s.inputs(request)),
+	}, nil
+}
+
+func (s *StreamProcessingHCLService) generateProvidersTf() string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	terraformBlock := rootBody.AppendNewBlock("terraform", nil)
+	requiredProvidersBlock := terraformBlock.Body().AppendNewBlock("required_providers", nil)
+	confluent.AddRequiredProvider(requiredProvidersBlock.Body())
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(confluent.GenerateProviderBlock())
+
+	return string(f.Bytes())
+}
+
+func (s *StreamProcessingHCLService) generateMainTf(request hclrequests.StreamProcessingRequest) string {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	rootBody.AppendBlock(confluent.GenerateEnvironmentDataSource(targetEnvironmentName, modules.VarTargetEnvironmentID))
+	rootBody.AppendNewline()
+	envIdRef := fmt.Sprintf("data.confluent_environment.%s.id", targetEnvironmentName)
+
+	usesKsqlDB := request.HasEngine(hclrequests.StreamProcessingEngineKsqlDB)
+	if usesKsqlDB {
+		clusterDataBlock := hclwrite.NewBlock("data", []string{"confluent_kafka_cluster", targetClusterName})
+		clusterDataBlock.Body().SetAttributeRaw("id", utils.TokensForVarReference(modules.VarTargetClusterID))
+		environmentBlock := clusterDataBlock.Body().AppendNewBlock("environment", nil)
+		environmentBlock.Body().SetAttributeRaw("id", utils.TokensForResourceReference(envIdRef))
+		rootBody.AppendBlock(clusterDataBlock)
+		rootBody.AppendNewline()
+
+		description := fmt.Sprintf("Identity of the ksqlDB clusters replacing the KSQL workloads of %s.", request.SourceClusterName)
+		rootBody.AppendBlock(confluent.GenerateServiceAccount(ksqlDBServiceAccountName, fmt.Sprintf("ksqldb-%s", request.SourceClusterName), description, false))
+		rootBody.AppendNewline()
+
+		rootBody.AppendBlock(confluent.GenerateRoleBinding(
+			ksqlDBRoleBindingName,
+			fmt.Sprintf("User:${confluent_service_account.%s.id}", ksqlDBServiceAccountName),
+			"CloudClusterAdmin",
+			utils.TokensForResourceReference(fmt.Sprintf("data.confluent_kafka_cluster.%s.rbac_crn", targetClusterName)),
+			false,
+		))
+		rootBody.AppendNewline()
+	}
+
+	for _, workload := range request.Workloads {
+		rootBody.AppendUnstructuredTokens(utils.TokensForComment(workloadComment(workload)))
+
+		switch workload.Engine {
+		case hclrequests.StreamProcessingEngineFlink:
+			rootBody.AppendBlock(confluent.GenerateFlinkComputePoolResource(
+				workloadResourceName(workload),
+				workloadDisplayName(request, workload),
+				varFlinkRegion,
+				varFlinkMaxCfu,
+				envIdRef,
+			))
+		case hclrequests.StreamProcessingEngineKsqlDB:
+			rootBody.AppendBlock(confluent.GenerateKsqlClusterResource(
+				workloadResourceName(workload),
+				workloadDisplayName(request, workload),
+				varKsqlDBCsu,
+				fmt.Sprintf("data.confluent_kafka_cluster.%s.id", targetClusterName),
+				fmt.Sprintf("confluent_service_account.%s.id", ksqlDBServiceAccountName),
+				envIdRef,
+				fmt.Sprintf("confluent_role_binding.%s", ksqlDBRoleBindingName),
+			))
+		}
+		rootBody.AppendNewline()
+	}
+
+	return string(f.Bytes())
+}
+
+// workloadComment documents why a placeholder exists, so whoever ports the
+// workload can trace it back to the source signal.
+func workloadComment(workload hclrequests.StreamProcessingWorkload) string {
+	kind, port := "Kafka Streams application", "Port its topology"
+	if workload.Kind == hclrequests.StreamProcessingKindKsql {
+		kind, port = "KSQL/ksqlDB cluster", "Port its queries"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Placeholder for the %s %q detected on the source.\n", kind, workload.Name)
+	fmt.Fprintf(&b, "# %s before cutover; this resource only reserves capacity.\n", port)
+	for _, evidence := range workload.Evidence {
+		fmt.Fprintf(&b, "#   - %s\n", evidence)
+	}
+	return b.String()
+}
+
+func workloadDisplayName(request hclrequests.StreamProcessingRequest, workload hclrequests.StreamProcessingWorkload) string {
+	return fmt.Sprintf("%s-%s", request.SourceClusterName, workload.Name)
+}
+
+func workloadResourceName(workload hclrequests.StreamProcessingWorkload) string {
+	return utils.FormatHclResourceName(fmt.Sprintf("%s_%s", workload.Engine, workload.Name))
+}
+
+func (s *StreamProcessingHCLService) variables(request hclrequests.StreamProcessingRequest) []hcltypes.TerraformVariable {
+	variables := append([]hcltypes.TerraformVariable{}, confluent.ConfluentProviderVariables...)
+	variables = append(variables, hcltypes.TerraformVariable{
+		Name:        modules.VarTargetEnvironmentID,
+		Description: "ID of the Confluent Cloud environment to create the stream-processing placeholders in.",
+		Type:        "string",
+		Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(modules.VarTargetEnvironmentID, "env")},
+	})
+
+	if request.HasEngine(hclrequests.StreamProcessingEngineFlink) {
+		variables = append(variables,
+			hcltypes.TerraformVariable{
+				Name:        varFlinkRegion,
+				Description: "AWS region of the Flink compute pools. Must match the region of the target cluster.",
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateAWSRegion(varFlinkRegion)},
+			},
+			hcltypes.TerraformVariable{
+				Name:        varFlinkMaxCfu,
+				Description: "Maximum number of Confluent Flink Units (CFUs) each compute pool can scale to.",
+				Type:        "number",
+				Validations: []hcltypes.TerraformValidation{{
+					Condition:    fmt.Sprintf("contains([5, 10, 20, 30, 40, 50], var.%s)", varFlinkMaxCfu),
+					ErrorMessage: fmt.Sprintf("The %s value must be one of: 5, 10, 20, 30, 40, 50.", varFlinkMaxCfu),
+				}},
+			},
+		)
+	}
+
+	if request.HasEngine(hclrequests.StreamProcessingEngineKsqlDB) {
+		variables = append(variables,
+			hcltypes.TerraformVariable{
+				Name:        modules.VarTargetClusterID,
+				Description: "ID of the Confluent Cloud cluster the ksqlDB clusters read from and write to.",
+				Type:        "string",
+				Validations: []hcltypes.TerraformValidation{hcltypes.ValidateResourceID(modules.VarTargetClusterID, "lkc")},
+			},
+			hcltypes.TerraformVariable{
+				Name:        varKsqlDBCsu,
+				Description: "Number of Confluent Streaming Units (CSUs) of each ksqlDB cluster.",
+				Type:        "number",
+				Validations: []hcltypes.TerraformValidation{{
+					Condition:    fmt.Sprintf("contains([1, 2, 4, 8, 12, 28], var.%s)", varKsqlDBCsu),
+					ErrorMessage: fmt.Sprintf("The %s value must be one of: 1, 2, 4, 8, 12, 28.", varKsqlDBCsu),
+				}},
+			},
+		)
+	}
+
+	return variables
+}
+
+func (s *StreamProcessingHCLService) inputs(request hclrequests.StreamProcessingRequest) map[string]any {
+	values := map[string]any{
+		modules.VarTargetEnvironmentID: request.TargetEnvironmentId,
+	}
+	if request.HasEngine(hclrequests.StreamProcessingEngineFlink) {
+		values[varFlinkRegion] = request.TargetRegion
+		values[varFlinkMaxCfu] = defaultFlinkMaxCfu
+	}
+	if request.HasEngine(hclrequests.StreamProcessingEngineKsqlDB) {
+		values[modules.VarTargetClusterID] = request.TargetClusterId
+		values[varKsqlDBCsu] = defaultKsqlDBCsu
+	}
+	return values
+}
+
+func (s *StreamProcessingHCLService) generateOutputsTf(request hclrequests.StreamProcessingRequest) string {
+	var outputs []hcltypes.TerraformOutput
+	for _, workload := range request.Workloads {
+		resourceName := workloadResourceName(workload)
+		switch workload.Engine {
+		case hclrequests.StreamProcessingEngineFlink:
+			outputs = append(outputs, hcltypes.TerraformOutput{
+				Name:        resourceName + "_id",
+				Description: fmt.Sprintf("ID of the Flink compute pool for %s", workload.Name),
+				Value:       fmt.Sprintf("confluent_flink_compute_pool.%s.id", resourceName),
+			})
+		case hclrequests.StreamProcessingEngineKsqlDB:
+			outputs = append(outputs, hcltypes.TerraformOutput{
+				Name:        resourceName + "_endpoint",
+				Description: fmt.Sprintf("REST endpoint of the ksqlDB cluster for %s", workload.Name),
+				Value:       fmt.Sprintf("confluent_ksql_cluster.%s.rest_endpoint", resourceName),
+			})
+		}
+	}
+	return GenerateOutputsTf(outputs)
+}
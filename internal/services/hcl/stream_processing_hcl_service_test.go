@@ -0,0 +1,82 @@
+//go:build terraform_validation
+
+package hcl
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamProcessing(t *testing.T) {
+	t.Parallel()
+
+	streams := hclrequests.StreamProcessingWorkload{
+		Name:     "orders-app",
+		Kind:     hclrequests.StreamProcessingKindKafkaStreams,
+		Engine:   hclrequests.StreamProcessingEngineFlink,
+		Evidence: []string{"consumer groups: `orders-app`"},
+	}
+	ksql := hclrequests.StreamProcessingWorkload{
+		Name:     "default",
+		Kind:     hclrequests.StreamProcessingKindKsql,
+		Engine:   hclrequests.StreamProcessingEngineKsqlDB,
+		Evidence: []string{"internal topics: `_confluent-ksql-default__command_topic`"},
+	}
+
+	cases := []struct {
+		name     string
+		request  hclrequests.StreamProcessingRequest
+		contains []string
+	}{
+		{
+			name: "flink_only",
+			request: hclrequests.StreamProcessingRequest{
+				SourceClusterName:   "orders",
+				TargetEnvironmentId: "env-abc123",
+				TargetRegion:        "us-east-1",
+				Workloads:           []hclrequests.StreamProcessingWorkload{streams},
+			},
+			contains: []string{`resource "confluent_flink_compute_pool" "flink_orders_app"`, "max_cfu      = var.flink_max_cfu"},
+		},
+		{
+			name: "flink_and_ksqldb",
+			request: hclrequests.StreamProcessingRequest{
+				SourceClusterName:   "orders",
+				TargetEnvironmentId: "env-abc123",
+				TargetClusterId:     "lkc-xyz789",
+				TargetRegion:        "us-east-1",
+				Workloads:           []hclrequests.StreamProcessingWorkload{streams, ksql},
+			},
+			contains: []string{`resource "confluent_ksql_cluster" "ksqldb_default"`, "depends_on = [confluent_role_binding.ksqldb_cluster_admin]"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := NewStreamProcessingHCLService().GenerateStreamProcessingFiles(tc.request)
+			require.NoError(t, err)
+			for _, want := range tc.contains {
+				require.Contains(t, files.MainTf, want)
+			}
+			require.Contains(t, files.MainTf, "# Placeholder for the Kafka Streams application \"orders-app\"")
+
+			validateTerraformProject(t, terraformFilesToMap(files))
+		})
+	}
+}
+
+func TestStreamProcessing_KsqlDBRequiresTargetCluster(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStreamProcessingHCLService().GenerateStreamProcessingFiles(hclrequests.StreamProcessingRequest{
+		TargetEnvironmentId: "env-abc123",
+		Workloads: []hclrequests.StreamProcessingWorkload{
+			{Name: "default", Kind: hclrequests.StreamProcessingKindKsql, Engine: hclrequests.StreamProcessingEngineKsqlDB},
+		},
+	})
+	require.ErrorContains(t, err, "target cluster ID is required")
+}
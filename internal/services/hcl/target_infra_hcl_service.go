@@ -30,6 +30,11 @@ type TerraformResourceNames struct {
 	SubjectResourceOwnerRoleBinding string
 	KafkaClusterAdminRoleBinding    string
 	DataStewardRoleBinding          string
+	IdentityProvider                string
+	IdentityPool                    string
+	IdentityPoolTopicReadBinding    string
+	IdentityPoolTopicWriteBinding   string
+	IdentityPoolGroupReadBinding    string
 
 	AvailabilityZones string
 	CallerIdentity    string
@@ -38,6 +43,14 @@ type TerraformResourceNames struct {
 	Route53Record     string
 	SecurityGroup     string
 	SubnetName        string
+
+	ZonalAvailabilityZones   string
+	Route53ZonalRecord       string
+	Route53ZoneAssociation   string
+	ResolverSecurityGroup    string
+	ResolverInboundEndpoint  string
+	ResolverOutboundEndpoint string
+	ResolverRule             string
 }
 
 type TargetInfraHCLService struct {
@@ -65,6 +78,11 @@ func NewTerraformResourceNames() TerraformResourceNames {
 		SubjectResourceOwnerRoleBinding: "subject-resource-owner",
 		KafkaClusterAdminRoleBinding:    "app-manager-kafka-cluster-admin",
 		DataStewardRoleBinding:          "app-manager-kafka-data-steward",
+		IdentityProvider:                "oidc",
+		IdentityPool:                    "oauth-clients",
+		IdentityPoolTopicReadBinding:    "oauth-clients-topic-read",
+		IdentityPoolTopicWriteBinding:   "oauth-clients-topic-write",
+		IdentityPoolGroupReadBinding:    "oauth-clients-group-read",
 
 		// AWS Resources
 		AvailabilityZones: "available",
@@ -74,6 +92,14 @@ func NewTerraformResourceNames() TerraformResourceNames {
 		Route53Record:     "cflt_route_entries",
 		SecurityGroup:     "cflt_private_link_sg",
 		SubnetName:        "cflt_private_link_subnet",
+
+		ZonalAvailabilityZones:   "cflt_private_link_zonal",
+		Route53ZonalRecord:       "cflt_zonal_route_entries",
+		Route53ZoneAssociation:   "cflt_private_link_zone_association",
+		ResolverSecurityGroup:    "cflt_private_link_resolver_sg",
+		ResolverInboundEndpoint:  "cflt_private_link_inbound",
+		ResolverOutboundEndpoint: "cflt_private_link_outbound",
+		ResolverRule:             "cflt_private_link_forward",
 	}
 }
 
@@ -99,14 +125,14 @@ func (ti *TargetInfraHCLService) GenerateTerraformFiles(request hclrequests.Targ
 			Name:        "private_link",
 			MainTf:      ti.generatePrivateLinkModuleMainTf(request),
 			VariablesTf: ti.generatePrivateLinkModuleVariablesTf(request),
-			OutputsTf:   ti.generatePrivateLinkModuleOutputsTf(),
+			OutputsTf:   ti.generatePrivateLinkModuleOutputsTf(request),
 			VersionsTf:  ti.generatePrivateLinkModuleVersionsTf(),
 		})
 	}
 
 	return hcltypes.MigrationInfraTerraformProject{
 		MainTf:           ti.generateRootMainTf(request),
-		ProvidersTf:      ti.generateRootProvidersTf(),
+		ProvidersTf:      ti.generateRootProvidersTf(request),
 		VariablesTf:      GenerateVariablesTf(modules.GetTargetClusterModuleVariableDefinitions(request)),
 		OutputsTf:        ti.generateRootOutputsTf(request),
 		InputsAutoTfvars: ti.generateInputsAutoTfvars(request),
@@ -167,7 +193,7 @@ func (ti *TargetInfraHCLService) generateRootMainTf(request hclrequests.TargetCl
 	return string(f.Bytes())
 }
 
-func (ti *TargetInfraHCLService) generateRootProvidersTf() string {
+func (ti *TargetInfraHCLService) generateRootProvidersTf(request hclrequests.TargetClusterWizardRequest) string {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
@@ -179,6 +205,7 @@ func (ti *TargetInfraHCLService) generateRootProvidersTf() string {
 
 	requiredProvidersBody.SetAttributeRaw(confluent.GenerateRequiredProviderTokens())
 	requiredProvidersBody.SetAttributeRaw(aws.GenerateRequiredProviderTokens())
+	appendBackendBlock(terraformBody, request.Backend)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(confluent.GenerateProviderBlock())
@@ -198,6 +225,8 @@ func (ti *TargetInfraHCLService) generateRootOutputsTf(request hclrequests.Targe
 		ClusterName:        ti.ResourceNames.Cluster,
 		ServiceAccountName: ti.ResourceNames.ServiceAccount,
 		KafkaAPIKeyName:    ti.ResourceNames.KafkaAPIKey,
+		IdentityProvider:   ti.ResourceNames.IdentityProvider,
+		IdentityPool:       ti.ResourceNames.IdentityPool,
 	})
 
 	var rootOutputs []hcltypes.TerraformOutput
@@ -223,7 +252,7 @@ func (ti *TargetInfraHCLService) generateRootOutputsTf(request hclrequests.Targe
 	}
 
 	if request.NeedsPrivateLink {
-		privateLinkOutputs := modules.GetPrivateLinkModuleOutputDefinitions(ti.ResourceNames.VpcEndpoint)
+		privateLinkOutputs := modules.GetPrivateLinkModuleOutputDefinitions(request, ti.ResourceNames.VpcEndpoint, ti.ResourceNames.ResolverRule)
 		for _, o := range privateLinkOutputs {
 			rootOutputs = append(rootOutputs, hcltypes.TerraformOutput{
 				Name:        o.Name,
@@ -369,9 +398,61 @@ func (ti *TargetInfraHCLService) generateConfluentCloudModuleMainTf(request hclr
 		request.PreventDestroy,
 	))
 
+	if request.OAuth != nil {
+		rootBody.AppendNewline()
+		ti.appendIdentityPool(rootBody, request)
+	}
+
 	return string(f.Bytes())
 }
 
+// appendIdentityPool federates the cluster with the customer's OIDC identity
+// provider: clients whose tokens map to the identity pool can produce to and
+// consume from every topic, mirror topics included, and commit offsets for
+// every consumer group, so they connect with OAUTHBEARER instead of API keys.
+func (ti *TargetInfraHCLService) appendIdentityPool(rootBody *hclwrite.Body, request hclrequests.TargetClusterWizardRequest) {
+	rootBody.AppendBlock(confluent.GenerateIdentityProvider(
+		ti.ResourceNames.IdentityProvider,
+		fmt.Sprintf("kcp-oidc-%s", request.ClusterName),
+		"OIDC identity provider of the clients migrated to this cluster.",
+		modules.VarOAuthIssuer,
+		modules.VarOAuthJwksURI,
+		request.PreventDestroy,
+	))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(confluent.GenerateIdentityPool(
+		ti.ResourceNames.IdentityPool,
+		fmt.Sprintf("kcp-oauth-clients-%s", request.ClusterName),
+		"Clients migrated to this cluster, authenticating with OAuth.",
+		fmt.Sprintf("confluent_identity_provider.%s.id", ti.ResourceNames.IdentityProvider),
+		modules.VarOAuthIdentityClaim,
+		modules.VarOAuthPoolFilter,
+		request.PreventDestroy,
+	))
+	rootBody.AppendNewline()
+
+	principal := fmt.Sprintf("User:${confluent_identity_pool.%s.id}", ti.ResourceNames.IdentityPool)
+	clusterCrn := fmt.Sprintf("${confluent_kafka_cluster.%[1]s.rbac_crn}/kafka=${confluent_kafka_cluster.%[1]s.id}", ti.ResourceNames.Cluster)
+	bindings := []struct {
+		name, role, resource string
+	}{
+		{ti.ResourceNames.IdentityPoolTopicReadBinding, "DeveloperRead", "topic=*"},
+		{ti.ResourceNames.IdentityPoolTopicWriteBinding, "DeveloperWrite", "topic=*"},
+		{ti.ResourceNames.IdentityPoolGroupReadBinding, "DeveloperRead", "group=*"},
+	}
+	for _, binding := range bindings {
+		rootBody.AppendBlock(confluent.GenerateRoleBinding(
+			binding.name,
+			principal,
+			binding.role,
+			utils.TokensForStringTemplate(fmt.Sprintf("%s/%s", clusterCrn, binding.resource)),
+			request.PreventDestroy,
+		))
+		rootBody.AppendNewline()
+	}
+}
+
 func (ti *TargetInfraHCLService) generateConfluentCloudModuleVariablesTf(request hclrequests.TargetClusterWizardRequest) string {
 	return GenerateVariablesTf(modules.GetConfluentCloudVariableDefinitions(request))
 }
@@ -383,12 +464,14 @@ func (ti *TargetInfraHCLService) generateConfluentCloudModuleOutputsTf(request h
 		ClusterName:        ti.ResourceNames.Cluster,
 		ServiceAccountName: ti.ResourceNames.ServiceAccount,
 		KafkaAPIKeyName:    ti.ResourceNames.KafkaAPIKey,
+		IdentityProvider:   ti.ResourceNames.IdentityProvider,
+		IdentityPool:       ti.ResourceNames.IdentityPool,
 	})
 	return GenerateOutputsTf(outputs)
 }
 
-func (ti *TargetInfraHCLService) generatePrivateLinkModuleOutputsTf() string {
-	outputs := modules.GetPrivateLinkModuleOutputDefinitions(ti.ResourceNames.VpcEndpoint)
+func (ti *TargetInfraHCLService) generatePrivateLinkModuleOutputsTf(request hclrequests.TargetClusterWizardRequest) string {
+	outputs := modules.GetPrivateLinkModuleOutputDefinitions(request, ti.ResourceNames.VpcEndpoint, ti.ResourceNames.ResolverRule)
 	return GenerateOutputsTf(outputs)
 }
 
@@ -452,23 +535,38 @@ func (ti *TargetInfraHCLService) generateDedicatedPrivateLinkModuleMainTf(reques
 
 	// Route53: when using an existing zone, both the zone and its records already exist.
 	// Only generate the zone and record resources when creating from scratch.
+	route53ZoneIdRef := fmt.Sprintf("aws_route53_zone.%s.zone_id", ti.ResourceNames.Route53Zone)
 	if !request.UseExistingRoute53Zone {
-		rootBody.AppendBlock(aws.GenerateRoute53ZoneResource(
+		ti.appendRoute53ZoneAndRecord(rootBody, request, networkDnsDomainVarRef)
+
+		if request.ZonalDnsRecords {
+			rootBody.AppendBlock(aws.GenerateZonalAvailabilityZoneDataSource(
+				ti.ResourceNames.ZonalAvailabilityZones,
+				modules.VarNetworkZones,
+				modules.VarSubnetCidrRanges,
+			))
+			rootBody.AppendNewline()
+
+			rootBody.AppendBlock(aws.GenerateRoute53ZonalRecordResource(
+				ti.ResourceNames.Route53ZonalRecord,
+				route53ZoneIdRef,
+				fmt.Sprintf("data.aws_availability_zone.%s", ti.ResourceNames.ZonalAvailabilityZones),
+				fmt.Sprintf("aws_vpc_endpoint.%s.dns_entry[0].dns_name", ti.ResourceNames.VpcEndpoint),
+			))
+			rootBody.AppendNewline()
+		}
+	} else if len(request.DnsVpcIds) > 0 {
+		rootBody.AppendBlock(aws.GenerateRoute53ZoneDataSource(
 			ti.ResourceNames.Route53Zone,
 			modules.VarVpcID,
 			networkDnsDomainVarRef,
 		))
 		rootBody.AppendNewline()
-
-		rootBody.AppendBlock(aws.GenerateRoute53RecordResource(
-			ti.ResourceNames.Route53Record,
-			fmt.Sprintf("aws_route53_zone.%s.zone_id", ti.ResourceNames.Route53Zone),
-			"*",
-			fmt.Sprintf("aws_vpc_endpoint.%s.dns_entry[0].dns_name", ti.ResourceNames.VpcEndpoint),
-		))
-		rootBody.AppendNewline()
+		route53ZoneIdRef = "data." + route53ZoneIdRef
 	}
 
+	ti.appendPrivateLinkDnsSharing(rootBody, request, route53ZoneIdRef, networkDnsDomainVarRef)
+
 	return string(f.Bytes())
 }
 
@@ -522,11 +620,25 @@ func (ti *TargetInfraHCLService) generateEnterprisePrivateLinkModuleMainTf(reque
 	// No shared-zone conflicts — each enterprise cluster gets its own zone.
 	dnsDomainRef := fmt.Sprintf("confluent_access_point.%s.aws_ingress_private_link_endpoint[0].dns_domain", ti.ResourceNames.AccessPoint)
 
-	rootBody.AppendBlock(aws.GenerateRoute53ZoneResource(
+	ti.appendRoute53ZoneAndRecord(rootBody, request, dnsDomainRef)
+	ti.appendPrivateLinkDnsSharing(rootBody, request, fmt.Sprintf("aws_route53_zone.%s.zone_id", ti.ResourceNames.Route53Zone), dnsDomainRef)
+
+	return string(f.Bytes())
+}
+
+// appendRoute53ZoneAndRecord writes the private hosted zone for dnsDomainRef and its
+// wildcard record pointing at the VPC endpoint.
+func (ti *TargetInfraHCLService) appendRoute53ZoneAndRecord(rootBody *hclwrite.Body, request hclrequests.TargetClusterWizardRequest, dnsDomainRef string) {
+	route53ZoneBlock := aws.GenerateRoute53ZoneResource(
 		ti.ResourceNames.Route53Zone,
 		modules.VarVpcID,
 		dnsDomainRef,
-	))
+	)
+	// The further VPCs are associated by separate resources, which the inline vpc block must not undo.
+	if len(request.DnsVpcIds) > 0 {
+		aws.IgnoreRoute53ZoneVpcChanges(route53ZoneBlock)
+	}
+	rootBody.AppendBlock(route53ZoneBlock)
 	rootBody.AppendNewline()
 
 	rootBody.AppendBlock(aws.GenerateRoute53RecordResource(
@@ -536,8 +648,70 @@ func (ti *TargetInfraHCLService) generateEnterprisePrivateLinkModuleMainTf(reque
 		fmt.Sprintf("aws_vpc_endpoint.%s.dns_entry[0].dns_name", ti.ResourceNames.VpcEndpoint),
 	))
 	rootBody.AppendNewline()
+}
 
-	return string(f.Bytes())
+// appendPrivateLinkDnsSharing lets VPCs other than the private link VPC resolve the
+// cluster's DNS domain to the VPC endpoint. VPCs in the same account are associated
+// with the private hosted zone directly. VPCs in other accounts cannot be, so queries
+// for the domain are forwarded by a Route 53 Resolver rule, shared with their accounts
+// through AWS RAM, to an inbound resolver endpoint in the private link VPC.
+func (ti *TargetInfraHCLService) appendPrivateLinkDnsSharing(rootBody *hclwrite.Body, request hclrequests.TargetClusterWizardRequest, route53ZoneIdRef, dnsDomainRef string) {
+	if len(request.DnsVpcIds) > 0 {
+		rootBody.AppendBlock(aws.GenerateRoute53ZoneAssociationResource(
+			ti.ResourceNames.Route53ZoneAssociation,
+			route53ZoneIdRef,
+			modules.VarDnsVpcIDs,
+		))
+		rootBody.AppendNewline()
+	}
+
+	if len(request.DnsShareAccountIds) == 0 {
+		return
+	}
+
+	rootBody.AppendBlock(aws.GenerateResolverSecurityGroup(ti.ResourceNames.ResolverSecurityGroup, modules.VarVpcID, modules.VarSubnetCidrRanges))
+	rootBody.AppendNewline()
+
+	subnetRef := aws.GenerateSubnetResourceReference(ti.ResourceNames.SubnetName)
+	securityGroupIdRef := fmt.Sprintf("aws_security_group.%s.id", ti.ResourceNames.ResolverSecurityGroup)
+	rootBody.AppendBlock(aws.GenerateResolverEndpointResource(
+		ti.ResourceNames.ResolverInboundEndpoint,
+		"kcp-private-link-inbound",
+		"INBOUND",
+		securityGroupIdRef,
+		subnetRef,
+		len(request.SubnetCidrRanges),
+	))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateResolverEndpointResource(
+		ti.ResourceNames.ResolverOutboundEndpoint,
+		"kcp-private-link-outbound",
+		"OUTBOUND",
+		securityGroupIdRef,
+		subnetRef,
+		len(request.SubnetCidrRanges),
+	))
+	rootBody.AppendNewline()
+
+	rootBody.AppendBlock(aws.GenerateForwardResolverRuleResource(
+		ti.ResourceNames.ResolverRule,
+		"kcp-private-link-forward",
+		dnsDomainRef,
+		fmt.Sprintf("aws_route53_resolver_endpoint.%s", ti.ResourceNames.ResolverOutboundEndpoint),
+		fmt.Sprintf("aws_route53_resolver_endpoint.%s", ti.ResourceNames.ResolverInboundEndpoint),
+	))
+	rootBody.AppendNewline()
+
+	for _, block := range aws.GenerateRAMResourceShareResources(
+		ti.ResourceNames.ResolverRule,
+		"kcp-private-link-forward",
+		fmt.Sprintf("aws_route53_resolver_rule.%s.arn", ti.ResourceNames.ResolverRule),
+		modules.VarDnsShareAccountIDs,
+	) {
+		rootBody.AppendBlock(block)
+		rootBody.AppendNewline()
+	}
 }
 
 func (ti *TargetInfraHCLService) generatePrivateLinkModuleVariablesTf(request hclrequests.TargetClusterWizardRequest) string {
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	hclv2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/stretchr/testify/require"
 )
 
@@ -76,6 +78,70 @@ func TestTargetInfra_DedicatedPrivateLink(t *testing.T) {
 	validateTerraformProject(t, files)
 }
 
+func TestTargetInfra_DedicatedPrivateLink_DnsSharing(t *testing.T) {
+	t.Parallel()
+
+	service := &TargetInfraHCLService{ResourceNames: NewTerraformResourceNames(), DeploymentID: "testdeploy"}
+	request := hclrequests.TargetClusterWizardRequest{
+		AwsRegion:           "us-east-1",
+		NeedsEnvironment:    true,
+		EnvironmentName:     "production",
+		NeedsCluster:        true,
+		ClusterName:         "prod-cluster-pl",
+		ClusterType:         "dedicated",
+		ClusterAvailability: "MULTI_ZONE",
+		ClusterCku:          2,
+		NeedsPrivateLink:    true,
+		VpcId:               "vpc-0123456789abcdef0",
+		SubnetCidrRanges:    []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		ZonalDnsRecords:     true,
+		DnsVpcIds:           []string{"vpc-0aaaaaaaaaaaaaaa1", "vpc-0aaaaaaaaaaaaaaa2"},
+		DnsShareAccountIds:  []string{"111111111111"},
+	}
+
+	project := service.GenerateTerraformFiles(request)
+	mainTf := project.Modules[1].MainTf
+	require.Contains(t, mainTf, `resource "aws_route53_record" "cflt_zonal_route_entries"`)
+	require.Contains(t, mainTf, `ignore_changes = [vpc]`)
+	require.Contains(t, mainTf, `resource "aws_route53_zone_association" "cflt_private_link_zone_association"`)
+	require.Contains(t, mainTf, `direction          = "INBOUND"`)
+	require.Contains(t, mainTf, `subnet_id = aws_subnet.cflt_private_link_subnet[2].id`)
+	require.Contains(t, mainTf, `domain_name          = var.network_dns_domain`)
+	require.Contains(t, mainTf, `resource "aws_ram_principal_association" "cflt_private_link_forward"`)
+	require.Contains(t, project.InputsAutoTfvars, `dns_share_account_ids = ["111111111111"]`)
+	require.Contains(t, project.OutputsTf, `module.private_link.dns_resolver_rule_id`)
+
+	validateTerraformProject(t, projectToFiles(project))
+}
+
+func TestTargetInfra_DedicatedPrivateLink_ExistingRoute53Zone_DnsVpcs(t *testing.T) {
+	t.Parallel()
+
+	service := &TargetInfraHCLService{ResourceNames: NewTerraformResourceNames(), DeploymentID: "testdeploy"}
+	request := hclrequests.TargetClusterWizardRequest{
+		AwsRegion:              "us-east-1",
+		EnvironmentId:          "env-abc123",
+		NeedsCluster:           true,
+		ClusterName:            "prod-cluster-pl",
+		ClusterType:            "dedicated",
+		ClusterAvailability:    "SINGLE_ZONE",
+		ClusterCku:             1,
+		NeedsPrivateLink:       true,
+		UseExistingRoute53Zone: true,
+		VpcId:                  "vpc-0123456789abcdef0",
+		SubnetCidrRanges:       []string{"10.0.1.0/24"},
+		DnsVpcIds:              []string{"vpc-0aaaaaaaaaaaaaaa1"},
+	}
+
+	project := service.GenerateTerraformFiles(request)
+	mainTf := project.Modules[1].MainTf
+	require.Contains(t, mainTf, `data "aws_route53_zone" "cflt_private_link_zone"`)
+	require.Contains(t, mainTf, `zone_id = data.aws_route53_zone.cflt_private_link_zone.zone_id`)
+	require.NotContains(t, mainTf, `aws_route53_resolver`)
+
+	validateTerraformProject(t, projectToFiles(project))
+}
+
 func TestTargetInfra_EnterpriseTrailingHyphen(t *testing.T) {
 	t.Parallel()
 
@@ -285,3 +351,69 @@ func TestTargetInfra_MultiZone_MinimumCKUs(t *testing.T) {
 	files := projectToFiles(project)
 	validateTerraformProject(t, files)
 }
+
+func TestTargetInfra_S3Backend(t *testing.T) {
+	t.Parallel()
+
+	service := &TargetInfraHCLService{ResourceNames: NewTerraformResourceNames(), DeploymentID: "testdeploy"}
+	request := hclrequests.TargetClusterWizardRequest{
+		AwsRegion:        "us-east-1",
+		NeedsEnvironment: true,
+		EnvironmentName:  "production",
+		NeedsCluster:     true,
+		ClusterName:      "kafka-cluster",
+		ClusterType:      "dedicated",
+		VpcId:            "vpc-12345",
+		Backend: &hclrequests.TerraformBackend{
+			Type:          hclrequests.TerraformBackendS3,
+			Bucket:        "tf-state",
+			Key:           "kcp/target-infra/terraform.tfstate",
+			Region:        "eu-west-1",
+			DynamoDBTable: "tf-locks",
+		},
+	}
+
+	providers := service.GenerateTerraformFiles(request).ProvidersTf
+
+	_, diags := hclwrite.ParseConfig([]byte(providers), "providers.tf", hclv2.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Contains(t, providers, `backend "s3" {`)
+	require.Contains(t, providers, `bucket         = "tf-state"`)
+	require.Contains(t, providers, `key            = "kcp/target-infra/terraform.tfstate"`)
+	require.Contains(t, providers, `dynamodb_table = "tf-locks"`)
+	require.Contains(t, providers, `encrypt        = true`)
+
+	// The backend cannot be initialised offline, so validate without it.
+	request.Backend = nil
+	validateTerraformProject(t, projectToFiles(service.GenerateTerraformFiles(request)))
+}
+
+func TestTargetInfra_OAuth(t *testing.T) {
+	t.Parallel()
+
+	service := &TargetInfraHCLService{ResourceNames: NewTerraformResourceNames(), DeploymentID: "testdeploy"}
+	request := hclrequests.TargetClusterWizardRequest{
+		AwsRegion:        "us-east-1",
+		NeedsEnvironment: true,
+		EnvironmentName:  "production",
+		NeedsCluster:     true,
+		ClusterName:      "kafka-cluster",
+		ClusterType:      "enterprise",
+		OAuth: &hclrequests.OAuthConfig{
+			Issuer:  "https://login.example.com/oauth2/default",
+			JwksUri: "https://login.example.com/oauth2/default/v1/keys",
+			Filter:  `claims.aud == "confluent"`,
+		},
+	}
+
+	project := service.GenerateTerraformFiles(request)
+	mainTf := project.Modules[0].MainTf
+	require.Contains(t, mainTf, `resource "confluent_identity_provider" "oidc"`)
+	require.Contains(t, mainTf, `resource "confluent_identity_pool" "oauth-clients"`)
+	require.Contains(t, mainTf, `principal   = "User:${confluent_identity_pool.oauth-clients.id}"`)
+	require.Contains(t, mainTf, `crn_pattern = "${confluent_kafka_cluster.cluster.rbac_crn}/kafka=${confluent_kafka_cluster.cluster.id}/group=*"`)
+	require.Contains(t, project.InputsAutoTfvars, `oauth_identity_claim       = "claims.sub"`)
+	require.Contains(t, project.OutputsTf, `module.confluent_cloud.identity_pool_id`)
+
+	validateTerraformProject(t, projectToFiles(project))
+}
@@ -0,0 +1,133 @@
+package hcl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/utils"
+	hclv2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// Validation modes for ValidateProject.
+const (
+	// ValidateAuto parses the project and, when a terraform binary is on
+	// PATH, formats it with `terraform fmt`.
+	ValidateAuto = "auto"
+	// ValidateTerraform also runs `terraform init -backend=false` and
+	// `terraform validate`, which download the project's providers.
+	ValidateTerraform = "terraform"
+	// ValidateEmbedded only parses the project with the HCL parser kcp is
+	// built with.
+	ValidateEmbedded = "embedded"
+	// ValidateOff skips validation.
+	ValidateOff = "off"
+)
+
+// ValidationModes are the accepted ValidateProject modes.
+var ValidationModes = []string{ValidateAuto, ValidateTerraform, ValidateEmbedded, ValidateOff}
+
+// ValidateProject checks the Terraform project written to dir, so invalid
+// HCL fails generation instead of `terraform apply`. Every mode but
+// ValidateOff parses each .tf and .tfvars file first; see the Validate*
+// constants for what each mode adds.
+func ValidateProject(ctx context.Context, dir, mode string, run utils.CommandRunner) error {
+	if mode == ValidateOff {
+		return nil
+	}
+	if err := parseProject(dir); err != nil {
+		return err
+	}
+	if mode == ValidateEmbedded {
+		return nil
+	}
+
+	if output, err := run(ctx, dir, "terraform", "fmt", "-recursive", "-list=false", "-no-color"); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			if mode == ValidateAuto {
+				return nil
+			}
+			return fmt.Errorf("terraform binary not found on PATH: install Terraform or validate with the embedded HCL parser instead")
+		}
+		return terraformError("terraform fmt", output, err)
+	}
+	if mode != ValidateTerraform {
+		return nil
+	}
+
+	// The backend is skipped: validating needs the providers, not the state.
+	if output, err := run(ctx, dir, "terraform", "init", "-backend=false", "-input=false", "-no-color"); err != nil {
+		return terraformError("terraform init", output, err)
+	}
+	if output, err := run(ctx, dir, "terraform", "validate", "-no-color"); err != nil {
+		return terraformError("terraform validate", output, err)
+	}
+	return nil
+}
+
+// parseProject parses every .tf and .tfvars file under dir and reports all
+// syntax errors, with their file and position, in one error.
+func parseProject(dir string) error {
+	parser := hclparse.NewParser()
+	var diags hclv2.Diagnostics
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".tf" && ext != ".tfvars" {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			name = path
+		}
+		_, fileDiags := parser.ParseHCL(src, name)
+		diags = append(diags, fileDiags...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read the generated Terraform: %w", err)
+	}
+	if !diags.HasErrors() {
+		return nil
+	}
+
+	var lines []string
+	for _, diag := range diags {
+		if diag.Severity != hclv2.DiagError {
+			continue
+		}
+		line := diag.Summary
+		if diag.Detail != "" {
+			line += ": " + diag.Detail
+		}
+		if diag.Subject != nil {
+			line = fmt.Sprintf("%s:%d,%d: %s", diag.Subject.Filename, diag.Subject.Start.Line, diag.Subject.Start.Column, line)
+		}
+		lines = append(lines, "  "+line)
+	}
+	return fmt.Errorf("the generated Terraform is not valid HCL:\n%s", strings.Join(lines, "\n"))
+}
+
+func terraformError(command string, output []byte, err error) error {
+	if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+		return fmt.Errorf("%s failed: %w\n%s", command, err, trimmed)
+	}
+	return fmt.Errorf("%s failed: %w", command, err)
+}
@@ -0,0 +1,133 @@
+package hcl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/confluentinc/kcp/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTerraform(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+// recordingRunner records the terraform subcommands it is asked to run and
+// fails the one named in failOn.
+func recordingRunner(calls *[]string, failOn string, err error) utils.CommandRunner {
+	return func(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+		*calls = append(*calls, args[0])
+		if args[0] == failOn || failOn == "*" {
+			return []byte("Error: Unsupported argument\n\n  on main.tf line 3"), err
+		}
+		return nil, nil
+	}
+}
+
+func TestValidateProject_ReportsInvalidHCL(t *testing.T) {
+	dir := writeTerraform(t, map[string]string{
+		"main.tf":                 "resource \"aws_instance\" \"broker\" {\n  ami = \"ami-123\"\n}\n",
+		"jump_cluster/main.tf":    "resource \"aws_instance\" \"broker\" {\n  ami = \n}\n",
+		"jump_cluster/README.txt": "not { hcl",
+	})
+	var calls []string
+
+	err := ValidateProject(context.Background(), dir, ValidateAuto, recordingRunner(&calls, "", nil))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the generated Terraform is not valid HCL")
+	assert.Contains(t, err.Error(), filepath.Join("jump_cluster", "main.tf")+":2,")
+	assert.NotContains(t, err.Error(), "README", "only .tf and .tfvars files are parsed")
+	assert.Empty(t, calls, "terraform is not run on a project that doesn't parse")
+}
+
+func TestValidateProject_Modes(t *testing.T) {
+	valid := map[string]string{"main.tf": "variable \"region\" {\n  type = string\n}\n", "inputs.auto.tfvars": "region = \"us-east-1\"\n"}
+	notFound := &exec.Error{Name: "terraform", Err: exec.ErrNotFound}
+
+	tests := []struct {
+		name      string
+		mode      string
+		failOn    string
+		runErr    error
+		wantCalls []string
+		wantErr   string
+	}{
+		{"off", ValidateOff, "", nil, nil, ""},
+		{"embedded", ValidateEmbedded, "", nil, nil, ""},
+		{"auto formats", ValidateAuto, "", nil, []string{"fmt"}, ""},
+		{"auto without terraform", ValidateAuto, "*", notFound, []string{"fmt"}, ""},
+		{"terraform", ValidateTerraform, "", nil, []string{"fmt", "init", "validate"}, ""},
+		{"terraform without terraform", ValidateTerraform, "*", notFound, []string{"fmt"}, "terraform binary not found on PATH"},
+		{"terraform validate fails", ValidateTerraform, "validate", errors.New("exit status 1"), []string{"fmt", "init", "validate"}, "terraform validate failed: exit status 1\nError: Unsupported argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			err := ValidateProject(context.Background(), writeTerraform(t, valid), tt.mode, recordingRunner(&calls, tt.failOn, tt.runErr))
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateProject_GeneratedMigrationInfraParses(t *testing.T) {
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	project := service.GenerateTerraformModules(hclrequests.MigrationWizardRequest{
+		HasPublicEndpoints:      true,
+		SourceClusterId:         "msk-cluster-123",
+		SourceRegion:            "us-east-1",
+		TargetEnvironmentId:     "env-abc123",
+		TargetClusterId:         "lkc-xyz789",
+		TargetRestEndpoint:      "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		TargetBootstrapEndpoint: "pkc-abc123.us-east-1.aws.confluent.cloud:9092",
+		ClusterLinkName:         "msk-to-cc-link",
+	})
+	dir := t.TempDir()
+	require.NoError(t, WriteTerraformProject(dir, project))
+
+	assert.NoError(t, ValidateProject(context.Background(), dir, ValidateEmbedded, nil))
+}
+
+func TestValidateProject_GeneratedMultipleDestinationsParses(t *testing.T) {
+	service := &MigrationInfraHCLService{SSHKeySuffix: "test1", DeploymentID: "testdeploy"}
+	project := service.GenerateTerraformModules(hclrequests.MigrationWizardRequest{
+		HasPublicEndpoints:                      true,
+		SourceClusterId:                         "msk-cluster-123",
+		SourceRegion:                            "us-east-1",
+		TargetEnvironmentId:                     "env-abc123",
+		TargetClusterId:                         "lkc-xyz789",
+		TargetRestEndpoint:                      "https://pkc-abc123.us-east-1.aws.confluent.cloud:443",
+		ClusterLinkName:                         "msk-to-cc-link",
+		ClusterLinkAutoCreateMirrorTopicFilters: []string{"orders.*"},
+		AdditionalDestinations: []hclrequests.ClusterLinkDestination{{
+			Name:               "payments",
+			TargetClusterId:    "lkc-pay123",
+			TargetRestEndpoint: "https://pkc-pay123.us-east-1.aws.confluent.cloud:443",
+			ClusterLinkName:    "msk-to-payments",
+			TopicFilters:       []string{"payments.*"},
+		}},
+	})
+	dir := t.TempDir()
+	require.NoError(t, WriteTerraformProject(dir, project))
+
+	assert.NoError(t, ValidateProject(context.Background(), dir, ValidateEmbedded, nil))
+	assert.Regexp(t, `target_cluster_id_payments\s+= "lkc-pay123"`, project.InputsAutoTfvars)
+}
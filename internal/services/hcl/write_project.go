@@ -3,114 +3,98 @@ package hcl
 import (
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/managedfiles"
+	"github.com/confluentinc/kcp/internal/summary"
 )
 
 // WriteMigrateConnectorsInfraFiles writes the shared Terraform infrastructure
 // files (providers.tf, variables.tf) needed by migrate-connectors output.
 func WriteMigrateConnectorsInfraFiles(outputDir string) error {
 	svc := NewMigrationScriptsHCLService()
-	if err := os.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(svc.GenerateProvidersTf()), 0644); err != nil {
+	if err := summary.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(svc.GenerateProvidersTf()), 0644); err != nil {
 		return fmt.Errorf("failed to write providers.tf: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(svc.GenerateMigrateConnectorsVariablesTf()), 0644); err != nil {
+	if err := summary.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(svc.GenerateMigrateConnectorsVariablesTf()), 0644); err != nil {
 		return fmt.Errorf("failed to write variables.tf: %w", err)
 	}
 	return nil
 }
 
-// WriteTerraformProject writes a MigrationInfraTerraformProject to disk at the given output directory.
+// WriteTerraformProject writes a MigrationInfraTerraformProject to disk at the
+// given output directory, with a managedfiles manifest so it can be refreshed
+// later.
 func WriteTerraformProject(outputDir string, project hcltypes.MigrationInfraTerraformProject) error {
-	if project.MainTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(project.MainTf), 0644); err != nil {
-			return fmt.Errorf("failed to write main.tf: %w", err)
-		}
-		slog.Debug("wrote root main.tf")
+	files, err := projectFiles(project)
+	if err != nil {
+		return err
 	}
-
-	if project.ProvidersTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(project.ProvidersTf), 0644); err != nil {
-			return fmt.Errorf("failed to write providers.tf: %w", err)
-		}
-		slog.Debug("wrote root providers.tf")
+	if err := managedfiles.Write(outputDir, files); err != nil {
+		return err
 	}
+	slog.Debug("wrote Terraform project", "files", len(files))
+	return nil
+}
 
-	if project.VariablesTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(project.VariablesTf), 0644); err != nil {
-			return fmt.Errorf("failed to write variables.tf: %w", err)
-		}
-		slog.Debug("wrote root variables.tf")
+// RefreshTerraformProject regenerates a project written by
+// WriteTerraformProject in place: files kcp wrote and the user did not edit
+// are rewritten, edited files are kept with the new content written beside
+// them, and files the user added are not touched. With dryRun nothing is
+// written. It returns the change to each file.
+func RefreshTerraformProject(outputDir string, project hcltypes.MigrationInfraTerraformProject, dryRun bool) ([]managedfiles.Change, error) {
+	files, err := projectFiles(project)
+	if err != nil {
+		return nil, err
 	}
-
-	if project.OutputsTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "outputs.tf"), []byte(project.OutputsTf), 0644); err != nil {
-			return fmt.Errorf("failed to write outputs.tf: %w", err)
-		}
-		slog.Debug("wrote root outputs.tf")
+	changes, err := managedfiles.Plan(outputDir, files)
+	if err != nil {
+		return nil, err
 	}
-
-	if project.ReadmeMd != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "README.md"), []byte(project.ReadmeMd), 0644); err != nil {
-			return fmt.Errorf("failed to write README.md: %w", err)
-		}
-		slog.Debug("wrote README.md")
+	if dryRun {
+		return changes, nil
 	}
+	if err := managedfiles.Apply(outputDir, files, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
 
-	if project.InputsAutoTfvars != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(project.InputsAutoTfvars), 0644); err != nil {
-			return fmt.Errorf("failed to write inputs.auto.tfvars: %w", err)
+// projectFiles lays a project out as file contents keyed by path relative to
+// the project directory. Empty files are not written.
+func projectFiles(project hcltypes.MigrationInfraTerraformProject) (map[string]string, error) {
+	files := map[string]string{}
+	add := func(path, content string) {
+		if content != "" {
+			files[path] = content
 		}
-		slog.Debug("wrote root inputs.auto.tfvars")
 	}
 
+	add("main.tf", project.MainTf)
+	add("providers.tf", project.ProvidersTf)
+	add("variables.tf", project.VariablesTf)
+	add("outputs.tf", project.OutputsTf)
+	add("README.md", project.ReadmeMd)
+	add("inputs.auto.tfvars", project.InputsAutoTfvars)
+
 	for _, module := range project.Modules {
 		if strings.Contains(module.Name, "..") || filepath.IsAbs(module.Name) {
-			return fmt.Errorf("invalid module name: %s", module.Name)
-		}
-		moduleDir := filepath.Join(outputDir, module.Name)
-		if err := os.MkdirAll(moduleDir, 0755); err != nil {
-			return fmt.Errorf("failed to create module directory %s: %w", module.Name, err)
-		}
-
-		if module.MainTf != "" {
-			if err := os.WriteFile(filepath.Join(moduleDir, "main.tf"), []byte(module.MainTf), 0644); err != nil {
-				return fmt.Errorf("failed to write module %s main.tf: %w", module.Name, err)
-			}
-		}
-
-		if module.VariablesTf != "" {
-			if err := os.WriteFile(filepath.Join(moduleDir, "variables.tf"), []byte(module.VariablesTf), 0644); err != nil {
-				return fmt.Errorf("failed to write module %s variables.tf: %w", module.Name, err)
-			}
-		}
-
-		if module.OutputsTf != "" {
-			if err := os.WriteFile(filepath.Join(moduleDir, "outputs.tf"), []byte(module.OutputsTf), 0644); err != nil {
-				return fmt.Errorf("failed to write module %s outputs.tf: %w", module.Name, err)
-			}
-		}
-
-		if module.VersionsTf != "" {
-			if err := os.WriteFile(filepath.Join(moduleDir, "versions.tf"), []byte(module.VersionsTf), 0644); err != nil {
-				return fmt.Errorf("failed to write module %s versions.tf: %w", module.Name, err)
-			}
+			return nil, fmt.Errorf("invalid module name: %s", module.Name)
 		}
+		add(module.Name+"/main.tf", module.MainTf)
+		add(module.Name+"/variables.tf", module.VariablesTf)
+		add(module.Name+"/outputs.tf", module.OutputsTf)
+		add(module.Name+"/versions.tf", module.VersionsTf)
 
 		for filename, content := range module.AdditionalFiles {
 			if strings.Contains(filename, "..") || filepath.IsAbs(filename) {
-				return fmt.Errorf("invalid filename in module %s: %s", module.Name, filename)
-			}
-			if err := os.WriteFile(filepath.Join(moduleDir, filename), []byte(content), 0644); err != nil {
-				return fmt.Errorf("failed to write module %s file %s: %w", module.Name, filename, err)
+				return nil, fmt.Errorf("invalid filename in module %s: %s", module.Name, filename)
 			}
+			add(module.Name+"/"+filename, content)
 		}
-
-		slog.Debug("wrote module", "module", module.Name)
 	}
 
-	return nil
+	return files, nil
 }
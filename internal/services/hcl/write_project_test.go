@@ -0,0 +1,49 @@
+package hcl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/services/managedfiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTerraformProject(t *testing.T) {
+	project := hcltypes.MigrationInfraTerraformProject{
+		MainTf:      "module \"cluster_link\" {}\n",
+		VariablesTf: "variable \"a\" {}\n",
+		Modules: []hcltypes.MigrationInfraTerraformModule{{
+			Name:   "cluster_link",
+			MainTf: "resource \"confluent_cluster_link\" \"link\" {}\n",
+		}},
+	}
+	dir := t.TempDir()
+	require.NoError(t, WriteTerraformProject(dir, project))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "variables.tf"), []byte("variable \"a\" { default = 1 }\n"), 0644))
+
+	project.VariablesTf = "variable \"a\" {}\nvariable \"b\" {}\n"
+	project.Modules[0].MainTf = "resource \"confluent_cluster_link\" \"link\" {\n  link_name = \"x\"\n}\n"
+
+	changes, err := RefreshTerraformProject(dir, project, true)
+	require.NoError(t, err)
+	got := map[string]managedfiles.Action{}
+	for _, c := range changes {
+		got[c.Path] = c.Action
+	}
+	assert.Equal(t, map[string]managedfiles.Action{
+		"main.tf":              managedfiles.ActionUnchanged,
+		"variables.tf":         managedfiles.ActionKeepEdited,
+		"cluster_link/main.tf": managedfiles.ActionUpdate,
+	}, got)
+	assert.NoFileExists(t, filepath.Join(dir, "variables.tf"+managedfiles.NewFileSuffix), "a dry run writes nothing")
+
+	_, err = RefreshTerraformProject(dir, project, false)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "cluster_link", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, project.Modules[0].MainTf, string(data))
+	assert.FileExists(t, filepath.Join(dir, "variables.tf"+managedfiles.NewFileSuffix))
+}
@@ -0,0 +1,318 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+const kafkaClusterActionPrefix = "kafka-cluster:"
+
+// Resource types MSK scopes under a cluster, e.g.
+// arn:aws:kafka:us-east-1:123456789012:topic/my-cluster/<uuid>/orders.
+var clusterScopedResourceTypes = map[string]bool{
+	"topic":            true,
+	"group":            true,
+	"transactional-id": true,
+}
+
+// KafkaAccessAPI is the subset of the IAM client used to map kafka-cluster
+// access. *iam.Client satisfies it.
+type KafkaAccessAPI interface {
+	iam.GetAccountAuthorizationDetailsAPIClient
+	SimulatePrincipalPolicy(ctx context.Context, params *iam.SimulatePrincipalPolicyInput, optFns ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error)
+}
+
+// KafkaAccessService maps which IAM principals are granted kafka-cluster
+// actions on an MSK cluster. For clusters using SASL/IAM this is the access
+// model that ACLs provide elsewhere, so it is what a migration has to
+// reproduce as Confluent Cloud ACLs or role bindings.
+type KafkaAccessService struct {
+	client     KafkaAccessAPI
+	principals []principalPolicies
+	loaded     bool
+}
+
+type principalPolicies struct {
+	arn      string
+	name     string
+	kind     string
+	policies []namedPolicy
+}
+
+type namedPolicy struct {
+	name     string
+	arn      string
+	document map[string]any
+}
+
+func NewKafkaAccessService(client KafkaAccessAPI) *KafkaAccessService {
+	return &KafkaAccessService{client: client}
+}
+
+// GetClusterAccess returns every user, role and group whose policies contain
+// an Allow statement with kafka-cluster actions on clusterArn, or on the
+// topics, groups and transactional IDs under it. Statements that only grant
+// "*" or use NotAction/NotResource are not attributed. The account's
+// authorization details are fetched on the first call and reused for later
+// clusters.
+func (s *KafkaAccessService) GetClusterAccess(ctx context.Context, clusterArn string) ([]types.IAMPrincipalAccess, error) {
+	if !s.loaded {
+		principals, err := s.loadAccountPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.principals = principals
+		s.loaded = true
+	}
+
+	var access []types.IAMPrincipalAccess
+	for _, principal := range s.principals {
+		var permissions []types.IAMKafkaPermission
+		for _, policy := range principal.policies {
+			permissions = append(permissions, clusterPermissions(policy, clusterArn)...)
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+
+		access = append(access, types.IAMPrincipalAccess{
+			PrincipalArn:  principal.arn,
+			PrincipalName: principal.name,
+			PrincipalType: principal.kind,
+			CanConnect:    s.simulateConnect(ctx, principal.arn, clusterArn),
+			Permissions:   permissions,
+		})
+	}
+	return access, nil
+}
+
+func (s *KafkaAccessService) loadAccountPolicies(ctx context.Context) ([]principalPolicies, error) {
+	paginator := iam.NewGetAccountAuthorizationDetailsPaginator(s.client, &iam.GetAccountAuthorizationDetailsInput{
+		Filter: []iamtypes.EntityType{
+			iamtypes.EntityTypeUser,
+			iamtypes.EntityTypeRole,
+			iamtypes.EntityTypeGroup,
+			iamtypes.EntityTypeLocalManagedPolicy,
+			iamtypes.EntityTypeAWSManagedPolicy,
+		},
+	})
+
+	var users []iamtypes.UserDetail
+	var roles []iamtypes.RoleDetail
+	var groups []iamtypes.GroupDetail
+	managed := map[string]map[string]any{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account authorization details: %w", err)
+		}
+		users = append(users, page.UserDetailList...)
+		roles = append(roles, page.RoleDetailList...)
+		groups = append(groups, page.GroupDetailList...)
+		for _, policy := range page.Policies {
+			for _, version := range policy.PolicyVersionList {
+				if !version.IsDefaultVersion {
+					continue
+				}
+				document, err := parsePolicyDocument(aws.ToString(version.Document))
+				if err != nil {
+					slog.Warn("⚠️ skipping unparseable managed policy", "policyArn", aws.ToString(policy.Arn), "error", err)
+					continue
+				}
+				managed[aws.ToString(policy.Arn)] = document
+			}
+		}
+	}
+
+	var principals []principalPolicies
+	for _, user := range users {
+		principals = append(principals, newPrincipalPolicies(aws.ToString(user.Arn), aws.ToString(user.UserName), "user", user.UserPolicyList, user.AttachedManagedPolicies, managed))
+	}
+	for _, role := range roles {
+		principals = append(principals, newPrincipalPolicies(aws.ToString(role.Arn), aws.ToString(role.RoleName), "role", role.RolePolicyList, role.AttachedManagedPolicies, managed))
+	}
+	for _, group := range groups {
+		principals = append(principals, newPrincipalPolicies(aws.ToString(group.Arn), aws.ToString(group.GroupName), "group", group.GroupPolicyList, group.AttachedManagedPolicies, managed))
+	}
+	sort.Slice(principals, func(i, j int) bool { return principals[i].arn < principals[j].arn })
+
+	return principals, nil
+}
+
+func newPrincipalPolicies(arn, name, kind string, inline []iamtypes.PolicyDetail, attached []iamtypes.AttachedPolicy, managed map[string]map[string]any) principalPolicies {
+	principal := principalPolicies{arn: arn, name: name, kind: kind}
+	for _, policy := range inline {
+		document, err := parsePolicyDocument(aws.ToString(policy.PolicyDocument))
+		if err != nil {
+			slog.Warn("⚠️ skipping unparseable inline policy", "principal", arn, "policy", aws.ToString(policy.PolicyName), "error", err)
+			continue
+		}
+		principal.policies = append(principal.policies, namedPolicy{name: aws.ToString(policy.PolicyName), document: document})
+	}
+	for _, policy := range attached {
+		document, ok := managed[aws.ToString(policy.PolicyArn)]
+		if !ok {
+			continue
+		}
+		principal.policies = append(principal.policies, namedPolicy{
+			name:     aws.ToString(policy.PolicyName),
+			arn:      aws.ToString(policy.PolicyArn),
+			document: document,
+		})
+	}
+	return principal
+}
+
+// simulateConnect asks the IAM policy simulator whether principalArn may call
+// kafka-cluster:Connect on clusterArn. Nil means the simulation failed, for
+// example because iam:SimulatePrincipalPolicy is not granted.
+func (s *KafkaAccessService) simulateConnect(ctx context.Context, principalArn, clusterArn string) *bool {
+	output, err := s.client.SimulatePrincipalPolicy(ctx, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalArn),
+		ActionNames:     []string{kafkaClusterActionPrefix + "Connect"},
+		ResourceArns:    []string{clusterArn},
+	})
+	if err != nil {
+		slog.Debug("failed to simulate kafka-cluster:Connect", "principal", principalArn, "error", err)
+		return nil
+	}
+	if len(output.EvaluationResults) == 0 {
+		return nil
+	}
+	return aws.Bool(output.EvaluationResults[0].EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed)
+}
+
+// clusterPermissions returns one permission per Allow statement in policy
+// that grants kafka-cluster actions on resources covering clusterArn.
+func clusterPermissions(policy namedPolicy, clusterArn string) []types.IAMKafkaPermission {
+	var permissions []types.IAMKafkaPermission
+	for _, statement := range policyStatements(policy.document) {
+		if effect, _ := statement["Effect"].(string); effect != "Allow" {
+			continue
+		}
+		if _, ok := statement["NotAction"]; ok {
+			continue
+		}
+		if _, ok := statement["NotResource"]; ok {
+			continue
+		}
+
+		var actions []string
+		for _, action := range stringOrList(statement["Action"]) {
+			if strings.HasPrefix(strings.ToLower(action), kafkaClusterActionPrefix) {
+				actions = append(actions, action)
+			}
+		}
+		if len(actions) == 0 {
+			continue
+		}
+
+		var resources []string
+		for _, resource := range stringOrList(statement["Resource"]) {
+			if resourceCoversCluster(resource, clusterArn) {
+				resources = append(resources, resource)
+			}
+		}
+		if len(resources) == 0 {
+			continue
+		}
+
+		permissions = append(permissions, types.IAMKafkaPermission{
+			Policy:    policy.name,
+			PolicyArn: policy.arn,
+			Actions:   actions,
+			Resources: resources,
+		})
+	}
+	return permissions
+}
+
+// policyStatements returns the Statement element of a policy document, which
+// may be a single object or a list.
+func policyStatements(document map[string]any) []map[string]any {
+	switch statement := document["Statement"].(type) {
+	case map[string]any:
+		return []map[string]any{statement}
+	case []any:
+		statements := make([]map[string]any, 0, len(statement))
+		for _, s := range statement {
+			if m, ok := s.(map[string]any); ok {
+				statements = append(statements, m)
+			}
+		}
+		return statements
+	}
+	return nil
+}
+
+func stringOrList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// resourceCoversCluster reports whether a statement resource applies to
+// clusterArn, either as a cluster ARN pattern or as a topic, group or
+// transactional-id ARN pattern whose cluster name and UUID match it.
+func resourceCoversCluster(resource, clusterArn string) bool {
+	if wildcardMatch(resource, clusterArn) {
+		return true
+	}
+
+	parts := strings.SplitN(resource, ":", 6)
+	if len(parts) != 6 {
+		return false
+	}
+	segments := strings.SplitN(parts[5], "/", 4)
+	if len(segments) < 2 || !clusterScopedResourceTypes[segments[0]] {
+		return false
+	}
+	clusterSegments := segments[1:min(len(segments), 3)]
+	clusterPattern := strings.Join(parts[:5], ":") + ":cluster/" + strings.Join(clusterSegments, "/")
+	return wildcardMatch(clusterPattern, clusterArn)
+}
+
+// wildcardMatch matches value against an IAM resource pattern, where '*'
+// matches any run of characters (including '/') and '?' matches one.
+func wildcardMatch(pattern, value string) bool {
+	p, v := 0, 0
+	star, mark := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, v
+			p++
+		case star >= 0:
+			p = star + 1
+			mark++
+			v = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,152 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKafkaClusterArn = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/0a1b2c3d-4e5f-6789-abcd-ef0123456789-2"
+
+type stubKafkaAccessAPI struct {
+	pages      []*iam.GetAccountAuthorizationDetailsOutput
+	calls      int
+	simulateFn func(principalArn string) (*iam.SimulatePrincipalPolicyOutput, error)
+}
+
+func (s *stubKafkaAccessAPI) GetAccountAuthorizationDetails(_ context.Context, _ *iam.GetAccountAuthorizationDetailsInput, _ ...func(*iam.Options)) (*iam.GetAccountAuthorizationDetailsOutput, error) {
+	page := s.pages[s.calls]
+	s.calls++
+	return page, nil
+}
+
+func (s *stubKafkaAccessAPI) SimulatePrincipalPolicy(_ context.Context, params *iam.SimulatePrincipalPolicyInput, _ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+	if s.simulateFn != nil {
+		return s.simulateFn(aws.ToString(params.PolicySourceArn))
+	}
+	return &iam.SimulatePrincipalPolicyOutput{}, nil
+}
+
+func encodedPolicy(document string) *string {
+	return aws.String(url.QueryEscape(document))
+}
+
+func TestGetClusterAccess(t *testing.T) {
+	producerPolicyArn := "arn:aws:iam::123456789012:policy/orders-producer"
+	api := &stubKafkaAccessAPI{
+		pages: []*iam.GetAccountAuthorizationDetailsOutput{
+			{
+				IsTruncated: true,
+				Marker:      aws.String("page-2"),
+				RoleDetailList: []iamtypes.RoleDetail{{
+					Arn:      aws.String("arn:aws:iam::123456789012:role/orders-producer"),
+					RoleName: aws.String("orders-producer"),
+					AttachedManagedPolicies: []iamtypes.AttachedPolicy{
+						{PolicyArn: aws.String(producerPolicyArn), PolicyName: aws.String("orders-producer")},
+					},
+				}},
+				UserDetailList: []iamtypes.UserDetail{{
+					Arn:      aws.String("arn:aws:iam::123456789012:user/admin"),
+					UserName: aws.String("admin"),
+					UserPolicyList: []iamtypes.PolicyDetail{{
+						PolicyName:     aws.String("everything"),
+						PolicyDocument: encodedPolicy(`{"Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`),
+					}},
+				}},
+			},
+			{
+				GroupDetailList: []iamtypes.GroupDetail{{
+					Arn:       aws.String("arn:aws:iam::123456789012:group/analytics"),
+					GroupName: aws.String("analytics"),
+					GroupPolicyList: []iamtypes.PolicyDetail{{
+						PolicyName: aws.String("read-all-clusters"),
+						PolicyDocument: encodedPolicy(`{"Statement":[
+							{"Effect":"Allow","Action":["kafka-cluster:Connect","kafka-cluster:DescribeCluster"],"Resource":"arn:aws:kafka:us-east-1:123456789012:cluster/*"},
+							{"Effect":"Allow","Action":"kafka-cluster:ReadData","Resource":["arn:aws:kafka:us-east-1:123456789012:topic/payments/*","arn:aws:kafka:*:123456789012:topic/*"]},
+							{"Effect":"Deny","Action":"kafka-cluster:AlterCluster","Resource":"*"}
+						]}`),
+					}},
+				}},
+				RoleDetailList: []iamtypes.RoleDetail{{
+					Arn:      aws.String("arn:aws:iam::123456789012:role/payments-consumer"),
+					RoleName: aws.String("payments-consumer"),
+					RolePolicyList: []iamtypes.PolicyDetail{{
+						PolicyName:     aws.String("payments"),
+						PolicyDocument: encodedPolicy(`{"Statement":{"Effect":"Allow","Action":"kafka-cluster:*","Resource":"arn:aws:kafka:us-east-1:123456789012:cluster/payments/*"}}`),
+					}},
+				}},
+				Policies: []iamtypes.ManagedPolicyDetail{{
+					Arn: aws.String(producerPolicyArn),
+					PolicyVersionList: []iamtypes.PolicyVersion{
+						{IsDefaultVersion: false, Document: encodedPolicy(`{"Statement":{"Effect":"Allow","Action":"kafka-cluster:*","Resource":"*"}}`)},
+						{IsDefaultVersion: true, Document: encodedPolicy(`{"Statement":{"Effect":"Allow","Action":["kafka-cluster:Connect","kafka-cluster:WriteData","s3:GetObject"],"Resource":["arn:aws:kafka:us-east-1:123456789012:cluster/orders/*","arn:aws:kafka:us-east-1:123456789012:topic/orders/*/orders-*"]}}`)},
+					},
+				}},
+			},
+		},
+		simulateFn: func(principalArn string) (*iam.SimulatePrincipalPolicyOutput, error) {
+			if principalArn == "arn:aws:iam::123456789012:group/analytics" {
+				return nil, errors.New("AccessDenied")
+			}
+			return &iam.SimulatePrincipalPolicyOutput{EvaluationResults: []iamtypes.EvaluationResult{
+				{EvalDecision: iamtypes.PolicyEvaluationDecisionTypeAllowed},
+			}}, nil
+		},
+	}
+
+	service := NewKafkaAccessService(api)
+	access, err := service.GetClusterAccess(context.Background(), testKafkaClusterArn)
+	require.NoError(t, err)
+
+	require.Len(t, access, 2, "the admin user only has Action \"*\" and payments-consumer is scoped to another cluster")
+
+	analytics := access[0]
+	assert.Equal(t, "analytics", analytics.PrincipalName)
+	assert.Equal(t, "group", analytics.PrincipalType)
+	assert.Nil(t, analytics.CanConnect, "a failed simulation leaves the verdict unknown")
+	require.Len(t, analytics.Permissions, 2)
+	assert.Equal(t, []string{"kafka-cluster:Connect", "kafka-cluster:DescribeCluster"}, analytics.Permissions[0].Actions)
+	assert.Equal(t, []string{"arn:aws:kafka:*:123456789012:topic/*"}, analytics.Permissions[1].Resources)
+
+	producer := access[1]
+	assert.Equal(t, "role", producer.PrincipalType)
+	require.NotNil(t, producer.CanConnect)
+	assert.True(t, *producer.CanConnect)
+	require.Len(t, producer.Permissions, 1)
+	assert.Equal(t, producerPolicyArn, producer.Permissions[0].PolicyArn)
+	assert.Equal(t, []string{"kafka-cluster:Connect", "kafka-cluster:WriteData"}, producer.Permissions[0].Actions)
+	assert.Len(t, producer.Permissions[0].Resources, 2)
+
+	_, err = service.GetClusterAccess(context.Background(), testKafkaClusterArn)
+	require.NoError(t, err)
+	assert.Equal(t, 2, api.calls, "account authorization details are fetched once")
+}
+
+func TestResourceCoversCluster(t *testing.T) {
+	tests := []struct {
+		resource string
+		want     bool
+	}{
+		{"*", true},
+		{testKafkaClusterArn, true},
+		{"arn:aws:kafka:us-east-1:123456789012:cluster/orders/*", true},
+		{"arn:aws:kafka:*:*:cluster/*", true},
+		{"arn:aws:kafka:us-east-1:123456789012:topic/orders/*", true},
+		{"arn:aws:kafka:us-east-1:123456789012:group/orders/0a1b2c3d-4e5f-6789-abcd-ef0123456789-2/billing", true},
+		{"arn:aws:kafka:us-east-1:123456789012:transactional-id/*/*/tx-*", true},
+		{"arn:aws:kafka:us-east-1:123456789012:cluster/payments/*", false},
+		{"arn:aws:kafka:eu-west-1:123456789012:topic/*", false},
+		{"arn:aws:kafka:us-east-1:123456789012:topic/ord", false},
+		{"arn:aws:s3:::orders/*", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, resourceCoversCluster(tt.resource, testKafkaClusterArn), tt.resource)
+	}
+}
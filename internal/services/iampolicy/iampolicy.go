@@ -6,6 +6,7 @@ package iampolicy
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 )
@@ -100,6 +101,11 @@ type Statement struct {
 	// "Resource": "*". If a single element, renders as a bare string. If
 	// two or more, renders as a JSON array in the given order.
 	Resources []string
+
+	// Condition is rendered verbatim as the statement's Condition block,
+	// operator → condition key → values (e.g. StringEquals →
+	// aws:RequestedRegion → [us-east-1]). Omitted when empty.
+	Condition map[string]map[string][]string
 }
 
 // RenderSingle renders the body of a cobra aws_iam_permissions annotation
@@ -133,20 +139,28 @@ func RenderStatements(intro string, statements []Statement) string {
 }
 
 // policyBlock renders a fenced JSON code block containing a policy with
-// the given statements. Field ordering (Version→Statement, then
-// Sid→Effect→Action→Resource) matches AWS documentation conventions and
-// the hand-written policies this helper is replacing.
+// the given statements.
+func policyBlock(statements []Statement) string {
+	return "```json\n" + PolicyJSON(statements) + "\n```\n"
+}
+
+// PolicyJSON renders an IAM policy document with the given Allow statements
+// as indented JSON, without a trailing newline. Field ordering
+// (Version→Statement, then Sid→Effect→Action→Resource→Condition) matches AWS
+// documentation conventions and the hand-written policies this helper is
+// replacing.
 //
 // Uses a json.Encoder with SetEscapeHTML(false) because placeholder ARNs
 // like "arn:aws:kafka:<AWS REGION>:..." appear in Resource fields and
 // must render as literal `<` / `>` (not `<` / `>`) so operators
 // can copy-paste the JSON block.
-func policyBlock(statements []Statement) string {
+func PolicyJSON(statements []Statement) string {
 	type jsonStatement struct {
-		Sid      string   `json:"Sid,omitempty"`
-		Effect   string   `json:"Effect"`
-		Action   []string `json:"Action"`
-		Resource any      `json:"Resource"`
+		Sid       string                         `json:"Sid,omitempty"`
+		Effect    string                         `json:"Effect"`
+		Action    []string                       `json:"Action"`
+		Resource  any                            `json:"Resource"`
+		Condition map[string]map[string][]string `json:"Condition,omitempty"`
 	}
 	type policy struct {
 		Version   string          `json:"Version"`
@@ -155,10 +169,11 @@ func policyBlock(statements []Statement) string {
 	js := make([]jsonStatement, 0, len(statements))
 	for _, s := range statements {
 		js = append(js, jsonStatement{
-			Sid:      s.Sid,
-			Effect:   "Allow",
-			Action:   sortedUnique(s.Actions),
-			Resource: resourceField(s.Resources),
+			Sid:       s.Sid,
+			Effect:    "Allow",
+			Action:    sortedUnique(s.Actions),
+			Resource:  resourceField(s.Resources),
+			Condition: s.Condition,
 		})
 	}
 
@@ -174,10 +189,10 @@ func policyBlock(statements []Statement) string {
 		// programming bug so it surfaces in tests.
 		panic("iampolicy: marshal policy: " + err.Error())
 	}
-	// Encoder.Encode appends a trailing newline that the existing fenced
-	// block contract does not want (we already control the newlines around
-	// the block).
-	return "```json\n" + strings.TrimRight(buf.String(), "\n") + "\n```\n"
+	// Encoder.Encode appends a trailing newline that the fenced block
+	// contract does not want (we already control the newlines around the
+	// block).
+	return strings.TrimRight(buf.String(), "\n")
 }
 
 // resourceField picks the JSON shape for the Resource field:
@@ -267,3 +282,38 @@ func Overlap(a, b []string) []string {
 	}
 	return sortedUnique(shared)
 }
+
+// ActionsFromAnnotation parses the JSON policy blocks of a rendered
+// aws_iam_permissions annotation (the output of Render, RenderSingle or
+// RenderStatements) and returns every action they grant, sorted and deduped.
+// For variant-bearing commands this is the union of the base and all
+// variants. Used by `kcp doctor` to simulate a command's documented policy.
+func ActionsFromAnnotation(annotation string) ([]string, error) {
+	var actions []string
+	rest := annotation
+	for {
+		start := strings.Index(rest, "```json\n")
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len("```json\n"):]
+		end := strings.Index(rest, "```")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated JSON policy block")
+		}
+
+		var policy struct {
+			Statement []struct {
+				Action []string `json:"Action"`
+			} `json:"Statement"`
+		}
+		if err := json.Unmarshal([]byte(rest[:end]), &policy); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON policy block: %w", err)
+		}
+		for _, s := range policy.Statement {
+			actions = append(actions, s.Action...)
+		}
+		rest = rest[end+len("```"):]
+	}
+	return sortedUnique(actions), nil
+}
@@ -212,6 +212,44 @@ func TestRenderStatementsOmitsSidWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestActionsFromAnnotationRoundTripsRender(t *testing.T) {
+	out := Render("Intro.", []string{"ec2:DescribeVpcs", "ec2:CreateVpc"}, []Variant{
+		{FlagHint: "--type 4", Summary: "Jump cluster.", Additions: []string{"ec2:RunInstances", "ec2:DescribeVpcs"}},
+		{FlagHint: "--type 1", Summary: "Nothing extra."},
+	})
+
+	got, err := ActionsFromAnnotation(out)
+	if err != nil {
+		t.Fatalf("ActionsFromAnnotation: %v", err)
+	}
+	want := []string{"ec2:CreateVpc", "ec2:DescribeVpcs", "ec2:RunInstances"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestActionsFromAnnotationMultiStatement(t *testing.T) {
+	out := RenderStatements("", []Statement{
+		{Sid: "First", Actions: []string{"kafka:ListClustersV2"}},
+		{Sid: "Second", Actions: []string{"s3:GetObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+	})
+
+	got, err := ActionsFromAnnotation(out)
+	if err != nil {
+		t.Fatalf("ActionsFromAnnotation: %v", err)
+	}
+	want := []string{"kafka:ListClustersV2", "s3:GetObject"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestActionsFromAnnotationRejectsMalformedBlock(t *testing.T) {
+	if _, err := ActionsFromAnnotation("```json\n{not json\n```\n"); err == nil {
+		t.Fatal("expected error for malformed JSON block")
+	}
+}
+
 func mustContain(t *testing.T, s, needle string) {
 	t.Helper()
 	if !strings.Contains(s, needle) {
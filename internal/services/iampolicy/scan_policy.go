@@ -0,0 +1,248 @@
+package iampolicy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ScanPolicyOpts scopes the policy returned by ScanPolicy. Empty Regions and
+// ClusterArns leave the corresponding resources unrestricted.
+type ScanPolicyOpts struct {
+	// Partition and AccountID fill the ARNs built from regions. Default to
+	// "aws" and "*".
+	Partition string
+	AccountID string
+
+	// Regions restricts every regional statement with aws:RequestedRegion
+	// and scopes per-region resource ARNs. The regions of ClusterArns are
+	// always included.
+	Regions []string
+	// ClusterArns restricts the per-cluster MSK actions to these clusters
+	// and their topics.
+	ClusterArns []string
+
+	SkipTopics  bool
+	SkipCosts   bool
+	SkipMetrics bool
+	// ScanSecrets adds the Secrets Manager reads of `kcp discover
+	// --scan-secrets`.
+	ScanSecrets bool
+
+	// GlueRegistries adds `kcp scan schema-registry --sr-type glue` access
+	// to these registry names.
+	GlueRegistries []string
+	// BrokerLogsBuckets adds `kcp scan client-inventory` read access to these
+	// S3 buckets.
+	BrokerLogsBuckets []string
+}
+
+// ScanPolicy returns the least-privilege statements covering the AWS calls
+// made by `kcp discover` and the MSK side of `kcp scan`. Actions that do not
+// support resource-level permissions (list and describe-all calls, EC2 and
+// CloudWatch reads) are granted on "*" but still pinned to the regions in
+// scope; Cost Explorer is global and cannot be region-restricted.
+func ScanPolicy(opts ScanPolicyOpts) ([]Statement, error) {
+	partition := opts.Partition
+	accountID := opts.AccountID
+
+	regions := slices.Clone(opts.Regions)
+	var topicArns []string
+	for _, clusterArn := range opts.ClusterArns {
+		arn, err := parseClusterArn(clusterArn)
+		if err != nil {
+			return nil, err
+		}
+		if partition == "" {
+			partition = arn.partition
+		}
+		if accountID == "" {
+			accountID = arn.account
+		}
+		regions = append(regions, arn.region)
+		topicArns = append(topicArns, fmt.Sprintf("arn:%s:kafka:%s:%s:topic/%s/*", arn.partition, arn.region, arn.account, arn.clusterPath))
+	}
+	if partition == "" {
+		partition = "aws"
+	}
+	if accountID == "" {
+		accountID = "*"
+	}
+	regions = sortedUnique(regions)
+
+	regionArns := func(service, resource string) []string {
+		if len(regions) == 0 {
+			return []string{fmt.Sprintf("arn:%s:%s:*:%s:%s", partition, service, accountID, resource)}
+		}
+		arns := make([]string, 0, len(regions))
+		for _, region := range regions {
+			arns = append(arns, fmt.Sprintf("arn:%s:%s:%s:%s:%s", partition, service, region, accountID, resource))
+		}
+		return arns
+	}
+	var regionCondition map[string]map[string][]string
+	if len(regions) > 0 {
+		regionCondition = map[string]map[string][]string{
+			"StringEquals": {"aws:RequestedRegion": regions},
+		}
+	}
+
+	clusterArns := opts.ClusterArns
+	if len(clusterArns) == 0 {
+		clusterArns = regionArns("kafka", "cluster/*/*")
+		topicArns = regionArns("kafka", "topic/*/*/*")
+	}
+
+	statements := []Statement{
+		{
+			Sid: "MSKAccountDiscovery",
+			Actions: []string{
+				"kafka:ListClustersV2",
+				"kafka:ListReplicators",
+				"kafka:DescribeReplicator",
+				"kafka:ListVpcConnections",
+				"kafka:GetCompatibleKafkaVersions",
+				"kafka:ListKafkaVersions",
+				"kafka:ListConfigurations",
+				"kafka:DescribeConfigurationRevision",
+				"kafkaconnect:ListConnectors",
+				"ec2:DescribeSubnets",
+				"ec2:DescribeNetworkInterfaces",
+				"ec2:DescribeSecurityGroups",
+			},
+			Condition: regionCondition,
+		},
+		{
+			Sid: "MSKClusterDiscovery",
+			Actions: []string{
+				"kafka:DescribeClusterV2",
+				"kafka:ListTagsForResource",
+				"kafka:GetBootstrapBrokers",
+				"kafka:ListNodes",
+				"kafka:ListClusterOperationsV2",
+				"kafka:DescribeClusterOperationV2",
+				"kafka:ListScramSecrets",
+				"kafka:ListClientVpcConnections",
+				"kafka:GetClusterPolicy",
+			},
+			Resources: clusterArns,
+		},
+		{
+			Sid: "MSKClusterConnect",
+			Actions: []string{
+				"kafka-cluster:Connect",
+				"kafka-cluster:DescribeCluster",
+				"kafka-cluster:DescribeClusterDynamicConfiguration",
+			},
+			Resources: clusterArns,
+		},
+		{
+			Sid:       "MSKConnectDiscovery",
+			Actions:   []string{"kafkaconnect:DescribeConnector", "kafkaconnect:ListConnectorOperations"},
+			Resources: regionArns("kafkaconnect", "connector/*/*"),
+		},
+		{
+			Sid:       "MSKConnectOperationDiscovery",
+			Actions:   []string{"kafkaconnect:DescribeConnectorOperation"},
+			Resources: regionArns("kafkaconnect", "connector-operation/*/*/*"),
+		},
+		{
+			// Maps which principals hold kafka-cluster permissions on
+			// IAM-authenticated clusters.
+			Sid:     "IAMAccessDiscovery",
+			Actions: []string{"iam:GetAccountAuthorizationDetails", "iam:SimulatePrincipalPolicy"},
+		},
+	}
+
+	if !opts.SkipTopics {
+		statements = append(statements, Statement{
+			Sid: "MSKTopicDiscovery",
+			Actions: []string{
+				"kafka:ListTopics",
+				"kafka:DescribeTopic",
+				"kafka-cluster:DescribeTopic",
+				"kafka-cluster:DescribeTopicDynamicConfiguration",
+			},
+			Resources: append(slices.Clone(clusterArns), topicArns...),
+		})
+	}
+	if !opts.SkipMetrics {
+		statements = append(statements, Statement{
+			Sid: "MSKMetricsDiscovery",
+			Actions: []string{
+				"cloudwatch:GetMetricData",
+				"cloudwatch:GetMetricStatistics",
+				"cloudwatch:ListMetrics",
+			},
+			Condition: regionCondition,
+		})
+	}
+	if !opts.SkipCosts {
+		statements = append(statements, Statement{
+			Sid:     "MSKCostDiscovery",
+			Actions: []string{"ce:GetCostAndUsage"},
+		})
+	}
+	if opts.ScanSecrets {
+		// Kafka secrets are found by name and tags, so every secret in the
+		// regions in scope is listed.
+		statements = append(statements, Statement{
+			Sid:       "KafkaSecretsDiscovery",
+			Actions:   []string{"secretsmanager:ListSecrets", "secretsmanager:GetResourcePolicy"},
+			Condition: regionCondition,
+		})
+	}
+	if len(opts.GlueRegistries) > 0 {
+		var resources []string
+		for _, registry := range opts.GlueRegistries {
+			resources = append(resources, regionArns("glue", "registry/"+registry)...)
+			resources = append(resources, regionArns("glue", "schema/"+registry+"/*")...)
+		}
+		statements = append(statements, Statement{
+			Sid: "GlueSchemaRegistryScan",
+			Actions: []string{
+				"glue:ListSchemas",
+				"glue:ListSchemaVersions",
+				"glue:GetSchema",
+				"glue:GetSchemaByDefinition",
+				"glue:GetSchemaVersion",
+				"glue:GetRegistry",
+			},
+			Resources: resources,
+		})
+	}
+	if len(opts.BrokerLogsBuckets) > 0 {
+		var resources []string
+		for _, bucket := range opts.BrokerLogsBuckets {
+			resources = append(resources, fmt.Sprintf("arn:%s:s3:::%s", partition, bucket), fmt.Sprintf("arn:%s:s3:::%s/*", partition, bucket))
+		}
+		statements = append(statements, Statement{
+			Sid:       "BrokerLogsClientInventory",
+			Actions:   []string{"s3:GetObject", "s3:ListBucket"},
+			Resources: resources,
+		})
+	}
+
+	return statements, nil
+}
+
+type clusterArnParts struct {
+	partition   string
+	region      string
+	account     string
+	clusterPath string // <cluster name>/<cluster uuid>
+}
+
+// parseClusterArn splits arn:<partition>:kafka:<region>:<account>:cluster/<name>/<uuid>.
+func parseClusterArn(arn string) (clusterArnParts, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "kafka" || !strings.HasPrefix(parts[5], "cluster/") {
+		return clusterArnParts{}, fmt.Errorf("invalid MSK cluster ARN %q", arn)
+	}
+	return clusterArnParts{
+		partition:   parts[1],
+		region:      parts[3],
+		account:     parts[4],
+		clusterPath: strings.TrimPrefix(parts[5], "cluster/"),
+	}, nil
+}
@@ -0,0 +1,128 @@
+package iampolicy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func statementBySid(t *testing.T, statements []Statement, sid string) Statement {
+	t.Helper()
+	for _, s := range statements {
+		if s.Sid == sid {
+			return s
+		}
+	}
+	t.Fatalf("no statement with Sid %q", sid)
+	return Statement{}
+}
+
+func TestScanPolicyScopesToClusters(t *testing.T) {
+	statements, err := ScanPolicy(ScanPolicyOpts{
+		Regions:     []string{"eu-west-1"},
+		ClusterArns: []string{"arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1"},
+	})
+	if err != nil {
+		t.Fatalf("ScanPolicy: %v", err)
+	}
+
+	account := statementBySid(t, statements, "MSKAccountDiscovery")
+	wantRegions := []string{"eu-west-1", "us-east-1"}
+	if got := account.Condition["StringEquals"]["aws:RequestedRegion"]; !reflect.DeepEqual(got, wantRegions) {
+		t.Errorf("region condition = %v, want %v", got, wantRegions)
+	}
+	if account.Resources != nil {
+		t.Errorf("account-level statement should use Resource *, got %v", account.Resources)
+	}
+
+	cluster := statementBySid(t, statements, "MSKClusterDiscovery")
+	if want := []string{"arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1"}; !reflect.DeepEqual(cluster.Resources, want) {
+		t.Errorf("cluster resources = %v, want %v", cluster.Resources, want)
+	}
+
+	topics := statementBySid(t, statements, "MSKTopicDiscovery")
+	if !reflect.DeepEqual(topics.Resources, []string{
+		"arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc-1",
+		"arn:aws:kafka:us-east-1:123456789012:topic/orders/abc-1/*",
+	}) {
+		t.Errorf("topic resources = %v", topics.Resources)
+	}
+
+	connect := statementBySid(t, statements, "MSKConnectDiscovery")
+	if !reflect.DeepEqual(connect.Resources, []string{
+		"arn:aws:kafkaconnect:eu-west-1:123456789012:connector/*/*",
+		"arn:aws:kafkaconnect:us-east-1:123456789012:connector/*/*",
+	}) {
+		t.Errorf("connector resources = %v", connect.Resources)
+	}
+}
+
+func TestScanPolicyUnscopedUsesWildcards(t *testing.T) {
+	statements, err := ScanPolicy(ScanPolicyOpts{})
+	if err != nil {
+		t.Fatalf("ScanPolicy: %v", err)
+	}
+
+	if cond := statementBySid(t, statements, "MSKAccountDiscovery").Condition; cond != nil {
+		t.Errorf("no region condition expected without regions, got %v", cond)
+	}
+	cluster := statementBySid(t, statements, "MSKClusterDiscovery")
+	if want := []string{"arn:aws:kafka:*:*:cluster/*/*"}; !reflect.DeepEqual(cluster.Resources, want) {
+		t.Errorf("cluster resources = %v, want %v", cluster.Resources, want)
+	}
+}
+
+func TestScanPolicySkipsAndOptionalStatements(t *testing.T) {
+	statements, err := ScanPolicy(ScanPolicyOpts{
+		Regions:           []string{"us-east-1"},
+		AccountID:         "123456789012",
+		SkipTopics:        true,
+		SkipCosts:         true,
+		SkipMetrics:       true,
+		ScanSecrets:       true,
+		GlueRegistries:    []string{"payments"},
+		BrokerLogsBuckets: []string{"msk-logs"},
+	})
+	if err != nil {
+		t.Fatalf("ScanPolicy: %v", err)
+	}
+
+	for _, s := range statements {
+		switch s.Sid {
+		case "MSKTopicDiscovery", "MSKCostDiscovery", "MSKMetricsDiscovery":
+			t.Errorf("statement %s should be skipped", s.Sid)
+		}
+	}
+
+	glue := statementBySid(t, statements, "GlueSchemaRegistryScan")
+	if want := []string{"arn:aws:glue:us-east-1:123456789012:registry/payments", "arn:aws:glue:us-east-1:123456789012:schema/payments/*"}; !reflect.DeepEqual(glue.Resources, want) {
+		t.Errorf("glue resources = %v, want %v", glue.Resources, want)
+	}
+	secrets := statementBySid(t, statements, "KafkaSecretsDiscovery")
+	if want := []string{"us-east-1"}; !reflect.DeepEqual(secrets.Condition["StringEquals"]["aws:RequestedRegion"], want) {
+		t.Errorf("secrets region condition = %v, want %v", secrets.Condition, want)
+	}
+	s3 := statementBySid(t, statements, "BrokerLogsClientInventory")
+	if want := []string{"arn:aws:s3:::msk-logs", "arn:aws:s3:::msk-logs/*"}; !reflect.DeepEqual(s3.Resources, want) {
+		t.Errorf("s3 resources = %v, want %v", s3.Resources, want)
+	}
+}
+
+func TestScanPolicyRejectsInvalidClusterArn(t *testing.T) {
+	_, err := ScanPolicy(ScanPolicyOpts{ClusterArns: []string{"arn:aws:s3:::bucket"}})
+	if err == nil || !strings.Contains(err.Error(), "invalid MSK cluster ARN") {
+		t.Fatalf("expected invalid ARN error, got %v", err)
+	}
+}
+
+func TestPolicyJSONRendersCondition(t *testing.T) {
+	out := PolicyJSON([]Statement{{
+		Actions:   []string{"ec2:DescribeSubnets"},
+		Condition: map[string]map[string][]string{"StringEquals": {"aws:RequestedRegion": {"us-east-1"}}},
+	}})
+	mustContain(t, out, `"Condition": {`)
+	mustContain(t, out, `"aws:RequestedRegion": [`)
+	if strings.HasPrefix(out, "```") {
+		t.Fatalf("PolicyJSON must not be fenced:\n%s", out)
+	}
+}
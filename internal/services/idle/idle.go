@@ -0,0 +1,167 @@
+// Package idle finds the topics nobody writes to and the consumer groups
+// nobody consumes with any more, from the offsets `kcp scan clusters`
+// tracks across runs, so they can be pruned before migrating instead of
+// mirroring dead data.
+package idle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+type Kind string
+
+const (
+	KindTopic         Kind = "topic"
+	KindConsumerGroup Kind = "consumer_group"
+)
+
+// Finding is one idle topic or consumer group.
+type Finding struct {
+	Cluster string
+	Region  string
+	Kind    Kind
+	Name    string
+	// IdleSince is the latest time the resource is known to have been used,
+	// nil when it never was.
+	IdleSince *time.Time
+	Reason    string
+	// Bytes is the on-disk size of an idle topic, 0 when unknown.
+	Bytes int64
+}
+
+// Result is the idle analysis of every cluster in a state file.
+type Result struct {
+	Findings []Finding
+	// TopicsChecked and GroupsChecked count the resources whose activity was
+	// recorded; the others could not be judged.
+	TopicsChecked int
+	GroupsChecked int
+	// AwaitingHistory counts resources whose offsets moved, or were first
+	// observed, less than the idle period ago: another scan after the period
+	// tells whether they are idle.
+	AwaitingHistory int
+}
+
+// Find reports the topics and consumer groups of every scanned cluster that
+// have been idle for at least idleFor as of now. Internal topics and the
+// groups of protocols that do not commit offsets are left out.
+func Find(state report.ProcessedState, idleFor time.Duration, now time.Time) Result {
+	cutoff := now.Add(-idleFor)
+	var result Result
+	for _, src := range state.Sources {
+		if src.MSKData != nil {
+			for _, region := range src.MSKData.Regions {
+				for _, cluster := range region.Clusters {
+					result.add(cluster.Name, region.Name, cluster.KafkaAdminClientInformation, cutoff)
+				}
+			}
+		}
+		if src.OSKData != nil {
+			for _, cluster := range src.OSKData.Clusters {
+				result.add(cluster.ID, "", cluster.KafkaAdminClientInformation, cutoff)
+			}
+		}
+	}
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind // topics first
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
+
+func (r *Result) add(cluster, region string, info types.KafkaAdminClientInformation, cutoff time.Time) {
+	if info.Topics != nil {
+		for _, topic := range info.Topics.Details {
+			if strings.HasPrefix(topic.Name, "__") || topic.Activity == nil {
+				continue
+			}
+			r.TopicsChecked++
+			finding, idle := idleTopic(topic, cutoff)
+			if !idle {
+				if topic.Activity.UnchangedSince.After(cutoff) {
+					r.AwaitingHistory++
+				}
+				continue
+			}
+			finding.Cluster, finding.Region = cluster, region
+			r.Findings = append(r.Findings, finding)
+		}
+	}
+
+	for _, group := range info.ConsumerGroups {
+		if group.Activity == nil {
+			continue
+		}
+		r.GroupsChecked++
+		finding, idle := idleGroup(group, cutoff)
+		if !idle {
+			if group.Members == 0 && group.Activity.UnchangedSince.After(cutoff) {
+				r.AwaitingHistory++
+			}
+			continue
+		}
+		finding.Cluster, finding.Region = cluster, region
+		r.Findings = append(r.Findings, finding)
+	}
+}
+
+// idleTopic judges a topic idle when nothing was ever produced to it, when
+// the newest sampled message predates the cutoff, or when its end offsets
+// have not moved since before the cutoff.
+func idleTopic(topic types.TopicDetails, cutoff time.Time) (Finding, bool) {
+	activity := topic.Activity
+	finding := Finding{Kind: KindTopic, Name: topic.Name}
+	for _, size := range topic.PartitionSizes {
+		finding.Bytes += size
+	}
+
+	switch {
+	case activity.Offset == 0:
+		finding.Reason = "no message was ever produced"
+	case activity.LastProducedAt != nil && activity.LastProducedAt.Before(cutoff):
+		finding.IdleSince = activity.LastProducedAt
+		finding.Reason = fmt.Sprintf("newest message produced %s", activity.LastProducedAt.Format(time.DateOnly))
+	case !activity.UnchangedSince.After(cutoff):
+		since := activity.UnchangedSince
+		finding.IdleSince = &since
+		finding.Reason = fmt.Sprintf("end offsets unchanged since %s", since.Format(time.DateOnly))
+	default:
+		return Finding{}, false
+	}
+	return finding, true
+}
+
+// idleGroup judges a consumer group idle when it has no members and either
+// never committed an offset or has not committed since before the cutoff.
+// A group with members is consuming, even from idle topics.
+func idleGroup(group types.ConsumerGroup, cutoff time.Time) (Finding, bool) {
+	if group.Members > 0 {
+		return Finding{}, false
+	}
+	activity := group.Activity
+	finding := Finding{Kind: KindConsumerGroup, Name: group.GroupID}
+
+	switch {
+	case activity.Offset == 0:
+		finding.Reason = "no members and no committed offsets"
+	case !activity.UnchangedSince.After(cutoff):
+		since := activity.UnchangedSince
+		finding.IdleSince = &since
+		finding.Reason = fmt.Sprintf("no members; committed offsets unchanged since %s", since.Format(time.DateOnly))
+	default:
+		return Finding{}, false
+	}
+	return finding, true
+}
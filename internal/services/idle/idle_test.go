@@ -0,0 +1,72 @@
+package idle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+func daysAgo(days int) time.Time {
+	return now.AddDate(0, 0, -days)
+}
+
+func activity(offset int64, unchangedFor int) *types.OffsetActivity {
+	return &types.OffsetActivity{ObservedAt: now, Offset: offset, UnchangedSince: daysAgo(unchangedFor)}
+}
+
+func TestFind_FlagsIdleTopicsAndGroups(t *testing.T) {
+	produced := daysAgo(90)
+	sampled := activity(50, 1)
+	sampled.LastProducedAt = &produced
+
+	info := types.KafkaAdminClientInformation{
+		Topics: &types.Topics{Details: []types.TopicDetails{
+			{Name: "orders", Activity: activity(1000, 2)},
+			{Name: "legacy", Activity: activity(500, 45), PartitionSizes: []int64{100, 200}},
+			{Name: "never-written", Activity: activity(0, 1)},
+			{Name: "archived", Activity: sampled},
+			{Name: "unobserved"},
+			{Name: "__consumer_offsets", Activity: activity(0, 100)},
+		}},
+		ConsumerGroups: []types.ConsumerGroup{
+			{GroupID: "orders-app", Members: 3, Activity: activity(10, 60)},
+			{GroupID: "old-batch", Members: 0, Activity: activity(10, 60)},
+			{GroupID: "drained-yesterday", Members: 0, Activity: activity(10, 1)},
+			{GroupID: "never-committed", Members: 0, Activity: activity(0, 1)},
+		},
+	}
+	state := report.ProcessedState{Sources: []report.ProcessedSource{{
+		Type: types.SourceTypeMSK,
+		MSKData: &report.ProcessedMSKSource{Regions: []report.ProcessedRegion{
+			{Name: "us-east-1", Clusters: []report.ProcessedCluster{{Name: "prod", KafkaAdminClientInformation: info}}},
+		}},
+	}}}
+
+	result := Find(state, 30*24*time.Hour, now)
+
+	var names []string
+	for _, f := range result.Findings {
+		names = append(names, string(f.Kind)+":"+f.Name)
+	}
+	assert.Equal(t, []string{
+		"topic:archived", "topic:legacy", "topic:never-written",
+		"consumer_group:never-committed", "consumer_group:old-batch",
+	}, names)
+	assert.Equal(t, 4, result.TopicsChecked, "internal and unobserved topics are not checked")
+	assert.Equal(t, 4, result.GroupsChecked)
+	assert.Equal(t, 2, result.AwaitingHistory, "orders and drained-yesterday need a later scan")
+
+	legacy := result.Findings[1]
+	assert.Equal(t, "us-east-1", legacy.Region)
+	assert.Equal(t, int64(300), legacy.Bytes)
+	require.NotNil(t, legacy.IdleSince)
+	assert.Equal(t, daysAgo(45), *legacy.IdleSince)
+	assert.Equal(t, produced, *result.Findings[0].IdleSince, "a sampled message dates the topic over its offsets")
+	assert.Nil(t, result.Findings[2].IdleSince)
+}
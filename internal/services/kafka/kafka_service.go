@@ -1,12 +1,19 @@
 package kafka
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"sort"
+	"time"
 
+	"github.com/IBM/sarama"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/tracing"
 	"github.com/confluentinc/kcp/internal/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type KafkaService struct {
@@ -15,30 +22,35 @@ type KafkaService struct {
 	clusterArn string
 	skipTopics bool
 	skipACLs   bool
+	// sampleMessages is how many messages to sample per topic; 0 disables
+	// sampling.
+	sampleMessages int
 }
 
 type KafkaServiceOpts struct {
-	AuthType   types.AuthType
-	ClusterArn string
-	SkipTopics bool
-	SkipACLs   bool
+	AuthType       types.AuthType
+	ClusterArn     string
+	SkipTopics     bool
+	SkipACLs       bool
+	SampleMessages int
 }
 
 func NewKafkaService(kafkaAdmin client.KafkaAdmin, opts KafkaServiceOpts) *KafkaService {
 	return &KafkaService{
-		client:     kafkaAdmin,
-		authType:   opts.AuthType,
-		clusterArn: opts.ClusterArn,
-		skipTopics: opts.SkipTopics,
-		skipACLs:   opts.SkipACLs,
+		client:         kafkaAdmin,
+		authType:       opts.AuthType,
+		clusterArn:     opts.ClusterArn,
+		skipTopics:     opts.SkipTopics,
+		skipACLs:       opts.SkipACLs,
+		sampleMessages: opts.SampleMessages,
 	}
 }
 
 // ScanKafkaResources scans all Kafka-related resources and populates the cluster information
-func (ks *KafkaService) ScanKafkaResources(clusterType kafkatypes.ClusterType) (*types.KafkaAdminClientInformation, error) {
+func (ks *KafkaService) ScanKafkaResources(ctx context.Context, clusterType kafkatypes.ClusterType) (*types.KafkaAdminClientInformation, error) {
 	kafkaAdminClientInformation := &types.KafkaAdminClientInformation{}
 	// Get cluster metadata including broker information and ClusterID
-	clusterMetadata, err := ks.describeKafkaCluster()
+	clusterMetadata, err := ks.describeKafkaCluster(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -47,16 +59,28 @@ func (ks *KafkaService) ScanKafkaResources(clusterType kafkatypes.ClusterType) (
 
 	// Store discovered broker addresses
 	brokerAddrs := make([]string, 0, len(clusterMetadata.Brokers))
+	brokerIDs := make([]int32, 0, len(clusterMetadata.Brokers))
+	rackByBroker := make(map[int32]string, len(clusterMetadata.Brokers))
 	for _, broker := range clusterMetadata.Brokers {
 		brokerAddrs = append(brokerAddrs, broker.Addr())
+		brokerIDs = append(brokerIDs, broker.ID())
+		rackByBroker[broker.ID()] = broker.Rack()
 	}
 	kafkaAdminClientInformation.DiscoveredBrokers = brokerAddrs
 
 	if !ks.skipTopics {
-		topics, err := ks.scanClusterTopics()
+		topics, err := ks.scanClusterTopics(ctx)
 		if err != nil {
 			return nil, err
 		}
+		// MSK Serverless does not expose broker log dirs.
+		if clusterType != kafkatypes.ClusterTypeServerless {
+			ks.scanPartitionSizes(ctx, topics, brokerIDs)
+		}
+		ks.scanTopicActivity(ctx, topics, time.Now().UTC())
+		if ks.sampleMessages > 0 {
+			ks.scanSerialization(ctx, topics)
+		}
 		kafkaAdminClientInformation.SetTopics(topics)
 	}
 
@@ -67,22 +91,30 @@ func (ks *KafkaService) ScanKafkaResources(clusterType kafkatypes.ClusterType) (
 	}
 
 	if !ks.skipACLs {
-		acls, err := ks.scanKafkaAcls()
+		acls, err := ks.scanKafkaAcls(ctx)
 		if err != nil {
 			return nil, err
 		}
 		kafkaAdminClientInformation.Acls = acls
 	}
 
+	kafkaAdminClientInformation.ConsumerGroups = ks.scanConsumerGroups(ctx)
+	ks.scanConsumerGroupActivity(ctx, kafkaAdminClientInformation.ConsumerGroups, time.Now().UTC())
+	partitions := ks.describeTopicPartitions(ctx)
+	kafkaAdminClientInformation.PartitionHealth = ks.scanPartitionHealth(ctx, partitions)
+	kafkaAdminClientInformation.BrokerRacks = brokerRacks(rackByBroker, partitions)
+
 	return kafkaAdminClientInformation, nil
 }
 
 // scanClusterTopics scans for topics in the Kafka cluster
-func (ks *KafkaService) scanClusterTopics() ([]types.TopicDetails, error) {
+func (ks *KafkaService) scanClusterTopics(ctx context.Context) ([]types.TopicDetails, error) {
 	slog.Info("🔍 scanning for cluster topics")
 	slog.Debug("🔍 scanning for cluster topics", "clusterArn", ks.clusterArn)
 
+	_, span := ks.startSpan(ctx, "ListTopicsWithConfigs")
 	topics, err := ks.client.ListTopicsWithConfigs()
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list topics with configs: %v", err)
 	}
@@ -109,12 +141,71 @@ func (ks *KafkaService) scanClusterTopics() ([]types.TopicDetails, error) {
 	return topicDetails, nil
 }
 
+// scanPartitionSizes fills in the on-disk size of every partition from the
+// brokers' log dirs. Sizes only feed the plan's partition skew analysis, so a
+// failure is logged and the scan carries on without them.
+func (ks *KafkaService) scanPartitionSizes(ctx context.Context, topics []types.TopicDetails, brokerIDs []int32) {
+	if len(topics) == 0 || len(brokerIDs) == 0 {
+		return
+	}
+
+	slog.Info("🔍 scanning partition sizes")
+	slog.Debug("🔍 scanning partition sizes", "clusterArn", ks.clusterArn)
+
+	_, span := ks.startSpan(ctx, "DescribeLogDirs", attribute.Int("kafka.broker_count", len(brokerIDs)))
+	logDirs, err := ks.client.DescribeLogDirs(brokerIDs)
+	tracing.End(span, err)
+	if err != nil {
+		slog.Warn("⚠️ failed to describe log dirs; partition sizes will be missing from the plan", "error", err)
+		return
+	}
+
+	// A partition is as large as its largest replica: followers that are
+	// catching up hold less, and mirroring reads the full log.
+	sizes := make(map[string]map[int32]int64)
+	for _, dirs := range logDirs {
+		for _, dir := range dirs {
+			if dir.ErrorCode != sarama.ErrNoError {
+				continue
+			}
+			for _, topic := range dir.Topics {
+				if sizes[topic.Topic] == nil {
+					sizes[topic.Topic] = make(map[int32]int64)
+				}
+				for _, partition := range topic.Partitions {
+					if partition.IsTemporary {
+						continue
+					}
+					if partition.Size > sizes[topic.Topic][partition.PartitionID] {
+						sizes[topic.Topic][partition.PartitionID] = partition.Size
+					}
+				}
+			}
+		}
+	}
+
+	for i := range topics {
+		partitionSizes, ok := sizes[topics[i].Name]
+		if !ok {
+			continue
+		}
+		topics[i].PartitionSizes = make([]int64, topics[i].Partitions)
+		for id, size := range partitionSizes {
+			if int(id) < len(topics[i].PartitionSizes) {
+				topics[i].PartitionSizes[id] = size
+			}
+		}
+	}
+}
+
 // describeKafkaCluster gets cluster metadata and returns the cluster ID along with logging information
-func (ks *KafkaService) describeKafkaCluster() (*client.ClusterKafkaMetadata, error) {
+func (ks *KafkaService) describeKafkaCluster(ctx context.Context) (*client.ClusterKafkaMetadata, error) {
 	slog.Info("🔍 describing kafka cluster")
 	slog.Debug("🔍 describing kafka cluster", "clusterArn", ks.clusterArn)
 
+	_, span := ks.startSpan(ctx, "GetClusterKafkaMetadata")
 	clusterMetadata, err := ks.client.GetClusterKafkaMetadata()
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe kafka cluster: %v", err)
 	}
@@ -122,11 +213,13 @@ func (ks *KafkaService) describeKafkaCluster() (*client.ClusterKafkaMetadata, er
 }
 
 // scanKafkaAcls scans for Kafka ACLs in the cluster
-func (ks *KafkaService) scanKafkaAcls() ([]types.Acls, error) {
+func (ks *KafkaService) scanKafkaAcls(ctx context.Context) ([]types.Acls, error) {
 	slog.Info("🔍 scanning for kafka acls")
 	slog.Debug("🔍 scanning for kafka acls", "clusterArn", ks.clusterArn)
 
+	_, span := ks.startSpan(ctx, "ListAcls")
 	acls, err := ks.client.ListAcls()
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list acls: %v", err)
 	}
@@ -150,3 +243,256 @@ func (ks *KafkaService) scanKafkaAcls() ([]types.Acls, error) {
 
 	return flattenedAcls, nil
 }
+
+// scanConsumerGroups describes every consumer group, recording which members
+// use static membership. Groups only feed the plan's migration semantics
+// analysis, so a failure (usually a missing DescribeGroup permission) is
+// logged and the scan carries on without them.
+func (ks *KafkaService) scanConsumerGroups(ctx context.Context) []types.ConsumerGroup {
+	slog.Info("🔍 scanning consumer groups")
+	slog.Debug("🔍 scanning consumer groups", "clusterArn", ks.clusterArn)
+
+	_, span := ks.startSpan(ctx, "DescribeConsumerGroups")
+	descriptions, err := ks.client.DescribeConsumerGroups()
+	tracing.End(span, err)
+	if err != nil {
+		slog.Warn("⚠️ failed to describe consumer groups; static membership will be missing from the plan", "error", err)
+		return nil
+	}
+
+	groups := make([]types.ConsumerGroup, 0, len(descriptions))
+	for _, description := range descriptions {
+		if description.Err != sarama.ErrNoError {
+			slog.Debug("skipping consumer group", "group", description.GroupId, "error", description.Err)
+			continue
+		}
+		group := types.ConsumerGroup{
+			GroupID:      description.GroupId,
+			State:        description.State,
+			ProtocolType: description.ProtocolType,
+			Members:      len(description.Members),
+		}
+		for _, member := range description.Members {
+			if member.GroupInstanceId != nil && *member.GroupInstanceId != "" {
+				group.StaticInstanceIDs = append(group.StaticInstanceIDs, *member.GroupInstanceId)
+			}
+			// Only the consumer protocol carries a client.rack; other
+			// protocols' metadata does not decode as a subscription.
+			if description.ProtocolType != "consumer" {
+				continue
+			}
+			metadata, err := member.GetMemberMetadata()
+			if err != nil || metadata == nil || metadata.RackID == nil || *metadata.RackID == "" {
+				continue
+			}
+			if group.ClientRacks == nil {
+				group.ClientRacks = make(map[string]int)
+			}
+			group.ClientRacks[*metadata.RackID]++
+		}
+		sort.Strings(group.StaticInstanceIDs)
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].GroupID < groups[j].GroupID })
+
+	slog.Info("🔍 found consumer groups", "count", len(groups))
+	return groups
+}
+
+// scanTopicActivity records the end offsets of every topic, so that rescans
+// can tell the topics nobody writes to any more. Activity only feeds the idle
+// resources report, so a failure is logged and the scan carries on without
+// it.
+func (ks *KafkaService) scanTopicActivity(ctx context.Context, topics []types.TopicDetails, now time.Time) {
+	if len(topics) == 0 {
+		return
+	}
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, topic.Name)
+	}
+
+	_, span := ks.startSpan(ctx, "ListEndOffsets", attribute.Int("kafka.topic_count", len(names)))
+	endOffsets, err := ks.client.ListEndOffsets(names)
+	tracing.End(span, err)
+	if err != nil {
+		slog.Warn("⚠️ failed to list topic end offsets; idle topics will be missing from the idle report", "error", err)
+		return
+	}
+
+	for i := range topics {
+		offset, ok := endOffsets[topics[i].Name]
+		if !ok {
+			continue
+		}
+		topics[i].Activity = &types.OffsetActivity{ObservedAt: now, Offset: offset, UnchangedSince: now}
+	}
+}
+
+// scanConsumerGroupActivity records the offsets each consumer group has
+// committed, so that rescans can tell the groups that stopped consuming. A
+// group whose offsets can't be fetched is left without activity and the scan
+// carries on.
+func (ks *KafkaService) scanConsumerGroupActivity(ctx context.Context, groups []types.ConsumerGroup, now time.Time) {
+	failed := 0
+	var firstErr error
+	for i := range groups {
+		// Only the consumer protocol commits offsets; Connect workers and
+		// other protocols only coordinate through the group.
+		if groups[i].ProtocolType != "" && groups[i].ProtocolType != "consumer" {
+			continue
+		}
+
+		_, span := ks.startSpan(ctx, "ListConsumerGroupOffsets", attribute.String("kafka.group_id", groups[i].GroupID))
+		offsets, err := ks.client.ListConsumerGroupOffsets(groups[i].GroupID)
+		tracing.End(span, err)
+		if err != nil {
+			slog.Debug("failed to fetch consumer group offsets", "group", groups[i].GroupID, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+
+		var committed int64
+		for _, partitions := range offsets {
+			for _, offset := range partitions {
+				committed += offset
+			}
+		}
+		groups[i].Activity = &types.OffsetActivity{ObservedAt: now, Offset: committed, UnchangedSince: now}
+	}
+
+	if failed > 0 {
+		slog.Warn("⚠️ failed to fetch the committed offsets of some consumer groups; they will be missing from the idle report", "failed", failed, "error", firstErr)
+	}
+}
+
+// describeTopicPartitions describes the partitions of every topic, internal
+// topics included, for the partition health and broker rack reports. Both
+// only inform the plan, so a failure is logged and the scan carries on
+// without them.
+func (ks *KafkaService) describeTopicPartitions(ctx context.Context) []*sarama.TopicMetadata {
+	_, span := ks.startSpan(ctx, "DescribeTopicPartitions")
+	topics, err := ks.client.DescribeTopicPartitions()
+	tracing.End(span, err)
+	if err != nil {
+		slog.Warn("⚠️ failed to describe topic partitions; partition health and leadership will be missing from the state file", "error", err)
+		return nil
+	}
+	return topics
+}
+
+// scanPartitionHealth counts the under-replicated and offline partitions and
+// lists the partition reassignments in flight. It returns nil when the
+// partitions could not be described.
+func (ks *KafkaService) scanPartitionHealth(ctx context.Context, topics []*sarama.TopicMetadata) *types.PartitionHealth {
+	if topics == nil {
+		return nil
+	}
+	slog.Info("🔍 checking partition health")
+	slog.Debug("🔍 checking partition health", "clusterArn", ks.clusterArn)
+
+	health := &types.PartitionHealth{CheckedAt: time.Now().UTC()}
+	partitionIDs := make(map[string][]int32, len(topics))
+	for _, topic := range topics {
+		if topic.Err != sarama.ErrNoError {
+			slog.Debug("skipping topic", "topic", topic.Name, "error", topic.Err)
+			continue
+		}
+		for _, partition := range topic.Partitions {
+			health.Partitions++
+			partitionIDs[topic.Name] = append(partitionIDs[topic.Name], partition.ID)
+
+			// Like the brokers' own metrics, an offline partition has no
+			// leader to count it under-replicated.
+			offline := partition.Leader < 0
+			if !offline && len(partition.Isr) >= len(partition.Replicas) {
+				continue
+			}
+			if offline {
+				health.OfflinePartitions++
+			} else {
+				health.UnderReplicatedPartitions++
+			}
+			health.UnhealthyPartitions = append(health.UnhealthyPartitions, types.UnhealthyPartition{
+				Topic:           topic.Name,
+				Partition:       partition.ID,
+				Leader:          partition.Leader,
+				Replicas:        partition.Replicas,
+				ISR:             partition.Isr,
+				OfflineReplicas: partition.OfflineReplicas,
+				Offline:         offline,
+			})
+		}
+	}
+	sort.Slice(health.UnhealthyPartitions, func(i, j int) bool {
+		a, b := health.UnhealthyPartitions[i], health.UnhealthyPartitions[j]
+		return a.Topic < b.Topic || (a.Topic == b.Topic && a.Partition < b.Partition)
+	})
+
+	_, span := ks.startSpan(ctx, "ListPartitionReassignments", attribute.Int("kafka.topic_count", len(partitionIDs)))
+	reassignments, err := ks.client.ListPartitionReassignments(partitionIDs)
+	tracing.End(span, err)
+	if err != nil {
+		slog.Warn("⚠️ failed to list partition reassignments; in-flight reassignments will not be reported", "error", err)
+		health.ReassignmentsError = err.Error()
+	}
+	for topic, byPartition := range reassignments {
+		for partition, status := range byPartition {
+			health.Reassignments = append(health.Reassignments, types.PartitionReassignment{
+				Topic:            topic,
+				Partition:        partition,
+				Replicas:         status.Replicas,
+				AddingReplicas:   status.AddingReplicas,
+				RemovingReplicas: status.RemovingReplicas,
+			})
+		}
+	}
+	sort.Slice(health.Reassignments, func(i, j int) bool {
+		a, b := health.Reassignments[i], health.Reassignments[j]
+		return a.Topic < b.Topic || (a.Topic == b.Topic && a.Partition < b.Partition)
+	})
+
+	slog.Info("🔍 checked partition health", "partitions", health.Partitions, "under_replicated", health.UnderReplicatedPartitions, "offline", health.OfflinePartitions, "reassignments", len(health.Reassignments))
+	return health
+}
+
+// brokerRacks records the broker.rack of each broker and how many partitions
+// it leads, which the plan combines with the consumers' client.rack to judge
+// how much consumer traffic crosses availability zones. Leader counts are
+// zero when the partitions could not be described.
+func brokerRacks(rackByBroker map[int32]string, topics []*sarama.TopicMetadata) []types.BrokerRack {
+	if len(rackByBroker) == 0 {
+		return nil
+	}
+	leaders := make(map[int32]int, len(rackByBroker))
+	for _, topic := range topics {
+		if topic.Err != sarama.ErrNoError {
+			continue
+		}
+		for _, partition := range topic.Partitions {
+			if partition.Leader >= 0 {
+				leaders[partition.Leader]++
+			}
+		}
+	}
+
+	racks := make([]types.BrokerRack, 0, len(rackByBroker))
+	for id, rack := range rackByBroker {
+		racks = append(racks, types.BrokerRack{BrokerID: id, Rack: rack, LeaderPartitions: leaders[id]})
+	}
+	sort.Slice(racks, func(i, j int) bool { return racks[i].BrokerID < racks[j].BrokerID })
+	return racks
+}
+
+// startSpan opens a span for one Kafka admin call, e.g. "KafkaAdmin.ListAcls".
+func (ks *KafkaService) startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("rpc.system", "kafka"),
+		attribute.String("rpc.method", operation),
+		attribute.String("kafka.cluster", ks.clusterArn),
+	)
+	return tracing.Start(ctx, "KafkaAdmin."+operation, attrs...)
+}
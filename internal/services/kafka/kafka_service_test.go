@@ -2,10 +2,13 @@ package kafka
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
 	"errors"
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/IBM/sarama"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
@@ -71,6 +74,9 @@ func TestKafkaService_ScanKafkaResources(t *testing.T) {
 						},
 					}, nil
 				},
+				ListEndOffsetsFunc: func([]string) (map[string]int64, error) {
+					return map[string]int64{"serverless-topic": 10}, nil
+				},
 				// Note: No ListAclsFunc needed since ACL scan should be skipped
 			},
 			clusterType:   kafkatypes.ClusterTypeServerless,
@@ -96,6 +102,9 @@ func TestKafkaService_ScanKafkaResources(t *testing.T) {
 						},
 					}, nil
 				},
+				ListEndOffsetsFunc: func([]string) (map[string]int64, error) {
+					return map[string]int64{"provisioned-topic": 0}, nil
+				},
 				ListAclsFunc: func() ([]sarama.ResourceAcls, error) {
 					return nil, errors.New("ACL authorization failed")
 				},
@@ -148,6 +157,18 @@ func TestKafkaService_ScanKafkaResources(t *testing.T) {
 						},
 					}, nil
 				},
+				DescribeConsumerGroupsFunc: func() ([]*sarama.GroupDescription, error) {
+					return nil, nil
+				},
+				DescribeTopicPartitionsFunc: func() ([]*sarama.TopicMetadata, error) {
+					return nil, nil
+				},
+				ListPartitionReassignmentsFunc: func(map[string][]int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+					return nil, nil
+				},
+				ListEndOffsetsFunc: func([]string) (map[string]int64, error) {
+					return nil, errors.New("topic authorization failed")
+				},
 			},
 			clusterType:   kafkatypes.ClusterTypeProvisioned,
 			wantErr:       false,
@@ -165,7 +186,7 @@ func TestKafkaService_ScanKafkaResources(t *testing.T) {
 				clusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/test/abc-123",
 			}
 
-			result, err := ks.ScanKafkaResources(tt.clusterType)
+			result, err := ks.ScanKafkaResources(context.Background(), tt.clusterType)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -278,7 +299,7 @@ func TestKafkaService_scanClusterTopics(t *testing.T) {
 				clusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/test/abc-123",
 			}
 
-			result, err := ks.scanClusterTopics()
+			result, err := ks.scanClusterTopics(context.Background())
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -307,6 +328,314 @@ func TestKafkaService_scanClusterTopics(t *testing.T) {
 	}
 }
 
+func TestKafkaService_scanPartitionSizes(t *testing.T) {
+	logDirs := map[int32][]sarama.DescribeLogDirsResponseDirMetadata{
+		1: {{
+			ErrorCode: sarama.ErrNoError,
+			Path:      "/kafka/data",
+			Topics: []sarama.DescribeLogDirsResponseTopic{{
+				Topic: "orders",
+				Partitions: []sarama.DescribeLogDirsResponsePartition{
+					{PartitionID: 0, Size: 1000},
+					{PartitionID: 1, Size: 50},
+				},
+			}},
+		}},
+		2: {
+			{
+				ErrorCode: sarama.ErrNoError,
+				Path:      "/kafka/data",
+				Topics: []sarama.DescribeLogDirsResponseTopic{{
+					Topic: "orders",
+					Partitions: []sarama.DescribeLogDirsResponsePartition{
+						{PartitionID: 0, Size: 900},
+						{PartitionID: 1, Size: 60},
+						{PartitionID: 2, Size: 9999, IsTemporary: true},
+					},
+				}},
+			},
+			{
+				ErrorCode: sarama.ErrKafkaStorageError,
+				Path:      "/kafka/offline",
+				Topics: []sarama.DescribeLogDirsResponseTopic{{
+					Topic:      "orders",
+					Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 2, Size: 7777}},
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockClient *mocks.MockKafkaAdmin
+		want       map[string][]int64
+	}{
+		{
+			name: "largest replica per partition",
+			mockClient: &mocks.MockKafkaAdmin{
+				DescribeLogDirsFunc: func(brokerIDs []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+					assert.Equal(t, []int32{1, 2}, brokerIDs)
+					return logDirs, nil
+				},
+			},
+			want: map[string][]int64{
+				"orders": {1000, 60, 0},
+				"users":  nil,
+			},
+		},
+		{
+			name: "DescribeLogDirs error leaves sizes unset",
+			mockClient: &mocks.MockKafkaAdmin{
+				DescribeLogDirsFunc: func([]int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+					return nil, errors.New("cluster authorization failed")
+				},
+			},
+			want: map[string][]int64{
+				"orders": nil,
+				"users":  nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &KafkaService{client: tt.mockClient}
+			topics := []types.TopicDetails{
+				{Name: "orders", Partitions: 3},
+				{Name: "users", Partitions: 2},
+			}
+
+			ks.scanPartitionSizes(context.Background(), topics, []int32{1, 2})
+
+			for _, topic := range topics {
+				assert.Equal(t, tt.want[topic.Name], topic.PartitionSizes, topic.Name)
+			}
+		})
+	}
+}
+
+// subscriptionWithRack encodes a version 3 consumer protocol subscription
+// (KIP-881) with the given client.rack, as a consumer joining a group sends it.
+func subscriptionWithRack(rack string) []byte {
+	var b []byte
+	b = binary.BigEndian.AppendUint16(b, 3)          // version
+	b = binary.BigEndian.AppendUint32(b, 1)          // topics
+	b = binary.BigEndian.AppendUint16(b, 6)          // topic name length
+	b = append(b, "orders"...)                       //
+	b = binary.BigEndian.AppendUint32(b, 0xffffffff) // null user data
+	b = binary.BigEndian.AppendUint32(b, 0)          // owned partitions
+	b = binary.BigEndian.AppendUint32(b, 1)          // generation
+	b = binary.BigEndian.AppendUint16(b, uint16(len(rack)))
+	return append(b, rack...)
+}
+
+func TestKafkaService_scanConsumerGroups(t *testing.T) {
+	instanceID := "billing-0"
+	empty := ""
+
+	tests := []struct {
+		name       string
+		mockClient *mocks.MockKafkaAdmin
+		want       []types.ConsumerGroup
+	}{
+		{
+			name: "static members recorded, failed groups skipped",
+			mockClient: &mocks.MockKafkaAdmin{
+				DescribeConsumerGroupsFunc: func() ([]*sarama.GroupDescription, error) {
+					return []*sarama.GroupDescription{
+						{GroupId: "orders", State: "Stable", ProtocolType: "consumer", Members: map[string]*sarama.GroupMemberDescription{
+							"m1": {GroupInstanceId: &empty},
+							"m2": {},
+						}},
+						{GroupId: "billing", State: "Stable", ProtocolType: "consumer", Members: map[string]*sarama.GroupMemberDescription{
+							"m1": {GroupInstanceId: &instanceID},
+						}},
+						{GroupId: "denied", Err: sarama.ErrGroupAuthorizationFailed},
+					}, nil
+				},
+			},
+			want: []types.ConsumerGroup{
+				{GroupID: "billing", State: "Stable", ProtocolType: "consumer", Members: 1, StaticInstanceIDs: []string{"billing-0"}},
+				{GroupID: "orders", State: "Stable", ProtocolType: "consumer", Members: 2},
+			},
+		},
+		{
+			name: "client racks counted for consumer groups only",
+			mockClient: &mocks.MockKafkaAdmin{
+				DescribeConsumerGroupsFunc: func() ([]*sarama.GroupDescription, error) {
+					return []*sarama.GroupDescription{
+						{GroupId: "orders", State: "Stable", ProtocolType: "consumer", Members: map[string]*sarama.GroupMemberDescription{
+							"m1": {MemberMetadata: subscriptionWithRack("use1-az1")},
+							"m2": {MemberMetadata: subscriptionWithRack("use1-az1")},
+							"m3": {MemberMetadata: subscriptionWithRack("use1-az2")},
+							"m4": {MemberMetadata: subscriptionWithRack("")},
+						}},
+						{GroupId: "connect-cluster", State: "Stable", ProtocolType: "connect", Members: map[string]*sarama.GroupMemberDescription{
+							"w1": {MemberMetadata: []byte{0, 1, 2}},
+						}},
+					}, nil
+				},
+			},
+			want: []types.ConsumerGroup{
+				{GroupID: "connect-cluster", State: "Stable", ProtocolType: "connect", Members: 1},
+				{GroupID: "orders", State: "Stable", ProtocolType: "consumer", Members: 4, ClientRacks: map[string]int{"use1-az1": 2, "use1-az2": 1}},
+			},
+		},
+		{
+			name: "DescribeConsumerGroups error leaves groups unset",
+			mockClient: &mocks.MockKafkaAdmin{
+				DescribeConsumerGroupsFunc: func() ([]*sarama.GroupDescription, error) {
+					return nil, errors.New("group authorization failed")
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &KafkaService{client: tt.mockClient}
+			assert.Equal(t, tt.want, ks.scanConsumerGroups(context.Background()))
+		})
+	}
+}
+
+func TestKafkaService_scanTopicActivity(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	ks := &KafkaService{client: &mocks.MockKafkaAdmin{
+		ListEndOffsetsFunc: func(topics []string) (map[string]int64, error) {
+			assert.Equal(t, []string{"orders", "gone"}, topics)
+			return map[string]int64{"orders": 42}, nil
+		},
+	}}
+	topics := []types.TopicDetails{{Name: "orders"}, {Name: "gone"}}
+
+	ks.scanTopicActivity(context.Background(), topics, now)
+
+	assert.Equal(t, &types.OffsetActivity{ObservedAt: now, Offset: 42, UnchangedSince: now}, topics[0].Activity)
+	assert.Nil(t, topics[1].Activity, "a topic the brokers returned no offsets for has no activity")
+}
+
+func TestKafkaService_scanConsumerGroupActivity(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	var fetched []string
+	ks := &KafkaService{client: &mocks.MockKafkaAdmin{
+		ListConsumerGroupOffsetsFunc: func(groupID string) (map[string]map[int32]int64, error) {
+			fetched = append(fetched, groupID)
+			if groupID == "denied" {
+				return nil, errors.New("group authorization failed")
+			}
+			return map[string]map[int32]int64{"orders": {0: 10, 1: 5}, "refunds": {0: 1}}, nil
+		},
+	}}
+	groups := []types.ConsumerGroup{
+		{GroupID: "billing", ProtocolType: "consumer"},
+		{GroupID: "connect-cluster", ProtocolType: "connect"},
+		{GroupID: "denied", ProtocolType: "consumer"},
+	}
+
+	ks.scanConsumerGroupActivity(context.Background(), groups, now)
+
+	assert.Equal(t, []string{"billing", "denied"}, fetched, "only consumer protocol groups commit offsets")
+	assert.Equal(t, &types.OffsetActivity{ObservedAt: now, Offset: 16, UnchangedSince: now}, groups[0].Activity)
+	assert.Nil(t, groups[1].Activity)
+	assert.Nil(t, groups[2].Activity)
+}
+
+func TestKafkaService_scanPartitionHealth(t *testing.T) {
+	topics := []*sarama.TopicMetadata{
+		{Name: "orders", Partitions: []*sarama.PartitionMetadata{
+			{ID: 0, Leader: 1, Replicas: []int32{1, 2, 3}, Isr: []int32{1, 2, 3}},
+			{ID: 1, Leader: 2, Replicas: []int32{2, 3, 1}, Isr: []int32{2}, OfflineReplicas: []int32{3}},
+			{ID: 2, Leader: -1, Replicas: []int32{3, 1, 2}, Isr: []int32{}, Err: sarama.ErrLeaderNotAvailable},
+		}},
+		{Name: "__consumer_offsets", IsInternal: true, Partitions: []*sarama.PartitionMetadata{
+			{ID: 0, Leader: 1, Replicas: []int32{1, 2}, Isr: []int32{1}},
+		}},
+		{Name: "denied", Err: sarama.ErrTopicAuthorizationFailed},
+	}
+
+	tests := []struct {
+		name                  string
+		reassignments         map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus
+		reassignmentsErr      error
+		wantReassignments     []types.PartitionReassignment
+		wantReassignmentError string
+	}{
+		{
+			name: "reassignments in flight",
+			reassignments: map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus{
+				"orders": {0: {Replicas: []int32{1, 2, 3, 4}, AddingReplicas: []int32{4}, RemovingReplicas: []int32{3}}},
+			},
+			wantReassignments: []types.PartitionReassignment{
+				{Topic: "orders", Partition: 0, Replicas: []int32{1, 2, 3, 4}, AddingReplicas: []int32{4}, RemovingReplicas: []int32{3}},
+			},
+		},
+		{
+			name:                  "ListPartitionReassignments error keeps the counts",
+			reassignmentsErr:      sarama.ErrUnsupportedVersion,
+			wantReassignmentError: sarama.ErrUnsupportedVersion.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &KafkaService{client: &mocks.MockKafkaAdmin{
+				DescribeTopicPartitionsFunc: func() ([]*sarama.TopicMetadata, error) {
+					return topics, nil
+				},
+				ListPartitionReassignmentsFunc: func(partitions map[string][]int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+					assert.Equal(t, map[string][]int32{"orders": {0, 1, 2}, "__consumer_offsets": {0}}, partitions)
+					return tt.reassignments, tt.reassignmentsErr
+				},
+			}}
+
+			health := ks.scanPartitionHealth(context.Background(), ks.describeTopicPartitions(context.Background()))
+
+			assert.Equal(t, 4, health.Partitions)
+			assert.Equal(t, 2, health.UnderReplicatedPartitions)
+			assert.Equal(t, 1, health.OfflinePartitions)
+			assert.Equal(t, []types.UnhealthyPartition{
+				{Topic: "__consumer_offsets", Partition: 0, Leader: 1, Replicas: []int32{1, 2}, ISR: []int32{1}},
+				{Topic: "orders", Partition: 1, Leader: 2, Replicas: []int32{2, 3, 1}, ISR: []int32{2}, OfflineReplicas: []int32{3}},
+				{Topic: "orders", Partition: 2, Leader: -1, Replicas: []int32{3, 1, 2}, ISR: []int32{}, Offline: true},
+			}, health.UnhealthyPartitions)
+			assert.Equal(t, tt.wantReassignments, health.Reassignments)
+			assert.Equal(t, tt.wantReassignmentError, health.ReassignmentsError)
+			assert.False(t, health.Healthy())
+		})
+	}
+
+	t.Run("DescribeTopicPartitions error leaves health unset", func(t *testing.T) {
+		ks := &KafkaService{client: &mocks.MockKafkaAdmin{
+			DescribeTopicPartitionsFunc: func() ([]*sarama.TopicMetadata, error) {
+				return nil, errors.New("cluster authorization failed")
+			},
+		}}
+		assert.Nil(t, ks.scanPartitionHealth(context.Background(), ks.describeTopicPartitions(context.Background())))
+	})
+}
+
+func TestBrokerRacks(t *testing.T) {
+	topics := []*sarama.TopicMetadata{
+		{Name: "orders", Partitions: []*sarama.PartitionMetadata{
+			{ID: 0, Leader: 1},
+			{ID: 1, Leader: 2},
+			{ID: 2, Leader: 1},
+			{ID: 3, Leader: -1},
+		}},
+		{Name: "denied", Err: sarama.ErrTopicAuthorizationFailed, Partitions: []*sarama.PartitionMetadata{{ID: 0, Leader: 3}}},
+	}
+	racks := brokerRacks(map[int32]string{2: "use1-az2", 1: "use1-az1", 3: "use1-az3"}, topics)
+
+	assert.Equal(t, []types.BrokerRack{
+		{BrokerID: 1, Rack: "use1-az1", LeaderPartitions: 2},
+		{BrokerID: 2, Rack: "use1-az2", LeaderPartitions: 1},
+		{BrokerID: 3, Rack: "use1-az3"},
+	}, racks)
+	assert.Nil(t, brokerRacks(nil, topics))
+}
+
 func TestKafkaService_describeKafkaCluster(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -350,7 +679,7 @@ func TestKafkaService_describeKafkaCluster(t *testing.T) {
 				clusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/test/abc-123",
 			}
 
-			result, err := ks.describeKafkaCluster()
+			result, err := ks.describeKafkaCluster(context.Background())
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -422,7 +751,7 @@ func TestKafkaService_scanKafkaAcls(t *testing.T) {
 				clusterArn: "arn:aws:kafka:us-east-1:123456789012:cluster/test/abc-123",
 			}
 
-			result, err := ks.scanKafkaAcls()
+			result, err := ks.scanKafkaAcls(context.Background())
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -475,7 +804,7 @@ func TestKafkaService_LogsClusterArnAtDebug(t *testing.T) {
 					return map[string]sarama.TopicDetail{}, nil
 				},
 			},
-			run: func(ks *KafkaService) { _, _ = ks.scanClusterTopics() },
+			run: func(ks *KafkaService) { _, _ = ks.scanClusterTopics(context.Background()) },
 		},
 		{
 			name: "describeKafkaCluster",
@@ -484,7 +813,7 @@ func TestKafkaService_LogsClusterArnAtDebug(t *testing.T) {
 					return &client.ClusterKafkaMetadata{ClusterID: "c-1"}, nil
 				},
 			},
-			run: func(ks *KafkaService) { _, _ = ks.describeKafkaCluster() },
+			run: func(ks *KafkaService) { _, _ = ks.describeKafkaCluster(context.Background()) },
 		},
 		{
 			name: "scanKafkaAcls",
@@ -493,7 +822,7 @@ func TestKafkaService_LogsClusterArnAtDebug(t *testing.T) {
 					return []sarama.ResourceAcls{}, nil
 				},
 			},
-			run: func(ks *KafkaService) { _, _ = ks.scanKafkaAcls() },
+			run: func(ks *KafkaService) { _, _ = ks.scanKafkaAcls(context.Background()) },
 		},
 	}
 
@@ -0,0 +1,168 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/confluentinc/kcp/internal/tracing"
+	"github.com/confluentinc/kcp/internal/types"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// sampleTimeout bounds how long sampling waits on a single topic.
+const sampleTimeout = 10 * time.Second
+
+// MaxSampleMessages caps --sample-messages: enough to see every format a
+// topic carries without turning the scan into a consumer.
+const MaxSampleMessages = 1000
+
+var avroContainerMagic = []byte("Obj\x01")
+
+// scanSerialization samples the most recent messages of each non-internal
+// topic and records the inferred format. Formats only scope the schema
+// migration, so a topic that can't be read (usually a missing ReadData
+// permission) is skipped and the scan carries on.
+func (ks *KafkaService) scanSerialization(ctx context.Context, topics []types.TopicDetails) {
+	slog.Info("🔍 sampling topic messages", "max_messages_per_topic", ks.sampleMessages)
+	slog.Debug("🔍 sampling topic messages", "clusterArn", ks.clusterArn)
+
+	sampled, failed := 0, 0
+	var firstErr error
+	for i := range topics {
+		if strings.HasPrefix(topics[i].Name, "__") {
+			continue
+		}
+
+		_, span := ks.startSpan(ctx, "SampleMessages", attribute.String("kafka.topic", topics[i].Name))
+		messages, err := ks.client.SampleMessages(topics[i].Name, ks.sampleMessages, sampleTimeout)
+		tracing.End(span, err)
+		if err != nil {
+			slog.Debug("failed to sample topic", "topic", topics[i].Name, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+
+		values := make([][]byte, 0, len(messages))
+		var newest time.Time
+		for _, message := range messages {
+			values = append(values, message.Value)
+			if message.Timestamp.After(newest) {
+				newest = message.Timestamp
+			}
+		}
+		topics[i].Serialization = InferSerialization(values, time.Now().UTC())
+		// The newest sampled message dates the last write to the topic.
+		if topics[i].Activity != nil && !newest.IsZero() {
+			producedAt := newest.UTC()
+			topics[i].Activity.LastProducedAt = &producedAt
+		}
+		sampled++
+	}
+
+	if failed > 0 {
+		slog.Warn("⚠️ failed to sample some topics; their serialization will be missing from the state file", "failed", failed, "error", firstErr)
+	}
+	slog.Info("🔍 sampled topics", "count", sampled)
+}
+
+// InferSerialization classifies each message value and summarises them. It
+// returns nil when there is nothing to classify, e.g. an empty topic.
+func InferSerialization(values [][]byte, sampledAt time.Time) *types.TopicSerialization {
+	if len(values) == 0 {
+		return nil
+	}
+
+	serialization := &types.TopicSerialization{
+		SampledAt:       sampledAt,
+		SampledMessages: len(values),
+		Formats:         make(map[string]int),
+	}
+	for _, value := range values {
+		format, schemaID, glueVersionID := classifyMessage(value)
+		serialization.Formats[format]++
+		if schemaID >= 0 && !slices.Contains(serialization.SchemaIDs, schemaID) {
+			serialization.SchemaIDs = append(serialization.SchemaIDs, schemaID)
+		}
+		if glueVersionID != "" && !slices.Contains(serialization.GlueSchemaVersionIDs, glueVersionID) {
+			serialization.GlueSchemaVersionIDs = append(serialization.GlueSchemaVersionIDs, glueVersionID)
+		}
+	}
+	slices.Sort(serialization.SchemaIDs)
+	slices.Sort(serialization.GlueSchemaVersionIDs)
+
+	// The most common format wins; ties go to the alphabetically first so the
+	// result is stable across scans.
+	for format, count := range serialization.Formats {
+		best := serialization.Formats[serialization.Format]
+		if count > best || (count == best && format < serialization.Format) {
+			serialization.Format = format
+		}
+	}
+	return serialization
+}
+
+// classifyMessage returns the format of one message value, with the schema
+// ID (-1 when none) or Glue schema version ID it was framed with.
+//
+//   - Confluent Schema Registry framing: magic byte 0x00 then a big-endian
+//     4-byte schema ID; a JSON payload after it means JSON Schema.
+//   - AWS Glue Schema Registry framing: header version 0x03, a compression
+//     byte (0x00 none, 0x05 zlib), then the 16-byte schema version UUID.
+//   - Avro object container files start with "Obj" 0x01.
+func classifyMessage(value []byte) (format string, schemaID int, glueVersionID string) {
+	switch {
+	case len(value) == 0:
+		return types.SerializationEmpty, -1, ""
+	case value[0] == 0x00 && len(value) >= 5:
+		id := int(binary.BigEndian.Uint32(value[1:5]))
+		if isJSONDocument(value[5:]) {
+			return types.SerializationSchemaRegistryJSON, id, ""
+		}
+		return types.SerializationSchemaRegistryBinary, id, ""
+	case value[0] == 0x03 && len(value) >= 18 && (value[1] == 0x00 || value[1] == 0x05):
+		u := value[2:18]
+		return types.SerializationGlueSchemaRegistry, -1, fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+	case bytes.HasPrefix(value, avroContainerMagic):
+		return types.SerializationAvroContainer, -1, ""
+	case isJSONDocument(value):
+		return types.SerializationJSON, -1, ""
+	case isText(value):
+		return types.SerializationText, -1, ""
+	default:
+		return types.SerializationBinary, -1, ""
+	}
+}
+
+// isJSONDocument reports whether b is a JSON object or array. Bare JSON
+// scalars such as 42 or "ok" are more likely plain text.
+func isJSONDocument(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || (b[0] != '{' && b[0] != '[') {
+		return false
+	}
+	return json.Valid(b)
+}
+
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
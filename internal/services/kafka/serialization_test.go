@@ -0,0 +1,105 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/mocks"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyMessage(t *testing.T) {
+	glueUUID := []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+
+	tests := []struct {
+		name         string
+		value        []byte
+		wantFormat   string
+		wantSchemaID int
+		wantGlueID   string
+	}{
+		{"empty", nil, types.SerializationEmpty, -1, ""},
+		{"schema registry avro", []byte{0x00, 0x00, 0x00, 0x01, 0x2c, 0x0c, 'o', 'r', 'd', 'e', 'r'}, types.SerializationSchemaRegistryBinary, 300, ""},
+		{"schema registry protobuf", []byte{0x00, 0x00, 0x00, 0x00, 0x07, 0x00, 0x0a, 0x03, 'a', 'b', 'c'}, types.SerializationSchemaRegistryBinary, 7, ""},
+		{"schema registry json schema", append([]byte{0x00, 0x00, 0x00, 0x00, 0x2a}, `{"id":1}`...), types.SerializationSchemaRegistryJSON, 42, ""},
+		{"glue schema registry", append(append([]byte{0x03, 0x00}, glueUUID...), 0x02), types.SerializationGlueSchemaRegistry, -1, "12345678-9abc-def0-0123-456789abcdef"},
+		{"avro container", []byte("Obj\x01\x04\x14avro.codec"), types.SerializationAvroContainer, -1, ""},
+		{"json object", []byte(` {"order_id": 1}`), types.SerializationJSON, -1, ""},
+		{"json scalar is text", []byte(`42`), types.SerializationText, -1, ""},
+		{"text", []byte("order 1 shipped\n"), types.SerializationText, -1, ""},
+		{"raw protobuf", []byte{0x08, 0x96, 0x01}, types.SerializationBinary, -1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, schemaID, glueID := classifyMessage(tt.value)
+			assert.Equal(t, tt.wantFormat, format)
+			assert.Equal(t, tt.wantSchemaID, schemaID)
+			assert.Equal(t, tt.wantGlueID, glueID)
+		})
+	}
+}
+
+func TestInferSerialization(t *testing.T) {
+	sampledAt := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, InferSerialization(nil, sampledAt))
+
+	got := InferSerialization([][]byte{
+		{0x00, 0x00, 0x00, 0x00, 0x09, 0x02},
+		{0x00, 0x00, 0x00, 0x00, 0x03, 0x02},
+		{0x00, 0x00, 0x00, 0x00, 0x09, 0x04},
+		[]byte(`{"legacy":true}`),
+	}, sampledAt)
+	assert.Equal(t, &types.TopicSerialization{
+		SampledAt:       sampledAt,
+		SampledMessages: 4,
+		Format:          types.SerializationSchemaRegistryBinary,
+		Formats:         map[string]int{types.SerializationSchemaRegistryBinary: 3, types.SerializationJSON: 1},
+		SchemaIDs:       []int{3, 9},
+	}, got)
+
+	// Ties go to the alphabetically first format.
+	got = InferSerialization([][]byte{[]byte("a"), []byte(`{}`)}, sampledAt)
+	assert.Equal(t, types.SerializationJSON, got.Format)
+}
+
+func TestKafkaService_scanSerialization(t *testing.T) {
+	producedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var sampled []string
+	ks := &KafkaService{
+		sampleMessages: 5,
+		client: &mocks.MockKafkaAdmin{
+			SampleMessagesFunc: func(topic string, maxMessages int, timeout time.Duration) ([]client.SampledMessage, error) {
+				sampled = append(sampled, topic)
+				assert.Equal(t, 5, maxMessages)
+				switch topic {
+				case "orders":
+					return []client.SampledMessage{
+						{Value: []byte(`{"id":1}`), Timestamp: producedAt},
+						{Value: []byte(`{"id":0}`), Timestamp: producedAt.Add(-time.Hour)},
+					}, nil
+				case "payments":
+					return nil, errors.New("topic authorization failed")
+				default:
+					return nil, nil
+				}
+			},
+		},
+	}
+	topics := []types.TopicDetails{{Name: "orders", Activity: &types.OffsetActivity{Offset: 2}}, {Name: "payments"}, {Name: "empty"}, {Name: "__consumer_offsets"}}
+
+	ks.scanSerialization(context.Background(), topics)
+
+	assert.Equal(t, []string{"orders", "payments", "empty"}, sampled, "internal topics are not sampled")
+	require.NotNil(t, topics[0].Serialization)
+	assert.Equal(t, types.SerializationJSON, topics[0].Serialization.Format)
+	require.NotNil(t, topics[0].Activity.LastProducedAt)
+	assert.True(t, producedAt.Equal(*topics[0].Activity.LastProducedAt), "the newest sampled message dates the last write")
+	assert.Nil(t, topics[1].Serialization, "a topic that can't be read is skipped")
+	assert.Nil(t, topics[2].Serialization)
+	assert.Nil(t, topics[3].Serialization)
+}
@@ -0,0 +1,195 @@
+// Package kafka_secrets finds the Secrets Manager secrets that hold Kafka
+// credentials and records their metadata. Secret values are never read.
+package kafka_secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// scramSecretPrefix is the name prefix MSK requires of SCRAM secrets.
+const scramSecretPrefix = "AmazonMSK_"
+
+// kafkaTagTerms mark a tag key or value as Kafka-related.
+var kafkaTagTerms = []string{"kafka", "msk"}
+
+type SecretsManagerClient interface {
+	ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error)
+	GetResourcePolicy(ctx context.Context, params *secretsmanager.GetResourcePolicyInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetResourcePolicyOutput, error)
+}
+
+type KafkaSecretsService struct {
+	client SecretsManagerClient
+}
+
+func NewKafkaSecretsService(client SecretsManagerClient) *KafkaSecretsService {
+	return &KafkaSecretsService{client: client}
+}
+
+// ListKafkaSecrets returns the region's secrets that hold Kafka credentials:
+// named with the MSK SCRAM prefix, associated with a cluster for SCRAM
+// (scramClusters maps secret ARN to cluster ARNs) or tagged for Kafka or
+// MSK. A failed resource policy lookup only leaves PolicyPrincipals empty.
+func (s *KafkaSecretsService) ListKafkaSecrets(ctx context.Context, scramClusters map[string][]string) ([]types.KafkaSecret, error) {
+	secrets := []types.KafkaSecret{}
+	var nextToken *string
+	for {
+		output, err := s.client.ListSecrets(ctx, &secretsmanager.ListSecretsInput{
+			MaxResults: aws.Int32(100),
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		for _, entry := range output.SecretList {
+			secret, ok := kafkaSecret(entry, scramClusters[aws.ToString(entry.ARN)])
+			if !ok {
+				continue
+			}
+			principals, err := s.policyPrincipals(ctx, secret.Arn)
+			if err != nil {
+				slog.Warn("⚠️ failed to get secret resource policy", "secret", secret.Name, "error", err)
+			}
+			secret.PolicyPrincipals = principals
+			secrets = append(secrets, secret)
+		}
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+	slices.SortFunc(secrets, func(a, b types.KafkaSecret) int { return strings.Compare(a.Name, b.Name) })
+	return secrets, nil
+}
+
+// kafkaSecret converts entry when it holds a Kafka credential.
+func kafkaSecret(entry smtypes.SecretListEntry, scramClusterArns []string) (types.KafkaSecret, bool) {
+	secret := types.KafkaSecret{
+		Arn:               aws.ToString(entry.ARN),
+		Name:              aws.ToString(entry.Name),
+		Description:       aws.ToString(entry.Description),
+		KmsKeyId:          aws.ToString(entry.KmsKeyId),
+		OwningService:     aws.ToString(entry.OwningService),
+		ScramClusterArns:  scramClusterArns,
+		RotationEnabled:   aws.ToBool(entry.RotationEnabled),
+		RotationLambdaArn: aws.ToString(entry.RotationLambdaARN),
+		CreatedDate:       entry.CreatedDate,
+		LastChangedDate:   entry.LastChangedDate,
+		LastRotatedDate:   entry.LastRotatedDate,
+		NextRotationDate:  entry.NextRotationDate,
+		LastAccessedDate:  entry.LastAccessedDate,
+	}
+	if rules := entry.RotationRules; rules != nil {
+		secret.RotationAfterDays = aws.ToInt64(rules.AutomaticallyAfterDays)
+		secret.RotationSchedule = aws.ToString(rules.ScheduleExpression)
+	}
+
+	if strings.HasPrefix(secret.Name, scramSecretPrefix) {
+		secret.MatchedBy = append(secret.MatchedBy, types.KafkaSecretMatchScramName)
+	}
+	if len(scramClusterArns) > 0 {
+		secret.MatchedBy = append(secret.MatchedBy, types.KafkaSecretMatchScramAssociation)
+	}
+	tagged := false
+	for _, tag := range entry.Tags {
+		key, value := aws.ToString(tag.Key), aws.ToString(tag.Value)
+		if secret.Tags == nil {
+			secret.Tags = map[string]string{}
+		}
+		secret.Tags[key] = value
+		tagged = tagged || mentionsKafka(key) || mentionsKafka(value)
+	}
+	if tagged {
+		secret.MatchedBy = append(secret.MatchedBy, types.KafkaSecretMatchTag)
+	}
+	return secret, len(secret.MatchedBy) > 0
+}
+
+func mentionsKafka(s string) bool {
+	s = strings.ToLower(s)
+	return slices.ContainsFunc(kafkaTagTerms, func(term string) bool { return strings.Contains(s, term) })
+}
+
+// policyPrincipals returns the principals the secret's resource policy
+// allows, sorted. A secret without a resource policy has none.
+func (s *KafkaSecretsService) policyPrincipals(ctx context.Context, secretArn string) ([]string, error) {
+	output, err := s.client.GetResourcePolicy(ctx, &secretsmanager.GetResourcePolicyInput{SecretId: aws.String(secretArn)})
+	if err != nil {
+		return nil, err
+	}
+	if aws.ToString(output.ResourcePolicy) == "" {
+		return nil, nil
+	}
+	return allowedPrincipals(aws.ToString(output.ResourcePolicy))
+}
+
+// allowedPrincipals extracts the principals of a policy document's Allow
+// statements. Statement, and each principal type's value, may be a single
+// item or a list.
+func allowedPrincipals(policy string) ([]string, error) {
+	var doc struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse resource policy: %w", err)
+	}
+	type statement struct {
+		Effect    string          `json:"Effect"`
+		Principal json.RawMessage `json:"Principal"`
+	}
+	var statements []statement
+	if err := unmarshalOneOrMany(doc.Statement, &statements); err != nil {
+		return nil, fmt.Errorf("failed to parse resource policy statements: %w", err)
+	}
+
+	var principals []string
+	for _, st := range statements {
+		if st.Effect != "Allow" || len(st.Principal) == 0 {
+			continue
+		}
+		var wildcard string
+		if json.Unmarshal(st.Principal, &wildcard) == nil {
+			principals = append(principals, wildcard)
+			continue
+		}
+		var byType map[string]json.RawMessage
+		if err := json.Unmarshal(st.Principal, &byType); err != nil {
+			return nil, fmt.Errorf("failed to parse resource policy principal: %w", err)
+		}
+		for _, raw := range byType {
+			var values []string
+			if err := unmarshalOneOrMany(raw, &values); err != nil {
+				return nil, fmt.Errorf("failed to parse resource policy principal: %w", err)
+			}
+			principals = append(principals, values...)
+		}
+	}
+	slices.Sort(principals)
+	return slices.Compact(principals), nil
+}
+
+// unmarshalOneOrMany decodes raw into out, a pointer to a slice, accepting
+// a single element as well as an array.
+func unmarshalOneOrMany[T any](raw json.RawMessage, out *[]T) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if raw[0] == '[' {
+		return json.Unmarshal(raw, out)
+	}
+	var one T
+	if err := json.Unmarshal(raw, &one); err != nil {
+		return err
+	}
+	*out = []T{one}
+	return nil
+}
@@ -0,0 +1,125 @@
+package kafka_secrets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSecretsManagerClient struct {
+	listSecretsFn       func(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error)
+	getResourcePolicyFn func(ctx context.Context, params *secretsmanager.GetResourcePolicyInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetResourcePolicyOutput, error)
+}
+
+func (m *mockSecretsManagerClient) ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+	return m.listSecretsFn(ctx, params, optFns...)
+}
+
+func (m *mockSecretsManagerClient) GetResourcePolicy(ctx context.Context, params *secretsmanager.GetResourcePolicyInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetResourcePolicyOutput, error) {
+	return m.getResourcePolicyFn(ctx, params, optFns...)
+}
+
+func TestListKafkaSecrets(t *testing.T) {
+	pages := map[string]*secretsmanager.ListSecretsOutput{
+		"": {
+			SecretList: []smtypes.SecretListEntry{
+				{
+					ARN:               aws.String("arn:scram"),
+					Name:              aws.String("AmazonMSK_orders"),
+					RotationEnabled:   aws.Bool(true),
+					RotationLambdaARN: aws.String("arn:rotate"),
+					RotationRules:     &smtypes.RotationRulesType{AutomaticallyAfterDays: aws.Int64(30)},
+				},
+				{ARN: aws.String("arn:db"), Name: aws.String("postgres/app")},
+			},
+			NextToken: aws.String("page-2"),
+		},
+		"page-2": {
+			SecretList: []smtypes.SecretListEntry{
+				{
+					ARN:  aws.String("arn:tagged"),
+					Name: aws.String("connect/sink"),
+					Tags: []smtypes.Tag{{Key: aws.String("Purpose"), Value: aws.String("Kafka Connect")}},
+				},
+			},
+		},
+	}
+	client := &mockSecretsManagerClient{
+		listSecretsFn: func(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+			return pages[aws.ToString(params.NextToken)], nil
+		},
+		getResourcePolicyFn: func(ctx context.Context, params *secretsmanager.GetResourcePolicyInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetResourcePolicyOutput, error) {
+			switch aws.ToString(params.SecretId) {
+			case "arn:scram":
+				return &secretsmanager.GetResourcePolicyOutput{ResourcePolicy: aws.String(`{
+					"Version": "2012-10-17",
+					"Statement": [
+						{"Effect": "Allow", "Principal": {"Service": "kafka.amazonaws.com"}, "Action": "secretsmanager:GetSecretValue"},
+						{"Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::123:role/app", "arn:aws:iam::123:role/ops"]}, "Action": "secretsmanager:GetSecretValue"},
+						{"Effect": "Deny", "Principal": "*", "Action": "secretsmanager:DeleteSecret"}
+					]
+				}`)}, nil
+			case "arn:tagged":
+				return nil, fmt.Errorf("access denied")
+			}
+			return &secretsmanager.GetResourcePolicyOutput{}, nil
+		},
+	}
+
+	secrets, err := NewKafkaSecretsService(client).ListKafkaSecrets(context.Background(), map[string][]string{
+		"arn:scram": {"arn:cluster/orders"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []types.KafkaSecret{
+		{
+			Arn:               "arn:scram",
+			Name:              "AmazonMSK_orders",
+			MatchedBy:         []string{types.KafkaSecretMatchScramName, types.KafkaSecretMatchScramAssociation},
+			ScramClusterArns:  []string{"arn:cluster/orders"},
+			RotationEnabled:   true,
+			RotationLambdaArn: "arn:rotate",
+			RotationAfterDays: 30,
+			PolicyPrincipals:  []string{"arn:aws:iam::123:role/app", "arn:aws:iam::123:role/ops", "kafka.amazonaws.com"},
+		},
+		{
+			Arn:       "arn:tagged",
+			Name:      "connect/sink",
+			Tags:      map[string]string{"Purpose": "Kafka Connect"},
+			MatchedBy: []string{types.KafkaSecretMatchTag},
+		},
+	}, secrets, "the untagged database secret is left out and a failed policy lookup is not fatal")
+}
+
+func TestListKafkaSecrets_ListError(t *testing.T) {
+	client := &mockSecretsManagerClient{
+		listSecretsFn: func(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+			return nil, fmt.Errorf("access denied")
+		},
+	}
+
+	_, err := NewKafkaSecretsService(client).ListKafkaSecrets(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list secrets")
+}
+
+func TestAllowedPrincipals(t *testing.T) {
+	principals, err := allowedPrincipals(`{"Statement": {"Effect": "Allow", "Principal": "*"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*"}, principals, "a single statement and a wildcard principal")
+
+	principals, err = allowedPrincipals(`{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123:root"}}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"arn:aws:iam::123:root"}, principals)
+
+	_, err = allowedPrincipals(`not json`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+// Package linkbandwidth estimates cluster link mirroring from a cluster's
+// data volume and produce rate: how long the initial sync takes while
+// producers keep writing, the bandwidth the link needs once it has caught
+// up, and the jump cluster instance type that carries it.
+package linkbandwidth
+
+import (
+	"math"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// BytesPerMB is the MB (1024^2 bytes) the MB/s figures are in.
+const BytesPerMB = 1024 * 1024
+
+// Input describes one source cluster.
+type Input struct {
+	// TotalBytes is the user topic data the link has to copy.
+	TotalBytes int64
+	// SustainedProduceBytesPerSec is the average produce rate (BytesInPerSec)
+	// and PeakProduceBytesPerSec its maximum. Zero when no metrics were
+	// collected.
+	SustainedProduceBytesPerSec float64
+	PeakProduceBytesPerSec      float64
+	// LinkThroughputMBps is the sustained throughput the link can reach.
+	LinkThroughputMBps float64
+	// Headroom is the fraction added to the peak produce rate for the
+	// steady-state bandwidth, e.g. 0.3.
+	Headroom float64
+}
+
+// DefaultHeadroom is the fraction added to the peak produce rate when no
+// other is configured.
+const DefaultHeadroom = 0.3
+
+// NewInput builds the input for one cluster from its scanned partition
+// sizes and its BytesInPerSec aggregates. Internal topics are left out:
+// cluster linking doesn't mirror them. The bool is false when the cluster
+// has neither, so there is nothing to estimate from.
+func NewInput(metrics types.ProcessedClusterMetrics, admin types.KafkaAdminClientInformation, linkThroughputMBps, headroom float64) (Input, bool) {
+	in := Input{LinkThroughputMBps: linkThroughputMBps, Headroom: headroom}
+	sized := false
+	if admin.Topics != nil {
+		for _, topic := range admin.Topics.Details {
+			if strings.HasPrefix(topic.Name, "__") || len(topic.PartitionSizes) == 0 {
+				continue
+			}
+			sized = true
+			for _, size := range topic.PartitionSizes {
+				in.TotalBytes += size
+			}
+		}
+	}
+	metered := false
+	if agg, ok := metrics.Aggregates["BytesInPerSec"]; ok {
+		if agg.Average != nil {
+			in.SustainedProduceBytesPerSec = *agg.Average
+			metered = true
+		}
+		if agg.Maximum != nil {
+			in.PeakProduceBytesPerSec = *agg.Maximum
+			metered = true
+		}
+	}
+	return in, sized || metered
+}
+
+// Estimate is the mirroring estimate for one cluster.
+type Estimate struct {
+	SustainedProduceMBps float64
+	PeakProduceMBps      float64
+	// CatchUpMBps is what the link copies of the existing data each second:
+	// its throughput less the sustained produce rate it mirrors meanwhile.
+	CatchUpMBps float64
+	// InitialSyncSeconds is how long the link takes to copy TotalBytes and
+	// reach zero lag. Zero when CanCatchUp is false.
+	InitialSyncSeconds float64
+	// CanCatchUp is false when producers write at least as fast as the
+	// link copies, so the initial sync never finishes.
+	CanCatchUp bool
+	// SteadyStateMBps is the link bandwidth needed after the initial sync:
+	// the peak produce rate plus headroom.
+	SteadyStateMBps float64
+	// RequiredMBps is the most the link carries in either phase, which the
+	// network path and any jump cluster must sustain.
+	RequiredMBps float64
+}
+
+// Calculate estimates mirroring for in.
+func Calculate(in Input) Estimate {
+	e := Estimate{
+		SustainedProduceMBps: in.SustainedProduceBytesPerSec / BytesPerMB,
+		PeakProduceMBps:      math.Max(in.PeakProduceBytesPerSec, in.SustainedProduceBytesPerSec) / BytesPerMB,
+	}
+	e.CatchUpMBps = in.LinkThroughputMBps - e.SustainedProduceMBps
+	e.CanCatchUp = e.CatchUpMBps > 0
+	if e.CanCatchUp {
+		e.InitialSyncSeconds = float64(in.TotalBytes) / (e.CatchUpMBps * BytesPerMB)
+	}
+	e.SteadyStateMBps = e.PeakProduceMBps * (1 + in.Headroom)
+	e.RequiredMBps = math.Max(in.LinkThroughputMBps, e.SteadyStateMBps)
+	return e
+}
+
+// InstanceType is an EC2 instance type jump cluster brokers can run on.
+type InstanceType struct {
+	Name string
+	// BaselineGbps is the instance's baseline network bandwidth, which it
+	// sustains indefinitely, unlike its burst bandwidth.
+	BaselineGbps float64
+}
+
+// BaselineMBps is the baseline bandwidth in MB/s.
+func (t InstanceType) BaselineMBps() float64 {
+	return t.BaselineGbps * 1e9 / 8 / BytesPerMB
+}
+
+// JumpClusterInstanceTypes are the m5 sizes, smallest first, with the
+// baseline bandwidths AWS publishes for them.
+var JumpClusterInstanceTypes = []InstanceType{
+	{Name: "m5.large", BaselineGbps: 0.75},
+	{Name: "m5.xlarge", BaselineGbps: 1.25},
+	{Name: "m5.2xlarge", BaselineGbps: 2.5},
+	{Name: "m5.4xlarge", BaselineGbps: 5},
+	{Name: "m5.8xlarge", BaselineGbps: 10},
+	{Name: "m5.12xlarge", BaselineGbps: 12},
+	{Name: "m5.16xlarge", BaselineGbps: 20},
+	{Name: "m5.24xlarge", BaselineGbps: 25},
+}
+
+// JumpClusterInstanceType returns the smallest instance type whose baseline
+// bandwidth carries one broker's share of requiredMBps split across brokers
+// jump cluster brokers. Every broker receives from the source and sends to
+// Confluent Cloud at the same rate, in opposite directions, so one direction
+// is what counts. The bool is false when even the largest type is too small,
+// which is returned anyway.
+func JumpClusterInstanceType(brokers int, requiredMBps float64) (InstanceType, bool) {
+	perBroker := requiredMBps / float64(max(brokers, 1))
+	for _, candidate := range JumpClusterInstanceTypes {
+		if candidate.BaselineMBps() >= perBroker {
+			return candidate, true
+		}
+	}
+	return JumpClusterInstanceTypes[len(JumpClusterInstanceTypes)-1], false
+}
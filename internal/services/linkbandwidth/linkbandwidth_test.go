@@ -0,0 +1,93 @@
+package linkbandwidth
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculate(t *testing.T) {
+	e := Calculate(Input{
+		TotalBytes:                  360 * 1024 * BytesPerMB, // 360 GiB
+		SustainedProduceBytesPerSec: 20 * BytesPerMB,
+		PeakProduceBytesPerSec:      50 * BytesPerMB,
+		LinkThroughputMBps:          100,
+		Headroom:                    0.2,
+	})
+
+	assert.True(t, e.CanCatchUp)
+	assert.InDelta(t, 80, e.CatchUpMBps, 1e-9)
+	// 368640 MB at the 80 MB/s left after mirroring live ingress.
+	assert.InDelta(t, 4608, e.InitialSyncSeconds, 1e-6)
+	assert.InDelta(t, 60, e.SteadyStateMBps, 1e-9)
+	assert.InDelta(t, 100, e.RequiredMBps, 1e-9)
+}
+
+func TestCalculate_ProducersOutpaceTheLink(t *testing.T) {
+	e := Calculate(Input{
+		TotalBytes:                  BytesPerMB,
+		SustainedProduceBytesPerSec: 120 * BytesPerMB,
+		PeakProduceBytesPerSec:      200 * BytesPerMB,
+		LinkThroughputMBps:          100,
+		Headroom:                    0.3,
+	})
+
+	assert.False(t, e.CanCatchUp)
+	assert.Zero(t, e.InitialSyncSeconds)
+	assert.InDelta(t, 260, e.SteadyStateMBps, 1e-9)
+	assert.InDelta(t, 260, e.RequiredMBps, 1e-9, "the steady state needs more than the link's assumed throughput")
+}
+
+func TestCalculate_NoMetrics(t *testing.T) {
+	e := Calculate(Input{TotalBytes: 100 * BytesPerMB, LinkThroughputMBps: 100, Headroom: 0.3})
+
+	assert.InDelta(t, 1, e.InitialSyncSeconds, 1e-9)
+	assert.Zero(t, e.SteadyStateMBps)
+	assert.InDelta(t, 100, e.RequiredMBps, 1e-9)
+}
+
+func TestJumpClusterInstanceType(t *testing.T) {
+	tests := []struct {
+		brokers  int
+		required float64
+		want     string
+		wantOK   bool
+	}{
+		{3, 100, "m5.large", true},    // 33 MB/s per broker
+		{3, 300, "m5.xlarge", true},   // 100 MB/s per broker, above m5.large's 89
+		{2, 1000, "m5.4xlarge", true}, // 500 MB/s per broker
+		{0, 50, "m5.large", true},     // no broker count: sized as one broker
+		{3, 10000, "m5.24xlarge", false},
+	}
+	for _, tt := range tests {
+		got, ok := JumpClusterInstanceType(tt.brokers, tt.required)
+		assert.Equal(t, tt.want, got.Name, "%d brokers at %g MB/s", tt.brokers, tt.required)
+		assert.Equal(t, tt.wantOK, ok)
+	}
+}
+
+func TestNewInput(t *testing.T) {
+	avg, peak := 20.0*BytesPerMB, 50.0*BytesPerMB
+	metrics := types.ProcessedClusterMetrics{Aggregates: map[string]types.MetricAggregate{
+		"BytesInPerSec": {Average: &avg, Maximum: &peak},
+	}}
+	admin := types.KafkaAdminClientInformation{Topics: &types.Topics{Details: []types.TopicDetails{
+		{Name: "orders", PartitionSizes: []int64{3 * BytesPerMB, 5 * BytesPerMB}},
+		{Name: "__consumer_offsets", PartitionSizes: []int64{100 * BytesPerMB}},
+		{Name: "not-sized"},
+	}}}
+
+	in, ok := NewInput(metrics, admin, 100, 0.3)
+	assert.True(t, ok)
+	assert.Equal(t, Input{
+		TotalBytes:                  8 * BytesPerMB,
+		SustainedProduceBytesPerSec: avg,
+		PeakProduceBytesPerSec:      peak,
+		LinkThroughputMBps:          100,
+		Headroom:                    0.3,
+	}, in)
+
+	_, ok = NewInput(types.ProcessedClusterMetrics{}, types.KafkaAdminClientInformation{}, 100, 0.3)
+	assert.False(t, ok, "nothing scanned, nothing to estimate")
+}
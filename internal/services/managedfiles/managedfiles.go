@@ -0,0 +1,271 @@
+// Package managedfiles writes generated projects so they can be generated
+// again without losing what the user changed. A manifest in the project
+// directory records the checksum of every file kcp wrote; on a refresh, a
+// file whose checksum still matches is kcp's to rewrite, and anything else —
+// a file the user edited or added — is left alone.
+package managedfiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ManifestFile is the name of the manifest in the project directory.
+const ManifestFile = ".kcp-managed.json"
+
+// NewFileSuffix is appended to the path of an edited file to write the
+// regenerated content beside it, for the user to merge.
+const NewFileSuffix = ".kcp-new"
+
+// Manifest maps the slash-separated path of each file kcp wrote, relative to
+// the project directory, to the sha256 of the content written.
+type Manifest struct {
+	Files map[string]string `json:"files"`
+}
+
+type Action string
+
+const (
+	// ActionCreate writes a file that does not exist yet.
+	ActionCreate Action = "create"
+	// ActionUpdate rewrites a file the user has not edited.
+	ActionUpdate Action = "update"
+	// ActionUnchanged leaves a file that already has the generated content.
+	ActionUnchanged Action = "unchanged"
+	// ActionKeepEdited leaves a file the user edited, or created where kcp
+	// generates one, and writes the generated content beside it.
+	ActionKeepEdited Action = "keep-edited"
+	// ActionRemove deletes a file kcp no longer generates that the user has
+	// not edited.
+	ActionRemove Action = "remove"
+	// ActionKeepRemoved leaves a file kcp no longer generates that the user
+	// edited.
+	ActionKeepRemoved Action = "keep-removed"
+)
+
+// Change is what a refresh does to one file. Diff is the unified diff from
+// the file on disk to the generated content, set for updates and edited
+// files.
+type Change struct {
+	Path   string
+	Action Action
+	Diff   string
+}
+
+// Write writes files, keyed by slash-separated path relative to dir, and a
+// manifest of them. Existing files are overwritten.
+func Write(dir string, files map[string]string) error {
+	manifest := Manifest{Files: make(map[string]string, len(files))}
+	for _, path := range sortedPaths(files) {
+		if err := writeFile(dir, path, files[path]); err != nil {
+			return err
+		}
+		manifest.Files[path] = checksum(files[path])
+	}
+	return writeManifest(dir, manifest)
+}
+
+// Plan compares files with the project in dir and returns the change a
+// refresh makes to each file, ordered by path. Files in dir that kcp did not
+// write and does not generate are not listed; a refresh never touches them.
+func Plan(dir string, files map[string]string) ([]Change, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, path := range sortedPaths(files) {
+		current, exists, err := readFile(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		change := Change{Path: path}
+		switch {
+		case !exists:
+			change.Action = ActionCreate
+		case current == files[path]:
+			change.Action = ActionUnchanged
+		case manifest.Files[path] == checksum(current):
+			change.Action = ActionUpdate
+			change.Diff = diff(path, current, files[path])
+		default:
+			change.Action = ActionKeepEdited
+			change.Diff = diff(path, current, files[path])
+		}
+		changes = append(changes, change)
+	}
+
+	for _, path := range sortedPaths(manifest.Files) {
+		if _, ok := files[path]; ok {
+			continue
+		}
+		current, exists, err := readFile(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		if manifest.Files[path] == checksum(current) {
+			changes = append(changes, Change{Path: path, Action: ActionRemove})
+		} else {
+			changes = append(changes, Change{Path: path, Action: ActionKeepRemoved})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// Apply makes the planned changes and rewrites the manifest. An edited file
+// keeps its previous checksum, so it stays the user's on later refreshes.
+func Apply(dir string, files map[string]string, changes []Change) error {
+	previous, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	manifest := Manifest{Files: make(map[string]string, len(files))}
+	for _, change := range changes {
+		switch change.Action {
+		case ActionCreate, ActionUpdate:
+			if err := writeFile(dir, change.Path, files[change.Path]); err != nil {
+				return err
+			}
+			manifest.Files[change.Path] = checksum(files[change.Path])
+		case ActionUnchanged:
+			manifest.Files[change.Path] = checksum(files[change.Path])
+		case ActionKeepEdited:
+			if err := writeFile(dir, change.Path+NewFileSuffix, files[change.Path]); err != nil {
+				return err
+			}
+			if sum, ok := previous.Files[change.Path]; ok {
+				manifest.Files[change.Path] = sum
+			}
+		case ActionRemove:
+			if err := os.Remove(filepath.Join(dir, filepath.FromSlash(change.Path))); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", change.Path, err)
+			}
+		case ActionKeepRemoved:
+		}
+	}
+	return writeManifest(dir, manifest)
+}
+
+// ReadManifest reads the manifest of the project in dir. A project without
+// one, e.g. generated before manifests were written, has an empty manifest:
+// every existing file counts as the user's.
+func ReadManifest(dir string) (Manifest, error) {
+	manifest := Manifest{Files: map[string]string{}}
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = map[string]string{}
+	}
+	return manifest, nil
+}
+
+func writeManifest(dir string, manifest Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", ManifestFile, err)
+	}
+	if err := summary.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return nil
+}
+
+func writeFile(dir, path, content string) error {
+	if strings.Contains(path, "..") || filepath.IsAbs(path) {
+		return fmt.Errorf("invalid file path: %s", path)
+	}
+	fullPath := filepath.Join(dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := summary.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func readFile(dir, path string) (string, bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), true, nil
+}
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func diff(path, from, to string) string {
+	text, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "a/" + path,
+		ToFile:   "b/" + path,
+		Context:  3,
+	})
+	return text
+}
+
+func sortedPaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// PrintChanges prints the change to each file a refresh touches, and with
+// diffs, the unified diff of each updated and edited file.
+func PrintChanges(changes []Change, diffs bool) {
+	for _, change := range changes {
+		switch change.Action {
+		case ActionUnchanged:
+			continue
+		case ActionKeepEdited:
+			fmt.Printf("  %-13s %s (edited; generated version in %s)\n", change.Action, change.Path, change.Path+NewFileSuffix)
+		case ActionKeepRemoved:
+			fmt.Printf("  %-13s %s (edited; no longer generated)\n", change.Action, change.Path)
+		default:
+			fmt.Printf("  %-13s %s\n", change.Action, change.Path)
+		}
+	}
+	if !diffs {
+		return
+	}
+	for _, change := range changes {
+		if change.Diff != "" {
+			fmt.Printf("\n%s", change.Diff)
+		}
+	}
+}
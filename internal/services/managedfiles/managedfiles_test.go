@@ -0,0 +1,97 @@
+package managedfiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readString(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func actions(changes []Change) map[string]Action {
+	got := make(map[string]Action, len(changes))
+	for _, c := range changes {
+		got[c.Path] = c.Action
+	}
+	return got
+}
+
+func TestWrite_RecordsManifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, Write(dir, map[string]string{"main.tf": "a\n", "cluster_link/main.tf": "b\n"}))
+
+	assert.Equal(t, "b\n", readString(t, filepath.Join(dir, "cluster_link", "main.tf")))
+	manifest, err := ReadManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, checksum("a\n"), manifest.Files["main.tf"])
+	assert.Len(t, manifest.Files, 2)
+}
+
+func TestPlanAndApply_PreservesUserChanges(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, Write(dir, map[string]string{
+		"main.tf":      "resource \"a\" {}\n",
+		"variables.tf": "variable \"x\" {}\n",
+		"outputs.tf":   "output \"o\" {}\n",
+		"old.tf":       "old\n",
+		"edited_old":   "old\n",
+	}))
+	// The user edits variables.tf and edited_old, and adds their own file.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "variables.tf"), []byte("variable \"x\" { default = 1 }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "edited_old"), []byte("mine\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "extra.tf"), []byte("locals {}\n"), 0644))
+
+	regenerated := map[string]string{
+		"main.tf":      "resource \"a\" {}\nresource \"b\" {}\n",
+		"variables.tf": "variable \"x\" {}\nvariable \"y\" {}\n",
+		"outputs.tf":   "output \"o\" {}\n",
+		"new.tf":       "new\n",
+	}
+	changes, err := Plan(dir, regenerated)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Action{
+		"main.tf":      ActionUpdate,
+		"variables.tf": ActionKeepEdited,
+		"outputs.tf":   ActionUnchanged,
+		"new.tf":       ActionCreate,
+		"old.tf":       ActionRemove,
+		"edited_old":   ActionKeepRemoved,
+	}, actions(changes))
+	for _, c := range changes {
+		if c.Path == "main.tf" {
+			assert.Contains(t, c.Diff, "+resource \"b\" {}")
+		}
+	}
+
+	require.NoError(t, Apply(dir, regenerated, changes))
+
+	assert.Equal(t, regenerated["main.tf"], readString(t, filepath.Join(dir, "main.tf")))
+	assert.Equal(t, "variable \"x\" { default = 1 }\n", readString(t, filepath.Join(dir, "variables.tf")), "the user's edit is kept")
+	assert.Equal(t, regenerated["variables.tf"], readString(t, filepath.Join(dir, "variables.tf"+NewFileSuffix)))
+	assert.Equal(t, "locals {}\n", readString(t, filepath.Join(dir, "extra.tf")), "user files are not touched")
+	assert.NoFileExists(t, filepath.Join(dir, "old.tf"))
+	assert.FileExists(t, filepath.Join(dir, "edited_old"))
+
+	// The edited file stays the user's on the next refresh.
+	changes, err = Plan(dir, regenerated)
+	require.NoError(t, err)
+	assert.Equal(t, ActionKeepEdited, actions(changes)["variables.tf"])
+	assert.Equal(t, ActionUnchanged, actions(changes)["main.tf"])
+}
+
+func TestPlan_WithoutManifestKeepsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte("hand written\n"), 0644))
+
+	changes, err := Plan(dir, map[string]string{"main.tf": "generated\n"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Action{"main.tf": ActionKeepEdited}, actions(changes))
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/glamour"
+
+	"github.com/confluentinc/kcp/internal/summary"
 )
 
 // PrintOptions configures where and how to print the markdown
@@ -197,6 +199,7 @@ func (m *Markdown) Print(opts ...PrintOptions) error {
 		if err != nil {
 			return fmt.Errorf("failed to write markdown to file %s: %v", options.ToFile, err)
 		}
+		summary.FileWritten(options.ToFile)
 		slog.Info("Markdown saved to file", "file", options.ToFile)
 	}
 
@@ -3,6 +3,7 @@ package metrics
 import (
 	"bytes"
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 	"testing"
@@ -11,6 +12,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/types"
 )
 
 // fakeCWClient is an in-memory cloudWatchGetMetricDataAPI for tests.
@@ -121,6 +124,83 @@ func TestResultStitcher_ConcatenatesPerIdPreservingOrder(t *testing.T) {
 	}
 }
 
+func TestResultStitcher_SeparatesMultiSeriesIdByLabel(t *testing.T) {
+	s := newResultStitcher()
+	t0, t1 := time.Unix(0, 0), time.Unix(60, 0)
+
+	s.add([]cloudwatchtypes.MetricDataResult{
+		{Id: aws.String("m_broker_disk_used"), Label: aws.String("KafkaDataLogsDiskUsed Broker 1"), Timestamps: []time.Time{t0}, Values: []float64{40}},
+		{Id: aws.String("m_broker_disk_used"), Label: aws.String("KafkaDataLogsDiskUsed Broker 2"), Timestamps: []time.Time{t0}, Values: []float64{50}},
+	})
+	s.add([]cloudwatchtypes.MetricDataResult{
+		{Id: aws.String("m_broker_disk_used"), Label: aws.String("KafkaDataLogsDiskUsed Broker 2"), Timestamps: []time.Time{t1}, Values: []float64{55}},
+		{Id: aws.String("m_broker_disk_used"), Label: aws.String("KafkaDataLogsDiskUsed Broker 1"), Timestamps: []time.Time{t1}, Values: []float64{45}},
+	})
+
+	out := s.output()
+	if len(out.MetricDataResults) != 2 {
+		t.Fatalf("expected 2 per-broker series, got %d", len(out.MetricDataResults))
+	}
+	b1, b2 := out.MetricDataResults[0], out.MetricDataResults[1]
+	if aws.ToString(b1.Label) != "KafkaDataLogsDiskUsed Broker 1" || len(b1.Values) != 2 || b1.Values[1] != 45 {
+		t.Errorf("broker 1 series not stitched: %s %v", aws.ToString(b1.Label), b1.Values)
+	}
+	if aws.ToString(b2.Label) != "KafkaDataLogsDiskUsed Broker 2" || len(b2.Values) != 2 || b2.Values[1] != 55 {
+		t.Errorf("broker 2 series not stitched: %s %v", aws.ToString(b2.Label), b2.Values)
+	}
+}
+
+func TestCollectTopicThroughput_SumsBrokersPerTopic(t *testing.T) {
+	t0, t1 := time.Unix(0, 0), time.Unix(3600, 0)
+	series := func(label string, values ...float64) cloudwatchtypes.MetricDataResult {
+		return cloudwatchtypes.MetricDataResult{
+			Id: aws.String("m_topic_bytesinpersec"), Label: aws.String(label),
+			Timestamps: []time.Time{t1, t0}, Values: values, StatusCode: cloudwatchtypes.StatusCodeComplete,
+		}
+	}
+	fake := &fakeCWClient{respond: func(_ int, _ *cloudwatch.GetMetricDataInput) (*cloudwatch.GetMetricDataOutput, error) {
+		// One series per topic and broker, newest first.
+		return &cloudwatch.GetMetricDataOutput{MetricDataResults: []cloudwatchtypes.MetricDataResult{
+			series("BytesInPerSec Topic orders", 20, 10),
+			series("BytesInPerSec Topic payments", 5, 5),
+			series("BytesInPerSec Topic orders", 40, 30),
+		}}, nil
+	}}
+	ms := &MetricService{client: fake}
+	cluster := kafkatypes.Cluster{ClusterName: aws.String("c"), ClusterArn: aws.String("arn:aws:kafka:us-east-1:123456789012:cluster/c/uuid")}
+	window := types.CloudWatchTimeWindow{StartTime: t0, EndTime: t1.Add(time.Hour), Period: 3600}
+
+	results, infos := ms.collectTopicThroughput(context.Background(), cluster, `{AWS/Kafka,"Cluster Name","Broker ID","Topic"}`, "Cluster Name, Broker ID, Topic", window)
+
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 query infos, got %d", len(infos))
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected one series per topic, got %d", len(results))
+	}
+	orders := results[0]
+	if aws.ToString(orders.Label) != "BytesInPerSec Topic orders" {
+		t.Fatalf("unexpected first series %s", aws.ToString(orders.Label))
+	}
+	if len(orders.Values) != 2 || !orders.Timestamps[0].Equal(t0) || orders.Values[0] != 40 || orders.Values[1] != 60 {
+		t.Errorf("orders brokers not summed in time order: %v %v", orders.Timestamps, orders.Values)
+	}
+}
+
+func TestCollectTopicThroughput_FailureKeepsClusterMetrics(t *testing.T) {
+	fake := &fakeCWClient{respond: func(_ int, _ *cloudwatch.GetMetricDataInput) (*cloudwatch.GetMetricDataOutput, error) {
+		return nil, errors.New("AccessDenied")
+	}}
+	ms := &MetricService{client: fake}
+	window := types.CloudWatchTimeWindow{StartTime: time.Unix(0, 0), EndTime: time.Unix(7200, 0), Period: 3600}
+
+	results, infos := ms.collectTopicThroughput(context.Background(), kafkatypes.Cluster{ClusterName: aws.String("c")}, `{AWS/Kafka,"Cluster Name","Topic"}`, "Cluster Name, Topic", window)
+
+	if results != nil || infos != nil {
+		t.Errorf("expected no topic series after a failure, got %v %v", results, infos)
+	}
+}
+
 func TestResultStitcher_MarkPartial(t *testing.T) {
 	s := newResultStitcher()
 	if s.partial {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
@@ -130,6 +131,14 @@ func (ms *MetricService) ProcessProvisionedCluster(ctx context.Context, cluster
 		return nil, err
 	}
 
+	// MSK only publishes per-topic metrics at the topic-level monitoring
+	// levels; without them the cost report cannot split spend by topic.
+	var topicResults []cloudwatchtypes.MetricDataResult
+	var topicQueryInfos []types.MetricQueryInfo
+	if topicLevelMonitoring(metricsMetadata.EnhancedMonitoring) {
+		topicResults, topicQueryInfos = ms.collectTopicThroughput(ctx, cluster, `{AWS/Kafka,"Cluster Name","Broker ID","Topic"}`, "Cluster Name, Broker ID, Topic", timeWindow)
+	}
+
 	// for express brokers there is no storage info
 	if metricsMetadata.BrokerType == types.BrokerTypeExpress {
 		allQueries := make([]cloudwatchtypes.MetricDataQuery, 0, len(brokerQueries)+len(clusterQueries))
@@ -139,10 +148,11 @@ func (ms *MetricService) ProcessProvisionedCluster(ctx context.Context, cluster
 		allQueryInfos = append(allQueryInfos, brokerQueryInfos...)
 		allQueryInfos = append(allQueryInfos, clusterQueryInfos...)
 		populateCLICommands(allQueryInfos, allQueries, timeWindow.StartTime, timeWindow.EndTime, regionFromArn(cluster.ClusterArn))
+		results := append(brokerQueryResult.MetricDataResults, clusterQueryResult.MetricDataResults...)
 		return &types.ClusterMetrics{
 			MetricMetadata: metricsMetadata,
-			Results:        append(brokerQueryResult.MetricDataResults, clusterQueryResult.MetricDataResults...),
-			QueryInfo:      allQueryInfos,
+			Results:        append(results, topicResults...),
+			QueryInfo:      append(allQueryInfos, topicQueryInfos...),
 		}, nil
 	}
 
@@ -163,6 +173,15 @@ func (ms *MetricService) ProcessProvisionedCluster(ctx context.Context, cluster
 		return nil, err
 	}
 
+	// Per-broker disk usage shares its SEARCH expression with the local storage
+	// total, so it runs and is matched to its CLI command on its own.
+	brokerStorageQueries, brokerStorageQueryInfos := ms.buildBrokerStorageUtilizationQuery(clusterName, timeWindow.Period)
+	brokerStorageQueryResult, err := ms.executeChunkedQuery(ctx, brokerStorageQueries, timeWindow.StartTime, timeWindow.EndTime, timeWindow.Period, brokerStorageSeriesEstimate(numBrokers), "broker storage metrics for "+clusterName)
+	if err != nil {
+		return nil, err
+	}
+	populateCLICommands(brokerStorageQueryInfos, brokerStorageQueries, timeWindow.StartTime, timeWindow.EndTime, regionFromArn(cluster.ClusterArn))
+
 	remoteStorageQueries, remoteStorageQueryInfos := ms.buildRemoteStorageUsageQuery(clusterName, timeWindow.Period)
 	remoteStorageQueryResult, err := ms.executeChunkedQuery(ctx, remoteStorageQueries, timeWindow.StartTime, timeWindow.EndTime, timeWindow.Period, storageSeriesEstimate(numBrokers), "remote-storage metrics for "+clusterName)
 	if err != nil {
@@ -173,12 +192,14 @@ func (ms *MetricService) ProcessProvisionedCluster(ctx context.Context, cluster
 	combinedResults := make([]cloudwatchtypes.MetricDataResult, 0,
 		len(brokerQueryResult.MetricDataResults)+len(clusterQueryResult.MetricDataResults)+
 			len(clientConnectionQueryResult.MetricDataResults)+len(storageQueryResult.MetricDataResults)+
-			len(remoteStorageQueryResult.MetricDataResults))
+			len(brokerStorageQueryResult.MetricDataResults)+len(remoteStorageQueryResult.MetricDataResults))
 	combinedResults = append(combinedResults, brokerQueryResult.MetricDataResults...)
 	combinedResults = append(combinedResults, clusterQueryResult.MetricDataResults...)
 	combinedResults = append(combinedResults, clientConnectionQueryResult.MetricDataResults...)
 	combinedResults = append(combinedResults, storageQueryResult.MetricDataResults...)
+	combinedResults = append(combinedResults, brokerStorageQueryResult.MetricDataResults...)
 	combinedResults = append(combinedResults, remoteStorageQueryResult.MetricDataResults...)
+	combinedResults = append(combinedResults, topicResults...)
 
 	// Combine all query infos and populate CLI commands
 	allQueries := make([]cloudwatchtypes.MetricDataQuery, 0,
@@ -198,6 +219,8 @@ func (ms *MetricService) ProcessProvisionedCluster(ctx context.Context, cluster
 	allQueryInfos = append(allQueryInfos, clusterQueryInfos...)
 	allQueryInfos = append(allQueryInfos, localStorageQueryInfos...)
 	allQueryInfos = append(allQueryInfos, remoteStorageQueryInfos...)
+	allQueryInfos = append(allQueryInfos, brokerStorageQueryInfos...)
+	allQueryInfos = append(allQueryInfos, topicQueryInfos...)
 	populateCLICommands(allQueryInfos, allQueries, timeWindow.StartTime, timeWindow.EndTime, regionFromArn(cluster.ClusterArn))
 
 	clusterMetrics := types.ClusterMetrics{
@@ -235,15 +258,102 @@ func (ms *MetricService) ProcessServerlessCluster(ctx context.Context, cluster k
 		return nil, fmt.Errorf("failed to execute serverless metric queries: %w", err)
 	}
 
+	topicResults, topicQueryInfos := ms.collectTopicThroughput(ctx, cluster, `{AWS/Kafka,"Cluster Name","Topic"}`, "Cluster Name, Topic", timeWindow)
+
 	clusterMetrics := types.ClusterMetrics{
 		MetricMetadata: metricsMetadata,
-		Results:        queryResult.MetricDataResults,
-		QueryInfo:      queryInfos,
+		Results:        append(queryResult.MetricDataResults, topicResults...),
+		QueryInfo:      append(queryInfos, topicQueryInfos...),
 	}
 
 	return &clusterMetrics, nil
 }
 
+// topicLevelMonitoring reports whether a provisioned cluster's enhanced
+// monitoring level publishes per-topic metrics.
+func topicLevelMonitoring(level string) bool {
+	return level == string(kafkatypes.EnhancedMonitoringPerTopicPerBroker) ||
+		level == string(kafkatypes.EnhancedMonitoringPerTopicPerPartition)
+}
+
+// collectTopicThroughput fetches BytesInPerSec and BytesOutPerSec per topic.
+// schema is the SEARCH schema the topic metrics are published under, which
+// includes the broker on provisioned clusters. Per-topic throughput only feeds
+// the cost allocation by topic, so a failure is logged and the cluster keeps
+// its other metrics.
+func (ms *MetricService) collectTopicThroughput(ctx context.Context, cluster kafkatypes.Cluster, schema, dimensions string, timeWindow types.CloudWatchTimeWindow) ([]cloudwatchtypes.MetricDataResult, []types.MetricQueryInfo) {
+	clusterName := aws.ToString(cluster.ClusterName)
+	queries, queryInfos := ms.buildTopicThroughputQueries(clusterName, schema, dimensions, timeWindow.Period)
+	result, err := ms.executeChunkedQuery(ctx, queries, timeWindow.StartTime, timeWindow.EndTime, timeWindow.Period, 0, "topic metrics for "+clusterName)
+	if err != nil {
+		slog.Warn("⚠️ failed to collect per-topic throughput; the cost report cannot allocate this cluster by topic", "cluster", clusterName, "error", err)
+		return nil, nil
+	}
+	populateCLICommands(queryInfos, queries, timeWindow.StartTime, timeWindow.EndTime, regionFromArn(cluster.ClusterArn))
+
+	results := make([]cloudwatchtypes.MetricDataResult, 0, len(result.MetricDataResults))
+	for _, r := range result.MetricDataResults {
+		results = append(results, sumByTimestamp(r))
+	}
+	return results, queryInfos
+}
+
+// buildTopicThroughputQueries returns BytesInPerSec and BytesOutPerSec with
+// one series per topic and broker, labelled with types.TopicBytesInLabelPrefix
+// or types.TopicBytesOutLabelPrefix followed by the topic name. The series of
+// a topic's brokers share a label, so the result stitcher collects them into
+// one result for sumByTimestamp to add up.
+func (ms *MetricService) buildTopicThroughputQueries(clusterName, schema, dimensions string, period int32) ([]cloudwatchtypes.MetricDataQuery, []types.MetricQueryInfo) {
+	metrics := []struct {
+		name, labelPrefix string
+	}{
+		{"BytesInPerSec", types.TopicBytesInLabelPrefix},
+		{"BytesOutPerSec", types.TopicBytesOutLabelPrefix},
+	}
+
+	var queries []cloudwatchtypes.MetricDataQuery
+	var queryInfos []types.MetricQueryInfo
+	for _, metric := range metrics {
+		searchExpr := fmt.Sprintf("SEARCH('%s MetricName=\"%s\" \"Cluster Name\"=\"%s\"', 'Average', %d)", schema, metric.name, clusterName, period)
+		queries = append(queries, cloudwatchtypes.MetricDataQuery{
+			Id:         aws.String(fmt.Sprintf("m_topic_%s", strings.ToLower(metric.name))),
+			Expression: aws.String(searchExpr),
+			Label:      aws.String(metric.labelPrefix + "${PROP('Dim.Topic')}"),
+			ReturnData: aws.Bool(true),
+		})
+		info := newSearchMetricQueryInfo(metric.name+" (per topic)", searchExpr, "", "Average", period, dimensions)
+		info.AggregationNote = fmt.Sprintf("Uses SEARCH to return %s for each topic, labelled by topic name, and sums each topic's brokers per timestamp. "+
+			"A SEARCH returns at most 500 series, so topics beyond that on large clusters are missing. "+
+			"The series feed the cost allocation by topic in the cost report.", metric.name)
+		queryInfos = append(queryInfos, info)
+	}
+	return queries, queryInfos
+}
+
+// sumByTimestamp adds up the values a result holds for the same timestamp,
+// returning them in time order.
+func sumByTimestamp(r cloudwatchtypes.MetricDataResult) cloudwatchtypes.MetricDataResult {
+	sums := make(map[time.Time]float64, len(r.Timestamps))
+	var timestamps []time.Time
+	for i, ts := range r.Timestamps {
+		if i >= len(r.Values) {
+			break
+		}
+		if _, ok := sums[ts]; !ok {
+			timestamps = append(timestamps, ts)
+		}
+		sums[ts] += r.Values[i]
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	r.Timestamps = timestamps
+	r.Values = make([]float64, len(timestamps))
+	for i, ts := range timestamps {
+		r.Values[i] = sums[ts]
+	}
+	return r
+}
+
 // Private Helper Functions - Query Building
 func (ms *MetricService) buildBrokerMetricQueries(clusterName string, period int32) ([]cloudwatchtypes.MetricDataQuery, []types.MetricQueryInfo) {
 	metricStatMap := map[string]string{
@@ -349,6 +459,27 @@ func (ms *MetricService) buildLocalStorageUsageQuery(clusterName string, period
 	return queries, queryInfos
 }
 
+// buildBrokerStorageUtilizationQuery returns KafkaDataLogsDiskUsed (percent of
+// the broker's EBS volume) as one series per broker, labelled with
+// types.BrokerDiskUsedLabelPrefix followed by the broker ID.
+func (ms *MetricService) buildBrokerStorageUtilizationQuery(clusterName string, period int32) ([]cloudwatchtypes.MetricDataQuery, []types.MetricQueryInfo) {
+	metricName := "KafkaDataLogsDiskUsed"
+	searchTemplate := "SEARCH('{AWS/Kafka,\"Cluster Name\",\"Broker ID\"} MetricName=\"%s\" \"Cluster Name\"=\"%s\"', 'Maximum', %d)"
+	searchExpr := fmt.Sprintf(searchTemplate, metricName, clusterName, period)
+	queries := []cloudwatchtypes.MetricDataQuery{
+		{
+			Id:         aws.String("m_broker_disk_used"),
+			Expression: aws.String(searchExpr),
+			Label:      aws.String(types.BrokerDiskUsedLabelPrefix + "${PROP('Dim.Broker ID')}"),
+			ReturnData: aws.Bool(true),
+		},
+	}
+	info := newSearchMetricQueryInfo(metricName+" (per broker)", searchExpr, "", "Maximum", period, "Cluster Name, Broker ID")
+	info.AggregationNote = "Uses SEARCH to return KafkaDataLogsDiskUsed (percent of EBS volume used) for each broker without aggregation. " +
+		"One series is returned per broker, labelled by broker ID, and feeds the storage headroom analysis in the metrics report."
+	return queries, []types.MetricQueryInfo{info}
+}
+
 func (ms *MetricService) buildRemoteStorageUsageQuery(clusterName string, period int32) ([]cloudwatchtypes.MetricDataQuery, []types.MetricQueryInfo) {
 	metricName := "RemoteLogSizeBytes"
 	searchTemplate := "SEARCH('{AWS/Kafka,\"Cluster Name\",\"Broker ID\"} MetricName=\"%s\" \"Cluster Name\"=\"%s\"', 'Maximum', %d)"
@@ -697,6 +828,8 @@ func (ms *MetricService) executeWindow(ctx context.Context, queries []cloudwatch
 
 // resultStitcher concatenates MetricDataResult points per Id across sub-window
 // calls, preserving first-seen Id order and tracking whether any chunk was partial.
+// A SEARCH query returning one series per broker yields several results with the
+// same Id; those are told apart by their (differing) labels.
 type resultStitcher struct {
 	order   []string
 	byID    map[string]*cloudwatchtypes.MetricDataResult
@@ -711,7 +844,7 @@ func (s *resultStitcher) markPartial() { s.partial = true }
 
 func (s *resultStitcher) add(results []cloudwatchtypes.MetricDataResult) {
 	for _, r := range results {
-		id := aws.ToString(r.Id)
+		id := s.key(r)
 		existing, ok := s.byID[id]
 		if !ok {
 			cp := r
@@ -730,6 +863,19 @@ func (s *resultStitcher) add(results []cloudwatchtypes.MetricDataResult) {
 	}
 }
 
+// key returns the stitching key for a result: its Id, qualified by its label
+// when an earlier series with the same Id carries a different label.
+func (s *resultStitcher) key(r cloudwatchtypes.MetricDataResult) string {
+	id := aws.ToString(r.Id)
+	if r.Label == nil {
+		return id
+	}
+	if first, ok := s.byID[id]; ok && first.Label != nil && *first.Label != *r.Label {
+		return id + "\x00" + *r.Label
+	}
+	return id
+}
+
 // output returns the stitched results. Per-window Messages are intentionally not
 // carried through (they are consumed during collection via isPartial); current
 // callers only read MetricDataResults.
@@ -759,9 +905,10 @@ func chunkSeconds(period int32, seriesEstimate int) int64 {
 // Series-count estimates count fan-out series (one per broker) PLUS the returned
 // math-result series, since both consume the datapoint budget. They err high so
 // chunks never exceed the cap; the fallback splitter self-heals any under-estimate.
-func brokerSeriesEstimate(numBrokers int) int     { return 4 * (numBrokers + 1) }                    // 4 metrics
-func clientConnSeriesEstimate(numBrokers int) int { return 2 * (numBrokers*maxClientAuthTypes + 1) } // 2 stats
-func storageSeriesEstimate(numBrokers int) int    { return numBrokers + 2 }                          // 1 returned + 1 intermediate
+func brokerSeriesEstimate(numBrokers int) int        { return 4 * (numBrokers + 1) }                    // 4 metrics
+func clientConnSeriesEstimate(numBrokers int) int    { return 2 * (numBrokers*maxClientAuthTypes + 1) } // 2 stats
+func storageSeriesEstimate(numBrokers int) int       { return numBrokers + 2 }                          // 1 returned + 1 intermediate
+func brokerStorageSeriesEstimate(numBrokers int) int { return numBrokers }                              // 1 returned per broker
 
 func getBrokerType(instanceType string) types.BrokerType {
 	if strings.HasPrefix(instanceType, "express.") {
@@ -229,6 +229,21 @@ func TestBuildStorageQueries(t *testing.T) {
 		assert.Contains(t, info.MathExpression, "1000")
 	})
 
+	t.Run("per-broker storage utilization", func(t *testing.T) {
+		queries, queryInfos := ms.buildBrokerStorageUtilizationQuery("test-cluster", 86400)
+
+		require.Len(t, queries, 1) // SEARCH returned per broker
+		assert.True(t, *queries[0].ReturnData)
+		assert.Contains(t, *queries[0].Label, types.BrokerDiskUsedLabelPrefix)
+		assert.Contains(t, *queries[0].Label, "Dim.Broker ID")
+		require.Len(t, queryInfos, 1)
+
+		info := queryInfos[0]
+		assert.Equal(t, "Maximum", info.Statistic)
+		assert.Contains(t, info.SearchExpression, "KafkaDataLogsDiskUsed")
+		assert.Empty(t, info.MathExpression)
+	})
+
 	t.Run("remote storage", func(t *testing.T) {
 		queries, queryInfos := ms.buildRemoteStorageUsageQuery("test-cluster", 86400)
 
@@ -264,6 +279,28 @@ func TestBuildServerlessMetricQueries(t *testing.T) {
 	assert.True(t, metricNames["MessagesInPerSec"])
 }
 
+func TestBuildTopicThroughputQueries(t *testing.T) {
+	ms := &MetricService{client: nil}
+	queries, queryInfos := ms.buildTopicThroughputQueries("test-cluster", `{AWS/Kafka,"Cluster Name","Broker ID","Topic"}`, "Cluster Name, Broker ID, Topic", 3600)
+
+	require.Len(t, queries, 2)
+	require.Len(t, queryInfos, 2)
+	assert.Equal(t, types.TopicBytesInLabelPrefix+"${PROP('Dim.Topic')}", aws.ToString(queries[0].Label))
+	assert.Equal(t, types.TopicBytesOutLabelPrefix+"${PROP('Dim.Topic')}", aws.ToString(queries[1].Label))
+	assert.Equal(t, `SEARCH('{AWS/Kafka,"Cluster Name","Broker ID","Topic"} MetricName="BytesInPerSec" "Cluster Name"="test-cluster"', 'Average', 3600)`, aws.ToString(queries[0].Expression))
+	for i, q := range queries {
+		assert.True(t, aws.ToBool(q.ReturnData), "per-topic series are returned without aggregation")
+		assert.Equal(t, "Cluster Name, Broker ID, Topic", queryInfos[i].Dimensions)
+	}
+}
+
+func TestTopicLevelMonitoring(t *testing.T) {
+	assert.False(t, topicLevelMonitoring(string(kafkatypes.EnhancedMonitoringDefault)))
+	assert.False(t, topicLevelMonitoring(string(kafkatypes.EnhancedMonitoringPerBroker)))
+	assert.True(t, topicLevelMonitoring(string(kafkatypes.EnhancedMonitoringPerTopicPerBroker)))
+	assert.True(t, topicLevelMonitoring(string(kafkatypes.EnhancedMonitoringPerTopicPerPartition)))
+}
+
 func TestPopulateCLICommands(t *testing.T) {
 	ms := &MetricService{client: nil}
 	startTime := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
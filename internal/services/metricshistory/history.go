@@ -0,0 +1,234 @@
+// Package metricshistory keeps every metrics and cost scan in an append-only
+// sidecar next to the state file. A discover or scan replaces the metrics in
+// the state file, so on their own they are a single sample; the history lets
+// reports show how throughput, storage and cost grow month over month.
+package metricshistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/summary"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// FileName is the history file written next to the state file.
+const FileName = "kcp-metrics-history.jsonl"
+
+const monthFormat = "2006-01"
+
+// PathFor returns the history file that belongs to stateFile.
+func PathFor(stateFile string) string {
+	return filepath.Join(filepath.Dir(stateFile), FileName)
+}
+
+// Snapshot is one scan of a cluster's metrics (ClusterID set) or of a
+// region's costs (ClusterID empty), broken down by calendar month.
+type Snapshot struct {
+	RecordedAt  time.Time `json:"recorded_at"`
+	ClusterID   string    `json:"cluster_id,omitempty"`
+	Region      string    `json:"region,omitempty"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	// Metrics holds the cluster-level metric aggregates of each month
+	// (YYYY-MM) in the window. Per-broker and per-topic series are left out.
+	Metrics map[string]map[string]types.MetricAggregate `json:"metrics,omitempty"`
+	// Costs holds the region's unblended cost of each month in the window.
+	Costs map[string]MonthlyCost `json:"costs,omitempty"`
+}
+
+// MonthlyCost is a month's unblended cost and the number of days of the
+// month it covers; months at the edges of a window are partial.
+type MonthlyCost struct {
+	Total float64 `json:"total"`
+	Days  int     `json:"days"`
+}
+
+func (s Snapshot) key() string {
+	return s.ClusterID + "|" + s.Region + "|" + s.WindowStart.UTC().Format(time.RFC3339) + "|" + s.WindowEnd.UTC().Format(time.RFC3339)
+}
+
+// Load reads the snapshots in path. A missing file is an empty history.
+func Load(path string) ([]Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open metrics history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var snapshots []Snapshot
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("invalid metrics history entry at %s:%d: %w", path, line, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics history: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Record appends a snapshot of each cluster's metrics and each region's
+// costs in state to the history at path, skipping any whose window is
+// already recorded (commands that rewrite the state without re-scanning
+// leave the windows unchanged). It returns the number of snapshots added.
+func Record(path string, state *types.State) (int, error) {
+	existing, err := Load(path)
+	if err != nil {
+		return 0, err
+	}
+	recorded := make(map[string]bool, len(existing))
+	for _, snapshot := range existing {
+		recorded[snapshot.key()] = true
+	}
+
+	var added []Snapshot
+	for _, snapshot := range Snapshots(state, time.Now().UTC()) {
+		if !recorded[snapshot.key()] {
+			added = append(added, snapshot)
+		}
+	}
+	if len(added) == 0 {
+		return 0, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open metrics history: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	for _, snapshot := range added {
+		if err := encoder.Encode(snapshot); err != nil {
+			_ = file.Close()
+			return 0, fmt.Errorf("failed to write metrics history: %w", err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write metrics history: %w", err)
+	}
+	summary.FileWritten(path)
+	return len(added), nil
+}
+
+// Snapshots breaks the metrics and costs in state down by month, recorded
+// at now. Clusters without metrics and regions without costs are skipped.
+func Snapshots(state *types.State, now time.Time) []Snapshot {
+	if state == nil {
+		return nil
+	}
+	processed := report.NewReportService().ProcessState(*state)
+
+	var snapshots []Snapshot
+	for _, source := range processed.Sources {
+		if source.MSKData != nil {
+			for _, region := range source.MSKData.Regions {
+				if costs := monthlyCosts(region.Costs.Results); len(costs) > 0 {
+					snapshots = append(snapshots, Snapshot{
+						RecordedAt:  now,
+						Region:      region.Name,
+						WindowStart: region.Costs.Metadata.StartDate,
+						WindowEnd:   region.Costs.Metadata.EndDate,
+						Costs:       costs,
+					})
+				}
+				for _, cluster := range region.Clusters {
+					if snapshot, ok := clusterSnapshot(cluster.Arn, region.Name, cluster.ClusterMetrics, now); ok {
+						snapshots = append(snapshots, snapshot)
+					}
+				}
+			}
+		}
+		if source.OSKData != nil {
+			for _, cluster := range source.OSKData.Clusters {
+				if cluster.ClusterMetrics == nil {
+					continue
+				}
+				if snapshot, ok := clusterSnapshot(cluster.ID, "", *cluster.ClusterMetrics, now); ok {
+					snapshots = append(snapshots, snapshot)
+				}
+			}
+		}
+	}
+	return snapshots
+}
+
+func clusterSnapshot(clusterID, region string, metrics types.ProcessedClusterMetrics, now time.Time) (Snapshot, bool) {
+	byMonth := make(map[string][]types.ProcessedMetric)
+	for _, metric := range metrics.Metrics {
+		if metric.Value == nil || isPerEntityLabel(metric.Label) {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, metric.Start)
+		if err != nil {
+			continue
+		}
+		month := start.UTC().Format(monthFormat)
+		byMonth[month] = append(byMonth[month], metric)
+	}
+	if len(byMonth) == 0 {
+		return Snapshot{}, false
+	}
+
+	months := make(map[string]map[string]types.MetricAggregate, len(byMonth))
+	for month, monthMetrics := range byMonth {
+		months[month] = report.CalculateMetricsAggregates(monthMetrics)
+	}
+	return Snapshot{
+		RecordedAt:  now,
+		ClusterID:   clusterID,
+		Region:      region,
+		WindowStart: metrics.Metadata.StartDate,
+		WindowEnd:   metrics.Metadata.EndDate,
+		Metrics:     months,
+	}, true
+}
+
+// isPerEntityLabel reports whether a series is one broker's or one topic's
+// rather than the cluster's.
+func isPerEntityLabel(label string) bool {
+	return strings.HasPrefix(label, types.BrokerDiskUsedLabelPrefix) ||
+		strings.HasPrefix(label, types.TopicBytesInLabelPrefix) ||
+		strings.HasPrefix(label, types.TopicBytesOutLabelPrefix)
+}
+
+func monthlyCosts(costs []report.ProcessedCost) map[string]MonthlyCost {
+	months := make(map[string]MonthlyCost)
+	counted := make(map[string]bool)
+	for _, cost := range costs {
+		start, err := time.Parse("2006-01-02", cost.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse("2006-01-02", cost.End)
+		if err != nil {
+			continue
+		}
+		month := start.Format(monthFormat)
+		entry := months[month]
+		entry.Total += cost.Values.UnblendedCost
+		// Each time period holds one row per service and usage type; count
+		// its days once.
+		if period := cost.Start + "|" + cost.End; !counted[period] {
+			counted[period] = true
+			entry.Days += int(end.Sub(start).Hours() / 24)
+		}
+		months[month] = entry
+	}
+	return months
+}
@@ -0,0 +1,137 @@
+package metricshistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	costexplorertypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clusterArn = "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc"
+
+func day(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func monthlyCost(start, end, amount string) costexplorertypes.ResultByTime {
+	return costexplorertypes.ResultByTime{
+		TimePeriod: &costexplorertypes.DateInterval{Start: aws.String(start), End: aws.String(end)},
+		Groups: []costexplorertypes.Group{{
+			Keys:    []string{types.ServiceMSK, "USE1-Kafka.m5.large"},
+			Metrics: map[string]costexplorertypes.MetricValue{"UnblendedCost": {Amount: aws.String(amount)}},
+		}},
+	}
+}
+
+// stateWithScan is a state holding a daily BytesInPerSec series that doubles
+// from January to February, a per-broker disk series, and the region's cost
+// for a partial December and full January and February.
+func stateWithScan() *types.State {
+	metrics := types.ClusterMetrics{
+		MetricMetadata: types.MetricMetadata{StartDate: day("2025-01-01"), EndDate: day("2025-03-01"), Period: 86400},
+		Results: []cloudwatchtypes.MetricDataResult{
+			{
+				Label:      aws.String("BytesInPerSec"),
+				Timestamps: []time.Time{day("2025-01-10"), day("2025-01-20"), day("2025-02-10")},
+				Values:     []float64{90, 110, 200},
+			},
+			{
+				Label:      aws.String(types.BrokerDiskUsedLabelPrefix + "1"),
+				Timestamps: []time.Time{day("2025-01-10")},
+				Values:     []float64{40},
+			},
+		},
+	}
+	return &types.State{
+		MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{{
+			Name: "us-east-1",
+			Costs: types.CostInformation{
+				CostMetadata: types.CostMetadata{StartDate: day("2024-12-15"), EndDate: day("2025-03-01")},
+				CostResults: []costexplorertypes.ResultByTime{
+					monthlyCost("2024-12-15", "2025-01-01", "50"),
+					monthlyCost("2025-01-01", "2025-02-01", "100"),
+					monthlyCost("2025-02-01", "2025-03-01", "110"),
+				},
+			},
+			Clusters: []types.DiscoveredCluster{{Name: "orders", Arn: clusterArn, Region: "us-east-1", ClusterMetrics: metrics}},
+		}}},
+	}
+}
+
+func TestRecord_AppendsOnlyNewWindows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	state := stateWithScan()
+
+	added, err := Record(path, state)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added, "one cost and one cluster snapshot")
+
+	added, err = Record(path, state)
+	require.NoError(t, err)
+	assert.Zero(t, added, "an unchanged window is not recorded twice")
+
+	snapshots, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+
+	var cluster Snapshot
+	for _, snapshot := range snapshots {
+		if snapshot.ClusterID == clusterArn {
+			cluster = snapshot
+		}
+	}
+	require.Contains(t, cluster.Metrics, "2025-01")
+	assert.InDelta(t, 100, *cluster.Metrics["2025-01"]["BytesInPerSec"].Average, 0.001)
+	for _, aggregates := range cluster.Metrics {
+		assert.NotContains(t, aggregates, types.BrokerDiskUsedLabelPrefix+"1", "per-broker series are left out")
+	}
+}
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	snapshots, err := Load(filepath.Join(t.TempDir(), FileName))
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestClusterTrends_GrowthAcrossSnapshots(t *testing.T) {
+	first := Snapshots(stateWithScan(), day("2025-03-01"))
+
+	// A later, shorter scan covers March and only part of February; its
+	// February has fewer samples than the earlier scan's and loses to it.
+	later := Snapshot{
+		RecordedAt: day("2025-04-01"),
+		ClusterID:  clusterArn,
+		Metrics: map[string]map[string]types.MetricAggregate{
+			"2025-02": {"BytesInPerSec": {Average: aws.Float64(500), Count: 0}},
+			"2025-03": {"BytesInPerSec": {Average: aws.Float64(400), Count: 1}},
+		},
+	}
+
+	trends := ClusterTrends(append(first, later), clusterArn)
+	require.Len(t, trends, 1)
+	trend := trends[0]
+	assert.Equal(t, "BytesInPerSec", trend.Metric)
+	assert.Equal(t, []MonthlyValue{{"2025-01", 100}, {"2025-02", 200}, {"2025-03", 400}}, trend.Points)
+	require.NotNil(t, trend.MonthlyGrowthPercent)
+	assert.InDelta(t, 100, *trend.MonthlyGrowthPercent, 0.001, "100 to 400 over two months is 100% a month")
+	assert.True(t, HasHistory(trends))
+}
+
+func TestCostTrend_OnlyFullMonths(t *testing.T) {
+	trend := CostTrend(Snapshots(stateWithScan(), day("2025-03-01")), "us-east-1")
+	require.NotNil(t, trend)
+	assert.Equal(t, []MonthlyValue{{"2025-01", 100}, {"2025-02", 110}}, trend.Points, "the partial December is left out")
+
+	headers, rows := TrendTable([]Trend{*trend}, 12)
+	assert.Equal(t, []string{"Metric", "Statistic", "2025-01", "2025-02", "Avg MoM Growth"}, headers)
+	assert.Equal(t, [][]string{{CostTrendMetric, "Sum", "100.00", "110.00", "+10.0%"}}, rows)
+
+	assert.Nil(t, CostTrend(nil, "us-east-1"))
+}
@@ -0,0 +1,241 @@
+package metricshistory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// trendMetric is a cluster metric shown in trend reports and the statistic
+// of each month that represents it.
+type trendMetric struct {
+	Label   string
+	Maximum bool
+}
+
+// trendMetrics are reported in this order when present. Throughput uses the
+// month's average; partitions and storage use its peak.
+var trendMetrics = []trendMetric{
+	{Label: "BytesInPerSec"},
+	{Label: "BytesOutPerSec"},
+	{Label: "MessagesInPerSec"},
+	{Label: "PartitionCount", Maximum: true},
+	{Label: "TotalLocalStorageUsage(GB)", Maximum: true},
+	{Label: "TotalRemoteStorageUsage(GB)", Maximum: true},
+	{Label: "TotalLocalStorageUsage", Maximum: true},
+}
+
+// CostTrendMetric names the trend CostTrend returns.
+const CostTrendMetric = "Unblended cost"
+
+// MonthlyValue is a metric's value for one calendar month (YYYY-MM).
+type MonthlyValue struct {
+	Month string
+	Value float64
+}
+
+// Trend is a metric's monthly values in ascending month order.
+type Trend struct {
+	Metric    string
+	Statistic string
+	Points    []MonthlyValue
+	// MonthlyGrowthPercent is the compound month-over-month growth from the
+	// first to the last month; nil with fewer than two months or a first
+	// value of zero.
+	MonthlyGrowthPercent *float64
+}
+
+// ClusterTrends returns the trends of the cluster's throughput, partition
+// and storage metrics across all snapshots. Where snapshots overlap, a
+// month's value comes from the snapshot with the most samples in it, and
+// the later snapshot on a tie.
+func ClusterTrends(snapshots []Snapshot, clusterID string) []Trend {
+	type choice struct {
+		count      int
+		recordedAt time.Time
+		value      float64
+	}
+	chosen := make(map[string]map[string]choice)
+
+	for _, snapshot := range snapshots {
+		if !strings.EqualFold(snapshot.ClusterID, clusterID) {
+			continue
+		}
+		for month, aggregates := range snapshot.Metrics {
+			for _, metric := range trendMetrics {
+				aggregate, ok := aggregates[metric.Label]
+				if !ok {
+					continue
+				}
+				value := aggregate.Average
+				if metric.Maximum {
+					value = aggregate.Maximum
+				}
+				if value == nil {
+					continue
+				}
+				if chosen[metric.Label] == nil {
+					chosen[metric.Label] = make(map[string]choice)
+				}
+				current, seen := chosen[metric.Label][month]
+				if seen && (aggregate.Count < current.count || (aggregate.Count == current.count && snapshot.RecordedAt.Before(current.recordedAt))) {
+					continue
+				}
+				chosen[metric.Label][month] = choice{count: aggregate.Count, recordedAt: snapshot.RecordedAt, value: *value}
+			}
+		}
+	}
+
+	var trends []Trend
+	for _, metric := range trendMetrics {
+		months, ok := chosen[metric.Label]
+		if !ok {
+			continue
+		}
+		values := make(map[string]float64, len(months))
+		for month, c := range months {
+			values[month] = c.value
+		}
+		statistic := "Average"
+		if metric.Maximum {
+			statistic = "Maximum"
+		}
+		trends = append(trends, newTrend(metric.Label, statistic, values))
+	}
+	return trends
+}
+
+// CostTrend returns the region's monthly unblended cost across all
+// snapshots, counting only months a snapshot covers in full, or nil when no
+// snapshot covers a full month.
+func CostTrend(snapshots []Snapshot, region string) *Trend {
+	type choice struct {
+		recordedAt time.Time
+		total      float64
+	}
+	chosen := make(map[string]choice)
+
+	for _, snapshot := range snapshots {
+		if snapshot.ClusterID != "" || snapshot.Region != region {
+			continue
+		}
+		for month, cost := range snapshot.Costs {
+			start, err := time.Parse(monthFormat, month)
+			if err != nil || cost.Days < daysIn(start) {
+				continue
+			}
+			if current, seen := chosen[month]; seen && snapshot.RecordedAt.Before(current.recordedAt) {
+				continue
+			}
+			chosen[month] = choice{recordedAt: snapshot.RecordedAt, total: cost.Total}
+		}
+	}
+	if len(chosen) == 0 {
+		return nil
+	}
+
+	values := make(map[string]float64, len(chosen))
+	for month, c := range chosen {
+		values[month] = c.total
+	}
+	trend := newTrend(CostTrendMetric, "Sum", values)
+	return &trend
+}
+
+func newTrend(metric, statistic string, values map[string]float64) Trend {
+	trend := Trend{Metric: metric, Statistic: statistic}
+	for month, value := range values {
+		trend.Points = append(trend.Points, MonthlyValue{Month: month, Value: value})
+	}
+	sort.Slice(trend.Points, func(i, j int) bool { return trend.Points[i].Month < trend.Points[j].Month })
+	trend.MonthlyGrowthPercent = monthlyGrowth(trend.Points)
+	return trend
+}
+
+// monthlyGrowth is the compound monthly growth rate, in percent, that turns
+// the first value into the last over the months between them.
+func monthlyGrowth(points []MonthlyValue) *float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	first, last := points[0], points[len(points)-1]
+	if first.Value <= 0 || last.Value < 0 {
+		return nil
+	}
+	firstMonth, err := time.Parse(monthFormat, first.Month)
+	if err != nil {
+		return nil
+	}
+	lastMonth, err := time.Parse(monthFormat, last.Month)
+	if err != nil {
+		return nil
+	}
+	months := (lastMonth.Year()-firstMonth.Year())*12 + int(lastMonth.Month()-firstMonth.Month())
+	if months <= 0 {
+		return nil
+	}
+	growth := (math.Pow(last.Value/first.Value, 1/float64(months)) - 1) * 100
+	return &growth
+}
+
+func daysIn(month time.Time) int {
+	return int(month.AddDate(0, 1, 0).Sub(month).Hours() / 24)
+}
+
+// TrendTable lays trends out as a table with one row per metric and a column
+// for each of the last maxMonths months any of them covers, followed by the
+// average month-over-month growth.
+func TrendTable(trends []Trend, maxMonths int) ([]string, [][]string) {
+	monthSet := make(map[string]bool)
+	for _, trend := range trends {
+		for _, point := range trend.Points {
+			monthSet[point.Month] = true
+		}
+	}
+	months := make([]string, 0, len(monthSet))
+	for month := range monthSet {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	if maxMonths > 0 && len(months) > maxMonths {
+		months = months[len(months)-maxMonths:]
+	}
+
+	headers := append([]string{"Metric", "Statistic"}, months...)
+	headers = append(headers, "Avg MoM Growth")
+
+	var rows [][]string
+	for _, trend := range trends {
+		values := make(map[string]float64, len(trend.Points))
+		for _, point := range trend.Points {
+			values[point.Month] = point.Value
+		}
+		row := []string{trend.Metric, trend.Statistic}
+		for _, month := range months {
+			if value, ok := values[month]; ok {
+				row = append(row, fmt.Sprintf("%.2f", value))
+			} else {
+				row = append(row, "N/A")
+			}
+		}
+		if trend.MonthlyGrowthPercent != nil {
+			row = append(row, fmt.Sprintf("%+.1f%%", *trend.MonthlyGrowthPercent))
+		} else {
+			row = append(row, "N/A")
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// HasHistory reports whether any trend spans at least two months.
+func HasHistory(trends []Trend) bool {
+	for _, trend := range trends {
+		if len(trend.Points) >= 2 {
+			return true
+		}
+	}
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/confluentinc/kcp/internal/client"
@@ -119,6 +120,16 @@ func (ms *MSKService) DescribeClusterV2(ctx context.Context, clusterArn string)
 	return cluster, nil
 }
 
+func (ms *MSKService) ListTagsForResource(ctx context.Context, resourceArn string) (map[string]string, error) {
+	output, err := ms.client.ListTagsForResource(ctx, &kafka.ListTagsForResourceInput{
+		ResourceArn: &resourceArn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %v", err)
+	}
+	return output.Tags, nil
+}
+
 func (ms *MSKService) ListClientVpcConnections(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.ClientVpcConnection, error) {
 	var connections []kafkatypes.ClientVpcConnection
 	var nextToken *string
@@ -169,6 +180,16 @@ func (ms *MSKService) ListClusterOperationsV2(ctx context.Context, clusterArn st
 	return operations, nil
 }
 
+func (ms *MSKService) DescribeClusterOperationV2(ctx context.Context, operationArn string) (*kafkatypes.ClusterOperationV2, error) {
+	output, err := ms.client.DescribeClusterOperationV2(ctx, &kafka.DescribeClusterOperationV2Input{
+		ClusterOperationArn: &operationArn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed describing operation: %v", err)
+	}
+	return output.ClusterOperationInfo, nil
+}
+
 func (ms *MSKService) ListNodes(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.NodeInfo, error) {
 	var nodes []kafkatypes.NodeInfo
 	var nextToken *string
@@ -223,17 +244,24 @@ func (ms *MSKService) ListScramSecrets(ctx context.Context, clusterArn string, m
 	return secrets, nil
 }
 
-func (ms *MSKService) ListClusters(ctx context.Context, maxResults int32) ([]kafkatypes.Cluster, error) {
+// ListClusters lists the region's MSK clusters, only those of clusterType
+// when it is set.
+func (ms *MSKService) ListClusters(ctx context.Context, maxResults int32, clusterType kafkatypes.ClusterType) ([]kafkatypes.Cluster, error) {
 	slog.Info("🔍 scanning for MSK clusters", "region", ms.client.Options().Region)
 
 	var nextToken *string
+	var clusterTypeFilter *string
+	if clusterType != "" {
+		clusterTypeFilter = aws.String(string(clusterType))
+	}
 
 	var clusterInfoList []kafkatypes.Cluster
 
 	for {
 		listClustersOutput, err := ms.client.ListClustersV2(ctx, &kafka.ListClustersV2Input{
-			MaxResults: &maxResults,
-			NextToken:  nextToken,
+			ClusterTypeFilter: clusterTypeFilter,
+			MaxResults:        &maxResults,
+			NextToken:         nextToken,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to list clusters: %v", err)
@@ -287,6 +315,48 @@ func (ms *MSKService) GetConfigurations(ctx context.Context, maxResults int32) (
 	return configurations, nil
 }
 
+// GetReplicators describes every MSK Replicator in the region. Replicator
+// references — the entries a cross-region replicator leaves in its source
+// cluster's region — are skipped; the replicator itself is described in its
+// own region.
+func (ms *MSKService) GetReplicators(ctx context.Context, maxResults int32) ([]kafka.DescribeReplicatorOutput, error) {
+	var replicators []kafka.DescribeReplicatorOutput
+	var nextToken *string
+
+	for {
+		output, err := ms.client.ListReplicators(ctx, &kafka.ListReplicatorsInput{
+			MaxResults: &maxResults,
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing replicators: %v", err)
+		}
+
+		for _, summary := range output.Replicators {
+			if aws.ToBool(summary.IsReplicatorReference) {
+				slog.Debug("skipping replicator reference", "replicatorArn", aws.ToString(summary.ReplicatorArn))
+				continue
+			}
+			replicator, err := ms.client.DescribeReplicator(ctx, &kafka.DescribeReplicatorInput{
+				ReplicatorArn: summary.ReplicatorArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error describing replicator %s: %v", aws.ToString(summary.ReplicatorName), err)
+			}
+			replicators = append(replicators, *replicator)
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	slog.Info("✅ found replicators", "count", len(replicators))
+
+	return replicators, nil
+}
+
 func (ms *MSKService) ListTopics(ctx context.Context, clusterArn string, maxResults int32) ([]kafkatypes.TopicInfo, error) {
 	slog.Info("🔍 listing topics")
 	slog.Debug("🔍 listing topics", "clusterArn", clusterArn)
@@ -340,23 +410,71 @@ func (ms *MSKService) GetTopicsWithConfigs(ctx context.Context, clusterArn strin
 		return nil, err
 	}
 
+	var names []string
+	for _, t := range topicList {
+		if t.TopicName != nil {
+			names = append(names, *t.TopicName)
+		}
+	}
+
+	topicDetails := ms.describeTopics(ctx, clusterArn, names)
+
+	slog.Info("✅ discovered topics", "count", len(topicDetails))
+	return topicDetails, nil
+}
+
+// RefreshTopicsWithConfigs lists the cluster's topics and only describes the
+// ones that are new or whose partition count or replication factor differs
+// from known. Other topics keep their known configurations, so config-only
+// changes on existing topics are not picked up.
+func (ms *MSKService) RefreshTopicsWithConfigs(ctx context.Context, clusterArn string, known []types.TopicDetails) ([]types.TopicDetails, error) {
+	slog.Debug("refreshing topics via AWS API", "clusterArn", clusterArn, "known", len(known))
+
+	topicList, err := ms.ListTopics(ctx, clusterArn, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	knownByName := make(map[string]types.TopicDetails, len(known))
+	for _, topic := range known {
+		knownByName[topic.Name] = topic
+	}
+
+	var topicDetails []types.TopicDetails
+	var changed []string
+	for _, t := range topicList {
+		if t.TopicName == nil {
+			continue
+		}
+		previous, ok := knownByName[*t.TopicName]
+		if ok && previous.Partitions == int(aws.ToInt32(t.PartitionCount)) && previous.ReplicationFactor == int(aws.ToInt32(t.ReplicationFactor)) {
+			topicDetails = append(topicDetails, previous)
+			continue
+		}
+		changed = append(changed, *t.TopicName)
+	}
+
+	topicDetails = append(topicDetails, ms.describeTopics(ctx, clusterArn, changed)...)
+
+	slog.Info("✅ refreshed topics", "count", len(topicDetails), "described", len(changed))
+	return topicDetails, nil
+}
+
+// describeTopics describes the named topics concurrently, retrying failures
+// once sequentially. Topics that still fail are left out of the result.
+func (ms *MSKService) describeTopics(ctx context.Context, clusterArn string, names []string) []types.TopicDetails {
 	// Concurrency limiting with semaphore
 	const maxConcurrency = 25
 	sem := semaphore.NewWeighted(maxConcurrency)
 
-	resultChan := make(chan types.TopicDetails, len(topicList))
+	resultChan := make(chan types.TopicDetails, len(names))
 
 	var wg sync.WaitGroup
 	var progressCount atomic.Int32
 	var failedTopics []string
 	var failedTopicsMu sync.Mutex
 
-	for _, t := range topicList {
-		if t.TopicName == nil {
-			continue
-		}
-		topicName := *t.TopicName
-
+	for _, topicName := range names {
 		if err := sem.Acquire(ctx, 1); err != nil {
 			slog.Warn("failed to acquire semaphore", "error", err)
 			break
@@ -383,7 +501,7 @@ func (ms *MSKService) GetTopicsWithConfigs(ctx context.Context, clusterArn strin
 
 			current := progressCount.Add(1)
 			if current%250 == 0 {
-				slog.Debug("🔍 describing topics", "processed", current, "total", len(topicList))
+				slog.Debug("🔍 describing topics", "processed", current, "total", len(names))
 			}
 		}(topicName)
 	}
@@ -413,8 +531,7 @@ func (ms *MSKService) GetTopicsWithConfigs(ctx context.Context, clusterArn strin
 		}
 	}
 
-	slog.Info("✅ discovered topics", "count", len(topicDetails))
-	return topicDetails, nil
+	return topicDetails
 }
 
 // The topic configs are encoded in base64 when returned by the `DescribeTopic` API.
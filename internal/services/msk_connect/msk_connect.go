@@ -21,3 +21,11 @@ func (ms *MSKConnectService) ListConnectors(ctx context.Context, params *kafkaco
 func (ms *MSKConnectService) DescribeConnector(ctx context.Context, params *kafkaconnect.DescribeConnectorInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOutput, error) {
 	return ms.client.DescribeConnector(ctx, params, optFns...)
 }
+
+func (ms *MSKConnectService) ListConnectorOperations(ctx context.Context, params *kafkaconnect.ListConnectorOperationsInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.ListConnectorOperationsOutput, error) {
+	return ms.client.ListConnectorOperations(ctx, params, optFns...)
+}
+
+func (ms *MSKConnectService) DescribeConnectorOperation(ctx context.Context, params *kafkaconnect.DescribeConnectorOperationInput, optFns ...func(*kafkaconnect.Options)) (*kafkaconnect.DescribeConnectorOperationOutput, error) {
+	return ms.client.DescribeConnectorOperation(ctx, params, optFns...)
+}
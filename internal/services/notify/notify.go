@@ -0,0 +1,337 @@
+// Package notify pushes completion events for long-running kcp commands to
+// SNS topics and webhooks, so scans scheduled via cron or EventBridge can
+// report success or failure to Slack and ops tooling.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	snsPrefix       = "sns:"
+	sendTimeout     = 10 * time.Second
+	maxSubjectRunes = 100
+)
+
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Options holds the --notify and --notify-webhook flag values of a command.
+type Options struct {
+	Targets  []string
+	Webhooks []string
+}
+
+func (o Options) enabled() bool {
+	return len(o.Targets) > 0 || len(o.Webhooks) > 0
+}
+
+// Validate checks every target up front so a typo fails the command before
+// a long scan rather than silently after it.
+func (o Options) Validate() error {
+	_, err := o.notifiers(nil)
+	return err
+}
+
+// Event is the payload delivered on completion. Text is a one-line summary,
+// which Slack incoming webhooks render as the message.
+type Event struct {
+	Text            string        `json:"text"`
+	Command         string        `json:"command"`
+	Status          Status        `json:"status"`
+	Error           string        `json:"error,omitempty"`
+	Host            string        `json:"host,omitempty"`
+	KcpVersion      string        `json:"kcp_version"`
+	StartedAt       time.Time     `json:"started_at"`
+	FinishedAt      time.Time     `json:"finished_at"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	StateFile       string        `json:"state_file,omitempty"`
+	Summary         *StateSummary `json:"summary,omitempty"`
+}
+
+// StateSummary counts what the state file holds after the command ran.
+type StateSummary struct {
+	MSKRegions       int `json:"msk_regions"`
+	MSKClusters      int `json:"msk_clusters"`
+	OSKClusters      int `json:"osk_clusters"`
+	SchemaRegistries int `json:"schema_registries"`
+}
+
+// NewEvent builds the completion event for command. The state file summary
+// is best effort: a command that failed before writing state has none.
+func NewEvent(command string, startedAt, finishedAt time.Time, runErr error, stateFile string) Event {
+	event := Event{
+		Command:         command,
+		Status:          StatusSucceeded,
+		KcpVersion:      build_info.Version,
+		StartedAt:       startedAt.UTC(),
+		FinishedAt:      finishedAt.UTC(),
+		DurationSeconds: finishedAt.Sub(startedAt).Round(time.Second).Seconds(),
+		StateFile:       stateFile,
+	}
+	if host, err := os.Hostname(); err == nil {
+		event.Host = host
+	}
+	if runErr != nil {
+		event.Status = StatusFailed
+		event.Error = runErr.Error()
+	}
+
+	if stateFile != "" {
+		if state, err := types.NewStateFromFile(stateFile); err == nil {
+			event.Summary = summarizeState(state)
+		} else {
+			slog.Debug("skipping state summary in notification", "file", stateFile, "error", err)
+		}
+	}
+
+	event.Text = eventText(event)
+	return event
+}
+
+func summarizeState(state *types.State) *StateSummary {
+	summary := &StateSummary{}
+	if state.MSKSources != nil {
+		for _, region := range state.MSKSources.Regions {
+			summary.MSKRegions++
+			summary.MSKClusters += len(region.Clusters)
+		}
+	}
+	if state.OSKSources != nil {
+		summary.OSKClusters = len(state.OSKSources.Clusters)
+	}
+	if state.SchemaRegistries != nil {
+		summary.SchemaRegistries = len(state.SchemaRegistries.ConfluentSchemaRegistry) + len(state.SchemaRegistries.AWSGlue)
+	}
+	return summary
+}
+
+func eventText(event Event) string {
+	duration := time.Duration(event.DurationSeconds) * time.Second
+	text := fmt.Sprintf("%s %s in %s", event.Command, event.Status, duration)
+	if event.Host != "" {
+		text += fmt.Sprintf(" on %s", event.Host)
+	}
+	if event.Status == StatusFailed {
+		return text + ": " + event.Error
+	}
+	if event.Summary != nil {
+		text += fmt.Sprintf(" (%d MSK clusters in %d regions, %d Apache Kafka clusters, %d schema registries)",
+			event.Summary.MSKClusters, event.Summary.MSKRegions, event.Summary.OSKClusters, event.Summary.SchemaRegistries)
+	}
+	return text
+}
+
+// Notifier delivers an event to one destination.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+	String() string
+}
+
+type publisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// SNSNotifier publishes the event as JSON to an SNS topic, with the summary
+// line as the subject for email subscriptions.
+type SNSNotifier struct {
+	client   publisher
+	topicArn string
+}
+
+func NewSNSNotifier(client publisher, topicArn string) *SNSNotifier {
+	return &SNSNotifier{client: client, topicArn: topicArn}
+}
+
+func (n *SNSNotifier) Notify(ctx context.Context, event Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	if _, err := n.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(n.topicArn),
+		Subject:  aws.String(snsSubject(event)),
+		Message:  aws.String(string(message)),
+	}); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", n.topicArn, err)
+	}
+	return nil
+}
+
+func (n *SNSNotifier) String() string {
+	return n.topicArn
+}
+
+// snsSubject is "kcp <command> <status>", trimmed to SNS's 100 character
+// subject limit.
+func snsSubject(event Event) string {
+	subject := []rune(fmt.Sprintf("%s %s", event.Command, event.Status))
+	if len(subject) > maxSubjectRunes {
+		subject = subject[:maxSubjectRunes]
+	}
+	return string(subject)
+}
+
+// WebhookNotifier POSTs the event as JSON to a URL.
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookNotifier(client *http.Client, url string) *WebhookNotifier {
+	return &WebhookNotifier{client: client, url: url}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kcp/"+build_info.Version)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// url.Error repeats the full URL; keep only the cause.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to call webhook %s: %w", n, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s", n, resp.Status)
+	}
+	return nil
+}
+
+// String omits the path and query, which for Slack and most chat webhooks
+// is the secret.
+func (n *WebhookNotifier) String() string {
+	parsed, err := url.Parse(n.url)
+	if err != nil {
+		return "webhook"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// notifiers builds a Notifier per target, creating SNS clients in each
+// topic's region. A nil snsClientFor only validates the targets.
+func (o Options) notifiers(snsClientFor func(region string) (publisher, error)) ([]Notifier, error) {
+	var notifiers []Notifier
+
+	for _, target := range o.Targets {
+		topicArn, ok := strings.CutPrefix(target, snsPrefix)
+		if !ok {
+			return nil, fmt.Errorf("invalid --notify target '%s': expected sns:<topic arn> (use --notify-webhook for URLs)", target)
+		}
+		parsed, err := arn.Parse(topicArn)
+		if err != nil || parsed.Service != "sns" || parsed.Region == "" || parsed.Resource == "" {
+			return nil, fmt.Errorf("invalid --notify target '%s': expected sns:arn:aws:sns:<region>:<account id>:<topic>", target)
+		}
+		if snsClientFor == nil {
+			continue
+		}
+		snsClient, err := snsClientFor(parsed.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SNS client: %w", err)
+		}
+		notifiers = append(notifiers, NewSNSNotifier(snsClient, topicArn))
+	}
+
+	httpClient := &http.Client{Timeout: sendTimeout}
+	for _, webhook := range o.Webhooks {
+		parsed, err := url.Parse(webhook)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid --notify-webhook '%s': expected an http(s) URL", webhook)
+		}
+		if snsClientFor == nil {
+			continue
+		}
+		notifiers = append(notifiers, NewWebhookNotifier(httpClient, webhook))
+	}
+
+	return notifiers, nil
+}
+
+// Send delivers event to every notifier. A failed delivery is reported but
+// never changes the outcome of the command that produced the event.
+func Send(ctx context.Context, notifiers []Notifier, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		slog.Debug("sent completion notification", "target", notifier.String(), "status", event.Status)
+		fmt.Printf("✅ Sent %s notification to %s\n", event.Status, notifier)
+	}
+	return errors.Join(errs...)
+}
+
+// WrapRunE runs runE and then notifies every target in opts of the outcome.
+// The summary is read from the command's --state-file flag, or from
+// kcp-state.json in --output-dir (or the working directory) without one.
+func WrapRunE(opts *Options, runE func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		startedAt := time.Now()
+		runErr := runE(cmd, args)
+		if !opts.enabled() {
+			return runErr
+		}
+
+		stateFile := "kcp-state.json"
+		if flag := cmd.Flags().Lookup("state-file"); flag != nil {
+			stateFile = flag.Value.String()
+		} else if flag := cmd.Flags().Lookup("output-dir"); flag != nil {
+			stateFile = filepath.Join(flag.Value.String(), stateFile)
+		}
+		event := NewEvent(cmd.CommandPath(), startedAt, time.Now(), runErr, stateFile)
+
+		notifiers, err := opts.notifiers(func(region string) (publisher, error) {
+			return client.NewSNSClient(region)
+		})
+		if err == nil {
+			err = Send(context.Background(), notifiers, event)
+		}
+		if err != nil {
+			slog.Warn("failed to send completion notification", "error", err)
+		}
+
+		return runErr
+	}
+}
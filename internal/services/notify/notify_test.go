@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	inputs []*sns.PublishInput
+	err    error
+}
+
+func (f *fakePublisher) Publish(_ context.Context, params *sns.PublishInput, _ ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	f.inputs = append(f.inputs, params)
+	return &sns.PublishOutput{}, f.err
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{name: "none", opts: Options{}},
+		{
+			name: "sns and webhook",
+			opts: Options{
+				Targets:  []string{"sns:arn:aws:sns:us-east-1:123456789012:kcp-scans"},
+				Webhooks: []string{"https://hooks.slack.com/services/T000/B000/XXXX"},
+			},
+		},
+		{
+			name:    "missing sns prefix",
+			opts:    Options{Targets: []string{"arn:aws:sns:us-east-1:123456789012:kcp-scans"}},
+			wantErr: "expected sns:<topic arn>",
+		},
+		{
+			name:    "not an sns arn",
+			opts:    Options{Targets: []string{"sns:arn:aws:sqs:us-east-1:123456789012:kcp-scans"}},
+			wantErr: "invalid --notify target",
+		},
+		{
+			name:    "webhook without scheme",
+			opts:    Options{Webhooks: []string{"hooks.slack.com/services/T000"}},
+			wantErr: "expected an http(s) URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewEvent_SummarizesState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "kcp-state.json")
+	state := `{
+		"schema_version": 1,
+		"msk_sources": {"regions": [
+			{"name": "us-east-1", "configurations": [], "costs": {}, "clusters": [{"name": "a", "arn": "arn:a"}, {"name": "b", "arn": "arn:b"}]},
+			{"name": "eu-west-1", "configurations": [], "costs": {}, "clusters": [{"name": "c", "arn": "arn:c"}]}
+		]},
+		"osk_sources": {"clusters": []},
+		"kcp_build_info": {"version": "1.0.0"},
+		"timestamp": "2026-01-01T00:00:00Z"
+	}`
+	require.NoError(t, os.WriteFile(stateFile, []byte(state), 0600))
+
+	started := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	event := NewEvent("kcp discover", started, started.Add(90*time.Second), nil, stateFile)
+
+	assert.Equal(t, StatusSucceeded, event.Status)
+	assert.Equal(t, float64(90), event.DurationSeconds)
+	if assert.NotNil(t, event.Summary) {
+		assert.Equal(t, StateSummary{MSKRegions: 2, MSKClusters: 3}, *event.Summary)
+	}
+	assert.Contains(t, event.Text, "kcp discover succeeded in 1m30s")
+	assert.Contains(t, event.Text, "3 MSK clusters in 2 regions")
+}
+
+func TestNewEvent_Failure(t *testing.T) {
+	started := time.Now()
+	event := NewEvent("kcp scan clusters", started, started, errors.New("failed to connect"), filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.Equal(t, StatusFailed, event.Status)
+	assert.Equal(t, "failed to connect", event.Error)
+	assert.Nil(t, event.Summary)
+	assert.Contains(t, event.Text, "kcp scan clusters failed")
+	assert.Contains(t, event.Text, ": failed to connect")
+}
+
+func TestSNSNotifier_Notify(t *testing.T) {
+	publisher := &fakePublisher{}
+	notifier := NewSNSNotifier(publisher, "arn:aws:sns:us-east-1:123456789012:kcp-scans")
+	event := Event{Text: "done", Command: "kcp discover", Status: StatusSucceeded}
+
+	require.NoError(t, notifier.Notify(context.Background(), event))
+
+	require.Len(t, publisher.inputs, 1)
+	assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:kcp-scans", *publisher.inputs[0].TopicArn)
+	assert.Equal(t, "kcp discover succeeded", *publisher.inputs[0].Subject)
+	var sent Event
+	require.NoError(t, json.Unmarshal([]byte(*publisher.inputs[0].Message), &sent))
+	assert.Equal(t, event, sent)
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.Client(), server.URL+"/services/secret")
+	require.NoError(t, notifier.Notify(context.Background(), Event{Text: "kcp discover succeeded", Status: StatusSucceeded}))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "kcp discover succeeded", payload["text"])
+	assert.NotContains(t, notifier.String(), "secret")
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.Client(), server.URL+"/services/secret")
+	err := notifier.Notify(context.Background(), Event{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestSend_ContinuesAfterFailure(t *testing.T) {
+	failing := NewSNSNotifier(&fakePublisher{err: errors.New("access denied")}, "arn:aws:sns:us-east-1:123456789012:a")
+	working := &fakePublisher{}
+
+	err := Send(context.Background(), []Notifier{failing, NewSNSNotifier(working, "arn:aws:sns:us-east-1:123456789012:b")}, Event{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+	assert.Len(t, working.inputs, 1)
+}
@@ -0,0 +1,174 @@
+// Package openmonitoring turns the Open Monitoring settings `kcp scan
+// clusters` records for MSK clusters into recommendations and a Prometheus
+// scrape config for watching the source brokers and the cluster link's mirror
+// lag during the migration.
+package openmonitoring
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/goccy/go-yaml"
+)
+
+// ConfluentCloudMetricsHost serves the Confluent Cloud Metrics API export
+// endpoint, which exposes the cluster link's mirror lag in the Prometheus
+// format.
+const ConfluentCloudMetricsHost = "api.telemetry.confluent.cloud"
+
+// MirrorLagMetric is the export endpoint's name for the offset lag of each
+// mirror topic behind its source topic.
+const MirrorLagMetric = "confluent_kafka_server_cluster_link_mirror_topic_offset_lag"
+
+// Placeholders for the Cloud API key the export endpoint authenticates with.
+const (
+	CloudAPIKeyPlaceholder    = "<cloud-api-key>"
+	CloudAPISecretPlaceholder = "<cloud-api-secret>"
+)
+
+// Cluster is the Open Monitoring status of one MSK cluster. Settings is nil
+// when the cluster was not scanned, or is serverless.
+type Cluster struct {
+	Name            string
+	Arn             string
+	Region          string
+	Settings        *types.OpenMonitoring
+	Recommendations []string
+}
+
+// Analyze reports the Open Monitoring status of every MSK cluster of a
+// processed state, ordered by region then name.
+func Analyze(state report.ProcessedState) []Cluster {
+	var clusters []Cluster
+	for _, src := range state.Sources {
+		if src.MSKData == nil {
+			continue
+		}
+		for _, region := range src.MSKData.Regions {
+			for _, cluster := range region.Clusters {
+				c := Cluster{
+					Name:     cluster.Name,
+					Arn:      cluster.Arn,
+					Region:   region.Name,
+					Settings: cluster.KafkaAdminClientInformation.OpenMonitoring,
+				}
+				c.Recommendations = recommendations(c.Settings)
+				clusters = append(clusters, c)
+			}
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Region != clusters[j].Region {
+			return clusters[i].Region < clusters[j].Region
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters
+}
+
+func recommendations(settings *types.OpenMonitoring) []string {
+	if settings == nil {
+		return []string{"No Open Monitoring settings were recorded; run `kcp scan clusters` (serverless clusters have no Open Monitoring)."}
+	}
+
+	var recs []string
+	if !settings.JmxExporterEnabled {
+		recs = append(recs, "Enable the JMX exporter (`aws kafka update-monitoring --open-monitoring Prometheus={JmxExporter={EnabledInBroker=true}}`) to watch the brokers' request load and bytes out while the cluster link fetches from them.")
+	}
+	if !settings.NodeExporterEnabled {
+		recs = append(recs, "Enable the node exporter (`aws kafka update-monitoring --open-monitoring Prometheus={NodeExporter={EnabledInBroker=true}}`) to watch the brokers' CPU, disk and network during the migration.")
+	}
+	if settings.JmxExporterEnabled || settings.NodeExporterEnabled {
+		recs = append(recs, fmt.Sprintf("Allow the Prometheus server through the brokers' security groups on TCP %d-%d.", types.JmxExporterPort, types.NodeExporterPort))
+	}
+	if len(settings.BrokerHosts) == 0 {
+		recs = append(recs, "No broker hosts were recorded; run `kcp discover` to fill in the scrape targets.")
+	}
+	return recs
+}
+
+// ScrapeConfig is the scrape_configs section of a prometheus.yml.
+type ScrapeConfig struct {
+	ScrapeConfigs []ScrapeJob `yaml:"scrape_configs"`
+}
+
+type ScrapeJob struct {
+	JobName        string              `yaml:"job_name"`
+	ScrapeInterval string              `yaml:"scrape_interval,omitempty"`
+	ScrapeTimeout  string              `yaml:"scrape_timeout,omitempty"`
+	Scheme         string              `yaml:"scheme,omitempty"`
+	MetricsPath    string              `yaml:"metrics_path,omitempty"`
+	Params         map[string][]string `yaml:"params,omitempty"`
+	BasicAuth      *BasicAuth          `yaml:"basic_auth,omitempty"`
+	StaticConfigs  []StaticConfig      `yaml:"static_configs"`
+}
+
+type BasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+type StaticConfig struct {
+	Targets []string          `yaml:"targets"`
+	Labels  map[string]string `yaml:"labels,omitempty"`
+}
+
+// BuildScrapeConfig returns a job per enabled exporter of each cluster, and,
+// when targetClusterIDs is non-empty, a job exporting the Confluent Cloud
+// metrics of those clusters, mirror lag included. The Cloud API key is left
+// as placeholders to fill in.
+func BuildScrapeConfig(clusters []Cluster, targetClusterIDs []string) ScrapeConfig {
+	config := ScrapeConfig{ScrapeConfigs: []ScrapeJob{}}
+	for _, c := range clusters {
+		if c.Settings == nil || len(c.Settings.BrokerHosts) == 0 {
+			continue
+		}
+		if c.Settings.JmxExporterEnabled {
+			config.ScrapeConfigs = append(config.ScrapeConfigs, exporterJob(c, "jmx", types.JmxExporterPort))
+		}
+		if c.Settings.NodeExporterEnabled {
+			config.ScrapeConfigs = append(config.ScrapeConfigs, exporterJob(c, "node", types.NodeExporterPort))
+		}
+	}
+
+	if len(targetClusterIDs) > 0 {
+		// The export endpoint serves one-minute aggregates and rejects
+		// scrapes more frequent than that.
+		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeJob{
+			JobName:        "confluent-cloud",
+			ScrapeInterval: "1m",
+			ScrapeTimeout:  "1m",
+			Scheme:         "https",
+			MetricsPath:    "/v2/metrics/cloud/export",
+			Params:         map[string][]string{"resource.kafka.id": targetClusterIDs},
+			BasicAuth:      &BasicAuth{Username: CloudAPIKeyPlaceholder, Password: CloudAPISecretPlaceholder},
+			StaticConfigs:  []StaticConfig{{Targets: []string{ConfluentCloudMetricsHost}}},
+		})
+	}
+	return config
+}
+
+func exporterJob(c Cluster, exporter string, port int) ScrapeJob {
+	targets := make([]string, 0, len(c.Settings.BrokerHosts))
+	for _, host := range c.Settings.BrokerHosts {
+		targets = append(targets, fmt.Sprintf("%s:%d", host, port))
+	}
+	return ScrapeJob{
+		JobName: fmt.Sprintf("msk-%s-%s", c.Name, exporter),
+		StaticConfigs: []StaticConfig{{
+			Targets: targets,
+			Labels:  map[string]string{"cluster": c.Name, "cluster_arn": c.Arn},
+		}},
+	}
+}
+
+// Marshal renders the scrape config as YAML.
+func (s ScrapeConfig) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scrape config: %w", err)
+	}
+	return data, nil
+}
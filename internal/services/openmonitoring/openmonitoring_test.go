@@ -0,0 +1,74 @@
+package openmonitoring
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stateWith(clusters ...report.ProcessedCluster) report.ProcessedState {
+	return report.ProcessedState{Sources: []report.ProcessedSource{{
+		MSKData: &report.ProcessedMSKSource{Regions: []report.ProcessedRegion{{Name: "us-east-1", Clusters: clusters}}},
+	}}}
+}
+
+func cluster(name string, om *types.OpenMonitoring) report.ProcessedCluster {
+	c := report.ProcessedCluster{Name: name, Arn: "arn:aws:kafka:us-east-1:123456789012:cluster/" + name + "/1"}
+	c.KafkaAdminClientInformation.OpenMonitoring = om
+	return c
+}
+
+func TestAnalyze_Recommendations(t *testing.T) {
+	hosts := []string{"b-1.orders.abc.kafka.us-east-1.amazonaws.com"}
+	clusters := Analyze(stateWith(
+		cluster("payments", &types.OpenMonitoring{JmxExporterEnabled: true, NodeExporterEnabled: true, BrokerHosts: hosts}),
+		cluster("orders", &types.OpenMonitoring{BrokerHosts: hosts}),
+		cluster("events", nil),
+	))
+
+	require.Len(t, clusters, 3)
+	assert.Equal(t, []string{"events", "orders", "payments"}, []string{clusters[0].Name, clusters[1].Name, clusters[2].Name})
+	assert.Len(t, clusters[0].Recommendations, 1, "an unscanned cluster is pointed at kcp scan clusters")
+	assert.Len(t, clusters[1].Recommendations, 2, "both exporters should be enabled")
+	require.Len(t, clusters[2].Recommendations, 1)
+	assert.Contains(t, clusters[2].Recommendations[0], "TCP 11001-11002")
+}
+
+func TestBuildScrapeConfig(t *testing.T) {
+	clusters := Analyze(stateWith(
+		cluster("orders", &types.OpenMonitoring{
+			JmxExporterEnabled: true,
+			BrokerHosts:        []string{"b-1.orders.abc.kafka.us-east-1.amazonaws.com", "b-2.orders.abc.kafka.us-east-1.amazonaws.com"},
+		}),
+		cluster("events", nil),
+	))
+
+	config := BuildScrapeConfig(clusters, []string{"lkc-abc123"})
+
+	require.Len(t, config.ScrapeConfigs, 2, "a JMX job for orders and the Confluent Cloud job")
+	jmx := config.ScrapeConfigs[0]
+	assert.Equal(t, "msk-orders-jmx", jmx.JobName)
+	assert.Equal(t, []string{
+		"b-1.orders.abc.kafka.us-east-1.amazonaws.com:11001",
+		"b-2.orders.abc.kafka.us-east-1.amazonaws.com:11001",
+	}, jmx.StaticConfigs[0].Targets)
+	assert.Equal(t, "orders", jmx.StaticConfigs[0].Labels["cluster"])
+
+	cc := config.ScrapeConfigs[1]
+	assert.Equal(t, "/v2/metrics/cloud/export", cc.MetricsPath)
+	assert.Equal(t, []string{"lkc-abc123"}, cc.Params["resource.kafka.id"])
+	assert.Equal(t, CloudAPIKeyPlaceholder, cc.BasicAuth.Username)
+
+	data, err := config.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "job_name: confluent-cloud")
+	assert.Contains(t, string(data), "resource.kafka.id:")
+}
+
+func TestBuildScrapeConfig_NoTargetCluster(t *testing.T) {
+	config := BuildScrapeConfig(nil, nil)
+	assert.Empty(t, config.ScrapeConfigs)
+}
@@ -0,0 +1,94 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/redact"
+)
+
+// Audience selects who a rendered plan is for. The platform team gets the
+// full plan; application teams get only what changes for their clients.
+type Audience string
+
+const (
+	AudiencePlatform Audience = "platform"
+	// AudienceAppTeam keeps topics, consumer groups and client auth
+	// changes, and redacts AWS account IDs, ARNs and network resource IDs
+	// from them.
+	AudienceAppTeam Audience = "app-team"
+)
+
+// ParseAudience validates an --audience value; empty means platform.
+func ParseAudience(raw string) (Audience, error) {
+	switch Audience(raw) {
+	case "", AudiencePlatform:
+		return AudiencePlatform, nil
+	case AudienceAppTeam:
+		return AudienceAppTeam, nil
+	default:
+		return "", fmt.Errorf("--audience %q is invalid; valid values are %s or %s", raw, AudiencePlatform, AudienceAppTeam)
+	}
+}
+
+// ForAppTeam returns the application-team view of p: the header, client
+// auth migration, topic data volume and migration semantics (compacted
+// topics, transactional producers, static-membership consumer groups).
+// Sizing, networking, cost and every other infrastructure section is left
+// empty, and the kept sections go through redact.Infrastructure so account
+// IDs, ARNs, subnets and security groups don't leak into documentation the
+// platform team doesn't own. p is not modified.
+func ForAppTeam(p *Plan) (*Plan, error) {
+	kept := Plan{
+		Header:             p.Header,
+		Auth:               p.Auth,
+		DataVolume:         p.DataVolume,
+		MigrationSemantics: p.MigrationSemantics,
+	}
+	// The state file path often names the account or environment.
+	kept.Header.StateFilePath = ""
+
+	// Deep-copy through JSON so redaction can rewrite strings in place
+	// without touching the slices p still shares.
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return nil, fmt.Errorf("copy plan for app team: %w", err)
+	}
+	var appTeam Plan
+	if err := json.Unmarshal(data, &appTeam); err != nil {
+		return nil, fmt.Errorf("copy plan for app team: %w", err)
+	}
+	redact.InfrastructureFields(&appTeam)
+	return &appTeam, nil
+}
+
+// RenderAppTeamMarkdown emits the application-team plan built by
+// ForAppTeam: client auth changes, topics and consumer-group semantics,
+// without the infrastructure sections, definitions or appendices.
+func RenderAppTeamMarkdown(p *Plan) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# Migration Notes for Application Teams — %s → Confluent Cloud\n\n", p.Header.Source)
+	fmt.Fprintf(&b, "_Generated %s by KCP %s._\n\n", p.Header.GeneratedAt.Format("2006-01-02 15:04:05 UTC"), p.Header.KCPVersion)
+	b.WriteString("What the migration changes for client applications: how clients authenticate, which topics move and in what order, and the topics and consumer groups that need special handling at cutover. Infrastructure details — cluster sizing, networking, AWS account IDs, ARNs, subnets and security groups — are left out; the platform team owns the full migration plan.\n\n")
+
+	section := 1
+	if len(p.Auth) > 0 {
+		writeAuth(&b, p.Auth, nil, p.Inputs, section)
+		section++
+	}
+	if p.DataVolume != nil && len(p.DataVolume.Clusters) > 0 {
+		writeDataVolume(&b, p.DataVolume, section)
+		section++
+	}
+	if p.MigrationSemantics != nil && len(p.MigrationSemantics.Clusters) > 0 {
+		writeMigrationSemantics(&b, p.MigrationSemantics, section)
+		section++
+	}
+	if section == 1 {
+		b.WriteString("_The scan found no client auth, topic or consumer group changes to report._\n")
+	}
+
+	return b.Bytes(), nil
+}
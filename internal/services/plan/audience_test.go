@@ -0,0 +1,74 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAudience(t *testing.T) {
+	for raw, want := range map[string]Audience{"": AudiencePlatform, "platform": AudiencePlatform, "app-team": AudienceAppTeam} {
+		got, err := ParseAudience(raw)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseAudience("apps")
+	assert.ErrorContains(t, err, `--audience "apps" is invalid`)
+}
+
+func TestForAppTeam_KeepsClientSectionsAndRedactsInfrastructure(t *testing.T) {
+	p := &Plan{
+		Header:            PlanHeader{Source: "AWS MSK", StateFilePath: "/home/ops/123456789012/kcp-state.json"},
+		SourceEnvironment: SourceEnvironment{Clusters: []SourceClusterSummary{{ClusterID: "orders", Region: "us-east-1"}}},
+		Sizing:            []ClusterSizing{{ClusterID: "orders", FinalECKU: 4}},
+		NetworkingDecision: []NetworkingDecision{
+			{ClusterID: "orders", Verdict: NetworkingPrivateLink, Reason: "VPC vpc-0123456789abcdef0"},
+		},
+		ClientAccess: &ClientAccessSection{Clusters: []ClusterClientAccess{{ClusterID: "orders"}}},
+		Auth: []AuthDecision{{
+			ClusterID:      "orders",
+			SourceAuths:    []string{SourceAuthIAM},
+			TargetMappings: []AuthMappingRow{{SourceAuth: SourceAuthIAM, EffectiveTarget: "sasl_oauthbearer"}},
+			IAMPrincipals: []IAMPrincipalGrant{{
+				PrincipalArn:  "arn:aws:iam::123456789012:role/orders-producer",
+				PrincipalType: "role",
+				Actions:       []string{"kafka-cluster:WriteData"},
+				Resources:     []string{"arn:aws:kafka:us-east-1:123456789012:topic/orders/abc-1/*"},
+				Policies:      []string{"orders-producer-policy"},
+			}},
+		}},
+		MigrationSemantics: &MigrationSemanticsSection{Clusters: []ClusterMigrationSemantics{{
+			ClusterID:              "orders",
+			StaticMembershipGroups: []StaticMembershipGroup{{GroupID: "billing", State: "Stable", Members: 2, StaticInstanceIDs: []string{"billing-0", "billing-1"}}},
+		}}},
+	}
+
+	appTeam, err := ForAppTeam(p)
+	require.NoError(t, err)
+
+	assert.Empty(t, appTeam.Header.StateFilePath)
+	assert.Empty(t, appTeam.SourceEnvironment.Clusters)
+	assert.Empty(t, appTeam.Sizing)
+	assert.Empty(t, appTeam.NetworkingDecision)
+	assert.Nil(t, appTeam.ClientAccess)
+
+	grant := appTeam.Auth[0].IAMPrincipals[0]
+	assert.Equal(t, "role/orders-producer", grant.PrincipalArn)
+	assert.Equal(t, []string{"topic/orders/abc-1/*"}, grant.Resources)
+	assert.Equal(t, "billing", appTeam.MigrationSemantics.Clusters[0].StaticMembershipGroups[0].GroupID)
+
+	// The platform plan is untouched.
+	assert.Equal(t, "arn:aws:iam::123456789012:role/orders-producer", p.Auth[0].IAMPrincipals[0].PrincipalArn)
+
+	out, err := RenderAppTeamMarkdown(appTeam)
+	require.NoError(t, err)
+	body := string(out)
+	assert.Contains(t, body, "# Migration Notes for Application Teams — AWS MSK → Confluent Cloud")
+	assert.Contains(t, body, "## 1. Client Auth Migration")
+	assert.Contains(t, body, "## 2. Compaction, Transactions and Static Membership")
+	assert.Contains(t, body, "`role/orders-producer`")
+	assert.NotContains(t, body, "123456789012")
+	assert.NotContains(t, body, "arn:aws")
+	assert.NotContains(t, body, "Sizing")
+}
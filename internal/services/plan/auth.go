@@ -1,6 +1,13 @@
 package plan
 
-import "github.com/confluentinc/kcp/internal/services/report"
+import (
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
 
 // Target auth method tokens. Customer-facing values written into
 // `plan-inputs.yaml` and rendered back in the AuthDecision JSON.
@@ -31,8 +38,9 @@ func knownTargetAuthMethod(t string) bool {
 func decideAuth(c report.ProcessedCluster, cfg *PlanConfig, inputs PlanInputsResolved) AuthDecision {
 	sources := sourceAuthsDetected(c)
 	out := AuthDecision{
-		ClusterID:   c.Name,
-		SourceAuths: sources,
+		ClusterID:     c.Name,
+		SourceAuths:   sources,
+		IAMPrincipals: iamPrincipalGrants(c.AWSClientInformation.IAMAccess),
 	}
 	if len(sources) == 0 {
 		return out
@@ -82,3 +90,32 @@ func effectiveTarget(mapping AuthMapping, override string) string {
 	}
 	return override
 }
+
+// iamPrincipalGrants flattens the discovered IAM access into one row
+// per principal, with actions, resources and policies de-duplicated
+// and sorted so the §Auth table is stable across runs.
+func iamPrincipalGrants(access []types.IAMPrincipalAccess) []IAMPrincipalGrant {
+	var grants []IAMPrincipalGrant
+	for _, principal := range access {
+		actions, resources, policies := map[string]bool{}, map[string]bool{}, map[string]bool{}
+		for _, permission := range principal.Permissions {
+			for _, action := range permission.Actions {
+				actions[action] = true
+			}
+			for _, resource := range permission.Resources {
+				resources[resource] = true
+			}
+			policies[permission.Policy] = true
+		}
+		grants = append(grants, IAMPrincipalGrant{
+			PrincipalArn:  principal.PrincipalArn,
+			PrincipalType: principal.PrincipalType,
+			Actions:       slices.Sorted(maps.Keys(actions)),
+			Resources:     slices.Sorted(maps.Keys(resources)),
+			Policies:      slices.Sorted(maps.Keys(policies)),
+			CanConnect:    principal.CanConnect,
+		})
+	}
+	sort.Slice(grants, func(i, j int) bool { return grants[i].PrincipalArn < grants[j].PrincipalArn })
+	return grants
+}
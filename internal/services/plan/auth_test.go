@@ -3,9 +3,12 @@ package plan
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // authInputs builds a PlanInputsResolved with the default target_auth
@@ -148,3 +151,71 @@ func TestDecideAuth_OverrideRejectedFalseForGoodValues(t *testing.T) {
 		assert.Empty(t, d.RejectedOverrideValue, "good override %q must not leak a rejected value", override)
 	}
 }
+
+// iamAccessCluster is an IAM cluster with the principal mapping that
+// `kcp discover` captures from account IAM policies.
+func iamAccessCluster(name string) report.ProcessedCluster {
+	c := withSourceAuth(name, SourceAuthIAM)
+	c.AWSClientInformation.IAMAccess = []types.IAMPrincipalAccess{
+		{
+			PrincipalArn:  "arn:aws:iam::123456789012:role/orders-producer",
+			PrincipalType: "role",
+			CanConnect:    aws.Bool(true),
+			Permissions: []types.IAMKafkaPermission{
+				{Policy: "orders-write", Actions: []string{"kafka-cluster:WriteData", "kafka-cluster:Connect"}, Resources: []string{"arn:aws:kafka:us-east-1:123456789012:topic/orders/*"}},
+				{Policy: "connect-all", Actions: []string{"kafka-cluster:Connect"}, Resources: []string{"*"}},
+			},
+		},
+		{
+			PrincipalArn:  "arn:aws:iam::123456789012:group/analytics",
+			PrincipalType: "group",
+			Permissions: []types.IAMKafkaPermission{
+				{Policy: "read|all", Actions: []string{"kafka-cluster:ReadData"}, Resources: []string{"*"}},
+			},
+		},
+	}
+	return c
+}
+
+// The discovered IAM access is merged per principal so one row lists
+// every action and policy, in a stable order.
+func TestDecideAuth_IAMPrincipalsMergedPerPrincipal(t *testing.T) {
+	d := decideAuth(iamAccessCluster("iam-cluster"), defaultCfg(t), authInputs())
+
+	require.Len(t, d.IAMPrincipals, 2)
+	assert.Equal(t, "arn:aws:iam::123456789012:group/analytics", d.IAMPrincipals[0].PrincipalArn, "rows sort by principal ARN")
+	assert.Nil(t, d.IAMPrincipals[0].CanConnect)
+
+	producer := d.IAMPrincipals[1]
+	assert.Equal(t, []string{"kafka-cluster:Connect", "kafka-cluster:WriteData"}, producer.Actions)
+	assert.Equal(t, []string{"*", "arn:aws:kafka:us-east-1:123456789012:topic/orders/*"}, producer.Resources)
+	assert.Equal(t, []string{"connect-all", "orders-write"}, producer.Policies)
+}
+
+func TestRenderMarkdown_IAMPrincipalPermissions(t *testing.T) {
+	cfg := defaultCfg(t)
+	p := &Plan{Auth: []AuthDecision{
+		decideAuth(iamAccessCluster("iam-cluster"), cfg, authInputs()),
+		decideAuth(withSourceAuth("scram-cluster", SourceAuthSCRAM), cfg, authInputs()),
+	}}
+
+	out, err := RenderMarkdown(p, cfg)
+	require.NoError(t, err)
+	body := string(out)
+
+	assert.Contains(t, body, "### IAM principal permissions")
+	assert.Contains(t, body, "| iam-cluster | `arn:aws:iam::123456789012:group/analytics` | group | `kafka-cluster:ReadData` | `*` | read\\|all | _unknown_ |")
+	assert.Contains(t, body, "|  | `arn:aws:iam::123456789012:role/orders-producer` | role | `kafka-cluster:Connect`, `kafka-cluster:WriteData` |")
+	assert.Contains(t, body, "| ✅ yes |")
+}
+
+// Plans from state written before the IAM analysis existed carry no
+// principals; the sub-table must not render empty.
+func TestRenderMarkdown_NoIAMPrincipalsOmitsTable(t *testing.T) {
+	cfg := defaultCfg(t)
+	p := &Plan{Auth: []AuthDecision{decideAuth(withSourceAuth("iam-cluster", SourceAuthIAM), cfg, authInputs())}}
+
+	out, err := RenderMarkdown(p, cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "IAM principal permissions")
+}
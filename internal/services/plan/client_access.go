@@ -0,0 +1,164 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// publicAccessEnabled is the MSK PublicAccess type for clusters whose
+// brokers have public endpoints.
+const publicAccessEnabled = "SERVICE_PROVIDED_EIPS"
+
+// detectClientAccess lists, per source cluster, the client access
+// paths beyond the cluster's own VPC: multi-VPC private connectivity
+// and the client VPC connections using it, and public access. Each
+// cluster gets the steps that recreate those paths on its Confluent
+// Cloud networking verdict. Returns nil when no cluster has any, so
+// the renderer omits the section.
+func detectClientAccess(state report.ProcessedState, networking []NetworkingDecision) *ClientAccessSection {
+	verdicts := map[string]Networking{}
+	for _, n := range networking {
+		verdicts[n.ClusterID] = n.Verdict
+	}
+
+	var clusters []ClusterClientAccess
+	for _, c := range collectClusters(state) {
+		access := ClusterClientAccess{
+			ClusterID:    c.Name,
+			MultiVPCAuth: multiVPCAuthTypes(c),
+			Networking:   verdicts[c.Name],
+		}
+		if info := connectivityInfo(c); info != nil && info.PublicAccess != nil {
+			access.PublicAccess = aws.ToString(info.PublicAccess.Type) == publicAccessEnabled
+		}
+		for _, conn := range c.AWSClientInformation.ClientVpcConnections {
+			access.Connections = append(access.Connections, clientVPCConnection(conn))
+		}
+		if len(access.MultiVPCAuth) == 0 && len(access.Connections) == 0 && !access.PublicAccess {
+			continue
+		}
+		access.Steps = clientAccessSteps(access)
+		clusters = append(clusters, access)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	return &ClientAccessSection{Clusters: clusters}
+}
+
+// connectivityInfo is the broker connectivity of a provisioned
+// cluster; nil for serverless clusters and incomplete scans.
+func connectivityInfo(c report.ProcessedCluster) *kafkatypes.ConnectivityInfo {
+	provisioned := c.AWSClientInformation.MskClusterConfig.Provisioned
+	if provisioned == nil || provisioned.BrokerNodeGroupInfo == nil {
+		return nil
+	}
+	return provisioned.BrokerNodeGroupInfo.ConnectivityInfo
+}
+
+// multiVPCAuthTypes lists the auth types enabled for multi-VPC
+// private connectivity, in IAM, SCRAM, TLS order.
+func multiVPCAuthTypes(c report.ProcessedCluster) []string {
+	info := connectivityInfo(c)
+	if info == nil || info.VpcConnectivity == nil || info.VpcConnectivity.ClientAuthentication == nil {
+		return nil
+	}
+	auth := info.VpcConnectivity.ClientAuthentication
+	var types []string
+	if auth.Sasl != nil && auth.Sasl.Iam != nil && aws.ToBool(auth.Sasl.Iam.Enabled) {
+		types = append(types, "IAM")
+	}
+	if auth.Sasl != nil && auth.Sasl.Scram != nil && aws.ToBool(auth.Sasl.Scram.Enabled) {
+		types = append(types, "SCRAM")
+	}
+	if auth.Tls != nil && aws.ToBool(auth.Tls.Enabled) {
+		types = append(types, "TLS")
+	}
+	return types
+}
+
+func clientVPCConnection(conn kafkatypes.ClientVpcConnection) ClientVPCConnection {
+	arn := aws.ToString(conn.VpcConnectionArn)
+	owner := aws.ToString(conn.Owner)
+	if owner == "" {
+		// arn:aws:kafka:<region>:<account>:vpc-connection/...
+		if parts := strings.Split(arn, ":"); len(parts) > 4 {
+			owner = parts[4]
+		}
+	}
+	return ClientVPCConnection{
+		Arn:            arn,
+		Owner:          owner,
+		Authentication: aws.ToString(conn.Authentication),
+		State:          string(conn.State),
+		// A connection still being created is on its way to AVAILABLE
+		// and needs recreating all the same.
+		Active: conn.State == kafkatypes.VpcConnectionStateAvailable || conn.State == kafkatypes.VpcConnectionStateCreating,
+	}
+}
+
+func clientAccessSteps(access ClusterClientAccess) []string {
+	var accounts, iamAccounts []string
+	for _, conn := range access.Connections {
+		if !conn.Active {
+			continue
+		}
+		accounts = append(accounts, conn.Owner)
+		if conn.Authentication == "SASL_IAM" {
+			iamAccounts = append(iamAccounts, conn.Owner)
+		}
+	}
+	accounts, iamAccounts = sortedUnique(accounts), sortedUnique(iamAccounts)
+
+	var steps []string
+	switch {
+	case len(accounts) == 0 && len(access.MultiVPCAuth) > 0:
+		steps = append(steps, "Multi-VPC connectivity is enabled but no client VPC connection is active — confirm nothing depends on it before dropping it.")
+	case len(accounts) == 0:
+	case access.Networking == NetworkingPrivateLink:
+		steps = append(steps, fmt.Sprintf("Allow AWS account(s) %s on the cluster's PrivateLink access (the PrivateLink Attachment on Enterprise, the network's PrivateLink access on Dedicated), then create an interface VPC endpoint and a private DNS zone for the cluster in each client VPC that has an MSK VPC connection today.",
+			strings.Join(quoteAll(accounts), ", ")))
+	case access.Networking == "":
+		steps = append(steps, fmt.Sprintf("Client VPCs in AWS account(s) %s reach the cluster over MSK VPC connections; give each of them a private path to the Confluent Cloud network — PrivateLink keeps one endpoint per VPC, as today.",
+			strings.Join(quoteAll(accounts), ", ")))
+	default:
+		steps = append(steps, fmt.Sprintf("%s networking has no per-VPC endpoint like an MSK VPC connection: route each client VPC in AWS account(s) %s to the Confluent Cloud network (%s), or plan PrivateLink instead to keep one endpoint per VPC without shared routing.",
+			networkingLabel(access.Networking), strings.Join(quoteAll(accounts), ", "), networkingRoute(access.Networking)))
+	}
+	if len(iamAccounts) > 0 {
+		steps = append(steps, fmt.Sprintf("VPC connections from %s authenticate with IAM, which Confluent Cloud doesn't accept — those clients move to API keys or OAuth along with the cluster's other IAM clients (see the Auth section).",
+			strings.Join(quoteAll(iamAccounts), ", ")))
+	}
+	if access.PublicAccess {
+		steps = append(steps, "Public access is on: clients reaching the brokers over the internet need a Confluent Cloud cluster with public networking — private networking has no public endpoint — or must move onto a private path first.")
+	}
+	return steps
+}
+
+func networkingLabel(n Networking) string {
+	switch n {
+	case NetworkingTransitGateway:
+		return "Transit Gateway"
+	case NetworkingVPCPeering:
+		return "VPC Peering"
+	default:
+		return string(n)
+	}
+}
+
+// networkingRoute is how a client VPC reaches a Confluent Cloud
+// network of the given kind.
+func networkingRoute(n Networking) string {
+	switch n {
+	case NetworkingTransitGateway:
+		return "attach it to the transit gateway"
+	case NetworkingVPCPeering:
+		return "peer it with the Confluent Cloud network"
+	default:
+		return "peer it with, or route it over a transit gateway to, the VPC hosting the PNI gateway"
+	}
+}
@@ -0,0 +1,118 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clusterWithConnectivity(name string, info *kafkatypes.ConnectivityInfo, conns ...kafkatypes.ClientVpcConnection) report.ProcessedCluster {
+	c := report.ProcessedCluster{Name: name}
+	c.AWSClientInformation.MskClusterConfig.Provisioned = &kafkatypes.Provisioned{
+		BrokerNodeGroupInfo: &kafkatypes.BrokerNodeGroupInfo{ConnectivityInfo: info},
+	}
+	c.AWSClientInformation.ClientVpcConnections = conns
+	return c
+}
+
+func multiVPC(iam, scram, tls bool) *kafkatypes.ConnectivityInfo {
+	return &kafkatypes.ConnectivityInfo{
+		PublicAccess: &kafkatypes.PublicAccess{Type: aws.String("DISABLED")},
+		VpcConnectivity: &kafkatypes.VpcConnectivity{ClientAuthentication: &kafkatypes.VpcConnectivityClientAuthentication{
+			Sasl: &kafkatypes.VpcConnectivitySasl{
+				Iam:   &kafkatypes.VpcConnectivityIam{Enabled: aws.Bool(iam)},
+				Scram: &kafkatypes.VpcConnectivityScram{Enabled: aws.Bool(scram)},
+			},
+			Tls: &kafkatypes.VpcConnectivityTls{Enabled: aws.Bool(tls)},
+		}},
+	}
+}
+
+func vpcConnection(account, auth string, state kafkatypes.VpcConnectionState) kafkatypes.ClientVpcConnection {
+	return kafkatypes.ClientVpcConnection{
+		VpcConnectionArn: aws.String("arn:aws:kafka:us-east-1:" + account + ":vpc-connection/" + account + "/orders/abc"),
+		Authentication:   aws.String(auth),
+		State:            state,
+	}
+}
+
+func TestDetectClientAccess_NoPathsReturnsNil(t *testing.T) {
+	state := wrapClusters(
+		report.ProcessedCluster{Name: "serverless"},
+		clusterWithConnectivity("orders", &kafkatypes.ConnectivityInfo{PublicAccess: &kafkatypes.PublicAccess{Type: aws.String("DISABLED")}}),
+	)
+	assert.Nil(t, detectClientAccess(state, nil))
+}
+
+func TestDetectClientAccess_MultiVPCConnectionsOnPrivateLink(t *testing.T) {
+	state := wrapClusters(clusterWithConnectivity("orders", multiVPC(true, true, false),
+		vpcConnection("111111111111", "SASL_IAM", kafkatypes.VpcConnectionStateAvailable),
+		vpcConnection("222222222222", "SASL_SCRAM", kafkatypes.VpcConnectionStateCreating),
+		vpcConnection("333333333333", "SASL_SCRAM", kafkatypes.VpcConnectionStateRejected),
+	))
+
+	section := detectClientAccess(state, []NetworkingDecision{{ClusterID: "orders", Verdict: NetworkingPrivateLink}})
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 1)
+	c := section.Clusters[0]
+	assert.Equal(t, []string{"IAM", "SCRAM"}, c.MultiVPCAuth)
+	assert.False(t, c.PublicAccess)
+	require.Len(t, c.Connections, 3)
+	assert.Equal(t, "111111111111", c.Connections[0].Owner, "owner falls back to the connection ARN's account")
+	assert.True(t, c.Connections[1].Active)
+	assert.False(t, c.Connections[2].Active)
+	require.Len(t, c.Steps, 2)
+	assert.Contains(t, c.Steps[0], "`111111111111`, `222222222222` on the cluster's PrivateLink access")
+	assert.NotContains(t, c.Steps[0], "333333333333", "rejected connections have nothing to recreate")
+	assert.Contains(t, c.Steps[1], "`111111111111` authenticate with IAM")
+}
+
+func TestDetectClientAccess_RoutedNetworkingAndPublicAccess(t *testing.T) {
+	info := multiVPC(false, false, true)
+	info.PublicAccess.Type = aws.String(publicAccessEnabled)
+	conn := vpcConnection("111111111111", "TLS", kafkatypes.VpcConnectionStateAvailable)
+	conn.Owner = aws.String("444444444444")
+	state := wrapClusters(clusterWithConnectivity("orders", info, conn))
+
+	c := detectClientAccess(state, []NetworkingDecision{{ClusterID: "orders", Verdict: NetworkingTransitGateway}}).Clusters[0]
+
+	assert.True(t, c.PublicAccess)
+	require.Len(t, c.Steps, 2)
+	assert.Contains(t, c.Steps[0], "Transit Gateway networking has no per-VPC endpoint")
+	assert.Contains(t, c.Steps[0], "`444444444444`")
+	assert.Contains(t, c.Steps[0], "attach it to the transit gateway")
+	assert.Contains(t, c.Steps[1], "Public access is on")
+}
+
+func TestDetectClientAccess_EnabledButUnused(t *testing.T) {
+	state := wrapClusters(clusterWithConnectivity("orders", multiVPC(true, false, false),
+		vpcConnection("111111111111", "SASL_IAM", kafkatypes.VpcConnectionStateInactive)))
+
+	c := detectClientAccess(state, []NetworkingDecision{{ClusterID: "orders", Verdict: NetworkingPNI}}).Clusters[0]
+
+	require.Len(t, c.Steps, 1)
+	assert.Contains(t, c.Steps[0], "no client VPC connection is active")
+}
+
+func TestWriteClientAccess_RendersConnectionsAndSteps(t *testing.T) {
+	section := detectClientAccess(wrapClusters(clusterWithConnectivity("orders", multiVPC(false, true, false),
+		vpcConnection("111111111111", "SASL_SCRAM", kafkatypes.VpcConnectionStateAvailable),
+		vpcConnection("333333333333", "SASL_SCRAM", kafkatypes.VpcConnectionStateRejected),
+	)), []NetworkingDecision{{ClusterID: "orders", Verdict: NetworkingPNI}})
+
+	var b bytes.Buffer
+	writeClientAccess(&b, section, 10)
+	out := b.String()
+
+	assert.Contains(t, out, "## 10. Client Access Paths")
+	assert.Contains(t, out, "### orders\n\nMulti-VPC connectivity: enabled (SCRAM) · Public access: off · Confluent Cloud networking: PNI")
+	assert.Contains(t, out, "| `111111111111` | SASL_SCRAM | AVAILABLE | `arn:aws:kafka:us-east-1:111111111111:vpc-connection/111111111111/orders/abc` |")
+	assert.Contains(t, out, "| REJECTED (nothing to recreate) |")
+	assert.Contains(t, out, "- PNI networking has no per-VPC endpoint")
+}
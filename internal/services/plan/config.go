@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/goccy/go-yaml"
 )
@@ -31,6 +32,96 @@ type PlanConfig struct {
 	AuthMapping        map[string]AuthMapping `yaml:"auth_mapping"`
 	Thresholds         Thresholds             `yaml:"thresholds"`
 	CostReconciliation CostReconciliationCfg  `yaml:"cost_reconciliation"`
+	ConfigBaseline     ConfigBaselineCfg      `yaml:"config_baseline"`
+	PartitionSkew      PartitionSkewCfg       `yaml:"partition_skew"`
+	DataVolume         DataVolumeCfg          `yaml:"data_volume"`
+	LinkBandwidth      LinkBandwidthCfg       `yaml:"link_bandwidth"`
+	CrossAZTraffic     CrossAZTrafficCfg      `yaml:"cross_az_traffic"`
+	MigrationWaves     MigrationWavesCfg      `yaml:"migration_waves"`
+}
+
+// PartitionSkewCfg holds the cutoffs §Partition Skew flags topics at,
+// judged on the on-disk partition sizes `kcp scan clusters` reads from
+// the brokers' log dirs. Sizes are in GB (1024^3 bytes), matching how
+// the Plan renders them.
+type PartitionSkewCfg struct {
+	// OversizedPartitionGB — a partition at or above this size is
+	// flagged on its own: a cluster link mirrors each partition
+	// sequentially, so one huge partition sets the initial sync time.
+	OversizedPartitionGB float64 `yaml:"oversized_partition_gb"`
+	// SkewRatio — a topic whose largest partition is at least this
+	// many times its mean partition size is flagged as skewed.
+	SkewRatio float64 `yaml:"skew_ratio"`
+	// MinTopicGB — topics smaller than this are never flagged as
+	// skewed; a 3x ratio on a few MB doesn't affect the migration.
+	MinTopicGB float64 `yaml:"min_topic_gb"`
+}
+
+// DataVolumeCfg drives §Topic Data Volume, which lists each cluster's
+// topics by the on-disk size `kcp scan clusters` reads from the
+// brokers' log dirs.
+type DataVolumeCfg struct {
+	// LinkThroughputMBps is the sustained cluster link throughput, in
+	// MB/s (1024^2 bytes), assumed when estimating how long each
+	// topic's initial sync takes. Replace it with a measured figure,
+	// e.g. from `kcp benchmark`, when one is available.
+	LinkThroughputMBps float64 `yaml:"link_throughput_mbps"`
+	// MaxTopics caps the topics listed per cluster; the rest are
+	// summarised in one line.
+	MaxTopics int `yaml:"max_topics"`
+}
+
+// LinkBandwidthCfg drives §Cluster Link Bandwidth, which combines each
+// cluster's data volume with its produce rate. The link throughput is
+// data_volume.link_throughput_mbps.
+type LinkBandwidthCfg struct {
+	// SteadyStateHeadroom is the fraction added to the peak produce
+	// rate for the bandwidth the link needs once the initial sync is
+	// done, e.g. 0.3 for 30%.
+	SteadyStateHeadroom float64 `yaml:"steady_state_headroom"`
+}
+
+// CrossAZTrafficCfg drives §Cross-AZ Consumer Traffic, which prices the
+// consumer traffic that crosses availability zones because consumers
+// fetch from partition leaders in other zones.
+type CrossAZTrafficCfg struct {
+	// TransferUSDPerGB is what AWS charges per GB crossing availability
+	// zones within a region, both directions together.
+	TransferUSDPerGB float64 `yaml:"transfer_usd_per_gb"`
+	// Source is where TransferUSDPerGB was read from, rendered next to
+	// the estimate.
+	Source string `yaml:"source"`
+}
+
+// MigrationWavesCfg holds the defaults §Migration Waves packs clusters
+// with when plan-inputs.yaml doesn't override them.
+type MigrationWavesCfg struct {
+	// MaxClustersPerWave caps how many clusters migrate in one wave.
+	// A team owning more clusters than this still gets a single wave.
+	MaxClustersPerWave int `yaml:"max_clusters_per_wave"`
+}
+
+// ConfigBaselineCfg is the broker-config baseline §Configuration Drift
+// compares each cluster's MSK configuration revision against. Rules
+// only fire on properties the revision sets explicitly — an unset
+// property runs with the MSK default, which already meets the baseline.
+type ConfigBaselineCfg struct {
+	Rules        []ConfigBaselineRule `yaml:"rules"`
+	Source       string               `yaml:"source"`
+	LastVerified string               `yaml:"last_verified"`
+}
+
+// ConfigBaselineRule is one server property check. Compare is `equals`
+// (string match, case-insensitive) or `min` / `max` (numeric bound on
+// Value). Kind is `best_practice` (risky on the source itself) or
+// `cc_immutable` (Confluent Cloud pins the value, so behavior that
+// depends on the source setting won't carry over).
+type ConfigBaselineRule struct {
+	Property string `yaml:"property"`
+	Compare  string `yaml:"compare"`
+	Value    string `yaml:"value"`
+	Kind     string `yaml:"kind"`
+	Note     string `yaml:"note"`
 }
 
 // CostReconciliationCfg pins the AWS Cost Explorer usage-string
@@ -225,6 +316,50 @@ func (c *PlanConfig) Validate() error {
 	if len(c.CostReconciliation.UsageFamilies) == 0 {
 		return fmt.Errorf("plan-config cost_reconciliation.usage_families must be non-empty (the cost-explorer parser uses this list to identify MSK broker usage strings)")
 	}
+	if c.ConfigBaseline.Source == "" {
+		return fmt.Errorf("plan-config config_baseline.source must be non-empty (provenance is mandatory)")
+	}
+	for i, rule := range c.ConfigBaseline.Rules {
+		if rule.Property == "" {
+			return fmt.Errorf("plan-config config_baseline.rules[%d].property must be non-empty", i)
+		}
+		switch rule.Compare {
+		case ConfigCompareEquals:
+		case ConfigCompareMin, ConfigCompareMax:
+			if _, err := strconv.ParseFloat(rule.Value, 64); err != nil {
+				return fmt.Errorf("plan-config config_baseline.rules[%d] (%s): value %q must be numeric for compare %q", i, rule.Property, rule.Value, rule.Compare)
+			}
+		default:
+			return fmt.Errorf("plan-config config_baseline.rules[%d] (%s): compare must be one of equals, min, max (got %q)", i, rule.Property, rule.Compare)
+		}
+		if rule.Kind != string(ConfigDriftBestPractice) && rule.Kind != string(ConfigDriftCCImmutable) {
+			return fmt.Errorf("plan-config config_baseline.rules[%d] (%s): kind must be %s or %s (got %q)", i, rule.Property, ConfigDriftBestPractice, ConfigDriftCCImmutable, rule.Kind)
+		}
+	}
+	if c.PartitionSkew.OversizedPartitionGB <= 0 {
+		return fmt.Errorf("plan-config partition_skew.oversized_partition_gb must be > 0 (got %v)", c.PartitionSkew.OversizedPartitionGB)
+	}
+	if c.PartitionSkew.SkewRatio <= 1 {
+		return fmt.Errorf("plan-config partition_skew.skew_ratio must be > 1 (got %v) — the largest partition is always at least the mean", c.PartitionSkew.SkewRatio)
+	}
+	if c.PartitionSkew.MinTopicGB < 0 {
+		return fmt.Errorf("plan-config partition_skew.min_topic_gb must be >= 0 (got %v)", c.PartitionSkew.MinTopicGB)
+	}
+	if c.DataVolume.LinkThroughputMBps <= 0 {
+		return fmt.Errorf("plan-config data_volume.link_throughput_mbps must be > 0 (got %v)", c.DataVolume.LinkThroughputMBps)
+	}
+	if c.DataVolume.MaxTopics <= 0 {
+		return fmt.Errorf("plan-config data_volume.max_topics must be > 0 (got %v)", c.DataVolume.MaxTopics)
+	}
+	if c.LinkBandwidth.SteadyStateHeadroom < 0 {
+		return fmt.Errorf("plan-config link_bandwidth.steady_state_headroom must be >= 0 (got %v)", c.LinkBandwidth.SteadyStateHeadroom)
+	}
+	if c.CrossAZTraffic.TransferUSDPerGB < 0 {
+		return fmt.Errorf("plan-config cross_az_traffic.transfer_usd_per_gb must be >= 0 (got %v)", c.CrossAZTraffic.TransferUSDPerGB)
+	}
+	if c.MigrationWaves.MaxClustersPerWave <= 0 {
+		return fmt.Errorf("plan-config migration_waves.max_clusters_per_wave must be > 0 (got %v)", c.MigrationWaves.MaxClustersPerWave)
+	}
 	// Every auth_mapping entry MUST carry Target + provenance (Source +
 	// LastVerified). The fields exist so the rendered Plan can audit
 	// where each recommendation came from — a silently-empty mapping
@@ -0,0 +1,147 @@
+package plan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// Baseline rule comparators (plan-config `config_baseline.rules[].compare`).
+const (
+	ConfigCompareEquals = "equals"
+	ConfigCompareMin    = "min"
+	ConfigCompareMax    = "max"
+)
+
+// detectConfigDrift compares each Provisioned cluster's current MSK
+// configuration revision against cfg.ConfigBaseline. Clusters on the
+// MSK default configuration have nothing to compare and are skipped,
+// as are Serverless clusters (no broker configuration). Returns nil
+// when no cluster has a finding so the renderer omits the section.
+func detectConfigDrift(state report.ProcessedState, cfg *PlanConfig) *ConfigDriftSection {
+	revisions := map[string][]byte{}
+	for _, src := range state.Sources {
+		if src.MSKData == nil {
+			continue
+		}
+		for _, region := range src.MSKData.Regions {
+			for _, rev := range region.Configurations {
+				revisions[configRevisionKey(aws.ToString(rev.Arn), aws.ToInt64(rev.Revision))] = rev.ServerProperties
+			}
+		}
+	}
+
+	var drifted []ClusterConfigDrift
+	for _, c := range collectClusters(state) {
+		if isServerless(c) {
+			continue
+		}
+		arn, revision, ok := clusterConfigRevision(c)
+		if !ok {
+			continue
+		}
+		serverProperties, ok := revisions[configRevisionKey(arn, revision)]
+		if !ok {
+			continue
+		}
+		findings := evalConfigBaseline(parseServerProperties(serverProperties), cfg.ConfigBaseline.Rules)
+		if len(findings) == 0 {
+			continue
+		}
+		drifted = append(drifted, ClusterConfigDrift{
+			ClusterID:             c.Name,
+			ConfigurationArn:      arn,
+			ConfigurationRevision: revision,
+			Findings:              findings,
+		})
+	}
+	if len(drifted) == 0 {
+		return nil
+	}
+	sort.Slice(drifted, func(i, j int) bool { return drifted[i].ClusterID < drifted[j].ClusterID })
+	return &ConfigDriftSection{Clusters: drifted}
+}
+
+// clusterConfigRevision returns the custom configuration the cluster's
+// brokers currently run with. ok is false on the MSK default
+// configuration, which carries no ARN.
+func clusterConfigRevision(c report.ProcessedCluster) (arn string, revision int64, ok bool) {
+	prov := c.AWSClientInformation.MskClusterConfig.Provisioned
+	if prov == nil || prov.CurrentBrokerSoftwareInfo == nil || prov.CurrentBrokerSoftwareInfo.ConfigurationArn == nil {
+		return "", 0, false
+	}
+	software := prov.CurrentBrokerSoftwareInfo
+	return aws.ToString(software.ConfigurationArn), aws.ToInt64(software.ConfigurationRevision), true
+}
+
+func configRevisionKey(arn string, revision int64) string {
+	return fmt.Sprintf("%s@%d", arn, revision)
+}
+
+// evalConfigBaseline returns a finding for every rule whose property
+// is set in props and fails the comparison, in rule order. Unparseable
+// numeric values are reported rather than skipped — a typo in a
+// durability setting is itself worth a look.
+func evalConfigBaseline(props map[string]string, rules []ConfigBaselineRule) []ConfigDriftFinding {
+	var findings []ConfigDriftFinding
+	for _, rule := range rules {
+		value, ok := props[rule.Property]
+		if !ok {
+			continue
+		}
+		var violates bool
+		var baseline string
+		switch rule.Compare {
+		case ConfigCompareEquals:
+			violates = !strings.EqualFold(value, rule.Value)
+			baseline = "= " + rule.Value
+		case ConfigCompareMin, ConfigCompareMax:
+			got, err := strconv.ParseFloat(value, 64)
+			want, _ := strconv.ParseFloat(rule.Value, 64)
+			if rule.Compare == ConfigCompareMin {
+				violates = err != nil || got < want
+				baseline = ">= " + rule.Value
+			} else {
+				violates = err != nil || got > want
+				baseline = "<= " + rule.Value
+			}
+		}
+		if !violates {
+			continue
+		}
+		findings = append(findings, ConfigDriftFinding{
+			Property: rule.Property,
+			Value:    value,
+			Baseline: baseline,
+			Kind:     ConfigDriftKind(rule.Kind),
+			Note:     rule.Note,
+		})
+	}
+	return findings
+}
+
+// parseServerProperties reads a Java properties body as MSK stores it
+// in a configuration revision: one `key=value` (or `key: value`) per
+// line, `#` / `!` comments, whitespace around the separator ignored.
+func parseServerProperties(data []byte) map[string]string {
+	props := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			continue
+		}
+		props[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	return props
+}
@@ -0,0 +1,102 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const driftConfigArn = "arn:aws:kafka:us-east-1:000000000000:configuration/orders-config/abc-1"
+
+// configuredCluster attaches a custom MSK configuration revision to a
+// Provisioned cluster and records the revision's server properties
+// on the region, the way `kcp discover` writes them.
+func configuredCluster(name string, revision int64) report.ProcessedCluster {
+	c := redFlagCluster(name, "3.6.0", "", "")
+	c.AWSClientInformation.MskClusterConfig.Provisioned.CurrentBrokerSoftwareInfo.ConfigurationArn = aws.String(driftConfigArn)
+	c.AWSClientInformation.MskClusterConfig.Provisioned.CurrentBrokerSoftwareInfo.ConfigurationRevision = aws.Int64(revision)
+	return c
+}
+
+func withConfigurations(state report.ProcessedState, revisions map[int64]string) report.ProcessedState {
+	region := &state.Sources[0].MSKData.Regions[0]
+	for revision, props := range revisions {
+		region.Configurations = append(region.Configurations, kafka.DescribeConfigurationRevisionOutput{
+			Arn:              aws.String(driftConfigArn),
+			Revision:         aws.Int64(revision),
+			ServerProperties: []byte(props),
+		})
+	}
+	return state
+}
+
+func TestDetectConfigDrift_FlagsRiskyAndNonPortableSettings(t *testing.T) {
+	state := withConfigurations(wrapClusters(configuredCluster("orders", 2)), map[int64]string{
+		1: "unclean.leader.election.enable=true\n",
+		2: "# tuned for throughput\nauto.create.topics.enable = true\nunclean.leader.election.enable=TRUE\nmin.insync.replicas=1\nnum.io.threads=8\nmessage.max.bytes=1048588\n",
+	})
+
+	section := detectConfigDrift(state, defaultCfg(t))
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 1)
+	drift := section.Clusters[0]
+	assert.Equal(t, "orders", drift.ClusterID)
+	assert.Equal(t, int64(2), drift.ConfigurationRevision)
+	assert.Equal(t, []ConfigDriftFinding{
+		{Property: "unclean.leader.election.enable", Value: "TRUE", Baseline: "= false", Kind: ConfigDriftBestPractice, Note: drift.Findings[0].Note},
+		{Property: "min.insync.replicas", Value: "1", Baseline: ">= 2", Kind: ConfigDriftBestPractice, Note: drift.Findings[1].Note},
+		{Property: "auto.create.topics.enable", Value: "true", Baseline: "= false", Kind: ConfigDriftCCImmutable, Note: drift.Findings[2].Note},
+	}, drift.Findings)
+}
+
+// Clusters on the MSK default configuration, or whose revision isn't
+// in the state file, have nothing to compare.
+func TestDetectConfigDrift_NothingToCompareReturnsNil(t *testing.T) {
+	missingRevision := configuredCluster("missing-revision", 3)
+	state := withConfigurations(wrapClusters(redFlagCluster("default-config", "3.6.0", "", ""), missingRevision), map[int64]string{
+		1: "unclean.leader.election.enable=true\n",
+	})
+	assert.Nil(t, detectConfigDrift(state, defaultCfg(t)))
+}
+
+func TestDetectConfigDrift_CompliantRevisionReturnsNil(t *testing.T) {
+	state := withConfigurations(wrapClusters(configuredCluster("orders", 1)), map[int64]string{
+		1: "unclean.leader.election.enable=false\nmin.insync.replicas=2\ndefault.replication.factor=3\n",
+	})
+	assert.Nil(t, detectConfigDrift(state, defaultCfg(t)))
+}
+
+func TestEvalConfigBaseline_UnparseableNumberIsAFinding(t *testing.T) {
+	rules := []ConfigBaselineRule{{Property: "min.insync.replicas", Compare: ConfigCompareMin, Value: "2", Kind: string(ConfigDriftBestPractice)}}
+	findings := evalConfigBaseline(map[string]string{"min.insync.replicas": "two"}, rules)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "two", findings[0].Value)
+}
+
+func TestPlanConfig_ValidateRejectsBadBaselineRule(t *testing.T) {
+	cfg := defaultCfg(t)
+	cfg.ConfigBaseline.Rules = append(cfg.ConfigBaseline.Rules, ConfigBaselineRule{Property: "log.retention.ms", Compare: ConfigCompareMax, Value: "forever", Kind: string(ConfigDriftBestPractice)})
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be numeric")
+}
+
+func TestRenderMarkdown_ConfigDriftSection(t *testing.T) {
+	state := withConfigurations(wrapClusters(configuredCluster("orders", 1)), map[int64]string{
+		1: "unclean.leader.election.enable=true\nauto.create.topics.enable=true\n",
+	})
+	cfg := defaultCfg(t)
+	plan := buildPlanForRedFlags(t, state, cfg, defaultInputs())
+	require.NotNil(t, plan.ConfigDrift)
+
+	out, err := RenderMarkdown(plan, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), ". Configuration Drift\n")
+	assert.Contains(t, string(out), "| `unclean.leader.election.enable` | `true` | `= false` | ⚠️ Risky |")
+	assert.Contains(t, string(out), "| `auto.create.topics.enable` | `true` | `= false` | 🚫 Not portable |")
+}
@@ -0,0 +1,58 @@
+package plan
+
+import (
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/connector_mapping"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// detectConnectorSupport lists, per cluster, the MSK Connect and
+// self-managed connectors with the Confluent Cloud status the report
+// service gave each connector class, and counts them fleet-wide.
+// Returns nil when no cluster has connectors so the renderer omits the
+// section.
+func detectConnectorSupport(state report.ProcessedState) *ConnectorSupportSection {
+	section := &ConnectorSupportSection{}
+	for _, c := range collectClusters(state) {
+		if len(c.ConnectorAvailability) == 0 {
+			continue
+		}
+		cluster := ClusterConnectorSupport{ClusterID: c.Name}
+		for _, a := range c.ConnectorAvailability {
+			cluster.Connectors = append(cluster.Connectors, ConnectorSupport{
+				Name:           a.Name,
+				Source:         a.Source,
+				ConnectorClass: a.ConnectorClass,
+				Plugin:         a.Plugin,
+				Availability:   string(a.Availability),
+				Note:           a.Note,
+			})
+			switch a.Availability {
+			case connector_mapping.AvailabilitySupported:
+				section.Supported++
+			case connector_mapping.AvailabilityPartial:
+				section.PartiallySupported++
+			default:
+				section.SelfManagedOnly++
+			}
+		}
+		section.Clusters = append(section.Clusters, cluster)
+	}
+	if len(section.Clusters) == 0 {
+		return nil
+	}
+	sort.Slice(section.Clusters, func(i, j int) bool { return section.Clusters[i].ClusterID < section.Clusters[j].ClusterID })
+	return section
+}
+
+func connectorSourceLabel(source string) string {
+	switch source {
+	case report.ConnectorSourceMSKConnect:
+		return "MSK Connect"
+	case report.ConnectorSourceSelfManaged:
+		return "Self-managed"
+	default:
+		return source
+	}
+}
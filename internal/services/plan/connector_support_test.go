@@ -0,0 +1,46 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/connector_mapping"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectConnectorSupport_NoConnectorsReturnsNil(t *testing.T) {
+	assert.Nil(t, detectConnectorSupport(wrapClusters(report.ProcessedCluster{Name: "orders"})))
+}
+
+func TestDetectConnectorSupport(t *testing.T) {
+	state := wrapClusters(
+		report.ProcessedCluster{Name: "payments", ConnectorAvailability: []report.ConnectorAvailability{
+			{Name: "mm2", Source: report.ConnectorSourceSelfManaged, ConnectorClass: "org.apache.kafka.connect.mirror.MirrorSourceConnector", Availability: connector_mapping.AvailabilitySelfManaged, Note: "replace MirrorMaker 2 with Cluster Linking rather than a connector"},
+		}},
+		report.ProcessedCluster{Name: "orders", ConnectorAvailability: []report.ConnectorAvailability{
+			{Name: "archive", Source: report.ConnectorSourceMSKConnect, ConnectorClass: "io.confluent.connect.s3.S3SinkConnector", Plugin: "S3_SINK", Availability: connector_mapping.AvailabilitySupported},
+			{Name: "warehouse", Source: report.ConnectorSourceMSKConnect, ConnectorClass: "com.wepay.kafka.connect.bigquery.BigQuerySinkConnector", Plugin: "BigQueryStorageSink", Availability: connector_mapping.AvailabilityPartial, Note: "the legacy BigQuery sink is deprecated"},
+		}},
+		report.ProcessedCluster{Name: "quiet"},
+	)
+
+	section := detectConnectorSupport(state)
+
+	require.NotNil(t, section)
+	assert.Equal(t, 1, section.Supported)
+	assert.Equal(t, 1, section.PartiallySupported)
+	assert.Equal(t, 1, section.SelfManagedOnly)
+	require.Len(t, section.Clusters, 2, "clusters without connectors are left out")
+	assert.Equal(t, "orders", section.Clusters[0].ClusterID, "sorted by cluster")
+	assert.Equal(t, "partially-supported", section.Clusters[0].Connectors[1].Availability)
+
+	var b bytes.Buffer
+	writeConnectorSupport(&b, section, 9)
+	out := b.String()
+	assert.Contains(t, out, "## 9. Connector Support")
+	assert.Contains(t, out, "| Partially supported | 1 |")
+	assert.Contains(t, out, "| `archive` | MSK Connect | `io.confluent.connect.s3.S3SinkConnector` | `S3_SINK` | supported | — |")
+	assert.Contains(t, out, "| `mm2` | Self-managed | `org.apache.kafka.connect.mirror.MirrorSourceConnector` | — | self-managed-only | replace MirrorMaker 2 with Cluster Linking rather than a connector |")
+}
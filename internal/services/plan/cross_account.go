@@ -0,0 +1,223 @@
+package plan
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// accountIDPattern matches a bare AWS account ID principal.
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// detectCrossAccountAccess parses each cluster's MSK cluster policy
+// (GetClusterPolicy) and lists the Allow grants to principals outside
+// the cluster's own account: other accounts and their roles or users,
+// AWS service principals and anonymous ("*") access. Each of those
+// integrations needs an equivalent binding on Confluent Cloud, or an
+// explicit decision to sunset it. Same-account principals are left to
+// the Auth section. Returns nil when no cluster policy grants
+// external access, so the renderer omits the section.
+func detectCrossAccountAccess(state report.ProcessedState) *CrossAccountAccessSection {
+	var clusters []ClusterCrossAccountAccess
+	for _, c := range collectClusters(state) {
+		policy := aws.ToString(c.AWSClientInformation.Policy.Policy)
+		if policy == "" {
+			continue
+		}
+		access := ClusterCrossAccountAccess{ClusterID: c.Name, Account: arnAccount(c.Arn)}
+		grants, err := externalPolicyGrants(policy, access.Account)
+		if err != nil {
+			access.ParseError = err.Error()
+		}
+		access.Grants = grants
+		if len(access.Grants) == 0 && access.ParseError == "" {
+			continue
+		}
+		clusters = append(clusters, access)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterID < clusters[j].ClusterID })
+	return &CrossAccountAccessSection{Clusters: clusters}
+}
+
+// externalPolicyGrants returns one grant per external principal of
+// each Allow statement in policy, in statement order.
+func externalPolicyGrants(policy, clusterAccount string) ([]CrossAccountGrant, error) {
+	var document map[string]any
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return nil, err
+	}
+
+	var statements []map[string]any
+	switch s := document["Statement"].(type) {
+	case map[string]any:
+		statements = []map[string]any{s}
+	case []any:
+		for _, item := range s {
+			if m, ok := item.(map[string]any); ok {
+				statements = append(statements, m)
+			}
+		}
+	}
+
+	var grants []CrossAccountGrant
+	for _, statement := range statements {
+		if effect, _ := statement["Effect"].(string); effect != "Allow" {
+			continue
+		}
+		sid, _ := statement["Sid"].(string)
+		actions := policyStringOrList(statement["Action"])
+		var conditions []string
+		if condition, ok := statement["Condition"].(map[string]any); ok {
+			for operator, keys := range condition {
+				if keys, ok := keys.(map[string]any); ok {
+					for key := range keys {
+						conditions = append(conditions, operator+" "+key)
+					}
+				}
+			}
+			sort.Strings(conditions)
+		}
+
+		for _, principal := range statementPrincipals(statement["Principal"]) {
+			grant := CrossAccountGrant{
+				StatementID: sid,
+				Actions:     actions,
+				Conditions:  conditions,
+			}
+			switch {
+			case principal.service:
+				grant.Principal = principal.value
+				grant.Kind = CrossAccountService
+			case principal.value == "*":
+				grant.Principal = "*"
+				grant.Account = "*"
+				grant.Kind = CrossAccountAnyone
+			default:
+				grant.Principal = principal.value
+				grant.Account, grant.Kind = awsPrincipalAccount(principal.value)
+				if grant.Account == clusterAccount {
+					continue
+				}
+			}
+			grant.Equivalent = crossAccountEquivalent(grant)
+			grants = append(grants, grant)
+		}
+	}
+	return grants, nil
+}
+
+type policyPrincipal struct {
+	value   string
+	service bool
+}
+
+// statementPrincipals flattens a statement's Principal: "*", or a map
+// of principal type to one value or a list. Federated and canonical
+// user principals don't apply to MSK and are ignored.
+func statementPrincipals(principal any) []policyPrincipal {
+	switch p := principal.(type) {
+	case string:
+		return []policyPrincipal{{value: p}}
+	case map[string]any:
+		var principals []policyPrincipal
+		for _, value := range policyStringOrList(p["AWS"]) {
+			principals = append(principals, policyPrincipal{value: value})
+		}
+		for _, value := range policyStringOrList(p["Service"]) {
+			principals = append(principals, policyPrincipal{value: value, service: true})
+		}
+		return principals
+	}
+	return nil
+}
+
+func policyStringOrList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// awsPrincipalAccount returns the account of an AWS principal — a bare
+// account ID or an IAM/STS ARN — and what kind of principal it is.
+func awsPrincipalAccount(principal string) (string, CrossAccountPrincipalKind) {
+	if principal == "*" {
+		return "*", CrossAccountAnyone
+	}
+	if accountIDPattern.MatchString(principal) {
+		return principal, CrossAccountAccount
+	}
+	account := arnAccount(principal)
+	resource := principal[strings.LastIndex(principal, ":")+1:]
+	switch {
+	case resource == "root":
+		return account, CrossAccountAccount
+	case strings.HasPrefix(resource, "role/"), strings.HasPrefix(resource, "assumed-role/"):
+		return account, CrossAccountRole
+	case strings.HasPrefix(resource, "user/"):
+		return account, CrossAccountUser
+	default:
+		return account, CrossAccountAccount
+	}
+}
+
+// arnAccount returns the account field of an ARN, or "" when arn
+// isn't one.
+func arnAccount(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[4]
+}
+
+// crossAccountEquivalent is what replaces grant on Confluent Cloud,
+// picked from the actions it allows: multi-VPC connectivity becomes
+// a private networking access grant, data-plane access a service
+// account with role bindings.
+func crossAccountEquivalent(grant CrossAccountGrant) string {
+	var vpcConnection, dataPlane bool
+	for _, action := range grant.Actions {
+		action = strings.ToLower(action)
+		switch {
+		case action == "*", action == "kafka:*":
+			vpcConnection = true
+			dataPlane = dataPlane || action == "*"
+		case action == "kafka:createvpcconnection":
+			vpcConnection = true
+		case strings.HasPrefix(action, "kafka-cluster:"):
+			dataPlane = true
+		}
+	}
+
+	switch {
+	case grant.Kind == CrossAccountAnyone:
+		return "Anonymous access has no Confluent Cloud equivalent — identify the clients relying on it and give each a service account, or sunset the grant."
+	case grant.Kind == CrossAccountService:
+		return "AWS service integration — replace it with the matching Confluent connector or integration, or sunset it."
+	case vpcConnection && dataPlane:
+		return "Add account `" + grant.Account + "` to the private networking access (PrivateLink access or peering) and create a service account with API keys and role bindings matching its actions."
+	case vpcConnection:
+		return "Add account `" + grant.Account + "` to the private networking access (PrivateLink access or peering) so its client VPCs keep a private path."
+	case dataPlane:
+		return "Create a service account with API keys and role bindings (or ACLs) matching the granted `kafka-cluster` actions."
+	default:
+		return "Control-plane access only — review whether the integration still needs an equivalent on Confluent Cloud."
+	}
+}
@@ -0,0 +1,90 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clusterWithPolicy(name, policy string) report.ProcessedCluster {
+	c := report.ProcessedCluster{Name: name, Arn: "arn:aws:kafka:us-east-1:111111111111:cluster/" + name + "/abc"}
+	c.AWSClientInformation.Policy.Policy = aws.String(policy)
+	return c
+}
+
+func TestDetectCrossAccountAccess_NoExternalGrantsReturnsNil(t *testing.T) {
+	state := wrapClusters(
+		report.ProcessedCluster{Name: "no-policy"},
+		clusterWithPolicy("same-account", `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111111111111:role/app"}, "Action": "kafka-cluster:*"}}`),
+	)
+	assert.Nil(t, detectCrossAccountAccess(state))
+}
+
+func TestDetectCrossAccountAccess(t *testing.T) {
+	state := wrapClusters(clusterWithPolicy("orders", `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "PartnerVpc",
+				"Effect": "Allow",
+				"Principal": {"AWS": ["222222222222", "arn:aws:iam::111111111111:root"]},
+				"Action": ["kafka:CreateVpcConnection", "kafka:GetBootstrapBrokers", "kafka:DescribeClusterV2"]
+			},
+			{
+				"Effect": "Allow",
+				"Principal": {"AWS": "arn:aws:iam::333333333333:role/analytics"},
+				"Action": ["kafka-cluster:Connect", "kafka-cluster:ReadData"],
+				"Condition": {"StringEquals": {"aws:PrincipalOrgID": "o-abc"}}
+			},
+			{"Effect": "Allow", "Principal": {"Service": "firehose.amazonaws.com"}, "Action": "kafka:CreateVpcConnection"},
+			{"Effect": "Deny", "Principal": "*", "Action": "kafka-cluster:DeleteTopic"}
+		]
+	}`), clusterWithPolicy("broken", `{not json`))
+
+	section := detectCrossAccountAccess(state)
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 2)
+	assert.Equal(t, "broken", section.Clusters[0].ClusterID)
+	assert.NotEmpty(t, section.Clusters[0].ParseError)
+
+	orders := section.Clusters[1]
+	assert.Equal(t, "111111111111", orders.Account)
+	require.Len(t, orders.Grants, 3, "the same-account root and the Deny statement are left out")
+	assert.Equal(t, CrossAccountGrant{
+		Principal:   "222222222222",
+		Account:     "222222222222",
+		Kind:        CrossAccountAccount,
+		StatementID: "PartnerVpc",
+		Actions:     []string{"kafka:CreateVpcConnection", "kafka:GetBootstrapBrokers", "kafka:DescribeClusterV2"},
+		Equivalent:  "Add account `222222222222` to the private networking access (PrivateLink access or peering) so its client VPCs keep a private path.",
+	}, orders.Grants[0])
+	assert.Equal(t, CrossAccountRole, orders.Grants[1].Kind)
+	assert.Equal(t, "333333333333", orders.Grants[1].Account)
+	assert.Equal(t, []string{"StringEquals aws:PrincipalOrgID"}, orders.Grants[1].Conditions)
+	assert.Contains(t, orders.Grants[1].Equivalent, "service account")
+	assert.Equal(t, CrossAccountService, orders.Grants[2].Kind)
+	assert.Empty(t, orders.Grants[2].Account)
+
+	var b bytes.Buffer
+	writeCrossAccountAccess(&b, section, 9)
+	out := b.String()
+	assert.Contains(t, out, "## 9. Cross-Account Access")
+	assert.Contains(t, out, "_The cluster policy could not be parsed")
+	assert.Contains(t, out, "| `arn:aws:iam::333333333333:role/analytics` (role) | 333333333333 | `kafka-cluster:Connect`, `kafka-cluster:ReadData` | `StringEquals aws:PrincipalOrgID` |")
+	assert.Contains(t, out, "| `firehose.amazonaws.com` (service) | — |")
+}
+
+func TestDetectCrossAccountAccess_AnonymousPrincipal(t *testing.T) {
+	section := detectCrossAccountAccess(wrapClusters(clusterWithPolicy("orders", `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "kafka-cluster:*"}]}`)))
+
+	require.NotNil(t, section)
+	grant := section.Clusters[0].Grants[0]
+	assert.Equal(t, CrossAccountAnyone, grant.Kind)
+	assert.Equal(t, "*", grant.Account)
+	assert.Contains(t, grant.Equivalent, "Anonymous access has no Confluent Cloud equivalent")
+}
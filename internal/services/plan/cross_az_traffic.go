@@ -0,0 +1,158 @@
+package plan
+
+import (
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// secondsPerMonth is a 730-hour month, as AWS bills data transfer.
+const secondsPerMonth = 730 * 3600
+
+// detectCrossAZTraffic combines, per cluster, the broker zones from
+// discovery (ListNodes) with the broker.rack and partition leadership
+// `kcp scan clusters` recorded, the replica.selector.class detected in
+// the MSK configuration and the consumers' client.rack, to report
+// whether consumers fetch from a follower in their own zone and what
+// the consumer traffic crossing zones costs at
+// cfg.CrossAZTraffic.TransferUSDPerGB. Returns nil when no cluster's
+// zones are known so the renderer omits the section.
+func detectCrossAZTraffic(state report.ProcessedState, cfg *PlanConfig) *CrossAZTrafficSection {
+	var clusters []ClusterCrossAZTraffic
+	for _, c := range collectClusters(state) {
+		zones := crossAZZones(c)
+		if len(zones) == 0 {
+			continue
+		}
+		cluster := ClusterCrossAZTraffic{
+			ClusterID:        c.Name,
+			FollowerFetching: c.ClusterMetrics.Metadata.FollowerFetching,
+		}
+
+		zoneByRack := make(map[string]int, len(zones))
+		for i, zone := range zones {
+			if zone.Rack != "" {
+				zoneByRack[zone.Rack] = i
+			}
+		}
+		unmatched := make(map[string]bool)
+		for _, group := range c.KafkaAdminClientInformation.ConsumerGroups {
+			if group.ProtocolType != "consumer" {
+				continue
+			}
+			cluster.ConsumerMembers += group.Members
+			for rack, members := range group.ClientRacks {
+				i, ok := zoneByRack[rack]
+				if !ok {
+					unmatched[rack] = true
+					continue
+				}
+				zones[i].ConsumerMembers += members
+				cluster.RackAwareMembers += members
+			}
+		}
+		for rack := range unmatched {
+			cluster.UnmatchedRacks = append(cluster.UnmatchedRacks, rack)
+		}
+		sort.Strings(cluster.UnmatchedRacks)
+		cluster.Zones = zones
+
+		// Without the rack-aware selector the brokers ignore client.rack,
+		// so every consumer fetches from the leader.
+		fromFollower := 0
+		switch {
+		case !cluster.FollowerFetching:
+			cluster.FetchFromFollower = FetchFromFollowerLeaderOnly
+		case cluster.ConsumerMembers == 0:
+			cluster.FetchFromFollower = FetchFromFollowerUnknown
+		case cluster.RackAwareMembers == 0:
+			cluster.FetchFromFollower = FetchFromFollowerLeaderOnly
+		case cluster.RackAwareMembers >= cluster.ConsumerMembers:
+			cluster.FetchFromFollower = FetchFromFollowerRackAware
+			fromFollower = cluster.ConsumerMembers
+		default:
+			cluster.FetchFromFollower = FetchFromFollowerPartial
+			fromFollower = cluster.RackAwareMembers
+		}
+
+		// A consumer fetching from leaders spread evenly over n zones
+		// reads (n-1)/n of its bytes from other zones; one fetching from
+		// a follower in its own zone reads none.
+		n := float64(len(zones))
+		cluster.CrossAZFraction = (n - 1) / n
+		if cluster.ConsumerMembers > 0 {
+			cluster.CrossAZFraction *= 1 - float64(fromFollower)/float64(cluster.ConsumerMembers)
+		}
+
+		consume, ok := pickPercentile(c.ClusterMetrics.Aggregates, "BytesOutPerSec", "avg")
+		cluster.ConsumeMetricsMissing = !ok
+		cluster.ConsumeMBps = consume / bytesPerMBps
+		cluster.MonthlyCrossAZGB = consume * cluster.CrossAZFraction * secondsPerMonth / bytesPerGB
+		cluster.MonthlyCrossAZUSD = cluster.MonthlyCrossAZGB * cfg.CrossAZTraffic.TransferUSDPerGB
+
+		clusters = append(clusters, cluster)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterID < clusters[j].ClusterID })
+	return &CrossAZTrafficSection{
+		TransferUSDPerGB: cfg.CrossAZTraffic.TransferUSDPerGB,
+		Source:           cfg.CrossAZTraffic.Source,
+		Clusters:         clusters,
+	}
+}
+
+// crossAZZones groups a cluster's brokers by zone. Brokers are matched
+// to the ListNodes zone names by broker ID; the scanned broker.rack is
+// preferred as the zone key because it is what consumers must set as
+// client.rack. Without a scan the zones come from ListNodes alone.
+func crossAZZones(c report.ProcessedCluster) []CrossAZZone {
+	azByBroker := make(map[int32]string)
+	for _, subnet := range c.AWSClientInformation.ClusterNetworking.Subnets {
+		if subnet.AvailabilityZone != "" {
+			azByBroker[int32(subnet.SubnetMskBrokerId)] = subnet.AvailabilityZone
+		}
+	}
+
+	var zones []CrossAZZone
+	index := make(map[string]int)
+	add := func(rack, az string, leaders int) {
+		key := rack
+		if key == "" {
+			key = az
+		}
+		if key == "" {
+			return
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(zones)
+			index[key] = i
+			zones = append(zones, CrossAZZone{Rack: rack})
+		}
+		if zones[i].AvailabilityZone == "" {
+			zones[i].AvailabilityZone = az
+		}
+		zones[i].Brokers++
+		zones[i].LeaderPartitions += leaders
+	}
+
+	if racks := c.KafkaAdminClientInformation.BrokerRacks; len(racks) > 0 {
+		for _, broker := range racks {
+			add(broker.Rack, azByBroker[broker.BrokerID], broker.LeaderPartitions)
+		}
+	} else {
+		for _, subnet := range c.AWSClientInformation.ClusterNetworking.Subnets {
+			add("", subnet.AvailabilityZone, 0)
+		}
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].Rack != zones[j].Rack {
+			return zones[i].Rack < zones[j].Rack
+		}
+		return zones[i].AvailabilityZone < zones[j].AvailabilityZone
+	})
+	return zones
+}
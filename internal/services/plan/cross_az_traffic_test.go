@@ -0,0 +1,122 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zonedCluster is a three-broker cluster with one broker per zone, as
+// ListNodes and `kcp scan clusters` record it.
+func zonedCluster(name string, followerFetching bool, consumeBytesPerSec float64, groups ...types.ConsumerGroup) report.ProcessedCluster {
+	c := redFlagCluster(name, "3.6.0", "", "")
+	c.AWSClientInformation.ClusterNetworking.Subnets = []types.SubnetInfo{
+		{SubnetMskBrokerId: 1, AvailabilityZone: "us-east-1a"},
+		{SubnetMskBrokerId: 2, AvailabilityZone: "us-east-1b"},
+		{SubnetMskBrokerId: 3, AvailabilityZone: "us-east-1c"},
+	}
+	c.KafkaAdminClientInformation.BrokerRacks = []types.BrokerRack{
+		{BrokerID: 1, Rack: "use1-az1", LeaderPartitions: 10},
+		{BrokerID: 2, Rack: "use1-az2", LeaderPartitions: 12},
+		{BrokerID: 3, Rack: "use1-az4", LeaderPartitions: 8},
+	}
+	c.KafkaAdminClientInformation.ConsumerGroups = groups
+	c.ClusterMetrics.Metadata.FollowerFetching = followerFetching
+	if consumeBytesPerSec > 0 {
+		c.ClusterMetrics.Aggregates = map[string]types.MetricAggregate{"BytesOutPerSec": {Average: &consumeBytesPerSec}}
+	}
+	return c
+}
+
+func TestDetectCrossAZTraffic_ClassifiesFetching(t *testing.T) {
+	leaderOnly := zonedCluster("orders", false, 10*bytesPerMBps,
+		types.ConsumerGroup{GroupID: "billing", ProtocolType: "consumer", Members: 3, ClientRacks: map[string]int{"use1-az1": 3}})
+	partial := zonedCluster("payments", true, 10*bytesPerMBps,
+		types.ConsumerGroup{GroupID: "ledger", ProtocolType: "consumer", Members: 4, ClientRacks: map[string]int{"use1-az1": 1, "use1-az2": 1, "us-east-1c": 1}},
+		types.ConsumerGroup{GroupID: "connect-cluster", ProtocolType: "connect", Members: 2})
+	rackAware := zonedCluster("audit", true, 0,
+		types.ConsumerGroup{GroupID: "archiver", ProtocolType: "consumer", Members: 2, ClientRacks: map[string]int{"use1-az1": 1, "use1-az4": 1}})
+	unscanned := redFlagCluster("idle", "3.6.0", "", "")
+	cfg := defaultCfg(t)
+	cfg.CrossAZTraffic.TransferUSDPerGB = 0.02
+
+	section := detectCrossAZTraffic(wrapClusters(leaderOnly, partial, rackAware, unscanned), cfg)
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 3, "a cluster without zones is left out")
+	assert.Equal(t, 0.02, section.TransferUSDPerGB)
+
+	audit := section.Clusters[0]
+	assert.Equal(t, FetchFromFollowerRackAware, audit.FetchFromFollower)
+	assert.Zero(t, audit.CrossAZFraction)
+	assert.True(t, audit.ConsumeMetricsMissing)
+	assert.Equal(t, []CrossAZZone{
+		{Rack: "use1-az1", AvailabilityZone: "us-east-1a", Brokers: 1, LeaderPartitions: 10, ConsumerMembers: 1},
+		{Rack: "use1-az2", AvailabilityZone: "us-east-1b", Brokers: 1, LeaderPartitions: 12},
+		{Rack: "use1-az4", AvailabilityZone: "us-east-1c", Brokers: 1, LeaderPartitions: 8, ConsumerMembers: 1},
+	}, audit.Zones)
+
+	orders := section.Clusters[1]
+	assert.Equal(t, FetchFromFollowerLeaderOnly, orders.FetchFromFollower, "client.rack is ignored without the rack-aware selector")
+	assert.InDelta(t, 2.0/3, orders.CrossAZFraction, 0.0001)
+	// 10 MB/s for a 730-hour month, two thirds of it across zones.
+	wantGB := 10.0 * 730 * 3600 / 1024 * 2 / 3
+	assert.InDelta(t, wantGB, orders.MonthlyCrossAZGB, 0.01)
+	assert.InDelta(t, wantGB*0.02, orders.MonthlyCrossAZUSD, 0.01)
+
+	payments := section.Clusters[2]
+	assert.Equal(t, FetchFromFollowerPartial, payments.FetchFromFollower)
+	assert.Equal(t, 4, payments.ConsumerMembers, "connect groups are not consumers")
+	assert.Equal(t, 2, payments.RackAwareMembers)
+	assert.Equal(t, []string{"us-east-1c"}, payments.UnmatchedRacks)
+	assert.InDelta(t, 2.0/3*0.5, payments.CrossAZFraction, 0.0001)
+}
+
+func TestDetectCrossAZTraffic_ZonesFromListNodesAlone(t *testing.T) {
+	c := zonedCluster("orders", true, 0)
+	c.KafkaAdminClientInformation.BrokerRacks = nil
+	c.AWSClientInformation.ClusterNetworking.Subnets = append(c.AWSClientInformation.ClusterNetworking.Subnets,
+		types.SubnetInfo{SubnetMskBrokerId: 4, AvailabilityZone: "us-east-1a"})
+
+	section := detectCrossAZTraffic(wrapClusters(c), defaultCfg(t))
+
+	require.NotNil(t, section)
+	orders := section.Clusters[0]
+	assert.Equal(t, FetchFromFollowerUnknown, orders.FetchFromFollower)
+	assert.Equal(t, []CrossAZZone{
+		{AvailabilityZone: "us-east-1a", Brokers: 2},
+		{AvailabilityZone: "us-east-1b", Brokers: 1},
+		{AvailabilityZone: "us-east-1c", Brokers: 1},
+	}, orders.Zones)
+	assert.InDelta(t, 2.0/3, orders.CrossAZFraction, 0.0001, "unknown fetching is estimated as leader-only")
+}
+
+func TestDetectCrossAZTraffic_NilWithoutZones(t *testing.T) {
+	assert.Nil(t, detectCrossAZTraffic(wrapClusters(redFlagCluster("idle", "3.6.0", "", "")), defaultCfg(t)))
+}
+
+func TestPlanConfig_ValidateRejectsNegativeTransferPrice(t *testing.T) {
+	cfg := defaultCfg(t)
+	cfg.CrossAZTraffic.TransferUSDPerGB = -0.01
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cross_az_traffic.transfer_usd_per_gb must be >= 0")
+}
+
+func TestRenderMarkdown_CrossAZTrafficSection(t *testing.T) {
+	c := zonedCluster("orders", true, 10*bytesPerMBps,
+		types.ConsumerGroup{GroupID: "billing", ProtocolType: "consumer", Members: 2, ClientRacks: map[string]int{"us-east-1a": 2}})
+	cfg := defaultCfg(t)
+	plan := buildPlanForRedFlags(t, wrapClusters(c), cfg, defaultInputs())
+	require.NotNil(t, plan.CrossAZTraffic)
+
+	out, err := RenderMarkdown(plan, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), ". Cross-AZ Consumer Traffic\n")
+	assert.Contains(t, string(out), "| `orders` | `use1-az1` us-east-1a: 1 broker(s), 10 leader(s)<br>`use1-az2` us-east-1b: 1 broker(s), 12 leader(s)<br>`use1-az4` us-east-1c: 1 broker(s), 8 leader(s) | from leaders | 0 / 2 | 67% | 10.0 MB/s | 17109 GB · $342.19 |")
+	assert.Contains(t, string(out), "**Consumers fetch across zones:** consumers of `orders`")
+	assert.Contains(t, string(out), "set `client.rack` to `us-east-1a`, which names no broker rack")
+}
@@ -0,0 +1,87 @@
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+const bytesPerMB = 1024 * 1024
+
+// detectDataVolume lists, per cluster, the user topics by on-disk
+// size, largest first — the order to create their mirror topics in —
+// with each topic's initial sync estimate at
+// cfg.DataVolume.LinkThroughputMBps. Only topics with scanned
+// partition sizes are considered, as in detectPartitionSkew. Returns
+// nil when no cluster has sizes so the renderer omits the section.
+func detectDataVolume(state report.ProcessedState, cfg *PlanConfig) *DataVolumeSection {
+	bytesPerSecond := cfg.DataVolume.LinkThroughputMBps * bytesPerMB
+
+	var clusters []ClusterDataVolume
+	for _, c := range collectClusters(state) {
+		if c.KafkaAdminClientInformation.Topics == nil {
+			continue
+		}
+		var (
+			sized   []TopicDataVolume
+			total   int64
+			empties int
+		)
+		for _, topic := range c.KafkaAdminClientInformation.Topics.Details {
+			// Internal topics are skipped: cluster linking doesn't mirror them.
+			if strings.HasPrefix(topic.Name, "__") || len(topic.PartitionSizes) == 0 {
+				continue
+			}
+			tv := TopicDataVolume{Topic: topic.Name, Partitions: len(topic.PartitionSizes)}
+			for _, size := range topic.PartitionSizes {
+				tv.TotalBytes += size
+				if size > tv.LargestPartitionBytes {
+					tv.LargestPartitionBytes = size
+				}
+			}
+			if tv.TotalBytes == 0 {
+				empties++
+				continue
+			}
+			tv.EstimatedSyncSeconds = float64(tv.TotalBytes) / bytesPerSecond
+			total += tv.TotalBytes
+			sized = append(sized, tv)
+		}
+		if len(sized) == 0 {
+			continue
+		}
+
+		sort.Slice(sized, func(i, j int) bool {
+			if sized[i].TotalBytes != sized[j].TotalBytes {
+				return sized[i].TotalBytes > sized[j].TotalBytes
+			}
+			return sized[i].Topic < sized[j].Topic
+		})
+		for i := range sized {
+			sized[i].MirrorOrder = i + 1
+			sized[i].ShareOfCluster = float64(sized[i].TotalBytes) / float64(total)
+		}
+
+		cluster := ClusterDataVolume{
+			ClusterID:            c.Name,
+			TotalBytes:           total,
+			EstimatedSyncSeconds: float64(total) / bytesPerSecond,
+			Topics:               sized,
+			EmptyTopics:          empties,
+		}
+		if len(sized) > cfg.DataVolume.MaxTopics {
+			cluster.Topics = sized[:cfg.DataVolume.MaxTopics]
+			for _, t := range sized[cfg.DataVolume.MaxTopics:] {
+				cluster.OmittedTopics++
+				cluster.OmittedBytes += t.TotalBytes
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterID < clusters[j].ClusterID })
+	return &DataVolumeSection{LinkThroughputMBps: cfg.DataVolume.LinkThroughputMBps, Clusters: clusters}
+}
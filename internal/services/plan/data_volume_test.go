@@ -0,0 +1,95 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDataVolume_OrdersTopicsLargestFirst(t *testing.T) {
+	state := wrapClusters(sizedCluster("orders", map[string][]int64{
+		"clickstream":        {120 * gb, 110 * gb, 115 * gb},
+		"payments":           {8 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb},
+		"audit":              {0, 0},
+		"__consumer_offsets": {500 * gb, 1 * gb},
+		"not-sized":          nil,
+	}))
+	cfg := defaultCfg(t)
+	cfg.DataVolume.LinkThroughputMBps = 100
+
+	section := detectDataVolume(state, cfg)
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 1)
+	cluster := section.Clusters[0]
+	assert.Equal(t, 358*gb, cluster.TotalBytes)
+	assert.Equal(t, 1, cluster.EmptyTopics)
+	require.Len(t, cluster.Topics, 2)
+
+	assert.Equal(t, TopicDataVolume{
+		MirrorOrder:           1,
+		Topic:                 "clickstream",
+		Partitions:            3,
+		TotalBytes:            345 * gb,
+		LargestPartitionBytes: 120 * gb,
+		ShareOfCluster:        345.0 / 358.0,
+		EstimatedSyncSeconds:  345 * 1024 / 100.0,
+	}, cluster.Topics[0])
+	assert.Equal(t, 2, cluster.Topics[1].MirrorOrder)
+	assert.Equal(t, "payments", cluster.Topics[1].Topic)
+	assert.InDelta(t, 358*1024/100.0, cluster.EstimatedSyncSeconds, 0.001)
+}
+
+func TestDetectDataVolume_CapsListedTopics(t *testing.T) {
+	state := wrapClusters(sizedCluster("orders", map[string][]int64{
+		"a": {3 * gb},
+		"b": {2 * gb},
+		"c": {1 * gb},
+	}))
+	cfg := defaultCfg(t)
+	cfg.DataVolume.MaxTopics = 1
+
+	cluster := detectDataVolume(state, cfg).Clusters[0]
+
+	require.Len(t, cluster.Topics, 1)
+	assert.Equal(t, "a", cluster.Topics[0].Topic)
+	assert.Equal(t, 2, cluster.OmittedTopics)
+	assert.Equal(t, 3*gb, cluster.OmittedBytes)
+	assert.Equal(t, 6*gb, cluster.TotalBytes)
+}
+
+func TestDetectDataVolume_NoSizesReturnsNil(t *testing.T) {
+	assert.Nil(t, detectDataVolume(wrapClusters(redFlagCluster("orders", "3.6.0", "", "")), defaultCfg(t)))
+}
+
+func TestPlanConfig_ValidateRejectsZeroLinkThroughput(t *testing.T) {
+	cfg := defaultCfg(t)
+	cfg.DataVolume.LinkThroughputMBps = 0
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "data_volume.link_throughput_mbps must be > 0")
+}
+
+func TestRenderMarkdown_DataVolumeSection(t *testing.T) {
+	state := wrapClusters(sizedCluster("orders", map[string][]int64{
+		"payments": {8 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb},
+	}))
+	cfg := defaultCfg(t)
+	plan := buildPlanForRedFlags(t, state, cfg, defaultInputs())
+	require.NotNil(t, plan.DataVolume)
+
+	out, err := RenderMarkdown(plan, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), ". Topic Data Volume\n")
+	assert.Contains(t, string(out), "**orders** — 13.0 GB in total, ≈ 2 min to sync")
+	assert.Contains(t, string(out), "| 1 | `payments` | 6 | 13.0 GB | 100.0% | 8.0 GB | 2 min |")
+	assert.Contains(t, string(out), "**Mirroring order:**")
+}
+
+func TestFormatSyncDuration(t *testing.T) {
+	assert.Equal(t, "< 1 min", formatSyncDuration(59))
+	assert.Equal(t, "45 min", formatSyncDuration(45*60))
+	assert.Equal(t, "2.5 h", formatSyncDuration(2.5*3600))
+	assert.Equal(t, "3.0 days", formatSyncDuration(72*3600))
+}
@@ -0,0 +1,77 @@
+package plan
+
+import (
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// detectKafkaCredentials lists the Secrets Manager secrets holding
+// Kafka credentials that `kcp discover --scan-secrets` found. Each is
+// a credential whose clients move to a Confluent Cloud API key, so
+// the secret has to be re-issued, and its rotation and consumers tell
+// who is affected. SCRAM cluster ARNs are resolved to cluster names
+// where the cluster is in the state. Returns nil when no region was
+// scanned or the scans found nothing, so the renderer omits the
+// section.
+func detectKafkaCredentials(state report.ProcessedState) *KafkaCredentialsSection {
+	names := map[string]string{}
+	for _, c := range collectClusters(state) {
+		names[c.Arn] = c.Name
+	}
+
+	var secrets []KafkaCredentialSecret
+	for _, src := range state.Sources {
+		if src.MSKData == nil {
+			continue
+		}
+		for _, region := range src.MSKData.Regions {
+			for _, s := range region.KafkaSecrets {
+				secret := KafkaCredentialSecret{
+					Region:            region.Name,
+					Name:              s.Name,
+					Arn:               s.Arn,
+					MatchedBy:         s.MatchedBy,
+					RotationEnabled:   s.RotationEnabled,
+					RotationAfterDays: s.RotationAfterDays,
+					RotationSchedule:  s.RotationSchedule,
+					Consumers:         s.PolicyPrincipals,
+					LastAccessedDate:  s.LastAccessedDate,
+				}
+				for _, arn := range s.ScramClusterArns {
+					name := names[arn]
+					if name == "" {
+						name = arn
+					}
+					secret.ScramClusters = append(secret.ScramClusters, name)
+				}
+				sort.Strings(secret.ScramClusters)
+				secrets = append(secrets, secret)
+			}
+		}
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Region != secrets[j].Region {
+			return secrets[i].Region < secrets[j].Region
+		}
+		return secrets[i].Name < secrets[j].Name
+	})
+	return &KafkaCredentialsSection{Secrets: secrets}
+}
+
+func kafkaSecretMatchLabel(m string) string {
+	switch m {
+	case types.KafkaSecretMatchScramName:
+		return "`AmazonMSK_` name"
+	case types.KafkaSecretMatchScramAssociation:
+		return "SCRAM association"
+	case types.KafkaSecretMatchTag:
+		return "tag"
+	default:
+		return m
+	}
+}
@@ -0,0 +1,57 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectKafkaCredentials_NotScannedReturnsNil(t *testing.T) {
+	assert.Nil(t, detectKafkaCredentials(wrapClusters(report.ProcessedCluster{Name: "orders"})))
+}
+
+func TestDetectKafkaCredentials(t *testing.T) {
+	lastAccessed := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	state := wrapClusters(report.ProcessedCluster{Name: "orders", Arn: "arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc"})
+	state.Sources[0].MSKData.Regions[0].KafkaSecrets = []types.KafkaSecret{
+		{
+			Name:      "connect/sink",
+			Arn:       "arn:aws:secretsmanager:us-east-1:123456789012:secret:connect/sink-xyz",
+			MatchedBy: []string{types.KafkaSecretMatchTag},
+		},
+		{
+			Name:              "AmazonMSK_orders",
+			Arn:               "arn:aws:secretsmanager:us-east-1:123456789012:secret:AmazonMSK_orders-abc",
+			MatchedBy:         []string{types.KafkaSecretMatchScramName, types.KafkaSecretMatchScramAssociation},
+			ScramClusterArns:  []string{"arn:aws:kafka:us-east-1:123456789012:cluster/orders/abc", "arn:aws:kafka:us-east-1:123456789012:cluster/gone/def"},
+			RotationEnabled:   true,
+			RotationAfterDays: 30,
+			PolicyPrincipals:  []string{"arn:aws:iam::123456789012:role/orders-app"},
+			LastAccessedDate:  &lastAccessed,
+		},
+	}
+
+	section := detectKafkaCredentials(state)
+
+	require.NotNil(t, section)
+	require.Len(t, section.Secrets, 2)
+	scram := section.Secrets[0]
+	assert.Equal(t, "AmazonMSK_orders", scram.Name, "sorted by name")
+	assert.Equal(t, "us-east-1", scram.Region)
+	assert.Equal(t, []string{"arn:aws:kafka:us-east-1:123456789012:cluster/gone/def", "orders"}, scram.ScramClusters, "clusters not in the state keep their ARN")
+	assert.Equal(t, []string{"arn:aws:iam::123456789012:role/orders-app"}, scram.Consumers)
+	assert.Equal(t, "connect/sink", section.Secrets[1].Name)
+
+	var b bytes.Buffer
+	writeKafkaCredentials(&b, section, 7)
+	out := b.String()
+	assert.Contains(t, out, "## 7. Kafka Credentials to Re-issue")
+	assert.Contains(t, out, "| `AmazonMSK_orders` | us-east-1 | `AmazonMSK_` name, SCRAM association | arn:aws:kafka:us-east-1:123456789012:cluster/gone/def, orders | every 30 days | `arn:aws:iam::123456789012:role/orders-app` | 2026-10-01 |")
+	assert.Contains(t, out, "| `connect/sink` | us-east-1 | tag | — | off | — | — |")
+	assert.Contains(t, out, "Rotation Lambdas written for MSK SCRAM")
+}
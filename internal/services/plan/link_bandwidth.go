@@ -0,0 +1,57 @@
+package plan
+
+import (
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/linkbandwidth"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// detectLinkBandwidth estimates, per cluster, the cluster link's
+// initial sync with live ingress taken into account and the bandwidth
+// it needs afterwards, from the scanned partition sizes and the
+// BytesInPerSec aggregates. The link throughput is
+// cfg.DataVolume.LinkThroughputMBps, as in detectDataVolume. Returns
+// nil when no cluster has either input so the renderer omits the
+// section.
+func detectLinkBandwidth(state report.ProcessedState, cfg *PlanConfig) *LinkBandwidthSection {
+	var clusters []ClusterLinkBandwidth
+	for _, c := range collectClusters(state) {
+		in, ok := linkbandwidth.NewInput(c.ClusterMetrics, c.KafkaAdminClientInformation, cfg.DataVolume.LinkThroughputMBps, cfg.LinkBandwidth.SteadyStateHeadroom)
+		if !ok {
+			continue
+		}
+		_, metered := pickPercentile(c.ClusterMetrics.Aggregates, "BytesInPerSec", "avg")
+		e := linkbandwidth.Calculate(in)
+
+		cluster := ClusterLinkBandwidth{
+			ClusterID:             c.Name,
+			TotalBytes:            in.TotalBytes,
+			SustainedProduceMBps:  e.SustainedProduceMBps,
+			PeakProduceMBps:       e.PeakProduceMBps,
+			ProduceMetricsMissing: !metered,
+			CatchUpMBps:           e.CatchUpMBps,
+			CanCatchUp:            e.CanCatchUp,
+			InitialSyncSeconds:    e.InitialSyncSeconds,
+			SteadyStateMBps:       e.SteadyStateMBps,
+			RequiredMBps:          e.RequiredMBps,
+		}
+		// A jump cluster runs one broker per source broker.
+		if brokers := c.ClusterMetrics.Metadata.NumberOfBrokerNodes; brokers > 0 {
+			instanceType, fits := linkbandwidth.JumpClusterInstanceType(brokers, e.RequiredMBps)
+			cluster.JumpClusterBrokers = brokers
+			cluster.JumpClusterInstanceType = instanceType.Name
+			cluster.JumpClusterInstanceTooSmall = !fits
+		}
+		clusters = append(clusters, cluster)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterID < clusters[j].ClusterID })
+	return &LinkBandwidthSection{
+		LinkThroughputMBps:  cfg.DataVolume.LinkThroughputMBps,
+		SteadyStateHeadroom: cfg.LinkBandwidth.SteadyStateHeadroom,
+		Clusters:            clusters,
+	}
+}
@@ -0,0 +1,80 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLinkBandwidth_CombinesVolumeAndProduceRate(t *testing.T) {
+	busy := sizedCluster("orders", map[string][]int64{
+		"clickstream":        {120 * gb, 120 * gb, 120 * gb},
+		"__consumer_offsets": {500 * gb},
+	})
+	avg, peak := 20*bytesPerMBps, 50*bytesPerMBps
+	busy.ClusterMetrics = types.ProcessedClusterMetrics{
+		Metadata:   types.MetricMetadata{NumberOfBrokerNodes: 3},
+		Aggregates: map[string]types.MetricAggregate{"BytesInPerSec": {Average: &avg, Maximum: &peak}},
+	}
+	unmetered := sizedCluster("audit", map[string][]int64{"events": {10 * gb}})
+	unscanned := redFlagCluster("idle", "3.6.0", "", "")
+	cfg := defaultCfg(t)
+	cfg.DataVolume.LinkThroughputMBps = 100
+	cfg.LinkBandwidth.SteadyStateHeadroom = 0.2
+
+	section := detectLinkBandwidth(wrapClusters(busy, unmetered, unscanned), cfg)
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 2, "a cluster with neither sizes nor metrics is left out")
+	assert.Equal(t, 0.2, section.SteadyStateHeadroom)
+
+	audit := section.Clusters[0]
+	assert.Equal(t, "audit", audit.ClusterID)
+	assert.True(t, audit.ProduceMetricsMissing)
+	assert.InDelta(t, 10*1024/100.0, audit.InitialSyncSeconds, 0.001)
+	assert.Empty(t, audit.JumpClusterInstanceType, "broker count unknown")
+
+	orders := section.Clusters[1]
+	assert.Equal(t, 360*gb, orders.TotalBytes)
+	assert.False(t, orders.ProduceMetricsMissing)
+	assert.True(t, orders.CanCatchUp)
+	assert.InDelta(t, 80, orders.CatchUpMBps, 0.001)
+	// 360 GiB copied at the 80 MB/s left after mirroring live ingress.
+	assert.InDelta(t, 360*1024/80.0, orders.InitialSyncSeconds, 0.001)
+	assert.InDelta(t, 60, orders.SteadyStateMBps, 0.001)
+	assert.InDelta(t, 100, orders.RequiredMBps, 0.001)
+	assert.Equal(t, 3, orders.JumpClusterBrokers)
+	assert.Equal(t, "m5.large", orders.JumpClusterInstanceType)
+}
+
+func TestDetectLinkBandwidth_NilWithoutInputs(t *testing.T) {
+	assert.Nil(t, detectLinkBandwidth(wrapClusters(redFlagCluster("idle", "3.6.0", "", "")), defaultCfg(t)))
+}
+
+func TestPlanConfig_ValidateRejectsNegativeHeadroom(t *testing.T) {
+	cfg := defaultCfg(t)
+	cfg.LinkBandwidth.SteadyStateHeadroom = -0.1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "link_bandwidth.steady_state_headroom must be >= 0")
+}
+
+func TestRenderMarkdown_LinkBandwidthSection(t *testing.T) {
+	stalled := sizedCluster("orders", map[string][]int64{"clickstream": {10 * gb}})
+	avg, peak := 150*bytesPerMBps, 200*bytesPerMBps
+	stalled.ClusterMetrics = types.ProcessedClusterMetrics{
+		Metadata:   types.MetricMetadata{NumberOfBrokerNodes: 3},
+		Aggregates: map[string]types.MetricAggregate{"BytesInPerSec": {Average: &avg, Maximum: &peak}},
+	}
+	cfg := defaultCfg(t)
+	plan := buildPlanForRedFlags(t, wrapClusters(stalled), cfg, defaultInputs())
+	require.NotNil(t, plan.LinkBandwidth)
+
+	out, err := RenderMarkdown(plan, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), ". Cluster Link Bandwidth\n")
+	assert.Contains(t, string(out), "| `orders` | 10.0 GB | 150.0 / 200.0 MB/s | **never completes** | 260.0 MB/s | 3 × `m5.large` |")
+	assert.Contains(t, string(out), "**Link too slow:** producers on `orders`")
+}
@@ -0,0 +1,185 @@
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// transactionStateTopic is the transaction coordinator's log. It is created
+// the first time a producer calls initTransactions, so its presence means the
+// cluster has served transactional producers.
+const transactionStateTopic = "__transaction_state"
+
+// detectMigrationSemantics flags, per cluster, the workloads whose
+// semantics need special handling when a cluster link replaces them:
+// compacted user topics, transactional (exactly-once) producers and
+// consumer groups with statically assigned members. Transactions are only
+// visible indirectly — the coordinator's log topic and the ACLs or IAM
+// permissions on transactional IDs — since the scan has no view of open
+// transactions. Returns nil when no cluster has any so the renderer omits
+// the section.
+func detectMigrationSemantics(state report.ProcessedState) *MigrationSemanticsSection {
+	var clusters []ClusterMigrationSemantics
+	for _, c := range collectClusters(state) {
+		cs := ClusterMigrationSemantics{
+			ClusterID:              c.Name,
+			CompactedTopics:        compactedTopics(c.KafkaAdminClientInformation.Topics),
+			Transactions:           transactionalUsage(c),
+			StaticMembershipGroups: staticMembershipGroups(c.KafkaAdminClientInformation.ConsumerGroups),
+		}
+		if len(cs.CompactedTopics) == 0 && cs.Transactions == nil && len(cs.StaticMembershipGroups) == 0 {
+			continue
+		}
+		clusters = append(clusters, cs)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterID < clusters[j].ClusterID })
+	return &MigrationSemanticsSection{Clusters: clusters}
+}
+
+// compactedTopics returns the user topics whose cleanup.policy includes
+// compact, sorted by name. Internal topics are skipped: cluster linking
+// doesn't mirror them.
+func compactedTopics(topics *types.Topics) []CompactedTopic {
+	if topics == nil {
+		return nil
+	}
+	var out []CompactedTopic
+	for _, topic := range topics.Details {
+		if strings.HasPrefix(topic.Name, "__") {
+			continue
+		}
+		policy := topic.Configurations["cleanup.policy"]
+		if policy == nil || !strings.Contains(*policy, "compact") {
+			continue
+		}
+		out = append(out, CompactedTopic{Topic: topic.Name, CleanupPolicy: *policy, Partitions: topic.Partitions})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Topic < out[j].Topic })
+	return out
+}
+
+// transactionalUsage collects the evidence of transactional producers on
+// a cluster. Nil when there is none.
+func transactionalUsage(c report.ProcessedCluster) *TransactionalUsage {
+	usage := &TransactionalUsage{}
+	if c.KafkaAdminClientInformation.Topics != nil {
+		for _, topic := range c.KafkaAdminClientInformation.Topics.Details {
+			if topic.Name == transactionStateTopic {
+				usage.TransactionStateTopic = true
+				usage.TransactionStatePartitions = topic.Partitions
+			}
+		}
+	}
+
+	for _, acl := range c.KafkaAdminClientInformation.Acls {
+		if acl.ResourceType != "TransactionalID" || acl.PermissionType != "Allow" {
+			continue
+		}
+		usage.Grants = append(usage.Grants, TransactionalIDGrant{
+			Principal:       acl.Principal,
+			TransactionalID: acl.ResourceName,
+			PatternType:     acl.ResourcePatternType,
+			Source:          TransactionalGrantACL,
+		})
+	}
+
+	for _, principal := range c.AWSClientInformation.IAMAccess {
+		for _, permission := range principal.Permissions {
+			if !grantsTransactions(permission.Actions) {
+				continue
+			}
+			for _, resource := range permission.Resources {
+				id, ok := iamTransactionalID(resource)
+				if !ok {
+					continue
+				}
+				usage.Grants = append(usage.Grants, TransactionalIDGrant{
+					Principal:       principal.PrincipalArn,
+					TransactionalID: id,
+					Source:          TransactionalGrantIAM,
+				})
+			}
+		}
+	}
+
+	if !usage.TransactionStateTopic && len(usage.Grants) == 0 {
+		return nil
+	}
+	usage.Grants = dedupeGrants(usage.Grants)
+	return usage
+}
+
+// grantsTransactions reports whether an IAM statement lets a producer use
+// transactional IDs.
+func grantsTransactions(actions []string) bool {
+	for _, action := range actions {
+		switch action {
+		case "kafka-cluster:*", "kafka-cluster:AlterTransactionalId", "kafka-cluster:DescribeTransactionalId":
+			return true
+		}
+	}
+	return false
+}
+
+// iamTransactionalID extracts the transactional ID (or wildcard) from an
+// MSK transactional-id resource ARN,
+// arn:aws:kafka:<region>:<account>:transactional-id/<cluster>/<uuid>/<id>.
+// A bare "*" resource covers every transactional ID.
+func iamTransactionalID(resource string) (string, bool) {
+	if resource == "*" {
+		return "*", true
+	}
+	_, rest, ok := strings.Cut(resource, ":transactional-id/")
+	if !ok {
+		return "", false
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 3 {
+		return "*", true
+	}
+	return parts[2], true
+}
+
+func dedupeGrants(grants []TransactionalIDGrant) []TransactionalIDGrant {
+	seen := make(map[TransactionalIDGrant]bool, len(grants))
+	out := make([]TransactionalIDGrant, 0, len(grants))
+	for _, g := range grants {
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Principal != out[j].Principal {
+			return out[i].Principal < out[j].Principal
+		}
+		return out[i].TransactionalID < out[j].TransactionalID
+	})
+	return out
+}
+
+// staticMembershipGroups returns the consumer groups with at least one
+// static member, sorted by group ID.
+func staticMembershipGroups(groups []types.ConsumerGroup) []StaticMembershipGroup {
+	var out []StaticMembershipGroup
+	for _, g := range groups {
+		if len(g.StaticInstanceIDs) == 0 {
+			continue
+		}
+		out = append(out, StaticMembershipGroup{
+			GroupID:           g.GroupID,
+			State:             g.State,
+			Members:           g.Members,
+			StaticInstanceIDs: g.StaticInstanceIDs,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GroupID < out[j].GroupID })
+	return out
+}
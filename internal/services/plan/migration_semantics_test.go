@@ -0,0 +1,116 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clusterWithTopics(name string, topics ...types.TopicDetails) report.ProcessedCluster {
+	c := report.ProcessedCluster{Name: name}
+	c.KafkaAdminClientInformation.Topics = &types.Topics{Details: topics}
+	return c
+}
+
+func topicWithPolicy(name, policy string, partitions int) types.TopicDetails {
+	return types.TopicDetails{Name: name, Partitions: partitions, Configurations: map[string]*string{"cleanup.policy": &policy}}
+}
+
+func TestDetectMigrationSemantics_NothingToReportReturnsNil(t *testing.T) {
+	state := wrapClusters(clusterWithTopics("orders",
+		topicWithPolicy("orders.events", "delete", 6),
+		topicWithPolicy("__consumer_offsets", "compact", 50),
+	))
+	assert.Nil(t, detectMigrationSemantics(state))
+}
+
+func TestDetectMigrationSemantics_CompactedTopics(t *testing.T) {
+	state := wrapClusters(clusterWithTopics("orders",
+		topicWithPolicy("orders.state", "compact,delete", 12),
+		topicWithPolicy("orders.events", "delete", 6),
+		topicWithPolicy("customers", "compact", 3),
+		types.TopicDetails{Name: "no-config", Partitions: 1},
+	))
+
+	section := detectMigrationSemantics(state)
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 1)
+	assert.Equal(t, []CompactedTopic{
+		{Topic: "customers", CleanupPolicy: "compact", Partitions: 3},
+		{Topic: "orders.state", CleanupPolicy: "compact,delete", Partitions: 12},
+	}, section.Clusters[0].CompactedTopics)
+	assert.Nil(t, section.Clusters[0].Transactions)
+}
+
+func TestDetectMigrationSemantics_TransactionsFromTopicACLsAndIAM(t *testing.T) {
+	c := clusterWithTopics("payments", types.TopicDetails{Name: transactionStateTopic, Partitions: 50})
+	c.KafkaAdminClientInformation.Acls = []types.Acls{
+		{ResourceType: "TransactionalID", ResourceName: "payments-", ResourcePatternType: "Prefixed", Principal: "User:payments", Operation: "Write", PermissionType: "Allow"},
+		{ResourceType: "TransactionalID", ResourceName: "payments-", ResourcePatternType: "Prefixed", Principal: "User:payments", Operation: "Describe", PermissionType: "Allow"},
+		{ResourceType: "TransactionalID", ResourceName: "*", ResourcePatternType: "Literal", Principal: "User:mallory", Operation: "Write", PermissionType: "Deny"},
+		{ResourceType: "Topic", ResourceName: "payments", ResourcePatternType: "Literal", Principal: "User:payments", Operation: "Write", PermissionType: "Allow"},
+	}
+	c.AWSClientInformation.IAMAccess = []types.IAMPrincipalAccess{
+		{PrincipalArn: "arn:aws:iam::111111111111:role/ledger", Permissions: []types.IAMKafkaPermission{{
+			Actions:   []string{"kafka-cluster:Connect", "kafka-cluster:AlterTransactionalId"},
+			Resources: []string{"arn:aws:kafka:us-east-1:111111111111:transactional-id/payments/abc-1/ledger-tx"},
+		}}},
+		{PrincipalArn: "arn:aws:iam::111111111111:role/reader", Permissions: []types.IAMKafkaPermission{{
+			Actions:   []string{"kafka-cluster:ReadData"},
+			Resources: []string{"*"},
+		}}},
+	}
+
+	section := detectMigrationSemantics(wrapClusters(c))
+
+	require.NotNil(t, section)
+	tx := section.Clusters[0].Transactions
+	require.NotNil(t, tx)
+	assert.True(t, tx.TransactionStateTopic)
+	assert.Equal(t, 50, tx.TransactionStatePartitions)
+	assert.Empty(t, section.Clusters[0].CompactedTopics, "__transaction_state is internal")
+	assert.Equal(t, []TransactionalIDGrant{
+		{Principal: "User:payments", TransactionalID: "payments-", PatternType: "Prefixed", Source: TransactionalGrantACL},
+		{Principal: "arn:aws:iam::111111111111:role/ledger", TransactionalID: "ledger-tx", Source: TransactionalGrantIAM},
+	}, tx.Grants, "one grant per principal and ID; denies and non-transactional actions are skipped")
+}
+
+func TestDetectMigrationSemantics_StaticMembershipGroups(t *testing.T) {
+	c := report.ProcessedCluster{Name: "orders"}
+	c.KafkaAdminClientInformation.ConsumerGroups = []types.ConsumerGroup{
+		{GroupID: "shipping", State: "Stable", Members: 2, StaticInstanceIDs: []string{"shipping-0", "shipping-1"}},
+		{GroupID: "billing", State: "Stable", Members: 4},
+		{GroupID: "audit", State: "Empty", Members: 1, StaticInstanceIDs: []string{"audit-0"}},
+	}
+
+	section := detectMigrationSemantics(wrapClusters(c))
+
+	require.NotNil(t, section)
+	groups := section.Clusters[0].StaticMembershipGroups
+	require.Len(t, groups, 2)
+	assert.Equal(t, "audit", groups[0].GroupID)
+	assert.Equal(t, []string{"shipping-0", "shipping-1"}, groups[1].StaticInstanceIDs)
+}
+
+func TestWriteMigrationSemantics_RendersOnlyPresentGuidance(t *testing.T) {
+	c := clusterWithTopics("orders", topicWithPolicy("orders.state", "compact", 12))
+	c.KafkaAdminClientInformation.ConsumerGroups = []types.ConsumerGroup{
+		{GroupID: "shipping", State: "Stable", Members: 2, StaticInstanceIDs: []string{"shipping-0", "shipping-1"}},
+	}
+
+	var b bytes.Buffer
+	writeMigrationSemantics(&b, detectMigrationSemantics(wrapClusters(c)), 11)
+	out := b.String()
+
+	assert.Contains(t, out, "## 11. Compaction, Transactions and Static Membership")
+	assert.Contains(t, out, "| `orders.state` | `compact` | 12 |")
+	assert.Contains(t, out, "| `shipping` | Stable | 2 | `shipping-0`, `shipping-1` |")
+	assert.Contains(t, out, "- **Compacted topics**")
+	assert.Contains(t, out, "- **Static membership**")
+	assert.NotContains(t, out, "- **Transactional producers**")
+}
@@ -97,6 +97,22 @@ var oqRegistry = map[string]oqMeta{
 		PromotedSeverity: "🔴",
 		PromotedTitle:    "Gateway intent — moot until the auth conflict above is resolved",
 	},
+	"migration_waves_input_invalid": {
+		Priority: 450,
+		Severity: "🟡",
+	},
+	"migration_waves_team_unknown_cluster": {
+		Priority: 460,
+		Severity: "🟡",
+	},
+	"migration_waves_team_conflict": {
+		Priority: 470,
+		Severity: "🟡",
+	},
+	"migration_waves_unscheduled": {
+		Priority: 480,
+		Severity: "🟡",
+	},
 
 	// Auth
 	"target_auth_method_unknown": {
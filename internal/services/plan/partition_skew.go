@@ -0,0 +1,77 @@
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+// detectPartitionSkew flags, per cluster, the user topics with an
+// oversized partition or a partition far larger than the topic's mean
+// (cfg.PartitionSkew). Only topics with scanned partition sizes are
+// considered — clusters scanned before sizes were recorded, and
+// Serverless clusters (no log dirs), contribute nothing. Returns nil
+// when no topic is flagged so the renderer omits the section.
+func detectPartitionSkew(state report.ProcessedState, cfg *PlanConfig) *PartitionSkewSection {
+	var clusters []ClusterPartitionSkew
+	for _, c := range collectClusters(state) {
+		if c.KafkaAdminClientInformation.Topics == nil {
+			continue
+		}
+		var flagged []TopicPartitionSkew
+		for _, topic := range c.KafkaAdminClientInformation.Topics.Details {
+			if skew, ok := evalPartitionSkew(topic, cfg.PartitionSkew); ok {
+				flagged = append(flagged, skew)
+			}
+		}
+		if len(flagged) == 0 {
+			continue
+		}
+		sort.Slice(flagged, func(i, j int) bool {
+			if flagged[i].LargestPartitionBytes != flagged[j].LargestPartitionBytes {
+				return flagged[i].LargestPartitionBytes > flagged[j].LargestPartitionBytes
+			}
+			return flagged[i].Topic < flagged[j].Topic
+		})
+		clusters = append(clusters, ClusterPartitionSkew{ClusterID: c.Name, Topics: flagged})
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ClusterID < clusters[j].ClusterID })
+	return &PartitionSkewSection{Clusters: clusters}
+}
+
+// evalPartitionSkew measures one topic against the cutoffs. Internal
+// topics are skipped: cluster linking doesn't mirror them.
+func evalPartitionSkew(topic types.TopicDetails, cfg PartitionSkewCfg) (TopicPartitionSkew, bool) {
+	if strings.HasPrefix(topic.Name, "__") || len(topic.PartitionSizes) == 0 {
+		return TopicPartitionSkew{}, false
+	}
+
+	skew := TopicPartitionSkew{Topic: topic.Name, Partitions: len(topic.PartitionSizes)}
+	for id, size := range topic.PartitionSizes {
+		skew.TotalBytes += size
+		if size > skew.LargestPartitionBytes {
+			skew.LargestPartition = id
+			skew.LargestPartitionBytes = size
+		}
+	}
+	if skew.TotalBytes == 0 {
+		return TopicPartitionSkew{}, false
+	}
+	mean := float64(skew.TotalBytes) / float64(skew.Partitions)
+	skew.SkewRatio = float64(skew.LargestPartitionBytes) / mean
+
+	if float64(skew.LargestPartitionBytes) >= cfg.OversizedPartitionGB*bytesPerGB {
+		skew.Kinds = append(skew.Kinds, PartitionSkewOversized)
+	}
+	if skew.Partitions > 1 && skew.SkewRatio >= cfg.SkewRatio && float64(skew.TotalBytes) >= cfg.MinTopicGB*bytesPerGB {
+		skew.Kinds = append(skew.Kinds, PartitionSkewSkewed)
+	}
+	return skew, len(skew.Kinds) > 0
+}
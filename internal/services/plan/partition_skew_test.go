@@ -0,0 +1,83 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gb = int64(bytesPerGB)
+
+// sizedCluster replaces the cluster's topics with ones carrying
+// scanned partition sizes, the way `kcp scan clusters` records them.
+func sizedCluster(name string, topics map[string][]int64) report.ProcessedCluster {
+	c := redFlagCluster(name, "3.6.0", "", "")
+	details := []types.TopicDetails{}
+	for topic, sizes := range topics {
+		details = append(details, types.TopicDetails{Name: topic, Partitions: len(sizes), PartitionSizes: sizes})
+	}
+	c.KafkaAdminClientInformation.Topics = &types.Topics{Details: details}
+	return c
+}
+
+func TestDetectPartitionSkew_FlagsOversizedAndSkewedTopics(t *testing.T) {
+	state := wrapClusters(sizedCluster("orders", map[string][]int64{
+		"clickstream":        {120 * gb, 110 * gb, 115 * gb},
+		"payments":           {8 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb},
+		"balanced":           {10 * gb, 10 * gb, 10 * gb},
+		"tiny-but-skewed":    {100 << 20, 1 << 20, 1 << 20, 1 << 20},
+		"__consumer_offsets": {500 * gb, 1 * gb},
+		"not-sized":          nil,
+	}))
+
+	section := detectPartitionSkew(state, defaultCfg(t))
+
+	require.NotNil(t, section)
+	require.Len(t, section.Clusters, 1)
+	assert.Equal(t, "orders", section.Clusters[0].ClusterID)
+	topics := section.Clusters[0].Topics
+	require.Len(t, topics, 2)
+
+	assert.Equal(t, "clickstream", topics[0].Topic)
+	assert.Equal(t, 0, topics[0].LargestPartition)
+	assert.Equal(t, 345*gb, topics[0].TotalBytes)
+	assert.Equal(t, []PartitionSkewKind{PartitionSkewOversized}, topics[0].Kinds)
+
+	assert.Equal(t, "payments", topics[1].Topic)
+	assert.Equal(t, 6, topics[1].Partitions)
+	assert.Equal(t, 8*gb, topics[1].LargestPartitionBytes)
+	assert.InDelta(t, 3.69, topics[1].SkewRatio, 0.01)
+	assert.Equal(t, []PartitionSkewKind{PartitionSkewSkewed}, topics[1].Kinds)
+}
+
+// Clusters scanned before partition sizes were recorded have nothing
+// to measure.
+func TestDetectPartitionSkew_NoSizesReturnsNil(t *testing.T) {
+	assert.Nil(t, detectPartitionSkew(wrapClusters(redFlagCluster("orders", "3.6.0", "", "")), defaultCfg(t)))
+}
+
+func TestPlanConfig_ValidateRejectsBadSkewRatio(t *testing.T) {
+	cfg := defaultCfg(t)
+	cfg.PartitionSkew.SkewRatio = 1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partition_skew.skew_ratio must be > 1")
+}
+
+func TestRenderMarkdown_PartitionSkewSection(t *testing.T) {
+	state := wrapClusters(sizedCluster("orders", map[string][]int64{
+		"payments": {8 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb, 1 * gb},
+	}))
+	cfg := defaultCfg(t)
+	plan := buildPlanForRedFlags(t, state, cfg, defaultInputs())
+	require.NotNil(t, plan.PartitionSkew)
+
+	out, err := RenderMarkdown(plan, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), ". Partition Skew\n")
+	assert.Contains(t, string(out), "| `payments` | 6 | 13.0 GB | 0 (8.0 GB) | 3.7x | 🔥 Skewed |")
+	assert.Contains(t, string(out), "**Before cutover:**")
+}
@@ -147,6 +147,12 @@ type PlanInputs struct {
 	//   defer_to_account_team   (default for the not_required cascade)
 	HistoricalDataStrategy *string `yaml:"historical_data_strategy,omitempty" json:"historical_data_strategy,omitempty"`
 
+	// ----- migration waves -----
+
+	// MigrationWaves constrains how §Migration Waves batches the fleet.
+	// Nil = plan-config defaults, no team groupings, no windows.
+	MigrationWaves *MigrationWavesInputs `yaml:"migration_waves,omitempty" json:"migration_waves,omitempty"`
+
 	// Clusters — per-cluster overrides keyed by source cluster name.
 	// Heterogeneous fleets (mixed-SLA, mixed-tier) need finer-grained
 	// inputs than the global flags above; without this, flipping a
@@ -158,6 +164,26 @@ type PlanInputs struct {
 	Clusters map[string]ClusterPlanInputs `yaml:"clusters,omitempty" json:"clusters,omitempty"`
 }
 
+// MigrationWavesInputs are the customer's constraints on the phased
+// migration order. All fields are optional.
+type MigrationWavesInputs struct {
+	// CutoverWindows are free-form labels (e.g. "2026-11-07 02:00-06:00
+	// UTC"), assigned to waves in order. Waves beyond the last window
+	// are left unscheduled and raise an OQ.
+	CutoverWindows []string `yaml:"cutover_windows,omitempty" json:"cutover_windows,omitempty"`
+	// LinkBandwidthMBps is the bandwidth, in MB/s, the clusters of one
+	// wave share for their initial sync. Defaults to
+	// data_volume.link_throughput_mbps in plan-config.yaml.
+	LinkBandwidthMBps *float64 `yaml:"link_bandwidth_mbps,omitempty" json:"link_bandwidth_mbps,omitempty"`
+	// MaxClustersPerWave overrides migration_waves.max_clusters_per_wave
+	// in plan-config.yaml.
+	MaxClustersPerWave *int `yaml:"max_clusters_per_wave,omitempty" json:"max_clusters_per_wave,omitempty"`
+	// Teams maps a team name to the source clusters it owns. A team's
+	// clusters always migrate in the same wave so its applications cut
+	// over once.
+	Teams map[string][]string `yaml:"teams,omitempty" json:"teams,omitempty"`
+}
+
 // ClusterPlanInputs is the per-cluster override slice of PlanInputs.
 // All fields are optional pointers so the resolver can detect "not set
 // for this cluster" and fall back to the global default.
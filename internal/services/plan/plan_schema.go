@@ -5,8 +5,10 @@ import "time"
 // Plan is the deterministic Migration Plan emitted by `kcp report plan`.
 // Scope: source-environment summary, sizing, cluster-type, networking,
 // cutover, auth (per-cluster), schema migration, red flags, effort
-// signals, tiered storage, and cost-vs-inventory reconciliation. Each
-// section is optional in the JSON and the renderer skips empty ones.
+// signals, tiered storage, cost-vs-inventory reconciliation,
+// configuration drift, partition skew, topic data volume, MSK Replicator mapping,
+// client access paths, cross-account access, migration semantics, Kafka credentials and connector support. Each section is optional in the JSON and the
+// renderer skips empty ones.
 //
 // Empty-section conventions across the struct:
 //
@@ -24,17 +26,24 @@ import "time"
 //     something to say (no overrides → no key; no OQs → no key).
 //
 //   - Fleet-wide pointer sections — `Cutover`, `Schema`, `RedFlags`,
-//     `EffortSignals`, `TieredStorage`, `CostReconciliation`. Tagged
-//     `omitempty`. Nil means "section omitted entirely" (no source
-//     data, or the path is intentionally skipped, e.g. schemaless).
+//     `EffortSignals`, `TieredStorage`, `CostReconciliation`,
+//     `ConfigDrift`, `PartitionSkew`, `DataVolume`, `LinkBandwidth`,
+//     `CrossAZTraffic`, `MigrationWaves`, `Replication`, `ClientAccess`, `CrossAccountAccess`,
+//     `MigrationSemantics`, `KafkaCredentials`, `ConnectorSupport`.
+//     Tagged `omitempty`. Nil means "section omitted entirely" (no
+//     source data, or the path is intentionally skipped, e.g.
+//     schemaless).
 //
 // In all three cases the renderer hides the corresponding §section
 // when the JSON value is empty / nil.
 type Plan struct {
-	Header              PlanHeader            `json:"header"`
-	Inputs              PlanInputsResolved    `json:"inputs"`
-	SourceEnvironment   SourceEnvironment     `json:"source_environment"`
-	Sizing              []ClusterSizing       `json:"sizing"`
+	Header            PlanHeader         `json:"header"`
+	Inputs            PlanInputsResolved `json:"inputs"`
+	SourceEnvironment SourceEnvironment  `json:"source_environment"`
+	Sizing            []ClusterSizing    `json:"sizing"`
+	// SizingByEnvironment totals Sizing per business environment. Nil
+	// unless at least one cluster is classified.
+	SizingByEnvironment []EnvironmentSizing   `json:"sizing_by_environment,omitempty"`
 	ClusterTypeDecision []ClusterTypeDecision `json:"cluster_type_decision"`
 	NetworkingDecision  []NetworkingDecision  `json:"networking_decision"`
 	// Cutover is the fleet-wide cutover decision — the default style
@@ -76,8 +85,66 @@ type Plan struct {
 	// TotalSpend desc. Nil when cost data is empty or the diff is
 	// clean.
 	CostReconciliation *CostReconciliationSection `json:"cost_reconciliation,omitempty"`
-	SizingAppendix     []SizingMathDetail         `json:"sizing_appendix"`
-	OpenQuestions      []OpenQuestion             `json:"open_questions,omitempty"`
+	// ConfigDrift lists per-cluster MSK configuration properties that
+	// deviate from the plan-config best-practice baseline or from a
+	// value Confluent Cloud pins. Nil when no cluster deviates.
+	ConfigDrift *ConfigDriftSection `json:"config_drift,omitempty"`
+	// PartitionSkew lists per-cluster topics whose partitions are
+	// oversized or unevenly filled, judged on the on-disk partition
+	// sizes from the admin scan. Nil when no topic crosses the
+	// plan-config cutoffs or no partition sizes were scanned.
+	PartitionSkew *PartitionSkewSection `json:"partition_skew,omitempty"`
+	// DataVolume lists per-cluster user topics by on-disk size, largest
+	// first, with the share of the cluster and an initial sync estimate
+	// at the plan-config link throughput. Nil when no partition sizes
+	// were scanned.
+	DataVolume *DataVolumeSection `json:"data_volume,omitempty"`
+	// LinkBandwidth estimates per cluster how long the initial sync
+	// takes while producers keep writing, the link bandwidth needed
+	// afterwards and the jump cluster instance type that carries it.
+	// Nil when no cluster has partition sizes or produce metrics.
+	LinkBandwidth *LinkBandwidthSection `json:"link_bandwidth,omitempty"`
+	// CrossAZTraffic reports per cluster which availability zones the
+	// brokers and partition leaders sit in, whether consumers fetch
+	// from a follower in their own zone and what the consumer traffic
+	// crossing zones costs. Nil when no cluster's zones are known.
+	CrossAZTraffic *CrossAZTrafficSection `json:"cross_az_traffic,omitempty"`
+	// MigrationWaves groups the fleet's clusters into ordered waves,
+	// smallest first, keeping each plan-inputs team in one wave and
+	// pairing waves with the customer's cutover windows. Nil for a
+	// single-cluster fleet without `migration_waves` inputs.
+	MigrationWaves *MigrationWavesSection `json:"migration_waves,omitempty"`
+	// Replication maps each MSK Replicator flow to the cluster link
+	// (or Confluent Replicator) that replaces it, with warnings for
+	// replication loops and conflicting topic renames. Nil when no
+	// replicators were discovered.
+	Replication *ReplicationSection `json:"replication,omitempty"`
+	// ClientAccess lists the client access paths beyond each cluster's
+	// own VPC — multi-VPC private connectivity, client VPC connections
+	// and public access — that must be recreated on Confluent Cloud.
+	// Nil when no cluster has any.
+	ClientAccess *ClientAccessSection `json:"client_access,omitempty"`
+	// CrossAccountAccess lists the cluster policy grants to other AWS
+	// accounts, AWS services and anonymous principals, each needing an
+	// equivalent on Confluent Cloud or an explicit sunset. Nil when no
+	// cluster policy grants external access.
+	CrossAccountAccess *CrossAccountAccessSection `json:"cross_account_access,omitempty"`
+	// MigrationSemantics lists per-cluster compacted topics,
+	// transactional producers and static-membership consumer groups —
+	// workloads whose guarantees need special handling around a
+	// cluster link cutover. Nil when no cluster has any.
+	MigrationSemantics *MigrationSemanticsSection `json:"migration_semantics,omitempty"`
+	// KafkaCredentials lists the Secrets Manager secrets holding Kafka
+	// credentials that must be re-issued as Confluent Cloud API keys.
+	// Nil when discover ran without `--scan-secrets` or found none.
+	KafkaCredentials *KafkaCredentialsSection `json:"kafka_credentials,omitempty"`
+	// ConnectorSupport lists the MSK Connect and self-managed
+	// connectors per cluster, each marked supported, partially
+	// supported or self-managed-only on Confluent Cloud, with
+	// fleet-wide counts. Nil when no cluster has connectors.
+	ConnectorSupport *ConnectorSupportSection `json:"connector_support,omitempty"`
+	SizingAppendix   []SizingMathDetail       `json:"sizing_appendix"`
+	OpenQuestions    []OpenQuestion           `json:"open_questions,omitempty"`
 }
 
 // OpenQuestion is a per-cluster (or plan-level) gap the customer needs
@@ -182,6 +249,22 @@ type ClusterSizing struct {
 	// this list to mark sizing as provisional rather than blanket-
 	// deferring the cluster.
 	InputsMissing []string `json:"inputs_missing,omitempty"`
+
+	// Environment is the cluster's business environment (dev, stage,
+	// prod); empty when unclassified.
+	Environment string `json:"environment,omitempty"`
+}
+
+// EnvironmentSizing totals the sizing recommendations of one business
+// environment. Degraded counts the clusters sized at the SLA floor
+// because their metrics were missing.
+type EnvironmentSizing struct {
+	Environment  string  `json:"environment"`
+	Clusters     int     `json:"clusters"`
+	SizedInMBps  float64 `json:"sized_in_mbps"`
+	SizedOutMBps float64 `json:"sized_out_mbps"`
+	FinalECKU    int     `json:"final_ecku"`
+	Degraded     int     `json:"degraded,omitempty"`
 }
 
 // ClusterType represents the Confluent Cloud cluster verdict.
@@ -536,6 +619,26 @@ type AuthDecision struct {
 	// surfaced in the renderer footnote so the reader recognises the
 	// typo at a glance.
 	RejectedOverrideValue string `json:"rejected_override_value,omitempty"`
+	// IAMPrincipals lists the IAM principals whose policies grant
+	// kafka-cluster actions on this cluster, as captured by `kcp
+	// discover` for SASL/IAM clusters. These clusters rarely carry
+	// Kafka ACLs, so this is the access model the migrated ACLs or
+	// role bindings have to reproduce. Empty when the cluster has no
+	// IAM auth or the state predates the analysis.
+	IAMPrincipals []IAMPrincipalGrant `json:"iam_principals,omitempty"`
+}
+
+// IAMPrincipalGrant is one IAM principal's kafka-cluster permissions
+// on a cluster, merged across all of its granting policies.
+type IAMPrincipalGrant struct {
+	PrincipalArn  string   `json:"principal_arn"`
+	PrincipalType string   `json:"principal_type"`
+	Actions       []string `json:"actions"`
+	Resources     []string `json:"resources"`
+	Policies      []string `json:"policies"`
+	// CanConnect is the IAM policy simulator's kafka-cluster:Connect
+	// verdict; nil when discover could not run the simulation.
+	CanConnect *bool `json:"can_connect,omitempty"`
 }
 
 // ----- schema -----
@@ -767,3 +870,546 @@ type HiddenClusterCandidate struct {
 type CostReconciliationSection struct {
 	Candidates []HiddenClusterCandidate `json:"candidates"`
 }
+
+// ----- configuration drift -----
+
+// ConfigDriftKind classifies a configuration drift finding.
+type ConfigDriftKind string
+
+const (
+	// ConfigDriftBestPractice — the setting is risky on the source
+	// itself (durability / availability), independent of the migration.
+	ConfigDriftBestPractice ConfigDriftKind = "best_practice"
+	// ConfigDriftCCImmutable — Confluent Cloud pins the value, so
+	// client or topic behavior that relies on the source setting
+	// changes after cutover.
+	ConfigDriftCCImmutable ConfigDriftKind = "cc_immutable"
+)
+
+// ConfigDriftFinding is one server property that fails its baseline
+// rule. Baseline is the rendered expectation (e.g. `= false`, `>= 2`).
+type ConfigDriftFinding struct {
+	Property string          `json:"property"`
+	Value    string          `json:"value"`
+	Baseline string          `json:"baseline"`
+	Kind     ConfigDriftKind `json:"kind"`
+	Note     string          `json:"note,omitempty"`
+}
+
+// ClusterConfigDrift carries the findings for one cluster, with the
+// configuration revision they were read from.
+type ClusterConfigDrift struct {
+	ClusterID             string               `json:"cluster_id"`
+	ConfigurationArn      string               `json:"configuration_arn"`
+	ConfigurationRevision int64                `json:"configuration_revision"`
+	Findings              []ConfigDriftFinding `json:"findings"`
+}
+
+// ConfigDriftSection lists clusters with at least one finding, sorted
+// by cluster ID. Nil when every cluster meets the baseline.
+type ConfigDriftSection struct {
+	Clusters []ClusterConfigDrift `json:"clusters"`
+}
+
+// ----- partition skew -----
+
+// PartitionSkewKind classifies why a topic is reported in §Partition
+// Skew. A topic can carry both.
+type PartitionSkewKind string
+
+const (
+	// PartitionSkewOversized — the largest partition is at or above
+	// partition_skew.oversized_partition_gb, so it alone bounds how
+	// long the cluster link's initial sync takes.
+	PartitionSkewOversized PartitionSkewKind = "oversized"
+	// PartitionSkewSkewed — the largest partition is at least
+	// partition_skew.skew_ratio times the mean, usually a hot key.
+	PartitionSkewSkewed PartitionSkewKind = "skewed"
+)
+
+// TopicPartitionSkew is one flagged topic. SkewRatio is the largest
+// partition's size over the mean partition size.
+type TopicPartitionSkew struct {
+	Topic                 string              `json:"topic"`
+	Partitions            int                 `json:"partitions"`
+	TotalBytes            int64               `json:"total_bytes"`
+	LargestPartition      int                 `json:"largest_partition"`
+	LargestPartitionBytes int64               `json:"largest_partition_bytes"`
+	SkewRatio             float64             `json:"skew_ratio"`
+	Kinds                 []PartitionSkewKind `json:"kinds"`
+}
+
+// ClusterPartitionSkew carries the flagged topics of one cluster,
+// largest partition first.
+type ClusterPartitionSkew struct {
+	ClusterID string               `json:"cluster_id"`
+	Topics    []TopicPartitionSkew `json:"topics"`
+}
+
+// PartitionSkewSection lists clusters with at least one flagged topic,
+// sorted by cluster ID. Nil when nothing is flagged.
+type PartitionSkewSection struct {
+	Clusters []ClusterPartitionSkew `json:"clusters"`
+}
+
+// ----- data volume -----
+
+// TopicDataVolume is one topic's on-disk size. MirrorOrder is its
+// 1-based position in the suggested mirroring order (largest first);
+// EstimatedSyncSeconds is TotalBytes at the plan-config link
+// throughput.
+type TopicDataVolume struct {
+	MirrorOrder           int     `json:"mirror_order"`
+	Topic                 string  `json:"topic"`
+	Partitions            int     `json:"partitions"`
+	TotalBytes            int64   `json:"total_bytes"`
+	LargestPartitionBytes int64   `json:"largest_partition_bytes"`
+	ShareOfCluster        float64 `json:"share_of_cluster"`
+	EstimatedSyncSeconds  float64 `json:"estimated_sync_seconds"`
+}
+
+// ClusterDataVolume carries one cluster's sized user topics, largest
+// first and capped at data_volume.max_topics; OmittedTopics and
+// OmittedBytes cover the rest. EmptyTopics counts topics holding no
+// data. TotalBytes and EstimatedSyncSeconds cover every user topic.
+type ClusterDataVolume struct {
+	ClusterID            string            `json:"cluster_id"`
+	TotalBytes           int64             `json:"total_bytes"`
+	EstimatedSyncSeconds float64           `json:"estimated_sync_seconds"`
+	Topics               []TopicDataVolume `json:"topics"`
+	OmittedTopics        int               `json:"omitted_topics,omitempty"`
+	OmittedBytes         int64             `json:"omitted_bytes,omitempty"`
+	EmptyTopics          int               `json:"empty_topics,omitempty"`
+}
+
+// DataVolumeSection lists clusters with scanned partition sizes,
+// sorted by cluster ID. LinkThroughputMBps is the assumed sustained
+// cluster link throughput the estimates use. Nil when no cluster has
+// sizes.
+type DataVolumeSection struct {
+	LinkThroughputMBps float64             `json:"link_throughput_mbps"`
+	Clusters           []ClusterDataVolume `json:"clusters"`
+}
+
+// ----- link bandwidth -----
+
+// ClusterLinkBandwidth is one cluster's mirroring estimate. The
+// initial sync copies TotalBytes at CatchUpMBps, the link throughput
+// less the sustained produce rate mirrored meanwhile; CanCatchUp is
+// false (and InitialSyncSeconds zero) when producers write at least
+// as fast as the link copies. SteadyStateMBps is the peak produce
+// rate plus headroom and RequiredMBps the most the link carries in
+// either phase. ProduceMetricsMissing marks clusters without
+// BytesInPerSec metrics, whose estimates leave live ingress out. The
+// jump cluster fields size one broker per source broker; they are
+// empty when the broker count is unknown.
+type ClusterLinkBandwidth struct {
+	ClusterID                   string  `json:"cluster_id"`
+	TotalBytes                  int64   `json:"total_bytes"`
+	SustainedProduceMBps        float64 `json:"sustained_produce_mbps"`
+	PeakProduceMBps             float64 `json:"peak_produce_mbps"`
+	ProduceMetricsMissing       bool    `json:"produce_metrics_missing,omitempty"`
+	CatchUpMBps                 float64 `json:"catch_up_mbps"`
+	CanCatchUp                  bool    `json:"can_catch_up"`
+	InitialSyncSeconds          float64 `json:"initial_sync_seconds"`
+	SteadyStateMBps             float64 `json:"steady_state_mbps"`
+	RequiredMBps                float64 `json:"required_mbps"`
+	JumpClusterBrokers          int     `json:"jump_cluster_brokers,omitempty"`
+	JumpClusterInstanceType     string  `json:"jump_cluster_instance_type,omitempty"`
+	JumpClusterInstanceTooSmall bool    `json:"jump_cluster_instance_too_small,omitempty"`
+}
+
+// LinkBandwidthSection lists clusters with partition sizes or produce
+// metrics, sorted by cluster ID, with the link throughput and
+// steady-state headroom the estimates assume.
+type LinkBandwidthSection struct {
+	LinkThroughputMBps  float64                `json:"link_throughput_mbps"`
+	SteadyStateHeadroom float64                `json:"steady_state_headroom"`
+	Clusters            []ClusterLinkBandwidth `json:"clusters"`
+}
+
+// ----- cross-AZ traffic -----
+
+// FetchFromFollower classifies how a cluster's consumers fetch.
+type FetchFromFollower string
+
+const (
+	// FetchFromFollowerRackAware — the brokers run the rack-aware
+	// replica selector and every consumer member sets a client.rack
+	// naming a broker zone, so consumers read from their own zone.
+	FetchFromFollowerRackAware FetchFromFollower = "rack_aware"
+	// FetchFromFollowerPartial — the brokers run the rack-aware replica
+	// selector but only some consumer members set a matching
+	// client.rack.
+	FetchFromFollowerPartial FetchFromFollower = "partial"
+	// FetchFromFollowerLeaderOnly — consumers fetch from partition
+	// leaders: the brokers don't select followers, or no consumer
+	// member sets a matching client.rack.
+	FetchFromFollowerLeaderOnly FetchFromFollower = "leader_only"
+	// FetchFromFollowerUnknown — the brokers run the rack-aware replica
+	// selector but no consumer group was scanned to tell whether
+	// consumers use it.
+	FetchFromFollowerUnknown FetchFromFollower = "unknown"
+)
+
+// CrossAZZone is one availability zone of a cluster. Rack is the
+// broker.rack (the zone ID on MSK) and AvailabilityZone the zone name
+// from discovery; either is empty when its source wasn't scanned.
+// LeaderPartitions is zero when partition leadership wasn't scanned.
+// ConsumerMembers counts the members whose client.rack is this zone.
+type CrossAZZone struct {
+	Rack             string `json:"rack,omitempty"`
+	AvailabilityZone string `json:"availability_zone,omitempty"`
+	Brokers          int    `json:"brokers"`
+	LeaderPartitions int    `json:"leader_partitions"`
+	ConsumerMembers  int    `json:"consumer_members"`
+}
+
+// ClusterCrossAZTraffic is one cluster's cross-AZ consumer traffic.
+// ConsumerMembers counts the members of consumer-protocol groups,
+// RackAwareMembers those whose client.rack names a broker zone and
+// UnmatchedRacks the client.rack values that name none (often a zone
+// name where the zone ID is expected). CrossAZFraction is the share of
+// consumed bytes estimated to cross zones, assuming leaders and
+// consumers spread evenly over the zones; the monthly figures apply it
+// to the average BytesOutPerSec and are zero when
+// ConsumeMetricsMissing.
+type ClusterCrossAZTraffic struct {
+	ClusterID             string            `json:"cluster_id"`
+	Zones                 []CrossAZZone     `json:"zones"`
+	FollowerFetching      bool              `json:"follower_fetching"`
+	ConsumerMembers       int               `json:"consumer_members"`
+	RackAwareMembers      int               `json:"rack_aware_members"`
+	UnmatchedRacks        []string          `json:"unmatched_racks,omitempty"`
+	FetchFromFollower     FetchFromFollower `json:"fetch_from_follower"`
+	CrossAZFraction       float64           `json:"cross_az_fraction"`
+	ConsumeMBps           float64           `json:"consume_mbps"`
+	ConsumeMetricsMissing bool              `json:"consume_metrics_missing,omitempty"`
+	MonthlyCrossAZGB      float64           `json:"monthly_cross_az_gb"`
+	MonthlyCrossAZUSD     float64           `json:"monthly_cross_az_usd"`
+}
+
+// CrossAZTrafficSection lists clusters with known zones, sorted by
+// cluster ID, with the transfer price the monthly costs assume.
+type CrossAZTrafficSection struct {
+	TransferUSDPerGB float64                 `json:"transfer_usd_per_gb"`
+	Source           string                  `json:"source,omitempty"`
+	Clusters         []ClusterCrossAZTraffic `json:"clusters"`
+}
+
+// ----- migration waves -----
+
+// MigrationWaveCluster is one cluster scheduled in a wave. Topics
+// counts user topics; TotalBytes is their on-disk size, zero when no
+// partition sizes were scanned.
+type MigrationWaveCluster struct {
+	ClusterID   string `json:"cluster_id"`
+	Team        string `json:"team,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Topics      int    `json:"topics"`
+	TotalBytes  int64  `json:"total_bytes"`
+}
+
+// MigrationWave is one batch of clusters migrated together. Clusters
+// in a wave share the migration network path, so
+// EstimatedSyncSeconds is the wave's combined TotalBytes at the
+// section's LinkBandwidthMBps. CutoverWindow is empty when the
+// customer declared fewer windows than there are waves.
+type MigrationWave struct {
+	Wave int `json:"wave"`
+	// Environment is the business environment (dev, stage, prod) all
+	// of the wave's clusters belong to; empty for unclassified ones.
+	Environment          string                 `json:"environment,omitempty"`
+	CutoverWindow        string                 `json:"cutover_window,omitempty"`
+	Clusters             []MigrationWaveCluster `json:"clusters"`
+	TotalBytes           int64                  `json:"total_bytes"`
+	EstimatedSyncSeconds float64                `json:"estimated_sync_seconds"`
+	// OversizedTeam names the team whose clusters exceed
+	// MaxClustersPerWave and were kept together regardless.
+	OversizedTeam string `json:"oversized_team,omitempty"`
+}
+
+// MigrationWavesSection is the phased migration order for the fleet.
+// LinkBandwidthMBps and MaxClustersPerWave echo the resolved
+// constraints the waves were built with.
+type MigrationWavesSection struct {
+	LinkBandwidthMBps  float64         `json:"link_bandwidth_mbps"`
+	MaxClustersPerWave int             `json:"max_clusters_per_wave"`
+	Waves              []MigrationWave `json:"waves"`
+}
+
+// ----- replication -----
+
+// ReplicationEquivalent is the Confluent mechanism an MSK Replicator
+// flow maps to once both of its clusters run on Confluent Cloud.
+type ReplicationEquivalent string
+
+const (
+	// ReplicationClusterLink — a one-way cluster link with mirror
+	// topics.
+	ReplicationClusterLink ReplicationEquivalent = "cluster_link"
+	// ReplicationClusterLinkBidirectional — the flow has a reverse
+	// flow between the same clusters, so the pair becomes one
+	// bidirectional cluster link.
+	ReplicationClusterLinkBidirectional ReplicationEquivalent = "cluster_link_bidirectional"
+	// ReplicationConfluentReplicator — the flow starts from the latest
+	// offset, which mirror topics cannot do (they always copy from the
+	// earliest retained offset).
+	ReplicationConfluentReplicator ReplicationEquivalent = "confluent_replicator"
+)
+
+// ReplicationFindingKind classifies a replication topology warning.
+type ReplicationFindingKind string
+
+const (
+	// ReplicationLoop — flows with identical topic names form a cycle,
+	// so records would be replicated back to the cluster they came
+	// from.
+	ReplicationLoop ReplicationFindingKind = "loop"
+	// ReplicationRenameConflict — two flows into the same target
+	// cluster write the same destination topic names from different
+	// sources.
+	ReplicationRenameConflict ReplicationFindingKind = "rename_conflict"
+)
+
+// ReplicationFlow maps one replication flow of an MSK Replicator (a
+// source → target cluster pair) to its Confluent equivalent.
+// Config is the cluster link configuration that reproduces the flow,
+// one `key=value` per entry (connector properties when the equivalent
+// is Confluent Replicator); Notes are the settings that don't carry
+// over as-is.
+type ReplicationFlow struct {
+	Replicator       string                `json:"replicator"`
+	Region           string                `json:"region"`
+	SourceCluster    string                `json:"source_cluster"`
+	TargetCluster    string                `json:"target_cluster"`
+	Topics           []string              `json:"topics"`
+	ExcludedTopics   []string              `json:"excluded_topics,omitempty"`
+	ConsumerGroups   []string              `json:"consumer_groups,omitempty"`
+	TopicPrefix      string                `json:"topic_prefix,omitempty"`
+	StartingPosition string                `json:"starting_position,omitempty"`
+	Equivalent       ReplicationEquivalent `json:"equivalent"`
+	Config           []string              `json:"config"`
+	Notes            []string              `json:"notes,omitempty"`
+}
+
+// ReplicationFinding is one topology warning. Replicators lists the
+// replicators whose flows take part, sorted.
+type ReplicationFinding struct {
+	Kind        ReplicationFindingKind `json:"kind"`
+	Replicators []string               `json:"replicators"`
+	Detail      string                 `json:"detail"`
+}
+
+// ReplicationSection lists every MSK Replicator flow in the state,
+// sorted by replicator then source cluster, with the topology
+// findings. Nil when no replicators were discovered.
+type ReplicationSection struct {
+	Flows    []ReplicationFlow    `json:"flows"`
+	Findings []ReplicationFinding `json:"findings,omitempty"`
+}
+
+// ----- client access paths -----
+
+// ClientVPCConnection is one MSK multi-VPC client connection: a VPC
+// in Owner's account that reaches the cluster over a managed
+// PrivateLink endpoint. Active is false for connections that were
+// rejected, deleted or failed — those have nothing to recreate.
+type ClientVPCConnection struct {
+	Arn            string `json:"arn"`
+	Owner          string `json:"owner"`
+	Authentication string `json:"authentication,omitempty"`
+	State          string `json:"state"`
+	Active         bool   `json:"active"`
+}
+
+// ClusterClientAccess lists the private and public client access
+// paths of one source cluster beyond its own VPC. MultiVPCAuth is the
+// auth types enabled for multi-VPC private connectivity (`IAM`,
+// `SCRAM`, `TLS`); Steps are the actions that recreate the paths on
+// the cluster's Confluent Cloud networking verdict.
+type ClusterClientAccess struct {
+	ClusterID    string                `json:"cluster_id"`
+	MultiVPCAuth []string              `json:"multi_vpc_auth,omitempty"`
+	PublicAccess bool                  `json:"public_access"`
+	Connections  []ClientVPCConnection `json:"connections,omitempty"`
+	Networking   Networking            `json:"networking,omitempty"`
+	Steps        []string              `json:"steps"`
+}
+
+// ClientAccessSection lists the clusters with multi-VPC connectivity,
+// client VPC connections or public access, in fleet order. Nil when
+// every cluster is only reachable from its own VPC.
+type ClientAccessSection struct {
+	Clusters []ClusterClientAccess `json:"clusters"`
+}
+
+// ----- cross-account access -----
+
+// CrossAccountPrincipalKind is the kind of principal a cluster policy
+// grants access to.
+type CrossAccountPrincipalKind string
+
+const (
+	CrossAccountAccount CrossAccountPrincipalKind = "account"
+	CrossAccountRole    CrossAccountPrincipalKind = "role"
+	CrossAccountUser    CrossAccountPrincipalKind = "user"
+	CrossAccountService CrossAccountPrincipalKind = "service"
+	CrossAccountAnyone  CrossAccountPrincipalKind = "anyone"
+)
+
+// CrossAccountGrant is one external principal of an Allow statement
+// in a cluster policy. Account is empty for service principals;
+// Conditions are the statement's condition operator and key pairs,
+// e.g. `StringEquals aws:SourceVpc`. Equivalent is what replaces the
+// grant on Confluent Cloud.
+type CrossAccountGrant struct {
+	Principal   string                    `json:"principal"`
+	Account     string                    `json:"account,omitempty"`
+	Kind        CrossAccountPrincipalKind `json:"kind"`
+	StatementID string                    `json:"statement_id,omitempty"`
+	Actions     []string                  `json:"actions"`
+	Conditions  []string                  `json:"conditions,omitempty"`
+	Equivalent  string                    `json:"equivalent"`
+}
+
+// ClusterCrossAccountAccess is one cluster's external grants. Account
+// is the cluster's own AWS account. ParseError is set when the cluster
+// policy isn't valid JSON, so its grants couldn't be read.
+type ClusterCrossAccountAccess struct {
+	ClusterID  string              `json:"cluster_id"`
+	Account    string              `json:"account"`
+	Grants     []CrossAccountGrant `json:"grants,omitempty"`
+	ParseError string              `json:"parse_error,omitempty"`
+}
+
+// CrossAccountAccessSection lists the clusters whose cluster policy
+// grants external access, sorted by cluster ID.
+type CrossAccountAccessSection struct {
+	Clusters []ClusterCrossAccountAccess `json:"clusters"`
+}
+
+// ----- migration semantics -----
+
+// CompactedTopic is a user topic whose cleanup.policy includes
+// compact.
+type CompactedTopic struct {
+	Topic         string `json:"topic"`
+	CleanupPolicy string `json:"cleanup_policy"`
+	Partitions    int    `json:"partitions"`
+}
+
+// TransactionalGrantSource is where a TransactionalIDGrant was read
+// from.
+type TransactionalGrantSource string
+
+const (
+	TransactionalGrantACL TransactionalGrantSource = "acl"
+	TransactionalGrantIAM TransactionalGrantSource = "iam"
+)
+
+// TransactionalIDGrant is a principal allowed to use a transactional
+// ID (or a prefix / wildcard of them): an Allow ACL on a
+// TransactionalID resource, or an IAM statement granting
+// kafka-cluster transactional-ID actions. PatternType is the ACL's
+// resource pattern type and empty for IAM grants.
+type TransactionalIDGrant struct {
+	Principal       string                   `json:"principal"`
+	TransactionalID string                   `json:"transactional_id"`
+	PatternType     string                   `json:"pattern_type,omitempty"`
+	Source          TransactionalGrantSource `json:"source"`
+}
+
+// TransactionalUsage is the evidence of transactional producers on one
+// cluster. TransactionStateTopic is true when the coordinator's
+// __transaction_state log exists, i.e. a producer has initialized
+// transactions at some point.
+type TransactionalUsage struct {
+	TransactionStateTopic      bool                   `json:"transaction_state_topic"`
+	TransactionStatePartitions int                    `json:"transaction_state_partitions,omitempty"`
+	Grants                     []TransactionalIDGrant `json:"grants,omitempty"`
+}
+
+// StaticMembershipGroup is a consumer group with at least one member
+// that joined with a group.instance.id (KIP-345 static membership).
+type StaticMembershipGroup struct {
+	GroupID           string   `json:"group_id"`
+	State             string   `json:"state"`
+	Members           int      `json:"members"`
+	StaticInstanceIDs []string `json:"static_instance_ids"`
+}
+
+// ClusterMigrationSemantics carries the findings of one cluster.
+// Transactions is nil when the cluster shows no sign of transactional
+// producers.
+type ClusterMigrationSemantics struct {
+	ClusterID              string                  `json:"cluster_id"`
+	CompactedTopics        []CompactedTopic        `json:"compacted_topics,omitempty"`
+	Transactions           *TransactionalUsage     `json:"transactions,omitempty"`
+	StaticMembershipGroups []StaticMembershipGroup `json:"static_membership_groups,omitempty"`
+}
+
+// MigrationSemanticsSection lists clusters with compacted topics,
+// transactional producers or static-membership groups, sorted by
+// cluster ID. Nil when no cluster has any.
+type MigrationSemanticsSection struct {
+	Clusters []ClusterMigrationSemantics `json:"clusters"`
+}
+
+// ----- kafka credentials -----
+
+// KafkaCredentialSecret is one Secrets Manager secret holding a Kafka
+// credential. MatchedBy is how discover recognised it (see the
+// types.KafkaSecretMatch* values); ScramClusters names the clusters
+// it is associated with for SASL/SCRAM. Consumers are the principals
+// its resource policy allows, which excludes principals granted
+// access through their own IAM policies.
+type KafkaCredentialSecret struct {
+	Region            string     `json:"region"`
+	Name              string     `json:"name"`
+	Arn               string     `json:"arn"`
+	MatchedBy         []string   `json:"matched_by"`
+	ScramClusters     []string   `json:"scram_clusters,omitempty"`
+	RotationEnabled   bool       `json:"rotation_enabled"`
+	RotationAfterDays int64      `json:"rotation_after_days,omitempty"`
+	RotationSchedule  string     `json:"rotation_schedule,omitempty"`
+	Consumers         []string   `json:"consumers,omitempty"`
+	LastAccessedDate  *time.Time `json:"last_accessed_date,omitempty"`
+}
+
+// KafkaCredentialsSection lists the Kafka credential secrets, sorted
+// by region and name.
+type KafkaCredentialsSection struct {
+	Secrets []KafkaCredentialSecret `json:"secrets"`
+}
+
+// ----- connector support -----
+
+// ConnectorSupport is one discovered connector and its Confluent Cloud
+// status: `supported`, `partially-supported` or `self-managed-only`.
+// Source is `msk_connect` or `self_managed`; Plugin is the
+// fully-managed plugin that replaces the class, when there is one.
+type ConnectorSupport struct {
+	Name           string `json:"name"`
+	Source         string `json:"source"`
+	ConnectorClass string `json:"connector_class"`
+	Plugin         string `json:"plugin,omitempty"`
+	Availability   string `json:"availability"`
+	Note           string `json:"note,omitempty"`
+}
+
+// ClusterConnectorSupport is the connectors of one cluster, sorted by
+// source and name.
+type ClusterConnectorSupport struct {
+	ClusterID  string             `json:"cluster_id"`
+	Connectors []ConnectorSupport `json:"connectors"`
+}
+
+// ConnectorSupportSection counts the fleet's connectors by Confluent
+// Cloud status and lists them per cluster, sorted by cluster ID.
+type ConnectorSupportSection struct {
+	Supported          int                       `json:"supported"`
+	PartiallySupported int                       `json:"partially_supported"`
+	SelfManagedOnly    int                       `json:"self_managed_only"`
+	Clusters           []ClusterConnectorSupport `json:"clusters"`
+}
@@ -100,6 +100,7 @@ func (s *PlanService) Build(state report.ProcessedState, inputs PlanInputsResolv
 		// `append` to any one InputsMissing would silently mutate the
 		// others if cap permits.
 		sizing.InputsMissing = slices.Clone(missing)
+		sizing.Environment = c.Environment
 		ct := decideClusterType(c, sizing, s.cfg, clusterInputs)
 		net := decideNetworking(sizing, ct, s.cfg, clusterInputs)
 		ct.InputsMissing = slices.Clone(missing)
@@ -123,6 +124,7 @@ func (s *PlanService) Build(state report.ProcessedState, inputs PlanInputsResolv
 	}
 	plan.SourceEnvironment.TotalRegions = countRegions(state)
 	plan.SizingAppendix = buildSizingAppendix(plan.Sizing, s.cfg, inputs)
+	plan.SizingByEnvironment = sizingByEnvironment(plan.Sizing)
 
 	// Cutover defaults are fleet-wide; per-cluster overrides layer on
 	// top via clusters[<name>].downtime_tolerance / .sub_pattern.
@@ -171,6 +173,66 @@ func (s *PlanService) Build(state report.ProcessedState, inputs PlanInputsResolv
 	plan.CostReconciliation = detectCostReconciliation(state, s.cfg)
 	plan.OpenQuestions = append(plan.OpenQuestions, detectCostReconciliationOpenQuestions(state)...)
 
+	// Configuration Drift — per-cluster MSK configuration properties
+	// that are risky on the source or that Confluent Cloud pins to a
+	// different value. Only explicitly-set properties are compared.
+	plan.ConfigDrift = detectConfigDrift(state, s.cfg)
+
+	// Partition Skew — oversized partitions and hot-key topics that
+	// slow the cluster link's initial sync, from the partition sizes
+	// `kcp scan clusters` read off the brokers' log dirs.
+	plan.PartitionSkew = detectPartitionSkew(state, s.cfg)
+
+	// Topic Data Volume — every sized user topic, largest first, with
+	// an initial sync estimate that orders the mirroring.
+	plan.DataVolume = detectDataVolume(state, s.cfg)
+
+	// Cluster Link Bandwidth — the initial sync with live ingress taken
+	// into account, the bandwidth the link needs afterwards and the
+	// jump cluster instance type that carries it.
+	plan.LinkBandwidth = detectLinkBandwidth(state, s.cfg)
+
+	// Cross-AZ Consumer Traffic — the zones of the brokers and
+	// partition leaders, whether consumers fetch from a follower in
+	// their own zone and what the traffic crossing zones costs.
+	plan.CrossAZTraffic = detectCrossAZTraffic(state, s.cfg)
+
+	// Migration Waves — the fleet batched into phased waves under the
+	// customer's team groupings, cutover windows and link bandwidth.
+	waves, waveOQs := detectMigrationWaves(state, s.cfg, inputs)
+	plan.MigrationWaves = waves
+	plan.OpenQuestions = append(plan.OpenQuestions, waveOQs...)
+
+	// Replication — each MSK Replicator flow mapped to the cluster
+	// link (or Confluent Replicator) that replaces it, with warnings
+	// for replication loops and conflicting topic renames.
+	plan.Replication = detectReplication(state)
+
+	// Client Access Paths — multi-VPC private connectivity, client VPC
+	// connections and public access, with the steps that recreate
+	// each path on the cluster's networking verdict.
+	plan.ClientAccess = detectClientAccess(state, plan.NetworkingDecision)
+
+	// Cross-Account Access — cluster policy grants to other accounts,
+	// AWS services and anonymous principals, each needing a Confluent
+	// Cloud equivalent or an explicit sunset.
+	plan.CrossAccountAccess = detectCrossAccountAccess(state)
+
+	// Migration Semantics — compacted topics, transactional producers
+	// and static-membership consumer groups, each of which needs its
+	// own handling around the cluster link cutover.
+	plan.MigrationSemantics = detectMigrationSemantics(state)
+
+	// Kafka Credentials — the Secrets Manager secrets holding SCRAM or
+	// other Kafka credentials, each re-issued as a Confluent Cloud API
+	// key, with the rotation and consumers that change with it.
+	plan.KafkaCredentials = detectKafkaCredentials(state)
+
+	// Connector Support — each MSK Connect and self-managed connector
+	// marked supported, partially supported or self-managed-only on
+	// Confluent Cloud, from the connector class catalog.
+	plan.ConnectorSupport = detectConnectorSupport(state)
+
 	// Stale-state OQ: surface a fleet-wide accuracy warning when the
 	// source state file is older than the freshness window. The Plan
 	// still renders against whatever's in state.json — but a 14-day-old
@@ -6,8 +6,11 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	kafkaconnecttypes "github.com/aws/aws-sdk-go-v2/service/kafkaconnect/types"
 	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
 )
 
 // Stable Red Flag IDs. Matches the spec's row numbering — kept stable
@@ -28,6 +31,8 @@ const (
 	RedFlagIDEOSInUse                  = "eos_in_use"
 	RedFlagIDKafkaStreamsInUse         = "kafka_streams_in_use"
 	RedFlagIDBroadTopicPatternMatch    = "broad_topic_pattern_match"
+	RedFlagIDClusterOperationInFlight  = "cluster_operation_in_flight"
+	RedFlagIDMSKConnectorFailed        = "msk_connector_failed"
 )
 
 // expressInstanceFamilies are the MSK Express broker instance-type
@@ -52,7 +57,7 @@ var broadTopicPatterns = []struct {
 	{label: "Connector heartbeats (`-heartbeats`)", re: regexp.MustCompile(`-heartbeats$`)},
 }
 
-// detectRedFlags evaluates the 15 boolean trigger rows from the spec.
+// detectRedFlags evaluates the 17 boolean trigger rows from the spec.
 // Returns nil when there are no clusters in the state file (the
 // renderer omits the section in that case). Each row is evaluated
 // independently and produces a {Status, Evidence} pair — Triggered
@@ -79,6 +84,8 @@ func detectRedFlags(state report.ProcessedState, plan *Plan, cfg *PlanConfig, in
 		evalEOSInUse(inputs),
 		evalKafkaStreamsInUse(clusters, inputs),
 		evalBroadTopicPatternMatch(clusters),
+		evalClusterOperationInFlight(clusters),
+		evalMSKConnectorFailed(clusters),
 	}
 	return &RedFlagsSection{Rows: rows}
 }
@@ -662,3 +669,151 @@ func evalBroadTopicPatternMatch(clusters []report.ProcessedCluster) RedFlag {
 	rf.Evidence = strings.Join(parts, "; ")
 	return rf
 }
+
+// ----- Row 16: cluster operation in flight at scan time -----
+
+// Triggered when any cluster had a PENDING / *_IN_PROGRESS operation
+// when it was discovered. Evaluated from the operation summaries so
+// older state files still fire; the step the operation was on comes
+// from the DescribeClusterOperationV2 detail when it was captured.
+// Mutating the cluster mid-operation is risky, and metrics gathered
+// while brokers roll don't reflect steady state.
+func evalClusterOperationInFlight(clusters []report.ProcessedCluster) RedFlag {
+	rf := RedFlag{ID: RedFlagIDClusterOperationInFlight, Title: "Cluster operation in flight at scan time"}
+	type operationHit struct {
+		Cluster string `json:"cluster"`
+		Type    string `json:"type"`
+		State   string `json:"state"`
+		Step    string `json:"step,omitempty"`
+	}
+	var hits []operationHit
+	var hitStrs []string
+	for _, c := range clusters {
+		for _, op := range c.AWSClientInformation.ClusterOperations {
+			state := aws.ToString(op.OperationState)
+			if !types.IsClusterOperationInFlight(state) {
+				continue
+			}
+			hit := operationHit{
+				Cluster: c.Name,
+				Type:    aws.ToString(op.OperationType),
+				State:   state,
+				Step:    currentOperationStep(c.AWSClientInformation.InFlightOperations, aws.ToString(op.OperationArn)),
+			}
+			hits = append(hits, hit)
+			desc := fmt.Sprintf("%s: %s %s", hit.Cluster, hit.Type, hit.State)
+			if hit.Step != "" {
+				desc += fmt.Sprintf(" (step %s)", hit.Step)
+			}
+			hitStrs = append(hitStrs, desc)
+		}
+	}
+	if len(hits) == 0 {
+		rf.Status = RedFlagNotTriggered
+		return rf
+	}
+	rf.Status = RedFlagTriggered
+	rf.Evidence = strings.Join(hitStrs, ", ") + " — don't change the cluster or rely on scanned metrics until the operation completes; re-run `kcp discover` afterwards"
+	rf.EvidenceFields = map[string]any{"operations": hits}
+	return rf
+}
+
+// currentOperationStep returns the name of the last step recorded for
+// the operation, or "" when its detail wasn't captured.
+func currentOperationStep(operations []kafkatypes.ClusterOperationV2, operationArn string) string {
+	for _, op := range operations {
+		if aws.ToString(op.OperationArn) != operationArn || op.Provisioned == nil {
+			continue
+		}
+		steps := op.Provisioned.OperationSteps
+		if len(steps) == 0 {
+			return ""
+		}
+		return aws.ToString(steps[len(steps)-1].StepName)
+	}
+	return ""
+}
+
+// ----- Row 17: MSK Connect connectors in FAILED state -----
+
+// A FAILED connector recreated as-is fails again on the target, so
+// the root cause has to be fixed first. Evidence carries the failure
+// reason, the last failed operation and the execution role / worker
+// log delivery captured by `kcp discover`; files scanned before those
+// diagnostics existed still fire, pointing at a rescan.
+func evalMSKConnectorFailed(clusters []report.ProcessedCluster) RedFlag {
+	rf := RedFlag{ID: RedFlagIDMSKConnectorFailed, Title: "MSK Connect connectors in FAILED state"}
+	type failedConnector struct {
+		Cluster         string   `json:"cluster"`
+		Connector       string   `json:"connector"`
+		Reason          string   `json:"reason,omitempty"`
+		FailedOperation string   `json:"failed_operation,omitempty"`
+		ExecutionRole   string   `json:"execution_role,omitempty"`
+		LogDestinations []string `json:"log_destinations,omitempty"`
+	}
+	var failed []failedConnector
+	var failedStrs []string
+	for _, c := range clusters {
+		for _, conn := range c.AWSClientInformation.Connectors {
+			if conn.ConnectorState != string(kafkaconnecttypes.ConnectorStateFailed) {
+				continue
+			}
+			hit := failedConnector{Cluster: c.Name, Connector: conn.ConnectorName}
+			desc := fmt.Sprintf("%s/%s", c.Name, conn.ConnectorName)
+			d := conn.Diagnostics
+			if d == nil {
+				failed = append(failed, hit)
+				failedStrs = append(failedStrs, desc+" (no diagnostics — re-run `kcp discover`)")
+				continue
+			}
+			hit.Reason = strings.TrimSpace(strings.Join([]string{d.StateCode, d.StateMessage}, " "))
+			hit.FailedOperation = failedConnectorOperation(d.Operations)
+			hit.ExecutionRole = d.ServiceExecutionRoleArn
+			hit.LogDestinations = d.LogDestinations()
+			failed = append(failed, hit)
+
+			var details []string
+			if hit.Reason != "" {
+				details = append(details, hit.Reason)
+			}
+			if hit.FailedOperation != "" {
+				details = append(details, "failed operation "+hit.FailedOperation)
+			}
+			if hit.ExecutionRole != "" {
+				details = append(details, "role "+hit.ExecutionRole)
+			}
+			if len(hit.LogDestinations) > 0 {
+				details = append(details, "worker logs in "+strings.Join(hit.LogDestinations, ", "))
+			} else {
+				details = append(details, "no worker log delivery configured")
+			}
+			failedStrs = append(failedStrs, fmt.Sprintf("%s (%s)", desc, strings.Join(details, "; ")))
+		}
+	}
+	if len(failed) == 0 {
+		rf.Status = RedFlagNotTriggered
+		return rf
+	}
+	rf.Status = RedFlagTriggered
+	rf.Evidence = strings.Join(failedStrs, ", ") + " — fix the root cause before recreating these connectors on the target"
+	rf.EvidenceFields = map[string]any{"connectors": failed}
+	return rf
+}
+
+// failedConnectorOperation describes the first failed operation in
+// the order ListConnectorOperations returned them, or "" when none
+// failed.
+func failedConnectorOperation(operations []types.ConnectorOperation) string {
+	for _, op := range operations {
+		if op.OperationState != string(kafkaconnecttypes.ConnectorOperationStateUpdateFailed) &&
+			op.OperationState != string(kafkaconnecttypes.ConnectorOperationStateRollbackFailed) {
+			continue
+		}
+		desc := fmt.Sprintf("%s %s", op.OperationType, op.OperationState)
+		if op.ErrorMessage != "" {
+			desc += ": " + op.ErrorMessage
+		}
+		return desc
+	}
+	return ""
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 )
 
@@ -123,6 +124,68 @@ func TestRedFlags_BroadTopicPatternMatch(t *testing.T) {
 
 // Empty fleet (no MSK clusters) → detectRedFlags returns nil so the
 // renderer omits the §Red Flags section entirely.
+// Row 16 — cluster operation in flight at scan time. Steps come from the
+// DescribeClusterOperationV2 detail when it was captured.
+func TestRedFlags_ClusterOperationInFlight(t *testing.T) {
+	rolling := redFlagCluster("rolling-cluster", "3.5.0", "kafka.m5.large", "")
+	rolling.AWSClientInformation.ClusterOperations = []kafkatypes.ClusterOperationV2Summary{
+		{OperationArn: aws.String("op-1"), OperationType: aws.String("UPDATE_BROKER_TYPE"), OperationState: aws.String("UPDATE_IN_PROGRESS")},
+		{OperationArn: aws.String("op-0"), OperationType: aws.String("UPDATE_BROKER_COUNT"), OperationState: aws.String("UPDATE_COMPLETE")},
+	}
+	rolling.AWSClientInformation.InFlightOperations = []kafkatypes.ClusterOperationV2{{
+		OperationArn: aws.String("op-1"),
+		Provisioned: &kafkatypes.ClusterOperationV2Provisioned{OperationSteps: []kafkatypes.ClusterOperationStep{
+			{StepName: aws.String("INITIALIZE_UPDATE")},
+			{StepName: aws.String("UPDATE_BROKERS")},
+		}},
+	}}
+	steady := redFlagCluster("steady-cluster", "3.5.0", "kafka.m5.large", "")
+	steady.AWSClientInformation.ClusterOperations = []kafkatypes.ClusterOperationV2Summary{
+		{OperationArn: aws.String("op-2"), OperationType: aws.String("UPDATE_CONFIG"), OperationState: aws.String("UPDATE_FAILED")},
+	}
+
+	plan := buildPlanForRedFlags(t, wrapClusters(rolling, steady), defaultCfg(t), defaultInputs())
+	row := findRow(t, plan.RedFlags, RedFlagIDClusterOperationInFlight)
+	assert.Equal(t, RedFlagTriggered, row.Status)
+	assert.Contains(t, row.Evidence, "rolling-cluster: UPDATE_BROKER_TYPE UPDATE_IN_PROGRESS (step UPDATE_BROKERS)")
+	assert.NotContains(t, row.Evidence, "steady-cluster")
+
+	plan = buildPlanForRedFlags(t, wrapClusters(steady), defaultCfg(t), defaultInputs())
+	assert.Equal(t, RedFlagNotTriggered, findRow(t, plan.RedFlags, RedFlagIDClusterOperationInFlight).Status)
+}
+
+// Row 17 — MSK Connect connectors in FAILED state.
+func TestRedFlags_MSKConnectorFailed(t *testing.T) {
+	c := redFlagCluster("orders-cluster", "3.5.0", "kafka.m5.large", "")
+	c.AWSClientInformation.Connectors = []types.ConnectorSummary{
+		{ConnectorName: "s3-sink", ConnectorState: "RUNNING"},
+		{
+			ConnectorName:  "pg-sink",
+			ConnectorState: "FAILED",
+			Diagnostics: &types.ConnectorDiagnostics{
+				StateCode:               "CONNECTOR_FAILED",
+				StateMessage:            "unable to reach database",
+				ServiceExecutionRoleArn: "arn:aws:iam::123:role/pg-sink",
+				Operations: []types.ConnectorOperation{
+					{OperationType: "UPDATE_CONNECTOR_CONFIGURATION", OperationState: "UPDATE_FAILED", ErrorMessage: "bad connection.url"},
+				},
+			},
+		},
+		{ConnectorName: "legacy-sink", ConnectorState: "FAILED"},
+	}
+
+	plan := buildPlanForRedFlags(t, wrapClusters(c), defaultCfg(t), defaultInputs())
+	row := findRow(t, plan.RedFlags, RedFlagIDMSKConnectorFailed)
+	assert.Equal(t, RedFlagTriggered, row.Status)
+	assert.Contains(t, row.Evidence, "orders-cluster/pg-sink (CONNECTOR_FAILED unable to reach database; failed operation UPDATE_CONNECTOR_CONFIGURATION UPDATE_FAILED: bad connection.url; role arn:aws:iam::123:role/pg-sink; no worker log delivery configured)")
+	assert.Contains(t, row.Evidence, "orders-cluster/legacy-sink (no diagnostics — re-run `kcp discover`)")
+	assert.NotContains(t, row.Evidence, "s3-sink")
+
+	c.AWSClientInformation.Connectors = c.AWSClientInformation.Connectors[:1]
+	plan = buildPlanForRedFlags(t, wrapClusters(c), defaultCfg(t), defaultInputs())
+	assert.Equal(t, RedFlagNotTriggered, findRow(t, plan.RedFlags, RedFlagIDMSKConnectorFailed).Status)
+}
+
 func TestDetectRedFlags_EmptyFleetReturnsNil(t *testing.T) {
 	assert.Nil(t, detectRedFlags(report.ProcessedState{}, &Plan{}, defaultCfg(t), defaultInputs()))
 }
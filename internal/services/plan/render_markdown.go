@@ -79,6 +79,54 @@ func RenderMarkdown(p *Plan, cfg *PlanConfig) ([]byte, error) {
 		writeCostReconciliation(&b, p.CostReconciliation, section)
 		section++
 	}
+	if p.ConfigDrift != nil && len(p.ConfigDrift.Clusters) > 0 {
+		writeConfigDrift(&b, p.ConfigDrift, cfg, section)
+		section++
+	}
+	if p.PartitionSkew != nil && len(p.PartitionSkew.Clusters) > 0 {
+		writePartitionSkew(&b, p.PartitionSkew, cfg, section)
+		section++
+	}
+	if p.DataVolume != nil && len(p.DataVolume.Clusters) > 0 {
+		writeDataVolume(&b, p.DataVolume, section)
+		section++
+	}
+	if p.LinkBandwidth != nil && len(p.LinkBandwidth.Clusters) > 0 {
+		writeLinkBandwidth(&b, p.LinkBandwidth, section)
+		section++
+	}
+	if p.CrossAZTraffic != nil && len(p.CrossAZTraffic.Clusters) > 0 {
+		writeCrossAZTraffic(&b, p.CrossAZTraffic, section)
+		section++
+	}
+	if p.MigrationWaves != nil && len(p.MigrationWaves.Waves) > 0 {
+		writeMigrationWaves(&b, p.MigrationWaves, section)
+		section++
+	}
+	if p.Replication != nil && len(p.Replication.Flows) > 0 {
+		writeReplication(&b, p.Replication, section)
+		section++
+	}
+	if p.ClientAccess != nil && len(p.ClientAccess.Clusters) > 0 {
+		writeClientAccess(&b, p.ClientAccess, section)
+		section++
+	}
+	if p.CrossAccountAccess != nil && len(p.CrossAccountAccess.Clusters) > 0 {
+		writeCrossAccountAccess(&b, p.CrossAccountAccess, section)
+		section++
+	}
+	if p.MigrationSemantics != nil && len(p.MigrationSemantics.Clusters) > 0 {
+		writeMigrationSemantics(&b, p.MigrationSemantics, section)
+		section++
+	}
+	if p.KafkaCredentials != nil && len(p.KafkaCredentials.Secrets) > 0 {
+		writeKafkaCredentials(&b, p.KafkaCredentials, section)
+		section++
+	}
+	if p.ConnectorSupport != nil && len(p.ConnectorSupport.Clusters) > 0 {
+		writeConnectorSupport(&b, p.ConnectorSupport, section)
+		section++
+	}
 	writeOpenQuestions(&b, p, section)
 	writeSizingAppendix(&b, p, cfg)
 	writeRulesAppendix(&b, p)
@@ -409,6 +457,8 @@ func writeSizingAndDecisions(b *bytes.Buffer, p *Plan, cfg *PlanConfig, section
 		b.WriteString("`*` = sizing is provisional — some scan inputs were missing or metrics were degraded; see each cluster's Why line. `(floor)` next to a size means the SLA minimum bound it; both can apply to the same cluster.\n\n")
 	}
 
+	writeSizingByEnvironment(b, p.SizingByEnvironment, percentileLabel)
+
 	// Per-cluster rationale: each line cites the cluster-type decision and
 	// the networking decision. Reads cleanly even for 30+ clusters because
 	// each entry is one or two lines.
@@ -564,6 +614,25 @@ func writeSizingAndDecisions(b *bytes.Buffer, p *Plan, cfg *PlanConfig, section
 // for the FYI note on spiky clusters. Guards against P95==0 (the spiky
 // flag fires for any positive peak when P95 is zero — `peak > 2.0 * 0`)
 // so the ratio doesn't render as +Inf.
+// writeSizingByEnvironment renders the per-environment totals of the
+// sizing table, so capacity can be committed one environment at a time.
+func writeSizingByEnvironment(b *bytes.Buffer, envs []EnvironmentSizing, percentileLabel string) {
+	if len(envs) == 0 {
+		return
+	}
+	b.WriteString("### Sizing by Environment\n\n")
+	fmt.Fprintf(b, "| Environment | Clusters | %s in / out (MBps) | Total size (eCKU / CKU) |\n", percentileLabel)
+	b.WriteString("|---|---:|---|---:|\n")
+	for _, e := range envs {
+		size := fmt.Sprintf("%d", e.FinalECKU)
+		if e.Degraded > 0 {
+			size += " *"
+		}
+		fmt.Fprintf(b, "| %s | %d | %.1f / %.1f | %s |\n", environmentLabel(e.Environment), e.Clusters, e.SizedInMBps, e.SizedOutMBps, size)
+	}
+	b.WriteString("\n")
+}
+
 func spikyDescription(s ClusterSizing) string {
 	var parts []string
 	if s.SpikyIngress {
@@ -1253,9 +1322,50 @@ func writeAuth(b *bytes.Buffer, auths []AuthDecision, cutover *CutoverDecision,
 		}
 	}
 	writeAuthMappingProvenance(b, auths)
+	writeIAMPrincipals(b, auths)
 	b.WriteString("\n")
 }
 
+// writeIAMPrincipals renders the principal→permission mapping
+// discovered from IAM policies on SASL/IAM clusters. Those clusters
+// usually have no Kafka ACLs, so without this table the plan would
+// show nothing to migrate for access control.
+func writeIAMPrincipals(b *bytes.Buffer, auths []AuthDecision) {
+	anyPrincipals := false
+	for _, a := range auths {
+		if len(a.IAMPrincipals) > 0 {
+			anyPrincipals = true
+			break
+		}
+	}
+	if !anyPrincipals {
+		return
+	}
+	b.WriteString("\n### IAM principal permissions\n\n")
+	b.WriteString("On SASL/IAM clusters access is granted by IAM policies rather than Kafka ACLs. Each principal below needs equivalent ACLs or role bindings on Confluent Cloud — `kcp create-asset migrate-acls iam` generates them per principal. **Can connect** is the IAM policy simulator's verdict for `kafka-cluster:Connect`, which accounts for explicit denies and permission boundaries.\n\n")
+	b.WriteString("| Cluster | Principal | Type | kafka-cluster actions | Resources | Policies | Can connect |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, a := range auths {
+		for i, p := range a.IAMPrincipals {
+			cluster := escapeMarkdownTableCell(a.ClusterID)
+			if i > 0 {
+				cluster = ""
+			}
+			canConnect := "_unknown_"
+			if p.CanConnect != nil {
+				canConnect = "❌ no"
+				if *p.CanConnect {
+					canConnect = "✅ yes"
+				}
+			}
+			fmt.Fprintf(b, "| %s | `%s` | %s | %s | %s | %s | %s |\n",
+				cluster, p.PrincipalArn, p.PrincipalType,
+				strings.Join(quoteAll(p.Actions), ", "), strings.Join(quoteAll(p.Resources), ", "),
+				escapeMarkdownTableCell(strings.Join(p.Policies, ", ")), canConnect)
+		}
+	}
+}
+
 // writeAuthMappingProvenance surfaces the per-row source URL +
 // last_verified date so a reviewer can audit where each auth-mapping
 // recommendation came from. Walks the unique (SourceAuth, Source,
@@ -1798,6 +1908,669 @@ func writeCostReconciliation(b *bytes.Buffer, cr *CostReconciliationSection, sec
 	b.WriteString("_Cross-reference each candidate with your AWS console; common causes: a cluster intentionally excluded from `kcp discover` scope, a decommissioned cluster still showing up on the bill, or a cross-account cluster the scanner's IAM role can't see._\n\n")
 }
 
+// ----- §configuration drift -----
+
+// writeConfigDrift renders one table per cluster of the MSK
+// configuration properties that fail the plan-config baseline. Risky
+// settings are worth fixing on the source regardless of the
+// migration; Confluent Cloud-pinned ones need a client or topic-level
+// change before cutover.
+func writeConfigDrift(b *bytes.Buffer, cd *ConfigDriftSection, cfg *PlanConfig, section int) {
+	if cd == nil || len(cd.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Configuration Drift\n\n", section)
+	b.WriteString("Broker properties in each cluster's MSK configuration revision that deviate from the best-practice baseline (**risky**) or from a value Confluent Cloud pins (**not portable**). Properties the revision doesn't set run with the MSK default and aren't listed.\n\n")
+	for _, c := range cd.Clusters {
+		fmt.Fprintf(b, "**%s** — `%s` revision %d\n\n", c.ClusterID, c.ConfigurationArn, c.ConfigurationRevision)
+		b.WriteString("| Property | Value | Baseline | Finding | Why it matters |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, f := range c.Findings {
+			fmt.Fprintf(b, "| `%s` | `%s` | `%s` | %s | %s |\n",
+				f.Property, escapeMarkdownTableCell(f.Value), f.Baseline, configDriftKindLabel(f.Kind), escapeMarkdownTableCell(f.Note))
+		}
+		b.WriteString("\n")
+	}
+	if cfg != nil && cfg.ConfigBaseline.Source != "" {
+		fmt.Fprintf(b, "_Baseline from `plan-config.yaml` (last verified %s; source: %s)._\n\n", cfg.ConfigBaseline.LastVerified, cfg.ConfigBaseline.Source)
+	}
+}
+
+func configDriftKindLabel(k ConfigDriftKind) string {
+	switch k {
+	case ConfigDriftBestPractice:
+		return "⚠️ Risky"
+	case ConfigDriftCCImmutable:
+		return "🚫 Not portable"
+	default:
+		return string(k)
+	}
+}
+
+// ----- §partition skew -----
+
+// writePartitionSkew renders one table per cluster of the topics
+// whose partition sizes will slow mirroring, followed by the
+// remediation options to weigh before cutover.
+func writePartitionSkew(b *bytes.Buffer, ps *PartitionSkewSection, cfg *PlanConfig, section int) {
+	if ps == nil || len(ps.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Partition Skew\n\n", section)
+	b.WriteString("Topics with an **oversized** partition or a partition far larger than the topic's mean (**skewed**), from the on-disk partition sizes `kcp scan clusters` read off the brokers' log dirs. The cluster link copies each partition on its own, so the largest partition — not the topic total — bounds how long the initial sync takes, and a hot partition stays the bottleneck for mirroring lag after it.\n\n")
+	if cfg != nil {
+		fmt.Fprintf(b, "_Cutoffs from `plan-config.yaml`: oversized at %g GB per partition; skewed at %gx the mean partition size for topics of at least %g GB._\n\n",
+			cfg.PartitionSkew.OversizedPartitionGB, cfg.PartitionSkew.SkewRatio, cfg.PartitionSkew.MinTopicGB)
+	}
+	for _, c := range ps.Clusters {
+		fmt.Fprintf(b, "**%s**\n\n", c.ClusterID)
+		b.WriteString("| Topic | Partitions | Total size | Largest partition | Skew (max / mean) | Finding |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+		for _, t := range c.Topics {
+			fmt.Fprintf(b, "| `%s` | %d | %s | %d (%s) | %.1fx | %s |\n",
+				escapeMarkdownTableCell(t.Topic), t.Partitions, formatBytesHuman(float64(t.TotalBytes)),
+				t.LargestPartition, formatBytesHuman(float64(t.LargestPartitionBytes)), t.SkewRatio, partitionSkewKindsLabel(t.Kinds))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("**Before cutover:**\n\n")
+	b.WriteString("- **Skewed topics** — fix the partitioning key (a hot or null key sends most records to one partition), or add partitions if the key space is wide enough. New partitions only receive new records, so existing skew drains with retention.\n")
+	b.WriteString("- **Oversized partitions** — shorten `retention.ms` / `retention.bytes` to what the target actually needs before creating the link, or pre-seed the topic and start mirroring from a recent offset where replaying history isn't required.\n")
+	b.WriteString("- **Schedule the initial sync** around the largest partition: plan the link creation early enough that it catches up well before the cutover window, and watch mirror lag on these partitions first.\n\n")
+}
+
+func partitionSkewKindsLabel(kinds []PartitionSkewKind) string {
+	labels := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		switch k {
+		case PartitionSkewOversized:
+			labels = append(labels, "📦 Oversized")
+		case PartitionSkewSkewed:
+			labels = append(labels, "🔥 Skewed")
+		default:
+			labels = append(labels, string(k))
+		}
+	}
+	return strings.Join(labels, ", ")
+}
+
+// ----- §data volume -----
+
+// writeDataVolume renders one table per cluster of its topics by
+// on-disk size, in the suggested mirroring order.
+func writeDataVolume(b *bytes.Buffer, dv *DataVolumeSection, section int) {
+	if dv == nil || len(dv.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Topic Data Volume\n\n", section)
+	b.WriteString("User topics by on-disk size, from the partition sizes `kcp scan clusters` read off the brokers' log dirs (the largest replica of each partition). The cluster link has to copy all of it before mirror lag reaches zero, so the largest topics set how early the links must be created.\n\n")
+	fmt.Fprintf(b, "_Sync estimates assume a sustained cluster link throughput of %g MB/s (`data_volume.link_throughput_mbps` in `plan-config.yaml`) and exclude live ingress; measure the real figure with `kcp benchmark`._\n\n", dv.LinkThroughputMBps)
+	for _, c := range dv.Clusters {
+		fmt.Fprintf(b, "**%s** — %s in total, ≈ %s to sync\n\n", c.ClusterID, formatBytesHuman(float64(c.TotalBytes)), formatSyncDuration(c.EstimatedSyncSeconds))
+		b.WriteString("| Mirror order | Topic | Partitions | Size | Share of cluster | Largest partition | Sync estimate |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, t := range c.Topics {
+			fmt.Fprintf(b, "| %d | `%s` | %d | %s | %.1f%% | %s | %s |\n",
+				t.MirrorOrder, escapeMarkdownTableCell(t.Topic), t.Partitions, formatBytesHuman(float64(t.TotalBytes)),
+				t.ShareOfCluster*100, formatBytesHuman(float64(t.LargestPartitionBytes)), formatSyncDuration(t.EstimatedSyncSeconds))
+		}
+		b.WriteString("\n")
+		if c.OmittedTopics > 0 {
+			fmt.Fprintf(b, "_%d smaller topic(s) holding %s not listed._\n\n", c.OmittedTopics, formatBytesHuman(float64(c.OmittedBytes)))
+		}
+		if c.EmptyTopics > 0 {
+			fmt.Fprintf(b, "_%d empty topic(s) not listed; they sync immediately._\n\n", c.EmptyTopics)
+		}
+	}
+	b.WriteString("**Mirroring order:** create the mirror topics for the largest topics first so their initial sync overlaps with the rest of the migration, then mirror the smaller topics in batches. Throughput is shared by every mirror topic on a link, so starting everything at once delays the large topics without finishing the small ones any sooner.\n\n")
+}
+
+// formatSyncDuration renders a sync estimate at a precision that
+// matches how rough it is.
+func formatSyncDuration(seconds float64) string {
+	switch {
+	case seconds < 60:
+		return "< 1 min"
+	case seconds < 3600:
+		return fmt.Sprintf("%.0f min", seconds/60)
+	case seconds < 48*3600:
+		return fmt.Sprintf("%.1f h", seconds/3600)
+	default:
+		return fmt.Sprintf("%.1f days", seconds/86400)
+	}
+}
+
+// ----- §link bandwidth -----
+
+// writeLinkBandwidth renders one timeline row per cluster: the initial
+// sync with live ingress, then the steady-state bandwidth until
+// cutover.
+func writeLinkBandwidth(b *bytes.Buffer, lb *LinkBandwidthSection, section int) {
+	if lb == nil || len(lb.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Cluster Link Bandwidth\n\n", section)
+	b.WriteString("The cluster link copies each cluster's existing data while also mirroring what producers write in the meantime, so the initial sync only gets the link throughput left over after the sustained produce rate (BytesInPerSec average). Once mirror lag reaches zero the link carries the live produce rate until cutover, sized at the peak plus headroom.\n\n")
+	fmt.Fprintf(b, "_Estimates assume a sustained cluster link throughput of %g MB/s (`data_volume.link_throughput_mbps` in `plan-config.yaml`) and %.0f%% steady-state headroom (`link_bandwidth.steady_state_headroom`); measure the real throughput with `kcp benchmark`._\n\n", lb.LinkThroughputMBps, lb.SteadyStateHeadroom*100)
+	b.WriteString("| Cluster | Data | Produce (avg / peak) | Initial sync | Steady state until cutover | Jump cluster brokers |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	var stalled, unmetered, tooSmall []string
+	for _, c := range lb.Clusters {
+		name := fmt.Sprintf("`%s`", escapeMarkdownTableCell(c.ClusterID))
+		produce := fmt.Sprintf("%.1f / %.1f MB/s", c.SustainedProduceMBps, c.PeakProduceMBps)
+		if c.ProduceMetricsMissing {
+			produce = "_no metrics_"
+			unmetered = append(unmetered, name)
+		}
+		sync := fmt.Sprintf("≈ %s at %.1f MB/s", formatSyncDuration(c.InitialSyncSeconds), c.CatchUpMBps)
+		if !c.CanCatchUp {
+			sync = "**never completes**"
+			stalled = append(stalled, name)
+		}
+		jump := "—"
+		if c.JumpClusterInstanceType != "" {
+			jump = fmt.Sprintf("%d × `%s`", c.JumpClusterBrokers, c.JumpClusterInstanceType)
+			if c.JumpClusterInstanceTooSmall {
+				tooSmall = append(tooSmall, name)
+			}
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %.1f MB/s | %s |\n",
+			name, formatBytesHuman(float64(c.TotalBytes)), produce, sync, c.SteadyStateMBps, jump)
+	}
+	b.WriteString("\n")
+	if len(stalled) > 0 {
+		fmt.Fprintf(b, "**Link too slow:** producers on %s write at least as fast as the assumed link throughput, so mirror lag never reaches zero. Raise the link throughput (more jump cluster brokers, a faster network path) or reduce the produce rate before cutover.\n\n", strings.Join(stalled, ", "))
+	}
+	if len(unmetered) > 0 {
+		fmt.Fprintf(b, "_No BytesInPerSec metrics for %s: the initial sync leaves live ingress out and the steady-state bandwidth is unknown. Run `kcp discover` with metrics to fill them in._\n\n", strings.Join(unmetered, ", "))
+	}
+	b.WriteString("Jump cluster brokers (migration types 4 and 5) are the smallest m5 size whose baseline network bandwidth carries each broker's share of the larger of the link throughput and the steady-state bandwidth; `kcp create-asset migration-infra` uses the same sizing when `--jump-cluster-instance-type` is not set.\n\n")
+	if len(tooSmall) > 0 {
+		fmt.Fprintf(b, "_Even the largest m5 size falls short of the bandwidth needed for %s; expect the link to run slower than estimated._\n\n", strings.Join(tooSmall, ", "))
+	}
+}
+
+// ----- §cross-AZ traffic -----
+
+// writeCrossAZTraffic renders one row per cluster: its zones, how its
+// consumers fetch and what the consumer traffic crossing zones costs.
+func writeCrossAZTraffic(b *bytes.Buffer, ct *CrossAZTrafficSection, section int) {
+	if ct == nil || len(ct.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Cross-AZ Consumer Traffic\n\n", section)
+	b.WriteString("A consumer fetching from a partition leader in another availability zone pays for every byte crossing zones. With `replica.selector.class` set to `org.apache.kafka.common.replica.RackAwareReplicaSelector` on the brokers and `client.rack` set to the consumer's zone, consumers read from a follower in their own zone instead (KIP-392). Brokers learn a consumer's `client.rack` from Kafka 3.5 clients on (KIP-881), so members of older clients count as leader-only.\n\n")
+	price := fmt.Sprintf("$%g per GB (`cross_az_traffic.transfer_usd_per_gb` in `plan-config.yaml`)", ct.TransferUSDPerGB)
+	if ct.Source != "" {
+		price = fmt.Sprintf("[$%g per GB](%s) (`cross_az_traffic.transfer_usd_per_gb` in `plan-config.yaml`)", ct.TransferUSDPerGB, ct.Source)
+	}
+	fmt.Fprintf(b, "_Cross-AZ share assumes partition leaders and consumers spread evenly over the zones; monthly figures apply it to the average consumer traffic (BytesOutPerSec) at %s._\n\n", price)
+	b.WriteString("| Cluster | Zones | Fetching | Consumers with matching client.rack | Cross-AZ share | Consume (avg) | Cross-AZ per month |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	var leaderOnly, unmetered []string
+	for _, c := range ct.Clusters {
+		name := fmt.Sprintf("`%s`", escapeMarkdownTableCell(c.ClusterID))
+		zones := make([]string, 0, len(c.Zones))
+		for _, z := range c.Zones {
+			label := fmt.Sprintf("`%s`", escapeMarkdownTableCell(z.Rack))
+			switch {
+			case z.Rack == "":
+				label = fmt.Sprintf("`%s`", escapeMarkdownTableCell(z.AvailabilityZone))
+			case z.AvailabilityZone != "":
+				label += " " + escapeMarkdownTableCell(z.AvailabilityZone)
+			}
+			label += fmt.Sprintf(": %d broker(s)", z.Brokers)
+			if z.LeaderPartitions > 0 {
+				label += fmt.Sprintf(", %d leader(s)", z.LeaderPartitions)
+			}
+			zones = append(zones, label)
+		}
+		consumers := "—"
+		if c.ConsumerMembers > 0 {
+			consumers = fmt.Sprintf("%d / %d", c.RackAwareMembers, c.ConsumerMembers)
+		}
+		consume, monthly := fmt.Sprintf("%.1f MB/s", c.ConsumeMBps), fmt.Sprintf("%.0f GB · $%s", c.MonthlyCrossAZGB, formatUSDWithCommas(c.MonthlyCrossAZUSD))
+		if c.ConsumeMetricsMissing {
+			consume, monthly = "_no metrics_", "—"
+			unmetered = append(unmetered, name)
+		}
+		if c.CrossAZFraction > 0 && (c.FetchFromFollower == FetchFromFollowerLeaderOnly || c.FetchFromFollower == FetchFromFollowerPartial) {
+			leaderOnly = append(leaderOnly, name)
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %.0f%% | %s | %s |\n",
+			name, strings.Join(zones, "<br>"), fetchFromFollowerLabel(c.FetchFromFollower), consumers, c.CrossAZFraction*100, consume, monthly)
+	}
+	b.WriteString("\n")
+	if len(leaderOnly) > 0 {
+		fmt.Fprintf(b, "**Consumers fetch across zones:** consumers of %s read partition leaders in other zones. Setting the rack-aware replica selector on the brokers and `client.rack` on the consumers removes most of this traffic; weigh it against the target's networking costs in the migration business case.\n\n", strings.Join(leaderOnly, ", "))
+	}
+	for _, c := range ct.Clusters {
+		if len(c.UnmatchedRacks) == 0 {
+			continue
+		}
+		racks := make([]string, len(c.UnmatchedRacks))
+		for i, rack := range c.UnmatchedRacks {
+			racks[i] = fmt.Sprintf("`%s`", rack)
+		}
+		fmt.Fprintf(b, "_Consumers of `%s` set `client.rack` to %s, which names no broker rack; on MSK the broker rack is the zone ID (e.g. `use1-az1`), not the zone name._\n\n", c.ClusterID, strings.Join(racks, ", "))
+	}
+	if len(unmetered) > 0 {
+		fmt.Fprintf(b, "_No BytesOutPerSec metrics for %s: the cross-AZ cost is unknown. Run `kcp discover` with metrics to fill it in._\n\n", strings.Join(unmetered, ", "))
+	}
+}
+
+func fetchFromFollowerLabel(f FetchFromFollower) string {
+	switch f {
+	case FetchFromFollowerRackAware:
+		return "from follower in own zone"
+	case FetchFromFollowerPartial:
+		return "partly from followers"
+	case FetchFromFollowerLeaderOnly:
+		return "from leaders"
+	default:
+		return "_unknown_"
+	}
+}
+
+// ----- §migration waves -----
+
+// writeMigrationWaves renders the fleet's waves in migration order,
+// one row per wave.
+func writeMigrationWaves(b *bytes.Buffer, mw *MigrationWavesSection, section int) {
+	if mw == nil || len(mw.Waves) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Migration Waves\n\n", section)
+	b.WriteString("The fleet's clusters batched into waves, smallest first, so the first wave is a pilot that proves the links, networking and client cutover before the larger clusters follow. Clusters owned by one team (`migration_waves.teams` in `plan-inputs.yaml`) stay in the same wave so the team's applications cut over once.\n\n")
+	byEnvironment := slices.ContainsFunc(mw.Waves, func(w MigrationWave) bool { return w.Environment != "" })
+	if byEnvironment {
+		b.WriteString("Waves are planned per business environment (`kcp state classify`): dev first, then stage, then prod, so each environment's cutover is rehearsed in the one before it. A wave never mixes environments.\n\n")
+	}
+	fmt.Fprintf(b, "_Up to %d clusters per wave. Sync estimates assume the clusters of a wave share %g MB/s of link bandwidth and exclude live ingress._\n\n", mw.MaxClustersPerWave, mw.LinkBandwidthMBps)
+	if byEnvironment {
+		b.WriteString("| Wave | Environment | Cutover window | Clusters | Topics | Data | Initial sync |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+	} else {
+		b.WriteString("| Wave | Cutover window | Clusters | Topics | Data | Initial sync |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+	}
+	scheduled := false
+	for _, w := range mw.Waves {
+		window := "_unscheduled_"
+		if w.CutoverWindow != "" {
+			window = escapeMarkdownTableCell(w.CutoverWindow)
+			scheduled = true
+		}
+		names := make([]string, 0, len(w.Clusters))
+		topics := 0
+		for _, c := range w.Clusters {
+			name := fmt.Sprintf("`%s`", escapeMarkdownTableCell(c.ClusterID))
+			if c.Team != "" {
+				name += fmt.Sprintf(" (%s)", escapeMarkdownTableCell(c.Team))
+			}
+			names = append(names, name)
+			topics += c.Topics
+		}
+		sync := "—"
+		if w.TotalBytes > 0 {
+			sync = formatSyncDuration(w.EstimatedSyncSeconds)
+		}
+		waveCell := fmt.Sprintf("%d", w.Wave)
+		if byEnvironment {
+			waveCell += " | " + environmentLabel(w.Environment)
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %d | %s | %s |\n",
+			waveCell, window, strings.Join(names, ", "), topics, formatBytesHuman(float64(w.TotalBytes)), sync)
+	}
+	b.WriteString("\n")
+	for _, w := range mw.Waves {
+		if w.OversizedTeam != "" {
+			fmt.Fprintf(b, "_Wave %d keeps all of team %s's clusters together, above the %d-cluster limit._\n\n", w.Wave, w.OversizedTeam, mw.MaxClustersPerWave)
+		}
+	}
+	if !scheduled {
+		b.WriteString("_No cutover windows declared; list them in order under `migration_waves.cutover_windows` in `plan-inputs.yaml` to schedule the waves._\n\n")
+	}
+	b.WriteString("Within a wave, create each cluster's mirror topics largest first (see §Topic Data Volume), and start the next wave's links once the current wave has cut over.\n\n")
+}
+
+// environmentLabel renders a business environment, marking clusters
+// no classification reached.
+func environmentLabel(env string) string {
+	if env == "" {
+		return "_unclassified_"
+	}
+	return escapeMarkdownTableCell(env)
+}
+
+// ----- §replication -----
+
+// writeReplication renders the MSK Replicator flows with their
+// Confluent equivalent, the topology warnings, and the configuration
+// to create for each flow.
+func writeReplication(b *bytes.Buffer, rs *ReplicationSection, section int) {
+	if rs == nil || len(rs.Flows) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Replication\n\n", section)
+	b.WriteString("MSK Replicators discovered in the scanned regions, each replication flow mapped to the Confluent mechanism that replaces it once both clusters run on Confluent Cloud. Cluster links carry the flow's topic naming over as `cluster.link.prefix`; flows with a reverse flow between the same clusters become one bidirectional link.\n\n")
+	if len(rs.Findings) > 0 {
+		for _, f := range rs.Findings {
+			fmt.Fprintf(b, "- %s (%s): %s\n", replicationFindingLabel(f.Kind), strings.Join(quoteAll(f.Replicators), ", "), f.Detail)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("| Replicator | Source → Target | Topics | Topic naming | Confluent equivalent |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, f := range rs.Flows {
+		naming := "identical"
+		if f.TopicPrefix != "" {
+			naming = fmt.Sprintf("prefix `%s`", f.TopicPrefix)
+		}
+		fmt.Fprintf(b, "| `%s` (%s) | %s → %s | %s | %s | %s |\n",
+			f.Replicator, f.Region, f.SourceCluster, f.TargetCluster,
+			escapeMarkdownTableCell(strings.Join(quoteAll(f.Topics), ", ")), naming, replicationEquivalentLabel(f.Equivalent))
+	}
+	b.WriteString("\n")
+	for _, f := range rs.Flows {
+		fmt.Fprintf(b, "**%s: %s → %s**\n\n", f.Replicator, f.SourceCluster, f.TargetCluster)
+		if len(f.Config) > 0 {
+			fmt.Fprintf(b, "```properties\n%s\n```\n\n", strings.Join(f.Config, "\n"))
+		}
+		for _, note := range f.Notes {
+			fmt.Fprintf(b, "- %s\n", note)
+		}
+		if len(f.Notes) > 0 {
+			b.WriteString("\n")
+		}
+	}
+}
+
+// writeClientAccess renders, per cluster, the client access paths
+// beyond the cluster's own VPC and the steps that recreate them on
+// Confluent Cloud.
+func writeClientAccess(b *bytes.Buffer, ca *ClientAccessSection, section int) {
+	if ca == nil || len(ca.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Client Access Paths\n\n", section)
+	b.WriteString("Clients that reach an MSK cluster from outside its VPC — over multi-VPC private connectivity (one MSK VPC connection per client VPC) or over public endpoints. Confluent Cloud doesn't carry these over: each path has to be recreated on the cluster's networking before its clients cut over.\n\n")
+	for _, c := range ca.Clusters {
+		fmt.Fprintf(b, "### %s\n\n", c.ClusterID)
+		multiVPC := "disabled"
+		if len(c.MultiVPCAuth) > 0 {
+			multiVPC = "enabled (" + strings.Join(c.MultiVPCAuth, ", ") + ")"
+		}
+		public := "off"
+		if c.PublicAccess {
+			public = "on"
+		}
+		fmt.Fprintf(b, "Multi-VPC connectivity: %s · Public access: %s", multiVPC, public)
+		if c.Networking != "" {
+			fmt.Fprintf(b, " · Confluent Cloud networking: %s", networkingLabel(c.Networking))
+		}
+		b.WriteString("\n\n")
+		if len(c.Connections) > 0 {
+			b.WriteString("| Client account | Auth | State | VPC connection |\n")
+			b.WriteString("|---|---|---|---|\n")
+			for _, conn := range c.Connections {
+				state := conn.State
+				if !conn.Active {
+					state += " (nothing to recreate)"
+				}
+				fmt.Fprintf(b, "| `%s` | %s | %s | `%s` |\n", conn.Owner, conn.Authentication, state, conn.Arn)
+			}
+			b.WriteString("\n")
+		}
+		for _, step := range c.Steps {
+			fmt.Fprintf(b, "- %s\n", step)
+		}
+		if len(c.Steps) > 0 {
+			b.WriteString("\n")
+		}
+	}
+}
+
+// ----- §cross-account access -----
+
+// writeCrossAccountAccess renders, per cluster, the cluster policy
+// grants to principals outside the cluster's account and what replaces
+// each on Confluent Cloud.
+func writeCrossAccountAccess(b *bytes.Buffer, ca *CrossAccountAccessSection, section int) {
+	if ca == nil || len(ca.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Cross-Account Access\n\n", section)
+	b.WriteString("Principals outside each cluster's AWS account that its MSK cluster policy allows — other accounts connecting over multi-VPC private connectivity or reading and writing with IAM, AWS services, and anonymous access. Cluster policies don't carry over to Confluent Cloud: each integration needs an equivalent binding there, or an explicit decision to sunset it before cutover.\n\n")
+	for _, c := range ca.Clusters {
+		fmt.Fprintf(b, "### %s\n\n", c.ClusterID)
+		if c.ParseError != "" {
+			fmt.Fprintf(b, "_The cluster policy could not be parsed (%s); review it by hand with `aws kafka get-cluster-policy`._\n\n", c.ParseError)
+		}
+		if len(c.Grants) == 0 {
+			continue
+		}
+		b.WriteString("| Principal | Account | Actions | Conditions | Confluent Cloud equivalent |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, g := range c.Grants {
+			account := g.Account
+			if account == "" {
+				account = "—"
+			}
+			conditions := "—"
+			if len(g.Conditions) > 0 {
+				conditions = "`" + escapeMarkdownTableCell(strings.Join(g.Conditions, "`, `")) + "`"
+			}
+			fmt.Fprintf(b, "| `%s` (%s) | %s | `%s` | %s | %s |\n", escapeMarkdownTableCell(g.Principal), g.Kind, account, escapeMarkdownTableCell(strings.Join(g.Actions, "`, `")), conditions, g.Equivalent)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// ----- §migration semantics -----
+
+// maxCompactedTopicRows caps the compacted-topic table per cluster; the
+// JSON plan keeps the full list.
+const maxCompactedTopicRows = 25
+
+// writeMigrationSemantics renders, per cluster, the compacted topics,
+// the evidence of transactional producers and the static-membership
+// consumer groups, followed by the handling each needs at cutover.
+func writeMigrationSemantics(b *bytes.Buffer, ms *MigrationSemanticsSection, section int) {
+	if ms == nil || len(ms.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Compaction, Transactions and Static Membership\n\n", section)
+	b.WriteString("Workloads whose guarantees depend on more than the records themselves. A cluster link mirrors every record at its source offset, but not the state around it: the producer IDs and epochs behind exactly-once delivery, or the members a consumer group is waiting on. Transactions are only visible indirectly — the transaction coordinator's `__transaction_state` log and the ACLs or IAM permissions on transactional IDs — so treat the list as the principals to ask, not a complete inventory. Static membership is read from the consumer groups `kcp scan clusters` described; brokers report it only to clients speaking Kafka 2.4 or later.\n\n")
+
+	var anyCompacted, anyTransactions, anyStatic bool
+	for _, c := range ms.Clusters {
+		fmt.Fprintf(b, "**%s**\n\n", c.ClusterID)
+
+		if len(c.CompactedTopics) > 0 {
+			anyCompacted = true
+			fmt.Fprintf(b, "_%d compacted topic(s):_\n\n", len(c.CompactedTopics))
+			b.WriteString("| Topic | cleanup.policy | Partitions |\n")
+			b.WriteString("|---|---|---|\n")
+			for i, t := range c.CompactedTopics {
+				if i == maxCompactedTopicRows {
+					break
+				}
+				fmt.Fprintf(b, "| `%s` | `%s` | %d |\n", escapeMarkdownTableCell(t.Topic), t.CleanupPolicy, t.Partitions)
+			}
+			if extra := len(c.CompactedTopics) - maxCompactedTopicRows; extra > 0 {
+				fmt.Fprintf(b, "\n_…and %d more; the JSON plan lists them all._\n", extra)
+			}
+			b.WriteString("\n")
+		}
+
+		if tx := c.Transactions; tx != nil {
+			anyTransactions = true
+			if tx.TransactionStateTopic {
+				fmt.Fprintf(b, "_Transactional producers: `__transaction_state` exists (%d partitions), so producers have initialized transactions on this cluster._\n\n", tx.TransactionStatePartitions)
+			} else {
+				b.WriteString("_Transactional producers: principals are allowed to use transactional IDs, but `__transaction_state` doesn't exist yet — no producer has initialized transactions._\n\n")
+			}
+			if len(tx.Grants) > 0 {
+				b.WriteString("| Principal | Transactional ID | Granted by |\n")
+				b.WriteString("|---|---|---|\n")
+				for _, g := range tx.Grants {
+					id := "`" + escapeMarkdownTableCell(g.TransactionalID) + "`"
+					if g.PatternType != "" && g.PatternType != "Literal" {
+						id += " (" + strings.ToLower(g.PatternType) + ")"
+					}
+					fmt.Fprintf(b, "| `%s` | %s | %s |\n", escapeMarkdownTableCell(g.Principal), id, transactionalGrantSourceLabel(g.Source))
+				}
+				b.WriteString("\n")
+			}
+		}
+
+		if len(c.StaticMembershipGroups) > 0 {
+			anyStatic = true
+			b.WriteString("| Consumer group | State | Members | Static instance IDs |\n")
+			b.WriteString("|---|---|---|---|\n")
+			for _, g := range c.StaticMembershipGroups {
+				fmt.Fprintf(b, "| `%s` | %s | %d | %s |\n", escapeMarkdownTableCell(g.GroupID), g.State, g.Members, "`"+escapeMarkdownTableCell(strings.Join(g.StaticInstanceIDs, "`, `"))+"`")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("**Before cutover:**\n\n")
+	if anyCompacted {
+		b.WriteString("- **Compacted topics** — mirror them with the cluster link rather than re-producing them: the mirror keeps the source offsets, including the gaps compaction left, which consumer offsets and Kafka Streams state stores depend on. Timestamp-based offset translation is approximate here, since the record a group last consumed may already be compacted away.\n")
+	}
+	if anyTransactions {
+		b.WriteString("- **Transactional producers** — producer IDs, epochs and open transactions are not migrated. Stop these producers and let in-flight transactions commit or reach `transaction.timeout.ms` before promoting the mirror topics, then restart them against Confluent Cloud, where `initTransactions` starts a new epoch. Recreate the transactional-ID ACLs (and `IdempotentWrite` on the cluster) for their principals, and move exactly-once read-process-write applications in one step, since they commit consumer offsets inside the transaction.\n")
+	}
+	if anyStatic {
+		b.WriteString("- **Static membership** — a stopped static member stays in its group until `session.timeout.ms` expires, so the source group isn't empty right after shutdown and offset sync or `kcp migration offsets apply` can't take over the group yet. Wait out the session timeout, or remove the members with the Admin API's `removeMembersFromConsumerGroup` (KIP-345), before cutting consumers over, and keep each `group.instance.id` unique within its group on the destination.\n")
+	}
+	b.WriteString("\n")
+}
+
+func transactionalGrantSourceLabel(s TransactionalGrantSource) string {
+	switch s {
+	case TransactionalGrantACL:
+		return "ACL"
+	case TransactionalGrantIAM:
+		return "IAM policy"
+	default:
+		return string(s)
+	}
+}
+
+// ----- §kafka credentials -----
+
+// writeKafkaCredentials renders one row per Secrets Manager secret
+// holding a Kafka credential, then what re-issuing it as a Confluent
+// Cloud API key involves.
+func writeKafkaCredentials(b *bytes.Buffer, kc *KafkaCredentialsSection, section int) {
+	if kc == nil || len(kc.Secrets) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Kafka Credentials to Re-issue\n\n", section)
+	b.WriteString("Secrets Manager secrets holding Kafka credentials — MSK SCRAM secrets and secrets tagged for Kafka or MSK — found by `kcp discover --scan-secrets`. Confluent Cloud authenticates clients with API keys, so none of these credentials carry over: each needs a service account and API key on the destination, stored where its consumers read it today. Only metadata was read, never secret values. Consumers are the principals the secret's resource policy allows; principals granted `secretsmanager:GetSecretValue` through their own IAM policies are not listed.\n\n")
+	b.WriteString("| Secret | Region | Found by | SCRAM clusters | Rotation | Consumers | Last accessed |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	anyRotation := false
+	for _, s := range kc.Secrets {
+		foundBy := make([]string, 0, len(s.MatchedBy))
+		for _, m := range s.MatchedBy {
+			foundBy = append(foundBy, kafkaSecretMatchLabel(m))
+		}
+		clusters := "—"
+		if len(s.ScramClusters) > 0 {
+			clusters = escapeMarkdownTableCell(strings.Join(s.ScramClusters, ", "))
+		}
+		rotation := "off"
+		if s.RotationEnabled {
+			anyRotation = true
+			switch {
+			case s.RotationSchedule != "":
+				rotation = "`" + escapeMarkdownTableCell(s.RotationSchedule) + "`"
+			case s.RotationAfterDays > 0:
+				rotation = fmt.Sprintf("every %d days", s.RotationAfterDays)
+			default:
+				rotation = "on"
+			}
+		}
+		consumers := "—"
+		if len(s.Consumers) > 0 {
+			consumers = "`" + escapeMarkdownTableCell(strings.Join(s.Consumers, "`, `")) + "`"
+		}
+		lastAccessed := "—"
+		if s.LastAccessedDate != nil {
+			lastAccessed = s.LastAccessedDate.Format("2006-01-02")
+		}
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s | %s | %s |\n", escapeMarkdownTableCell(s.Name), s.Region, strings.Join(foundBy, ", "), clusters, rotation, consumers, lastAccessed)
+	}
+	b.WriteString("\n")
+	if anyRotation {
+		b.WriteString("_Rotation Lambdas written for MSK SCRAM don't rotate Confluent Cloud API keys. Replace them with a rotation that creates a new API key and deletes the old one, or turn rotation off on the re-issued secrets until one exists._\n\n")
+	}
+}
+
+// ----- §connector support -----
+
+// writeConnectorSupport renders the fleet-wide counts of connectors by
+// Confluent Cloud status, then one table per cluster.
+func writeConnectorSupport(b *bytes.Buffer, cs *ConnectorSupportSection, section int) {
+	if cs == nil || len(cs.Clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %d. Connector Support\n\n", section)
+	b.WriteString("Each MSK Connect and self-managed connector, looked up by its `connector.class` in kcp's catalog of Confluent Cloud fully-managed connectors. Supported connectors have a fully-managed equivalent; partially-supported ones have one with gaps, or are deprecated classes that move to a successor plugin; self-managed-only ones keep running on a Connect cluster you operate, or as custom connectors. Classes missing from the catalog count as self-managed-only — check them against the Confluent Cloud connector list.\n\n")
+	b.WriteString("| Status | Connectors |\n")
+	b.WriteString("|---|---|\n")
+	fmt.Fprintf(b, "| Supported | %d |\n", cs.Supported)
+	fmt.Fprintf(b, "| Partially supported | %d |\n", cs.PartiallySupported)
+	fmt.Fprintf(b, "| Self-managed only | %d |\n\n", cs.SelfManagedOnly)
+	for _, c := range cs.Clusters {
+		fmt.Fprintf(b, "**%s**\n\n", c.ClusterID)
+		b.WriteString("| Connector | Runs on | Class | Fully-managed plugin | Status | Note |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+		for _, conn := range c.Connectors {
+			class, plugin, note := "—", "—", "—"
+			if conn.ConnectorClass != "" {
+				class = "`" + escapeMarkdownTableCell(conn.ConnectorClass) + "`"
+			}
+			if conn.Plugin != "" {
+				plugin = "`" + conn.Plugin + "`"
+			}
+			if conn.Note != "" {
+				note = escapeMarkdownTableCell(conn.Note)
+			}
+			fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s | %s |\n", escapeMarkdownTableCell(conn.Name), connectorSourceLabel(conn.Source), class, plugin, conn.Availability, note)
+		}
+		b.WriteString("\n")
+	}
+}
+
+func replicationEquivalentLabel(e ReplicationEquivalent) string {
+	switch e {
+	case ReplicationClusterLink:
+		return "Cluster link"
+	case ReplicationClusterLinkBidirectional:
+		return "Bidirectional cluster link"
+	case ReplicationConfluentReplicator:
+		return "Confluent Replicator"
+	default:
+		return string(e)
+	}
+}
+
+func replicationFindingLabel(k ReplicationFindingKind) string {
+	switch k {
+	case ReplicationLoop:
+		return "🔁 **Replication loop**"
+	case ReplicationRenameConflict:
+		return "⚠️ **Conflicting topic names**"
+	default:
+		return string(k)
+	}
+}
+
 // formatUSDWithCommas renders a dollar amount with thousands
 // separators and 2 decimal places (`1234567.89` → `1,234,567.89`).
 // Keeps amounts in §Cost Reconciliation scannable at a glance.
@@ -0,0 +1,414 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// regexMetaChars are the Java regex characters that keep an MSK
+// Replicator topic or group pattern from being a plain name. '.' is
+// left out: it is common in topic names and, as a wildcard, still
+// matches the literal name.
+const regexMetaChars = `^$|?*+()[]{}\`
+
+// linkFilter is one entry of a cluster link's topic or group filter
+// JSON.
+type linkFilter struct {
+	Name        string `json:"name"`
+	PatternType string `json:"patternType"`
+	FilterType  string `json:"filterType"`
+}
+
+// replicationEdge is one flow's source → target cluster pair, keyed
+// on cluster ARN, used for the topology checks.
+type replicationEdge struct {
+	replicator string
+	source     string
+	target     string
+	prefix     string
+	topics     []string
+}
+
+// detectReplication maps every MSK Replicator flow in the state to the
+// Confluent mechanism that replaces it once both clusters run on
+// Confluent Cloud, and checks the topology for replication loops and
+// conflicting topic renames. Returns nil when no replicators were
+// discovered so the renderer omits the section.
+func detectReplication(state report.ProcessedState) *ReplicationSection {
+	names := map[string]string{}
+	for _, c := range collectClusters(state) {
+		names[c.Arn] = c.Name
+	}
+
+	type replicatorInRegion struct {
+		region     string
+		replicator kafka.DescribeReplicatorOutput
+	}
+	var replicators []replicatorInRegion
+	for _, src := range state.Sources {
+		if src.MSKData == nil {
+			continue
+		}
+		for _, region := range src.MSKData.Regions {
+			for _, r := range region.Replicators {
+				replicators = append(replicators, replicatorInRegion{region: region.Name, replicator: r})
+			}
+		}
+	}
+	if len(replicators) == 0 {
+		return nil
+	}
+
+	var edges []replicationEdge
+	var infos []kafkatypes.ReplicationInfoDescription
+	var regions []string
+	pairs := map[[2]string]bool{}
+	for _, r := range replicators {
+		aliases := replicatorClusterAliases(r.replicator)
+		for _, info := range r.replicator.ReplicationInfoList {
+			edge := replicationEdge{
+				replicator: aws.ToString(r.replicator.ReplicatorName),
+				source:     aliases[aws.ToString(info.SourceKafkaClusterAlias)],
+				target:     aliases[aws.ToString(info.TargetKafkaClusterAlias)],
+				prefix:     replicatedTopicPrefix(info),
+			}
+			if info.TopicReplication != nil {
+				edge.topics = info.TopicReplication.TopicsToReplicate
+			}
+			edges = append(edges, edge)
+			infos = append(infos, info)
+			regions = append(regions, r.region)
+			pairs[[2]string{edge.source, edge.target}] = true
+		}
+	}
+
+	flows := make([]ReplicationFlow, 0, len(edges))
+	for i, edge := range edges {
+		bidirectional := pairs[[2]string{edge.target, edge.source}]
+		flows = append(flows, mapReplicationFlow(regions[i], edge, infos[i], bidirectional, names))
+	}
+	sort.SliceStable(flows, func(i, j int) bool {
+		if flows[i].Replicator != flows[j].Replicator {
+			return flows[i].Replicator < flows[j].Replicator
+		}
+		return flows[i].SourceCluster < flows[j].SourceCluster
+	})
+
+	findings := append(detectReplicationLoops(edges, names), detectRenameConflicts(edges, names)...)
+	return &ReplicationSection{Flows: flows, Findings: findings}
+}
+
+// replicatorClusterAliases maps each cluster alias a replicator uses
+// in its replication info to the MSK cluster ARN.
+func replicatorClusterAliases(r kafka.DescribeReplicatorOutput) map[string]string {
+	aliases := map[string]string{}
+	for _, kc := range r.KafkaClusters {
+		if kc.AmazonMskCluster == nil {
+			continue
+		}
+		aliases[aws.ToString(kc.KafkaClusterAlias)] = aws.ToString(kc.AmazonMskCluster.MskClusterArn)
+	}
+	return aliases
+}
+
+// replicatedTopicPrefix is the prefix MSK Replicator puts in front of
+// replicated topic names: `<source alias>.` unless the flow keeps
+// names IDENTICAL. Replicators created before the setting existed
+// report no name configuration and use the prefix.
+func replicatedTopicPrefix(info kafkatypes.ReplicationInfoDescription) string {
+	if info.TopicReplication != nil && info.TopicReplication.TopicNameConfiguration != nil &&
+		info.TopicReplication.TopicNameConfiguration.Type == kafkatypes.ReplicationTopicNameConfigurationTypeIdentical {
+		return ""
+	}
+	return aws.ToString(info.SourceKafkaClusterAlias) + "."
+}
+
+func mapReplicationFlow(region string, edge replicationEdge, info kafkatypes.ReplicationInfoDescription, bidirectional bool, names map[string]string) ReplicationFlow {
+	flow := ReplicationFlow{
+		Replicator:    edge.replicator,
+		Region:        region,
+		SourceCluster: replicationClusterName(edge.source, names),
+		TargetCluster: replicationClusterName(edge.target, names),
+		TopicPrefix:   edge.prefix,
+		Equivalent:    ReplicationClusterLink,
+	}
+	for _, arn := range []string{edge.source, edge.target} {
+		if _, ok := names[arn]; !ok {
+			flow.Notes = append(flow.Notes, fmt.Sprintf("`%s` was not discovered, so its Confluent Cloud cluster isn't part of this plan — run `kcp discover` in its region.", replicationClusterName(arn, names)))
+		}
+	}
+
+	topics := info.TopicReplication
+	if topics == nil {
+		topics = &kafkatypes.TopicReplication{}
+	}
+	flow.Topics = topics.TopicsToReplicate
+	flow.ExcludedTopics = topics.TopicsToExclude
+	if topics.StartingPosition != nil {
+		flow.StartingPosition = string(topics.StartingPosition.Type)
+	}
+	groups := info.ConsumerGroupReplication
+	if groups != nil {
+		flow.ConsumerGroups = groups.ConsumerGroupsToReplicate
+	}
+
+	switch {
+	case topics.StartingPosition != nil && topics.StartingPosition.Type == kafkatypes.ReplicationStartingPositionTypeLatest:
+		flow.Equivalent = ReplicationConfluentReplicator
+		flow.Config = replicatorConnectorConfig(flow, topics)
+		flow.Notes = append(flow.Notes, "The replicator starts from the latest offset. Mirror topics always copy from the earliest retained offset, so this flow maps to Confluent Replicator — or to a cluster link if copying the retained history is acceptable.")
+		return flow
+	case bidirectional:
+		flow.Equivalent = ReplicationClusterLinkBidirectional
+		flow.Config = append(flow.Config, "link.mode=BIDIRECTIONAL")
+	}
+
+	if edge.prefix != "" {
+		flow.Config = append(flow.Config, "cluster.link.prefix="+edge.prefix)
+	}
+	if aws.ToBool(topics.DetectAndCopyNewTopics) {
+		filters, unmapped := linkFilters(topics.TopicsToReplicate, topics.TopicsToExclude)
+		flow.Config = append(flow.Config,
+			"auto.create.mirror.topics.enable=true",
+			"auto.create.mirror.topics.filters="+linkFiltersJSON("topicFilters", filters))
+		flow.Notes = append(flow.Notes, unmappedPatternNotes("topic", unmapped)...)
+	} else {
+		flow.Notes = append(flow.Notes, "The replicator doesn't pick up new topics, so create the mirror topics explicitly (`kcp create-asset migrate-topics`) instead of enabling auto-create.")
+	}
+	if groups != nil && aws.ToBool(groups.SynchroniseConsumerGroupOffsets) {
+		filters, unmapped := linkFilters(groups.ConsumerGroupsToReplicate, groups.ConsumerGroupsToExclude)
+		flow.Config = append(flow.Config,
+			"consumer.offset.sync.enable=true",
+			"consumer.offset.group.filters="+linkFiltersJSON("groupFilters", filters))
+		flow.Notes = append(flow.Notes, unmappedPatternNotes("consumer group", unmapped)...)
+	}
+	if aws.ToBool(topics.CopyAccessControlListsForTopics) {
+		flow.Config = append(flow.Config, "acl.sync.enable=true")
+		flow.Notes = append(flow.Notes, "ACL sync also needs `acl.filters` naming the principals to copy; topic ACLs of MSK IAM principals don't carry over and are recreated for service accounts.")
+	}
+	if topics.CopyTopicConfigurations != nil && !*topics.CopyTopicConfigurations {
+		flow.Notes = append(flow.Notes, "The replicator doesn't copy topic configurations, but mirror topics always mirror them from the source topic.")
+	}
+	if info.TargetCompressionType != "" && info.TargetCompressionType != kafkatypes.TargetCompressionTypeNone {
+		flow.Notes = append(flow.Notes, fmt.Sprintf("The replicator recompresses records with %s; mirror topics keep the source batches byte-for-byte.", info.TargetCompressionType))
+	}
+	return flow
+}
+
+// replicatorConnectorConfig is the Confluent Replicator connector
+// configuration for a flow a cluster link can't reproduce.
+func replicatorConnectorConfig(flow ReplicationFlow, topics *kafkatypes.TopicReplication) []string {
+	config := []string{
+		"connector.class=io.confluent.connect.replicator.ReplicatorSourceConnector",
+		"topic.regex=" + strings.Join(topics.TopicsToReplicate, "|"),
+	}
+	if flow.TopicPrefix != "" {
+		config = append(config, "topic.rename.format="+flow.TopicPrefix+"${topic}")
+	}
+	config = append(config,
+		"src.consumer.auto.offset.reset=latest",
+		fmt.Sprintf("topic.config.sync=%t", topics.CopyTopicConfigurations == nil || *topics.CopyTopicConfigurations),
+	)
+	return config
+}
+
+func replicationClusterName(arn string, names map[string]string) string {
+	if name, ok := names[arn]; ok {
+		return name
+	}
+	// arn:aws:kafka:<region>:<account>:cluster/<name>/<uuid>
+	if parts := strings.Split(arn, "/"); len(parts) >= 2 {
+		return parts[1]
+	}
+	return arn
+}
+
+// linkFilters converts MSK Replicator include / exclude patterns (Java
+// regular expressions) to cluster link filters. Catch-all patterns
+// become `*`, plain names LITERAL and `name.*` PREFIXED filters;
+// anything else is returned as unmapped.
+func linkFilters(include, exclude []string) (filters []linkFilter, unmapped []string) {
+	add := func(patterns []string, filterType string) {
+		for _, pattern := range patterns {
+			switch {
+			case pattern == ".*" || pattern == "*":
+				filters = append(filters, linkFilter{Name: "*", PatternType: "LITERAL", FilterType: filterType})
+			case !strings.ContainsAny(pattern, regexMetaChars):
+				filters = append(filters, linkFilter{Name: pattern, PatternType: "LITERAL", FilterType: filterType})
+			case strings.HasSuffix(pattern, ".*") && !strings.ContainsAny(strings.TrimSuffix(pattern, ".*"), regexMetaChars):
+				filters = append(filters, linkFilter{Name: strings.TrimSuffix(pattern, ".*"), PatternType: "PREFIXED", FilterType: filterType})
+			default:
+				unmapped = append(unmapped, pattern)
+			}
+		}
+	}
+	add(include, "INCLUDE")
+	add(exclude, "EXCLUDE")
+	return filters, unmapped
+}
+
+func linkFiltersJSON(key string, filters []linkFilter) string {
+	if filters == nil {
+		filters = []linkFilter{}
+	}
+	data, _ := json.Marshal(map[string][]linkFilter{key: filters})
+	return string(data)
+}
+
+func unmappedPatternNotes(kind string, patterns []string) []string {
+	notes := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		notes = append(notes, fmt.Sprintf("The %s pattern `%s` is a regular expression with no cluster link filter equivalent; list the matching names explicitly.", kind, pattern))
+	}
+	return notes
+}
+
+// detectReplicationLoops reports every group of clusters that flows
+// keeping topic names identical connect in a cycle. Prefixed flows
+// can't loop: each hop renames the topic.
+func detectReplicationLoops(edges []replicationEdge, names map[string]string) []ReplicationFinding {
+	next := map[string][]string{}
+	for _, e := range edges {
+		if e.prefix == "" && e.source != e.target {
+			next[e.source] = append(next[e.source], e.target)
+		}
+	}
+	reaches := func(from, to string) bool {
+		seen := map[string]bool{}
+		stack := []string{from}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, n := range next[node] {
+				if n == to {
+					return true
+				}
+				if !seen[n] {
+					seen[n] = true
+					stack = append(stack, n)
+				}
+			}
+		}
+		return false
+	}
+
+	var findings []ReplicationFinding
+	grouped := map[string]bool{}
+	nodes := make([]string, 0, len(next))
+	for node := range next {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if grouped[node] || !reaches(node, node) {
+			continue
+		}
+		cycle := []string{node}
+		for _, other := range nodes {
+			if other != node && reaches(node, other) && reaches(other, node) {
+				cycle = append(cycle, other)
+			}
+		}
+		inCycle := map[string]bool{}
+		clusterNames := make([]string, 0, len(cycle))
+		for _, arn := range cycle {
+			grouped[arn] = true
+			inCycle[arn] = true
+			clusterNames = append(clusterNames, "`"+replicationClusterName(arn, names)+"`")
+		}
+		var replicators []string
+		for _, e := range edges {
+			if e.prefix == "" && inCycle[e.source] && inCycle[e.target] {
+				replicators = append(replicators, e.replicator)
+			}
+		}
+		findings = append(findings, ReplicationFinding{
+			Kind:        ReplicationLoop,
+			Replicators: sortedUnique(replicators),
+			Detail: fmt.Sprintf("Flows between %s keep topic names identical and form a cycle, so records would be replicated back to the cluster they came from. Prefix at least one direction; on Confluent Cloud, replace the pair with a bidirectional cluster link with `cluster.link.prefix` set.",
+				strings.Join(clusterNames, ", ")),
+		})
+	}
+	return findings
+}
+
+// detectRenameConflicts reports flows from different source clusters
+// that write the same destination topic names into one target: the
+// same prefix (or both identical) and overlapping topic patterns. A
+// mirror topic has exactly one source, so the pair can't both become
+// cluster links as they stand.
+func detectRenameConflicts(edges []replicationEdge, names map[string]string) []ReplicationFinding {
+	var findings []ReplicationFinding
+	for i := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			a, b := edges[i], edges[j]
+			if a.target != b.target || a.prefix != b.prefix || a.source == b.source {
+				continue
+			}
+			overlap, ok := topicPatternsOverlap(a.topics, b.topics)
+			if !ok {
+				continue
+			}
+			naming := "identical topic names"
+			if a.prefix != "" {
+				naming = fmt.Sprintf("the same prefix `%s`", a.prefix)
+			}
+			findings = append(findings, ReplicationFinding{
+				Kind:        ReplicationRenameConflict,
+				Replicators: sortedUnique([]string{a.replicator, b.replicator}),
+				Detail: fmt.Sprintf("`%s` and `%s` both replicate into `%s` with %s and overlapping topic patterns (`%s`), so one destination topic would receive records from two sources. Give each flow a distinct prefix; each cluster link into the target needs its own `cluster.link.prefix`.",
+					replicationClusterName(a.source, names), replicationClusterName(b.source, names), replicationClusterName(a.target, names), naming, overlap),
+			})
+		}
+	}
+	return findings
+}
+
+// topicPatternsOverlap reports a pattern both lists can match: a
+// catch-all on either side, the same pattern on both, or a plain name
+// on one side matched by a pattern on the other.
+func topicPatternsOverlap(a, b []string) (string, bool) {
+	for _, p := range a {
+		for _, q := range b {
+			switch {
+			case p == ".*" || p == "*":
+				return p, true
+			case q == ".*" || q == "*":
+				return q, true
+			case p == q:
+				return p, true
+			case !strings.ContainsAny(p, regexMetaChars) && patternMatches(q, p):
+				return p, true
+			case !strings.ContainsAny(q, regexMetaChars) && patternMatches(p, q):
+				return q, true
+			}
+		}
+	}
+	return "", false
+}
+
+func patternMatches(pattern, name string) bool {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	return err == nil && re.MatchString(name)
+}
+
+func sortedUnique(values []string) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
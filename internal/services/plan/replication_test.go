@@ -0,0 +1,185 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ordersArn   = "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1"
+	ordersDRArn = "arn:aws:kafka:us-west-2:000000000000:cluster/orders-dr/def-2"
+	paymentsArn = "arn:aws:kafka:us-east-1:000000000000:cluster/payments/ghi-3"
+)
+
+// replicator builds a DescribeReplicator output with one flow per
+// source alias into the "target" alias.
+func replicator(name string, clusters map[string]string, infos ...kafkatypes.ReplicationInfoDescription) kafka.DescribeReplicatorOutput {
+	r := kafka.DescribeReplicatorOutput{ReplicatorName: aws.String(name), ReplicationInfoList: infos}
+	for alias, arn := range clusters {
+		r.KafkaClusters = append(r.KafkaClusters, kafkatypes.KafkaClusterDescription{
+			KafkaClusterAlias: aws.String(alias),
+			AmazonMskCluster:  &kafkatypes.AmazonMskCluster{MskClusterArn: aws.String(arn)},
+		})
+	}
+	return r
+}
+
+func replicationInfo(source, target string, naming kafkatypes.ReplicationTopicNameConfigurationType, topics ...string) kafkatypes.ReplicationInfoDescription {
+	return kafkatypes.ReplicationInfoDescription{
+		SourceKafkaClusterAlias: aws.String(source),
+		TargetKafkaClusterAlias: aws.String(target),
+		TopicReplication: &kafkatypes.TopicReplication{
+			TopicsToReplicate:      topics,
+			DetectAndCopyNewTopics: aws.Bool(true),
+			TopicNameConfiguration: &kafkatypes.ReplicationTopicNameConfiguration{Type: naming},
+		},
+	}
+}
+
+func withReplicators(state report.ProcessedState, replicators ...kafka.DescribeReplicatorOutput) report.ProcessedState {
+	state.Sources[0].MSKData.Regions[0].Replicators = replicators
+	return state
+}
+
+func replicationState(replicators ...kafka.DescribeReplicatorOutput) report.ProcessedState {
+	clusters := []report.ProcessedCluster{
+		{Name: "orders", Arn: ordersArn},
+		{Name: "orders-dr", Arn: ordersDRArn},
+		{Name: "payments", Arn: paymentsArn},
+	}
+	return withReplicators(wrapClusters(clusters...), replicators...)
+}
+
+func TestDetectReplication_NoReplicatorsReturnsNil(t *testing.T) {
+	assert.Nil(t, detectReplication(wrapClusters(report.ProcessedCluster{Name: "orders", Arn: ordersArn})))
+}
+
+func TestDetectReplication_MapsFlowToClusterLink(t *testing.T) {
+	info := replicationInfo("src", "dst", kafkatypes.ReplicationTopicNameConfigurationTypePrefixedWithSourceClusterAlias, "orders", "payments.*", "audit-(eu|us)")
+	info.TopicReplication.TopicsToExclude = []string{"orders-internal"}
+	info.TopicReplication.CopyAccessControlListsForTopics = aws.Bool(true)
+	info.ConsumerGroupReplication = &kafkatypes.ConsumerGroupReplication{
+		ConsumerGroupsToReplicate:       []string{".*"},
+		SynchroniseConsumerGroupOffsets: aws.Bool(true),
+	}
+	state := replicationState(replicator("orders-to-dr", map[string]string{"src": ordersArn, "dst": ordersDRArn}, info))
+
+	section := detectReplication(state)
+
+	require.NotNil(t, section)
+	assert.Empty(t, section.Findings)
+	require.Len(t, section.Flows, 1)
+	flow := section.Flows[0]
+	assert.Equal(t, "orders", flow.SourceCluster)
+	assert.Equal(t, "orders-dr", flow.TargetCluster)
+	assert.Equal(t, "src.", flow.TopicPrefix)
+	assert.Equal(t, ReplicationClusterLink, flow.Equivalent)
+	assert.Equal(t, []string{
+		"cluster.link.prefix=src.",
+		"auto.create.mirror.topics.enable=true",
+		`auto.create.mirror.topics.filters={"topicFilters":[{"name":"orders","patternType":"LITERAL","filterType":"INCLUDE"},{"name":"payments","patternType":"PREFIXED","filterType":"INCLUDE"},{"name":"orders-internal","patternType":"LITERAL","filterType":"EXCLUDE"}]}`,
+		"consumer.offset.sync.enable=true",
+		`consumer.offset.group.filters={"groupFilters":[{"name":"*","patternType":"LITERAL","filterType":"INCLUDE"}]}`,
+		"acl.sync.enable=true",
+	}, flow.Config)
+	require.Len(t, flow.Notes, 2)
+	assert.Contains(t, flow.Notes[0], "`audit-(eu|us)`")
+	assert.Contains(t, flow.Notes[1], "acl.filters")
+}
+
+func TestDetectReplication_LatestStartingPositionMapsToReplicator(t *testing.T) {
+	info := replicationInfo("src", "dst", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, "orders")
+	info.TopicReplication.StartingPosition = &kafkatypes.ReplicationStartingPosition{Type: kafkatypes.ReplicationStartingPositionTypeLatest}
+	state := replicationState(replicator("orders-to-dr", map[string]string{"src": ordersArn, "dst": ordersDRArn}, info))
+
+	flow := detectReplication(state).Flows[0]
+
+	assert.Equal(t, ReplicationConfluentReplicator, flow.Equivalent)
+	assert.Contains(t, flow.Config, "src.consumer.auto.offset.reset=latest")
+	assert.Contains(t, flow.Config, "topic.regex=orders")
+}
+
+func TestDetectReplication_IdenticalBidirectionalFlowsLoop(t *testing.T) {
+	clusters := map[string]string{"a": ordersArn, "b": ordersDRArn}
+	state := replicationState(
+		replicator("a-to-b", clusters, replicationInfo("a", "b", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, ".*")),
+		replicator("b-to-a", clusters, replicationInfo("b", "a", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, ".*")),
+	)
+
+	section := detectReplication(state)
+
+	require.Len(t, section.Flows, 2)
+	for _, flow := range section.Flows {
+		assert.Equal(t, ReplicationClusterLinkBidirectional, flow.Equivalent)
+		assert.Contains(t, flow.Config, "link.mode=BIDIRECTIONAL")
+	}
+	require.Len(t, section.Findings, 1)
+	assert.Equal(t, ReplicationLoop, section.Findings[0].Kind)
+	assert.Equal(t, []string{"a-to-b", "b-to-a"}, section.Findings[0].Replicators)
+	assert.Contains(t, section.Findings[0].Detail, "`orders`, `orders-dr`")
+}
+
+// Prefixed flows rename topics on every hop, so a bidirectional pair
+// can't loop.
+func TestDetectReplication_PrefixedBidirectionalFlowsDoNotLoop(t *testing.T) {
+	clusters := map[string]string{"a": ordersArn, "b": ordersDRArn}
+	state := replicationState(
+		replicator("a-to-b", clusters, replicationInfo("a", "b", kafkatypes.ReplicationTopicNameConfigurationTypePrefixedWithSourceClusterAlias, ".*")),
+		replicator("b-to-a", clusters, replicationInfo("b", "a", kafkatypes.ReplicationTopicNameConfigurationTypePrefixedWithSourceClusterAlias, ".*")),
+	)
+
+	assert.Empty(t, detectReplication(state).Findings)
+}
+
+func TestDetectReplication_ConflictingTopicNames(t *testing.T) {
+	state := replicationState(
+		replicator("orders-to-dr", map[string]string{"src": ordersArn, "dst": ordersDRArn},
+			replicationInfo("src", "dst", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, "orders", "refunds")),
+		replicator("payments-to-dr", map[string]string{"src": paymentsArn, "dst": ordersDRArn},
+			replicationInfo("src", "dst", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, "ord.*")),
+	)
+
+	section := detectReplication(state)
+
+	require.Len(t, section.Findings, 1)
+	finding := section.Findings[0]
+	assert.Equal(t, ReplicationRenameConflict, finding.Kind)
+	assert.Equal(t, []string{"orders-to-dr", "payments-to-dr"}, finding.Replicators)
+	assert.Contains(t, finding.Detail, "identical topic names")
+	assert.Contains(t, finding.Detail, "(`orders`)")
+}
+
+func TestDetectReplication_UndiscoveredClusterIsNoted(t *testing.T) {
+	otherArn := "arn:aws:kafka:eu-west-1:000000000000:cluster/analytics/jkl-4"
+	state := replicationState(replicator("orders-to-analytics", map[string]string{"src": ordersArn, "dst": otherArn},
+		replicationInfo("src", "dst", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, "orders")))
+
+	flow := detectReplication(state).Flows[0]
+
+	assert.Equal(t, "analytics", flow.TargetCluster)
+	assert.Contains(t, flow.Notes[0], "`analytics` was not discovered")
+}
+
+func TestWriteReplication_RendersFlowsFindingsAndConfig(t *testing.T) {
+	clusters := map[string]string{"a": ordersArn, "b": ordersDRArn}
+	section := detectReplication(replicationState(
+		replicator("a-to-b", clusters, replicationInfo("a", "b", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, "orders|refunds")),
+		replicator("b-to-a", clusters, replicationInfo("b", "a", kafkatypes.ReplicationTopicNameConfigurationTypeIdentical, "orders")),
+	))
+
+	var b bytes.Buffer
+	writeReplication(&b, section, 9)
+	out := b.String()
+
+	assert.Contains(t, out, "## 9. Replication")
+	assert.Contains(t, out, "🔁 **Replication loop** (`a-to-b`, `b-to-a`)")
+	assert.Contains(t, out, "| `a-to-b` (us-east-1) | orders → orders-dr | `orders\\|refunds` | identical | Bidirectional cluster link |")
+	assert.Contains(t, out, "```properties\nlink.mode=BIDIRECTIONAL\n")
+}
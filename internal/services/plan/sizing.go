@@ -3,7 +3,9 @@ package plan
 import (
 	"fmt"
 	"math"
+	"sort"
 
+	"github.com/confluentinc/kcp/internal/services/environment"
 	"github.com/confluentinc/kcp/internal/services/report"
 	"github.com/confluentinc/kcp/internal/types"
 )
@@ -121,6 +123,40 @@ func computeClusterSizing(c report.ProcessedCluster, cfg *PlanConfig, inputs Pla
 	}
 }
 
+// sizingByEnvironment totals the fleet's sizing per business
+// environment, in migration order (dev, stage, prod, then
+// unclassified). Returns nil when no cluster is classified, since one
+// unclassified row would only repeat the fleet total.
+func sizingByEnvironment(sizings []ClusterSizing) []EnvironmentSizing {
+	byEnv := map[string]*EnvironmentSizing{}
+	classified := false
+	for _, s := range sizings {
+		e, ok := byEnv[s.Environment]
+		if !ok {
+			e = &EnvironmentSizing{Environment: s.Environment}
+			byEnv[s.Environment] = e
+		}
+		e.Clusters++
+		e.SizedInMBps += s.SizedInMBps
+		e.SizedOutMBps += s.SizedOutMBps
+		e.FinalECKU += s.FinalECKU
+		if s.Degraded {
+			e.Degraded++
+		}
+		classified = classified || s.Environment != ""
+	}
+	if !classified {
+		return nil
+	}
+
+	out := make([]EnvironmentSizing, 0, len(byEnv))
+	for _, e := range byEnv {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool { return environment.Less(out[i].Environment, out[j].Environment) })
+	return out
+}
+
 // normalizePercentile maps the customer's sizing_percentile input
 // (p95 | p99 | max) to the canonical lowercase form. Accepts legacy
 // uppercase variants (`P95`, `P99`) for back-compat with older
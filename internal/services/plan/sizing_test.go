@@ -107,3 +107,18 @@ func TestComputeClusterSizing_PartitionWinner(t *testing.T) {
 	// partition ratio = 100000 / 3000 = 33.33; CEIL(33.33 * 1.30) = 44
 	assert.Equal(t, 44, s.SizedECKU)
 }
+
+func TestSizingByEnvironment(t *testing.T) {
+	assert.Nil(t, sizingByEnvironment([]ClusterSizing{{FinalECKU: 2}}), "no rollup when nothing is classified")
+
+	got := sizingByEnvironment([]ClusterSizing{
+		{Environment: "prod", SizedInMBps: 10, SizedOutMBps: 20, FinalECKU: 4},
+		{Environment: "", FinalECKU: 1, Degraded: true},
+		{Environment: "dev", SizedInMBps: 1, SizedOutMBps: 2, FinalECKU: 1},
+		{Environment: "prod", SizedInMBps: 5, SizedOutMBps: 5, FinalECKU: 2, Degraded: true},
+	})
+	require.Len(t, got, 3)
+	assert.Equal(t, EnvironmentSizing{Environment: "dev", Clusters: 1, SizedInMBps: 1, SizedOutMBps: 2, FinalECKU: 1}, got[0])
+	assert.Equal(t, EnvironmentSizing{Environment: "prod", Clusters: 2, SizedInMBps: 15, SizedOutMBps: 25, FinalECKU: 6, Degraded: 1}, got[1])
+	assert.Equal(t, EnvironmentSizing{Environment: "", Clusters: 1, FinalECKU: 1, Degraded: 1}, got[2])
+}
@@ -0,0 +1,223 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/services/environment"
+	"github.com/confluentinc/kcp/internal/services/report"
+)
+
+// waveGroup is a set of clusters that must land in the same wave: the
+// clusters of a plan-inputs team in one environment, or a single
+// cluster no team claims.
+type waveGroup struct {
+	team        string
+	environment string
+	clusters    []MigrationWaveCluster
+	bytes       int64
+}
+
+// detectMigrationWaves batches the fleet's clusters into ordered
+// migration waves. Clusters are grouped by plan-inputs team, groups
+// are ordered by environment (dev, stage, prod, then unclassified)
+// and within an environment by data volume ascending so the first
+// wave is the smallest (a pilot), and groups are packed into waves of
+// at most max_clusters_per_wave clusters that never span environments. Cutover windows are assigned to
+// waves in order. Returns nil for an empty fleet, and for a
+// single-cluster fleet unless `migration_waves` was set, since one
+// cluster is one wave.
+func detectMigrationWaves(state report.ProcessedState, cfg *PlanConfig, inputs PlanInputsResolved) (*MigrationWavesSection, []OpenQuestion) {
+	clusters := collectClusters(state)
+	var in MigrationWavesInputs
+	if inputs.Raw != nil && inputs.Raw.MigrationWaves != nil {
+		in = *inputs.Raw.MigrationWaves
+	}
+	if len(clusters) == 0 || (len(clusters) == 1 && (inputs.Raw == nil || inputs.Raw.MigrationWaves == nil)) {
+		return nil, nil
+	}
+
+	var oqs []OpenQuestion
+	bandwidth := cfg.DataVolume.LinkThroughputMBps
+	if in.LinkBandwidthMBps != nil {
+		if *in.LinkBandwidthMBps > 0 {
+			bandwidth = *in.LinkBandwidthMBps
+		} else {
+			oqs = append(oqs, invalidWaveInputOQ("link_bandwidth_mbps", fmt.Sprintf("%g", *in.LinkBandwidthMBps), fmt.Sprintf("%g MB/s", bandwidth)))
+		}
+	}
+	maxPerWave := cfg.MigrationWaves.MaxClustersPerWave
+	if in.MaxClustersPerWave != nil {
+		if *in.MaxClustersPerWave > 0 {
+			maxPerWave = *in.MaxClustersPerWave
+		} else {
+			oqs = append(oqs, invalidWaveInputOQ("max_clusters_per_wave", fmt.Sprintf("%d", *in.MaxClustersPerWave), fmt.Sprintf("%d", maxPerWave)))
+		}
+	}
+
+	groups, teamOQs := buildWaveGroups(clusters, in.Teams)
+	oqs = append(oqs, teamOQs...)
+
+	bytesPerSecond := bandwidth * bytesPerMB
+	var waves []MigrationWave
+	for _, g := range groups {
+		if len(waves) == 0 || len(waves[len(waves)-1].Clusters)+len(g.clusters) > maxPerWave || waves[len(waves)-1].Environment != g.environment {
+			waves = append(waves, MigrationWave{Wave: len(waves) + 1, Environment: g.environment})
+		}
+		w := &waves[len(waves)-1]
+		w.Clusters = append(w.Clusters, g.clusters...)
+		w.TotalBytes += g.bytes
+		if len(g.clusters) > maxPerWave {
+			w.OversizedTeam = g.team
+		}
+	}
+	for i := range waves {
+		waves[i].EstimatedSyncSeconds = float64(waves[i].TotalBytes) / bytesPerSecond
+		if i < len(in.CutoverWindows) {
+			waves[i].CutoverWindow = in.CutoverWindows[i]
+		}
+	}
+
+	if len(in.CutoverWindows) > 0 && len(waves) > len(in.CutoverWindows) {
+		unscheduled := fmt.Sprintf("Waves %d–%d are", len(in.CutoverWindows)+1, len(waves))
+		if len(waves) == len(in.CutoverWindows)+1 {
+			unscheduled = fmt.Sprintf("Wave %d is", len(waves))
+		}
+		oqs = append(oqs, OpenQuestion{
+			ID:         "migration_waves_unscheduled",
+			Title:      fmt.Sprintf("%d migration wave(s) have no cutover window", len(waves)-len(in.CutoverWindows)),
+			Body:       fmt.Sprintf("`migration_waves.cutover_windows` in `plan-inputs.yaml` lists %d window(s), but the fleet needs %d waves. %s left unscheduled in §Migration Waves.", len(in.CutoverWindows), len(waves), unscheduled),
+			HowToClose: "Add a window per remaining wave under `migration_waves.cutover_windows`, OR raise `migration_waves.max_clusters_per_wave` so the fleet fits in fewer waves.",
+		})
+	}
+
+	return &MigrationWavesSection{
+		LinkBandwidthMBps:  bandwidth,
+		MaxClustersPerWave: maxPerWave,
+		Waves:              waves,
+	}, oqs
+}
+
+// buildWaveGroups turns the fleet into wave groups ordered by
+// environment, then data volume ascending, ties broken by the first
+// cluster ID. A team spanning environments becomes one group per
+// environment, since its environments cut over separately. A cluster
+// listed under several teams stays with the first team alphabetically;
+// cluster names matching no scanned cluster are dropped. Both raise
+// an OQ.
+func buildWaveGroups(clusters []report.ProcessedCluster, teams map[string][]string) ([]waveGroup, []OpenQuestion) {
+	byName := make(map[string]MigrationWaveCluster, len(clusters))
+	for _, c := range clusters {
+		topics, bytes := userTopicVolume(c)
+		byName[c.Name] = MigrationWaveCluster{ClusterID: c.Name, Environment: c.Environment, Topics: topics, TotalBytes: bytes}
+	}
+
+	teamNames := make([]string, 0, len(teams))
+	for team := range teams {
+		teamNames = append(teamNames, team)
+	}
+	sort.Strings(teamNames)
+
+	var (
+		groups []waveGroup
+		oqs    []OpenQuestion
+		owner  = map[string]string{}
+	)
+	for _, team := range teamNames {
+		byEnvironment := map[string]*waveGroup{}
+		var environments []string
+		var unknown []string
+		for _, name := range teams[team] {
+			wc, ok := byName[name]
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			if first, claimed := owner[name]; claimed {
+				if first != team {
+					oqs = append(oqs, OpenQuestion{
+						ID:         "migration_waves_team_conflict",
+						ClusterID:  name,
+						Title:      fmt.Sprintf("Cluster `%s` is listed under more than one team in `migration_waves.teams`", name),
+						Body:       fmt.Sprintf("`%s` appears under both `%s` and `%s`. A cluster migrates once, so §Migration Waves keeps it with `%s` and plans `%s` without it.", name, first, team, first, team),
+						HowToClose: fmt.Sprintf("Remove `%s` from all but one team under `migration_waves.teams` in `plan-inputs.yaml`.", name),
+					})
+				}
+				continue
+			}
+			owner[name] = team
+			wc.Team = team
+			g, ok := byEnvironment[wc.Environment]
+			if !ok {
+				g = &waveGroup{team: team, environment: wc.Environment}
+				byEnvironment[wc.Environment] = g
+				environments = append(environments, wc.Environment)
+			}
+			g.clusters = append(g.clusters, wc)
+			g.bytes += wc.TotalBytes
+		}
+		if len(unknown) > 0 {
+			oqs = append(oqs, OpenQuestion{
+				ID:         "migration_waves_team_unknown_cluster",
+				Title:      fmt.Sprintf("Team `%s` in `migration_waves.teams` names cluster(s) that weren't scanned", team),
+				Body:       fmt.Sprintf("`%s` doesn't match any cluster in the state file, so §Migration Waves plans team `%s` without it. Either the name is a typo, or the state file is from a different source than expected.", strings.Join(unknown, "`, `"), team),
+				HowToClose: fmt.Sprintf("Correct the cluster name(s) under `migration_waves.teams.%s` in `plan-inputs.yaml` to match scanned clusters, OR remove them.", team),
+			})
+		}
+		for _, env := range environments {
+			groups = append(groups, *byEnvironment[env])
+		}
+	}
+	for _, c := range clusters {
+		if _, claimed := owner[c.Name]; claimed {
+			continue
+		}
+		wc := byName[c.Name]
+		groups = append(groups, waveGroup{environment: wc.Environment, clusters: []MigrationWaveCluster{wc}, bytes: wc.TotalBytes})
+	}
+
+	for _, g := range groups {
+		sort.Slice(g.clusters, func(i, j int) bool { return g.clusters[i].ClusterID < g.clusters[j].ClusterID })
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].environment != groups[j].environment {
+			return environment.Less(groups[i].environment, groups[j].environment)
+		}
+		if groups[i].bytes != groups[j].bytes {
+			return groups[i].bytes < groups[j].bytes
+		}
+		return groups[i].clusters[0].ClusterID < groups[j].clusters[0].ClusterID
+	})
+	return groups, oqs
+}
+
+// userTopicVolume counts a cluster's user topics and sums their
+// scanned partition sizes; internal (`__`) topics are skipped, as in
+// detectDataVolume.
+func userTopicVolume(c report.ProcessedCluster) (topics int, bytes int64) {
+	if c.KafkaAdminClientInformation.Topics == nil {
+		return 0, 0
+	}
+	for _, topic := range c.KafkaAdminClientInformation.Topics.Details {
+		if strings.HasPrefix(topic.Name, "__") {
+			continue
+		}
+		topics++
+		for _, size := range topic.PartitionSizes {
+			bytes += size
+		}
+	}
+	return topics, bytes
+}
+
+// invalidWaveInputOQ reports a non-positive `migration_waves` value
+// that was ignored in favour of the default.
+func invalidWaveInputOQ(field, value, fallback string) OpenQuestion {
+	return OpenQuestion{
+		ID:         "migration_waves_input_invalid",
+		Title:      fmt.Sprintf("`migration_waves.%s: %s` must be > 0 — ignored", field, value),
+		Body:       fmt.Sprintf("§Migration Waves was planned with the default of %s instead.", fallback),
+		HowToClose: fmt.Sprintf("Set `migration_waves.%s` in `plan-inputs.yaml` to a positive value, OR remove it to keep the default.", field),
+	}
+}
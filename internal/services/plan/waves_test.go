@@ -0,0 +1,172 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waveInputs(mw *MigrationWavesInputs) PlanInputsResolved {
+	in := defaultInputs()
+	in.Raw = &PlanInputs{MigrationWaves: mw}
+	return in
+}
+
+func waveClusterIDs(w MigrationWave) []string {
+	ids := make([]string, 0, len(w.Clusters))
+	for _, c := range w.Clusters {
+		ids = append(ids, c.ClusterID)
+	}
+	return ids
+}
+
+func TestDetectMigrationWaves_SmallestFirstWithTeamsTogether(t *testing.T) {
+	state := wrapClusters(
+		sizedCluster("orders", map[string][]int64{"payments": {40 * gb}}),
+		sizedCluster("billing", map[string][]int64{"invoices": {30 * gb}}),
+		sizedCluster("sandbox", map[string][]int64{"scratch": {1 * gb}}),
+		sizedCluster("analytics", map[string][]int64{"clickstream": {200 * gb}}),
+	)
+	cfg := defaultCfg(t)
+	cfg.MigrationWaves.MaxClustersPerWave = 2
+	bandwidth := 50.0
+
+	section, oqs := detectMigrationWaves(state, cfg, waveInputs(&MigrationWavesInputs{
+		CutoverWindows:    []string{"2026-11-07 02:00-06:00 UTC", "2026-11-14 02:00-06:00 UTC"},
+		LinkBandwidthMBps: &bandwidth,
+		Teams:             map[string][]string{"finance": {"orders", "billing"}},
+	}))
+
+	require.NotNil(t, section)
+	require.Len(t, oqs, 1)
+	assert.Equal(t, "migration_waves_unscheduled", oqs[0].ID)
+	assert.Contains(t, oqs[0].Body, "Wave 3 is left unscheduled")
+	assert.Equal(t, 50.0, section.LinkBandwidthMBps)
+	require.Len(t, section.Waves, 3)
+
+	assert.Equal(t, []string{"sandbox"}, waveClusterIDs(section.Waves[0]))
+	assert.Equal(t, "2026-11-07 02:00-06:00 UTC", section.Waves[0].CutoverWindow)
+	assert.Equal(t, []string{"billing", "orders"}, waveClusterIDs(section.Waves[1]))
+	assert.Equal(t, "finance", section.Waves[1].Clusters[0].Team)
+	assert.Equal(t, 70*gb, section.Waves[1].TotalBytes)
+	assert.InDelta(t, 70*1024/50.0, section.Waves[1].EstimatedSyncSeconds, 0.001)
+	assert.Equal(t, []string{"analytics"}, waveClusterIDs(section.Waves[2]))
+	assert.Empty(t, section.Waves[2].CutoverWindow)
+}
+
+func TestDetectMigrationWaves_OversizedTeamKeptTogether(t *testing.T) {
+	state := wrapClusters(
+		sizedCluster("a", map[string][]int64{"t": {1 * gb}}),
+		sizedCluster("b", map[string][]int64{"t": {1 * gb}}),
+		sizedCluster("c", map[string][]int64{"t": {1 * gb}}),
+	)
+	maxPerWave := 2
+
+	section, _ := detectMigrationWaves(state, defaultCfg(t), waveInputs(&MigrationWavesInputs{
+		MaxClustersPerWave: &maxPerWave,
+		Teams:              map[string][]string{"platform": {"a", "b", "c"}},
+	}))
+
+	require.Len(t, section.Waves, 1)
+	assert.Len(t, section.Waves[0].Clusters, 3)
+	assert.Equal(t, "platform", section.Waves[0].OversizedTeam)
+}
+
+func TestDetectMigrationWaves_InputProblemsRaiseOQs(t *testing.T) {
+	state := wrapClusters(
+		sizedCluster("orders", map[string][]int64{"t": {1 * gb}}),
+		sizedCluster("billing", map[string][]int64{"t": {2 * gb}}),
+	)
+	maxPerWave := 0
+	cfg := defaultCfg(t)
+	cfg.MigrationWaves.MaxClustersPerWave = 1
+
+	section, oqs := detectMigrationWaves(state, cfg, waveInputs(&MigrationWavesInputs{
+		CutoverWindows:     []string{"week 1"},
+		MaxClustersPerWave: &maxPerWave,
+		Teams: map[string][]string{
+			"finance":  {"orders", "ledger"},
+			"payments": {"orders"},
+		},
+	}))
+
+	require.NotNil(t, section)
+	assert.Equal(t, 1, section.MaxClustersPerWave)
+	ids := make([]string, 0, len(oqs))
+	for _, oq := range oqs {
+		ids = append(ids, oq.ID)
+	}
+	assert.ElementsMatch(t, []string{
+		"migration_waves_input_invalid",
+		"migration_waves_team_unknown_cluster",
+		"migration_waves_team_conflict",
+		"migration_waves_unscheduled",
+	}, ids)
+}
+
+func TestDetectMigrationWaves_SingleClusterWithoutInputsReturnsNil(t *testing.T) {
+	state := wrapClusters(sizedCluster("orders", map[string][]int64{"t": {1 * gb}}))
+	section, oqs := detectMigrationWaves(state, defaultCfg(t), defaultInputs())
+	assert.Nil(t, section)
+	assert.Nil(t, oqs)
+}
+
+func TestPlanConfig_ValidateRejectsZeroClustersPerWave(t *testing.T) {
+	cfg := defaultCfg(t)
+	cfg.MigrationWaves.MaxClustersPerWave = 0
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "migration_waves.max_clusters_per_wave must be > 0")
+}
+
+func TestRenderMarkdown_MigrationWavesSection(t *testing.T) {
+	state := wrapClusters(
+		sizedCluster("orders", map[string][]int64{"payments": {2 * gb}}),
+		sizedCluster("sandbox", map[string][]int64{"scratch": {1 * gb}}),
+	)
+	cfg := defaultCfg(t)
+	plan := buildPlanForRedFlags(t, state, cfg, defaultInputs())
+	require.NotNil(t, plan.MigrationWaves)
+
+	out, err := RenderMarkdown(plan, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), ". Migration Waves\n")
+	assert.Contains(t, string(out), "| 1 | _unscheduled_ | `sandbox`, `orders` | 2 | 3.0 GB | < 1 min |")
+	assert.Contains(t, string(out), "No cutover windows declared")
+}
+
+func TestDetectMigrationWaves_PerEnvironment(t *testing.T) {
+	inEnvironment := func(c report.ProcessedCluster, env string) report.ProcessedCluster {
+		c.Environment = env
+		return c
+	}
+	state := wrapClusters(
+		inEnvironment(sizedCluster("orders-prod", map[string][]int64{"payments": {5 * gb}}), "prod"),
+		inEnvironment(sizedCluster("orders-dev", map[string][]int64{"payments": {50 * gb}}), "dev"),
+		inEnvironment(sizedCluster("billing-dev", map[string][]int64{"invoices": {10 * gb}}), "dev"),
+		inEnvironment(sizedCluster("billing-stage", map[string][]int64{"invoices": {1 * gb}}), "stage"),
+		sizedCluster("legacy", map[string][]int64{"events": {1 * gb}}),
+	)
+	cfg := defaultCfg(t)
+	cfg.MigrationWaves.MaxClustersPerWave = 3
+
+	section, oqs := detectMigrationWaves(state, cfg, waveInputs(&MigrationWavesInputs{
+		Teams: map[string][]string{"billing": {"billing-dev", "billing-stage"}},
+	}))
+
+	require.NotNil(t, section)
+	assert.Empty(t, oqs)
+	require.Len(t, section.Waves, 4, "waves never span environments, even with room to spare")
+
+	assert.Equal(t, "dev", section.Waves[0].Environment)
+	assert.Equal(t, []string{"billing-dev", "orders-dev"}, waveClusterIDs(section.Waves[0]))
+	assert.Equal(t, "stage", section.Waves[1].Environment)
+	assert.Equal(t, []string{"billing-stage"}, waveClusterIDs(section.Waves[1]))
+	assert.Equal(t, "billing", section.Waves[1].Clusters[0].Team, "a team spanning environments is split per environment")
+	assert.Equal(t, "prod", section.Waves[2].Environment)
+	assert.Equal(t, []string{"orders-prod"}, waveClusterIDs(section.Waves[2]))
+	assert.Empty(t, section.Waves[3].Environment, "unclassified clusters migrate last")
+	assert.Equal(t, []string{"legacy"}, waveClusterIDs(section.Waves[3]))
+}
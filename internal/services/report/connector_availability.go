@@ -0,0 +1,66 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/services/connector_mapping"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// Where a discovered connector runs today.
+const (
+	ConnectorSourceMSKConnect  = "msk_connect"
+	ConnectorSourceSelfManaged = "self_managed"
+)
+
+// ConnectorAvailability is the Confluent Cloud status of one discovered
+// connector, looked up by its connector.class in the connector catalog.
+type ConnectorAvailability struct {
+	Name           string                         `json:"name"`
+	Source         string                         `json:"source"`
+	ConnectorClass string                         `json:"connector_class"`
+	Plugin         string                         `json:"plugin,omitempty"`
+	Availability   connector_mapping.Availability `json:"availability"`
+	Note           string                         `json:"note,omitempty"`
+}
+
+// classifyConnectors marks each MSK Connect and self-managed connector of a
+// cluster as supported, partially-supported or self-managed-only on Confluent
+// Cloud, sorted by source and name. Returns nil when the cluster has none.
+func classifyConnectors(awsInfo *types.AWSClientInformation, adminInfo types.KafkaAdminClientInformation) []ConnectorAvailability {
+	var out []ConnectorAvailability
+	if awsInfo != nil {
+		for _, c := range awsInfo.Connectors {
+			out = append(out, newConnectorAvailability(c.ConnectorName, ConnectorSourceMSKConnect, c.ConnectorConfiguration["connector.class"]))
+		}
+	}
+	if adminInfo.SelfManagedConnectors != nil {
+		for _, c := range adminInfo.SelfManagedConnectors.Connectors {
+			class := ""
+			if v, ok := c.Config["connector.class"]; ok && v != nil {
+				class = fmt.Sprint(v)
+			}
+			out = append(out, newConnectorAvailability(c.Name, ConnectorSourceSelfManaged, class))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Source != out[j].Source {
+			return out[i].Source < out[j].Source
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func newConnectorAvailability(name, source, class string) ConnectorAvailability {
+	entry := connector_mapping.Classify(class)
+	return ConnectorAvailability{
+		Name:           name,
+		Source:         source,
+		ConnectorClass: class,
+		Plugin:         entry.Plugin,
+		Availability:   entry.Availability,
+		Note:           entry.Note,
+	}
+}
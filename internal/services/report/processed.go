@@ -27,6 +27,8 @@ type ProcessedRegion struct {
 	Configurations []kafka.DescribeConfigurationRevisionOutput `json:"configurations"`
 	Costs          ProcessedRegionCosts                        `json:"costs"`    // Flattened from raw AWS Cost Explorer data
 	Clusters       []ProcessedCluster                          `json:"clusters"` // Simplified from full DiscoveredCluster data
+	Replicators    []kafka.DescribeReplicatorOutput            `json:"replicators,omitempty"`
+	KafkaSecrets   []types.KafkaSecret                         `json:"kafka_secrets,omitempty"`
 }
 
 type ProcessedRegionCosts struct {
@@ -112,6 +114,12 @@ type ProcessedCluster struct {
 	AWSClientInformation        types.AWSClientInformation        `json:"aws_client_information"`
 	KafkaAdminClientInformation types.KafkaAdminClientInformation `json:"kafka_admin_client_information"`
 	DiscoveredClients           []types.DiscoveredClient          `json:"discovered_clients"`
+	// ConnectorAvailability marks each MSK Connect and self-managed
+	// connector of the cluster with its Confluent Cloud status.
+	ConnectorAvailability []ConnectorAvailability `json:"connector_availability,omitempty"`
+	// Environment is the cluster's business environment (dev, stage,
+	// prod), empty when unclassified.
+	Environment string `json:"environment,omitempty"`
 }
 
 type CostAggregate struct {
@@ -156,4 +164,10 @@ type ProcessedOSKCluster struct {
 	ClusterMetrics              *types.ProcessedClusterMetrics    `json:"metrics,omitempty"`
 	DiscoveredClients           []types.DiscoveredClient          `json:"discovered_clients"`
 	Metadata                    types.OSKClusterMetadata          `json:"metadata"`
+	// ConnectorAvailability marks each self-managed connector of the
+	// cluster with its Confluent Cloud status.
+	ConnectorAvailability []ConnectorAvailability `json:"connector_availability,omitempty"`
+	// Environment is the cluster's business environment, empty when
+	// unclassified.
+	Environment string `json:"environment,omitempty"`
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/confluentinc/kcp/internal/services/environment"
 	"github.com/confluentinc/kcp/internal/types"
 )
 
@@ -56,6 +57,8 @@ func (rs *ReportService) ProcessState(state types.State) ProcessedState {
 					AWSClientInformation:        cluster.AWSClientInformation,
 					KafkaAdminClientInformation: cluster.KafkaAdminClientInformation,
 					DiscoveredClients:           cluster.DiscoveredClients,
+					ConnectorAvailability:       classifyConnectors(&cluster.AWSClientInformation, cluster.KafkaAdminClientInformation),
+					Environment:                 clusterEnvironment(cluster.Annotations, cluster.AWSClientInformation.MskClusterConfig.Tags),
 				})
 			}
 
@@ -64,6 +67,8 @@ func (rs *ReportService) ProcessState(state types.State) ProcessedState {
 				Configurations: region.Configurations,
 				Costs:          processedCosts,
 				Clusters:       processedClusters,
+				Replicators:    region.Replicators,
+				KafkaSecrets:   region.KafkaSecrets,
 			})
 		}
 
@@ -88,6 +93,8 @@ func (rs *ReportService) ProcessState(state types.State) ProcessedState {
 				ClusterMetrics:              cluster.ClusterMetrics,
 				DiscoveredClients:           cluster.DiscoveredClients,
 				Metadata:                    cluster.Metadata,
+				ConnectorAvailability:       classifyConnectors(nil, cluster.KafkaAdminClientInformation),
+				Environment:                 clusterEnvironment(cluster.Annotations, nil),
 			})
 		}
 
@@ -810,3 +817,13 @@ func CalculateMetricsAggregates(metrics []types.ProcessedMetric) map[string]type
 
 	return aggregates
 }
+
+// clusterEnvironment is the cluster's business environment: the annotation
+// `kcp state classify` (or `kcp state annotate`) recorded, falling back to the
+// default environment tags for states that were never classified.
+func clusterEnvironment(annotations, tags map[string]string) string {
+	if env := annotations[types.AnnotationEnvironment]; env != "" {
+		return environment.Normalize(env)
+	}
+	return environment.FromTags(tags, environment.DefaultTagKeys)
+}
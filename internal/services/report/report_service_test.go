@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/confluentinc/kcp/internal/services/connector_mapping"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1184,3 +1185,37 @@ func TestFilterConnectMetrics(t *testing.T) {
 		assert.Len(t, result.Metrics, 1) // only the Jan 1 MSK metric
 	})
 }
+
+func TestProcessState_ClassifiesConnectors(t *testing.T) {
+	state := types.State{
+		MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{{
+			Name: "us-east-1",
+			Clusters: []types.DiscoveredCluster{{
+				Name: "orders",
+				AWSClientInformation: types.AWSClientInformation{Connectors: []types.ConnectorSummary{
+					{ConnectorName: "warehouse", ConnectorConfiguration: map[string]string{"connector.class": "com.wepay.kafka.connect.bigquery.BigQuerySinkConnector"}},
+				}},
+				KafkaAdminClientInformation: types.KafkaAdminClientInformation{SelfManagedConnectors: &types.SelfManagedConnectors{Connectors: []types.SelfManagedConnector{
+					{Name: "archive", Config: map[string]any{"connector.class": "io.confluent.connect.s3.S3SinkConnector"}},
+					{Name: "custom", Config: map[string]any{"connector.class": "com.example.AuditSourceConnector"}},
+				}}},
+			}, {Name: "quiet"}},
+		}}},
+	}
+
+	processed := NewReportService().ProcessState(state)
+
+	clusters := processed.Sources[0].MSKData.Regions[0].Clusters
+	require.Len(t, clusters, 2)
+	assert.Nil(t, clusters[1].ConnectorAvailability)
+	got := clusters[0].ConnectorAvailability
+	require.Len(t, got, 3)
+	assert.Equal(t, "warehouse", got[0].Name, "MSK Connect connectors sort first")
+	assert.Equal(t, ConnectorSourceMSKConnect, got[0].Source)
+	assert.Equal(t, connector_mapping.AvailabilityPartial, got[0].Availability)
+	assert.Equal(t, "archive", got[1].Name)
+	assert.Equal(t, connector_mapping.AvailabilitySupported, got[1].Availability)
+	assert.Equal(t, "S3_SINK", got[1].Plugin)
+	assert.Equal(t, "custom", got[2].Name)
+	assert.Equal(t, connector_mapping.AvailabilitySelfManaged, got[2].Availability)
+}
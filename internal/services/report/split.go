@@ -0,0 +1,99 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// UntaggedGroup is the group SplitStateByTag puts clusters in when they do
+// not carry the tag being split on.
+const UntaggedGroup = "untagged"
+
+// StateGroup is the part of a state file owned by one value of a tag.
+type StateGroup struct {
+	Name  string
+	State types.State
+}
+
+// SplitStateByTag splits a state file into one state per value of the tagKey
+// cluster tag (MSK) or label (Apache Kafka), matched the same way as
+// types.InferOwnerFromTags. Each state keeps only that group's clusters, and
+// with them their topics, connectors and clients. Regions left without
+// clusters are dropped; region-level data (costs, configurations,
+// replicators) and schema registries are not owned by a single cluster and
+// are kept in every group. Groups are sorted by name, with untagged clusters
+// last.
+func SplitStateByTag(state types.State, tagKey string) []StateGroup {
+	groups := map[string]*types.State{}
+	group := func(tags map[string]string) *types.State {
+		name := types.InferOwnerFromTags(tags, []string{tagKey})
+		if name == "" {
+			name = UntaggedGroup
+		}
+		if groups[name] == nil {
+			groups[name] = emptyStateLike(state)
+		}
+		return groups[name]
+	}
+
+	if state.MSKSources != nil {
+		for _, region := range state.MSKSources.Regions {
+			for _, cluster := range region.Clusters {
+				g := group(cluster.AWSClientInformation.MskClusterConfig.Tags)
+				if g.MSKSources == nil {
+					g.MSKSources = &types.MSKSourcesState{}
+				}
+				regions := g.MSKSources.Regions
+				if len(regions) == 0 || regions[len(regions)-1].Name != region.Name {
+					r := region
+					r.Clusters = nil
+					r.ClusterArns = nil
+					regions = append(regions, r)
+				}
+				regions[len(regions)-1].Clusters = append(regions[len(regions)-1].Clusters, cluster)
+				g.MSKSources.Regions = regions
+			}
+		}
+	}
+
+	if state.OSKSources != nil {
+		for _, cluster := range state.OSKSources.Clusters {
+			g := group(cluster.Metadata.Labels)
+			if g.OSKSources == nil {
+				g.OSKSources = &types.OSKSourcesState{}
+			}
+			g.OSKSources.Clusters = append(g.OSKSources.Clusters, cluster)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == UntaggedGroup) != (names[j] == UntaggedGroup) {
+			return names[j] == UntaggedGroup
+		}
+		return names[i] < names[j]
+	})
+
+	out := make([]StateGroup, 0, len(names))
+	for _, name := range names {
+		out = append(out, StateGroup{Name: name, State: *groups[name]})
+	}
+	return out
+}
+
+// emptyStateLike returns a state carrying the metadata and schema registries
+// of state, but none of its clusters.
+func emptyStateLike(state types.State) *types.State {
+	return &types.State{
+		SchemaVersion:    state.SchemaVersion,
+		SchemaRegistries: state.SchemaRegistries,
+		KcpBuildInfo:     state.KcpBuildInfo,
+		Timestamp:        state.Timestamp,
+		UpdatedAt:        state.UpdatedAt,
+		UpgradedFrom:     state.UpgradedFrom,
+	}
+}
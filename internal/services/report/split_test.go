@@ -0,0 +1,60 @@
+package report
+
+import (
+	"testing"
+
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mskCluster(name string, tags map[string]string) types.DiscoveredCluster {
+	return types.DiscoveredCluster{
+		Name:                 name,
+		AWSClientInformation: types.AWSClientInformation{MskClusterConfig: kafkatypes.Cluster{Tags: tags}},
+	}
+}
+
+func TestSplitStateByTag(t *testing.T) {
+	state := types.State{
+		SchemaVersion: 11,
+		MSKSources: &types.MSKSourcesState{Regions: []types.DiscoveredRegion{
+			{Name: "us-east-1", Clusters: []types.DiscoveredCluster{
+				mskCluster("orders", map[string]string{"Team": "payments"}),
+				mskCluster("clicks", map[string]string{"team": "analytics"}),
+				mskCluster("legacy", nil),
+			}},
+			{Name: "eu-west-1", Clusters: []types.DiscoveredCluster{
+				mskCluster("ledger", map[string]string{"team": "payments"}),
+			}},
+		}},
+		OSKSources: &types.OSKSourcesState{Clusters: []types.OSKDiscoveredCluster{
+			{ID: "onprem-payments", Metadata: types.OSKClusterMetadata{Labels: map[string]string{"team": "payments"}}},
+		}},
+		SchemaRegistries: &types.SchemaRegistriesState{},
+	}
+
+	groups := SplitStateByTag(state, "team")
+
+	require.Len(t, groups, 3)
+	assert.Equal(t, []string{"analytics", "payments", UntaggedGroup}, []string{groups[0].Name, groups[1].Name, groups[2].Name})
+
+	payments := groups[1].State
+	assert.Equal(t, 11, payments.SchemaVersion)
+	assert.Same(t, state.SchemaRegistries, payments.SchemaRegistries)
+	require.Len(t, payments.MSKSources.Regions, 2)
+	assert.Equal(t, "us-east-1", payments.MSKSources.Regions[0].Name)
+	assert.Equal(t, "orders", payments.MSKSources.Regions[0].Clusters[0].Name)
+	assert.Equal(t, "ledger", payments.MSKSources.Regions[1].Clusters[0].Name)
+	require.Len(t, payments.OSKSources.Clusters, 1)
+
+	analytics := groups[0].State
+	require.Len(t, analytics.MSKSources.Regions, 1)
+	assert.Len(t, analytics.MSKSources.Regions[0].Clusters, 1)
+	assert.Nil(t, analytics.OSKSources)
+
+	assert.Equal(t, "legacy", groups[2].State.MSKSources.Regions[0].Clusters[0].Name)
+	// Splitting must not alias the input's cluster slices.
+	assert.Len(t, state.MSKSources.Regions[0].Clusters, 3)
+}
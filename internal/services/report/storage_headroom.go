@@ -0,0 +1,87 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// Disk-used thresholds (percent of the broker EBS volume) for the storage
+// headroom analysis.
+const (
+	StorageHeadroomWarningPercent  = 70.0
+	StorageHeadroomCriticalPercent = 85.0
+)
+
+type StorageHeadroomStatus string
+
+const (
+	StorageHeadroomOK       StorageHeadroomStatus = "OK"
+	StorageHeadroomWarning  StorageHeadroomStatus = "WARNING"
+	StorageHeadroomCritical StorageHeadroomStatus = "CRITICAL"
+)
+
+// BrokerStorageHeadroom is the peak disk usage observed for a single broker.
+type BrokerStorageHeadroom struct {
+	BrokerID        string
+	MaxUsedPercent  float64
+	HeadroomPercent float64
+	Status          StorageHeadroomStatus
+}
+
+// StorageHeadroom summarises per-broker KafkaDataLogsDiskUsed for a cluster.
+// Status is that of the fullest broker.
+type StorageHeadroom struct {
+	Brokers        []BrokerStorageHeadroom
+	MaxUsedPercent float64
+	Status         StorageHeadroomStatus
+}
+
+// AnalyzeStorageHeadroom derives per-broker storage headroom from the
+// per-broker disk-used aggregates (see types.BrokerDiskUsedLabelPrefix).
+// Returns nil when the cluster has no per-broker disk usage data, e.g.
+// express brokers or OSK clusters.
+func AnalyzeStorageHeadroom(aggregates map[string]types.MetricAggregate) *StorageHeadroom {
+	var brokers []BrokerStorageHeadroom
+	for label, aggregate := range aggregates {
+		brokerID, ok := strings.CutPrefix(label, types.BrokerDiskUsedLabelPrefix)
+		if !ok || aggregate.Maximum == nil {
+			continue
+		}
+		used := *aggregate.Maximum
+		brokers = append(brokers, BrokerStorageHeadroom{
+			BrokerID:        brokerID,
+			MaxUsedPercent:  used,
+			HeadroomPercent: max(100-used, 0),
+			Status:          storageHeadroomStatus(used),
+		})
+	}
+	if len(brokers) == 0 {
+		return nil
+	}
+
+	sort.Slice(brokers, func(i, j int) bool {
+		if brokers[i].MaxUsedPercent != brokers[j].MaxUsedPercent {
+			return brokers[i].MaxUsedPercent > brokers[j].MaxUsedPercent
+		}
+		return brokers[i].BrokerID < brokers[j].BrokerID
+	})
+
+	return &StorageHeadroom{
+		Brokers:        brokers,
+		MaxUsedPercent: brokers[0].MaxUsedPercent,
+		Status:         brokers[0].Status,
+	}
+}
+
+func storageHeadroomStatus(usedPercent float64) StorageHeadroomStatus {
+	switch {
+	case usedPercent >= StorageHeadroomCriticalPercent:
+		return StorageHeadroomCritical
+	case usedPercent >= StorageHeadroomWarningPercent:
+		return StorageHeadroomWarning
+	default:
+		return StorageHeadroomOK
+	}
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeStorageHeadroom(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	t.Run("no per-broker data returns nil", func(t *testing.T) {
+		aggregates := map[string]types.MetricAggregate{
+			"TotalLocalStorageUsage(GB)": {Maximum: f(500)},
+		}
+		assert.Nil(t, AnalyzeStorageHeadroom(aggregates))
+		assert.Nil(t, AnalyzeStorageHeadroom(nil))
+	})
+
+	t.Run("brokers sorted by usage and cluster status follows fullest broker", func(t *testing.T) {
+		aggregates := map[string]types.MetricAggregate{
+			types.BrokerDiskUsedLabelPrefix + "1": {Maximum: f(42)},
+			types.BrokerDiskUsedLabelPrefix + "2": {Maximum: f(88.5)},
+			types.BrokerDiskUsedLabelPrefix + "3": {Maximum: f(71)},
+			types.BrokerDiskUsedLabelPrefix + "4": {},
+			"BytesInPerSec":                       {Maximum: f(99)},
+		}
+
+		headroom := AnalyzeStorageHeadroom(aggregates)
+		require.NotNil(t, headroom)
+		require.Len(t, headroom.Brokers, 3)
+
+		assert.Equal(t, "2", headroom.Brokers[0].BrokerID)
+		assert.Equal(t, StorageHeadroomCritical, headroom.Brokers[0].Status)
+		assert.InDelta(t, 11.5, headroom.Brokers[0].HeadroomPercent, 0.001)
+		assert.Equal(t, "3", headroom.Brokers[1].BrokerID)
+		assert.Equal(t, StorageHeadroomWarning, headroom.Brokers[1].Status)
+		assert.Equal(t, "1", headroom.Brokers[2].BrokerID)
+		assert.Equal(t, StorageHeadroomOK, headroom.Brokers[2].Status)
+
+		assert.Equal(t, 88.5, headroom.MaxUsedPercent)
+		assert.Equal(t, StorageHeadroomCritical, headroom.Status)
+	})
+
+	t.Run("headroom never negative", func(t *testing.T) {
+		headroom := AnalyzeStorageHeadroom(map[string]types.MetricAggregate{
+			types.BrokerDiskUsedLabelPrefix + "1": {Maximum: f(101)},
+		})
+		require.NotNil(t, headroom)
+		assert.Equal(t, 0.0, headroom.Brokers[0].HeadroomPercent)
+	})
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// TopicCost is the share of a cluster's cost allocated to one topic.
+type TopicCost struct {
+	Topic          string
+	BytesInPerSec  float64
+	BytesOutPerSec float64
+	// StorageBytes is the topic's on-disk size across all replicas.
+	StorageBytes int64
+	// Share is the fraction of the cluster cost allocated to the topic.
+	Share float64
+	Cost  float64
+}
+
+// TopicCostAllocation splits one cluster's cost across its topics. Dimensions
+// lists what the shares were measured on ("bytes in", "bytes out",
+// "storage"); a dimension without data on the cluster is left out rather
+// than counted as zero.
+type TopicCostAllocation struct {
+	ClusterCost float64
+	Dimensions  []string
+	Topics      []TopicCost
+}
+
+// AllocateTopicCost splits clusterCost across topics in proportion to their
+// average bytes in, bytes out and storage, weighting each measured dimension
+// equally: a topic with a third of the ingress and none of the egress or
+// storage carries a ninth of the cost. Throughput comes from the per-topic
+// aggregates (see types.TopicBytesInLabelPrefix), storage from the partition
+// sizes `kcp scan clusters` records, multiplied by the replication factor.
+// Returns nil when none of the dimensions has data for the cluster.
+func AllocateTopicCost(clusterCost float64, topics []types.TopicDetails, aggregates map[string]types.MetricAggregate) *TopicCostAllocation {
+	byTopic := make(map[string]*TopicCost)
+	topic := func(name string) *TopicCost {
+		t, ok := byTopic[name]
+		if !ok {
+			t = &TopicCost{Topic: name}
+			byTopic[name] = t
+		}
+		return t
+	}
+
+	var totalIn, totalOut float64
+	for label, aggregate := range aggregates {
+		if aggregate.Average == nil {
+			continue
+		}
+		if name, ok := strings.CutPrefix(label, types.TopicBytesInLabelPrefix); ok {
+			topic(name).BytesInPerSec = *aggregate.Average
+			totalIn += *aggregate.Average
+		} else if name, ok := strings.CutPrefix(label, types.TopicBytesOutLabelPrefix); ok {
+			topic(name).BytesOutPerSec = *aggregate.Average
+			totalOut += *aggregate.Average
+		}
+	}
+
+	var totalStorage int64
+	for _, details := range topics {
+		var size int64
+		for _, partition := range details.PartitionSizes {
+			size += partition
+		}
+		if size == 0 {
+			continue
+		}
+		replicas := int64(max(details.ReplicationFactor, 1))
+		topic(details.Name).StorageBytes = size * replicas
+		totalStorage += size * replicas
+	}
+
+	allocation := &TopicCostAllocation{ClusterCost: clusterCost}
+	if totalIn > 0 {
+		allocation.Dimensions = append(allocation.Dimensions, "bytes in")
+	}
+	if totalOut > 0 {
+		allocation.Dimensions = append(allocation.Dimensions, "bytes out")
+	}
+	if totalStorage > 0 {
+		allocation.Dimensions = append(allocation.Dimensions, "storage")
+	}
+	if len(allocation.Dimensions) == 0 {
+		return nil
+	}
+
+	weight := 1 / float64(len(allocation.Dimensions))
+	for _, t := range byTopic {
+		if totalIn > 0 {
+			t.Share += weight * t.BytesInPerSec / totalIn
+		}
+		if totalOut > 0 {
+			t.Share += weight * t.BytesOutPerSec / totalOut
+		}
+		if totalStorage > 0 {
+			t.Share += weight * float64(t.StorageBytes) / float64(totalStorage)
+		}
+		t.Cost = clusterCost * t.Share
+		allocation.Topics = append(allocation.Topics, *t)
+	}
+
+	sort.Slice(allocation.Topics, func(i, j int) bool {
+		if allocation.Topics[i].Share != allocation.Topics[j].Share {
+			return allocation.Topics[i].Share > allocation.Topics[j].Share
+		}
+		return allocation.Topics[i].Topic < allocation.Topics[j].Topic
+	})
+	return allocation
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocateTopicCost(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	t.Run("no per-topic data returns nil", func(t *testing.T) {
+		aggregates := map[string]types.MetricAggregate{"BytesInPerSec": {Average: f(100)}}
+		assert.Nil(t, AllocateTopicCost(300, []types.TopicDetails{{Name: "orders", ReplicationFactor: 3}}, aggregates))
+	})
+
+	t.Run("each measured dimension weighs equally", func(t *testing.T) {
+		aggregates := map[string]types.MetricAggregate{
+			types.TopicBytesInLabelPrefix + "orders":    {Average: f(30)},
+			types.TopicBytesInLabelPrefix + "payments":  {Average: f(10)},
+			types.TopicBytesOutLabelPrefix + "orders":   {Average: f(50)},
+			types.TopicBytesOutLabelPrefix + "payments": {Average: f(50)},
+			types.TopicBytesOutLabelPrefix + "audit":    {},
+			"BytesInPerSec":                             {Average: f(40)},
+		}
+		topics := []types.TopicDetails{
+			{Name: "orders", ReplicationFactor: 3, PartitionSizes: []int64{100, 100}},
+			{Name: "audit", ReplicationFactor: 2, PartitionSizes: []int64{300}},
+			{Name: "empty", ReplicationFactor: 3},
+		}
+
+		allocation := AllocateTopicCost(900, topics, aggregates)
+		require.NotNil(t, allocation)
+		assert.Equal(t, []string{"bytes in", "bytes out", "storage"}, allocation.Dimensions)
+		require.Len(t, allocation.Topics, 3, "topics without throughput or storage get no row")
+
+		orders := allocation.Topics[0]
+		assert.Equal(t, "orders", orders.Topic)
+		assert.Equal(t, int64(600), orders.StorageBytes)
+		// (30/40 + 50/100 + 600/1200) / 3
+		assert.InDelta(t, 0.5833, orders.Share, 0.0001)
+		assert.InDelta(t, 525, orders.Cost, 0.01)
+
+		assert.Equal(t, "payments", allocation.Topics[1].Topic)
+		assert.InDelta(t, 0.25, allocation.Topics[1].Share, 0.0001)
+		assert.Equal(t, "audit", allocation.Topics[2].Topic)
+		assert.InDelta(t, 1.0/6, allocation.Topics[2].Share, 0.0001)
+
+		var total float64
+		for _, topic := range allocation.Topics {
+			total += topic.Cost
+		}
+		assert.InDelta(t, 900, total, 0.01, "the whole cluster cost is allocated")
+	})
+
+	t.Run("storage alone when throughput is not published per topic", func(t *testing.T) {
+		topics := []types.TopicDetails{
+			{Name: "orders", ReplicationFactor: 3, PartitionSizes: []int64{100}},
+			{Name: "audit", ReplicationFactor: 1, PartitionSizes: []int64{100}},
+		}
+		allocation := AllocateTopicCost(100, topics, nil)
+		require.NotNil(t, allocation)
+		assert.Equal(t, []string{"storage"}, allocation.Dimensions)
+		assert.InDelta(t, 75, allocation.Topics[0].Cost, 0.01)
+	})
+}
@@ -0,0 +1,93 @@
+package rightsizing
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+)
+
+//go:embed msk-price-sheet.yaml
+var embeddedPriceSheet []byte
+
+// expectedSchemaVersion is the schema_version this loader understands.
+const expectedSchemaVersion = 1
+
+// PriceSheet is the deserialized msk-price-sheet.yaml. The embedded copy is
+// the default; a user-supplied file replaces only the fields it sets.
+type PriceSheet struct {
+	SchemaVersion int    `yaml:"schema_version"`
+	Currency      string `yaml:"currency"`
+	Source        string `yaml:"source"`
+	LastVerified  string `yaml:"last_verified"`
+
+	StoragePerGBMonth                 float64                    `yaml:"storage_per_gb_month"`
+	ProvisionedThroughputPerMBpsMonth float64                    `yaml:"provisioned_throughput_per_mbps_month"`
+	BaselineThroughputMBps            float64                    `yaml:"baseline_throughput_mbps"`
+	Instances                         map[string]InstancePricing `yaml:"instances"`
+	HeadroomFraction                  float64                    `yaml:"headroom_fraction"`
+}
+
+// InstancePricing is the hourly price and sustained capacity of one broker
+// of an instance type.
+type InstancePricing struct {
+	Hourly      float64 `yaml:"hourly"`
+	IngressMBps float64 `yaml:"ingress_mbps"`
+	EgressMBps  float64 `yaml:"egress_mbps"`
+	Partitions  int     `yaml:"partitions"`
+}
+
+// LoadPriceSheet returns the embedded price sheet, with overridePath
+// (when non-empty) unmarshalled on top of it.
+func LoadPriceSheet(overridePath string) (*PriceSheet, error) {
+	sheet := &PriceSheet{}
+	if err := yaml.Unmarshal(embeddedPriceSheet, sheet); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded MSK price sheet: %w", err)
+	}
+
+	if overridePath != "" {
+		data, err := os.ReadFile(overridePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MSK price sheet %s: %w", overridePath, err)
+		}
+		if err := yaml.Unmarshal(data, sheet); err != nil {
+			return nil, fmt.Errorf("failed to parse MSK price sheet %s: %w", overridePath, err)
+		}
+	}
+
+	if err := sheet.Validate(); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+func (p *PriceSheet) Validate() error {
+	if p.SchemaVersion != expectedSchemaVersion {
+		return fmt.Errorf("MSK price sheet schema_version %d does not match expected %d", p.SchemaVersion, expectedSchemaVersion)
+	}
+	if p.Currency == "" {
+		return fmt.Errorf("MSK price sheet currency must be non-empty")
+	}
+	if p.StoragePerGBMonth < 0 || p.ProvisionedThroughputPerMBpsMonth < 0 {
+		return fmt.Errorf("MSK price sheet storage prices must be >= 0")
+	}
+	if p.BaselineThroughputMBps <= 0 {
+		return fmt.Errorf("MSK price sheet baseline_throughput_mbps must be > 0 (got %v)", p.BaselineThroughputMBps)
+	}
+	if len(p.Instances) == 0 {
+		return fmt.Errorf("MSK price sheet has no instances")
+	}
+	for name, instance := range p.Instances {
+		if instance.Hourly < 0 {
+			return fmt.Errorf("MSK price sheet %s.hourly must be >= 0 (got %v)", name, instance.Hourly)
+		}
+		if instance.IngressMBps <= 0 || instance.EgressMBps <= 0 || instance.Partitions <= 0 {
+			return fmt.Errorf("MSK price sheet %s ingress, egress and partition capacity must be > 0", name)
+		}
+	}
+	if p.HeadroomFraction < 0 || p.HeadroomFraction > 1 {
+		return fmt.Errorf("MSK price sheet headroom_fraction must be in [0, 1] (got %v)", p.HeadroomFraction)
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+// Package rightsizing recommends cheaper MSK provisioned configurations —
+// fewer brokers, a smaller or Graviton instance type, no provisioned storage
+// throughput — for the months a migration to Confluent Cloud runs, from the
+// throughput, partition and disk metrics kcp collected, and projects the
+// monthly savings from a price sheet of MSK list prices.
+package rightsizing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+const (
+	bytesPerMB    = 1024 * 1024
+	hoursPerMonth = 730
+	// minSavingsFraction keeps rounding noise from being recommended as a
+	// change: a configuration must be at least this much cheaper.
+	minSavingsFraction = 0.01
+)
+
+type Status string
+
+const (
+	// StatusRightsize means a cheaper configuration covers the workload.
+	StatusRightsize Status = "rightsize"
+	// StatusRightSized means no cheaper configuration covers the workload.
+	StatusRightSized Status = "right_sized"
+	// StatusSkipped means the cluster could not be analyzed; Reason says why.
+	StatusSkipped Status = "skipped"
+)
+
+// Configuration is the broker fleet of a provisioned cluster.
+type Configuration struct {
+	InstanceType string
+	Brokers      int
+	// VolumeGB is the EBS volume of each broker.
+	VolumeGB int
+	// ProvisionedThroughputMBps is the provisioned storage throughput of
+	// each broker volume, 0 when not enabled.
+	ProvisionedThroughputMBps int
+}
+
+func (c Configuration) String() string {
+	return fmt.Sprintf("%d × %s", c.Brokers, c.InstanceType)
+}
+
+// Demand is the workload measured on a cluster over the metrics period.
+type Demand struct {
+	PeakIngressMBps float64
+	PeakEgressMBps  float64
+	// PartitionReplicas counts leaders and followers across all brokers.
+	PartitionReplicas int
+	StorageUsedGB     float64
+	// MaxReplicationFactor is the highest topic replication factor, below
+	// which the broker count cannot go.
+	MaxReplicationFactor int
+}
+
+// Recommendation is the rightsizing result of one MSK cluster.
+type Recommendation struct {
+	ClusterName string
+	ClusterArn  string
+	Region      string
+	Status      Status
+	// Reason explains a skipped or right-sized cluster.
+	Reason      string
+	Current     Configuration
+	Recommended Configuration
+	Demand      Demand
+	// Monthly costs of the broker instances, storage and provisioned
+	// throughput at the price sheet's list prices.
+	CurrentMonthly     float64
+	RecommendedMonthly float64
+	// Notes are the caveats of applying the recommendation.
+	Notes []string
+}
+
+func (r Recommendation) MonthlySavings() float64 {
+	return r.CurrentMonthly - r.RecommendedMonthly
+}
+
+// Analyze rightsizes every MSK cluster of a processed state, ordered by
+// descending savings, then by name.
+func Analyze(state report.ProcessedState, sheet *PriceSheet) []Recommendation {
+	var recommendations []Recommendation
+	for _, src := range state.Sources {
+		if src.MSKData == nil {
+			continue
+		}
+		for _, region := range src.MSKData.Regions {
+			for _, cluster := range region.Clusters {
+				recommendation := AnalyzeCluster(cluster, sheet)
+				recommendation.Region = region.Name
+				recommendations = append(recommendations, recommendation)
+			}
+		}
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		si, sj := recommendations[i].MonthlySavings(), recommendations[j].MonthlySavings()
+		if si != sj {
+			return si > sj
+		}
+		return recommendations[i].ClusterName < recommendations[j].ClusterName
+	})
+	return recommendations
+}
+
+// AnalyzeCluster rightsizes one MSK cluster.
+func AnalyzeCluster(cluster report.ProcessedCluster, sheet *PriceSheet) Recommendation {
+	r := Recommendation{ClusterName: cluster.Name, ClusterArn: cluster.Arn, Region: cluster.Region, Status: StatusSkipped}
+
+	config := cluster.AWSClientInformation.MskClusterConfig
+	if config.ClusterType == kafkatypes.ClusterTypeServerless {
+		r.Reason = "serverless clusters are billed by usage and have no brokers to resize"
+		return r
+	}
+	prov := config.Provisioned
+	if prov == nil || prov.BrokerNodeGroupInfo == nil {
+		r.Reason = "the state has no broker configuration for the cluster; run `kcp discover`"
+		return r
+	}
+	current := currentConfiguration(prov)
+	r.Current = current
+	if strings.HasPrefix(current.InstanceType, "express.") {
+		r.Reason = "express brokers have no EBS volumes and are resized by AWS; only the broker count can change"
+		return r
+	}
+	instance, ok := sheet.Instances[current.InstanceType]
+	if !ok {
+		r.Reason = fmt.Sprintf("%s is not in the price sheet", current.InstanceType)
+		return r
+	}
+
+	demand, ok := measureDemand(cluster)
+	if !ok {
+		r.Reason = "no throughput metrics were collected for the cluster; run `kcp discover` with metrics"
+		return r
+	}
+	r.Demand = demand
+	r.CurrentMonthly = monthlyCost(current, instance, sheet)
+
+	azs := max(len(prov.BrokerNodeGroupInfo.ClientSubnets), 1)
+	best, bestCost := current, r.CurrentMonthly
+	for _, candidate := range candidates(current, azs, demand, sheet) {
+		cost := monthlyCost(candidate, sheet.Instances[candidate.InstanceType], sheet)
+		if cost < bestCost {
+			best, bestCost = candidate, cost
+		}
+	}
+
+	if bestCost > r.CurrentMonthly*(1-minSavingsFraction) {
+		r.Status = StatusRightSized
+		r.Reason = "no smaller configuration covers the measured peaks with headroom"
+		r.Recommended, r.RecommendedMonthly = current, r.CurrentMonthly
+		return r
+	}
+	r.Status = StatusRightsize
+	r.Recommended, r.RecommendedMonthly = best, bestCost
+	r.Notes = notes(current, best, demand)
+	return r
+}
+
+func currentConfiguration(prov *kafkatypes.Provisioned) Configuration {
+	c := Configuration{
+		InstanceType: aws.ToString(prov.BrokerNodeGroupInfo.InstanceType),
+		Brokers:      int(aws.ToInt32(prov.NumberOfBrokerNodes)),
+	}
+	if storage := prov.BrokerNodeGroupInfo.StorageInfo; storage != nil && storage.EbsStorageInfo != nil {
+		c.VolumeGB = int(aws.ToInt32(storage.EbsStorageInfo.VolumeSize))
+		if pt := storage.EbsStorageInfo.ProvisionedThroughput; pt != nil && aws.ToBool(pt.Enabled) {
+			c.ProvisionedThroughputMBps = int(aws.ToInt32(pt.VolumeThroughput))
+		}
+	}
+	return c
+}
+
+// measureDemand reads the peak workload from the cluster's metric
+// aggregates. ok is false when throughput was not collected.
+func measureDemand(cluster report.ProcessedCluster) (Demand, bool) {
+	aggregates := cluster.ClusterMetrics.Aggregates
+	in, haveIn := peak(aggregates, "BytesInPerSec")
+	out, haveOut := peak(aggregates, "BytesOutPerSec")
+	if !haveIn || !haveOut {
+		return Demand{}, false
+	}
+
+	d := Demand{PeakIngressMBps: in / bytesPerMB, PeakEgressMBps: out / bytesPerMB}
+	if replicas, ok := peak(aggregates, "PartitionCount"); ok {
+		d.PartitionReplicas = int(replicas)
+	}
+	if used, ok := peak(aggregates, "TotalLocalStorageUsage(GB)"); ok {
+		d.StorageUsedGB = used
+	}
+	if topics := cluster.KafkaAdminClientInformation.Topics; topics != nil {
+		for _, topic := range topics.Details {
+			d.MaxReplicationFactor = max(d.MaxReplicationFactor, topic.ReplicationFactor)
+		}
+	}
+	return d, true
+}
+
+// peak returns the maximum of a metric, falling back to its p95 when the
+// maximum was not recorded.
+func peak(aggregates map[string]types.MetricAggregate, name string) (float64, bool) {
+	aggregate, ok := aggregates[name]
+	switch {
+	case !ok:
+		return 0, false
+	case aggregate.Maximum != nil:
+		return *aggregate.Maximum, true
+	case aggregate.P95 != nil:
+		return *aggregate.P95, true
+	default:
+		return 0, false
+	}
+}
+
+// candidates lists the configurations that cover demand with headroom: every
+// priced instance type (burstable ones only for clusters already on them) at
+// every broker count from the AZ count up to the current count, in steps of
+// the AZ count as MSK requires.
+func candidates(current Configuration, azs int, demand Demand, sheet *PriceSheet) []Configuration {
+	headroom := 1 + sheet.HeadroomFraction
+	minBrokers := max(azs, demand.MaxReplicationFactor)
+	burstable := isBurstable(current.InstanceType)
+
+	names := make([]string, 0, len(sheet.Instances))
+	for name := range sheet.Instances {
+		if isBurstable(name) && !burstable {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []Configuration
+	for _, name := range names {
+		instance := sheet.Instances[name]
+		for brokers := azs; brokers <= current.Brokers; brokers += azs {
+			if brokers < minBrokers ||
+				float64(brokers)*instance.IngressMBps < demand.PeakIngressMBps*headroom ||
+				float64(brokers)*instance.EgressMBps < demand.PeakEgressMBps*headroom ||
+				brokers*instance.Partitions < demand.PartitionReplicas {
+				continue
+			}
+			out = append(out, Configuration{
+				InstanceType:              name,
+				Brokers:                   brokers,
+				VolumeGB:                  volumeFor(current.VolumeGB, brokers, demand.StorageUsedGB),
+				ProvisionedThroughputMBps: throughputFor(current.ProvisionedThroughputMBps, brokers, demand, sheet),
+			})
+		}
+	}
+	return out
+}
+
+func isBurstable(instanceType string) bool {
+	return strings.HasPrefix(instanceType, "kafka.t3.")
+}
+
+// volumeFor is the per-broker volume that keeps the data used on brokers
+// brokers under the storage headroom warning level. EBS volumes cannot
+// shrink, so it is never below the current volume.
+func volumeFor(currentGB, brokers int, usedGB float64) int {
+	needed := int(math.Ceil(usedGB * 100 / (float64(brokers) * report.StorageHeadroomWarningPercent)))
+	return max(currentGB, needed)
+}
+
+// throughputFor keeps provisioned storage throughput only while the
+// replicated write rate on each broker, with headroom, exceeds the volume
+// baseline.
+func throughputFor(currentMBps, brokers int, demand Demand, sheet *PriceSheet) int {
+	if currentMBps == 0 {
+		return 0
+	}
+	replication := float64(max(demand.MaxReplicationFactor, 1))
+	perBroker := demand.PeakIngressMBps * replication / float64(brokers) * (1 + sheet.HeadroomFraction)
+	if perBroker <= sheet.BaselineThroughputMBps {
+		return 0
+	}
+	return currentMBps
+}
+
+func monthlyCost(c Configuration, instance InstancePricing, sheet *PriceSheet) float64 {
+	brokers := float64(c.Brokers)
+	return brokers*instance.Hourly*hoursPerMonth +
+		brokers*float64(c.VolumeGB)*sheet.StoragePerGBMonth +
+		brokers*float64(c.ProvisionedThroughputMBps)*sheet.ProvisionedThroughputPerMBpsMonth
+}
+
+func notes(current, recommended Configuration, demand Demand) []string {
+	var notes []string
+	if recommended.InstanceType != current.InstanceType {
+		notes = append(notes, fmt.Sprintf("Change the broker type from %s to %s (`aws kafka update-broker-type`). Brokers restart one at a time; clients reconnect.", current.InstanceType, recommended.InstanceType))
+	}
+	if recommended.Brokers < current.Brokers {
+		notes = append(notes, fmt.Sprintf("Remove %d broker(s) (`aws kafka update-broker-count`). Reassign their partition replicas to the remaining brokers first; MSK refuses to remove brokers that still host partitions.", current.Brokers-recommended.Brokers))
+	}
+	if recommended.VolumeGB > current.VolumeGB {
+		notes = append(notes, fmt.Sprintf("Grow each broker volume from %d GB to %d GB (`aws kafka update-broker-storage`) before removing brokers, so the remaining brokers stay under %.0f%% disk used.", current.VolumeGB, recommended.VolumeGB, report.StorageHeadroomWarningPercent))
+	}
+	if current.ProvisionedThroughputMBps > 0 && recommended.ProvisionedThroughputMBps == 0 {
+		notes = append(notes, "Turn off provisioned storage throughput (`aws kafka update-storage`); the replicated write rate fits within the volume baseline.")
+	}
+	if current.VolumeGB > 0 && current.Brokers > 0 && demand.StorageUsedGB > 0 {
+		if used := demand.StorageUsedGB / float64(current.Brokers*current.VolumeGB) * 100; used < 30 {
+			notes = append(notes, fmt.Sprintf("Brokers use %.0f%% of their volumes, but EBS volumes cannot shrink; the storage cost falls only with the broker count.", used))
+		}
+	}
+	return notes
+}
@@ -0,0 +1,140 @@
+package rightsizing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/services/report"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mb = 1024 * 1024
+
+func provisionedCluster(name, instanceType string, brokers, volumeGB int) report.ProcessedCluster {
+	c := report.ProcessedCluster{Name: name, Arn: "arn:aws:kafka:us-east-1:123456789012:cluster/" + name + "/1"}
+	c.AWSClientInformation.MskClusterConfig = kafkatypes.Cluster{
+		ClusterType: kafkatypes.ClusterTypeProvisioned,
+		Provisioned: &kafkatypes.Provisioned{
+			NumberOfBrokerNodes: aws.Int32(int32(brokers)),
+			BrokerNodeGroupInfo: &kafkatypes.BrokerNodeGroupInfo{
+				InstanceType:  aws.String(instanceType),
+				ClientSubnets: []string{"subnet-a", "subnet-b", "subnet-c"},
+				StorageInfo: &kafkatypes.StorageInfo{EbsStorageInfo: &kafkatypes.EBSStorageInfo{
+					VolumeSize: aws.Int32(int32(volumeGB)),
+				}},
+			},
+		},
+	}
+	return c
+}
+
+func withMetrics(c report.ProcessedCluster, inMBps, outMBps, replicas, storedGB float64) report.ProcessedCluster {
+	c.ClusterMetrics.Aggregates = map[string]types.MetricAggregate{
+		"BytesInPerSec":              {Maximum: aws.Float64(inMBps * mb)},
+		"BytesOutPerSec":             {P95: aws.Float64(outMBps * mb)},
+		"PartitionCount":             {Maximum: aws.Float64(replicas)},
+		"TotalLocalStorageUsage(GB)": {Maximum: aws.Float64(storedGB)},
+	}
+	return c
+}
+
+func loadSheet(t *testing.T) *PriceSheet {
+	t.Helper()
+	sheet, err := LoadPriceSheet("")
+	require.NoError(t, err)
+	return sheet
+}
+
+func TestAnalyzeCluster_ShrinksOversizedClusterToCheapestFit(t *testing.T) {
+	cluster := withMetrics(provisionedCluster("orders", "kafka.m5.2xlarge", 6, 1000), 20, 40, 1800, 600)
+
+	r := AnalyzeCluster(cluster, loadSheet(t))
+
+	require.Equal(t, StatusRightsize, r.Status, r.Reason)
+	assert.Equal(t, Configuration{InstanceType: "kafka.m7g.large", Brokers: 3, VolumeGB: 1000}, r.Recommended,
+		"3 Graviton brokers carry 26 MB/s in with headroom; burstable types are not offered to an m5 cluster")
+	assert.InDelta(t, 6*0.84*730+6*1000*0.10, r.CurrentMonthly, 0.01)
+	assert.InDelta(t, 3*0.204*730+3*1000*0.10, r.RecommendedMonthly, 0.01)
+	assert.Greater(t, r.MonthlySavings(), 0.0)
+	assert.NotEmpty(t, r.Notes)
+}
+
+func TestAnalyzeCluster_GrowsVolumesAndDropsProvisionedThroughput(t *testing.T) {
+	cluster := withMetrics(provisionedCluster("events", "kafka.m5.large", 6, 500), 10, 10, 600, 2100)
+	ebs := cluster.AWSClientInformation.MskClusterConfig.Provisioned.BrokerNodeGroupInfo.StorageInfo.EbsStorageInfo
+	ebs.ProvisionedThroughput = &kafkatypes.ProvisionedThroughput{Enabled: aws.Bool(true), VolumeThroughput: aws.Int32(300)}
+
+	r := AnalyzeCluster(cluster, loadSheet(t))
+
+	require.Equal(t, StatusRightsize, r.Status, r.Reason)
+	assert.Equal(t, 3, r.Recommended.Brokers)
+	assert.Equal(t, 1000, r.Recommended.VolumeGB, "2100 GB on 3 brokers stays under 70% only with 1000 GB volumes")
+	assert.Equal(t, 0, r.Recommended.ProvisionedThroughputMBps, "13 MB/s in is far below the volume baseline")
+	assert.Equal(t, 300, r.Current.ProvisionedThroughputMBps)
+}
+
+func TestAnalyzeCluster_KeepsBrokersForReplicationFactorAndPartitions(t *testing.T) {
+	busy := withMetrics(provisionedCluster("busy", "kafka.m7g.large", 3, 100), 60, 120, 2900, 50)
+	busy.KafkaAdminClientInformation.Topics = &types.Topics{Details: []types.TopicDetails{{Name: "t", ReplicationFactor: 3}}}
+
+	r := AnalyzeCluster(busy, loadSheet(t))
+
+	assert.Equal(t, StatusRightSized, r.Status)
+	assert.Equal(t, r.Current, r.Recommended)
+	assert.Zero(t, r.MonthlySavings())
+	assert.Equal(t, 3, r.Demand.MaxReplicationFactor)
+}
+
+func TestAnalyzeCluster_SkipsWhatCannotBeRightsized(t *testing.T) {
+	sheet := loadSheet(t)
+
+	serverless := report.ProcessedCluster{Name: "serverless"}
+	serverless.AWSClientInformation.MskClusterConfig.ClusterType = kafkatypes.ClusterTypeServerless
+	express := withMetrics(provisionedCluster("express", "express.m7g.large", 3, 0), 1, 1, 10, 1)
+	unpriced := withMetrics(provisionedCluster("unpriced", "kafka.m5.48xlarge", 3, 100), 1, 1, 10, 1)
+	noMetrics := provisionedCluster("no-metrics", "kafka.m5.large", 3, 100)
+
+	for _, c := range []report.ProcessedCluster{serverless, express, unpriced, noMetrics} {
+		r := AnalyzeCluster(c, sheet)
+		assert.Equal(t, StatusSkipped, r.Status, c.Name)
+		assert.NotEmpty(t, r.Reason, c.Name)
+	}
+}
+
+func TestAnalyze_OrdersBySavings(t *testing.T) {
+	small := withMetrics(provisionedCluster("small", "kafka.m5.large", 6, 100), 1, 1, 100, 1)
+	large := withMetrics(provisionedCluster("large", "kafka.m5.4xlarge", 6, 100), 1, 1, 100, 1)
+	state := report.ProcessedState{Sources: []report.ProcessedSource{{
+		Type: types.SourceTypeMSK,
+		MSKData: &report.ProcessedMSKSource{Regions: []report.ProcessedRegion{
+			{Name: "us-east-1", Clusters: []report.ProcessedCluster{small, large}},
+		}},
+	}}}
+
+	recommendations := Analyze(state, loadSheet(t))
+
+	require.Len(t, recommendations, 2)
+	assert.Equal(t, "large", recommendations[0].ClusterName)
+	assert.Equal(t, "us-east-1", recommendations[0].Region)
+}
+
+func TestLoadPriceSheet_OverrideAndValidation(t *testing.T) {
+	dir := t.TempDir()
+	override := filepath.Join(dir, "prices.yaml")
+	require.NoError(t, os.WriteFile(override, []byte("storage_per_gb_month: 0.11\n"), 0600))
+
+	sheet, err := LoadPriceSheet(override)
+	require.NoError(t, err)
+	assert.Equal(t, 0.11, sheet.StoragePerGBMonth)
+	assert.Contains(t, sheet.Instances, "kafka.m5.large", "an override keeps the fields it does not set")
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("headroom_fraction: 2\n"), 0600))
+	_, err = LoadPriceSheet(invalid)
+	assert.ErrorContains(t, err, "headroom_fraction")
+}
@@ -0,0 +1,186 @@
+// Package scanplugin runs external scanner binaries against scanned clusters
+// so organisations can add their own checks without forking kcp. A plugin is
+// any executable: it receives the cluster context as a JSON object on stdin
+// and writes a JSON object to stdout, which is recorded in the state file
+// under the plugin's name.
+package scanplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+const (
+	defaultTimeout = 2 * time.Minute
+	// maxOutputBytes bounds what a plugin can add to the state file.
+	maxOutputBytes = 4 << 20
+	// maxStderrBytes bounds the stderr kept for error messages.
+	maxStderrBytes = 4 << 10
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Plugin is an external scanner. Name is the key its output is recorded
+// under in kafka_admin_client_information.plugins.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// ParsePlugin parses a --plugin value: either a path, named after the
+// executable without its extension, or name=path.
+func ParsePlugin(raw string) (Plugin, error) {
+	name, path, named := strings.Cut(raw, "=")
+	if !named {
+		path = raw
+		name = strings.TrimSuffix(filepath.Base(raw), filepath.Ext(raw))
+	}
+	if path == "" {
+		return Plugin{}, fmt.Errorf("invalid --plugin %q: missing path", raw)
+	}
+	if !namePattern.MatchString(name) {
+		return Plugin{}, fmt.Errorf("invalid --plugin %q: name %q must start with a letter or digit and contain only letters, digits, '.', '_' and '-'", raw, name)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Plugin{}, fmt.Errorf("invalid --plugin %q: %w", raw, err)
+	}
+	if info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+		return Plugin{}, fmt.Errorf("invalid --plugin %q: %s is not an executable file", raw, path)
+	}
+	return Plugin{Name: name, Path: path}, nil
+}
+
+// ParsePlugins parses every --plugin value and rejects duplicate names, since
+// two plugins would otherwise overwrite each other's output.
+func ParsePlugins(raw []string) ([]Plugin, error) {
+	plugins := make([]Plugin, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		plugin, err := ParsePlugin(r)
+		if err != nil {
+			return nil, err
+		}
+		if seen[plugin.Name] {
+			return nil, fmt.Errorf("invalid --plugin %q: another plugin is already named %q; use name=path to rename one", r, plugin.Name)
+		}
+		seen[plugin.Name] = true
+		plugins = append(plugins, plugin)
+	}
+	return plugins, nil
+}
+
+// ClusterContext is what a plugin receives on stdin. It describes the
+// cluster as kcp scanned it; credentials are never passed to plugins.
+type ClusterContext struct {
+	// SourceType is "msk" or "osk" (Apache Kafka).
+	SourceType       string                             `json:"source_type"`
+	Name             string                             `json:"name"`
+	ID               string                             `json:"id"` // MSK: cluster ARN, Apache Kafka: cluster ID from the credentials file
+	BootstrapServers []string                           `json:"bootstrap_servers"`
+	KafkaAdminInfo   *types.KafkaAdminClientInformation `json:"kafka_admin_client_information,omitempty"`
+	KCPVersion       string                             `json:"kcp_version"`
+	PluginName       string                             `json:"plugin_name"`
+	PluginAPIVersion int                                `json:"plugin_api_version"`
+}
+
+// APIVersion is the version of the ClusterContext contract; it is bumped
+// only when a field is removed or changes meaning.
+const APIVersion = 1
+
+type Runner struct {
+	Timeout time.Duration
+}
+
+func NewRunner() *Runner {
+	return &Runner{Timeout: defaultTimeout}
+}
+
+// Run executes plugin with input on stdin and returns its stdout, which must
+// be a single JSON object. A non-zero exit, a timeout or output that isn't a
+// JSON object is an error that includes what the plugin wrote to stderr.
+func (r *Runner) Run(ctx context.Context, plugin Plugin, input ClusterContext) (json.RawMessage, error) {
+	input.PluginName = plugin.Name
+	input.PluginAPIVersion = APIVersion
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cluster context: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	var stdout, stderr limitedBuffer
+	stdout.limit = maxOutputBytes
+	stderr.limit = maxStderrBytes
+
+	cmd := exec.CommandContext(ctx, plugin.Path)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on grandchildren that inherited stdout after the plugin exits.
+	cmd.WaitDelay = time.Second
+
+	slog.Debug("running scan plugin", "plugin", plugin.Name, "path", plugin.Path, "cluster", input.Name)
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("plugin %s timed out after %s", plugin.Name, r.Timeout)
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w%s", plugin.Name, err, stderrSuffix(stderr.String()))
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("plugin %s wrote more than %d bytes to stdout", plugin.Name, maxOutputBytes)
+	}
+
+	output := bytes.TrimSpace(stdout.Bytes())
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(output, &object); err != nil || object == nil {
+		return nil, fmt.Errorf("plugin %s must write a JSON object to stdout%s", plugin.Name, stderrSuffix(stderr.String()))
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, output); err != nil {
+		return nil, fmt.Errorf("plugin %s wrote invalid JSON: %w", plugin.Name, err)
+	}
+	return compact.Bytes(), nil
+}
+
+func stderrSuffix(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if stderr == "" {
+		return ""
+	}
+	return ": " + stderr
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a runaway plugin can't exhaust memory.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
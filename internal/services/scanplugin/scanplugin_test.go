@@ -0,0 +1,85 @@
+package scanplugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin writes an executable shell script plugin to a temp dir.
+func writePlugin(t *testing.T, name, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestParsePlugin(t *testing.T) {
+	path := writePlugin(t, "naming-policy.sh", "echo '{}'\n")
+
+	plugin, err := ParsePlugin(path)
+	require.NoError(t, err)
+	assert.Equal(t, Plugin{Name: "naming-policy", Path: path}, plugin)
+
+	plugin, err = ParsePlugin("naming=" + path)
+	require.NoError(t, err)
+	assert.Equal(t, Plugin{Name: "naming", Path: path}, plugin)
+
+	_, err = ParsePlugin("bad name=" + path)
+	assert.ErrorContains(t, err, `name "bad name" must start with a letter or digit`)
+
+	notExecutable := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(notExecutable, []byte("{}"), 0o644))
+	_, err = ParsePlugin(notExecutable)
+	assert.ErrorContains(t, err, "is not an executable file")
+
+	_, err = ParsePlugins([]string{path, "naming-policy=" + path})
+	assert.ErrorContains(t, err, `another plugin is already named "naming-policy"`)
+}
+
+func TestRun_PassesContextAndReturnsObject(t *testing.T) {
+	// Echo back the fields the plugin received, proving the context arrives on stdin.
+	path := writePlugin(t, "echo", `input=$(cat)
+case "$input" in
+  *'"name":"orders"'*'"plugin_name":"echo"'*'"plugin_api_version":1'*) echo '{ "saw_orders": true }' ;;
+  *) echo "unexpected input: $input" >&2; exit 1 ;;
+esac
+`)
+
+	output, err := NewRunner().Run(context.Background(), Plugin{Name: "echo", Path: path}, ClusterContext{
+		SourceType:     "msk",
+		Name:           "orders",
+		ID:             "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1",
+		KafkaAdminInfo: &types.KafkaAdminClientInformation{ClusterID: "lkc-orders"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"saw_orders":true}`, string(output))
+	assert.Equal(t, `{"saw_orders":true}`, string(output), "output is compacted")
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		wantErr string
+	}{
+		{"non-zero exit", "echo 'cannot reach inventory' >&2; exit 3\n", time.Minute, "plugin p failed: exit status 3: cannot reach inventory"},
+		{"not JSON", "echo 'all good'\n", time.Minute, "plugin p must write a JSON object to stdout"},
+		{"JSON array", "echo '[1, 2]'\n", time.Minute, "plugin p must write a JSON object to stdout"},
+		{"timeout", "sleep 5\n", 100 * time.Millisecond, "plugin p timed out after 100ms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &Runner{Timeout: tt.timeout}
+			_, err := runner.Run(context.Background(), Plugin{Name: "p", Path: writePlugin(t, "p", tt.script)}, ClusterContext{Name: "orders"})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
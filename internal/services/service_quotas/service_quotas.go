@@ -0,0 +1,357 @@
+// Package service_quotas checks, before migration Terraform is generated,
+// that the AWS account has enough Service Quotas headroom in the target
+// region for the EC2 instances, Elastic IPs, network interfaces and interface
+// VPC endpoints the generated Terraform creates. Unlike subnet_capacity it
+// queries AWS: the limit from Service Quotas and the current usage from EC2.
+package service_quotas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	"github.com/confluentinc/kcp/internal/client"
+)
+
+// Quota identifies one Service Quotas quota.
+type Quota struct {
+	ServiceCode string
+	QuotaCode   string
+	Name        string
+}
+
+var (
+	QuotaOnDemandStandardVCPUs = Quota{ServiceCode: "ec2", QuotaCode: "L-1216C47A", Name: "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances (vCPUs)"}
+	QuotaSpotStandardVCPUs     = Quota{ServiceCode: "ec2", QuotaCode: "L-34B43A08", Name: "All Standard (A, C, D, H, I, M, R, T, Z) Spot Instance Requests (vCPUs)"}
+	QuotaElasticIPs            = Quota{ServiceCode: "ec2", QuotaCode: "L-0263D0A3", Name: "EC2-VPC Elastic IPs"}
+	QuotaNetworkInterfaces     = Quota{ServiceCode: "vpc", QuotaCode: "L-DF5E4CA3", Name: "Network interfaces per Region"}
+	QuotaInterfaceEndpoints    = Quota{ServiceCode: "vpc", QuotaCode: "L-29B6F2EB", Name: "Interface VPC endpoints per VPC"}
+)
+
+type Severity string
+
+const (
+	// SeverityOK findings report a quota the planned resources fit in.
+	SeverityOK Severity = "ok"
+	// SeverityWarning findings are quotas that could not be checked.
+	SeverityWarning Severity = "warning"
+	// SeverityError findings mean `terraform apply` would hit the quota.
+	SeverityError Severity = "error"
+)
+
+type Finding struct {
+	Severity Severity
+	Quota    Quota
+	Message  string
+}
+
+// HasErrors reports whether any finding is an error.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+// Instance is one EC2 instance the generated assets launch.
+type Instance struct {
+	InstanceType string
+	Spot         bool
+}
+
+// Demand is what the generated assets create in the target region.
+type Demand struct {
+	Instances          []Instance
+	ElasticIPs         int
+	NetworkInterfaces  int
+	InterfaceEndpoints int
+	// VpcID is the VPC the interface endpoints are created in; the
+	// endpoint quota is per VPC.
+	VpcID string
+}
+
+type serviceQuotasAPI interface {
+	GetServiceQuota(ctx context.Context, params *servicequotas.GetServiceQuotaInput, optFns ...func(*servicequotas.Options)) (*servicequotas.GetServiceQuotaOutput, error)
+	GetAWSDefaultServiceQuota(ctx context.Context, params *servicequotas.GetAWSDefaultServiceQuotaInput, optFns ...func(*servicequotas.Options)) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error)
+}
+
+type ec2API interface {
+	DescribeAddresses(ctx context.Context, params *ec2.DescribeAddressesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error)
+	ec2.DescribeInstancesAPIClient
+	ec2.DescribeNetworkInterfacesAPIClient
+	ec2.DescribeVpcEndpointsAPIClient
+	ec2.DescribeInstanceTypesAPIClient
+}
+
+type Checker struct {
+	quotas serviceQuotasAPI
+	ec2    ec2API
+}
+
+func NewChecker(quotas serviceQuotasAPI, ec2Client ec2API) *Checker {
+	return &Checker{quotas: quotas, ec2: ec2Client}
+}
+
+// NewRegionChecker returns a Checker for region using the default AWS
+// credentials.
+func NewRegionChecker(region string) (*Checker, error) {
+	quotasClient, err := client.NewServiceQuotasClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Service Quotas client: %w", err)
+	}
+	ec2Client, err := client.NewEC2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EC2 client: %w", err)
+	}
+	return NewChecker(quotasClient, ec2Client), nil
+}
+
+// Check compares demand with the remaining headroom of every quota it
+// touches and returns one finding per quota. A quota whose limit or usage
+// cannot be read is reported as a warning rather than failing the check.
+func (c *Checker) Check(ctx context.Context, demand Demand) []Finding {
+	var findings []Finding
+
+	if len(demand.Instances) > 0 {
+		findings = append(findings, c.checkInstances(ctx, demand.Instances)...)
+	}
+	if demand.ElasticIPs > 0 {
+		findings = append(findings, c.checkQuota(ctx, QuotaElasticIPs, demand.ElasticIPs, "Elastic IPs", c.countElasticIPs))
+	}
+	if demand.NetworkInterfaces > 0 {
+		findings = append(findings, c.checkQuota(ctx, QuotaNetworkInterfaces, demand.NetworkInterfaces, "network interfaces", c.countNetworkInterfaces))
+	}
+	if demand.InterfaceEndpoints > 0 {
+		countEndpoints := func(ctx context.Context) (int, error) { return c.countInterfaceEndpoints(ctx, demand.VpcID) }
+		findings = append(findings, c.checkQuota(ctx, QuotaInterfaceEndpoints, demand.InterfaceEndpoints, "interface endpoints", countEndpoints))
+	}
+	return findings
+}
+
+// checkInstances splits the planned instances into the on-demand and spot
+// standard vCPU quotas. Instance types outside the standard families count
+// against quotas of their own, which are not checked.
+func (c *Checker) checkInstances(ctx context.Context, instances []Instance) []Finding {
+	var findings []Finding
+
+	vcpus, err := c.instanceTypeVCPUs(ctx, instances)
+	if err != nil {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Quota:    QuotaOnDemandStandardVCPUs,
+			Message:  fmt.Sprintf("could not look up instance type vCPUs, EC2 instance quotas not checked: %v", err),
+		}}
+	}
+
+	var onDemand, spot int
+	for _, instance := range instances {
+		if !isStandardFamily(instance.InstanceType) {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%s is not a standard (A, C, D, H, I, M, R, T, Z) instance type; check its instance family quota manually", instance.InstanceType),
+			})
+			continue
+		}
+		if instance.Spot {
+			spot += vcpus[instance.InstanceType]
+		} else {
+			onDemand += vcpus[instance.InstanceType]
+		}
+	}
+
+	if onDemand > 0 {
+		countOnDemand := func(ctx context.Context) (int, error) { return c.countStandardVCPUs(ctx, false) }
+		findings = append(findings, c.checkQuota(ctx, QuotaOnDemandStandardVCPUs, onDemand, "on-demand vCPUs", countOnDemand))
+	}
+	if spot > 0 {
+		countSpot := func(ctx context.Context) (int, error) { return c.countStandardVCPUs(ctx, true) }
+		findings = append(findings, c.checkQuota(ctx, QuotaSpotStandardVCPUs, spot, "spot vCPUs", countSpot))
+	}
+	return findings
+}
+
+func (c *Checker) checkQuota(ctx context.Context, quota Quota, needed int, unit string, countUsage func(context.Context) (int, error)) Finding {
+	limit, err := c.quotaValue(ctx, quota)
+	if err != nil {
+		return Finding{Severity: SeverityWarning, Quota: quota, Message: fmt.Sprintf("%s: could not read the quota: %v", quota.Name, err)}
+	}
+	used, err := countUsage(ctx)
+	if err != nil {
+		return Finding{Severity: SeverityWarning, Quota: quota, Message: fmt.Sprintf("%s: could not count current usage: %v", quota.Name, err)}
+	}
+	return evaluate(quota, limit, used, needed, unit)
+}
+
+// evaluate compares the planned usage with what is left of the quota.
+func evaluate(quota Quota, limit, used, needed int, unit string) Finding {
+	available := max(limit-used, 0)
+	message := fmt.Sprintf("%s: %d %s needed, %d of %d available", quota.Name, needed, unit, available, limit)
+	if needed > available {
+		return Finding{
+			Severity: SeverityError,
+			Quota:    quota,
+			Message:  fmt.Sprintf("%s; request an increase of %s (%s) in Service Quotas", message, quota.QuotaCode, quota.ServiceCode),
+		}
+	}
+	return Finding{Severity: SeverityOK, Quota: quota, Message: message}
+}
+
+// quotaValue returns the applied quota of the account, or the AWS default
+// when the account has never had the quota changed.
+func (c *Checker) quotaValue(ctx context.Context, quota Quota) (int, error) {
+	out, err := c.quotas.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(quota.ServiceCode),
+		QuotaCode:   aws.String(quota.QuotaCode),
+	})
+	var noSuchResource *servicequotastypes.NoSuchResourceException
+	if errors.As(err, &noSuchResource) {
+		defaultOut, defaultErr := c.quotas.GetAWSDefaultServiceQuota(ctx, &servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: aws.String(quota.ServiceCode),
+			QuotaCode:   aws.String(quota.QuotaCode),
+		})
+		if defaultErr != nil {
+			return 0, defaultErr
+		}
+		return quotaInt(defaultOut.Quota)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return quotaInt(out.Quota)
+}
+
+func quotaInt(quota *servicequotastypes.ServiceQuota) (int, error) {
+	if quota == nil || quota.Value == nil {
+		return 0, fmt.Errorf("quota has no value")
+	}
+	return int(*quota.Value), nil
+}
+
+func (c *Checker) instanceTypeVCPUs(ctx context.Context, instances []Instance) (map[string]int, error) {
+	var instanceTypes []ec2types.InstanceType
+	for _, instance := range instances {
+		instanceType := ec2types.InstanceType(instance.InstanceType)
+		if !slices.Contains(instanceTypes, instanceType) {
+			instanceTypes = append(instanceTypes, instanceType)
+		}
+	}
+
+	vcpus := map[string]int{}
+	paginator := ec2.NewDescribeInstanceTypesPaginator(c.ec2, &ec2.DescribeInstanceTypesInput{InstanceTypes: instanceTypes})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range page.InstanceTypes {
+			if info.VCpuInfo != nil && info.VCpuInfo.DefaultVCpus != nil {
+				vcpus[string(info.InstanceType)] = int(*info.VCpuInfo.DefaultVCpus)
+			}
+		}
+	}
+	for _, instanceType := range instanceTypes {
+		if _, ok := vcpus[string(instanceType)]; !ok {
+			return nil, fmt.Errorf("instance type %s not found", instanceType)
+		}
+	}
+	return vcpus, nil
+}
+
+// countStandardVCPUs sums the vCPUs of the pending and running standard
+// family instances, either the spot or the on-demand ones.
+func (c *Checker) countStandardVCPUs(ctx context.Context, spot bool) (int, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("instance-state-name"), Values: []string{"pending", "running"}}},
+	}
+	total := 0
+	paginator := ec2.NewDescribeInstancesPaginator(c.ec2, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if (instance.InstanceLifecycle == ec2types.InstanceLifecycleTypeSpot) != spot || !isStandardFamily(string(instance.InstanceType)) {
+					continue
+				}
+				if instance.CpuOptions != nil && instance.CpuOptions.CoreCount != nil && instance.CpuOptions.ThreadsPerCore != nil {
+					total += int(*instance.CpuOptions.CoreCount * *instance.CpuOptions.ThreadsPerCore)
+				}
+			}
+		}
+	}
+	return total, nil
+}
+
+func (c *Checker) countElasticIPs(ctx context.Context) (int, error) {
+	out, err := c.ec2.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("domain"), Values: []string{"vpc"}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(out.Addresses), nil
+}
+
+func (c *Checker) countNetworkInterfaces(ctx context.Context) (int, error) {
+	total := 0
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(c.ec2, &ec2.DescribeNetworkInterfacesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += len(page.NetworkInterfaces)
+	}
+	return total, nil
+}
+
+func (c *Checker) countInterfaceEndpoints(ctx context.Context, vpcID string) (int, error) {
+	input := &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("vpc-id"), Values: []string{vpcID}},
+			{Name: aws.String("vpc-endpoint-type"), Values: []string{string(ec2types.VpcEndpointTypeInterface)}},
+		},
+	}
+	total := 0
+	paginator := ec2.NewDescribeVpcEndpointsPaginator(c.ec2, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, endpoint := range page.VpcEndpoints {
+			if !slices.ContainsFunc(inactiveEndpointStates, func(state ec2types.State) bool { return strings.EqualFold(string(endpoint.State), string(state)) }) {
+				total++
+			}
+		}
+	}
+	return total, nil
+}
+
+// inactiveEndpointStates are the VPC endpoint states that no longer count
+// against the quota. The API reports them in either case.
+var inactiveEndpointStates = []ec2types.State{ec2types.StateDeleted, ec2types.StateDeleting, ec2types.StateFailed, ec2types.StateRejected}
+
+// nonStandardPrefixes are instance families whose first letter is a standard
+// one but which have quotas of their own.
+var nonStandardPrefixes = []string{"dl", "hpc", "inf", "mac", "trn", "u-"}
+
+// isStandardFamily reports whether instanceType counts against the standard
+// (A, C, D, H, I, M, R, T, Z) instance quotas.
+func isStandardFamily(instanceType string) bool {
+	if instanceType == "" {
+		return false
+	}
+	for _, prefix := range nonStandardPrefixes {
+		if strings.HasPrefix(instanceType, prefix) {
+			return false
+		}
+	}
+	return strings.ContainsRune("acdhimrtz", rune(instanceType[0]))
+}
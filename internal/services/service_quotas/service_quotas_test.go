@@ -0,0 +1,183 @@
+package service_quotas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuotas serves applied quotas; codes missing from applied fall back
+// to defaults, like an account that never requested an increase.
+type fakeQuotas struct {
+	applied  map[string]float64
+	defaults map[string]float64
+}
+
+func (f *fakeQuotas) GetServiceQuota(_ context.Context, params *servicequotas.GetServiceQuotaInput, _ ...func(*servicequotas.Options)) (*servicequotas.GetServiceQuotaOutput, error) {
+	value, ok := f.applied[*params.QuotaCode]
+	if !ok {
+		return nil, &servicequotastypes.NoSuchResourceException{Message: aws.String("not applied")}
+	}
+	return &servicequotas.GetServiceQuotaOutput{Quota: &servicequotastypes.ServiceQuota{Value: aws.Float64(value)}}, nil
+}
+
+func (f *fakeQuotas) GetAWSDefaultServiceQuota(_ context.Context, params *servicequotas.GetAWSDefaultServiceQuotaInput, _ ...func(*servicequotas.Options)) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error) {
+	value, ok := f.defaults[*params.QuotaCode]
+	if !ok {
+		return nil, &servicequotastypes.AccessDeniedException{Message: aws.String("denied")}
+	}
+	return &servicequotas.GetAWSDefaultServiceQuotaOutput{Quota: &servicequotastypes.ServiceQuota{Value: aws.Float64(value)}}, nil
+}
+
+type fakeEC2 struct {
+	instances  []ec2types.Instance
+	addresses  int
+	interfaces int
+	endpoints  []ec2types.VpcEndpoint
+}
+
+func (f *fakeEC2) DescribeAddresses(context.Context, *ec2.DescribeAddressesInput, ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
+	return &ec2.DescribeAddressesOutput{Addresses: make([]ec2types.Address, f.addresses)}, nil
+}
+
+func (f *fakeEC2) DescribeInstances(context.Context, *ec2.DescribeInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: f.instances}}}, nil
+}
+
+func (f *fakeEC2) DescribeNetworkInterfaces(context.Context, *ec2.DescribeNetworkInterfacesInput, ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: make([]ec2types.NetworkInterface, f.interfaces)}, nil
+}
+
+func (f *fakeEC2) DescribeVpcEndpoints(context.Context, *ec2.DescribeVpcEndpointsInput, ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: f.endpoints}, nil
+}
+
+func (f *fakeEC2) DescribeInstanceTypes(_ context.Context, params *ec2.DescribeInstanceTypesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	vcpus := map[ec2types.InstanceType]int32{"m5.xlarge": 4, "t2.medium": 2, "p4d.24xlarge": 96}
+	out := &ec2.DescribeInstanceTypesOutput{}
+	for _, instanceType := range params.InstanceTypes {
+		if n, ok := vcpus[instanceType]; ok {
+			out.InstanceTypes = append(out.InstanceTypes, ec2types.InstanceTypeInfo{InstanceType: instanceType, VCpuInfo: &ec2types.VCpuInfo{DefaultVCpus: aws.Int32(n)}})
+		}
+	}
+	return out, nil
+}
+
+func runningInstance(instanceType string, cores int32, lifecycle ec2types.InstanceLifecycleType) ec2types.Instance {
+	return ec2types.Instance{
+		InstanceType:      ec2types.InstanceType(instanceType),
+		InstanceLifecycle: lifecycle,
+		CpuOptions:        &ec2types.CpuOptions{CoreCount: aws.Int32(cores), ThreadsPerCore: aws.Int32(2)},
+	}
+}
+
+func findingFor(t *testing.T, findings []Finding, quota Quota) Finding {
+	t.Helper()
+	for _, f := range findings {
+		if f.Quota == quota {
+			return f
+		}
+	}
+	t.Fatalf("no finding for %s in %v", quota.Name, findings)
+	return Finding{}
+}
+
+func TestCheck_JumpClusterDemand(t *testing.T) {
+	quotas := &fakeQuotas{
+		applied:  map[string]float64{QuotaOnDemandStandardVCPUs.QuotaCode: 32},
+		defaults: map[string]float64{QuotaElasticIPs.QuotaCode: 5, QuotaNetworkInterfaces.QuotaCode: 5000, QuotaSpotStandardVCPUs.QuotaCode: 8},
+	}
+	ec2Client := &fakeEC2{
+		// 16 on-demand vCPUs in use; the spot instance and the GPU instance
+		// count against other quotas.
+		instances: []ec2types.Instance{
+			runningInstance("m5.4xlarge", 8, ""),
+			runningInstance("m5.large", 1, ec2types.InstanceLifecycleTypeSpot),
+			runningInstance("p3.2xlarge", 4, ""),
+		},
+		addresses:  5,
+		interfaces: 120,
+	}
+
+	// Three m5.xlarge brokers and a t2.medium setup host need 14 vCPUs; the
+	// NAT gateway needs one Elastic IP.
+	demand := Demand{
+		Instances:         []Instance{{InstanceType: "m5.xlarge"}, {InstanceType: "m5.xlarge"}, {InstanceType: "m5.xlarge"}, {InstanceType: "t2.medium"}},
+		ElasticIPs:        1,
+		NetworkInterfaces: 5,
+	}
+	findings := NewChecker(quotas, ec2Client).Check(context.Background(), demand)
+	require.Len(t, findings, 3)
+
+	vcpus := findingFor(t, findings, QuotaOnDemandStandardVCPUs)
+	assert.Equal(t, SeverityOK, vcpus.Severity)
+	assert.Contains(t, vcpus.Message, "14 on-demand vCPUs needed, 16 of 32 available")
+
+	eips := findingFor(t, findings, QuotaElasticIPs)
+	assert.Equal(t, SeverityError, eips.Severity)
+	assert.Contains(t, eips.Message, "1 Elastic IPs needed, 0 of 5 available")
+	assert.Contains(t, eips.Message, "L-0263D0A3")
+
+	assert.Equal(t, SeverityOK, findingFor(t, findings, QuotaNetworkInterfaces).Severity)
+	assert.True(t, HasErrors(findings))
+}
+
+func TestCheck_SpotAndNonStandardInstances(t *testing.T) {
+	quotas := &fakeQuotas{defaults: map[string]float64{QuotaSpotStandardVCPUs.QuotaCode: 8}}
+	ec2Client := &fakeEC2{instances: []ec2types.Instance{runningInstance("m5.large", 1, ec2types.InstanceLifecycleTypeSpot)}}
+
+	demand := Demand{Instances: []Instance{{InstanceType: "m5.xlarge", Spot: true}, {InstanceType: "p4d.24xlarge"}}}
+	findings := NewChecker(quotas, ec2Client).Check(context.Background(), demand)
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, SeverityWarning, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "p4d.24xlarge is not a standard")
+
+	spot := findingFor(t, findings, QuotaSpotStandardVCPUs)
+	assert.Equal(t, SeverityOK, spot.Severity)
+	assert.Contains(t, spot.Message, "4 spot vCPUs needed, 6 of 8 available")
+}
+
+func TestCheck_InterfaceEndpointsAndUnreadableQuota(t *testing.T) {
+	quotas := &fakeQuotas{applied: map[string]float64{QuotaInterfaceEndpoints.QuotaCode: 2}}
+	ec2Client := &fakeEC2{endpoints: []ec2types.VpcEndpoint{
+		{State: ec2types.State("available")},
+		{State: ec2types.State("deleted")},
+	}}
+
+	demand := Demand{InterfaceEndpoints: 1, NetworkInterfaces: 3, VpcID: "vpc-123"}
+	findings := NewChecker(quotas, ec2Client).Check(context.Background(), demand)
+	require.Len(t, findings, 2)
+
+	endpoints := findingFor(t, findings, QuotaInterfaceEndpoints)
+	assert.Equal(t, SeverityOK, endpoints.Severity, "deleted endpoints do not count")
+	assert.Contains(t, endpoints.Message, "1 interface endpoints needed, 1 of 2 available")
+
+	interfaces := findingFor(t, findings, QuotaNetworkInterfaces)
+	assert.Equal(t, SeverityWarning, interfaces.Severity)
+	assert.Contains(t, interfaces.Message, "could not read the quota")
+	assert.False(t, HasErrors(findings))
+}
+
+func TestIsStandardFamily(t *testing.T) {
+	for instanceType, want := range map[string]bool{
+		"m5.xlarge":    true,
+		"t2.medium":    true,
+		"r6g.large":    true,
+		"z1d.large":    true,
+		"p4d.24xlarge": false,
+		"inf2.xlarge":  false,
+		"trn1.2xlarge": false,
+		"x2idn.large":  false,
+		"":             false,
+	} {
+		assert.Equal(t, want, isStandardFamily(instanceType), instanceType)
+	}
+}
@@ -0,0 +1,154 @@
+package sink
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/audit"
+	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/summary"
+)
+
+// BundleManifestName is the manifest every bundle starts with.
+const BundleManifestName = "manifest.json"
+
+// BundleManifest describes a bundle's contents so a recipient can check that
+// nothing was truncated or altered on the way.
+type BundleManifest struct {
+	KcpVersion string       `json:"kcp_version"`
+	Command    string       `json:"command"`
+	CreatedAt  time.Time    `json:"created_at"`
+	Files      []BundleFile `json:"files"`
+}
+
+// BundleFile is one artifact in a bundle. Size and SHA256 are those of the
+// bundled, redacted copy, not of the local file.
+type BundleFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BundleArtifacts writes the artifacts of command, plus the audit log when
+// one was written, to a single gzip-compressed tar next to the first
+// artifact, e.g. kcp-scan-clusters_2025-01-02_15-04-05.tar.gz, and returns
+// its path. A bundle is meant to be attached to tickets or sent to support,
+// so JSON artifacts are redacted as they are for --upload-to.
+func BundleArtifacts(command string, localPaths ...string) (string, error) {
+	if len(localPaths) == 0 {
+		return "", fmt.Errorf("no artifacts to bundle")
+	}
+	if logPath := audit.Path(); logPath != "" {
+		if _, err := os.Stat(logPath); err == nil {
+			localPaths = append(localPaths, logPath)
+		}
+	}
+
+	slug := strings.Join(strings.Fields(command), "-")
+	bundlePath := filepath.Join(filepath.Dir(localPaths[0]), fmt.Sprintf("%s_%s.tar.gz", slug, time.Now().Format("2006-01-02_15-04-05")))
+	manifest, err := WriteBundle(bundlePath, command, localPaths...)
+	if err != nil {
+		return "", err
+	}
+
+	var size int64
+	for _, f := range manifest.Files {
+		size += f.Size
+	}
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("✅ Bundled %d artifacts (%s) into %s (%s)\n", len(manifest.Files), formatBytes(size), bundlePath, formatBytes(info.Size()))
+	return bundlePath, nil
+}
+
+// WriteBundle writes the artifacts to a gzip-compressed tar at bundlePath,
+// under their base names, after a manifest.json listing each one's size and
+// SHA-256. JSON artifacts are redacted for upload first.
+func WriteBundle(bundlePath, command string, localPaths ...string) (BundleManifest, error) {
+	manifest := BundleManifest{
+		KcpVersion: build_info.Version,
+		Command:    command,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	bodies := make([][]byte, len(localPaths))
+	seen := make(map[string]bool)
+	for i, localPath := range localPaths {
+		name := filepath.Base(localPath)
+		if seen[name] || name == BundleManifestName {
+			return BundleManifest{}, fmt.Errorf("cannot bundle %s: an artifact named %s is already in the bundle", localPath, name)
+		}
+		seen[name] = true
+
+		body, err := uploadBody(localPath)
+		if err != nil {
+			return BundleManifest{}, err
+		}
+		sum := sha256.Sum256(body)
+		bodies[i] = body
+		manifest.Files = append(manifest.Files, BundleFile{Name: name, Size: int64(len(body)), SHA256: hex.EncodeToString(sum[:])})
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+
+	file, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("failed to create bundle %s: %w", bundlePath, err)
+	}
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	writeErr := writeTarEntry(tw, BundleManifestName, manifestJSON, manifest.CreatedAt)
+	for i := 0; writeErr == nil && i < len(bodies); i++ {
+		writeErr = writeTarEntry(tw, manifest.Files[i].Name, bodies[i], manifest.CreatedAt)
+	}
+	for _, closeErr := range []error{tw.Close(), gz.Close(), file.Close()} {
+		if writeErr == nil {
+			writeErr = closeErr
+		}
+	}
+	if writeErr != nil {
+		_ = os.Remove(bundlePath)
+		return BundleManifest{}, fmt.Errorf("failed to write bundle %s: %w", bundlePath, writeErr)
+	}
+	summary.FileWritten(bundlePath)
+	return manifest, nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, body []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(body)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(body)
+	return err
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,93 @@
+package sink
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBundle(t *testing.T, path string) map[string][]byte {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = body
+	}
+	return entries
+}
+
+func TestWriteBundle_ManifestChecksumsMatchContents(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "kcp-state.json")
+	reportPath := filepath.Join(dir, "report.md")
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"msk_sources":{"regions":[]}}`), 0644))
+	require.NoError(t, os.WriteFile(reportPath, []byte("# Report\n"), 0644))
+
+	bundlePath := filepath.Join(dir, "bundle.tar.gz")
+	manifest, err := WriteBundle(bundlePath, "kcp scan clusters", statePath, reportPath)
+	require.NoError(t, err)
+	assert.Equal(t, "kcp scan clusters", manifest.Command)
+	require.Len(t, manifest.Files, 2)
+
+	entries := readBundle(t, bundlePath)
+	require.Len(t, entries, 3)
+
+	var bundled BundleManifest
+	require.NoError(t, json.Unmarshal(entries[BundleManifestName], &bundled))
+	assert.Equal(t, manifest.Files, bundled.Files)
+	for _, f := range bundled.Files {
+		body, ok := entries[f.Name]
+		require.True(t, ok, "manifest names %s", f.Name)
+		sum := sha256.Sum256(body)
+		assert.Equal(t, hex.EncodeToString(sum[:]), f.SHA256)
+		assert.Equal(t, int64(len(body)), f.Size)
+	}
+	assert.Equal(t, "# Report\n", string(entries["report.md"]))
+}
+
+func TestWriteBundle_RejectsDuplicateNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"a", "b"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, sub), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, sub, "kcp-state.json"), []byte("{}"), 0644))
+	}
+
+	bundlePath := filepath.Join(dir, "bundle.tar.gz")
+	_, err := WriteBundle(bundlePath, "kcp scan kafka", filepath.Join(dir, "a", "kcp-state.json"), filepath.Join(dir, "b", "kcp-state.json"))
+	assert.ErrorContains(t, err, "already in the bundle")
+	assert.NoFileExists(t, bundlePath)
+}
+
+func TestBundleArtifacts_WritesNextToFirstArtifact(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "kcp-state.json")
+	require.NoError(t, os.WriteFile(statePath, []byte("{}"), 0644))
+
+	bundlePath, err := BundleArtifacts("kcp scan flow-logs", statePath)
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(bundlePath))
+	assert.Regexp(t, `^kcp-scan-flow-logs_\d{4}-\d{2}-\d{2}_\d{2}-\d{2}-\d{2}\.tar\.gz$`, filepath.Base(bundlePath))
+	assert.Contains(t, readBundle(t, bundlePath), "kcp-state.json")
+}
@@ -0,0 +1,218 @@
+// Package sink delivers finished kcp artifacts (state files, markdown and JSON
+// reports) to a destination other than the local working directory.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/redact"
+	s3service "github.com/confluentinc/kcp/internal/services/s3"
+)
+
+// Sink receives an artifact kcp has already written locally and returns where
+// the copy now lives.
+type Sink interface {
+	Put(ctx context.Context, localPath string) (string, error)
+}
+
+type bucketLocator interface {
+	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+}
+
+type objectPutter interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3Sink uploads artifacts under s3://<bucket>/<prefix>/<file name>. Objects are
+// always written with SSE-S3 (AES256) so reports containing cluster topology
+// are never stored unencrypted, whatever the bucket default is.
+type S3Sink struct {
+	client objectPutter
+	bucket string
+	prefix string
+}
+
+func NewS3Sink(client objectPutter, uri string) (*S3Sink, error) {
+	bucket, prefix, err := s3service.NewS3Service(nil).ParseS3URI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Sink{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+func (s *S3Sink) Put(ctx context.Context, localPath string) (string, error) {
+	body, err := uploadBody(localPath)
+	if err != nil {
+		return "", err
+	}
+
+	key := path.Join(s.prefix, filepath.Base(localPath))
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:               &s.bucket,
+		Key:                  &key,
+		Body:                 bytes.NewReader(body),
+		ContentType:          contentType(localPath),
+		ServerSideEncryption: s3types.ServerSideEncryptionAes256,
+	}); err != nil {
+		return "", fmt.Errorf("failed to upload %s to s3://%s/%s: %w", localPath, s.bucket, key, err)
+	}
+
+	return fmt.Sprintf("s3://%s/%s", s.bucket, key), nil
+}
+
+// ValidateUploadTo checks an --upload-to value before a command does any work,
+// so a typo fails fast rather than after a long scan. An empty value is valid.
+func ValidateUploadTo(uploadTo string) error {
+	if uploadTo == "" {
+		return nil
+	}
+	if _, _, err := s3service.NewS3Service(nil).ParseS3URI(uploadTo); err != nil {
+		return fmt.Errorf("invalid --upload-to '%s': %w", uploadTo, err)
+	}
+	return nil
+}
+
+// UploadArtifacts copies each local file to the --upload-to destination. It
+// is a no-op when uploadTo is empty so commands can call it unconditionally.
+func UploadArtifacts(ctx context.Context, uploadTo string, localPaths ...string) error {
+	if uploadTo == "" {
+		return nil
+	}
+
+	bucket, _, err := s3service.NewS3Service(nil).ParseS3URI(uploadTo)
+	if err != nil {
+		return err
+	}
+
+	locator, err := client.NewS3Client("")
+	if err != nil {
+		return fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	if locator.Options().Region == "" {
+		// GetBucketLocation answers for buckets in any commercial region.
+		if locator, err = client.NewS3Client(defaultBucketRegion); err != nil {
+			return fmt.Errorf("failed to create S3 client: %w", err)
+		}
+	}
+
+	region, err := bucketRegion(ctx, locator, bucket)
+	if err != nil {
+		return err
+	}
+
+	s3Client, err := client.NewS3Client(region)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	s3Sink, err := NewS3Sink(s3Client, uploadTo)
+	if err != nil {
+		return err
+	}
+
+	return upload(ctx, s3Sink, localPaths)
+}
+
+// DeliverArtifacts bundles the artifacts of command into one tar.gz when
+// bundle is set (see BundleArtifacts), then uploads the bundle, or the
+// artifacts themselves, to uploadTo.
+func DeliverArtifacts(ctx context.Context, uploadTo string, bundle bool, command string, localPaths ...string) error {
+	if bundle {
+		bundlePath, err := BundleArtifacts(command, localPaths...)
+		if err != nil {
+			return err
+		}
+		localPaths = []string{bundlePath}
+	}
+	return UploadArtifacts(ctx, uploadTo, localPaths...)
+}
+
+// defaultBucketRegion is where buckets without a location constraint live.
+const defaultBucketRegion = "us-east-1"
+
+// bucketRegion returns the region bucket lives in, so uploads go to the
+// bucket's own regional endpoint whatever region the AWS profile defaults to.
+func bucketRegion(ctx context.Context, locator bucketLocator, bucket string) (string, error) {
+	output, err := locator.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: &bucket})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the region of bucket %s: %w", bucket, err)
+	}
+
+	switch output.LocationConstraint {
+	case "":
+		return defaultBucketRegion, nil
+	case s3types.BucketLocationConstraintEu:
+		return "eu-west-1", nil
+	default:
+		return string(output.LocationConstraint), nil
+	}
+}
+
+func upload(ctx context.Context, sink Sink, localPaths []string) error {
+	for _, localPath := range localPaths {
+		location, err := sink.Put(ctx, localPath)
+		if err != nil {
+			return err
+		}
+		slog.Debug("uploaded artifact", "file", localPath, "location", location)
+		fmt.Printf("✅ Uploaded %s to %s\n", localPath, location)
+	}
+
+	return nil
+}
+
+// uploadBody reads an artifact for upload. JSON artifacts are redacted for
+// ScopeUpload first, so secret references kcp keeps locally (SCRAM secret ARNs,
+// cluster policies) never leave the machine; the local file is not modified.
+// Markdown and other reports are rendered from the already-redacted state and
+// are uploaded as written.
+func uploadBody(localPath string) ([]byte, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", localPath, err)
+	}
+	if !strings.EqualFold(filepath.Ext(localPath), ".json") {
+		return data, nil
+	}
+
+	data, redacted, err := redact.JSON(data, redact.ScopeUpload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redact %s: %w", localPath, err)
+	}
+	if redacted > 0 {
+		slog.Debug("redacted sensitive values before upload", "file", localPath, "count", redacted)
+	}
+	return data, nil
+}
+
+func contentType(localPath string) *string {
+	var ct string
+	switch strings.ToLower(filepath.Ext(localPath)) {
+	case ".json":
+		ct = "application/json"
+	case ".md":
+		ct = "text/markdown; charset=utf-8"
+	case ".html":
+		ct = "text/html; charset=utf-8"
+	case ".gz":
+		ct = "application/gzip"
+	default:
+		ct = "application/octet-stream"
+	}
+	return &ct
+}
@@ -0,0 +1,124 @@
+package sink
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePutter struct {
+	inputs []*s3.PutObjectInput
+	bodies []string
+}
+
+func (f *fakePutter) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	body, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.inputs = append(f.inputs, params)
+	f.bodies = append(f.bodies, string(body))
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3Sink_PutUsesPrefixAndEncryption(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(localPath, []byte(`{"waves":[]}`), 0644))
+
+	putter := &fakePutter{}
+	s3Sink, err := NewS3Sink(putter, "s3://reports/kcp/run-1/")
+	require.NoError(t, err)
+
+	location, err := s3Sink.Put(context.Background(), localPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://reports/kcp/run-1/plan.json", location)
+	require.Len(t, putter.inputs, 1)
+	assert.Equal(t, "reports", *putter.inputs[0].Bucket)
+	assert.Equal(t, "kcp/run-1/plan.json", *putter.inputs[0].Key)
+	assert.Equal(t, s3types.ServerSideEncryptionAes256, putter.inputs[0].ServerSideEncryption)
+	assert.Equal(t, "application/json", *putter.inputs[0].ContentType)
+	assert.Equal(t, `{"waves":[]}`, putter.bodies[0])
+}
+
+func TestS3Sink_BucketRoot(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "kcp-state.json")
+	require.NoError(t, os.WriteFile(localPath, []byte("{}"), 0644))
+
+	putter := &fakePutter{}
+	s3Sink, err := NewS3Sink(putter, "s3://reports")
+	require.NoError(t, err)
+
+	location, err := s3Sink.Put(context.Background(), localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "s3://reports/kcp-state.json", location)
+}
+
+func TestS3Sink_PutRedactsJSONLeavingLocalFileIntact(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "kcp-state.json")
+	state := `{"msk_sources":{"regions":[{"clusters":[{"aws_client_information":{"ScramSecrets":["arn:aws:secretsmanager:us-east-1:000000000000:secret:AmazonMSK_orders"]}}]}]}}`
+	require.NoError(t, os.WriteFile(localPath, []byte(state), 0644))
+
+	putter := &fakePutter{}
+	s3Sink, err := NewS3Sink(putter, "s3://reports")
+	require.NoError(t, err)
+
+	_, err = s3Sink.Put(context.Background(), localPath)
+	require.NoError(t, err)
+
+	assert.NotContains(t, putter.bodies[0], "AmazonMSK_orders")
+	assert.Contains(t, putter.bodies[0], `"ScramSecrets":["\u003ckcp-redacted\u003e"]`)
+	local, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, state, string(local))
+}
+
+func TestNewS3Sink_RejectsNonS3URI(t *testing.T) {
+	_, err := NewS3Sink(&fakePutter{}, "https://reports.example.com/kcp")
+	assert.Error(t, err)
+}
+
+type fakeLocator struct {
+	constraint s3types.BucketLocationConstraint
+	bucket     string
+}
+
+func (f *fakeLocator) GetBucketLocation(_ context.Context, params *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	f.bucket = *params.Bucket
+	return &s3.GetBucketLocationOutput{LocationConstraint: f.constraint}, nil
+}
+
+func TestBucketRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint s3types.BucketLocationConstraint
+		want       string
+	}{
+		{"no constraint is us-east-1", "", "us-east-1"},
+		{"legacy EU is eu-west-1", s3types.BucketLocationConstraintEu, "eu-west-1"},
+		{"regional bucket", s3types.BucketLocationConstraintEuCentral1, "eu-central-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator := &fakeLocator{constraint: tt.constraint}
+			region, err := bucketRegion(context.Background(), locator, "reports")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, region)
+			assert.Equal(t, "reports", locator.bucket)
+		})
+	}
+}
+
+func TestValidateUploadTo(t *testing.T) {
+	assert.NoError(t, ValidateUploadTo(""))
+	assert.NoError(t, ValidateUploadTo("s3://reports/kcp"))
+	assert.Error(t, ValidateUploadTo("reports/kcp"))
+	assert.Error(t, ValidateUploadTo("s3://"))
+}
@@ -0,0 +1,250 @@
+// Package ssmtunnel reaches Kafka brokers in a private VPC through Session
+// Manager port forwarding sessions opened on a bastion instance, so kcp can
+// scan clusters from a laptop without direct VPC connectivity.
+package ssmtunnel
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+const (
+	// PluginBinary is the Session Manager plugin the AWS CLI also uses to
+	// carry port forwarding sessions.
+	PluginBinary = "session-manager-plugin"
+
+	portForwardingDocument = "AWS-StartPortForwardingSessionToRemoteHost"
+	readyTimeout           = 30 * time.Second
+	readyMessage           = "Waiting for connections"
+)
+
+type sessionAPI interface {
+	StartSession(ctx context.Context, params *ssm.StartSessionInput, optFns ...func(*ssm.Options)) (*ssm.StartSessionOutput, error)
+	TerminateSession(ctx context.Context, params *ssm.TerminateSessionInput, optFns ...func(*ssm.Options)) (*ssm.TerminateSessionOutput, error)
+}
+
+// Tunnel dials broker addresses through port forwarding sessions on a bastion
+// instance. Each broker gets its own session and local port, opened on first
+// dial, since brokers advertise their own addresses in metadata responses.
+// Tunnel implements the dialer sarama uses when its proxy is enabled, and
+// TLS still verifies against the broker host names.
+type Tunnel struct {
+	client     sessionAPI
+	instanceID string
+	region     string
+
+	mu       sync.Mutex
+	forwards map[string]*forward
+}
+
+type forward struct {
+	localAddr string
+	sessionID string
+	stop      func() error
+}
+
+// CheckPlugin reports whether the Session Manager plugin is installed.
+func CheckPlugin() error {
+	if _, err := exec.LookPath(PluginBinary); err != nil {
+		return fmt.Errorf("%s not found in PATH: install the Session Manager plugin (https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html)", PluginBinary)
+	}
+	return nil
+}
+
+func NewTunnel(client sessionAPI, instanceID, region string) *Tunnel {
+	return &Tunnel{
+		client:     client,
+		instanceID: instanceID,
+		region:     region,
+		forwards:   make(map[string]*forward),
+	}
+}
+
+func (t *Tunnel) Dial(network, addr string) (net.Conn, error) {
+	localAddr, err := t.forward(addr)
+	if err != nil {
+		return nil, err
+	}
+	return net.Dial(network, localAddr)
+}
+
+// forward returns the local address forwarded to addr, starting a session
+// through the bastion the first time addr is dialled.
+func (t *Tunnel) forward(addr string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if f, ok := t.forwards[addr]; ok {
+		return f.localAddr, nil
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid broker address '%s': %w", addr, err)
+	}
+	localPort, err := freeLocalPort()
+	if err != nil {
+		return "", fmt.Errorf("failed to find a free local port: %w", err)
+	}
+
+	input := &ssm.StartSessionInput{
+		Target:       aws.String(t.instanceID),
+		DocumentName: aws.String(portForwardingDocument),
+		Parameters: map[string][]string{
+			"host":            {host},
+			"portNumber":      {port},
+			"localPortNumber": {strconv.Itoa(localPort)},
+		},
+		Reason: aws.String("kcp scan clusters"),
+	}
+	output, err := t.client.StartSession(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("failed to start SSM session to %s via %s: %w", addr, t.instanceID, err)
+	}
+	sessionID := aws.ToString(output.SessionId)
+
+	stop, err := runPlugin(output, input, t.region)
+	if err != nil {
+		t.terminate(sessionID)
+		return "", fmt.Errorf("failed to forward %s via %s: %w", addr, t.instanceID, err)
+	}
+
+	f := &forward{
+		localAddr: net.JoinHostPort("127.0.0.1", strconv.Itoa(localPort)),
+		sessionID: sessionID,
+		stop:      stop,
+	}
+	t.forwards[addr] = f
+	slog.Debug("forwarding broker through SSM", "broker", addr, "local", f.localAddr, "instance", t.instanceID, "session", sessionID)
+
+	return f.localAddr, nil
+}
+
+// Close stops every port forward and terminates its session.
+func (t *Tunnel) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var errs []error
+	for addr, f := range t.forwards {
+		if err := f.stop(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop forwarding %s: %w", addr, err))
+		}
+		t.terminate(f.sessionID)
+		delete(t.forwards, addr)
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Tunnel) terminate(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	if _, err := t.client.TerminateSession(context.Background(), &ssm.TerminateSessionInput{SessionId: aws.String(sessionID)}); err != nil {
+		slog.Warn("failed to terminate SSM session", "session", sessionID, "error", err)
+	}
+}
+
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = listener.Close() }()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// runPlugin hands a started session to the Session Manager plugin and returns
+// once its local port accepts connections. Replaced in tests.
+var runPlugin = execPlugin
+
+// execPlugin runs the plugin with the arguments the AWS CLI passes it for
+// `aws ssm start-session`.
+func execPlugin(session *ssm.StartSessionOutput, input *ssm.StartSessionInput, region string) (func() error, error) {
+	sessionJSON, err := json.Marshal(map[string]string{
+		"SessionId":  aws.ToString(session.SessionId),
+		"StreamUrl":  aws.ToString(session.StreamUrl),
+		"TokenValue": aws.ToString(session.TokenValue),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session: %w", err)
+	}
+	inputJSON, err := json.Marshal(map[string]any{
+		"Target":       aws.ToString(input.Target),
+		"DocumentName": aws.ToString(input.DocumentName),
+		"Parameters":   input.Parameters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session parameters: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("https://ssm.%s.amazonaws.com", region)
+	cmd := exec.Command(PluginBinary, string(sessionJSON), region, "StartSession", os.Getenv("AWS_PROFILE"), string(inputJSON), endpoint)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture %s output: %w", PluginBinary, err)
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", PluginBinary, err)
+	}
+
+	stop := func() error {
+		if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return err
+		}
+		_ = cmd.Wait()
+		return nil
+	}
+
+	if err := waitForReady(stdout, readyTimeout); err != nil {
+		_ = stop()
+		return nil, err
+	}
+	// Keep draining the plugin output so it never blocks on a full pipe.
+	go func() { _, _ = io.Copy(io.Discard, stdout) }()
+
+	return stop, nil
+}
+
+// waitForReady reads plugin output until it reports the local port is open.
+func waitForReady(output io.Reader, timeout time.Duration) error {
+	ready := make(chan error, 1)
+	go func() {
+		var lastLine string
+		scanner := bufio.NewScanner(output)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.Contains(line, readyMessage) {
+				ready <- nil
+				return
+			}
+			if line != "" {
+				lastLine = line
+			}
+		}
+		ready <- fmt.Errorf("%s exited before the port was forwarded: %s", PluginBinary, lastLine)
+	}()
+
+	select {
+	case err := <-ready:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for %s to forward the port", timeout, PluginBinary)
+	}
+}
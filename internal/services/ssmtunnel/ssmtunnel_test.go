@@ -0,0 +1,113 @@
+package ssmtunnel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSessions struct {
+	started    []*ssm.StartSessionInput
+	terminated []string
+}
+
+func (f *fakeSessions) StartSession(_ context.Context, params *ssm.StartSessionInput, _ ...func(*ssm.Options)) (*ssm.StartSessionOutput, error) {
+	f.started = append(f.started, params)
+	return &ssm.StartSessionOutput{SessionId: aws.String(fmt.Sprintf("session-%d", len(f.started)))}, nil
+}
+
+func (f *fakeSessions) TerminateSession(_ context.Context, params *ssm.TerminateSessionInput, _ ...func(*ssm.Options)) (*ssm.TerminateSessionOutput, error) {
+	f.terminated = append(f.terminated, aws.ToString(params.SessionId))
+	return &ssm.TerminateSessionOutput{}, nil
+}
+
+// fakePlugin listens on the requested local port and answers each line with
+// the remote host it stands in for.
+func fakePlugin(t *testing.T) {
+	original := runPlugin
+	t.Cleanup(func() { runPlugin = original })
+
+	runPlugin = func(_ *ssm.StartSessionOutput, input *ssm.StartSessionInput, _ string) (func() error, error) {
+		listener, err := net.Listen("tcp", "127.0.0.1:"+input.Parameters["localPortNumber"][0])
+		if err != nil {
+			return nil, err
+		}
+		remote := input.Parameters["host"][0] + ":" + input.Parameters["portNumber"][0]
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer func() { _ = conn.Close() }()
+					if _, err := bufio.NewReader(conn).ReadString('\n'); err == nil {
+						_, _ = fmt.Fprintf(conn, "%s\n", remote)
+					}
+				}()
+			}
+		}()
+		return listener.Close, nil
+	}
+}
+
+func TestTunnel_DialForwardsThroughSession(t *testing.T) {
+	fakePlugin(t)
+	sessions := &fakeSessions{}
+	tunnel := NewTunnel(sessions, "i-0abc123", "us-east-1")
+
+	for _, addr := range []string{"b-1.msk.example:9098", "b-2.msk.example:9098", "b-1.msk.example:9098"} {
+		conn, err := tunnel.Dial("tcp", addr)
+		require.NoError(t, err)
+		require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+		_, err = fmt.Fprintln(conn, "ping")
+		require.NoError(t, err)
+		reply, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, addr, strings.TrimSpace(reply))
+		_ = conn.Close()
+	}
+
+	// One session per broker, reused on later dials.
+	require.Len(t, sessions.started, 2)
+	assert.Equal(t, "i-0abc123", aws.ToString(sessions.started[0].Target))
+	assert.Equal(t, portForwardingDocument, aws.ToString(sessions.started[0].DocumentName))
+	assert.Equal(t, []string{"b-1.msk.example"}, sessions.started[0].Parameters["host"])
+	assert.Equal(t, []string{"9098"}, sessions.started[0].Parameters["portNumber"])
+
+	require.NoError(t, tunnel.Close())
+	assert.ElementsMatch(t, []string{"session-1", "session-2"}, sessions.terminated)
+}
+
+func TestTunnel_PluginFailureTerminatesSession(t *testing.T) {
+	original := runPlugin
+	t.Cleanup(func() { runPlugin = original })
+	runPlugin = func(*ssm.StartSessionOutput, *ssm.StartSessionInput, string) (func() error, error) {
+		return nil, fmt.Errorf("TargetNotConnected")
+	}
+
+	sessions := &fakeSessions{}
+	tunnel := NewTunnel(sessions, "i-0abc123", "us-east-1")
+
+	_, err := tunnel.Dial("tcp", "b-1.msk.example:9098")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TargetNotConnected")
+	assert.Equal(t, []string{"session-1"}, sessions.terminated)
+}
+
+func TestWaitForReady(t *testing.T) {
+	require.NoError(t, waitForReady(strings.NewReader("Starting session with SessionId: s-1\nPort 50123 opened for sessionId s-1.\nWaiting for connections...\n"), time.Second))
+
+	err := waitForReady(strings.NewReader("An error occurred (TargetNotConnected)\n"), time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TargetNotConnected")
+}
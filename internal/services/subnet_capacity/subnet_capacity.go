@@ -0,0 +1,178 @@
+// Package subnet_capacity checks, before migration Terraform is generated,
+// that the subnets the generated resources land in have room for them and
+// that the source brokers are spread evenly across availability zones. It
+// works purely from the subnet data `kcp discover` stored in the state file.
+package subnet_capacity
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// awsReservedIPsPerSubnet is the number of addresses AWS reserves in every
+// subnet (network, VPC router, DNS, future use and broadcast).
+const awsReservedIPsPerSubnet = 5
+
+// awsMinSubnetPrefix is the smallest subnet AWS allows (/28).
+const awsMinSubnetPrefix = 28
+
+type Severity string
+
+const (
+	// SeverityWarning findings are printed but do not block generation.
+	SeverityWarning Severity = "warning"
+	// SeverityError findings mean `terraform apply` would fail or produce a
+	// broken layout.
+	SeverityError Severity = "error"
+)
+
+type Finding struct {
+	Severity Severity
+	Message  string
+}
+
+// HasErrors reports whether any finding is an error.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+// CheckBrokerAZDistribution reports brokers that all sit in one availability
+// zone, or that are spread unevenly (more than one broker apart between the
+// busiest and the quietest zone). Jump cluster brokers mirror this layout.
+func CheckBrokerAZDistribution(subnets []types.SubnetInfo) []Finding {
+	brokersPerAZ := map[string]int{}
+	for _, subnet := range subnets {
+		if subnet.AvailabilityZone == "" {
+			continue
+		}
+		brokersPerAZ[subnet.AvailabilityZone]++
+	}
+	if len(brokersPerAZ) == 0 {
+		return nil
+	}
+
+	zones := make([]string, 0, len(brokersPerAZ))
+	for zone := range brokersPerAZ {
+		zones = append(zones, zone)
+	}
+	slices.Sort(zones)
+
+	if len(zones) == 1 {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("all %d brokers are in availability zone %s: an AZ outage takes the source cluster and its jump cluster down together", brokersPerAZ[zones[0]], zones[0]),
+		}}
+	}
+
+	minBrokers, maxBrokers := brokersPerAZ[zones[0]], brokersPerAZ[zones[0]]
+	counts := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		n := brokersPerAZ[zone]
+		minBrokers = min(minBrokers, n)
+		maxBrokers = max(maxBrokers, n)
+		counts = append(counts, fmt.Sprintf("%s=%d", zone, n))
+	}
+	if maxBrokers-minBrokers > 1 {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("brokers are unevenly spread across availability zones (%s): partition leadership and replication traffic will skew towards the busiest zone", strings.Join(counts, ", ")),
+		}}
+	}
+	return nil
+}
+
+// CheckExistingSubnet verifies that an existing subnet has at least required
+// free IPs for the given purpose. The free IP count comes from the scan, so a
+// subnet that is not in the state file, or a state file written before the
+// count was recorded, only produces a warning.
+func CheckExistingSubnet(subnets []types.SubnetInfo, subnetID string, required int, purpose string) []Finding {
+	idx := slices.IndexFunc(subnets, func(s types.SubnetInfo) bool { return s.SubnetId == subnetID })
+	if idx < 0 {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("subnet %s (%s) was not scanned: free IP addresses not verified", subnetID, purpose),
+		}}
+	}
+
+	subnet := subnets[idx]
+	if subnet.AvailableIpAddressCount == nil {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("subnet %s (%s) has no free IP address count in the state file: re-run `kcp discover` to verify capacity", subnetID, purpose),
+		}}
+	}
+
+	available := int(*subnet.AvailableIpAddressCount)
+	if available < required {
+		return []Finding{{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("subnet %s (%s) has %d free IP addresses but %d are needed", subnetID, purpose, available, required),
+		}}
+	}
+	return nil
+}
+
+// CheckNewSubnets validates the CIDRs of subnets the generated Terraform will
+// create: each must be a valid AWS subnet size with room for requiredPerSubnet
+// addresses, and none may overlap each other or an existing scanned subnet.
+func CheckNewSubnets(cidrs []string, requiredPerSubnet int, purpose string, existing []types.SubnetInfo) []Finding {
+	var findings []Finding
+
+	type newSubnet struct {
+		cidr  string
+		ipNet *net.IPNet
+	}
+	var parsed []newSubnet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			findings = append(findings, Finding{Severity: SeverityError, Message: fmt.Sprintf("invalid %s subnet CIDR %q: %v", purpose, cidr, err)})
+			continue
+		}
+
+		ones, bits := ipNet.Mask.Size()
+		if bits != 32 {
+			findings = append(findings, Finding{Severity: SeverityError, Message: fmt.Sprintf("%s subnet CIDR %s is not IPv4", purpose, cidr)})
+			continue
+		}
+		if ones > awsMinSubnetPrefix {
+			findings = append(findings, Finding{Severity: SeverityError, Message: fmt.Sprintf("%s subnet CIDR %s is smaller than the AWS minimum of /%d", purpose, cidr, awsMinSubnetPrefix)})
+			continue
+		}
+		if usable := (1 << (bits - ones)) - awsReservedIPsPerSubnet; usable < requiredPerSubnet {
+			findings = append(findings, Finding{Severity: SeverityError, Message: fmt.Sprintf("%s subnet CIDR %s has %d usable IP addresses but %d are needed", purpose, cidr, usable, requiredPerSubnet)})
+		}
+
+		for _, other := range parsed {
+			if overlaps(ipNet, other.ipNet) {
+				findings = append(findings, Finding{Severity: SeverityError, Message: fmt.Sprintf("%s subnet CIDR %s overlaps %s", purpose, cidr, other.cidr)})
+			}
+		}
+		// Several brokers can share a subnet, so report each existing subnet once.
+		seen := map[string]bool{}
+		for _, subnet := range existing {
+			if seen[subnet.SubnetId] {
+				continue
+			}
+			seen[subnet.SubnetId] = true
+			_, existingNet, err := net.ParseCIDR(subnet.CidrBlock)
+			if err != nil {
+				continue
+			}
+			if overlaps(ipNet, existingNet) {
+				findings = append(findings, Finding{Severity: SeverityError, Message: fmt.Sprintf("%s subnet CIDR %s overlaps existing subnet %s (%s)", purpose, cidr, subnet.SubnetId, subnet.CidrBlock)})
+			}
+		}
+		parsed = append(parsed, newSubnet{cidr: cidr, ipNet: ipNet})
+	}
+
+	return findings
+}
+
+func overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
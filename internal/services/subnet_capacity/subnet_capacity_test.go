@@ -0,0 +1,109 @@
+package subnet_capacity
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int32Ptr(n int32) *int32 { return &n }
+
+func TestCheckBrokerAZDistribution(t *testing.T) {
+	tests := []struct {
+		name         string
+		zones        []string
+		wantContains string
+	}{
+		{name: "balanced", zones: []string{"us-east-1a", "us-east-1b", "us-east-1c", "us-east-1a"}},
+		{name: "single zone", zones: []string{"us-east-1a", "us-east-1a", "us-east-1a"}, wantContains: "all 3 brokers are in availability zone us-east-1a"},
+		{name: "uneven", zones: []string{"us-east-1a", "us-east-1a", "us-east-1a", "us-east-1b"}, wantContains: "us-east-1a=3, us-east-1b=1"},
+		{name: "no subnets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var subnets []types.SubnetInfo
+			for _, zone := range tt.zones {
+				subnets = append(subnets, types.SubnetInfo{AvailabilityZone: zone})
+			}
+
+			findings := CheckBrokerAZDistribution(subnets)
+			if tt.wantContains == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, SeverityWarning, findings[0].Severity)
+			assert.Contains(t, findings[0].Message, tt.wantContains)
+		})
+	}
+}
+
+func TestCheckExistingSubnet(t *testing.T) {
+	subnets := []types.SubnetInfo{
+		{SubnetId: "subnet-full", AvailableIpAddressCount: int32Ptr(0)},
+		{SubnetId: "subnet-free", AvailableIpAddressCount: int32Ptr(12)},
+		{SubnetId: "subnet-legacy"},
+	}
+
+	tests := []struct {
+		name         string
+		subnetID     string
+		wantSeverity Severity
+	}{
+		{name: "enough free IPs", subnetID: "subnet-free"},
+		{name: "exhausted", subnetID: "subnet-full", wantSeverity: SeverityError},
+		{name: "count not recorded", subnetID: "subnet-legacy", wantSeverity: SeverityWarning},
+		{name: "not scanned", subnetID: "subnet-other", wantSeverity: SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := CheckExistingSubnet(subnets, tt.subnetID, 1, "test host")
+			if tt.wantSeverity == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, tt.wantSeverity, findings[0].Severity)
+			assert.Contains(t, findings[0].Message, tt.subnetID)
+		})
+	}
+}
+
+func TestCheckNewSubnets(t *testing.T) {
+	existing := []types.SubnetInfo{
+		{SubnetId: "subnet-a", CidrBlock: "10.0.1.0/24"},
+		{SubnetId: "subnet-a", CidrBlock: "10.0.1.0/24"},
+		{SubnetId: "subnet-b", CidrBlock: "10.0.2.0/24"},
+	}
+
+	tests := []struct {
+		name         string
+		cidrs        []string
+		required     int
+		wantMessages []string
+	}{
+		{name: "valid", cidrs: []string{"10.0.101.0/24", "10.0.102.0/24"}, required: 1},
+		{name: "invalid", cidrs: []string{"not-a-cidr"}, required: 1, wantMessages: []string{`invalid test subnet CIDR "not-a-cidr"`}},
+		{name: "too small for AWS", cidrs: []string{"10.0.101.0/29"}, required: 1, wantMessages: []string{"smaller than the AWS minimum of /28"}},
+		{name: "not enough usable IPs", cidrs: []string{"10.0.101.0/28"}, required: 12, wantMessages: []string{"has 11 usable IP addresses but 12 are needed"}},
+		{name: "overlap with each other", cidrs: []string{"10.0.100.0/23", "10.0.101.0/24"}, required: 1, wantMessages: []string{"10.0.101.0/24 overlaps 10.0.100.0/23"}},
+		{name: "overlap with existing reported once", cidrs: []string{"10.0.1.128/25"}, required: 1, wantMessages: []string{"overlaps existing subnet subnet-a (10.0.1.0/24)"}},
+		{name: "skipped CIDR does not shift overlap", cidrs: []string{"bad", "10.0.50.0/24", "10.0.50.0/25"}, required: 1, wantMessages: []string{`"bad"`, "10.0.50.0/25 overlaps 10.0.50.0/24"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := CheckNewSubnets(tt.cidrs, tt.required, "test", existing)
+			require.Len(t, findings, len(tt.wantMessages))
+			for i, want := range tt.wantMessages {
+				assert.Equal(t, SeverityError, findings[i].Severity)
+				assert.Contains(t, findings[i].Message, want)
+			}
+			assert.Equal(t, len(tt.wantMessages) > 0, HasErrors(findings))
+		})
+	}
+}
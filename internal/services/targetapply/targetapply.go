@@ -0,0 +1,444 @@
+// Package targetapply creates the target Confluent Cloud environment, network
+// and Kafka cluster directly through the Confluent Cloud API, for users who
+// don't run Terraform. Every object is looked up by display name first, so
+// re-running an apply only creates what is still missing.
+package targetapply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/audit"
+)
+
+// DefaultBaseURL is the Confluent Cloud API host. Overridable so tests can
+// point it at a local stub.
+const DefaultBaseURL = "https://api.confluent.cloud"
+
+// Display names of the objects kcp creates alongside the cluster. They match
+// the names used by `kcp create-asset target-infra`, so an apply finds objects
+// created by the generated Terraform and vice versa.
+const (
+	NetworkDisplayName        = "private-link-network"
+	IngressGatewayDisplayName = "kcp_ingress_gateway"
+)
+
+type Resource string
+
+const (
+	ResourceEnvironment    Resource = "environment"
+	ResourceNetwork        Resource = "network"
+	ResourceIngressGateway Resource = "ingress gateway"
+	ResourceCluster        Resource = "cluster"
+)
+
+// Action is one object of the target, either already present or (to be)
+// created.
+type Action struct {
+	Resource Resource
+	Name     string
+	// ID is empty for objects a plan would create.
+	ID     string
+	Exists bool
+}
+
+// Config addresses the Confluent Cloud API with a Cloud API key.
+type Config struct {
+	BaseURL   string
+	APIKey    string
+	APISecret string
+}
+
+// Request describes the target to create. Exactly one of EnvironmentID and
+// EnvironmentName is set.
+type Request struct {
+	EnvironmentID   string
+	EnvironmentName string
+
+	ClusterName string
+	// ClusterType is "dedicated" or "enterprise".
+	ClusterType string
+	// ClusterAvailability is "SINGLE_ZONE" or "MULTI_ZONE"; enterprise
+	// clusters are always created with HIGH availability.
+	ClusterAvailability string
+	ClusterCku          int
+	Region              string
+
+	// NeedsPrivateLink creates a PrivateLink network for dedicated clusters
+	// and an ingress PrivateLink gateway for enterprise clusters.
+	NeedsPrivateLink bool
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Applier struct {
+	httpClient HTTPClient
+	config     Config
+}
+
+// NewApplier records every call httpClient makes in the audit log, so
+// httpClient must not audit them itself.
+func NewApplier(httpClient HTTPClient, config Config) *Applier {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Applier{httpClient: audit.WrapHTTPClient(audit.SystemConfluentCloud, httpClient), config: config}
+}
+
+type objectRef struct {
+	ID string `json:"id"`
+}
+
+type environment struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+type network struct {
+	ID   string `json:"id"`
+	Spec struct {
+		DisplayName string `json:"display_name"`
+		Region      string `json:"region"`
+	} `json:"spec"`
+}
+
+type gateway struct {
+	ID   string `json:"id"`
+	Spec struct {
+		DisplayName string `json:"display_name"`
+		Config      struct {
+			Kind   string `json:"kind"`
+			Region string `json:"region"`
+		} `json:"config"`
+	} `json:"spec"`
+}
+
+type cluster struct {
+	ID   string `json:"id"`
+	Spec struct {
+		DisplayName string `json:"display_name"`
+		Region      string `json:"region"`
+		Config      struct {
+			Kind string `json:"kind"`
+		} `json:"config"`
+	} `json:"spec"`
+}
+
+type listMetadata struct {
+	Next string `json:"next"`
+}
+
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// Plan reports what Apply would do without changing anything. Objects that
+// depend on an environment that doesn't exist yet are always planned as
+// creates.
+func (a *Applier) Plan(ctx context.Context, request Request) ([]Action, error) {
+	return a.run(ctx, request, false)
+}
+
+// Apply creates every object of request that doesn't exist yet and returns
+// all of them with their IDs. Clusters and networks are still provisioning
+// when Apply returns.
+func (a *Applier) Apply(ctx context.Context, request Request) ([]Action, error) {
+	return a.run(ctx, request, true)
+}
+
+func (a *Applier) run(ctx context.Context, request Request, create bool) ([]Action, error) {
+	var actions []Action
+
+	envAction, err := a.environment(ctx, request, create)
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, envAction)
+
+	networkID := ""
+	if request.NeedsPrivateLink {
+		var privateLinkAction Action
+		if request.ClusterType == "dedicated" {
+			privateLinkAction, err = a.network(ctx, request, envAction.ID, create)
+			networkID = privateLinkAction.ID
+		} else {
+			privateLinkAction, err = a.ingressGateway(ctx, request, envAction.ID, create)
+		}
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, privateLinkAction)
+	}
+
+	clusterAction, err := a.cluster(ctx, request, envAction.ID, networkID, create)
+	if err != nil {
+		return nil, err
+	}
+	return append(actions, clusterAction), nil
+}
+
+func (a *Applier) environment(ctx context.Context, request Request, create bool) (Action, error) {
+	if request.EnvironmentID != "" {
+		var env environment
+		if err := a.do(ctx, http.MethodGet, "/org/v2/environments/"+url.PathEscape(request.EnvironmentID), nil, &env); err != nil {
+			var statusErr *statusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+				return Action{}, fmt.Errorf("environment %s not found", request.EnvironmentID)
+			}
+			return Action{}, fmt.Errorf("failed to describe environment %s: %w", request.EnvironmentID, explain(err))
+		}
+		return Action{Resource: ResourceEnvironment, Name: env.DisplayName, ID: env.ID, Exists: true}, nil
+	}
+
+	environments, err := list[environment](ctx, a, "/org/v2/environments?page_size=100")
+	if err != nil {
+		return Action{}, fmt.Errorf("failed to list environments: %w", explain(err))
+	}
+	for _, env := range environments {
+		if env.DisplayName == request.EnvironmentName {
+			return Action{Resource: ResourceEnvironment, Name: env.DisplayName, ID: env.ID, Exists: true}, nil
+		}
+	}
+
+	action := Action{Resource: ResourceEnvironment, Name: request.EnvironmentName}
+	if !create {
+		return action, nil
+	}
+	// Stream Governance ADVANCED matches the environment the generated
+	// Terraform creates.
+	body := map[string]any{
+		"display_name":             request.EnvironmentName,
+		"stream_governance_config": map[string]string{"package": "ADVANCED"},
+	}
+	var created environment
+	if err := a.do(ctx, http.MethodPost, "/org/v2/environments", body, &created); err != nil {
+		return Action{}, fmt.Errorf("failed to create environment %s: %w", request.EnvironmentName, explain(err))
+	}
+	action.ID = created.ID
+	return action, nil
+}
+
+func (a *Applier) network(ctx context.Context, request Request, environmentID string, create bool) (Action, error) {
+	action := Action{Resource: ResourceNetwork, Name: NetworkDisplayName}
+
+	if environmentID != "" {
+		networks, err := list[network](ctx, a, "/networking/v1/networks?environment="+url.QueryEscape(environmentID))
+		if err != nil {
+			return Action{}, fmt.Errorf("failed to list networks: %w", explain(err))
+		}
+		for _, n := range networks {
+			if n.Spec.DisplayName == NetworkDisplayName && n.Spec.Region == request.Region {
+				action.ID = n.ID
+				action.Exists = true
+				return action, nil
+			}
+		}
+	}
+
+	if !create {
+		return action, nil
+	}
+	body := map[string]any{"spec": map[string]any{
+		"display_name":     NetworkDisplayName,
+		"cloud":            "AWS",
+		"region":           request.Region,
+		"connection_types": []string{"PRIVATELINK"},
+		"dns_config":       map[string]string{"resolution": "PRIVATE"},
+		"environment":      objectRef{ID: environmentID},
+	}}
+	var created network
+	if err := a.do(ctx, http.MethodPost, "/networking/v1/networks", body, &created); err != nil {
+		return Action{}, fmt.Errorf("failed to create network: %w", explain(err))
+	}
+	action.ID = created.ID
+	return action, nil
+}
+
+func (a *Applier) ingressGateway(ctx context.Context, request Request, environmentID string, create bool) (Action, error) {
+	action := Action{Resource: ResourceIngressGateway, Name: IngressGatewayDisplayName}
+
+	if environmentID != "" {
+		gateways, err := list[gateway](ctx, a, "/networking/v1/gateways?environment="+url.QueryEscape(environmentID))
+		if err != nil {
+			return Action{}, fmt.Errorf("failed to list gateways: %w", explain(err))
+		}
+		for _, g := range gateways {
+			if g.Spec.DisplayName == IngressGatewayDisplayName && g.Spec.Config.Region == request.Region {
+				action.ID = g.ID
+				action.Exists = true
+				return action, nil
+			}
+		}
+	}
+
+	if !create {
+		return action, nil
+	}
+	body := map[string]any{"spec": map[string]any{
+		"display_name": IngressGatewayDisplayName,
+		"config": map[string]string{
+			"kind":   "AwsIngressPrivateLinkGatewaySpec",
+			"region": request.Region,
+		},
+		"environment": objectRef{ID: environmentID},
+	}}
+	var created gateway
+	if err := a.do(ctx, http.MethodPost, "/networking/v1/gateways", body, &created); err != nil {
+		return Action{}, fmt.Errorf("failed to create ingress gateway: %w", explain(err))
+	}
+	action.ID = created.ID
+	return action, nil
+}
+
+func (a *Applier) cluster(ctx context.Context, request Request, environmentID, networkID string, create bool) (Action, error) {
+	action := Action{Resource: ResourceCluster, Name: request.ClusterName}
+	kind := "Dedicated"
+	if request.ClusterType == "enterprise" {
+		kind = "Enterprise"
+	}
+
+	if environmentID != "" {
+		clusters, err := list[cluster](ctx, a, "/cmk/v2/clusters?environment="+url.QueryEscape(environmentID))
+		if err != nil {
+			return Action{}, fmt.Errorf("failed to list clusters: %w", explain(err))
+		}
+		for _, c := range clusters {
+			if c.Spec.DisplayName != request.ClusterName {
+				continue
+			}
+			// A same-named cluster of another type or region is not the one
+			// asked for, and creating a second one would be confusing.
+			if !strings.EqualFold(c.Spec.Config.Kind, kind) || c.Spec.Region != request.Region {
+				return Action{}, fmt.Errorf("cluster %s (%s) already exists as a %s cluster in %s, not a %s cluster in %s", c.Spec.DisplayName, c.ID, c.Spec.Config.Kind, c.Spec.Region, kind, request.Region)
+			}
+			action.ID = c.ID
+			action.Exists = true
+			return action, nil
+		}
+	}
+
+	if !create {
+		return action, nil
+	}
+	config := map[string]any{"kind": kind}
+	availability := "HIGH"
+	if request.ClusterType == "dedicated" {
+		config["cku"] = request.ClusterCku
+		availability = request.ClusterAvailability
+	}
+	spec := map[string]any{
+		"display_name": request.ClusterName,
+		"availability": availability,
+		"cloud":        "AWS",
+		"region":       request.Region,
+		"config":       config,
+		"environment":  objectRef{ID: environmentID},
+	}
+	if networkID != "" {
+		spec["network"] = objectRef{ID: networkID}
+	}
+	var created cluster
+	if err := a.do(ctx, http.MethodPost, "/cmk/v2/clusters", map[string]any{"spec": spec}, &created); err != nil {
+		return Action{}, fmt.Errorf("failed to create cluster %s: %w", request.ClusterName, explain(err))
+	}
+	action.ID = created.ID
+	return action, nil
+}
+
+// explain turns authentication failures into a hint about the key type, the
+// most common mistake being a cluster API key instead of a Cloud API key.
+func explain(err error) error {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusUnauthorized:
+			return fmt.Errorf("authentication failed (status 401) — verify --cc-api-key and --cc-api-secret are a Cloud API key, not a cluster API key")
+		case http.StatusForbidden:
+			return fmt.Errorf("permission denied (status 403) — the API key's principal needs the OrganizationAdmin or EnvironmentAdmin role")
+		}
+	}
+	return err
+}
+
+// list fetches every page of a Confluent Cloud list endpoint, following
+// metadata.next.
+func list[T any](ctx context.Context, a *Applier, path string) ([]T, error) {
+	var items []T
+	for path != "" {
+		var page struct {
+			Data     []T          `json:"data"`
+			Metadata listMetadata `json:"metadata"`
+		}
+		if err := a.do(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+
+		path = ""
+		if page.Metadata.Next != "" {
+			next, err := url.Parse(page.Metadata.Next)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse next page URL: %w", err)
+			}
+			path = next.RequestURI()
+		}
+	}
+	return items, nil
+}
+
+func (a *Applier) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.config.BaseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(a.config.APIKey, a.config.APISecret)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		slog.Debug("🔍 confluent cloud request failed", "method", method, "path", path, "ms", time.Since(start).Milliseconds(), "error", err)
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	slog.Debug("🔍 confluent cloud request", "method", method, "path", path, "status", res.StatusCode, "ms", time.Since(start).Milliseconds())
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return &statusError{StatusCode: res.StatusCode, Body: string(resBody)}
+	}
+	if err := json.Unmarshal(resBody, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
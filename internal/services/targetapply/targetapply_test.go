@@ -0,0 +1,200 @@
+package targetapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloud is an in-memory Confluent Cloud serving the list and create
+// endpoints the applier uses. Environments are served two per page so
+// pagination is exercised.
+type fakeCloud struct {
+	mu           sync.Mutex
+	environments []map[string]any
+	networks     []map[string]any
+	gateways     []map[string]any
+	clusters     []map[string]any
+	posts        map[string]map[string]any
+}
+
+func newFakeCloud(t *testing.T, cloud *fakeCloud) *httptest.Server {
+	t.Helper()
+	cloud.posts = map[string]map[string]any{}
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/org/v2/environments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			cloud.create(w, r, &cloud.environments)
+			return
+		}
+		start := 0
+		if r.URL.Query().Get("page_token") != "" {
+			start = 2
+		}
+		end := min(start+2, len(cloud.environments))
+		page := map[string]any{"data": cloud.environments[start:end], "metadata": map[string]any{}}
+		if end < len(cloud.environments) {
+			page["metadata"] = map[string]any{"next": server.URL + "/org/v2/environments?page_size=100&page_token=p2"}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("/org/v2/environments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for _, env := range cloud.environments {
+			if env["id"] == r.PathValue("id") {
+				_ = json.NewEncoder(w).Encode(env)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	for path, objects := range map[string]*[]map[string]any{
+		"/networking/v1/networks": &cloud.networks,
+		"/networking/v1/gateways": &cloud.gateways,
+		"/cmk/v2/clusters":        &cloud.clusters,
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				cloud.create(w, r, objects)
+				return
+			}
+			var data []map[string]any
+			for _, o := range *objects {
+				if o["spec"].(map[string]any)["environment"].(map[string]any)["id"] == r.URL.Query().Get("environment") {
+					data = append(data, o)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+		})
+	}
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (c *fakeCloud) create(w http.ResponseWriter, r *http.Request, objects *[]map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	c.posts[r.URL.Path] = body
+	body["id"] = fmt.Sprintf("%s-%d", r.URL.Path, len(*objects)+1)
+	*objects = append(*objects, body)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func dedicatedRequest() Request {
+	return Request{
+		EnvironmentName:     "migration",
+		ClusterName:         "orders",
+		ClusterType:         "dedicated",
+		ClusterAvailability: "MULTI_ZONE",
+		ClusterCku:          2,
+		Region:              "us-east-1",
+		NeedsPrivateLink:    true,
+	}
+}
+
+func TestApply_CreatesMissingObjectsThenIsIdempotent(t *testing.T) {
+	cloud := &fakeCloud{environments: []map[string]any{
+		{"id": "env-1", "display_name": "default"},
+		{"id": "env-2", "display_name": "staging"},
+		{"id": "env-3", "display_name": "other"},
+	}}
+	server := newFakeCloud(t, cloud)
+	applier := NewApplier(server.Client(), Config{BaseURL: server.URL, APIKey: "key", APISecret: "secret"})
+
+	actions, err := applier.Apply(context.Background(), dedicatedRequest())
+	require.NoError(t, err)
+	require.Len(t, actions, 3)
+	for _, action := range actions {
+		assert.False(t, action.Exists, action.Resource)
+		assert.NotEmpty(t, action.ID, action.Resource)
+	}
+
+	assert.Equal(t, "ADVANCED", cloud.posts["/org/v2/environments"]["stream_governance_config"].(map[string]any)["package"])
+	networkSpec := cloud.posts["/networking/v1/networks"]["spec"].(map[string]any)
+	assert.Equal(t, NetworkDisplayName, networkSpec["display_name"])
+	assert.Equal(t, []any{"PRIVATELINK"}, networkSpec["connection_types"])
+	assert.Equal(t, actions[0].ID, networkSpec["environment"].(map[string]any)["id"])
+	clusterSpec := cloud.posts["/cmk/v2/clusters"]["spec"].(map[string]any)
+	assert.Equal(t, "MULTI_ZONE", clusterSpec["availability"])
+	assert.Equal(t, map[string]any{"kind": "Dedicated", "cku": float64(2)}, clusterSpec["config"])
+	assert.Equal(t, actions[1].ID, clusterSpec["network"].(map[string]any)["id"])
+
+	// A second apply finds everything, including the environment on the
+	// second page, and creates nothing.
+	cloud.posts = map[string]map[string]any{}
+	again, err := applier.Apply(context.Background(), dedicatedRequest())
+	require.NoError(t, err)
+	assert.Empty(t, cloud.posts)
+	for i, action := range again {
+		assert.True(t, action.Exists, action.Resource)
+		assert.Equal(t, actions[i].ID, action.ID)
+	}
+}
+
+func TestPlan_DoesNotCreate(t *testing.T) {
+	cloud := &fakeCloud{}
+	server := newFakeCloud(t, cloud)
+	applier := NewApplier(server.Client(), Config{BaseURL: server.URL})
+
+	request := dedicatedRequest()
+	request.ClusterType = "enterprise"
+	actions, err := applier.Plan(context.Background(), request)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Action{
+		{Resource: ResourceEnvironment, Name: "migration"},
+		{Resource: ResourceIngressGateway, Name: IngressGatewayDisplayName},
+		{Resource: ResourceCluster, Name: "orders"},
+	}, actions)
+	assert.Empty(t, cloud.posts)
+}
+
+func TestApply_ExistingEnvironmentAndConflictingCluster(t *testing.T) {
+	cloud := &fakeCloud{
+		environments: []map[string]any{{"id": "env-1", "display_name": "migration"}},
+		clusters: []map[string]any{{"id": "lkc-1", "spec": map[string]any{
+			"display_name": "orders",
+			"region":       "us-east-1",
+			"config":       map[string]any{"kind": "Basic"},
+			"environment":  map[string]any{"id": "env-1"},
+		}}},
+	}
+	server := newFakeCloud(t, cloud)
+	applier := NewApplier(server.Client(), Config{BaseURL: server.URL})
+
+	request := dedicatedRequest()
+	request.EnvironmentName = ""
+	request.EnvironmentID = "env-1"
+	request.NeedsPrivateLink = false
+	_, err := applier.Apply(context.Background(), request)
+	require.ErrorContains(t, err, "cluster orders (lkc-1) already exists as a Basic cluster in us-east-1")
+	assert.Empty(t, cloud.posts)
+
+	request.EnvironmentID = "env-missing"
+	_, err = applier.Plan(context.Background(), request)
+	require.EqualError(t, err, "environment env-missing not found")
+}
+
+func TestApply_ExplainsRejectedKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := NewApplier(server.Client(), Config{BaseURL: server.URL}).Plan(context.Background(), dedicatedRequest())
+	require.ErrorContains(t, err, "not a cluster API key")
+}
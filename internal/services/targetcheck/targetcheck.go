@@ -0,0 +1,276 @@
+// Package targetcheck inspects the target Confluent Cloud cluster before
+// migration assets are generated, so topic-name collisions, existing mirror
+// topics and conflicting cluster links are reported up front instead of
+// failing a Terraform apply halfway through.
+package targetcheck
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/audit"
+)
+
+type Severity string
+
+const (
+	// SeverityError findings make the generated assets fail on apply.
+	SeverityError Severity = "error"
+	// SeverityWarning findings are worth a look but don't block the apply.
+	SeverityWarning Severity = "warning"
+)
+
+type Kind string
+
+const (
+	KindTopicCollision      Kind = "topic_collision"
+	KindExistingMirrorTopic Kind = "existing_mirror_topic"
+	KindClusterLink         Kind = "cluster_link"
+)
+
+// Finding is one problem found on the target cluster.
+type Finding struct {
+	Severity Severity
+	Kind     Kind
+	Topic    string
+	Message  string
+}
+
+// Config addresses the target cluster's REST API with a cluster API key.
+type Config struct {
+	RestEndpoint string
+	ClusterID    string
+	APIKey       string
+	APISecret    string
+}
+
+// Request describes the assets about to be generated.
+type Request struct {
+	// Mirror is true for cluster-link mirror topics, false for plain topics.
+	Mirror bool
+	// LinkName is the cluster link the mirror topics are created on.
+	LinkName string
+	// SourceClusterID is the Kafka cluster ID of the source, used to spot
+	// links that already replicate from it. Optional.
+	SourceClusterID string
+	// Topics are the destination topic names.
+	Topics []string
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Checker struct {
+	httpClient HTTPClient
+}
+
+func NewChecker(httpClient HTTPClient) *Checker {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Checker{httpClient: audit.WrapHTTPClient(audit.SystemConfluentCloud, httpClient)}
+}
+
+type topicData struct {
+	TopicName  string `json:"topic_name"`
+	IsInternal bool   `json:"is_internal"`
+}
+
+type linkData struct {
+	LinkName        string `json:"link_name"`
+	SourceClusterID string `json:"source_cluster_id"`
+	LinkState       string `json:"link_state"`
+}
+
+type mirrorData struct {
+	LinkName        string `json:"link_name"`
+	MirrorTopicName string `json:"mirror_topic_name"`
+	MirrorStatus    string `json:"mirror_status"`
+}
+
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// Check verifies the target cluster exists and reports every finding for
+// request, errors first. It returns an error only when the cluster can't be
+// inspected at all.
+func (c *Checker) Check(ctx context.Context, config Config, request Request) ([]Finding, error) {
+	clusterPath := "/kafka/v3/clusters/" + url.PathEscape(config.ClusterID)
+
+	var cluster struct {
+		ClusterID string `json:"cluster_id"`
+	}
+	if err := c.get(ctx, config, clusterPath, &cluster); err != nil {
+		var statusErr *statusError
+		if errors.As(err, &statusErr) {
+			switch statusErr.StatusCode {
+			case http.StatusNotFound:
+				return nil, fmt.Errorf("target cluster %s not found at %s", config.ClusterID, config.RestEndpoint)
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return nil, fmt.Errorf("authentication failed (status %d) — verify --cluster-api-key and --cluster-api-secret", statusErr.StatusCode)
+			}
+		}
+		return nil, fmt.Errorf("failed to describe target cluster: %w", err)
+	}
+
+	var topics struct {
+		Data []topicData `json:"data"`
+	}
+	if err := c.get(ctx, config, clusterPath+"/topics", &topics); err != nil {
+		return nil, fmt.Errorf("failed to list target topics: %w", err)
+	}
+	var links struct {
+		Data []linkData `json:"data"`
+	}
+	if err := c.get(ctx, config, clusterPath+"/links", &links); err != nil {
+		return nil, fmt.Errorf("failed to list target cluster links: %w", err)
+	}
+	var mirrors struct {
+		Data []mirrorData `json:"data"`
+	}
+	if err := c.get(ctx, config, clusterPath+"/links/-/mirrors", &mirrors); err != nil {
+		return nil, fmt.Errorf("failed to list target mirror topics: %w", err)
+	}
+
+	return evaluate(request, topics.Data, links.Data, mirrors.Data), nil
+}
+
+func evaluate(request Request, topics []topicData, links []linkData, mirrors []mirrorData) []Finding {
+	var findings []Finding
+
+	if request.Mirror {
+		findings = append(findings, evaluateLinks(request, links)...)
+	}
+
+	mirrorByTopic := make(map[string]mirrorData, len(mirrors))
+	for _, m := range mirrors {
+		mirrorByTopic[m.MirrorTopicName] = m
+	}
+	existing := make(map[string]bool, len(topics))
+	for _, t := range topics {
+		existing[t.TopicName] = true
+	}
+
+	for _, topic := range request.Topics {
+		if m, ok := mirrorByTopic[topic]; ok {
+			if request.Mirror && m.LinkName == request.LinkName {
+				findings = append(findings, Finding{
+					Severity: SeverityWarning,
+					Kind:     KindExistingMirrorTopic,
+					Topic:    topic,
+					Message:  fmt.Sprintf("already mirrored on link %s (%s); import it into Terraform state or drop it from the selection", m.LinkName, m.MirrorStatus),
+				})
+				continue
+			}
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Kind:     KindExistingMirrorTopic,
+				Topic:    topic,
+				Message:  fmt.Sprintf("exists as a mirror topic on link %s (%s)", m.LinkName, m.MirrorStatus),
+			})
+			continue
+		}
+		if existing[topic] {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Kind:     KindTopicCollision,
+				Topic:    topic,
+				Message:  "already exists on the target cluster",
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity == SeverityError && findings[j].Severity != SeverityError
+	})
+	return findings
+}
+
+func evaluateLinks(request Request, links []linkData) []Finding {
+	var findings []Finding
+	found := false
+	for _, l := range links {
+		if l.LinkName == request.LinkName {
+			found = true
+			if request.SourceClusterID != "" && l.SourceClusterID != "" && l.SourceClusterID != request.SourceClusterID {
+				findings = append(findings, Finding{
+					Severity: SeverityError,
+					Kind:     KindClusterLink,
+					Message:  fmt.Sprintf("cluster link %s replicates from %s, not the source cluster %s", l.LinkName, l.SourceClusterID, request.SourceClusterID),
+				})
+			}
+			continue
+		}
+		if request.SourceClusterID != "" && l.SourceClusterID == request.SourceClusterID {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Kind:     KindClusterLink,
+				Message:  fmt.Sprintf("cluster link %s also replicates from source cluster %s", l.LinkName, request.SourceClusterID),
+			})
+		}
+	}
+	if !found {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Kind:     KindClusterLink,
+			Message:  fmt.Sprintf("cluster link %s does not exist on the target cluster; create it before the mirror topics", request.LinkName),
+		})
+	}
+	return findings
+}
+
+// CountErrors returns the number of error findings.
+func CountErrors(findings []Finding) int {
+	count := 0
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Checker) get(ctx context.Context, config Config, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.RestEndpoint+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString(fmt.Appendf(nil, "%s:%s", config.APIKey, config.APISecret)))
+
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		slog.Debug("🔍 confluent cloud request failed", "method", http.MethodGet, "path", path, "ms", time.Since(start).Milliseconds(), "error", err)
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	slog.Debug("🔍 confluent cloud request", "method", http.MethodGet, "path", path, "status", res.StatusCode, "ms", time.Since(start).Milliseconds())
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return &statusError{StatusCode: res.StatusCode, Body: string(body)}
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package targetcheck
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topicsBody = `{"data":[
+		{"topic_name":"orders","is_internal":false},
+		{"topic_name":"payments","is_internal":false},
+		{"topic_name":"legacy","is_internal":false}
+	]}`
+	linksBody = `{"data":[
+		{"link_name":"msk-link","source_cluster_id":"src-1","link_state":"ACTIVE"},
+		{"link_name":"old-link","source_cluster_id":"src-1","link_state":"ACTIVE"}
+	]}`
+	mirrorsBody = `{"data":[
+		{"link_name":"msk-link","mirror_topic_name":"orders","mirror_status":"ACTIVE"},
+		{"link_name":"old-link","mirror_topic_name":"payments","mirror_status":"PAUSED"}
+	]}`
+)
+
+func newTargetServer(t *testing.T, clusterStatus int) *httptest.Server {
+	t.Helper()
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("key:secret"))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != wantAuth {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if clusterStatus != http.StatusOK {
+			w.WriteHeader(clusterStatus)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"cluster_id":"lkc-1"}`)
+	})
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1/topics", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, topicsBody)
+	})
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1/links", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, linksBody)
+	})
+	mux.HandleFunc("/kafka/v3/clusters/lkc-1/links/-/mirrors", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, mirrorsBody)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testConfig(endpoint string) Config {
+	return Config{RestEndpoint: endpoint, ClusterID: "lkc-1", APIKey: "key", APISecret: "secret"}
+}
+
+func TestCheck_MirrorMode(t *testing.T) {
+	server := newTargetServer(t, http.StatusOK)
+
+	findings, err := NewChecker(server.Client()).Check(context.Background(), testConfig(server.URL), Request{
+		Mirror:          true,
+		LinkName:        "msk-link",
+		SourceClusterID: "src-1",
+		Topics:          []string{"orders", "payments", "legacy", "fresh"},
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 4)
+
+	// Errors sort ahead of warnings.
+	assert.Equal(t, Finding{Severity: SeverityError, Kind: KindExistingMirrorTopic, Topic: "payments", Message: "exists as a mirror topic on link old-link (PAUSED)"}, findings[0])
+	assert.Equal(t, Finding{Severity: SeverityError, Kind: KindTopicCollision, Topic: "legacy", Message: "already exists on the target cluster"}, findings[1])
+	assert.Equal(t, SeverityWarning, findings[2].Severity)
+	assert.Equal(t, KindClusterLink, findings[2].Kind)
+	assert.Contains(t, findings[2].Message, "old-link")
+	assert.Equal(t, SeverityWarning, findings[3].Severity)
+	assert.Equal(t, "orders", findings[3].Topic)
+	assert.Equal(t, 2, CountErrors(findings))
+}
+
+func TestCheck_MirrorModeMissingLink(t *testing.T) {
+	server := newTargetServer(t, http.StatusOK)
+
+	findings, err := NewChecker(server.Client()).Check(context.Background(), testConfig(server.URL), Request{
+		Mirror:          true,
+		LinkName:        "new-link",
+		SourceClusterID: "src-1",
+		Topics:          []string{"fresh"},
+	})
+	require.NoError(t, err)
+
+	var missing bool
+	for _, f := range findings {
+		if f.Kind == KindClusterLink && f.Severity == SeverityError {
+			missing = true
+			assert.Contains(t, f.Message, "new-link does not exist")
+		}
+	}
+	assert.True(t, missing, "a missing link is an error")
+}
+
+func TestCheck_MirrorModeLinkFromOtherSource(t *testing.T) {
+	server := newTargetServer(t, http.StatusOK)
+
+	findings, err := NewChecker(server.Client()).Check(context.Background(), testConfig(server.URL), Request{
+		Mirror:          true,
+		LinkName:        "msk-link",
+		SourceClusterID: "src-2",
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "replicates from src-1, not the source cluster src-2")
+}
+
+func TestCheck_NewTopicsModeIgnoresLinks(t *testing.T) {
+	server := newTargetServer(t, http.StatusOK)
+
+	findings, err := NewChecker(server.Client()).Check(context.Background(), testConfig(server.URL), Request{
+		Topics: []string{"orders", "fresh"},
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, SeverityError, findings[0].Severity, "a mirror topic blocks a plain topic of the same name")
+	assert.Equal(t, "orders", findings[0].Topic)
+}
+
+func TestCheck_ClusterNotFound(t *testing.T) {
+	server := newTargetServer(t, http.StatusNotFound)
+
+	_, err := NewChecker(server.Client()).Check(context.Background(), testConfig(server.URL), Request{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target cluster lkc-1 not found")
+}
+
+func TestCheck_AuthFailure(t *testing.T) {
+	server := newTargetServer(t, http.StatusOK)
+	config := testConfig(server.URL)
+	config.APISecret = "wrong"
+
+	_, err := NewChecker(server.Client()).Check(context.Background(), config, Request{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication failed (status 401)")
+}
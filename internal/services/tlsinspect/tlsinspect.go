@@ -0,0 +1,163 @@
+// Package tlsinspect connects to Kafka broker endpoints and records what their
+// TLS listeners present: DNS resolution, the certificate chain with its expiry
+// and SANs, and whether the chain is issued by a private CA that clients —
+// jump cluster brokers included — must add to their trust stores.
+package tlsinspect
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// ExpiryWarning is how close to expiry a certificate is reported.
+const ExpiryWarning = 30 * 24 * time.Hour
+
+const defaultTimeout = 10 * time.Second
+
+type Inspector struct {
+	// Roots verifies presented chains; nil uses the system roots, so any
+	// chain that does not verify is flagged as issued by a private CA.
+	Roots    *x509.CertPool
+	Timeout  time.Duration
+	Resolver *net.Resolver
+	now      func() time.Time
+}
+
+func NewInspector() *Inspector {
+	return &Inspector{
+		Timeout:  defaultTimeout,
+		Resolver: net.DefaultResolver,
+		now:      time.Now,
+	}
+}
+
+// Inspect inspects each host:port address in turn. A failing endpoint is
+// recorded with its error rather than failing the inspection.
+func (i *Inspector) Inspect(ctx context.Context, addresses []string) *types.TLSInspection {
+	inspection := &types.TLSInspection{InspectedAt: i.now().UTC()}
+	for _, address := range addresses {
+		endpoint := i.inspectEndpoint(ctx, address)
+		if endpoint.Error != "" {
+			slog.Debug("tls inspection failed", "address", address, "error", endpoint.Error)
+		}
+		inspection.Endpoints = append(inspection.Endpoints, endpoint)
+	}
+	return inspection
+}
+
+func (i *Inspector) inspectEndpoint(ctx context.Context, address string) types.TLSEndpoint {
+	endpoint := types.TLSEndpoint{Address: address}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		endpoint.Error = fmt.Sprintf("invalid address: %v", err)
+		return endpoint
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	if net.ParseIP(host) == nil {
+		ips, err := i.Resolver.LookupHost(ctx, host)
+		if err != nil {
+			endpoint.Error = fmt.Sprintf("failed to resolve %s: %v", host, err)
+			return endpoint
+		}
+		endpoint.ResolvedIPs = ips
+	}
+
+	// Verification is done by hand below, so a chain the system doesn't trust
+	// is still recorded rather than failing the handshake.
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Resolver: i.Resolver},
+		Config:    &tls.Config{ServerName: host, InsecureSkipVerify: true}, //nolint:gosec // inspection only, nothing is sent
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		endpoint.Error = fmt.Sprintf("tls handshake failed: %v", err)
+		return endpoint
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	endpoint.TLSVersion = tls.VersionName(state.Version)
+	for _, cert := range state.PeerCertificates {
+		endpoint.Certificates = append(endpoint.Certificates, certificate(cert))
+	}
+	if len(state.PeerCertificates) == 0 {
+		return endpoint
+	}
+
+	leaf := state.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: i.Roots, Intermediates: intermediates, CurrentTime: i.now()})
+	var unknownAuthority x509.UnknownAuthorityError
+	endpoint.PrivateCA = errors.As(err, &unknownAuthority)
+	endpoint.HostnameMismatch = leaf.VerifyHostname(host) != nil
+
+	return endpoint
+}
+
+func certificate(cert *x509.Certificate) types.TLSCertificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+	c := types.TLSCertificate{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SerialNumber:      cert.SerialNumber.String(),
+		NotBefore:         cert.NotBefore.UTC(),
+		NotAfter:          cert.NotAfter.UTC(),
+		DNSNames:          cert.DNSNames,
+		IsCA:              cert.IsCA,
+		SHA256Fingerprint: hex.EncodeToString(fingerprint[:]),
+		PEM:               string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+	}
+	for _, ip := range cert.IPAddresses {
+		c.IPAddresses = append(c.IPAddresses, ip.String())
+	}
+	return c
+}
+
+// Warnings describes what in an inspection needs attention before migrating:
+// private CAs, hostname mismatches, certificates that have expired or expire
+// within ExpiryWarning of now, and endpoints that could not be inspected.
+func Warnings(inspection *types.TLSInspection, now time.Time) []string {
+	if inspection == nil {
+		return nil
+	}
+	var warnings []string
+	for _, e := range inspection.Endpoints {
+		if e.Error != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", e.Address, e.Error))
+			continue
+		}
+		if e.PrivateCA {
+			warnings = append(warnings, fmt.Sprintf("%s: certificate is issued by a private CA (%s) — jump cluster trust stores need it", e.Address, e.Certificates[len(e.Certificates)-1].Issuer))
+		}
+		if e.HostnameMismatch {
+			warnings = append(warnings, fmt.Sprintf("%s: certificate SANs do not cover this host", e.Address))
+		}
+		for _, c := range e.Certificates {
+			switch {
+			case !now.Before(c.NotAfter):
+				warnings = append(warnings, fmt.Sprintf("%s: certificate %q expired on %s", e.Address, c.Subject, c.NotAfter.Format(time.DateOnly)))
+			case c.NotAfter.Sub(now) < ExpiryWarning:
+				warnings = append(warnings, fmt.Sprintf("%s: certificate %q expires on %s", e.Address, c.Subject, c.NotAfter.Format(time.DateOnly)))
+			}
+		}
+	}
+	return warnings
+}
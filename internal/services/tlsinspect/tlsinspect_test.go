@@ -0,0 +1,89 @@
+package tlsinspect
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect_SelfSignedListenerIsPrivateCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	address := strings.TrimPrefix(srv.URL, "https://")
+
+	inspection := NewInspector().Inspect(context.Background(), []string{address})
+
+	require.Len(t, inspection.Endpoints, 1)
+	e := inspection.Endpoints[0]
+	require.Empty(t, e.Error)
+	assert.Equal(t, address, e.Address)
+	assert.Empty(t, e.ResolvedIPs, "IP addresses are not resolved")
+	assert.NotEmpty(t, e.TLSVersion)
+	assert.True(t, e.PrivateCA)
+	assert.False(t, e.HostnameMismatch, "the test certificate covers 127.0.0.1")
+	require.Len(t, e.Certificates, 1)
+	cert := e.Certificates[0]
+	assert.True(t, cert.IsCA)
+	assert.Contains(t, cert.DNSNames, "example.com")
+	assert.Contains(t, cert.IPAddresses, "127.0.0.1")
+	assert.Len(t, cert.SHA256Fingerprint, 64)
+	assert.True(t, strings.HasPrefix(cert.PEM, "-----BEGIN CERTIFICATE-----"))
+	assert.Equal(t, cert.PEM, inspection.PrivateCABundle())
+}
+
+func TestInspect_TrustedChainIsNotPrivateCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	inspector := NewInspector()
+	inspector.Roots = x509.NewCertPool()
+	inspector.Roots.AddCert(srv.Certificate())
+
+	e := inspector.Inspect(context.Background(), []string{strings.TrimPrefix(srv.URL, "https://")}).Endpoints[0]
+
+	require.Empty(t, e.Error)
+	assert.False(t, e.PrivateCA)
+}
+
+func TestInspect_PlaintextListenerRecordsError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	inspector := NewInspector()
+	inspector.Timeout = 2 * time.Second
+	inspection := inspector.Inspect(context.Background(), []string{strings.TrimPrefix(srv.URL, "http://"), "no-port"})
+
+	require.Len(t, inspection.Endpoints, 2)
+	assert.Contains(t, inspection.Endpoints[0].Error, "tls handshake failed")
+	assert.Empty(t, inspection.Endpoints[0].Certificates)
+	assert.Contains(t, inspection.Endpoints[1].Error, "invalid address")
+}
+
+func TestWarnings(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	inspection := &types.TLSInspection{Endpoints: []types.TLSEndpoint{
+		{Address: "b-1:9093", Certificates: []types.TLSCertificate{{Subject: "CN=b-1", NotAfter: now.AddDate(1, 0, 0)}}},
+		{Address: "b-2:9093", PrivateCA: true, HostnameMismatch: true, Certificates: []types.TLSCertificate{
+			{Subject: "CN=b-2", NotAfter: now.AddDate(0, 0, 10)},
+			{Subject: "CN=Corp CA", Issuer: "CN=Corp Root", NotAfter: now.AddDate(0, 0, -1)},
+		}},
+		{Address: "b-3:9092", Error: "tls handshake failed: EOF"},
+	}}
+
+	assert.Equal(t, []string{
+		"b-2:9093: certificate is issued by a private CA (CN=Corp Root) — jump cluster trust stores need it",
+		"b-2:9093: certificate SANs do not cover this host",
+		`b-2:9093: certificate "CN=b-2" expires on 2026-03-11`,
+		`b-2:9093: certificate "CN=Corp CA" expired on 2026-02-28`,
+		"b-3:9092: tls handshake failed: EOF",
+	}, Warnings(inspection, now))
+	assert.Nil(t, Warnings(nil, now))
+}
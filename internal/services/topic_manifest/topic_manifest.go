@@ -0,0 +1,112 @@
+// Package topic_manifest renders scanned topics as a declarative YAML
+// manifest plus a confluent CLI script that creates them, the non-Terraform
+// counterpart of `kcp create-asset migrate-topics --mode new`.
+package topic_manifest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/services/hcl/confluent"
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/goccy/go-yaml"
+)
+
+type Manifest struct {
+	Cluster ManifestCluster `yaml:"cluster"`
+	Topics  []ManifestTopic `yaml:"topics"`
+}
+
+type ManifestCluster struct {
+	ID           string `yaml:"id"`
+	RestEndpoint string `yaml:"rest_endpoint"`
+}
+
+// ManifestTopic is one topic to create. Configs are already filtered to the
+// topic configs Confluent Cloud accepts at create time.
+type ManifestTopic struct {
+	Name       string            `yaml:"name"`
+	SourceName string            `yaml:"source_name,omitempty"`
+	Partitions int               `yaml:"partitions"`
+	Configs    map[string]string `yaml:"configs,omitempty"`
+}
+
+// Build assembles a manifest for the given topics. destinationName maps a
+// source topic name to the name it is created under on Confluent Cloud;
+// SourceName is only recorded when the two differ.
+func Build(topics []types.TopicDetails, destinationName func(string) string, clusterID, restEndpoint string) Manifest {
+	manifest := Manifest{
+		Cluster: ManifestCluster{ID: clusterID, RestEndpoint: restEndpoint},
+		Topics:  make([]ManifestTopic, 0, len(topics)),
+	}
+	for _, topic := range topics {
+		mt := ManifestTopic{
+			Name:       destinationName(topic.Name),
+			Partitions: topic.Partitions,
+			Configs:    confluent.FilterCCSupportedConfigs(topic.Configurations),
+		}
+		if mt.Name != topic.Name {
+			mt.SourceName = topic.Name
+		}
+		if len(mt.Configs) == 0 {
+			mt.Configs = nil
+		}
+		manifest.Topics = append(manifest.Topics, mt)
+	}
+	return manifest
+}
+
+func (m Manifest) YAML() ([]byte, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal topic manifest: %w", err)
+	}
+	header := fmt.Sprintf("# Generated by kcp (version: %s, commit: %s)\n", build_info.Version, build_info.Commit)
+	return append([]byte(header), data...), nil
+}
+
+// CreateScript renders one `confluent kafka topic create` per manifest topic.
+// --if-not-exists keeps the script safe to re-run after a partial failure.
+func (m Manifest) CreateScript() string {
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&b, "# Generated by kcp (version: %s, commit: %s).\n", build_info.Version, build_info.Commit)
+	b.WriteString("# Creates the topics listed in topics.yaml. Log in with `confluent login` first.\n")
+	b.WriteString("set -euo pipefail\n\n")
+	fmt.Fprintf(&b, "CLUSTER_ID=\"${CLUSTER_ID:-%s}\"\n", m.Cluster.ID)
+
+	for _, topic := range m.Topics {
+		fmt.Fprintf(&b, "\nconfluent kafka topic create %s --cluster \"$CLUSTER_ID\" --partitions %d --if-not-exists",
+			shellQuote(topic.Name), topic.Partitions)
+
+		keys := make([]string, 0, len(topic.Configs))
+		for k := range topic.Configs {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, " \\\n  --config %s", shellQuote(configFlagValue(k, topic.Configs[k])))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// configFlagValue renders key=value for the CLI's --config flag. The flag is
+// parsed as CSV, so values containing commas (e.g. cleanup.policy
+// "compact,delete") must be wrapped in double quotes.
+func configFlagValue(key, value string) string {
+	kv := key + "=" + value
+	if strings.ContainsAny(kv, ",\"") {
+		return `"` + strings.ReplaceAll(kv, `"`, `""`) + `"`
+	}
+	return kv
+}
+
+// shellQuote wraps s in single quotes for bash.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,74 @@
+package topic_manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func sampleTopics() []types.TopicDetails {
+	return []types.TopicDetails{
+		{
+			Name:       "orders",
+			Partitions: 6,
+			Configurations: map[string]*string{
+				"cleanup.policy":     strPtr("compact,delete"),
+				"retention.ms":       strPtr("604800000"),
+				"replication.factor": strPtr("3"),
+			},
+		},
+		{Name: "audit", Partitions: 1},
+	}
+}
+
+func TestBuild_TranslatesConfigsAndNames(t *testing.T) {
+	rename := func(name string) string {
+		if name == "orders" {
+			return "sales.orders"
+		}
+		return name
+	}
+
+	manifest := Build(sampleTopics(), rename, "lkc-abc", "https://lkc-abc.confluent.cloud:443")
+	require.Len(t, manifest.Topics, 2)
+
+	orders := manifest.Topics[0]
+	assert.Equal(t, "sales.orders", orders.Name)
+	assert.Equal(t, "orders", orders.SourceName)
+	assert.Equal(t, 6, orders.Partitions)
+	assert.Equal(t, map[string]string{"cleanup.policy": "compact,delete", "retention.ms": "604800000"}, orders.Configs)
+
+	audit := manifest.Topics[1]
+	assert.Empty(t, audit.SourceName)
+	assert.Nil(t, audit.Configs)
+}
+
+func TestManifest_YAMLRoundTrips(t *testing.T) {
+	manifest := Build(sampleTopics(), func(n string) string { return n }, "lkc-abc", "https://lkc-abc.confluent.cloud:443")
+
+	data, err := manifest.YAML()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# Generated by kcp"))
+
+	var decoded Manifest
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, manifest, decoded)
+}
+
+func TestManifest_CreateScript(t *testing.T) {
+	manifest := Build(sampleTopics(), func(n string) string { return "msk." + n }, "lkc-abc", "")
+
+	script := manifest.CreateScript()
+	assert.Contains(t, script, `CLUSTER_ID="${CLUSTER_ID:-lkc-abc}"`)
+	assert.Contains(t, script, `confluent kafka topic create 'msk.orders' --cluster "$CLUSTER_ID" --partitions 6 --if-not-exists`)
+	assert.Contains(t, script, `--config '"cleanup.policy=compact,delete"'`)
+	assert.Contains(t, script, `--config 'retention.ms=604800000'`)
+	assert.NotContains(t, script, "replication.factor")
+	assert.Contains(t, script, `confluent kafka topic create 'msk.audit' --cluster "$CLUSTER_ID" --partitions 1 --if-not-exists`+"\n")
+}
@@ -0,0 +1,210 @@
+// Package topicnaming maps source topic names to the names they take on
+// Confluent Cloud, so a migration can adopt a new naming convention. The same
+// mapping drives the topic manifests, the cluster link prefix, the translated
+// ACLs and the client playbooks, which keeps every artifact in agreement.
+package topicnaming
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// maxTopicNameLength is the longest topic name Kafka accepts.
+const maxTopicNameLength = 249
+
+var validTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// Rule renames every source topic whose whole name matches Pattern.
+// Replacement may reference capture groups ($1, ${name}).
+type Rule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// ParseRule parses a PATTERN=REPLACEMENT rule, e.g. `legacy\.(.*)=orders.$1`.
+// The pattern must match the whole topic name. The rule is split on the last
+// '=', so the pattern may contain one but the replacement may not.
+func ParseRule(s string) (Rule, error) {
+	i := strings.LastIndex(s, "=")
+	if i <= 0 || i == len(s)-1 {
+		return Rule{}, fmt.Errorf("invalid rename rule %q: expected PATTERN=REPLACEMENT", s)
+	}
+	pattern, err := regexp.Compile("^(?:" + s[:i] + ")$")
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rename rule %q: %w", s, err)
+	}
+	return Rule{Pattern: pattern, Replacement: s[i+1:]}, nil
+}
+
+// Mapping is a topic naming scheme. A source topic takes its explicit rename
+// if it has one, else the first rule it matches, else Prefix + its name.
+// Policy, when set, is a pattern every destination name must fully match.
+type Mapping struct {
+	Prefix  string
+	Renames map[string]string
+	Rules   []Rule
+	Policy  *regexp.Regexp
+}
+
+// IsIdentity reports whether the mapping keeps every topic name.
+func (m Mapping) IsIdentity() bool {
+	return m.Prefix == "" && len(m.Renames) == 0 && len(m.Rules) == 0
+}
+
+// Destination returns the name the source topic takes on Confluent Cloud.
+func (m Mapping) Destination(source string) string {
+	if renamed, ok := m.Renames[source]; ok {
+		return renamed
+	}
+	for _, rule := range m.Rules {
+		if rule.Pattern.MatchString(source) {
+			return rule.Pattern.ReplaceAllString(source, rule.Replacement)
+		}
+	}
+	return m.Prefix + source
+}
+
+// ResolvedRenames returns the destination of every source topic that does
+// not simply take the prefix, for consumers that only understand a prefix
+// plus explicit renames (the Terraform generators).
+func (m Mapping) ResolvedRenames(sources []string) map[string]string {
+	renames := make(map[string]string)
+	for _, source := range sources {
+		if destination := m.Destination(source); destination != m.Prefix+source {
+			renames[source] = destination
+		}
+	}
+	return renames
+}
+
+// Validate checks the mapping against the source topics it applies to:
+// explicit renames must name one of them, and every destination must be a
+// valid Kafka topic name, match the naming policy and be unique.
+func (m Mapping) Validate(sources []string) error {
+	known := make(map[string]struct{}, len(sources))
+	for _, source := range sources {
+		known[source] = struct{}{}
+	}
+	renamed := make([]string, 0, len(m.Renames))
+	for source := range m.Renames {
+		renamed = append(renamed, source)
+	}
+	sort.Strings(renamed)
+	for _, source := range renamed {
+		if _, ok := known[source]; !ok {
+			return fmt.Errorf("rename references %q, which is not among the selected topics", source)
+		}
+	}
+
+	claimed := make(map[string]string, len(sources))
+	var violations []string
+	for _, source := range sources {
+		destination := m.Destination(source)
+		if err := validateTopicName(destination); err != nil {
+			return fmt.Errorf("topic %q would be created as %q: %w", source, destination, err)
+		}
+		if other, exists := claimed[destination]; exists {
+			return fmt.Errorf("source topics %q and %q would both be created as %q", other, source, destination)
+		}
+		claimed[destination] = source
+		if m.Policy != nil && !strings.HasPrefix(source, "__") && !m.Policy.MatchString(destination) {
+			violations = append(violations, destination)
+		}
+	}
+	if len(violations) > 0 {
+		shown := violations[:min(len(violations), 10)]
+		return fmt.Errorf("%d destination topic name(s) do not match the naming policy %s: %s", len(violations), m.Policy.String(), strings.Join(shown, ", "))
+	}
+	return nil
+}
+
+// LinkPrefix returns the cluster.link.prefix that produces the mapping for
+// the given source topics. A cluster link can only prepend one prefix to
+// every mirror topic, so ok is false when any topic is renamed otherwise.
+func (m Mapping) LinkPrefix(sources []string) (prefix string, ok bool) {
+	for _, source := range sources {
+		if m.Destination(source) != m.Prefix+source {
+			return "", false
+		}
+	}
+	return m.Prefix, true
+}
+
+// RenameAcls returns the ACLs with their topic resources renamed. LITERAL
+// topic names take their destination name; PREFIXED patterns take the
+// mapping's prefix, since a rename or rule cannot be applied to a prefix.
+// The '*' wildcard is kept.
+func (m Mapping) RenameAcls(acls []types.Acls) []types.Acls {
+	renamed := make([]types.Acls, len(acls))
+	for i, acl := range acls {
+		renamed[i] = acl
+		if !strings.EqualFold(acl.ResourceType, "Topic") || acl.ResourceName == "*" {
+			continue
+		}
+		if strings.EqualFold(acl.ResourcePatternType, "PREFIXED") {
+			renamed[i].ResourceName = m.Prefix + acl.ResourceName
+		} else {
+			renamed[i].ResourceName = m.Destination(acl.ResourceName)
+		}
+	}
+	return renamed
+}
+
+// LoadRenamesCSV reads explicit renames from a CSV file of source,destination
+// rows. A first row of "source,destination" is treated as a header, and
+// lines starting with '#' are ignored.
+func LoadRenamesCSV(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rename file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	renames := make(map[string]string)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rename file %s: %w", path, err)
+		}
+		source, destination := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if row == 1 && strings.EqualFold(source, "source") && strings.EqualFold(destination, "destination") {
+			continue
+		}
+		if source == "" || destination == "" {
+			return nil, fmt.Errorf("rename file %s: row %d has an empty source or destination name", path, row)
+		}
+		if previous, exists := renames[source]; exists && previous != destination {
+			return nil, fmt.Errorf("rename file %s: %q is renamed to both %q and %q", path, source, previous, destination)
+		}
+		renames[source] = destination
+	}
+	return renames, nil
+}
+
+func validateTopicName(name string) error {
+	switch {
+	case name == "" || name == "." || name == "..":
+		return fmt.Errorf("not a valid topic name")
+	case len(name) > maxTopicNameLength:
+		return fmt.Errorf("topic names are limited to %d characters", maxTopicNameLength)
+	case !validTopicName.MatchString(name):
+		return fmt.Errorf("topic names may only contain letters, digits, '.', '_' and '-'")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package topicnaming
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRule(t *testing.T, s string) Rule {
+	t.Helper()
+	rule, err := ParseRule(s)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestParseRule(t *testing.T) {
+	rule := mustRule(t, `a=b\.(.*)=x.$1`)
+	assert.Equal(t, "x.$1", rule.Replacement, "the rule is split on the last '='")
+	assert.True(t, rule.Pattern.MatchString("a=b.c"))
+	assert.False(t, rule.Pattern.MatchString("za=b.c"), "the pattern matches the whole name")
+
+	for _, invalid := range []string{"orders", "=orders", "orders=", "(=x"} {
+		_, err := ParseRule(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMapping_Destination(t *testing.T) {
+	mapping := Mapping{
+		Prefix:  "msk.",
+		Renames: map[string]string{"legacy-orders": "orders"},
+		Rules:   []Rule{mustRule(t, `legacy-(.*)=$1.v1`), mustRule(t, `legacy-payments=never`)},
+	}
+
+	assert.Equal(t, "orders", mapping.Destination("legacy-orders"), "explicit renames come first")
+	assert.Equal(t, "payments.v1", mapping.Destination("legacy-payments"), "the first matching rule wins")
+	assert.Equal(t, "msk.events", mapping.Destination("events"))
+	assert.Equal(t, map[string]string{"legacy-orders": "orders", "legacy-payments": "payments.v1"}, mapping.ResolvedRenames([]string{"legacy-orders", "legacy-payments", "events"}))
+	assert.True(t, Mapping{}.IsIdentity())
+	assert.False(t, mapping.IsIdentity())
+}
+
+func TestMapping_Validate(t *testing.T) {
+	sources := []string{"orders", "legacy-orders", "events"}
+
+	tests := []struct {
+		name    string
+		mapping Mapping
+		wantErr string
+	}{
+		{name: "identity", mapping: Mapping{}},
+		{name: "prefix and rename", mapping: Mapping{Prefix: "msk.", Renames: map[string]string{"legacy-orders": "orders-v1"}}},
+		{name: "unknown source", mapping: Mapping{Renames: map[string]string{"typo": "x"}}, wantErr: `references "typo"`},
+		{name: "collision", mapping: Mapping{Renames: map[string]string{"legacy-orders": "orders"}}, wantErr: `would both be created as "orders"`},
+		{name: "invalid name", mapping: Mapping{Rules: []Rule{mustRule(t, `events=events/v1`)}}, wantErr: "may only contain"},
+		{name: "policy match", mapping: Mapping{Prefix: "msk.", Policy: regexp.MustCompile(`^msk\..+$`)}},
+		{name: "policy violation", mapping: Mapping{Policy: regexp.MustCompile(`^[a-z]+$`)}, wantErr: "1 destination topic name(s) do not match the naming policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate(sources)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMapping_LinkPrefix(t *testing.T) {
+	sources := []string{"orders", "events"}
+
+	prefix, ok := Mapping{Prefix: "msk."}.LinkPrefix(sources)
+	assert.True(t, ok)
+	assert.Equal(t, "msk.", prefix)
+
+	prefix, ok = Mapping{Prefix: "msk.", Renames: map[string]string{"orders": "msk.orders"}}.LinkPrefix(sources)
+	assert.True(t, ok, "a rename that agrees with the prefix keeps the mapping a prefix")
+	assert.Equal(t, "msk.", prefix)
+
+	_, ok = Mapping{Rules: []Rule{mustRule(t, `orders=orders.v1`)}}.LinkPrefix(sources)
+	assert.False(t, ok)
+}
+
+func TestMapping_RenameAcls(t *testing.T) {
+	mapping := Mapping{Prefix: "msk.", Renames: map[string]string{"legacy-orders": "orders"}}
+	acls := []types.Acls{
+		{ResourceType: "Topic", ResourceName: "legacy-orders", ResourcePatternType: "LITERAL"},
+		{ResourceType: "Topic", ResourceName: "payments.", ResourcePatternType: "PREFIXED"},
+		{ResourceType: "Topic", ResourceName: "*", ResourcePatternType: "LITERAL"},
+		{ResourceType: "Group", ResourceName: "legacy-orders", ResourcePatternType: "LITERAL"},
+	}
+
+	renamed := mapping.RenameAcls(acls)
+	assert.Equal(t, "orders", renamed[0].ResourceName)
+	assert.Equal(t, "msk.payments.", renamed[1].ResourceName)
+	assert.Equal(t, "*", renamed[2].ResourceName)
+	assert.Equal(t, "legacy-orders", renamed[3].ResourceName, "only topic resources are renamed")
+	assert.Equal(t, "legacy-orders", acls[0].ResourceName, "the input is not modified")
+}
+
+func TestLoadRenamesCSV(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "renames.csv")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	renames, err := LoadRenamesCSV(write("source,destination\n# comment\nlegacy-orders, orders\nevents,events.v1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"legacy-orders": "orders", "events": "events.v1"}, renames)
+
+	_, err = LoadRenamesCSV(write("orders,\n"))
+	assert.ErrorContains(t, err, "empty source or destination")
+
+	_, err = LoadRenamesCSV(write("orders,a\norders,b\n"))
+	assert.ErrorContains(t, err, "renamed to both")
+
+	_, err = LoadRenamesCSV(write("orders,a,b\n"))
+	assert.Error(t, err)
+}
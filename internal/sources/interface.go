@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 
+	"github.com/confluentinc/kcp/internal/client"
 	"github.com/confluentinc/kcp/internal/types"
 )
 
@@ -14,7 +15,9 @@ type Source interface {
 	// LoadCredentials loads authentication credentials from a file
 	LoadCredentials(credentialsPath string) error
 
-	// Scan performs discovery/scanning of the source clusters
+	// Scan performs discovery/scanning of the source clusters. Once ctx is
+	// cancelled it stops before the next cluster and returns the clusters
+	// scanned so far.
 	Scan(ctx context.Context, opts ScanOptions) (*ScanResult, error)
 
 	// GetClusters returns the list of clusters available to scan
@@ -32,9 +35,25 @@ type ClusterIdentifier struct {
 type ScanOptions struct {
 	SkipTopics bool
 	SkipACLs   bool
+	// SampleMessages is how many of each topic's most recent messages to
+	// read to infer its serialization format; 0 disables sampling.
+	SampleMessages int
+	// AutoAuth tries each auth method the cluster advertises instead of the
+	// one marked use in the credentials file. MSK only.
+	AutoAuth bool
+	// SSMBastionInstanceID, when set, connects to brokers through Session
+	// Manager port forwarding on this instance instead of directly. MSK only.
+	SSMBastionInstanceID string
+	// Connection overrides the Kafka client timeouts and protocol version.
+	// InsecureSkipTLSVerify is ignored for MSK, whose brokers use
+	// AWS-managed certificates.
+	Connection client.ConnectionSettings
 	// State is the existing kcp state. Required for MSK scanning (broker addresses
 	// come from prior kcp discover output). Ignored by OSK.
 	State *types.State
+	// SkipClusters are the UniqueIDs of clusters an interrupted scan already
+	// saved, which a resumed scan leaves out.
+	SkipClusters map[string]bool
 }
 
 // ScanResult contains the results of scanning a source
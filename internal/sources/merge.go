@@ -0,0 +1,112 @@
+package sources
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// MergeResultsIntoState merges scan results into state
+func MergeResultsIntoState(state *types.State, result *ScanResult) error {
+	switch result.SourceType {
+	case types.SourceTypeMSK:
+		return mergeMSKResults(state, result)
+	case types.SourceTypeOSK:
+		return mergeOSKResults(state, result)
+	default:
+		return fmt.Errorf("unsupported source type: %s", result.SourceType)
+	}
+}
+
+// mergeMSKResults merges MSK scan results into state
+func mergeMSKResults(state *types.State, result *ScanResult) error {
+	if state.MSKSources == nil {
+		return fmt.Errorf("no MSK sources in state; run 'kcp discover' before scanning MSK clusters")
+	}
+
+	// Index scanned results by ARN for O(1) lookup
+	scannedByARN := make(map[string]*types.KafkaAdminClientInformation, len(result.Clusters))
+	for i := range result.Clusters {
+		c := &result.Clusters[i]
+		scannedByARN[c.Identifier.UniqueID] = c.KafkaAdminInfo
+	}
+
+	// Apply results into state in-place. Merge old admin info into the new
+	// scan result before overwriting so previously-discovered data (topics,
+	// ACLs, self-managed connectors) is preserved when the new scan returns
+	// empty/nil for those fields. Mirrors the OSK merge path; ensures a
+	// re-run of `kcp scan clusters` does not wipe data already in state.
+	for i := range state.MSKSources.Regions {
+		for j := range state.MSKSources.Regions[i].Clusters {
+			cluster := &state.MSKSources.Regions[i].Clusters[j]
+			if info, ok := scannedByARN[cluster.Arn]; ok {
+				info.MergeFrom(cluster.KafkaAdminClientInformation)
+				cluster.KafkaAdminClientInformation = *info
+			}
+		}
+	}
+
+	slog.Debug("merged MSK scan results", "clusters_scanned", len(result.Clusters))
+	return nil
+}
+
+// mergeOSKResults merges OSK scan results into state
+func mergeOSKResults(state *types.State, result *ScanResult) error {
+	if state.OSKSources == nil {
+		state.OSKSources = &types.OSKSourcesState{
+			Clusters: []types.OSKDiscoveredCluster{},
+		}
+	}
+
+	// Build index of existing clusters by ID for efficient lookup
+	existingIndex := make(map[string]int)
+	for i := range state.OSKSources.Clusters {
+		existingIndex[state.OSKSources.Clusters[i].ID] = i
+	}
+
+	// Separate scan results into updates and new clusters to avoid pointer
+	// invalidation: appending to the slice may reallocate the backing array,
+	// which would invalidate any pointers taken before the append.
+	var newClusters []types.OSKDiscoveredCluster
+
+	for _, clusterResult := range result.Clusters {
+		metadata, ok := clusterResult.SourceSpecificData.(types.OSKClusterMetadata)
+		if !ok {
+			return fmt.Errorf("invalid source-specific data for Apache Kafka cluster")
+		}
+
+		newCluster := types.OSKDiscoveredCluster{
+			ID:                          clusterResult.Identifier.UniqueID,
+			BootstrapServers:            clusterResult.Identifier.BootstrapServers,
+			KafkaAdminClientInformation: *clusterResult.KafkaAdminInfo,
+			Metadata:                    metadata,
+		}
+
+		if idx, exists := existingIndex[newCluster.ID]; exists {
+			existing := state.OSKSources.Clusters[idx]
+
+			// Merge KafkaAdminClientInformation: preserve old topics/ACLs/connectors
+			// if the new scan returned empty results (e.g. transient permission failure)
+			newCluster.KafkaAdminClientInformation.MergeFrom(existing.KafkaAdminClientInformation)
+
+			// Preserve discovered clients, metrics and annotations from prior scans
+			newCluster.DiscoveredClients = existing.DiscoveredClients
+			newCluster.Annotations = existing.Annotations
+			if newCluster.ClusterMetrics == nil {
+				newCluster.ClusterMetrics = existing.ClusterMetrics
+			}
+
+			// Update in-place by index
+			state.OSKSources.Clusters[idx] = newCluster
+		} else {
+			newClusters = append(newClusters, newCluster)
+		}
+	}
+
+	// Append new clusters after all in-place updates are done
+	state.OSKSources.Clusters = append(state.OSKSources.Clusters, newClusters...)
+
+	slog.Debug("merged Apache Kafka scan results", "clusters", len(result.Clusters))
+	return nil
+}
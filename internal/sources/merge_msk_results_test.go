@@ -1,9 +1,8 @@
-package clusters
+package sources
 
 import (
 	"testing"
 
-	"github.com/confluentinc/kcp/internal/sources"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,7 +10,7 @@ import (
 
 func TestMergeMSKResults_ErrorsWhenNoMSKSourcesInState(t *testing.T) {
 	state := &types.State{}
-	result := &sources.ScanResult{SourceType: types.SourceTypeMSK}
+	result := &ScanResult{SourceType: types.SourceTypeMSK}
 
 	err := mergeMSKResults(state, result)
 	require.Error(t, err)
@@ -50,11 +49,11 @@ func TestMergeMSKResults_RescanPreservesOldDataOnEmpty(t *testing.T) {
 		},
 	}
 
-	result := &sources.ScanResult{
+	result := &ScanResult{
 		SourceType: types.SourceTypeMSK,
-		Clusters: []sources.ClusterScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{UniqueID: arn},
+				Identifier: ClusterIdentifier{UniqueID: arn},
 				// Re-scan returned empty topics/ACLs (e.g. transient permission failure)
 				KafkaAdminInfo: &types.KafkaAdminClientInformation{
 					ClusterID: "new-id",
@@ -108,11 +107,11 @@ func TestMergeMSKResults_RescanPreservesSelfManagedConnectors(t *testing.T) {
 		},
 	}
 
-	result := &sources.ScanResult{
+	result := &ScanResult{
 		SourceType: types.SourceTypeMSK,
-		Clusters: []sources.ClusterScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{UniqueID: arn},
+				Identifier: ClusterIdentifier{UniqueID: arn},
 				// Post-refactor scan-clusters shape: SelfManagedConnectors is nil.
 				KafkaAdminInfo: &types.KafkaAdminClientInformation{
 					SelfManagedConnectors: nil,
@@ -162,11 +161,11 @@ func TestMergeMSKResults_NewDataTakesPrecedence(t *testing.T) {
 		},
 	}
 
-	result := &sources.ScanResult{
+	result := &ScanResult{
 		SourceType: types.SourceTypeMSK,
-		Clusters: []sources.ClusterScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{UniqueID: arn},
+				Identifier: ClusterIdentifier{UniqueID: arn},
 				KafkaAdminInfo: &types.KafkaAdminClientInformation{
 					Topics: &types.Topics{
 						Details: []types.TopicDetails{
@@ -227,11 +226,11 @@ func TestMergeMSKResults_UnscannedClustersUntouched(t *testing.T) {
 		},
 	}
 
-	result := &sources.ScanResult{
+	result := &ScanResult{
 		SourceType: types.SourceTypeMSK,
-		Clusters: []sources.ClusterScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{UniqueID: scannedArn},
+				Identifier: ClusterIdentifier{UniqueID: scannedArn},
 				KafkaAdminInfo: &types.KafkaAdminClientInformation{
 					ClusterID: "scanned-new",
 				},
@@ -1,9 +1,8 @@
-package clusters
+package sources
 
 import (
 	"testing"
 
-	"github.com/confluentinc/kcp/internal/sources"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,7 +10,7 @@ import (
 
 func TestMergeOSKResults_InitializesNilOSKSources(t *testing.T) {
 	state := &types.State{}
-	result := &sources.ScanResult{}
+	result := &ScanResult{}
 
 	err := mergeOSKResults(state, result)
 	require.NoError(t, err)
@@ -26,10 +25,10 @@ func TestMergeOSKResults_AppendsNewCluster(t *testing.T) {
 		},
 	}
 
-	result := &sources.ScanResult{
-		Clusters: []sources.ClusterScanResult{
+	result := &ScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{
+				Identifier: ClusterIdentifier{
 					UniqueID:         "cluster-1",
 					BootstrapServers: []string{"broker1:9092"},
 				},
@@ -66,10 +65,10 @@ func TestMergeOSKResults_UpdateExistingPreservesMetricsAndClients(t *testing.T)
 		},
 	}
 
-	result := &sources.ScanResult{
-		Clusters: []sources.ClusterScanResult{
+	result := &ScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{
+				Identifier: ClusterIdentifier{
 					UniqueID:         "cluster-1",
 					BootstrapServers: []string{"broker1:9092", "broker2:9092"},
 				},
@@ -116,10 +115,10 @@ func TestMergeOSKResults_RescanPreservesOldTopicsOnEmpty(t *testing.T) {
 		},
 	}
 
-	result := &sources.ScanResult{
-		Clusters: []sources.ClusterScanResult{
+	result := &ScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{
+				Identifier: ClusterIdentifier{
 					UniqueID:         "cluster-1",
 					BootstrapServers: []string{"broker1:9092"},
 				},
@@ -162,11 +161,11 @@ func TestMergeOSKResults_MixedUpdateAndAppend(t *testing.T) {
 		ClusterMetrics:   &types.ProcessedClusterMetrics{Region: "preserved"},
 	}
 
-	result := &sources.ScanResult{
-		Clusters: []sources.ClusterScanResult{
+	result := &ScanResult{
+		Clusters: []ClusterScanResult{
 			{
 				// Update existing
-				Identifier: sources.ClusterIdentifier{
+				Identifier: ClusterIdentifier{
 					UniqueID:         "existing-cluster",
 					BootstrapServers: []string{"new-broker:9092"},
 				},
@@ -175,7 +174,7 @@ func TestMergeOSKResults_MixedUpdateAndAppend(t *testing.T) {
 			},
 			{
 				// Append new
-				Identifier: sources.ClusterIdentifier{
+				Identifier: ClusterIdentifier{
 					UniqueID:         "new-cluster",
 					BootstrapServers: []string{"broker3:9092"},
 				},
@@ -204,10 +203,10 @@ func TestMergeOSKResults_InvalidSourceSpecificData(t *testing.T) {
 		OSKSources: &types.OSKSourcesState{},
 	}
 
-	result := &sources.ScanResult{
-		Clusters: []sources.ClusterScanResult{
+	result := &ScanResult{
+		Clusters: []ClusterScanResult{
 			{
-				Identifier: sources.ClusterIdentifier{
+				Identifier: ClusterIdentifier{
 					UniqueID: "cluster-1",
 				},
 				KafkaAdminInfo:     &types.KafkaAdminClientInformation{},
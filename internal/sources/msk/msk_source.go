@@ -2,15 +2,19 @@ package msk
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
 	"github.com/confluentinc/kcp/internal/client"
 	kafkaservice "github.com/confluentinc/kcp/internal/services/kafka"
+	"github.com/confluentinc/kcp/internal/services/ssmtunnel"
 	"github.com/confluentinc/kcp/internal/sources"
+	"github.com/confluentinc/kcp/internal/tracing"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/confluentinc/kcp/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // MSKSource implements the Source interface for AWS MSK clusters
@@ -76,57 +80,88 @@ func (s *MSKSource) Scan(ctx context.Context, opts sources.ScanOptions) (*source
 	}
 
 	for _, regionAuth := range s.credentials.Regions {
-		for _, clusterAuth := range regionAuth.Clusters {
-			clusterResult, err := s.scanCluster(regionAuth.Name, clusterAuth, opts)
-			if err != nil {
-				slog.Warn("skipping cluster", "cluster", clusterAuth.Name, "error", err)
-				continue
-			}
-			result.Clusters = append(result.Clusters, *clusterResult)
+		if ctx.Err() != nil {
+			break
+		}
+		clusters, err := s.scanRegion(ctx, regionAuth, opts)
+		if err != nil {
+			return nil, err
 		}
+		result.Clusters = append(result.Clusters, clusters...)
 	}
 
 	slog.Info("MSK scan complete", "scanned", len(result.Clusters))
 	return result, nil
 }
 
-func (s *MSKSource) scanCluster(region string, clusterAuth types.ClusterAuth, opts sources.ScanOptions) (*sources.ClusterScanResult, error) {
-	discoveredCluster, err := s.findClusterInState(opts.State, region, clusterAuth.Arn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get cluster from discovery state: %v", err)
+// scanRegion scans the clusters of one region, through an SSM tunnel on the
+// bastion instance when opts.SSMBastionInstanceID is set.
+func (s *MSKSource) scanRegion(ctx context.Context, regionAuth types.RegionAuth, opts sources.ScanOptions) (clusters []sources.ClusterScanResult, err error) {
+	ctx, span := tracing.Start(ctx, "scan.region", attribute.String("cloud.region", regionAuth.Name))
+	defer func() { tracing.End(span, err) }()
+
+	// MSK uses AWS-managed certificates; never skip TLS verification.
+	connection := opts.Connection
+	connection.InsecureSkipTLSVerify = false
+	adminOpts := connection.AdminOptions()
+	if opts.SSMBastionInstanceID != "" {
+		ssmClient, err := client.NewSSMClient(regionAuth.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SSM client: %v", err)
+		}
+		tunnel := ssmtunnel.NewTunnel(ssmClient, opts.SSMBastionInstanceID, regionAuth.Name)
+		defer func() {
+			if err := tunnel.Close(); err != nil {
+				slog.Warn("failed to close SSM tunnel", "instance", opts.SSMBastionInstanceID, "error", err)
+			}
+		}()
+		slog.Info("connecting to brokers through SSM", "instance", opts.SSMBastionInstanceID, "region", regionAuth.Name)
+		adminOpts = append(adminOpts, client.WithDialer(tunnel))
 	}
 
-	authType, err := clusterAuth.GetSelectedAuthType()
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine auth type for cluster: %s in region: %s: %v", clusterAuth.Arn, region, err)
+	for _, clusterAuth := range regionAuth.Clusters {
+		if opts.SkipClusters[clusterAuth.Arn] {
+			slog.Info("skipping cluster scanned before the interruption", "cluster", clusterAuth.Name)
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		clusterResult, err := s.scanCluster(ctx, regionAuth.Name, clusterAuth, opts, adminOpts...)
+		if err != nil {
+			slog.Warn("skipping cluster", "cluster", clusterAuth.Name, "error", err)
+			continue
+		}
+		clusters = append(clusters, *clusterResult)
 	}
+	return clusters, nil
+}
 
-	slog.Info(fmt.Sprintf("starting broker scan using %s authentication", authType))
-	slog.Debug("starting broker scan", "clusterArn", clusterAuth.Arn, "authType", authType)
+func (s *MSKSource) scanCluster(ctx context.Context, region string, clusterAuth types.ClusterAuth, opts sources.ScanOptions, adminOpts ...client.AdminOption) (_ *sources.ClusterScanResult, err error) {
+	ctx, span := tracing.Start(ctx, "scan.cluster", attribute.String("cloud.region", region), attribute.String("kafka.cluster", clusterAuth.Arn))
+	defer func() { tracing.End(span, err) }()
 
-	brokerAddresses, err := discoveredCluster.AWSClientInformation.GetBootstrapBrokersForAuthType(authType)
+	discoveredCluster, err := s.findClusterInState(opts.State, region, clusterAuth.Arn)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get broker addresses for cluster: %s in region: %s: %v", clusterAuth.Arn, region, err)
+		return nil, fmt.Errorf("failed to get cluster from discovery state: %v", err)
 	}
 
-	clientBrokerEncryptionInTransit := utils.GetClientBrokerEncryptionInTransit(discoveredCluster.AWSClientInformation.MskClusterConfig)
-	kafkaVersion := utils.GetKafkaVersion(discoveredCluster.AWSClientInformation)
-
-	kafkaAdmin, err := createKafkaAdmin(authType, brokerAddresses, clientBrokerEncryptionInTransit, region, kafkaVersion, clusterAuth)
+	kafkaAdmin, authType, err := s.connect(region, clusterAuth, discoveredCluster, opts.AutoAuth, adminOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Kafka admin: %v", err)
+		return nil, err
 	}
 	defer func() { _ = (*kafkaAdmin).Close() }()
 
 	ks := kafkaservice.NewKafkaService(*kafkaAdmin, kafkaservice.KafkaServiceOpts{
-		AuthType:   authType,
-		ClusterArn: clusterAuth.Arn,
-		SkipTopics: opts.SkipTopics,
-		SkipACLs:   opts.SkipACLs,
+		AuthType:       authType,
+		ClusterArn:     clusterAuth.Arn,
+		SkipTopics:     opts.SkipTopics,
+		SkipACLs:       opts.SkipACLs,
+		SampleMessages: opts.SampleMessages,
 	})
 
 	clusterType := discoveredCluster.AWSClientInformation.MskClusterConfig.ClusterType
-	kafkaAdminInfo, err := ks.ScanKafkaResources(clusterType)
+	kafkaAdminInfo, err := ks.ScanKafkaResources(ctx, clusterType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan Kafka resources: %v", err)
 	}
@@ -135,6 +170,8 @@ func (s *MSKSource) scanCluster(region string, clusterAuth types.ClusterAuth, op
 	if authType == types.AuthTypeSASLSCRAM && clusterAuth.AuthMethod.SASLScram != nil {
 		kafkaAdminInfo.SaslMechanism = types.NormalizeSaslMechanism(clusterAuth.AuthMethod.SASLScram.Mechanism)
 	}
+	kafkaAdminInfo.AuthType = authType
+	kafkaAdminInfo.OpenMonitoring = discoveredCluster.AWSClientInformation.OpenMonitoring()
 
 	slog.Info("broker scan complete")
 	slog.Debug("broker scan complete", "clusterArn", clusterAuth.Arn)
@@ -149,6 +186,65 @@ func (s *MSKSource) scanCluster(region string, clusterAuth types.ClusterAuth, op
 	}, nil
 }
 
+// connect opens an admin client with the method marked use in the credentials
+// file or, with autoAuth, with the first of the cluster's configured methods
+// that connects, trying them in types.AutoAuthOrder.
+func (s *MSKSource) connect(region string, clusterAuth types.ClusterAuth, discoveredCluster *types.DiscoveredCluster, autoAuth bool, adminOpts ...client.AdminOption) (*client.KafkaAdmin, types.AuthType, error) {
+	if !autoAuth {
+		authType, err := clusterAuth.GetSelectedAuthType()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to determine auth type for cluster: %s in region: %s: %v", clusterAuth.Arn, region, err)
+		}
+
+		slog.Info(fmt.Sprintf("starting broker scan using %s authentication", authType))
+		slog.Debug("starting broker scan", "clusterArn", clusterAuth.Arn, "authType", authType)
+
+		kafkaAdmin, err := connectWithAuthType(region, clusterAuth, discoveredCluster, authType, adminOpts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return kafkaAdmin, authType, nil
+	}
+
+	candidates := clusterAuth.AuthMethod.ConfiguredAuthMethods()
+	if len(candidates) == 0 {
+		return nil, "", fmt.Errorf("no authentication methods configured for cluster: %s in region: %s", clusterAuth.Arn, region)
+	}
+
+	var errs []error
+	for _, authType := range candidates {
+		slog.Debug("trying authentication method", "clusterArn", clusterAuth.Arn, "authType", authType)
+		kafkaAdmin, err := connectWithAuthType(region, clusterAuth, discoveredCluster, authType, adminOpts...)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("%s authentication failed, trying the next method", authType), "clusterArn", clusterAuth.Arn, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %v", authType, err))
+			continue
+		}
+
+		slog.Info(fmt.Sprintf("starting broker scan using %s authentication", authType))
+		slog.Debug("starting broker scan", "clusterArn", clusterAuth.Arn, "authType", authType, "auto", true)
+		return kafkaAdmin, authType, nil
+	}
+
+	return nil, "", fmt.Errorf("no authentication method succeeded for cluster: %s in region: %s: %w", clusterAuth.Arn, region, errors.Join(errs...))
+}
+
+func connectWithAuthType(region string, clusterAuth types.ClusterAuth, discoveredCluster *types.DiscoveredCluster, authType types.AuthType, adminOpts ...client.AdminOption) (*client.KafkaAdmin, error) {
+	brokerAddresses, err := discoveredCluster.AWSClientInformation.GetBootstrapBrokersForAuthType(authType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broker addresses for cluster: %s in region: %s: %v", clusterAuth.Arn, region, err)
+	}
+
+	clientBrokerEncryptionInTransit := utils.GetClientBrokerEncryptionInTransit(discoveredCluster.AWSClientInformation.MskClusterConfig)
+	kafkaVersion := utils.GetKafkaVersion(discoveredCluster.AWSClientInformation)
+
+	kafkaAdmin, err := newKafkaAdmin(authType, brokerAddresses, clientBrokerEncryptionInTransit, region, kafkaVersion, clusterAuth, adminOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka admin: %v", err)
+	}
+	return kafkaAdmin, nil
+}
+
 func (s *MSKSource) findClusterInState(state *types.State, region, clusterArn string) (*types.DiscoveredCluster, error) {
 	if state.MSKSources == nil {
 		return nil, fmt.Errorf("no MSK sources found in state file")
@@ -165,14 +261,17 @@ func (s *MSKSource) findClusterInState(state *types.State, region, clusterArn st
 	return nil, fmt.Errorf("cluster %s not found in region %s", clusterArn, region)
 }
 
-func createKafkaAdmin(authType types.AuthType, brokerAddresses []string, clientBrokerEncryptionInTransit kafkatypes.ClientBroker, region string, kafkaVersion string, clusterAuth types.ClusterAuth) (*client.KafkaAdmin, error) {
+// newKafkaAdmin is replaced in tests so auth fallback can run without brokers.
+var newKafkaAdmin = createKafkaAdmin
+
+func createKafkaAdmin(authType types.AuthType, brokerAddresses []string, clientBrokerEncryptionInTransit kafkatypes.ClientBroker, region string, kafkaVersion string, clusterAuth types.ClusterAuth, adminOpts ...client.AdminOption) (*client.KafkaAdmin, error) {
 	// MSK uses AWS-managed certificates; never skip TLS verification.
 	authOpt, err := client.AdminOptionForAuthMethod(authType, clusterAuth.AuthMethod, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve auth option: %w", err)
 	}
 
-	kafkaAdmin, err := client.NewKafkaAdmin(brokerAddresses, clientBrokerEncryptionInTransit, region, kafkaVersion, authOpt)
+	kafkaAdmin, err := client.NewKafkaAdmin(brokerAddresses, clientBrokerEncryptionInTransit, region, kafkaVersion, append([]client.AdminOption{authOpt}, adminOpts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kafka admin: %v", err)
 	}
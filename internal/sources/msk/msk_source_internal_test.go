@@ -1,14 +1,19 @@
 package msk
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/mocks"
 	"github.com/confluentinc/kcp/internal/sources"
 	"github.com/confluentinc/kcp/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMSKSource_findClusterInState(t *testing.T) {
@@ -271,7 +276,7 @@ func TestMSKSource_scanCluster(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			opts := sources.ScanOptions{State: tt.state}
-			_, err := tt.source.scanCluster(tt.region, tt.clusterAuth, opts)
+			_, err := tt.source.scanCluster(context.Background(), tt.region, tt.clusterAuth, opts)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -282,3 +287,89 @@ func TestMSKSource_scanCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestMSKSource_connect_AutoAuth(t *testing.T) {
+	discoveredCluster := &types.DiscoveredCluster{
+		Name: "test-cluster",
+		Arn:  "arn:aws:kafka:us-east-1:123456789012:cluster/test-cluster/abc-123",
+		AWSClientInformation: types.AWSClientInformation{
+			BootstrapBrokers: kafka.GetBootstrapBrokersOutput{
+				BootstrapBrokerStringSaslIam:   aws.String("broker1:9098"),
+				BootstrapBrokerStringSaslScram: aws.String("broker1:9096"),
+				BootstrapBrokerStringTls:       aws.String("broker1:9094"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		authMethod   types.AuthMethodConfig
+		failing      map[types.AuthType]bool
+		wantAuthType types.AuthType
+		wantTried    []types.AuthType
+		wantErrMsg   string
+	}{
+		{
+			name: "first advertised method connects",
+			authMethod: types.AuthMethodConfig{
+				IAM:       &types.IAMConfig{Use: false},
+				SASLScram: &types.SASLScramConfig{Use: true, Username: "user", Password: "pass"},
+			},
+			wantAuthType: types.AuthTypeIAM,
+			wantTried:    []types.AuthType{types.AuthTypeIAM},
+		},
+		{
+			name: "falls back past failing methods",
+			authMethod: types.AuthMethodConfig{
+				TLS:       &types.TLSConfig{Use: false},
+				IAM:       &types.IAMConfig{Use: true},
+				SASLScram: &types.SASLScramConfig{Use: false, Username: "user", Password: "pass"},
+			},
+			failing:      map[types.AuthType]bool{types.AuthTypeIAM: true, types.AuthTypeSASLSCRAM: true},
+			wantAuthType: types.AuthTypeTLS,
+			wantTried:    []types.AuthType{types.AuthTypeIAM, types.AuthTypeSASLSCRAM, types.AuthTypeTLS},
+		},
+		{
+			name: "skips methods without brokers",
+			authMethod: types.AuthMethodConfig{
+				UnauthenticatedPlaintext: &types.UnauthenticatedPlaintextConfig{Use: false},
+				SASLScram:                &types.SASLScramConfig{Use: false, Username: "user", Password: "pass"},
+			},
+			failing:    map[types.AuthType]bool{types.AuthTypeSASLSCRAM: true},
+			wantTried:  []types.AuthType{types.AuthTypeSASLSCRAM},
+			wantErrMsg: "no authentication method succeeded for cluster",
+		},
+		{
+			name:       "no methods configured",
+			wantErrMsg: "no authentication methods configured for cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tried []types.AuthType
+			original := newKafkaAdmin
+			t.Cleanup(func() { newKafkaAdmin = original })
+			newKafkaAdmin = func(authType types.AuthType, _ []string, _ kafkatypes.ClientBroker, _ string, _ string, _ types.ClusterAuth, _ ...client.AdminOption) (*client.KafkaAdmin, error) {
+				tried = append(tried, authType)
+				if tt.failing[authType] {
+					return nil, fmt.Errorf("authentication failed")
+				}
+				var admin client.KafkaAdmin = &mocks.MockKafkaAdmin{}
+				return &admin, nil
+			}
+
+			clusterAuth := types.ClusterAuth{Arn: discoveredCluster.Arn, AuthMethod: tt.authMethod}
+			_, authType, err := (&MSKSource{}).connect("us-east-1", clusterAuth, discoveredCluster, true)
+
+			assert.Equal(t, tt.wantTried, tried)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuthType, authType)
+		})
+	}
+}
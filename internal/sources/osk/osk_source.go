@@ -10,7 +10,9 @@ import (
 	"github.com/confluentinc/kcp/internal/client"
 	kafkaservice "github.com/confluentinc/kcp/internal/services/kafka"
 	"github.com/confluentinc/kcp/internal/sources"
+	"github.com/confluentinc/kcp/internal/tracing"
 	"github.com/confluentinc/kcp/internal/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // OSKSource implements the Source interface for Apache Kafka clusters
@@ -23,6 +25,16 @@ func NewOSKSource() *OSKSource {
 	return &OSKSource{}
 }
 
+// NewOSKSourceFromCredentials creates an OSK source for credentials built in
+// memory rather than loaded from apache-kafka-credentials.yaml, validating
+// them the same way.
+func NewOSKSourceFromCredentials(creds types.OSKCredentials) (*OSKSource, error) {
+	if valid, errs := creds.Validate(); !valid {
+		return nil, fmt.Errorf("invalid Apache Kafka cluster settings: %v", errs)
+	}
+	return &OSKSource{credentials: &creds}, nil
+}
+
 // Type returns the source type
 func (s *OSKSource) Type() types.SourceType {
 	return types.SourceTypeOSK
@@ -72,6 +84,13 @@ func (s *OSKSource) Scan(ctx context.Context, opts sources.ScanOptions) (*source
 	var scanErrors []error
 
 	for _, clusterCreds := range s.credentials.Clusters {
+		if opts.SkipClusters[clusterCreds.ID] {
+			slog.Info("skipping cluster scanned before the interruption", "id", clusterCreds.ID)
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
 		slog.Info("scanning Apache Kafka cluster", "id", clusterCreds.ID)
 
 		clusterResult, err := s.scanCluster(ctx, clusterCreds, opts)
@@ -108,7 +127,10 @@ func (s *OSKSource) Scan(ctx context.Context, opts sources.ScanOptions) (*source
 }
 
 // scanCluster scans a single OSK cluster using Kafka Admin API
-func (s *OSKSource) scanCluster(ctx context.Context, clusterCreds types.OSKClusterAuth, opts sources.ScanOptions) (*sources.ClusterScanResult, error) {
+func (s *OSKSource) scanCluster(ctx context.Context, clusterCreds types.OSKClusterAuth, opts sources.ScanOptions) (_ *sources.ClusterScanResult, err error) {
+	ctx, span := tracing.Start(ctx, "scan.cluster", attribute.String("kafka.cluster", clusterCreds.ID))
+	defer func() { tracing.End(span, err) }()
+
 	// Skip clusters with all auth methods disabled
 	enabledMethods := clusterCreds.GetAuthMethods()
 	if len(enabledMethods) == 0 {
@@ -128,21 +150,22 @@ func (s *OSKSource) scanCluster(ctx context.Context, clusterCreds types.OSKClust
 		"auth_type", authType,
 		"bootstrap_servers", clusterCreds.BootstrapServers)
 
-	kafkaAdmin, err := s.createKafkaAdmin(clusterCreds, authType)
+	kafkaAdmin, err := s.createKafkaAdmin(clusterCreds, authType, opts.Connection)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kafka admin client: %w", err)
 	}
 	defer func() { _ = kafkaAdmin.Close() }()
 
 	kafkaService := kafkaservice.NewKafkaService(kafkaAdmin, kafkaservice.KafkaServiceOpts{
-		AuthType:   authType,
-		ClusterArn: clusterCreds.ID,
-		SkipTopics: opts.SkipTopics,
-		SkipACLs:   opts.SkipACLs,
+		AuthType:       authType,
+		ClusterArn:     clusterCreds.ID,
+		SkipTopics:     opts.SkipTopics,
+		SkipACLs:       opts.SkipACLs,
+		SampleMessages: opts.SampleMessages,
 	})
 
 	// OSK clusters are always provisioned (never serverless)
-	kafkaAdminInfo, err := kafkaService.ScanKafkaResources(kafkatypes.ClusterTypeProvisioned)
+	kafkaAdminInfo, err := kafkaService.ScanKafkaResources(ctx, kafkatypes.ClusterTypeProvisioned)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan Kafka resources: %w", err)
 	}
@@ -156,6 +179,7 @@ func (s *OSKSource) scanCluster(ctx context.Context, clusterCreds types.OSKClust
 	if authType == types.AuthTypeSASLPlain {
 		kafkaAdminInfo.SaslMechanism = "PLAIN"
 	}
+	kafkaAdminInfo.AuthType = authType
 
 	metadata := types.OSKClusterMetadata{
 		Environment: clusterCreds.Metadata.Environment,
@@ -180,8 +204,9 @@ func (s *OSKSource) scanCluster(ctx context.Context, clusterCreds types.OSKClust
 	}, nil
 }
 
-// createKafkaAdmin creates a Kafka Admin client for the OSK cluster
-func (s *OSKSource) createKafkaAdmin(clusterCreds types.OSKClusterAuth, authType types.AuthType) (client.KafkaAdmin, error) {
+// createKafkaAdmin creates a Kafka Admin client for the OSK cluster. TLS
+// verification is skipped when either the credentials or connection ask for it.
+func (s *OSKSource) createKafkaAdmin(clusterCreds types.OSKClusterAuth, authType types.AuthType, connection client.ConnectionSettings) (client.KafkaAdmin, error) {
 	// Default Kafka version for OSK clusters; region is not applicable for OSK.
 	kafkaVersion := "3.6.0"
 	region := ""
@@ -193,7 +218,7 @@ func (s *OSKSource) createKafkaAdmin(clusterCreds types.OSKClusterAuth, authType
 
 	// clientBrokerEncryptionInTransit is unused inside NewKafkaAdmin; TLS behavior is
 	// driven by the auth option. Pass a uniform value — behavior is unchanged.
-	kafkaAdmin, err := client.NewKafkaAdmin(clusterCreds.BootstrapServers, kafkatypes.ClientBrokerTls, region, kafkaVersion, authOpt)
+	kafkaAdmin, err := client.NewKafkaAdmin(clusterCreds.BootstrapServers, kafkatypes.ClientBrokerTls, region, kafkaVersion, append([]client.AdminOption{authOpt}, connection.AdminOptions()...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kafka admin client: %w", err)
 	}
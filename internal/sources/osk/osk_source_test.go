@@ -123,3 +123,35 @@ clusters:
 	// Disabled cluster should have been skipped
 	assert.Equal(t, 0, len(result.Clusters), "disabled cluster should be skipped")
 }
+
+func TestOSKSource_Scan_StopsWhenCancelled(t *testing.T) {
+	content := `
+clusters:
+  - id: prod-cluster
+    bootstrap_servers:
+      - localhost:1
+    auth_method:
+      unauthenticated_plaintext:
+        use: true
+  - id: staging-cluster
+    bootstrap_servers:
+      - localhost:2
+    auth_method:
+      unauthenticated_plaintext:
+        use: true
+`
+	credFile := filepath.Join(t.TempDir(), "creds.yaml")
+	require.NoError(t, os.WriteFile(credFile, []byte(content), 0644))
+	source := osk.NewOSKSource()
+	require.NoError(t, source.LoadCredentials(credFile))
+
+	// Already-scanned clusters are skipped and a cancelled scan stops
+	// before connecting to the next cluster.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := source.Scan(ctx, sources.ScanOptions{SkipClusters: map[string]bool{"prod-cluster": true}})
+
+	assert.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Empty(t, result.Clusters)
+}
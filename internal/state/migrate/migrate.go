@@ -13,7 +13,7 @@ import (
 // CurrentSchemaVersion is the schema_version this build reads and writes.
 // Bump in lockstep with any breaking change to the kcp-state.json shape, and
 // add the matching upcaster to steps (see internal/state/migrate/steps.go).
-const CurrentSchemaVersion = 1
+const CurrentSchemaVersion = 23
 
 // ErrNewerSchema means the file was written by a newer (released) KCP than this build can model.
 var ErrNewerSchema = errors.New("state file schema is newer than this KCP build supports")
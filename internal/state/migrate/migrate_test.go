@@ -87,13 +87,13 @@ func TestUpgradeForwardIncompatibleDevStamped(t *testing.T) {
 }
 
 func TestUpgradeCurrentIsIdentity(t *testing.T) {
-	data := `{"schema_version":1,"msk_sources":{},"kcp_build_info":{"version":"0.8.5"}}`
+	data := `{"schema_version":23,"msk_sources":{},"kcp_build_info":{"version":"0.8.5"}}`
 	got, from, err := Upgrade([]byte(data))
 	if err != nil {
 		t.Fatalf("Upgrade error: %v", err)
 	}
-	if from != "schema_version=1" {
-		t.Errorf("from label = %q, want schema_version=1", from)
+	if from != "schema_version=23" {
+		t.Errorf("from label = %q, want schema_version=23", from)
 	}
 	if string(got) != data {
 		t.Errorf("current-version data must pass through unchanged.\n got: %s\nwant: %s", got, data)
@@ -128,6 +128,468 @@ func TestUpgradeEraBv073ToC(t *testing.T) {
 	}
 }
 
+func TestUpgradeSchemaV1ToV2(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.0" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV2ToV3(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.1" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV3ToV4(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v3.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.2" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV4ToV5(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v4.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.3" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV5ToV6(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v5.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.4" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV6ToV7(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v6.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.5" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV7ToV8(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v7.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.6" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV8ToV9(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v8.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.7" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV9ToV10(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v9.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.8" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV10ToV11(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v10.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV11ToV12(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v11.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV12ToV13(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v12.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV13ToV14(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v13.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV14ToV15(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v14.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["osk_sources"].(map[string]any); !ok {
+		t.Error("osk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV15ToV16(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v15.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV16ToV17(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v16.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV17ToV18(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v17.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV18ToV19(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v18.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV19ToV20(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v19.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV20ToV21(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v20.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV21ToV22(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v21.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
+func TestUpgradeSchemaV22ToV23(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema-v22.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, from, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if from != "kcp_build_info.version=0.9.9" {
+		t.Errorf("from = %q", from)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["msk_sources"].(map[string]any); !ok {
+		t.Error("msk_sources should pass through unchanged")
+	}
+}
+
 func TestUpgradeUnrecognizedIsNotSpecialCased(t *testing.T) {
 	// A pre-v0.4.0 region-scan file (or any unrelated JSON) is NOT detected or migrated
 	// (spec N5): no Era A branch exists, so it resolves to the current shape and Upgrade
@@ -13,8 +13,9 @@ type step struct {
 }
 
 // steps is the ordered upcaster registry, applied in slice order. Era B only (Era A is out
-// of scope — spec N5). The schema_registries normalization runs before the B→C root reshape
-// so B→C carries the already-normalized object through.
+// of scope — spec N5), plus the era C schema_version bumps. The schema_registries
+// normalization runs before the B→C root reshape so B→C carries the already-normalized
+// object through.
 var steps = []step{
 	{
 		// v0.4.2–v0.7.1 serialized schema_registries as a flat ARRAY of confluent registries;
@@ -62,4 +63,248 @@ var steps = []step{
 			return out, nil
 		},
 	},
+	{
+		// schema_version 2 added the optional
+		// cluster_networking.subnets[].available_ip_address_count. A v1 file is already a
+		// valid v2 file without it (consumers treat the missing count as unknown), so this
+		// is a pure pass-through. Only versioned era C files reach the step chain: an
+		// unversioned era C file is treated as current before any step runs.
+		name:        "C: schema_version 1 -> 2 (subnet available IP counts)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 3 added the optional kafka_admin_client_information.auth_type,
+		// the method the last `kcp scan clusters` connected with. A v2 file is already a
+		// valid v3 file without it (the method is unknown until the next scan), so this is
+		// a pure pass-through.
+		name:        "C: schema_version 2 -> 3 (scan auth type)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 4 added the optional topics.details[].partition_sizes, the
+		// on-disk size of each partition from DescribeLogDirs. A v3 file is a valid v4
+		// file without it (sizes are filled in by the next scan), so this is a pure
+		// pass-through.
+		name:        "C: schema_version 3 -> 4 (partition sizes)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 5 added the optional aws_client_information.in_flight_operations,
+		// the DescribeClusterOperationV2 detail of operations still pending or in progress
+		// at scan time. A v4 file is a valid v5 file without it, so this is a pure
+		// pass-through.
+		name:        "C: schema_version 4 -> 5 (in-flight operations)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 6 added the optional aws_client_information.connectors[].diagnostics,
+		// the failure state, operation history, execution role and log delivery of
+		// FAILED MSK Connect connectors. A v5 file is a valid v6 file without it, so
+		// this is a pure pass-through.
+		name:        "C: schema_version 5 -> 6 (connector diagnostics)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 7 added the optional aws_client_information.iam_access, the IAM
+		// principals whose policies grant kafka-cluster actions on IAM-authenticated
+		// clusters. A v6 file is a valid v7 file without it, so this is a pure
+		// pass-through.
+		name:        "C: schema_version 6 -> 7 (IAM access)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 8 added the optional msk_sources.regions[].replicators, the
+		// DescribeReplicator output of every MSK Replicator in the region. A v7 file is
+		// a valid v8 file without it, so this is a pure pass-through.
+		name:        "C: schema_version 7 -> 8 (MSK replicators)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 9 added the optional kafka_admin_client_information.tls_inspection,
+		// the broker endpoint certificates recorded by scan clusters --inspect-tls. A v8
+		// file is a valid v9 file without it, so this is a pure pass-through.
+		name:        "C: schema_version 8 -> 9 (TLS inspection)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 10 added the optional kafka_admin_client_information.consumer_groups,
+		// each group's state, member count and static members from DescribeGroups. A v9
+		// file is a valid v10 file without it, so this is a pure pass-through.
+		name:        "C: schema_version 9 -> 10 (consumer groups)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 11 added the optional aws_client_information.cluster_networking
+		// broker_enis and security_group_rules: each broker's ENI and attached security
+		// groups, and their inbound rules that reach a Kafka port. A v10 file is a valid
+		// v11 file without them, so this is a pure pass-through.
+		name:        "C: schema_version 10 -> 11 (broker ENIs and security group rules)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 12 added the optional kafka_admin_client_information.plugins:
+		// the JSON object each `kcp scan clusters --plugin` scanner returned, keyed by
+		// plugin name. A v11 file is a valid v12 file without it, so this is a pure
+		// pass-through.
+		name:        "C: schema_version 11 -> 12 (scan plugin output)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 13 added the optional topics.details.serialization: the
+		// message format and schema IDs inferred by `kcp scan clusters
+		// --sample-messages`. A v12 file is a valid v13 file without it, so this
+		// is a pure pass-through.
+		name:        "C: schema_version 12 -> 13 (topic serialization)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 14 added the optional msk_sources.regions.kafka_secrets:
+		// metadata of the Secrets Manager secrets holding Kafka credentials,
+		// from `kcp discover --scan-secrets`. A v13 file is a valid v14 file
+		// without it, so this is a pure pass-through.
+		name:        "C: schema_version 13 -> 14 (kafka secrets)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 15 added the optional connect_url and tasks of
+		// self_managed_connectors.connectors: the Connect REST endpoint each
+		// connector was read from and its task states, now that `kcp scan
+		// self-managed-connectors` can scan several Connect clusters at once.
+		// A v14 file is a valid v15 file without them, so this is a pure
+		// pass-through.
+		name:        "C: schema_version 14 -> 15 (self-managed connector tasks)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 16 added the optional cloudtrail_activity of
+		// msk_sources clusters: the IAM principals and source IPs `kcp scan
+		// cloudtrail-clients` found calling each cluster. A v15 file is a
+		// valid v16 file without it, so this is a pure pass-through.
+		name:        "C: schema_version 15 -> 16 (CloudTrail client activity)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 17 added the optional flow_log_traffic of
+		// msk_sources clusters: the client addresses `kcp scan flow-logs`
+		// found connecting to each cluster's brokers. A v16 file is a valid
+		// v17 file without it, so this is a pure pass-through.
+		name:        "C: schema_version 16 -> 17 (VPC Flow Logs client traffic)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 18 added the optional annotations of msk_sources
+		// and osk_sources clusters and the top-level manual_overrides that
+		// `kcp state annotate` records and every load re-applies. A v17 file
+		// is a valid v18 file without them, so this is a pure pass-through.
+		name:        "C: schema_version 17 -> 18 (manual overrides and cluster annotations)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 19 added the optional partition_health of
+		// kafka_admin_client_information: the under-replicated and offline
+		// partitions and reassignments in flight `kcp scan clusters` found.
+		// A v18 file is a valid v19 file without it, so this is a pure
+		// pass-through.
+		name:        "C: schema_version 18 -> 19 (partition health)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 20 added the optional broker_racks of
+		// kafka_admin_client_information and client_racks of its consumer_groups:
+		// the broker.rack and partition leadership of each broker, and the
+		// client.rack of consumer group members, `kcp scan clusters` found. A v19
+		// file is a valid v20 file without them, so this is a pure pass-through.
+		name:        "C: schema_version 19 -> 20 (broker racks)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 21 added the optional api_version of discovered_clients:
+		// the oldest Produce or Fetch API version each client was seen using in the
+		// broker logs `kcp scan client-inventory` parsed. A v20 file is a valid v21
+		// file without it, so this is a pure pass-through.
+		name:        "C: schema_version 20 -> 21 (client API versions)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 22 added the optional activity of topics.details and
+		// consumer_groups: the end offsets and committed offsets `kcp scan
+		// clusters` observed, and since when they have not moved. A v21 file is
+		// a valid v22 file without them, so this is a pure pass-through.
+		name:        "C: schema_version 21 -> 22 (offset activity)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
+	{
+		// schema_version 23 added the optional open_monitoring of
+		// kafka_admin_client_information: the Open Monitoring exporters `kcp
+		// scan clusters` found enabled on an MSK cluster's brokers. A v22 file
+		// is a valid v23 file without it, so this is a pure pass-through.
+		name:        "C: schema_version 22 -> 23 (open monitoring)",
+		appliesWhen: func(era, _ string) bool { return era == "C" },
+		transform: func(in map[string]any) (map[string]any, error) {
+			return in, nil
+		},
+	},
 }
@@ -0,0 +1,276 @@
+// Package overrides applies manual overrides, a user-maintained list of JSON
+// Patch-style operations, to kcp-state.json documents. It lets knowledge that
+// discovery cannot find, such as which clusters are out of scope or the
+// target environment a cluster moves to, live apart from the discovered data
+// and be re-applied after every discover or scan rewrites it.
+//
+// Operations follow RFC 6902 with these differences, mostly because they are
+// re-applied every time the state is loaded and so must be idempotent:
+//   - only add, replace and remove are supported, and only on object members,
+//     since inserting into or removing from an array is not idempotent;
+//   - removing a member that is already gone does nothing;
+//   - add creates missing parent objects, so a first annotation does not need
+//     its map created first;
+//   - an operation may name a cluster, by MSK ARN, MSK cluster name or OSK
+//     cluster ID, to make its path relative to that cluster instead of the
+//     array index it happens to sit at.
+package overrides
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	OpAdd     = "add"
+	OpReplace = "replace"
+	OpRemove  = "remove"
+)
+
+// protectedPaths are top-level members overrides may not touch: the schema
+// stamp and build provenance, and the overrides themselves.
+var protectedPaths = []string{"schema_version", "kcp_build_info", "manual_overrides"}
+
+// Operation is one override. Path is a JSON Pointer into the state file, or
+// into the Cluster's object when Cluster is set.
+type Operation struct {
+	Op      string          `json:"op"`
+	Cluster string          `json:"cluster,omitempty"`
+	Path    string          `json:"path"`
+	Value   json.RawMessage `json:"value,omitempty"`
+}
+
+func (o Operation) String() string {
+	if o.Cluster != "" {
+		return fmt.Sprintf("%s %s on cluster %s", o.Op, o.Path, o.Cluster)
+	}
+	return fmt.Sprintf("%s %s", o.Op, o.Path)
+}
+
+// Parse reads an overrides file: a JSON array of operations.
+func Parse(data []byte) ([]Operation, error) {
+	var ops []Operation
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&ops); err != nil {
+		return nil, fmt.Errorf("overrides must be a JSON array of operations: %w", err)
+	}
+	for i, op := range ops {
+		if err := op.validate(); err != nil {
+			return nil, fmt.Errorf("operation %d (%s): %w", i, op, err)
+		}
+	}
+	return ops, nil
+}
+
+func (o Operation) validate() error {
+	switch o.Op {
+	case OpAdd, OpReplace:
+		if len(o.Value) == 0 {
+			return fmt.Errorf("%s needs a value", o.Op)
+		}
+	case OpRemove:
+		if len(o.Value) != 0 {
+			return fmt.Errorf("remove takes no value")
+		}
+	default:
+		return fmt.Errorf("unsupported op '%s', expected add, replace or remove", o.Op)
+	}
+
+	tokens, err := splitPointer(o.Path)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("path must not be the whole document")
+	}
+	if o.Cluster == "" && slices.Contains(protectedPaths, tokens[0]) {
+		return fmt.Errorf("/%s cannot be overridden", tokens[0])
+	}
+	return nil
+}
+
+// Apply applies ops to a state document in order. When strict, the first
+// operation that cannot be applied is returned as an error. Otherwise it is
+// skipped and described in skipped, so a cluster that has since been deleted
+// does not stop the state file from loading.
+func Apply(data []byte, ops []Operation, strict bool) (patched []byte, skipped []string, err error) {
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	for i, op := range ops {
+		opErr := op.validate()
+		if opErr == nil {
+			opErr = apply(doc, op)
+		}
+		if opErr != nil {
+			if strict {
+				return nil, nil, fmt.Errorf("operation %d (%s): %w", i, op, opErr)
+			}
+			skipped = append(skipped, fmt.Sprintf("%s: %v", op, opErr))
+		}
+	}
+
+	patched, err = json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	return patched, skipped, nil
+}
+
+// apply applies op to doc in place.
+func apply(doc any, op Operation) error {
+	var value any
+	if len(op.Value) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(op.Value))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("invalid value: %w", err)
+		}
+	}
+
+	tokens, _ := splitPointer(op.Path)
+	target := doc
+	if op.Cluster != "" {
+		cluster, err := findCluster(doc, op.Cluster)
+		if err != nil {
+			return err
+		}
+		target = cluster
+	}
+
+	// Walk to the parent of the last token. Only add may create missing
+	// objects on the way, and remove has nothing to do past a missing one.
+	parent := target
+	for i, token := range tokens[:len(tokens)-1] {
+		child, err := member(parent, token)
+		if err != nil {
+			obj, isObject := parent.(map[string]any)
+			if op.Op == OpRemove && isObject {
+				return nil
+			}
+			if op.Op != OpAdd || !isObject {
+				return fmt.Errorf("%s: %w", pointer(tokens[:i+1]), err)
+			}
+			child = map[string]any{}
+			obj[token] = child
+		}
+		if child == nil && op.Op == OpAdd {
+			if obj, isObject := parent.(map[string]any); isObject {
+				child = map[string]any{}
+				obj[token] = child
+			}
+		}
+		parent = child
+	}
+
+	container, isObject := parent.(map[string]any)
+	if !isObject {
+		// Overrides are re-applied every time the state is loaded, so they
+		// must be idempotent, which inserting into or removing from an array
+		// is not.
+		return fmt.Errorf("%s: parent is not an object; array elements can only be changed by replacing the whole array", op.Path)
+	}
+	last := tokens[len(tokens)-1]
+	_, exists := container[last]
+	switch {
+	case op.Op == OpRemove && !exists:
+		return nil
+	case op.Op == OpReplace && !exists:
+		return fmt.Errorf("%s does not exist", op.Path)
+	case op.Op == OpRemove:
+		delete(container, last)
+	default:
+		container[last] = value
+	}
+	return nil
+}
+
+func member(parent any, token string) (any, error) {
+	switch container := parent.(type) {
+	case map[string]any:
+		child, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("does not exist")
+		}
+		return child, nil
+	case []any:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(container) {
+			return nil, fmt.Errorf("'%s' is not an index of the array", token)
+		}
+		return container[index], nil
+	default:
+		return nil, fmt.Errorf("is not an object or array")
+	}
+}
+
+// findCluster returns the object of the MSK cluster with the given ARN or
+// name, or the OSK cluster with the given ID. A name shared by clusters in
+// several regions is ambiguous and must be given as an ARN.
+func findCluster(doc any, selector string) (map[string]any, error) {
+	var matches []map[string]any
+	root, _ := doc.(map[string]any)
+
+	msk, _ := root["msk_sources"].(map[string]any)
+	regions, _ := msk["regions"].([]any)
+	for _, r := range regions {
+		region, _ := r.(map[string]any)
+		clusters, _ := region["clusters"].([]any)
+		for _, c := range clusters {
+			cluster, _ := c.(map[string]any)
+			if cluster["arn"] == selector || cluster["name"] == selector {
+				matches = append(matches, cluster)
+			}
+		}
+	}
+
+	osk, _ := root["osk_sources"].(map[string]any)
+	clusters, _ := osk["clusters"].([]any)
+	for _, c := range clusters {
+		cluster, _ := c.(map[string]any)
+		if cluster["id"] == selector {
+			matches = append(matches, cluster)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("cluster %s not found", selector)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("cluster %s is ambiguous, %d clusters match; use the cluster ARN", selector, len(matches))
+	}
+}
+
+// splitPointer splits an RFC 6901 JSON Pointer into its unescaped tokens.
+func splitPointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path '%s' must start with /", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func pointer(tokens []string) string {
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	}
+	return "/" + strings.Join(escaped, "/")
+}
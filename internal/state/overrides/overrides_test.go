@@ -0,0 +1,181 @@
+package overrides
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testState = `{
+  "msk_sources": {"regions": [
+    {"name": "us-east-1", "clusters": [
+      {"name": "orders", "arn": "arn:aws:kafka:us-east-1:123456789012:cluster/orders/a", "aws_client_information": {"msk_cluster_config": {"Tags": {"owner": "unknown"}}}},
+      {"name": "payments", "arn": "arn:aws:kafka:us-east-1:123456789012:cluster/payments/b", "annotations": null}
+    ]},
+    {"name": "eu-west-1", "clusters": [
+      {"name": "payments", "arn": "arn:aws:kafka:eu-west-1:123456789012:cluster/payments/c"}
+    ]}
+  ]},
+  "osk_sources": {"clusters": [{"id": "legacy-dc1"}]},
+  "schema_version": 18
+}`
+
+func mustParse(t *testing.T, overrides string) []Operation {
+	t.Helper()
+	ops, err := Parse([]byte(overrides))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return ops
+}
+
+func TestParseRejectsInvalidOperations(t *testing.T) {
+	for name, tc := range map[string]struct {
+		overrides string
+		want      string
+	}{
+		"not an array":      {`{"op": "add"}`, "must be a JSON array"},
+		"unknown field":     {`[{"op": "add", "path": "/a", "value": 1, "from": "/b"}]`, "unknown field"},
+		"unsupported op":    {`[{"op": "move", "path": "/a"}]`, "unsupported op 'move'"},
+		"add without value": {`[{"op": "add", "path": "/a"}]`, "add needs a value"},
+		"remove with value": {`[{"op": "remove", "path": "/a", "value": 1}]`, "remove takes no value"},
+		"relative path":     {`[{"op": "add", "path": "a", "value": 1}]`, "must start with /"},
+		"whole document":    {`[{"op": "replace", "path": "", "value": {}}]`, "whole document"},
+		"schema version":    {`[{"op": "replace", "path": "/schema_version", "value": 1}]`, "/schema_version cannot be overridden"},
+		"stored overrides":  {`[{"op": "remove", "path": "/manual_overrides"}]`, "/manual_overrides cannot be overridden"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.overrides))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyByClusterSelector(t *testing.T) {
+	ops := mustParse(t, `[
+		{"op": "add", "cluster": "orders", "path": "/annotations/scope", "value": "out-of-scope"},
+		{"op": "add", "cluster": "arn:aws:kafka:us-east-1:123456789012:cluster/payments/b", "path": "/annotations/target_environment_id", "value": "env-123"},
+		{"op": "replace", "cluster": "orders", "path": "/aws_client_information/msk_cluster_config/Tags/owner", "value": "orders-team"},
+		{"op": "add", "cluster": "legacy-dc1", "path": "/annotations/owner", "value": "platform"}
+	]`)
+
+	patched, skipped, err := Apply([]byte(testState), ops, true)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v", skipped)
+	}
+
+	var doc struct {
+		MSKSources struct {
+			Regions []struct {
+				Clusters []struct {
+					Annotations          map[string]string `json:"annotations"`
+					AWSClientInformation struct {
+						MSKClusterConfig struct {
+							Tags map[string]string
+						} `json:"msk_cluster_config"`
+					} `json:"aws_client_information"`
+				} `json:"clusters"`
+			} `json:"regions"`
+		} `json:"msk_sources"`
+		OSKSources struct {
+			Clusters []struct {
+				Annotations map[string]string `json:"annotations"`
+			} `json:"clusters"`
+		} `json:"osk_sources"`
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(patched, &doc); err != nil {
+		t.Fatal(err)
+	}
+	clusters := doc.MSKSources.Regions[0].Clusters
+	if got := clusters[0].Annotations["scope"]; got != "out-of-scope" {
+		t.Errorf("orders scope = %q", got)
+	}
+	if got := clusters[0].AWSClientInformation.MSKClusterConfig.Tags["owner"]; got != "orders-team" {
+		t.Errorf("orders owner tag = %q", got)
+	}
+	if got := clusters[1].Annotations["target_environment_id"]; got != "env-123" {
+		t.Errorf("payments target environment = %q", got)
+	}
+	if got := doc.OSKSources.Clusters[0].Annotations["owner"]; got != "platform" {
+		t.Errorf("OSK owner = %q", got)
+	}
+	if doc.SchemaVersion != 18 {
+		t.Errorf("schema_version = %d, want it untouched", doc.SchemaVersion)
+	}
+}
+
+func TestApplyIsIdempotent(t *testing.T) {
+	ops := mustParse(t, `[
+		{"op": "add", "cluster": "orders", "path": "/annotations/scope", "value": "out-of-scope"},
+		{"op": "remove", "cluster": "orders", "path": "/aws_client_information/msk_cluster_config/Tags/owner"},
+		{"op": "remove", "cluster": "orders", "path": "/flow_log_traffic/clients"}
+	]`)
+
+	once, _, err := Apply([]byte(testState), ops, true)
+	if err != nil {
+		t.Fatalf("first Apply: %v", err)
+	}
+	twice, _, err := Apply(once, ops, true)
+	if err != nil {
+		t.Fatalf("second Apply: %v", err)
+	}
+	if string(once) != string(twice) {
+		t.Errorf("re-applying changed the state\n once: %s\ntwice: %s", once, twice)
+	}
+}
+
+func TestApplyStrictAndLenient(t *testing.T) {
+	ops := mustParse(t, `[
+		{"op": "add", "cluster": "deleted-cluster", "path": "/annotations/scope", "value": "out-of-scope"},
+		{"op": "add", "cluster": "payments", "path": "/annotations/scope", "value": "out-of-scope"},
+		{"op": "add", "path": "/msk_sources/regions/0/clusters/-", "value": {}},
+		{"op": "replace", "cluster": "orders", "path": "/annotations/scope", "value": "in-scope"},
+		{"op": "add", "cluster": "orders", "path": "/annotations/owner", "value": "orders-team"}
+	]`)
+
+	_, _, err := Apply([]byte(testState), ops, true)
+	if err == nil || !strings.Contains(err.Error(), "operation 0") || !strings.Contains(err.Error(), "cluster deleted-cluster not found") {
+		t.Fatalf("strict error = %v", err)
+	}
+
+	patched, skipped, err := Apply([]byte(testState), ops, false)
+	if err != nil {
+		t.Fatalf("lenient Apply: %v", err)
+	}
+	want := []string{
+		"cluster deleted-cluster not found",
+		"cluster payments is ambiguous, 2 clusters match",
+		"parent is not an object",
+		"/annotations: does not exist",
+	}
+	if len(skipped) != len(want) {
+		t.Fatalf("skipped = %v, want %d entries", skipped, len(want))
+	}
+	for i, w := range want {
+		if !strings.Contains(skipped[i], w) {
+			t.Errorf("skipped[%d] = %q, want it to contain %q", i, skipped[i], w)
+		}
+	}
+	if !strings.Contains(string(patched), `"annotations":{"owner":"orders-team"}`) {
+		t.Errorf("the operation that applies was not applied: %s", patched)
+	}
+}
+
+func TestSplitPointerUnescapes(t *testing.T) {
+	tokens, err := splitPointer("/Tags/team~1owner/a~0b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 3 || tokens[1] != "team/owner" || tokens[2] != "a~b" {
+		t.Errorf("tokens = %q", tokens)
+	}
+	if got := pointer(tokens); got != "/Tags/team~1owner/a~0b" {
+		t.Errorf("pointer = %q", got)
+	}
+}
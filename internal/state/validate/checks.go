@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// checkState finds problems encoding/json accepts but later commands trip
+// over, mostly left behind by merging two state files by hand: the same
+// region, cluster or Apache Kafka cluster ID twice, and clusters filed under
+// the wrong region.
+func checkState(state *types.State, w *walker) []Issue {
+	var issues []Issue
+	if state.MSKSources != nil {
+		regions := make(map[string]string)
+		clusters := make(map[string]string)
+		for i, region := range state.MSKSources.Regions {
+			path := fmt.Sprintf("msk_sources.regions[%d]", i)
+			issues = append(issues, duplicate(w, regions, region.Name, path+".name", "region")...)
+			issues = append(issues, checkRegion(region, path, w)...)
+			for j, cluster := range region.Clusters {
+				issues = append(issues, duplicate(w, clusters, cluster.Arn, fmt.Sprintf("%s.clusters[%d].arn", path, j), "cluster ARN")...)
+			}
+		}
+	}
+	if state.OSKSources != nil {
+		ids := make(map[string]string)
+		for i, cluster := range state.OSKSources.Clusters {
+			path := fmt.Sprintf("osk_sources.clusters[%d].id", i)
+			if cluster.ID == "" {
+				issues = append(issues, w.issueAt(path, "Apache Kafka cluster has no id"))
+				continue
+			}
+			issues = append(issues, duplicate(w, ids, cluster.ID, path, "Apache Kafka cluster id")...)
+		}
+	}
+	return issues
+}
+
+// checkRegion checks the clusters of one region at path.
+func checkRegion(region types.DiscoveredRegion, path string, w *walker) []Issue {
+	var issues []Issue
+	if region.Name == "" {
+		issues = append(issues, w.issueAt(path+".name", "region has no name"))
+	}
+	for j, cluster := range region.Clusters {
+		clusterPath := fmt.Sprintf("%s.clusters[%d]", path, j)
+		if cluster.Arn == "" {
+			issues = append(issues, w.issueAt(clusterPath+".arn", "cluster has no ARN"))
+		}
+		if cluster.Region != "" && region.Name != "" && cluster.Region != region.Name {
+			issues = append(issues, w.issueAt(clusterPath+".region", fmt.Sprintf("cluster is in region %s but listed under region %s", cluster.Region, region.Name)))
+		}
+	}
+	return issues
+}
+
+// duplicate records value at path in seen, reporting it when an earlier
+// path already holds it. Empty values are left to the other checks.
+func duplicate(w *walker, seen map[string]string, value, path, what string) []Issue {
+	if value == "" {
+		return nil
+	}
+	first, ok := seen[value]
+	if !ok {
+		seen[value] = path
+		return nil
+	}
+	message := fmt.Sprintf("duplicate %s %s, first at %s", what, value, first)
+	if line, column := w.locate(first); line > 0 {
+		message += fmt.Sprintf(" (%d:%d)", line, column)
+	}
+	return []Issue{w.issueAt(path, message)}
+}
+
+// issueAt is an issue located at the value recorded for path.
+func (w *walker) issueAt(path, message string) Issue {
+	line, column := w.locate(path)
+	return Issue{Line: line, Column: column, Path: path, Message: message}
+}
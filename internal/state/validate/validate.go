@@ -0,0 +1,188 @@
+// Package validate checks kcp-state.json files, and the per-region and
+// per-cluster scan files `kcp discover` checkpoints, against the types this
+// build reads them into. Unlike the loader, which stops at the first
+// mismatch with a bare encoding/json error, it reports every problem with
+// the line, column and JSON path it was found at, so hand-edited or merged
+// files can be fixed before report and generate commands consume them.
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/confluentinc/kcp/internal/state/migrate"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// Kind is the kind of file that was validated.
+type Kind string
+
+const (
+	KindState             Kind = "kcp state file"
+	KindRegionCheckpoint  Kind = "discover region scan"
+	KindClusterCheckpoint Kind = "discover cluster scan"
+)
+
+// regionCheckpoint mirrors the <region>/region.json file `kcp discover`
+// checkpoints (see cmd/discover/checkpoint.go).
+type regionCheckpoint struct {
+	Region      types.DiscoveredRegion `json:"region"`
+	ClusterArns []string               `json:"cluster_arns"`
+}
+
+// Issue is one problem found in a file. Line and Column are 1-based and
+// zero when the problem has no single location, e.g. a file that needs
+// upgrading before its contents can be checked.
+type Issue struct {
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	var b bytes.Buffer
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d ", i.Line, i.Column)
+	}
+	if i.Path != "" {
+		fmt.Fprintf(&b, "%s: ", i.Path)
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// Result is the outcome of validating one file.
+type Result struct {
+	Kind Kind
+	// SchemaVersion is the state file's declared schema_version, zero for
+	// unversioned files and scan files.
+	SchemaVersion int
+	// UpgradedFrom describes the legacy shape a state file was upgraded from
+	// before it was checked. Issues then carry no line and column, since
+	// they refer to the upgraded document rather than the file.
+	UpgradedFrom string
+	Issues       []Issue
+}
+
+// Valid reports whether no problems were found.
+func (r Result) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Validate checks data, detecting from its top-level keys whether it is a
+// kcp-state.json or a discover scan file.
+func Validate(data []byte) Result {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		return Result{Kind: KindState, Issues: []Issue{syntaxIssue(data, err)}}
+	}
+
+	switch {
+	case root["region"] != nil && root["cluster_arns"] != nil:
+		return validateScan(data, KindRegionCheckpoint, &regionCheckpoint{})
+	case root["arn"] != nil && root["aws_client_information"] != nil:
+		return validateScan(data, KindClusterCheckpoint, &types.DiscoveredCluster{})
+	default:
+		return validateState(data)
+	}
+}
+
+func validateState(data []byte) Result {
+	result := Result{Kind: KindState}
+
+	var probe struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	_ = json.Unmarshal(data, &probe)
+	result.SchemaVersion = probe.SchemaVersion
+
+	migrated, fromLabel, err := migrate.Upgrade(data)
+	if err != nil {
+		// The loader explains what to do about a newer or unsupported file.
+		_, err = types.NewStateFromBytes(data)
+		result.Issues = append(result.Issues, Issue{Message: err.Error()})
+		return result
+	}
+	located := bytes.Equal(migrated, data)
+	if !located {
+		result.UpgradedFrom = fromLabel
+	}
+
+	w := newWalker(migrated)
+	w.walk(&types.State{})
+	result.Issues = w.issues
+	if !located {
+		w.offsets = nil
+	}
+	if len(result.Issues) == 0 {
+		state, err := types.NewStateFromBytes(data)
+		if err != nil {
+			result.Issues = append(result.Issues, Issue{Message: err.Error()})
+		} else {
+			result.Issues = append(result.Issues, checkState(state, w)...)
+		}
+	}
+
+	if !located {
+		for i := range result.Issues {
+			result.Issues[i].Line, result.Issues[i].Column = 0, 0
+		}
+	}
+	sortIssues(result.Issues)
+	return result
+}
+
+func validateScan(data []byte, kind Kind, v any) Result {
+	w := newWalker(data)
+	w.walk(v)
+	result := Result{Kind: kind, Issues: w.issues}
+	if len(result.Issues) == 0 {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(v); err != nil {
+			result.Issues = append(result.Issues, Issue{Message: err.Error()})
+		} else if checkpoint, ok := v.(*regionCheckpoint); ok {
+			result.Issues = append(result.Issues, checkRegion(checkpoint.Region, "region", w)...)
+		}
+	}
+	sortIssues(result.Issues)
+	return result
+}
+
+// syntaxIssue locates a JSON syntax error, or reports that the document is
+// not an object.
+func syntaxIssue(data []byte, err error) Issue {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Offset is just past the offending byte.
+		line, column := position(data, syntaxErr.Offset-1)
+		return Issue{Line: line, Column: column, Message: "invalid JSON: " + syntaxErr.Error()}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return Issue{Line: 1, Column: 1, Message: fmt.Sprintf("expected a JSON object, found %s", typeErr.Value)}
+	}
+	return Issue{Message: err.Error()}
+}
+
+// position converts a byte offset into a 1-based line and column.
+func position(data []byte, offset int64) (line, column int) {
+	offset = min(max(offset, 0), int64(len(data)))
+	prefix := data[:offset]
+	line = bytes.Count(prefix, []byte("\n")) + 1
+	column = int(offset) - (bytes.LastIndexByte(prefix, '\n') + 1) + 1
+	return line, column
+}
+
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+}
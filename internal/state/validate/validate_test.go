@@ -0,0 +1,117 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/state/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var header = fmt.Sprintf(`"schema_version": %d,
+  "kcp_build_info": {"version": "1.0.0", "commit": "abc", "date": "2026-10-01"},
+  "timestamp": "2026-10-01T00:00:00Z",`, migrate.CurrentSchemaVersion)
+
+func TestValidate_ValidState(t *testing.T) {
+	result := Validate([]byte(`{
+  ` + header + `
+  "msk_sources": {"regions": [{"name": "us-east-1", "clusters": [{"name": "orders", "arn": "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1", "region": "us-east-1"}]}]},
+  "osk_sources": {"clusters": [{"id": "onprem", "bootstrap_servers": ["broker:9092"]}]}
+}`))
+
+	assert.True(t, result.Valid(), "%v", result.Issues)
+	assert.Equal(t, KindState, result.Kind)
+	assert.Equal(t, migrate.CurrentSchemaVersion, result.SchemaVersion)
+	assert.Empty(t, result.UpgradedFrom)
+}
+
+func TestValidate_ReportsEveryProblemWithItsLocation(t *testing.T) {
+	result := Validate([]byte(`{
+  ` + header + `
+  "msk_sources": {"regions": [{
+    "name": "us-east-1",
+    "clusters": [{"name": 5, "arn": "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1", "colour": "blue"}],
+    "costs": []
+  }]},
+  "osk_sources": {"clusters": [{"id": "onprem", "bootstrap_servers": "broker:9092", "metadata": {"last_scanned": "yesterday"}}]}
+}`))
+
+	require.False(t, result.Valid())
+	assert.Equal(t, []Issue{
+		{Line: 7, Column: 27, Path: "msk_sources.regions[0].clusters[0].name", Message: "expected string, found number"},
+		{Line: 7, Column: 98, Path: "msk_sources.regions[0].clusters[0].colour", Message: `unknown field "colour" in types.DiscoveredCluster`},
+		{Line: 8, Column: 14, Path: "msk_sources.regions[0].costs", Message: "expected object, found array"},
+		{Line: 10, Column: 70, Path: "osk_sources.clusters[0].bootstrap_servers", Message: "expected array, found string"},
+		{Line: 10, Column: 114, Path: "osk_sources.clusters[0].metadata.last_scanned", Message: `invalid time.Time: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`},
+	}, result.Issues)
+}
+
+func TestValidate_MergedDuplicates(t *testing.T) {
+	result := Validate([]byte(`{
+  ` + header + `
+  "msk_sources": {"regions": [
+    {"name": "us-east-1", "clusters": [{"name": "orders", "arn": "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1", "region": "us-east-1"}]},
+    {"name": "us-east-1", "clusters": [{"name": "orders", "arn": "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1", "region": "eu-west-1"}]}
+  ]},
+  "osk_sources": {"clusters": [{"id": "onprem"}, {"id": "onprem"}]}
+}`))
+
+	var got []string
+	for _, issue := range result.Issues {
+		got = append(got, issue.String())
+	}
+	assert.Equal(t, []string{
+		"7:14 msk_sources.regions[1].name: duplicate region us-east-1, first at msk_sources.regions[0].name (6:14)",
+		"7:66 msk_sources.regions[1].clusters[0].arn: duplicate cluster ARN arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1, first at msk_sources.regions[0].clusters[0].arn (6:66)",
+		"7:137 msk_sources.regions[1].clusters[0].region: cluster is in region eu-west-1 but listed under region us-east-1",
+		"9:57 osk_sources.clusters[1].id: duplicate Apache Kafka cluster id onprem, first at osk_sources.clusters[0].id (9:39)",
+	}, got)
+}
+
+func TestValidate_SyntaxError(t *testing.T) {
+	result := Validate([]byte("{\n  \"msk_sources\": {\"regions\": [}\n}"))
+
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, 2, result.Issues[0].Line)
+	assert.Equal(t, 31, result.Issues[0].Column)
+	assert.Contains(t, result.Issues[0].Message, "invalid JSON")
+
+	result = Validate([]byte(`[1, 2]`))
+	assert.Equal(t, []Issue{{Line: 1, Column: 1, Message: "expected a JSON object, found array"}}, result.Issues)
+}
+
+func TestValidate_LegacyStateIsCheckedAfterUpgrading(t *testing.T) {
+	result := Validate([]byte(`{
+  "regions": [{"name": "us-east-1", "clusters": [{"name": "orders", "arn": "arn:1", "colour": "blue"}]}],
+  "kcp_build_info": {"version": "0.5.0", "commit": "abc", "date": "2026-01-01"},
+  "timestamp": "2026-01-01T00:00:00Z"
+}`))
+
+	assert.Equal(t, "kcp_build_info.version=0.5.0", result.UpgradedFrom)
+	assert.Equal(t, []Issue{
+		{Path: "msk_sources.regions[0].clusters[0].colour", Message: `unknown field "colour" in types.DiscoveredCluster`},
+	}, result.Issues, "locations in the upgraded document don't match the file")
+}
+
+func TestValidate_NewerSchema(t *testing.T) {
+	result := Validate([]byte(fmt.Sprintf(`{"schema_version": %d, "kcp_build_info": {"version": "9.0.0"}}`, migrate.CurrentSchemaVersion+1)))
+
+	require.Len(t, result.Issues, 1)
+	assert.Contains(t, result.Issues[0].Message, "Run `kcp update` to upgrade")
+}
+
+func TestValidate_DiscoverCheckpoints(t *testing.T) {
+	result := Validate([]byte(`{
+  "region": {"name": "us-east-1", "clusters": null, "costs": {"extra": 1}},
+  "cluster_arns": ["arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1"]
+}`))
+	assert.Equal(t, KindRegionCheckpoint, result.Kind)
+	assert.Equal(t, []Issue{
+		{Line: 2, Column: 63, Path: "region.costs.extra", Message: `unknown field "extra" in types.CostInformation`},
+	}, result.Issues)
+
+	result = Validate([]byte(`{"name": "orders", "arn": "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1", "region": "us-east-1", "aws_client_information": {}}`))
+	assert.Equal(t, KindClusterCheckpoint, result.Kind)
+	assert.True(t, result.Valid(), "%v", result.Issues)
+}
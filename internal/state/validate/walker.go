@@ -0,0 +1,360 @@
+package validate
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// walker streams a JSON document alongside the Go type it decodes into,
+// recording every unknown field and type mismatch where it occurs instead of
+// stopping at the first, as encoding/json does.
+type walker struct {
+	data    []byte
+	decoder *json.Decoder
+	issues  []Issue
+	// offsets holds where each struct field's value starts, by path, so
+	// problems found after decoding can point back into the file.
+	offsets map[string]int64
+}
+
+func newWalker(data []byte) *walker {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return &walker{data: data, decoder: decoder, offsets: make(map[string]int64)}
+}
+
+// walk checks the document against the type v points to.
+func (w *walker) walk(v any) {
+	w.value(reflect.TypeOf(v).Elem(), "")
+}
+
+// next returns the next token and the offset it starts at.
+func (w *walker) next() (json.Token, int64, error) {
+	offset := w.skipSpace(w.decoder.InputOffset())
+	token, err := w.decoder.Token()
+	return token, offset, err
+}
+
+func (w *walker) add(offset int64, path, format string, args ...any) {
+	line, column := position(w.data, offset)
+	w.issues = append(w.issues, Issue{Line: line, Column: column, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// locate returns the position of the value at path, if it was recorded.
+func (w *walker) locate(path string) (line, column int) {
+	offset, ok := w.offsets[path]
+	if !ok {
+		return 0, 0
+	}
+	return position(w.data, offset)
+}
+
+// value checks the next value in the stream against t.
+func (w *walker) value(t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Types with their own decoding (timestamps, AWS enums, raw messages)
+	// are decoded as encoding/json would, to surface their errors.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		offset := w.skipSpace(w.decoder.InputOffset())
+		var raw json.RawMessage
+		if err := w.decoder.Decode(&raw); err != nil {
+			w.add(offset, path, "invalid JSON: %v", err)
+			return
+		}
+		if err := json.Unmarshal(raw, reflect.New(t).Interface()); err != nil {
+			w.add(offset, path, "invalid %s: %v", typeName(t), unwrapJSONError(err))
+		}
+		return
+	}
+
+	token, offset, err := w.next()
+	if err != nil {
+		w.add(offset, path, "invalid JSON: %v", err)
+		return
+	}
+	if token == nil {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if !w.expectDelim(token, '{', offset, path, "object") {
+			return
+		}
+		w.object(path, func(key string, keyOffset int64) {
+			field, ok := lookupField(t, key)
+			if !ok {
+				w.add(keyOffset, joinPath(path, key), "unknown field %q in %s", key, typeName(t))
+				w.skipValue()
+				return
+			}
+			fieldPath := joinPath(path, field.name)
+			w.offsets[fieldPath] = w.skipSpace(w.decoder.InputOffset())
+			if field.asString {
+				w.skipValue()
+				return
+			}
+			w.value(field.typ, fieldPath)
+		})
+	case reflect.Map:
+		if !w.expectDelim(token, '{', offset, path, "object") {
+			return
+		}
+		w.object(path, func(key string, _ int64) {
+			w.value(t.Elem(), fmt.Sprintf("%s[%s]", path, strconv.Quote(key)))
+		})
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			w.expectScalar(token, offset, path, t)
+			return
+		}
+		if !w.expectDelim(token, '[', offset, path, "array") {
+			return
+		}
+		for i := 0; w.decoder.More(); i++ {
+			w.value(t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+		_, _ = w.decoder.Token()
+	case reflect.Interface:
+		w.skipRest(token)
+	default:
+		w.expectScalar(token, offset, path, t)
+	}
+}
+
+// object calls fn for each key of the object whose '{' was just read, with
+// the decoder positioned before the key's value.
+func (w *walker) object(path string, fn func(key string, offset int64)) {
+	for w.decoder.More() {
+		token, offset, err := w.next()
+		if err != nil {
+			w.add(offset, path, "invalid JSON: %v", err)
+			return
+		}
+		fn(token.(string), offset)
+	}
+	_, _ = w.decoder.Token()
+}
+
+// expectDelim reports a mismatch, and skips the value, unless token opens
+// the wanted composite.
+func (w *walker) expectDelim(token json.Token, want json.Delim, offset int64, path, name string) bool {
+	if delim, ok := token.(json.Delim); ok && delim == want {
+		return true
+	}
+	w.add(offset, path, "expected %s, found %s", name, describe(token))
+	w.skipRest(token)
+	return false
+}
+
+func (w *walker) expectScalar(token json.Token, offset int64, path string, t reflect.Type) {
+	found := describe(token)
+	switch t.Kind() {
+	case reflect.String:
+		if _, ok := token.(string); ok {
+			return
+		}
+	case reflect.Slice: // []byte, base64 encoded
+		if _, ok := token.(string); ok {
+			return
+		}
+		found += " (bytes are a base64 string)"
+	case reflect.Bool:
+		if _, ok := token.(bool); ok {
+			return
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := token.(json.Number); ok {
+			i, err := strconv.ParseInt(string(n), 10, 64)
+			if err == nil && !reflect.New(t).Elem().OverflowInt(i) {
+				return
+			}
+			w.add(offset, path, "%s does not fit in %s", n, typeName(t))
+			return
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := token.(json.Number); ok {
+			u, err := strconv.ParseUint(string(n), 10, 64)
+			if err == nil && !reflect.New(t).Elem().OverflowUint(u) {
+				return
+			}
+			w.add(offset, path, "%s does not fit in %s", n, typeName(t))
+			return
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := token.(json.Number); ok {
+			return
+		}
+	default:
+		w.skipRest(token)
+		return
+	}
+	w.add(offset, path, "expected %s, found %s", typeName(t), found)
+	w.skipRest(token)
+}
+
+// skipValue skips the next value in the stream.
+func (w *walker) skipValue() {
+	var raw json.RawMessage
+	_ = w.decoder.Decode(&raw)
+}
+
+// skipRest skips the rest of a value whose first token was just read.
+func (w *walker) skipRest(token json.Token) {
+	delim, ok := token.(json.Delim)
+	if !ok || delim == '}' || delim == ']' {
+		return
+	}
+	for depth := 1; depth > 0; {
+		token, err := w.decoder.Token()
+		if err != nil {
+			return
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
+
+// skipSpace advances offset past whitespace and the separators the
+// decoder leaves unread between tokens.
+func (w *walker) skipSpace(offset int64) int64 {
+	for offset < int64(len(w.data)) && strings.IndexByte(" \t\r\n,:", w.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func describe(token json.Token) string {
+	switch v := token.(type) {
+	case json.Delim:
+		if v == '{' {
+			return "object"
+		}
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+	}
+	if t.Name() != "" {
+		return t.String()
+	}
+	return t.Kind().String()
+}
+
+// unwrapJSONError drops the "json: " prefix and Go type names encoding/json
+// puts in errors from nested decoders.
+func unwrapJSONError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Sprintf("expected %s, found %s", typeName(typeErr.Type), typeErr.Value)
+	}
+	return strings.TrimPrefix(err.Error(), "json: ")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+type field struct {
+	name     string
+	typ      reflect.Type
+	asString bool
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+// lookupField finds the field a JSON key decodes into, matching names
+// exactly and then case-insensitively, as encoding/json does.
+func lookupField(t reflect.Type, key string) (field, bool) {
+	fields := structFields(t)
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+func structFields(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structFields(embedded)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, typ: sf.Type, asString: strings.Contains(","+opts+",", ",string,")})
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
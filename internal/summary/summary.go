@@ -0,0 +1,178 @@
+// Package summary collects the machine-readable result of a command — the
+// files it wrote, what it counted, how long it took and what went wrong — and
+// prints it as a single JSON object on stdout when --json-summary is set, so
+// pipelines and wrappers can act on a run without parsing its terminal
+// output.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Statuses of a finished command.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Summary is the object printed when the command finishes.
+type Summary struct {
+	Command      string         `json:"command"`
+	Status       string         `json:"status"`
+	StartedAt    time.Time      `json:"started_at"`
+	DurationMs   int64          `json:"duration_ms"`
+	FilesWritten []string       `json:"files_written"`
+	Counts       map[string]int `json:"counts,omitempty"`
+	Warnings     []string       `json:"warnings,omitempty"`
+	Errors       []string       `json:"errors,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	out     io.Writer
+	current Summary
+	written map[string]bool
+)
+
+// Setup starts collecting the summary of commandPath, to be written to w by
+// Finish. Until Setup is called every recording function is a no-op, so
+// commands record unconditionally.
+func Setup(commandPath string, w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled, out = true, w
+	current = Summary{Command: commandPath, StartedAt: time.Now().UTC(), FilesWritten: []string{}}
+	written = map[string]bool{}
+}
+
+// Enabled reports whether a summary is being collected.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// FileWritten records that the command wrote path. Paths are recorded
+// absolute, once each, in the order first written.
+func FileWritten(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if written[path] {
+		return
+	}
+	written[path] = true
+	current.FilesWritten = append(current.FilesWritten, path)
+}
+
+// WriteFile is os.WriteFile that records name as written on success.
+func WriteFile(name string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+	FileWritten(name)
+	return nil
+}
+
+// Count adds n to the named count, e.g. "clusters_scanned".
+func Count(name string, n int) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return
+	}
+	if current.Counts == nil {
+		current.Counts = map[string]int{}
+	}
+	current.Counts[name] += n
+}
+
+// Finish writes the summary of a command that returned err and stops
+// collecting. It is a no-op when Setup was not called.
+func Finish(err error) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return nil
+	}
+	enabled = false
+
+	current.DurationMs = time.Since(current.StartedAt).Milliseconds()
+	current.Status = StatusSuccess
+	if err != nil {
+		current.Status = StatusError
+		current.Errors = append(current.Errors, err.Error())
+	}
+
+	data, marshalErr := json.Marshal(current)
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal command summary: %w", marshalErr)
+	}
+	_, writeErr := fmt.Fprintf(out, "%s\n", data)
+	return writeErr
+}
+
+// Handler returns a slog.Handler that records WARN records as warnings and
+// ERROR records as errors, so problems a command logs and carries on from
+// still reach the summary.
+func Handler() slog.Handler {
+	return &logHandler{}
+}
+
+type logHandler struct {
+	attrs []slog.Attr
+}
+
+func (h *logHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn && Enabled()
+}
+
+func (h *logHandler) Handle(_ context.Context, r slog.Record) error {
+	parts := []string{r.Message}
+	appendAttr := func(a slog.Attr) bool {
+		parts = append(parts, fmt.Sprintf("%s=%v", a.Key, a.Value.Any()))
+		return true
+	}
+	for _, a := range h.attrs {
+		appendAttr(a)
+	}
+	r.Attrs(appendAttr)
+	message := strings.Join(parts, " ")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return nil
+	}
+	if r.Level >= slog.LevelError {
+		current.Errors = append(current.Errors, message)
+	} else {
+		current.Warnings = append(current.Warnings, message)
+	}
+	return nil
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logHandler{attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+}
+
+// Groups only namespace attribute keys, which a one-line message does not
+// need.
+func (h *logHandler) WithGroup(string) slog.Handler {
+	return h
+}
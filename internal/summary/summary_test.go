@@ -0,0 +1,76 @@
+package summary
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSummary(t *testing.T, command string) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	Setup(command, &out)
+	t.Cleanup(func() { _ = Finish(nil) })
+	return &out
+}
+
+func decode(t *testing.T, out *bytes.Buffer) Summary {
+	t.Helper()
+	var s Summary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &s), out.String())
+	return s
+}
+
+func TestFinish_ReportsFilesCountsAndStatus(t *testing.T) {
+	dir := t.TempDir()
+	out := setupSummary(t, "kcp scan clusters")
+
+	statePath := filepath.Join(dir, "kcp-state.json")
+	require.NoError(t, WriteFile(statePath, []byte("{}"), 0600))
+	FileWritten(statePath)
+	Count("clusters_scanned", 2)
+	Count("clusters_scanned", 1)
+	require.NoError(t, Finish(nil))
+
+	s := decode(t, out)
+	assert.Equal(t, "kcp scan clusters", s.Command)
+	assert.Equal(t, StatusSuccess, s.Status)
+	assert.Equal(t, []string{statePath}, s.FilesWritten, "a file written twice is listed once")
+	assert.Equal(t, map[string]int{"clusters_scanned": 3}, s.Counts)
+	assert.Empty(t, s.Errors)
+	assert.False(t, s.StartedAt.IsZero())
+}
+
+func TestFinish_RecordsCommandErrorAndLoggedProblems(t *testing.T) {
+	out := setupSummary(t, "kcp discover")
+
+	logger := slog.New(Handler()).With("region", "us-east-1")
+	logger.Info("not recorded")
+	logger.Warn("⚠️ failed to record metrics history")
+	logger.Error("failed to discover cluster", "cluster", "orders")
+	require.NoError(t, Finish(errors.New("failed to write state to file")))
+
+	s := decode(t, out)
+	assert.Equal(t, StatusError, s.Status)
+	assert.Equal(t, []string{"⚠️ failed to record metrics history region=us-east-1"}, s.Warnings)
+	assert.Equal(t, []string{"failed to discover cluster region=us-east-1 cluster=orders", "failed to write state to file"}, s.Errors)
+	assert.Equal(t, []string{}, s.FilesWritten, "files_written is always an array")
+}
+
+func TestDisabled_RecordsNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.md")
+	FileWritten(path)
+	Count("clusters", 1)
+	require.NoError(t, WriteFile(path, []byte("# report"), 0644))
+	require.NoError(t, Finish(nil))
+
+	_, err := os.Stat(path)
+	assert.NoError(t, err, "WriteFile still writes when no summary is collected")
+}
@@ -0,0 +1,94 @@
+// Package tracing wires kcp into OpenTelemetry. Scans and discovers open a
+// span per region and cluster, and every AWS and Kafka admin call is a child
+// span, so slow API calls in long multi-account scans show up in an existing
+// observability stack. Without an OTLP endpoint the global no-op provider is
+// left in place and spans cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/confluentinc/kcp/internal/build_info"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/confluentinc/kcp"
+
+// Standard OpenTelemetry variables that enable the exporter without
+// --otlp-endpoint.
+var otlpEndpointEnvs = []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"}
+
+// Setup installs a tracer provider that batches spans to the OTLP/HTTP
+// collector at endpoint (e.g. http://localhost:4318). An empty endpoint falls
+// back to the OTEL_EXPORTER_OTLP_* variables, and leaves tracing disabled when
+// those are unset too. The returned function flushes pending spans and must
+// be called before exit.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "http://" + endpoint
+		}
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	} else if !envEndpointSet() {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("kcp"),
+		semconv.ServiceVersion(build_info.Version),
+	))
+	if err != nil {
+		return noop, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+func envEndpointSet() bool {
+	for _, name := range otlpEndpointEnvs {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Start opens a span named name under the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup_DisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), "")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	assert.Equal(t, previous, otel.GetTracerProvider(), "no provider is installed without an endpoint")
+	_, span := Start(context.Background(), "noop")
+	assert.False(t, span.SpanContext().IsValid())
+	End(span, errors.New("ignored"))
+}
+
+func TestSetup_ExportsToCollector(t *testing.T) {
+	var requests atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			requests.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	shutdown, err := Setup(context.Background(), collector.URL)
+	require.NoError(t, err)
+
+	ctx, parent := Start(context.Background(), "scan.region")
+	_, child := Start(ctx, "scan.cluster")
+	assert.Equal(t, parent.SpanContext().TraceID(), child.SpanContext().TraceID())
+	End(child, errors.New("broker unreachable"))
+	End(parent, nil)
+
+	require.NoError(t, shutdown(context.Background()))
+	assert.Positive(t, requests.Load(), "shutdown flushes spans to the collector")
+}
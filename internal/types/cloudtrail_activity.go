@@ -0,0 +1,32 @@
+package types
+
+import "time"
+
+// CloudTrail client activity sources.
+const (
+	CloudTrailSourceLookupEvents = "lookup-events"
+	CloudTrailSourceAthena       = "athena"
+)
+
+// CloudTrailClientActivity is the evidence of who uses an MSK cluster: the
+// IAM principals whose MSK API or kafka-cluster calls CloudTrail recorded for
+// it in the scanned window. An empty Principals means no calls were found,
+// which is itself evidence that nothing looked the cluster up.
+type CloudTrailClientActivity struct {
+	Source     string                        `json:"source"`
+	StartTime  time.Time                     `json:"start_time"`
+	EndTime    time.Time                     `json:"end_time"`
+	Principals []CloudTrailPrincipalActivity `json:"principals"`
+}
+
+// CloudTrailPrincipalActivity is one IAM principal's calls against a cluster.
+// Assumed-role sessions are attributed to their role, so every instance of
+// an application counts as one principal.
+type CloudTrailPrincipalActivity struct {
+	PrincipalArn string    `json:"principal_arn"`
+	SourceIPs    []string  `json:"source_ips"`
+	EventNames   []string  `json:"event_names"`
+	EventCount   int       `json:"event_count"`
+	FirstSeen    time.Time `json:"first_seen"`
+	LastSeen     time.Time `json:"last_seen"`
+}
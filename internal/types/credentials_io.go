@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/goccy/go-yaml"
+
+	"github.com/confluentinc/kcp/internal/summary"
 )
 
 // writeYAMLFile marshals v to YAML and writes it to path with 0600 permissions.
@@ -14,7 +16,7 @@ func writeYAMLFile(path string, v any) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
 	}
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	if err := summary.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("failed to write YAML file: %w", err)
 	}
 	return nil
@@ -191,6 +191,36 @@ func (amc AuthMethodConfig) SelectedAuthType(includeIAM bool) (AuthType, error)
 	return enabled[0], nil
 }
 
+// AutoAuthOrder is the order `kcp scan clusters --auth-type auto` tries
+// methods in: IAM, then SCRAM, then TLS, then unauthenticated.
+var AutoAuthOrder = []AuthType{
+	AuthTypeIAM,
+	AuthTypeSASLSCRAM,
+	AuthTypeTLS,
+	AuthTypeUnauthenticatedTLS,
+	AuthTypeUnauthenticatedPlaintext,
+}
+
+// ConfiguredAuthMethods returns every method with a config block, whether or
+// not it is marked use, in AutoAuthOrder. For MSK these are the methods the
+// cluster advertised in DescribeCluster when kcp discover wrote the file.
+func (amc AuthMethodConfig) ConfiguredAuthMethods() []AuthType {
+	configured := map[AuthType]bool{
+		AuthTypeIAM:                      amc.IAM != nil,
+		AuthTypeSASLSCRAM:                amc.SASLScram != nil,
+		AuthTypeTLS:                      amc.TLS != nil,
+		AuthTypeUnauthenticatedTLS:       amc.UnauthenticatedTLS != nil,
+		AuthTypeUnauthenticatedPlaintext: amc.UnauthenticatedPlaintext != nil,
+	}
+	methods := []AuthType{}
+	for _, authType := range AutoAuthOrder {
+		if configured[authType] {
+			methods = append(methods, authType)
+		}
+	}
+	return methods
+}
+
 // MergeWith preserves existing auth configurations only for auth methods that still exist in the new config
 func (amc *AuthMethodConfig) MergeWith(existing AuthMethodConfig) {
 	// Only preserve existing configs if the auth method still exists in the new discovery
@@ -632,6 +632,18 @@ func createTempFile(t *testing.T, content string) string {
 	return tmpFile.Name()
 }
 
+func TestAuthMethodConfig_ConfiguredAuthMethods(t *testing.T) {
+	amc := AuthMethodConfig{
+		UnauthenticatedPlaintext: &UnauthenticatedPlaintextConfig{Use: false},
+		TLS:                      &TLSConfig{Use: true},
+		IAM:                      &IAMConfig{Use: false},
+		SASLScram:                &SASLScramConfig{Use: false},
+	}
+
+	assert.Equal(t, []AuthType{AuthTypeIAM, AuthTypeSASLSCRAM, AuthTypeTLS, AuthTypeUnauthenticatedPlaintext}, amc.ConfiguredAuthMethods())
+	assert.Empty(t, AuthMethodConfig{}.ConfiguredAuthMethods())
+}
+
 func TestAuthMethodConfig_MergeWith(t *testing.T) {
 	tests := []struct {
 		name     string
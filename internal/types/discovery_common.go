@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -9,9 +10,18 @@ type KafkaAdminClientInformation struct {
 	ClusterID             string                 `json:"cluster_id"`
 	DiscoveredBrokers     []string               `json:"discovered_brokers,omitempty"`
 	SaslMechanism         string                 `json:"sasl_mechanism,omitempty"`
+	AuthType              AuthType               `json:"auth_type,omitempty"` // method the last scan connected with
 	Topics                *Topics                `json:"topics"`
 	Acls                  []Acls                 `json:"acls"`
 	SelfManagedConnectors *SelfManagedConnectors `json:"self_managed_connectors"`
+	TLSInspection         *TLSInspection         `json:"tls_inspection,omitempty"`
+	ConsumerGroups        []ConsumerGroup        `json:"consumer_groups,omitempty"`
+	PartitionHealth       *PartitionHealth       `json:"partition_health,omitempty"`
+	BrokerRacks           []BrokerRack           `json:"broker_racks,omitempty"`
+	OpenMonitoring        *OpenMonitoring        `json:"open_monitoring,omitempty"`
+	// Plugins holds the JSON object each `kcp scan clusters --plugin` scanner
+	// returned, keyed by plugin name.
+	Plugins map[string]json.RawMessage `json:"plugins,omitempty"`
 }
 
 // MergeFrom merges values from another KafkaAdminClientInformation
@@ -27,6 +37,11 @@ func (c *KafkaAdminClientInformation) MergeFrom(other KafkaAdminClientInformatio
 		c.SaslMechanism = other.SaslMechanism
 	}
 
+	// Only use old AuthType if new one is empty
+	if c.AuthType == "" {
+		c.AuthType = other.AuthType
+	}
+
 	// Merge Topics: new topics take precedence, old topics preserved if not re-discovered
 	c.Topics = mergeTopics(c.Topics, other.Topics)
 
@@ -35,6 +50,47 @@ func (c *KafkaAdminClientInformation) MergeFrom(other KafkaAdminClientInformatio
 
 	// Merge SelfManagedConnectors: new connectors take precedence, old preserved if not re-discovered
 	c.SelfManagedConnectors = mergeSelfManagedConnectors(c.SelfManagedConnectors, other.SelfManagedConnectors)
+
+	// Keep the last TLS inspection when this scan did not inspect
+	if c.TLSInspection == nil {
+		c.TLSInspection = other.TLSInspection
+	}
+
+	// Consumer groups are a point-in-time snapshot: keep the last one only when
+	// this scan did not describe any
+	if c.ConsumerGroups == nil {
+		c.ConsumerGroups = other.ConsumerGroups
+	} else {
+		c.ConsumerGroups = mergeConsumerGroupActivity(c.ConsumerGroups, other.ConsumerGroups)
+	}
+
+	// Partition health is a point-in-time snapshot too: keep the last one
+	// only when this scan did not check it
+	if c.PartitionHealth == nil {
+		c.PartitionHealth = other.PartitionHealth
+	}
+
+	// Broker racks and partition leadership are a snapshot as well
+	if c.BrokerRacks == nil {
+		c.BrokerRacks = other.BrokerRacks
+	}
+
+	// Keep the last Open Monitoring settings when this scan did not read them
+	if c.OpenMonitoring == nil {
+		c.OpenMonitoring = other.OpenMonitoring
+	}
+
+	// Merge Plugins: this scan's plugin output takes precedence, output from
+	// plugins not run this time is preserved
+	for name, output := range other.Plugins {
+		if _, ok := c.Plugins[name]; ok {
+			continue
+		}
+		if c.Plugins == nil {
+			c.Plugins = make(map[string]json.RawMessage, len(other.Plugins))
+		}
+		c.Plugins[name] = output
+	}
 }
 
 func (c *KafkaAdminClientInformation) CalculateTopicSummary() TopicSummary {
@@ -63,6 +119,13 @@ func (c *KafkaAdminClientInformation) SetSelfManagedConnectors(connectors []Self
 	}
 }
 
+// MergeSelfManagedConnectors is SetSelfManagedConnectors for a partial scan:
+// connectors take precedence by name, and stored connectors it lacks are kept
+// rather than dropped.
+func (c *KafkaAdminClientInformation) MergeSelfManagedConnectors(connectors []SelfManagedConnector) {
+	c.SelfManagedConnectors = mergeSelfManagedConnectors(&SelfManagedConnectors{Connectors: connectors}, c.SelfManagedConnectors)
+}
+
 // mergeTopics merges two Topics, with newTopics taking precedence for duplicates (by name)
 func mergeTopics(newTopics, oldTopics *Topics) *Topics {
 	// If no old topics, just return new (even if empty)
@@ -81,6 +144,13 @@ func mergeTopics(newTopics, oldTopics *Topics) *Topics {
 		topicsByName[topic.Name] = topic
 	}
 	for _, topic := range newTopics.Details {
+		if old, ok := topicsByName[topic.Name]; ok {
+			// Keep the last sampled serialization when this scan did not sample
+			if topic.Serialization == nil {
+				topic.Serialization = old.Serialization
+			}
+			topic.Activity = mergeActivity(topic.Activity, old.Activity)
+		}
 		topicsByName[topic.Name] = topic // new takes precedence
 	}
 
@@ -127,6 +197,20 @@ func mergeAcls(newAcls, oldAcls []Acls) []Acls {
 	return merged
 }
 
+// mergeConsumerGroupActivity carries each group's offset activity over from
+// the last scan's snapshot, so a group that stops committing is recognised
+// however often it is rescanned.
+func mergeConsumerGroupActivity(newGroups, oldGroups []ConsumerGroup) []ConsumerGroup {
+	oldByID := make(map[string]*OffsetActivity, len(oldGroups))
+	for _, group := range oldGroups {
+		oldByID[group.GroupID] = group.Activity
+	}
+	for i := range newGroups {
+		newGroups[i].Activity = mergeActivity(newGroups[i].Activity, oldByID[newGroups[i].GroupID])
+	}
+	return newGroups
+}
+
 // mergeSelfManagedConnectors merges connectors, with new taking precedence for duplicates (by name)
 func mergeSelfManagedConnectors(newConnectors, oldConnectors *SelfManagedConnectors) *SelfManagedConnectors {
 	// Metrics are resolved up front (prefer-new-fall-back-to-old) so neither
@@ -179,13 +263,25 @@ func preferConnectorMetrics(newConnectors, oldConnectors *SelfManagedConnectors)
 }
 
 type DiscoveredClient struct {
-	CompositeKey string    `json:"composite_key"`
-	ClientId     string    `json:"client_id"`
-	Role         string    `json:"role"`
-	Topic        string    `json:"topic"`
-	Auth         string    `json:"auth"`
-	Principal    string    `json:"principal"`
-	Timestamp    time.Time `json:"timestamp"`
+	CompositeKey string `json:"composite_key"`
+	ClientId     string `json:"client_id"`
+	Role         string `json:"role"`
+	Topic        string `json:"topic"`
+	Auth         string `json:"auth"`
+	Principal    string `json:"principal"`
+	// ApiVersion is the oldest version of the Produce (producers) or Fetch
+	// (consumers) API the client was seen using; 0 when not recorded.
+	ApiVersion int       `json:"api_version,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// OldestApiVersion returns the lower of two recorded API versions, where 0
+// means not recorded.
+func OldestApiVersion(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
 }
 
 type KcpBuildInfo struct {
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -94,3 +96,127 @@ func TestSetSelfManagedConnectors_PreservesExistingMetrics(t *testing.T) {
 	require.Len(t, info.SelfManagedConnectors.Connectors, 1)
 	require.Equal(t, "new", info.SelfManagedConnectors.Connectors[0].Name)
 }
+
+func TestMergeSelfManagedConnectors_KeepsUnscannedConnectors(t *testing.T) {
+	existing := &ConnectClusterMetrics{Metadata: ConnectMetricMetadata{MetricsSource: MetricBackendJolokia}}
+	info := &KafkaAdminClientInformation{
+		SelfManagedConnectors: &SelfManagedConnectors{
+			Connectors: []SelfManagedConnector{{Name: "orders-sink", State: "FAILED"}, {Name: "payments-source"}},
+			Metrics:    existing,
+		},
+	}
+
+	info.MergeSelfManagedConnectors([]SelfManagedConnector{{Name: "orders-sink", State: "RUNNING"}})
+
+	require.Same(t, existing, info.SelfManagedConnectors.Metrics)
+	states := map[string]string{}
+	for _, c := range info.SelfManagedConnectors.Connectors {
+		states[c.Name] = c.State
+	}
+	require.Equal(t, map[string]string{"orders-sink": "RUNNING", "payments-source": ""}, states)
+}
+
+// Output from plugins not run on this scan survives; a re-run plugin replaces
+// its previous output.
+func TestMergeFrom_Plugins(t *testing.T) {
+	info := KafkaAdminClientInformation{Plugins: map[string]json.RawMessage{"naming": json.RawMessage(`{"violations":0}`)}}
+	info.MergeFrom(KafkaAdminClientInformation{Plugins: map[string]json.RawMessage{
+		"naming": json.RawMessage(`{"violations":3}`),
+		"owners": json.RawMessage(`{"teams":["payments"]}`),
+	}})
+	require.Equal(t, map[string]json.RawMessage{
+		"naming": json.RawMessage(`{"violations":0}`),
+		"owners": json.RawMessage(`{"teams":["payments"]}`),
+	}, info.Plugins)
+
+	info = KafkaAdminClientInformation{}
+	info.MergeFrom(KafkaAdminClientInformation{Plugins: map[string]json.RawMessage{"owners": json.RawMessage(`{}`)}})
+	require.Equal(t, map[string]json.RawMessage{"owners": json.RawMessage(`{}`)}, info.Plugins)
+}
+
+// A scan that could not check partition health keeps the last check; a new
+// check replaces it.
+func TestMergeFrom_PartitionHealth(t *testing.T) {
+	last := &PartitionHealth{Partitions: 6, UnderReplicatedPartitions: 1}
+
+	info := KafkaAdminClientInformation{}
+	info.MergeFrom(KafkaAdminClientInformation{PartitionHealth: last})
+	require.Same(t, last, info.PartitionHealth)
+
+	info = KafkaAdminClientInformation{PartitionHealth: &PartitionHealth{Partitions: 6}}
+	info.MergeFrom(KafkaAdminClientInformation{PartitionHealth: last})
+	require.True(t, info.PartitionHealth.Healthy())
+}
+
+func TestPartitionHealth_Problems(t *testing.T) {
+	var unchecked *PartitionHealth
+	require.True(t, unchecked.Healthy())
+	require.Empty(t, unchecked.Problems())
+
+	health := &PartitionHealth{
+		Partitions:                12,
+		UnderReplicatedPartitions: 2,
+		OfflinePartitions:         1,
+		Reassignments:             []PartitionReassignment{{Topic: "orders", Partition: 0}},
+	}
+	require.False(t, health.Healthy())
+	require.Equal(t, []string{
+		"1 of 12 partitions are offline (no leader)",
+		"2 of 12 partitions are under-replicated",
+		"1 partition reassignment(s) in flight",
+	}, health.Problems())
+}
+
+// Re-scanning without --sample-messages keeps each topic's last sampled
+// serialization; a new sample replaces it.
+func TestMergeTopics_KeepsSerializationWhenNotResampled(t *testing.T) {
+	old := &Topics{Details: []TopicDetails{
+		{Name: "orders", Serialization: &TopicSerialization{Format: SerializationJSON}},
+		{Name: "payments", Serialization: &TopicSerialization{Format: SerializationJSON}},
+	}}
+	fresh := &Topics{Details: []TopicDetails{
+		{Name: "orders", Partitions: 6},
+		{Name: "payments", Serialization: &TopicSerialization{Format: SerializationSchemaRegistryBinary}},
+	}}
+
+	merged := map[string]TopicDetails{}
+	for _, topic := range mergeTopics(fresh, old).Details {
+		merged[topic.Name] = topic
+	}
+	require.Equal(t, 6, merged["orders"].Partitions)
+	require.Equal(t, SerializationJSON, merged["orders"].Serialization.Format)
+	require.Equal(t, SerializationSchemaRegistryBinary, merged["payments"].Serialization.Format)
+}
+
+// A rescan that observes the same offset keeps the time it was first seen;
+// an offset that moved starts over, and drops a last produce time it did
+// not resample.
+func TestMergeFrom_CarriesOffsetActivity(t *testing.T) {
+	first := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	produced := first.Add(-time.Hour)
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	old := KafkaAdminClientInformation{
+		Topics: &Topics{Details: []TopicDetails{
+			{Name: "idle", Activity: &OffsetActivity{ObservedAt: first, Offset: 10, UnchangedSince: first, LastProducedAt: &produced}},
+			{Name: "busy", Activity: &OffsetActivity{ObservedAt: first, Offset: 10, UnchangedSince: first, LastProducedAt: &produced}},
+		}},
+		ConsumerGroups: []ConsumerGroup{{GroupID: "abandoned", Activity: &OffsetActivity{ObservedAt: first, Offset: 5, UnchangedSince: first}}},
+	}
+	info := KafkaAdminClientInformation{
+		Topics: &Topics{Details: []TopicDetails{
+			{Name: "idle", Activity: &OffsetActivity{ObservedAt: now, Offset: 10, UnchangedSince: now}},
+			{Name: "busy", Activity: &OffsetActivity{ObservedAt: now, Offset: 20, UnchangedSince: now}},
+		}},
+		ConsumerGroups: []ConsumerGroup{{GroupID: "abandoned", Activity: &OffsetActivity{ObservedAt: now, Offset: 5, UnchangedSince: now}}},
+	}
+	info.MergeFrom(old)
+
+	topics := map[string]TopicDetails{}
+	for _, topic := range info.Topics.Details {
+		topics[topic.Name] = topic
+	}
+	require.Equal(t, &OffsetActivity{ObservedAt: now, Offset: 10, UnchangedSince: first, LastProducedAt: &produced}, topics["idle"].Activity)
+	require.Equal(t, &OffsetActivity{ObservedAt: now, Offset: 20, UnchangedSince: now}, topics["busy"].Activity)
+	require.Equal(t, first, info.ConsumerGroups[0].Activity.UnchangedSince)
+}
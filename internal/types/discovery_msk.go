@@ -21,6 +21,14 @@ type DiscoveredRegion struct {
 	Configurations []kafka.DescribeConfigurationRevisionOutput `json:"configurations"`
 	Costs          CostInformation                             `json:"costs"`
 	Clusters       []DiscoveredCluster                         `json:"clusters"`
+	// Replicators are the MSK Replicators running in the region, as returned by
+	// DescribeReplicator. Replicators are created in their target cluster's
+	// region, so each one appears in exactly one region.
+	Replicators []kafka.DescribeReplicatorOutput `json:"replicators,omitempty"`
+	// KafkaSecrets are the region's Secrets Manager secrets holding Kafka
+	// credentials, from `kcp discover --scan-secrets`. Nil when the scan
+	// was not run.
+	KafkaSecrets []KafkaSecret `json:"kafka_secrets,omitempty"`
 	// internal only - exclude from JSON output
 	ClusterArns []string `json:"-"`
 }
@@ -32,13 +40,23 @@ type DiscoveredRegion struct {
 // MSK connectors live on AWSClientInformation, which MergeFrom does not reach, so they are
 // merged explicitly here: a denied or empty ListConnectors re-run must not wipe connectors
 // already in state. Only Connectors is merge-preserved; every other AWSClientInformation
-// field keeps its wholesale-replace semantics.
+// field keeps its wholesale-replace semantics. CloudTrail activity and flow log traffic
+// come from their own scans and are carried over as is.
 func mergeClusterPreservingAdminInfo(existing, newCluster DiscoveredCluster) DiscoveredCluster {
 	newCluster.KafkaAdminClientInformation.MergeFrom(existing.KafkaAdminClientInformation)
 	newCluster.AWSClientInformation.Connectors = mergeConnectors(
 		newCluster.AWSClientInformation.Connectors,
 		existing.AWSClientInformation.Connectors,
 	)
+	if newCluster.CloudTrailActivity == nil {
+		newCluster.CloudTrailActivity = existing.CloudTrailActivity
+	}
+	if newCluster.FlowLogTraffic == nil {
+		newCluster.FlowLogTraffic = existing.FlowLogTraffic
+	}
+	if newCluster.Annotations == nil {
+		newCluster.Annotations = existing.Annotations
+	}
 	return newCluster
 }
 
@@ -98,6 +116,33 @@ func (dr *DiscoveredRegion) UpsertCluster(newCluster DiscoveredCluster) {
 	dr.Clusters = append(dr.Clusters, newCluster)
 }
 
+// DefaultOwnerTagKeys are the cluster tag keys checked, in order, when
+// inferring which team owns a cluster.
+var DefaultOwnerTagKeys = []string{"team", "owner", "cost-center"}
+
+// InferOwnerFromTags returns the value of the first tag matching one of keys,
+// or "" when none match. Keys match case-insensitively and ignoring '-', '_'
+// and spaces, so "cost-center" also matches "CostCenter" and "cost_center".
+func InferOwnerFromTags(tags map[string]string, keys []string) string {
+	normalize := func(key string) string {
+		return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(key))
+	}
+
+	normalizedTags := make(map[string]string, len(tags))
+	for key, value := range tags {
+		if value = strings.TrimSpace(value); value != "" {
+			normalizedTags[normalize(key)] = value
+		}
+	}
+
+	for _, key := range keys {
+		if value, ok := normalizedTags[normalize(key)]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
 type DiscoveredCluster struct {
 	Name                        string                      `json:"name"`
 	Arn                         string                      `json:"arn"`
@@ -106,12 +151,20 @@ type DiscoveredCluster struct {
 	AWSClientInformation        AWSClientInformation        `json:"aws_client_information"`
 	KafkaAdminClientInformation KafkaAdminClientInformation `json:"kafka_admin_client_information"`
 	DiscoveredClients           []DiscoveredClient          `json:"discovered_clients"`
+	// CloudTrailActivity is written by `kcp scan cloudtrail-clients`.
+	CloudTrailActivity *CloudTrailClientActivity `json:"cloudtrail_activity,omitempty"`
+	// FlowLogTraffic is written by `kcp scan flow-logs`.
+	FlowLogTraffic *FlowLogTraffic `json:"flow_log_traffic,omitempty"`
+	// Annotations hold manual knowledge about the cluster, set with `kcp
+	// state annotate`, e.g. AnnotationScope.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 type AWSClientInformation struct {
 	MskClusterConfig     kafkatypes.Cluster                     `json:"msk_cluster_config"`
 	ClientVpcConnections []kafkatypes.ClientVpcConnection       `json:"client_vpc_connections"`
 	ClusterOperations    []kafkatypes.ClusterOperationV2Summary `json:"cluster_operations"`
+	InFlightOperations   []kafkatypes.ClusterOperationV2        `json:"in_flight_operations,omitempty"`
 	Nodes                []kafkatypes.NodeInfo                  `json:"nodes"`
 	ScramSecrets         []string                               `json:"ScramSecrets"`
 	BootstrapBrokers     kafka.GetBootstrapBrokersOutput        `json:"bootstrap_brokers"`
@@ -119,6 +172,38 @@ type AWSClientInformation struct {
 	CompatibleVersions   kafka.GetCompatibleKafkaVersionsOutput `json:"compatible_versions"`
 	ClusterNetworking    ClusterNetworking                      `json:"cluster_networking"`
 	Connectors           []ConnectorSummary                     `json:"connectors"`
+	// IAMAccess maps the IAM principals whose identity policies grant
+	// kafka-cluster actions on this cluster. Only captured for clusters with
+	// SASL/IAM enabled, where access is controlled by IAM rather than ACLs.
+	IAMAccess []IAMPrincipalAccess `json:"iam_access,omitempty"`
+}
+
+// IAMPrincipalAccess is the kafka-cluster access that one IAM user, role or
+// group is granted on a cluster by its inline and attached policies.
+type IAMPrincipalAccess struct {
+	PrincipalArn  string `json:"principal_arn"`
+	PrincipalName string `json:"principal_name"`
+	PrincipalType string `json:"principal_type"` // "user", "role" or "group"
+	// CanConnect is the IAM policy simulator's verdict for kafka-cluster:Connect
+	// on the cluster ARN, which also accounts for explicit denies and
+	// permission boundaries. Nil when the simulation could not run.
+	CanConnect  *bool                `json:"can_connect,omitempty"`
+	Permissions []IAMKafkaPermission `json:"permissions"`
+}
+
+// IAMKafkaPermission is one Allow statement granting kafka-cluster actions on
+// the cluster or on its topics, groups or transactional IDs.
+type IAMKafkaPermission struct {
+	Policy    string   `json:"policy"`
+	PolicyArn string   `json:"policy_arn,omitempty"` // empty for inline policies
+	Actions   []string `json:"actions"`
+	Resources []string `json:"resources"`
+}
+
+// IsClusterOperationInFlight reports whether an MSK operation state is
+// PENDING or still running (UPDATE_IN_PROGRESS, ROLLBACK_IN_PROGRESS, ...).
+func IsClusterOperationInFlight(state string) bool {
+	return state == "PENDING" || strings.HasSuffix(state, "IN_PROGRESS")
 }
 
 // Returns only one bootstrap broker per authentication type.
@@ -231,6 +316,39 @@ type ClusterNetworking struct {
 	SubnetIds      []string     `json:"subnet_ids"`
 	SecurityGroups []string     `json:"security_groups"`
 	Subnets        []SubnetInfo `json:"subnets"`
+	// BrokerENIs and SecurityGroupRules are empty when the ENI and security
+	// group lookups were denied, and in state files written before they were
+	// recorded.
+	BrokerENIs         []BrokerENI                `json:"broker_enis,omitempty"`
+	SecurityGroupRules []SecurityGroupInboundRule `json:"security_group_rules,omitempty"`
+}
+
+// MSKBrokerPorts are the ports MSK brokers listen on: plaintext, TLS,
+// SASL/SCRAM and IAM, then the public TLS, SASL/SCRAM and IAM listeners.
+var MSKBrokerPorts = []int32{9092, 9094, 9096, 9098, 9194, 9196, 9198}
+
+// BrokerENI is the elastic network interface a broker serves clients on, and
+// the security groups attached to it.
+type BrokerENI struct {
+	BrokerId           int      `json:"broker_id"`
+	NetworkInterfaceId string   `json:"network_interface_id"`
+	SubnetId           string   `json:"subnet_id"`
+	PrivateIpAddress   string   `json:"private_ip_address"`
+	SecurityGroupIds   []string `json:"security_group_ids"`
+}
+
+// SecurityGroupInboundRule is one source of an inbound rule, on a security
+// group attached to a broker ENI, that lets traffic reach a Kafka port.
+// Protocol is "-1" and the ports are -1 for a rule allowing all traffic.
+// SourceType is cidr, ipv6-cidr, security-group or prefix-list.
+type SecurityGroupInboundRule struct {
+	SecurityGroupId string `json:"security_group_id"`
+	Protocol        string `json:"protocol"`
+	FromPort        int32  `json:"from_port"`
+	ToPort          int32  `json:"to_port"`
+	SourceType      string `json:"source_type"`
+	Source          string `json:"source"`
+	Description     string `json:"description,omitempty"`
 }
 
 type SubnetInfo struct {
@@ -239,6 +357,9 @@ type SubnetInfo struct {
 	AvailabilityZone  string `json:"availability_zone"`
 	PrivateIpAddress  string `json:"private_ip_address"`
 	CidrBlock         string `json:"cidr_block"`
+	// AvailableIpAddressCount is the subnet's free IP count at scan time. Nil
+	// in state files written before it was recorded.
+	AvailableIpAddressCount *int32 `json:"available_ip_address_count,omitempty"`
 }
 
 type ConnectorSummary struct {
@@ -251,4 +372,48 @@ type ConnectorSummary struct {
 	Capacity                         kafkaconnecttypes.CapacityDescription                         `json:"capacity"`
 	Plugins                          []kafkaconnecttypes.PluginDescription                         `json:"plugins"`
 	ConnectorConfiguration           map[string]string                                             `json:"connector_configuration"`
+	Diagnostics                      *ConnectorDiagnostics                                         `json:"diagnostics,omitempty"`
+}
+
+// ConnectorDiagnostics is captured for FAILED connectors only: why the
+// connector failed, the operations that led there, and the execution role and
+// log delivery it runs with, so the plan can call out what to remediate before
+// the connector is recreated.
+type ConnectorDiagnostics struct {
+	StateCode               string                                          `json:"state_code,omitempty"`
+	StateMessage            string                                          `json:"state_message,omitempty"`
+	ServiceExecutionRoleArn string                                          `json:"service_execution_role_arn,omitempty"`
+	LogDelivery             *kafkaconnecttypes.WorkerLogDeliveryDescription `json:"log_delivery,omitempty"`
+	Operations              []ConnectorOperation                            `json:"operations,omitempty"`
+}
+
+// ConnectorOperation is one update of a connector, as ListConnectorOperations
+// reports it. Error details are only recorded for operations that failed.
+type ConnectorOperation struct {
+	OperationArn   string `json:"operation_arn"`
+	OperationType  string `json:"operation_type"`
+	OperationState string `json:"operation_state"`
+	CreationTime   string `json:"creation_time,omitempty"`
+	EndTime        string `json:"end_time,omitempty"`
+	ErrorCode      string `json:"error_code,omitempty"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+}
+
+// LogDestinations lists the enabled worker log destinations, or nil when
+// worker logs aren't delivered anywhere.
+func (d ConnectorDiagnostics) LogDestinations() []string {
+	if d.LogDelivery == nil {
+		return nil
+	}
+	var destinations []string
+	if cw := d.LogDelivery.CloudWatchLogs; cw != nil && cw.Enabled {
+		destinations = append(destinations, "CloudWatch Logs "+aws.ToString(cw.LogGroup))
+	}
+	if fh := d.LogDelivery.Firehose; fh != nil && fh.Enabled {
+		destinations = append(destinations, "Firehose "+aws.ToString(fh.DeliveryStream))
+	}
+	if s3 := d.LogDelivery.S3; s3 != nil && s3.Enabled {
+		destinations = append(destinations, "S3 "+aws.ToString(s3.Bucket))
+	}
+	return destinations
 }
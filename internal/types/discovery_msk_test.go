@@ -163,3 +163,40 @@ func TestUpsertCluster_PreservesConnectorsOnDeniedRerun(t *testing.T) {
 	require.Len(t, dr.Clusters, 1)
 	require.Len(t, dr.Clusters[0].AWSClientInformation.Connectors, 1, "UpsertCluster must preserve prior connectors on a denied re-run")
 }
+
+// CloudTrail activity and flow log traffic are written by their own scans, so
+// re-discovering the cluster must keep them.
+func TestRefreshClusters_PreservesClientEvidence(t *testing.T) {
+	activity := &CloudTrailClientActivity{Source: CloudTrailSourceLookupEvents, Principals: []CloudTrailPrincipalActivity{{PrincipalArn: "arn:aws:iam::123:role/app"}}}
+	traffic := &FlowLogTraffic{Clients: []FlowLogClient{{Address: "10.0.5.20"}}}
+	dr := &DiscoveredRegion{Clusters: []DiscoveredCluster{{Arn: "arn:cluster", CloudTrailActivity: activity, FlowLogTraffic: traffic}}}
+
+	dr.RefreshClusters([]DiscoveredCluster{{Arn: "arn:cluster"}})
+
+	require.Len(t, dr.Clusters, 1)
+	assert.Equal(t, activity, dr.Clusters[0].CloudTrailActivity)
+	assert.Equal(t, traffic, dr.Clusters[0].FlowLogTraffic)
+}
+
+func TestInferOwnerFromTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string]string
+		keys []string
+		want string
+	}{
+		{name: "no tags", tags: nil, keys: DefaultOwnerTagKeys, want: ""},
+		{name: "no matching key", tags: map[string]string{"env": "prod"}, keys: DefaultOwnerTagKeys, want: ""},
+		{name: "team wins over owner", tags: map[string]string{"Owner": "alice", "Team": "payments"}, keys: DefaultOwnerTagKeys, want: "payments"},
+		{name: "owner when no team", tags: map[string]string{"owner": "alice", "CostCenter": "cc-42"}, keys: DefaultOwnerTagKeys, want: "alice"},
+		{name: "cost center spelling variants", tags: map[string]string{"cost_center": "cc-42"}, keys: DefaultOwnerTagKeys, want: "cc-42"},
+		{name: "blank value ignored", tags: map[string]string{"team": "  ", "owner": "alice"}, keys: DefaultOwnerTagKeys, want: "alice"},
+		{name: "custom keys", tags: map[string]string{"team": "payments", "Squad": "ledger"}, keys: []string{"squad"}, want: "ledger"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, InferOwnerFromTags(tt.tags, tt.keys))
+		})
+	}
+}
@@ -15,6 +15,9 @@ type OSKDiscoveredCluster struct {
 	ClusterMetrics              *ProcessedClusterMetrics    `json:"metrics,omitempty"`
 	DiscoveredClients           []DiscoveredClient          `json:"discovered_clients"`
 	Metadata                    OSKClusterMetadata          `json:"metadata"`
+	// Annotations hold manual knowledge about the cluster, set with `kcp
+	// state annotate`, e.g. AnnotationScope.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // OSKClusterMetadata contains optional metadata about OSK clusters
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// FlowLogTraffic is the client traffic VPC Flow Logs recorded to an MSK
+// cluster's broker ENIs on Kafka ports. It identifies clients by address,
+// where ACLs and IAM policies do not name them, e.g. on unauthenticated
+// listeners or with principals shared between applications.
+type FlowLogTraffic struct {
+	StartTime time.Time       `json:"start_time"`
+	EndTime   time.Time       `json:"end_time"`
+	Clients   []FlowLogClient `json:"clients"`
+	// ClientCIDRs rolls Clients up into networks, which usually map to the
+	// subnets or VPCs applications run in.
+	ClientCIDRs []FlowLogClientCIDR `json:"client_cidrs"`
+}
+
+// FlowLogClient is the accepted traffic from one client address.
+type FlowLogClient struct {
+	Address    string    `json:"address"`
+	BrokerENIs []string  `json:"broker_enis"`
+	Ports      []int32   `json:"ports"`
+	Flows      int64     `json:"flows"`
+	Packets    int64     `json:"packets"`
+	Bytes      int64     `json:"bytes"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+}
+
+// FlowLogClientCIDR is the traffic from the client addresses in one network.
+type FlowLogClientCIDR struct {
+	CIDR    string `json:"cidr"`
+	Clients int    `json:"clients"`
+	Bytes   int64  `json:"bytes"`
+}
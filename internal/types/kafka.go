@@ -2,6 +2,7 @@ package types
 
 import (
 	"strings"
+	"time"
 )
 
 type TopicSummary struct {
@@ -21,6 +22,82 @@ type TopicDetails struct {
 	Partitions        int                `json:"partitions"`
 	ReplicationFactor int                `json:"replication_factor"`
 	Configurations    map[string]*string `json:"configurations"`
+	// PartitionSizes is the on-disk size in bytes of each partition, indexed by
+	// partition id: the largest replica as reported by DescribeLogDirs. Absent
+	// when log dirs could not be described (e.g. MSK Serverless).
+	PartitionSizes []int64 `json:"partition_sizes,omitempty"`
+	// Serialization is the message format inferred from sampling the topic
+	// (kcp scan clusters --sample-messages). Absent when the topic was not
+	// sampled.
+	Serialization *TopicSerialization `json:"serialization,omitempty"`
+	// Activity tracks the topic's end offsets across scans, to tell idle
+	// topics apart. Absent when the offsets could not be listed.
+	Activity *OffsetActivity `json:"activity,omitempty"`
+}
+
+// OffsetActivity tracks whether a topic is still written to, or a consumer
+// group still commits, across `kcp scan clusters` runs.
+type OffsetActivity struct {
+	ObservedAt time.Time `json:"observed_at"`
+	// Offset is the sum over partitions of the topic's end offsets or the
+	// group's committed offsets.
+	Offset int64 `json:"offset"`
+	// UnchangedSince is when a scan first observed Offset; rescans carry it
+	// forward for as long as the offset does not move.
+	UnchangedSince time.Time `json:"unchanged_since"`
+	// LastProducedAt is the timestamp of the newest message `kcp scan
+	// clusters --sample-messages` read. Topics only; dropped once the end
+	// offsets move without a new sample.
+	LastProducedAt *time.Time `json:"last_produced_at,omitempty"`
+}
+
+// mergeActivity carries UnchangedSince, and LastProducedAt when not
+// resampled, from the last scan's activity while the offset has not moved.
+func mergeActivity(fresh, old *OffsetActivity) *OffsetActivity {
+	if fresh == nil {
+		return old
+	}
+	if old == nil || fresh.Offset != old.Offset {
+		return fresh
+	}
+	merged := *fresh
+	if old.UnchangedSince.Before(merged.UnchangedSince) {
+		merged.UnchangedSince = old.UnchangedSince
+	}
+	if merged.LastProducedAt == nil {
+		merged.LastProducedAt = old.LastProducedAt
+	}
+	return &merged
+}
+
+// Message formats recognised by sampling. Schema Registry framed values carry
+// the schema ID the producer serialized with; Avro and Protobuf share a
+// framing, so telling them apart takes a Schema Registry lookup of the ID.
+const (
+	SerializationSchemaRegistryJSON   = "schema_registry_json"
+	SerializationSchemaRegistryBinary = "schema_registry_avro_or_protobuf"
+	SerializationGlueSchemaRegistry   = "glue_schema_registry"
+	SerializationAvroContainer        = "avro_container"
+	SerializationJSON                 = "json"
+	SerializationText                 = "text"
+	SerializationBinary               = "binary"
+	SerializationEmpty                = "empty"
+)
+
+// TopicSerialization summarises the formats of a topic's sampled message
+// values. Keys are not sampled.
+type TopicSerialization struct {
+	SampledAt       time.Time `json:"sampled_at"`
+	SampledMessages int       `json:"sampled_messages"`
+	// Format is the most common format among the sampled messages.
+	Format string `json:"format"`
+	// Formats counts the sampled messages of each format.
+	Formats map[string]int `json:"formats"`
+	// SchemaIDs are the Confluent Schema Registry schema IDs seen.
+	SchemaIDs []int `json:"schema_ids,omitempty"`
+	// GlueSchemaVersionIDs are the AWS Glue Schema Registry schema version
+	// IDs seen.
+	GlueSchemaVersionIDs []string `json:"glue_schema_version_ids,omitempty"`
 }
 
 type Topics struct {
@@ -28,6 +105,34 @@ type Topics struct {
 	Details []TopicDetails `json:"details"`
 }
 
+// ConsumerGroup is one consumer group as described by the admin scan.
+type ConsumerGroup struct {
+	GroupID      string `json:"group_id"`
+	State        string `json:"state"`
+	ProtocolType string `json:"protocol_type,omitempty"`
+	Members      int    `json:"members"`
+	// StaticInstanceIDs are the group.instance.id of the members using static
+	// membership (KIP-345). Brokers only report them to clients speaking Kafka
+	// 2.4 or later, so older scans leave them empty.
+	StaticInstanceIDs []string `json:"static_instance_ids,omitempty"`
+	// ClientRacks counts the members by the client.rack they subscribed with.
+	// Consumers only send it from Kafka 3.5 (KIP-881), so members of older
+	// clients, and members with no client.rack, are not counted.
+	ClientRacks map[string]int `json:"client_racks,omitempty"`
+	// Activity tracks the group's committed offsets across scans, to tell
+	// abandoned groups apart. Absent when the offsets could not be fetched.
+	Activity *OffsetActivity `json:"activity,omitempty"`
+}
+
+// BrokerRack is the broker.rack of one broker and the number of partitions it
+// leads. On MSK the rack is the availability zone ID of the broker, e.g.
+// use1-az1.
+type BrokerRack struct {
+	BrokerID         int32  `json:"broker_id"`
+	Rack             string `json:"rack,omitempty"`
+	LeaderPartitions int    `json:"leader_partitions"`
+}
+
 // Preferred over sarama.ResourceAcls because it is flattened vs sarama's nested structure.
 type Acls struct {
 	ResourceType        string `json:"ResourceType"`
@@ -0,0 +1,55 @@
+package types
+
+import "time"
+
+// Reasons a Secrets Manager secret is recorded as a Kafka credential.
+const (
+	// KafkaSecretMatchScramName — the name starts with AmazonMSK_, the
+	// prefix MSK requires for SCRAM secrets, whether or not the secret is
+	// associated with a cluster.
+	KafkaSecretMatchScramName = "scram_name_prefix"
+	// KafkaSecretMatchScramAssociation — the secret is associated with a
+	// discovered cluster for SASL/SCRAM.
+	KafkaSecretMatchScramAssociation = "scram_association"
+	// KafkaSecretMatchTag — a tag key or value mentions Kafka or MSK.
+	KafkaSecretMatchTag = "tag"
+)
+
+// KafkaSecret is the metadata of a Secrets Manager secret holding a Kafka
+// credential. The secret value is never read: this only records which
+// credentials exist, how they rotate and who can read them, so they can be
+// re-issued as Confluent Cloud API keys.
+type KafkaSecret struct {
+	Arn         string            `json:"arn"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	KmsKeyId    string            `json:"kms_key_id,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	// OwningService is set when another AWS service manages the secret.
+	OwningService string `json:"owning_service,omitempty"`
+	// MatchedBy lists the KafkaSecretMatch* reasons the secret was picked.
+	MatchedBy []string `json:"matched_by"`
+	// ScramClusterArns are the discovered clusters the secret is associated
+	// with for SASL/SCRAM.
+	ScramClusterArns []string `json:"scram_cluster_arns,omitempty"`
+
+	RotationEnabled   bool   `json:"rotation_enabled"`
+	RotationLambdaArn string `json:"rotation_lambda_arn,omitempty"`
+	// RotationAfterDays or RotationSchedule (a rate() or cron() expression)
+	// is the rotation interval when rotation is enabled.
+	RotationAfterDays int64  `json:"rotation_after_days,omitempty"`
+	RotationSchedule  string `json:"rotation_schedule,omitempty"`
+
+	CreatedDate      *time.Time `json:"created_date,omitempty"`
+	LastChangedDate  *time.Time `json:"last_changed_date,omitempty"`
+	LastRotatedDate  *time.Time `json:"last_rotated_date,omitempty"`
+	NextRotationDate *time.Time `json:"next_rotation_date,omitempty"`
+	// LastAccessedDate is day-granular; a secret nobody read in months is
+	// likely a stale credential that needn't be re-issued.
+	LastAccessedDate *time.Time `json:"last_accessed_date,omitempty"`
+
+	// PolicyPrincipals are the principals the secret's resource policy
+	// grants access to, i.e. the consumers beyond identity-based IAM
+	// policies. Empty when the secret has no resource policy.
+	PolicyPrincipals []string `json:"policy_principals,omitempty"`
+}
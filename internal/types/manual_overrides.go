@@ -0,0 +1,83 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/state/overrides"
+)
+
+// Conventional cluster annotation keys. Annotations are free-form, these are
+// only the ones kcp documents.
+const (
+	AnnotationScope               = "scope"
+	AnnotationTargetEnvironmentId = "target_environment_id"
+	AnnotationOwner               = "owner"
+	// AnnotationEnvironment is the business environment (dev, stage, prod)
+	// `kcp state classify` assigns; reports and planners group by it.
+	AnnotationEnvironment = "environment"
+)
+
+// ManualOverrides records the overrides file last applied with `kcp state
+// annotate`. The operations are re-applied every time the state file is
+// loaded, so manual corrections survive discover and scan runs rewriting the
+// data they correct.
+type ManualOverrides struct {
+	File       string                `json:"file"`
+	AppliedAt  time.Time             `json:"applied_at"`
+	Operations []overrides.Operation `json:"operations"`
+}
+
+// ApplyOverrides applies ops to the state, failing on the first operation
+// that does not apply or leaves the state in a shape kcp cannot read, and
+// records them as the state's manual overrides.
+func (s *State) ApplyOverrides(file string, ops []overrides.Operation) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	patched, _, err := overrides.Apply(data, ops, true)
+	if err != nil {
+		return err
+	}
+
+	var updated State
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&updated); err != nil {
+		return fmt.Errorf("overrides leave the state in a shape kcp cannot read: %w", err)
+	}
+	updated.ManualOverrides = &ManualOverrides{
+		File:       file,
+		AppliedAt:  time.Now().UTC(),
+		Operations: ops,
+	}
+	*s = updated
+	return nil
+}
+
+// applyStoredOverrides re-applies the manual overrides stored in a state
+// document at load time. Operations that no longer apply, e.g. to a cluster
+// that has been deleted, are skipped with a warning rather than failing the
+// load.
+func applyStoredOverrides(data []byte) ([]byte, error) {
+	var probe struct {
+		ManualOverrides *ManualOverrides `json:"manual_overrides"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil || probe.ManualOverrides == nil || len(probe.ManualOverrides.Operations) == 0 {
+		return data, nil
+	}
+
+	patched, skipped, err := overrides.Apply(data, probe.ManualOverrides.Operations, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply manual overrides: %w", err)
+	}
+	for _, reason := range skipped {
+		slog.Warn("⚠️ skipping manual override that no longer applies", "override", reason)
+	}
+	slog.Debug("applied manual overrides", "file", probe.ManualOverrides.File, "operations", len(probe.ManualOverrides.Operations)-len(skipped))
+	return patched, nil
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/state/overrides"
+)
+
+func TestApplyOverridesSurvivesRediscovery(t *testing.T) {
+	const arn = "arn:aws:kafka:us-east-1:000000000000:cluster/orders/abc-1"
+	state := &State{MSKSources: &MSKSourcesState{Regions: []DiscoveredRegion{
+		{Name: "us-east-1", Clusters: []DiscoveredCluster{{Name: "orders", Arn: arn, Region: "us-east-1"}}},
+	}}}
+
+	ops, err := overrides.Parse([]byte(`[
+		{"op": "add", "cluster": "orders", "path": "/annotations/scope", "value": "out-of-scope"},
+		{"op": "add", "cluster": "retired", "path": "/annotations/scope", "value": "out-of-scope"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := state.ApplyOverrides("kcp-overrides.json", ops); err == nil {
+		t.Fatal("expected an error for an override of a cluster not in the state")
+	}
+
+	ops = ops[:1]
+	if err := state.ApplyOverrides("kcp-overrides.json", ops); err != nil {
+		t.Fatalf("ApplyOverrides: %v", err)
+	}
+	if got := state.MSKSources.Regions[0].Clusters[0].Annotations[AnnotationScope]; got != "out-of-scope" {
+		t.Fatalf("scope = %q", got)
+	}
+
+	// A rediscovery that drops the annotation does not lose it: the stored
+	// overrides are re-applied when the state file is next loaded.
+	state.MSKSources.Regions[0].Clusters[0].Annotations = nil
+	stateFile := filepath.Join(t.TempDir(), "kcp-state.json")
+	if err := state.WriteToFile(stateFile); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := NewStateFromFile(stateFile)
+	if err != nil {
+		t.Fatalf("NewStateFromFile: %v", err)
+	}
+	if got := loaded.MSKSources.Regions[0].Clusters[0].Annotations[AnnotationScope]; got != "out-of-scope" {
+		t.Errorf("scope after reload = %q", got)
+	}
+	if loaded.ManualOverrides == nil || loaded.ManualOverrides.File != "kcp-overrides.json" || len(loaded.ManualOverrides.Operations) != 1 {
+		t.Errorf("manual_overrides = %+v", loaded.ManualOverrides)
+	}
+}
+
+func TestApplyOverridesRejectsUnreadableState(t *testing.T) {
+	state := &State{MSKSources: &MSKSourcesState{Regions: []DiscoveredRegion{}}}
+	ops, err := overrides.Parse([]byte(`[{"op": "add", "path": "/msk_sources/regoins", "value": []}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := state.ApplyOverrides("kcp-overrides.json", ops); err == nil {
+		t.Fatal("expected an error for an override adding an unknown field")
+	}
+	if state.ManualOverrides != nil {
+		t.Error("a failed apply must not record the overrides")
+	}
+}
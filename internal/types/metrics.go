@@ -15,6 +15,18 @@ const (
 	BrokerTypeStandard BrokerType = "standard"
 )
 
+// BrokerDiskUsedLabelPrefix prefixes the label of each per-broker
+// KafkaDataLogsDiskUsed series; the broker ID follows it.
+const BrokerDiskUsedLabelPrefix = "KafkaDataLogsDiskUsed Broker "
+
+// TopicBytesInLabelPrefix and TopicBytesOutLabelPrefix prefix the label of
+// each per-topic BytesInPerSec and BytesOutPerSec series, summed across
+// brokers; the topic name follows them.
+const (
+	TopicBytesInLabelPrefix  = "BytesInPerSec Topic "
+	TopicBytesOutLabelPrefix = "BytesOutPerSec Topic "
+)
+
 type ClusterMetrics struct {
 	MetricMetadata MetricMetadata                     `json:"metadata"`
 	Results        []cloudwatchtypes.MetricDataResult `json:"results"`
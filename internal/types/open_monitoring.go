@@ -0,0 +1,51 @@
+package types
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Ports MSK serves the Open Monitoring exporters on, on every broker.
+const (
+	JmxExporterPort  = 11001
+	NodeExporterPort = 11002
+)
+
+// OpenMonitoring is a cluster's Open Monitoring with Prometheus settings, as
+// `kcp scan clusters` found them: the exporters MSK runs on every broker for
+// a Prometheus server to scrape, and the broker hosts they listen on.
+type OpenMonitoring struct {
+	JmxExporterEnabled  bool     `json:"jmx_exporter_enabled"`
+	NodeExporterEnabled bool     `json:"node_exporter_enabled"`
+	BrokerHosts         []string `json:"broker_hosts,omitempty"`
+}
+
+// Enabled reports whether both exporters are on.
+func (o OpenMonitoring) Enabled() bool {
+	return o.JmxExporterEnabled && o.NodeExporterEnabled
+}
+
+// OpenMonitoring returns the Open Monitoring settings of a provisioned
+// cluster, or nil for a serverless cluster or when `kcp discover` recorded no
+// broker configuration.
+func (c *AWSClientInformation) OpenMonitoring() *OpenMonitoring {
+	prov := c.MskClusterConfig.Provisioned
+	if prov == nil {
+		return nil
+	}
+
+	om := &OpenMonitoring{}
+	if prov.OpenMonitoring != nil && prov.OpenMonitoring.Prometheus != nil {
+		prom := prov.OpenMonitoring.Prometheus
+		om.JmxExporterEnabled = prom.JmxExporter != nil && aws.ToBool(prom.JmxExporter.EnabledInBroker)
+		om.NodeExporterEnabled = prom.NodeExporter != nil && aws.ToBool(prom.NodeExporter.EnabledInBroker)
+	}
+	for _, node := range c.Nodes {
+		if node.BrokerNodeInfo != nil && len(node.BrokerNodeInfo.Endpoints) > 0 {
+			om.BrokerHosts = append(om.BrokerHosts, node.BrokerNodeInfo.Endpoints[0])
+		}
+	}
+	sort.Strings(om.BrokerHosts)
+	return om
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kafkatypes "github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func brokerNode(endpoint string) kafkatypes.NodeInfo {
+	return kafkatypes.NodeInfo{BrokerNodeInfo: &kafkatypes.BrokerNodeInfo{Endpoints: []string{endpoint}}}
+}
+
+func TestAWSClientInformation_OpenMonitoring(t *testing.T) {
+	info := AWSClientInformation{
+		MskClusterConfig: kafkatypes.Cluster{
+			ClusterType: kafkatypes.ClusterTypeProvisioned,
+			Provisioned: &kafkatypes.Provisioned{
+				OpenMonitoring: &kafkatypes.OpenMonitoringInfo{Prometheus: &kafkatypes.PrometheusInfo{
+					JmxExporter: &kafkatypes.JmxExporterInfo{EnabledInBroker: aws.Bool(true)},
+				}},
+			},
+		},
+		Nodes: []kafkatypes.NodeInfo{
+			brokerNode("b-2.orders.abc.c2.kafka.us-east-1.amazonaws.com"),
+			brokerNode("b-1.orders.abc.c2.kafka.us-east-1.amazonaws.com"),
+			{},
+		},
+	}
+
+	om := info.OpenMonitoring()
+
+	require.NotNil(t, om)
+	assert.True(t, om.JmxExporterEnabled)
+	assert.False(t, om.NodeExporterEnabled)
+	assert.False(t, om.Enabled())
+	assert.Equal(t, []string{
+		"b-1.orders.abc.c2.kafka.us-east-1.amazonaws.com",
+		"b-2.orders.abc.c2.kafka.us-east-1.amazonaws.com",
+	}, om.BrokerHosts)
+}
+
+func TestAWSClientInformation_OpenMonitoring_Serverless(t *testing.T) {
+	info := AWSClientInformation{MskClusterConfig: kafkatypes.Cluster{ClusterType: kafkatypes.ClusterTypeServerless}}
+	assert.Nil(t, info.OpenMonitoring())
+}
+
+func TestMergeFrom_OpenMonitoring(t *testing.T) {
+	last := &OpenMonitoring{JmxExporterEnabled: true}
+
+	info := KafkaAdminClientInformation{}
+	info.MergeFrom(KafkaAdminClientInformation{OpenMonitoring: last})
+	require.Same(t, last, info.OpenMonitoring)
+
+	info = KafkaAdminClientInformation{OpenMonitoring: &OpenMonitoring{NodeExporterEnabled: true}}
+	info.MergeFrom(KafkaAdminClientInformation{OpenMonitoring: last})
+	assert.False(t, info.OpenMonitoring.JmxExporterEnabled)
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartitionHealth is the replication state of a cluster's partitions when
+// `kcp scan clusters` scanned it. Mirroring from a degraded source yields
+// misleading lag signals, so unhealthy clusters are reported before
+// migration.
+type PartitionHealth struct {
+	CheckedAt                 time.Time `json:"checked_at"`
+	Partitions                int       `json:"partitions"`
+	UnderReplicatedPartitions int       `json:"under_replicated_partitions"`
+	OfflinePartitions         int       `json:"offline_partitions"`
+	// UnhealthyPartitions are the under-replicated and offline partitions.
+	UnhealthyPartitions []UnhealthyPartition `json:"unhealthy_partitions,omitempty"`
+	// Reassignments are the partition reassignments in flight. Absent when
+	// the brokers could not list them (Kafka before 2.4, or a missing
+	// permission); ReassignmentsError then says why.
+	Reassignments      []PartitionReassignment `json:"reassignments,omitempty"`
+	ReassignmentsError string                  `json:"reassignments_error,omitempty"`
+}
+
+// UnhealthyPartition is a partition with replicas out of sync with its
+// leader, or with no leader at all.
+type UnhealthyPartition struct {
+	Topic           string  `json:"topic"`
+	Partition       int32   `json:"partition"`
+	Leader          int32   `json:"leader"`
+	Replicas        []int32 `json:"replicas"`
+	ISR             []int32 `json:"isr"`
+	OfflineReplicas []int32 `json:"offline_replicas,omitempty"`
+	Offline         bool    `json:"offline"`
+}
+
+// PartitionReassignment is a partition reassignment in flight (KIP-455).
+type PartitionReassignment struct {
+	Topic            string  `json:"topic"`
+	Partition        int32   `json:"partition"`
+	Replicas         []int32 `json:"replicas"`
+	AddingReplicas   []int32 `json:"adding_replicas,omitempty"`
+	RemovingReplicas []int32 `json:"removing_replicas,omitempty"`
+}
+
+// Healthy reports whether every partition is fully replicated and none is
+// being reassigned.
+func (h *PartitionHealth) Healthy() bool {
+	return h == nil || (h.UnderReplicatedPartitions == 0 && h.OfflinePartitions == 0 && len(h.Reassignments) == 0)
+}
+
+// Problems describes what makes the cluster unhealthy, one line per kind of
+// problem, or nothing when it is healthy.
+func (h *PartitionHealth) Problems() []string {
+	if h == nil {
+		return nil
+	}
+	var problems []string
+	if h.OfflinePartitions > 0 {
+		problems = append(problems, fmt.Sprintf("%d of %d partitions are offline (no leader)", h.OfflinePartitions, h.Partitions))
+	}
+	if h.UnderReplicatedPartitions > 0 {
+		problems = append(problems, fmt.Sprintf("%d of %d partitions are under-replicated", h.UnderReplicatedPartitions, h.Partitions))
+	}
+	if len(h.Reassignments) > 0 {
+		problems = append(problems, fmt.Sprintf("%d partition reassignment(s) in flight", len(h.Reassignments)))
+	}
+	return problems
+}
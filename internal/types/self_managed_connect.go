@@ -5,6 +5,17 @@ type SelfManagedConnector struct {
 	Config      map[string]any `json:"config"`
 	State       string         `json:"state,omitempty"`
 	ConnectHost string         `json:"connect_host,omitempty"`
+	// ConnectURL is the Connect REST endpoint the connector was read from,
+	// which tells connectors of different Connect clusters apart.
+	ConnectURL string                     `json:"connect_url,omitempty"`
+	Tasks      []SelfManagedConnectorTask `json:"tasks,omitempty"`
+}
+
+// SelfManagedConnectorTask is the status of one task of a connector.
+type SelfManagedConnectorTask struct {
+	ID       int    `json:"id"`
+	State    string `json:"state"`
+	WorkerID string `json:"worker_id,omitempty"`
 }
 
 type SelfManagedConnectors struct {
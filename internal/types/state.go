@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/redact"
 	"github.com/confluentinc/kcp/internal/state/migrate"
+	"github.com/confluentinc/kcp/internal/summary"
 )
 
 // State represents the unified state file (kcp-state.json)
@@ -25,6 +27,8 @@ type State struct {
 	Timestamp        time.Time              `json:"timestamp"`
 	UpdatedAt        time.Time              `json:"updated_at,omitempty"`
 	UpgradedFrom     string                 `json:"upgraded_from,omitempty"`
+	// ManualOverrides is written by `kcp state annotate`.
+	ManualOverrides *ManualOverrides `json:"manual_overrides,omitempty"`
 }
 
 func NewStateFrom(fromState *State) *State {
@@ -75,10 +79,12 @@ func NewStateFrom(fromState *State) *State {
 
 		// Carry forward data that isn't source-scoped so a RUW write (discover/scan)
 		// doesn't silently drop it: the upgraded_from breadcrumb (durable provenance
-		// of the file's origin shape) and any previously discovered schema registries
-		// (discover does not repopulate these — dropping them violates append-only).
+		// of the file's origin shape), any previously discovered schema registries
+		// (discover does not repopulate these — dropping them violates append-only)
+		// and the manual overrides.
 		workingState.UpgradedFrom = fromState.UpgradedFrom
 		workingState.SchemaRegistries = fromState.SchemaRegistries
+		workingState.ManualOverrides = fromState.ManualOverrides
 
 		// Timestamp is the created-at; only updated_at moves per write. Preserve the
 		// original so re-running discover/scan doesn't reset creation time to now.
@@ -119,6 +125,11 @@ func NewStateFromBytes(data []byte) (*State, error) {
 		}
 	}
 
+	migrated, err = applyStoredOverrides(migrated)
+	if err != nil {
+		return nil, err
+	}
+
 	var state State
 	decoder := json.NewDecoder(bytes.NewReader(migrated))
 	decoder.DisallowUnknownFields()
@@ -164,16 +175,33 @@ func fileBuildIsDev(data []byte) bool {
 	return probe.KcpBuildInfo.Version != "" && build_info.IsDevVersion(probe.KcpBuildInfo.Version)
 }
 
-func (s *State) WriteToFile(filePath string) error {
-	if err := backupIfMigrating(filePath); err != nil {
-		return err
-	}
+// Marshal stamps the current schema version and update time and returns the
+// redacted JSON that WriteToFile would write.
+func (s *State) Marshal() ([]byte, error) {
 	s.SchemaVersion = migrate.CurrentSchemaVersion
 	s.UpdatedAt = time.Now()
 
 	data, err := json.Marshal(s)
 	if err != nil {
-		return fmt.Errorf("failed to marshal state: %w", err)
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+	data, redacted, err := redact.JSON(data, redact.ScopeFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redact state: %w", err)
+	}
+	if redacted > 0 {
+		slog.Debug("redacted sensitive values from state file", "count", redacted)
+	}
+	return data, nil
+}
+
+func (s *State) WriteToFile(filePath string) error {
+	if err := backupIfMigrating(filePath); err != nil {
+		return err
+	}
+	data, err := s.Marshal()
+	if err != nil {
+		return err
 	}
 
 	// Write to a uniquely-named temp file in the same directory, then atomically
@@ -210,6 +238,7 @@ func (s *State) WriteToFile(filePath string) error {
 		return fmt.Errorf("failed to rename temp file: %w", err)
 	}
 
+	summary.FileWritten(filePath)
 	slog.Debug("wrote state file", "path", filePath, "schema_version", s.SchemaVersion, "bytes", len(data))
 	return nil
 }
@@ -246,7 +275,7 @@ func backupIfMigrating(filePath string) error {
 		}
 		bak = fmt.Sprintf("%s.%s-%d.bak", filePath, ts, i)
 	}
-	if err := os.WriteFile(bak, existing, 0600); err != nil {
+	if err := summary.WriteFile(bak, existing, 0600); err != nil {
 		return fmt.Errorf("failed to back up state file before migrating write: %w", err)
 	}
 	slog.Debug("backed up state file before migrating write",
@@ -283,7 +312,7 @@ func (s *State) WriteReportCommands(filePath string, stateFilePath string) error
 	clusterLines := strings.Join(clusterCommands, "\n")
 	allLines := regionLines + "\n" + clusterLines + "\n"
 
-	err := os.WriteFile(filePath, []byte(allLines), 0644)
+	err := summary.WriteFile(filePath, []byte(allLines), 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write commands to file: %v", err)
 	}
@@ -306,6 +335,11 @@ func (s *State) UpsertRegion(newRegion DiscoveredRegion) {
 	}
 	for i, existingRegion := range s.MSKSources.Regions {
 		if existingRegion.Name == newRegion.Name {
+			// The secrets scan is optional; a discover without it keeps
+			// the secrets an earlier run found.
+			if newRegion.KafkaSecrets == nil {
+				newRegion.KafkaSecrets = existingRegion.KafkaSecrets
+			}
 			discoveredClusters := newRegion.Clusters
 			newRegion.Clusters = existingRegion.Clusters
 			// set discovered clusters and refresh into state (preserves KafkaAdminClientInformation)
@@ -317,7 +351,7 @@ func (s *State) UpsertRegion(newRegion DiscoveredRegion) {
 	s.MSKSources.Regions = append(s.MSKSources.Regions, newRegion)
 }
 
-// UpsertTargetedClusters refreshes region-level data (costs, configurations) and creates
+// UpsertTargetedClusters refreshes region-level data (costs, configurations, replicators) and creates
 // or replaces only the clusters present in newRegion.Clusters, preserving every other
 // existing cluster in the region. Used by targeted (--cluster-arn) discovery. If the
 // region does not yet exist it is added as-is (fresh-state / new-region case).
@@ -332,6 +366,10 @@ func (s *State) UpsertTargetedClusters(newRegion DiscoveredRegion) {
 			// refresh region-level data discovered this run
 			s.MSKSources.Regions[i].Configurations = newRegion.Configurations
 			s.MSKSources.Regions[i].Costs = newRegion.Costs
+			s.MSKSources.Regions[i].Replicators = newRegion.Replicators
+			if newRegion.KafkaSecrets != nil {
+				s.MSKSources.Regions[i].KafkaSecrets = newRegion.KafkaSecrets
+			}
 			// create-or-replace only the targeted clusters
 			for _, targeted := range newRegion.Clusters {
 				s.MSKSources.Regions[i].UpsertCluster(targeted)
@@ -371,7 +409,13 @@ func dedupDiscoveredClients(discoveredClients []DiscoveredClient) []DiscoveredCl
 		existingClient, exists := clientsByCompositeKey[currentClient.CompositeKey]
 
 		if !exists || currentClient.Timestamp.After(existingClient.Timestamp) {
+			if exists {
+				currentClient.ApiVersion = OldestApiVersion(existingClient.ApiVersion, currentClient.ApiVersion)
+			}
 			clientsByCompositeKey[currentClient.CompositeKey] = currentClient
+		} else {
+			existingClient.ApiVersion = OldestApiVersion(existingClient.ApiVersion, currentClient.ApiVersion)
+			clientsByCompositeKey[currentClient.CompositeKey] = existingClient
 		}
 	}
 
@@ -17,6 +17,44 @@ func TestLoadFixtures(t *testing.T) {
 	}{
 		{"era-c-v0.8.0.json", true},
 		{"era-c-v0.8.5.json", true},
+		// schema_version 1, before subnets recorded available_ip_address_count.
+		{"schema-v1.json", true},
+		// schema_version 2, before scans recorded the auth_type they connected with.
+		{"schema-v2.json", true},
+		// schema_version 3, before scans recorded per-partition sizes.
+		{"schema-v3.json", true},
+		// schema_version 4, before scans captured in-flight cluster operations.
+		{"schema-v4.json", true},
+		// schema_version 5, before scans captured FAILED connector diagnostics.
+		{"schema-v5.json", true},
+		// schema_version 6, before discover captured IAM access for IAM-authenticated clusters.
+		{"schema-v6.json", true},
+		// schema_version 7, before discover captured MSK Replicators.
+		{"schema-v7.json", true},
+		// schema_version 8, before scan clusters recorded TLS inspections.
+		{"schema-v8.json", true},
+		// schema_version 9, before scan clusters recorded consumer groups.
+		{"schema-v9.json", true},
+		// schema_version 10, before discover recorded broker ENIs and security group rules.
+		{"schema-v10.json", true},
+		// schema_version 11, before scan clusters recorded --plugin output.
+		{"schema-v11.json", true},
+		// schema_version 12, before scan clusters recorded sampled topic serialization.
+		{"schema-v12.json", true},
+		// schema_version 13, before discover recorded Secrets Manager Kafka secrets.
+		{"schema-v13.json", true},
+		// schema_version 14, before self-managed connectors recorded their tasks.
+		{"schema-v14.json", true},
+		// schema_version 15, before discovered clusters recorded CloudTrail client activity.
+		{"schema-v15.json", true},
+		// schema_version 16, before discovered clusters recorded VPC Flow Logs client traffic.
+		{"schema-v16.json", true},
+		{"schema-v17.json", true},
+		{"schema-v18.json", true},
+		{"schema-v19.json", true},
+		{"schema-v20.json", true},
+		{"schema-v21.json", true},
+		{"schema-v22.json", true},
 		{"era-b-v0.7.3.json", true},
 		// Array-form schema_registries (v0.4.2–v0.7.1) — recovered to the object form by the
 		// schema_registries array→object upcaster, so it now loads.
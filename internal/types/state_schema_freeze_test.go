@@ -21,7 +21,29 @@ import (
 // changed) by making a shape change impossible to land without also bumping the
 // version — otherwise TestCurrentSchemaShapeMatchesEntry goes red.
 var schemaShapes = map[int]string{
-	1: "sha256:720619a5a172c612894076b92921683302818ad1c02372310e3e2e4291c81660",
+	1:  "sha256:720619a5a172c612894076b92921683302818ad1c02372310e3e2e4291c81660",
+	2:  "sha256:12db25a0e5687039500600d56f84c6fef783319a334f2deffe95d75d13b9234c",
+	3:  "sha256:ad9f3fb4697ccd407c8a908e332ee21dd81e179c85768ec16a758626611499e4",
+	4:  "sha256:f70482f4118f2ee4d5fc9bd330573a2b68fe90f1f9737fd8dbdb44fa5d59c5d8",
+	5:  "sha256:bbb1e12976afd2cc29ddf01c86f5b1b5ec15903530c12c0a9dfb8d455d97c827",
+	6:  "sha256:7d27f93162ccacb2bd6038487e5ebbb5c71096fffed62a394366e73b9f6258f3",
+	7:  "sha256:4c4bac906e5f7724c502a5adb0703eaca725d5fd4de75e4e8b496bb29fef2a64",
+	8:  "sha256:b66583669303689860ad7c4414bd6a1ab94820f370bc6cc1955010b260c61034",
+	9:  "sha256:8bef39df59e941204236f25e10d036833962bd1d5464a0daad6f45f3b1125d8e",
+	10: "sha256:cd70209bf581378705a56e55b29a578adca1ae10b5c94b0bdbb5ff8bdd1a239d",
+	11: "sha256:08bffcdf29d88f4100e5b03d57a866287ce38e3264c60c92f791b2d91643925b",
+	12: "sha256:55084436108a4c58fdd0f545545bfb40b37c001011a3c3332fcf5449fd06fe91",
+	13: "sha256:783c46d74f7b0ca8f178615b06b2c4c0323f9ef99a1ebdad44289061511e0f76",
+	14: "sha256:d94f7cc146e96723cebd66103631d73f47be5fa5efc715a2690fec469e15ec8c",
+	15: "sha256:e2449168adce6c99d44b336d0f446a331cf4ae7fe542718744394e40d3494f0d",
+	16: "sha256:96cfec2ab17c91fd35dea9a5def106f6d0cd8232c18c46e9baff3ecf7e677b97",
+	17: "sha256:fd5c333a0ecd2dcf85aed0f655941ca21bf352575a9539f607896fd4e6751a45",
+	18: "sha256:de429ab9a8a7914b50f04d875370dac77ce6c0214c0e7d657f83b54d9b5175be",
+	19: "sha256:68758c90b6bbb0430dfaa193e107d0941144ed2cdb9d9b7b9d8f0ae1fda20151",
+	20: "sha256:ee56f581aa96e1cc663054f2c7e4277ffd4c2785fdd24658701e409fd6d7fb73",
+	21: "sha256:c19b904aef283de1018891f6c488722df5d61e0aeb68165db6081affb58284dc",
+	22: "sha256:34c16d4f712e041e68b0cf53075752b5a72e1461fe149715795837bf5d4fdd05",
+	23: "sha256:d791902a81986e4f297fb7f327d1728d23fdc1aac592e9ef4badbcb3bdfe8e0f",
 }
 
 // schemaFloor is the first versioned schema.
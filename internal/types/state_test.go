@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/confluentinc/kcp/internal/build_info"
+	"github.com/confluentinc/kcp/internal/redact"
 	"github.com/confluentinc/kcp/internal/state/migrate"
+	"github.com/confluentinc/kcp/internal/state/overrides"
 
 	costexplorertypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
 )
@@ -185,6 +187,27 @@ func TestUpsertRegion(t *testing.T) {
 	}
 }
 
+func TestUpsertRegion_KeepsKafkaSecretsWhenNotScanned(t *testing.T) {
+	secrets := []KafkaSecret{{Arn: "arn:secret", Name: "AmazonMSK_orders", MatchedBy: []string{KafkaSecretMatchScramName}}}
+	state := &State{MSKSources: &MSKSourcesState{Regions: []DiscoveredRegion{{Name: "us-east-1", KafkaSecrets: secrets}}}}
+
+	// A discover without --scan-secrets keeps earlier results.
+	state.UpsertRegion(DiscoveredRegion{Name: "us-east-1"})
+	if got := state.MSKSources.Regions[0].KafkaSecrets; !reflect.DeepEqual(got, secrets) {
+		t.Errorf("UpsertRegion() KafkaSecrets = %v, want %v", got, secrets)
+	}
+	state.UpsertTargetedClusters(DiscoveredRegion{Name: "us-east-1"})
+	if got := state.MSKSources.Regions[0].KafkaSecrets; !reflect.DeepEqual(got, secrets) {
+		t.Errorf("UpsertTargetedClusters() KafkaSecrets = %v, want %v", got, secrets)
+	}
+
+	// A scan that found nothing replaces them.
+	state.UpsertRegion(DiscoveredRegion{Name: "us-east-1", KafkaSecrets: []KafkaSecret{}})
+	if got := state.MSKSources.Regions[0].KafkaSecrets; len(got) != 0 {
+		t.Errorf("UpsertRegion() KafkaSecrets = %v, want none", got)
+	}
+}
+
 func TestRefreshClusters(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -907,6 +930,11 @@ func TestNewStateFrom_NoFieldSilentlyDropped(t *testing.T) {
 		Timestamp:    fixed,
 		UpdatedAt:    fixed.Add(time.Hour),
 		UpgradedFrom: "era=B",
+		ManualOverrides: &ManualOverrides{
+			File:       "kcp-overrides.json",
+			AppliedAt:  fixed,
+			Operations: []overrides.Operation{{Op: overrides.OpRemove, Cluster: "osk-1", Path: "/annotations/scope"}},
+		},
 	}
 
 	st := reflect.TypeOf(State{})
@@ -1581,6 +1609,41 @@ func TestWriteToFile_RoundTripsContent(t *testing.T) {
 	}
 }
 
+// TestWriteToFile_RedactsSensitiveValues verifies known secret keys are
+// redacted before the state file reaches disk, while the SCRAM secret ARNs later
+// commands read back are kept.
+func TestWriteToFile_RedactsSensitiveValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kcp-state.json")
+	jaas := "org.apache.kafka.common.security.scram.ScramLoginModule required password=\"hunter2\";"
+	secretArn := "arn:aws:secretsmanager:us-east-1:000000000000:secret:AmazonMSK_orders"
+	state := &State{MSKSources: &MSKSourcesState{Regions: []DiscoveredRegion{{
+		Name: "us-east-1",
+		Clusters: []DiscoveredCluster{{
+			Name:                 "orders",
+			AWSClientInformation: AWSClientInformation{ScramSecrets: []string{secretArn}},
+			KafkaAdminClientInformation: KafkaAdminClientInformation{Topics: &Topics{Details: []TopicDetails{{
+				Name:           "orders",
+				Configurations: map[string]*string{"sasl.jaas.config": &jaas},
+			}}}},
+		}},
+	}}}}
+	if err := state.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile: %v", err)
+	}
+
+	got, err := NewStateFromFile(path)
+	if err != nil {
+		t.Fatalf("NewStateFromFile: %v", err)
+	}
+	cluster := got.MSKSources.Regions[0].Clusters[0]
+	if v := cluster.KafkaAdminClientInformation.Topics.Details[0].Configurations["sasl.jaas.config"]; v == nil || *v != redact.Placeholder {
+		t.Errorf("sasl.jaas.config not redacted")
+	}
+	if !reflect.DeepEqual(cluster.AWSClientInformation.ScramSecrets, []string{secretArn}) {
+		t.Errorf("ScramSecrets = %v, want the ARN kept", cluster.AWSClientInformation.ScramSecrets)
+	}
+}
+
 func TestWriteToFileStampsSchemaVersion(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "kcp-state.json")
@@ -1643,7 +1706,7 @@ func TestWriteToFileBacksUpMigratingWrite(t *testing.T) {
 }
 
 func TestNewStateFromBytesLoadsCurrentEraC(t *testing.T) {
-	data := []byte(`{"schema_version":1,"msk_sources":{"regions":[]},"kcp_build_info":{"version":"0.8.5","commit":"x","date":"y"},"timestamp":"2026-01-01T00:00:00Z"}`)
+	data := []byte(`{"schema_version":23,"msk_sources":{"regions":[]},"kcp_build_info":{"version":"0.8.5","commit":"x","date":"y"},"timestamp":"2026-01-01T00:00:00Z"}`)
 	st, err := NewStateFromBytes(data)
 	if err != nil {
 		t.Fatalf("NewStateFromBytes: %v", err)
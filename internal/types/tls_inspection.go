@@ -0,0 +1,76 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// TLSInspection is what `kcp scan clusters --inspect-tls` saw on each broker
+// endpoint of a cluster.
+type TLSInspection struct {
+	InspectedAt time.Time     `json:"inspected_at"`
+	Endpoints   []TLSEndpoint `json:"endpoints"`
+}
+
+// TLSEndpoint is one broker endpoint (host:port) and the certificate chain it
+// presented. Error is set, and the chain empty, when the endpoint did not
+// resolve or did not complete a TLS handshake (e.g. a PLAINTEXT listener).
+type TLSEndpoint struct {
+	Address     string   `json:"address"`
+	ResolvedIPs []string `json:"resolved_ips,omitempty"`
+	TLSVersion  string   `json:"tls_version,omitempty"`
+	// Certificates is the chain as presented, leaf first.
+	Certificates []TLSCertificate `json:"certificates,omitempty"`
+	// PrivateCA is set when the chain does not verify against the system
+	// roots, so clients — jump cluster brokers included — need the issuing CA
+	// in their trust store.
+	PrivateCA bool `json:"private_ca"`
+	// HostnameMismatch is set when the leaf's SANs do not cover the host the
+	// endpoint was reached on.
+	HostnameMismatch bool   `json:"hostname_mismatch,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+type TLSCertificate struct {
+	Subject           string    `json:"subject"`
+	Issuer            string    `json:"issuer"`
+	SerialNumber      string    `json:"serial_number"`
+	NotBefore         time.Time `json:"not_before"`
+	NotAfter          time.Time `json:"not_after"`
+	DNSNames          []string  `json:"dns_names,omitempty"`
+	IPAddresses       []string  `json:"ip_addresses,omitempty"`
+	IsCA              bool      `json:"is_ca"`
+	SHA256Fingerprint string    `json:"sha256_fingerprint"`
+	PEM               string    `json:"pem"`
+}
+
+// PrivateCABundle returns the PEM certificates a client needs to trust the
+// endpoints flagged PrivateCA, deduplicated and concatenated, or "" when no
+// endpoint uses a private CA. Each endpoint contributes the top-most CA
+// certificate it presented; an endpoint that presented no CA certificate
+// (a self-signed leaf, or a server that only sends its leaf) contributes its
+// leaf, which pins that certificate until the real CA is supplied.
+func (t *TLSInspection) PrivateCABundle() string {
+	if t == nil {
+		return ""
+	}
+	seen := map[string]bool{}
+	var bundle strings.Builder
+	for _, e := range t.Endpoints {
+		if !e.PrivateCA || len(e.Certificates) == 0 {
+			continue
+		}
+		anchor := e.Certificates[0]
+		for _, c := range e.Certificates {
+			if c.IsCA {
+				anchor = c
+			}
+		}
+		if seen[anchor.SHA256Fingerprint] {
+			continue
+		}
+		seen[anchor.SHA256Fingerprint] = true
+		bundle.WriteString(anchor.PEM)
+	}
+	return bundle.String()
+}
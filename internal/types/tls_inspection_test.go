@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFrom_KeepsTLSInspectionWhenNotReinspected(t *testing.T) {
+	old := &TLSInspection{Endpoints: []TLSEndpoint{{Address: "b-1:9096"}}}
+
+	info := KafkaAdminClientInformation{ClusterID: "c1"}
+	info.MergeFrom(KafkaAdminClientInformation{ClusterID: "c1", TLSInspection: old})
+	require.Same(t, old, info.TLSInspection)
+
+	fresh := &TLSInspection{}
+	info = KafkaAdminClientInformation{ClusterID: "c1", TLSInspection: fresh}
+	info.MergeFrom(KafkaAdminClientInformation{ClusterID: "c1", TLSInspection: old})
+	require.Same(t, fresh, info.TLSInspection, "a new inspection replaces the old one")
+}
+
+func TestPrivateCABundle(t *testing.T) {
+	leaf := func(fp string) TLSCertificate { return TLSCertificate{SHA256Fingerprint: fp, PEM: "leaf-" + fp + "\n"} }
+	ca := TLSCertificate{SHA256Fingerprint: "ca", IsCA: true, PEM: "ca\n"}
+
+	inspection := &TLSInspection{Endpoints: []TLSEndpoint{
+		{Address: "b-1:9093", PrivateCA: true, Certificates: []TLSCertificate{leaf("1"), ca}},
+		{Address: "b-2:9093", PrivateCA: true, Certificates: []TLSCertificate{leaf("2"), ca}},
+		{Address: "b-3:9093", PrivateCA: true, Certificates: []TLSCertificate{leaf("3")}},
+		{Address: "b-4:9093", Certificates: []TLSCertificate{leaf("4")}},
+		{Address: "b-5:9092", PrivateCA: true, Error: "handshake failed"},
+	}}
+
+	require.Equal(t, "ca\nleaf-3\n", inspection.PrivateCABundle())
+	require.Empty(t, (*TLSInspection)(nil).PrivateCABundle())
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"context"
+	"os/exec"
+)
+
+// CommandRunner runs an external command in dir (the current directory when
+// empty) and returns its output. Tests replace it to avoid running binaries.
+type CommandRunner func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+
+// ExecRunner is the CommandRunner backed by os/exec. It returns stdout; stderr
+// is kept on the returned *exec.ExitError.
+func ExecRunner(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd.Output()
+}
+
+// CombinedExecRunner is ExecRunner returning stdout and stderr interleaved,
+// for commands whose errors are only useful with both.
+func CombinedExecRunner(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
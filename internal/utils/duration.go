@@ -17,3 +17,20 @@ func ParseDurationDays(s string) (time.Duration, error) {
 	}
 	return time.Duration(days) * 24 * time.Hour, nil
 }
+
+// ParseSince parses a --since value relative to now. It accepts an RFC3339
+// timestamp ("2025-06-01T00:00:00Z"), a day count ("7d") or a Go duration
+// ("36h", "90m").
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if d, err := ParseDurationDays(s); err == nil {
+		return now.Add(-d), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("must be an RFC3339 timestamp (e.g. 2025-06-01T00:00:00Z) or a duration (e.g. 24h, 7d)")
+	}
+	return now.Add(-d), nil
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "2025-06-01T00:00:00Z", want: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{input: "36h", want: now.Add(-36 * time.Hour)},
+		{input: "90m", want: now.Add(-90 * time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSince(tt.input, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+
+	for _, invalid := range []string{"", "yesterday", "-24h", "0d", "2025-06-01"} {
+		_, err := ParseSince(invalid, now)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -113,10 +113,19 @@ var terraformOutputGetters = map[string]TerraformOutputGetter{
 	},
 }
 
-// sets flag values from corresponding environment variables if flags weren't explicitly provided
+// sets flag values from corresponding environment variables, then from the
+// selected kcp config profile, if flags weren't explicitly provided, and
+// finally resolves secret flags read from stdin or the OS keychain
 func BindEnvToFlags(cmd *cobra.Command) error {
 	v := viper.New()
 
+	onCommandLine := map[string]bool{}
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed && IsSecretFlag(f) {
+			onCommandLine[f.Name] = true
+		}
+	})
+
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
 		flagName := f.Name
 
@@ -124,7 +133,12 @@ func BindEnvToFlags(cmd *cobra.Command) error {
 		// e.g., "vpc-id" -> "VPC_ID"
 		envVarName := strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
 
-		_ = v.BindEnv(flagName, envVarName)
+		// The standard variable, e.g. KAFKA_API_SECRET, is the fallback.
+		if alias, ok := standardEnvAliases[f.Name]; ok {
+			_ = v.BindEnv(flagName, envVarName, alias)
+		} else {
+			_ = v.BindEnv(flagName, envVarName)
+		}
 
 		// If the flag wasn't explicitly set via command line
 		// AND
@@ -137,5 +151,12 @@ func BindEnvToFlags(cmd *cobra.Command) error {
 		}
 	})
 
-	return nil
+	// Profile values fill whatever the command line and environment left unset.
+	if err := ApplyProfileToFlags(cmd); err != nil {
+		return err
+	}
+
+	// Secrets from stdin and the keychain are resolved last, before any
+	// command builds its clients.
+	return resolveSecretFlags(cmd, onCommandLine)
 }
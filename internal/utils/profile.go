@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	// ProfileFlag selects a named profile from the kcp config file.
+	ProfileFlag = "profile"
+
+	// ProfileConfigEnv overrides the config file location.
+	ProfileConfigEnv = "KCP_CONFIG"
+)
+
+// ProfileConfigPath returns $KCP_CONFIG, or ~/.kcp/config.yaml when unset.
+func ProfileConfigPath() (string, error) {
+	if path := os.Getenv(ProfileConfigEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".kcp", "config.yaml"), nil
+}
+
+// LoadProfile reads the named profile from the config file at path. Profiles
+// map flag names to values:
+//
+//	default_profile: prod
+//	profiles:
+//	  prod:
+//	    region: [us-east-1, eu-west-1]
+//	    output-dir: ./prod
+//
+// An empty name selects default_profile. It returns nil when no profile
+// applies, and an error when a named profile is missing.
+func LoadProfile(path, name string) (map[string]any, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) && name == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read kcp config %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = v.GetString("default_profile")
+		if name == "" {
+			return nil, nil
+		}
+	}
+
+	profiles := v.GetStringMap("profiles")
+	profile, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("profile %q not found in %s (available: %s)", name, path, strings.Join(names, ", "))
+	}
+	values, ok := profile.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("profile %q in %s must be a map of flag names to values", name, path)
+	}
+	return values, nil
+}
+
+// ApplyProfileToFlags sets flags that weren't provided on the command line or
+// via environment variables from the selected profile. names restricts which
+// flags are considered; none means all. Profile keys that name no flag of cmd
+// are ignored, so one profile can serve every command.
+func ApplyProfileToFlags(cmd *cobra.Command, names ...string) error {
+	var profileName string
+	if f := cmd.Flags().Lookup(ProfileFlag); f != nil {
+		profileName = f.Value.String()
+	}
+	if profileName == "" {
+		// The root command reads the profile before BindEnvToFlags has run.
+		profileName = os.Getenv(strings.ToUpper(ProfileFlag))
+	}
+
+	path, err := ProfileConfigPath()
+	if err != nil {
+		if profileName == "" {
+			return nil
+		}
+		return err
+	}
+	values, err := LoadProfile(path, profileName)
+	if err != nil || values == nil {
+		return err
+	}
+
+	var applied []string
+	var setErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if setErr != nil || f.Changed || f.Name == ProfileFlag {
+			return
+		}
+		if len(names) > 0 && !slices.Contains(names, f.Name) {
+			return
+		}
+		value, ok := values[f.Name]
+		if !ok {
+			return
+		}
+		if err := cmd.Flags().Set(f.Name, profileValue(value)); err != nil {
+			setErr = fmt.Errorf("invalid %s in kcp config profile: %w", f.Name, err)
+			return
+		}
+		applied = append(applied, f.Name)
+	})
+	if setErr != nil {
+		return setErr
+	}
+
+	if len(applied) > 0 {
+		slog.Debug("applied kcp config profile", "path", path, "profile", profileName, "flags", applied)
+	}
+	return nil
+}
+
+// profileValue renders a YAML value in the form pflag parses: lists as
+// comma-separated values and maps as comma-separated key=value pairs.
+func profileValue(value any) string {
+	switch v := value.(type) {
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		parts := make([]string, 0, len(v))
+		for key, item := range v {
+			parts = append(parts, fmt.Sprintf("%s=%v", key, item))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const testProfileConfig = `default_profile: dev
+profiles:
+  dev:
+    region: [us-east-1, eu-west-1]
+    output-dir: ./dev
+  prod:
+    region: us-west-2
+    output-dir: ./prod
+    tags:
+      team: payments
+      env: prod
+    max-connections: 5
+`
+
+func writeProfileConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv(ProfileConfigEnv, path)
+	t.Setenv("PROFILE", "")
+	return path
+}
+
+type profileTestFlags struct {
+	regions        []string
+	outputDir      string
+	tags           map[string]string
+	maxConnections int
+}
+
+func newProfileTestCmd(flags *profileTestFlags) *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String(ProfileFlag, "", "")
+	cmd.Flags().StringSliceVar(&flags.regions, "region", nil, "")
+	cmd.Flags().StringVar(&flags.outputDir, "output-dir", ".", "")
+	cmd.Flags().StringToStringVar(&flags.tags, "tags", nil, "")
+	cmd.Flags().IntVar(&flags.maxConnections, "max-connections", 1, "")
+	return cmd
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := writeProfileConfig(t, testProfileConfig)
+
+	values, err := LoadProfile(path, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if values["output-dir"] != "./dev" {
+		t.Fatalf("expected default_profile dev, got %v", values)
+	}
+
+	_, err = LoadProfile(path, "staging")
+	if err == nil || !strings.Contains(err.Error(), `profile "staging" not found`) || !strings.Contains(err.Error(), "dev, prod") {
+		t.Fatalf("expected not-found error listing profiles, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if values, err := LoadProfile(missing, ""); err != nil || values != nil {
+		t.Fatalf("a missing config without --profile should be ignored, got %v, %v", values, err)
+	}
+	if _, err := LoadProfile(missing, "prod"); err == nil {
+		t.Fatal("a missing config with --profile should error")
+	}
+}
+
+func TestBindEnvToFlags_AppliesDefaultProfile(t *testing.T) {
+	writeProfileConfig(t, testProfileConfig)
+
+	var flags profileTestFlags
+	cmd := newProfileTestCmd(&flags)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := BindEnvToFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(flags.regions, ",") != "us-east-1,eu-west-1" {
+		t.Errorf("expected profile regions, got %v", flags.regions)
+	}
+	if flags.outputDir != "./dev" {
+		t.Errorf("expected profile output-dir, got %q", flags.outputDir)
+	}
+}
+
+func TestBindEnvToFlags_FlagAndEnvBeatProfile(t *testing.T) {
+	writeProfileConfig(t, testProfileConfig)
+	t.Setenv("OUTPUT_DIR", "./from-env")
+
+	var flags profileTestFlags
+	cmd := newProfileTestCmd(&flags)
+	if err := cmd.ParseFlags([]string{"--profile", "prod", "--max-connections", "9"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := BindEnvToFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if flags.outputDir != "./from-env" {
+		t.Errorf("environment should win over the profile, got %q", flags.outputDir)
+	}
+	if flags.maxConnections != 9 {
+		t.Errorf("command line should win over the profile, got %d", flags.maxConnections)
+	}
+	if strings.Join(flags.regions, ",") != "us-west-2" {
+		t.Errorf("expected prod regions, got %v", flags.regions)
+	}
+	if flags.tags["team"] != "payments" || flags.tags["env"] != "prod" {
+		t.Errorf("expected prod tags, got %v", flags.tags)
+	}
+}
+
+func TestApplyProfileToFlags_RestrictsToNames(t *testing.T) {
+	writeProfileConfig(t, testProfileConfig)
+
+	var flags profileTestFlags
+	cmd := newProfileTestCmd(&flags)
+	if err := cmd.ParseFlags([]string{"--profile", "prod"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := ApplyProfileToFlags(cmd, "max-connections"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if flags.maxConnections != 5 {
+		t.Errorf("expected max-connections from profile, got %d", flags.maxConnections)
+	}
+	if flags.outputDir != "." {
+		t.Errorf("output-dir should be left for a later pass, got %q", flags.outputDir)
+	}
+}
+
+func TestApplyProfileToFlags_InvalidValue(t *testing.T) {
+	writeProfileConfig(t, "profiles:\n  bad:\n    max-connections: lots\n")
+
+	var flags profileTestFlags
+	cmd := newProfileTestCmd(&flags)
+	if err := cmd.ParseFlags([]string{"--profile", "bad"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	err := ApplyProfileToFlags(cmd)
+	if err == nil || !strings.Contains(err.Error(), "invalid max-connections") {
+		t.Fatalf("expected invalid value error, got %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+const (
+	// SecretStdinSuffix names the flag that reads a secret flag's value from
+	// stdin instead of the command line, e.g. --cluster-api-secret-stdin.
+	SecretStdinSuffix = "-stdin"
+
+	// KeychainPrefix marks a secret value as a reference to an OS keychain
+	// entry, keychain:<service>/<account>, looked up before the command runs.
+	KeychainPrefix = "keychain:"
+)
+
+// standardEnvAliases are the variables other Confluent tools (the confluent
+// CLI, the Terraform provider) read the same credentials from. They are
+// consulted when the flag's own variable (e.g. CLUSTER_API_SECRET) is unset.
+var standardEnvAliases = map[string]string{
+	"cc-api-key":         "CONFLUENT_CLOUD_API_KEY",
+	"cc-api-secret":      "CONFLUENT_CLOUD_API_SECRET",
+	"cluster-api-key":    "KAFKA_API_KEY",
+	"cluster-api-secret": "KAFKA_API_SECRET",
+}
+
+// secretStdin is where --<flag>-stdin reads from; replaced in tests.
+var secretStdin io.Reader = os.Stdin
+
+// keychainLookup reads a generic password from the OS keychain: the macOS
+// login keychain through `security`, or the Secret Service (GNOME Keyring,
+// KWallet) through `secret-tool` on Linux. Replaced in tests.
+var keychainLookup = func(service, account string) (string, error) {
+	var lookup *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		lookup = exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		lookup = exec.Command("secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("keychain lookup is not supported on %s: use --<flag>-stdin or an environment variable", runtime.GOOS)
+	}
+	out, err := lookup.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.Join(lookup.Args[:2], " "), err)
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("no keychain entry for service %q and account %q", service, account)
+	}
+	return secret, nil
+}
+
+// IsSecretFlag reports whether a flag carries a credential: a string flag
+// whose name ends in "secret" or "password", e.g. --cluster-api-secret or
+// --sasl-scram-password.
+func IsSecretFlag(f *pflag.Flag) bool {
+	return f.Value.Type() == "string" && (strings.HasSuffix(f.Name, "secret") || strings.HasSuffix(f.Name, "password"))
+}
+
+// AddSecretStdinFlag adds the --<name>-stdin companion of secret flag name to
+// fs. Commands that print their flags in groups call it right after defining
+// the secret flag, so the companion is listed next to it.
+func AddSecretStdinFlag(fs *pflag.FlagSet, name string) {
+	fs.Bool(name+SecretStdinSuffix, false, fmt.Sprintf("Read --%s from stdin (prompted without echo on a terminal).", name))
+}
+
+// RegisterSecretFlagSources adds a --<flag>-stdin companion to every secret
+// flag of cmd and its subcommands that lacks one, and notes the safer sources
+// in the flag's help. Call it once the command tree is built; the values are
+// resolved by BindEnvToFlags.
+func RegisterSecretFlagSources(cmd *cobra.Command) {
+	var secrets []*pflag.Flag
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if IsSecretFlag(f) {
+			secrets = append(secrets, f)
+		}
+	})
+	for _, f := range secrets {
+		if cmd.Flags().Lookup(f.Name+SecretStdinSuffix) == nil {
+			AddSecretStdinFlag(cmd.Flags(), f.Name)
+		}
+		note := fmt.Sprintf(" Prefer --%s%s, ", f.Name, SecretStdinSuffix)
+		if strings.Contains(f.Usage, note) {
+			continue
+		}
+		envVarName := strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if alias, ok := standardEnvAliases[f.Name]; ok {
+			envVarName += " (or " + alias + ")"
+		}
+		if !strings.HasSuffix(f.Usage, ".") {
+			f.Usage += "."
+		}
+		f.Usage += fmt.Sprintf("%s%s or a %s<service>/<account> reference: values on the command line end up in shell history and process lists.", note, envVarName, KeychainPrefix)
+	}
+
+	for _, sub := range cmd.Commands() {
+		RegisterSecretFlagSources(sub)
+	}
+}
+
+// resolveSecretFlags fills secret flags from their --<flag>-stdin companion
+// and replaces keychain references with the stored secret. onCommandLine
+// lists the secret flags given a value on the command line, which are warned
+// about.
+func resolveSecretFlags(cmd *cobra.Command, onCommandLine map[string]bool) error {
+	var fromStdin []string
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if companion := cmd.Flags().Lookup(f.Name + SecretStdinSuffix); IsSecretFlag(f) && companion != nil && companion.Value.String() == "true" {
+			fromStdin = append(fromStdin, f.Name)
+		}
+	})
+	if len(fromStdin) > 1 {
+		return fmt.Errorf("only one secret can be read from stdin, got --%s", strings.Join(fromStdin, SecretStdinSuffix+", --")+SecretStdinSuffix)
+	}
+	for _, name := range fromStdin {
+		if onCommandLine[name] {
+			return fmt.Errorf("--%s and --%s%s are mutually exclusive", name, name, SecretStdinSuffix)
+		}
+		secret, err := readSecret(name)
+		if err != nil {
+			return err
+		}
+		if err := cmd.Flags().Set(name, secret); err != nil {
+			return err
+		}
+	}
+
+	var resolveErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if resolveErr != nil || !IsSecretFlag(f) {
+			return
+		}
+		reference, ok := strings.CutPrefix(f.Value.String(), KeychainPrefix)
+		if !ok {
+			if onCommandLine[f.Name] {
+				slog.Warn("⚠️ secret given on the command line, where shell history and process lists can expose it", "flag", "--"+f.Name, "instead", fmt.Sprintf("--%s%s, an environment variable or a %s<service>/<account> reference", f.Name, SecretStdinSuffix, KeychainPrefix))
+			}
+			return
+		}
+		service, account, found := strings.Cut(reference, "/")
+		if !found || service == "" || account == "" {
+			resolveErr = fmt.Errorf("invalid --%s keychain reference %q: expected %s<service>/<account>", f.Name, f.Value.String(), KeychainPrefix)
+			return
+		}
+		secret, err := keychainLookup(service, account)
+		if err != nil {
+			resolveErr = fmt.Errorf("failed to read --%s from the keychain: %w", f.Name, err)
+			return
+		}
+		resolveErr = cmd.Flags().Set(f.Name, secret)
+	})
+	return resolveErr
+}
+
+// readSecret reads one secret from stdin: prompted without echo when stdin
+// is a terminal, otherwise the first line of the piped input.
+func readSecret(name string) (string, error) {
+	if file, ok := secretStdin.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		fmt.Fprintf(os.Stderr, "Enter --%s: ", name)
+		secret, err := term.ReadPassword(int(file.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read --%s from the terminal: %w", name, err)
+		}
+		return strings.TrimRight(string(secret), "\r\n"), nil
+	}
+
+	line, err := bufio.NewReader(secretStdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read --%s from stdin: %w", name, err)
+	}
+	secret := strings.TrimRight(line, "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("--%s%s: stdin was empty", name, SecretStdinSuffix)
+	}
+	return secret, nil
+}
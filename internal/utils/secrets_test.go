@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// newSecretTestCmd builds a command with one secret flag, registered the way
+// the root command registers them, and no kcp config profile.
+func newSecretTestCmd(t *testing.T, secret *string) *cobra.Command {
+	t.Helper()
+	t.Setenv(ProfileConfigEnv, filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv("PROFILE", "")
+	t.Setenv("CLUSTER_API_SECRET", "")
+	t.Setenv("KAFKA_API_SECRET", "")
+
+	root := &cobra.Command{Use: "kcp"}
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(secret, "cluster-api-secret", "", "API secret.")
+	cmd.Flags().Bool("scan-secrets", false, "")
+	root.AddCommand(cmd)
+	RegisterSecretFlagSources(root)
+	return cmd
+}
+
+func TestRegisterSecretFlagSources(t *testing.T) {
+	var secret string
+	cmd := newSecretTestCmd(t, &secret)
+
+	if cmd.Flags().Lookup("cluster-api-secret-stdin") == nil {
+		t.Fatal("expected --cluster-api-secret-stdin to be registered")
+	}
+	if cmd.Flags().Lookup("scan-secrets-stdin") != nil {
+		t.Error("bool flags are not secrets")
+	}
+	if usage := cmd.Flags().Lookup("cluster-api-secret").Usage; !strings.Contains(usage, "KAFKA_API_SECRET") {
+		t.Errorf("expected the usage to name the standard variable, got %q", usage)
+	}
+}
+
+func TestRegisterSecretFlagSources_KeepsGroupedCompanion(t *testing.T) {
+	var secret string
+	group := pflag.NewFlagSet("target", pflag.ContinueOnError)
+	group.StringVar(&secret, "cluster-api-secret", "", "API secret.")
+	AddSecretStdinFlag(group, "cluster-api-secret")
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().AddFlagSet(group)
+
+	RegisterSecretFlagSources(cmd)
+	RegisterSecretFlagSources(cmd)
+
+	if !strings.Contains(group.FlagUsages(), "--cluster-api-secret-stdin") {
+		t.Error("expected the companion in the secret flag's group")
+	}
+	if usage := cmd.Flags().Lookup("cluster-api-secret").Usage; strings.Count(usage, "Prefer --cluster-api-secret-stdin") != 1 {
+		t.Errorf("expected the sources noted once, got %q", usage)
+	}
+}
+
+func TestBindEnvToFlags_SecretFromStdin(t *testing.T) {
+	var secret string
+	cmd := newSecretTestCmd(t, &secret)
+	original := secretStdin
+	t.Cleanup(func() { secretStdin = original })
+	secretStdin = strings.NewReader("s3cr3t\nignored\n")
+
+	if err := cmd.ParseFlags([]string{"--cluster-api-secret-stdin"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := BindEnvToFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret != "s3cr3t" {
+		t.Errorf("expected the first line of stdin, got %q", secret)
+	}
+}
+
+func TestBindEnvToFlags_SecretStdinConflictsWithFlag(t *testing.T) {
+	var secret string
+	cmd := newSecretTestCmd(t, &secret)
+	original := secretStdin
+	t.Cleanup(func() { secretStdin = original })
+	secretStdin = strings.NewReader("s3cr3t\n")
+
+	if err := cmd.ParseFlags([]string{"--cluster-api-secret", "x", "--cluster-api-secret-stdin"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := BindEnvToFlags(cmd); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected a mutually exclusive error, got %v", err)
+	}
+}
+
+func TestBindEnvToFlags_SecretFromStandardEnv(t *testing.T) {
+	var secret string
+	cmd := newSecretTestCmd(t, &secret)
+	t.Setenv("KAFKA_API_SECRET", "from-standard")
+
+	if err := BindEnvToFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret != "from-standard" {
+		t.Errorf("expected KAFKA_API_SECRET, got %q", secret)
+	}
+
+	cmd = newSecretTestCmd(t, &secret)
+	t.Setenv("CLUSTER_API_SECRET", "from-flag-env")
+	t.Setenv("KAFKA_API_SECRET", "from-standard")
+	if err := BindEnvToFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret != "from-flag-env" {
+		t.Errorf("expected CLUSTER_API_SECRET to take precedence, got %q", secret)
+	}
+}
+
+func TestBindEnvToFlags_SecretFromKeychain(t *testing.T) {
+	original := keychainLookup
+	t.Cleanup(func() { keychainLookup = original })
+	keychainLookup = func(service, account string) (string, error) {
+		if service == "kcp" && account == "prod-link" {
+			return "from-keychain", nil
+		}
+		return "", fmt.Errorf("not found")
+	}
+
+	var secret string
+	cmd := newSecretTestCmd(t, &secret)
+	t.Setenv("CLUSTER_API_SECRET", "keychain:kcp/prod-link")
+	if err := BindEnvToFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret != "from-keychain" {
+		t.Errorf("expected the keychain secret, got %q", secret)
+	}
+
+	cmd = newSecretTestCmd(t, &secret)
+	if err := cmd.ParseFlags([]string{"--cluster-api-secret", "keychain:kcp"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := BindEnvToFlags(cmd); err == nil || !strings.Contains(err.Error(), "invalid --cluster-api-secret keychain reference") {
+		t.Errorf("expected an invalid reference error, got %v", err)
+	}
+}
@@ -3,11 +3,11 @@ package utils
 import (
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/confluentinc/kcp/internal/services/hcl/hcltypes"
+	"github.com/confluentinc/kcp/internal/summary"
 )
 
 // CleanPrincipalName cleans the principal name for use in Terraform resources
@@ -27,35 +27,42 @@ func CleanPrincipalName(principal string) string {
 // WriteTerraformFiles writes the generated Terraform files to the output directory
 func WriteTerraformFiles(outputDir string, files hcltypes.TerraformFiles) error {
 	if files.MainTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(files.MainTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "main.tf"), []byte(files.MainTf), 0644); err != nil {
 			return fmt.Errorf("failed to write main.tf: %w", err)
 		}
 		slog.Info("wrote main.tf")
 	}
 
 	for fileName, content := range files.PerPrincipalTf {
-		if err := os.WriteFile(filepath.Join(outputDir, fileName), []byte(content), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, fileName), []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write %s: %w", fileName, err)
 		}
 		slog.Info("wrote per-principal file", "file", fileName)
 	}
 
 	if files.ProvidersTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(files.ProvidersTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "providers.tf"), []byte(files.ProvidersTf), 0644); err != nil {
 			return fmt.Errorf("failed to write providers.tf: %w", err)
 		}
 		slog.Info("wrote providers.tf")
 	}
 
 	if files.VariablesTf != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(files.VariablesTf), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "variables.tf"), []byte(files.VariablesTf), 0644); err != nil {
 			return fmt.Errorf("failed to write variables.tf: %w", err)
 		}
 		slog.Info("wrote variables.tf")
 	}
 
+	if files.OutputsTf != "" {
+		if err := summary.WriteFile(filepath.Join(outputDir, "outputs.tf"), []byte(files.OutputsTf), 0644); err != nil {
+			return fmt.Errorf("failed to write outputs.tf: %w", err)
+		}
+		slog.Info("wrote outputs.tf")
+	}
+
 	if files.InputsAutoTfvars != "" {
-		if err := os.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(files.InputsAutoTfvars), 0644); err != nil {
+		if err := summary.WriteFile(filepath.Join(outputDir, "inputs.auto.tfvars"), []byte(files.InputsAutoTfvars), 0644); err != nil {
 			return fmt.Errorf("failed to write inputs.auto.tfvars: %w", err)
 		}
 		slog.Info("wrote inputs.auto.tfvars")
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/spf13/pflag"
+)
+
+// TerraformBackendFlags holds the --tf-backend* flags of the commands that
+// generate a root Terraform project, so the generated providers.tf can keep
+// its state remotely instead of being hand-edited after every generation.
+type TerraformBackendFlags struct {
+	Backend       string
+	Bucket        string
+	Key           string
+	Region        string
+	DynamoDBTable string
+	Organization  string
+	Workspace     string
+}
+
+// FlagSet returns the --tf-backend* flags bound to f. defaultKey is the S3
+// object key used when --tf-backend-key is not set, so projects generated by
+// different commands do not share a state file.
+func (f *TerraformBackendFlags) FlagSet(defaultKey string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet("tf-backend", pflag.ExitOnError)
+	fs.SortFlags = false
+	fs.StringVar(&f.Backend, "tf-backend", "", "[Optional] Remote state backend written into providers.tf: 's3' or 'cloud' (Terraform Cloud / HCP Terraform). (default: local state)")
+	fs.StringVar(&f.Bucket, "tf-backend-bucket", "", "The S3 bucket holding the Terraform state. (required for 's3')")
+	fs.StringVar(&f.Key, "tf-backend-key", defaultKey, "The S3 object key of the Terraform state.")
+	fs.StringVar(&f.Region, "tf-backend-region", "", "The AWS region of the S3 state bucket. (required for 's3')")
+	fs.StringVar(&f.DynamoDBTable, "tf-backend-dynamodb-table", "", "[Optional] The DynamoDB table used to lock the S3 state. Without it the state is not locked.")
+	fs.StringVar(&f.Organization, "tf-backend-organization", "", "The Terraform Cloud organization. (required for 'cloud')")
+	fs.StringVar(&f.Workspace, "tf-backend-workspace", "", "The Terraform Cloud workspace. (required for 'cloud')")
+	return fs
+}
+
+// TerraformBackend validates the flags and returns the backend they select,
+// or nil when --tf-backend is not set.
+func (f *TerraformBackendFlags) TerraformBackend() (*hclrequests.TerraformBackend, error) {
+	switch f.Backend {
+	case "":
+		if f.Bucket != "" || f.Region != "" || f.DynamoDBTable != "" || f.Organization != "" || f.Workspace != "" {
+			return nil, fmt.Errorf("--tf-backend-* flags require --tf-backend")
+		}
+		return nil, nil
+
+	case hclrequests.TerraformBackendS3:
+		if f.Bucket == "" || f.Region == "" {
+			return nil, fmt.Errorf("--tf-backend s3 requires --tf-backend-bucket and --tf-backend-region")
+		}
+		if f.Key == "" {
+			return nil, fmt.Errorf("--tf-backend-key must not be empty")
+		}
+		if f.Organization != "" || f.Workspace != "" {
+			return nil, fmt.Errorf("--tf-backend-organization and --tf-backend-workspace only apply to --tf-backend cloud")
+		}
+		return &hclrequests.TerraformBackend{
+			Type:          hclrequests.TerraformBackendS3,
+			Bucket:        f.Bucket,
+			Key:           f.Key,
+			Region:        f.Region,
+			DynamoDBTable: f.DynamoDBTable,
+		}, nil
+
+	case hclrequests.TerraformBackendCloud:
+		if f.Organization == "" || f.Workspace == "" {
+			return nil, fmt.Errorf("--tf-backend cloud requires --tf-backend-organization and --tf-backend-workspace")
+		}
+		if f.Bucket != "" || f.Region != "" || f.DynamoDBTable != "" {
+			return nil, fmt.Errorf("--tf-backend-bucket, --tf-backend-region and --tf-backend-dynamodb-table only apply to --tf-backend s3")
+		}
+		return &hclrequests.TerraformBackend{
+			Type:         hclrequests.TerraformBackendCloud,
+			Organization: f.Organization,
+			Workspace:    f.Workspace,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("invalid --tf-backend '%s': must be '%s' or '%s'", f.Backend, hclrequests.TerraformBackendS3, hclrequests.TerraformBackendCloud)
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/confluentinc/kcp/internal/services/hcl/hclrequests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformBackendFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    *hclrequests.TerraformBackend
+		wantErr string
+	}{
+		{name: "local state by default"},
+		{
+			name: "s3 with default key",
+			args: []string{"--tf-backend", "s3", "--tf-backend-bucket", "state", "--tf-backend-region", "us-east-1", "--tf-backend-dynamodb-table", "locks"},
+			want: &hclrequests.TerraformBackend{Type: "s3", Bucket: "state", Key: "kcp/test/terraform.tfstate", Region: "us-east-1", DynamoDBTable: "locks"},
+		},
+		{
+			name: "cloud",
+			args: []string{"--tf-backend", "cloud", "--tf-backend-organization", "acme", "--tf-backend-workspace", "kcp"},
+			want: &hclrequests.TerraformBackend{Type: "cloud", Organization: "acme", Workspace: "kcp"},
+		},
+		{name: "s3 without bucket", args: []string{"--tf-backend", "s3", "--tf-backend-region", "us-east-1"}, wantErr: "requires --tf-backend-bucket"},
+		{name: "cloud without workspace", args: []string{"--tf-backend", "cloud", "--tf-backend-organization", "acme"}, wantErr: "requires --tf-backend-organization and --tf-backend-workspace"},
+		{name: "s3 flags on cloud", args: []string{"--tf-backend", "cloud", "--tf-backend-organization", "acme", "--tf-backend-workspace", "kcp", "--tf-backend-bucket", "state"}, wantErr: "only apply to --tf-backend s3"},
+		{name: "settings without backend", args: []string{"--tf-backend-bucket", "state"}, wantErr: "require --tf-backend"},
+		{name: "unknown backend", args: []string{"--tf-backend", "gcs"}, wantErr: "invalid --tf-backend 'gcs'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var flags TerraformBackendFlags
+			require.NoError(t, flags.FlagSet("kcp/test/terraform.tfstate").Parse(tt.args))
+
+			backend, err := flags.TerraformBackend()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, backend)
+		})
+	}
+}
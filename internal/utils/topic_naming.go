@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/confluentinc/kcp/internal/services/topicnaming"
+	"github.com/spf13/pflag"
+)
+
+// TopicNamingFlags holds the topic renaming flags shared by the commands that
+// name topics on Confluent Cloud (migrate-topics, migration-infra, the ACL
+// migrations and client-playbooks), so one set of flags renames a topic the
+// same way in every generated artifact.
+type TopicNamingFlags struct {
+	Prefix     string
+	Renames    map[string]string
+	RenameFile string
+	Rules      []string
+	Policy     string
+}
+
+// FlagSet returns the topic naming flags bound to f.
+func (f *TopicNamingFlags) FlagSet() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("topic-naming", pflag.ExitOnError)
+	fs.SortFlags = false
+	fs.StringVar(&f.Prefix, "topic-prefix", "", "[Optional] Prefix added to every destination topic name not renamed otherwise.")
+	fs.StringToStringVar(&f.Renames, "topic-rename", map[string]string{}, "[Optional] Explicit destination names as source=destination pairs (comma separated or repeated flag). Takes precedence over the rules and the prefix.")
+	fs.StringVar(&f.RenameFile, "topic-rename-file", "", "[Optional] A CSV file of source,destination rows of explicit destination names, merged with --topic-rename.")
+	fs.StringArrayVar(&f.Rules, "topic-rename-rule", []string{}, "[Optional] A PATTERN=REPLACEMENT rule renaming every topic whose whole name matches the regular expression, e.g. 'legacy\\.(.*)=orders.$1'. Can be repeated; the first matching rule wins.")
+	fs.StringVar(&f.Policy, "topic-name-policy", "", "[Optional] A regular expression every destination topic name must fully match, e.g. '^[a-z]+\\.[a-z0-9-]+$'. Generation fails on any violation.")
+	return fs
+}
+
+// Mapping parses the flags into a topic naming mapping. The mapping is
+// validated against the source topics by the caller, once they are known.
+func (f *TopicNamingFlags) Mapping() (topicnaming.Mapping, error) {
+	mapping := topicnaming.Mapping{Prefix: f.Prefix, Renames: make(map[string]string)}
+
+	if f.RenameFile != "" {
+		renames, err := topicnaming.LoadRenamesCSV(f.RenameFile)
+		if err != nil {
+			return topicnaming.Mapping{}, fmt.Errorf("invalid --topic-rename-file: %w", err)
+		}
+		mapping.Renames = renames
+	}
+	for source, destination := range f.Renames {
+		if destination == "" {
+			return topicnaming.Mapping{}, fmt.Errorf("--topic-rename %s= has an empty destination name", source)
+		}
+		if previous, exists := mapping.Renames[source]; exists && previous != destination {
+			return topicnaming.Mapping{}, fmt.Errorf("--topic-rename %s=%s conflicts with --topic-rename-file, which renames it to %q", source, destination, previous)
+		}
+		mapping.Renames[source] = destination
+	}
+
+	for _, rule := range f.Rules {
+		parsed, err := topicnaming.ParseRule(rule)
+		if err != nil {
+			return topicnaming.Mapping{}, fmt.Errorf("invalid --topic-rename-rule: %w", err)
+		}
+		mapping.Rules = append(mapping.Rules, parsed)
+	}
+
+	if f.Policy != "" {
+		policy, err := regexp.Compile("^(?:" + f.Policy + ")$")
+		if err != nil {
+			return topicnaming.Mapping{}, fmt.Errorf("invalid --topic-name-policy: %w", err)
+		}
+		mapping.Policy = policy
+	}
+
+	return mapping, nil
+}
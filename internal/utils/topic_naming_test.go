@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicNamingFlags(t *testing.T) {
+	renameFile := filepath.Join(t.TempDir(), "renames.csv")
+	require.NoError(t, os.WriteFile(renameFile, []byte("source,destination\n# retired\nlegacy-orders,orders\n"), 0644))
+
+	tests := []struct {
+		name    string
+		args    []string
+		source  string
+		want    string
+		wantErr string
+	}{
+		{name: "identity by default", source: "orders", want: "orders"},
+		{name: "prefix", args: []string{"--topic-prefix", "msk."}, source: "orders", want: "msk.orders"},
+		{name: "rule", args: []string{"--topic-prefix", "msk.", "--topic-rename-rule", `legacy-(.*)=$1.v1`}, source: "legacy-payments", want: "payments.v1"},
+		{name: "file", args: []string{"--topic-rename-file", renameFile}, source: "legacy-orders", want: "orders"},
+		{name: "flag agrees with file", args: []string{"--topic-rename-file", renameFile, "--topic-rename", "legacy-orders=orders"}, source: "legacy-orders", want: "orders"},
+		{name: "flag conflicts with file", args: []string{"--topic-rename-file", renameFile, "--topic-rename", "legacy-orders=orders-v2"}, wantErr: "conflicts with --topic-rename-file"},
+		{name: "empty destination", args: []string{"--topic-rename", "orders="}, wantErr: "empty destination name"},
+		{name: "invalid rule", args: []string{"--topic-rename-rule", "orders"}, wantErr: "expected PATTERN=REPLACEMENT"},
+		{name: "invalid policy", args: []string{"--topic-name-policy", "("}, wantErr: "invalid --topic-name-policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var flags TopicNamingFlags
+			require.NoError(t, flags.FlagSet().Parse(tt.args))
+
+			mapping, err := flags.Mapping()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mapping.Destination(tt.source))
+		})
+	}
+}
@@ -14,7 +14,12 @@ func main() {
 }
 
 func run() error {
-	if err := cmd.RootCmd.Execute(); err != nil {
+	err := cmd.RootCmd.Execute()
+	cmd.FinishCancellation()
+	cmd.FinishTracing(err)
+	cmd.FinishAudit()
+	cmd.FinishSummary(err)
+	if err != nil {
 		slog.Error(err.Error())
 		return err
 	}
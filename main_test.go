@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"log/slog"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/confluentinc/kcp/cmd"
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,3 +73,58 @@ func TestCommandErrorNotDuplicated(t *testing.T) {
 		"error message should appear exactly once in combined output, but appeared %d times.\nError text: %q\nCombined output:\n%s",
 		count, errText, combined)
 }
+
+func TestJSONSummaryIsTheOnlyStdout(t *testing.T) {
+	oldStdout := os.Stdout
+	oldStderr := os.Stderr
+	oldColorOutput := color.Output
+	oldLogger := slog.Default()
+	defer func() {
+		os.Stdout = oldStdout
+		os.Stderr = oldStderr
+		color.Output = oldColorOutput
+		slog.SetDefault(oldLogger)
+		cmd.RootCmd.SetArgs(nil)
+		_ = cmd.RootCmd.PersistentFlags().Set("json-summary", "false")
+	}()
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(origDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	rOut, wOut, err := os.Pipe()
+	require.NoError(t, err)
+	rErr, wErr, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = wOut
+	os.Stderr = wErr
+
+	cmd.RootCmd.SetArgs([]string{
+		"scan", "clusters",
+		"--source-type", "invalid",
+		"--credentials-file", "dummy.yaml",
+		"--json-summary",
+	})
+	runErr := run()
+
+	require.NoError(t, wOut.Close())
+	require.NoError(t, wErr.Close())
+	var stdoutBuf, stderrBuf bytes.Buffer
+	_, _ = io.Copy(&stdoutBuf, rOut)
+	_, _ = io.Copy(&stderrBuf, rErr)
+
+	require.Error(t, runErr)
+	var result struct {
+		Command      string   `json:"command"`
+		Status       string   `json:"status"`
+		FilesWritten []string `json:"files_written"`
+		Errors       []string `json:"errors"`
+	}
+	require.NoErrorf(t, json.Unmarshal(stdoutBuf.Bytes(), &result), "stdout must hold only the summary:\n%s", stdoutBuf.String())
+	assert.Equal(t, "kcp scan clusters", result.Command)
+	assert.Equal(t, "error", result.Status)
+	assert.NotNil(t, result.FilesWritten)
+	assert.Contains(t, result.Errors, runErr.Error())
+	assert.Contains(t, stderrBuf.String(), "Executing kcp with build", "the banner moves to stderr")
+}
@@ -0,0 +1,98 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/confluentinc/kcp/cmd/discover"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// DiscoverOptions mirrors the `kcp discover` flags. The zero value runs a
+// full discover of every cluster in the given regions at 1d metrics
+// granularity.
+type DiscoverOptions struct {
+	SkipCosts   bool
+	SkipMetrics bool
+	SkipTopics  bool
+	// MetricsGranularity is one of 60s, 5m, 1h or 1d; empty means 1d.
+	MetricsGranularity string
+	// ClusterARNs discovers only these clusters, leaving the rest of the
+	// state untouched. Each must be in one of the regions passed to Discover.
+	ClusterARNs []string
+	// ScanSecrets also records the Secrets Manager secrets that hold Kafka
+	// credentials in each region.
+	ScanSecrets bool
+}
+
+// DiscoverResult is the output of Discover.
+type DiscoverResult struct {
+	State       []byte // kcp-state.json, as JSON
+	Credentials []byte // msk-credentials.yaml for the regions discovered, as YAML
+}
+
+// Discover finds the MSK clusters in regions with the ambient AWS
+// credentials and merges them into stateJSON, the library equivalent of
+// `kcp discover`. Pass nil stateJSON to start a new state. The returned
+// State is what Scanner.Scan expects for SourceTypeMSK, and Credentials is
+// the msk-credentials.yaml to write out and pass to NewScanner.
+//
+// Discover prints the same progress output as the CLI. Once ctx is
+// cancelled it stops before the next region or cluster and returns what it
+// finished together with the interruption error.
+func Discover(ctx context.Context, regions []string, stateJSON []byte, opts DiscoverOptions) (*DiscoverResult, error) {
+	if len(regions) == 0 {
+		return nil, errors.New("at least one region is required")
+	}
+	granularity := opts.MetricsGranularity
+	switch granularity {
+	case "":
+		granularity = "1d"
+	case "60s", "5m", "1h", "1d":
+	default:
+		return nil, fmt.Errorf("invalid metrics granularity %q: must be one of: 60s, 5m, 1h, 1d", granularity)
+	}
+
+	var state *types.State
+	if len(stateJSON) > 0 {
+		parsed, err := types.NewStateFromBytes(stateJSON)
+		if err != nil {
+			return nil, fmt.Errorf("parse state: %w", err)
+		}
+		state = parsed
+	}
+
+	// The Discoverer writes its state, credentials and checkpoint files to
+	// an output directory; keep them out of the caller's working directory.
+	outputDir, err := os.MkdirTemp("", "kcp-discover-")
+	if err != nil {
+		return nil, fmt.Errorf("create discover output directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(outputDir) }()
+
+	runErr := discover.NewDiscoverer(discover.DiscovererOpts{
+		Regions:            regions,
+		SkipCosts:          opts.SkipCosts,
+		SkipMetrics:        opts.SkipMetrics,
+		SkipTopics:         opts.SkipTopics,
+		State:              state,
+		MetricsGranularity: granularity,
+		ClusterArns:        opts.ClusterARNs,
+		ScanSecrets:        opts.ScanSecrets,
+		OutputDir:          outputDir,
+	}).Run(ctx)
+
+	// The Discoverer logs rather than returns a failure to write its files,
+	// so a missing file is the only sign of one.
+	discoveredState, err := os.ReadFile(discover.StatePath(outputDir))
+	if err != nil {
+		return nil, errors.Join(runErr, fmt.Errorf("read discovered state: %w", err))
+	}
+	credentials, err := os.ReadFile(discover.CredentialsPath(outputDir))
+	if err != nil {
+		return nil, errors.Join(runErr, fmt.Errorf("read discovered credentials: %w", err))
+	}
+	return &DiscoverResult{State: discoveredState, Credentials: credentials}, runErr
+}
@@ -0,0 +1,53 @@
+package lib_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/kcp/pkg/lib"
+)
+
+func TestDiscover_RejectsInvalidOptions(t *testing.T) {
+	for name, call := range map[string]func() error{
+		"no regions": func() error {
+			_, err := lib.Discover(context.Background(), nil, nil, lib.DiscoverOptions{})
+			return err
+		},
+		"invalid granularity": func() error {
+			_, err := lib.Discover(context.Background(), []string{"us-east-1"}, nil, lib.DiscoverOptions{MetricsGranularity: "10m"})
+			return err
+		},
+		"invalid state": func() error {
+			_, err := lib.Discover(context.Background(), []string{"us-east-1"}, []byte("not json"), lib.DiscoverOptions{})
+			return err
+		},
+	} {
+		if err := call(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// A cancelled context stops Discover before it calls AWS, so this exercises
+// the façade without credentials: the previous state comes back alongside
+// the interruption error.
+func TestDiscover_InterruptedReturnsStateSoFar(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := lib.Discover(ctx, []string{"us-east-1"}, []byte(sampleStateJSON), lib.DiscoverOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Discover error = %v, want context.Canceled", err)
+	}
+	if result == nil {
+		t.Fatal("Discover returned no result with the interruption error")
+	}
+	if !strings.Contains(string(result.State), "demo-cluster") {
+		t.Errorf("previous state was not kept: %s", result.State)
+	}
+	if _, err := lib.ScanSummary(result.State); err != nil {
+		t.Errorf("ScanSummary on discovered state: %v", err)
+	}
+}
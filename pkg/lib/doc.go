@@ -1,10 +1,14 @@
 // Package lib is the stable bytes-in / bytes-out façade over kcp's
-// state-processing and plan-generation pipelines. External Go modules
-// (cc-growth-service, etc.) import this package; it wraps the
+// cluster-scan, state-processing and plan-generation pipelines. External
+// Go modules (cc-growth-service, etc.) import this package; it wraps the
 // internal/* implementations so they stay private.
 //
 // Formats:
-//   - state bytes must be JSON (kcp-state.json — what `kcp scan` writes).
+//   - state bytes must be JSON (kcp-state.json — what `kcp discover` and
+//     `kcp scan clusters` write).
+//   - credentials are files (msk-credentials.yaml,
+//     apache-kafka-credentials.yaml), read by path because they refer
+//     to certificate files by path.
 //   - plan_inputs bytes must be YAML (plan-inputs.yaml shape).
 //   - Plan output is JSON + Markdown. Resolved plan_inputs are echoed
 //     back as YAML so callers preserve the plan-inputs.yaml shape their
@@ -17,6 +21,23 @@
 //
 //	type PlanResult struct { JSON, Markdown, PlanInputs []byte }
 //
+//	func Discover(ctx context.Context, regions []string, stateJSON []byte, opts DiscoverOptions) (*DiscoverResult, error)
+//
+//	type DiscoverResult struct { State, Credentials []byte }
+//
+//	func NewScanner(sourceType SourceType, credentialsFile string) (*Scanner, error)
+//	func (s *Scanner) Clusters() []Cluster
+//	func (s *Scanner) Scan(ctx context.Context, stateJSON []byte, opts ScanOptions) ([]byte, error)
+//
+// Discover and Scan return the updated state, so embedding tools can chain
+// discovery, cluster scanning, ScanSummary and GeneratePlan without
+// shelling out to the CLI. Discover uses the ambient AWS credentials.
+//
+// The other scanners (schema-registry, client-inventory, cloudtrail-clients,
+// flow-logs, self-managed-connectors, kafka) read and write the state file
+// directly and are available through the CLI only; run them on the state
+// Discover or Scan returned and pass the result on.
+//
 // EXPERIMENTAL: signatures and payload shapes may change while
 // `plan_schema_version` is `"1-experimental"`. Pin to a specific kcp
 // version in your go.mod and bump deliberately. Function names and
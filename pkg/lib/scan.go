@@ -0,0 +1,143 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/kcp/internal/client"
+	"github.com/confluentinc/kcp/internal/sources"
+	"github.com/confluentinc/kcp/internal/sources/msk"
+	"github.com/confluentinc/kcp/internal/sources/osk"
+	"github.com/confluentinc/kcp/internal/types"
+)
+
+// SourceType selects the kind of Kafka a Scanner connects to.
+type SourceType string
+
+const (
+	// SourceTypeMSK scans AWS MSK clusters. Credentials are an
+	// msk-credentials.yaml (what `kcp discover` writes), and Scan needs a
+	// state that already holds the discovered clusters.
+	SourceTypeMSK SourceType = "msk"
+	// SourceTypeApacheKafka scans self-managed Apache Kafka clusters.
+	// Credentials are an apache-kafka-credentials.yaml.
+	SourceTypeApacheKafka SourceType = "osk"
+)
+
+// Cluster identifies a cluster a Scanner will scan.
+type Cluster struct {
+	Name             string   // MSK: cluster name; Apache Kafka: cluster ID
+	ID               string   // MSK: cluster ARN; Apache Kafka: cluster ID
+	BootstrapServers []string // Apache Kafka only; MSK brokers come from the state
+}
+
+// ScanOptions mirrors the `kcp scan clusters` flags. The zero value scans
+// topics and ACLs with the credentials file's auth method and the default
+// Kafka client timeouts.
+type ScanOptions struct {
+	SkipTopics bool
+	SkipACLs   bool
+	// SampleMessages is how many of each topic's most recent messages to
+	// read to infer its serialization format; 0 disables sampling.
+	SampleMessages int
+	// AutoAuth tries each auth method the cluster advertises instead of the
+	// one marked use in the credentials file. MSK only.
+	AutoAuth bool
+	// SSMBastionInstanceID connects to brokers through Session Manager port
+	// forwarding on this instance. MSK only.
+	SSMBastionInstanceID string
+	DialTimeout          time.Duration
+	ReadTimeout          time.Duration
+	// KafkaVersion is the protocol version to speak, e.g. "2.8.1", instead
+	// of the one derived from the cluster.
+	KafkaVersion string
+}
+
+// Scanner scans the clusters listed in a credentials file, the library
+// equivalent of `kcp scan clusters`. A Scanner holds no connections
+// between calls; it is not safe for concurrent Scan calls.
+type Scanner struct {
+	source sources.Source
+}
+
+// NewScanner loads a credentials file for sourceType. The file is read from
+// disk rather than passed as bytes because Apache Kafka credentials refer to
+// certificate and keystore files by path.
+func NewScanner(sourceType SourceType, credentialsFile string) (*Scanner, error) {
+	var source sources.Source
+	switch sourceType {
+	case SourceTypeMSK:
+		source = msk.NewMSKSource()
+	case SourceTypeApacheKafka:
+		source = osk.NewOSKSource()
+	default:
+		return nil, fmt.Errorf("unsupported source type: %s", sourceType)
+	}
+	if err := source.LoadCredentials(credentialsFile); err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+	return &Scanner{source: source}, nil
+}
+
+// Clusters returns the clusters the credentials file lists, including ones
+// it disables, which Scan skips.
+func (s *Scanner) Clusters() []Cluster {
+	ids := s.source.GetClusters()
+	clusters := make([]Cluster, 0, len(ids))
+	for _, id := range ids {
+		clusters = append(clusters, Cluster{Name: id.Name, ID: id.UniqueID, BootstrapServers: id.BootstrapServers})
+	}
+	return clusters
+}
+
+// Scan connects to each enabled cluster and merges what it finds into
+// stateJSON, returning the updated kcp-state.json bytes — the same content
+// `kcp scan clusters` would write. Pass nil stateJSON to start a new state
+// (Apache Kafka only). Earlier scan results the new scan did not return,
+// such as topics when SkipTopics is set, are kept. Once ctx is cancelled
+// Scan stops before the next cluster and returns the clusters scanned so
+// far.
+func (s *Scanner) Scan(ctx context.Context, stateJSON []byte, opts ScanOptions) ([]byte, error) {
+	connection := client.ConnectionSettings{
+		DialTimeout:  opts.DialTimeout,
+		ReadTimeout:  opts.ReadTimeout,
+		KafkaVersion: opts.KafkaVersion,
+	}
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	var state *types.State
+	if len(stateJSON) == 0 {
+		state = types.NewStateFrom(nil)
+		state.SchemaRegistries = &types.SchemaRegistriesState{}
+	} else {
+		parsed, err := types.NewStateFromBytes(stateJSON)
+		if err != nil {
+			return nil, fmt.Errorf("parse state: %w", err)
+		}
+		state = parsed
+	}
+
+	result, err := s.source.Scan(ctx, sources.ScanOptions{
+		SkipTopics:           opts.SkipTopics,
+		SkipACLs:             opts.SkipACLs,
+		SampleMessages:       opts.SampleMessages,
+		AutoAuth:             opts.AutoAuth,
+		SSMBastionInstanceID: opts.SSMBastionInstanceID,
+		Connection:           connection,
+		State:                state,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	if err := sources.MergeResultsIntoState(state, result); err != nil {
+		return nil, fmt.Errorf("merge scan results: %w", err)
+	}
+	out, err := state.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshal state: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package lib_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/confluentinc/kcp/pkg/lib"
+)
+
+// A disabled cluster is listed but never dialled, so these tests exercise
+// the façade without a broker.
+const sampleOSKCredentials = `
+clusters:
+  - id: orders
+    bootstrap_servers:
+      - localhost:9092
+    auth_method:
+      unauthenticated_plaintext:
+        use: false
+`
+
+func writeCredentials(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "apache-kafka-credentials.yaml")
+	if err := os.WriteFile(path, []byte(sampleOSKCredentials), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewScanner_ListsCredentialClusters(t *testing.T) {
+	scanner, err := lib.NewScanner(lib.SourceTypeApacheKafka, writeCredentials(t))
+	if err != nil {
+		t.Fatalf("NewScanner: %v", err)
+	}
+	clusters := scanner.Clusters()
+	if len(clusters) != 1 || clusters[0].ID != "orders" || len(clusters[0].BootstrapServers) != 1 {
+		t.Errorf("Clusters() = %+v", clusters)
+	}
+}
+
+func TestNewScanner_RejectsUnknownSourceType(t *testing.T) {
+	if _, err := lib.NewScanner("confluent-platform", writeCredentials(t)); err == nil {
+		t.Fatal("expected error for unknown source type")
+	}
+}
+
+func TestScan_NilStateReturnsNewState(t *testing.T) {
+	scanner, err := lib.NewScanner(lib.SourceTypeApacheKafka, writeCredentials(t))
+	if err != nil {
+		t.Fatalf("NewScanner: %v", err)
+	}
+	out, err := scanner.Scan(context.Background(), nil, lib.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	var state map[string]any
+	if err := json.Unmarshal(out, &state); err != nil {
+		t.Fatalf("Scan returned invalid JSON: %v\nbody=%s", err, out)
+	}
+	if _, ok := state["schema_version"]; !ok {
+		t.Errorf("state has no schema_version: %s", out)
+	}
+	// The result must be a state the other façade functions accept.
+	if _, err := lib.ScanSummary(out); err != nil {
+		t.Errorf("ScanSummary on scanned state: %v", err)
+	}
+}
+
+func TestScan_MSKRequiresDiscoveredState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "msk-credentials.yaml")
+	if err := os.WriteFile(path, []byte("regions: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	scanner, err := lib.NewScanner(lib.SourceTypeMSK, path)
+	if err != nil {
+		t.Fatalf("NewScanner: %v", err)
+	}
+	if _, err := scanner.Scan(context.Background(), []byte(`{"timestamp":"2026-05-01T00:00:00Z","kcp_build_info":{"version":"","commit":"","date":""}}`), lib.ScanOptions{}); err == nil {
+		t.Fatal("expected error scanning MSK without discovered clusters")
+	}
+}
+
+func TestScan_RejectsInvalidKafkaVersion(t *testing.T) {
+	scanner, err := lib.NewScanner(lib.SourceTypeApacheKafka, writeCredentials(t))
+	if err != nil {
+		t.Fatalf("NewScanner: %v", err)
+	}
+	if _, err := scanner.Scan(context.Background(), nil, lib.ScanOptions{KafkaVersion: "not-a-version"}); err == nil {
+		t.Fatal("expected error for invalid Kafka version")
+	}
+}